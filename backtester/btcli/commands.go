@@ -1,7 +1,9 @@
 package main
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"path/filepath"
 	"strconv"
 	"time"
@@ -398,6 +400,81 @@ func clearAllTasks(c *cli.Context) error {
 	return nil
 }
 
+var streamTaskProgressCommand = &cli.Command{
+	Name:      "streamtaskprogress",
+	Usage:     "streams a strategy task's progress until it finishes. Long running tasks can be cancelled with stoptask",
+	ArgsUsage: "<id>",
+	Action:    streamTaskProgress,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "id",
+			Usage: "the id of the strategy task",
+		},
+		&cli.DurationFlag{
+			Name:    "interval",
+			Aliases: []string{"i"},
+			Usage:   "how often progress is sent, at least 100ms",
+			Value:   time.Second,
+		},
+	},
+}
+
+func streamTaskProgress(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+
+	// progress streams for as long as the task runs, so are not bound by
+	// the request timeout
+	ctx := c.Context
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	var id string
+	if c.IsSet("id") {
+		id = c.String("id")
+	} else {
+		id = c.Args().First()
+	}
+
+	client := btrpc.NewBacktesterServiceClient(conn)
+	result, err := client.StreamTaskProgress(
+		ctx,
+		&btrpc.StreamTaskProgressRequest{
+			Id:             id,
+			UpdateInterval: uint64(c.Duration("interval")),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := result.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		complete := resp.PercentComplete + "%"
+		if resp.Task.LiveTesting {
+			complete = "live"
+		}
+		fmt.Printf("%s %s | %s | simulated time: %s\n", resp.Task.Id, resp.Task.StrategyName, complete, resp.SimulatedTime)
+		for i := range resp.Pairs {
+			fmt.Printf("\t%s %s %s value: %s PNL: %s\n", resp.Pairs[i].Exchange, resp.Pairs[i].Asset, resp.Pairs[i].Pair, resp.Pairs[i].TotalValue, resp.Pairs[i].Pnl)
+		}
+		if resp.Task.Closed {
+			fmt.Println("task finished")
+			return nil
+		}
+	}
+}
+
 var executeStrategyFromConfigCommand = &cli.Command{
 	Name:        "executestrategyfromconfig",
 	Usage:       fmt.Sprintf("runs the default strategy config but via passing in as a struct instead of a filepath - this is a proof-of-concept implementation using %v", filepath.Join("..", "config", "strategyexamples", "dca-api-candles.strat")),
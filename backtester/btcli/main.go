@@ -119,6 +119,7 @@ func main() {
 		stopAllTasksCommand,
 		clearTaskCommand,
 		clearAllTasksCommand,
+		streamTaskProgressCommand,
 	}
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -28,6 +28,7 @@ const (
 	BacktesterService_StopAllTasks_FullMethodName              = "/btrpc.BacktesterService/StopAllTasks"
 	BacktesterService_ClearTask_FullMethodName                 = "/btrpc.BacktesterService/ClearTask"
 	BacktesterService_ClearAllTasks_FullMethodName             = "/btrpc.BacktesterService/ClearAllTasks"
+	BacktesterService_StreamTaskProgress_FullMethodName        = "/btrpc.BacktesterService/StreamTaskProgress"
 )
 
 // BacktesterServiceClient is the client API for BacktesterService service.
@@ -43,6 +44,7 @@ type BacktesterServiceClient interface {
 	StopAllTasks(ctx context.Context, in *StopAllTasksRequest, opts ...grpc.CallOption) (*StopAllTasksResponse, error)
 	ClearTask(ctx context.Context, in *ClearTaskRequest, opts ...grpc.CallOption) (*ClearTaskResponse, error)
 	ClearAllTasks(ctx context.Context, in *ClearAllTasksRequest, opts ...grpc.CallOption) (*ClearAllTasksResponse, error)
+	StreamTaskProgress(ctx context.Context, in *StreamTaskProgressRequest, opts ...grpc.CallOption) (BacktesterService_StreamTaskProgressClient, error)
 }
 
 type backtesterServiceClient struct {
@@ -134,6 +136,38 @@ func (c *backtesterServiceClient) ClearAllTasks(ctx context.Context, in *ClearAl
 	return out, nil
 }
 
+func (c *backtesterServiceClient) StreamTaskProgress(ctx context.Context, in *StreamTaskProgressRequest, opts ...grpc.CallOption) (BacktesterService_StreamTaskProgressClient, error) {
+	stream, err := c.cc.NewStream(ctx, &BacktesterService_ServiceDesc.Streams[0], BacktesterService_StreamTaskProgress_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &backtesterServiceStreamTaskProgressClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type BacktesterService_StreamTaskProgressClient interface {
+	Recv() (*StreamTaskProgressResponse, error)
+	grpc.ClientStream
+}
+
+type backtesterServiceStreamTaskProgressClient struct {
+	grpc.ClientStream
+}
+
+func (x *backtesterServiceStreamTaskProgressClient) Recv() (*StreamTaskProgressResponse, error) {
+	m := new(StreamTaskProgressResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // BacktesterServiceServer is the server API for BacktesterService service.
 // All implementations must embed UnimplementedBacktesterServiceServer
 // for forward compatibility
@@ -147,6 +181,7 @@ type BacktesterServiceServer interface {
 	StopAllTasks(context.Context, *StopAllTasksRequest) (*StopAllTasksResponse, error)
 	ClearTask(context.Context, *ClearTaskRequest) (*ClearTaskResponse, error)
 	ClearAllTasks(context.Context, *ClearAllTasksRequest) (*ClearAllTasksResponse, error)
+	StreamTaskProgress(*StreamTaskProgressRequest, BacktesterService_StreamTaskProgressServer) error
 	mustEmbedUnimplementedBacktesterServiceServer()
 }
 
@@ -181,6 +216,9 @@ func (UnimplementedBacktesterServiceServer) ClearTask(context.Context, *ClearTas
 func (UnimplementedBacktesterServiceServer) ClearAllTasks(context.Context, *ClearAllTasksRequest) (*ClearAllTasksResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ClearAllTasks not implemented")
 }
+func (UnimplementedBacktesterServiceServer) StreamTaskProgress(*StreamTaskProgressRequest, BacktesterService_StreamTaskProgressServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamTaskProgress not implemented")
+}
 func (UnimplementedBacktesterServiceServer) mustEmbedUnimplementedBacktesterServiceServer() {}
 
 // UnsafeBacktesterServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -356,6 +394,27 @@ func _BacktesterService_ClearAllTasks_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BacktesterService_StreamTaskProgress_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamTaskProgressRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BacktesterServiceServer).StreamTaskProgress(m, &backtesterServiceStreamTaskProgressServer{stream})
+}
+
+type BacktesterService_StreamTaskProgressServer interface {
+	Send(*StreamTaskProgressResponse) error
+	grpc.ServerStream
+}
+
+type backtesterServiceStreamTaskProgressServer struct {
+	grpc.ServerStream
+}
+
+func (x *backtesterServiceStreamTaskProgressServer) Send(m *StreamTaskProgressResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // BacktesterService_ServiceDesc is the grpc.ServiceDesc for BacktesterService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -400,6 +459,12 @@ var BacktesterService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _BacktesterService_ClearAllTasks_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamTaskProgress",
+			Handler:       _BacktesterService_StreamTaskProgress_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "btrpc.proto",
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/shopspring/decimal"
 	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/statistics"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies/base"
 	gctcommon "github.com/thrasher-corp/gocryptotrader/common"
@@ -50,9 +51,61 @@ func (c *Config) Validate() error {
 	if err != nil {
 		return err
 	}
+	err = c.validateStatisticSettings()
+	if err != nil {
+		return err
+	}
+	err = c.validateRiskRules()
+	if err != nil {
+		return err
+	}
 	return c.validateMinMaxes()
 }
 
+// validateStatisticSettings ensures benchmarks have unique names and a
+// source
+func (c *Config) validateStatisticSettings() error {
+	names := make(map[string]struct{}, len(c.StatisticSettings.Benchmarks))
+	for i := range c.StatisticSettings.Benchmarks {
+		b := &c.StatisticSettings.Benchmarks[i]
+		if b.Name == "" {
+			return fmt.Errorf("%w name unset", errInvalidBenchmark)
+		}
+		name := strings.ToLower(b.Name)
+		if _, ok := names[name]; ok || name == statistics.BuyAndHoldBenchmark || name == statistics.BTCIndexBenchmark {
+			return fmt.Errorf("%w name %v is not unique", errInvalidBenchmark, b.Name)
+		}
+		names[name] = struct{}{}
+		if b.CSVPath == "" {
+			return fmt.Errorf("%w %v csv path unset", errInvalidBenchmark, b.Name)
+		}
+	}
+	return nil
+}
+
+// validateRiskRules ensures loss limits are proportions and the leverage cap
+// is not negative
+func (c *Config) validateRiskRules() error {
+	r := c.PortfolioSettings.RiskRules
+	if r == nil {
+		return nil
+	}
+	one := decimal.NewFromInt(1)
+	if r.MaximumDrawdown.IsNegative() || r.MaximumDrawdown.GreaterThan(one) {
+		return fmt.Errorf("%w maximum drawdown %v must be between 0 and 1", errInvalidRiskRules, r.MaximumDrawdown)
+	}
+	if r.DailyLossLimit.IsNegative() || r.DailyLossLimit.GreaterThan(one) {
+		return fmt.Errorf("%w daily loss limit %v must be between 0 and 1", errInvalidRiskRules, r.DailyLossLimit)
+	}
+	if r.MaximumLeverage.IsNegative() {
+		return fmt.Errorf("%w maximum leverage %v %w", errInvalidRiskRules, r.MaximumLeverage, errSizeLessThanZero)
+	}
+	if r.TerminateOnBreach && r.MaximumDrawdown.IsZero() {
+		return fmt.Errorf("%w terminate on breach requires a maximum drawdown", errInvalidRiskRules)
+	}
+	return nil
+}
+
 // validate ensures no one sets bad config values on purpose
 func (m *MinMax) validate() error {
 	if m.MaximumSize.IsNegative() {
@@ -302,6 +355,9 @@ func (c *Config) PrintSetting() {
 	log.Infof(common.Config, "Buy rules: %+v", c.PortfolioSettings.BuySide)
 	log.Infof(common.Config, "Sell rules: %+v", c.PortfolioSettings.SellSide)
 	log.Infof(common.Config, "Leverage rules: %+v", c.PortfolioSettings.Leverage)
+	if c.PortfolioSettings.RiskRules != nil {
+		log.Infof(common.Config, "Risk rules: %+v", *c.PortfolioSettings.RiskRules)
+	}
 	if c.DataSettings.LiveData != nil {
 		log.Infoln(common.Config, common.CMDColours.H2+"------------------Live Settings------------------------------"+common.CMDColours.Default)
 		log.Infof(common.Config, "Data type: %v", c.DataSettings.DataType)
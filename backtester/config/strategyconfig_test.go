@@ -10,6 +10,7 @@ import (
 
 	"github.com/shopspring/decimal"
 	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/statistics"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies/base"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies/top2bottom2"
 	gctcommon "github.com/thrasher-corp/gocryptotrader/common"
@@ -355,6 +356,79 @@ func TestValidateStrategySettings(t *testing.T) {
 	}
 }
 
+func TestValidateStatisticSettings(t *testing.T) {
+	t.Parallel()
+	c := &Config{}
+	err := c.validateStatisticSettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received %v expected %v", err, nil)
+	}
+	c.StatisticSettings.Benchmarks = []Benchmark{{}}
+	err = c.validateStatisticSettings()
+	if !errors.Is(err, errInvalidBenchmark) {
+		t.Errorf("received %v expected %v", err, errInvalidBenchmark)
+	}
+	c.StatisticSettings.Benchmarks = []Benchmark{{Name: statistics.BTCIndexBenchmark, CSVPath: "index.csv"}}
+	err = c.validateStatisticSettings()
+	if !errors.Is(err, errInvalidBenchmark) {
+		t.Errorf("received %v expected %v", err, errInvalidBenchmark)
+	}
+	c.StatisticSettings.Benchmarks = []Benchmark{{Name: "index"}}
+	err = c.validateStatisticSettings()
+	if !errors.Is(err, errInvalidBenchmark) {
+		t.Errorf("received %v expected %v", err, errInvalidBenchmark)
+	}
+	c.StatisticSettings.Benchmarks = []Benchmark{{Name: "index", CSVPath: "index.csv"}, {Name: "Index", CSVPath: "index.csv"}}
+	err = c.validateStatisticSettings()
+	if !errors.Is(err, errInvalidBenchmark) {
+		t.Errorf("received %v expected %v", err, errInvalidBenchmark)
+	}
+	c.StatisticSettings.Benchmarks = c.StatisticSettings.Benchmarks[:1]
+	err = c.validateStatisticSettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received %v expected %v", err, nil)
+	}
+}
+
+func TestValidateRiskRules(t *testing.T) {
+	t.Parallel()
+	c := &Config{}
+	err := c.validateRiskRules()
+	if !errors.Is(err, nil) {
+		t.Errorf("received %v expected %v", err, nil)
+	}
+	c.PortfolioSettings.RiskRules = &RiskRules{MaximumDrawdown: decimal.NewFromInt(2)}
+	err = c.validateRiskRules()
+	if !errors.Is(err, errInvalidRiskRules) {
+		t.Errorf("received %v expected %v", err, errInvalidRiskRules)
+	}
+	c.PortfolioSettings.RiskRules = &RiskRules{DailyLossLimit: decimal.NewFromInt(-1)}
+	err = c.validateRiskRules()
+	if !errors.Is(err, errInvalidRiskRules) {
+		t.Errorf("received %v expected %v", err, errInvalidRiskRules)
+	}
+	c.PortfolioSettings.RiskRules = &RiskRules{MaximumLeverage: decimal.NewFromInt(-1)}
+	err = c.validateRiskRules()
+	if !errors.Is(err, errSizeLessThanZero) {
+		t.Errorf("received %v expected %v", err, errSizeLessThanZero)
+	}
+	c.PortfolioSettings.RiskRules = &RiskRules{TerminateOnBreach: true}
+	err = c.validateRiskRules()
+	if !errors.Is(err, errInvalidRiskRules) {
+		t.Errorf("received %v expected %v", err, errInvalidRiskRules)
+	}
+	c.PortfolioSettings.RiskRules = &RiskRules{
+		MaximumDrawdown:   decimal.NewFromFloat(0.2),
+		DailyLossLimit:    decimal.NewFromFloat(0.05),
+		MaximumLeverage:   decimal.NewFromInt(2),
+		TerminateOnBreach: true,
+	}
+	err = c.validateRiskRules()
+	if !errors.Is(err, nil) {
+		t.Errorf("received %v expected %v", err, nil)
+	}
+}
+
 func TestPrintSettings(t *testing.T) {
 	t.Parallel()
 	cfg := Config{
@@ -26,6 +26,8 @@ var (
 	errMinMaxEqual                      = errors.New("minimum and maximum limits cannot be equal")
 	errPerpetualsUnsupported            = errors.New("perpetual futures not yet supported")
 	errFeatureIncompatible              = errors.New("feature is not compatible")
+	errInvalidBenchmark                 = errors.New("invalid benchmark")
+	errInvalidRiskRules                 = errors.New("invalid risk rules")
 )
 
 // Config defines what is in an individual strategy config
@@ -90,15 +92,43 @@ type ExchangeLevelFunding struct {
 // proper data is currently lacking
 type StatisticSettings struct {
 	RiskFreeRate decimal.Decimal `json:"risk-free-rate"`
+	// Benchmarks are compared against strategy returns alongside the buy
+	// and hold and BTC index benchmarks calculated for every run
+	Benchmarks []Benchmark `json:"benchmarks,omitempty"`
+}
+
+// Benchmark is a user supplied series strategy returns are compared against
+type Benchmark struct {
+	Name string `json:"name"`
+	// CSVPath is a CSV file where each row is a unix timestamp in seconds
+	// followed by the benchmark's value
+	CSVPath string `json:"csv-path"`
 }
 
 // PortfolioSettings act as a global protector for strategies
 // these settings will override ExchangeSettings that go against it
 // and assess the bigger picture
 type PortfolioSettings struct {
-	Leverage Leverage `json:"leverage"`
-	BuySide  MinMax   `json:"buy-side"`
-	SellSide MinMax   `json:"sell-side"`
+	Leverage  Leverage   `json:"leverage"`
+	BuySide   MinMax     `json:"buy-side"`
+	SellSide  MinMax     `json:"sell-side"`
+	RiskRules *RiskRules `json:"risk-rules,omitempty"`
+}
+
+// RiskRules limit the portfolio's losses and exposure during a run. Once a
+// loss limit is breached, orders adding exposure are rejected while orders
+// reducing positions are still allowed
+type RiskRules struct {
+	// MaximumDrawdown is the largest fall from the portfolio's peak value as a proportion, eg 0.2 for 20%.
+	// Breaching it blocks new exposure for the remainder of the run
+	MaximumDrawdown decimal.Decimal `json:"maximum-drawdown"`
+	// DailyLossLimit is the largest fall from the portfolio's value at the start of the UTC day as a proportion.
+	// Breaching it blocks new exposure until the next day
+	DailyLossLimit decimal.Decimal `json:"daily-loss-limit"`
+	// MaximumLeverage caps the value of all positions as a multiple of the portfolio's value
+	MaximumLeverage decimal.Decimal `json:"maximum-leverage"`
+	// TerminateOnBreach ends the run when the maximum drawdown is breached
+	TerminateOnBreach bool `json:"terminate-on-breach"`
 }
 
 // Leverage rules are used to allow or limit the use of leverage in orders
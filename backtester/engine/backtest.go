@@ -3,14 +3,19 @@ package engine
 import (
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/shopspring/decimal"
 	"github.com/thrasher-corp/gocryptotrader/backtester/common"
 	"github.com/thrasher-corp/gocryptotrader/backtester/data"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/exchange"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio/risk"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/statistics"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies/base"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/kline"
@@ -18,6 +23,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/signal"
 	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
 	gctcommon "github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/key"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	gctexchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
@@ -116,7 +122,15 @@ func (bt *BackTest) liveCheck() error {
 		case <-bt.LiveDataHandler.Updated():
 			err := bt.Run()
 			if err != nil {
-				return err
+				if !errors.Is(err, risk.ErrRunTerminated) {
+					return err
+				}
+				log.Warnln(common.LiveStrategy, err)
+				err = bt.Stop()
+				if err != nil {
+					return err
+				}
+				return bt.LiveDataHandler.Stop()
 			}
 		}
 	}
@@ -238,6 +252,9 @@ func (bt *BackTest) Run() error {
 			doubleNil = false
 			err := bt.handleEvent(ev)
 			if err != nil {
+				if errors.Is(err, risk.ErrRunTerminated) {
+					return err
+				}
 				log.Errorln(common.Backtester, err)
 			}
 			if !bt.hasProcessedAnEvent {
@@ -359,6 +376,8 @@ func (bt *BackTest) processSimultaneousDataEvents() error {
 				continue
 			case errors.Is(err, futures.ErrPositionLiquidated):
 				return nil
+			case errors.Is(err, risk.ErrRunTerminated):
+				return err
 			default:
 				log.Errorln(common.Backtester, err)
 			}
@@ -409,8 +428,12 @@ func (bt *BackTest) updateStatsForDataEvent(ev data.Event, funds funding.IFundRe
 	// update portfolio manager with the latest price
 	err = bt.Portfolio.UpdateHoldings(ev, funds)
 	if err != nil {
+		if errors.Is(err, risk.ErrRunTerminated) {
+			return err
+		}
 		log.Errorf(common.Backtester, "UpdateHoldings %v", err)
 	}
+	bt.updateProgress(ev, nil)
 
 	if ev.GetAssetType().IsFutures() {
 		var cr funding.ICollateralReleaser
@@ -435,6 +458,7 @@ func (bt *BackTest) updateStatsForDataEvent(ev data.Event, funds funding.IFundRe
 			return err
 		}
 
+		bt.updateProgress(ev, pnl)
 		if pnl.Result.IsLiquidated {
 			return nil
 		}
@@ -457,6 +481,44 @@ func (bt *BackTest) updateStatsForDataEvent(ev data.Event, funds funding.IFundRe
 	return nil
 }
 
+// updateProgress records the simulated time and latest value of the data
+// event's currency pair so progress can be reported while a task runs.
+// Futures profit is taken from the position's PNL when provided
+func (bt *BackTest) updateProgress(ev data.Event, pnl *portfolio.PNLSummary) {
+	bt.progress.m.Lock()
+	defer bt.progress.m.Unlock()
+	if ev.GetTime().After(bt.progress.simulatedTime) {
+		bt.progress.simulatedTime = ev.GetTime()
+	}
+	h, err := bt.Portfolio.ViewHoldingAtTimePeriod(ev)
+	if err != nil || h == nil {
+		return
+	}
+	if bt.progress.pairs == nil {
+		bt.progress.pairs = make(map[key.ExchangePairAsset]*PairProgress)
+	}
+	k := key.ExchangePairAsset{
+		Exchange: ev.GetExchange(),
+		Base:     ev.Pair().Base.Item,
+		Quote:    ev.Pair().Quote.Item,
+		Asset:    ev.GetAssetType(),
+	}
+	p, ok := bt.progress.pairs[k]
+	if !ok {
+		p = &PairProgress{
+			Exchange: ev.GetExchange(),
+			Asset:    ev.GetAssetType(),
+			Pair:     ev.Pair(),
+		}
+		bt.progress.pairs[k] = p
+	}
+	p.TotalValue = h.TotalValue
+	p.PNL = h.TotalValue.Sub(h.TotalInitialValue)
+	if pnl != nil {
+		p.PNL = pnl.Result.RealisedPNL.Add(pnl.Result.UnrealisedPNL)
+	}
+}
+
 // processSignalEvent receives an event from the strategy for processing under the portfolio
 func (bt *BackTest) processSignalEvent(ev signal.Event, funds funding.IFundReserver) error {
 	if ev == nil {
@@ -541,6 +603,13 @@ func (bt *BackTest) processFillEvent(ev fill.Event, funds funding.IFundReleaser)
 		log.Errorf(common.Backtester, "AddComplianceSnapshotForTime %v %v %v %v", ev.GetExchange(), ev.GetAssetType(), ev.Pair(), err)
 	}
 
+	if fh, ok := bt.Strategy.(strategies.FillHandler); ok {
+		err = fh.OnFill(ev)
+		if err != nil {
+			log.Errorf(common.Backtester, "OnFill strategy %v %v %v %v", ev.GetExchange(), ev.GetAssetType(), ev.Pair(), err)
+		}
+	}
+
 	fde := ev.GetFillDependentEvent()
 	if fde != nil && !fde.IsNil() {
 		// some events can only be triggered on a successful fill event
@@ -775,6 +844,54 @@ func (bt *BackTest) GenerateSummary() (*TaskSummary, error) {
 	}, nil
 }
 
+// GetProgress returns how far a task has run through its data along with
+// the latest value and running profit of each currency pair
+func (bt *BackTest) GetProgress() (*TaskProgress, error) {
+	if bt == nil {
+		return nil, gctcommon.ErrNilPointer
+	}
+	bt.m.Lock()
+	resp := &TaskProgress{
+		MetaData: bt.MetaData,
+	}
+	bt.m.Unlock()
+	if bt.DataHolder != nil && !resp.MetaData.LiveTesting {
+		dataHandlers, err := bt.DataHolder.GetAllData()
+		if err != nil {
+			return nil, err
+		}
+		var processed, total int64
+		for i := range dataHandlers {
+			var offset int64
+			offset, err = dataHandlers[i].Offset()
+			if err != nil {
+				return nil, err
+			}
+			var stream data.Events
+			stream, err = dataHandlers[i].GetStream()
+			if err != nil {
+				return nil, err
+			}
+			processed += offset
+			total += int64(len(stream))
+		}
+		if total > 0 {
+			resp.PercentComplete = decimal.NewFromInt(processed).Div(decimal.NewFromInt(total)).Mul(decimal.NewFromInt(100))
+		}
+	}
+	bt.progress.m.Lock()
+	defer bt.progress.m.Unlock()
+	resp.SimulatedTime = bt.progress.simulatedTime
+	resp.Pairs = make([]PairProgress, 0, len(bt.progress.pairs))
+	for _, p := range bt.progress.pairs {
+		resp.Pairs = append(resp.Pairs, *p)
+	}
+	slices.SortFunc(resp.Pairs, func(a, b PairProgress) int {
+		return strings.Compare(a.Exchange+a.Asset.String()+a.Pair.String(), b.Exchange+b.Asset.String()+b.Pair.String())
+	})
+	return resp, nil
+}
+
 // SetupMetaData will populate metadata fields
 func (bt *BackTest) SetupMetaData() error {
 	if bt == nil {
@@ -12,6 +12,7 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/thrasher-corp/gocryptotrader/backtester/common"
 	"github.com/thrasher-corp/gocryptotrader/backtester/config"
 	"github.com/thrasher-corp/gocryptotrader/backtester/data"
@@ -46,6 +47,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/binance"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/binanceus"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
 	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
 )
@@ -2001,3 +2003,76 @@ func TestProcessSingleDataEvent(t *testing.T) {
 		t.Errorf("received '%v' expected '%v'", err, nil)
 	}
 }
+
+type holdingsFolio struct {
+	fakeFolio
+	h *holdings.Holding
+}
+
+func (f holdingsFolio) ViewHoldingAtTimePeriod(common.Event) (*holdings.Holding, error) {
+	return f.h, nil
+}
+
+func TestUpdateProgress(t *testing.T) {
+	t.Parallel()
+	cp := currency.NewPair(currency.BTC, currency.USDT)
+	tt := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	ev := &evkline.Kline{
+		Base: &event.Base{
+			Exchange:     testExchange,
+			Time:         tt,
+			AssetType:    asset.Spot,
+			CurrencyPair: cp,
+		},
+	}
+	bt := &BackTest{Portfolio: &fakeFolio{}}
+	bt.updateProgress(ev, nil)
+	assert.Equal(t, tt, bt.progress.simulatedTime, "updateProgress should set the simulated time")
+	assert.Empty(t, bt.progress.pairs, "updateProgress should not track pairs without holdings")
+
+	bt.Portfolio = holdingsFolio{h: &holdings.Holding{TotalValue: decimal.NewFromInt(120), TotalInitialValue: decimal.NewFromInt(100)}}
+	bt.updateProgress(ev, nil)
+	require.Len(t, bt.progress.pairs, 1, "updateProgress must track the pair")
+	for _, p := range bt.progress.pairs {
+		assert.Equal(t, "120", p.TotalValue.String(), "TotalValue should be set from holdings")
+		assert.Equal(t, "20", p.PNL.String(), "PNL should be the change from the initial value")
+	}
+
+	bt.updateProgress(ev, &portfolio.PNLSummary{Result: futures.PNLResult{RealisedPNL: decimal.NewFromInt(5), UnrealisedPNL: decimal.NewFromInt(-2)}})
+	for _, p := range bt.progress.pairs {
+		assert.Equal(t, "3", p.PNL.String(), "PNL should be taken from the position when provided")
+	}
+}
+
+func TestGetProgress(t *testing.T) {
+	t.Parallel()
+	var bt *BackTest
+	_, err := bt.GetProgress()
+	assert.ErrorIs(t, err, gctcommon.ErrNilPointer)
+
+	cp := currency.NewPair(currency.BTC, currency.USDT)
+	d := &data.Base{}
+	err = d.SetStream([]data.Event{
+		&evkline.Kline{Base: &event.Base{Exchange: testExchange, Time: time.Now(), Interval: gctkline.OneDay, CurrencyPair: cp, AssetType: asset.Spot}},
+		&evkline.Kline{Base: &event.Base{Exchange: testExchange, Time: time.Now().Add(gctkline.OneDay.Duration()), Interval: gctkline.OneDay, CurrencyPair: cp, AssetType: asset.Spot}},
+	})
+	require.NoError(t, err, "SetStream must not error")
+	_, err = d.Next()
+	require.NoError(t, err, "Next must not error")
+	bt = &BackTest{DataHolder: &data.HandlerHolder{}}
+	err = bt.DataHolder.SetDataForCurrency(testExchange, asset.Spot, cp, &kline.DataFromKline{Base: d})
+	require.NoError(t, err, "SetDataForCurrency must not error")
+	bt.progress.pairs = map[key.ExchangePairAsset]*PairProgress{
+		{Exchange: testExchange, Base: cp.Base.Item, Quote: cp.Quote.Item, Asset: asset.Spot}: {Exchange: testExchange, Asset: asset.Spot, Pair: cp, PNL: decimal.NewFromInt(1)},
+	}
+	p, err := bt.GetProgress()
+	require.NoError(t, err, "GetProgress must not error")
+	assert.Equal(t, "50", p.PercentComplete.String(), "PercentComplete should reflect the processed data")
+	require.Len(t, p.Pairs, 1, "GetProgress must return each pair")
+	assert.Equal(t, "1", p.Pairs[0].PNL.String(), "GetProgress should return the pair's PNL")
+
+	bt.MetaData.LiveTesting = true
+	p, err = bt.GetProgress()
+	require.NoError(t, err, "GetProgress must not error")
+	assert.True(t, p.PercentComplete.IsZero(), "PercentComplete should be unset for live tasks")
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/shopspring/decimal"
 	"github.com/thrasher-corp/gocryptotrader/backtester/data"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/eventholder"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/exchange"
@@ -14,7 +15,10 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies"
 	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
 	"github.com/thrasher-corp/gocryptotrader/backtester/report"
+	"github.com/thrasher-corp/gocryptotrader/common/key"
+	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/engine"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 )
 
 var (
@@ -50,6 +54,34 @@ type BackTest struct {
 	orderManager             *engine.OrderManager
 	databaseManager          *engine.DatabaseConnectionManager
 	hasProcessedDataAtOffset map[int64]bool
+	progress                 progress
+}
+
+// progress tracks the latest simulated time and value of each currency pair
+// as a task runs, guarded separately so it can be read mid-run
+type progress struct {
+	m             sync.Mutex
+	simulatedTime time.Time
+	pairs         map[key.ExchangePairAsset]*PairProgress
+}
+
+// TaskProgress is a snapshot of how far a task has run
+type TaskProgress struct {
+	MetaData TaskMetaData
+	// PercentComplete is the proportion of loaded data processed, it is
+	// unset for live tasks as their data has no end
+	PercentComplete decimal.Decimal
+	SimulatedTime   time.Time
+	Pairs           []PairProgress
+}
+
+// PairProgress is the latest value and running profit of a currency pair
+type PairProgress struct {
+	Exchange   string
+	Asset      asset.Item
+	Pair       currency.Pair
+	TotalValue decimal.Decimal
+	PNL        decimal.Decimal
 }
 
 // TaskSummary holds details of a BackTest
@@ -35,8 +35,14 @@ import (
 )
 
 var (
-	errBadPort             = errors.New("received bad port")
-	errCannotHandleRequest = errors.New("cannot handle request")
+	errBadPort               = errors.New("received bad port")
+	errCannotHandleRequest   = errors.New("cannot handle request")
+	errInvalidUpdateInterval = errors.New("invalid update interval")
+)
+
+const (
+	defaultProgressUpdateInterval = time.Second
+	minimumProgressUpdateInterval = time.Millisecond * 100
 )
 
 // GRPCServer struct
@@ -807,3 +813,68 @@ func (s *GRPCServer) ClearAllTasks(_ context.Context, _ *btrpc.ClearAllTasksRequ
 		RemainingTasks: remainingResponse,
 	}, nil
 }
+
+// StreamTaskProgress sends a strategy task's progress at each update interval
+// until the task has finished or the client disconnects
+func (s *GRPCServer) StreamTaskProgress(req *btrpc.StreamTaskProgressRequest, stream btrpc.BacktesterService_StreamTaskProgressServer) error {
+	if s.manager == nil {
+		return fmt.Errorf("%w task manager", gctcommon.ErrNilPointer)
+	}
+	if req == nil {
+		return fmt.Errorf("%w StreamTaskProgressRequest", gctcommon.ErrNilPointer)
+	}
+	id, err := uuid.FromString(req.Id)
+	if err != nil {
+		return err
+	}
+	interval := defaultProgressUpdateInterval
+	if req.UpdateInterval > 0 {
+		interval = time.Duration(req.UpdateInterval)
+		if interval < minimumProgressUpdateInterval {
+			return fmt.Errorf("%w %v must be at least %v", errInvalidUpdateInterval, interval, minimumProgressUpdateInterval)
+		}
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		var progress *TaskProgress
+		progress, err = s.manager.GetProgress(id)
+		if err != nil {
+			return err
+		}
+		err = stream.Send(convertProgress(progress))
+		if err != nil {
+			return err
+		}
+		if progress.MetaData.Closed {
+			return nil
+		}
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// convertProgress converts a task's progress into a RPC format
+func convertProgress(progress *TaskProgress) *btrpc.StreamTaskProgressResponse {
+	resp := &btrpc.StreamTaskProgressResponse{
+		Task:            convertSummary(&TaskSummary{MetaData: progress.MetaData}),
+		PercentComplete: progress.PercentComplete.StringFixed(2),
+		Pairs:           make([]*btrpc.PairProgress, len(progress.Pairs)),
+	}
+	if !progress.SimulatedTime.IsZero() {
+		resp.SimulatedTime = progress.SimulatedTime.Format(gctcommon.SimpleTimeFormatWithTimezone)
+	}
+	for i := range progress.Pairs {
+		resp.Pairs[i] = &btrpc.PairProgress{
+			Exchange:   progress.Pairs[i].Exchange,
+			Asset:      progress.Pairs[i].Asset.String(),
+			Pair:       progress.Pairs[i].Pair.String(),
+			TotalValue: progress.Pairs[i].TotalValue.String(),
+			Pnl:        progress.Pairs[i].PNL.String(),
+		}
+	}
+	return resp
+}
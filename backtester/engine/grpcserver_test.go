@@ -17,6 +17,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies/binancecashandcarry"
 	gctcommon "github.com/thrasher-corp/gocryptotrader/common"
 	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -666,3 +667,73 @@ func TestGRPCClearAllTasks(t *testing.T) {
 		t.Fatalf("received '%v' expecting '%v'", len(s.manager.tasks), 0)
 	}
 }
+
+type fakeProgressStream struct {
+	grpc.ServerStream
+	ctx       context.Context
+	responses []*btrpc.StreamTaskProgressResponse
+}
+
+func (f *fakeProgressStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeProgressStream) Send(resp *btrpc.StreamTaskProgressResponse) error {
+	f.responses = append(f.responses, resp)
+	return nil
+}
+
+func TestStreamTaskProgress(t *testing.T) {
+	t.Parallel()
+	s := &GRPCServer{}
+	stream := &fakeProgressStream{ctx: context.Background()}
+	err := s.StreamTaskProgress(nil, stream)
+	if !errors.Is(err, gctcommon.ErrNilPointer) {
+		t.Errorf("received '%v' expecting '%v'", err, gctcommon.ErrNilPointer)
+	}
+
+	s.manager = NewTaskManager()
+	err = s.StreamTaskProgress(nil, stream)
+	if !errors.Is(err, gctcommon.ErrNilPointer) {
+		t.Errorf("received '%v' expecting '%v'", err, gctcommon.ErrNilPointer)
+	}
+
+	bt := &BackTest{
+		Strategy:   &fakeStrat{},
+		DataHolder: &data.HandlerHolder{},
+		Statistic:  &fakeStats{},
+		shutdown:   make(chan struct{}),
+	}
+	err = s.manager.AddTask(bt)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	err = s.StreamTaskProgress(&btrpc.StreamTaskProgressRequest{Id: bt.MetaData.ID.String(), UpdateInterval: uint64(time.Millisecond)}, stream)
+	if !errors.Is(err, errInvalidUpdateInterval) {
+		t.Errorf("received '%v' expecting '%v'", err, errInvalidUpdateInterval)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	stream.ctx = ctx
+	err = s.StreamTaskProgress(&btrpc.StreamTaskProgressRequest{Id: bt.MetaData.ID.String()}, stream)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(stream.responses) != 1 {
+		t.Fatalf("received '%v' expecting '%v'", len(stream.responses), 1)
+	}
+	if stream.responses[0].Task.Id != bt.MetaData.ID.String() {
+		t.Errorf("received '%v' expecting '%v'", stream.responses[0].Task.Id, bt.MetaData.ID.String())
+	}
+
+	bt.MetaData.Closed = true
+	stream = &fakeProgressStream{ctx: context.Background()}
+	err = s.StreamTaskProgress(&btrpc.StreamTaskProgressRequest{Id: bt.MetaData.ID.String()}, stream)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expecting '%v'", err, nil)
+	}
+	if len(stream.responses) != 1 || !stream.responses[0].Task.Closed {
+		t.Errorf("received '%v' expecting a single closed task response", stream.responses)
+	}
+}
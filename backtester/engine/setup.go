@@ -197,6 +197,15 @@ func (bt *BackTest) SetupFromConfig(cfg *config.Config, templatePath, output str
 	portfolioRisk := &risk.Risk{
 		CurrencySettings: make(map[key.ExchangePairAsset]*risk.CurrencySettings),
 	}
+	if rules := cfg.PortfolioSettings.RiskRules; rules != nil {
+		portfolioRisk.Rules = &risk.Rules{
+			MaximumDrawdown:   rules.MaximumDrawdown,
+			DailyLossLimit:    rules.DailyLossLimit,
+			MaximumLeverage:   rules.MaximumLeverage,
+			TerminateOnBreach: rules.TerminateOnBreach,
+			SharedQuoteFunds:  cfg.FundingSettings.UseExchangeLevelFunding,
+		}
+	}
 
 	bt.Funding = funds
 	var trackFuturesPositions bool
@@ -404,6 +413,14 @@ func (bt *BackTest) SetupFromConfig(cfg *config.Config, templatePath, output str
 		CandleInterval:              cfg.DataSettings.Interval,
 		FundManager:                 bt.Funding,
 	}
+	for i := range cfg.StatisticSettings.Benchmarks {
+		var benchmark *statistics.BenchmarkSeries
+		benchmark, err = statistics.LoadBenchmarkSeries(cfg.StatisticSettings.Benchmarks[i].Name, cfg.StatisticSettings.Benchmarks[i].CSVPath)
+		if err != nil {
+			return err
+		}
+		stats.Benchmarks = append(stats.Benchmarks, *benchmark)
+	}
 	bt.Statistic = stats
 	reports.Statistics = stats
 
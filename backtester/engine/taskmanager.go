@@ -80,6 +80,22 @@ func (r *TaskManager) GetSummary(id uuid.UUID) (*TaskSummary, error) {
 	return nil, fmt.Errorf("%s %w", id, errTaskNotFound)
 }
 
+// GetProgress returns how far a strategy task has run
+func (r *TaskManager) GetProgress(id uuid.UUID) (*TaskProgress, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w TaskManager", gctcommon.ErrNilPointer)
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	for i := range r.tasks {
+		if !r.tasks[i].MatchesID(id) {
+			continue
+		}
+		return r.tasks[i].GetProgress()
+	}
+	return nil, fmt.Errorf("%s %w", id, errTaskNotFound)
+}
+
 // StopTask stops a strategy task if enabled, this will run CloseAllPositions
 func (r *TaskManager) StopTask(id uuid.UUID) error {
 	if r == nil {
@@ -440,3 +440,38 @@ func TestClearAllRuns(t *testing.T) {
 		t.Errorf("received '%v' expected '%v'", err, gctcommon.ErrNilPointer)
 	}
 }
+
+func TestGetTaskProgress(t *testing.T) {
+	t.Parallel()
+	rm := NewTaskManager()
+	id, err := uuid.NewV4()
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	_, err = rm.GetProgress(id)
+	if !errors.Is(err, errTaskNotFound) {
+		t.Errorf("received '%v' expected '%v'", err, errTaskNotFound)
+	}
+
+	bt := &BackTest{
+		Strategy:  &binancecashandcarry.Strategy{},
+		Statistic: &statistics.Statistic{},
+	}
+	err = rm.AddTask(bt)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	progress, err := rm.GetProgress(bt.MetaData.ID)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	if progress.MetaData.ID != bt.MetaData.ID {
+		t.Errorf("received '%v' expected '%v'", progress.MetaData.ID, bt.MetaData.ID)
+	}
+
+	rm = nil
+	_, err = rm.GetProgress(id)
+	if !errors.Is(err, gctcommon.ErrNilPointer) {
+		t.Errorf("received '%v' expected '%v'", err, gctcommon.ErrNilPointer)
+	}
+}
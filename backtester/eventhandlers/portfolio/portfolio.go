@@ -641,6 +641,7 @@ func (p *Portfolio) SetHoldingsForTimestamp(h *holdings.Holding) error {
 }
 
 // UpdateHoldings updates the portfolio holdings for the data event
+// and assesses them against the risk manager's loss limits
 func (p *Portfolio) UpdateHoldings(e data.Event, funds funding.IFundReleaser) error {
 	if e == nil {
 		return common.ErrNilEvent
@@ -666,7 +667,14 @@ func (p *Portfolio) UpdateHoldings(e data.Event, funds funding.IFundReleaser) er
 	if err != nil {
 		return err
 	}
-	return p.SetHoldingsForTimestamp(h)
+	err = p.SetHoldingsForTimestamp(h)
+	if err != nil {
+		return err
+	}
+	if p.riskManager == nil {
+		return nil
+	}
+	return p.riskManager.EvaluateHoldings(e.GetTime(), p.GetLatestHoldingsForAllCurrencies())
 }
 
 // GetLatestHoldingsForAllCurrencies will return the current holdings for all loaded currencies
@@ -766,7 +774,14 @@ func (p *Portfolio) SetHoldingsForEvent(fm funding.IFundReader, e common.Event)
 	if err != nil {
 		return err
 	}
-	return p.SetHoldingsForTimestamp(h)
+	err = p.SetHoldingsForTimestamp(h)
+	if err != nil {
+		return err
+	}
+	if p.riskManager == nil {
+		return nil
+	}
+	return p.riskManager.EvaluateHoldings(e.GetTime(), p.GetLatestHoldingsForAllCurrencies())
 }
 
 // GetUnrealisedPNL returns a basic struct containing unrealised PNL
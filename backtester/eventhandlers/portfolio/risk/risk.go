@@ -2,6 +2,7 @@ package risk
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/shopspring/decimal"
 	"github.com/thrasher-corp/gocryptotrader/backtester/common"
@@ -11,6 +12,7 @@ import (
 	gctcommon "github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/common/key"
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
 )
 
 // EvaluateOrder goes through a standard list of evaluations to make to ensure that
@@ -49,6 +51,12 @@ func (r *Risk) EvaluateOrder(o order.Event, latestHoldings []holdings.Holding, s
 			return nil, fmt.Errorf("proceeding with the order would put leverage rate beyond its limit of %v to %v and %w", lookup.MaxLeverageRate, retOrder.GetLeverage(), errCannotPlaceLeverageOrder)
 		}
 	}
+	if r.Rules != nil && increasesExposure(o) {
+		err := r.evaluateRules(retOrder, latestHoldings)
+		if err != nil {
+			return nil, fmt.Errorf("%v %v %v %w", ex, a, p, err)
+		}
+	}
 	if len(latestHoldings) > 1 {
 		ratio := assessHoldingsRatio(o.Pair(), latestHoldings)
 		if lookup.MaximumHoldingRatio.GreaterThan(decimal.Zero) && !ratio.Equal(decimal.NewFromInt(1)) && ratio.GreaterThan(lookup.MaximumHoldingRatio) {
@@ -89,3 +97,99 @@ func assessHoldingsRatio(c currency.Pair, h []holdings.Holding) decimal.Decimal
 
 	return ratio
 }
+
+// EvaluateHoldings tracks the portfolio's value against the risk rules' loss
+// limits, blocking new exposure when they are breached. ErrRunTerminated is
+// returned the first time the maximum drawdown is breached when the rules
+// require the run to end
+func (r *Risk) EvaluateHoldings(t time.Time, latestHoldings []holdings.Holding) error {
+	if r.Rules == nil {
+		return nil
+	}
+	value, _ := portfolioValue(latestHoldings, r.Rules.SharedQuoteFunds)
+	if !value.IsPositive() {
+		return nil
+	}
+	if value.GreaterThan(r.peakValue) {
+		r.peakValue = value
+	}
+	day := t.UTC().Truncate(24 * time.Hour)
+	if !day.Equal(r.day) {
+		r.day = day
+		r.dayOpenValue = value
+		r.dailyHalted = nil
+	}
+	one := decimal.NewFromInt(1)
+	if r.dailyHalted == nil && r.Rules.DailyLossLimit.IsPositive() &&
+		value.LessThan(r.dayOpenValue.Mul(one.Sub(r.Rules.DailyLossLimit))) {
+		r.dailyHalted = fmt.Errorf("%w value %v fell more than %v from day open %v", errDailyLossLimitBreached, value, r.Rules.DailyLossLimit, r.dayOpenValue)
+	}
+	if r.halted != nil || !r.Rules.MaximumDrawdown.IsPositive() ||
+		!value.LessThan(r.peakValue.Mul(one.Sub(r.Rules.MaximumDrawdown))) {
+		return nil
+	}
+	r.halted = fmt.Errorf("%w value %v fell more than %v from peak %v", errMaximumDrawdownBreached, value, r.Rules.MaximumDrawdown, r.peakValue)
+	if r.Rules.TerminateOnBreach && !r.terminated {
+		r.terminated = true
+		return fmt.Errorf("%w %w", ErrRunTerminated, r.halted)
+	}
+	return nil
+}
+
+// evaluateRules rejects an order which would add exposure while a loss limit
+// is breached or take the portfolio beyond its maximum leverage
+func (r *Risk) evaluateRules(o *order.Order, latestHoldings []holdings.Holding) error {
+	if r.halted != nil {
+		return r.halted
+	}
+	if r.dailyHalted != nil {
+		return r.dailyHalted
+	}
+	if !r.Rules.MaximumLeverage.IsPositive() {
+		return nil
+	}
+	value, exposure := portfolioValue(latestHoldings, r.Rules.SharedQuoteFunds)
+	if !value.IsPositive() {
+		return nil
+	}
+	leverage := exposure.Add(o.Amount.Mul(o.ClosePrice).Abs()).Div(value)
+	if leverage.GreaterThan(r.Rules.MaximumLeverage) {
+		return fmt.Errorf("%w of %v to %v", errMaximumLeverageExceeded, r.Rules.MaximumLeverage, leverage)
+	}
+	return nil
+}
+
+// increasesExposure returns whether an order opens or adds to a position
+func increasesExposure(o order.Event) bool {
+	if o.IsClosingPosition() || o.IsLiquidating() {
+		return false
+	}
+	switch o.GetDirection() {
+	case gctorder.Buy, gctorder.Bid, gctorder.Long, gctorder.Short:
+		return true
+	}
+	return false
+}
+
+// portfolioValue returns the combined value of all holdings along with the
+// value of all positions held
+func portfolioValue(h []holdings.Holding, sharedQuoteFunds bool) (value, exposure decimal.Decimal) {
+	quotes := make(map[key.ExchangePairAsset]bool)
+	for i := range h {
+		exposure = exposure.Add(h[i].BaseValue.Abs())
+		value = value.Add(h[i].BaseValue)
+		if sharedQuoteFunds {
+			k := key.ExchangePairAsset{
+				Exchange: h[i].Exchange,
+				Quote:    h[i].Pair.Quote.Item,
+				Asset:    h[i].Asset,
+			}
+			if quotes[k] {
+				continue
+			}
+			quotes[k] = true
+		}
+		value = value.Add(h[i].QuoteSize)
+	}
+	return value, exposure
+}
@@ -3,6 +3,7 @@ package risk
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/shopspring/decimal"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio/compliance"
@@ -166,3 +167,121 @@ func TestEvaluateOrder(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestEvaluateHoldings(t *testing.T) {
+	t.Parallel()
+	r := &Risk{}
+	err := r.EvaluateHoldings(time.Now(), nil)
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+
+	r.Rules = &Rules{
+		MaximumDrawdown:   decimal.NewFromFloat(0.2),
+		DailyLossLimit:    decimal.NewFromFloat(0.05),
+		TerminateOnBreach: true,
+	}
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	tt := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	err = r.EvaluateHoldings(tt, []holdings.Holding{{Pair: p, BaseValue: decimal.NewFromInt(50), QuoteSize: decimal.NewFromInt(50)}})
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	err = r.EvaluateHoldings(tt.Add(time.Hour), []holdings.Holding{{Pair: p, BaseValue: decimal.NewFromInt(44), QuoteSize: decimal.NewFromInt(50)}})
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	if !errors.Is(r.dailyHalted, errDailyLossLimitBreached) {
+		t.Errorf("received: %v, expected: %v", r.dailyHalted, errDailyLossLimitBreached)
+	}
+	err = r.EvaluateHoldings(tt.Add(time.Hour*24), []holdings.Holding{{Pair: p, BaseValue: decimal.NewFromInt(44), QuoteSize: decimal.NewFromInt(50)}})
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	if r.dailyHalted != nil {
+		t.Errorf("received: %v, expected: %v", r.dailyHalted, nil)
+	}
+	err = r.EvaluateHoldings(tt.Add(time.Hour*25), []holdings.Holding{{Pair: p, BaseValue: decimal.NewFromInt(25), QuoteSize: decimal.NewFromInt(50)}})
+	if !errors.Is(err, ErrRunTerminated) {
+		t.Errorf("received: %v, expected: %v", err, ErrRunTerminated)
+	}
+	if !errors.Is(err, errMaximumDrawdownBreached) {
+		t.Errorf("received: %v, expected: %v", err, errMaximumDrawdownBreached)
+	}
+	err = r.EvaluateHoldings(tt.Add(time.Hour*26), []holdings.Holding{{Pair: p, BaseValue: decimal.NewFromInt(20), QuoteSize: decimal.NewFromInt(50)}})
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	if !errors.Is(r.halted, errMaximumDrawdownBreached) {
+		t.Errorf("received: %v, expected: %v", r.halted, errMaximumDrawdownBreached)
+	}
+}
+
+func TestEvaluateOrderRiskRules(t *testing.T) {
+	t.Parallel()
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	e := "binance"
+	a := asset.Spot
+	r := &Risk{
+		CurrencySettings: map[key.ExchangePairAsset]*CurrencySettings{
+			{Exchange: e, Base: p.Base.Item, Quote: p.Quote.Item, Asset: a}: {},
+		},
+		Rules: &Rules{MaximumLeverage: decimal.NewFromInt(1)},
+	}
+	o := &order.Order{
+		Base: &event.Base{
+			Exchange:     e,
+			AssetType:    a,
+			CurrencyPair: p,
+		},
+		Direction:  gctorder.Buy,
+		ClosePrice: decimal.NewFromInt(10),
+		Amount:     decimal.NewFromInt(6),
+	}
+	h := []holdings.Holding{{Exchange: e, Asset: a, Pair: p, BaseValue: decimal.NewFromInt(50), QuoteSize: decimal.NewFromInt(50)}}
+	_, err := r.EvaluateOrder(o, h, compliance.Snapshot{})
+	if !errors.Is(err, errMaximumLeverageExceeded) {
+		t.Errorf("received: %v, expected: %v", err, errMaximumLeverageExceeded)
+	}
+	o.Amount = decimal.NewFromInt(5)
+	_, err = r.EvaluateOrder(o, h, compliance.Snapshot{})
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+
+	r.halted = errMaximumDrawdownBreached
+	_, err = r.EvaluateOrder(o, h, compliance.Snapshot{})
+	if !errors.Is(err, errMaximumDrawdownBreached) {
+		t.Errorf("received: %v, expected: %v", err, errMaximumDrawdownBreached)
+	}
+	o.Direction = gctorder.Sell
+	_, err = r.EvaluateOrder(o, h, compliance.Snapshot{})
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	o.Direction = gctorder.Short
+	o.ClosingPosition = true
+	_, err = r.EvaluateOrder(o, h, compliance.Snapshot{})
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+}
+
+func TestPortfolioValue(t *testing.T) {
+	t.Parallel()
+	h := []holdings.Holding{
+		{Exchange: "binance", Asset: asset.Spot, Pair: currency.NewPair(currency.BTC, currency.USDT), BaseValue: decimal.NewFromInt(10), QuoteSize: decimal.NewFromInt(100)},
+		{Exchange: "binance", Asset: asset.Spot, Pair: currency.NewPair(currency.ETH, currency.USDT), BaseValue: decimal.NewFromInt(20), QuoteSize: decimal.NewFromInt(100)},
+	}
+	value, exposure := portfolioValue(h, false)
+	if !value.Equal(decimal.NewFromInt(230)) {
+		t.Errorf("received: %v, expected: %v", value, 230)
+	}
+	if !exposure.Equal(decimal.NewFromInt(30)) {
+		t.Errorf("received: %v, expected: %v", exposure, 30)
+	}
+	value, _ = portfolioValue(h, true)
+	if !value.Equal(decimal.NewFromInt(130)) {
+		t.Errorf("received: %v, expected: %v", value, 130)
+	}
+}
@@ -2,6 +2,7 @@ package risk
 
 import (
 	"errors"
+	"time"
 
 	"github.com/shopspring/decimal"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio/compliance"
@@ -14,11 +15,19 @@ var (
 	errNoCurrencySettings       = errors.New("lacking currency settings, cannot evaluate order")
 	errLeverageNotAllowed       = errors.New("order is using leverage when leverage is not enabled in config")
 	errCannotPlaceLeverageOrder = errors.New("cannot place leveraged order")
+	errMaximumDrawdownBreached  = errors.New("maximum drawdown breached")
+	errDailyLossLimitBreached   = errors.New("daily loss limit breached")
+	errMaximumLeverageExceeded  = errors.New("order would exceed maximum portfolio leverage")
+
+	// ErrRunTerminated is returned once when the maximum drawdown is breached
+	// and the rules require the run to end
+	ErrRunTerminated = errors.New("run terminated by risk rules")
 )
 
 // Handler defines what is expected to be able to assess risk of an order
 type Handler interface {
 	EvaluateOrder(order.Event, []holdings.Holding, compliance.Snapshot) (*order.Order, error)
+	EvaluateHoldings(time.Time, []holdings.Holding) error
 }
 
 // Risk contains all currency settings in order to evaluate potential orders
@@ -26,6 +35,36 @@ type Risk struct {
 	CurrencySettings map[key.ExchangePairAsset]*CurrencySettings
 	CanUseLeverage   bool
 	MaximumLeverage  decimal.Decimal
+	Rules            *Rules
+
+	peakValue    decimal.Decimal
+	day          time.Time
+	dayOpenValue decimal.Decimal
+	halted       error
+	dailyHalted  error
+	terminated   bool
+}
+
+// Rules limit the portfolio's losses and exposure over the course of a run.
+// Once a loss limit is breached, orders which would increase exposure are
+// rejected while orders reducing positions are still allowed
+type Rules struct {
+	// MaximumDrawdown is the largest fall from the portfolio's peak value as
+	// a proportion, eg 0.2 for 20%. Breaching it blocks new exposure for the
+	// remainder of the run
+	MaximumDrawdown decimal.Decimal
+	// DailyLossLimit is the largest fall from the portfolio's value at the
+	// start of the UTC day as a proportion. Breaching it blocks new exposure
+	// until the next day
+	DailyLossLimit decimal.Decimal
+	// MaximumLeverage caps the value of all positions as a multiple of the
+	// portfolio's value
+	MaximumLeverage decimal.Decimal
+	// TerminateOnBreach ends the run when the maximum drawdown is breached
+	TerminateOnBreach bool
+	// SharedQuoteFunds is set when pairs on the same exchange and asset share
+	// quote funds, so each quote balance is only valued once
+	SharedQuoteFunds bool
 }
 
 // CurrencySettings contains relevant limits to assess risk
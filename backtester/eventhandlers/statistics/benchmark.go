@@ -0,0 +1,208 @@
+package statistics
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	gctmath "github.com/thrasher-corp/gocryptotrader/common/math"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// LoadBenchmarkSeries reads a user supplied benchmark from a CSV file where
+// each row is a unix timestamp in seconds followed by the benchmark's value
+func LoadBenchmarkSeries(name, path string) (*BenchmarkSeries, error) {
+	if name == "" {
+		return nil, fmt.Errorf("%w name unset", errInvalidBenchmark)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := f.Close(); closeErr != nil {
+			log.Errorln(common.Statistics, closeErr)
+		}
+	}()
+	resp := &BenchmarkSeries{Name: name}
+	r := csv.NewReader(f)
+	for row := 1; ; row++ {
+		var record []string
+		record, err = r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("benchmark %v %w", name, err)
+		}
+		if len(record) < 2 {
+			return nil, fmt.Errorf("%w %v row %v requires a timestamp and value", errInvalidBenchmark, name, row)
+		}
+		var ts int64
+		ts, err = strconv.ParseInt(strings.TrimSpace(record[0]), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w %v row %v timestamp: %w", errInvalidBenchmark, name, row, err)
+		}
+		var value decimal.Decimal
+		value, err = decimal.NewFromString(strings.TrimSpace(record[1]))
+		if err != nil {
+			return nil, fmt.Errorf("%w %v row %v value: %w", errInvalidBenchmark, name, row, err)
+		}
+		resp.Values = append(resp.Values, ValueAtTime{Time: time.Unix(ts, 0).UTC(), Value: value, Set: true})
+	}
+	if len(resp.Values) < 2 {
+		return nil, fmt.Errorf("%w %v", errInsufficientBenchmarkData, name)
+	}
+	return resp, nil
+}
+
+// CalculateBenchmark compares a strategy's value over time against a
+// benchmark. Each strategy value is compared to the latest benchmark value at
+// or before its time, so benchmarks can be sampled less often than the
+// strategy. Beta is the covariance of the strategy's and benchmark's returns
+// over the variance of the benchmark's returns
+func CalculateBenchmark(name string, strategy, benchmark []ValueAtTime, riskFreeRatePerCandle decimal.Decimal, intervalsPerYear float64) (*BenchmarkStatistic, error) {
+	sorted := slices.Clone(benchmark)
+	slices.SortFunc(sorted, func(a, b ValueAtTime) int {
+		return a.Time.Compare(b.Time)
+	})
+	var strategyValues, benchmarkValues []decimal.Decimal
+	j := -1
+	for i := range strategy {
+		for j+1 < len(sorted) && !sorted[j+1].Time.After(strategy[i].Time) {
+			j++
+		}
+		if j == -1 || !sorted[j].Value.IsPositive() {
+			continue
+		}
+		strategyValues = append(strategyValues, strategy[i].Value)
+		benchmarkValues = append(benchmarkValues, sorted[j].Value)
+	}
+	var returns, benchmarkReturns []decimal.Decimal
+	for i := 1; i < len(strategyValues); i++ {
+		if strategyValues[i-1].IsZero() {
+			continue
+		}
+		returns = append(returns, strategyValues[i].Sub(strategyValues[i-1]).Div(strategyValues[i-1]))
+		benchmarkReturns = append(benchmarkReturns, benchmarkValues[i].Sub(benchmarkValues[i-1]).Div(benchmarkValues[i-1]))
+	}
+	if len(returns) < 2 {
+		return nil, fmt.Errorf("%w %v", errInsufficientBenchmarkData, name)
+	}
+
+	oneHundred := decimal.NewFromInt(100)
+	resp := &BenchmarkStatistic{
+		Name:     name,
+		Movement: benchmarkValues[len(benchmarkValues)-1].Sub(benchmarkValues[0]).Div(benchmarkValues[0]).Mul(oneHundred),
+	}
+	var strategyMovement decimal.Decimal
+	if !strategyValues[0].IsZero() {
+		strategyMovement = strategyValues[len(strategyValues)-1].Sub(strategyValues[0]).Div(strategyValues[0]).Mul(oneHundred)
+	}
+	resp.DidStrategyBeatTheBenchmark = strategyMovement.GreaterThan(resp.Movement)
+
+	averageReturn, err := gctmath.DecimalArithmeticMean(returns)
+	if err != nil {
+		return nil, err
+	}
+	averageBenchmarkReturn, err := gctmath.DecimalArithmeticMean(benchmarkReturns)
+	if err != nil {
+		return nil, err
+	}
+	var covariance, variance decimal.Decimal
+	for i := range returns {
+		benchmarkDiff := benchmarkReturns[i].Sub(averageBenchmarkReturn)
+		covariance = covariance.Add(returns[i].Sub(averageReturn).Mul(benchmarkDiff))
+		variance = variance.Add(benchmarkDiff.Mul(benchmarkDiff))
+	}
+	if !variance.IsZero() {
+		resp.Beta = covariance.Div(variance)
+	}
+	alphaPerCandle := averageReturn.Sub(riskFreeRatePerCandle).Sub(resp.Beta.Mul(averageBenchmarkReturn.Sub(riskFreeRatePerCandle)))
+	resp.Alpha = alphaPerCandle.Mul(decimal.NewFromFloat(intervalsPerYear))
+	resp.InformationRatio, err = gctmath.DecimalInformationRatio(returns, benchmarkReturns, averageReturn, averageBenchmarkReturn)
+	if err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// calculateBenchmarks compares a strategy's value over time against each
+// benchmark, logging benchmarks which cannot be compared
+func calculateBenchmarks(sep string, strategy []ValueAtTime, benchmarks []BenchmarkSeries, riskFreeRatePerCandle decimal.Decimal, intervalsPerYear float64) []BenchmarkStatistic {
+	resp := make([]BenchmarkStatistic, 0, len(benchmarks))
+	for i := range benchmarks {
+		b, err := CalculateBenchmark(benchmarks[i].Name, strategy, benchmarks[i].Values, riskFreeRatePerCandle, intervalsPerYear)
+		if err != nil {
+			log.Warnf(common.Statistics, "%s could not compare against benchmark: %v", sep, err)
+			continue
+		}
+		resp = append(resp, *b)
+	}
+	return resp
+}
+
+// benchmarkSeries returns the BTC index, when the run contains BTC spot
+// pairs quoted in USD or stablecoins, followed by any user supplied benchmarks
+func (s *Statistic) benchmarkSeries() []BenchmarkSeries {
+	totals := make(map[int64]decimal.Decimal)
+	counts := make(map[int64]int64)
+	for k, v := range s.ExchangeAssetPairStatistics {
+		quote := k.Quote.Currency()
+		if k.Asset != asset.Spot || !k.Base.Currency().Equal(currency.BTC) ||
+			(!quote.Equal(currency.USD) && !quote.IsStableCurrency()) {
+			continue
+		}
+		for i := range v.Events {
+			if !v.Events[i].ClosePrice.IsPositive() {
+				continue
+			}
+			t := v.Events[i].Time.UnixNano()
+			totals[t] = totals[t].Add(v.Events[i].ClosePrice)
+			counts[t]++
+		}
+	}
+	resp := make([]BenchmarkSeries, 0, len(s.Benchmarks)+1)
+	if len(totals) > 0 {
+		index := BenchmarkSeries{Name: BTCIndexBenchmark, Values: make([]ValueAtTime, 0, len(totals))}
+		for t, total := range totals {
+			index.Values = append(index.Values, ValueAtTime{
+				Time:  time.Unix(0, t).UTC(),
+				Value: total.Div(decimal.NewFromInt(counts[t])),
+				Set:   true,
+			})
+		}
+		slices.SortFunc(index.Values, func(a, b ValueAtTime) int {
+			return a.Time.Compare(b.Time)
+		})
+		resp = append(resp, index)
+	}
+	return append(resp, s.Benchmarks...)
+}
+
+// calculateBenchmarks compares the currency pair's holdings value against
+// buying and holding the pair and each benchmark series
+func (c *CurrencyPairStatistic) calculateBenchmarks(benchmarks []BenchmarkSeries, riskFreeRate decimal.Decimal) {
+	if len(c.Events) == 0 || c.Events[0].DataEvent == nil {
+		return
+	}
+	intervalsPerYear := c.Events[0].DataEvent.GetInterval().IntervalsPerYear()
+	strategy := make([]ValueAtTime, len(c.Events))
+	buyAndHold := BenchmarkSeries{Name: BuyAndHoldBenchmark, Values: make([]ValueAtTime, len(c.Events))}
+	for i := range c.Events {
+		strategy[i] = ValueAtTime{Time: c.Events[i].Time, Value: c.Events[i].Holdings.TotalValue, Set: true}
+		buyAndHold.Values[i] = ValueAtTime{Time: c.Events[i].Time, Value: c.Events[i].ClosePrice, Set: true}
+	}
+	sep := fmt.Sprintf("%v %v %v |\t", c.Exchange, c.Asset, c.Currency)
+	c.Benchmarks = calculateBenchmarks(sep, strategy, append([]BenchmarkSeries{buyAndHold}, benchmarks...), riskFreeRate.Div(decimal.NewFromFloat(intervalsPerYear)), intervalsPerYear)
+}
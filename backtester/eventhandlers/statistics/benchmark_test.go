@@ -0,0 +1,115 @@
+package statistics
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/common/key"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+func valuesAtTimes(start time.Time, values ...float64) []ValueAtTime {
+	resp := make([]ValueAtTime, len(values))
+	for i := range values {
+		resp[i] = ValueAtTime{Time: start.Add(time.Hour * time.Duration(i)), Value: decimal.NewFromFloat(values[i]), Set: true}
+	}
+	return resp
+}
+
+func TestLoadBenchmarkSeries(t *testing.T) {
+	t.Parallel()
+	_, err := LoadBenchmarkSeries("", "")
+	assert.ErrorIs(t, err, errInvalidBenchmark, "LoadBenchmarkSeries should error without a name")
+
+	dir := t.TempDir()
+	_, err = LoadBenchmarkSeries("index", filepath.Join(dir, "missing.csv"))
+	assert.ErrorIs(t, err, os.ErrNotExist, "LoadBenchmarkSeries should error on a missing file")
+
+	path := filepath.Join(dir, "index.csv")
+	require.NoError(t, os.WriteFile(path, []byte("1609459200,100\n1609462800,a\n"), 0o600), "WriteFile must not error")
+	_, err = LoadBenchmarkSeries("index", path)
+	assert.ErrorIs(t, err, errInvalidBenchmark, "LoadBenchmarkSeries should error on an invalid value")
+
+	require.NoError(t, os.WriteFile(path, []byte("1609459200,100\n"), 0o600), "WriteFile must not error")
+	_, err = LoadBenchmarkSeries("index", path)
+	assert.ErrorIs(t, err, errInsufficientBenchmarkData, "LoadBenchmarkSeries should error on a single value")
+
+	require.NoError(t, os.WriteFile(path, []byte("1609459200,100\n1609462800, 101.5\n"), 0o600), "WriteFile must not error")
+	series, err := LoadBenchmarkSeries("index", path)
+	require.NoError(t, err, "LoadBenchmarkSeries must not error")
+	assert.Equal(t, "index", series.Name, "LoadBenchmarkSeries should set the name")
+	require.Len(t, series.Values, 2, "LoadBenchmarkSeries must load every row")
+	assert.Equal(t, time.Unix(1609462800, 0).UTC(), series.Values[1].Time, "LoadBenchmarkSeries should parse the timestamp")
+	assert.Equal(t, "101.5", series.Values[1].Value.String(), "LoadBenchmarkSeries should parse the value")
+}
+
+func TestCalculateBenchmark(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	benchmark := valuesAtTimes(start, 100, 110, 99, 108.9)
+	// the strategy doubles every benchmark return
+	strategy := valuesAtTimes(start, 1000, 1200, 960, 1152)
+
+	_, err := CalculateBenchmark("index", strategy[:2], benchmark, decimal.Zero, 8760)
+	assert.ErrorIs(t, err, errInsufficientBenchmarkData, "CalculateBenchmark should error with a single return")
+
+	b, err := CalculateBenchmark("index", strategy, benchmark, decimal.Zero, 8760)
+	require.NoError(t, err, "CalculateBenchmark must not error")
+	assert.Equal(t, "index", b.Name, "CalculateBenchmark should set the name")
+	assert.True(t, b.Beta.Round(8).Equal(decimal.NewFromInt(2)), "CalculateBenchmark should calculate a beta of 2")
+	assert.True(t, b.Alpha.Round(8).IsZero(), "CalculateBenchmark should calculate no alpha when returns are explained by beta")
+	assert.True(t, b.Movement.Round(8).Equal(decimal.NewFromFloat(8.9)), "CalculateBenchmark should calculate the benchmark movement")
+	assert.True(t, b.InformationRatio.IsPositive(), "CalculateBenchmark should calculate a positive information ratio")
+	assert.True(t, b.DidStrategyBeatTheBenchmark, "CalculateBenchmark should report the strategy beat the benchmark")
+
+	// a benchmark sampled less often than the strategy uses its latest value
+	sparse := []ValueAtTime{benchmark[0], benchmark[2]}
+	b, err = CalculateBenchmark("index", strategy, sparse, decimal.Zero, 8760)
+	require.NoError(t, err, "CalculateBenchmark must not error")
+	assert.True(t, b.Movement.Round(8).Equal(decimal.NewFromInt(-1)), "CalculateBenchmark should use the latest benchmark value")
+
+	// strategy values before the benchmark starts are ignored
+	late := valuesAtTimes(start.Add(time.Hour), 110, 99, 108.9)
+	b, err = CalculateBenchmark("index", strategy, late, decimal.Zero, 8760)
+	require.NoError(t, err, "CalculateBenchmark must not error")
+	assert.True(t, b.Beta.Round(8).Equal(decimal.NewFromInt(2)), "CalculateBenchmark should align the strategy to the benchmark")
+}
+
+func TestBenchmarkSeries(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	events := func(prices ...float64) []DataAtOffset {
+		resp := make([]DataAtOffset, len(prices))
+		for i := range prices {
+			resp[i] = DataAtOffset{Time: start.Add(time.Hour * time.Duration(i)), ClosePrice: decimal.NewFromFloat(prices[i])}
+		}
+		return resp
+	}
+	s := &Statistic{
+		ExchangeAssetPairStatistics: map[key.ExchangePairAsset]*CurrencyPairStatistic{
+			key.ExchangePairAsset{Exchange: testExchange, Base: currency.BTC.Item, Quote: currency.USDT.Item, Asset: asset.Spot}: {Events: events(100, 200)},
+			key.ExchangePairAsset{Exchange: "kraken", Base: currency.BTC.Item, Quote: currency.USD.Item, Asset: asset.Spot}:      {Events: events(102, 204)},
+			key.ExchangePairAsset{Exchange: testExchange, Base: currency.ETH.Item, Quote: currency.USDT.Item, Asset: asset.Spot}: {Events: events(10, 20)},
+		},
+		Benchmarks: []BenchmarkSeries{{Name: "custom"}},
+	}
+	series := s.benchmarkSeries()
+	require.Len(t, series, 2, "benchmarkSeries must return the BTC index and custom benchmark")
+	assert.Equal(t, BTCIndexBenchmark, series[0].Name, "benchmarkSeries should return the BTC index first")
+	require.Len(t, series[0].Values, 2, "BTC index must have a value at each time")
+	assert.Equal(t, "101", series[0].Values[0].Value.String(), "BTC index should average BTC close prices")
+	assert.Equal(t, "202", series[0].Values[1].Value.String(), "BTC index should average BTC close prices")
+	assert.Equal(t, "custom", series[1].Name, "benchmarkSeries should return custom benchmarks")
+
+	delete(s.ExchangeAssetPairStatistics, key.ExchangePairAsset{Exchange: testExchange, Base: currency.BTC.Item, Quote: currency.USDT.Item, Asset: asset.Spot})
+	delete(s.ExchangeAssetPairStatistics, key.ExchangePairAsset{Exchange: "kraken", Base: currency.BTC.Item, Quote: currency.USD.Item, Asset: asset.Spot})
+	series = s.benchmarkSeries()
+	require.Len(t, series, 1, "benchmarkSeries must not return a BTC index without BTC pairs")
+	assert.Equal(t, "custom", series[0].Name, "benchmarkSeries should return custom benchmarks")
+}
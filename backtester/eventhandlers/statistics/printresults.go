@@ -269,6 +269,9 @@ func (c *CurrencyPairStatistic) PrintResults(e string, a asset.Item, p currency.
 		log.Infof(common.CurrencyStatistics, "%s Information ratio: %v", sep, c.GeometricRatios.InformationRatio.Round(4))
 		log.Infof(common.CurrencyStatistics, "%s Calmar ratio: %v", sep, c.GeometricRatios.CalmarRatio.Round(4))
 	}
+	if !usingExchangeLevelFunding {
+		printBenchmarks(common.CurrencyStatistics, common.CMDColours.H2, sep, c.Benchmarks)
+	}
 
 	log.Infoln(common.CurrencyStatistics, common.CMDColours.H2+"------------------Results------------------------------------"+common.CMDColours.Default)
 	log.Infof(common.CurrencyStatistics, "%s Starting Close Price: %s at %v", sep, convert.DecimalToHumanFriendlyString(c.StartingClosePrice.Value, 8, ".", ","), c.StartingClosePrice.Time)
@@ -419,6 +422,23 @@ func (f *FundingStatistics) PrintResults(wasAnyDataMissing bool) error {
 	log.Infof(common.FundingStatistics, "%s Sortino ratio: %v", sep, f.TotalUSDStatistics.GeometricRatios.SortinoRatio.Round(4))
 	log.Infof(common.FundingStatistics, "%s Information ratio: %v", sep, f.TotalUSDStatistics.GeometricRatios.InformationRatio.Round(4))
 	log.Infof(common.FundingStatistics, "%s Calmar ratio: %v\n\n", sep, f.TotalUSDStatistics.GeometricRatios.CalmarRatio.Round(4))
+	printBenchmarks(common.FundingStatistics, common.CMDColours.H3, sep, f.TotalUSDStatistics.Benchmarks)
 
 	return nil
 }
+
+// printBenchmarks outputs the comparison of strategy returns against each
+// benchmark
+func printBenchmarks(sl *log.SubLogger, colour, sep string, benchmarks []BenchmarkStatistic) {
+	if len(benchmarks) == 0 {
+		return
+	}
+	log.Infoln(sl, colour+"------------------Benchmarks--------------------------------------------"+common.CMDColours.Default)
+	for i := range benchmarks {
+		log.Infof(sl, "%s %v movement: %s%%", sep, benchmarks[i].Name, convert.DecimalToHumanFriendlyString(benchmarks[i].Movement, 2, ".", ","))
+		log.Infof(sl, "%s %v alpha: %v", sep, benchmarks[i].Name, benchmarks[i].Alpha.Round(4))
+		log.Infof(sl, "%s %v beta: %v", sep, benchmarks[i].Name, benchmarks[i].Beta.Round(4))
+		log.Infof(sl, "%s %v information ratio: %v", sep, benchmarks[i].Name, benchmarks[i].InformationRatio.Round(4))
+		log.Infof(sl, "%s Did it beat %v: %v", sep, benchmarks[i].Name, benchmarks[i].DidStrategyBeatTheBenchmark)
+	}
+}
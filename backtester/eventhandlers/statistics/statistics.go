@@ -47,6 +47,7 @@ func (s *Statistic) Reset() error {
 	s.FundingStatistics = nil
 	s.FundManager = nil
 	s.HasCollateral = false
+	s.Benchmarks = nil
 	return nil
 }
 
@@ -223,6 +224,7 @@ func (s *Statistic) CalculateAllResults() error {
 	currCount := 0
 	finalResults := make([]FinalResultsHolder, 0, len(s.ExchangeAssetPairStatistics))
 	var err error
+	benchmarks := s.benchmarkSeries()
 	for mapKey, stats := range s.ExchangeAssetPairStatistics {
 		currCount++
 		last := stats.Events[len(stats.Events)-1]
@@ -233,6 +235,7 @@ func (s *Statistic) CalculateAllResults() error {
 		if err != nil {
 			log.Errorln(common.Statistics, err)
 		}
+		stats.calculateBenchmarks(benchmarks, s.RiskFreeRate)
 		stats.FinalHoldings = last.Holdings
 		stats.InitialHoldings = stats.Events[0].Holdings
 		if last.ComplianceSnapshot == nil {
@@ -271,6 +274,10 @@ func (s *Statistic) CalculateAllResults() error {
 	if err != nil {
 		return err
 	}
+	if usdStats := s.FundingStatistics.TotalUSDStatistics; usdStats != nil {
+		intervalsPerYear := s.CandleInterval.IntervalsPerYear()
+		usdStats.Benchmarks = calculateBenchmarks("USD Totals |\t", usdStats.HoldingValues, benchmarks, s.RiskFreeRate.Div(decimal.NewFromFloat(intervalsPerYear)), intervalsPerYear)
+	}
 	err = s.FundingStatistics.PrintResults(s.WasAnyDataMissing)
 	if err != nil {
 		return err
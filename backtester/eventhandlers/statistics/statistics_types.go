@@ -30,6 +30,18 @@ var (
 	errNoRelevantStatsFound        = errors.New("no relevant currency pair statistics found")
 	errReceivedNoData              = errors.New("received no data")
 	errNoDataAtOffset              = errors.New("no data found at offset")
+	errInsufficientBenchmarkData   = errors.New("insufficient benchmark data")
+	errInvalidBenchmark            = errors.New("invalid benchmark")
+)
+
+// Benchmarks calculated for every run alongside any user supplied benchmarks
+const (
+	// BuyAndHoldBenchmark compares a currency pair's strategy returns
+	// against holding the pair for the entire run
+	BuyAndHoldBenchmark = "buy-and-hold"
+	// BTCIndexBenchmark compares strategy returns against the average close
+	// price of the run's BTC spot pairs quoted in USD or stablecoins
+	BTCIndexBenchmark = "btc-index"
 )
 
 // Statistic holds all statistical information for a backtester run, from drawdowns to ratios.
@@ -57,6 +69,9 @@ type Statistic struct {
 	FundingStatistics           *FundingStatistics                               `json:"funding-statistics"`
 	FundManager                 funding.IFundingManager                          `json:"-"`
 	HasCollateral               bool                                             `json:"has-collateral"`
+	// Benchmarks are user supplied series that strategy returns are
+	// compared against
+	Benchmarks []BenchmarkSeries `json:"-"`
 }
 
 // FinalResultsHolder holds important stats about a currency's performance
@@ -173,13 +188,14 @@ type CurrencyPairStatistic struct {
 
 	Events []DataAtOffset `json:"-"`
 
-	MaxDrawdown           Swing               `json:"max-drawdown,omitempty"`
-	HighestCommittedFunds ValueAtTime         `json:"highest-committed-funds"`
-	GeometricRatios       *Ratios             `json:"geometric-ratios"`
-	ArithmeticRatios      *Ratios             `json:"arithmetic-ratios"`
-	InitialHoldings       holdings.Holding    `json:"initial-holdings-holdings"`
-	FinalHoldings         holdings.Holding    `json:"final-holdings"`
-	FinalOrders           compliance.Snapshot `json:"final-orders"`
+	MaxDrawdown           Swing                `json:"max-drawdown,omitempty"`
+	HighestCommittedFunds ValueAtTime          `json:"highest-committed-funds"`
+	GeometricRatios       *Ratios              `json:"geometric-ratios"`
+	ArithmeticRatios      *Ratios              `json:"arithmetic-ratios"`
+	InitialHoldings       holdings.Holding     `json:"initial-holdings-holdings"`
+	FinalHoldings         holdings.Holding     `json:"final-holdings"`
+	FinalOrders           compliance.Snapshot  `json:"final-orders"`
+	Benchmarks            []BenchmarkStatistic `json:"benchmarks,omitempty"`
 }
 
 // Ratios stores all the ratios used for statistics
@@ -250,16 +266,36 @@ type FundingItemStatistics struct {
 
 // TotalFundingStatistics holds values for overall statistics for funding items
 type TotalFundingStatistics struct {
-	HoldingValues            []ValueAtTime   `json:"-"`
-	HighestHoldingValue      ValueAtTime     `json:"highest-holding-value"`
-	LowestHoldingValue       ValueAtTime     `json:"lowest-holding-value"`
-	BenchmarkMarketMovement  decimal.Decimal `json:"benchmark-market-movement"`
-	RiskFreeRate             decimal.Decimal `json:"risk-free-rate"`
-	CompoundAnnualGrowthRate decimal.Decimal `json:"compound-annual-growth-rate"`
-	MaxDrawdown              Swing           `json:"max-drawdown"`
-	GeometricRatios          *Ratios         `json:"geometric-ratios"`
-	ArithmeticRatios         *Ratios         `json:"arithmetic-ratios"`
-	DidStrategyBeatTheMarket bool            `json:"did-strategy-beat-the-market"`
-	DidStrategyMakeProfit    bool            `json:"did-strategy-make-profit"`
-	HoldingValueDifference   decimal.Decimal `json:"holding-value-difference"`
+	HoldingValues            []ValueAtTime        `json:"-"`
+	HighestHoldingValue      ValueAtTime          `json:"highest-holding-value"`
+	LowestHoldingValue       ValueAtTime          `json:"lowest-holding-value"`
+	BenchmarkMarketMovement  decimal.Decimal      `json:"benchmark-market-movement"`
+	RiskFreeRate             decimal.Decimal      `json:"risk-free-rate"`
+	CompoundAnnualGrowthRate decimal.Decimal      `json:"compound-annual-growth-rate"`
+	MaxDrawdown              Swing                `json:"max-drawdown"`
+	GeometricRatios          *Ratios              `json:"geometric-ratios"`
+	ArithmeticRatios         *Ratios              `json:"arithmetic-ratios"`
+	DidStrategyBeatTheMarket bool                 `json:"did-strategy-beat-the-market"`
+	DidStrategyMakeProfit    bool                 `json:"did-strategy-make-profit"`
+	HoldingValueDifference   decimal.Decimal      `json:"holding-value-difference"`
+	Benchmarks               []BenchmarkStatistic `json:"benchmarks,omitempty"`
+}
+
+// BenchmarkSeries is a series of values strategy returns are compared against
+type BenchmarkSeries struct {
+	Name   string
+	Values []ValueAtTime
+}
+
+// BenchmarkStatistic compares strategy returns against a benchmark
+type BenchmarkStatistic struct {
+	Name string `json:"name"`
+	// Movement is the benchmark's percentage movement over the run
+	Movement decimal.Decimal `json:"movement"`
+	// Alpha is the annualised return in excess of the return predicted by
+	// the strategy's beta to the benchmark
+	Alpha                       decimal.Decimal `json:"alpha"`
+	Beta                        decimal.Decimal `json:"beta"`
+	InformationRatio            decimal.Decimal `json:"information-ratio"`
+	DidStrategyBeatTheBenchmark bool            `json:"did-strategy-beat-the-benchmark"`
 }
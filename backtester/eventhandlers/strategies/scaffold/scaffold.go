@@ -0,0 +1,185 @@
+package scaffold
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/config"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies"
+	gctcommon "github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/file"
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+var strategyNameRegex = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+
+// NewStrategy generates a strategy skeleton with its unit tests, README and
+// documentation template, registers it as a supported strategy and generates
+// a strategy config to run it. Nothing is written unless every file is
+// generated successfully
+func NewStrategy(o *Options) (*Result, error) {
+	if o == nil {
+		return nil, fmt.Errorf("%w scaffold options", gctcommon.ErrNilPointer)
+	}
+	if err := checkName(o.Name); err != nil {
+		return nil, err
+	}
+	if strings.Contains(o.Description, "`") {
+		return nil, errInvalidDescription
+	}
+	description := strings.TrimSpace(o.Description)
+	if description == "" {
+		description = "The " + o.Name + " strategy. Describe what the strategy does and the market conditions it acts upon here"
+	}
+	strategyDir := filepath.Join(o.BacktesterDirectory, strategiesPath, o.Name)
+	if file.Exists(strategyDir) {
+		return nil, fmt.Errorf("%w: %v", errStrategyExists, strategyDir)
+	}
+	td := &templateData{
+		Name:           o.Name,
+		CapitalName:    cases.Title(language.English).String(o.Name),
+		Description:    description,
+		SettingKey:     settingKey,
+		SettingDefault: settingDefault,
+	}
+	tmpl, err := template.ParseGlob(filepath.Join(o.BacktesterDirectory, templatesPath, "*.tmpl"))
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]generatedFile, 0, 6)
+	for _, f := range []struct {
+		template, path string
+		goSource       bool
+	}{
+		{template: "strategy", path: filepath.Join(strategyDir, o.Name+".go"), goSource: true},
+		{template: "test", path: filepath.Join(strategyDir, o.Name+"_test.go"), goSource: true},
+		{template: "readme", path: filepath.Join(strategyDir, "README.md")},
+		{template: "documentation", path: filepath.Join(o.BacktesterDirectory, docTemplatesPath, "backtester_eventhandlers_strategies_"+o.Name+"_readme.tmpl")},
+	} {
+		var contents []byte
+		contents, err = execute(tmpl, f.template, td, f.goSource)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, generatedFile{path: f.path, contents: contents})
+	}
+
+	registrationPath := filepath.Join(o.BacktesterDirectory, strategiesPath, registrationFile)
+	registration, err := os.ReadFile(registrationPath)
+	if err != nil {
+		return nil, err
+	}
+	registration, err = register(registration, o.Name)
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, generatedFile{path: registrationPath, contents: registration, exists: true})
+
+	configPath := filepath.Join(o.BacktesterDirectory, strategyExamplesPath, o.Name+"-api-candles.strat")
+	if file.Exists(configPath) {
+		return nil, fmt.Errorf("%w: %v", errStrategyExists, configPath)
+	}
+	cfg, err := generateConfig(filepath.Join(o.BacktesterDirectory, strategyExamplesPath, ExampleConfig), o.Name)
+	if err != nil {
+		return nil, err
+	}
+	files = append(files, generatedFile{path: configPath, contents: cfg})
+
+	if err = os.MkdirAll(strategyDir, file.DefaultPermissionOctal); err != nil {
+		return nil, err
+	}
+	resp := &Result{}
+	for i := range files {
+		if err = os.WriteFile(files[i].path, files[i].contents, file.DefaultPermissionOctal); err != nil {
+			return nil, err
+		}
+		if files[i].exists {
+			resp.Modified = append(resp.Modified, files[i].path)
+		} else {
+			resp.Created = append(resp.Created, files[i].path)
+		}
+	}
+	return resp, nil
+}
+
+// checkName ensures a strategy name is a valid package name which is not
+// already used by a supported strategy
+func checkName(name string) error {
+	if !strategyNameRegex.MatchString(name) || token.IsKeyword(name) {
+		return fmt.Errorf("%w: '%v'", errInvalidStrategyName, name)
+	}
+	supported := strategies.GetSupportedStrategies()
+	for i := range supported {
+		if strings.EqualFold(supported[i].Name(), name) {
+			return fmt.Errorf("'%v' %w", name, errStrategyExists)
+		}
+	}
+	return nil
+}
+
+// execute renders a template, formatting Go source so the generated code
+// passes gofmt
+func execute(tmpl *template.Template, name string, td *templateData, goSource bool) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, name, td); err != nil {
+		return nil, err
+	}
+	if !goSource {
+		return buf.Bytes(), nil
+	}
+	return format.Source(buf.Bytes())
+}
+
+// register adds a strategy's import and instance to the supported strategies
+// source
+func register(src []byte, name string) ([]byte, error) {
+	s := string(src)
+	baseImport := "\t\"" + strategiesImportPath + "base\"\n"
+	importIndex := strings.Index(s, baseImport)
+	if importIndex == -1 {
+		return nil, fmt.Errorf("%w: strategies import", errRegistrationNotFound)
+	}
+	importIndex += len(baseImport)
+	s = s[:importIndex] + "\t\"" + strategiesImportPath + name + "\"\n" + s[importIndex:]
+
+	holderIndex := strings.Index(s, registrationAnchor)
+	if holderIndex == -1 {
+		return nil, fmt.Errorf("%w: %v", errRegistrationNotFound, registrationAnchor)
+	}
+	closeIndex := strings.Index(s[holderIndex:], "\n\t}")
+	if closeIndex == -1 {
+		return nil, fmt.Errorf("%w: supported strategies closing brace", errRegistrationNotFound)
+	}
+	closeIndex += holderIndex + 1
+	s = s[:closeIndex] + "\t\tnew(" + name + ".Strategy),\n" + s[closeIndex:]
+	return format.Source([]byte(s))
+}
+
+// generateConfig returns the example strategy config set to run the strategy
+// with its default custom settings
+func generateConfig(examplePath, name string) ([]byte, error) {
+	cfg, err := config.ReadStrategyConfigFromFile(examplePath)
+	if err != nil {
+		return nil, err
+	}
+	if cfg == nil {
+		return nil, fmt.Errorf("%w example strategy config", gctcommon.ErrNilPointer)
+	}
+	cfg.Nickname = "Example" + cases.Title(language.English).String(name) + "APICandles"
+	cfg.Goal = "To demonstrate the " + name + " strategy using API candle data and custom settings"
+	cfg.StrategySettings.Name = name
+	cfg.StrategySettings.CustomSettings = map[string]interface{}{
+		settingKey: settingDefault,
+	}
+	return json.MarshalIndent(cfg, "", " ")
+}
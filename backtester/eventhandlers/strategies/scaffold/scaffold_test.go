@@ -0,0 +1,105 @@
+package scaffold
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/backtester/config"
+	gctcommon "github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/file"
+)
+
+// setupBacktesterDirectory copies the files used to generate a strategy into a
+// temporary backtester directory
+func setupBacktesterDirectory(t *testing.T) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "backtester")
+	for _, dst := range []string{templatesPath, strategyExamplesPath, docTemplatesPath} {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, dst), file.DefaultPermissionOctal), "MkdirAll must not error")
+	}
+	templates, err := filepath.Glob("*.tmpl")
+	require.NoError(t, err, "Glob must not error")
+	copies := map[string]string{
+		filepath.Join("..", registrationFile):                                filepath.Join(dir, strategiesPath, registrationFile),
+		filepath.Join("..", "..", "..", strategyExamplesPath, ExampleConfig): filepath.Join(dir, strategyExamplesPath, ExampleConfig),
+	}
+	for i := range templates {
+		copies[templates[i]] = filepath.Join(dir, templatesPath, templates[i])
+	}
+	for src, dst := range copies {
+		contents, err := os.ReadFile(src)
+		require.NoError(t, err, "ReadFile must not error")
+		require.NoError(t, os.WriteFile(dst, contents, file.DefaultPermissionOctal), "WriteFile must not error")
+	}
+	return dir
+}
+
+func TestNewStrategy(t *testing.T) {
+	t.Parallel()
+	_, err := NewStrategy(nil)
+	assert.ErrorIs(t, err, gctcommon.ErrNilPointer, "NewStrategy should error on nil options")
+
+	dir := setupBacktesterDirectory(t)
+	_, err = NewStrategy(&Options{Name: "Bad-Name", BacktesterDirectory: dir})
+	assert.ErrorIs(t, err, errInvalidStrategyName, "NewStrategy should error on an invalid name")
+
+	_, err = NewStrategy(&Options{Name: "rsi", BacktesterDirectory: dir})
+	assert.ErrorIs(t, err, errStrategyExists, "NewStrategy should error on a supported strategy's name")
+
+	_, err = NewStrategy(&Options{Name: "momentum", Description: "`", BacktesterDirectory: dir})
+	assert.ErrorIs(t, err, errInvalidDescription, "NewStrategy should error on a description with backticks")
+
+	resp, err := NewStrategy(&Options{Name: "momentum", Description: "buys momentum", BacktesterDirectory: dir})
+	require.NoError(t, err, "NewStrategy must not error")
+	assert.Len(t, resp.Created, 5, "NewStrategy should create the strategy, test, README, documentation and config files")
+	require.Len(t, resp.Modified, 1, "NewStrategy must modify the strategy registration")
+
+	fset := token.NewFileSet()
+	for _, name := range []string{"momentum.go", "momentum_test.go"} {
+		_, err = parser.ParseFile(fset, filepath.Join(dir, strategiesPath, "momentum", name), nil, parser.AllErrors)
+		assert.NoErrorf(t, err, "%v should be valid Go source", name)
+	}
+	f, err := parser.ParseFile(fset, resp.Modified[0], nil, parser.AllErrors)
+	require.NoError(t, err, "registration must be valid Go source")
+	var imported bool
+	for i := range f.Imports {
+		if f.Imports[i].Path.Value == `"`+strategiesImportPath+`momentum"` {
+			imported = true
+		}
+	}
+	assert.True(t, imported, "registration should import the strategy")
+	registration, err := os.ReadFile(resp.Modified[0])
+	require.NoError(t, err, "ReadFile must not error")
+	assert.Contains(t, string(registration), "\t\tnew(momentum.Strategy),\n\t}", "registration should add the strategy to the supported strategies")
+
+	cfg, err := config.ReadStrategyConfigFromFile(filepath.Join(dir, strategyExamplesPath, "momentum-api-candles.strat"))
+	require.NoError(t, err, "ReadStrategyConfigFromFile must not error")
+	assert.Equal(t, "momentum", cfg.StrategySettings.Name, "config should run the strategy")
+	assert.Equal(t, map[string]interface{}{settingKey: float64(settingDefault)}, cfg.StrategySettings.CustomSettings, "config should set the default custom settings")
+
+	_, err = NewStrategy(&Options{Name: "momentum", BacktesterDirectory: dir})
+	assert.ErrorIs(t, err, errStrategyExists, "NewStrategy should error when the strategy directory exists")
+}
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+	_, err := register([]byte("package strategies\n"), "momentum")
+	assert.ErrorIs(t, err, errRegistrationNotFound, "register should error without the strategies import")
+
+	_, err = register([]byte("package strategies\n\nimport (\n\t\""+strategiesImportPath+"base\"\n)\n"), "momentum")
+	assert.ErrorIs(t, err, errRegistrationNotFound, "register should error without the supported strategies")
+}
+
+func TestCheckName(t *testing.T) {
+	t.Parallel()
+	for _, name := range []string{"", "1strategy", "my-strategy", "MyStrategy", "func"} {
+		assert.ErrorIsf(t, checkName(name), errInvalidStrategyName, "checkName should error on %q", name)
+	}
+	assert.ErrorIs(t, checkName("dollarcostaverage"), errStrategyExists, "checkName should error on a supported strategy")
+	assert.NoError(t, checkName("momentum2"), "checkName should not error on a valid name")
+}
@@ -0,0 +1,58 @@
+package scaffold
+
+import "errors"
+
+const (
+	// ExampleConfig is the strategy config a new strategy's config is
+	// generated from
+	ExampleConfig = "dca-api-candles.strat"
+
+	strategiesPath       = "eventhandlers/strategies"
+	templatesPath        = "eventhandlers/strategies/scaffold"
+	strategyExamplesPath = "config/strategyexamples"
+	docTemplatesPath     = "../cmd/documentation/backtester_templates"
+	registrationFile     = "strategies.go"
+	strategiesImportPath = "github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies/"
+	registrationAnchor   = "supportedStrategies = StrategyHolder{"
+	settingKey           = "example-setting"
+	settingDefault       = 10
+)
+
+var (
+	errInvalidStrategyName  = errors.New("strategy name must start with a lowercase letter and only contain lowercase letters and numbers")
+	errInvalidDescription   = errors.New("strategy description must not contain backticks")
+	errStrategyExists       = errors.New("strategy already exists")
+	errRegistrationNotFound = errors.New("strategy registration not found")
+)
+
+// Options defines the strategy to generate
+type Options struct {
+	// Name is the strategy's name and package name
+	Name        string
+	Description string
+	// BacktesterDirectory is the backtester directory of the repository,
+	// which strategies, configs and documentation are generated into
+	BacktesterDirectory string
+}
+
+// Result lists the files generated or modified
+type Result struct {
+	Created  []string
+	Modified []string
+}
+
+// templateData is passed to each template
+type templateData struct {
+	Name           string
+	CapitalName    string
+	Description    string
+	SettingKey     string
+	SettingDefault int64
+}
+
+// generatedFile is a file to write once every file has been generated
+type generatedFile struct {
+	path     string
+	contents []byte
+	exists   bool
+}
@@ -0,0 +1,283 @@
+package sdk
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/data"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/signal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
+	gctcommon "github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/key"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// Register adds an SDK strategy to the list of supported backtester and live
+// strategies
+func Register(f Factory) error {
+	a, err := NewAdapter(f)
+	if err != nil {
+		return err
+	}
+	return strategies.AddStrategy(a)
+}
+
+// NewAdapter returns an Adapter for a freshly created SDK strategy
+func NewAdapter(f Factory) (*Adapter, error) {
+	if f == nil {
+		return nil, ErrNilFactory
+	}
+	s := f()
+	if s == nil {
+		return nil, ErrNilStrategy
+	}
+	return &Adapter{
+		factory:  f,
+		strategy: s,
+		timers:   make(map[key.ExchangePairAsset]time.Time),
+		handlers: make(map[key.ExchangePairAsset]data.Handler),
+	}, nil
+}
+
+// NewInstance returns a new Adapter with its own strategy instance
+func (a *Adapter) NewInstance() (strategies.Handler, error) {
+	return NewAdapter(a.factory)
+}
+
+// Name returns the name of the underlying strategy
+func (a *Adapter) Name() string {
+	return a.strategy.Name()
+}
+
+// Description returns the description of the underlying strategy
+func (a *Adapter) Description() string {
+	return a.strategy.Description()
+}
+
+// SupportsSimultaneousProcessing SDK strategies are evaluated per exchange
+// asset pair, so simultaneous processing is always supported
+func (a *Adapter) SupportsSimultaneousProcessing() bool {
+	return true
+}
+
+// SetCustomSettings passes custom settings to the underlying strategy
+func (a *Adapter) SetCustomSettings(customSettings map[string]interface{}) error {
+	return a.strategy.SetCustomSettings(customSettings)
+}
+
+// SetDefaults sets the underlying strategy's default settings
+func (a *Adapter) SetDefaults() {
+	a.strategy.SetDefaults()
+}
+
+// OnSignal runs the strategy lifecycle hooks for a single data event
+func (a *Adapter) OnSignal(d data.Handler, _ funding.IFundingTransferer, _ portfolio.Handler) (signal.Event, error) {
+	if d == nil {
+		return nil, common.ErrNilEvent
+	}
+	sig, err := a.GetBaseData(d)
+	if err != nil {
+		return nil, err
+	}
+	ctx, err := newContext(d, &sig)
+	if err != nil {
+		return nil, err
+	}
+	sig.SetPrice(ctx.latest.GetClosePrice())
+	sig.SetDirection(order.DoNothing)
+
+	hasData, err := d.HasDataAtTime(ctx.Time())
+	if err != nil {
+		return nil, err
+	}
+	if !hasData {
+		sig.SetDirection(order.MissingData)
+		sig.AppendReasonf("missing data at %v, cannot perform any actions", ctx.Time())
+		return &sig, nil
+	}
+
+	k := key.ExchangePairAsset{
+		Exchange: ctx.Exchange(),
+		Base:     ctx.Pair().Base.Item,
+		Quote:    ctx.Pair().Quote.Item,
+		Asset:    ctx.Asset(),
+	}
+	a.m.Lock()
+	a.handlers[k] = d
+	a.m.Unlock()
+
+	w := a.strategy.Warmup()
+	if offset := ctx.latest.GetOffset(); offset <= w.Periods {
+		sig.AppendReasonf("warming up %v/%v", offset, w.Periods)
+		return &sig, nil
+	}
+
+	if w.TimerInterval > 0 {
+		if due := a.timerDue(k, ctx.Time(), w.TimerInterval); !due.IsZero() {
+			if err = a.strategy.OnTimer(ctx, due); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err = a.strategy.OnData(ctx); err != nil {
+		return nil, err
+	}
+	return &sig, nil
+}
+
+// OnSimultaneousSignals runs the strategy lifecycle hooks against each data
+// handler in turn
+func (a *Adapter) OnSimultaneousSignals(d []data.Handler, f funding.IFundingTransferer, p portfolio.Handler) ([]signal.Event, error) {
+	if len(d) == 0 {
+		return nil, common.ErrNilEvent
+	}
+	resp := make([]signal.Event, 0, len(d))
+	var errs error
+	for i := range d {
+		sig, err := a.OnSignal(d[i], f, p)
+		if err != nil {
+			errs = gctcommon.AppendError(errs, err)
+			continue
+		}
+		resp = append(resp, sig)
+	}
+	return resp, errs
+}
+
+// OnFill passes fill events to the underlying strategy using the context of
+// the last data event received for the filled exchange asset pair
+func (a *Adapter) OnFill(f fill.Event) error {
+	if f == nil {
+		return common.ErrNilEvent
+	}
+	k := key.ExchangePairAsset{
+		Exchange: f.GetExchange(),
+		Base:     f.Pair().Base.Item,
+		Quote:    f.Pair().Quote.Item,
+		Asset:    f.GetAssetType(),
+	}
+	a.m.Lock()
+	d, ok := a.handlers[k]
+	a.m.Unlock()
+	if !ok {
+		return fmt.Errorf("%v %v %v %w", f.GetExchange(), f.GetAssetType(), f.Pair(), errNoDataForFill)
+	}
+	sig, err := a.GetBaseData(d)
+	if err != nil {
+		return err
+	}
+	ctx, err := newContext(d, &sig)
+	if err != nil {
+		return err
+	}
+	return a.strategy.OnFill(ctx, f)
+}
+
+// timerDue returns the timer boundary which has been crossed since the last
+// timer event, or a zero time if no timer is due
+func (a *Adapter) timerDue(k key.ExchangePairAsset, t time.Time, interval time.Duration) time.Time {
+	a.m.Lock()
+	defer a.m.Unlock()
+	boundary := t.Truncate(interval)
+	if last, ok := a.timers[k]; ok && !boundary.After(last) {
+		return time.Time{}
+	}
+	a.timers[k] = boundary
+	return boundary
+}
+
+func newContext(d data.Handler, sig *signal.Signal) (*strategyContext, error) {
+	latest, err := d.Latest()
+	if err != nil {
+		return nil, err
+	}
+	if latest == nil {
+		return nil, common.ErrNilEvent
+	}
+	ctx := &strategyContext{
+		handler: d,
+		latest:  latest,
+		signal:  sig,
+	}
+	if l, ok := d.(interface{ IsLive() (bool, error) }); ok {
+		ctx.live, err = l.IsLive()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ctx, nil
+}
+
+// Exchange returns the exchange name of the current data event
+func (c *strategyContext) Exchange() string {
+	return c.latest.GetExchange()
+}
+
+// Asset returns the asset type of the current data event
+func (c *strategyContext) Asset() asset.Item {
+	return c.latest.GetAssetType()
+}
+
+// Pair returns the currency pair of the current data event
+func (c *strategyContext) Pair() currency.Pair {
+	return c.latest.Pair()
+}
+
+// Time returns the time of the current data event
+func (c *strategyContext) Time() time.Time {
+	return c.latest.GetTime()
+}
+
+// IsLive returns whether the strategy is being run against live data
+func (c *strategyContext) IsLive() bool {
+	return c.live
+}
+
+// Latest returns the current data event
+func (c *strategyContext) Latest() (data.Event, error) {
+	return c.latest, nil
+}
+
+// History returns all data events up to and including the current event
+func (c *strategyContext) History() (data.Events, error) {
+	return c.handler.History()
+}
+
+// Closes returns the close prices of all data events up to and including the
+// current event
+func (c *strategyContext) Closes() ([]decimal.Decimal, error) {
+	return c.handler.StreamClose()
+}
+
+// Buy signals a buy order. A zero amount leaves sizing to the portfolio
+func (c *strategyContext) Buy(amount decimal.Decimal, reason string) {
+	c.setDirection(order.Buy, amount, reason)
+}
+
+// Sell signals a sell order. A zero amount leaves sizing to the portfolio
+func (c *strategyContext) Sell(amount decimal.Decimal, reason string) {
+	c.setDirection(order.Sell, amount, reason)
+}
+
+// Hold signals that no action should be taken
+func (c *strategyContext) Hold(reason string) {
+	c.setDirection(order.DoNothing, decimal.Zero, reason)
+}
+
+func (c *strategyContext) setDirection(side order.Side, amount decimal.Decimal, reason string) {
+	c.signal.SetDirection(side)
+	if amount.IsPositive() {
+		c.signal.SetAmount(amount)
+	}
+	if reason != "" {
+		c.signal.AppendReason(reason)
+	}
+}
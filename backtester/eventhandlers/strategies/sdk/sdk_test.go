@@ -0,0 +1,195 @@
+package sdk
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/data"
+	"github.com/thrasher-corp/gocryptotrader/backtester/data/kline"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+var errTest = errors.New("test error")
+
+type testStrategy struct {
+	warmup  Warmup
+	onData  int
+	onFill  int
+	onTimer []time.Time
+	err     error
+}
+
+func (t *testStrategy) Name() string                                   { return "sdktest" }
+func (t *testStrategy) Description() string                            { return "sdk test strategy" }
+func (t *testStrategy) Warmup() Warmup                                 { return t.warmup }
+func (t *testStrategy) SetCustomSettings(map[string]interface{}) error { return t.err }
+func (t *testStrategy) SetDefaults()                                   {}
+
+func (t *testStrategy) OnData(ctx Context) error {
+	t.onData++
+	if t.err != nil {
+		return t.err
+	}
+	closes, err := ctx.Closes()
+	if err != nil {
+		return err
+	}
+	if len(closes) > 1 && closes[len(closes)-1].GreaterThan(closes[len(closes)-2]) {
+		ctx.Buy(decimal.NewFromInt(1), "price up")
+		return nil
+	}
+	ctx.Hold("price not up")
+	return nil
+}
+
+func (t *testStrategy) OnFill(Context, fill.Event) error {
+	t.onFill++
+	return nil
+}
+
+func (t *testStrategy) OnTimer(_ Context, tt time.Time) error {
+	t.onTimer = append(t.onTimer, tt)
+	return nil
+}
+
+func newTestData(t *testing.T) *kline.DataFromKline {
+	t.Helper()
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	d := &kline.DataFromKline{
+		Base: &data.Base{},
+		Item: &gctkline.Item{
+			Exchange: "binance",
+			Pair:     p,
+			Asset:    asset.Spot,
+			Interval: gctkline.OneDay,
+		},
+	}
+	for i := range 4 {
+		d.Item.Candles = append(d.Item.Candles, gctkline.Candle{
+			Time:   start.AddDate(0, 0, i),
+			Open:   float64(1337 + i),
+			High:   float64(1337 + i),
+			Low:    float64(1337 + i),
+			Close:  float64(1337 + i),
+			Volume: 1337,
+		})
+	}
+	require.NoError(t, d.Load())
+	var err error
+	d.RangeHolder, err = gctkline.CalculateCandleDateRanges(start, start.AddDate(0, 0, 4), gctkline.OneDay, 100000)
+	require.NoError(t, err)
+	require.NoError(t, d.RangeHolder.SetHasDataFromCandles(d.Item.Candles))
+	return d
+}
+
+func TestNewAdapter(t *testing.T) {
+	t.Parallel()
+	_, err := NewAdapter(nil)
+	assert.ErrorIs(t, err, ErrNilFactory)
+
+	_, err = NewAdapter(func() Strategy { return nil })
+	assert.ErrorIs(t, err, ErrNilStrategy)
+
+	a, err := NewAdapter(func() Strategy { return &testStrategy{} })
+	require.NoError(t, err)
+	assert.Equal(t, "sdktest", a.Name())
+	assert.Equal(t, "sdk test strategy", a.Description())
+	assert.True(t, a.SupportsSimultaneousProcessing())
+
+	h, err := a.NewInstance()
+	require.NoError(t, err)
+	b, ok := h.(*Adapter)
+	require.True(t, ok)
+	assert.NotSame(t, a.strategy, b.strategy, "NewInstance should not share strategy state")
+}
+
+func TestAdapterImplementsInterfaces(t *testing.T) {
+	t.Parallel()
+	var a any = &Adapter{}
+	_, ok := a.(strategies.Handler)
+	assert.True(t, ok, "Adapter should implement strategies.Handler")
+	_, ok = a.(strategies.InstanceCreator)
+	assert.True(t, ok, "Adapter should implement strategies.InstanceCreator")
+	_, ok = a.(strategies.FillHandler)
+	assert.True(t, ok, "Adapter should implement strategies.FillHandler")
+}
+
+func TestOnSignal(t *testing.T) {
+	t.Parallel()
+	s := &testStrategy{warmup: Warmup{Periods: 1, TimerInterval: time.Hour * 48}}
+	a, err := NewAdapter(func() Strategy { return s })
+	require.NoError(t, err)
+
+	_, err = a.OnSignal(nil, nil, nil)
+	assert.ErrorIs(t, err, common.ErrNilEvent)
+
+	d := newTestData(t)
+	_, err = d.Next()
+	require.NoError(t, err)
+	sig, err := a.OnSignal(d, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, order.DoNothing, sig.GetDirection())
+	assert.Zero(t, s.onData, "OnData should not be called during warmup")
+
+	_, err = d.Next()
+	require.NoError(t, err)
+	sig, err = a.OnSignal(d, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, order.Buy, sig.GetDirection())
+	assert.Equal(t, decimal.NewFromInt(1), sig.GetAmount())
+	assert.Equal(t, 1, s.onData)
+	require.Len(t, s.onTimer, 1)
+
+	_, err = d.Next()
+	require.NoError(t, err)
+	_, err = a.OnSignal(d, nil, nil)
+	require.NoError(t, err)
+	assert.Len(t, s.onTimer, 2, "OnTimer should be called once the interval boundary is crossed")
+
+	_, err = a.OnSimultaneousSignals(nil, nil, nil)
+	assert.ErrorIs(t, err, common.ErrNilEvent)
+
+	s.err = errTest
+	_, err = a.OnSimultaneousSignals([]data.Handler{d}, nil, nil)
+	assert.ErrorIs(t, err, errTest)
+}
+
+func TestOnFill(t *testing.T) {
+	t.Parallel()
+	s := &testStrategy{}
+	a, err := NewAdapter(func() Strategy { return s })
+	require.NoError(t, err)
+
+	err = a.OnFill(nil)
+	assert.ErrorIs(t, err, common.ErrNilEvent)
+
+	f := &fill.Fill{Base: &event.Base{
+		Exchange:     "binance",
+		CurrencyPair: currency.NewPair(currency.BTC, currency.USDT),
+		AssetType:    asset.Spot,
+	}}
+	err = a.OnFill(f)
+	assert.ErrorIs(t, err, errNoDataForFill)
+
+	d := newTestData(t)
+	_, err = d.Next()
+	require.NoError(t, err)
+	_, err = a.OnSignal(d, nil, nil)
+	require.NoError(t, err)
+
+	err = a.OnFill(f)
+	require.NoError(t, err)
+	assert.Equal(t, 1, s.onFill)
+}
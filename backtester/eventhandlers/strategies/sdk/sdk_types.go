@@ -0,0 +1,97 @@
+package sdk
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/backtester/data"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies/base"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/signal"
+	"github.com/thrasher-corp/gocryptotrader/common/key"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+var (
+	// ErrNilStrategy is returned when an adapter is created without a strategy
+	ErrNilStrategy = errors.New("nil sdk strategy")
+	// ErrNilFactory is returned when a strategy is registered without a factory
+	ErrNilFactory = errors.New("nil sdk strategy factory")
+
+	errNoDataForFill = errors.New("no data received prior to fill")
+)
+
+// Strategy defines the lifecycle hooks an SDK strategy implements. The same
+// implementation is run by both the backtester and the live strategy runner as
+// each is driven through the Adapter
+type Strategy interface {
+	Name() string
+	Description() string
+	// Warmup returns the data requirements which must be met before OnData
+	// is called
+	Warmup() Warmup
+	// OnData is called for every new data event once the warmup period has
+	// been satisfied
+	OnData(Context) error
+	// OnFill is called whenever an order raised by the strategy is filled
+	OnFill(Context, fill.Event) error
+	// OnTimer is called on each Warmup.TimerInterval boundary as measured
+	// by data event time, before OnData is called
+	OnTimer(Context, time.Time) error
+	// SetCustomSettings applies strategy specific settings from config
+	SetCustomSettings(map[string]interface{}) error
+	// SetDefaults sets strategy specific settings to their default values
+	SetDefaults()
+}
+
+// Warmup defines the data requirements of a strategy before it starts
+// receiving OnData calls
+type Warmup struct {
+	// Periods is the number of data events required before OnData is called
+	Periods int64
+	// TimerInterval is the frequency OnTimer is called. Zero disables timers
+	TimerInterval time.Duration
+}
+
+// Context provides a strategy with identical data and order APIs regardless
+// of whether it is being backtested or run live
+type Context interface {
+	Exchange() string
+	Asset() asset.Item
+	Pair() currency.Pair
+	Time() time.Time
+	IsLive() bool
+	Latest() (data.Event, error)
+	History() (data.Events, error)
+	Closes() ([]decimal.Decimal, error)
+
+	Buy(amount decimal.Decimal, reason string)
+	Sell(amount decimal.Decimal, reason string)
+	Hold(reason string)
+}
+
+// Factory returns a fresh instance of an SDK strategy so that tasks never share
+// strategy state
+type Factory func() Strategy
+
+// Adapter wraps an SDK Strategy so that it satisfies the backtester's
+// strategies.Handler interface
+type Adapter struct {
+	base.Strategy
+	factory  Factory
+	strategy Strategy
+	m        sync.Mutex
+	timers   map[key.ExchangePairAsset]time.Time
+	handlers map[key.ExchangePairAsset]data.Handler
+}
+
+// strategyContext implements Context for a single exchange asset pair
+type strategyContext struct {
+	handler data.Handler
+	latest  data.Event
+	signal  *signal.Signal
+	live    bool
+}
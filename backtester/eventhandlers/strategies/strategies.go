@@ -36,6 +36,17 @@ func createNewStrategy(name string, useSimultaneousProcessing bool, h Handler) (
 	if !strings.EqualFold(name, h.Name()) {
 		return nil, nil
 	}
+	if c, ok := h.(InstanceCreator); ok {
+		strategy, err := c.NewInstance()
+		if err != nil {
+			return nil, fmt.Errorf("cannot load %v %w", name, err)
+		}
+		if useSimultaneousProcessing && !strategy.SupportsSimultaneousProcessing() {
+			return nil, base.ErrSimultaneousProcessingNotSupported
+		}
+		strategy.SetSimultaneousProcessing(useSimultaneousProcessing)
+		return strategy, nil
+	}
 	// create new instance so strategy is not shared across all tasks
 	strategyValue := reflect.ValueOf(h)
 	if strategyValue.IsNil() {
@@ -6,6 +6,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/backtester/data"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio/holdings"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/signal"
 	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
 )
@@ -29,3 +30,15 @@ type Handler interface {
 	SetDefaults()
 	CloseAllPositions([]holdings.Holding, []data.Event) ([]signal.Event, error)
 }
+
+// InstanceCreator is implemented by strategies which cannot be instantiated
+// via reflection, such as those wrapping an SDK strategy
+type InstanceCreator interface {
+	NewInstance() (Handler, error)
+}
+
+// FillHandler is implemented by strategies which want to be notified when
+// an order is filled
+type FillHandler interface {
+	OnFill(fill.Event) error
+}
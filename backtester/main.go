@@ -12,6 +12,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/backtester/common"
 	"github.com/thrasher-corp/gocryptotrader/backtester/config"
 	backtest "github.com/thrasher-corp/gocryptotrader/backtester/engine"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies/scaffold"
 	"github.com/thrasher-corp/gocryptotrader/backtester/plugins/strategies"
 	"github.com/thrasher-corp/gocryptotrader/common/convert"
 	"github.com/thrasher-corp/gocryptotrader/common/file"
@@ -20,6 +21,10 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/signaler"
 )
 
+// newStrategyCommand generates a strategy skeleton instead of running the
+// backtester
+const newStrategyCommand = "new-strategy"
+
 var singleTaskStrategyPath, templatePath, outputPath, btConfigDir, strategyPluginPath, pprofURL string
 var printLogo, generateReport, darkReport, colourOutput, logSubHeader, enablePProf bool
 
@@ -30,6 +35,15 @@ func main() {
 		os.Exit(1)
 	}
 
+	if len(os.Args) > 1 && os.Args[1] == newStrategyCommand {
+		err = newStrategy(wd, os.Args[2:])
+		if err != nil {
+			fmt.Printf("Could not generate strategy. Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	flags := parseFlags(wd)
 	var btCfg *config.BacktesterConfig
 	if btConfigDir == "" {
@@ -345,3 +359,33 @@ func parseFlags(wd string) map[string]bool {
 	flag.Visit(func(f *flag.Flag) { flags[f.Name] = true })
 	return flags
 }
+
+// newStrategy parses the new-strategy command's flags and generates a strategy
+// skeleton, registration, config and unit tests
+func newStrategy(wd string, args []string) error {
+	var o scaffold.Options
+	fs := flag.NewFlagSet(newStrategyCommand, flag.ExitOnError)
+	fs.StringVar(&o.Name, "name", "", "the strategy name, used as its package name. Must be lowercase letters and numbers")
+	fs.StringVar(&o.Description, "description", "", "a description of the strategy")
+	fs.StringVar(&o.BacktesterDirectory, "backtesterpath", wd, "the path of the backtester directory to generate the strategy in")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if o.Name == "" && fs.NArg() > 0 {
+		o.Name = fs.Arg(0)
+	}
+	resp, err := scaffold.NewStrategy(&o)
+	if err != nil {
+		return err
+	}
+	for i := range resp.Created {
+		fmt.Printf("Created %v\n", resp.Created[i])
+	}
+	for i := range resp.Modified {
+		fmt.Printf("Modified %v\n", resp.Modified[i])
+	}
+	fmt.Printf("Strategy '%v' generated. Implement its logic in OnSignal, then run it with -singlerunstrategypath=%v\n",
+		o.Name,
+		filepath.Join(o.BacktesterDirectory, "config", "strategyexamples", o.Name+"-api-candles.strat"))
+	return nil
+}
@@ -3,17 +3,24 @@ package strategies
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 	"plugin"
+	"strings"
 
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies"
+	"github.com/thrasher-corp/gocryptotrader/backtester/plugins/strategies/wasm"
 	gctcommon "github.com/thrasher-corp/gocryptotrader/common"
 )
 
 var errNoStrategies = errors.New("no strategies contained in plugin. please refer to docs")
 
 // LoadCustomStrategies utilises Go's plugin system to load
-// custom strategies into the backtester.
+// custom strategies into the backtester. Files with a .wasm extension
+// are loaded as sandboxed WASM strategies
 func LoadCustomStrategies(strategyPluginPath string) error {
+	if strings.EqualFold(filepath.Ext(strategyPluginPath), ".wasm") {
+		return wasm.Register(strategyPluginPath, wasm.Config{})
+	}
 	p, err := plugin.Open(strategyPluginPath)
 	if err != nil {
 		return fmt.Errorf("could not open plugin: %w", err)
@@ -2,8 +2,12 @@ package strategies
 
 import (
 	"errors"
+	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	"github.com/thrasher-corp/gocryptotrader/backtester/data"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies"
@@ -63,3 +67,11 @@ func (s *CustomStrategy) SetCustomSettings(map[string]interface{}) error {
 }
 
 func (s *CustomStrategy) SetDefaults() {}
+
+func TestLoadCustomStrategiesWASM(t *testing.T) {
+	t.Parallel()
+	err := LoadCustomStrategies(filepath.Join("wasm", "testdata", "buy.wasm"))
+	require.NoError(t, err)
+	_, err = strategies.LoadStrategyByName("buy", false)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,18 @@
+;; buy.wasm is a minimal strategy used by tests. It signals a buy of 0.5 on
+;; every data event and stores the latest data payload under state key "k"
+(module
+  (import "gct" "signal" (func $signal (param i32 f64 i32 i32)))
+  (import "gct" "state_set" (func $state_set (param i32 i32 i32 i32) (result i32)))
+  (memory (export "memory") 1)
+  (data (i32.const 0) "k")
+  (data (i32.const 16) "buy")
+  (func (export "gct_alloc") (param i32) (result i32)
+    i32.const 1024)
+  (func (export "gct_on_data") (param i32 i32) (result i32)
+    (call $signal (i32.const 1) (f64.const 0.5) (i32.const 16) (i32.const 3))
+    (drop (call $state_set (i32.const 0) (i32.const 1) (local.get 0) (local.get 1)))
+    i32.const 0)
+  (func (export "gct_warmup") (result i64)
+    i64.const 1)
+  (func (export "gct_on_timer") (param i64) (result i32)
+    i32.const 7))
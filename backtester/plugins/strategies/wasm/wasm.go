@@ -0,0 +1,412 @@
+package wasm
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies/sdk"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/fill"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// Load reads and validates a WASM strategy from disk
+func Load(path string, cfg Config) (*Plugin, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Name == "" {
+		cfg.Name = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	}
+	return New(b, cfg)
+}
+
+// New validates a WASM strategy binary and returns a Plugin which can create
+// sandboxed strategy instances
+func New(binary []byte, cfg Config) (*Plugin, error) {
+	if cfg.Name == "" {
+		return nil, errEmptyName
+	}
+	if cfg.MemoryLimitPages == 0 {
+		cfg.MemoryLimitPages = DefaultMemoryLimitPages
+	}
+	if cfg.CallTimeout <= 0 {
+		cfg.CallTimeout = DefaultCallTimeout
+	}
+	if cfg.MaxStateKeys <= 0 {
+		cfg.MaxStateKeys = DefaultMaxStateKeys
+	}
+	if cfg.MaxStateValueSize <= 0 {
+		cfg.MaxStateValueSize = DefaultMaxStateValueSize
+	}
+	p := &Plugin{
+		cfg:    cfg,
+		binary: binary,
+		cache:  wazero.NewCompilationCache(),
+	}
+	// instantiate once to surface compile and export errors at load time
+	s := p.newInstance()
+	if s.err != nil {
+		return nil, s.err
+	}
+	return p, s.Close()
+}
+
+// Register loads a WASM strategy from disk and registers it as a strategy
+func Register(path string, cfg Config) error {
+	p, err := Load(path, cfg)
+	if err != nil {
+		return err
+	}
+	return sdk.Register(p.NewStrategy)
+}
+
+// Name returns the name of the plugin's strategy
+func (p *Plugin) Name() string {
+	return p.cfg.Name
+}
+
+// NewStrategy returns a new sandboxed strategy instance. It satisfies
+// sdk.Factory
+func (p *Plugin) NewStrategy() sdk.Strategy {
+	return p.newInstance()
+}
+
+func (p *Plugin) newInstance() *Strategy {
+	s := &Strategy{
+		plugin: p,
+		state:  make(map[string][]byte),
+	}
+	s.err = s.instantiate()
+	return s
+}
+
+func (s *Strategy) instantiate() error {
+	ctx := context.Background()
+	s.runtime = wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithMemoryLimitPages(s.plugin.cfg.MemoryLimitPages).
+		WithCloseOnContextDone(true).
+		WithCompilationCache(s.plugin.cache))
+	_, err := s.runtime.NewHostModuleBuilder(hostModule).
+		NewFunctionBuilder().WithFunc(s.hostSignal).Export("signal").
+		NewFunctionBuilder().WithFunc(s.hostCloses).Export("closes").
+		NewFunctionBuilder().WithFunc(s.hostStateGet).Export("state_get").
+		NewFunctionBuilder().WithFunc(s.hostStateSet).Export("state_set").
+		NewFunctionBuilder().WithFunc(s.hostLog).Export("log").
+		Instantiate(ctx)
+	if err != nil {
+		return err
+	}
+	compiled, err := s.runtime.CompileModule(ctx, s.plugin.binary)
+	if err != nil {
+		return fmt.Errorf("%v %w", s.plugin.cfg.Name, err)
+	}
+	exports := compiled.ExportedFunctions()
+	for _, name := range []string{exportAlloc, exportOnData} {
+		if _, ok := exports[name]; !ok {
+			return fmt.Errorf("%v %w %q", s.plugin.cfg.Name, errMissingExport, name)
+		}
+	}
+	s.module, err = s.runtime.InstantiateModule(ctx, compiled, wazero.NewModuleConfig().WithName(""))
+	if err != nil {
+		return fmt.Errorf("%v %w", s.plugin.cfg.Name, err)
+	}
+	if s.module.Memory() == nil {
+		return fmt.Errorf("%v %w %q", s.plugin.cfg.Name, errMissingExport, "memory")
+	}
+	return nil
+}
+
+// Close releases the resources held by the strategy instance
+func (s *Strategy) Close() error {
+	if s.runtime == nil {
+		return nil
+	}
+	return s.runtime.Close(context.Background())
+}
+
+// Name returns the strategy name
+func (s *Strategy) Name() string {
+	return s.plugin.cfg.Name
+}
+
+// Description returns the strategy description
+func (s *Strategy) Description() string {
+	return "sandboxed WASM strategy " + s.plugin.cfg.Name
+}
+
+// Warmup returns the guest's data requirements via its optional warmup
+// exports
+func (s *Strategy) Warmup() sdk.Warmup {
+	var w sdk.Warmup
+	if s.err != nil {
+		return w
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	if periods, err := s.callI64(exportWarmup); err == nil {
+		w.Periods = periods
+	}
+	if seconds, err := s.callI64(exportTimerInterval); err == nil {
+		w.TimerInterval = time.Duration(seconds) * time.Second
+	}
+	return w
+}
+
+// OnData passes the latest data event to the guest
+func (s *Strategy) OnData(ctx sdk.Context) error {
+	if s.err != nil {
+		return s.err
+	}
+	latest, err := ctx.Latest()
+	if err != nil {
+		return err
+	}
+	if latest == nil {
+		return common.ErrNilEvent
+	}
+	return s.callWithPayload(ctx, exportOnData, &dataPayload{
+		Exchange: latest.GetExchange(),
+		Asset:    latest.GetAssetType().String(),
+		Pair:     latest.Pair().String(),
+		Time:     latest.GetTime().UnixMilli(),
+		Open:     latest.GetOpenPrice().InexactFloat64(),
+		High:     latest.GetHighPrice().InexactFloat64(),
+		Low:      latest.GetLowPrice().InexactFloat64(),
+		Close:    latest.GetClosePrice().InexactFloat64(),
+		Volume:   latest.GetVolume().InexactFloat64(),
+		Live:     ctx.IsLive(),
+	})
+}
+
+// OnFill passes fill events to the guest if it exports an on fill handler
+func (s *Strategy) OnFill(ctx sdk.Context, f fill.Event) error {
+	if s.err != nil {
+		return s.err
+	}
+	if f == nil {
+		return common.ErrNilEvent
+	}
+	return s.callWithPayload(ctx, exportOnFill, &fillPayload{
+		Exchange: f.GetExchange(),
+		Asset:    f.GetAssetType().String(),
+		Pair:     f.Pair().String(),
+		Time:     f.GetTime().UnixMilli(),
+		Side:     f.GetDirection().String(),
+		Amount:   f.GetAmount().InexactFloat64(),
+		Price:    f.GetPurchasePrice().InexactFloat64(),
+		Fee:      f.GetExchangeFee().InexactFloat64(),
+	})
+}
+
+// OnTimer notifies the guest of a timer event if it exports a timer handler
+func (s *Strategy) OnTimer(ctx sdk.Context, t time.Time) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.current = ctx
+	defer func() { s.current = nil }()
+	return s.call(exportOnTimer, uint64(t.Unix()))
+}
+
+// SetCustomSettings stores custom settings as JSON in the state bag under the
+// "settings" key for the guest to read
+func (s *Strategy) SetCustomSettings(customSettings map[string]interface{}) error {
+	b, err := json.Marshal(customSettings)
+	if err != nil {
+		return err
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.state["settings"] = b
+	return nil
+}
+
+// SetDefaults clears any stored custom settings
+func (s *Strategy) SetDefaults() {
+	s.m.Lock()
+	defer s.m.Unlock()
+	delete(s.state, "settings")
+}
+
+// callWithPayload writes the JSON payload into guest memory and calls the
+// named export with its location. Missing optional exports are ignored
+func (s *Strategy) callWithPayload(ctx sdk.Context, name string, payload any) error {
+	if s.module.ExportedFunction(name) == nil {
+		return nil
+	}
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.current = ctx
+	defer func() { s.current = nil }()
+
+	cctx, cancel := s.callContext()
+	defer cancel()
+	res, err := s.module.ExportedFunction(exportAlloc).Call(cctx, uint64(len(b)))
+	if err != nil {
+		return fmt.Errorf("%v %v %w", s.plugin.cfg.Name, exportAlloc, err)
+	}
+	if len(res) == 0 {
+		return fmt.Errorf("%v %v %w", s.plugin.cfg.Name, exportAlloc, errMemoryAccess)
+	}
+	ptr := uint32(res[0])
+	if !s.module.Memory().Write(ptr, b) {
+		return fmt.Errorf("%v %w", s.plugin.cfg.Name, errMemoryAccess)
+	}
+	return s.call(name, uint64(ptr), uint64(len(b)))
+}
+
+// call invokes an optional guest export which returns a non-zero value on
+// error. The caller must hold the lock
+func (s *Strategy) call(name string, params ...uint64) error {
+	fn := s.module.ExportedFunction(name)
+	if fn == nil {
+		return nil
+	}
+	cctx, cancel := s.callContext()
+	defer cancel()
+	res, err := fn.Call(cctx, params...)
+	if err != nil {
+		return fmt.Errorf("%v %v %w", s.plugin.cfg.Name, name, err)
+	}
+	if len(res) > 0 && int32(res[0]) != 0 {
+		return fmt.Errorf("%v %v %w %d", s.plugin.cfg.Name, name, errGuestReturnedError, int32(res[0]))
+	}
+	return nil
+}
+
+// callI64 invokes a guest export returning a single int64. The caller must
+// hold the lock
+func (s *Strategy) callI64(name string) (int64, error) {
+	fn := s.module.ExportedFunction(name)
+	if fn == nil {
+		return 0, fmt.Errorf("%v %w %q", s.plugin.cfg.Name, errMissingExport, name)
+	}
+	cctx, cancel := s.callContext()
+	defer cancel()
+	res, err := fn.Call(cctx)
+	if err != nil {
+		return 0, err
+	}
+	if len(res) == 0 {
+		return 0, fmt.Errorf("%v %v %w", s.plugin.cfg.Name, name, errGuestReturnedError)
+	}
+	return int64(res[0]), nil
+}
+
+// hostSignal allows the guest to signal an order. Side is 0 hold, 1 buy and
+// 2 sell. A zero amount leaves sizing to the portfolio
+func (s *Strategy) hostSignal(_ context.Context, m api.Module, side uint32, amount float64, reasonPtr, reasonLen uint32) {
+	if s.current == nil {
+		return
+	}
+	reason := string(read(m, reasonPtr, reasonLen))
+	amt := decimal.Zero
+	if !math.IsNaN(amount) && !math.IsInf(amount, 0) && amount > 0 {
+		amt = decimal.NewFromFloat(amount)
+	}
+	switch side {
+	case sideBuy:
+		s.current.Buy(amt, reason)
+	case sideSell:
+		s.current.Sell(amt, reason)
+	case sideHold:
+		s.current.Hold(reason)
+	}
+}
+
+// hostCloses writes up to capacity little endian float64 close prices into
+// guest memory, most recent last, and returns the number written
+func (s *Strategy) hostCloses(_ context.Context, m api.Module, ptr, capacity uint32) uint32 {
+	if s.current == nil {
+		return 0
+	}
+	closes, err := s.current.Closes()
+	if err != nil {
+		return 0
+	}
+	if uint32(len(closes)) > capacity {
+		closes = closes[uint32(len(closes))-capacity:]
+	}
+	b := make([]byte, len(closes)*8)
+	for i := range closes {
+		binary.LittleEndian.PutUint64(b[i*8:], math.Float64bits(closes[i].InexactFloat64()))
+	}
+	if !m.Memory().Write(ptr, b) {
+		panic(errMemoryAccess)
+	}
+	return uint32(len(closes))
+}
+
+// hostStateGet copies a stored value into guest memory and returns its length.
+// -1 is returned when the key does not exist. If the value is larger than
+// capacity nothing is copied and the required length is returned
+func (s *Strategy) hostStateGet(_ context.Context, m api.Module, keyPtr, keyLen, valPtr, valCap uint32) int32 {
+	v, ok := s.state[string(read(m, keyPtr, keyLen))]
+	if !ok {
+		return -1
+	}
+	if uint32(len(v)) <= valCap && !m.Memory().Write(valPtr, v) {
+		panic(errMemoryAccess)
+	}
+	return int32(len(v))
+}
+
+// hostStateSet stores a value in the instance's state bag, returning -1 when
+// configured limits are exceeded
+func (s *Strategy) hostStateSet(_ context.Context, m api.Module, keyPtr, keyLen, valPtr, valLen uint32) int32 {
+	k := string(read(m, keyPtr, keyLen))
+	if int(valLen) > s.plugin.cfg.MaxStateValueSize {
+		return -1
+	}
+	if _, ok := s.state[k]; !ok && len(s.state) >= s.plugin.cfg.MaxStateKeys {
+		return -1
+	}
+	v := read(m, valPtr, valLen)
+	s.state[k] = append([]byte(nil), v...)
+	return 0
+}
+
+// hostLog writes a guest message to the strategy logger
+func (s *Strategy) hostLog(_ context.Context, m api.Module, ptr, length uint32) {
+	log.Infof(common.Strategy, "%v: %s", s.plugin.cfg.Name, read(m, ptr, length))
+}
+
+// State returns a copy of the value stored in the state bag under key
+func (s *Strategy) State(key string) ([]byte, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	v, ok := s.state[key]
+	if !ok {
+		return nil, fmt.Errorf("%v %w", key, errStateNotFound)
+	}
+	return append([]byte(nil), v...), nil
+}
+
+func read(m api.Module, ptr, length uint32) []byte {
+	if length == 0 {
+		return nil
+	}
+	b, ok := m.Memory().Read(ptr, length)
+	if !ok {
+		panic(errMemoryAccess)
+	}
+	return b
+}
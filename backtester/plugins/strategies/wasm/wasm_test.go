@@ -0,0 +1,130 @@
+package wasm
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/data"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/event"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/kline"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+)
+
+var testPath = filepath.Join("testdata", "buy.wasm")
+
+type testContext struct {
+	latest data.Event
+	side   string
+	amount decimal.Decimal
+	reason string
+}
+
+func (c *testContext) Exchange() string              { return c.latest.GetExchange() }
+func (c *testContext) Asset() asset.Item             { return c.latest.GetAssetType() }
+func (c *testContext) Pair() currency.Pair           { return c.latest.Pair() }
+func (c *testContext) Time() time.Time               { return c.latest.GetTime() }
+func (c *testContext) IsLive() bool                  { return false }
+func (c *testContext) Latest() (data.Event, error)   { return c.latest, nil }
+func (c *testContext) History() (data.Events, error) { return data.Events{c.latest}, nil }
+func (c *testContext) Closes() ([]decimal.Decimal, error) {
+	return []decimal.Decimal{c.latest.GetClosePrice()}, nil
+}
+func (c *testContext) Hold(reason string) { c.side, c.reason = "hold", reason }
+
+func (c *testContext) Buy(amount decimal.Decimal, reason string) {
+	c.side, c.amount, c.reason = "buy", amount, reason
+}
+
+func (c *testContext) Sell(amount decimal.Decimal, reason string) {
+	c.side, c.amount, c.reason = "sell", amount, reason
+}
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+	_, err := Load(filepath.Join("testdata", "missing.wasm"), Config{})
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	p, err := Load(testPath, Config{})
+	require.NoError(t, err)
+	assert.Equal(t, "buy", p.Name())
+	assert.Equal(t, uint32(DefaultMemoryLimitPages), p.cfg.MemoryLimitPages)
+	assert.Equal(t, DefaultCallTimeout, p.cfg.CallTimeout)
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+	_, err := New(nil, Config{})
+	assert.ErrorIs(t, err, errEmptyName)
+
+	_, err = New([]byte("not wasm"), Config{Name: "bad"})
+	assert.Error(t, err)
+
+	// smallest valid module, exports nothing
+	_, err = New([]byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00}, Config{Name: "empty"})
+	assert.ErrorIs(t, err, errMissingExport)
+}
+
+func TestStrategy(t *testing.T) {
+	t.Parallel()
+	b, err := os.ReadFile(testPath)
+	require.NoError(t, err)
+	p, err := New(b, Config{Name: "test"})
+	require.NoError(t, err)
+
+	s, ok := p.NewStrategy().(*Strategy)
+	require.True(t, ok)
+	require.NoError(t, s.err)
+	defer func() { assert.NoError(t, s.Close()) }()
+
+	assert.Equal(t, "test", s.Name())
+	assert.NotEmpty(t, s.Description())
+
+	w := s.Warmup()
+	assert.Equal(t, int64(1), w.Periods)
+	assert.Zero(t, w.TimerInterval, "guest does not export a timer interval")
+
+	ctx := &testContext{latest: &kline.Kline{
+		Base: &event.Base{
+			Exchange:     "binance",
+			Time:         time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			Interval:     gctkline.OneDay,
+			CurrencyPair: currency.NewPair(currency.BTC, currency.USDT),
+			AssetType:    asset.Spot,
+		},
+		Close: decimal.NewFromInt(1337),
+	}}
+	require.NoError(t, s.OnData(ctx))
+	assert.Equal(t, "buy", ctx.side)
+	assert.Equal(t, "buy", ctx.reason)
+	assert.True(t, ctx.amount.Equal(decimal.NewFromFloat(0.5)))
+
+	stored, err := s.State("k")
+	require.NoError(t, err)
+	var payload dataPayload
+	require.NoError(t, json.Unmarshal(stored, &payload))
+	assert.Equal(t, "binance", payload.Exchange)
+	assert.Equal(t, 1337.0, payload.Close)
+
+	err = s.OnTimer(ctx, time.Now())
+	assert.ErrorIs(t, err, errGuestReturnedError)
+
+	err = s.OnFill(ctx, nil)
+	assert.ErrorIs(t, err, common.ErrNilEvent)
+
+	require.NoError(t, s.SetCustomSettings(map[string]any{"period": 14}))
+	stored, err = s.State("settings")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"period":14}`, string(stored))
+	s.SetDefaults()
+	_, err = s.State("settings")
+	assert.ErrorIs(t, err, errStateNotFound)
+}
@@ -0,0 +1,112 @@
+package wasm
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/strategies/sdk"
+)
+
+const (
+	// hostModule is the import module name guests use to access the host API
+	hostModule = "gct"
+
+	exportAlloc         = "gct_alloc"
+	exportOnData        = "gct_on_data"
+	exportOnFill        = "gct_on_fill"
+	exportOnTimer       = "gct_on_timer"
+	exportWarmup        = "gct_warmup"
+	exportTimerInterval = "gct_timer_interval"
+
+	// DefaultMemoryLimitPages limits guest memory to 16MiB
+	DefaultMemoryLimitPages = 256
+	// DefaultCallTimeout is the maximum time a single guest call may run
+	DefaultCallTimeout = time.Second
+	// DefaultMaxStateKeys is the maximum number of keys a guest may store
+	DefaultMaxStateKeys = 1024
+	// DefaultMaxStateValueSize is the maximum size of a single stored value
+	DefaultMaxStateValueSize = 64 * 1024
+
+	sideHold = 0
+	sideBuy  = 1
+	sideSell = 2
+)
+
+var (
+	errMissingExport      = errors.New("wasm module missing required export")
+	errGuestReturnedError = errors.New("wasm guest returned error code")
+	errMemoryAccess       = errors.New("wasm guest memory access out of range")
+	errStateNotFound      = errors.New("wasm strategy state key not found")
+	errEmptyName          = errors.New("wasm strategy name cannot be empty")
+)
+
+// Config defines the constraints applied to a loaded WASM strategy
+type Config struct {
+	// Name is the strategy name. Defaults to the file name without extension
+	Name string
+	// MemoryLimitPages is the maximum number of 64KiB pages a guest can use
+	MemoryLimitPages uint32
+	// CallTimeout is the maximum duration of any single guest call
+	CallTimeout time.Duration
+	// MaxStateKeys is the maximum number of keys stored in the state bag
+	MaxStateKeys int
+	// MaxStateValueSize is the maximum byte size of any stored value
+	MaxStateValueSize int
+}
+
+// Plugin holds a compiled WASM strategy which can be instantiated as many
+// times as there are tasks using it
+type Plugin struct {
+	cfg    Config
+	binary []byte
+	cache  wazero.CompilationCache
+}
+
+// Strategy is a single sandboxed instance of a WASM strategy. It implements
+// sdk.Strategy so it runs unchanged under both backtesting and live data
+type Strategy struct {
+	plugin  *Plugin
+	runtime wazero.Runtime
+	module  api.Module
+	err     error
+
+	// m protects current and state. Guest calls are serialised
+	m       sync.Mutex
+	current sdk.Context
+	state   map[string][]byte
+}
+
+// dataPayload is the JSON document passed to the guest on each data event
+type dataPayload struct {
+	Exchange string  `json:"exchange"`
+	Asset    string  `json:"asset"`
+	Pair     string  `json:"pair"`
+	Time     int64   `json:"time"`
+	Open     float64 `json:"open"`
+	High     float64 `json:"high"`
+	Low      float64 `json:"low"`
+	Close    float64 `json:"close"`
+	Volume   float64 `json:"volume"`
+	Live     bool    `json:"live"`
+}
+
+// fillPayload is the JSON document passed to the guest on each fill event
+type fillPayload struct {
+	Exchange string  `json:"exchange"`
+	Asset    string  `json:"asset"`
+	Pair     string  `json:"pair"`
+	Time     int64   `json:"time"`
+	Side     string  `json:"side"`
+	Amount   float64 `json:"amount"`
+	Price    float64 `json:"price"`
+	Fee      float64 `json:"fee"`
+}
+
+// callContext returns a context bounded by the configured call timeout
+func (s *Strategy) callContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), s.plugin.cfg.CallTimeout)
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"log"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/thrasher-corp/gocryptotrader/common/file"
 	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/config/versions"
 )
 
 // EncryptOrDecrypt returns a string from a boolean
@@ -20,16 +22,25 @@ func EncryptOrDecrypt(encrypt bool) string {
 
 func main() {
 	var inFile, outFile, key string
-	var encrypt bool
+	var encrypt, upgrade, dryrun bool
 	defaultCfgFile := config.DefaultFilePath()
 	flag.StringVar(&inFile, "infile", defaultCfgFile, "The config input file to process.")
 	flag.StringVar(&outFile, "outfile", defaultCfgFile+".out", "The config output file.")
 	flag.BoolVar(&encrypt, "encrypt", true, "Whether to encrypt or decrypt.")
 	flag.StringVar(&key, "key", "", "The key to use for AES encryption.")
+	flag.BoolVar(&upgrade, "upgrade", false, "Upgrade the config to the latest version instead of encrypting or decrypting.")
+	flag.BoolVar(&dryrun, "dryrun", false, "Print the changes an upgrade would make without writing the output file.")
 	flag.Parse()
 
 	log.Println("GoCryptoTrader: config-helper tool.")
 
+	if upgrade {
+		if err := upgradeConfig(inFile, outFile, key, dryrun); err != nil {
+			log.Fatalf("Unable to upgrade config %s. Error: %s.", inFile, err)
+		}
+		return
+	}
+
 	if key == "" {
 		result, err := config.PromptForConfigKey(false)
 		if err != nil {
@@ -80,3 +91,54 @@ func main() {
 		EncryptOrDecrypt(encrypt), inFile, outFile,
 	)
 }
+
+// upgradeConfig upgrades a config file to the latest config version, printing
+// the changes made. The output file is not written when dryrun is set
+func upgradeConfig(inFile, outFile, key string, dryrun bool) error {
+	data, err := os.ReadFile(inFile)
+	if err != nil {
+		return err
+	}
+	encrypted := config.ConfirmECS(data)
+	if encrypted {
+		if key == "" {
+			result, err := config.PromptForConfigKey(false)
+			if err != nil {
+				return err
+			}
+			key = string(result)
+		}
+		if data, err = config.DecryptConfigFile(data, []byte(key)); err != nil {
+			return err
+		}
+	}
+	upgraded, err := versions.Manager.Deploy(context.Background(), data)
+	if err != nil {
+		return err
+	}
+	changes, err := versions.Diff(data, upgraded)
+	if err != nil {
+		return err
+	}
+	if len(changes) == 0 {
+		log.Printf("Config is already at the latest version %d.\n", versions.Manager.Latest())
+		return nil
+	}
+	for i := range changes {
+		log.Println(changes[i])
+	}
+	if dryrun {
+		log.Printf("Dry run, %d changes to upgrade to version %d were not written.\n", len(changes), versions.Manager.Latest())
+		return nil
+	}
+	if encrypted {
+		if upgraded, err = config.EncryptConfigFile(upgraded, []byte(key)); err != nil {
+			return err
+		}
+	}
+	if err := file.Write(outFile, upgraded); err != nil {
+		return err
+	}
+	log.Printf("Upgraded config to version %d and wrote output to %s.\n", versions.Manager.Latest(), outFile)
+	return nil
+}
@@ -1,6 +1,14 @@
 package main
 
-import "testing"
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/config"
+)
 
 func TestEncryptOrDecrypt(t *testing.T) {
 	reValue := EncryptOrDecrypt(true)
@@ -16,3 +24,31 @@ func TestEncryptOrDecrypt(t *testing.T) {
 		)
 	}
 }
+
+func TestUpgradeConfig(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	inFile := filepath.Join(dir, "config.json")
+	outFile := filepath.Join(dir, "config.json.out")
+	require.NoError(t, os.WriteFile(inFile, []byte(`{"name":"test","exchanges":[{"name":"GDAX"}]}`), 0o600), "WriteFile must not error")
+
+	require.NoError(t, upgradeConfig(inFile, outFile, "", true), "upgradeConfig must not error")
+	assert.NoFileExists(t, outFile, "dry run should not write the output file")
+
+	require.NoError(t, upgradeConfig(inFile, outFile, "", false), "upgradeConfig must not error")
+	data, err := os.ReadFile(outFile)
+	require.NoError(t, err, "ReadFile must not error")
+	assert.JSONEq(t, `{"version":1,"name":"test","exchanges":[{"name":"CoinbasePro"}]}`, string(data))
+
+	encrypted, err := config.EncryptConfigFile([]byte(`{"name":"test"}`), []byte("key"))
+	require.NoError(t, err, "EncryptConfigFile must not error")
+	require.NoError(t, os.WriteFile(inFile, encrypted, 0o600), "WriteFile must not error")
+	require.NoError(t, upgradeConfig(inFile, outFile, "key", false), "upgradeConfig must not error")
+	data, err = os.ReadFile(outFile)
+	require.NoError(t, err, "ReadFile must not error")
+	data, err = config.DecryptConfigFile(data, []byte("key"))
+	require.NoError(t, err, "DecryptConfigFile must not error")
+	assert.JSONEq(t, `{"version":1,"name":"test"}`, string(data), "encrypted configs should stay encrypted")
+
+	assert.ErrorIs(t, upgradeConfig(filepath.Join(dir, "missing.json"), outFile, "", false), os.ErrNotExist)
+}
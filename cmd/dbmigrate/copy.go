@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+)
+
+var (
+	errTargetNotEmpty = errors.New("target table is not empty")
+	errNoColumns      = errors.New("no matching columns between source and target table")
+)
+
+// copyTable describes a table copied between databases
+type copyTable struct {
+	name string
+	// serial names the Postgres column backed by a sequence which must be
+	// advanced past the copied values
+	serial string
+}
+
+// copyResult holds the outcome of copying a single table
+type copyResult struct {
+	table string
+	rows  int64
+	// skipped holds source columns which do not exist in the target table
+	skipped []string
+}
+
+// copyTables lists every GoCryptoTrader table ordered so referenced rows are
+// copied before the rows which reference them
+var copyTables = []copyTable{
+	{name: "exchange"},
+	{name: "audit_event", serial: "id"},
+	{name: "script"},
+	{name: "script_execution"},
+	{name: "withdrawal_history"},
+	{name: "withdrawal_fiat", serial: "id"},
+	{name: "withdrawal_crypto", serial: "id"},
+	{name: "datahistoryjob"},
+	{name: "datahistoryjobrelations"},
+	{name: "datahistoryjobresult"},
+	{name: "candle"},
+	{name: "trade"},
+	{name: "fill", serial: "sequence"},
+	{name: "calendar_spread"},
+}
+
+// copyDatabase copies all rows of the supplied tables from src to dst in a
+// single transaction, so a failure leaves the target untouched. Target tables
+// must already exist and be empty
+func copyDatabase(ctx context.Context, src, dst *sql.DB, dstDialect string, tables []copyTable) ([]copyResult, error) {
+	tx, err := dst.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]copyResult, 0, len(tables))
+	for i := range tables {
+		r, err := copyTableRows(ctx, src, tx, dstDialect, tables[i])
+		if err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				return nil, fmt.Errorf("%w: rollback failed: %w", err, rbErr)
+			}
+			return nil, err
+		}
+		results = append(results, r)
+	}
+	return results, tx.Commit()
+}
+
+func copyTableRows(ctx context.Context, src *sql.DB, tx *sql.Tx, dstDialect string, t copyTable) (copyResult, error) {
+	result := copyResult{table: t.name}
+	quoted := strconv.Quote(t.name)
+
+	var existing int64
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+quoted).Scan(&existing); err != nil {
+		return result, fmt.Errorf("%s: %w", t.name, err)
+	}
+	if existing > 0 {
+		return result, fmt.Errorf("%s: %w, contains %d rows", t.name, errTargetNotEmpty, existing)
+	}
+
+	targetColumns, err := tableColumns(ctx, tx, quoted)
+	if err != nil {
+		return result, fmt.Errorf("%s: %w", t.name, err)
+	}
+
+	rows, err := src.QueryContext(ctx, "SELECT * FROM "+quoted)
+	if err != nil {
+		return result, fmt.Errorf("%s: %w", t.name, err)
+	}
+	defer rows.Close()
+	sourceColumns, err := rows.Columns()
+	if err != nil {
+		return result, fmt.Errorf("%s: %w", t.name, err)
+	}
+
+	// columns maps source column positions to those inserted into the target
+	var columns []int
+	names := make([]string, 0, len(sourceColumns))
+	placeholders := make([]string, 0, len(sourceColumns))
+	for i, c := range sourceColumns {
+		if !slices.Contains(targetColumns, c) {
+			result.skipped = append(result.skipped, c)
+			continue
+		}
+		columns = append(columns, i)
+		names = append(names, strconv.Quote(c))
+		if dstDialect == database.DBPostgreSQL {
+			placeholders = append(placeholders, "$"+strconv.Itoa(len(placeholders)+1))
+		} else {
+			placeholders = append(placeholders, "?")
+		}
+	}
+	if len(columns) == 0 {
+		return result, fmt.Errorf("%s: %w", t.name, errNoColumns)
+	}
+
+	// the insert is prepared once and reused for every row
+	stmt, err := tx.PrepareContext(ctx, "INSERT INTO "+quoted+" ("+strings.Join(names, ", ")+") VALUES ("+strings.Join(placeholders, ", ")+")")
+	if err != nil {
+		return result, fmt.Errorf("%s: %w", t.name, err)
+	}
+	defer stmt.Close()
+
+	values := make([]any, len(sourceColumns))
+	scan := make([]any, len(sourceColumns))
+	for i := range values {
+		scan[i] = &values[i]
+	}
+	args := make([]any, len(columns))
+	for rows.Next() {
+		if err = rows.Scan(scan...); err != nil {
+			return result, fmt.Errorf("%s: %w", t.name, err)
+		}
+		for i, c := range columns {
+			// SQLite returns text stored without a declared type as bytes,
+			// which would otherwise be inserted as binary data
+			if b, ok := values[c].([]byte); ok {
+				args[i] = string(b)
+				continue
+			}
+			args[i] = values[c]
+		}
+		if _, err = stmt.ExecContext(ctx, args...); err != nil {
+			return result, fmt.Errorf("%s row %d: %w", t.name, result.rows+1, err)
+		}
+		result.rows++
+	}
+	if err = rows.Err(); err != nil {
+		return result, fmt.Errorf("%s: %w", t.name, err)
+	}
+
+	if t.serial != "" && dstDialect == database.DBPostgreSQL {
+		col := strconv.Quote(t.serial)
+		_, err = tx.ExecContext(ctx, "SELECT setval(pg_get_serial_sequence($1, $2), COALESCE(MAX("+col+"), 1), MAX("+col+") IS NOT NULL) FROM "+quoted, t.name, t.serial)
+		if err != nil {
+			return result, fmt.Errorf("%s: unable to reset sequence: %w", t.name, err)
+		}
+	}
+	return result, nil
+}
+
+// tableColumns returns the column names of a table
+func tableColumns(ctx context.Context, tx *sql.Tx, quotedTable string) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT * FROM "+quotedTable+" LIMIT 0")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return rows.Columns()
+}
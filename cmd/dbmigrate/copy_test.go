@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	dbsqlite3 "github.com/thrasher-corp/gocryptotrader/database/drivers/sqlite3"
+	"github.com/thrasher-corp/goose"
+)
+
+func openMigratedSQLite(t *testing.T, name string) *sql.DB {
+	t.Helper()
+	db, err := dbsqlite3.Open(filepath.Join(t.TempDir(), name), nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, db.Close()) })
+	require.NoError(t, goose.Run("up", db, database.DBSQLite3, database.MigrationDir, ""))
+	return db
+}
+
+func TestCopyDatabase(t *testing.T) {
+	src := openMigratedSQLite(t, "src.db")
+	dst := openMigratedSQLite(t, "dst.db")
+
+	var journalMode string
+	require.NoError(t, src.QueryRow(`PRAGMA journal_mode`).Scan(&journalMode))
+	assert.Equal(t, "wal", journalMode, "SQLite should default to WAL journaling")
+
+	_, err := src.Exec(`INSERT INTO exchange (id, name) VALUES ('9e7fb3c8-2fce-4a51-9ab0-6e1a1d1e4ffa', 'binance')`)
+	require.NoError(t, err)
+	_, err = src.Exec(`INSERT INTO audit_event (type, identifier, message) VALUES ('test', 'one', 'first'), ('test', 'two', 'second')`)
+	require.NoError(t, err)
+
+	results, err := copyDatabase(context.Background(), src, dst, database.DBSQLite3, copyTables)
+	require.NoError(t, err)
+	require.Len(t, results, len(copyTables))
+	assert.Equal(t, int64(1), results[0].rows, "exchange should copy one row")
+	assert.Equal(t, int64(2), results[1].rows, "audit_event should copy two rows")
+	assert.Empty(t, results[1].skipped, "identical schemas should not skip columns")
+
+	var name string
+	require.NoError(t, dst.QueryRow(`SELECT name FROM exchange`).Scan(&name))
+	assert.Equal(t, "binance", name)
+	var count int
+	require.NoError(t, dst.QueryRow(`SELECT COUNT(*) FROM audit_event`).Scan(&count))
+	assert.Equal(t, 2, count)
+
+	_, err = copyDatabase(context.Background(), src, dst, database.DBSQLite3, copyTables)
+	assert.ErrorIs(t, err, errTargetNotEmpty, "copying into a populated database should fail")
+	require.NoError(t, dst.QueryRow(`SELECT COUNT(*) FROM audit_event`).Scan(&count))
+	assert.Equal(t, 2, count, "failed copy should roll back")
+}
@@ -1,11 +1,14 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	"runtime"
+	"strconv"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/config"
@@ -24,8 +27,13 @@ var (
 	migrationDir   string
 	command        string
 	args           string
+	target         database.Config
 )
 
+// toPostgresCommand copies all data from the configured SQLite database into
+// the Postgres database supplied via flags
+const toPostgresCommand = "topostgres"
+
 func openDBConnection(cfg *database.Config) (err error) {
 	if cfg.Driver == database.DBPostgreSQL {
 		dbConn, err = dbPSQL.Connect(cfg)
@@ -48,11 +56,21 @@ func main() {
 	fmt.Println(core.Copyright)
 	fmt.Println()
 
-	flag.StringVar(&command, "command", "", "command to run status|up|up-by-one|up-to|down|create")
+	flag.StringVar(&command, "command", "", "command to run status|up|up-by-one|up-to|down|create|"+toPostgresCommand)
 	flag.StringVar(&args, "args", "", "arguments to pass to goose")
 	flag.StringVar(&configFile, "config", config.DefaultFilePath(), "config file to load")
 	flag.StringVar(&defaultDataDir, "datadir", common.GetDefaultDataDir(runtime.GOOS), "default data directory for GoCryptoTrader files")
 	flag.StringVar(&migrationDir, "migrationdir", database.MigrationDir, "override migration folder")
+	flag.StringVar(&target.Host, "pghost", "localhost", "target Postgres host used by the "+toPostgresCommand+" command")
+	flag.Func("pgport", "target Postgres port used by the "+toPostgresCommand+" command (default 5432)", func(v string) error {
+		p, err := strconv.ParseUint(v, 10, 16)
+		target.Port = uint16(p)
+		return err
+	})
+	flag.StringVar(&target.Username, "pguser", "", "target Postgres username used by the "+toPostgresCommand+" command")
+	flag.StringVar(&target.Password, "pgpassword", "", "target Postgres password used by the "+toPostgresCommand+" command")
+	flag.StringVar(&target.Database, "pgdatabase", "", "target Postgres database used by the "+toPostgresCommand+" command")
+	flag.StringVar(&target.SSLMode, "pgsslmode", "disable", "target Postgres SSL mode used by the "+toPostgresCommand+" command")
 
 	flag.Parse()
 
@@ -68,6 +86,14 @@ func main() {
 		os.Exit(1)
 	}
 
+	if command == toPostgresCommand {
+		if err = copyToPostgres(&conf.Database); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	err = openDBConnection(&conf.Database)
 	if err != nil {
 		fmt.Println(err)
@@ -92,3 +118,49 @@ func main() {
 		fmt.Println(err)
 	}
 }
+
+// copyToPostgres migrates the target Postgres schema to the latest version and
+// copies every row from the configured SQLite database into it
+func copyToPostgres(cfg *database.Config) error {
+	if cfg.Driver != database.DBSQLite && cfg.Driver != database.DBSQLite3 {
+		return fmt.Errorf("%s requires the configured database driver to be %s, received %s", toPostgresCommand, database.DBSQLite3, cfg.Driver)
+	}
+	if target.Port == 0 {
+		target.Port = 5432
+	}
+	target.Driver = database.DBPostgreSQL
+	target.Enabled = true
+
+	src, err := dbsqlite3.Open(filepath.Join(database.DB.DataPath, cfg.Database), &cfg.SQLite)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := dbPSQL.Open(&target)
+	if err != nil {
+		return err
+	}
+	defer dst.Close()
+
+	// both schemas must be identical before any data is copied
+	if err = goose.Run("up", src, database.DBSQLite3, migrationDir, ""); err != nil {
+		return fmt.Errorf("unable to migrate source database: %w", err)
+	}
+	if err = goose.Run("up", dst, database.DBPostgreSQL, migrationDir, ""); err != nil {
+		return fmt.Errorf("unable to migrate target database: %w", err)
+	}
+
+	fmt.Printf("Copying %s to Postgres %s/%s\n", cfg.Database, target.Host, target.Database)
+	results, err := copyDatabase(context.Background(), src, dst, database.DBPostgreSQL, copyTables)
+	if err != nil {
+		return err
+	}
+	for i := range results {
+		fmt.Printf("%s: %d rows copied\n", results[i].table, results[i].rows)
+		if len(results[i].skipped) > 0 {
+			fmt.Printf("%s: columns not present in target skipped: %v\n", results[i].table, results[i].skipped)
+		}
+	}
+	fmt.Println("Copy complete. Update the database driver and connection details in your config to use Postgres.")
+	return nil
+}
@@ -1,15 +1,16 @@
 package main
 
 import (
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
-	"html/template"
 	"log"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"text/template"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/common/file"
@@ -25,27 +26,40 @@ const (
 	targetPath         = "../../exchanges"
 )
 
+// exchange describes the exchange to scaffold. It can be loaded from a JSON
+// descriptor file instead of being supplied by flags
 type exchange struct {
-	Name        string
-	CapitalName string
-	Variable    string
-	REST        bool
-	WS          bool
-	FIX         bool
+	Name        string `json:"name"`
+	CapitalName string `json:"-"`
+	Variable    string `json:"-"`
+	REST        bool   `json:"rest"`
+	WS          bool   `json:"websocket"`
+	FIX         bool   `json:"fix"`
+	RESTURL     string `json:"restURL"`
+	WSURL       string `json:"websocketURL"`
+	// Channels are the websocket channels subscribed to by default for every
+	// enabled pair
+	Channels []string `json:"channels"`
 }
 
 var (
-	errInvalidExchangeName = errors.New("invalid exchange name")
+	errInvalidExchangeName    = errors.New("invalid exchange name")
+	errNoProtocols            = errors.New("at least one protocol must be specified (rest/ws or fix)")
+	errWebsocketURLIsRequired = errors.New("a websocket URL is required when websocket is supported")
 )
 
 func main() {
-	var newExchangeName string
-	var websocketSupport, restSupport, fixSupport bool
-
-	flag.StringVar(&newExchangeName, "name", "", "the exchange name")
-	flag.BoolVar(&websocketSupport, "ws", false, "whether the exchange supports websocket")
-	flag.BoolVar(&restSupport, "rest", false, "whether the exchange supports REST")
-	flag.BoolVar(&fixSupport, "fix", false, "whether the exchange supports FIX")
+	var exch exchange
+	var descriptorPath, channels string
+
+	flag.StringVar(&descriptorPath, "descriptor", "", "a JSON exchange descriptor file, used instead of the other flags")
+	flag.StringVar(&exch.Name, "name", "", "the exchange name")
+	flag.BoolVar(&exch.WS, "ws", false, "whether the exchange supports websocket")
+	flag.BoolVar(&exch.REST, "rest", false, "whether the exchange supports REST")
+	flag.BoolVar(&exch.FIX, "fix", false, "whether the exchange supports FIX")
+	flag.StringVar(&exch.RESTURL, "resturl", "", "the exchange REST API URL")
+	flag.StringVar(&exch.WSURL, "wsurl", "", "the exchange websocket URL, required when websocket is supported")
+	flag.StringVar(&channels, "channels", "", "comma separated websocket channels to subscribe to by default")
 
 	flag.Parse()
 
@@ -59,21 +73,37 @@ func main() {
 		return
 	}
 
-	if err := checkExchangeName(newExchangeName); err != nil {
-		log.Fatal(err)
+	if descriptorPath != "" {
+		d, err := loadDescriptor(descriptorPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		exch = *d
+	} else if channels != "" {
+		exch.Channels = strings.Split(channels, ",")
 	}
-	newExchangeName = strings.ToLower(newExchangeName)
 
-	if !websocketSupport && !restSupport && !fixSupport {
-		log.Println("At least one protocol must be specified (rest/ws or fix)")
-		flag.Usage()
-		return
+	if err := checkExchange(&exch); err != nil {
+		if errors.Is(err, errNoProtocols) {
+			log.Println(err)
+			flag.Usage()
+			return
+		}
+		log.Fatal(err)
+	}
+	exch.Name = strings.ToLower(exch.Name)
+
+	fmt.Println("Exchange Name: ", exch.Name)
+	fmt.Println("Websocket Supported: ", exch.WS)
+	fmt.Println("REST Supported: ", exch.REST)
+	fmt.Println("FIX Supported: ", exch.FIX)
+	if exch.RESTURL != "" {
+		fmt.Println("REST URL: ", exch.RESTURL)
+	}
+	if exch.WS {
+		fmt.Println("Websocket URL: ", exch.WSURL)
+		fmt.Println("Websocket Channels: ", strings.Join(exch.Channels, ", "))
 	}
-
-	fmt.Println("Exchange Name: ", newExchangeName)
-	fmt.Println("Websocket Supported: ", websocketSupport)
-	fmt.Println("REST Supported: ", restSupport)
-	fmt.Println("FIX Supported: ", fixSupport)
 	fmt.Println()
 	fmt.Println("Please check if everything is correct and then type y to continue or n to cancel...")
 
@@ -87,12 +117,6 @@ func main() {
 		log.Fatal("GoCryptoTrader: Exchange templating tool stopped...")
 	}
 
-	exch := exchange{
-		Name: newExchangeName,
-		REST: restSupport,
-		WS:   websocketSupport,
-		FIX:  fixSupport,
-	}
 	exchangeDirectory := filepath.Join(targetPath, exch.Name)
 	configTestFile := config.GetConfig()
 
@@ -125,6 +149,33 @@ func checkExchangeName(exchName string) error {
 	return nil
 }
 
+// checkExchange validates an exchange description before scaffolding
+func checkExchange(exch *exchange) error {
+	if err := checkExchangeName(exch.Name); err != nil {
+		return err
+	}
+	if !exch.WS && !exch.REST && !exch.FIX {
+		return errNoProtocols
+	}
+	if exch.WS && exch.WSURL == "" {
+		return errWebsocketURLIsRequired
+	}
+	return nil
+}
+
+// loadDescriptor reads an exchange description from a JSON descriptor file
+func loadDescriptor(path string) (*exchange, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var exch exchange
+	if err := json.Unmarshal(data, &exch); err != nil {
+		return nil, fmt.Errorf("invalid descriptor %s: %w", path, err)
+	}
+	return &exch, nil
+}
+
 func makeExchange(exchangeDirectory string, configTestFile *config.Config, exch *exchange) (*config.Exchange, error) {
 	err := configTestFile.LoadConfig(exchangeConfigPath, true)
 	if err != nil {
@@ -171,6 +222,8 @@ func makeExchange(exchangeDirectory string, configTestFile *config.Config, exch
 		Filename     string
 		FilePostfix  string
 		TemplateFile string
+		// Websocket files are only generated for websocket exchanges
+		Websocket bool
 	}{
 		{
 			Name:         "readme",
@@ -201,9 +254,19 @@ func makeExchange(exchangeDirectory string, configTestFile *config.Config, exch
 			FilePostfix:  "_wrapper.go",
 			TemplateFile: "wrapper_file.tmpl",
 		},
+		{
+			Name:         "websocket",
+			Filename:     "websocket_file.tmpl",
+			FilePostfix:  "_websocket.go",
+			TemplateFile: "websocket_file.tmpl",
+			Websocket:    true,
+		},
 	}
 
 	for x := range outputFiles {
+		if outputFiles[x].Websocket && !exch.WS {
+			continue
+		}
 		var tmpl *template.Template
 		tmpl, err = template.New(outputFiles[x].Name).ParseFiles(outputFiles[x].TemplateFile)
 		if err != nil {
@@ -223,7 +286,7 @@ func makeExchange(exchangeDirectory string, configTestFile *config.Config, exch
 			return nil, err
 		}
 
-		if err = tmpl.Execute(f, exch); err != nil {
+		if err = tmpl.ExecuteTemplate(f, outputFiles[x].Name, exch); err != nil {
 			f.Close()
 			return nil, err
 		}
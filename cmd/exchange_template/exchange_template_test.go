@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/thrasher-corp/gocryptotrader/common/file"
 	"github.com/thrasher-corp/gocryptotrader/config"
 )
@@ -45,6 +47,41 @@ func TestCheckExchangeName(t *testing.T) {
 	}
 }
 
+func TestCheckExchange(t *testing.T) {
+	t.Parallel()
+	exch := &exchange{Name: "m"}
+	assert.ErrorIs(t, checkExchange(exch), errInvalidExchangeName)
+	exch.Name = "testexch"
+	assert.ErrorIs(t, checkExchange(exch), errNoProtocols)
+	exch.WS = true
+	assert.ErrorIs(t, checkExchange(exch), errWebsocketURLIsRequired)
+	exch.WSURL = "wss://testexch.com/ws"
+	assert.NoError(t, checkExchange(exch))
+}
+
+func TestLoadDescriptor(t *testing.T) {
+	t.Parallel()
+	_, err := loadDescriptor(filepath.Join(t.TempDir(), "missing.json"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	path := filepath.Join(t.TempDir(), "descriptor.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"name":"testexch","rest":true,"websocket":true,"restURL":"https://testexch.com","websocketURL":"wss://testexch.com/ws","channels":["ticker"]}`), file.DefaultPermissionOctal))
+	exch, err := loadDescriptor(path)
+	require.NoError(t, err, "loadDescriptor must not error")
+	assert.Equal(t, &exchange{
+		Name:     "testexch",
+		REST:     true,
+		WS:       true,
+		RESTURL:  "https://testexch.com",
+		WSURL:    "wss://testexch.com/ws",
+		Channels: []string{"ticker"},
+	}, exch)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{`), file.DefaultPermissionOctal))
+	_, err = loadDescriptor(path)
+	assert.Error(t, err, "loadDescriptor should error on an invalid descriptor")
+}
+
 func TestNewExchangeAndSaveConfig(t *testing.T) {
 	const testExchangeName = "testexch"
 	testExchangeDir := filepath.Join(targetPath, testExchangeName)
@@ -60,9 +97,11 @@ func TestNewExchangeAndSaveConfig(t *testing.T) {
 		testExchangeDir,
 		cfg,
 		&exchange{
-			Name: testExchangeName,
-			REST: true,
-			WS:   true,
+			Name:     testExchangeName,
+			REST:     true,
+			WS:       true,
+			WSURL:    "wss://testexch.com/ws",
+			Channels: []string{"ticker", "trades"},
 		},
 	)
 	if err != nil {
@@ -186,7 +186,7 @@ func executeExchangeWrapperTests(ctx context.Context, t *testing.T, exch exchang
 		for y := 0; y < method.Type().NumIn(); y++ {
 			input := method.Type().In(y)
 			for _, t := range []reflect.Type{
-				assetParam, orderSubmitParam, orderModifyParam, orderCancelParam, orderCancelsParam, pairKeySliceParam, getOrdersRequestParam, latestRateRequest,
+				assetParam, orderSubmitParam, orderSubmitsParam, orderModifyParam, orderCancelParam, orderCancelsParam, pairKeySliceParam, getOrdersRequestParam, latestRateRequest,
 			} {
 				if input.AssignableTo(t) {
 					// this allows wrapper functions that support assets types
@@ -287,6 +287,7 @@ var (
 	// types with asset in params
 	assetParam                  = reflect.TypeOf((*asset.Item)(nil)).Elem()
 	orderSubmitParam            = reflect.TypeOf((**order.Submit)(nil)).Elem()
+	orderSubmitsParam           = reflect.TypeOf((*[]order.Submit)(nil)).Elem()
 	orderModifyParam            = reflect.TypeOf((**order.Modify)(nil)).Elem()
 	orderCancelParam            = reflect.TypeOf((**order.Cancel)(nil)).Elem()
 	orderCancelsParam           = reflect.TypeOf((*[]order.Cancel)(nil)).Elem()
@@ -354,7 +355,7 @@ func generateMethodArg(ctx context.Context, t *testing.T, argGenerator *MethodAr
 	case argGenerator.MethodInputType.AssignableTo(klineParam):
 		input = reflect.ValueOf(kline.OneDay)
 	case argGenerator.MethodInputType.AssignableTo(codeParam):
-		if argGenerator.MethodName == "GetAvailableTransferChains" {
+		if argGenerator.MethodName == "GetAvailableTransferChains" || argGenerator.MethodName == "GetTransferNetworks" {
 			input = reflect.ValueOf(currency.ETH)
 		} else {
 			input = reflect.ValueOf(argGenerator.AssetParams.Pair.Base)
@@ -441,30 +442,43 @@ func generateMethodArg(ctx context.Context, t *testing.T, argGenerator *MethodAr
 		input = reflect.ValueOf(req)
 	case argGenerator.MethodInputType.AssignableTo(orderSubmitParam):
 		input = reflect.ValueOf(&order.Submit{
-			Exchange:          exchName,
-			Type:              order.Limit,
-			Side:              order.Buy,
-			Pair:              argGenerator.AssetParams.Pair,
-			AssetType:         argGenerator.AssetParams.Asset,
-			Price:             150,
-			Amount:            1,
-			ClientID:          "1337",
-			ClientOrderID:     "13371337",
-			ImmediateOrCancel: true,
-			Leverage:          1,
+			Exchange:      exchName,
+			Type:          order.Limit,
+			Side:          order.Buy,
+			Pair:          argGenerator.AssetParams.Pair,
+			AssetType:     argGenerator.AssetParams.Asset,
+			Price:         150,
+			Amount:        1,
+			ClientID:      "1337",
+			ClientOrderID: "13371337",
+			TimeInForce:   order.IOC,
+			Leverage:      1,
+		})
+	case argGenerator.MethodInputType.AssignableTo(orderSubmitsParam):
+		input = reflect.ValueOf([]order.Submit{
+			{
+				Exchange:      exchName,
+				Type:          order.Limit,
+				Side:          order.Buy,
+				Pair:          argGenerator.AssetParams.Pair,
+				AssetType:     argGenerator.AssetParams.Asset,
+				Price:         150,
+				Amount:        1,
+				ClientOrderID: "13371337",
+			},
 		})
 	case argGenerator.MethodInputType.AssignableTo(orderModifyParam):
 		input = reflect.ValueOf(&order.Modify{
-			Exchange:          exchName,
-			Type:              order.Limit,
-			Side:              order.Buy,
-			Pair:              argGenerator.AssetParams.Pair,
-			AssetType:         argGenerator.AssetParams.Asset,
-			Price:             150,
-			Amount:            1,
-			ClientOrderID:     "13371337",
-			OrderID:           "1337",
-			ImmediateOrCancel: true,
+			Exchange:      exchName,
+			Type:          order.Limit,
+			Side:          order.Buy,
+			Pair:          argGenerator.AssetParams.Pair,
+			AssetType:     argGenerator.AssetParams.Asset,
+			Price:         150,
+			Amount:        1,
+			ClientOrderID: "13371337",
+			OrderID:       "1337",
+			TimeInForce:   order.IOC,
 		})
 	case argGenerator.MethodInputType.AssignableTo(orderCancelParam):
 		input = reflect.ValueOf(&order.Cancel{
@@ -567,6 +581,16 @@ var excludedMethodNames = map[string]struct{}{
 	"CanWithdraw":                    {}, // Not widely supported/implemented feature
 	"CanDeposit":                     {}, // Not widely supported/implemented feature
 	"GetCurrencyStateSnapshot":       {}, // Not widely supported/implemented feature
+	"GetConversionQuote":             {}, // Not widely supported/implemented feature
+	"AcceptConversionQuote":          {}, // Not widely supported/implemented feature
+	"GetDustBalances":                {}, // Not widely supported/implemented feature
+	"ConvertDust":                    {}, // Not widely supported/implemented feature
+	"GetEarnProducts":                {}, // Not widely supported/implemented feature
+	"GetEarnPositions":               {}, // Not widely supported/implemented feature
+	"SubscribeEarnProduct":           {}, // Not widely supported/implemented feature
+	"RedeemEarnProduct":              {}, // Not widely supported/implemented feature
+	"GetEarnRewards":                 {}, // Not widely supported/implemented feature
+	"SubmitComboOrder":               {}, // Not widely supported/implemented feature
 	"SetHTTPClientUserAgent":         {}, // standard base implementation
 	"SetClientProxyAddress":          {}, // standard base implementation
 	// Not widely supported/implemented futures endpoints
@@ -631,6 +655,7 @@ var acceptableErrors = []error{
 	request.ErrRateLimiterAlreadyEnabled, // If the rate limiter is already enabled, it is not an error
 	context.DeadlineExceeded,             // If the context deadline is exceeded, it is not an error as only blockedCIExchanges use expired contexts by design
 	order.ErrPairIsEmpty,                 // Is thrown when the empty pair and asset scenario for an order submission is sent in the Validate() function
+	order.ErrUnsupportedTimeInForce,      // Is thrown when an exchange or asset cannot honour the requested time in force
 	deposit.ErrAddressNotFound,           // Is thrown when an address is not found due to the exchange requiring valid API keys
 	futures.ErrNotFuturesAsset,           // Is thrown when a futures function receives a non-futures asset
 	currency.ErrSymbolStringEmpty,        // Is thrown when a symbol string is empty for blank MatchSymbol func checks
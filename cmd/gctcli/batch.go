@@ -0,0 +1,323 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/urfave/cli/v2"
+)
+
+// Batch file directives
+const (
+	batchSetDirective     = "set"
+	batchOnErrorDirective = "onerror"
+	batchOnErrorAbort     = "abort"
+	batchOnErrorContinue  = "continue"
+	// batchIgnoreErrorPrefix marks a line whose error never aborts the batch
+	batchIgnoreErrorPrefix = "-"
+)
+
+var (
+	errBatchFileUnset        = errors.New("batch file unset")
+	errBatchSyntax           = errors.New("batch syntax error")
+	errBatchUndefinedVar     = errors.New("undefined batch variable")
+	errBatchUnknownCommand   = errors.New("unknown command")
+	errBatchNestedBatch      = errors.New("batch files cannot run batch")
+	errBatchCommandsFailed   = errors.New("batch commands failed")
+	errBatchAborted          = errors.New("batch aborted")
+	batchVariableNameMatcher = regexp.MustCompile(`^[A-Za-z_]\w*$`)
+)
+
+var batchCommand = &cli.Command{
+	Name:      "batch",
+	Usage:     "executes a file of gctcli commands sequentially, such as an operational runbook",
+	ArgsUsage: "<file>",
+	Description: `Each line of the file is a gctcli command without the leading "gctcli", eg
+"cancelallorders --exchange $exchange". Global flags given to batch apply to
+every command. Blank lines and lines starting with # are ignored.
+
+  set <name> <value>       sets a variable, referenced as $name or ${name}
+  onerror abort|continue   whether a failing command aborts the remaining
+                           commands, abort by default
+  -<command>               runs a command whose error never aborts the batch
+
+Variables may also be set with --var name=value, which is overridden by set.
+Use $$ for a literal $. Variables are not substituted within single quotes.
+Use - as the file to read commands from stdin.`,
+	Action: batch,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "file",
+			Usage: "the file of commands to execute, - for stdin",
+		},
+		&cli.StringSliceFlag{
+			Name:  "var",
+			Usage: "sets a variable as name=value, may be repeated",
+		},
+		&cli.BoolFlag{
+			Name:  "continueonerror",
+			Usage: "runs the remaining commands when a command fails, as if the file began with onerror continue",
+		},
+		&cli.BoolFlag{
+			Name:  "dryrun",
+			Usage: "prints each command with its variables substituted without running it",
+		},
+	},
+}
+
+// batchLine is a parsed command from a batch file
+type batchLine struct {
+	args        []string
+	ignoreError bool
+}
+
+func batch(c *cli.Context) error {
+	var path string
+	if c.IsSet("file") {
+		path = c.String("file")
+	} else {
+		path = c.Args().First()
+	}
+	if path == "" {
+		return errBatchFileUnset
+	}
+
+	vars := make(map[string]string)
+	for _, v := range c.StringSlice("var") {
+		name, value, ok := strings.Cut(v, "=")
+		if !ok || !batchVariableNameMatcher.MatchString(name) {
+			return fmt.Errorf("%w: invalid --var %q, must be name=value", errBatchSyntax, v)
+		}
+		vars[name] = value
+	}
+
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := f.Close(); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+		}()
+		r = f
+	}
+
+	// Commands must not prompt for omitted arguments part way through a batch
+	nonInteractive = true
+	// Commands run beneath the root command's context so global flags apply
+	var root *cli.Context
+	for _, l := range c.Lineage() {
+		if l.Command != nil {
+			root = l
+		}
+	}
+	abortOnError := !c.Bool("continueonerror")
+	dryRun := c.Bool("dryrun")
+
+	var run, failed int
+	scanner := bufio.NewScanner(r)
+	for number := 1; scanner.Scan(); number++ {
+		line, err := parseBatchLine(number, scanner.Text(), vars)
+		if err != nil {
+			return err
+		}
+		if line == nil {
+			continue
+		}
+		switch line.args[0] {
+		case batchSetDirective:
+			if len(line.args) < 2 || !batchVariableNameMatcher.MatchString(line.args[1]) {
+				return fmt.Errorf("%w line %d: usage set <name> <value>", errBatchSyntax, number)
+			}
+			vars[line.args[1]] = strings.Join(line.args[2:], " ")
+			continue
+		case batchOnErrorDirective:
+			if len(line.args) != 2 || (line.args[1] != batchOnErrorAbort && line.args[1] != batchOnErrorContinue) {
+				return fmt.Errorf("%w line %d: usage onerror abort|continue", errBatchSyntax, number)
+			}
+			abortOnError = line.args[1] == batchOnErrorAbort
+			continue
+		case c.Command.Name:
+			return fmt.Errorf("%w line %d", errBatchNestedBatch, number)
+		}
+
+		cmd := c.App.Command(line.args[0])
+		if cmd == nil {
+			return fmt.Errorf("%w line %d: %q", errBatchUnknownCommand, number, line.args[0])
+		}
+		fmt.Fprintf(os.Stderr, "> %s\n", strings.Join(line.args, " "))
+		if dryRun {
+			continue
+		}
+		run++
+		cmdCtx := cli.NewContext(c.App, nil, root)
+		cmdCtx.Command = cmd
+		if err := cmd.Run(cmdCtx, line.args...); err != nil {
+			failed++
+			fmt.Fprintf(os.Stderr, "line %d: %s: %v\n", number, line.args[0], err)
+			if abortOnError && !line.ignoreError {
+				return fmt.Errorf("%w at line %d after %d commands: %w", errBatchAborted, number, run, err)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%w: %d of %d", errBatchCommandsFailed, failed, run)
+	}
+	return nil
+}
+
+// parseBatchLine splits a batch file line into arguments and substitutes its
+// variables. Blank lines and comments return nil
+func parseBatchLine(number int, text string, vars map[string]string) (*batchLine, error) {
+	text = strings.TrimSpace(text)
+	if text == "" || strings.HasPrefix(text, "#") {
+		return nil, nil
+	}
+	line := &batchLine{}
+	if rest, ok := strings.CutPrefix(text, batchIgnoreErrorPrefix); ok {
+		line.ignoreError = true
+		text = strings.TrimSpace(rest)
+	}
+	args, err := splitBatchArgs(text, vars)
+	if err != nil {
+		if errors.Is(err, errBatchUndefinedVar) {
+			return nil, fmt.Errorf("line %d: %w", number, err)
+		}
+		return nil, fmt.Errorf("%w line %d: %w", errBatchSyntax, number, err)
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("%w line %d: missing command", errBatchSyntax, number)
+	}
+	line.args = args
+	return line, nil
+}
+
+// splitBatchArgs splits a line into arguments on whitespace and substitutes
+// $name and ${name} variables, following shell quoting. Single quotes group
+// text literally. Double quotes group text with variables substituted, where a
+// backslash only escapes \, " and $. Outside of quotes a backslash escapes the
+// following character
+func splitBatchArgs(text string, vars map[string]string) ([]string, error) {
+	var (
+		args      []string
+		current   strings.Builder
+		inArg     bool
+		undefined []string
+	)
+	rs := []rune(text)
+	// expand substitutes the variable referenced at rs[i], which must be a $,
+	// returning the index of the last rune consumed
+	expand := func(i int) (int, error) {
+		if i+1 < len(rs) && rs[i+1] == '$' {
+			current.WriteRune('$')
+			return i + 1, nil
+		}
+		var name string
+		end := i
+		if i+1 < len(rs) && rs[i+1] == '{' {
+			closing := slices.Index(rs[i+2:], '}')
+			if closing < 0 {
+				return 0, errors.New("unterminated ${")
+			}
+			end = i + 2 + closing
+			name = string(rs[i+2 : end])
+			if !batchVariableNameMatcher.MatchString(name) {
+				return 0, fmt.Errorf("invalid variable name %q", name)
+			}
+		} else {
+			for end+1 < len(rs) && (rs[end+1] == '_' || unicode.IsLetter(rs[end+1]) || (end > i && unicode.IsDigit(rs[end+1]))) {
+				end++
+			}
+			if end == i {
+				// A $ which does not start a name is literal
+				current.WriteRune('$')
+				return i, nil
+			}
+			name = string(rs[i+1 : end+1])
+		}
+		v, ok := vars[name]
+		if !ok {
+			undefined = append(undefined, name)
+		}
+		current.WriteString(v)
+		return end, nil
+	}
+	var err error
+	for i := 0; i < len(rs); i++ {
+		switch r := rs[i]; r {
+		case '\\':
+			if i+1 == len(rs) {
+				return nil, errors.New("trailing backslash")
+			}
+			i++
+			current.WriteRune(rs[i])
+			inArg = true
+		case '\'':
+			closing := slices.Index(rs[i+1:], '\'')
+			if closing < 0 {
+				return nil, errors.New("unterminated ' quote")
+			}
+			current.WriteString(string(rs[i+1 : i+1+closing]))
+			i += 1 + closing
+			inArg = true
+		case '"':
+			inArg = true
+			closed := false
+			for i++; i < len(rs) && !closed; i++ {
+				switch rs[i] {
+				case '"':
+					closed = true
+					i-- // the outer loop advances past the closing quote
+				case '\\':
+					if i+1 < len(rs) && strings.ContainsRune(`\"$`, rs[i+1]) {
+						i++
+					}
+					current.WriteRune(rs[i])
+				case '$':
+					if i, err = expand(i); err != nil {
+						return nil, err
+					}
+				default:
+					current.WriteRune(rs[i])
+				}
+			}
+			if !closed {
+				return nil, errors.New("unterminated \" quote")
+			}
+		case '$':
+			if i, err = expand(i); err != nil {
+				return nil, err
+			}
+			inArg = true
+		case ' ', '\t':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if len(undefined) > 0 {
+		return nil, fmt.Errorf("%w: %s", errBatchUndefinedVar, strings.Join(undefined, ", "))
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitBatchArgs(t *testing.T) {
+	t.Parallel()
+	vars := map[string]string{"exchange": "binance", "pair": "BTC USDT", "empty": ""}
+	for _, tc := range []struct {
+		name    string
+		in      string
+		want    []string
+		err     error
+		invalid bool
+	}{
+		{name: "whitespace", in: "getticker  --exchange\tbinance", want: []string{"getticker", "--exchange", "binance"}},
+		{name: "empty", in: "", want: nil},
+		{name: "double quotes group", in: `submitorder --note "two words"`, want: []string{"submitorder", "--note", "two words"}},
+		{name: "single quotes group", in: `submitorder --note 'two words'`, want: []string{"submitorder", "--note", "two words"}},
+		{name: "adjacent quotes join", in: `a"b c"'d e'f`, want: []string{"ab cd ef"}},
+		{name: "empty quotes", in: `a "" ''`, want: []string{"a", "", ""}},
+		{name: "escaped space", in: `a\ b c`, want: []string{"a b", "c"}},
+		{name: "escaped quote", in: `a\"b`, want: []string{`a"b`}},
+		{name: "variable", in: "--exchange $exchange", want: []string{"--exchange", "binance"}},
+		{name: "braced variable", in: "${exchange}us", want: []string{"binanceus"}},
+		{name: "variable name ends", in: "$exchange-spot", want: []string{"binance-spot"}},
+		{name: "unquoted variable is one argument", in: "$pair", want: []string{"BTC USDT"}},
+		{name: "empty variable", in: "a $empty", want: []string{"a", ""}},
+		{name: "double quoted variable", in: `"$exchange x"`, want: []string{"binance x"}},
+		{name: "single quoted variable", in: `'$exchange ${pair}'`, want: []string{"$exchange ${pair}"}},
+		{name: "single quoted undefined variable", in: `'$missing'`, want: []string{"$missing"}},
+		{name: "literal dollar", in: "$$exchange", want: []string{"$exchange"}},
+		{name: "double quoted literal dollar", in: `"$$exchange"`, want: []string{"$exchange"}},
+		{name: "escaped dollar", in: `\$exchange "\$exchange"`, want: []string{"$exchange", "$exchange"}},
+		{name: "lone dollar", in: "$ 5$", want: []string{"$", "5$"}},
+		{name: "double quoted backslash", in: `"a\b\\c\"d"`, want: []string{`a\b\c"d`}},
+		{name: "single quoted backslash", in: `'a\b\'`, want: []string{`a\b\`}},
+		{name: "undefined variable", in: "$missing ${other}", err: errBatchUndefinedVar},
+		{name: "unterminated double quote", in: `"abc`, invalid: true},
+		{name: "unterminated single quote", in: `'abc`, invalid: true},
+		{name: "trailing backslash", in: `abc\`, invalid: true},
+		{name: "unterminated brace", in: "${exchange", invalid: true},
+		{name: "invalid braced name", in: "${1x}", invalid: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := splitBatchArgs(tc.in, vars)
+			switch {
+			case tc.err != nil:
+				assert.ErrorIs(t, err, tc.err)
+			case tc.invalid:
+				assert.Error(t, err, "invalid syntax should error")
+			default:
+				require.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestParseBatchLine(t *testing.T) {
+	t.Parallel()
+	vars := map[string]string{"exchange": "binance"}
+	for _, tc := range []struct {
+		name string
+		in   string
+		want *batchLine
+		err  error
+	}{
+		{name: "blank", in: "  \t"},
+		{name: "comment", in: "  # cancelallorders"},
+		{name: "command", in: " getticker --exchange $exchange ", want: &batchLine{args: []string{"getticker", "--exchange", "binance"}}},
+		{name: "ignore error", in: "- cancelallorders", want: &batchLine{args: []string{"cancelallorders"}, ignoreError: true}},
+		{name: "ignore error without space", in: "-cancelallorders", want: &batchLine{args: []string{"cancelallorders"}, ignoreError: true}},
+		{name: "single quoted", in: `set note '$exchange'`, want: &batchLine{args: []string{"set", "note", "$exchange"}}},
+		{name: "missing command", in: "-", err: errBatchSyntax},
+		{name: "empty quoted command", in: `""`, want: &batchLine{args: []string{""}}},
+		{name: "undefined variable", in: "getticker $missing", err: errBatchUndefinedVar},
+		{name: "unterminated quote", in: `getticker "binance`, err: errBatchSyntax},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseBatchLine(3, tc.in, vars)
+			if tc.err != nil {
+				require.ErrorIs(t, err, tc.err)
+				assert.Contains(t, err.Error(), "line 3", "errors should include the line number")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -43,7 +43,7 @@ func getInfo(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -69,7 +69,7 @@ func getSubsystems(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -119,7 +119,7 @@ func enableSubsystem(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -169,7 +169,7 @@ func disableSubsystem(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -195,7 +195,124 @@ func getRPCEndpoints(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
+	return nil
+}
+
+var getRPCCapabilitiesCommand = &cli.Command{
+	Name:   "getrpccapabilities",
+	Usage:  "gets the gRPC API versions served and the deprecation status of each method",
+	Action: getRPCCapabilities,
+}
+
+func getRPCCapabilities(c *cli.Context) error {
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetRPCCapabilities(c.Context,
+		&gctrpc.GetRPCCapabilitiesRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printOutput(result)
+	return nil
+}
+
+var captureProfileCommand = &cli.Command{
+	Name:      "captureprofile",
+	Usage:     "captures a runtime profile of the running engine, saving it to the profiler output path",
+	ArgsUsage: "<kind> <duration>",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "kind",
+			Usage: "the profile to capture, cpu, heap, allocs, goroutine, block, mutex or threadcreate",
+			Value: "cpu",
+		},
+		&cli.StringFlag{
+			Name:  "duration",
+			Usage: "how long to sample cpu usage for, e.g. 30s",
+		},
+		&cli.BoolFlag{
+			Name:  "flamegraph",
+			Usage: "also saves the profile as folded stacks for flamegraph tools",
+		},
+	},
+	Action: captureProfile,
+}
+
+func captureProfile(c *cli.Context) error {
+	kind := c.String("kind")
+	if !c.IsSet("kind") && c.Args().First() != "" {
+		kind = c.Args().First()
+	}
+
+	var duration string
+	if c.IsSet("duration") {
+		duration = c.String("duration")
+	} else {
+		duration = c.Args().Get(1)
+	}
+	if duration != "" {
+		d, err := time.ParseDuration(duration)
+		if err != nil {
+			return err
+		}
+		// Allows the capture to complete before the request times out
+		if d+defaultTimeout > timeout {
+			timeout = d + defaultTimeout
+		}
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.CaptureProfile(c.Context,
+		&gctrpc.CaptureProfileRequest{
+			Kind:       kind,
+			Duration:   duration,
+			Flamegraph: c.Bool("flamegraph"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printOutput(result)
+	return nil
+}
+
+var getMemoryUsageCommand = &cli.Command{
+	Name:   "getmemoryusage",
+	Usage:  "gets the approximate memory used by the orderbook, ticker and trade buffer caches",
+	Action: getMemoryUsage,
+}
+
+func getMemoryUsage(c *cli.Context) error {
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetMemoryUsage(c.Context,
+		&gctrpc.GetMemoryUsageRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printOutput(result)
 	return nil
 }
 
@@ -221,7 +338,7 @@ func getCommunicationRelayers(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -261,7 +378,7 @@ func getExchanges(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -307,7 +424,7 @@ func enableExchange(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -353,7 +470,7 @@ func disableExchange(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -399,7 +516,7 @@ func getExchangeOTPCode(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -424,7 +541,7 @@ func getExchangeOTPCodes(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -470,7 +587,7 @@ func getExchangeInfo(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -559,7 +676,7 @@ func getTicker(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -582,7 +699,7 @@ func getTickers(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -642,7 +759,7 @@ func getAccountInfo(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -774,7 +891,7 @@ func updateAccountInfo(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -797,7 +914,7 @@ func getConfig(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -820,7 +937,7 @@ func getPortfolio(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -843,14 +960,14 @@ func getPortfolioSummary(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
 var addPortfolioAddressCommand = &cli.Command{
 	Name:      "addportfolioaddress",
 	Usage:     "adds an address to the portfolio",
-	ArgsUsage: "<address> <coin_type> <description> <balance> <cold_storage> <supported_exchanges> ",
+	ArgsUsage: "<address> <coin_type> <description> <balance> <cold_storage> <supported_exchanges> <chain>",
 	Action:    addPortfolioAddress,
 	Flags: []cli.Flag{
 		&cli.StringFlag{
@@ -863,7 +980,7 @@ var addPortfolioAddressCommand = &cli.Command{
 		},
 		&cli.StringFlag{
 			Name:  "description",
-			Usage: "description of the address",
+			Usage: "description of the address, use 'Manual' for off-exchange holdings such as bank balances whose balance is set manually",
 		},
 		&cli.Float64Flag{
 			Name:  "balance",
@@ -877,6 +994,10 @@ var addPortfolioAddressCommand = &cli.Command{
 			Name:  "supported_exchanges",
 			Usage: "common separated list of exchanges supported by this address for withdrawals",
 		},
+		&cli.StringFlag{
+			Name:  "chain",
+			Usage: "the chain to fetch the address native and token balances from e.g. ('solana', 'tron', 'arbitrum', 'base')",
+		},
 	},
 }
 
@@ -934,6 +1055,13 @@ func addPortfolioAddress(c *cli.Context) error {
 		supportedExchanges = c.Args().Get(5)
 	}
 
+	var chain string
+	if c.IsSet("chain") {
+		chain = c.String("chain")
+	} else {
+		chain = c.Args().Get(6)
+	}
+
 	conn, cancel, err := setupClient(c)
 	if err != nil {
 		return err
@@ -949,6 +1077,7 @@ func addPortfolioAddress(c *cli.Context) error {
 			Balance:            balance,
 			SupportedExchanges: supportedExchanges,
 			ColdStorage:        coldstorage,
+			Chain:              chain,
 		},
 	)
 
@@ -956,7 +1085,7 @@ func addPortfolioAddress(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -1027,7 +1156,7 @@ func removePortfolioAddress(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -1050,7 +1179,7 @@ func getForexProviders(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -1073,7 +1202,7 @@ func getForexRates(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -1198,7 +1327,7 @@ func getOrders(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -1284,7 +1413,7 @@ func getManagedOrders(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -1379,7 +1508,7 @@ func getOrder(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -1426,6 +1555,18 @@ var submitOrderCommand = &cli.Command{
 			Usage:    "required asset type",
 			Required: false,
 		},
+		&cli.StringFlag{
+			Name:  "strategy_tag",
+			Usage: "the optional strategy tag used to filter and cancel the strategy's orders",
+		},
+		&cli.StringFlag{
+			Name:  "self_trade_prevention",
+			Usage: "the optional self trade prevention mode (CANCEL_MAKER, CANCEL_TAKER, CANCEL_BOTH OR DECREMENT)",
+		},
+		&cli.Float64Flag{
+			Name:  "display_amount",
+			Usage: "the optional visible amount of an iceberg limit order",
+		},
 	},
 }
 
@@ -1552,18 +1693,141 @@ func submitOrder(c *cli.Context) error {
 			Base:      p.Base.String(),
 			Quote:     p.Quote.String(),
 		},
-		Side:      orderSide,
-		OrderType: orderType,
-		Amount:    amount,
-		Price:     price,
-		ClientId:  clientID,
-		AssetType: assetType,
+		Side:                orderSide,
+		OrderType:           orderType,
+		Amount:              amount,
+		Price:               price,
+		ClientId:            clientID,
+		AssetType:           assetType,
+		StrategyTag:         c.String("strategy_tag"),
+		SelfTradePrevention: c.String("self_trade_prevention"),
+		DisplayAmount:       c.Float64("display_amount"),
 	})
 	if err != nil {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
+	return nil
+}
+
+var submitOptionStrategyCommand = &cli.Command{
+	Name:      "submitoptionstrategy",
+	Usage:     "submits a multi-leg option strategy as a combo order or as individual legs",
+	ArgsUsage: "<exchange> <strategy> <legs> <amount> <fill_timeout>",
+	Action:    submitOptionStrategy,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange to submit the strategy for",
+		},
+		&cli.StringFlag{
+			Name:  "strategy",
+			Usage: "the strategy type (VERTICAL, STRADDLE, CALENDAR OR CUSTOM)",
+		},
+		&cli.StringFlag{
+			Name:  "legs",
+			Usage: "comma separated legs as contract:side with an optional :ratio and :price e.g. BTC-27DEC24-60000-C:buy,BTC-27DEC24-70000-C:sell",
+		},
+		&cli.Float64Flag{
+			Name:  "amount",
+			Usage: "the amount of the strategy, each leg's amount is scaled by its ratio",
+		},
+		&cli.StringFlag{
+			Name:  "fill_timeout",
+			Usage: "the optional time each leg may take to fill before the strategy is unwound, when not submitted as a combo order e.g. 30s",
+		},
+	},
+}
+
+func submitOptionStrategy(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	var strategy string
+	if c.IsSet("strategy") {
+		strategy = c.String("strategy")
+	} else {
+		strategy = c.Args().Get(1)
+	}
+
+	var legs string
+	if c.IsSet("legs") {
+		legs = c.String("legs")
+	} else {
+		legs = c.Args().Get(2)
+	}
+
+	var amount float64
+	if c.IsSet("amount") {
+		amount = c.Float64("amount")
+	} else if c.Args().Get(3) != "" {
+		var err error
+		amount, err = strconv.ParseFloat(c.Args().Get(3), 64)
+		if err != nil {
+			return err
+		}
+	}
+
+	if amount == 0 {
+		return errors.New("amount must be set")
+	}
+
+	var fillTimeout string
+	if c.IsSet("fill_timeout") {
+		fillTimeout = c.String("fill_timeout")
+	} else {
+		fillTimeout = c.Args().Get(4)
+	}
+
+	req := &gctrpc.SubmitOptionStrategyRequest{
+		Exchange:    exchangeName,
+		Strategy:    strategy,
+		Amount:      amount,
+		FillTimeout: fillTimeout,
+	}
+	for _, leg := range strings.Split(legs, ",") {
+		parts := strings.Split(leg, ":")
+		if len(parts) < 2 || len(parts) > 4 {
+			return fmt.Errorf("invalid leg %q, expected contract:side[:ratio[:price]]", leg)
+		}
+		l := &gctrpc.OptionStrategyLeg{Pair: parts[0], Side: parts[1]}
+		if len(parts) > 2 {
+			var err error
+			if l.Ratio, err = strconv.ParseFloat(parts[2], 64); err != nil {
+				return err
+			}
+		}
+		if len(parts) > 3 {
+			var err error
+			if l.Price, err = strconv.ParseFloat(parts[3], 64); err != nil {
+				return err
+			}
+		}
+		req.Legs = append(req.Legs, l)
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.SubmitOptionStrategy(c.Context, req)
+	if err != nil {
+		return err
+	}
+
+	printOutput(result)
 	return nil
 }
 
@@ -1668,7 +1932,7 @@ func simulateOrder(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -1810,7 +2074,7 @@ func cancelOrder(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -1952,13 +2216,13 @@ func cancelBatchOrders(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
 var cancelAllOrdersCommand = &cli.Command{
 	Name:      "cancelallorders",
-	Usage:     "cancels all orders (all or by exchange name)",
+	Usage:     "cancels all orders on an exchange, optionally scoped to tracked orders matching the filters",
 	ArgsUsage: "<exchange>",
 	Action:    cancelAllOrders,
 	Flags: []cli.Flag{
@@ -1966,6 +2230,26 @@ var cancelAllOrdersCommand = &cli.Command{
 			Name:  "exchange",
 			Usage: "the exchange to cancel all orders on",
 		},
+		&cli.StringFlag{
+			Name:  "asset",
+			Usage: "the optional asset type to cancel orders for",
+		},
+		&cli.StringFlag{
+			Name:  "pair",
+			Usage: "the optional currency pair to cancel orders for, requires an asset",
+		},
+		&cli.StringFlag{
+			Name:  "side",
+			Usage: "the optional order side to cancel",
+		},
+		&cli.StringFlag{
+			Name:  "type",
+			Usage: "the optional order type to cancel",
+		},
+		&cli.StringFlag{
+			Name:  "strategy_tag",
+			Usage: "the optional strategy tag to cancel orders for",
+		},
 	},
 }
 
@@ -2010,6 +2294,24 @@ func cancelAllOrders(c *cli.Context) error {
 		exchangeName = c.Args().First()
 	}
 
+	assetType := strings.ToLower(c.String("asset"))
+	if assetType != "" && !validAsset(assetType) {
+		return errInvalidAsset
+	}
+
+	var pair *gctrpc.CurrencyPair
+	if c.IsSet("pair") {
+		p, err := currency.NewPairDelimiter(c.String("pair"), pairDelimiter)
+		if err != nil {
+			return err
+		}
+		pair = &gctrpc.CurrencyPair{
+			Delimiter: p.Delimiter,
+			Base:      p.Base.String(),
+			Quote:     p.Quote.String(),
+		}
+	}
+
 	conn, cancel, err := setupClient(c)
 	if err != nil {
 		return err
@@ -2018,13 +2320,18 @@ func cancelAllOrders(c *cli.Context) error {
 
 	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
 	result, err := client.CancelAllOrders(c.Context, &gctrpc.CancelAllOrdersRequest{
-		Exchange: exchangeName,
+		Exchange:    exchangeName,
+		Asset:       assetType,
+		Pair:        pair,
+		Side:        c.String("side"),
+		OrderType:   c.String("type"),
+		StrategyTag: c.String("strategy_tag"),
 	})
 	if err != nil {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -2113,7 +2420,7 @@ func modifyOrder(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -2136,7 +2443,7 @@ func getEvents(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -2298,7 +2605,7 @@ func addEvent(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -2348,7 +2655,7 @@ func removeEvent(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -2390,7 +2697,7 @@ func getCryptocurrencyDepositAddresses(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -2410,7 +2717,7 @@ var getCryptocurrencyDepositAddressCommand = &cli.Command{
 		},
 		&cli.StringFlag{
 			Name:  "chain",
-			Usage: "the chain to use for the deposit",
+			Usage: "the chain to use for the deposit, either the exchange's chain name or a network such as 'ERC20' or 'TRC20'",
 		},
 		&cli.BoolFlag{
 			Name:  "bypass",
@@ -2480,7 +2787,7 @@ func getCryptocurrencyDepositAddress(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -2542,84 +2849,333 @@ func getAvailableTransferChains(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
-var withdrawCryptocurrencyFundsCommand = &cli.Command{
-	Name:      "withdrawcryptofunds",
-	Usage:     "withdraws cryptocurrency funds from the desired exchange",
-	ArgsUsage: "<exchange> <currency> <amount> <address> <addresstag> <fee> <description> <chain>",
-	Action:    withdrawCryptocurrencyFunds,
+var getTransferNetworksCommand = &cli.Command{
+	Name:      "gettransfernetworks",
+	Usage:     "gets the normalised transfer networks of a cryptocurrency on an exchange, including each network's withdrawal fee and minimums",
+	ArgsUsage: "<exchange> <cryptocurrency>",
+	Action:    getTransferNetworks,
 	Flags: []cli.Flag{
 		&cli.StringFlag{
 			Name:  "exchange",
-			Usage: "the exchange to withdraw from",
-		},
-		&cli.StringFlag{
-			Name:  "currency",
-			Usage: "the cryptocurrency to withdraw funds from",
-		},
-		&cli.StringFlag{
-			Name:  "address",
-			Usage: "address to withdraw to",
-		},
-		&cli.StringFlag{
-			Name:  "addresstag",
-			Usage: "address tag/memo",
-		},
-		&cli.Float64Flag{
-			Name:  "amount",
-			Usage: "amount of funds to withdraw",
-		},
-		&cli.Float64Flag{
-			Name:  "fee",
-			Usage: "fee to submit with request",
-		},
-		&cli.StringFlag{
-			Name:  "description",
-			Usage: "description to submit with request",
+			Usage: "the exchange to get the transfer networks from",
 		},
 		&cli.StringFlag{
-			Name:  "chain",
-			Usage: "chain to use for the withdrawal",
+			Name:  "cryptocurrency",
+			Usage: "the cryptocurrency to get the transfer networks for",
 		},
 	},
 }
 
-func withdrawCryptocurrencyFunds(c *cli.Context) error {
+func getTransferNetworks(c *cli.Context) error {
 	if c.NArg() == 0 && c.NumFlags() == 0 {
 		return cli.ShowSubcommandHelp(c)
 	}
 
-	var exchange, cur, address, addressTag, chain, description string
-	var amount, fee float64
-
+	var exchangeName string
 	if c.IsSet("exchange") {
-		exchange = c.String("exchange")
-	} else if c.Args().Get(0) != "" {
-		exchange = c.Args().Get(0)
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
 	}
 
-	if c.IsSet("currency") {
-		cur = c.String("currency")
-	} else if c.Args().Get(1) != "" {
-		cur = c.Args().Get(1)
+	var cryptocurrency string
+	if c.IsSet("cryptocurrency") {
+		cryptocurrency = c.String("cryptocurrency")
+	} else {
+		cryptocurrency = c.Args().Get(1)
 	}
 
-	if c.IsSet("amount") {
-		amount = c.Float64("amount")
-	} else if c.Args().Get(2) != "" {
-		amountStr, err := strconv.ParseFloat(c.Args().Get(2), 64)
-		if err == nil {
-			amount = amountStr
-		}
+	if cryptocurrency == "" {
+		return errors.New("cryptocurrency must be set")
 	}
 
-	if c.IsSet("address") {
-		address = c.String("address")
-	} else if c.Args().Get(3) != "" {
-		address = c.Args().Get(3)
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetTransferNetworks(c.Context,
+		&gctrpc.GetTransferNetworksRequest{
+			Exchange:       exchangeName,
+			Cryptocurrency: cryptocurrency,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printOutput(result)
+	return nil
+}
+
+var convertCurrencyCommand = &cli.Command{
+	Name:      "convertcurrency",
+	Usage:     "gets a quote to convert one currency into another using an exchange's native conversion service, and accepts it when requested",
+	ArgsUsage: "<exchange> <from> <to> <amount> <accept>",
+	Action:    convertCurrency,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange to convert on",
+		},
+		&cli.StringFlag{
+			Name:  "from",
+			Usage: "the currency to convert from",
+		},
+		&cli.StringFlag{
+			Name:  "to",
+			Usage: "the currency to convert into",
+		},
+		&cli.Float64Flag{
+			Name:  "amount",
+			Usage: "the amount of the from currency to convert",
+		},
+		&cli.BoolFlag{
+			Name:  "accept",
+			Usage: "accepts the quote immediately, otherwise only the quote is returned",
+		},
+	},
+}
+
+func convertCurrency(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	var from string
+	if c.IsSet("from") {
+		from = c.String("from")
+	} else {
+		from = c.Args().Get(1)
+	}
+
+	var to string
+	if c.IsSet("to") {
+		to = c.String("to")
+	} else {
+		to = c.Args().Get(2)
+	}
+
+	var amount float64
+	if c.IsSet("amount") {
+		amount = c.Float64("amount")
+	} else if c.Args().Get(3) != "" {
+		var err error
+		amount, err = strconv.ParseFloat(c.Args().Get(3), 64)
+		if err != nil {
+			return err
+		}
+	}
+
+	var accept bool
+	if c.IsSet("accept") {
+		accept = c.Bool("accept")
+	} else if c.Args().Get(4) != "" {
+		var err error
+		accept, err = strconv.ParseBool(c.Args().Get(4))
+		if err != nil {
+			return err
+		}
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.ConvertCurrency(c.Context,
+		&gctrpc.ConvertCurrencyRequest{
+			Exchange: exchangeName,
+			From:     from,
+			To:       to,
+			Amount:   amount,
+			Accept:   accept,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printOutput(result)
+	return nil
+}
+
+var dustSweepCommand = &cli.Command{
+	Name:      "dustsweep",
+	Usage:     "converts balances too small to trade into a single currency, sweeping all eligible balances when no currencies are specified",
+	ArgsUsage: "<exchange> <currencies> <to> <dryrun>",
+	Action:    dustSweep,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange to sweep dust on",
+		},
+		&cli.StringFlag{
+			Name:  "currencies",
+			Usage: "comma separated currencies to sweep, all eligible balances are swept when unset",
+		},
+		&cli.StringFlag{
+			Name:  "to",
+			Usage: "the currency to convert dust into, the exchange's default is used when unset",
+		},
+		&cli.BoolFlag{
+			Name:  "dryrun",
+			Usage: "only lists the balances which would be swept",
+		},
+	},
+}
+
+func dustSweep(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	var currencies string
+	if c.IsSet("currencies") {
+		currencies = c.String("currencies")
+	} else {
+		currencies = c.Args().Get(1)
+	}
+
+	var to string
+	if c.IsSet("to") {
+		to = c.String("to")
+	} else {
+		to = c.Args().Get(2)
+	}
+
+	var dryRun bool
+	if c.IsSet("dryrun") {
+		dryRun = c.Bool("dryrun")
+	} else if c.Args().Get(3) != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(c.Args().Get(3))
+		if err != nil {
+			return err
+		}
+	}
+
+	req := &gctrpc.SweepDustRequest{
+		Exchange: exchangeName,
+		To:       to,
+		DryRun:   dryRun,
+	}
+	if currencies != "" {
+		req.Currencies = strings.Split(currencies, ",")
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.SweepDust(c.Context, req)
+	if err != nil {
+		return err
+	}
+
+	printOutput(result)
+	return nil
+}
+
+var withdrawCryptocurrencyFundsCommand = &cli.Command{
+	Name:      "withdrawcryptofunds",
+	Usage:     "withdraws cryptocurrency funds from the desired exchange",
+	ArgsUsage: "<exchange> <currency> <amount> <address> <addresstag> <fee> <description> <chain>",
+	Action:    withdrawCryptocurrencyFunds,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange to withdraw from",
+		},
+		&cli.StringFlag{
+			Name:  "currency",
+			Usage: "the cryptocurrency to withdraw funds from",
+		},
+		&cli.StringFlag{
+			Name:  "address",
+			Usage: "address to withdraw to",
+		},
+		&cli.StringFlag{
+			Name:  "addresstag",
+			Usage: "address tag/memo",
+		},
+		&cli.Float64Flag{
+			Name:  "amount",
+			Usage: "amount of funds to withdraw",
+		},
+		&cli.Float64Flag{
+			Name:  "fee",
+			Usage: "fee to submit with request",
+		},
+		&cli.StringFlag{
+			Name:  "description",
+			Usage: "description to submit with request",
+		},
+		&cli.StringFlag{
+			Name:  "chain",
+			Usage: "chain to use for the withdrawal, either the exchange's chain name or a network such as 'ERC20' or 'TRC20'",
+		},
+	},
+}
+
+func withdrawCryptocurrencyFunds(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+
+	var exchange, cur, address, addressTag, chain, description string
+	var amount, fee float64
+
+	if c.IsSet("exchange") {
+		exchange = c.String("exchange")
+	} else if c.Args().Get(0) != "" {
+		exchange = c.Args().Get(0)
+	}
+
+	if c.IsSet("currency") {
+		cur = c.String("currency")
+	} else if c.Args().Get(1) != "" {
+		cur = c.Args().Get(1)
+	}
+
+	if c.IsSet("amount") {
+		amount = c.Float64("amount")
+	} else if c.Args().Get(2) != "" {
+		amountStr, err := strconv.ParseFloat(c.Args().Get(2), 64)
+		if err == nil {
+			amount = amountStr
+		}
+	}
+
+	if c.IsSet("address") {
+		address = c.String("address")
+	} else if c.Args().Get(3) != "" {
+		address = c.Args().Get(3)
 	}
 
 	if c.IsSet("addresstag") {
@@ -2672,7 +3228,7 @@ func withdrawCryptocurrencyFunds(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -2765,51 +3321,221 @@ func withdrawFiatFunds(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
-var withdrawalRequestCommand = &cli.Command{
-	Name:      "withdrawalrequesthistory",
-	Usage:     "retrieve previous withdrawal request details",
-	ArgsUsage: "<type> <args>",
-	Subcommands: []*cli.Command{
-		{
-			Name:      "byid",
-			Usage:     "id",
-			ArgsUsage: "<id>",
-			Flags: []cli.Flag{
-				&cli.StringFlag{
-					Name:  "id",
-					Usage: "withdrawal id",
-				},
-			},
-			Action: withdrawlRequestByID,
+var getWithdrawalSigningPayloadCommand = &cli.Command{
+	Name:      "getwithdrawalsigningpayload",
+	Usage:     "gets the payload to be signed offline to approve a withdrawal awaiting offline approval",
+	ArgsUsage: "<id> <output>",
+	Action:    getWithdrawalSigningPayload,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "id",
+			Usage: "the id of the withdrawal awaiting offline approval",
 		},
-		{
-			Name:      "byexchangeid",
-			Usage:     "exchange id",
-			ArgsUsage: "<id>",
-			Flags: []cli.Flag{
-				&cli.StringFlag{
-					Name:  "exchange",
-					Usage: "exchange name",
-				},
-				&cli.StringFlag{
-					Name:  "id",
-					Usage: "withdrawal id",
-				},
-			},
-			Action: withdrawlRequestByExchangeID,
+		&cli.StringFlag{
+			Name:  "output",
+			Usage: "optional file to write the payload to for signing",
 		},
-		{
-			Name:      "byexchange",
-			Usage:     "exchange limit",
-			ArgsUsage: "<id>",
-			Flags: []cli.Flag{
-				&cli.StringFlag{
-					Name:  "exchange",
-					Usage: "exchange name",
+	},
+}
+
+func getWithdrawalSigningPayload(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+
+	var id, output string
+	if c.IsSet("id") {
+		id = c.String("id")
+	} else {
+		id = c.Args().First()
+	}
+
+	if c.IsSet("output") {
+		output = c.String("output")
+	} else {
+		output = c.Args().Get(1)
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetWithdrawalSigningPayload(c.Context,
+		&gctrpc.GetWithdrawalSigningPayloadRequest{
+			Id: id,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if output != "" {
+		if err := os.WriteFile(output, []byte(result.Payload), 0o600); err != nil {
+			return err
+		}
+	}
+	printOutput(result)
+	return nil
+}
+
+var submitSignedWithdrawalCommand = &cli.Command{
+	Name:      "submitsignedwithdrawal",
+	Usage:     "submits a withdrawal approved by signing its payload offline",
+	ArgsUsage: "<payload> <signature>",
+	Action:    submitSignedWithdrawal,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "payload",
+			Usage: "the file containing the exact payload which was signed",
+		},
+		&cli.StringFlag{
+			Name:  "signature",
+			Usage: "the hex encoded ed25519 signature of the payload",
+		},
+	},
+}
+
+func submitSignedWithdrawal(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+
+	var payloadFile, signature string
+	if c.IsSet("payload") {
+		payloadFile = c.String("payload")
+	} else {
+		payloadFile = c.Args().First()
+	}
+
+	if c.IsSet("signature") {
+		signature = c.String("signature")
+	} else {
+		signature = c.Args().Get(1)
+	}
+
+	payload, err := os.ReadFile(payloadFile)
+	if err != nil {
+		return err
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.SubmitSignedWithdrawal(c.Context,
+		&gctrpc.SubmitSignedWithdrawalRequest{
+			Payload:   string(payload),
+			Signature: strings.TrimSpace(signature),
+		},
+	)
+	if err != nil {
+		return err
+	}
+	printOutput(result)
+	return nil
+}
+
+var getWithdrawalLocksCommand = &cli.Command{
+	Name:      "getwithdrawallocks",
+	Usage:     "gets the withdrawal locks and delays enforced by exchanges",
+	ArgsUsage: "<exchange> <currency>",
+	Action:    getWithdrawalLocks,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "exchange",
+			Usage: "optional exchange to get withdrawal locks for",
+		},
+		&cli.StringFlag{
+			Name:  "currency",
+			Usage: "optional currency to fetch the exchange's current withdrawal locks for",
+		},
+	},
+}
+
+func getWithdrawalLocks(c *cli.Context) error {
+	var exchangeName, cur string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	if c.IsSet("currency") {
+		cur = c.String("currency")
+	} else {
+		cur = c.Args().Get(1)
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetWithdrawalLocks(c.Context,
+		&gctrpc.GetWithdrawalLocksRequest{
+			Exchange: exchangeName,
+			Currency: cur,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	printOutput(result)
+	return nil
+}
+
+var withdrawalRequestCommand = &cli.Command{
+	Name:      "withdrawalrequesthistory",
+	Usage:     "retrieve previous withdrawal request details",
+	ArgsUsage: "<type> <args>",
+	Subcommands: []*cli.Command{
+		{
+			Name:      "byid",
+			Usage:     "id",
+			ArgsUsage: "<id>",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "id",
+					Usage: "withdrawal id",
+				},
+			},
+			Action: withdrawlRequestByID,
+		},
+		{
+			Name:      "byexchangeid",
+			Usage:     "exchange id",
+			ArgsUsage: "<id>",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "exchange",
+					Usage: "exchange name",
+				},
+				&cli.StringFlag{
+					Name:  "id",
+					Usage: "withdrawal id",
+				},
+			},
+			Action: withdrawlRequestByExchangeID,
+		},
+		{
+			Name:      "byexchange",
+			Usage:     "exchange limit",
+			ArgsUsage: "<id>",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "exchange",
+					Usage: "exchange name",
 				},
 				&cli.Int64Flag{
 					Name:  "limit",
@@ -2889,7 +3615,7 @@ func withdrawlRequestByID(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -2966,7 +3692,7 @@ func withdrawlRequestByExchangeID(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -3040,7 +3766,7 @@ func withdrawlRequestByDate(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -3089,7 +3815,7 @@ func getLoggerDetails(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -3155,7 +3881,7 @@ func setLoggerDetails(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -3272,30 +3998,614 @@ func getTickerStream(c *cli.Context) error {
 	}
 }
 
-var getExchangeTickerStreamCommand = &cli.Command{
-	Name:      "getexchangetickerstream",
-	Usage:     "gets a stream for all tickers associated with an exchange",
-	ArgsUsage: "<exchange>",
-	Action:    getExchangeTickerStream,
+var getExchangeTickerStreamCommand = &cli.Command{
+	Name:      "getexchangetickerstream",
+	Usage:     "gets a stream for all tickers associated with an exchange",
+	ArgsUsage: "<exchange>",
+	Action:    getExchangeTickerStream,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange to get the ticker from",
+		},
+	},
+}
+
+func getExchangeTickerStream(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetExchangeTickerStream(c.Context,
+		&gctrpc.GetExchangeTickerStreamRequest{
+			Exchange: exchangeName,
+		})
+
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := result.Recv()
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Ticker stream for %s %s:\n",
+			exchangeName,
+			resp.Pair.String())
+
+		fmt.Printf("LAST: %f HIGH: %f LOW: %f BID: %f ASK: %f VOLUME: %f PRICEATH: %f LASTUPDATED: %d\n",
+			resp.Last,
+			resp.High,
+			resp.Low,
+			resp.Bid,
+			resp.Ask,
+			resp.Volume,
+			resp.PriceAth,
+			resp.LastUpdated)
+	}
+}
+
+var streamFillsCommand = &cli.Command{
+	Name:      "streamfills",
+	Usage:     "streams stored fills followed by new fills as they are stored, resuming after the supplied resume token",
+	ArgsUsage: "<resumetoken> <exchange> <asset>",
+	Action:    streamFills,
+	Flags: []cli.Flag{
+		&cli.Int64Flag{
+			Name:  "resumetoken",
+			Usage: "optional - the resume token of the last fill received, fills stored after it are replayed",
+		},
+		&cli.StringFlag{
+			Name:  "exchange",
+			Usage: "optional - the exchange to stream fills for",
+		},
+		&cli.StringFlag{
+			Name:  "asset",
+			Usage: "optional - the asset type to stream fills for",
+		},
+	},
+}
+
+func streamFills(c *cli.Context) error {
+	var (
+		resumeToken int64
+		err         error
+	)
+	if c.IsSet("resumetoken") {
+		resumeToken = c.Int64("resumetoken")
+	} else if c.Args().First() != "" {
+		resumeToken, err = strconv.ParseInt(c.Args().First(), 10, 64)
+		if err != nil {
+			return err
+		}
+	}
+
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().Get(1)
+	}
+
+	var assetType string
+	if c.IsSet("asset") {
+		assetType = c.String("asset")
+	} else {
+		assetType = c.Args().Get(2)
+	}
+	assetType = strings.ToLower(assetType)
+	if assetType != "" && !validAsset(assetType) {
+		return errInvalidAsset
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.StreamFills(c.Context,
+		&gctrpc.StreamFillsRequest{
+			ResumeToken: resumeToken,
+			Exchange:    exchangeName,
+			Asset:       assetType,
+		})
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := result.Recv()
+		if err != nil {
+			return err
+		}
+		printOutput(resp)
+	}
+}
+
+var getAuditEventCommand = &cli.Command{
+	Name:      "getauditevent",
+	Usage:     "gets audit events matching query parameters",
+	ArgsUsage: "<starttime> <endtime> <orderby> <limit>",
+	Action:    getAuditEvent,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "start",
+			Aliases:     []string{"s"},
+			Usage:       "start date to search",
+			Value:       time.Now().Add(-time.Hour).Format(time.DateTime),
+			Destination: &startTime,
+		},
+		&cli.StringFlag{
+			Name:        "end",
+			Aliases:     []string{"e"},
+			Usage:       "end time to search",
+			Value:       time.Now().Format(time.DateTime),
+			Destination: &endTime,
+		},
+		&cli.StringFlag{
+			Name:        "order",
+			Aliases:     []string{"o"},
+			Usage:       "order results by ascending/descending",
+			Value:       "asc",
+			Destination: &orderingDirection,
+		},
+		&cli.IntFlag{
+			Name:        "limit",
+			Aliases:     []string{"l"},
+			Usage:       "how many results to retrieve",
+			Value:       100,
+			Destination: &limit,
+		},
+	},
+}
+
+func getAuditEvent(c *cli.Context) error {
+	if !c.IsSet("start") {
+		if c.Args().Get(0) != "" {
+			startTime = c.Args().Get(0)
+		}
+	}
+
+	if !c.IsSet("end") {
+		if c.Args().Get(1) != "" {
+			endTime = c.Args().Get(1)
+		}
+	}
+
+	if !c.IsSet("order") {
+		if c.Args().Get(2) != "" {
+			orderingDirection = c.Args().Get(2)
+		}
+	}
+
+	if !c.IsSet("limit") {
+		if c.Args().Get(3) != "" {
+			limitStr, err := strconv.ParseInt(c.Args().Get(3), 10, 64)
+			if err == nil {
+				limit = int(limitStr)
+			}
+		}
+	}
+
+	s, err := time.ParseInLocation(time.DateTime, startTime, time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid time format for start: %v", err)
+	}
+
+	e, err := time.ParseInLocation(time.DateTime, endTime, time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid time format for end: %v", err)
+	}
+
+	if e.Before(s) {
+		return common.ErrStartAfterEnd
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+
+	result, err := client.GetAuditEvent(c.Context,
+		&gctrpc.GetAuditEventRequest{
+			StartDate: s.Format(common.SimpleTimeFormatWithTimezone),
+			EndDate:   e.Format(common.SimpleTimeFormatWithTimezone),
+			Limit:     int32(limit),
+			OrderBy:   orderingDirection,
+		})
+
+	if err != nil {
+		return err
+	}
+
+	printOutput(result)
+	return nil
+}
+
+var getScheduledTasksCommand = &cli.Command{
+	Name:   "getscheduledtasks",
+	Usage:  "gets the scheduler's tasks, their next run and recent run history",
+	Action: getScheduledTasks,
+}
+
+func getScheduledTasks(c *cli.Context) error {
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetScheduledTasks(c.Context, &gctrpc.GetScheduledTasksRequest{})
+	if err != nil {
+		return err
+	}
+
+	printOutput(result)
+	return nil
+}
+
+var getRateLimitStatusCommand = &cli.Command{
+	Name:      "getratelimitstatus",
+	Usage:     "gets the rate limit usage of each endpoint of an exchange, or of all enabled exchanges",
+	ArgsUsage: "<exchange>",
+	Action:    getRateLimitStatus,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange to get the rate limit status for, all enabled exchanges if empty",
+		},
+	},
+}
+
+func getRateLimitStatus(c *cli.Context) error {
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetRateLimitStatus(c.Context, &gctrpc.GetRateLimitStatusRequest{Exchange: exchangeName})
+	if err != nil {
+		return err
+	}
+
+	printOutput(result)
+	return nil
+}
+
+var getDataQualityScoresCommand = &cli.Command{
+	Name:      "getdataqualityscores",
+	Usage:     "gets the streamed market data quality score of an exchange, or of all monitored exchanges",
+	ArgsUsage: "<exchange>",
+	Action:    getDataQualityScores,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange to get the data quality score for, all monitored exchanges if empty",
+		},
+	},
+}
+
+func getDataQualityScores(c *cli.Context) error {
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetDataQualityScores(c.Context, &gctrpc.GetDataQualityScoresRequest{Exchange: exchangeName})
+	if err != nil {
+		return err
+	}
+
+	printOutput(result)
+	return nil
+}
+
+var getDashboardSnapshotCommand = &cli.Command{
+	Name:      "getdashboardsnapshot",
+	Usage:     "gets tickers, balances, open orders, positions, subsystem status and recent events held by the engine in one call",
+	ArgsUsage: "<sections> <exchanges>",
+	Action:    getDashboardSnapshot,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "sections",
+			Usage: "comma separated sections to get, any of tickers, balances, orders, positions, subsystems and events, all if empty",
+		},
+		&cli.StringFlag{
+			Name:  "exchanges",
+			Usage: "comma separated exchanges to get data for, all enabled exchanges if empty",
+		},
+		&cli.Int64Flag{
+			Name:  "eventlimit",
+			Usage: "the maximum number of recent events to get, all retained events if 0",
+		},
+	},
+}
+
+func getDashboardSnapshot(c *cli.Context) error {
+	req := &gctrpc.GetDashboardSnapshotRequest{EventLimit: c.Int64("eventlimit")}
+	sections := c.String("sections")
+	if !c.IsSet("sections") {
+		sections = c.Args().First()
+	}
+	if sections != "" {
+		req.Sections = strings.Split(sections, ",")
+	}
+	exchanges := c.String("exchanges")
+	if !c.IsSet("exchanges") {
+		exchanges = c.Args().Get(1)
+	}
+	if exchanges != "" {
+		req.Exchanges = strings.Split(exchanges, ",")
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetDashboardSnapshot(c.Context, req)
+	if err != nil {
+		return err
+	}
+
+	printOutput(result)
+	return nil
+}
+
+var getExchangeCalendarCommand = &cli.Command{
+	Name:      "getexchangecalendar",
+	Usage:     "gets upcoming expiries, listings, delistings and maintenance windows from the exchange calendar",
+	ArgsUsage: "<exchange> <asset> <pair> <types>",
+	Action:    getExchangeCalendar,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "exchange",
+			Usage: "the exchange to get events for, all exchanges if empty",
+		},
+		&cli.StringFlag{
+			Name:  "asset",
+			Usage: "the asset type to get events for",
+		},
+		&cli.StringFlag{
+			Name:  "pair",
+			Usage: "the currency pair to get events for",
+		},
+		&cli.StringFlag{
+			Name:  "types",
+			Usage: "comma separated event types to get, any of expiry, listing, delisting and maintenance",
+		},
+		&cli.StringFlag{
+			Name:  "start",
+			Usage: "only get events occurring after this time",
+		},
+		&cli.StringFlag{
+			Name:  "end",
+			Usage: "only get events occurring before this time",
+		},
+	},
+}
+
+func getExchangeCalendar(c *cli.Context) error {
+	req := &gctrpc.GetExchangeCalendarRequest{
+		Exchange: c.String("exchange"),
+		Asset:    c.String("asset"),
+	}
+	if !c.IsSet("exchange") {
+		req.Exchange = c.Args().First()
+	}
+	if !c.IsSet("asset") {
+		req.Asset = c.Args().Get(1)
+	}
+
+	pairString := c.String("pair")
+	if !c.IsSet("pair") {
+		pairString = c.Args().Get(2)
+	}
+	if pairString != "" {
+		if !validPair(pairString) {
+			return errInvalidPair
+		}
+		p, err := currency.NewPairDelimiter(pairString, pairDelimiter)
+		if err != nil {
+			return err
+		}
+		req.Pair = &gctrpc.CurrencyPair{
+			Delimiter: p.Delimiter,
+			Base:      p.Base.String(),
+			Quote:     p.Quote.String(),
+		}
+	}
+
+	types := c.String("types")
+	if !c.IsSet("types") {
+		types = c.Args().Get(3)
+	}
+	if types != "" {
+		req.Types = strings.Split(types, ",")
+	}
+
+	if c.IsSet("start") {
+		s, err := time.ParseInLocation(time.DateTime, c.String("start"), time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid time format for start: %v", err)
+		}
+		req.StartDate = s.Format(common.SimpleTimeFormatWithTimezone)
+	}
+	if c.IsSet("end") {
+		e, err := time.ParseInLocation(time.DateTime, c.String("end"), time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid time format for end: %v", err)
+		}
+		req.EndDate = e.Format(common.SimpleTimeFormatWithTimezone)
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetExchangeCalendar(c.Context, req)
+	if err != nil {
+		return err
+	}
+
+	printOutput(result)
+	return nil
+}
+
+var getVolumeProfileCommand = &cli.Command{
+	Name:      "getvolumeprofile",
+	Usage:     "gets the volume and market profile of each session of stored candles or trades",
+	ArgsUsage: "<exchange> <pair> <asset> <ticksize>",
+	Action:    getVolumeProfile,
 	Flags: []cli.Flag{
 		&cli.StringFlag{
-			Name:  "exchange",
-			Usage: "the exchange to get the ticker from",
+			Name:    "exchange",
+			Aliases: []string{"e"},
+			Usage:   "the exchange to get the profile for",
+		},
+		&cli.StringFlag{
+			Name:  "pair",
+			Usage: "the currency pair to get the profile for",
+		},
+		&cli.StringFlag{
+			Name:  "asset",
+			Usage: "the asset type of the currency pair",
+		},
+		&cli.Float64Flag{
+			Name:  "ticksize",
+			Usage: "the price increment of each profile level",
+		},
+		&cli.StringFlag{
+			Name:        "start",
+			Usage:       "<start>",
+			Value:       time.Now().AddDate(0, 0, -1).Format(time.DateTime),
+			Destination: &startTime,
+		},
+		&cli.StringFlag{
+			Name:        "end",
+			Usage:       "<end>",
+			Value:       time.Now().Format(time.DateTime),
+			Destination: &endTime,
+		},
+		&cli.Int64Flag{
+			Name:  "interval",
+			Usage: "the candle interval in seconds, also the TPO period of candle profiles",
+			Value: 1800,
+		},
+		&cli.Int64Flag{
+			Name:  "session",
+			Usage: "the session length in seconds, 0 returns a single profile of the whole range",
+			Value: 86400,
+		},
+		&cli.Int64Flag{
+			Name:  "tpoperiod",
+			Usage: "the TPO period in seconds of trade profiles, 0 counts each trade",
+			Value: 1800,
+		},
+		&cli.Float64Flag{
+			Name:  "valuearea",
+			Usage: "the proportion of session volume within the value area",
+			Value: 0.7,
+		},
+		&cli.BoolFlag{
+			Name:  "usetrades",
+			Usage: "builds profiles from stored trades instead of stored candles",
 		},
 	},
 }
 
-func getExchangeTickerStream(c *cli.Context) error {
+func getVolumeProfile(c *cli.Context) error {
 	if c.NArg() == 0 && c.NumFlags() == 0 {
 		return cli.ShowSubcommandHelp(c)
 	}
 
-	var exchangeName string
-	if c.IsSet("exchange") {
-		exchangeName = c.String("exchange")
-	} else {
+	exchangeName := c.String("exchange")
+	if !c.IsSet("exchange") {
 		exchangeName = c.Args().First()
 	}
+	currencyPair := c.String("pair")
+	if !c.IsSet("pair") {
+		currencyPair = c.Args().Get(1)
+	}
+	if !validPair(currencyPair) {
+		return errInvalidPair
+	}
+	p, err := currency.NewPairDelimiter(currencyPair, pairDelimiter)
+	if err != nil {
+		return err
+	}
+	assetType := c.String("asset")
+	if !c.IsSet("asset") {
+		assetType = c.Args().Get(2)
+	}
+	if !validAsset(assetType) {
+		return errInvalidAsset
+	}
+	tickSize := c.Float64("ticksize")
+	if !c.IsSet("ticksize") {
+		tickSize, err = strconv.ParseFloat(c.Args().Get(3), 64)
+		if err != nil {
+			return err
+		}
+	}
+
+	s, err := time.ParseInLocation(time.DateTime, startTime, time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid time format for start: %v", err)
+	}
+	e, err := time.ParseInLocation(time.DateTime, endTime, time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid time format for end: %v", err)
+	}
+	if e.Before(s) {
+		return common.ErrStartAfterEnd
+	}
 
 	conn, cancel, err := setupClient(c)
 	if err != nil {
@@ -3304,99 +4614,111 @@ func getExchangeTickerStream(c *cli.Context) error {
 	defer closeConn(conn, cancel)
 
 	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
-	result, err := client.GetExchangeTickerStream(c.Context,
-		&gctrpc.GetExchangeTickerStreamRequest{
+	result, err := client.GetVolumeProfile(c.Context,
+		&gctrpc.GetVolumeProfileRequest{
 			Exchange: exchangeName,
+			Pair: &gctrpc.CurrencyPair{
+				Delimiter: p.Delimiter,
+				Base:      p.Base.String(),
+				Quote:     p.Quote.String(),
+			},
+			AssetType:    assetType,
+			Start:        s.Format(common.SimpleTimeFormatWithTimezone),
+			End:          e.Format(common.SimpleTimeFormatWithTimezone),
+			TimeInterval: int64(time.Duration(c.Int64("interval")) * time.Second),
+			Session:      int64(time.Duration(c.Int64("session")) * time.Second),
+			TpoPeriod:    int64(time.Duration(c.Int64("tpoperiod")) * time.Second),
+			TickSize:     tickSize,
+			ValueArea:    c.Float64("valuearea"),
+			UseTrades:    c.Bool("usetrades"),
 		})
-
 	if err != nil {
 		return err
 	}
 
-	for {
-		resp, err := result.Recv()
-		if err != nil {
-			return err
-		}
-
-		fmt.Printf("Ticker stream for %s %s:\n",
-			exchangeName,
-			resp.Pair.String())
-
-		fmt.Printf("LAST: %f HIGH: %f LOW: %f BID: %f ASK: %f VOLUME: %f PRICEATH: %f LASTUPDATED: %d\n",
-			resp.Last,
-			resp.High,
-			resp.Low,
-			resp.Bid,
-			resp.Ask,
-			resp.Volume,
-			resp.PriceAth,
-			resp.LastUpdated)
-	}
+	printOutput(result)
+	return nil
 }
 
-var getAuditEventCommand = &cli.Command{
-	Name:      "getauditevent",
-	Usage:     "gets audit events matching query parameters",
-	ArgsUsage: "<starttime> <endtime> <orderby> <limit>",
-	Action:    getAuditEvent,
+var getVolatilityCorrelationCommand = &cli.Command{
+	Name:      "getvolatilitycorrelation",
+	Usage:     "gets the historical volatility and correlation matrix of stored candles for a set of pairs",
+	ArgsUsage: "<exchange> <pairs> <asset>",
+	Action:    getVolatilityCorrelation,
 	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    "exchange",
+			Aliases: []string{"e"},
+			Usage:   "the exchange the candles were stored from",
+		},
+		&cli.StringFlag{
+			Name:  "pairs",
+			Usage: "the comma separated currency pairs, eg btc-usdt,eth-usdt",
+		},
+		&cli.StringFlag{
+			Name:  "asset",
+			Usage: "the asset type of the currency pairs",
+		},
 		&cli.StringFlag{
 			Name:        "start",
-			Aliases:     []string{"s"},
-			Usage:       "start date to search",
-			Value:       time.Now().Add(-time.Hour).Format(time.DateTime),
+			Usage:       "<start> the start of the lookback",
+			Value:       time.Now().AddDate(0, 0, -90).Format(time.DateTime),
 			Destination: &startTime,
 		},
 		&cli.StringFlag{
 			Name:        "end",
-			Aliases:     []string{"e"},
-			Usage:       "end time to search",
+			Usage:       "<end>",
 			Value:       time.Now().Format(time.DateTime),
 			Destination: &endTime,
 		},
-		&cli.StringFlag{
-			Name:        "order",
-			Aliases:     []string{"o"},
-			Usage:       "order results by ascending/descending",
-			Value:       "asc",
-			Destination: &orderingDirection,
+		&cli.Int64Flag{
+			Name:  "interval",
+			Usage: "the candle interval in seconds",
+			Value: 86400,
 		},
-		&cli.IntFlag{
-			Name:        "limit",
-			Aliases:     []string{"l"},
-			Usage:       "how many results to retrieve",
-			Value:       100,
-			Destination: &limit,
+		&cli.Int64Flag{
+			Name:  "window",
+			Usage: "the number of returns in each rolling volatility, 0 returns only the volatility over the lookback",
+			Value: 30,
 		},
 	},
 }
 
-func getAuditEvent(c *cli.Context) error {
-	if !c.IsSet("start") {
-		if c.Args().Get(0) != "" {
-			startTime = c.Args().Get(0)
-		}
+func getVolatilityCorrelation(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowSubcommandHelp(c)
 	}
 
-	if !c.IsSet("end") {
-		if c.Args().Get(1) != "" {
-			endTime = c.Args().Get(1)
-		}
+	exchangeName := c.String("exchange")
+	if !c.IsSet("exchange") {
+		exchangeName = c.Args().First()
 	}
-
-	if !c.IsSet("order") {
-		if c.Args().Get(2) != "" {
-			orderingDirection = c.Args().Get(2)
-		}
+	pairs := c.String("pairs")
+	if !c.IsSet("pairs") {
+		pairs = c.Args().Get(1)
+	}
+	assetType := c.String("asset")
+	if !c.IsSet("asset") {
+		assetType = c.Args().Get(2)
+	}
+	if !validAsset(assetType) {
+		return errInvalidAsset
 	}
 
-	if !c.IsSet("limit") {
-		if c.Args().Get(3) != "" {
-			limitStr, err := strconv.ParseInt(c.Args().Get(3), 10, 64)
-			if err == nil {
-				limit = int(limitStr)
-			}
+	pairList := strings.Split(pairs, ",")
+	requestPairs := make([]*gctrpc.CurrencyPair, len(pairList))
+	for i := range pairList {
+		if !validPair(pairList[i]) {
+			return errInvalidPair
+		}
+		p, err := currency.NewPairDelimiter(pairList[i], pairDelimiter)
+		if err != nil {
+			return err
+		}
+		requestPairs[i] = &gctrpc.CurrencyPair{
+			Delimiter: p.Delimiter,
+			Base:      p.Base.String(),
+			Quote:     p.Quote.String(),
 		}
 	}
 
@@ -3404,12 +4726,10 @@ func getAuditEvent(c *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("invalid time format for start: %v", err)
 	}
-
 	e, err := time.ParseInLocation(time.DateTime, endTime, time.Local)
 	if err != nil {
 		return fmt.Errorf("invalid time format for end: %v", err)
 	}
-
 	if e.Before(s) {
 		return common.ErrStartAfterEnd
 	}
@@ -3418,24 +4738,24 @@ func getAuditEvent(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-
 	defer closeConn(conn, cancel)
 
 	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
-
-	result, err := client.GetAuditEvent(c.Context,
-		&gctrpc.GetAuditEventRequest{
-			StartDate: s.Format(common.SimpleTimeFormatWithTimezone),
-			EndDate:   e.Format(common.SimpleTimeFormatWithTimezone),
-			Limit:     int32(limit),
-			OrderBy:   orderingDirection,
+	result, err := client.GetVolatilityCorrelation(c.Context,
+		&gctrpc.GetVolatilityCorrelationRequest{
+			Exchange:     exchangeName,
+			Pairs:        requestPairs,
+			AssetType:    assetType,
+			Start:        s.Format(common.SimpleTimeFormatWithTimezone),
+			End:          e.Format(common.SimpleTimeFormatWithTimezone),
+			TimeInterval: int64(time.Duration(c.Int64("interval")) * time.Second),
+			Window:       c.Int64("window"),
 		})
-
 	if err != nil {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -3549,9 +4869,139 @@ var gctScriptCommand = &cli.Command{
 			},
 			Action: gctScriptAutoload,
 		},
+		{
+			Name:      "schedule",
+			Usage:     "manage scheduled script execution",
+			ArgsUsage: "<command> <args>",
+			Subcommands: []*cli.Command{
+				{
+					Name:      "add",
+					Usage:     "execute a script on a cron-style schedule",
+					ArgsUsage: "<script> <schedule> <timeout>",
+					Flags: []cli.Flag{
+						&cli.StringFlag{
+							Name:  "script",
+							Usage: "<script name>",
+						},
+						&cli.StringFlag{
+							Name:  "schedule",
+							Usage: "cron expression e.g. '*/5 * * * *', '@daily' or '@every 30s'",
+						},
+						&cli.StringFlag{
+							Name:  "timeout",
+							Usage: "optional script timeout override e.g. 30s",
+						},
+					},
+					Action: gctScriptScheduleAdd,
+				},
+				{
+					Name:      "remove",
+					Usage:     "remove a scheduled script",
+					ArgsUsage: "<id>",
+					Flags: []cli.Flag{
+						&cli.StringFlag{
+							Name:  "id",
+							Usage: "the unique id of the schedule",
+						},
+					},
+					Action: gctScriptScheduleRemove,
+				},
+				{
+					Name:   "list",
+					Usage:  "lists all scheduled scripts",
+					Action: gctScriptScheduleList,
+				},
+			},
+		},
 	},
 }
 
+func gctScriptScheduleAdd(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+
+	script := c.String("script")
+	if !c.IsSet("script") {
+		script = c.Args().Get(0)
+	}
+
+	schedule := c.String("schedule")
+	if !c.IsSet("schedule") {
+		schedule = c.Args().Get(1)
+	}
+
+	timeout := c.String("timeout")
+	if !c.IsSet("timeout") {
+		timeout = c.Args().Get(2)
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+
+	result, err := client.GCTScriptScheduleAdd(c.Context,
+		&gctrpc.GCTScriptScheduleAddRequest{
+			Script:   script,
+			Schedule: schedule,
+			Timeout:  timeout,
+		})
+	if err != nil {
+		return err
+	}
+
+	printOutput(result)
+	return nil
+}
+
+func gctScriptScheduleRemove(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+
+	id := c.String("id")
+	if !c.IsSet("id") {
+		id = c.Args().First()
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+
+	result, err := client.GCTScriptScheduleRemove(c.Context,
+		&gctrpc.GCTScriptScheduleRemoveRequest{Id: id})
+	if err != nil {
+		return err
+	}
+
+	printOutput(result)
+	return nil
+}
+
+func gctScriptScheduleList(c *cli.Context) error {
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+
+	result, err := client.GCTScriptScheduleList(c.Context,
+		&gctrpc.GCTScriptScheduleListRequest{})
+	if err != nil {
+		return err
+	}
+
+	printOutput(result)
+	return nil
+}
+
 func gctScriptAutoload(c *cli.Context) error {
 	if c.NArg() == 0 && c.NumFlags() == 0 {
 		return cli.ShowSubcommandHelp(c)
@@ -3597,7 +5047,7 @@ func gctScriptAutoload(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(executeCommand)
+	printOutput(executeCommand)
 	return nil
 }
 
@@ -3637,7 +5087,7 @@ func gctScriptExecute(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(executeCommand)
+	printOutput(executeCommand)
 
 	return nil
 }
@@ -3657,7 +5107,7 @@ func gctScriptStatus(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(executeCommand)
+	printOutput(executeCommand)
 	return nil
 }
 
@@ -3676,7 +5126,7 @@ func gctScriptList(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(executeCommand)
+	printOutput(executeCommand)
 	return nil
 }
 
@@ -3707,7 +5157,7 @@ func gctScriptStop(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(executeCommand)
+	printOutput(executeCommand)
 	return nil
 }
 
@@ -3726,7 +5176,7 @@ func gctScriptStopAll(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(executeCommand)
+	printOutput(executeCommand)
 	return nil
 }
 
@@ -3759,7 +5209,7 @@ func gctScriptRead(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(executeCommand)
+	printOutput(executeCommand)
 	return nil
 }
 
@@ -3792,7 +5242,7 @@ func gctScriptQuery(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(executeCommand)
+	printOutput(executeCommand)
 
 	return nil
 }
@@ -3861,7 +5311,7 @@ func gctScriptUpload(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(uploadCommand)
+	printOutput(uploadCommand)
 	return nil
 }
 
@@ -4001,7 +5451,7 @@ func getHistoricCandles(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -4193,7 +5643,7 @@ func getHistoricCandlesExtended(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -4337,7 +5787,7 @@ func findMissingSavedCandleIntervals(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -4360,7 +5810,7 @@ func shutdown(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -4559,6 +6009,6 @@ func getMarginRatesHistory(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
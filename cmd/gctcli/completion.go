@@ -0,0 +1,188 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"github.com/urfave/cli/v2"
+)
+
+// completionTimeout bounds engine queries made while completing so a stopped
+// engine does not hang the shell
+const completionTimeout = time.Second * 3
+
+// Flags whose values are completed and picked from the running engine
+const (
+	exchangeFlagName = "exchange"
+	assetFlagName    = "asset"
+	pairFlagName     = "pair"
+)
+
+// setupDynamicCompletion adds engine aware shell completion and interactive
+// pickers to every command which takes an exchange, asset or pair flag
+func setupDynamicCompletion(cmds []*cli.Command) {
+	for _, cmd := range cmds {
+		setupDynamicCompletion(cmd.Subcommands)
+		if !hasFlag(cmd, exchangeFlagName) && !hasFlag(cmd, assetFlagName) && !hasFlag(cmd, pairFlagName) {
+			continue
+		}
+		if cmd.BashComplete == nil {
+			cmd.BashComplete = completeCommand(cmd)
+		}
+		if cmd.Action != nil {
+			cmd.Before = chainBefore(pickMissingArguments, cmd.Before)
+		}
+	}
+}
+
+func hasFlag(cmd *cli.Command, name string) bool {
+	for _, f := range cmd.Flags {
+		if slices.Contains(f.Names(), name) {
+			return true
+		}
+	}
+	return false
+}
+
+func chainBefore(first, next cli.BeforeFunc) cli.BeforeFunc {
+	if next == nil {
+		return first
+	}
+	return func(c *cli.Context) error {
+		if err := first(c); err != nil {
+			return err
+		}
+		return next(c)
+	}
+}
+
+// completeCommand suggests exchange names, asset types and pairs from the
+// running engine when completing those flag values, otherwise it falls back to
+// the default flag and subcommand suggestions
+func completeCommand(cmd *cli.Command) cli.BashCompleteFunc {
+	return func(c *cli.Context) {
+		var prev string
+		// The word being completed is dropped by the completion scripts, so
+		// the flag awaiting a value is the last argument before the marker
+		if len(os.Args) > 2 {
+			prev = os.Args[len(os.Args)-2]
+		}
+		var options []string
+		switch strings.TrimLeft(prev, "-") {
+		case exchangeFlagName:
+			options = completionQuery(c, func(ctx context.Context, client gctrpc.GoCryptoTraderServiceClient) ([]string, error) {
+				return enabledExchanges(ctx, client)
+			})
+		case assetFlagName:
+			exch := argValue(exchangeFlagName)
+			options = completionQuery(c, func(ctx context.Context, client gctrpc.GoCryptoTraderServiceClient) ([]string, error) {
+				return exchangeAssets(ctx, client, exch)
+			})
+		case pairFlagName:
+			exch, a := argValue(exchangeFlagName), argValue(assetFlagName)
+			options = completionQuery(c, func(ctx context.Context, client gctrpc.GoCryptoTraderServiceClient) ([]string, error) {
+				return exchangePairs(ctx, client, exch, a)
+			})
+		default:
+			cli.DefaultCompleteWithFlags(cmd)(c)
+			return
+		}
+		for i := range options {
+			fmt.Fprintln(c.App.Writer, options[i])
+		}
+	}
+}
+
+// argValue returns the value given for a flag on the command line being
+// completed
+func argValue(name string) string {
+	for i := 1; i < len(os.Args); i++ {
+		arg := strings.TrimLeft(os.Args[i], "-")
+		if arg == os.Args[i] {
+			continue
+		}
+		if v, ok := strings.CutPrefix(arg, name+"="); ok {
+			return v
+		}
+		if arg == name && i+1 < len(os.Args) {
+			return os.Args[i+1]
+		}
+	}
+	return ""
+}
+
+// completionQuery runs an engine query for completion, returning nothing on
+// error so completion degrades silently when the engine is unavailable
+func completionQuery(c *cli.Context, query func(context.Context, gctrpc.GoCryptoTraderServiceClient) ([]string, error)) []string {
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return nil
+	}
+	defer closeConn(conn, cancel)
+	ctx, ctxCancel := context.WithTimeout(c.Context, completionTimeout)
+	defer ctxCancel()
+	options, err := query(ctx, gctrpc.NewGoCryptoTraderServiceClient(conn))
+	if err != nil {
+		return nil
+	}
+	return options
+}
+
+func enabledExchanges(ctx context.Context, client gctrpc.GoCryptoTraderServiceClient) ([]string, error) {
+	resp, err := client.GetExchanges(ctx, &gctrpc.GetExchangesRequest{Enabled: true})
+	if err != nil {
+		return nil, err
+	}
+	return splitList(resp.Exchanges), nil
+}
+
+// exchangeAssets returns the exchange's assets or all supported assets if no
+// exchange is given
+func exchangeAssets(ctx context.Context, client gctrpc.GoCryptoTraderServiceClient, exch string) ([]string, error) {
+	if exch == "" {
+		return asset.Supported().Strings(), nil
+	}
+	resp, err := client.GetExchangeAssets(ctx, &gctrpc.GetExchangeAssetsRequest{Exchange: exch})
+	if err != nil {
+		return nil, err
+	}
+	return splitList(resp.Assets), nil
+}
+
+// exchangePairs returns the enabled pairs for an exchange asset, or across all
+// of the exchange's assets if no asset is given
+func exchangePairs(ctx context.Context, client gctrpc.GoCryptoTraderServiceClient, exch, a string) ([]string, error) {
+	if exch == "" {
+		return nil, nil
+	}
+	resp, err := client.GetExchangePairs(ctx, &gctrpc.GetExchangePairsRequest{Exchange: exch, Asset: a})
+	if err != nil {
+		return nil, err
+	}
+	var pairs []string
+	for _, supported := range resp.SupportedAssets {
+		for _, p := range splitList(supported.EnabledPairs) {
+			if !slices.Contains(pairs, p) {
+				pairs = append(pairs, p)
+			}
+		}
+	}
+	slices.Sort(pairs)
+	return pairs, nil
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
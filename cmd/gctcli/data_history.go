@@ -129,6 +129,12 @@ var dataHistoryJobCommands = &cli.Command{
 			Flags:  append(baseJobSubCommands, tradeHandlingJobSubCommands...),
 			Action: upsertDataHistoryJob,
 		},
+		{
+			Name:   "backfilltrades",
+			Usage:  "will page an exchange's trade history backwards from the end date to the start date, saving trades not already in the database and reporting coverage gaps via 'getjobsummary'",
+			Flags:  append(baseJobSubCommands, requestSize10Flag),
+			Action: upsertDataHistoryJob,
+		},
 		{
 			Name:   "converttrades",
 			Usage:  "convert trades saved to the database to any candle resolution eg 30min",
@@ -333,7 +339,7 @@ func getDataHistoryJob(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -351,7 +357,7 @@ func getActiveDataHistoryJobs(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -461,6 +467,8 @@ func upsertDataHistoryJob(c *cli.Context) error {
 		dataType = 4
 	case "secondaryvalidatecandles":
 		dataType = 5
+	case "backfilltrades":
+		dataType = 6
 	default:
 		return errors.New("unrecognised command, cannot set data type")
 	}
@@ -525,7 +533,7 @@ func upsertDataHistoryJob(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -572,7 +580,7 @@ func getDataHistoryJobsBetween(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -626,7 +634,7 @@ func setDataHistoryJobStatus(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -657,7 +665,7 @@ func getDataHistoryJobSummary(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -700,6 +708,6 @@ func setPrerequisiteJob(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
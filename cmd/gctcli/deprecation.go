@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// warnDeprecated prints a warning when the response headers of a call mark the
+// method as deprecated
+func warnDeprecated(method string, header metadata.MD) {
+	if len(header.Get(gctrpc.DeprecatedMetadataKey)) == 0 {
+		return
+	}
+	warning := "warning: " + method + " is deprecated"
+	if v := header.Get(gctrpc.RemovalVersionMetadataKey); len(v) > 0 {
+		warning += " and will be removed in API version " + v[0]
+	}
+	if v := header.Get(gctrpc.DeprecationNoticeMetadataKey); len(v) > 0 && v[0] != "" {
+		warning += ": " + v[0]
+	}
+	fmt.Fprintln(os.Stderr, warning)
+}
+
+// deprecationUnaryInterceptor warns when a unary call is deprecated
+func deprecationUnaryInterceptor(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	var header metadata.MD
+	err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Header(&header))...)
+	warnDeprecated(method, header)
+	return err
+}
+
+// deprecationStream warns once the headers of a deprecated stream are
+// received
+type deprecationStream struct {
+	grpc.ClientStream
+	method string
+	warned bool
+}
+
+func (d *deprecationStream) RecvMsg(m any) error {
+	err := d.ClientStream.RecvMsg(m)
+	if !d.warned {
+		d.warned = true
+		if header, headerErr := d.ClientStream.Header(); headerErr == nil {
+			warnDeprecated(d.method, header)
+		}
+	}
+	return err
+}
+
+// deprecationStreamInterceptor warns when a stream is deprecated
+func deprecationStreamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	s, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &deprecationStream{ClientStream: s, method: method}, nil
+}
@@ -0,0 +1,395 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"github.com/urfave/cli/v2"
+)
+
+var earnCommand = &cli.Command{
+	Name:      "earn",
+	Usage:     "execute exchange earn and staking product commands",
+	ArgsUsage: "<command> <args>",
+	Subcommands: []*cli.Command{
+		{
+			Name:      "products",
+			Usage:     "returns the earn and staking products offered by an exchange",
+			ArgsUsage: "<exchange> <currency>",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "exchange",
+					Usage: "the exchange to act on",
+				},
+				&cli.StringFlag{
+					Name:  "currency",
+					Usage: "only returns products for the currency when set",
+				},
+			},
+			Action: getEarnProducts,
+		},
+		{
+			Name:      "positions",
+			Usage:     "returns the amounts held in an exchange's earn and staking products",
+			ArgsUsage: "<exchange>",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "exchange",
+					Usage: "the exchange to act on",
+				},
+			},
+			Action: getEarnPositions,
+		},
+		{
+			Name:      "subscribe",
+			Usage:     "subscribes an amount to an earn or staking product",
+			ArgsUsage: "<exchange> <product> <currency> <amount> <type>",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "exchange",
+					Usage: "the exchange to act on",
+				},
+				&cli.StringFlag{
+					Name:  "product",
+					Usage: "the product ID",
+				},
+				&cli.StringFlag{
+					Name:  "currency",
+					Usage: "the currency to subscribe",
+				},
+				&cli.Float64Flag{
+					Name:  "amount",
+					Usage: "the amount to subscribe",
+				},
+				&cli.StringFlag{
+					Name:  "type",
+					Usage: "the product type, flexible or locked",
+					Value: "flexible",
+				},
+			},
+			Action: subscribeEarnProduct,
+		},
+		{
+			Name:      "redeem",
+			Usage:     "redeems an amount, or the full position, from an earn or staking product",
+			ArgsUsage: "<exchange> <product> <currency> <amount> <type>",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "exchange",
+					Usage: "the exchange to act on",
+				},
+				&cli.StringFlag{
+					Name:  "product",
+					Usage: "the product ID",
+				},
+				&cli.StringFlag{
+					Name:  "currency",
+					Usage: "the currency to redeem",
+				},
+				&cli.Float64Flag{
+					Name:  "amount",
+					Usage: "the amount to redeem, must be unset when redeeming all",
+				},
+				&cli.StringFlag{
+					Name:  "type",
+					Usage: "the product type, flexible or locked",
+					Value: "flexible",
+				},
+				&cli.StringFlag{
+					Name:  "position",
+					Usage: "the position ID of a locked position, for exchanges which track each subscription separately",
+				},
+				&cli.BoolFlag{
+					Name:  "all",
+					Usage: "redeems the full position",
+				},
+			},
+			Action: redeemEarnProduct,
+		},
+		{
+			Name:      "rewards",
+			Usage:     "returns the earn and staking rewards paid between the start and end times, from the earn manager's ledger when it is running",
+			ArgsUsage: "<exchange> <start> <end>",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "exchange",
+					Usage: "the exchange to act on, may be unset when the earn manager is running to return the rewards of every exchange",
+				},
+				&cli.StringFlag{
+					Name:        "start",
+					Usage:       "the start date",
+					Value:       time.Now().AddDate(0, -1, 0).Format(time.DateTime),
+					Destination: &startTime,
+				},
+				&cli.StringFlag{
+					Name:        "end",
+					Usage:       "the end date",
+					Value:       time.Now().Format(time.DateTime),
+					Destination: &endTime,
+				},
+			},
+			Action: getEarnRewards,
+		},
+		{
+			Name:   "lending",
+			Usage:  "returns the flexible earn rates compared by the lending optimizer and the idle balances found by its last check",
+			Action: getLendingOpportunities,
+		},
+	},
+}
+
+func getEarnProducts(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	var curr string
+	if c.IsSet("currency") {
+		curr = c.String("currency")
+	} else {
+		curr = c.Args().Get(1)
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetEarnProducts(c.Context,
+		&gctrpc.GetEarnProductsRequest{
+			Exchange: exchangeName,
+			Currency: curr,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printOutput(result)
+	return nil
+}
+
+func getEarnPositions(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetEarnPositions(c.Context,
+		&gctrpc.GetEarnPositionsRequest{
+			Exchange: exchangeName,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printOutput(result)
+	return nil
+}
+
+// earnProductArgs returns the exchange, product, currency, amount and type
+// arguments shared by the subscribe and redeem commands
+func earnProductArgs(c *cli.Context) (exchangeName, product, curr string, amount float64, productType string, err error) {
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	if c.IsSet("product") {
+		product = c.String("product")
+	} else {
+		product = c.Args().Get(1)
+	}
+
+	if c.IsSet("currency") {
+		curr = c.String("currency")
+	} else {
+		curr = c.Args().Get(2)
+	}
+
+	if c.IsSet("amount") {
+		amount = c.Float64("amount")
+	} else if c.Args().Get(3) != "" {
+		amount, err = strconv.ParseFloat(c.Args().Get(3), 64)
+		if err != nil {
+			return "", "", "", 0, "", err
+		}
+	}
+
+	productType = c.String("type")
+	if !c.IsSet("type") && c.Args().Get(4) != "" {
+		productType = c.Args().Get(4)
+	}
+	return exchangeName, product, curr, amount, productType, nil
+}
+
+func subscribeEarnProduct(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+
+	exchangeName, product, curr, amount, productType, err := earnProductArgs(c)
+	if err != nil {
+		return err
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.SubscribeEarnProduct(c.Context,
+		&gctrpc.SubscribeEarnProductRequest{
+			Exchange:  exchangeName,
+			ProductId: product,
+			Currency:  curr,
+			Type:      productType,
+			Amount:    amount,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printOutput(result)
+	return nil
+}
+
+func redeemEarnProduct(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+
+	exchangeName, product, curr, amount, productType, err := earnProductArgs(c)
+	if err != nil {
+		return err
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.RedeemEarnProduct(c.Context,
+		&gctrpc.RedeemEarnProductRequest{
+			Exchange:   exchangeName,
+			ProductId:  product,
+			PositionId: c.String("position"),
+			Currency:   curr,
+			Type:       productType,
+			Amount:     amount,
+			All:        c.Bool("all"),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printOutput(result)
+	return nil
+}
+
+func getEarnRewards(c *cli.Context) error {
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	if !c.IsSet("start") {
+		if c.Args().Get(1) != "" {
+			startTime = c.Args().Get(1)
+		}
+	}
+
+	if !c.IsSet("end") {
+		if c.Args().Get(2) != "" {
+			endTime = c.Args().Get(2)
+		}
+	}
+
+	s, err := time.ParseInLocation(time.DateTime, startTime, time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid time format for start: %v", err)
+	}
+	e, err := time.ParseInLocation(time.DateTime, endTime, time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid time format for end: %v", err)
+	}
+
+	if e.Before(s) {
+		return common.ErrStartAfterEnd
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetEarnRewards(c.Context,
+		&gctrpc.GetEarnRewardsRequest{
+			Exchange: exchangeName,
+			Start:    s.Format(common.SimpleTimeFormatWithTimezone),
+			End:      e.Format(common.SimpleTimeFormatWithTimezone),
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	printOutput(result)
+	return nil
+}
+
+func getLendingOpportunities(c *cli.Context) error {
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetLendingOpportunities(c.Context,
+		&gctrpc.GetLendingOpportunitiesRequest{},
+	)
+	if err != nil {
+		return err
+	}
+
+	printOutput(result)
+	return nil
+}
@@ -96,6 +96,138 @@ var futuresCommands = &cli.Command{
 				},
 			},
 		},
+		{
+			Name:      "getportfoliogreeks",
+			Aliases:   []string{"greeks", "pg"},
+			Usage:     "returns aggregated delta, gamma, vega and theta per underlying for open futures and options positions",
+			ArgsUsage: "<exchange> <underlying> <riskfreerate>",
+			Action:    getPortfolioGreeks,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "exchange",
+					Aliases: []string{"e"},
+					Usage:   "optional - the exchange to limit positions to",
+				},
+				&cli.StringFlag{
+					Name:    "underlying",
+					Aliases: []string{"u"},
+					Usage:   "optional - the underlying currency to limit positions to, such as BTC",
+				},
+				&cli.Float64Flag{
+					Name:    "riskfreerate",
+					Aliases: []string{"r"},
+					Usage:   "optional - the annualised risk free rate used to price options, where 0.05 is 5%",
+				},
+			},
+		},
+		{
+			Name:      "getvolatilitysurface",
+			Aliases:   []string{"surface", "vs"},
+			Usage:     "returns the implied volatility surface built from option tickers for an exchange and underlying",
+			ArgsUsage: "<exchange> <underlying> <at>",
+			Action:    getVolatilitySurface,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "exchange",
+					Aliases: []string{"e"},
+					Usage:   "the exchange the surface was built from",
+				},
+				&cli.StringFlag{
+					Name:    "underlying",
+					Aliases: []string{"u"},
+					Usage:   "the underlying currency of the surface, such as BTC",
+				},
+				&cli.StringFlag{
+					Name:  "at",
+					Usage: "optional - returns the most recent surface snapshot at or before the time",
+				},
+			},
+		},
+		{
+			Name:      "getimpliedvolatility",
+			Aliases:   []string{"iv"},
+			Usage:     "returns the implied volatility interpolated from a volatility surface at a strike and expiry",
+			ArgsUsage: "<exchange> <underlying> <strike> <expiry> <at>",
+			Action:    getImpliedVolatility,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "exchange",
+					Aliases: []string{"e"},
+					Usage:   "the exchange the surface was built from",
+				},
+				&cli.StringFlag{
+					Name:    "underlying",
+					Aliases: []string{"u"},
+					Usage:   "the underlying currency of the surface, such as BTC",
+				},
+				&cli.Float64Flag{
+					Name:    "strike",
+					Aliases: []string{"k"},
+					Usage:   "the strike price to interpolate at",
+				},
+				&cli.StringFlag{
+					Name:  "expiry",
+					Usage: "the expiry to interpolate at",
+				},
+				&cli.StringFlag{
+					Name:  "at",
+					Usage: "optional - uses the most recent surface snapshot at or before the time",
+				},
+			},
+		},
+		{
+			Name:      "simulateportfoliomargin",
+			Aliases:   []string{"whatif", "spm"},
+			Usage:     "estimates portfolio margin requirements before and after a hypothetical futures or options trade using a venue's risk scenarios",
+			ArgsUsage: "<exchange> <asset> <pair> <side> <amount> <availablemargin> <includepositions> <model> <riskfreerate>",
+			Action:    simulatePortfolioMargin,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "exchange",
+					Aliases: []string{"e"},
+					Usage:   "the exchange to simulate the trade on",
+				},
+				&cli.StringFlag{
+					Name:    "asset",
+					Aliases: []string{"a"},
+					Usage:   "the asset type of the trade, must be a futures type or options",
+				},
+				&cli.StringFlag{
+					Name:    "pair",
+					Aliases: []string{"p"},
+					Usage:   "the currency pair of the trade",
+				},
+				&cli.StringFlag{
+					Name:    "side",
+					Aliases: []string{"s"},
+					Usage:   "the side of the trade, buy or sell",
+				},
+				&cli.Float64Flag{
+					Name:  "amount",
+					Usage: "the amount of the trade in units of the underlying",
+				},
+				&cli.Float64Flag{
+					Name:    "availablemargin",
+					Aliases: []string{"am"},
+					Usage:   "optional - returns the largest amount of the trade which keeps initial margin within the available margin",
+				},
+				&cli.BoolFlag{
+					Name:    "includepositions",
+					Aliases: []string{"ip"},
+					Usage:   "includes held futures and options positions on the trade's underlying",
+				},
+				&cli.StringFlag{
+					Name:    "model",
+					Aliases: []string{"m"},
+					Usage:   "optional - the portfolio margin model, deribit or okx, defaults to the exchange",
+				},
+				&cli.Float64Flag{
+					Name:    "riskfreerate",
+					Aliases: []string{"r"},
+					Usage:   "optional - the annualised risk free rate used to price options, where 0.05 is 5%",
+				},
+			},
+		},
 		{
 			Name:      "getcollateral",
 			Aliases:   []string{"collateral", "c"},
@@ -217,6 +349,73 @@ var futuresCommands = &cli.Command{
 				},
 			},
 		},
+		{
+			Name:      "getstreamedfundingrates",
+			Aliases:   []string{"streamedrates", "sfr"},
+			Usage:     "returns the latest perpetual funding rates received over exchange websockets",
+			ArgsUsage: "<exchange> <asset>",
+			Action:    getStreamedFundingRates,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "exchange",
+					Aliases: []string{"e"},
+					Usage:   "optional - the exchange to return funding rates for",
+				},
+				&cli.StringFlag{
+					Name:    "asset",
+					Aliases: []string{"a"},
+					Usage:   "optional - the asset type to return funding rates for, must be a futures type",
+				},
+			},
+		},
+		{
+			Name:      "getstreamedfundingratehistory",
+			Aliases:   []string{"streamedratehistory", "sfrh"},
+			Usage:     "returns the changes to a perpetual's funding rate received over its exchange's websocket",
+			ArgsUsage: "<exchange> <asset> <pair> <start>",
+			Action:    getStreamedFundingRateHistory,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "exchange",
+					Aliases: []string{"e"},
+					Usage:   "the exchange to return funding rate history for",
+				},
+				&cli.StringFlag{
+					Name:    "asset",
+					Aliases: []string{"a"},
+					Usage:   "the asset type of the currency pair, must be a futures type",
+				},
+				&cli.StringFlag{
+					Name:    "pair",
+					Aliases: []string{"p"},
+					Usage:   "currency pair",
+				},
+				&cli.StringFlag{
+					Name:    "start",
+					Aliases: []string{"sd"},
+					Usage:   "optional - only return changes received since <start>",
+				},
+			},
+		},
+		{
+			Name:      "streamfundingrates",
+			Aliases:   []string{"streamrates"},
+			Usage:     "streams perpetual funding rates as they are received over exchange websockets",
+			ArgsUsage: "<exchange> <asset>",
+			Action:    streamFundingRates,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "exchange",
+					Aliases: []string{"e"},
+					Usage:   "optional - the exchange to stream funding rates for",
+				},
+				&cli.StringFlag{
+					Name:    "asset",
+					Aliases: []string{"a"},
+					Usage:   "optional - the asset type to stream funding rates for, must be a futures type",
+				},
+			},
+		},
 		{
 			Name:      "getcollateralmode",
 			Aliases:   []string{"gcm"},
@@ -488,6 +687,30 @@ var futuresCommands = &cli.Command{
 				},
 			},
 		},
+		{
+			Name:      "getmargintype",
+			Aliases:   []string{"gmt"},
+			Usage:     "gets the margin type for a exchange asset pair",
+			ArgsUsage: "<exchange> <asset> <pair>",
+			Action:    getMarginType,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "exchange",
+					Aliases: []string{"e"},
+					Usage:   "the exchange to retrieve the margin type from",
+				},
+				&cli.StringFlag{
+					Name:    "asset",
+					Aliases: []string{"a"},
+					Usage:   "the asset type of the currency pair, must be a futures type",
+				},
+				&cli.StringFlag{
+					Name:    "pair",
+					Aliases: []string{"p"},
+					Usage:   "the currency pair",
+				},
+			},
+		},
 		{
 			Name:      "getopeninterest",
 			Aliases:   []string{"goi", "oi"},
@@ -596,85 +819,371 @@ func getManagedPosition(c *cli.Context) error {
 	if err != nil {
 		return err
 	}
-
-	conn, cancel, err := setupClient(c)
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetManagedPosition(c.Context,
+		&gctrpc.GetManagedPositionRequest{
+			Exchange: exchangeName,
+			Asset:    assetType,
+			Pair: &gctrpc.CurrencyPair{
+				Delimiter: p.Delimiter,
+				Base:      p.Base.String(),
+				Quote:     p.Quote.String(),
+			},
+			IncludeFullOrderData:    includeOrderDetails,
+			GetFundingPayments:      getFundingData,
+			IncludeFullFundingRates: includeFundingEntries,
+			IncludePredictedRate:    includePredictedRate,
+		})
+	if err != nil {
+		return err
+	}
+
+	printOutput(result)
+	return nil
+}
+
+func getAllManagedPositions(c *cli.Context) error {
+	var (
+		err                   error
+		includeOrderDetails   bool
+		getFundingData        bool
+		includeFundingEntries bool
+		includePredictedRate  bool
+	)
+	if c.IsSet("includeorderdetails") {
+		includeOrderDetails = c.Bool("includeorderdetails")
+	} else if c.Args().Get(0) != "" {
+		includeOrderDetails, err = strconv.ParseBool(c.Args().Get(0))
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.IsSet("getfundingdata") {
+		getFundingData = c.Bool("getfundingdata")
+	} else if c.Args().Get(1) != "" {
+		getFundingData, err = strconv.ParseBool(c.Args().Get(1))
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.IsSet("includefundingentries") {
+		includeFundingEntries = c.Bool("includefundingentries")
+	} else if c.Args().Get(2) != "" {
+		includeFundingEntries, err = strconv.ParseBool(c.Args().Get(2))
+		if err != nil {
+			return err
+		}
+	}
+
+	if c.IsSet("includepredictedrate") {
+		includePredictedRate = c.Bool("includepredictedrate")
+	} else if c.Args().Get(2) != "" {
+		includePredictedRate, err = strconv.ParseBool(c.Args().Get(3))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = futures.CheckFundingRatePrerequisites(getFundingData, includePredictedRate, includeFundingEntries)
+	if err != nil {
+		return err
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetAllManagedPositions(c.Context,
+		&gctrpc.GetAllManagedPositionsRequest{
+			IncludeFullOrderData:    includeOrderDetails,
+			GetFundingPayments:      getFundingData,
+			IncludeFullFundingRates: includeFundingEntries,
+			IncludePredictedRate:    includePredictedRate,
+		})
+	if err != nil {
+		return err
+	}
+
+	printOutput(result)
+	return nil
+}
+
+func getPortfolioGreeks(c *cli.Context) error {
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	var underlying string
+	if c.IsSet("underlying") {
+		underlying = c.String("underlying")
+	} else {
+		underlying = c.Args().Get(1)
+	}
+
+	var (
+		riskFreeRate float64
+		err          error
+	)
+	if c.IsSet("riskfreerate") {
+		riskFreeRate = c.Float64("riskfreerate")
+	} else if c.Args().Get(2) != "" {
+		riskFreeRate, err = strconv.ParseFloat(c.Args().Get(2), 64)
+		if err != nil {
+			return err
+		}
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetPortfolioGreeks(c.Context,
+		&gctrpc.GetPortfolioGreeksRequest{
+			Exchange:     exchangeName,
+			Underlying:   underlying,
+			RiskFreeRate: riskFreeRate,
+		})
+	if err != nil {
+		return err
+	}
+
+	printOutput(result)
+	return nil
+}
+
+func getVolatilitySurface(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	var underlying string
+	if c.IsSet("underlying") {
+		underlying = c.String("underlying")
+	} else {
+		underlying = c.Args().Get(1)
+	}
+
+	var at string
+	if c.IsSet("at") {
+		at = c.String("at")
+	} else {
+		at = c.Args().Get(2)
+	}
+	at, err := formatOptionalTime(at)
+	if err != nil {
+		return fmt.Errorf("invalid time format for at: %v", err)
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetVolatilitySurface(c.Context,
+		&gctrpc.GetVolatilitySurfaceRequest{
+			Exchange:   exchangeName,
+			Underlying: underlying,
+			At:         at,
+		})
+	if err != nil {
+		return err
+	}
+
+	printOutput(result)
+	return nil
+}
+
+func getImpliedVolatility(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	var underlying string
+	if c.IsSet("underlying") {
+		underlying = c.String("underlying")
+	} else {
+		underlying = c.Args().Get(1)
+	}
+
+	var (
+		strike float64
+		err    error
+	)
+	if c.IsSet("strike") {
+		strike = c.Float64("strike")
+	} else {
+		strike, err = strconv.ParseFloat(c.Args().Get(2), 64)
+		if err != nil {
+			return err
+		}
+	}
+
+	var expiry string
+	if c.IsSet("expiry") {
+		expiry = c.String("expiry")
+	} else {
+		expiry = c.Args().Get(3)
+	}
+	e, err := time.ParseInLocation(time.DateTime, expiry, time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid time format for expiry: %v", err)
+	}
+
+	var at string
+	if c.IsSet("at") {
+		at = c.String("at")
+	} else {
+		at = c.Args().Get(4)
+	}
+	at, err = formatOptionalTime(at)
+	if err != nil {
+		return fmt.Errorf("invalid time format for at: %v", err)
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetImpliedVolatility(c.Context,
+		&gctrpc.GetImpliedVolatilityRequest{
+			Exchange:   exchangeName,
+			Underlying: underlying,
+			Strike:     strike,
+			Expiry:     e.Format(common.SimpleTimeFormatWithTimezone),
+			At:         at,
+		})
+	if err != nil {
+		return err
+	}
+
+	printOutput(result)
+	return nil
+}
+
+func simulatePortfolioMargin(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	var assetType string
+	if c.IsSet("asset") {
+		assetType = c.String("asset")
+	} else {
+		assetType = c.Args().Get(1)
+	}
+
+	var currencyPair string
+	if c.IsSet("pair") {
+		currencyPair = c.String("pair")
+	} else {
+		currencyPair = c.Args().Get(2)
+	}
+	if !validPair(currencyPair) {
+		return errInvalidPair
+	}
+	p, err := currency.NewPairDelimiter(currencyPair, pairDelimiter)
 	if err != nil {
 		return err
 	}
-	defer closeConn(conn, cancel)
 
-	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
-	result, err := client.GetManagedPosition(c.Context,
-		&gctrpc.GetManagedPositionRequest{
-			Exchange: exchangeName,
-			Asset:    assetType,
-			Pair: &gctrpc.CurrencyPair{
-				Delimiter: p.Delimiter,
-				Base:      p.Base.String(),
-				Quote:     p.Quote.String(),
-			},
-			IncludeFullOrderData:    includeOrderDetails,
-			GetFundingPayments:      getFundingData,
-			IncludeFullFundingRates: includeFundingEntries,
-			IncludePredictedRate:    includePredictedRate,
-		})
-	if err != nil {
-		return err
+	var side string
+	if c.IsSet("side") {
+		side = c.String("side")
+	} else {
+		side = c.Args().Get(3)
 	}
 
-	jsonOutput(result)
-	return nil
-}
-
-func getAllManagedPositions(c *cli.Context) error {
-	var (
-		err                   error
-		includeOrderDetails   bool
-		getFundingData        bool
-		includeFundingEntries bool
-		includePredictedRate  bool
-	)
-	if c.IsSet("includeorderdetails") {
-		includeOrderDetails = c.Bool("includeorderdetails")
-	} else if c.Args().Get(0) != "" {
-		includeOrderDetails, err = strconv.ParseBool(c.Args().Get(0))
+	var amount float64
+	if c.IsSet("amount") {
+		amount = c.Float64("amount")
+	} else {
+		amount, err = strconv.ParseFloat(c.Args().Get(4), 64)
 		if err != nil {
 			return err
 		}
 	}
 
-	if c.IsSet("getfundingdata") {
-		getFundingData = c.Bool("getfundingdata")
-	} else if c.Args().Get(1) != "" {
-		getFundingData, err = strconv.ParseBool(c.Args().Get(1))
+	var availableMargin float64
+	if c.IsSet("availablemargin") {
+		availableMargin = c.Float64("availablemargin")
+	} else if c.Args().Get(5) != "" {
+		availableMargin, err = strconv.ParseFloat(c.Args().Get(5), 64)
 		if err != nil {
 			return err
 		}
 	}
 
-	if c.IsSet("includefundingentries") {
-		includeFundingEntries = c.Bool("includefundingentries")
-	} else if c.Args().Get(2) != "" {
-		includeFundingEntries, err = strconv.ParseBool(c.Args().Get(2))
+	var includePositions bool
+	if c.IsSet("includepositions") {
+		includePositions = c.Bool("includepositions")
+	} else if c.Args().Get(6) != "" {
+		includePositions, err = strconv.ParseBool(c.Args().Get(6))
 		if err != nil {
 			return err
 		}
 	}
 
-	if c.IsSet("includepredictedrate") {
-		includePredictedRate = c.Bool("includepredictedrate")
-	} else if c.Args().Get(2) != "" {
-		includePredictedRate, err = strconv.ParseBool(c.Args().Get(3))
+	var model string
+	if c.IsSet("model") {
+		model = c.String("model")
+	} else {
+		model = c.Args().Get(7)
+	}
+
+	var riskFreeRate float64
+	if c.IsSet("riskfreerate") {
+		riskFreeRate = c.Float64("riskfreerate")
+	} else if c.Args().Get(8) != "" {
+		riskFreeRate, err = strconv.ParseFloat(c.Args().Get(8), 64)
 		if err != nil {
 			return err
 		}
 	}
 
-	err = futures.CheckFundingRatePrerequisites(getFundingData, includePredictedRate, includeFundingEntries)
-	if err != nil {
-		return err
-	}
-
 	conn, cancel, err := setupClient(c)
 	if err != nil {
 		return err
@@ -682,21 +1191,43 @@ func getAllManagedPositions(c *cli.Context) error {
 	defer closeConn(conn, cancel)
 
 	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
-	result, err := client.GetAllManagedPositions(c.Context,
-		&gctrpc.GetAllManagedPositionsRequest{
-			IncludeFullOrderData:    includeOrderDetails,
-			GetFundingPayments:      getFundingData,
-			IncludeFullFundingRates: includeFundingEntries,
-			IncludePredictedRate:    includePredictedRate,
+	result, err := client.SimulatePortfolioMargin(c.Context,
+		&gctrpc.SimulatePortfolioMarginRequest{
+			Exchange: exchangeName,
+			Model:    model,
+			Pair: &gctrpc.CurrencyPair{
+				Delimiter: p.Delimiter,
+				Base:      p.Base.String(),
+				Quote:     p.Quote.String(),
+			},
+			Asset:            assetType,
+			Side:             side,
+			Amount:           amount,
+			AvailableMargin:  availableMargin,
+			IncludePositions: includePositions,
+			RiskFreeRate:     riskFreeRate,
 		})
 	if err != nil {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
+// formatOptionalTime converts a local time to the RPC time format, leaving
+// unset times empty
+func formatOptionalTime(t string) (string, error) {
+	if t == "" {
+		return "", nil
+	}
+	parsed, err := time.ParseInLocation(time.DateTime, t, time.Local)
+	if err != nil {
+		return "", err
+	}
+	return parsed.Format(common.SimpleTimeFormatWithTimezone), nil
+}
+
 func getCollateral(c *cli.Context) error {
 	if c.NArg() == 0 && c.NumFlags() == 0 {
 		return cli.ShowSubcommandHelp(c)
@@ -767,7 +1298,7 @@ func getCollateral(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -892,7 +1423,7 @@ func getFundingRates(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -966,10 +1497,166 @@ func getLatestFundingRate(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
+	return nil
+}
+
+func getStreamedFundingRates(c *cli.Context) error {
+	exchangeName, assetType, err := streamedFundingRateFilters(c)
+	if err != nil {
+		return err
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetStreamedFundingRates(c.Context,
+		&gctrpc.GetStreamedFundingRatesRequest{
+			Exchange: exchangeName,
+			Asset:    assetType,
+		})
+	if err != nil {
+		return err
+	}
+
+	printOutput(result)
+	return nil
+}
+
+func getStreamedFundingRateHistory(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+	var (
+		exchangeName, assetType, currencyPair, start string
+		p                                            currency.Pair
+		err                                          error
+	)
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	if c.IsSet("asset") {
+		assetType = c.String("asset")
+	} else {
+		assetType = c.Args().Get(1)
+	}
+	err = isFuturesAsset(assetType)
+	if err != nil {
+		return err
+	}
+
+	if c.IsSet("pair") {
+		currencyPair = c.String("pair")
+	} else {
+		currencyPair = c.Args().Get(2)
+	}
+	if !validPair(currencyPair) {
+		return errInvalidPair
+	}
+	p, err = currency.NewPairDelimiter(currencyPair, pairDelimiter)
+	if err != nil {
+		return err
+	}
+
+	if c.IsSet("start") {
+		start = c.String("start")
+	} else {
+		start = c.Args().Get(3)
+	}
+	if start != "" {
+		s, err := time.ParseInLocation(time.DateTime, start, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid time format for start: %v", err)
+		}
+		start = s.Format(common.SimpleTimeFormatWithTimezone)
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetStreamedFundingRateHistory(c.Context,
+		&gctrpc.GetStreamedFundingRateHistoryRequest{
+			Exchange: exchangeName,
+			Asset:    assetType,
+			Pair: &gctrpc.CurrencyPair{
+				Delimiter: p.Delimiter,
+				Base:      p.Base.String(),
+				Quote:     p.Quote.String(),
+			},
+			StartDate: start,
+		})
+	if err != nil {
+		return err
+	}
+
+	printOutput(result)
 	return nil
 }
 
+func streamFundingRates(c *cli.Context) error {
+	exchangeName, assetType, err := streamedFundingRateFilters(c)
+	if err != nil {
+		return err
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.StreamFundingRates(c.Context,
+		&gctrpc.StreamFundingRatesRequest{
+			Exchange: exchangeName,
+			Asset:    assetType,
+		})
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := result.Recv()
+		if err != nil {
+			return err
+		}
+		printOutput(resp)
+	}
+}
+
+// streamedFundingRateFilters returns the optional exchange and futures asset
+// filters of the streamed funding rate commands
+func streamedFundingRateFilters(c *cli.Context) (exchangeName, assetType string, err error) {
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	if c.IsSet("asset") {
+		assetType = c.String("asset")
+	} else {
+		assetType = c.Args().Get(1)
+	}
+	if assetType != "" {
+		if err = isFuturesAsset(assetType); err != nil {
+			return "", "", err
+		}
+	}
+	return exchangeName, assetType, nil
+}
+
 func getCollateralMode(c *cli.Context) error {
 	if c.NArg() == 0 && c.NumFlags() == 0 {
 		return cli.ShowSubcommandHelp(c)
@@ -1011,7 +1698,7 @@ func getCollateralMode(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -1067,7 +1754,7 @@ func setCollateralMode(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -1164,7 +1851,7 @@ func setLeverage(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -1250,7 +1937,7 @@ func getLeverage(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -1351,7 +2038,7 @@ func changePositionMargin(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -1432,7 +2119,7 @@ func getFuturesPositionSummary(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -1559,7 +2246,7 @@ func getFuturePositionOrders(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -1632,7 +2319,67 @@ func setMarginType(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
+	return nil
+}
+
+func getMarginType(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+	var exchangeName, assetType, currencyPair string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	if c.IsSet("asset") {
+		assetType = c.String("asset")
+	} else {
+		assetType = c.Args().Get(1)
+	}
+
+	err := isFuturesAsset(assetType)
+	if err != nil {
+		return err
+	}
+
+	if c.IsSet("pair") {
+		currencyPair = c.String("pair")
+	} else {
+		currencyPair = c.Args().Get(2)
+	}
+	if !validPair(currencyPair) {
+		return fmt.Errorf("%w currencypair:%v", errInvalidPair, currencyPair)
+	}
+	pair, err := currency.NewPairDelimiter(currencyPair, pairDelimiter)
+	if err != nil {
+		return err
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetMarginType(c.Context,
+		&gctrpc.GetMarginTypeRequest{
+			Exchange: exchangeName,
+			Asset:    assetType,
+			Pair: &gctrpc.CurrencyPair{
+				Delimiter: pair.Delimiter,
+				Base:      pair.Base.String(),
+				Quote:     pair.Quote.String(),
+			},
+		})
+	if err != nil {
+		return err
+	}
+
+	printOutput(result)
 	return nil
 }
 
@@ -1707,6 +2454,6 @@ func getOpenInterest(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
@@ -2,17 +2,18 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/core"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
 	"github.com/thrasher-corp/gocryptotrader/gctrpc/auth"
 	"github.com/thrasher-corp/gocryptotrader/signaler"
 	"github.com/urfave/cli/v2"
@@ -31,18 +32,13 @@ var (
 	exchangeCreds account.Credentials
 	verbose       bool
 	ignoreTimeout bool
+	// nonInteractive disables prompting for omitted exchange, asset and pair
+	// arguments
+	nonInteractive bool
 )
 
 const defaultTimeout = time.Second * 30
 
-func jsonOutput(in interface{}) {
-	j, err := json.MarshalIndent(in, "", " ")
-	if err != nil {
-		return
-	}
-	fmt.Print(string(j))
-}
-
 func setupClient(c *cli.Context) (*grpc.ClientConn, context.CancelFunc, error) {
 	creds, err := credentials.NewClientTLSFromFile(certPath, "")
 	if err != nil {
@@ -54,12 +50,15 @@ func setupClient(c *cli.Context) (*grpc.ClientConn, context.CancelFunc, error) {
 			Username: username,
 			Password: password,
 		}),
+		grpc.WithChainUnaryInterceptor(deprecationUnaryInterceptor),
+		grpc.WithChainStreamInterceptor(deprecationStreamInterceptor),
 	}
 
 	var cancel context.CancelFunc
 	if !ignoreTimeout {
 		c.Context, cancel = context.WithTimeout(c.Context, timeout)
 	}
+	c.Context = metadata.AppendToOutgoingContext(c.Context, gctrpc.APIVersionMetadataKey, strconv.Itoa(gctrpc.APIVersion))
 	if !exchangeCreds.IsEmpty() {
 		flag, values := exchangeCreds.GetMetaData()
 		c.Context = metadata.AppendToOutgoingContext(c.Context, flag, values)
@@ -155,6 +154,20 @@ func main() {
 			Usage:       "ignores the context timeout for requests",
 			Destination: &ignoreTimeout,
 		},
+		&cli.StringFlag{
+			Name:        "output",
+			Value:       outputJSON,
+			Usage:       "the output format for command results: json, csv or table",
+			Destination: &outputFormat,
+		},
+		&cli.BoolFlag{
+			Name:        "nointeractive",
+			Usage:       "disables prompting for the exchange, asset and pair when a command is run without arguments",
+			Destination: &nonInteractive,
+		},
+	}
+	app.Before = func(*cli.Context) error {
+		return validateOutputFormat(outputFormat)
 	}
 	app.Commands = []*cli.Command{
 		getInfoCommand,
@@ -162,6 +175,9 @@ func main() {
 		enableSubsystemCommand,
 		disableSubsystemCommand,
 		getRPCEndpointsCommand,
+		getRPCCapabilitiesCommand,
+		captureProfileCommand,
+		getMemoryUsageCommand,
 		getCommunicationRelayersCommand,
 		getExchangesCommand,
 		enableExchangeCommand,
@@ -185,6 +201,7 @@ func main() {
 		getManagedOrdersCommand,
 		getOrderCommand,
 		submitOrderCommand,
+		submitOptionStrategyCommand,
 		simulateOrderCommand,
 		cancelOrderCommand,
 		cancelBatchOrdersCommand,
@@ -196,15 +213,30 @@ func main() {
 		getCryptocurrencyDepositAddressesCommand,
 		getCryptocurrencyDepositAddressCommand,
 		getAvailableTransferChainsCommand,
+		getTransferNetworksCommand,
+		convertCurrencyCommand,
+		dustSweepCommand,
+		earnCommand,
 		withdrawCryptocurrencyFundsCommand,
 		withdrawFiatFundsCommand,
+		getWithdrawalSigningPayloadCommand,
+		submitSignedWithdrawalCommand,
+		getWithdrawalLocksCommand,
 		withdrawalRequestCommand,
 		getLoggerDetailsCommand,
 		setLoggerDetailsCommand,
 		exchangePairManagerCommand,
 		getTickerStreamCommand,
 		getExchangeTickerStreamCommand,
+		streamFillsCommand,
 		getAuditEventCommand,
+		getScheduledTasksCommand,
+		getRateLimitStatusCommand,
+		getDataQualityScoresCommand,
+		getDashboardSnapshotCommand,
+		getExchangeCalendarCommand,
+		getVolumeProfileCommand,
+		getVolatilityCorrelationCommand,
 		getHistoricCandlesCommand,
 		getHistoricCandlesExtendedCommand,
 		findMissingSavedCandleIntervalsCommand,
@@ -218,7 +250,9 @@ func main() {
 		technicalAnalysisCommand,
 		getMarginRatesHistoryCommand,
 		orderbookCommand,
+		batchCommand,
 	}
+	setupDynamicCompletion(app.Commands)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	go func() {
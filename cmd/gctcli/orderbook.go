@@ -136,7 +136,7 @@ func getNominal(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -222,7 +222,7 @@ func getImpact(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -346,7 +346,7 @@ func getMovement(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 
 	return nil
 }
@@ -469,7 +469,7 @@ func getOrderbook(c *cli.Context) error {
 		}
 		renderOrderbookExchangeStyle(result, exchangeName, assetType, maxLen, askLen, bidLen)
 	} else {
-		jsonOutput(result)
+		printOutput(result)
 	}
 	return nil
 }
@@ -493,7 +493,7 @@ func getOrderbooks(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
 
@@ -863,6 +863,6 @@ func whaleBomb(c *cli.Context) error {
 		return err
 	}
 
-	jsonOutput(result)
+	printOutput(result)
 	return nil
 }
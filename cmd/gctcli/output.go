@@ -0,0 +1,244 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+)
+
+// Supported output formats
+const (
+	outputJSON  = "json"
+	outputCSV   = "csv"
+	outputTable = "table"
+)
+
+var (
+	outputFormat = outputJSON
+
+	errInvalidOutputFormat = errors.New("invalid output format, supported formats are json, csv and table")
+)
+
+// orderedObject is a JSON object which retains the order of its keys
+type orderedObject struct {
+	keys   []string
+	values map[string]any
+}
+
+func validateOutputFormat(format string) error {
+	switch format {
+	case outputJSON, outputCSV, outputTable:
+		return nil
+	}
+	return fmt.Errorf("%w: %q", errInvalidOutputFormat, format)
+}
+
+// printOutput writes a command result to stdout in the selected output format
+func printOutput(in any) {
+	if err := writeOutput(os.Stdout, outputFormat, in); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+}
+
+func writeOutput(w io.Writer, format string, in any) error {
+	j, err := json.MarshalIndent(in, "", " ")
+	if err != nil {
+		return err
+	}
+	if format == outputJSON {
+		_, err = w.Write(j)
+		return err
+	}
+	dec := json.NewDecoder(bytes.NewReader(j))
+	dec.UseNumber()
+	v, err := decodeOrdered(dec)
+	if err != nil {
+		return err
+	}
+	header, rows := tabulate(v)
+	switch format {
+	case outputCSV:
+		cw := csv.NewWriter(w)
+		if err = cw.Write(header); err != nil {
+			return err
+		}
+		if err = cw.WriteAll(rows); err != nil {
+			return err
+		}
+		cw.Flush()
+		return cw.Error()
+	case outputTable:
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		upper := make([]string, len(header))
+		for i := range header {
+			upper[i] = strings.ToUpper(header[i])
+		}
+		if _, err = fmt.Fprintln(tw, strings.Join(upper, "\t")); err != nil {
+			return err
+		}
+		for i := range rows {
+			if _, err = fmt.Fprintln(tw, strings.Join(rows[i], "\t")); err != nil {
+				return err
+			}
+		}
+		return tw.Flush()
+	}
+	return fmt.Errorf("%w: %q", errInvalidOutputFormat, format)
+}
+
+// tabulate converts a decoded result into a header and rows. Arrays, and
+// objects whose only field is an array such as {"orders": [...]}, produce a
+// row per element; any other result is a single row. Nested objects are
+// flattened into dotted columns
+func tabulate(v any) (header []string, rows [][]string) {
+	records := []any{v}
+	switch t := v.(type) {
+	case []any:
+		records = t
+	case *orderedObject:
+		if len(t.keys) == 1 {
+			if arr, ok := t.values[t.keys[0]].([]any); ok {
+				records = arr
+			}
+		}
+	}
+
+	columns := make(map[string]int)
+	flat := make([]map[string]string, len(records))
+	for i := range records {
+		flat[i] = make(map[string]string)
+		flatten("", records[i], flat[i], func(col string) {
+			if _, ok := columns[col]; !ok {
+				columns[col] = len(header)
+				header = append(header, col)
+			}
+		})
+	}
+	rows = make([][]string, len(flat))
+	for i := range flat {
+		rows[i] = make([]string, len(header))
+		for col, val := range flat[i] {
+			rows[i][columns[col]] = val
+		}
+	}
+	return header, rows
+}
+
+func flatten(prefix string, v any, out map[string]string, addColumn func(string)) {
+	join := func(k string) string {
+		if prefix == "" {
+			return k
+		}
+		return prefix + "." + k
+	}
+	switch t := v.(type) {
+	case *orderedObject:
+		for _, k := range t.keys {
+			flatten(join(k), t.values[k], out, addColumn)
+		}
+		return
+	case []any:
+		if prefix == "" {
+			prefix = "value"
+		}
+		scalars := make([]string, 0, len(t))
+		for i := range t {
+			switch t[i].(type) {
+			case *orderedObject, []any:
+				j, _ := json.Marshal(toPlain(t))
+				addColumn(prefix)
+				out[prefix] = string(j)
+				return
+			}
+			scalars = append(scalars, scalarString(t[i]))
+		}
+		addColumn(prefix)
+		out[prefix] = strings.Join(scalars, ";")
+		return
+	}
+	if prefix == "" {
+		prefix = "value"
+	}
+	addColumn(prefix)
+	out[prefix] = scalarString(v)
+}
+
+func scalarString(v any) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// toPlain converts ordered objects back into maps for re-encoding
+func toPlain(v any) any {
+	switch t := v.(type) {
+	case *orderedObject:
+		m := make(map[string]any, len(t.keys))
+		for k, val := range t.values {
+			m[k] = toPlain(val)
+		}
+		return m
+	case []any:
+		out := make([]any, len(t))
+		for i := range t {
+			out[i] = toPlain(t[i])
+		}
+		return out
+	}
+	return v
+}
+
+// decodeOrdered decodes the next JSON value, retaining object key order
+func decodeOrdered(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch d := tok.(type) {
+	case json.Delim:
+		switch d {
+		case '{':
+			obj := &orderedObject{values: make(map[string]any)}
+			for dec.More() {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				k, ok := keyTok.(string)
+				if !ok {
+					return nil, fmt.Errorf("unexpected object key %v", keyTok)
+				}
+				val, err := decodeOrdered(dec)
+				if err != nil {
+					return nil, err
+				}
+				if _, ok := obj.values[k]; !ok {
+					obj.keys = append(obj.keys, k)
+				}
+				obj.values[k] = val
+			}
+			_, err = dec.Token() // closing brace
+			return obj, err
+		case '[':
+			arr := []any{}
+			for dec.More() {
+				val, err := decodeOrdered(dec)
+				if err != nil {
+					return nil, err
+				}
+				arr = append(arr, val)
+			}
+			_, err = dec.Token() // closing bracket
+			return arr, err
+		}
+		return nil, fmt.Errorf("unexpected delimiter %v", d)
+	}
+	return tok, nil
+}
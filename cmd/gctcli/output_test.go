@@ -0,0 +1,160 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func decodeTestJSON(t *testing.T, s string) any {
+	t.Helper()
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	v, err := decodeOrdered(dec)
+	require.NoError(t, err, "decodeOrdered must not error")
+	return v
+}
+
+func TestValidateOutputFormat(t *testing.T) {
+	t.Parallel()
+	for _, f := range []string{outputJSON, outputCSV, outputTable} {
+		assert.NoError(t, validateOutputFormat(f), f)
+	}
+	assert.ErrorIs(t, validateOutputFormat("xml"), errInvalidOutputFormat)
+}
+
+func TestTabulate(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name   string
+		in     string
+		header []string
+		rows   [][]string
+	}{
+		{
+			name:   "key order",
+			in:     `{"zeta":1,"alpha":"x","mid":true}`,
+			header: []string{"zeta", "alpha", "mid"},
+			rows:   [][]string{{"1", "x", "true"}},
+		},
+		{
+			name:   "nested maps",
+			in:     `{"order":{"id":"1","fees":{"maker":0.1,"taker":0.2}},"ok":true}`,
+			header: []string{"order.id", "order.fees.maker", "order.fees.taker", "ok"},
+			rows:   [][]string{{"1", "0.1", "0.2", "true"}},
+		},
+		{
+			name:   "array of records",
+			in:     `[{"a":1,"b":2},{"b":3,"c":null}]`,
+			header: []string{"a", "b", "c"},
+			rows:   [][]string{{"1", "2", ""}, {"", "3", ""}},
+		},
+		{
+			name:   "wrapped array",
+			in:     `{"orders":[{"id":"1"},{"id":"2"}]}`,
+			header: []string{"id"},
+			rows:   [][]string{{"1"}, {"2"}},
+		},
+		{
+			name:   "scalar array field",
+			in:     `{"pairs":["BTC-USD","ETH-USD"],"count":2}`,
+			header: []string{"pairs", "count"},
+			rows:   [][]string{{"BTC-USD;ETH-USD", "2"}},
+		},
+		{
+			name:   "nested array of objects",
+			in:     `{"fills":[{"price":1}],"count":1}`,
+			header: []string{"fills", "count"},
+			rows:   [][]string{{`[{"price":1}]`, "1"}},
+		},
+		{
+			name:   "duplicate keys",
+			in:     `{"a":1,"b":2,"a":3}`,
+			header: []string{"a", "b"},
+			rows:   [][]string{{"3", "2"}},
+		},
+		{
+			name:   "scalar",
+			in:     `5`,
+			header: []string{"value"},
+			rows:   [][]string{{"5"}},
+		},
+		{
+			name:   "scalar array",
+			in:     `["a","b"]`,
+			header: []string{"value"},
+			rows:   [][]string{{"a"}, {"b"}},
+		},
+		{name: "empty array", in: `[]`},
+		{name: "empty wrapped array", in: `{"orders":[]}`},
+		{name: "empty object", in: `{}`, rows: [][]string{{}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			header, rows := tabulate(decodeTestJSON(t, tc.in))
+			assert.Equal(t, tc.header, header, "header should match")
+			if len(tc.rows) == 0 {
+				assert.Empty(t, rows, "rows should be empty")
+				return
+			}
+			assert.Equal(t, tc.rows, rows, "rows should match")
+		})
+	}
+}
+
+func TestDecodeOrdered(t *testing.T) {
+	t.Parallel()
+	v := decodeTestJSON(t, `{"b":{"d":1,"c":[2,{"f":3,"e":4}]},"a":null}`)
+	obj, ok := v.(*orderedObject)
+	require.True(t, ok, "objects must decode as ordered objects")
+	assert.Equal(t, []string{"b", "a"}, obj.keys, "object key order should be retained")
+	nested, ok := obj.values["b"].(*orderedObject)
+	require.True(t, ok, "nested objects must decode as ordered objects")
+	assert.Equal(t, []string{"d", "c"}, nested.keys, "nested key order should be retained")
+	arr, ok := nested.values["c"].([]any)
+	require.True(t, ok, "arrays must decode as slices")
+	require.Len(t, arr, 2)
+	assert.Equal(t, json.Number("2"), arr[0], "numbers should be decoded as json.Number")
+	inner, ok := arr[1].(*orderedObject)
+	require.True(t, ok, "objects within arrays must decode as ordered objects")
+	assert.Equal(t, []string{"f", "e"}, inner.keys)
+	assert.Nil(t, obj.values["a"])
+	assert.Equal(t, map[string]any{"b": map[string]any{"d": json.Number("1"), "c": []any{json.Number("2"), map[string]any{"f": json.Number("3"), "e": json.Number("4")}}}, "a": nil}, toPlain(v), "toPlain should convert ordered objects to maps")
+
+	for _, in := range []string{`{"a":1`, `{"a":}`, `]`, ``} {
+		_, err := decodeOrdered(json.NewDecoder(strings.NewReader(in)))
+		assert.Error(t, err, "decodeOrdered should error on %q", in)
+	}
+}
+
+func TestWriteOutput(t *testing.T) {
+	t.Parallel()
+	in := struct {
+		Orders []struct {
+			ID    string  `json:"id"`
+			Price float64 `json:"price"`
+		} `json:"orders"`
+	}{}
+	in.Orders = append(in.Orders, struct {
+		ID    string  `json:"id"`
+		Price float64 `json:"price"`
+	}{ID: "1", Price: 1.5})
+
+	var b bytes.Buffer
+	require.NoError(t, writeOutput(&b, outputCSV, in))
+	assert.Equal(t, "id,price\n1,1.5\n", b.String(), "csv output should match")
+
+	b.Reset()
+	require.NoError(t, writeOutput(&b, outputTable, in))
+	assert.Equal(t, "ID  PRICE\n1   1.5\n", b.String(), "table output should match")
+
+	b.Reset()
+	require.NoError(t, writeOutput(&b, outputJSON, in))
+	assert.JSONEq(t, `{"orders":[{"id":"1","price":1.5}]}`, b.String(), "json output should match")
+
+	assert.ErrorIs(t, writeOutput(&b, "xml", in), errInvalidOutputFormat)
+}
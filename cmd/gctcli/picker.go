@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"github.com/urfave/cli/v2"
+)
+
+// maxPickerOptions limits the options listed at once, a filter narrows the
+// remainder
+const maxPickerOptions = 20
+
+var errNoPickerOptions = errors.New("no options available to pick from")
+
+// pickMissingArguments prompts for the exchange, asset and pair when a command
+// is run without any arguments from an interactive terminal
+func pickMissingArguments(c *cli.Context) error {
+	if nonInteractive || c.NArg() != 0 || c.NumFlags() != 0 || !isTerminal(os.Stdin) {
+		return nil
+	}
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	in := bufio.NewReader(os.Stdin)
+
+	pickers := []struct {
+		flag    string
+		options func(ctx context.Context) ([]string, error)
+	}{
+		{exchangeFlagName, func(ctx context.Context) ([]string, error) {
+			return enabledExchanges(ctx, client)
+		}},
+		{assetFlagName, func(ctx context.Context) ([]string, error) {
+			return exchangeAssets(ctx, client, c.String(exchangeFlagName))
+		}},
+		{pairFlagName, func(ctx context.Context) ([]string, error) {
+			return exchangePairs(ctx, client, c.String(exchangeFlagName), c.String(assetFlagName))
+		}},
+	}
+	for _, p := range pickers {
+		if !hasFlag(c.Command, p.flag) {
+			continue
+		}
+		options, err := p.options(c.Context)
+		if err != nil {
+			return err
+		}
+		choice, err := pick(in, os.Stdout, p.flag, options)
+		if err != nil {
+			return fmt.Errorf("%s: %w", p.flag, err)
+		}
+		if err := c.Set(p.flag, choice); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pick prompts for a choice from options. Entering a number selects the listed
+// option and any other input fuzzy filters the options until one remains
+func pick(in *bufio.Reader, out io.Writer, label string, options []string) (string, error) {
+	if len(options) == 0 {
+		return "", errNoPickerOptions
+	}
+	matches := options
+	for {
+		if len(matches) == 1 {
+			fmt.Fprintf(out, "%s: %s\n", label, matches[0])
+			return matches[0], nil
+		}
+		for i := 0; i < len(matches) && i < maxPickerOptions; i++ {
+			fmt.Fprintf(out, "%3d) %s\n", i+1, matches[i])
+		}
+		if len(matches) > maxPickerOptions {
+			fmt.Fprintf(out, "     ... %d more, type to filter\n", len(matches)-maxPickerOptions)
+		}
+		fmt.Fprintf(out, "Select %s: ", label)
+		line, err := in.ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+		line = strings.TrimSpace(line)
+		if n, err := strconv.Atoi(line); err == nil && n > 0 && n <= len(matches) && n <= maxPickerOptions {
+			return matches[n-1], nil
+		}
+		filtered := fuzzyFilter(options, line)
+		if len(filtered) == 0 {
+			fmt.Fprintf(out, "No %s matches %q\n", label, line)
+			continue
+		}
+		matches = filtered
+	}
+}
+
+// fuzzyFilter returns the options containing the characters of query in
+// order, ignoring case. Exact matches rank first, then prefix matches, then
+// substring matches, then other matches
+func fuzzyFilter(options []string, query string) []string {
+	query = strings.ToLower(query)
+	if query == "" {
+		return options
+	}
+	type match struct {
+		option string
+		rank   int
+	}
+	var matches []match
+	for _, o := range options {
+		lower := strings.ToLower(o)
+		switch {
+		case lower == query:
+			matches = append(matches, match{o, 0})
+		case strings.HasPrefix(lower, query):
+			matches = append(matches, match{o, 1})
+		case strings.Contains(lower, query):
+			matches = append(matches, match{o, 2})
+		case isSubsequence(lower, query):
+			matches = append(matches, match{o, 3})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].rank < matches[j].rank })
+	if len(matches) > 0 && matches[0].rank == 0 {
+		return []string{matches[0].option}
+	}
+	out := make([]string, len(matches))
+	for i := range matches {
+		out[i] = matches[i].option
+	}
+	return out
+}
+
+func isSubsequence(s, sub string) bool {
+	i := 0
+	for j := 0; j < len(s) && i < len(sub); j++ {
+		if s[j] == sub[i] {
+			i++
+		}
+	}
+	return i == len(sub)
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFuzzyFilter(t *testing.T) {
+	t.Parallel()
+	options := []string{"Binance", "BinanceUS", "Bitstamp", "Bitfinex", "Kraken", "coinbasepro"}
+	for _, tc := range []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{name: "empty query", query: "", want: options},
+		{name: "exact match only", query: "binance", want: []string{"Binance"}},
+		{name: "case insensitive exact", query: "KRAKEN", want: []string{"Kraken"}},
+		{name: "prefix", query: "bit", want: []string{"Bitstamp", "Bitfinex"}},
+		{name: "prefix before substring", query: "b", want: []string{"Binance", "BinanceUS", "Bitstamp", "Bitfinex", "coinbasepro"}},
+		{name: "substrings keep option order", query: "in", want: []string{"Binance", "BinanceUS", "Bitfinex", "coinbasepro"}},
+		{name: "substring", query: "base", want: []string{"coinbasepro"}},
+		{name: "subsequence", query: "bstp", want: []string{"Bitstamp"}},
+		{name: "substring before subsequence", query: "ba", want: []string{"coinbasepro", "Binance", "BinanceUS", "Bitstamp"}},
+		{name: "no match", query: "xyz", want: []string{}},
+		{name: "out of order", query: "pmats", want: []string{}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := fuzzyFilter(options, tc.query)
+			if len(tc.want) == 0 {
+				assert.Empty(t, got, "no options should match")
+				return
+			}
+			assert.Equal(t, tc.want, got, "matches should be ranked exact, prefix, substring then subsequence")
+		})
+	}
+}
+
+func TestIsSubsequence(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		s, sub string
+		want   bool
+	}{
+		{"bitstamp", "", true},
+		{"bitstamp", "bsp", true},
+		{"bitstamp", "bitstamp", true},
+		{"bitstamp", "pb", false},
+		{"bit", "bits", false},
+	} {
+		assert.Equal(t, tc.want, isSubsequence(tc.s, tc.sub), "%q in %q", tc.sub, tc.s)
+	}
+}
+
+func TestPick(t *testing.T) {
+	t.Parallel()
+	options := []string{"Binance", "Bitstamp", "Bitfinex", "Kraken"}
+	for _, tc := range []struct {
+		name  string
+		input string
+		want  string
+		err   error
+	}{
+		{name: "number", input: "2\n", want: "Bitstamp"},
+		{name: "exact", input: "kraken\n", want: "Kraken"},
+		{name: "single fuzzy match", input: "fnx\n", want: "Bitfinex"},
+		{name: "filter then number", input: "bit\n2\n", want: "Bitfinex"},
+		{name: "no match then match", input: "xyz\nkra\n", want: "Kraken"},
+		{name: "out of range number filters", input: "9\n1\n", want: "Binance"},
+		{name: "input ends", input: "bit\n", err: io.EOF},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var out bytes.Buffer
+			got, err := pick(bufio.NewReader(strings.NewReader(tc.input)), &out, "exchange", options)
+			if tc.err != nil {
+				assert.ErrorIs(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	_, err := pick(bufio.NewReader(strings.NewReader("")), io.Discard, "exchange", nil)
+	assert.ErrorIs(t, err, errNoPickerOptions)
+
+	var out bytes.Buffer
+	got, err := pick(bufio.NewReader(strings.NewReader("")), &out, "exchange", []string{"Kraken"})
+	require.NoError(t, err)
+	assert.Equal(t, "Kraken", got, "a single option should be picked without prompting")
+	assert.Equal(t, "exchange: Kraken\n", out.String())
+
+	many := make([]string, maxPickerOptions+5)
+	for i := range many {
+		many[i] = fmt.Sprintf("option%02d", i)
+	}
+	out.Reset()
+	_, err = pick(bufio.NewReader(strings.NewReader("")), &out, "option", many)
+	assert.ErrorIs(t, err, io.EOF)
+	assert.Contains(t, out.String(), "... 5 more, type to filter", "options beyond the limit should be summarised")
+	assert.NotContains(t, out.String(), many[maxPickerOptions], "options beyond the limit should not be listed")
+}
+
+func TestSplitList(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		in   string
+		want []string
+	}{
+		{"", nil},
+		{"binance", []string{"binance"}},
+		{"binance, kraken ,,bitstamp", []string{"binance", "kraken", "bitstamp"}},
+		{" , ", nil},
+	} {
+		assert.Equal(t, tc.want, splitList(tc.in), tc.in)
+	}
+}
@@ -0,0 +1,661 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	errNoPaths        = errors.New("spec has no paths")
+	errUnresolvedRef  = errors.New("unresolved reference")
+	errUnsupportedRef = errors.New("unsupported reference")
+)
+
+// methods are the operation methods generated, in output order
+var methods = []string{"get", "put", "post", "delete", "patch"}
+
+// spec holds the parts of an OpenAPI 3 document used for generation
+type spec struct {
+	Paths      map[string]map[string]json.RawMessage `json:"paths"`
+	Security   []map[string][]string                 `json:"security"`
+	Components struct {
+		Schemas    map[string]*schema    `json:"schemas"`
+		Parameters map[string]*parameter `json:"parameters"`
+	} `json:"components"`
+}
+
+type operation struct {
+	OperationID string                 `json:"operationId"`
+	Summary     string                 `json:"summary"`
+	Description string                 `json:"description"`
+	Tags        []string               `json:"tags"`
+	Deprecated  bool                   `json:"deprecated"`
+	Parameters  []*parameter           `json:"parameters"`
+	RequestBody *content               `json:"requestBody"`
+	Responses   map[string]*content    `json:"responses"`
+	Security    *[]map[string][]string `json:"security"`
+}
+
+type content struct {
+	Content map[string]struct {
+		Schema *schema `json:"schema"`
+	} `json:"content"`
+}
+
+// jsonSchema returns the JSON media type schema of a request or response
+func (c *content) jsonSchema() *schema {
+	if c == nil {
+		return nil
+	}
+	for mediaType, v := range c.Content {
+		if strings.HasPrefix(mediaType, "application/json") {
+			return v.Schema
+		}
+	}
+	return nil
+}
+
+type parameter struct {
+	Ref         string  `json:"$ref"`
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description"`
+	Required    bool    `json:"required"`
+	Schema      *schema `json:"schema"`
+}
+
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 schemaType         `json:"type"`
+	Description          string             `json:"description"`
+	Properties           map[string]*schema `json:"properties"`
+	Required             []string           `json:"required"`
+	Items                *schema            `json:"items"`
+	AdditionalProperties json.RawMessage    `json:"additionalProperties"`
+	AllOf                []*schema          `json:"allOf"`
+}
+
+// schemaType is a schema type, which OpenAPI 3.1 allows to be a list such as
+// ["string", "null"]
+type schemaType string
+
+// UnmarshalJSON takes the first non null type of a type list
+func (t *schemaType) UnmarshalJSON(data []byte) error {
+	var types []string
+	if err := json.Unmarshal(data, &types); err != nil {
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*t = schemaType(s)
+		return nil
+	}
+	for _, s := range types {
+		if s != "null" {
+			*t = schemaType(s)
+			return nil
+		}
+	}
+	return nil
+}
+
+// loadSpec reads a JSON or YAML OpenAPI spec
+func loadSpec(path string) (*spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if ext := strings.ToLower(filepath.Ext(path)); ext == ".yaml" || ext == ".yml" {
+		var doc any
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if data, err = json.Marshal(normaliseYAML(doc)); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	var s spec
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(s.Paths) == 0 {
+		return nil, fmt.Errorf("%s: %w", path, errNoPaths)
+	}
+	return &s, nil
+}
+
+// normaliseYAML converts YAML maps with non string keys, such as response
+// status codes, so the document can be marshalled as JSON
+func normaliseYAML(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, e := range t {
+			t[k] = normaliseYAML(e)
+		}
+		return t
+	case map[any]any:
+		m := make(map[string]any, len(t))
+		for k, e := range t {
+			m[fmt.Sprint(k)] = normaliseYAML(e)
+		}
+		return m
+	case []any:
+		for i := range t {
+			t[i] = normaliseYAML(t[i])
+		}
+	}
+	return v
+}
+
+type genConfig struct {
+	pkg    string
+	prefix string
+	tags   []string
+	// source is the spec file name recorded in the generated header
+	source string
+}
+
+type generator struct {
+	cfg   *genConfig
+	spec  *spec
+	types map[string]bool
+	decls bytes.Buffer
+	ops   bytes.Buffer
+	// usesQuery is set when an operation has query parameters
+	usesQuery bool
+}
+
+// generate returns the formatted source of a client for the spec
+func generate(s *spec, cfg *genConfig) ([]byte, error) {
+	g := &generator{cfg: cfg, spec: s, types: make(map[string]bool)}
+	names := make([]string, 0, len(s.Components.Schemas))
+	for name := range s.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := g.defineComponent(name); err != nil {
+			return nil, err
+		}
+	}
+
+	paths := make([]string, 0, len(s.Paths))
+	for path := range s.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	var opCount int
+	for _, path := range paths {
+		item := s.Paths[path]
+		var shared []*parameter
+		if raw, ok := item["parameters"]; ok {
+			if err := json.Unmarshal(raw, &shared); err != nil {
+				return nil, fmt.Errorf("%s parameters: %w", path, err)
+			}
+		}
+		for _, method := range methods {
+			raw, ok := item[method]
+			if !ok {
+				continue
+			}
+			var op operation
+			if err := json.Unmarshal(raw, &op); err != nil {
+				return nil, fmt.Errorf("%s %s: %w", method, path, err)
+			}
+			if !g.matchesTags(op.Tags) {
+				continue
+			}
+			op.Parameters = append(slices.Clone(shared), op.Parameters...)
+			if err := g.writeOperation(path, method, &op); err != nil {
+				return nil, fmt.Errorf("%s %s: %w", method, path, err)
+			}
+			opCount++
+		}
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by openapi_gen from %s; DO NOT EDIT.\n\npackage %s\n\n", g.cfg.source, g.cfg.pkg)
+	if opCount > 0 {
+		out.WriteString("import (\n\"context\"\n\"net/http\"\n")
+		if g.usesQuery {
+			out.WriteString("\"net/url\"\n")
+		}
+		out.WriteString("\n\"github.com/thrasher-corp/gocryptotrader/exchanges/openapi\"\n)\n\n")
+	}
+	out.Write(g.decls.Bytes())
+	out.Write(g.ops.Bytes())
+	return format.Source(out.Bytes())
+}
+
+func (g *generator) matchesTags(tags []string) bool {
+	if len(g.cfg.tags) == 0 {
+		return true
+	}
+	for _, t := range tags {
+		if slices.ContainsFunc(g.cfg.tags, func(want string) bool { return strings.EqualFold(want, t) }) {
+			return true
+		}
+	}
+	return false
+}
+
+// typeName returns the generated name for a spec name
+func (g *generator) typeName(name string) string {
+	return g.cfg.prefix + exportName(name)
+}
+
+// defineComponent declares a type for a component schema
+func (g *generator) defineComponent(name string) error {
+	s := g.spec.Components.Schemas[name]
+	typeName := g.typeName(name)
+	if g.types[typeName] {
+		return nil
+	}
+	if isObject(s) {
+		return g.defineStruct(typeName, name+" schema", s)
+	}
+	g.types[typeName] = true
+	t, err := g.goType(s, typeName+"Item")
+	if err != nil {
+		return fmt.Errorf("schema %s: %w", name, err)
+	}
+	writeComment(&g.decls, typeName, s.Description, "is the "+name+" schema")
+	fmt.Fprintf(&g.decls, "type %s %s\n\n", typeName, t)
+	return nil
+}
+
+func isObject(s *schema) bool {
+	return s != nil && s.Ref == "" && len(s.Properties) > 0 || s != nil && len(s.AllOf) > 1
+}
+
+// defineStruct declares a struct type for an object schema
+func (g *generator) defineStruct(typeName, source string, s *schema) error {
+	g.types[typeName] = true
+	props, required, err := g.properties(s)
+	if err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(props))
+	for k := range props {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var fields bytes.Buffer
+	used := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		field := uniqueName(exportName(k), used)
+		t, err := g.goType(props[k], typeName+field)
+		if err != nil {
+			return fmt.Errorf("%s property %s: %w", source, k, err)
+		}
+		if d := firstLine(props[k].Description); d != "" {
+			fmt.Fprintf(&fields, "// %s %s\n", field, describe(d))
+		}
+		tag := k
+		if !slices.Contains(required, k) {
+			tag += ",omitempty"
+		}
+		fmt.Fprintf(&fields, "%s %s `json:%q`\n", field, t, tag)
+	}
+	writeComment(&g.decls, typeName, s.Description, "is generated from the "+source)
+	fmt.Fprintf(&g.decls, "type %s struct {\n%s}\n\n", typeName, fields.Bytes())
+	return nil
+}
+
+// properties returns the properties and required properties of an object
+// schema, merging allOf schemas
+func (g *generator) properties(s *schema) (map[string]*schema, []string, error) {
+	props := make(map[string]*schema, len(s.Properties))
+	for k, v := range s.Properties {
+		props[k] = v
+	}
+	required := slices.Clone(s.Required)
+	for _, sub := range s.AllOf {
+		resolved, err := g.resolve(sub)
+		if err != nil {
+			return nil, nil, err
+		}
+		subProps, subRequired, err := g.properties(resolved)
+		if err != nil {
+			return nil, nil, err
+		}
+		for k, v := range subProps {
+			props[k] = v
+		}
+		required = append(required, subRequired...)
+	}
+	return props, required, nil
+}
+
+// resolve returns the component schema referenced by a schema
+func (g *generator) resolve(s *schema) (*schema, error) {
+	if s.Ref == "" {
+		return s, nil
+	}
+	name, err := refName(s.Ref, "#/components/schemas/")
+	if err != nil {
+		return nil, err
+	}
+	resolved, ok := g.spec.Components.Schemas[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errUnresolvedRef, s.Ref)
+	}
+	return resolved, nil
+}
+
+// goType returns the Go type of a schema, declaring struct types for inline
+// objects with the supplied name
+func (g *generator) goType(s *schema, name string) (string, error) {
+	switch {
+	case s == nil:
+		return "any", nil
+	case s.Ref != "":
+		ref, err := refName(s.Ref, "#/components/schemas/")
+		if err != nil {
+			return "", err
+		}
+		if _, ok := g.spec.Components.Schemas[ref]; !ok {
+			return "", fmt.Errorf("%w: %s", errUnresolvedRef, s.Ref)
+		}
+		return g.typeName(ref), nil
+	case len(s.AllOf) == 1 && len(s.Properties) == 0:
+		return g.goType(s.AllOf[0], name)
+	case isObject(s):
+		if !g.types[name] {
+			if err := g.defineStruct(name, name+" object", s); err != nil {
+				return "", err
+			}
+		}
+		return name, nil
+	}
+	switch s.Type {
+	case "string":
+		return "string", nil
+	case "integer":
+		return "int64", nil
+	case "number":
+		return "float64", nil
+	case "boolean":
+		return "bool", nil
+	case "array":
+		t, err := g.goType(s.Items, name+"Item")
+		return "[]" + t, err
+	case "object":
+		var values *schema
+		if len(s.AdditionalProperties) > 0 && s.AdditionalProperties[0] == '{' {
+			if err := json.Unmarshal(s.AdditionalProperties, &values); err != nil {
+				return "", err
+			}
+		}
+		t, err := g.goType(values, name+"Value")
+		return "map[string]" + t, err
+	}
+	return "any", nil
+}
+
+// writeOperation declares an operation, its parameters and its client func
+func (g *generator) writeOperation(path, method string, op *operation) error {
+	fn := g.typeName(op.OperationID)
+	if op.OperationID == "" {
+		fn = g.typeName(method + " " + path)
+	}
+	authenticated := len(g.spec.Security) > 0
+	if op.Security != nil {
+		authenticated = len(*op.Security) > 0
+	}
+	opVar := "op" + fn
+	fmt.Fprintf(&g.ops, "// %s describes the %s %s operation\n", opVar, strings.ToUpper(method), path)
+	fmt.Fprintf(&g.ops, "var %s = openapi.Operation{ID: %q, Method: http.Method%s, Path: %q, Authenticated: %t}\n\n",
+		opVar, op.OperationID, exportName(method), path, authenticated)
+
+	params := make([]*parameter, 0, len(op.Parameters))
+	for _, p := range op.Parameters {
+		if p.Ref != "" {
+			name, err := refName(p.Ref, "#/components/parameters/")
+			if err != nil {
+				return err
+			}
+			resolved, ok := g.spec.Components.Parameters[name]
+			if !ok {
+				return fmt.Errorf("%w: %s", errUnresolvedRef, p.Ref)
+			}
+			p = resolved
+		}
+		if p.In == "path" || p.In == "query" {
+			params = append(params, p)
+		}
+	}
+	body := op.RequestBody.jsonSchema()
+
+	var fields, pathArgs, queryArgs bytes.Buffer
+	used := make(map[string]bool, len(params))
+	paramsType := fn + "Params"
+	for _, p := range params {
+		field := uniqueName(exportName(p.Name), used)
+		t, err := g.goType(p.Schema, paramsType+field)
+		if err != nil {
+			return fmt.Errorf("parameter %s: %w", p.Name, err)
+		}
+		if d := firstLine(p.Description); d != "" {
+			fmt.Fprintf(&fields, "// %s %s\n", field, describe(d))
+		}
+		fmt.Fprintf(&fields, "%s %s\n", field, t)
+		if p.In == "path" {
+			fmt.Fprintf(&pathArgs, "%q: p.%s,\n", p.Name, field)
+		} else {
+			fmt.Fprintf(&queryArgs, "openapi.SetQuery(q, %q, p.%s, %t)\n", p.Name, field, p.Required)
+		}
+	}
+	if body != nil {
+		t, err := g.goType(body, fn+"Request")
+		if err != nil {
+			return fmt.Errorf("request body: %w", err)
+		}
+		fmt.Fprintf(&fields, "Body %s\n", t)
+	}
+	hasParams := fields.Len() > 0
+	if hasParams {
+		fmt.Fprintf(&g.ops, "// %s holds the parameters of %s\ntype %s struct {\n%s}\n\n", paramsType, fn, paramsType, fields.Bytes())
+	}
+
+	resultType, pointer, err := g.resultType(op, fn)
+	if err != nil {
+		return err
+	}
+
+	writeComment(&g.ops, fn, firstLine(op.Summary+"\n"+op.Description), "sends the "+strings.ToUpper(method)+" "+path+" operation")
+	if op.Deprecated {
+		g.ops.WriteString("//\n// Deprecated: the operation is deprecated by the exchange\n")
+	}
+	args := "ctx context.Context, s openapi.Sender"
+	if hasParams {
+		args += ", p *" + paramsType
+	}
+	ret, errRet := "error", "err"
+	if resultType != "" {
+		if pointer {
+			ret, errRet = "(*"+resultType+", error)", "nil, err"
+		} else {
+			ret, errRet = "("+resultType+", error)", "resp, err"
+		}
+	}
+	fmt.Fprintf(&g.ops, "func %s(%s) %s {\n", fn, args, ret)
+	if hasParams {
+		g.ops.WriteString("if p == nil {\np = new(" + paramsType + ")\n}\n")
+	}
+	if resultType != "" && !pointer {
+		g.ops.WriteString("var resp " + resultType + "\n")
+	}
+	pathExpr := opVar + ".Path"
+	if pathArgs.Len() > 0 {
+		fmt.Fprintf(&g.ops, "path, err := openapi.ExpandPath(%s.Path, map[string]any{\n%s})\nif err != nil {\nreturn %s\n}\n", opVar, pathArgs.Bytes(), errRet)
+		pathExpr = "path"
+	}
+	queryExpr := "nil"
+	if queryArgs.Len() > 0 {
+		g.usesQuery = true
+		fmt.Fprintf(&g.ops, "q := url.Values{}\n%s", queryArgs.Bytes())
+		queryExpr = "q"
+	}
+	bodyExpr := "nil"
+	if body != nil {
+		bodyExpr = "p.Body"
+	}
+	switch {
+	case resultType == "":
+		fmt.Fprintf(&g.ops, "return s.SendOperation(ctx, &%s, %s, %s, %s, nil)\n}\n\n", opVar, pathExpr, queryExpr, bodyExpr)
+	case pointer:
+		fmt.Fprintf(&g.ops, "var resp %s\nreturn &resp, s.SendOperation(ctx, &%s, %s, %s, %s, &resp)\n}\n\n", resultType, opVar, pathExpr, queryExpr, bodyExpr)
+	default:
+		fmt.Fprintf(&g.ops, "return resp, s.SendOperation(ctx, &%s, %s, %s, %s, &resp)\n}\n\n", opVar, pathExpr, queryExpr, bodyExpr)
+	}
+	return nil
+}
+
+// resultType returns the Go type of an operation's successful JSON response
+// and whether it is returned by pointer
+func (g *generator) resultType(op *operation, fn string) (string, bool, error) {
+	codes := make([]string, 0, len(op.Responses))
+	for code := range op.Responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	codes = append(codes, "default")
+	for _, code := range codes {
+		s := op.Responses[code].jsonSchema()
+		if s == nil {
+			continue
+		}
+		t, err := g.goType(s, fn+"Response")
+		if err != nil {
+			return "", false, fmt.Errorf("response %s: %w", code, err)
+		}
+		return t, g.isStruct(s), nil
+	}
+	return "", false, nil
+}
+
+// isStruct returns whether a schema generates a struct type
+func (g *generator) isStruct(s *schema) bool {
+	if s.Ref != "" {
+		resolved, err := g.resolve(s)
+		return err == nil && isObject(resolved)
+	}
+	if len(s.AllOf) == 1 && len(s.Properties) == 0 {
+		return g.isStruct(s.AllOf[0])
+	}
+	return isObject(s)
+}
+
+func refName(ref, prefix string) (string, error) {
+	if !strings.HasPrefix(ref, prefix) {
+		return "", fmt.Errorf("%w: %s", errUnsupportedRef, ref)
+	}
+	return ref[len(prefix):], nil
+}
+
+// exportName converts a spec name to an exported Go identifier
+func exportName(name string) string {
+	var parts []string
+	for _, word := range strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		parts = append(parts, splitCamel(word)...)
+	}
+	var b strings.Builder
+	for _, p := range parts {
+		switch strings.ToLower(p) {
+		case "id", "url", "api", "uuid":
+			b.WriteString(strings.ToUpper(p))
+		default:
+			r := []rune(p)
+			r[0] = unicode.ToUpper(r[0])
+			b.WriteString(string(r))
+		}
+	}
+	if b.Len() == 0 {
+		return "Value"
+	}
+	s := b.String()
+	if unicode.IsDigit(rune(s[0])) {
+		return "N" + s
+	}
+	return s
+}
+
+// splitCamel splits a camel case word such as "orderId" into its parts
+func splitCamel(word string) []string {
+	r := []rune(word)
+	var parts []string
+	start := 0
+	for i := 1; i < len(r); i++ {
+		if unicode.IsLower(r[i-1]) && unicode.IsUpper(r[i]) {
+			parts = append(parts, string(r[start:i]))
+			start = i
+		}
+	}
+	return append(parts, string(r[start:]))
+}
+
+// uniqueName returns name, suffixed with a number when already used
+func uniqueName(name string, used map[string]bool) string {
+	unique := name
+	for i := 2; used[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	used[unique] = true
+	return unique
+}
+
+func writeComment(b *bytes.Buffer, name, description, fallback string) {
+	if d := firstLine(description); d != "" {
+		fmt.Fprintf(b, "// %s %s\n", name, describe(d))
+		return
+	}
+	fmt.Fprintf(b, "// %s %s\n", name, fallback)
+}
+
+func firstLine(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		s = s[:i]
+	}
+	return strings.TrimSpace(s)
+}
+
+// describe turns a spec description into the remainder of a doc comment
+// sentence, so "The order side" documents Side as "Side is the order side"
+func describe(s string) string {
+	s = strings.TrimSuffix(lowerFirst(s), ".")
+	for _, article := range []string{"the ", "a ", "an "} {
+		if strings.HasPrefix(s, article) {
+			return "is " + s
+		}
+	}
+	return s
+}
+
+func lowerFirst(s string) string {
+	r := []rune(s)
+	if len(r) > 1 && unicode.IsUpper(r[1]) {
+		// Keep acronyms such as "REST" intact
+		return s
+	}
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSpec = "testdata/spec.yaml"
+
+func TestLoadSpec(t *testing.T) {
+	t.Parallel()
+	_, err := loadSpec("testdata/missing.yaml")
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	empty := filepath.Join(t.TempDir(), "empty.json")
+	require.NoError(t, os.WriteFile(empty, []byte(`{"openapi":"3.0.0"}`), 0o600), "WriteFile must not error")
+	_, err = loadSpec(empty)
+	assert.ErrorIs(t, err, errNoPaths)
+
+	s, err := loadSpec(testSpec)
+	require.NoError(t, err, "loadSpec must not error")
+	assert.Len(t, s.Paths, 4)
+	require.Contains(t, s.Components.Schemas, "Ticker")
+	assert.Equal(t, schemaType("number"), s.Components.Schemas["Ticker"].Properties["last"].Type, "type lists should use the non null type")
+}
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+	s, err := loadSpec(testSpec)
+	require.NoError(t, err, "loadSpec must not error")
+
+	src, err := generate(s, &genConfig{pkg: "testexch", source: "spec.yaml"})
+	require.NoError(t, err, "generate must not error")
+	f, err := parser.ParseFile(token.NewFileSet(), "", src, parser.ParseComments)
+	require.NoError(t, err, "generated source must parse")
+	assert.Equal(t, "testexch", f.Name.Name)
+
+	out := string(src)
+	assert.Contains(t, out, "// Code generated by openapi_gen from spec.yaml; DO NOT EDIT.")
+	assert.Contains(t, out, `var opGetTicker = openapi.Operation{ID: "getTicker", Method: http.MethodGet, Path: "/public/ticker/{symbol}", Authenticated: false}`, "operation security should override the spec security")
+	assert.Contains(t, out, "Authenticated: true}", "spec security should apply to operations without their own")
+	assert.Contains(t, out, "func GetTicker(ctx context.Context, s openapi.Sender, p *GetTickerParams) (*Ticker, error)")
+	assert.Contains(t, out, `"symbol": p.Symbol,`, "path parameters should be expanded")
+	assert.Contains(t, out, `openapi.SetQuery(q, "limit", p.Limit, false)`, "referenced parameters should be resolved")
+	assert.Contains(t, out, `openapi.SetQuery(q, "symbols", p.Symbols, true)`)
+	assert.Contains(t, out, "([]GetRecentTradesResponseItem, error)", "inline objects should be named types")
+	assert.Contains(t, out, "Amount float64 `json:\"amount\"`", "allOf properties should be merged")
+	assert.Contains(t, out, "Side   Side    `json:\"side\"`", "allOf references should be merged")
+	assert.Contains(t, out, "OrderID string `json:\"orderId,omitempty\"`")
+	assert.Contains(t, out, "func GetPrivateBalances(ctx context.Context, s openapi.Sender) (map[string]float64, error)", "operations without an ID should be named by method and path")
+	assert.Contains(t, out, "// Deprecated:")
+
+	again, err := generate(s, &genConfig{pkg: "testexch", source: "spec.yaml"})
+	require.NoError(t, err, "generate must not error")
+	assert.Equal(t, src, again, "generation should be deterministic")
+
+	src, err = generate(s, &genConfig{pkg: "testexch", prefix: "Spot", tags: []string{"Market"}, source: "spec.yaml"})
+	require.NoError(t, err, "generate must not error")
+	out = string(src)
+	assert.Contains(t, out, "func SpotGetTicker(", "prefix should apply to operations")
+	assert.Contains(t, out, "type SpotTicker struct", "prefix should apply to schemas")
+	assert.NotContains(t, out, "PlaceOrder(", "operations outside the tags should be excluded")
+}
+
+func TestGenerateUnresolvedRef(t *testing.T) {
+	t.Parallel()
+	bad := filepath.Join(t.TempDir(), "bad.json")
+	require.NoError(t, os.WriteFile(bad, []byte(`{"paths":{"/x":{"get":{"responses":{"200":{"content":{"application/json":{"schema":{"$ref":"#/components/schemas/Missing"}}}}}}}}}`), 0o600), "WriteFile must not error")
+	s, err := loadSpec(bad)
+	require.NoError(t, err, "loadSpec must not error")
+	_, err = generate(s, &genConfig{pkg: "testexch"})
+	assert.ErrorIs(t, err, errUnresolvedRef)
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+	output := filepath.Join(t.TempDir(), "client.go")
+	cfg := &config{specPath: testSpec, pkg: "testexch", output: output, check: true}
+	assert.ErrorIs(t, run(cfg), os.ErrNotExist, "check should error without an existing client")
+
+	cfg.check = false
+	require.NoError(t, run(cfg), "run must not error")
+	cfg.check = true
+	assert.NoError(t, run(cfg), "check should not error when the client is up to date")
+
+	require.NoError(t, os.WriteFile(output, []byte("package testexch\n"), 0o600), "WriteFile must not error")
+	assert.ErrorIs(t, run(cfg), errOutOfDate)
+}
@@ -0,0 +1,100 @@
+// Command openapi_gen generates a typed REST client from an exchange's OpenAPI
+// 3 specification. The generated operations are sent through the exchange's
+// openapi.Sender implementation so they can be bound into wrapper methods
+// while keeping endpoint signatures in sync with the upstream spec.
+//
+// Regenerate a client:
+//
+//	go run ./cmd/openapi_gen -spec kraken.yaml -package kraken -output exchanges/kraken/kraken_openapi.go
+//
+// Check that a client matches its spec, e.g. in CI after updating the spec:
+//
+//	go run ./cmd/openapi_gen -spec kraken.yaml -package kraken -output exchanges/kraken/kraken_openapi.go -check
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/thrasher-corp/gocryptotrader/common/file"
+	"github.com/thrasher-corp/gocryptotrader/core"
+)
+
+var errOutOfDate = errors.New("generated client is out of date with the spec, regenerate it")
+
+func main() {
+	var cfg config
+	var tags string
+	flag.StringVar(&cfg.specPath, "spec", "", "the OpenAPI 3 spec file, JSON or YAML")
+	flag.StringVar(&cfg.pkg, "package", "", "the package name of the generated client")
+	flag.StringVar(&cfg.output, "output", "", "the generated client file")
+	flag.StringVar(&cfg.prefix, "prefix", "", "an optional prefix for generated type and function names")
+	flag.StringVar(&tags, "tags", "", "comma separated spec tags to generate operations for, all when empty")
+	flag.BoolVar(&cfg.check, "check", false, "check the output file matches the spec instead of writing it")
+	flag.Parse()
+
+	fmt.Println("GoCryptoTrader: OpenAPI REST client generator.")
+	fmt.Println(core.Copyright)
+	fmt.Println()
+
+	if cfg.specPath == "" || cfg.pkg == "" || cfg.output == "" {
+		log.Println("The spec, package and output flags are required, please see application usage below:")
+		flag.Usage()
+		os.Exit(1)
+	}
+	if tags != "" {
+		cfg.tags = strings.Split(tags, ",")
+	}
+	if err := run(&cfg); err != nil {
+		log.Fatal(err)
+	}
+}
+
+type config struct {
+	specPath string
+	pkg      string
+	output   string
+	prefix   string
+	tags     []string
+	check    bool
+}
+
+// run generates the client and writes it, or compares it with the existing
+// output when checking
+func run(cfg *config) error {
+	s, err := loadSpec(cfg.specPath)
+	if err != nil {
+		return err
+	}
+	src, err := generate(s, &genConfig{
+		pkg:    cfg.pkg,
+		prefix: cfg.prefix,
+		tags:   cfg.tags,
+		source: filepath.Base(cfg.specPath),
+	})
+	if err != nil {
+		return err
+	}
+	if cfg.check {
+		existing, err := os.ReadFile(cfg.output)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(existing, src) {
+			return fmt.Errorf("%s: %w", cfg.output, errOutOfDate)
+		}
+		fmt.Printf("%s is up to date\n", cfg.output)
+		return nil
+	}
+	if err := file.Write(cfg.output, src); err != nil {
+		return err
+	}
+	fmt.Printf("Generated %s\n", cfg.output)
+	return nil
+}
@@ -3,6 +3,7 @@ package cache
 import (
 	"container/list"
 	"sync"
+	"time"
 )
 
 // LRUCache thread safe fixed size LRU cache
@@ -23,3 +24,20 @@ type item struct {
 	key   interface{}
 	value interface{}
 }
+
+// TTLCache thread safe cache whose entries expire after a time to live
+type TTLCache struct {
+	ttl   time.Duration
+	items map[interface{}]*ttlItem
+	now   func() time.Time
+	m     sync.Mutex
+}
+
+// ttlItem holds a cached value and its expiry. ready is closed once the value
+// has been set
+type ttlItem struct {
+	value   interface{}
+	err     error
+	expires time.Time
+	ready   chan struct{}
+}
@@ -0,0 +1,125 @@
+package cache
+
+import (
+	"time"
+)
+
+// DefaultAnalyticsTTL is the time to live of entries added to Analytics
+// without an explicit time to live
+const DefaultAnalyticsTTL = time.Minute
+
+// Analytics is a shared cache of derived analytics such as volatility, basis
+// and funding aggregates, keyed by key.ExchangePairAssetMetric. Consumers
+// should use GetOrCompute so values are computed once and shared until they
+// expire
+var Analytics = NewTTL(DefaultAnalyticsTTL)
+
+// NewTTL returns a new concurrent-safe cache whose entries expire after the
+// supplied time to live
+func NewTTL(ttl time.Duration) *TTLCache {
+	return &TTLCache{
+		ttl:   ttl,
+		items: make(map[interface{}]*ttlItem),
+		now:   time.Now,
+	}
+}
+
+// Set adds an entry which expires after the cache's time to live
+func (c *TTLCache) Set(k, v interface{}) {
+	c.SetWithTTL(k, v, c.ttl)
+}
+
+// SetWithTTL adds an entry which expires after the supplied time to live
+func (c *TTLCache) SetWithTTL(k, v interface{}, ttl time.Duration) {
+	ready := make(chan struct{})
+	close(ready)
+	c.m.Lock()
+	c.items[k] = &ttlItem{value: v, expires: c.now().Add(ttl), ready: ready}
+	c.m.Unlock()
+}
+
+// Get returns an entry's value and whether it was found and unexpired
+func (c *TTLCache) Get(k interface{}) (interface{}, bool) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	i, ok := c.items[k]
+	if !ok || !i.isReady() || c.expired(i) {
+		return nil, false
+	}
+	return i.value, true
+}
+
+// GetOrCompute returns an entry's value, computing and storing it when missing
+// or expired. Concurrent callers for the same key wait for a single
+// computation. Errors are returned to all waiting callers and are not cached
+func (c *TTLCache) GetOrCompute(k interface{}, compute func() (interface{}, error)) (interface{}, error) {
+	c.m.Lock()
+	if i, ok := c.items[k]; ok && (!i.isReady() || !c.expired(i)) {
+		c.m.Unlock()
+		<-i.ready
+		return i.value, i.err
+	}
+	i := &ttlItem{ready: make(chan struct{})}
+	c.items[k] = i
+	c.m.Unlock()
+
+	i.value, i.err = compute()
+
+	c.m.Lock()
+	if i.err != nil {
+		if c.items[k] == i {
+			delete(c.items, k)
+		}
+	} else {
+		i.expires = c.now().Add(c.ttl)
+	}
+	close(i.ready)
+	c.m.Unlock()
+	return i.value, i.err
+}
+
+// Remove removes an entry, returning whether it was present
+func (c *TTLCache) Remove(k interface{}) bool {
+	c.m.Lock()
+	defer c.m.Unlock()
+	_, ok := c.items[k]
+	delete(c.items, k)
+	return ok
+}
+
+// Purge removes expired entries, returning the number removed
+func (c *TTLCache) Purge() int {
+	c.m.Lock()
+	defer c.m.Unlock()
+	var removed int
+	for k, i := range c.items {
+		if i.isReady() && c.expired(i) {
+			delete(c.items, k)
+			removed++
+		}
+	}
+	return removed
+}
+
+// Len returns the number of entries in the cache, including expired entries
+// which have not been purged
+func (c *TTLCache) Len() int {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return len(c.items)
+}
+
+// expired returns whether an entry has expired, c.m must be held
+func (c *TTLCache) expired(i *ttlItem) bool {
+	return !c.now().Before(i.expires)
+}
+
+// isReady returns whether an entry's value has been computed
+func (i *ttlItem) isReady() bool {
+	select {
+	case <-i.ready:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,83 @@
+package cache
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errComputeTest = errors.New("compute test error")
+
+func TestTTLCache(t *testing.T) {
+	t.Parallel()
+	c := NewTTL(time.Minute)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	c.Set("hello", "world")
+	c.SetWithTTL("short", 1, time.Second)
+	v, ok := c.Get("hello")
+	require.True(t, ok, "Get must find an unexpired entry")
+	assert.Equal(t, "world", v)
+	_, ok = c.Get("missing")
+	assert.False(t, ok, "Get should not find a missing entry")
+
+	now = now.Add(time.Second)
+	_, ok = c.Get("short")
+	assert.False(t, ok, "Get should not return an expired entry")
+	assert.Equal(t, 2, c.Len(), "expired entries should be retained until purged")
+	assert.Equal(t, 1, c.Purge(), "Purge should remove expired entries")
+	assert.Equal(t, 1, c.Len())
+
+	assert.True(t, c.Remove("hello"))
+	assert.False(t, c.Remove("hello"), "Remove should report a missing entry")
+}
+
+func TestTTLCacheGetOrCompute(t *testing.T) {
+	t.Parallel()
+	c := NewTTL(time.Minute)
+	now := time.Now()
+	var nowMtx sync.Mutex
+	c.now = func() time.Time {
+		nowMtx.Lock()
+		defer nowMtx.Unlock()
+		return now
+	}
+
+	var computed atomic.Int32
+	release := make(chan struct{})
+	compute := func() (interface{}, error) {
+		computed.Add(1)
+		<-release
+		return 1337, nil
+	}
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := c.GetOrCompute("volatility", compute)
+			assert.NoError(t, err, "GetOrCompute should not error")
+			assert.Equal(t, 1337, v)
+		}()
+	}
+	require.Eventually(t, func() bool { return computed.Load() == 1 }, time.Second, time.Millisecond, "compute must be called")
+	_, ok := c.Get("volatility")
+	assert.False(t, ok, "Get should not return a value still being computed")
+	close(release)
+	wg.Wait()
+	assert.Equal(t, int32(1), computed.Load(), "concurrent callers should share a single computation")
+
+	nowMtx.Lock()
+	now = now.Add(time.Minute)
+	nowMtx.Unlock()
+	_, err := c.GetOrCompute("volatility", func() (interface{}, error) { return nil, errComputeTest })
+	assert.ErrorIs(t, err, errComputeTest, "an expired entry should be recomputed")
+	_, ok = c.Get("volatility")
+	assert.False(t, ok, "errors should not be cached")
+}
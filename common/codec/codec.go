@@ -0,0 +1,128 @@
+// Package codec provides pluggable serialisation for data persisted by GCT, so
+// high volume captures can use a compact binary format instead of JSON
+package codec
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Codec names
+const (
+	JSON     = "json"
+	Protobuf = "protobuf"
+	Msgpack  = "msgpack"
+)
+
+var (
+	errUnsupportedCodec = errors.New("unsupported codec")
+	errCodecNameEmpty   = errors.New("codec name cannot be empty")
+	errExtensionInUse   = errors.New("codec file extension already in use")
+	errNotProtoMessage  = errors.New("value is not a protobuf message")
+)
+
+// Codec serialises and deserialises persisted data
+type Codec interface {
+	// Name returns the name the codec is registered and configured by
+	Name() string
+	// Extension returns the file extension for data written by the codec
+	Extension() string
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+var (
+	mtx    sync.RWMutex
+	codecs = map[string]Codec{
+		JSON:     jsonCodec{},
+		Protobuf: protobufCodec{},
+		Msgpack:  msgpackCodec{},
+	}
+)
+
+// Register adds a codec, replacing any codec with the same name. This allows
+// further formats to be supplied without adding a dependency here. Each file
+// extension may only be used by one codec so ForPath is unambiguous
+func Register(c Codec) error {
+	if c == nil {
+		return fmt.Errorf("%w: nil", errUnsupportedCodec)
+	}
+	name := strings.ToLower(c.Name())
+	if name == "" {
+		return errCodecNameEmpty
+	}
+	ext := strings.ToLower(c.Extension())
+	mtx.Lock()
+	defer mtx.Unlock()
+	for n, existing := range codecs {
+		if n != name && strings.ToLower(existing.Extension()) == ext {
+			return fmt.Errorf("%w: %q by %s", errExtensionInUse, ext, n)
+		}
+	}
+	codecs[name] = c
+	return nil
+}
+
+// Get returns the codec registered by name. An empty name returns the JSON
+// codec
+func Get(name string) (Codec, error) {
+	if name == "" {
+		name = JSON
+	}
+	mtx.RLock()
+	defer mtx.RUnlock()
+	c, ok := codecs[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", errUnsupportedCodec, name)
+	}
+	return c, nil
+}
+
+// ForPath returns the registered codec whose extension matches the file path,
+// defaulting to the JSON codec so existing files continue to be read as JSON
+func ForPath(path string) Codec {
+	ext := strings.ToLower(filepath.Ext(path))
+	mtx.RLock()
+	defer mtx.RUnlock()
+	for _, c := range codecs {
+		if strings.ToLower(c.Extension()) == ext {
+			return c
+		}
+	}
+	return codecs[JSON]
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string                       { return JSON }
+func (jsonCodec) Extension() string                  { return ".json" }
+func (jsonCodec) Marshal(v any) ([]byte, error)      { return json.Marshal(v) }
+func (jsonCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+// protobufCodec serialises protobuf messages, such as those defined in gctrpc
+type protobufCodec struct{}
+
+func (protobufCodec) Name() string      { return Protobuf }
+func (protobufCodec) Extension() string { return ".pb" }
+
+func (protobufCodec) Marshal(v any) ([]byte, error) {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", errNotProtoMessage, v)
+	}
+	return proto.Marshal(m)
+}
+
+func (protobufCodec) Unmarshal(data []byte, v any) error {
+	m, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("%w: %T", errNotProtoMessage, v)
+	}
+	return proto.Unmarshal(data, m)
+}
@@ -0,0 +1,183 @@
+package codec
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type namedCodec struct {
+	jsonCodec
+	name, ext string
+}
+
+func (n namedCodec) Name() string      { return n.name }
+func (n namedCodec) Extension() string { return n.ext }
+
+func TestGet(t *testing.T) {
+	t.Parallel()
+	c, err := Get("")
+	require.NoError(t, err, "Get must not error")
+	assert.Equal(t, JSON, c.Name(), "empty name should return the JSON codec")
+	c, err = Get("ProtoBuf")
+	require.NoError(t, err, "Get must not error")
+	assert.Equal(t, Protobuf, c.Name(), "Get should be case insensitive")
+	_, err = Get("carrier pigeon")
+	assert.ErrorIs(t, err, errUnsupportedCodec)
+}
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+	assert.ErrorIs(t, Register(nil), errUnsupportedCodec)
+	assert.ErrorIs(t, Register(namedCodec{}), errCodecNameEmpty)
+	assert.ErrorIs(t, Register(namedCodec{name: "yaml", ext: ".JSON"}), errExtensionInUse)
+	require.NoError(t, Register(namedCodec{name: "YAML", ext: ".yaml"}), "Register must not error")
+	require.NoError(t, Register(namedCodec{name: "YAML", ext: ".yml"}), "Register must not error when replacing a codec")
+	c, err := Get("yaml")
+	require.NoError(t, err, "Get must not error for a registered codec")
+	assert.Equal(t, "YAML", c.Name())
+	assert.Equal(t, ".yml", c.Extension(), "Register should replace codecs with the same name")
+}
+
+func TestJSON(t *testing.T) {
+	t.Parallel()
+	c := jsonCodec{}
+	b, err := c.Marshal(map[string]int{"a": 1})
+	require.NoError(t, err, "Marshal must not error")
+	var resp map[string]int
+	require.NoError(t, c.Unmarshal(b, &resp), "Unmarshal must not error")
+	assert.Equal(t, 1, resp["a"])
+}
+
+func TestProtobuf(t *testing.T) {
+	t.Parallel()
+	c := protobufCodec{}
+	_, err := c.Marshal(map[string]int{})
+	assert.ErrorIs(t, err, errNotProtoMessage)
+	assert.ErrorIs(t, c.Unmarshal(nil, new(int)), errNotProtoMessage)
+
+	b, err := c.Marshal(wrapperspb.String("bitcoin"))
+	require.NoError(t, err, "Marshal must not error")
+	resp := new(wrapperspb.StringValue)
+	require.NoError(t, c.Unmarshal(b, resp), "Unmarshal must not error")
+	assert.Equal(t, "bitcoin", resp.GetValue())
+}
+
+func TestForPath(t *testing.T) {
+	t.Parallel()
+	for path, name := range map[string]string{
+		"fixture.json":             JSON,
+		"fixture":                  JSON,
+		"testdata/fixture.txt":     JSON,
+		"fixture.msgpack":          Msgpack,
+		"testdata/FIXTURE.MSGPACK": Msgpack,
+		"state.pb":                 Protobuf,
+	} {
+		assert.Equal(t, name, ForPath(path).Name(), path)
+	}
+}
+
+type msgpackTest struct {
+	Name    string          `json:"name"`
+	Price   float64         `json:"price"`
+	Amount  decimal.Decimal `json:"amount"`
+	Count   int64           `json:"count"`
+	Big     uint64          `json:"big"`
+	Neg     int64           `json:"neg"`
+	Enabled bool            `json:"enabled"`
+	Tags    []string        `json:"tags"`
+	Nested  map[string]any  `json:"nested"`
+	Raw     json.RawMessage `json:"raw"`
+	Empty   *int            `json:"empty"`
+	Data    []byte          `json:"data"`
+}
+
+func TestMsgpack(t *testing.T) {
+	t.Parallel()
+	c, err := Get(Msgpack)
+	require.NoError(t, err, "Get must not error")
+	assert.Equal(t, ".msgpack", c.Extension())
+
+	in := msgpackTest{
+		Name:    strings.Repeat("bitcoin", 10),
+		Price:   42123.45,
+		Amount:  decimal.RequireFromString("0.00000001"),
+		Count:   70000,
+		Big:     math.MaxUint64,
+		Neg:     -5000000000,
+		Enabled: true,
+		Tags:    []string{"a", "b"},
+		Nested:  map[string]any{"list": []any{float64(1), "two", nil, false, float64(-1), 1.5}},
+		Raw:     json.RawMessage(`{"z":1,"a":[{"y":true}]}`),
+		Data:    []byte{0, 1, 2},
+	}
+	b, err := c.Marshal(in)
+	require.NoError(t, err, "Marshal must not error")
+	j, err := json.Marshal(in)
+	require.NoError(t, err)
+	assert.Less(t, len(b), len(j), "msgpack should be smaller than JSON")
+
+	var out msgpackTest
+	require.NoError(t, c.Unmarshal(b, &out), "Unmarshal must not error")
+	assert.Equal(t, in, out, "values should round trip")
+	assert.Equal(t, `{"z":1,"a":[{"y":true}]}`, string(out.Raw), "object key order should be retained")
+
+	var ints []int64
+	values := []int64{0, 127, 128, 255, 256, 65535, 65536, math.MaxUint32, math.MaxUint32 + 1, -1, -32, -33, -128, -129, -32768, -32769, math.MinInt32, math.MinInt32 - 1, math.MinInt64}
+	b, err = c.Marshal(values)
+	require.NoError(t, err, "Marshal must not error")
+	require.NoError(t, c.Unmarshal(b, &ints), "Unmarshal must not error")
+	assert.Equal(t, values, ints, "integers should round trip at every size")
+
+	long := strings.Repeat("x", 70000)
+	many := make([]int, 70000)
+	keys := make(map[string]int, 20)
+	for i := range 20 {
+		keys[strconv.Itoa(i)] = i
+	}
+	var lengths struct {
+		Long  string         `json:"long"`
+		Mid   string         `json:"mid"`
+		Many  []int          `json:"many"`
+		Keys  map[string]int `json:"keys"`
+		Float float32        `json:"float"`
+	}
+	lengths.Long, lengths.Mid, lengths.Many, lengths.Keys, lengths.Float = long, long[:300], many, keys, 0.5
+	b, err = c.Marshal(lengths)
+	require.NoError(t, err, "Marshal must not error")
+	want := lengths
+	require.NoError(t, c.Unmarshal(b, &lengths), "Unmarshal must not error")
+	assert.Equal(t, want, lengths, "strings, arrays and maps should round trip at every size")
+
+	var v any
+	require.NoError(t, c.Unmarshal([]byte{0xca, 0x3f, 0xc0, 0x00, 0x00}, &v), "Unmarshal must decode float32")
+	assert.Equal(t, 1.5, v)
+	require.NoError(t, c.Unmarshal([]byte{0xc4, 0x02, 0x01, 0x02}, &v), "Unmarshal must decode binary")
+	assert.Equal(t, "AQI=", v, "binary should decode as base64 like JSON byte slices")
+
+	for _, tc := range []struct {
+		data []byte
+		err  error
+	}{
+		{data: nil, err: io.EOF},
+		{data: []byte{0xa5, 'a'}, err: io.ErrUnexpectedEOF},
+		{data: []byte{0xd9, 0xff, 'a'}, err: io.ErrUnexpectedEOF},
+		{data: []byte{0x92, 0x01}, err: io.EOF},
+		{data: []byte{0x81, 0x01, 0x01}, err: errMsgpackKeyNotString},
+		{data: []byte{0xc1}, err: errMsgpackUnsupported},
+		{data: []byte{0xd4, 0x01, 0x01}, err: errMsgpackUnsupported},
+		{data: []byte{0x01, 0x02}, err: errMsgpackTrailingBytes},
+	} {
+		assert.ErrorIs(t, c.Unmarshal(tc.data, &v), tc.err, "%x", tc.data)
+	}
+	_, err = c.Marshal(make(chan int))
+	assert.Error(t, err, "Marshal should error on values which cannot be encoded")
+}
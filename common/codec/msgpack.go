@@ -0,0 +1,370 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+var (
+	errMsgpackKeyNotString  = errors.New("msgpack map key is not a string")
+	errMsgpackUnsupported   = errors.New("unsupported msgpack type")
+	errMsgpackTrailingBytes = errors.New("unexpected data after msgpack value")
+)
+
+// msgpackCodec serialises values as MessagePack. Values are transcoded via
+// their JSON encoding, so any type which can be persisted as JSON can be
+// persisted as msgpack using the same struct tags and marshalers, retaining
+// object key order
+type msgpackCodec struct{}
+
+func (msgpackCodec) Name() string      { return Msgpack }
+func (msgpackCodec) Extension() string { return ".msgpack" }
+
+func (msgpackCodec) Marshal(v any) ([]byte, error) {
+	j, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(j))
+	dec.UseNumber()
+	var buf bytes.Buffer
+	if err := jsonToMsgpack(dec, &buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (msgpackCodec) Unmarshal(data []byte, v any) error {
+	r := bytes.NewReader(data)
+	var j bytes.Buffer
+	if err := msgpackToJSON(r, &j); err != nil {
+		return err
+	}
+	if r.Len() != 0 {
+		return errMsgpackTrailingBytes
+	}
+	return json.Unmarshal(j.Bytes(), v)
+}
+
+// jsonToMsgpack writes the next JSON value read from dec to buf as msgpack
+func jsonToMsgpack(dec *json.Decoder, buf *bytes.Buffer) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		var elems bytes.Buffer
+		var n int
+		for ; dec.More(); n++ {
+			if t == '{' {
+				k, err := dec.Token()
+				if err != nil {
+					return err
+				}
+				writeMsgpackString(&elems, k.(string))
+			}
+			if err := jsonToMsgpack(dec, &elems); err != nil {
+				return err
+			}
+		}
+		if _, err := dec.Token(); err != nil { // closing delimiter
+			return err
+		}
+		if t == '{' {
+			writeMsgpackHeader(buf, n, 0x80, 0xde, 0xdf)
+		} else {
+			writeMsgpackHeader(buf, n, 0x90, 0xdc, 0xdd)
+		}
+		buf.Write(elems.Bytes())
+	case nil:
+		buf.WriteByte(0xc0)
+	case bool:
+		if t {
+			buf.WriteByte(0xc3)
+		} else {
+			buf.WriteByte(0xc2)
+		}
+	case string:
+		writeMsgpackString(buf, t)
+	case json.Number:
+		if i, err := t.Int64(); err == nil {
+			writeMsgpackInt(buf, i)
+			return nil
+		}
+		if u, err := strconv.ParseUint(t.String(), 10, 64); err == nil {
+			buf.WriteByte(0xcf)
+			buf.Write(binary.BigEndian.AppendUint64(nil, u))
+			return nil
+		}
+		f, err := t.Float64()
+		if err != nil {
+			return err
+		}
+		buf.WriteByte(0xcb)
+		buf.Write(binary.BigEndian.AppendUint64(nil, math.Float64bits(f)))
+	}
+	return nil
+}
+
+// writeMsgpackHeader writes an array or map header, using the fix format for
+// fewer than 16 elements
+func writeMsgpackHeader(buf *bytes.Buffer, n int, fix, b16, b32 byte) {
+	switch {
+	case n < 16:
+		buf.WriteByte(fix | byte(n))
+	case n <= math.MaxUint16:
+		buf.WriteByte(b16)
+		buf.Write(binary.BigEndian.AppendUint16(nil, uint16(n)))
+	default:
+		buf.WriteByte(b32)
+		buf.Write(binary.BigEndian.AppendUint32(nil, uint32(n))) //nolint:gosec // JSON input cannot exceed uint32 elements
+	}
+}
+
+func writeMsgpackString(buf *bytes.Buffer, s string) {
+	switch n := len(s); {
+	case n < 32:
+		buf.WriteByte(0xa0 | byte(n))
+	case n <= math.MaxUint8:
+		buf.WriteByte(0xd9)
+		buf.WriteByte(byte(n))
+	case n <= math.MaxUint16:
+		buf.WriteByte(0xda)
+		buf.Write(binary.BigEndian.AppendUint16(nil, uint16(n)))
+	default:
+		buf.WriteByte(0xdb)
+		buf.Write(binary.BigEndian.AppendUint32(nil, uint32(n))) //nolint:gosec // JSON input cannot exceed uint32 bytes
+	}
+	buf.WriteString(s)
+}
+
+// writeMsgpackInt writes an integer in its smallest msgpack representation
+func writeMsgpackInt(buf *bytes.Buffer, i int64) {
+	switch {
+	case i >= 0 && i <= 0x7f:
+		buf.WriteByte(byte(i))
+	case i >= -32 && i < 0:
+		buf.WriteByte(byte(i))
+	case i >= 0 && i <= math.MaxUint8:
+		buf.WriteByte(0xcc)
+		buf.WriteByte(byte(i))
+	case i >= 0 && i <= math.MaxUint16:
+		buf.WriteByte(0xcd)
+		buf.Write(binary.BigEndian.AppendUint16(nil, uint16(i)))
+	case i >= 0 && i <= math.MaxUint32:
+		buf.WriteByte(0xce)
+		buf.Write(binary.BigEndian.AppendUint32(nil, uint32(i)))
+	case i >= math.MinInt8 && i <= math.MaxInt8:
+		buf.WriteByte(0xd0)
+		buf.WriteByte(byte(i))
+	case i >= math.MinInt16 && i <= math.MaxInt16:
+		buf.WriteByte(0xd1)
+		buf.Write(binary.BigEndian.AppendUint16(nil, uint16(i)))
+	case i >= math.MinInt32 && i <= math.MaxInt32:
+		buf.WriteByte(0xd2)
+		buf.Write(binary.BigEndian.AppendUint32(nil, uint32(i)))
+	default:
+		buf.WriteByte(0xd3)
+		buf.Write(binary.BigEndian.AppendUint64(nil, uint64(i)))
+	}
+}
+
+// msgpackToJSON reads the next msgpack value from r and writes it to w as
+// JSON. Binary values are written as base64 strings, matching the JSON
+// encoding of byte slices
+func msgpackToJSON(r *bytes.Reader, w *bytes.Buffer) error {
+	b, err := r.ReadByte()
+	if err != nil {
+		return err
+	}
+	switch {
+	case b <= 0x7f:
+		w.WriteString(strconv.FormatUint(uint64(b), 10))
+		return nil
+	case b >= 0xe0:
+		w.WriteString(strconv.FormatInt(int64(int8(b)), 10))
+		return nil
+	case b&0xf0 == 0x80:
+		return msgpackMapToJSON(r, w, int(b&0x0f))
+	case b&0xf0 == 0x90:
+		return msgpackArrayToJSON(r, w, int(b&0x0f))
+	case b&0xe0 == 0xa0:
+		return msgpackStringToJSON(r, w, int(b&0x1f))
+	}
+	switch b {
+	case 0xc0:
+		w.WriteString("null")
+	case 0xc2:
+		w.WriteString("false")
+	case 0xc3:
+		w.WriteString("true")
+	case 0xc4, 0xc5, 0xc6:
+		n, err := readMsgpackLength(r, 1<<(b-0xc4))
+		if err != nil {
+			return err
+		}
+		data, err := readMsgpackBytes(r, n)
+		if err != nil {
+			return err
+		}
+		return writeJSONString(w, base64.StdEncoding.EncodeToString(data))
+	case 0xca:
+		v, err := readMsgpackUint(r, 4)
+		if err != nil {
+			return err
+		}
+		return writeJSONFloat(w, float64(math.Float32frombits(uint32(v))))
+	case 0xcb:
+		v, err := readMsgpackUint(r, 8)
+		if err != nil {
+			return err
+		}
+		return writeJSONFloat(w, math.Float64frombits(v))
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		v, err := readMsgpackUint(r, 1<<(b-0xcc))
+		if err != nil {
+			return err
+		}
+		w.WriteString(strconv.FormatUint(v, 10))
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (b - 0xd0)
+		v, err := readMsgpackUint(r, size)
+		if err != nil {
+			return err
+		}
+		shift := 64 - 8*size
+		w.WriteString(strconv.FormatInt(int64(v<<shift)>>shift, 10)) //nolint:gosec // sign extension of a two's complement value
+	case 0xd9, 0xda, 0xdb:
+		n, err := readMsgpackLength(r, 1<<(b-0xd9))
+		if err != nil {
+			return err
+		}
+		return msgpackStringToJSON(r, w, n)
+	case 0xdc, 0xdd:
+		n, err := readMsgpackLength(r, 2<<(b-0xdc))
+		if err != nil {
+			return err
+		}
+		return msgpackArrayToJSON(r, w, n)
+	case 0xde, 0xdf:
+		n, err := readMsgpackLength(r, 2<<(b-0xde))
+		if err != nil {
+			return err
+		}
+		return msgpackMapToJSON(r, w, n)
+	default:
+		return fmt.Errorf("%w: 0x%x", errMsgpackUnsupported, b)
+	}
+	return nil
+}
+
+func msgpackArrayToJSON(r *bytes.Reader, w *bytes.Buffer, n int) error {
+	w.WriteByte('[')
+	for i := range n {
+		if i > 0 {
+			w.WriteByte(',')
+		}
+		if err := msgpackToJSON(r, w); err != nil {
+			return err
+		}
+	}
+	w.WriteByte(']')
+	return nil
+}
+
+func msgpackMapToJSON(r *bytes.Reader, w *bytes.Buffer, n int) error {
+	w.WriteByte('{')
+	for i := range n {
+		if i > 0 {
+			w.WriteByte(',')
+		}
+		if b, err := r.ReadByte(); err != nil {
+			return err
+		} else if b&0xe0 != 0xa0 && (b < 0xd9 || b > 0xdb) {
+			return fmt.Errorf("%w: 0x%x", errMsgpackKeyNotString, b)
+		}
+		if err := r.UnreadByte(); err != nil {
+			return err
+		}
+		if err := msgpackToJSON(r, w); err != nil {
+			return err
+		}
+		w.WriteByte(':')
+		if err := msgpackToJSON(r, w); err != nil {
+			return err
+		}
+	}
+	w.WriteByte('}')
+	return nil
+}
+
+func msgpackStringToJSON(r *bytes.Reader, w *bytes.Buffer, n int) error {
+	data, err := readMsgpackBytes(r, n)
+	if err != nil {
+		return err
+	}
+	return writeJSONString(w, string(data))
+}
+
+func writeJSONString(w *bytes.Buffer, s string) error {
+	j, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	w.Write(j)
+	return nil
+}
+
+func writeJSONFloat(w *bytes.Buffer, f float64) error {
+	j, err := json.Marshal(f)
+	if err != nil {
+		return err
+	}
+	w.Write(j)
+	return nil
+}
+
+// readMsgpackUint reads a big endian unsigned integer of size bytes
+func readMsgpackUint(r *bytes.Reader, size int) (uint64, error) {
+	data, err := readMsgpackBytes(r, size)
+	if err != nil {
+		return 0, err
+	}
+	var v uint64
+	for _, b := range data {
+		v = v<<8 | uint64(b)
+	}
+	return v, nil
+}
+
+// readMsgpackLength reads a length of size bytes, ensuring it does not exceed
+// the remaining data
+func readMsgpackLength(r *bytes.Reader, size int) (int, error) {
+	v, err := readMsgpackUint(r, size)
+	if err != nil {
+		return 0, err
+	}
+	if v > uint64(r.Len()) {
+		return 0, io.ErrUnexpectedEOF
+	}
+	return int(v), nil //nolint:gosec // bounded by the remaining data
+}
+
+func readMsgpackBytes(r *bytes.Reader, n int) ([]byte, error) {
+	data := make([]byte, n)
+	if _, err := io.ReadFull(r, data); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	return data, nil
+}
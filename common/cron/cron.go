@@ -0,0 +1,166 @@
+package cron
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSearchYears limits how far Next will search for a matching time, which
+// prevents impossible schedules such as "0 0 31 2 *" looping forever
+const maxSearchYears = 5
+
+// Parse parses a cron-style schedule expression
+func Parse(expr string) (*Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, errEmptySchedule
+	}
+	s := &Schedule{expr: expr}
+	if strings.HasPrefix(expr, "@every ") {
+		d, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(expr, "@every ")))
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %w", ErrInvalidSchedule, expr, err)
+		}
+		if d < time.Second {
+			return nil, fmt.Errorf("%w %q: interval must be at least one second", ErrInvalidSchedule, expr)
+		}
+		s.every = d
+		return s, nil
+	}
+	if d, ok := descriptors[strings.ToLower(expr)]; ok {
+		expr = d
+	}
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%w %q: expected 5 fields, received %d", ErrInvalidSchedule, s.expr, len(fields))
+	}
+	var err error
+	if s.minute, err = parseField(fields[0], minuteBounds); err != nil {
+		return nil, fmt.Errorf("%w %q: %w", ErrInvalidSchedule, s.expr, err)
+	}
+	if s.hour, err = parseField(fields[1], hourBounds); err != nil {
+		return nil, fmt.Errorf("%w %q: %w", ErrInvalidSchedule, s.expr, err)
+	}
+	if s.dom, err = parseField(fields[2], domBounds); err != nil {
+		return nil, fmt.Errorf("%w %q: %w", ErrInvalidSchedule, s.expr, err)
+	}
+	if s.month, err = parseField(fields[3], monthBounds); err != nil {
+		return nil, fmt.Errorf("%w %q: %w", ErrInvalidSchedule, s.expr, err)
+	}
+	// allow 7 as an alias for Sunday
+	weekday := fields[4]
+	if s.weekday, err = parseField(weekday, fieldBounds{weekdayBounds.name, 0, 7}); err != nil {
+		return nil, fmt.Errorf("%w %q: %w", ErrInvalidSchedule, s.expr, err)
+	}
+	if s.weekday&(1<<7) != 0 {
+		s.weekday = s.weekday&^(1<<7) | 1
+	}
+	s.domStar = fields[2] == "*"
+	s.weekdayStar = weekday == "*"
+	return s, nil
+}
+
+// String returns the original schedule expression
+func (s *Schedule) String() string {
+	return s.expr
+}
+
+// Next returns the first time after t which matches the schedule. A zero time
+// is returned if no matching time can be found
+func (s *Schedule) Next(t time.Time) time.Time {
+	if s.every > 0 {
+		return t.Truncate(s.every).Add(s.every)
+	}
+	// cron resolution is one minute
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxSearchYears, 0, 0)
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.dayMatches(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	weekday := s.weekday&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.weekdayStar {
+		return dom && weekday
+	}
+	return dom || weekday
+}
+
+// parseField parses a comma separated list of values, ranges and steps into a
+// bit set
+func parseField(field string, b fieldBounds) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		if hasStep {
+			var err error
+			step, err = strconv.Atoi(stepPart)
+			if err != nil || step <= 0 {
+				return 0, fmt.Errorf("%s: invalid step %q", b.name, stepPart)
+			}
+			part = rangePart
+		}
+		low, high := b.min, b.max
+		switch {
+		case part == "*":
+		case strings.Contains(part, "-"):
+			lowStr, highStr, _ := strings.Cut(part, "-")
+			var err error
+			if low, err = parseValue(lowStr, b); err != nil {
+				return 0, err
+			}
+			if high, err = parseValue(highStr, b); err != nil {
+				return 0, err
+			}
+			if low > high {
+				return 0, fmt.Errorf("%s: invalid range %q", b.name, part)
+			}
+		default:
+			v, err := parseValue(part, b)
+			if err != nil {
+				return 0, err
+			}
+			low = v
+			if !hasStep {
+				high = v
+			}
+		}
+		for i := low; i <= high; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+	return bits, nil
+}
+
+func parseValue(v string, b fieldBounds) (int, error) {
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("%s: invalid value %q", b.name, v)
+	}
+	if i < b.min || i > b.max {
+		return 0, fmt.Errorf("%s: value %d out of range %d-%d", b.name, i, b.min, b.max)
+	}
+	return i, nil
+}
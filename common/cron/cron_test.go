@@ -0,0 +1,48 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "* * * 13 *", "* * * * 8", "5-1 * * * *", "*/0 * * * *", "a * * * *", "@every 1ms", "@every lol"} {
+		_, err := Parse(expr)
+		assert.Errorf(t, err, "Parse should error for %q", expr)
+	}
+	for _, expr := range []string{"* * * * *", "*/5 1,2,3 1-15 * 1-5", "0 0 * * 7", "@daily", "@every 1h30m"} {
+		s, err := Parse(expr)
+		require.NoErrorf(t, err, "Parse should not error for %q", expr)
+		assert.Equal(t, expr, s.String())
+	}
+}
+
+func TestNext(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2024, 1, 1, 12, 30, 15, 0, time.UTC) // Monday
+	for _, tc := range []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2024, 1, 1, 12, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2024, 1, 1, 12, 45, 0, 0, time.UTC)},
+		{"5/20 * * * *", time.Date(2024, 1, 1, 12, 45, 0, 0, time.UTC)},
+		{"0 9 * * *", time.Date(2024, 1, 2, 9, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC)},
+		{"@weekly", time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 13 * 5", time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)}, // day of month or Friday
+		{"@every 1h", time.Date(2024, 1, 1, 13, 0, 0, 0, time.UTC)},
+		{"0 0 31 2 *", time.Time{}},
+	} {
+		s, err := Parse(tc.expr)
+		require.NoError(t, err)
+		assert.Equalf(t, tc.want, s.Next(start), "Next should return the correct time for %q", tc.expr)
+	}
+}
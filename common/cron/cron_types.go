@@ -0,0 +1,56 @@
+package cron
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	// ErrInvalidSchedule is returned when a schedule expression cannot be parsed
+	ErrInvalidSchedule = errors.New("invalid schedule")
+
+	errEmptySchedule = errors.New("schedule expression cannot be empty")
+)
+
+// fieldBounds holds the inclusive bounds of a cron field
+type fieldBounds struct {
+	name     string
+	min, max int
+}
+
+var (
+	minuteBounds  = fieldBounds{"minute", 0, 59}
+	hourBounds    = fieldBounds{"hour", 0, 23}
+	domBounds     = fieldBounds{"day of month", 1, 31}
+	monthBounds   = fieldBounds{"month", 1, 12}
+	weekdayBounds = fieldBounds{"day of week", 0, 6}
+)
+
+// descriptors maps predefined schedules to their cron expression
+var descriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Schedule is a parsed cron-style schedule. It supports standard five field
+// expressions (minute hour day-of-month month day-of-week) with lists, ranges
+// and steps, predefined descriptors such as @daily and fixed intervals via
+// "@every <duration>"
+type Schedule struct {
+	expr    string
+	every   time.Duration
+	minute  uint64
+	hour    uint64
+	dom     uint64
+	month   uint64
+	weekday uint64
+	// domStar and weekdayStar track wildcards, as when both day fields are
+	// restricted a time matches if either field matches
+	domStar     bool
+	weekdayStar bool
+}
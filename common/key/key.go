@@ -15,6 +15,13 @@ type ExchangePairAsset struct {
 	Asset    asset.Item
 }
 
+// ExchangePairAssetMetric is a unique map key signature for a metric derived
+// for an exchange, currency pair and asset
+type ExchangePairAssetMetric struct {
+	ExchangePairAsset
+	Metric string
+}
+
 // ExchangeAsset is a unique map key signature for exchange and asset
 type ExchangeAsset struct {
 	Exchange string
@@ -1,6 +1,9 @@
 package base
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -13,10 +16,74 @@ type Base struct {
 	ServiceStarted time.Time
 }
 
+// ErrUnknownSeverity is returned when a severity string cannot be parsed
+var ErrUnknownSeverity = errors.New("unknown severity")
+
+// Severity defines the importance of an event
+type Severity uint8
+
+// Event severities in ascending order of importance
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+	SeverityCritical
+)
+
 // Event is a generalise event type
 type Event struct {
-	Type    string
-	Message string
+	Type     string
+	Message  string
+	Severity Severity
+	Exchange string
+	// Key identifies the condition an event relates to so that incident
+	// managers can resolve alerts once the condition clears
+	Key string
+	// Resolved marks the condition identified by Key as cleared
+	Resolved bool
+}
+
+// IsIncident returns whether an event should be forwarded to an incident
+// manager. Events at or above the minimum severity raise incidents and
+// resolution events with a key resolve them
+func (e *Event) IsIncident(minSeverity Severity) bool {
+	if e.Resolved {
+		return e.Key != ""
+	}
+	return e.Severity >= minSeverity
+}
+
+// String returns the severity name
+func (s Severity) String() string {
+	switch s {
+	case SeverityInfo:
+		return "info"
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	case SeverityCritical:
+		return "critical"
+	default:
+		return fmt.Sprintf("severity(%d)", uint8(s))
+	}
+}
+
+// ParseSeverity returns the severity for the supplied name. An empty string
+// is treated as SeverityInfo
+func ParseSeverity(s string) (Severity, error) {
+	switch strings.ToLower(s) {
+	case "", "info":
+		return SeverityInfo, nil
+	case "warning", "warn":
+		return SeverityWarning, nil
+	case "error":
+		return SeverityError, nil
+	case "critical":
+		return SeverityCritical, nil
+	default:
+		return 0, fmt.Errorf("%w: %q", ErrUnknownSeverity, s)
+	}
 }
 
 // CommsStatus stores the status of a comms relayer
@@ -60,6 +127,35 @@ type CommunicationsConfig struct {
 	SMSGlobalConfig SMSGlobalConfig `json:"smsGlobal"`
 	SMTPConfig      SMTPConfig      `json:"smtp"`
 	TelegramConfig  TelegramConfig  `json:"telegram"`
+	PagerDutyConfig PagerDutyConfig `json:"pagerDuty"`
+	OpsgenieConfig  OpsgenieConfig  `json:"opsgenie"`
+	Routing         RoutingConfig   `json:"routing"`
+}
+
+// RoutingConfig defines how events are routed to communication relayers.
+// When disabled all events are sent to every enabled relayer
+type RoutingConfig struct {
+	Enabled bool          `json:"enabled"`
+	Rules   []RoutingRule `json:"rules"`
+	// DefaultChannels receive events which match no rule. Leave empty to
+	// discard unmatched events
+	DefaultChannels []string `json:"defaultChannels"`
+	// DedupWindow suppresses identical events sent within the window
+	DedupWindow time.Duration `json:"dedupWindow"`
+	// RateLimit is the maximum number of events sent to a single relayer per
+	// RateLimitInterval. Zero disables rate limiting
+	RateLimit         int           `json:"rateLimit"`
+	RateLimitInterval time.Duration `json:"rateLimitInterval"`
+}
+
+// RoutingRule directs events matching all of its filters to a set of
+// relayers. Empty filters match any value
+type RoutingRule struct {
+	Name        string   `json:"name"`
+	EventTypes  []string `json:"eventTypes"`
+	Exchanges   []string `json:"exchanges"`
+	MinSeverity string   `json:"minSeverity"`
+	Channels    []string `json:"channels"`
 }
 
 // IsAnyEnabled returns whether any comms relayers
@@ -68,7 +164,9 @@ func (c *CommunicationsConfig) IsAnyEnabled() bool {
 	if c.SMSGlobalConfig.Enabled ||
 		c.SMTPConfig.Enabled ||
 		c.SlackConfig.Enabled ||
-		c.TelegramConfig.Enabled {
+		c.TelegramConfig.Enabled ||
+		c.PagerDutyConfig.Enabled ||
+		c.OpsgenieConfig.Enabled {
 		return true
 	}
 	return false
@@ -123,3 +221,29 @@ type TelegramConfig struct {
 	VerificationToken string           `json:"verificationToken"`
 	AuthorisedClients map[string]int64 `json:"authorisedClients"`
 }
+
+// PagerDutyConfig holds all variables to start and run the PagerDuty package
+type PagerDutyConfig struct {
+	Name       string `json:"name"`
+	Enabled    bool   `json:"enabled"`
+	Verbose    bool   `json:"verbose"`
+	RoutingKey string `json:"routingKey"`
+	Source     string `json:"source"`
+	// MinSeverity is the minimum event severity which raises an incident.
+	// Defaults to critical
+	MinSeverity string `json:"minSeverity"`
+}
+
+// OpsgenieConfig holds all variables to start and run the Opsgenie package
+type OpsgenieConfig struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	Verbose bool   `json:"verbose"`
+	APIKey  string `json:"apiKey"`
+	// APIURL allows the EU instance to be used. Defaults to the US instance
+	APIURL string   `json:"apiURL"`
+	Tags   []string `json:"tags"`
+	// MinSeverity is the minimum event severity which raises an alert.
+	// Defaults to critical
+	MinSeverity string `json:"minSeverity"`
+}
@@ -1,8 +1,11 @@
 package base
 
 import (
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/stretchr/testify/assert"
 )
 
 var (
@@ -151,3 +154,28 @@ func TestPushEvent(t *testing.T) {
 		}
 	}
 }
+
+func TestParseSeverity(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		in  string
+		out Severity
+	}{
+		{"", SeverityInfo},
+		{"info", SeverityInfo},
+		{"WARN", SeverityWarning},
+		{"warning", SeverityWarning},
+		{"Error", SeverityError},
+		{"critical", SeverityCritical},
+	} {
+		s, err := ParseSeverity(tc.in)
+		assert.NoError(t, err, "ParseSeverity should not error")
+		assert.Equal(t, tc.out, s, "ParseSeverity should return the correct severity")
+		if tc.in != "" && tc.in != "WARN" {
+			assert.True(t, strings.EqualFold(tc.in, s.String()), "String should round trip")
+		}
+	}
+	_, err := ParseSeverity("meow")
+	assert.ErrorIs(t, err, ErrUnknownSeverity)
+	assert.Equal(t, "severity(9)", Severity(9).String())
+}
@@ -2,17 +2,23 @@ package communications
 
 import (
 	"errors"
+	"strings"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/communications/opsgenie"
+	"github.com/thrasher-corp/gocryptotrader/communications/pagerduty"
 	"github.com/thrasher-corp/gocryptotrader/communications/slack"
 	"github.com/thrasher-corp/gocryptotrader/communications/smsglobal"
 	"github.com/thrasher-corp/gocryptotrader/communications/smtpservice"
 	"github.com/thrasher-corp/gocryptotrader/communications/telegram"
+	"github.com/thrasher-corp/gocryptotrader/log"
 )
 
 // Communications is the overarching type across the communications packages
 type Communications struct {
 	base.IComm
+	router *Router
 }
 
 // ErrNoRelayersEnabled returns when no communication relayers are enabled
@@ -25,6 +31,14 @@ func NewComm(cfg *base.CommunicationsConfig) (*Communications, error) {
 	}
 
 	var comm Communications
+	if cfg.Routing.Enabled {
+		var err error
+		comm.router, err = NewRouter(&cfg.Routing)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if cfg.TelegramConfig.Enabled {
 		Telegram := new(telegram.Telegram)
 		Telegram.Setup(cfg)
@@ -49,6 +63,48 @@ func NewComm(cfg *base.CommunicationsConfig) (*Communications, error) {
 		comm.IComm = append(comm.IComm, Slack)
 	}
 
+	if cfg.PagerDutyConfig.Enabled {
+		PagerDuty := new(pagerduty.PagerDuty)
+		PagerDuty.Setup(cfg)
+		comm.IComm = append(comm.IComm, PagerDuty)
+	}
+
+	if cfg.OpsgenieConfig.Enabled {
+		Opsgenie := new(opsgenie.Opsgenie)
+		Opsgenie.Setup(cfg)
+		comm.IComm = append(comm.IComm, Opsgenie)
+	}
+
 	comm.Setup()
 	return &comm, nil
 }
+
+// PushEvent pushes an event to enabled communication relayers. When routing is
+// enabled duplicate events are suppressed and events are only sent to the
+// relayers selected by the routing rules within their rate limit
+func (c *Communications) PushEvent(event base.Event) {
+	if c.router == nil {
+		c.IComm.PushEvent(event)
+		return
+	}
+	now := time.Now()
+	if c.router.IsDuplicate(&event, now) {
+		return
+	}
+	channels := c.router.Route(&event)
+	for i := range c.IComm {
+		if !c.IComm[i].IsEnabled() || !c.IComm[i].IsConnected() {
+			continue
+		}
+		if _, ok := channels[strings.ToLower(c.IComm[i].GetName())]; !ok {
+			continue
+		}
+		if !c.router.Allow(c.IComm[i].GetName(), now) {
+			continue
+		}
+		if err := c.IComm[i].PushEvent(event); err != nil {
+			log.Errorf(log.CommunicationMgr, "Communications error - PushEvent() in package %s with %v. Err %s",
+				c.IComm[i].GetName(), event, err)
+		}
+	}
+}
@@ -2,7 +2,10 @@ package communications
 
 import (
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/thrasher-corp/gocryptotrader/communications/base"
 )
 
@@ -16,13 +19,53 @@ func TestNewComm(t *testing.T) {
 	cfg.SMSGlobalConfig.Enabled = true
 	cfg.SMTPConfig.Enabled = true
 	cfg.SlackConfig.Enabled = true
+	cfg.PagerDutyConfig.Enabled = true
+	cfg.OpsgenieConfig.Enabled = true
 	communications, err := NewComm(&cfg)
 	if err != nil {
 		t.Error("Unexpected result")
 	}
 
-	if len(communications.IComm) != 4 {
-		t.Errorf("communications NewComm, expected len 4, got len %d",
+	if len(communications.IComm) != 6 {
+		t.Errorf("communications NewComm, expected len 6, got len %d",
 			len(communications.IComm))
 	}
 }
+
+type mockRelayer struct {
+	base.Base
+	events []base.Event
+}
+
+func (m *mockRelayer) Setup(*base.CommunicationsConfig) {}
+func (m *mockRelayer) Connect() error                   { return nil }
+func (m *mockRelayer) PushEvent(e base.Event) error {
+	m.events = append(m.events, e)
+	return nil
+}
+
+func TestPushEvent(t *testing.T) {
+	t.Parallel()
+	slack := &mockRelayer{Base: base.Base{Name: "Slack", Enabled: true, Connected: true}}
+	telegram := &mockRelayer{Base: base.Base{Name: "Telegram", Enabled: true, Connected: true}}
+	c := &Communications{IComm: base.IComm{slack, telegram}}
+	c.PushEvent(base.Event{Type: "order", Message: "filled"})
+	assert.Len(t, slack.events, 1, "events should be broadcast without routing")
+	assert.Len(t, telegram.events, 1, "events should be broadcast without routing")
+
+	var err error
+	c.router, err = NewRouter(&base.RoutingConfig{
+		Enabled:     true,
+		Rules:       []base.RoutingRule{{EventTypes: []string{"order"}, Channels: []string{"telegram"}}},
+		DedupWindow: time.Minute,
+		RateLimit:   1,
+	})
+	require.NoError(t, err)
+	c.PushEvent(base.Event{Type: "order", Message: "filled"})
+	c.PushEvent(base.Event{Type: "order", Message: "filled"})
+	c.PushEvent(base.Event{Type: "order", Message: "cancelled"})
+	c.PushEvent(base.Event{Type: "event", Message: "triggered"})
+	assert.Len(t, slack.events, 1, "unmatched events should not be sent without default channels")
+	require.Len(t, telegram.events, 2, "duplicate and rate limited events should not be sent")
+	assert.Equal(t, "filled", telegram.events[1].Message)
+}
@@ -0,0 +1,150 @@
+// Package opsgenie raises and closes alerts via the Opsgenie Alert API
+package opsgenie
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+const (
+	defaultAPIURL = "https://api.opsgenie.com"
+	alertsPath    = "/v2/alerts"
+	source        = "GoCryptoTrader"
+	// maxMessageLength is the maximum length of an alert message
+	maxMessageLength = 130
+)
+
+var (
+	errAPIKeyNotSet    = errors.New("Opsgenie API key not set")
+	errRequestRejected = errors.New("Opsgenie request rejected")
+	errEmptyAlias      = errors.New("Opsgenie alert alias must be set to close an alert")
+)
+
+// Opsgenie is the overarching type across this package
+type Opsgenie struct {
+	base.Base
+	APIKey      string
+	APIURL      string
+	Tags        []string
+	MinSeverity base.Severity
+}
+
+// Setup takes in an Opsgenie configuration and sets the API key, URL, tags
+// and minimum severity
+func (o *Opsgenie) Setup(cfg *base.CommunicationsConfig) {
+	o.Name = cfg.OpsgenieConfig.Name
+	o.Enabled = cfg.OpsgenieConfig.Enabled
+	o.Verbose = cfg.OpsgenieConfig.Verbose
+	o.APIKey = cfg.OpsgenieConfig.APIKey
+	o.APIURL = strings.TrimSuffix(cfg.OpsgenieConfig.APIURL, "/")
+	if o.APIURL == "" {
+		o.APIURL = defaultAPIURL
+	}
+	o.Tags = cfg.OpsgenieConfig.Tags
+	o.MinSeverity = base.SeverityCritical
+	if cfg.OpsgenieConfig.MinSeverity != "" {
+		s, err := base.ParseSeverity(cfg.OpsgenieConfig.MinSeverity)
+		if err != nil {
+			log.Errorf(log.CommunicationMgr, "Opsgenie: %v, defaulting to %s", err, o.MinSeverity)
+		} else {
+			o.MinSeverity = s
+		}
+	}
+}
+
+// Connect validates the configuration as the Alert API is stateless
+func (o *Opsgenie) Connect() error {
+	if o.APIKey == "" {
+		return errAPIKeyNotSet
+	}
+	o.Connected = true
+	return nil
+}
+
+// PushEvent creates an alert for events at or above the minimum severity and
+// closes the alert for resolution events
+func (o *Opsgenie) PushEvent(event base.Event) error {
+	if !event.IsIncident(o.MinSeverity) {
+		return nil
+	}
+	if event.Resolved {
+		return o.CloseAlert(context.TODO(), event.Key, &CloseAlert{Source: source, Note: event.Message})
+	}
+	message := event.Message
+	if len(message) > maxMessageLength {
+		message = message[:maxMessageLength]
+	}
+	return o.CreateAlert(context.TODO(), &Alert{
+		Message:     message,
+		Alias:       event.Key,
+		Description: event.Message,
+		Tags:        o.Tags,
+		Entity:      event.Exchange,
+		Source:      source,
+		Priority:    severityToPriority(event.Severity),
+	})
+}
+
+// CreateAlert creates an Opsgenie alert
+func (o *Opsgenie) CreateAlert(ctx context.Context, a *Alert) error {
+	return o.sendRequest(ctx, o.APIURL+alertsPath, a)
+}
+
+// CloseAlert closes an Opsgenie alert by its alias
+func (o *Opsgenie) CloseAlert(ctx context.Context, alias string, c *CloseAlert) error {
+	if alias == "" {
+		return errEmptyAlias
+	}
+	path := o.APIURL + alertsPath + "/" + url.PathEscape(alias) + "/close?identifierType=alias"
+	return o.sendRequest(ctx, path, c)
+}
+
+func (o *Opsgenie) sendRequest(ctx context.Context, path string, body any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	headers := map[string]string{
+		"Content-Type":  "application/json",
+		"Authorization": "GenieKey " + o.APIKey,
+	}
+	resp, err := common.SendHTTPRequest(ctx, http.MethodPost, path, headers, bytes.NewReader(payload), o.Verbose)
+	if err != nil {
+		return err
+	}
+	var r Response
+	if err := json.Unmarshal(resp, &r); err != nil {
+		return err
+	}
+	if r.Result == "" {
+		return fmt.Errorf("%w: %s", errRequestRejected, r.Message)
+	}
+	if o.Verbose {
+		log.Debugf(log.CommunicationMgr, "Opsgenie: request %s accepted: %s", r.RequestID, r.Result)
+	}
+	return nil
+}
+
+// severityToPriority converts a severity to an Opsgenie priority
+func severityToPriority(s base.Severity) string {
+	switch s {
+	case base.SeverityCritical:
+		return "P1"
+	case base.SeverityError:
+		return "P2"
+	case base.SeverityWarning:
+		return "P3"
+	default:
+		return "P5"
+	}
+}
@@ -0,0 +1,91 @@
+package opsgenie
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+)
+
+func TestSetup(t *testing.T) {
+	t.Parallel()
+	var o Opsgenie
+	o.Setup(&base.CommunicationsConfig{OpsgenieConfig: base.OpsgenieConfig{Name: "Opsgenie", APIKey: "key"}})
+	assert.Equal(t, "Opsgenie", o.Name)
+	assert.Equal(t, defaultAPIURL, o.APIURL, "APIURL should default")
+	assert.Equal(t, base.SeverityCritical, o.MinSeverity, "MinSeverity should default to critical")
+
+	o.Setup(&base.CommunicationsConfig{OpsgenieConfig: base.OpsgenieConfig{APIURL: "https://api.eu.opsgenie.com/", MinSeverity: "error"}})
+	assert.Equal(t, "https://api.eu.opsgenie.com", o.APIURL)
+	assert.Equal(t, base.SeverityError, o.MinSeverity)
+}
+
+func TestConnect(t *testing.T) {
+	t.Parallel()
+	var o Opsgenie
+	assert.ErrorIs(t, o.Connect(), errAPIKeyNotSet)
+	o.APIKey = "key"
+	require.NoError(t, o.Connect())
+	assert.True(t, o.IsConnected())
+}
+
+func TestPushEvent(t *testing.T) {
+	t.Parallel()
+	var paths []string
+	var alerts []Alert
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "GenieKey key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"Key format is not valid!","took":0.001}`))
+			return
+		}
+		paths = append(paths, r.URL.String())
+		if strings.HasSuffix(r.URL.Path, "/close") {
+			var c CloseAlert
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&c), "Decode should not error")
+		} else {
+			var a Alert
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&a), "Decode should not error")
+			alerts = append(alerts, a)
+		}
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"result":"Request will be processed","took":0.302,"requestId":"43a29c5c"}`))
+	}))
+	defer srv.Close()
+
+	o := &Opsgenie{APIKey: "key", APIURL: srv.URL, MinSeverity: base.SeverityError, Tags: []string{"gct"}}
+	require.NoError(t, o.PushEvent(base.Event{Message: "filled", Severity: base.SeverityWarning}))
+	assert.Empty(t, paths, "events below the minimum severity should not be sent")
+
+	require.NoError(t, o.PushEvent(base.Event{Message: strings.Repeat("a", 200), Severity: base.SeverityError, Key: "risk breach", Exchange: "Binance"}))
+	require.Len(t, alerts, 1)
+	assert.Equal(t, alertsPath, paths[0])
+	assert.Len(t, alerts[0].Message, maxMessageLength, "message should be truncated")
+	assert.Len(t, alerts[0].Description, 200, "description should contain the full message")
+	assert.Equal(t, "P2", alerts[0].Priority)
+	assert.Equal(t, "risk breach", alerts[0].Alias)
+	assert.Equal(t, "Binance", alerts[0].Entity)
+
+	require.NoError(t, o.PushEvent(base.Event{Key: "risk breach", Resolved: true}))
+	require.Len(t, paths, 2)
+	assert.Equal(t, alertsPath+"/risk%20breach/close?identifierType=alias", paths[1])
+
+	assert.ErrorIs(t, o.CloseAlert(context.Background(), "", &CloseAlert{}), errEmptyAlias)
+
+	o.APIKey = "bad"
+	assert.ErrorIs(t, o.PushEvent(base.Event{Severity: base.SeverityCritical}), errRequestRejected)
+}
+
+func TestSeverityToPriority(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "P1", severityToPriority(base.SeverityCritical))
+	assert.Equal(t, "P2", severityToPriority(base.SeverityError))
+	assert.Equal(t, "P3", severityToPriority(base.SeverityWarning))
+	assert.Equal(t, "P5", severityToPriority(base.SeverityInfo))
+}
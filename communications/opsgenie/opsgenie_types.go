@@ -0,0 +1,25 @@
+package opsgenie
+
+// Alert is an Opsgenie create alert request
+type Alert struct {
+	Message     string   `json:"message"`
+	Alias       string   `json:"alias,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Entity      string   `json:"entity,omitempty"`
+	Source      string   `json:"source,omitempty"`
+	Priority    string   `json:"priority"`
+}
+
+// CloseAlert is an Opsgenie close alert request
+type CloseAlert struct {
+	Source string `json:"source,omitempty"`
+	Note   string `json:"note,omitempty"`
+}
+
+// Response is an Opsgenie alert API response
+type Response struct {
+	Result    string `json:"result"`
+	Message   string `json:"message"`
+	RequestID string `json:"requestId"`
+}
@@ -0,0 +1,139 @@
+// Package pagerduty raises and resolves incidents via the PagerDuty Events
+// API v2
+package pagerduty
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+const (
+	eventsAPIURL = "https://events.pagerduty.com/v2/enqueue"
+	// maxSummaryLength is the maximum length of an incident summary
+	maxSummaryLength = 1024
+
+	actionTrigger = "trigger"
+	actionResolve = "resolve"
+)
+
+var (
+	errRoutingKeyNotSet = errors.New("PagerDuty routing key not set")
+	errEventRejected    = errors.New("PagerDuty event rejected")
+)
+
+// PagerDuty is the overarching type across this package
+type PagerDuty struct {
+	base.Base
+	RoutingKey  string
+	Source      string
+	MinSeverity base.Severity
+
+	apiURL string
+}
+
+// Setup takes in a PagerDuty configuration and sets the routing key, source
+// and minimum severity
+func (p *PagerDuty) Setup(cfg *base.CommunicationsConfig) {
+	p.Name = cfg.PagerDutyConfig.Name
+	p.Enabled = cfg.PagerDutyConfig.Enabled
+	p.Verbose = cfg.PagerDutyConfig.Verbose
+	p.RoutingKey = cfg.PagerDutyConfig.RoutingKey
+	p.Source = cfg.PagerDutyConfig.Source
+	if p.Source == "" {
+		p.Source = "GoCryptoTrader"
+	}
+	p.MinSeverity = base.SeverityCritical
+	if cfg.PagerDutyConfig.MinSeverity != "" {
+		s, err := base.ParseSeverity(cfg.PagerDutyConfig.MinSeverity)
+		if err != nil {
+			log.Errorf(log.CommunicationMgr, "PagerDuty: %v, defaulting to %s", err, p.MinSeverity)
+		} else {
+			p.MinSeverity = s
+		}
+	}
+	p.apiURL = eventsAPIURL
+}
+
+// Connect validates the configuration as the Events API is stateless
+func (p *PagerDuty) Connect() error {
+	if p.RoutingKey == "" {
+		return errRoutingKeyNotSet
+	}
+	p.Connected = true
+	return nil
+}
+
+// PushEvent triggers an incident for events at or above the minimum severity
+// and resolves the incident for resolution events
+func (p *PagerDuty) PushEvent(event base.Event) error {
+	if !event.IsIncident(p.MinSeverity) {
+		return nil
+	}
+	e := &Event{
+		RoutingKey: p.RoutingKey,
+		DedupKey:   event.Key,
+	}
+	if event.Resolved {
+		e.EventAction = actionResolve
+	} else {
+		summary := event.Message
+		if len(summary) > maxSummaryLength {
+			summary = summary[:maxSummaryLength]
+		}
+		e.EventAction = actionTrigger
+		e.Payload = &Payload{
+			Summary:   summary,
+			Source:    p.Source,
+			Severity:  severityToPagerDuty(event.Severity),
+			Component: event.Exchange,
+			Group:     event.Type,
+		}
+	}
+	return p.SendEvent(context.TODO(), e)
+}
+
+// SendEvent sends an event to the PagerDuty Events API
+func (p *PagerDuty) SendEvent(ctx context.Context, e *Event) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	headers := map[string]string{"Content-Type": "application/json"}
+	resp, err := common.SendHTTPRequest(ctx, http.MethodPost, p.apiURL, headers, bytes.NewReader(payload), p.Verbose)
+	if err != nil {
+		return err
+	}
+	var r Response
+	if err := json.Unmarshal(resp, &r); err != nil {
+		return err
+	}
+	if r.Status != "success" {
+		return fmt.Errorf("%w: %s %v", errEventRejected, r.Message, r.Errors)
+	}
+	if p.Verbose {
+		log.Debugf(log.CommunicationMgr, "PagerDuty: %s event accepted with dedup key %s", e.EventAction, r.DedupKey)
+	}
+	return nil
+}
+
+// severityToPagerDuty converts a severity to its PagerDuty equivalent
+func severityToPagerDuty(s base.Severity) string {
+	switch s {
+	case base.SeverityCritical:
+		return "critical"
+	case base.SeverityError:
+		return "error"
+	case base.SeverityWarning:
+		return "warning"
+	default:
+		return "info"
+	}
+}
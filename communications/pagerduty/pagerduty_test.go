@@ -0,0 +1,85 @@
+package pagerduty
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+)
+
+func TestSetup(t *testing.T) {
+	t.Parallel()
+	var p PagerDuty
+	p.Setup(&base.CommunicationsConfig{PagerDutyConfig: base.PagerDutyConfig{
+		Name:       "PagerDuty",
+		Enabled:    true,
+		RoutingKey: "key",
+	}})
+	assert.Equal(t, "PagerDuty", p.Name)
+	assert.Equal(t, "GoCryptoTrader", p.Source, "Source should default")
+	assert.Equal(t, base.SeverityCritical, p.MinSeverity, "MinSeverity should default to critical")
+
+	p.Setup(&base.CommunicationsConfig{PagerDutyConfig: base.PagerDutyConfig{MinSeverity: "warning"}})
+	assert.Equal(t, base.SeverityWarning, p.MinSeverity)
+}
+
+func TestConnect(t *testing.T) {
+	t.Parallel()
+	var p PagerDuty
+	assert.ErrorIs(t, p.Connect(), errRoutingKeyNotSet)
+	p.RoutingKey = "key"
+	require.NoError(t, p.Connect())
+	assert.True(t, p.IsConnected())
+}
+
+func TestPushEvent(t *testing.T) {
+	t.Parallel()
+	var received []Event
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var e Event
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&e), "Decode should not error")
+		received = append(received, e)
+		w.WriteHeader(http.StatusAccepted)
+		if e.RoutingKey != "key" {
+			_, _ = w.Write([]byte(`{"status":"invalid event","message":"Event object is invalid","errors":["Invalid routing key"]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"success","message":"Event processed","dedup_key":"connectivity"}`))
+	}))
+	defer srv.Close()
+
+	p := &PagerDuty{RoutingKey: "key", Source: "test", MinSeverity: base.SeverityCritical, apiURL: srv.URL}
+	require.NoError(t, p.PushEvent(base.Event{Type: "order", Message: "filled"}))
+	assert.Empty(t, received, "events below the minimum severity should not be sent")
+
+	require.NoError(t, p.PushEvent(base.Event{Type: "connectivity", Message: "lost", Severity: base.SeverityCritical, Key: "connectivity"}))
+	require.Len(t, received, 1)
+	assert.Equal(t, actionTrigger, received[0].EventAction)
+	assert.Equal(t, "connectivity", received[0].DedupKey)
+	require.NotNil(t, received[0].Payload)
+	assert.Equal(t, "critical", received[0].Payload.Severity)
+
+	require.NoError(t, p.PushEvent(base.Event{Resolved: true}))
+	assert.Len(t, received, 1, "resolution events without a key should not be sent")
+
+	require.NoError(t, p.PushEvent(base.Event{Message: "restored", Key: "connectivity", Resolved: true}))
+	require.Len(t, received, 2)
+	assert.Equal(t, actionResolve, received[1].EventAction)
+	assert.Nil(t, received[1].Payload)
+
+	p.RoutingKey = "bad"
+	err := p.PushEvent(base.Event{Severity: base.SeverityCritical})
+	assert.ErrorIs(t, err, errEventRejected)
+}
+
+func TestSeverityToPagerDuty(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "critical", severityToPagerDuty(base.SeverityCritical))
+	assert.Equal(t, "error", severityToPagerDuty(base.SeverityError))
+	assert.Equal(t, "warning", severityToPagerDuty(base.SeverityWarning))
+	assert.Equal(t, "info", severityToPagerDuty(base.SeverityInfo))
+}
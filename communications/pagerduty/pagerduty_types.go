@@ -0,0 +1,26 @@
+package pagerduty
+
+// Event is a PagerDuty Events API v2 request
+type Event struct {
+	RoutingKey  string   `json:"routing_key"`
+	EventAction string   `json:"event_action"`
+	DedupKey    string   `json:"dedup_key,omitempty"`
+	Payload     *Payload `json:"payload,omitempty"`
+}
+
+// Payload holds the details of a triggered incident
+type Payload struct {
+	Summary   string `json:"summary"`
+	Source    string `json:"source"`
+	Severity  string `json:"severity"`
+	Component string `json:"component,omitempty"`
+	Group     string `json:"group,omitempty"`
+}
+
+// Response is a PagerDuty Events API v2 response
+type Response struct {
+	Status   string   `json:"status"`
+	Message  string   `json:"message"`
+	DedupKey string   `json:"dedup_key"`
+	Errors   []string `json:"errors"`
+}
@@ -0,0 +1,150 @@
+package communications
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// NewRouter returns a Router for the supplied routing config
+func NewRouter(cfg *base.RoutingConfig) (*Router, error) {
+	if cfg == nil {
+		return nil, errNilRoutingConfig
+	}
+	if cfg.DedupWindow < 0 {
+		return nil, errNegativeDedupWindow
+	}
+	if cfg.RateLimit < 0 {
+		return nil, errNegativeRateLimit
+	}
+	r := &Router{
+		rules:             make([]routingRule, len(cfg.Rules)),
+		defaultChannels:   toSet(cfg.DefaultChannels),
+		dedupWindow:       cfg.DedupWindow,
+		rateLimit:         cfg.RateLimit,
+		rateLimitInterval: cfg.RateLimitInterval,
+		recent:            make(map[dedupKey]time.Time),
+		windows:           make(map[string]*rateWindow),
+	}
+	if r.rateLimit > 0 && r.rateLimitInterval <= 0 {
+		r.rateLimitInterval = DefaultRateLimitInterval
+	}
+	for i := range cfg.Rules {
+		if len(cfg.Rules[i].Channels) == 0 {
+			return nil, fmt.Errorf("%w: %q", errRuleNoChannels, cfg.Rules[i].Name)
+		}
+		severity, err := base.ParseSeverity(cfg.Rules[i].MinSeverity)
+		if err != nil {
+			return nil, fmt.Errorf("routing rule %q: %w", cfg.Rules[i].Name, err)
+		}
+		r.rules[i] = routingRule{
+			name:        cfg.Rules[i].Name,
+			eventTypes:  toSet(cfg.Rules[i].EventTypes),
+			exchanges:   toSet(cfg.Rules[i].Exchanges),
+			minSeverity: severity,
+			channels:    toSet(cfg.Rules[i].Channels),
+		}
+	}
+	return r, nil
+}
+
+// Route returns the set of relayer names, in lower case, an event should be
+// sent to. Events matching no rule are sent to the default channels
+func (r *Router) Route(e *base.Event) map[string]struct{} {
+	channels := make(map[string]struct{})
+	for i := range r.rules {
+		if !r.rules[i].matches(e) {
+			continue
+		}
+		for c := range r.rules[i].channels {
+			channels[c] = struct{}{}
+		}
+	}
+	if len(channels) == 0 {
+		return r.defaultChannels
+	}
+	return channels
+}
+
+// IsDuplicate returns true if an identical event has been seen within the
+// dedup window, otherwise the event is recorded
+func (r *Router) IsDuplicate(e *base.Event, now time.Time) bool {
+	if r.dedupWindow == 0 {
+		return false
+	}
+	r.m.Lock()
+	defer r.m.Unlock()
+	for k, seen := range r.recent {
+		if now.Sub(seen) >= r.dedupWindow {
+			delete(r.recent, k)
+		}
+	}
+	k := dedupKey{
+		eventType: strings.ToLower(e.Type),
+		exchange:  strings.ToLower(e.Exchange),
+		severity:  e.Severity,
+		message:   e.Message,
+	}
+	if _, ok := r.recent[k]; ok {
+		return true
+	}
+	r.recent[k] = now
+	return false
+}
+
+// Allow returns whether an event may be sent to the named relayer without
+// exceeding its rate limit
+func (r *Router) Allow(channel string, now time.Time) bool {
+	if r.rateLimit == 0 {
+		return true
+	}
+	channel = strings.ToLower(channel)
+	r.m.Lock()
+	defer r.m.Unlock()
+	w, ok := r.windows[channel]
+	if !ok || now.Sub(w.start) >= r.rateLimitInterval {
+		if ok && w.suppressed > 0 {
+			log.Warnf(log.CommunicationMgr, "Communications: %d events to %s were suppressed by rate limiting", w.suppressed, channel)
+		}
+		w = &rateWindow{start: now}
+		r.windows[channel] = w
+	}
+	if w.sent >= r.rateLimit {
+		if w.suppressed == 0 {
+			log.Warnf(log.CommunicationMgr, "Communications: rate limit of %d events per %s reached for %s", r.rateLimit, r.rateLimitInterval, channel)
+		}
+		w.suppressed++
+		return false
+	}
+	w.sent++
+	return true
+}
+
+// matches returns whether an event satisfies all of the rule's filters
+func (r *routingRule) matches(e *base.Event) bool {
+	if e.Severity < r.minSeverity {
+		return false
+	}
+	if len(r.eventTypes) > 0 {
+		if _, ok := r.eventTypes[strings.ToLower(e.Type)]; !ok {
+			return false
+		}
+	}
+	if len(r.exchanges) > 0 {
+		if _, ok := r.exchanges[strings.ToLower(e.Exchange)]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func toSet(s []string) map[string]struct{} {
+	m := make(map[string]struct{}, len(s))
+	for i := range s {
+		m[strings.ToLower(s[i])] = struct{}{}
+	}
+	return m
+}
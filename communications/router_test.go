@@ -0,0 +1,91 @@
+package communications
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+)
+
+func TestNewRouter(t *testing.T) {
+	t.Parallel()
+	_, err := NewRouter(nil)
+	assert.ErrorIs(t, err, errNilRoutingConfig)
+
+	_, err = NewRouter(&base.RoutingConfig{DedupWindow: -1})
+	assert.ErrorIs(t, err, errNegativeDedupWindow)
+
+	_, err = NewRouter(&base.RoutingConfig{RateLimit: -1})
+	assert.ErrorIs(t, err, errNegativeRateLimit)
+
+	_, err = NewRouter(&base.RoutingConfig{Rules: []base.RoutingRule{{Name: "meow"}}})
+	assert.ErrorIs(t, err, errRuleNoChannels)
+
+	_, err = NewRouter(&base.RoutingConfig{Rules: []base.RoutingRule{{Channels: []string{"slack"}, MinSeverity: "meow"}}})
+	assert.ErrorIs(t, err, base.ErrUnknownSeverity)
+
+	r, err := NewRouter(&base.RoutingConfig{RateLimit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultRateLimitInterval, r.rateLimitInterval, "rate limit interval should default")
+}
+
+func TestRoute(t *testing.T) {
+	t.Parallel()
+	r, err := NewRouter(&base.RoutingConfig{
+		Rules: []base.RoutingRule{
+			{Name: "errors", MinSeverity: "error", Channels: []string{"PagerDuty"}},
+			{Name: "fills", EventTypes: []string{"order"}, Exchanges: []string{"Binance"}, Channels: []string{"Telegram"}},
+		},
+		DefaultChannels: []string{"SMTP"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]struct{}{"pagerduty": {}},
+		r.Route(&base.Event{Type: "event", Severity: base.SeverityCritical}))
+	assert.Equal(t, map[string]struct{}{"telegram": {}},
+		r.Route(&base.Event{Type: "ORDER", Exchange: "binance"}))
+	assert.Equal(t, map[string]struct{}{"telegram": {}, "pagerduty": {}},
+		r.Route(&base.Event{Type: "order", Exchange: "binance", Severity: base.SeverityError}),
+		"events matching multiple rules should be sent to all matching channels")
+	assert.Equal(t, map[string]struct{}{"smtp": {}},
+		r.Route(&base.Event{Type: "order", Exchange: "okx"}),
+		"unmatched events should be sent to the default channels")
+}
+
+func TestIsDuplicate(t *testing.T) {
+	t.Parallel()
+	r, err := NewRouter(&base.RoutingConfig{})
+	require.NoError(t, err)
+	e := &base.Event{Type: "order", Message: "filled"}
+	now := time.Now()
+	assert.False(t, r.IsDuplicate(e, now))
+	assert.False(t, r.IsDuplicate(e, now), "dedup should be disabled without a window")
+
+	r, err = NewRouter(&base.RoutingConfig{DedupWindow: time.Minute})
+	require.NoError(t, err)
+	assert.False(t, r.IsDuplicate(e, now))
+	assert.True(t, r.IsDuplicate(e, now.Add(time.Second)))
+	assert.False(t, r.IsDuplicate(&base.Event{Type: "order", Message: "cancelled"}, now.Add(time.Second)))
+	assert.False(t, r.IsDuplicate(e, now.Add(time.Minute)), "events should be sent again once the window has passed")
+}
+
+func TestAllow(t *testing.T) {
+	t.Parallel()
+	r, err := NewRouter(&base.RoutingConfig{})
+	require.NoError(t, err)
+	now := time.Now()
+	for range 10 {
+		assert.True(t, r.Allow("slack", now), "Allow should always succeed without a rate limit")
+	}
+
+	r, err = NewRouter(&base.RoutingConfig{RateLimit: 2, RateLimitInterval: time.Minute})
+	require.NoError(t, err)
+	assert.True(t, r.Allow("slack", now))
+	assert.True(t, r.Allow("Slack", now))
+	assert.False(t, r.Allow("slack", now.Add(time.Second)))
+	assert.True(t, r.Allow("telegram", now), "rate limits should be per channel")
+	assert.Equal(t, 1, r.windows["slack"].suppressed)
+	assert.True(t, r.Allow("slack", now.Add(time.Minute)), "rate limit should reset each interval")
+}
@@ -0,0 +1,59 @@
+package communications
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+)
+
+// DefaultRateLimitInterval is used when a rate limit is configured without an
+// interval
+const DefaultRateLimitInterval = time.Minute
+
+var (
+	errNilRoutingConfig    = errors.New("nil routing config")
+	errRuleNoChannels      = errors.New("routing rule has no channels")
+	errNegativeDedupWindow = errors.New("dedup window cannot be negative")
+	errNegativeRateLimit   = errors.New("rate limit cannot be negative")
+)
+
+// Router filters events and directs them to communication relayers based on
+// routing rules, suppressing duplicates and limiting the rate of events sent
+// to each relayer to prevent alert storms
+type Router struct {
+	rules             []routingRule
+	defaultChannels   map[string]struct{}
+	dedupWindow       time.Duration
+	rateLimit         int
+	rateLimitInterval time.Duration
+
+	m       sync.Mutex
+	recent  map[dedupKey]time.Time
+	windows map[string]*rateWindow
+}
+
+// routingRule is a parsed base.RoutingRule with case insensitive lookups
+type routingRule struct {
+	name        string
+	eventTypes  map[string]struct{}
+	exchanges   map[string]struct{}
+	minSeverity base.Severity
+	channels    map[string]struct{}
+}
+
+// dedupKey identifies identical events
+type dedupKey struct {
+	eventType string
+	exchange  string
+	severity  base.Severity
+	message   string
+}
+
+// rateWindow tracks events sent to a relayer within a fixed window
+type rateWindow struct {
+	start      time.Time
+	sent       int
+	suppressed int
+}
@@ -3,6 +3,9 @@ package config
 import (
 	"bufio"
 	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -16,8 +19,10 @@ import (
 
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/common/convert"
+	"github.com/thrasher-corp/gocryptotrader/common/cron"
 	"github.com/thrasher-corp/gocryptotrader/common/file"
 	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config/versions"
 	"github.com/thrasher-corp/gocryptotrader/connchecker"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/currency/forexprovider"
@@ -302,6 +307,21 @@ func (c *Config) CheckCommunicationsConfig() {
 		}
 	}
 
+	if c.Communications.PagerDutyConfig.Name == "" {
+		c.Communications.PagerDutyConfig = base.PagerDutyConfig{
+			Name:        "PagerDuty",
+			Source:      "GoCryptoTrader",
+			MinSeverity: "critical",
+		}
+	}
+
+	if c.Communications.OpsgenieConfig.Name == "" {
+		c.Communications.OpsgenieConfig = base.OpsgenieConfig{
+			Name:        "Opsgenie",
+			MinSeverity: "critical",
+		}
+	}
+
 	if c.Communications.TelegramConfig.AuthorisedClients == nil {
 		c.Communications.TelegramConfig.AuthorisedClients = map[string]int64{"user_example": 0}
 	}
@@ -309,7 +329,9 @@ func (c *Config) CheckCommunicationsConfig() {
 	if c.Communications.SlackConfig.Name != "Slack" ||
 		c.Communications.SMSGlobalConfig.Name != "SMSGlobal" ||
 		c.Communications.SMTPConfig.Name != "SMTP" ||
-		c.Communications.TelegramConfig.Name != "Telegram" {
+		c.Communications.TelegramConfig.Name != "Telegram" ||
+		c.Communications.PagerDutyConfig.Name != "PagerDuty" ||
+		c.Communications.OpsgenieConfig.Name != "Opsgenie" {
 		log.Warnln(log.ConfigMgr, "Communications config name/s not set correctly")
 	}
 	if c.Communications.SlackConfig.Enabled {
@@ -346,6 +368,14 @@ func (c *Config) CheckCommunicationsConfig() {
 			log.Warnln(log.ConfigMgr, "Telegram enabled in config but variable data not set, disabling.")
 		}
 	}
+	if c.Communications.PagerDutyConfig.Enabled && c.Communications.PagerDutyConfig.RoutingKey == "" {
+		c.Communications.PagerDutyConfig.Enabled = false
+		log.Warnln(log.ConfigMgr, "PagerDuty enabled in config but variable data not set, disabling.")
+	}
+	if c.Communications.OpsgenieConfig.Enabled && c.Communications.OpsgenieConfig.APIKey == "" {
+		c.Communications.OpsgenieConfig.Enabled = false
+		log.Warnln(log.ConfigMgr, "Opsgenie enabled in config but variable data not set, disabling.")
+	}
 }
 
 // GetExchangeAssetTypes returns the exchanges supported asset types
@@ -844,12 +874,6 @@ func (c *Config) CheckExchangeConfigValues() error {
 
 	exchanges := 0
 	for i := range c.Exchanges {
-		if strings.EqualFold(c.Exchanges[i].Name, "GDAX") {
-			c.Exchanges[i].Name = "CoinbasePro"
-		} else if strings.EqualFold(c.Exchanges[i].Name, "OKCOIN International") {
-			c.Exchanges[i].Name = "Okcoin"
-		}
-
 		// Check to see if the old API storage format is used
 		if c.Exchanges[i].APIKey != nil {
 			// It is, migrate settings to new format
@@ -1249,9 +1273,22 @@ func (c *Config) CheckLoggerConfig() error {
 			log.Warnf(log.ConfigMgr, "Logger rotation size invalid, defaulting to %v", log.DefaultMaxFileSize)
 			c.Logging.LoggerFileConfig.MaxSize = log.DefaultMaxFileSize
 		}
+		if c.Logging.LoggerFileConfig.RotateInterval < 0 {
+			log.Warnln(log.ConfigMgr, "Logger rotation interval invalid, disabling time based rotation")
+			c.Logging.LoggerFileConfig.RotateInterval = 0
+		}
+		if c.Logging.LoggerFileConfig.MaxBackups < 0 {
+			log.Warnln(log.ConfigMgr, "Logger max backups invalid, keeping all rotated files")
+			c.Logging.LoggerFileConfig.MaxBackups = 0
+		}
 		log.SetFileLoggingState( /*Is correctly configured*/ true)
 	}
 
+	if c.Logging.RemoteSettings != nil && c.Logging.RemoteSettings.Loki != nil && c.Logging.RemoteSettings.Loki.URL == "" {
+		log.Warnln(log.ConfigMgr, "Logger loki url not set, disabling loki shipping")
+		c.Logging.RemoteSettings.Loki = nil
+	}
+
 	err := log.SetGlobalLogConfig(&c.Logging)
 	if err != nil {
 		return err
@@ -1319,6 +1356,10 @@ func (c *Config) checkDatabaseConfig() error {
 			return err
 		}
 		database.DB.DataPath = databaseDir
+		if err := c.Database.SQLite.CheckAndSetDefaults(); err != nil {
+			c.Database.Enabled = false
+			return fmt.Errorf("%w, database disabled", err)
+		}
 	}
 
 	return database.DB.SetConfig(&c.Database)
@@ -1410,6 +1451,508 @@ func (c *Config) CheckCurrencyStateManager() {
 	}
 }
 
+// CheckFillSyncManagerConfig ensures the fill sync manager config is valid,
+// or sets default values
+func (c *Config) CheckFillSyncManagerConfig() {
+	m.Lock()
+	defer m.Unlock()
+	if c.FillSyncManager.SyncInterval <= 0 {
+		c.FillSyncManager.SyncInterval = defaultFillSyncInterval
+	}
+	if c.FillSyncManager.Lookback <= 0 {
+		c.FillSyncManager.Lookback = defaultFillSyncLookback
+	}
+}
+
+// CheckWebhookListenerConfig ensures the webhook listener config is valid, or
+// sets default values
+func (c *Config) CheckWebhookListenerConfig() {
+	m.Lock()
+	defer m.Unlock()
+	wl := &c.WebhookListener
+	if wl.ListenAddress == "" {
+		wl.ListenAddress = defaultWebhookListenAddress
+	}
+	if wl.MaxBodySize <= 0 {
+		wl.MaxBodySize = defaultWebhookMaxBodySize
+	}
+	for i := range wl.Sources {
+		if wl.Sources[i].Format == "" {
+			wl.Sources[i].Format = defaultWebhookFormat
+		}
+		if wl.Sources[i].Exchange == "" {
+			wl.Sources[i].Exchange = wl.Sources[i].Name
+		}
+	}
+}
+
+// CheckPairRefreshManagerConfig ensures the pair refresh manager config is
+// valid, or sets default values
+func (c *Config) CheckPairRefreshManagerConfig() {
+	m.Lock()
+	defer m.Unlock()
+	if c.PairRefreshManager.Interval <= 0 {
+		c.PairRefreshManager.Interval = defaultPairRefreshInterval
+	}
+}
+
+// CheckEarnManagerConfig ensures the earn manager config is valid, or sets
+// default values
+func (c *Config) CheckEarnManagerConfig() {
+	m.Lock()
+	defer m.Unlock()
+	if c.EarnManager.SyncInterval <= 0 {
+		c.EarnManager.SyncInterval = defaultEarnSyncInterval
+	}
+	if c.EarnManager.Lookback <= 0 {
+		c.EarnManager.Lookback = defaultEarnLookback
+	}
+}
+
+// CheckLendingOptimizerConfig ensures the lending optimizer config is valid,
+// or sets default values
+func (c *Config) CheckLendingOptimizerConfig() {
+	m.Lock()
+	defer m.Unlock()
+	lo := &c.LendingOptimizer
+	if lo.DryRun == nil {
+		lo.DryRun = convert.BoolPtr(true)
+	}
+	if lo.CheckInterval <= 0 {
+		lo.CheckInterval = defaultLendingCheckInterval
+	}
+	if len(lo.Currencies) == 0 {
+		lo.Currencies = []string{currency.USDT.String(), currency.USDC.String()}
+	}
+	if lo.MinRateImprovement <= 0 {
+		lo.MinRateImprovement = defaultLendingMinRateImprovement
+	}
+	if lo.MinAmount <= 0 {
+		lo.MinAmount = defaultLendingMinAmount
+	}
+	if lo.MaxTransferAmount < 0 {
+		lo.MaxTransferAmount = 0
+	}
+	if lo.Reserve < 0 {
+		lo.Reserve = 0
+	}
+}
+
+// CheckMemoryManagerConfig ensures the memory manager config is valid, or sets
+// default values
+func (c *Config) CheckMemoryManagerConfig() {
+	m.Lock()
+	defer m.Unlock()
+	mm := &c.MemoryManager
+	if mm.CheckInterval <= 0 {
+		mm.CheckInterval = defaultMemoryCheckInterval
+	}
+	if mm.OrderbookBudgetMB < 0 {
+		mm.OrderbookBudgetMB = 0
+	}
+	if mm.TickerBudgetMB < 0 {
+		mm.TickerBudgetMB = 0
+	}
+	if mm.TradeBufferBudgetMB < 0 {
+		mm.TradeBufferBudgetMB = 0
+	}
+}
+
+// CheckRolloverManagerConfig ensures the rollover manager config is valid, or
+// sets default values
+func (c *Config) CheckRolloverManagerConfig() {
+	m.Lock()
+	defer m.Unlock()
+	if c.RolloverManager.DryRun == nil {
+		c.RolloverManager.DryRun = convert.BoolPtr(true)
+	}
+	if c.RolloverManager.CheckInterval <= 0 {
+		c.RolloverManager.CheckInterval = defaultRolloverCheckInterval
+	}
+	if c.RolloverManager.RolloverWindow <= 0 {
+		c.RolloverManager.RolloverWindow = defaultRolloverWindow
+	}
+	if c.RolloverManager.MaxSpread <= 0 {
+		c.RolloverManager.MaxSpread = defaultRolloverMaxSpread
+	}
+}
+
+// CheckCalendarSpreadManagerConfig ensures the calendar spread manager config
+// is valid, or sets default values
+func (c *Config) CheckCalendarSpreadManagerConfig() {
+	m.Lock()
+	defer m.Unlock()
+	if c.CalendarSpread.CheckInterval <= 0 {
+		c.CalendarSpread.CheckInterval = defaultSpreadCheckInterval
+	}
+	if c.CalendarSpread.HistorySize <= 1 {
+		c.CalendarSpread.HistorySize = defaultSpreadHistorySize
+	}
+	if c.CalendarSpread.MinSamples <= 1 || c.CalendarSpread.MinSamples > c.CalendarSpread.HistorySize {
+		c.CalendarSpread.MinSamples = min(defaultSpreadMinSamples, c.CalendarSpread.HistorySize)
+	}
+	if c.CalendarSpread.ZScoreThreshold <= 0 {
+		c.CalendarSpread.ZScoreThreshold = defaultSpreadZScoreThreshold
+	}
+}
+
+// CheckVolatilitySurfaceManagerConfig ensures the volatility surface manager
+// config is valid, or sets default values
+func (c *Config) CheckVolatilitySurfaceManagerConfig() {
+	m.Lock()
+	defer m.Unlock()
+	vs := &c.VolatilitySurface
+	if vs.CheckInterval <= 0 {
+		vs.CheckInterval = defaultSurfaceCheckInterval
+	}
+	if vs.Interpolation == "" {
+		vs.Interpolation = defaultSurfaceInterpolation
+	}
+	if vs.HistorySize <= 0 {
+		vs.HistorySize = defaultSurfaceHistorySize
+	}
+}
+
+// CheckLatencySimulationConfig ensures the latency simulation config is valid,
+// resetting negative durations
+func (c *Config) CheckLatencySimulationConfig() {
+	m.Lock()
+	defer m.Unlock()
+	for _, d := range []*time.Duration{
+		&c.LatencySimulation.RESTLatency,
+		&c.LatencySimulation.RESTJitter,
+		&c.LatencySimulation.WebsocketLatency,
+		&c.LatencySimulation.WebsocketJitter,
+	} {
+		if *d < 0 {
+			log.Warnf(log.ConfigMgr, "Latency simulation duration %v cannot be negative, setting to 0\n", *d)
+			*d = 0
+		}
+	}
+}
+
+// CheckCircuitBreakerConfig ensures the circuit breaker config is valid, or
+// sets default values
+func (c *Config) CheckCircuitBreakerConfig() {
+	m.Lock()
+	defer m.Unlock()
+	if c.CircuitBreaker.PublicFailureThreshold <= 0 {
+		c.CircuitBreaker.PublicFailureThreshold = defaultCircuitFailureThreshold
+	}
+	if c.CircuitBreaker.PrivateFailureThreshold <= 0 {
+		c.CircuitBreaker.PrivateFailureThreshold = defaultCircuitFailureThreshold
+	}
+	if c.CircuitBreaker.Cooldown <= 0 {
+		c.CircuitBreaker.Cooldown = defaultCircuitCooldown
+	}
+}
+
+// CheckMarginMonitorConfig ensures the margin monitor config is valid, or sets
+// default values
+func (c *Config) CheckMarginMonitorConfig() {
+	m.Lock()
+	defer m.Unlock()
+	if c.MarginMonitor.InitialMarginThreshold <= 0 {
+		c.MarginMonitor.InitialMarginThreshold = defaultMarginInitialThreshold
+	}
+	if c.MarginMonitor.CriticalThreshold <= 0 {
+		c.MarginMonitor.CriticalThreshold = defaultMarginCriticalThreshold
+	}
+	if c.MarginMonitor.WarningThreshold <= 0 || c.MarginMonitor.WarningThreshold > c.MarginMonitor.CriticalThreshold {
+		c.MarginMonitor.WarningThreshold = min(defaultMarginWarningThreshold, c.MarginMonitor.CriticalThreshold)
+	}
+	if c.MarginMonitor.Deleverage.DryRun == nil {
+		c.MarginMonitor.Deleverage.DryRun = convert.BoolPtr(true)
+	}
+	if c.MarginMonitor.Deleverage.ReduceFraction <= 0 || c.MarginMonitor.Deleverage.ReduceFraction > 1 {
+		c.MarginMonitor.Deleverage.ReduceFraction = defaultDeleverageReduceFraction
+	}
+	if c.MarginMonitor.Deleverage.Cooldown <= 0 {
+		c.MarginMonitor.Deleverage.Cooldown = defaultDeleverageCooldown
+	}
+}
+
+// CheckADLMonitorConfig ensures the ADL monitor config is valid, or sets
+// default values
+func (c *Config) CheckADLMonitorConfig() {
+	m.Lock()
+	defer m.Unlock()
+	if c.ADLMonitor.CheckInterval <= 0 {
+		c.ADLMonitor.CheckInterval = defaultADLCheckInterval
+	}
+	if c.ADLMonitor.CriticalThreshold <= 0 || c.ADLMonitor.CriticalThreshold > 1 {
+		c.ADLMonitor.CriticalThreshold = defaultADLCriticalThreshold
+	}
+	if c.ADLMonitor.WarningThreshold <= 0 || c.ADLMonitor.WarningThreshold > c.ADLMonitor.CriticalThreshold {
+		c.ADLMonitor.WarningThreshold = min(defaultADLWarningThreshold, c.ADLMonitor.CriticalThreshold)
+	}
+}
+
+// CheckExchangeCalendarConfig ensures the exchange calendar config is valid, or
+// sets default values
+func (c *Config) CheckExchangeCalendarConfig() {
+	m.Lock()
+	defer m.Unlock()
+	if c.ExchangeCalendar.RefreshInterval <= 0 {
+		c.ExchangeCalendar.RefreshInterval = defaultCalendarRefreshInterval
+	}
+	if c.ExchangeCalendar.Horizon <= 0 {
+		c.ExchangeCalendar.Horizon = defaultCalendarHorizon
+	}
+}
+
+// CheckMaintenanceSchedulerConfig ensures the maintenance scheduler config is
+// valid, or sets default values
+func (c *Config) CheckMaintenanceSchedulerConfig() {
+	m.Lock()
+	defer m.Unlock()
+	if c.MaintenanceScheduler.CheckInterval <= 0 {
+		c.MaintenanceScheduler.CheckInterval = defaultMaintenanceCheckInterval
+	}
+	if c.MaintenanceScheduler.ReconnectInterval <= 0 {
+		c.MaintenanceScheduler.ReconnectInterval = defaultMaintenanceReconnectInterval
+	}
+}
+
+// CheckBasisHarvesterConfig ensures the basis harvester config is valid, or
+// sets default values
+func (c *Config) CheckBasisHarvesterConfig() {
+	m.Lock()
+	defer m.Unlock()
+	bh := &c.BasisHarvester
+	if bh.DryRun == nil {
+		bh.DryRun = convert.BoolPtr(true)
+	}
+	if bh.CheckInterval <= 0 {
+		bh.CheckInterval = defaultBasisCheckInterval
+	}
+	if bh.FundingInterval <= 0 {
+		bh.FundingInterval = defaultBasisFundingInterval
+	}
+	if bh.EntryThreshold <= 0 {
+		bh.EntryThreshold = defaultBasisEntryThreshold
+	}
+	if bh.ExitThreshold == 0 || bh.ExitThreshold >= bh.EntryThreshold {
+		bh.ExitThreshold = min(defaultBasisExitThreshold, bh.EntryThreshold/2)
+	}
+	if bh.MaxMarginRatio <= 0 || bh.MaxMarginRatio >= 1 {
+		bh.MaxMarginRatio = defaultBasisMaxMarginRatio
+	}
+	if bh.ReduceFraction <= 0 || bh.ReduceFraction > 1 {
+		bh.ReduceFraction = defaultBasisReduceFraction
+	}
+}
+
+// CheckAnomalyDetectorConfig ensures the anomaly detector config is valid, or
+// sets default values
+func (c *Config) CheckAnomalyDetectorConfig() {
+	m.Lock()
+	defer m.Unlock()
+	ad := &c.AnomalyDetector
+	if ad.MaxDeviation <= 0 {
+		ad.MaxDeviation = defaultAnomalyMaxDeviation
+	}
+	if ad.MinDeviation <= 0 {
+		ad.MinDeviation = defaultAnomalyMinDeviation
+	}
+	if ad.Window <= 1 {
+		ad.Window = defaultAnomalyWindow
+	}
+	if ad.MinSamples <= 1 || ad.MinSamples > ad.Window {
+		ad.MinSamples = min(defaultAnomalyMinSamples, ad.Window)
+	}
+	if ad.StaleAfter <= 0 {
+		ad.StaleAfter = defaultAnomalyStaleAfter
+	}
+	if ad.QuarantinePeriod <= 0 {
+		ad.QuarantinePeriod = defaultAnomalyQuarantinePeriod
+	}
+}
+
+// CheckDataQualityMonitorConfig ensures the data quality monitor config is
+// valid, or sets default values
+func (c *Config) CheckDataQualityMonitorConfig() {
+	m.Lock()
+	defer m.Unlock()
+	dq := &c.DataQuality
+	if dq.Window < time.Minute {
+		dq.Window = defaultDataQualityWindow
+	}
+	if dq.StaleTickThreshold <= 0 {
+		dq.StaleTickThreshold = defaultDataQualityStaleTickThreshold
+	}
+}
+
+// CheckSurveillanceManagerConfig ensures the surveillance manager config is
+// valid, or sets default values
+func (c *Config) CheckSurveillanceManagerConfig() {
+	m.Lock()
+	defer m.Unlock()
+	sm := &c.SurveillanceManager
+	if sm.CheckInterval <= 0 {
+		sm.CheckInterval = defaultSurveillanceCheckInterval
+	}
+	if sm.Window <= 0 {
+		sm.Window = defaultSurveillanceWindow
+	}
+	if sm.MinOrders <= 0 {
+		sm.MinOrders = defaultSurveillanceMinOrders
+	}
+	if sm.MaxCancelRatio <= 0 || sm.MaxCancelRatio > 1 {
+		sm.MaxCancelRatio = defaultSurveillanceMaxCancelRatio
+	}
+	if sm.MaxOrderToFillRatio <= 0 {
+		sm.MaxOrderToFillRatio = defaultSurveillanceMaxOrderToFill
+	}
+	if sm.WashTradeWindow <= 0 {
+		sm.WashTradeWindow = defaultWashTradeWindow
+	}
+	if sm.WashTradePriceTolerance < 0 {
+		sm.WashTradePriceTolerance = defaultWashTradePriceTolerance
+	}
+	if sm.SpoofMaxLifetime <= 0 {
+		sm.SpoofMaxLifetime = defaultSpoofMaxLifetime
+	}
+	if sm.SpoofMinOccurrences <= 0 {
+		sm.SpoofMinOccurrences = defaultSpoofMinOccurrences
+	}
+	if _, err := cron.Parse(sm.ReportSchedule); err != nil {
+		if sm.ReportSchedule != "" {
+			log.Warnf(log.ConfigMgr, "Surveillance manager report schedule invalid, defaulting to @daily: %s", err)
+		}
+		sm.ReportSchedule = "@daily"
+	}
+	if sm.ReportPeriod <= 0 {
+		sm.ReportPeriod = time.Hour * 24
+	}
+}
+
+// CheckFeeAccountingConfig ensures the fee accounting config is valid, or sets
+// default values
+func (c *Config) CheckFeeAccountingConfig() {
+	m.Lock()
+	defer m.Unlock()
+	fa := &c.FeeAccounting
+	if _, err := cron.Parse(fa.Schedule); err != nil {
+		if fa.Schedule != "" {
+			log.Warnf(log.ConfigMgr, "Fee accounting schedule invalid, defaulting to @monthly: %s", err)
+		}
+		fa.Schedule = "@monthly"
+	}
+	for i := range fa.Accounts {
+		a := &fa.Accounts[i]
+		if a.Name == "" {
+			a.Name = "Account " + strconv.Itoa(i+1)
+		}
+		if a.Currency == "" {
+			a.Currency = currency.USDT.String()
+		}
+		if a.ManagementFeeRate < 0 || a.ManagementFeeRate >= 1 {
+			log.Warnf(log.ConfigMgr, "Fee accounting account %s management fee rate %v invalid, defaulting to 0", a.Name, a.ManagementFeeRate)
+			a.ManagementFeeRate = 0
+		}
+		if a.PerformanceFeeRate < 0 || a.PerformanceFeeRate >= 1 {
+			log.Warnf(log.ConfigMgr, "Fee accounting account %s performance fee rate %v invalid, defaulting to 0", a.Name, a.PerformanceFeeRate)
+			a.PerformanceFeeRate = 0
+		}
+	}
+}
+
+// CheckOfflineWithdrawalsConfig ensures the offline withdrawals config is
+// valid, or sets default values
+func (c *Config) CheckOfflineWithdrawalsConfig() {
+	m.Lock()
+	defer m.Unlock()
+	ow := &c.OfflineWithdrawals
+	if ow.Expiry <= 0 {
+		ow.Expiry = defaultOfflineWithdrawalExpiry
+	}
+	keys := ow.PublicKeys[:0]
+	for _, k := range ow.PublicKeys {
+		if b, err := hex.DecodeString(k); err != nil || len(b) != ed25519.PublicKeySize {
+			log.Warnf(log.ConfigMgr, "Offline withdrawals public key %q invalid, ignoring", k)
+			continue
+		}
+		keys = append(keys, k)
+	}
+	ow.PublicKeys = keys
+	for code, amount := range ow.Thresholds {
+		if amount < 0 {
+			log.Warnf(log.ConfigMgr, "Offline withdrawals %s threshold %v invalid, defaulting to 0", code, amount)
+			ow.Thresholds[code] = 0
+		}
+	}
+	if ow.Enabled && len(ow.PublicKeys) == 0 {
+		log.Warnln(log.ConfigMgr, "Offline withdrawals enabled with no valid public keys, disabling")
+		ow.Enabled = false
+	}
+}
+
+// CheckDigestConfig ensures the digest config is valid, or sets default values
+func (c *Config) CheckDigestConfig() {
+	m.Lock()
+	defer m.Unlock()
+	if c.Digest.TopN <= 0 {
+		c.Digest.TopN = defaultDigestTopN
+	}
+	if c.Digest.MaxEvents <= 0 {
+		c.Digest.MaxEvents = defaultDigestMaxEvents
+	}
+	if len(c.Digest.Reports) == 0 {
+		c.Digest.Reports = []DigestReport{
+			{Name: "Daily", Schedule: "@daily", Period: time.Hour * 24},
+			{Name: "Weekly", Schedule: "@weekly", Period: time.Hour * 24 * 7},
+		}
+		return
+	}
+	for i := range c.Digest.Reports {
+		if c.Digest.Reports[i].Name == "" {
+			c.Digest.Reports[i].Name = "Digest " + strconv.Itoa(i+1)
+		}
+		if _, err := cron.Parse(c.Digest.Reports[i].Schedule); err != nil {
+			log.Warnf(log.ConfigMgr, "Digest report %s schedule invalid, defaulting to @daily: %s",
+				c.Digest.Reports[i].Name, err)
+			c.Digest.Reports[i].Schedule = "@daily"
+		}
+		if c.Digest.Reports[i].Period <= 0 {
+			c.Digest.Reports[i].Period = time.Hour * 24
+		}
+	}
+}
+
+// CheckSchedulerConfig ensures the scheduler config is valid, or sets default
+// values
+func (c *Config) CheckSchedulerConfig() {
+	m.Lock()
+	defer m.Unlock()
+	if c.Scheduler.HistoryLimit <= 0 {
+		c.Scheduler.HistoryLimit = defaultSchedulerHistoryLimit
+	}
+	for i := range c.Scheduler.Tasks {
+		t := &c.Scheduler.Tasks[i]
+		if t.Name == "" {
+			t.Name = "Task " + strconv.Itoa(i+1)
+		}
+		if t.Jitter < 0 {
+			log.Warnf(log.ConfigMgr, "Scheduled task %s jitter %v invalid, defaulting to 0", t.Name, t.Jitter)
+			t.Jitter = 0
+		}
+		if t.Timeout < 0 {
+			log.Warnf(log.ConfigMgr, "Scheduled task %s timeout %v invalid, defaulting to none", t.Name, t.Timeout)
+			t.Timeout = 0
+		}
+		t.Overlap = strings.ToLower(t.Overlap)
+		switch t.Overlap {
+		case TaskOverlapSkip, TaskOverlapAllow, TaskOverlapQueue:
+		default:
+			if t.Overlap != "" {
+				log.Warnf(log.ConfigMgr, "Scheduled task %s overlap policy %q invalid, defaulting to %s", t.Name, t.Overlap, TaskOverlapSkip)
+			}
+			t.Overlap = TaskOverlapSkip
+		}
+	}
+}
+
 // CheckOrderManagerConfig ensures the order manager is setup correctly
 func (c *Config) CheckOrderManagerConfig() {
 	m.Lock()
@@ -1560,6 +2103,21 @@ func (c *Config) ReadConfigFromFile(configPath string, dryrun bool) error {
 	// Override values in the current config
 	*c = *result
 
+	if len(c.migrations) > 0 {
+		if dryrun {
+			log.Warnf(log.ConfigMgr, "Config requires upgrading to version %d, dry run will not save the following changes:\n", c.Version)
+			for i := range c.migrations {
+				log.Warnln(log.ConfigMgr, c.migrations[i])
+			}
+		} else {
+			log.Infof(log.ConfigMgr, "Upgraded config to version %d with %d changes\n", c.Version, len(c.migrations))
+			if err := c.SaveConfigToFile(defaultPath); err != nil {
+				return fmt.Errorf("error saving upgraded config %w", err)
+			}
+		}
+		c.migrations = nil
+	}
+
 	if dryrun || wasEncrypted || c.EncryptConfig == fileEncryptionDisabled {
 		return nil
 	}
@@ -1597,9 +2155,12 @@ func ReadConfig(configReader io.Reader, keyProvider func() ([]byte, error)) (*Co
 
 	if !ConfirmECS(pref) {
 		// Read unencrypted configuration
-		decoder := json.NewDecoder(reader)
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, false, err
+		}
 		c := &Config{}
-		err = decoder.Decode(c)
+		err = c.decode(data)
 		return c, false, err
 	}
 
@@ -1638,10 +2199,25 @@ func readEncryptedConf(reader io.Reader, key []byte) (*Config, error) {
 		return nil, err
 	}
 
-	err = json.Unmarshal(data, c)
+	err = c.decode(data)
 	return c, err
 }
 
+// decode upgrades JSON config data to the latest config version and decodes
+// it into the config, recording the changes made by the upgrade
+func (c *Config) decode(data []byte) error {
+	upgraded, err := versions.Manager.Deploy(context.Background(), data)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(data, upgraded) {
+		if c.migrations, err = versions.Diff(data, upgraded); err != nil {
+			return err
+		}
+	}
+	return json.Unmarshal(upgraded, c)
+}
+
 // SaveConfigToFile saves your configuration to your desired path as a JSON object.
 // The function encrypts the data and prompts for encryption key, if necessary
 func (c *Config) SaveConfigToFile(configPath string) error {
@@ -1702,49 +2278,6 @@ func (c *Config) Save(writerProvider func() (io.Writer, error), keyProvider func
 	return err
 }
 
-// CheckRemoteControlConfig checks to see if the old c.Webserver field is used
-// and migrates the existing settings to the new RemoteControl struct
-func (c *Config) CheckRemoteControlConfig() {
-	m.Lock()
-	defer m.Unlock()
-
-	if c.Webserver != nil {
-		port := common.ExtractPort(c.Webserver.ListenAddress)
-		host := common.ExtractHost(c.Webserver.ListenAddress)
-
-		c.RemoteControl = RemoteControlConfig{
-			Username: c.Webserver.AdminUsername,
-			Password: c.Webserver.AdminPassword,
-
-			DeprecatedRPC: DepcrecatedRPCConfig{
-				Enabled:       c.Webserver.Enabled,
-				ListenAddress: host + ":" + strconv.Itoa(port),
-			},
-		}
-
-		port++
-		c.RemoteControl.WebsocketRPC = WebsocketRPCConfig{
-			Enabled:             c.Webserver.Enabled,
-			ListenAddress:       host + ":" + strconv.Itoa(port),
-			ConnectionLimit:     c.Webserver.WebsocketConnectionLimit,
-			MaxAuthFailures:     c.Webserver.WebsocketMaxAuthFailures,
-			AllowInsecureOrigin: c.Webserver.WebsocketAllowInsecureOrigin,
-		}
-
-		port++
-		gRPCProxyPort := port + 1
-		c.RemoteControl.GRPC = GRPCConfig{
-			Enabled:                c.Webserver.Enabled,
-			ListenAddress:          host + ":" + strconv.Itoa(port),
-			GRPCProxyEnabled:       c.Webserver.Enabled,
-			GRPCProxyListenAddress: host + ":" + strconv.Itoa(gRPCProxyPort),
-		}
-
-		// Then flush the old webserver settings
-		c.Webserver = nil
-	}
-}
-
 // CheckConfig checks all config settings
 func (c *Config) CheckConfig() error {
 	err := c.CheckLoggerConfig()
@@ -1776,11 +2309,33 @@ func (c *Config) CheckConfig() error {
 	c.CheckConnectionMonitorConfig()
 	c.CheckDataHistoryMonitorConfig()
 	c.CheckCurrencyStateManager()
+	c.CheckDigestConfig()
+	c.CheckSchedulerConfig()
+	c.CheckFillSyncManagerConfig()
+	c.CheckWebhookListenerConfig()
+	c.CheckPairRefreshManagerConfig()
+	c.CheckEarnManagerConfig()
+	c.CheckLendingOptimizerConfig()
+	c.CheckMemoryManagerConfig()
+	c.CheckRolloverManagerConfig()
+	c.CheckCalendarSpreadManagerConfig()
+	c.CheckVolatilitySurfaceManagerConfig()
+	c.CheckMarginMonitorConfig()
+	c.CheckADLMonitorConfig()
+	c.CheckExchangeCalendarConfig()
+	c.CheckMaintenanceSchedulerConfig()
+	c.CheckBasisHarvesterConfig()
+	c.CheckAnomalyDetectorConfig()
+	c.CheckDataQualityMonitorConfig()
+	c.CheckSurveillanceManagerConfig()
+	c.CheckFeeAccountingConfig()
+	c.CheckOfflineWithdrawalsConfig()
+	c.CheckLatencySimulationConfig()
+	c.CheckCircuitBreakerConfig()
 	c.CheckOrderManagerConfig()
 	c.CheckCommunicationsConfig()
 	c.CheckClientBankAccounts()
 	c.CheckBankAccountConfig()
-	c.CheckRemoteControlConfig()
 	c.CheckSyncManagerConfig()
 
 	err = c.CheckCurrencyConfigValues()
@@ -1824,7 +2379,6 @@ func (c *Config) UpdateConfig(configPath string, newCfg *Config, dryrun bool) er
 	c.GlobalHTTPTimeout = newCfg.GlobalHTTPTimeout
 	c.Portfolio = newCfg.Portfolio
 	c.Communications = newCfg.Communications
-	c.Webserver = newCfg.Webserver
 	c.Exchanges = newCfg.Exchanges
 
 	if !dryrun {
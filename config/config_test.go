@@ -10,10 +10,12 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/common/convert"
 	"github.com/thrasher-corp/gocryptotrader/common/file"
 	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config/versions"
 	"github.com/thrasher-corp/gocryptotrader/connchecker"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/database"
@@ -1308,24 +1310,6 @@ func TestCheckExchangeConfigValues(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	cfg.Exchanges[0].Name = "GDAX"
-	err = cfg.CheckExchangeConfigValues()
-	if err != nil {
-		t.Error(err)
-	}
-	if cfg.Exchanges[0].Name != "CoinbasePro" {
-		t.Error("exchange name should have been updated from GDAX to CoinbasePRo")
-	}
-
-	cfg.Exchanges[0].Name = "OKCOIN International"
-	err = cfg.CheckExchangeConfigValues()
-	if err != nil {
-		t.Error(err)
-	}
-	if cfg.Exchanges[0].Name != "Okcoin" {
-		t.Error("exchange name should have been updated from 'OKCOIN International' to 'Okcoin'")
-	}
-
 	// Test API settings migration
 	sptr := func(s string) *string { return &s }
 	int64ptr := func(i int64) *int64 { return &i }
@@ -1695,6 +1679,23 @@ func TestReadConfigFromReader(t *testing.T) {
 	}
 }
 
+func TestReadConfigUpgrade(t *testing.T) {
+	t.Parallel()
+	conf, _, err := ReadConfig(strings.NewReader(`{"name":"test","exchanges":[{"name":"GDAX"}]}`), Unencrypted)
+	require.NoError(t, err, "ReadConfig must not error")
+	assert.Equal(t, versions.Manager.Latest(), conf.Version, "Version should be upgraded to the latest")
+	require.Len(t, conf.Exchanges, 1)
+	assert.Equal(t, "CoinbasePro", conf.Exchanges[0].Name, "exchange should be renamed")
+	assert.NotEmpty(t, conf.migrations, "upgrade changes should be recorded")
+
+	conf, _, err = ReadConfig(strings.NewReader(`{"version":1,"name":"test"}`), Unencrypted)
+	require.NoError(t, err, "ReadConfig must not error")
+	assert.Empty(t, conf.migrations, "an up to date config should not be changed")
+
+	_, _, err = ReadConfig(strings.NewReader(`{"version":1000,"name":"test"}`), Unencrypted)
+	assert.Error(t, err, "ReadConfig should error on an unknown version")
+}
+
 func TestLoadConfig(t *testing.T) {
 	cfg := &Config{}
 	err := cfg.LoadConfig(TestFile, true)
@@ -1784,44 +1785,6 @@ func TestGetFilePath(t *testing.T) {
 	}
 }
 
-func TestCheckRemoteControlConfig(t *testing.T) {
-	t.Parallel()
-
-	var c Config
-	c.Webserver = &WebserverConfig{
-		Enabled:                      true,
-		AdminUsername:                "satoshi",
-		AdminPassword:                "ultrasecurepassword",
-		ListenAddress:                ":9050",
-		WebsocketConnectionLimit:     5,
-		WebsocketMaxAuthFailures:     10,
-		WebsocketAllowInsecureOrigin: true,
-	}
-
-	c.CheckRemoteControlConfig()
-
-	if c.RemoteControl.Username != "satoshi" ||
-		c.RemoteControl.Password != "ultrasecurepassword" ||
-		!c.RemoteControl.GRPC.Enabled ||
-		c.RemoteControl.GRPC.ListenAddress != "localhost:9052" ||
-		!c.RemoteControl.GRPC.GRPCProxyEnabled ||
-		c.RemoteControl.GRPC.GRPCProxyListenAddress != "localhost:9053" ||
-		!c.RemoteControl.DeprecatedRPC.Enabled ||
-		c.RemoteControl.DeprecatedRPC.ListenAddress != "localhost:9050" ||
-		!c.RemoteControl.WebsocketRPC.Enabled ||
-		c.RemoteControl.WebsocketRPC.ListenAddress != "localhost:9051" ||
-		!c.RemoteControl.WebsocketRPC.AllowInsecureOrigin ||
-		c.RemoteControl.WebsocketRPC.ConnectionLimit != 5 ||
-		c.RemoteControl.WebsocketRPC.MaxAuthFailures != 10 {
-		t.Error("unexpected results")
-	}
-
-	// Now test to ensure the previous settings are flushed
-	if c.Webserver != nil {
-		t.Error("old webserver settings should be nil")
-	}
-}
-
 func TestCheckConfig(t *testing.T) {
 	t.Parallel()
 	cp1 := currency.NewPair(currency.DOGE, currency.XRP)
@@ -1925,6 +1888,14 @@ func TestCheckLoggerConfig(t *testing.T) {
 		*c.Logging.AdvancedSettings.ShowLogSystemName {
 		t.Error("unexpected result")
 	}
+
+	c.Logging.LoggerFileConfig.RotateInterval = -time.Hour
+	c.Logging.LoggerFileConfig.MaxBackups = -1
+	c.Logging.RemoteSettings = &log.RemoteConfig{Loki: &log.LokiConfig{}}
+	require.NoError(t, c.CheckLoggerConfig())
+	assert.Zero(t, c.Logging.LoggerFileConfig.RotateInterval, "invalid rotation intervals should be disabled")
+	assert.Zero(t, c.Logging.LoggerFileConfig.MaxBackups, "invalid max backups should keep all rotated files")
+	assert.Nil(t, c.Logging.RemoteSettings.Loki, "loki shipping without a url should be disabled")
 }
 
 func TestDisableNTPCheck(t *testing.T) {
@@ -1998,6 +1969,12 @@ func TestCheckDatabaseConfig(t *testing.T) {
 	if err := c.checkDatabaseConfig(); err != nil {
 		t.Error(err)
 	}
+	assert.Equal(t, database.DefaultSQLiteJournalMode, c.Database.SQLite.JournalMode, "journal mode should default")
+
+	c.Database.SQLite.JournalMode = "bad"
+	err := c.checkDatabaseConfig()
+	assert.ErrorIs(t, err, database.ErrInvalidSQLiteOption)
+	assert.False(t, c.Database.Enabled, "database should be disabled on invalid SQLite options")
 }
 
 func TestCheckNTPConfig(t *testing.T) {
@@ -2316,3 +2293,402 @@ func TestCheckSyncManagerConfig(t *testing.T) {
 		t.Errorf("received %v expected %v", c.SyncManagerConfig.NumWorkers, DefaultSyncerWorkers)
 	}
 }
+
+func TestCheckDigestConfig(t *testing.T) {
+	t.Parallel()
+	c := Config{}
+	c.CheckDigestConfig()
+	assert.Equal(t, defaultDigestTopN, c.Digest.TopN, "TopN should default")
+	assert.Equal(t, defaultDigestMaxEvents, c.Digest.MaxEvents, "MaxEvents should default")
+	require.Len(t, c.Digest.Reports, 2, "daily and weekly reports should be added by default")
+	assert.Equal(t, "@daily", c.Digest.Reports[0].Schedule)
+	assert.Equal(t, "@weekly", c.Digest.Reports[1].Schedule)
+
+	c.Digest.Reports = []DigestReport{{Schedule: "bad"}}
+	c.CheckDigestConfig()
+	require.Len(t, c.Digest.Reports, 1)
+	assert.Equal(t, "Digest 1", c.Digest.Reports[0].Name, "Name should default")
+	assert.Equal(t, "@daily", c.Digest.Reports[0].Schedule, "invalid schedule should default")
+	assert.Equal(t, time.Hour*24, c.Digest.Reports[0].Period, "Period should default")
+}
+
+func TestCheckSchedulerConfig(t *testing.T) {
+	t.Parallel()
+	c := Config{}
+	c.CheckSchedulerConfig()
+	assert.Equal(t, defaultSchedulerHistoryLimit, c.Scheduler.HistoryLimit, "HistoryLimit should default")
+
+	c.Scheduler.Tasks = []ScheduledTask{
+		{Jitter: -time.Second, Timeout: -time.Second, Overlap: "bad"},
+		{Name: "queued", Overlap: "QUEUE"},
+	}
+	c.CheckSchedulerConfig()
+	assert.Equal(t, "Task 1", c.Scheduler.Tasks[0].Name, "Name should default")
+	assert.Zero(t, c.Scheduler.Tasks[0].Jitter, "invalid Jitter should default")
+	assert.Zero(t, c.Scheduler.Tasks[0].Timeout, "invalid Timeout should default")
+	assert.Equal(t, TaskOverlapSkip, c.Scheduler.Tasks[0].Overlap, "invalid Overlap should default")
+	assert.Equal(t, TaskOverlapQueue, c.Scheduler.Tasks[1].Overlap, "Overlap should be case insensitive")
+}
+
+func TestCheckFillSyncManagerConfig(t *testing.T) {
+	t.Parallel()
+	c := Config{}
+	c.CheckFillSyncManagerConfig()
+	assert.Equal(t, defaultFillSyncInterval, c.FillSyncManager.SyncInterval, "SyncInterval should default")
+	assert.Equal(t, defaultFillSyncLookback, c.FillSyncManager.Lookback, "Lookback should default")
+
+	c.FillSyncManager.SyncInterval = time.Hour
+	c.CheckFillSyncManagerConfig()
+	assert.Equal(t, time.Hour, c.FillSyncManager.SyncInterval, "valid SyncInterval should be retained")
+}
+
+func TestCheckWebhookListenerConfig(t *testing.T) {
+	t.Parallel()
+	c := Config{WebhookListener: WebhookListener{Sources: []WebhookSource{{Name: "custodian"}, {Name: "broker", Exchange: "Broker", Format: "custom"}}}}
+	c.CheckWebhookListenerConfig()
+	assert.Equal(t, defaultWebhookListenAddress, c.WebhookListener.ListenAddress, "ListenAddress should default")
+	assert.Equal(t, int64(defaultWebhookMaxBodySize), c.WebhookListener.MaxBodySize, "MaxBodySize should default")
+	assert.Equal(t, WebhookSource{Name: "custodian", Exchange: "custodian", Format: defaultWebhookFormat}, c.WebhookListener.Sources[0], "source Exchange and Format should default")
+	assert.Equal(t, WebhookSource{Name: "broker", Exchange: "Broker", Format: "custom"}, c.WebhookListener.Sources[1], "source Exchange and Format should be retained")
+}
+
+func TestCheckPairRefreshManagerConfig(t *testing.T) {
+	t.Parallel()
+	c := Config{}
+	c.CheckPairRefreshManagerConfig()
+	assert.Equal(t, defaultPairRefreshInterval, c.PairRefreshManager.Interval, "Interval should default")
+
+	c.PairRefreshManager.Interval = time.Minute
+	c.CheckPairRefreshManagerConfig()
+	assert.Equal(t, time.Minute, c.PairRefreshManager.Interval, "valid Interval should be retained")
+}
+
+func TestCheckEarnManagerConfig(t *testing.T) {
+	t.Parallel()
+	c := Config{}
+	c.CheckEarnManagerConfig()
+	assert.Equal(t, defaultEarnSyncInterval, c.EarnManager.SyncInterval, "SyncInterval should default")
+	assert.Equal(t, defaultEarnLookback, c.EarnManager.Lookback, "Lookback should default")
+
+	c.EarnManager.SyncInterval = time.Minute
+	c.EarnManager.Lookback = time.Hour
+	c.CheckEarnManagerConfig()
+	assert.Equal(t, time.Minute, c.EarnManager.SyncInterval, "valid SyncInterval should be retained")
+	assert.Equal(t, time.Hour, c.EarnManager.Lookback, "valid Lookback should be retained")
+}
+
+func TestCheckLendingOptimizerConfig(t *testing.T) {
+	t.Parallel()
+	c := Config{}
+	c.LendingOptimizer.MaxTransferAmount = -1
+	c.LendingOptimizer.Reserve = -1
+	c.CheckLendingOptimizerConfig()
+	lo := &c.LendingOptimizer
+	require.NotNil(t, lo.DryRun, "DryRun must be set")
+	assert.True(t, *lo.DryRun, "DryRun should default to true")
+	assert.Equal(t, defaultLendingCheckInterval, lo.CheckInterval, "CheckInterval should default")
+	assert.Equal(t, []string{"USDT", "USDC"}, lo.Currencies, "Currencies should default")
+	assert.Equal(t, defaultLendingMinRateImprovement, lo.MinRateImprovement, "MinRateImprovement should default")
+	assert.Equal(t, float64(defaultLendingMinAmount), lo.MinAmount, "MinAmount should default")
+	assert.Zero(t, lo.MaxTransferAmount, "negative MaxTransferAmount should be reset")
+	assert.Zero(t, lo.Reserve, "negative Reserve should be reset")
+
+	lo.DryRun = convert.BoolPtr(false)
+	lo.CheckInterval = time.Minute
+	lo.Currencies = []string{"DAI"}
+	lo.MinRateImprovement = 0.02
+	lo.MinAmount = 10
+	c.CheckLendingOptimizerConfig()
+	assert.False(t, *lo.DryRun, "DryRun should be retained")
+	assert.Equal(t, time.Minute, lo.CheckInterval, "valid CheckInterval should be retained")
+	assert.Equal(t, []string{"DAI"}, lo.Currencies, "Currencies should be retained")
+	assert.Equal(t, 0.02, lo.MinRateImprovement, "valid MinRateImprovement should be retained")
+	assert.Equal(t, 10.0, lo.MinAmount, "valid MinAmount should be retained")
+}
+
+func TestCheckMemoryManagerConfig(t *testing.T) {
+	t.Parallel()
+	c := Config{}
+	c.MemoryManager.OrderbookBudgetMB = -1
+	c.MemoryManager.TickerBudgetMB = -1
+	c.MemoryManager.TradeBufferBudgetMB = -1
+	c.CheckMemoryManagerConfig()
+	mm := &c.MemoryManager
+	assert.Equal(t, defaultMemoryCheckInterval, mm.CheckInterval, "CheckInterval should default")
+	assert.Zero(t, mm.OrderbookBudgetMB, "negative OrderbookBudgetMB should be reset")
+	assert.Zero(t, mm.TickerBudgetMB, "negative TickerBudgetMB should be reset")
+	assert.Zero(t, mm.TradeBufferBudgetMB, "negative TradeBufferBudgetMB should be reset")
+
+	mm.CheckInterval = time.Hour
+	mm.OrderbookBudgetMB = 256
+	c.CheckMemoryManagerConfig()
+	assert.Equal(t, time.Hour, mm.CheckInterval, "valid CheckInterval should be retained")
+	assert.Equal(t, int64(256), mm.OrderbookBudgetMB, "valid OrderbookBudgetMB should be retained")
+}
+
+func TestCheckRolloverManagerConfig(t *testing.T) {
+	t.Parallel()
+	c := Config{}
+	c.CheckRolloverManagerConfig()
+	require.NotNil(t, c.RolloverManager.DryRun, "DryRun must be set")
+	assert.True(t, *c.RolloverManager.DryRun, "DryRun should default to true")
+	assert.Equal(t, defaultRolloverCheckInterval, c.RolloverManager.CheckInterval, "CheckInterval should default")
+	assert.Equal(t, defaultRolloverWindow, c.RolloverManager.RolloverWindow, "RolloverWindow should default")
+	assert.Equal(t, defaultRolloverMaxSpread, c.RolloverManager.MaxSpread, "MaxSpread should default")
+
+	c.RolloverManager.DryRun = convert.BoolPtr(false)
+	c.RolloverManager.MaxSpread = 0.05
+	c.CheckRolloverManagerConfig()
+	assert.False(t, *c.RolloverManager.DryRun, "DryRun should be retained")
+	assert.Equal(t, 0.05, c.RolloverManager.MaxSpread, "valid MaxSpread should be retained")
+}
+
+func TestCheckCalendarSpreadManagerConfig(t *testing.T) {
+	t.Parallel()
+	c := Config{}
+	c.CheckCalendarSpreadManagerConfig()
+	assert.Equal(t, defaultSpreadCheckInterval, c.CalendarSpread.CheckInterval, "CheckInterval should default")
+	assert.Equal(t, defaultSpreadHistorySize, c.CalendarSpread.HistorySize, "HistorySize should default")
+	assert.Equal(t, defaultSpreadMinSamples, c.CalendarSpread.MinSamples, "MinSamples should default")
+	assert.Equal(t, float64(defaultSpreadZScoreThreshold), c.CalendarSpread.ZScoreThreshold, "ZScoreThreshold should default")
+
+	c.CalendarSpread.HistorySize = 10
+	c.CalendarSpread.MinSamples = 20
+	c.CheckCalendarSpreadManagerConfig()
+	assert.Equal(t, 10, c.CalendarSpread.HistorySize, "valid HistorySize should be retained")
+	assert.Equal(t, 10, c.CalendarSpread.MinSamples, "MinSamples should not exceed HistorySize")
+}
+
+func TestCheckVolatilitySurfaceManagerConfig(t *testing.T) {
+	t.Parallel()
+	c := Config{}
+	c.CheckVolatilitySurfaceManagerConfig()
+	vs := &c.VolatilitySurface
+	assert.Equal(t, defaultSurfaceCheckInterval, vs.CheckInterval, "CheckInterval should default")
+	assert.Equal(t, defaultSurfaceInterpolation, vs.Interpolation, "Interpolation should default")
+	assert.Equal(t, defaultSurfaceHistorySize, vs.HistorySize, "HistorySize should default")
+
+	vs.Interpolation = "spline"
+	vs.HistorySize = 10
+	c.CheckVolatilitySurfaceManagerConfig()
+	assert.Equal(t, "spline", vs.Interpolation, "valid Interpolation should be retained")
+	assert.Equal(t, 10, vs.HistorySize, "valid HistorySize should be retained")
+}
+
+func TestCheckLatencySimulationConfig(t *testing.T) {
+	t.Parallel()
+	c := Config{
+		LatencySimulation: LatencySimulation{
+			RESTLatency:      time.Millisecond * 200,
+			RESTJitter:       -time.Millisecond,
+			WebsocketLatency: -time.Second,
+			WebsocketJitter:  time.Millisecond * 50,
+		},
+	}
+	c.CheckLatencySimulationConfig()
+	assert.Equal(t, time.Millisecond*200, c.LatencySimulation.RESTLatency, "valid RESTLatency should be retained")
+	assert.Zero(t, c.LatencySimulation.RESTJitter, "negative RESTJitter should be reset")
+	assert.Zero(t, c.LatencySimulation.WebsocketLatency, "negative WebsocketLatency should be reset")
+	assert.Equal(t, time.Millisecond*50, c.LatencySimulation.WebsocketJitter, "valid WebsocketJitter should be retained")
+}
+
+func TestCheckCircuitBreakerConfig(t *testing.T) {
+	t.Parallel()
+	c := Config{CircuitBreaker: CircuitBreaker{PublicFailureThreshold: 10, PrivateFailureThreshold: -1}}
+	c.CheckCircuitBreakerConfig()
+	assert.Equal(t, 10, c.CircuitBreaker.PublicFailureThreshold, "valid PublicFailureThreshold should be retained")
+	assert.Equal(t, defaultCircuitFailureThreshold, c.CircuitBreaker.PrivateFailureThreshold, "invalid PrivateFailureThreshold should be defaulted")
+	assert.Equal(t, defaultCircuitCooldown, c.CircuitBreaker.Cooldown, "unset Cooldown should be defaulted")
+}
+
+func TestCheckMarginMonitorConfig(t *testing.T) {
+	t.Parallel()
+	c := Config{}
+	c.CheckMarginMonitorConfig()
+	assert.Equal(t, defaultMarginInitialThreshold, c.MarginMonitor.InitialMarginThreshold, "InitialMarginThreshold should default")
+	assert.Equal(t, defaultMarginWarningThreshold, c.MarginMonitor.WarningThreshold, "WarningThreshold should default")
+	assert.Equal(t, defaultMarginCriticalThreshold, c.MarginMonitor.CriticalThreshold, "CriticalThreshold should default")
+	require.NotNil(t, c.MarginMonitor.Deleverage.DryRun, "DryRun must be set")
+	assert.True(t, *c.MarginMonitor.Deleverage.DryRun, "DryRun should default to true")
+	assert.Equal(t, defaultDeleverageReduceFraction, c.MarginMonitor.Deleverage.ReduceFraction, "ReduceFraction should default")
+	assert.Equal(t, defaultDeleverageCooldown, c.MarginMonitor.Deleverage.Cooldown, "Cooldown should default")
+
+	c.MarginMonitor.CriticalThreshold = 0.4
+	c.MarginMonitor.WarningThreshold = 0.6
+	c.MarginMonitor.Deleverage.ReduceFraction = 2
+	c.CheckMarginMonitorConfig()
+	assert.Equal(t, 0.4, c.MarginMonitor.CriticalThreshold, "valid CriticalThreshold should be retained")
+	assert.Equal(t, 0.4, c.MarginMonitor.WarningThreshold, "WarningThreshold should not exceed CriticalThreshold")
+	assert.Equal(t, defaultDeleverageReduceFraction, c.MarginMonitor.Deleverage.ReduceFraction, "ReduceFraction above 1 should default")
+}
+
+func TestCheckADLMonitorConfig(t *testing.T) {
+	t.Parallel()
+	c := Config{}
+	c.CheckADLMonitorConfig()
+	assert.Equal(t, defaultADLCheckInterval, c.ADLMonitor.CheckInterval, "CheckInterval should default")
+	assert.Equal(t, defaultADLWarningThreshold, c.ADLMonitor.WarningThreshold, "WarningThreshold should default")
+	assert.Equal(t, defaultADLCriticalThreshold, c.ADLMonitor.CriticalThreshold, "CriticalThreshold should default")
+
+	c.ADLMonitor.CriticalThreshold = 0.4
+	c.ADLMonitor.WarningThreshold = 0.5
+	c.CheckADLMonitorConfig()
+	assert.Equal(t, 0.4, c.ADLMonitor.CriticalThreshold, "valid CriticalThreshold should be retained")
+	assert.Equal(t, 0.4, c.ADLMonitor.WarningThreshold, "WarningThreshold should not exceed CriticalThreshold")
+
+	c.ADLMonitor.CriticalThreshold = 2
+	c.CheckADLMonitorConfig()
+	assert.Equal(t, defaultADLCriticalThreshold, c.ADLMonitor.CriticalThreshold, "CriticalThreshold above 1 should default")
+}
+
+func TestCheckExchangeCalendarConfig(t *testing.T) {
+	t.Parallel()
+	c := Config{}
+	c.CheckExchangeCalendarConfig()
+	assert.Equal(t, defaultCalendarRefreshInterval, c.ExchangeCalendar.RefreshInterval, "RefreshInterval should default")
+	assert.Equal(t, defaultCalendarHorizon, c.ExchangeCalendar.Horizon, "Horizon should default")
+
+	c.ExchangeCalendar.RefreshInterval = time.Minute
+	c.ExchangeCalendar.Horizon = -time.Hour
+	c.CheckExchangeCalendarConfig()
+	assert.Equal(t, time.Minute, c.ExchangeCalendar.RefreshInterval, "valid RefreshInterval should be retained")
+	assert.Equal(t, defaultCalendarHorizon, c.ExchangeCalendar.Horizon, "negative Horizon should default")
+}
+
+func TestCheckMaintenanceSchedulerConfig(t *testing.T) {
+	t.Parallel()
+	c := Config{}
+	c.CheckMaintenanceSchedulerConfig()
+	assert.Equal(t, defaultMaintenanceCheckInterval, c.MaintenanceScheduler.CheckInterval, "CheckInterval should default")
+	assert.Equal(t, defaultMaintenanceReconnectInterval, c.MaintenanceScheduler.ReconnectInterval, "ReconnectInterval should default")
+
+	c.MaintenanceScheduler.CheckInterval = time.Minute
+	c.MaintenanceScheduler.ReconnectInterval = -time.Second
+	c.CheckMaintenanceSchedulerConfig()
+	assert.Equal(t, time.Minute, c.MaintenanceScheduler.CheckInterval, "valid CheckInterval should be retained")
+	assert.Equal(t, defaultMaintenanceReconnectInterval, c.MaintenanceScheduler.ReconnectInterval, "negative ReconnectInterval should default")
+}
+
+func TestCheckBasisHarvesterConfig(t *testing.T) {
+	t.Parallel()
+	c := Config{}
+	c.CheckBasisHarvesterConfig()
+	require.NotNil(t, c.BasisHarvester.DryRun, "DryRun must default")
+	assert.True(t, *c.BasisHarvester.DryRun, "DryRun should default to true")
+	assert.Equal(t, defaultBasisCheckInterval, c.BasisHarvester.CheckInterval, "CheckInterval should default")
+	assert.Equal(t, defaultBasisFundingInterval, c.BasisHarvester.FundingInterval, "FundingInterval should default")
+	assert.Equal(t, defaultBasisEntryThreshold, c.BasisHarvester.EntryThreshold, "EntryThreshold should default")
+	assert.Equal(t, defaultBasisExitThreshold, c.BasisHarvester.ExitThreshold, "ExitThreshold should default")
+	assert.Equal(t, defaultBasisMaxMarginRatio, c.BasisHarvester.MaxMarginRatio, "MaxMarginRatio should default")
+	assert.Equal(t, defaultBasisReduceFraction, c.BasisHarvester.ReduceFraction, "ReduceFraction should default")
+
+	c.BasisHarvester.DryRun = convert.BoolPtr(false)
+	c.BasisHarvester.EntryThreshold = 0.02
+	c.BasisHarvester.ExitThreshold = -0.01
+	c.BasisHarvester.MaxMarginRatio = 1.5
+	c.CheckBasisHarvesterConfig()
+	assert.False(t, *c.BasisHarvester.DryRun, "DryRun should be retained")
+	assert.Equal(t, 0.02, c.BasisHarvester.EntryThreshold, "valid EntryThreshold should be retained")
+	assert.Equal(t, -0.01, c.BasisHarvester.ExitThreshold, "a negative ExitThreshold should be retained")
+	assert.Equal(t, defaultBasisMaxMarginRatio, c.BasisHarvester.MaxMarginRatio, "MaxMarginRatio above 1 should default")
+
+	c.BasisHarvester.ExitThreshold = 0.05
+	c.CheckBasisHarvesterConfig()
+	assert.Equal(t, 0.01, c.BasisHarvester.ExitThreshold, "ExitThreshold above EntryThreshold should default below it")
+}
+
+func TestCheckAnomalyDetectorConfig(t *testing.T) {
+	t.Parallel()
+	c := Config{}
+	c.CheckAnomalyDetectorConfig()
+	assert.Equal(t, float64(defaultAnomalyMaxDeviation), c.AnomalyDetector.MaxDeviation, "MaxDeviation should default")
+	assert.Equal(t, defaultAnomalyMinDeviation, c.AnomalyDetector.MinDeviation, "MinDeviation should default")
+	assert.Equal(t, defaultAnomalyWindow, c.AnomalyDetector.Window, "Window should default")
+	assert.Equal(t, defaultAnomalyMinSamples, c.AnomalyDetector.MinSamples, "MinSamples should default")
+	assert.Equal(t, defaultAnomalyStaleAfter, c.AnomalyDetector.StaleAfter, "StaleAfter should default")
+	assert.Equal(t, defaultAnomalyQuarantinePeriod, c.AnomalyDetector.QuarantinePeriod, "QuarantinePeriod should default")
+
+	c.AnomalyDetector.Window = 10
+	c.AnomalyDetector.MinSamples = 50
+	c.CheckAnomalyDetectorConfig()
+	assert.Equal(t, 10, c.AnomalyDetector.Window, "valid Window should be retained")
+	assert.Equal(t, 10, c.AnomalyDetector.MinSamples, "MinSamples should not exceed Window")
+}
+
+func TestCheckDataQualityMonitorConfig(t *testing.T) {
+	t.Parallel()
+	c := Config{}
+	c.CheckDataQualityMonitorConfig()
+	assert.Equal(t, defaultDataQualityWindow, c.DataQuality.Window, "Window should default")
+	assert.Equal(t, defaultDataQualityStaleTickThreshold, c.DataQuality.StaleTickThreshold, "StaleTickThreshold should default")
+
+	c.DataQuality.Window = time.Minute
+	c.CheckDataQualityMonitorConfig()
+	assert.Equal(t, time.Minute, c.DataQuality.Window, "valid Window should be retained")
+}
+
+func TestCheckSurveillanceManagerConfig(t *testing.T) {
+	t.Parallel()
+	c := Config{}
+	c.CheckSurveillanceManagerConfig()
+	assert.Equal(t, defaultSurveillanceCheckInterval, c.SurveillanceManager.CheckInterval, "CheckInterval should default")
+	assert.Equal(t, defaultSurveillanceWindow, c.SurveillanceManager.Window, "Window should default")
+	assert.Equal(t, defaultSurveillanceMinOrders, c.SurveillanceManager.MinOrders, "MinOrders should default")
+	assert.Equal(t, defaultSurveillanceMaxCancelRatio, c.SurveillanceManager.MaxCancelRatio, "MaxCancelRatio should default")
+	assert.Equal(t, float64(defaultSurveillanceMaxOrderToFill), c.SurveillanceManager.MaxOrderToFillRatio, "MaxOrderToFillRatio should default")
+	assert.Equal(t, defaultWashTradeWindow, c.SurveillanceManager.WashTradeWindow, "WashTradeWindow should default")
+	assert.Zero(t, c.SurveillanceManager.WashTradePriceTolerance, "a zero price tolerance should be retained for exact matches")
+	assert.Equal(t, defaultSpoofMaxLifetime, c.SurveillanceManager.SpoofMaxLifetime, "SpoofMaxLifetime should default")
+	assert.Equal(t, defaultSpoofMinOccurrences, c.SurveillanceManager.SpoofMinOccurrences, "SpoofMinOccurrences should default")
+	assert.Equal(t, "@daily", c.SurveillanceManager.ReportSchedule, "ReportSchedule should default")
+	assert.Equal(t, time.Hour*24, c.SurveillanceManager.ReportPeriod, "ReportPeriod should default")
+
+	c.SurveillanceManager.MaxCancelRatio = 2
+	c.SurveillanceManager.WashTradePriceTolerance = -1
+	c.SurveillanceManager.ReportSchedule = "bad"
+	c.CheckSurveillanceManagerConfig()
+	assert.Equal(t, defaultSurveillanceMaxCancelRatio, c.SurveillanceManager.MaxCancelRatio, "MaxCancelRatio above 1 should default")
+	assert.Equal(t, defaultWashTradePriceTolerance, c.SurveillanceManager.WashTradePriceTolerance, "negative WashTradePriceTolerance should default")
+	assert.Equal(t, "@daily", c.SurveillanceManager.ReportSchedule, "invalid ReportSchedule should default")
+}
+
+func TestCheckFeeAccountingConfig(t *testing.T) {
+	t.Parallel()
+	c := Config{FeeAccounting: FeeAccounting{Accounts: []ManagedAccount{
+		{ManagementFeeRate: 0.02, PerformanceFeeRate: 0.2},
+		{Name: "bad", Currency: "USD", ManagementFeeRate: -1, PerformanceFeeRate: 1},
+	}}}
+	c.CheckFeeAccountingConfig()
+	assert.Equal(t, "@monthly", c.FeeAccounting.Schedule, "Schedule should default")
+	assert.Equal(t, "Account 1", c.FeeAccounting.Accounts[0].Name, "Name should default")
+	assert.Equal(t, "USDT", c.FeeAccounting.Accounts[0].Currency, "Currency should default")
+	assert.Equal(t, 0.02, c.FeeAccounting.Accounts[0].ManagementFeeRate, "valid ManagementFeeRate should be retained")
+	assert.Equal(t, 0.2, c.FeeAccounting.Accounts[0].PerformanceFeeRate, "valid PerformanceFeeRate should be retained")
+	assert.Equal(t, "USD", c.FeeAccounting.Accounts[1].Currency, "Currency should be retained")
+	assert.Zero(t, c.FeeAccounting.Accounts[1].ManagementFeeRate, "negative ManagementFeeRate should default")
+	assert.Zero(t, c.FeeAccounting.Accounts[1].PerformanceFeeRate, "PerformanceFeeRate of 1 or more should default")
+
+	c.FeeAccounting.Schedule = "bad"
+	c.CheckFeeAccountingConfig()
+	assert.Equal(t, "@monthly", c.FeeAccounting.Schedule, "invalid Schedule should default")
+}
+
+func TestCheckOfflineWithdrawalsConfig(t *testing.T) {
+	t.Parallel()
+	key := strings.Repeat("ab", 32)
+	c := Config{OfflineWithdrawals: OfflineWithdrawals{
+		Enabled:    true,
+		PublicKeys: []string{"bad", key, strings.Repeat("ab", 16)},
+		Thresholds: map[string]float64{"BTC": 1, "ETH": -1},
+	}}
+	c.CheckOfflineWithdrawalsConfig()
+	assert.Equal(t, defaultOfflineWithdrawalExpiry, c.OfflineWithdrawals.Expiry, "Expiry should default")
+	assert.Equal(t, []string{key}, c.OfflineWithdrawals.PublicKeys, "invalid PublicKeys should be removed")
+	assert.Equal(t, 1.0, c.OfflineWithdrawals.Thresholds["BTC"], "valid Thresholds should be retained")
+	assert.Zero(t, c.OfflineWithdrawals.Thresholds["ETH"], "negative Thresholds should default")
+	assert.True(t, c.OfflineWithdrawals.Enabled, "should remain enabled with a valid public key")
+
+	c.OfflineWithdrawals.PublicKeys = []string{"bad"}
+	c.CheckOfflineWithdrawalsConfig()
+	assert.False(t, c.OfflineWithdrawals.Enabled, "should be disabled without a valid public key")
+}
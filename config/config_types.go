@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config/versions"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/database"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/protocol"
@@ -38,7 +39,70 @@ const (
 	defaultDataHistoryMonitorCheckTimer  = time.Minute
 	defaultCurrencyStateManagerDelay     = time.Minute
 	defaultMaxJobsPerCycle               = 5
+	defaultDigestTopN                    = 5
+	defaultDigestMaxEvents               = 10
+	defaultFillSyncInterval              = time.Minute * 15
+	defaultFillSyncLookback              = time.Hour * 24 * 7
+	defaultPairRefreshInterval           = time.Hour
+	defaultEarnSyncInterval              = time.Minute * 15
+	defaultEarnLookback                  = time.Hour * 24 * 30
+	defaultLendingCheckInterval          = time.Hour
+	defaultLendingMinRateImprovement     = 0.01
+	defaultLendingMinAmount              = 100
+	defaultMemoryCheckInterval           = time.Minute
+	defaultRolloverCheckInterval         = time.Minute * 5
+	defaultRolloverWindow                = time.Hour * 24
+	defaultRolloverMaxSpread             = 0.02
+	defaultSpreadCheckInterval           = time.Minute
+	defaultSpreadHistorySize             = 1440
+	defaultSpreadMinSamples              = 30
+	defaultSpreadZScoreThreshold         = 3
+	defaultSurfaceCheckInterval          = time.Minute
+	defaultSurfaceInterpolation          = "svi"
+	defaultSurfaceHistorySize            = 1440
+	defaultMarginInitialThreshold        = 0.9
+	defaultMarginWarningThreshold        = 0.5
+	defaultMarginCriticalThreshold       = 0.8
+	defaultDeleverageReduceFraction      = 0.25
+	defaultDeleverageCooldown            = time.Minute
+	defaultADLCheckInterval              = time.Minute
+	defaultADLWarningThreshold           = 0.6
+	defaultADLCriticalThreshold          = 0.8
+	defaultCalendarRefreshInterval       = time.Hour
+	defaultCalendarHorizon               = time.Hour * 24 * 30
+	defaultBasisCheckInterval            = time.Minute
+	defaultBasisFundingInterval          = time.Hour * 8
+	defaultBasisEntryThreshold           = 0.15
+	defaultBasisExitThreshold            = 0.02
+	defaultBasisMaxMarginRatio           = 0.5
+	defaultBasisReduceFraction           = 0.5
+	defaultAnomalyMaxDeviation           = 6
+	defaultAnomalyMinDeviation           = 0.005
+	defaultAnomalyWindow                 = 200
+	defaultAnomalyMinSamples             = 20
+	defaultAnomalyStaleAfter             = time.Second * 30
+	defaultAnomalyQuarantinePeriod       = time.Minute
+	defaultDataQualityWindow             = time.Hour
+	defaultDataQualityStaleTickThreshold = time.Second * 5
+	defaultWebhookListenAddress          = "localhost:9055"
+	defaultWebhookMaxBodySize            = 1 << 20
+	defaultWebhookFormat                 = "gct"
+	defaultCircuitFailureThreshold       = 5
+	defaultCircuitCooldown               = time.Second * 30
+	defaultSurveillanceCheckInterval     = time.Minute * 5
+	defaultSurveillanceWindow            = time.Hour
+	defaultSurveillanceMinOrders         = 20
+	defaultSurveillanceMaxCancelRatio    = 0.95
+	defaultSurveillanceMaxOrderToFill    = 50
+	defaultWashTradeWindow               = time.Minute
+	defaultWashTradePriceTolerance       = 0.0005
+	defaultSpoofMaxLifetime              = time.Second * 10
+	defaultSpoofMinOccurrences           = 3
+	defaultOfflineWithdrawalExpiry       = time.Hour * 24
 	DefaultOrderbookPublishPeriod        = time.Second * 10
+	defaultSchedulerHistoryLimit         = 20
+	defaultMaintenanceCheckInterval      = time.Second * 10
+	defaultMaintenanceReconnectInterval  = time.Minute
 	// DefaultSyncerWorkers limits the number of sync workers
 	DefaultSyncerWorkers = 15
 	// DefaultSyncerTimeoutREST the default time to switch from REST to websocket protocols without a response
@@ -90,6 +154,9 @@ var (
 // prestart management of Portfolio, Communications, Webserver and Enabled
 // Exchanges
 type Config struct {
+	// Version is the config version, configs are upgraded to the latest
+	// version when loaded
+	Version              int                       `json:"version"`
 	Name                 string                    `json:"name"`
 	DataDirectory        string                    `json:"dataDirectory"`
 	EncryptConfig        int                       `json:"encryptConfig"`
@@ -101,6 +168,29 @@ type Config struct {
 	OrderManager         OrderManager              `json:"orderManager"`
 	DataHistoryManager   DataHistoryManager        `json:"dataHistoryManager"`
 	CurrencyStateManager CurrencyStateManager      `json:"currencyStateManager"`
+	Digest               DigestConfig              `json:"digest"`
+	Scheduler            SchedulerConfig           `json:"scheduler"`
+	FillSyncManager      FillSyncManager           `json:"fillSyncManager"`
+	WebhookListener      WebhookListener           `json:"webhookListener"`
+	PairRefreshManager   PairRefreshManager        `json:"pairRefreshManager"`
+	EarnManager          EarnManager               `json:"earnManager"`
+	LendingOptimizer     LendingOptimizer          `json:"lendingOptimizer"`
+	MemoryManager        MemoryManager             `json:"memoryManager"`
+	RolloverManager      RolloverManager           `json:"rolloverManager"`
+	CalendarSpread       CalendarSpreadManager     `json:"calendarSpreadManager"`
+	VolatilitySurface    VolatilitySurfaceManager  `json:"volatilitySurfaceManager"`
+	MarginMonitor        MarginMonitor             `json:"marginMonitor"`
+	ADLMonitor           ADLMonitor                `json:"adlMonitor"`
+	ExchangeCalendar     ExchangeCalendar          `json:"exchangeCalendar"`
+	MaintenanceScheduler MaintenanceScheduler      `json:"maintenanceScheduler"`
+	BasisHarvester       BasisHarvester            `json:"basisHarvester"`
+	AnomalyDetector      AnomalyDetector           `json:"anomalyDetector"`
+	DataQuality          DataQualityMonitor        `json:"dataQualityMonitor"`
+	SurveillanceManager  SurveillanceManager       `json:"surveillanceManager"`
+	FeeAccounting        FeeAccounting             `json:"feeAccounting"`
+	OfflineWithdrawals   OfflineWithdrawals        `json:"offlineWithdrawals"`
+	LatencySimulation    LatencySimulation         `json:"latencySimulation"`
+	CircuitBreaker       CircuitBreaker            `json:"circuitBreaker"`
 	Profiler             Profiler                  `json:"profiler"`
 	NTPClient            NTPClientConfig           `json:"ntpclient"`
 	GCTScript            gctscript.Config          `json:"gctscript"`
@@ -112,7 +202,6 @@ type Config struct {
 	BankAccounts         []banking.Account         `json:"bankAccounts"`
 
 	// Deprecated config settings, will be removed at a future date
-	Webserver           *WebserverConfig      `json:"webserver,omitempty"`
 	CurrencyPairFormat  *currency.PairFormat  `json:"currencyPairFormat,omitempty"`
 	FiatDisplayCurrency *currency.Code        `json:"fiatDispayCurrency,omitempty"`
 	Cryptocurrencies    *currency.Currencies  `json:"cryptocurrencies,omitempty"`
@@ -120,6 +209,8 @@ type Config struct {
 	// encryption session values
 	storedSalt []byte
 	sessionDK  []byte
+	// migrations are the changes made when the config was upgraded on load
+	migrations []versions.Change
 }
 
 // OrderManager holds settings used for the order manager
@@ -130,6 +221,57 @@ type OrderManager struct {
 	FuturesTrackingSeekDuration   time.Duration `json:"futuresTrackingSeekDuration"`
 	RespectOrderHistoryLimits     *bool         `json:"respectOrderHistoryLimits"`
 	CancelOrdersOnShutdown        bool          `json:"cancelOrdersOnShutdown"`
+	// SelfTradePrevention is the default self trade prevention mode for
+	// orders submitted without one, such as cancel_taker. It is emulated for
+	// exchanges which do not support it natively
+	SelfTradePrevention string `json:"selfTradePrevention,omitempty"`
+	// PriceBands rejects limit orders priced too far from a live reference
+	// price, keyed by asset type such as options or spot
+	PriceBands map[string]PriceBand `json:"priceBands,omitempty"`
+	// MaxLeverage is the highest leverage which may be set or submitted with
+	// an order, keyed by asset type such as usdtmarginedfutures
+	MaxLeverage map[string]float64 `json:"maxLeverage,omitempty"`
+	// PaperTrading fills submitted orders against live orderbooks instead of
+	// sending them to exchanges
+	PaperTrading PaperTrading `json:"paperTrading"`
+}
+
+// PaperTrading defines how orders are simulated when paper trading
+type PaperTrading struct {
+	Enabled bool `json:"enabled"`
+	// Latency is the delay between an order being submitted and matched
+	Latency time.Duration `json:"latency"`
+	// Jitter randomly varies Latency by up to this amount either way
+	Jitter time.Duration `json:"jitter"`
+	// SlippageModel is how fill prices are derived from the orderbook. depth
+	// walks the orderbook levels, consuming their liquidity, while fixed fills
+	// the whole amount at the best price. Defaults to depth
+	SlippageModel string `json:"slippageModel,omitempty"`
+	// SlippageBasisPoints moves each fill price against the order by this
+	// many basis points, never beyond a limit order's price
+	SlippageBasisPoints float64 `json:"slippageBasisPoints,omitempty"`
+	// MatchInterval is how often resting limit orders are matched against
+	// the orderbook. Defaults to a second
+	MatchInterval time.Duration `json:"matchInterval,omitempty"`
+	// MaxOrderbookAge leaves orders unmatched while their orderbook has not
+	// been updated within this duration. Disabled when zero
+	MaxOrderbookAge time.Duration `json:"maxOrderbookAge,omitempty"`
+}
+
+// PriceBand defines how far a limit order price may deviate from a live
+// reference price before the order is rejected
+type PriceBand struct {
+	// References are the ticker prices compared against in order of
+	// preference: mark, index or last. Defaults to mark then index
+	References []string `json:"references,omitempty"`
+	// MaxDeviation is the largest fraction a price may deviate from the
+	// reference price, such as 0.1 for 10%
+	MaxDeviation float64 `json:"maxDeviation"`
+	// MaxAge treats reference prices older than this as unavailable
+	MaxAge time.Duration `json:"maxAge,omitempty"`
+	// RejectWithoutReference rejects orders when no reference price is
+	// available instead of allowing them
+	RejectWithoutReference bool `json:"rejectWithoutReference,omitempty"`
 }
 
 // DataHistoryManager holds all information required for the data history manager
@@ -141,6 +283,455 @@ type DataHistoryManager struct {
 	Verbose             bool          `json:"verbose"`
 }
 
+// FillSyncManager holds the configuration for periodically syncing user fill
+// history from exchanges to the database
+type FillSyncManager struct {
+	Enabled      bool          `json:"enabled"`
+	Verbose      bool          `json:"verbose"`
+	SyncInterval time.Duration `json:"syncInterval"`
+	// Lookback is how far back fills are fetched when none are stored
+	Lookback time.Duration `json:"lookback"`
+}
+
+// WebhookListener holds the configuration for receiving account events pushed
+// by exchanges and providers via HTTP callbacks
+type WebhookListener struct {
+	Enabled       bool   `json:"enabled"`
+	Verbose       bool   `json:"verbose"`
+	ListenAddress string `json:"listenAddress"`
+	// MaxBodySize is the largest callback body accepted in bytes
+	MaxBodySize int64           `json:"maxBodySize"`
+	Sources     []WebhookSource `json:"sources"`
+}
+
+// WebhookSource is a provider permitted to push account events to the
+// webhook listener
+type WebhookSource struct {
+	// Name is the source's path segment, callbacks are posted to
+	// /webhooks/{name}
+	Name string `json:"name"`
+	// Exchange is the exchange or provider name events are attributed to
+	Exchange string `json:"exchange"`
+	// Format selects the parser which normalises the source's callbacks
+	Format string `json:"format"`
+	// Secret is the key callback bodies are signed with using HMAC-SHA256
+	Secret string `json:"secret"`
+}
+
+// PairRefreshManager holds the configuration for periodically refreshing
+// exchange pair lists and enabling pairs which match exchange pair rules
+type PairRefreshManager struct {
+	Enabled  bool          `json:"enabled"`
+	Verbose  bool          `json:"verbose"`
+	Interval time.Duration `json:"interval"`
+}
+
+// EarnManager holds the configuration for syncing exchange earn and staking
+// positions and recording their rewards
+type EarnManager struct {
+	Enabled      bool          `json:"enabled"`
+	Verbose      bool          `json:"verbose"`
+	SyncInterval time.Duration `json:"syncInterval"`
+	// Lookback is how far back rewards are fetched when none are recorded
+	Lookback time.Duration `json:"lookback"`
+	// LedgerFile stores recorded rewards between restarts. Defaults to
+	// earnrewards.json in the data directory
+	LedgerFile string `json:"ledgerFile"`
+}
+
+// MemoryManager holds the configuration for attributing approximate memory
+// usage to the major caches and trimming them when over budget
+type MemoryManager struct {
+	Enabled       bool          `json:"enabled"`
+	Verbose       bool          `json:"verbose"`
+	CheckInterval time.Duration `json:"checkInterval"`
+	// Budgets are in megabytes, zero reports usage without trimming
+	OrderbookBudgetMB   int64 `json:"orderbookBudgetMB"`
+	TickerBudgetMB      int64 `json:"tickerBudgetMB"`
+	TradeBufferBudgetMB int64 `json:"tradeBufferBudgetMB"`
+}
+
+// LendingOptimizer holds the configuration for moving idle stablecoin
+// balances to the exchange offering the best flexible earn rate
+type LendingOptimizer struct {
+	Enabled bool `json:"enabled"`
+	Verbose bool `json:"verbose"`
+	// DryRun reports transfers and subscriptions without submitting them.
+	// Defaults to true
+	DryRun        *bool         `json:"dryRun"`
+	CheckInterval time.Duration `json:"checkInterval"`
+	// Currencies are the idle balances optimised. Defaults to USDT and USDC
+	Currencies []string `json:"currencies"`
+	// Exchanges limits the venues compared, all enabled exchanges with
+	// authenticated API support are compared when empty
+	Exchanges []string `json:"exchanges"`
+	// MinRateImprovement is the increase in APR required to move funds to
+	// another exchange, where 0.01 is 1%
+	MinRateImprovement float64 `json:"minRateImprovement"`
+	// MinAmount is the smallest idle balance which is subscribed or moved
+	MinAmount float64 `json:"minAmount"`
+	// MaxTransferAmount caps the amount moved by a single transfer, zero is
+	// unlimited
+	MaxTransferAmount float64 `json:"maxTransferAmount"`
+	// Reserve is the balance of each currency left idle on every exchange
+	Reserve float64 `json:"reserve"`
+	// Network is the transfer network funds are moved over, such as TRC20.
+	// Funds are only subscribed on the exchange holding them when unset
+	Network string `json:"network"`
+}
+
+// RolloverManager holds the configuration for rolling futures and options
+// positions from soon to expire contracts to the next expiry
+type RolloverManager struct {
+	Enabled bool `json:"enabled"`
+	Verbose bool `json:"verbose"`
+	// DryRun reports rollovers without placing orders. Defaults to true
+	DryRun        *bool         `json:"dryRun"`
+	CheckInterval time.Duration `json:"checkInterval"`
+	// RolloverWindow is how long before expiry a position is rolled
+	RolloverWindow time.Duration `json:"rolloverWindow"`
+	// MaxSpread is the maximum relative price difference between the near and
+	// far contracts, where 0.02 is 2%
+	MaxSpread float64 `json:"maxSpread"`
+}
+
+// CalendarSpreadManager holds the configuration for monitoring spreads
+// between perpetual and dated futures contracts
+type CalendarSpreadManager struct {
+	Enabled       bool          `json:"enabled"`
+	Verbose       bool          `json:"verbose"`
+	CheckInterval time.Duration `json:"checkInterval"`
+	// Underlyings limits monitoring to the listed underlying pairs, such as
+	// BTC-USDT. All underlyings are monitored when empty
+	Underlyings []string `json:"underlyings"`
+	// HistorySize is the number of spread samples used to calculate z-scores
+	HistorySize int `json:"historySize"`
+	// MinSamples is the number of samples required before alerting
+	MinSamples int `json:"minSamples"`
+	// ZScoreThreshold is the absolute z-score at which an alert is sent
+	ZScoreThreshold float64 `json:"zScoreThreshold"`
+	// StoreHistory saves spread samples to the database
+	StoreHistory bool `json:"storeHistory"`
+}
+
+// VolatilitySurfaceManager holds the configuration for building implied
+// volatility surfaces from live option tickers
+type VolatilitySurfaceManager struct {
+	Enabled       bool          `json:"enabled"`
+	Verbose       bool          `json:"verbose"`
+	CheckInterval time.Duration `json:"checkInterval"`
+	// Exchanges and Underlyings limit which surfaces are built, such as BTC.
+	// All exchanges with enabled option pairs and all underlyings are used
+	// when empty
+	Exchanges   []string `json:"exchanges"`
+	Underlyings []string `json:"underlyings"`
+	// Interpolation is how volatility is interpolated between strikes of an
+	// expiry, either svi or spline
+	Interpolation string `json:"interpolation"`
+	// RiskFreeRate is used when solving implied volatility from mark prices
+	RiskFreeRate float64 `json:"riskFreeRate"`
+	// HistorySize is the number of surface snapshots retained per surface
+	HistorySize int `json:"historySize"`
+}
+
+// MarginMonitor holds the configuration for alerting on account margin
+// utilisation and deleveraging accounts approaching liquidation
+type MarginMonitor struct {
+	Enabled bool `json:"enabled"`
+	Verbose bool `json:"verbose"`
+	// InitialMarginThreshold is the initial margin to equity ratio at which a
+	// warning is sent, where 1 prevents new positions from being opened
+	InitialMarginThreshold float64 `json:"initialMarginThreshold"`
+	// WarningThreshold is the maintenance margin to equity ratio at which a
+	// warning is sent, where 1 results in liquidation
+	WarningThreshold float64 `json:"warningThreshold"`
+	// CriticalThreshold is the maintenance margin to equity ratio at which a
+	// critical alert is sent and positions are deleveraged when enabled
+	CriticalThreshold float64          `json:"criticalThreshold"`
+	Deleverage        MarginDeleverage `json:"deleverage"`
+}
+
+// ADLMonitor holds the configuration for collecting insurance fund balances
+// and alerting when held positions move up an exchange's auto-deleveraging
+// queue
+type ADLMonitor struct {
+	Enabled bool `json:"enabled"`
+	Verbose bool `json:"verbose"`
+	// CheckInterval is how often insurance funds and ADL indicators are polled
+	CheckInterval time.Duration `json:"checkInterval"`
+	// WarningThreshold is the fraction of an exchange's highest ADL rank at
+	// which a warning is sent, where 1 is first in the deleveraging queue
+	WarningThreshold float64 `json:"warningThreshold"`
+	// CriticalThreshold is the fraction of an exchange's highest ADL rank at
+	// which a critical alert is sent
+	CriticalThreshold float64 `json:"criticalThreshold"`
+}
+
+// ExchangeCalendar holds the configuration for aggregating upcoming contract
+// expiries, listings, delistings and maintenance windows across exchanges
+type ExchangeCalendar struct {
+	Enabled bool `json:"enabled"`
+	Verbose bool `json:"verbose"`
+	// RefreshInterval is how often exchanges are polled for scheduled events
+	RefreshInterval time.Duration `json:"refreshInterval"`
+	// Horizon limits the calendar to events starting within this period
+	Horizon time.Duration `json:"horizon"`
+}
+
+// MaintenanceScheduler holds the configuration for pausing exchanges during
+// their configured maintenance windows
+type MaintenanceScheduler struct {
+	Enabled bool `json:"enabled"`
+	Verbose bool `json:"verbose"`
+	// CheckInterval is how often maintenance windows are checked for starting
+	// or ending
+	CheckInterval time.Duration `json:"checkInterval"`
+	// ReconnectInterval is the delay between websocket reconnection attempts
+	// while an exchange is under maintenance
+	ReconnectInterval time.Duration `json:"reconnectInterval"`
+}
+
+// MaintenanceWindow is a period of scheduled exchange maintenance. A one-off
+// window is set by Start and End. A recurring window is set by Schedule, a cron
+// expression evaluated in UTC, with each occurrence lasting Duration
+type MaintenanceWindow struct {
+	Name     string        `json:"name,omitempty"`
+	Start    *time.Time    `json:"start,omitempty"`
+	End      *time.Time    `json:"end,omitempty"`
+	Schedule string        `json:"schedule,omitempty"`
+	Duration time.Duration `json:"duration,omitempty"`
+}
+
+// BasisHarvester holds the configuration for the cash and carry strategy
+// which holds matched spot long and perpetual short positions while the
+// perpetual trades at a premium to spot
+type BasisHarvester struct {
+	Enabled bool `json:"enabled"`
+	Verbose bool `json:"verbose"`
+	// DryRun reports trades without placing orders. Defaults to true
+	DryRun        *bool         `json:"dryRun"`
+	CheckInterval time.Duration `json:"checkInterval"`
+	// FundingInterval is how often the perpetual premium converges to spot
+	// through funding payments, used to annualise the basis
+	FundingInterval time.Duration `json:"fundingInterval"`
+	// EntryThreshold is the annualised basis at which positions are opened,
+	// where 0.15 is 15%
+	EntryThreshold float64 `json:"entryThreshold"`
+	// ExitThreshold is the annualised basis at or below which positions are
+	// unwound
+	ExitThreshold float64 `json:"exitThreshold"`
+	// MaxMarginRatio is the perpetual account's maintenance margin to equity
+	// ratio at which positions are reduced and no new positions are opened.
+	// Requires the margin monitor
+	MaxMarginRatio float64 `json:"maxMarginRatio"`
+	// ReduceFraction is the proportion of a position unwound when the margin
+	// ratio is exceeded, where 0.5 is 50%
+	ReduceFraction float64       `json:"reduceFraction"`
+	Markets        []BasisMarket `json:"markets"`
+}
+
+// BasisMarket is a spot and perpetual pair traded by the basis harvester
+type BasisMarket struct {
+	Exchange string `json:"exchange"`
+	// Spot is the spot pair bought, such as BTC-USDT
+	Spot string `json:"spot"`
+	// Perpetual is the perpetual contract sold, such as BTC-USDT
+	Perpetual      string `json:"perpetual"`
+	PerpetualAsset string `json:"perpetualAsset"`
+	// Amount is the base currency amount of each leg
+	Amount float64 `json:"amount"`
+}
+
+// AnomalyDetector holds the configuration for flagging anomalous streamed
+// market data and quarantining the affected pairs from strategies
+type AnomalyDetector struct {
+	Enabled bool `json:"enabled"`
+	Verbose bool `json:"verbose"`
+	// MaxDeviation is the number of standard deviations a price may stray
+	// from the consolidated mid of all exchanges before it is flagged
+	MaxDeviation float64 `json:"maxDeviation"`
+	// MinDeviation is the smallest relative deviation from the consolidated
+	// mid flagged, where 0.005 is 0.5%, so quiet markets are not flagged on
+	// ordinary moves
+	MinDeviation float64 `json:"minDeviation"`
+	// Window is the number of recent deviations used to measure volatility
+	Window int `json:"window"`
+	// MinSamples is the number of deviations required before prices are
+	// checked against the consolidated mid
+	MinSamples int `json:"minSamples"`
+	// StaleAfter excludes an exchange's mid from the consolidated mid when it
+	// has not updated within this period
+	StaleAfter time.Duration `json:"staleAfter"`
+	// QuarantinePeriod is how long a pair is withheld from strategies after
+	// its last anomaly
+	QuarantinePeriod time.Duration `json:"quarantinePeriod"`
+}
+
+// DataQualityMonitor holds the configuration for scoring the quality of each
+// exchange's streamed market data
+type DataQualityMonitor struct {
+	Enabled bool `json:"enabled"`
+	Verbose bool `json:"verbose"`
+	// Window is the rolling period over which data quality is scored
+	Window time.Duration `json:"window"`
+	// StaleTickThreshold counts a ticker or orderbook as stale when its
+	// exchange timestamp lags its receipt by more than this period
+	StaleTickThreshold time.Duration `json:"staleTickThreshold"`
+}
+
+// MarginDeleverage holds the configuration for reducing positions when an
+// account's margin utilisation is critical
+type MarginDeleverage struct {
+	Enabled bool `json:"enabled"`
+	// DryRun reports deleveraging without placing orders. Defaults to true
+	DryRun *bool `json:"dryRun"`
+	// ReduceFraction is the proportion of the largest open position closed on
+	// each deleverage, where 0.25 is 25%
+	ReduceFraction float64 `json:"reduceFraction"`
+	// Cooldown is the minimum time between deleverages of the same account
+	Cooldown time.Duration `json:"cooldown"`
+}
+
+// SurveillanceManager holds the configuration for analysing the bot's own
+// order, cancel and fill activity for patterns resembling wash trading or
+// spoofing which could trigger exchange compliance actions
+type SurveillanceManager struct {
+	Enabled bool `json:"enabled"`
+	Verbose bool `json:"verbose"`
+	// CheckInterval is how often recent activity is checked for patterns
+	CheckInterval time.Duration `json:"checkInterval"`
+	// Window is the lookback period activity is checked over
+	Window time.Duration `json:"window"`
+	// MinOrders is the number of orders a pair must place within the window
+	// before its cancel and order to fill ratios are checked
+	MinOrders int `json:"minOrders"`
+	// MaxCancelRatio is the largest fraction of placed orders which may be
+	// cancelled, such as 0.95 for 95%
+	MaxCancelRatio float64 `json:"maxCancelRatio"`
+	// MaxOrderToFillRatio is the largest number of orders placed per order
+	// filled
+	MaxOrderToFillRatio float64 `json:"maxOrderToFillRatio"`
+	// WashTradeWindow is the time within which opposing fills on the same
+	// pair at the same price are flagged as potential wash trades
+	WashTradeWindow time.Duration `json:"washTradeWindow"`
+	// WashTradePriceTolerance is the fraction opposing fill prices may differ
+	// by and still be flagged as potential wash trades
+	WashTradePriceTolerance float64 `json:"washTradePriceTolerance"`
+	// SpoofMaxLifetime is the time within which an unfilled order cancelled
+	// after an opposing order fills is treated as spoof-like
+	SpoofMaxLifetime time.Duration `json:"spoofMaxLifetime"`
+	// SpoofMinOccurrences is the number of spoof-like cancels within the
+	// window before a pair is flagged
+	SpoofMinOccurrences int `json:"spoofMinOccurrences"`
+	// ReportSchedule is a cron expression for when reports covering the
+	// previous ReportPeriod are sent
+	ReportSchedule string        `json:"reportSchedule"`
+	ReportPeriod   time.Duration `json:"reportPeriod"`
+}
+
+// FeeAccounting holds the configuration for tracking the high-water marks of
+// managed accounts and charging them management and performance fees
+type FeeAccounting struct {
+	Enabled bool `json:"enabled"`
+	Verbose bool `json:"verbose"`
+	// Schedule is a cron expression for when fees are crystallised and
+	// statements are sent
+	Schedule string `json:"schedule"`
+	// StateFile stores each account's net asset value and high-water mark
+	// between restarts. Defaults to feeaccounting.json in the data directory
+	StateFile string           `json:"stateFile"`
+	Accounts  []ManagedAccount `json:"accounts"`
+}
+
+// ManagedAccount defines a strategy or sub-portfolio which is charged fees
+type ManagedAccount struct {
+	Name string `json:"name"`
+	// StrategyTags restricts the account to orders placed with these strategy
+	// tags. All strategies are included when empty
+	StrategyTags []string `json:"strategyTags"`
+	// Exchanges restricts the account to orders placed on these exchanges.
+	// All exchanges are included when empty
+	Exchanges []string `json:"exchanges"`
+	// Currency is the currency the account is valued in. Only orders quoted
+	// in this currency contribute to the account's profit and loss
+	Currency       string  `json:"currency"`
+	InitialCapital float64 `json:"initialCapital"`
+	// ManagementFeeRate is the annual fee charged on net asset value, such as
+	// 0.02 for 2%
+	ManagementFeeRate float64 `json:"managementFeeRate"`
+	// PerformanceFeeRate is the fee charged on gains above the high-water
+	// mark, such as 0.2 for 20%
+	PerformanceFeeRate float64 `json:"performanceFeeRate"`
+}
+
+// OfflineWithdrawals holds the configuration for holding large withdrawals
+// until a payload exported for them is signed offline, such as on an
+// air-gapped machine or hardware wallet, and re-imported
+type OfflineWithdrawals struct {
+	Enabled bool `json:"enabled"`
+	// PublicKeys are the hex encoded ed25519 public keys whose signatures
+	// approve a withdrawal
+	PublicKeys []string `json:"publicKeys"`
+	// Thresholds maps currency codes to the amount at or above which a
+	// withdrawal requires offline approval. Withdrawals of currencies without
+	// a threshold always require offline approval
+	Thresholds map[string]float64 `json:"thresholds"`
+	// Expiry is how long an exported payload can be signed and re-imported
+	// for before the withdrawal is discarded
+	Expiry time.Duration `json:"expiry"`
+}
+
+// LatencySimulation holds the configuration for injecting artificial latency
+// and jitter into exchange REST requests and websocket messages, used to test
+// how strategies behave under poor connectivity
+type LatencySimulation struct {
+	Enabled bool `json:"enabled"`
+	// RESTLatency is the delay added before each exchange REST request is sent
+	RESTLatency time.Duration `json:"restLatency"`
+	// RESTJitter randomly varies RESTLatency by up to this amount either way
+	RESTJitter time.Duration `json:"restJitter"`
+	// WebsocketLatency is the delay added to each websocket message received
+	WebsocketLatency time.Duration `json:"websocketLatency"`
+	// WebsocketJitter randomly varies WebsocketLatency by up to this amount
+	// either way
+	WebsocketJitter time.Duration `json:"websocketJitter"`
+}
+
+// CircuitBreaker holds the configuration for short-circuiting exchange REST
+// requests after repeated failures, protecting against hammering a failing
+// exchange
+type CircuitBreaker struct {
+	Enabled bool `json:"enabled"`
+	// PublicFailureThreshold is the number of consecutive failed public
+	// requests to an exchange which opens its public circuit
+	PublicFailureThreshold int `json:"publicFailureThreshold"`
+	// PrivateFailureThreshold is the number of consecutive failed
+	// authenticated requests to an exchange which opens its private circuit
+	PrivateFailureThreshold int `json:"privateFailureThreshold"`
+	// Cooldown is how long a circuit stays open before a probe request is
+	// sent to check whether the exchange has recovered
+	Cooldown time.Duration `json:"cooldown"`
+}
+
+// PairRules defines rules which automatically enable available pairs
+type PairRules struct {
+	// DisableUnmatched disables enabled pairs of a ruled asset which no longer
+	// match any rule
+	DisableUnmatched bool       `json:"disableUnmatched"`
+	Rules            []PairRule `json:"rules"`
+}
+
+// PairRule matches available pairs of an asset. Unset fields match all pairs
+type PairRule struct {
+	Asset  string   `json:"asset"`
+	Bases  []string `json:"bases,omitempty"`
+	Quotes []string `json:"quotes,omitempty"`
+	// MinQuoteVolume is the minimum 24 hour volume in the quote currency
+	MinQuoteVolume float64 `json:"minQuoteVolume,omitempty"`
+	// MaxTimeToExpiry limits contracts to those expiring within the duration
+	MaxTimeToExpiry time.Duration `json:"maxTimeToExpiry,omitempty"`
+}
+
 // CurrencyStateManager defines a set of configuration options for the currency
 // state manager
 type CurrencyStateManager struct {
@@ -148,6 +739,54 @@ type CurrencyStateManager struct {
 	Delay   time.Duration `json:"delay"`
 }
 
+// DigestConfig defines the configuration for scheduled performance digests
+// which are sent via the enabled communication channels
+type DigestConfig struct {
+	Enabled   bool           `json:"enabled"`
+	Verbose   bool           `json:"verbose"`
+	TopN      int            `json:"topN"`
+	MaxEvents int            `json:"maxEvents"`
+	Reports   []DigestReport `json:"reports"`
+}
+
+// DigestReport defines a single scheduled digest. Schedule is a cron
+// expression and Period is the lookback window the digest covers
+type DigestReport struct {
+	Name     string        `json:"name"`
+	Schedule string        `json:"schedule"`
+	Period   time.Duration `json:"period"`
+}
+
+// Scheduled task overlap policies decide what happens when a task is due
+// while a previous run is still in progress
+const (
+	TaskOverlapSkip  = "skip"
+	TaskOverlapAllow = "allow"
+	TaskOverlapQueue = "queue"
+)
+
+// SchedulerConfig defines the configuration for the engine scheduler which
+// runs user defined tasks on cron schedules
+type SchedulerConfig struct {
+	Enabled      bool            `json:"enabled"`
+	Verbose      bool            `json:"verbose"`
+	HistoryLimit int             `json:"historyLimit"`
+	Tasks        []ScheduledTask `json:"tasks"`
+}
+
+// ScheduledTask defines a single scheduled task. Schedule is a cron expression,
+// Jitter is the maximum random delay added to each run and Overlap is the
+// policy applied when the task is due while still running
+type ScheduledTask struct {
+	Name     string            `json:"name"`
+	Type     string            `json:"type"`
+	Schedule string            `json:"schedule"`
+	Jitter   time.Duration     `json:"jitter"`
+	Overlap  string            `json:"overlap"`
+	Timeout  time.Duration     `json:"timeout"`
+	Args     map[string]string `json:"args,omitempty"`
+}
+
 // SyncManagerConfig stores the currency pair synchronization manager config
 type SyncManagerConfig struct {
 	Enabled                 bool                 `json:"enabled"`
@@ -188,6 +827,12 @@ type Exchange struct {
 	WebsocketResponseMaxLimit     time.Duration          `json:"websocketResponseMaxLimit"`
 	WebsocketTrafficTimeout       time.Duration          `json:"websocketTrafficTimeout"`
 	ConnectionMonitorDelay        time.Duration          `json:"connectionMonitorDelay"`
+	SubscriptionReconcileInterval time.Duration          `json:"subscriptionReconcileInterval,omitempty"`
+	ChannelStalenessTimeout       time.Duration          `json:"channelStalenessTimeout,omitempty"`
+	ResubscribeStaleChannels      bool                   `json:"resubscribeStaleChannels,omitempty"`
+	WebsocketProcessingWorkers    int                    `json:"websocketProcessingWorkers,omitempty"`
+	WebsocketOrderEntry           bool                   `json:"websocketOrderEntry,omitempty"`
+	WebsocketShards               int                    `json:"websocketShards,omitempty"`
 	ProxyAddress                  string                 `json:"proxyAddress,omitempty"`
 	BaseCurrencies                currency.Currencies    `json:"baseCurrencies"`
 	CurrencyPairs                 *currency.PairsManager `json:"currencyPairs"`
@@ -195,6 +840,8 @@ type Exchange struct {
 	Features                      *FeaturesConfig        `json:"features"`
 	BankAccounts                  []banking.Account      `json:"bankAccounts,omitempty"`
 	Orderbook                     Orderbook              `json:"orderbook"`
+	PairRules                     *PairRules             `json:"pairRules,omitempty"`
+	MaintenanceWindows            []MaintenanceWindow    `json:"maintenanceWindows,omitempty"`
 
 	// Deprecated settings which will be removed in a future update
 	AvailablePairs                   *currency.Pairs      `json:"availablePairs,omitempty"`
@@ -221,6 +868,9 @@ type Exchange struct {
 type Profiler struct {
 	Enabled              bool `json:"enabled"`
 	MutexProfileFraction int  `json:"mutex_profile_fraction"`
+	// OutputPath is where profiles captured via gRPC are saved, defaults to
+	// the profiles directory within the data directory
+	OutputPath string `json:"output_path,omitempty"`
 }
 
 // NTPClientConfig defines a network time protocol configuration to allow for
@@ -267,17 +917,6 @@ type RemoteControlConfig struct {
 	WebsocketRPC  WebsocketRPCConfig   `json:"websocketRPC"`
 }
 
-// WebserverConfig stores the old webserver config
-type WebserverConfig struct {
-	Enabled                      bool   `json:"enabled"`
-	AdminUsername                string `json:"adminUsername"`
-	AdminPassword                string `json:"adminPassword"`
-	ListenAddress                string `json:"listenAddress"`
-	WebsocketConnectionLimit     int    `json:"websocketConnectionLimit"`
-	WebsocketMaxAuthFailures     int    `json:"websocketMaxAuthFailures"`
-	WebsocketAllowInsecureOrigin bool   `json:"websocketAllowInsecureOrigin"`
-}
-
 // Post holds the bot configuration data
 type Post struct {
 	Data Config `json:"data"`
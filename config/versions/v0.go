@@ -0,0 +1,39 @@
+package versions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/buger/jsonparser"
+)
+
+// Version0 renames exchanges which have been rebranded
+type Version0 struct{}
+
+// exchangeRenames maps lower case legacy exchange names to their current name
+var exchangeRenames = map[string]string{
+	"gdax":                 "CoinbasePro",
+	"okcoin international": "Okcoin",
+}
+
+// UpgradeExchange renames a legacy exchange
+func (v *Version0) UpgradeExchange(_ context.Context, e []byte) ([]byte, error) {
+	name, err := jsonparser.GetString(e, "name")
+	if err != nil {
+		if errors.Is(err, jsonparser.KeyPathNotFoundError) {
+			return e, nil
+		}
+		return nil, err
+	}
+	newName, ok := exchangeRenames[strings.ToLower(name)]
+	if !ok {
+		return e, nil
+	}
+	val, err := json.Marshal(newName)
+	if err != nil {
+		return nil, err
+	}
+	return jsonparser.Set(e, val, "name")
+}
@@ -0,0 +1,97 @@
+package versions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+
+	"github.com/buger/jsonparser"
+	"github.com/thrasher-corp/gocryptotrader/common"
+)
+
+// Version1 migrates the legacy webserver settings to remote control settings.
+// The deprecated RPC, websocket RPC, gRPC and gRPC proxy services listen on
+// consecutive ports from the webserver's listen address
+type Version1 struct{}
+
+// v1Webserver is the legacy webserver config
+type v1Webserver struct {
+	Enabled                      bool   `json:"enabled"`
+	AdminUsername                string `json:"adminUsername"`
+	AdminPassword                string `json:"adminPassword"`
+	ListenAddress                string `json:"listenAddress"`
+	WebsocketConnectionLimit     int    `json:"websocketConnectionLimit"`
+	WebsocketMaxAuthFailures     int    `json:"websocketMaxAuthFailures"`
+	WebsocketAllowInsecureOrigin bool   `json:"websocketAllowInsecureOrigin"`
+}
+
+// v1RemoteControl is the remote control config which replaced the webserver
+type v1RemoteControl struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	GRPC     struct {
+		Enabled                bool   `json:"enabled"`
+		ListenAddress          string `json:"listenAddress"`
+		GRPCProxyEnabled       bool   `json:"grpcProxyEnabled"`
+		GRPCProxyListenAddress string `json:"grpcProxyListenAddress"`
+	} `json:"gRPC"`
+	DeprecatedRPC struct {
+		Enabled       bool   `json:"enabled"`
+		ListenAddress string `json:"listenAddress"`
+	} `json:"deprecatedRPC"`
+	WebsocketRPC struct {
+		Enabled             bool   `json:"enabled"`
+		ListenAddress       string `json:"listenAddress"`
+		ConnectionLimit     int    `json:"connectionLimit"`
+		MaxAuthFailures     int    `json:"maxAuthFailures"`
+		AllowInsecureOrigin bool   `json:"allowInsecureOrigin"`
+	} `json:"websocketRPC"`
+}
+
+// UpgradeConfig replaces the remote control settings with the webserver
+// settings and removes the webserver settings
+func (v *Version1) UpgradeConfig(_ context.Context, j []byte) ([]byte, error) {
+	raw, dataType, _, err := jsonparser.Get(j, "webserver")
+	if err != nil {
+		if errors.Is(err, jsonparser.KeyPathNotFoundError) {
+			return j, nil
+		}
+		return nil, err
+	}
+	if dataType == jsonparser.Null {
+		return jsonparser.Delete(j, "webserver"), nil
+	}
+	var w v1Webserver
+	if err := json.Unmarshal(raw, &w); err != nil {
+		return nil, err
+	}
+
+	host := common.ExtractHost(w.ListenAddress)
+	port := common.ExtractPort(w.ListenAddress)
+	address := func(offset int) string { return host + ":" + strconv.Itoa(port+offset) }
+
+	var rc v1RemoteControl
+	rc.Username = w.AdminUsername
+	rc.Password = w.AdminPassword
+	rc.DeprecatedRPC.Enabled = w.Enabled
+	rc.DeprecatedRPC.ListenAddress = address(0)
+	rc.WebsocketRPC.Enabled = w.Enabled
+	rc.WebsocketRPC.ListenAddress = address(1)
+	rc.WebsocketRPC.ConnectionLimit = w.WebsocketConnectionLimit
+	rc.WebsocketRPC.MaxAuthFailures = w.WebsocketMaxAuthFailures
+	rc.WebsocketRPC.AllowInsecureOrigin = w.WebsocketAllowInsecureOrigin
+	rc.GRPC.Enabled = w.Enabled
+	rc.GRPC.ListenAddress = address(2)
+	rc.GRPC.GRPCProxyEnabled = w.Enabled
+	rc.GRPC.GRPCProxyListenAddress = address(3)
+
+	val, err := json.Marshal(&rc)
+	if err != nil {
+		return nil, err
+	}
+	if j, err = jsonparser.Set(j, val, "remoteControl"); err != nil {
+		return nil, err
+	}
+	return jsonparser.Delete(j, "webserver"), nil
+}
@@ -0,0 +1,216 @@
+// Package versions upgrades persisted configs through a sequence of versioned
+// transforms. Each version is applied in order from the config's version to
+// the latest, so old configs are upgraded step by step rather than by checks
+// scattered across config loading
+package versions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/buger/jsonparser"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+var (
+	errConfigVersionUnknown = errors.New("config version is newer than the latest supported version")
+	errVersionInvalid       = errors.New("config version is invalid")
+	errVersionUnsupported   = errors.New("version does not implement ConfigVersion or ExchangeVersion")
+)
+
+// ConfigVersion is implemented by versions which upgrade the whole config
+type ConfigVersion interface {
+	UpgradeConfig(ctx context.Context, j []byte) ([]byte, error)
+}
+
+// ExchangeVersion is implemented by versions which upgrade each exchange config
+type ExchangeVersion interface {
+	UpgradeExchange(ctx context.Context, j []byte) ([]byte, error)
+}
+
+// Manager upgrades configs with the registered versions, the index of each
+// version is its version number
+var Manager = &manager{versions: []any{
+	&Version0{},
+	&Version1{},
+}}
+
+// Latest returns the latest config version
+func (m *manager) Latest() int {
+	return len(m.versions) - 1
+}
+
+// Deploy upgrades a JSON config from its version to the latest version and
+// sets its version field. Configs without a version field are upgraded through
+// every version
+func (m *manager) Deploy(ctx context.Context, j []byte) ([]byte, error) {
+	latest := m.Latest()
+	// jsonparser.Set appends new keys to the end of the data, so trailing
+	// whitespace would leave them outside the config object. The data is
+	// copied as appending could otherwise overwrite the caller's data
+	j = bytes.Clone(bytes.TrimSpace(j))
+	current, err := jsonparser.GetInt(j, "version")
+	switch {
+	case errors.Is(err, jsonparser.KeyPathNotFoundError):
+		current = -1
+	case err != nil:
+		return nil, fmt.Errorf("%w: %w", errVersionInvalid, err)
+	case current < 0:
+		return nil, fmt.Errorf("%w: %d", errVersionInvalid, current)
+	case current > int64(latest):
+		return nil, fmt.Errorf("%w: config version %d, latest version %d", errConfigVersionUnknown, current, latest)
+	case current == int64(latest):
+		return j, nil
+	}
+
+	for ver := int(current) + 1; ver <= latest; ver++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if j, err = m.upgrade(ctx, ver, j); err != nil {
+			return nil, fmt.Errorf("upgrading config to version %d: %w", ver, err)
+		}
+		log.Debugf(log.ConfigMgr, "Upgraded config to version %d", ver)
+	}
+	return jsonparser.Set(j, []byte(strconv.Itoa(latest)), "version")
+}
+
+func (m *manager) upgrade(ctx context.Context, ver int, j []byte) ([]byte, error) {
+	var err error
+	var applied bool
+	if v, ok := m.versions[ver].(ConfigVersion); ok {
+		if j, err = v.UpgradeConfig(ctx, j); err != nil {
+			return nil, err
+		}
+		applied = true
+	}
+	if v, ok := m.versions[ver].(ExchangeVersion); ok {
+		if j, err = upgradeExchanges(ctx, v, j); err != nil {
+			return nil, err
+		}
+		applied = true
+	}
+	if !applied {
+		return nil, fmt.Errorf("%T: %w", m.versions[ver], errVersionUnsupported)
+	}
+	return j, nil
+}
+
+// upgradeExchanges applies an exchange version to each exchange config
+func upgradeExchanges(ctx context.Context, v ExchangeVersion, j []byte) ([]byte, error) {
+	var exchanges [][]byte
+	_, err := jsonparser.ArrayEach(j, func(e []byte, _ jsonparser.ValueType, _ int, _ error) {
+		exchanges = append(exchanges, bytes.Clone(e))
+	}, "exchanges")
+	if err != nil {
+		if errors.Is(err, jsonparser.KeyPathNotFoundError) {
+			return j, nil
+		}
+		return nil, err
+	}
+	for i := range exchanges {
+		e, err := v.UpgradeExchange(ctx, exchanges[i])
+		if err != nil {
+			return nil, fmt.Errorf("exchange %d: %w", i, err)
+		}
+		if j, err = jsonparser.Set(j, e, "exchanges", "["+strconv.Itoa(i)+"]"); err != nil {
+			return nil, err
+		}
+	}
+	return j, nil
+}
+
+// Change is a single difference between two configs
+type Change struct {
+	// Path is the location of the change, e.g. exchanges[0].name
+	Path string
+	// Old is unset for added values and New is unset for removed values
+	Old, New any
+}
+
+// String returns a readable description of the change
+func (c Change) String() string {
+	switch {
+	case c.Old == nil:
+		return fmt.Sprintf("%s: added %v", c.Path, jsonString(c.New))
+	case c.New == nil:
+		return fmt.Sprintf("%s: removed %v", c.Path, jsonString(c.Old))
+	}
+	return fmt.Sprintf("%s: %v -> %v", c.Path, jsonString(c.Old), jsonString(c.New))
+}
+
+func jsonString(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+// Diff returns the changes between two JSON configs ordered by path, allowing
+// an upgrade to be reviewed before it is saved
+func Diff(before, after []byte) ([]Change, error) {
+	var b, a any
+	if err := json.Unmarshal(before, &b); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(after, &a); err != nil {
+		return nil, err
+	}
+	var changes []Change
+	diff("", b, a, &changes)
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+func diff(path string, before, after any, changes *[]Change) {
+	switch b := before.(type) {
+	case map[string]any:
+		a, ok := after.(map[string]any)
+		if !ok {
+			break
+		}
+		for k, bv := range b {
+			diff(joinPath(path, k), bv, a[k], changes)
+		}
+		for k, av := range a {
+			if _, ok := b[k]; !ok {
+				*changes = append(*changes, Change{Path: joinPath(path, k), New: av})
+			}
+		}
+		return
+	case []any:
+		a, ok := after.([]any)
+		if !ok {
+			break
+		}
+		for i := range max(len(a), len(b)) {
+			elemPath := path + "[" + strconv.Itoa(i) + "]"
+			switch {
+			case i >= len(a):
+				*changes = append(*changes, Change{Path: elemPath, Old: b[i]})
+			case i >= len(b):
+				*changes = append(*changes, Change{Path: elemPath, New: a[i]})
+			default:
+				diff(elemPath, b[i], a[i], changes)
+			}
+		}
+		return
+	}
+	if !reflect.DeepEqual(before, after) {
+		*changes = append(*changes, Change{Path: path, Old: before, New: after})
+	}
+}
+
+func joinPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
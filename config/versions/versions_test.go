@@ -0,0 +1,128 @@
+package versions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errUpgradeTest = errors.New("upgrade test error")
+
+type testConfigVersion struct{ err error }
+
+func (v *testConfigVersion) UpgradeConfig(_ context.Context, j []byte) ([]byte, error) {
+	if v.err != nil {
+		return nil, v.err
+	}
+	return append([]byte(`{"upgraded":true,`), j[1:]...), nil
+}
+
+type testExchangeVersion struct{}
+
+func (v *testExchangeVersion) UpgradeExchange(_ context.Context, e []byte) ([]byte, error) {
+	return append([]byte(`{"upgraded":true,`), e[1:]...), nil
+}
+
+func TestDeploy(t *testing.T) {
+	t.Parallel()
+	m := &manager{versions: []any{&testConfigVersion{}, &testExchangeVersion{}}}
+	assert.Equal(t, 1, m.Latest())
+
+	j, err := m.Deploy(context.Background(), []byte(`{"name":"test","exchanges":[{"name":"a"},{"name":"b"}]}`))
+	require.NoError(t, err, "Deploy must not error")
+	assert.JSONEq(t, `{"version":1,"upgraded":true,"name":"test","exchanges":[{"upgraded":true,"name":"a"},{"upgraded":true,"name":"b"}]}`, string(j), "unversioned configs should apply every version")
+
+	j, err = m.Deploy(context.Background(), []byte(`{"version":0,"name":"test"}`))
+	require.NoError(t, err, "Deploy must not error")
+	assert.JSONEq(t, `{"version":1,"name":"test"}`, string(j), "only later versions should be applied")
+
+	in := []byte(`{"version":1,"name":"test"}`)
+	j, err = m.Deploy(context.Background(), in)
+	require.NoError(t, err, "Deploy must not error")
+	assert.Equal(t, in, j, "latest configs should not be changed")
+
+	_, err = m.Deploy(context.Background(), []byte(`{"version":2}`))
+	assert.ErrorIs(t, err, errConfigVersionUnknown)
+	_, err = m.Deploy(context.Background(), []byte(`{"version":-1}`))
+	assert.ErrorIs(t, err, errVersionInvalid)
+	_, err = m.Deploy(context.Background(), []byte(`{"version":"1"}`))
+	assert.ErrorIs(t, err, errVersionInvalid)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = m.Deploy(ctx, []byte(`{}`))
+	assert.ErrorIs(t, err, context.Canceled)
+
+	m = &manager{versions: []any{&testConfigVersion{err: errUpgradeTest}}}
+	_, err = m.Deploy(context.Background(), []byte(`{}`))
+	assert.ErrorIs(t, err, errUpgradeTest)
+
+	m = &manager{versions: []any{struct{}{}}}
+	_, err = m.Deploy(context.Background(), []byte(`{}`))
+	assert.ErrorIs(t, err, errVersionUnsupported)
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+	changes, err := Diff(
+		[]byte(`{"name":"a","webserver":{"enabled":true},"exchanges":[{"name":"GDAX"},{"name":"b"}]}`),
+		[]byte(`{"version":1,"name":"a","exchanges":[{"name":"CoinbasePro"}]}`),
+	)
+	require.NoError(t, err, "Diff must not error")
+	require.Len(t, changes, 4)
+	assert.Equal(t, Change{Path: "exchanges[0].name", Old: "GDAX", New: "CoinbasePro"}, changes[0])
+	assert.Equal(t, "exchanges[1]: removed {\"name\":\"b\"}", changes[1].String())
+	assert.Equal(t, "version: added 1", changes[2].String())
+	assert.Equal(t, "webserver: removed {\"enabled\":true}", changes[3].String())
+
+	_, err = Diff([]byte(`{`), []byte(`{}`))
+	assert.Error(t, err, "Diff should error on invalid JSON")
+}
+
+func TestVersion0(t *testing.T) {
+	t.Parallel()
+	v := &Version0{}
+	for in, want := range map[string]string{
+		`{"name":"GDAX"}`:                 `{"name":"CoinbasePro"}`,
+		`{"name":"OKCOIN International"}`: `{"name":"Okcoin"}`,
+		`{"name":"Binance"}`:              `{"name":"Binance"}`,
+		`{"enabled":true}`:                `{"enabled":true}`,
+	} {
+		e, err := v.UpgradeExchange(context.Background(), []byte(in))
+		require.NoError(t, err, "UpgradeExchange must not error")
+		assert.JSONEq(t, want, string(e))
+	}
+}
+
+func TestVersion1(t *testing.T) {
+	t.Parallel()
+	v := &Version1{}
+	in := []byte(`{"name":"test"}`)
+	j, err := v.UpgradeConfig(context.Background(), in)
+	require.NoError(t, err, "UpgradeConfig must not error")
+	assert.Equal(t, in, j, "configs without webserver settings should not be changed")
+
+	j, err = v.UpgradeConfig(context.Background(), []byte(`{"name":"test","remoteControl":{"username":"old"},"webserver":{
+		"enabled":true,
+		"adminUsername":"satoshi",
+		"adminPassword":"ultrasecurepassword",
+		"listenAddress":":9050",
+		"websocketConnectionLimit":5,
+		"websocketMaxAuthFailures":10,
+		"websocketAllowInsecureOrigin":true
+	}}`))
+	require.NoError(t, err, "UpgradeConfig must not error")
+	assert.JSONEq(t, `{"name":"test","remoteControl":{
+		"username":"satoshi",
+		"password":"ultrasecurepassword",
+		"gRPC":{"enabled":true,"listenAddress":"localhost:9052","grpcProxyEnabled":true,"grpcProxyListenAddress":"localhost:9053"},
+		"deprecatedRPC":{"enabled":true,"listenAddress":"localhost:9050"},
+		"websocketRPC":{"enabled":true,"listenAddress":"localhost:9051","connectionLimit":5,"maxAuthFailures":10,"allowInsecureOrigin":true}
+	}}`, string(j), "webserver settings should be migrated to remote control")
+
+	_, err = v.UpgradeConfig(context.Background(), []byte(`{"webserver":{"enabled":"yes"}}`))
+	assert.Error(t, err, "UpgradeConfig should error on invalid webserver settings")
+}
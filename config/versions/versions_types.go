@@ -0,0 +1,7 @@
+package versions
+
+type manager struct {
+	// versions are ConfigVersion or ExchangeVersion implementations indexed
+	// by version number
+	versions []any
+}
@@ -75,6 +75,15 @@ type Checker struct {
 	wg            sync.WaitGroup
 	connected     bool
 	mu            sync.Mutex
+	onChange      func(connected bool)
+}
+
+// SetConnectionChangeHandler sets a function which is called whenever
+// connectivity is lost or restored
+func (c *Checker) SetConnectionChangeHandler(fn func(connected bool)) {
+	c.mu.Lock()
+	c.onChange = fn
+	c.mu.Unlock()
 }
 
 // Shutdown cleanly shutsdown monitor routine
@@ -136,12 +145,7 @@ func (c *Checker) connectionTest() {
 	for i := range c.DNSList {
 		err := c.CheckDNS(c.DNSList[i])
 		if err == nil {
-			c.mu.Lock()
-			if !c.connected {
-				log.Debugln(log.Global, ConnRe)
-				c.connected = true
-			}
-			c.mu.Unlock()
+			c.setConnected(true)
 			return
 		}
 	}
@@ -149,22 +153,33 @@ func (c *Checker) connectionTest() {
 	for i := range c.DomainList {
 		err := c.CheckHost(c.DomainList[i])
 		if err == nil {
-			c.mu.Lock()
-			if !c.connected {
-				log.Debugln(log.Global, ConnRe)
-				c.connected = true
-			}
-			c.mu.Unlock()
+			c.setConnected(true)
 			return
 		}
 	}
 
+	c.setConnected(false)
+}
+
+// setConnected updates connectivity status and notifies the change handler
+// when the status changes
+func (c *Checker) setConnected(connected bool) {
 	c.mu.Lock()
-	if c.connected {
+	if c.connected == connected {
+		c.mu.Unlock()
+		return
+	}
+	c.connected = connected
+	if connected {
+		log.Debugln(log.Global, ConnRe)
+	} else {
 		log.Warnln(log.Global, ConnLost)
-		c.connected = false
 	}
+	onChange := c.onChange
 	c.mu.Unlock()
+	if onChange != nil {
+		onChange(connected)
+	}
 }
 
 // CheckDNS checks current dns for connectivity
@@ -3,6 +3,8 @@ package connchecker
 import (
 	"testing"
 	"time"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestConnection(t *testing.T) {
@@ -36,3 +38,15 @@ func TestConnection(t *testing.T) {
 
 	c.Shutdown()
 }
+
+func TestSetConnectionChangeHandler(t *testing.T) {
+	t.Parallel()
+	var c Checker
+	var changes []bool
+	c.SetConnectionChangeHandler(func(connected bool) { changes = append(changes, connected) })
+	c.setConnected(true)
+	c.setConnected(true)
+	c.setConnected(false)
+	assert.Equal(t, []bool{true, false}, changes, "handler should only be called when connectivity changes")
+	assert.False(t, c.IsConnected())
+}
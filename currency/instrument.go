@@ -0,0 +1,413 @@
+package currency
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+var (
+	errInstrumentTemplateInvalid  = errors.New("instrument template is invalid")
+	errInstrumentKindInvalid      = errors.New("instrument kind is invalid")
+	errInstrumentNameUnmatched    = errors.New("instrument name does not match any pattern")
+	errInstrumentKindUnsupported  = errors.New("instrument kind has no pattern")
+	errInstrumentFieldMissing     = errors.New("instrument field missing")
+	errInstrumentLegsUnsupported  = errors.New("instrument grammar does not support legs")
+	errInstrumentNotPairFormatted = errors.New("instrument name does not start with its base currency")
+)
+
+// canonicalInstruments is the grammar of the pairs instruments are stored as.
+// The base is the pair's base currency and the quote holds the remainder of
+// the instrument, e.g. BTC-28JUN24-60000-C is stored as BTC and 28JUN24-60000-C
+var canonicalInstruments = mustInstrumentGrammar(
+	[]InstrumentPattern{
+		{Kind: InstrumentOption, Template: "{base}-{expiry:2Jan06}-{strike}-{type}"},
+		{Kind: InstrumentFuture, Template: "{base}-{expiry:2Jan06}"},
+		{Kind: InstrumentPerpetual, Template: "{base}-PERP"},
+		{Kind: InstrumentCombo, Template: "{base}-{legs}"},
+		{Kind: InstrumentSpot, Template: "{base}-{quote}"},
+	},
+	"_",
+	[]InstrumentPattern{
+		{Kind: InstrumentOption, Template: "{expiry:2Jan06}-{strike}-{type}"},
+		{Kind: InstrumentFuture, Template: "{expiry:2Jan06}"},
+		{Kind: InstrumentPerpetual, Template: "PERP"},
+		{Kind: InstrumentSpot, Template: "{quote}"},
+	},
+)
+
+func mustInstrumentGrammar(patterns []InstrumentPattern, legDelimiter string, legPatterns []InstrumentPattern) *InstrumentGrammar {
+	g, err := NewInstrumentGrammar(patterns...)
+	if err != nil {
+		panic(err)
+	}
+	legs, err := NewInstrumentGrammar(legPatterns...)
+	if err != nil {
+		panic(err)
+	}
+	return g.WithLegs(legDelimiter, legs)
+}
+
+// NewInstrumentGrammar returns a grammar for the supplied patterns. Patterns
+// are tried in order when parsing and the first pattern of an instrument's
+// kind is used when formatting
+func NewInstrumentGrammar(patterns ...InstrumentPattern) (*InstrumentGrammar, error) {
+	g := &InstrumentGrammar{patterns: make([]instrumentPattern, len(patterns))}
+	for i := range patterns {
+		tokens, err := parseInstrumentTemplate(patterns[i].Template)
+		if err != nil {
+			return nil, err
+		}
+		if err := validateInstrumentPattern(patterns[i].Kind, tokens); err != nil {
+			return nil, fmt.Errorf("%w: %s", err, patterns[i].Template)
+		}
+		g.patterns[i] = instrumentPattern{kind: patterns[i].Kind, tokens: tokens}
+	}
+	return g, nil
+}
+
+// WithLegs sets the grammar and delimiter of combo legs and returns the grammar
+func (g *InstrumentGrammar) WithLegs(delimiter string, legs *InstrumentGrammar) *InstrumentGrammar {
+	g.legDelimiter = delimiter
+	g.legs = legs
+	return g
+}
+
+func parseInstrumentTemplate(template string) ([]instrumentToken, error) {
+	var tokens []instrumentToken
+	for template != "" {
+		start := strings.IndexByte(template, '{')
+		if start != 0 {
+			if start == -1 {
+				start = len(template)
+			}
+			tokens = append(tokens, instrumentToken{literal: template[:start]})
+			template = template[start:]
+			continue
+		}
+		end := strings.IndexByte(template, '}')
+		if end == -1 {
+			return nil, fmt.Errorf("%w: unclosed field in %s", errInstrumentTemplateInvalid, template)
+		}
+		name, layout, _ := strings.Cut(template[1:end], ":")
+		t := instrumentToken{layout: layout}
+		switch name {
+		case "base":
+			t.field = fieldBase
+		case "quote":
+			t.field = fieldQuote
+		case "expiry":
+			if layout == "" {
+				return nil, fmt.Errorf("%w: expiry requires a time layout", errInstrumentTemplateInvalid)
+			}
+			t.field = fieldExpiry
+		case "strike":
+			t.field = fieldStrike
+		case "type":
+			t.field = fieldOptionType
+		case "legs":
+			t.field = fieldLegs
+		default:
+			return nil, fmt.Errorf("%w: unknown field %q", errInstrumentTemplateInvalid, name)
+		}
+		tokens = append(tokens, t)
+		template = template[end+1:]
+	}
+	return tokens, nil
+}
+
+// validateInstrumentPattern checks a pattern has the fields its kind requires
+func validateInstrumentPattern(kind InstrumentKind, tokens []instrumentToken) error {
+	var required []instrumentField
+	switch kind {
+	case InstrumentSpot:
+		required = []instrumentField{fieldQuote}
+	case InstrumentPerpetual:
+	case InstrumentFuture:
+		required = []instrumentField{fieldExpiry}
+	case InstrumentOption:
+		required = []instrumentField{fieldExpiry, fieldStrike, fieldOptionType}
+	case InstrumentCombo:
+		required = []instrumentField{fieldLegs}
+	default:
+		return fmt.Errorf("%w: %d", errInstrumentKindInvalid, kind)
+	}
+	for _, f := range required {
+		if !containsField(tokens, f) {
+			return errInstrumentTemplateInvalid
+		}
+	}
+	for i := range tokens {
+		if tokens[i].field == fieldLegs && (kind != InstrumentCombo || i != len(tokens)-1) {
+			return fmt.Errorf("%w: legs must be the last field of a combo", errInstrumentTemplateInvalid)
+		}
+	}
+	return nil
+}
+
+func containsField(tokens []instrumentToken, f instrumentField) bool {
+	for i := range tokens {
+		if tokens[i].field == f {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse returns the instrument described by an instrument name
+func (g *InstrumentGrammar) Parse(name string) (Instrument, error) {
+	for i := range g.patterns {
+		if inst, ok := g.match(g.patterns[i].tokens, name, Instrument{Kind: g.patterns[i].kind}); ok {
+			return inst, nil
+		}
+	}
+	return Instrument{}, fmt.Errorf("%w: %s", errInstrumentNameUnmatched, name)
+}
+
+// match matches the remainder of a name against the remaining tokens of a
+// pattern, trying each possible length of a field until the rest matches
+func (g *InstrumentGrammar) match(tokens []instrumentToken, s string, inst Instrument) (Instrument, bool) {
+	if len(tokens) == 0 {
+		return inst, s == ""
+	}
+	t := &tokens[0]
+	switch t.field {
+	case fieldLiteral:
+		if len(s) < len(t.literal) || !strings.EqualFold(s[:len(t.literal)], t.literal) {
+			return inst, false
+		}
+		return g.match(tokens[1:], s[len(t.literal):], inst)
+	case fieldLegs:
+		legs, err := g.parseLegs(s, inst.Base)
+		if err != nil {
+			return inst, false
+		}
+		inst.Legs = legs
+		return inst, true
+	}
+	for end := 1; end <= len(s) && isFieldChar(t.field, s[end-1]); end++ {
+		next := inst
+		if !setInstrumentField(&next, t, s[:end]) {
+			continue
+		}
+		if res, ok := g.match(tokens[1:], s[end:], next); ok {
+			return res, true
+		}
+	}
+	return inst, false
+}
+
+func (g *InstrumentGrammar) parseLegs(s string, base Code) ([]Instrument, error) {
+	if g.legs == nil {
+		return nil, errInstrumentLegsUnsupported
+	}
+	names := strings.Split(s, g.legDelimiter)
+	if len(names) < 2 {
+		return nil, fmt.Errorf("%w: legs", errInstrumentFieldMissing)
+	}
+	legs := make([]Instrument, len(names))
+	for i := range names {
+		leg, err := g.legs.Parse(names[i])
+		if err != nil {
+			return nil, err
+		}
+		if leg.Base.IsEmpty() {
+			leg.Base = base
+		}
+		legs[i] = leg
+	}
+	return legs, nil
+}
+
+func isFieldChar(f instrumentField, c byte) bool {
+	switch f {
+	case fieldStrike:
+		return c >= '0' && c <= '9' || c == '.'
+	case fieldOptionType:
+		return unicode.IsLetter(rune(c))
+	}
+	return c < unicode.MaxASCII && (unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c)))
+}
+
+func setInstrumentField(inst *Instrument, t *instrumentToken, v string) bool {
+	switch t.field {
+	case fieldBase:
+		inst.Base = NewCode(v)
+	case fieldQuote:
+		inst.Quote = NewCode(v)
+	case fieldExpiry:
+		expiry, err := time.Parse(t.layout, v)
+		if err != nil {
+			return false
+		}
+		inst.Expiry = expiry
+	case fieldStrike:
+		strike, err := strconv.ParseFloat(v, 64)
+		if err != nil || strike <= 0 {
+			return false
+		}
+		inst.Strike = strike
+	case fieldOptionType:
+		switch strings.ToUpper(v) {
+		case "C", "CALL":
+			inst.OptionType = OptionCall
+		case "P", "PUT":
+			inst.OptionType = OptionPut
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Format returns the name of an instrument using the first pattern of its
+// kind. Fields are upper cased
+func (g *InstrumentGrammar) Format(inst *Instrument) (string, error) {
+	for i := range g.patterns {
+		if g.patterns[i].kind == inst.Kind {
+			return g.format(g.patterns[i].tokens, inst)
+		}
+	}
+	return "", fmt.Errorf("%w: %d", errInstrumentKindUnsupported, inst.Kind)
+}
+
+func (g *InstrumentGrammar) format(tokens []instrumentToken, inst *Instrument) (string, error) {
+	var sb strings.Builder
+	for i := range tokens {
+		var v string
+		switch tokens[i].field {
+		case fieldLiteral:
+			sb.WriteString(tokens[i].literal)
+			continue
+		case fieldBase:
+			if inst.Base.IsEmpty() {
+				return "", fmt.Errorf("%w: base", errInstrumentFieldMissing)
+			}
+			v = inst.Base.String()
+		case fieldQuote:
+			if inst.Quote.IsEmpty() {
+				return "", fmt.Errorf("%w: quote", errInstrumentFieldMissing)
+			}
+			v = inst.Quote.String()
+		case fieldExpiry:
+			if inst.Expiry.IsZero() {
+				return "", fmt.Errorf("%w: expiry", errInstrumentFieldMissing)
+			}
+			v = inst.Expiry.Format(tokens[i].layout)
+		case fieldStrike:
+			if inst.Strike <= 0 {
+				return "", fmt.Errorf("%w: strike", errInstrumentFieldMissing)
+			}
+			v = strconv.FormatFloat(inst.Strike, 'f', -1, 64)
+		case fieldOptionType:
+			switch inst.OptionType {
+			case OptionCall:
+				v = "C"
+			case OptionPut:
+				v = "P"
+			default:
+				return "", fmt.Errorf("%w: option type", errInstrumentFieldMissing)
+			}
+		case fieldLegs:
+			if g.legs == nil {
+				return "", errInstrumentLegsUnsupported
+			}
+			if len(inst.Legs) < 2 {
+				return "", fmt.Errorf("%w: legs", errInstrumentFieldMissing)
+			}
+			legs := make([]string, len(inst.Legs))
+			for j := range inst.Legs {
+				leg, err := g.legs.Format(&inst.Legs[j])
+				if err != nil {
+					return "", err
+				}
+				legs[j] = leg
+			}
+			sb.WriteString(strings.Join(legs, g.legDelimiter))
+			continue
+		}
+		sb.WriteString(strings.ToUpper(v))
+	}
+	return sb.String(), nil
+}
+
+// Asset returns the asset an instrument is traded as. Combos with an option
+// leg are option combos, combos of futures have no tradable asset
+func (i *Instrument) Asset() asset.Item {
+	switch i.Kind {
+	case InstrumentSpot:
+		return asset.Spot
+	case InstrumentPerpetual:
+		return asset.PerpetualContract
+	case InstrumentFuture:
+		return asset.Futures
+	case InstrumentOption:
+		return asset.Options
+	case InstrumentCombo:
+		for j := range i.Legs {
+			if i.Legs[j].Kind == InstrumentOption {
+				return asset.OptionCombo
+			}
+		}
+	}
+	return asset.Empty
+}
+
+// Pair returns the pair an instrument is stored as, with the base currency as
+// the base and the remainder of the instrument as the quote
+func (i *Instrument) Pair() (Pair, error) {
+	name, err := canonicalInstruments.Format(i)
+	if err != nil {
+		return EMPTYPAIR, err
+	}
+	return splitInstrumentName(name, i.Base)
+}
+
+// ParsePair parses an instrument name and returns the pair it is stored as
+func (g *InstrumentGrammar) ParsePair(name string) (Pair, error) {
+	inst, err := g.Parse(name)
+	if err != nil {
+		return EMPTYPAIR, err
+	}
+	return inst.Pair()
+}
+
+// FormatPair returns a stored instrument pair formatted with the grammar. The
+// returned pair's string is the instrument name, split after its base currency
+func (g *InstrumentGrammar) FormatPair(p Pair) (Pair, error) {
+	inst, err := PairInstrument(p)
+	if err != nil {
+		return EMPTYPAIR, err
+	}
+	name, err := g.Format(&inst)
+	if err != nil {
+		return EMPTYPAIR, err
+	}
+	return splitInstrumentName(name, inst.Base)
+}
+
+// PairInstrument returns the instrument a stored instrument pair describes,
+// so the asset of pairs such as option combos can be determined
+func PairInstrument(p Pair) (Instrument, error) {
+	return canonicalInstruments.Parse(p.Base.String() + DashDelimiter + p.Quote.String())
+}
+
+// splitInstrumentName splits an instrument name after its base currency and
+// any delimiter which follows it
+func splitInstrumentName(name string, base Code) (Pair, error) {
+	b := base.Upper().String()
+	if !strings.HasPrefix(name, b) {
+		return EMPTYPAIR, fmt.Errorf("%w: %s", errInstrumentNotPairFormatted, name)
+	}
+	rest := name[len(b):]
+	var delimiter string
+	if rest != "" && unicode.IsPunct(rune(rest[0])) {
+		delimiter = rest[:1]
+	}
+	return Pair{Base: NewCode(b), Delimiter: delimiter, Quote: NewCode(rest[len(delimiter):])}, nil
+}
@@ -0,0 +1,204 @@
+package currency
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+func testDeribitGrammar(t *testing.T) *InstrumentGrammar {
+	t.Helper()
+	g, err := NewInstrumentGrammar(
+		InstrumentPattern{Kind: InstrumentOption, Template: "{base}-{expiry:2Jan06}-{strike}-{type}"},
+		InstrumentPattern{Kind: InstrumentPerpetual, Template: "{base}-PERPETUAL"},
+		InstrumentPattern{Kind: InstrumentFuture, Template: "{base}-{expiry:2Jan06}"},
+		InstrumentPattern{Kind: InstrumentCombo, Template: "{base}-FS-{legs}"},
+		InstrumentPattern{Kind: InstrumentSpot, Template: "{base}_{quote}"},
+	)
+	require.NoError(t, err, "NewInstrumentGrammar must not error")
+	legs, err := NewInstrumentGrammar(
+		InstrumentPattern{Kind: InstrumentPerpetual, Template: "PERP"},
+		InstrumentPattern{Kind: InstrumentFuture, Template: "{expiry:2Jan06}"},
+	)
+	require.NoError(t, err, "NewInstrumentGrammar must not error")
+	return g.WithLegs("_", legs)
+}
+
+func TestNewInstrumentGrammar(t *testing.T) {
+	t.Parallel()
+	for _, p := range []InstrumentPattern{
+		{Kind: InstrumentFuture, Template: "{base}-{expiry"},
+		{Kind: InstrumentFuture, Template: "{base}-{expiry}"},
+		{Kind: InstrumentFuture, Template: "{base}-{size}"},
+		{Kind: InstrumentFuture, Template: "{base}-PERP"},
+		{Kind: InstrumentOption, Template: "{base}-{expiry:2Jan06}-{strike}"},
+		{Kind: InstrumentCombo, Template: "{legs}-{base}"},
+		{Kind: InstrumentSpot, Template: "{base}{quote}{legs}"},
+	} {
+		_, err := NewInstrumentGrammar(p)
+		assert.ErrorIs(t, err, errInstrumentTemplateInvalid, p.Template)
+	}
+	_, err := NewInstrumentGrammar(InstrumentPattern{Template: "{base}"})
+	assert.ErrorIs(t, err, errInstrumentKindInvalid)
+}
+
+func TestInstrumentGrammarParse(t *testing.T) {
+	t.Parallel()
+	g := testDeribitGrammar(t)
+	expiry := time.Date(2024, time.June, 28, 0, 0, 0, 0, time.UTC)
+
+	inst, err := g.Parse("BTC-28JUN24-60000-C")
+	require.NoError(t, err, "Parse must not error")
+	assert.Equal(t, Instrument{Kind: InstrumentOption, Base: BTC, Expiry: expiry, Strike: 60000, OptionType: OptionCall}, inst)
+
+	inst, err = g.Parse("eth-5jul24-3500.5-p")
+	require.NoError(t, err, "Parse must not error")
+	assert.Equal(t, time.Date(2024, time.July, 5, 0, 0, 0, 0, time.UTC), inst.Expiry, "single digit days and lower case should parse")
+	assert.Equal(t, 3500.5, inst.Strike)
+	assert.Equal(t, OptionPut, inst.OptionType)
+
+	inst, err = g.Parse("BTC-PERPETUAL")
+	require.NoError(t, err, "Parse must not error")
+	assert.Equal(t, Instrument{Kind: InstrumentPerpetual, Base: BTC}, inst)
+
+	inst, err = g.Parse("BTC-28JUN24")
+	require.NoError(t, err, "Parse must not error")
+	assert.Equal(t, Instrument{Kind: InstrumentFuture, Base: BTC, Expiry: expiry}, inst)
+
+	inst, err = g.Parse("BTC-FS-28JUN24_PERP")
+	require.NoError(t, err, "Parse must not error")
+	assert.Equal(t, InstrumentCombo, inst.Kind)
+	assert.Equal(t, []Instrument{
+		{Kind: InstrumentFuture, Base: BTC, Expiry: expiry},
+		{Kind: InstrumentPerpetual, Base: BTC},
+	}, inst.Legs, "legs should inherit the combo base")
+
+	inst, err = g.Parse("ETH_USDC")
+	require.NoError(t, err, "Parse must not error")
+	assert.Equal(t, Instrument{Kind: InstrumentSpot, Base: ETH, Quote: USDC}, inst)
+
+	for _, name := range []string{"BTC-30FEB24", "BTC-28JUN24-0-C", "BTC-28JUN24-60000-X", "BTC-FS-28JUN24", "BTC"} {
+		_, err = g.Parse(name)
+		assert.ErrorIs(t, err, errInstrumentNameUnmatched, name)
+	}
+}
+
+func TestInstrumentGrammarFormat(t *testing.T) {
+	t.Parallel()
+	g := testDeribitGrammar(t)
+	for _, name := range []string{"BTC-28JUN24-60000-C", "ETH-5JUL24-3500.5-P", "BTC-PERPETUAL", "BTC-28JUN24", "BTC-FS-28JUN24_PERP", "ETH_USDC"} {
+		inst, err := g.Parse(name)
+		require.NoError(t, err, "Parse must not error")
+		formatted, err := g.Format(&inst)
+		require.NoError(t, err, "Format must not error")
+		assert.Equal(t, name, formatted, "Format should round trip")
+	}
+
+	spot, err := NewInstrumentGrammar(InstrumentPattern{Kind: InstrumentSpot, Template: "{base}{quote}"})
+	require.NoError(t, err, "NewInstrumentGrammar must not error")
+	_, err = spot.Format(&Instrument{Kind: InstrumentFuture, Base: BTC})
+	assert.ErrorIs(t, err, errInstrumentKindUnsupported)
+	_, err = spot.Format(&Instrument{Kind: InstrumentSpot, Base: BTC})
+	assert.ErrorIs(t, err, errInstrumentFieldMissing)
+
+	for _, inst := range []Instrument{
+		{Kind: InstrumentFuture, Base: BTC},
+		{Kind: InstrumentOption, Base: BTC, Expiry: time.Now()},
+		{Kind: InstrumentOption, Base: BTC, Expiry: time.Now(), Strike: 1},
+		{Kind: InstrumentCombo, Base: BTC, Legs: []Instrument{{Kind: InstrumentPerpetual}}},
+		{Kind: InstrumentPerpetual},
+	} {
+		_, err = g.Format(&inst)
+		assert.ErrorIs(t, err, errInstrumentFieldMissing)
+	}
+}
+
+func TestInstrumentAsset(t *testing.T) {
+	t.Parallel()
+	g := testDeribitGrammar(t)
+	legs, err := NewInstrumentGrammar(InstrumentPattern{Kind: InstrumentOption, Template: "{expiry:2Jan06}-{strike}-{type}"})
+	require.NoError(t, err, "NewInstrumentGrammar must not error")
+	options, err := NewInstrumentGrammar(InstrumentPattern{Kind: InstrumentCombo, Template: "{base}-CS-{legs}"})
+	require.NoError(t, err, "NewInstrumentGrammar must not error")
+	options = options.WithLegs("_", legs)
+
+	for name, a := range map[string]asset.Item{
+		"BTC-28JUN24-60000-C": asset.Options,
+		"BTC-PERPETUAL":       asset.PerpetualContract,
+		"BTC-28JUN24":         asset.Futures,
+		"BTC-FS-28JUN24_PERP": asset.Empty,
+		"ETH_USDC":            asset.Spot,
+	} {
+		inst, err := g.Parse(name)
+		require.NoError(t, err, "Parse must not error")
+		assert.Equal(t, a, inst.Asset(), name)
+	}
+	inst, err := options.Parse("BTC-CS-28JUN24-60000-C_28JUN24-65000-C")
+	require.NoError(t, err, "Parse must not error")
+	assert.Equal(t, asset.OptionCombo, inst.Asset(), "combos with option legs should be option combos")
+	assert.Equal(t, asset.Empty, (&Instrument{}).Asset())
+}
+
+func TestInstrumentGrammarPairs(t *testing.T) {
+	t.Parallel()
+	g := testDeribitGrammar(t)
+	for name, stored := range map[string]string{
+		"BTC-28JUN24-60000-C": "BTC-28JUN24-60000-C",
+		"BTC-PERPETUAL":       "BTC-PERP",
+		"BTC-28JUN24":         "BTC-28JUN24",
+		"BTC-FS-28JUN24_PERP": "BTC-28JUN24_PERP",
+		"ETH_USDC":            "ETH-USDC",
+	} {
+		p, err := g.ParsePair(name)
+		require.NoError(t, err, "ParsePair must not error")
+		assert.Equal(t, stored, p.String(), "ParsePair should return the stored pair")
+
+		formatted, err := g.FormatPair(p)
+		require.NoError(t, err, "FormatPair must not error")
+		assert.Equal(t, name, formatted.String(), "FormatPair should return the instrument name")
+	}
+
+	p, err := g.FormatPair(NewPair(BTC, NewCode("28JUN24-60000-C")))
+	require.NoError(t, err, "FormatPair must not error")
+	assert.Equal(t, Pair{Base: BTC, Delimiter: DashDelimiter, Quote: NewCode("28JUN24-60000-C")}, p, "pair should be split after the base")
+
+	_, err = g.ParsePair("BTC")
+	assert.ErrorIs(t, err, errInstrumentNameUnmatched)
+}
+
+func TestPairInstrument(t *testing.T) {
+	t.Parallel()
+	for stored, a := range map[string]asset.Item{
+		"28JUN24-60000-C_28JUN24-70000-C": asset.OptionCombo,
+		"28JUN24_PERP":                    asset.Empty,
+		"28JUN24-60000-C":                 asset.Options,
+		"USDT":                            asset.Spot,
+	} {
+		inst, err := PairInstrument(NewPair(BTC, NewCode(stored)))
+		require.NoError(t, err, "PairInstrument must not error")
+		assert.Equal(t, a, inst.Asset(), stored)
+		assert.True(t, inst.Base.Equal(BTC), "base should be the pair base")
+	}
+	_, err := PairInstrument(EMPTYPAIR)
+	assert.ErrorIs(t, err, errInstrumentNameUnmatched)
+}
+
+func TestPairFormatGrammar(t *testing.T) {
+	t.Parallel()
+	g, err := NewInstrumentGrammar(
+		InstrumentPattern{Kind: InstrumentPerpetual, Template: "{base}PERP"},
+		InstrumentPattern{Kind: InstrumentFuture, Template: "{base}-{expiry:2Jan06}"},
+	)
+	require.NoError(t, err, "NewInstrumentGrammar must not error")
+	pf := PairFormat{Uppercase: true, Grammar: g}
+	assert.Equal(t, "BTCPERP", pf.Format(NewPairWithDelimiter("btc", "perp", "-")))
+	assert.Equal(t, "BTC-28JUN24", pf.Format(NewPair(BTC, NewCode("28JUN24"))))
+	assert.Equal(t, "BTCUSDC", pf.Format(NewPair(BTC, USDC)), "pairs the grammar cannot format should use the delimiter")
+
+	pairs := Pairs{NewPair(BTC, PERP), NewPair(ETH, NewCode("28JUN24"))}.Format(pf)
+	assert.Equal(t, "BTCPERP", pairs[0].String())
+	assert.Equal(t, "ETH-28JUN24", pairs[1].String())
+}
@@ -0,0 +1,82 @@
+package currency
+
+import "time"
+
+// InstrumentKind is the kind of instrument described by an instrument name
+type InstrumentKind uint8
+
+// Instrument kinds
+const (
+	InstrumentSpot InstrumentKind = iota + 1
+	InstrumentPerpetual
+	InstrumentFuture
+	InstrumentOption
+	InstrumentCombo
+)
+
+// OptionType is the type of an option instrument
+type OptionType uint8
+
+// Option types
+const (
+	OptionCall OptionType = iota + 1
+	OptionPut
+)
+
+// Instrument holds the parts of an exchange instrument name
+type Instrument struct {
+	Kind  InstrumentKind
+	Base  Code
+	Quote Code
+	// Expiry is the expiry date of futures and options
+	Expiry     time.Time
+	Strike     float64
+	OptionType OptionType
+	// Legs are the instruments of a combo
+	Legs []Instrument
+}
+
+// InstrumentPattern is a template for the names of a kind of instrument.
+// Templates are made of literal text and the fields {base}, {quote},
+// {expiry:layout} where layout is a time layout, {strike}, {type} for an
+// option type of C or P and {legs} for the legs of a combo, which must be the
+// last field. e.g. {base}-{expiry:2Jan06}-{strike}-{type} matches
+// BTC-28JUN24-60000-C
+type InstrumentPattern struct {
+	Kind     InstrumentKind
+	Template string
+}
+
+// InstrumentGrammar parses and formats instrument names with a set of
+// patterns, for exchanges whose instrument names cannot be formatted by a
+// delimiter alone
+type InstrumentGrammar struct {
+	patterns     []instrumentPattern
+	legDelimiter string
+	legs         *InstrumentGrammar
+}
+
+type instrumentPattern struct {
+	kind   InstrumentKind
+	tokens []instrumentToken
+}
+
+// instrumentToken is either literal text or a field of an instrument pattern
+type instrumentToken struct {
+	field   instrumentField
+	literal string
+	// layout is the time layout of an expiry field
+	layout string
+}
+
+type instrumentField uint8
+
+const (
+	fieldLiteral instrumentField = iota
+	fieldBase
+	fieldQuote
+	fieldExpiry
+	fieldStrike
+	fieldOptionType
+	fieldLegs
+)
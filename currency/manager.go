@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/common/convert"
@@ -35,6 +36,9 @@ var (
 	// ErrSymbolStringEmpty is an error when a symbol string is empty
 	ErrSymbolStringEmpty = errors.New("symbol string is empty")
 
+	// ErrInvalidPairState is returned when a pair state is not recognised
+	ErrInvalidPairState = errors.New("invalid pair state")
+
 	errPairStoreIsNil      = errors.New("pair store is nil")
 	errPairFormatIsNil     = errors.New("pair format is nil")
 	errPairMatcherIsNil    = errors.New("pair matcher is nil")
@@ -434,6 +438,56 @@ func (p *PairsManager) SetAssetEnabled(a asset.Item, enabled bool) error {
 	return nil
 }
 
+// GetPairState returns the lifecycle state of a currency pair. Pairs without a
+// stored state are tradeable
+func (p *PairsManager) GetPairState(pair Pair, a asset.Item) (PairState, error) {
+	if !a.IsValid() {
+		return PairStateTradeable, fmt.Errorf("%s %w", a, asset.ErrNotSupported)
+	}
+	if pair.IsEmpty() {
+		return PairStateTradeable, ErrCurrencyPairEmpty
+	}
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+	return p.states[pairStateKey(pair, a)], nil
+}
+
+// SetPairState stores the lifecycle state of a currency pair. Returns the
+// change, or nil when the state is unchanged
+func (p *PairsManager) SetPairState(pair Pair, a asset.Item, state PairState) (*PairStateChange, error) {
+	if !a.IsValid() {
+		return nil, fmt.Errorf("%s %w", a, asset.ErrNotSupported)
+	}
+	if pair.IsEmpty() {
+		return nil, ErrCurrencyPairEmpty
+	}
+	if !state.IsValid() {
+		return nil, fmt.Errorf("%w %d", ErrInvalidPairState, state)
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	k := pairStateKey(pair, a)
+	previous := p.states[k]
+	if previous == state {
+		return nil, nil
+	}
+	if state == PairStateTradeable {
+		delete(p.states, k)
+	} else {
+		if p.states == nil {
+			p.states = make(map[key]PairState)
+		}
+		p.states[k] = state
+	}
+	return &PairStateChange{
+		Pair:     pair,
+		Asset:    a,
+		Previous: previous,
+		Current:  state,
+		Time:     time.Now(),
+	}, nil
+}
+
 // Load sets the pair manager from a seed without copying mutexes
 func (p *PairsManager) Load(seed *PairsManager) error {
 	if seed == nil {
@@ -465,6 +519,10 @@ func (p *PairsManager) Load(seed *PairsManager) error {
 	return nil
 }
 
+func pairStateKey(pair Pair, a asset.Item) key {
+	return key{Symbol: pair.Base.Lower().String() + pair.Quote.Lower().String(), Asset: a}
+}
+
 func (p *PairsManager) getPairStoreRequiresLock(a asset.Item) (*PairStore, error) {
 	if p.Pairs == nil {
 		return nil, fmt.Errorf("%w when requesting %v pairs", ErrPairManagerNotInitialised, a)
@@ -575,3 +633,26 @@ func (ps *PairStore) copy() (*PairStore, error) {
 		ConfigFormat:  cFmt,
 	}, nil
 }
+
+// IsValid returns whether the pair state is recognised
+func (s PairState) IsValid() bool {
+	return s <= PairStateDelisted
+}
+
+// String implements the stringer interface
+func (s PairState) String() string {
+	switch s {
+	case PairStateTradeable:
+		return "TRADEABLE"
+	case PairStatePostOnly:
+		return "POST_ONLY"
+	case PairStateReduceOnly:
+		return "REDUCE_ONLY"
+	case PairStateSuspended:
+		return "SUSPENDED"
+	case PairStateDelisted:
+		return "DELISTED"
+	default:
+		return "UNKNOWN"
+	}
+}
@@ -575,6 +575,20 @@ func TestFullStoreUnmarshalMarshal(t *testing.T) {
 	}
 }
 
+func TestFullStoreMultiLegAssets(t *testing.T) {
+	t.Parallel()
+	pm := &PairsManager{Pairs: make(FullStore)}
+	combo := NewPairWithDelimiter("BTC", "28JUN24-60000C_28JUN24-70000C", "-")
+	require.NoError(t, pm.StorePairs(asset.OptionCombo, Pairs{combo}, false), "StorePairs must not error")
+
+	data, err := json.Marshal(pm.Pairs)
+	require.NoError(t, err, "Marshal must not error")
+	var loaded FullStore
+	require.NoError(t, json.Unmarshal(data, &loaded), "Unmarshal must not error")
+	require.Contains(t, loaded, asset.OptionCombo, "option combo pairs must be loaded")
+	assert.True(t, loaded[asset.OptionCombo].Available.Contains(combo, true), "option combo pair should be available")
+}
+
 func TestIsPairAvailable(t *testing.T) {
 	t.Parallel()
 	pm := initTest(t)
@@ -857,3 +871,62 @@ func TestPairManagerSetDelimitersFromConfig(t *testing.T) {
 		assert.ErrorContains(t, err, "spot.enabled.BTC-USDT: delimiter: [_] not found in currencypair string", "SetDelimitersFromConfig should error correctly")
 	}
 }
+
+func TestPairState(t *testing.T) {
+	t.Parallel()
+	pm := initTest(t)
+	cp := NewPairWithDelimiter("BTC", "USD", "-")
+	_, err := pm.GetPairState(cp, asset.Empty)
+	assert.ErrorIs(t, err, asset.ErrNotSupported)
+	_, err = pm.GetPairState(EMPTYPAIR, asset.Spot)
+	assert.ErrorIs(t, err, ErrCurrencyPairEmpty)
+	state, err := pm.GetPairState(cp, asset.Spot)
+	require.NoError(t, err, "GetPairState must not error")
+	assert.Equal(t, PairStateTradeable, state, "pairs without a stored state should be tradeable")
+
+	_, err = pm.SetPairState(cp, asset.Empty, PairStateSuspended)
+	assert.ErrorIs(t, err, asset.ErrNotSupported)
+	_, err = pm.SetPairState(EMPTYPAIR, asset.Spot, PairStateSuspended)
+	assert.ErrorIs(t, err, ErrCurrencyPairEmpty)
+	_, err = pm.SetPairState(cp, asset.Spot, PairState(255))
+	assert.ErrorIs(t, err, ErrInvalidPairState)
+
+	change, err := pm.SetPairState(cp, asset.Spot, PairStateSuspended)
+	require.NoError(t, err, "SetPairState must not error")
+	require.NotNil(t, change, "SetPairState must return a change")
+	assert.Equal(t, PairStateTradeable, change.Previous)
+	assert.Equal(t, PairStateSuspended, change.Current)
+	assert.Equal(t, asset.Spot, change.Asset)
+
+	state, err = pm.GetPairState(NewPair(BTC, USD), asset.Spot)
+	require.NoError(t, err, "GetPairState must not error")
+	assert.Equal(t, PairStateSuspended, state, "state should be matched regardless of pair format")
+	state, err = pm.GetPairState(cp, asset.Futures)
+	require.NoError(t, err, "GetPairState must not error")
+	assert.Equal(t, PairStateTradeable, state, "state should be stored per asset")
+
+	change, err = pm.SetPairState(cp, asset.Spot, PairStateSuspended)
+	require.NoError(t, err, "SetPairState must not error")
+	assert.Nil(t, change, "an unchanged state should not return a change")
+
+	change, err = pm.SetPairState(cp, asset.Spot, PairStateTradeable)
+	require.NoError(t, err, "SetPairState must not error")
+	require.NotNil(t, change, "SetPairState must return a change")
+	assert.Equal(t, PairStateSuspended, change.Previous)
+	assert.Empty(t, pm.states, "tradeable pairs should not be stored")
+}
+
+func TestPairStateString(t *testing.T) {
+	t.Parallel()
+	for s, exp := range map[PairState]string{
+		PairStateTradeable:  "TRADEABLE",
+		PairStatePostOnly:   "POST_ONLY",
+		PairStateReduceOnly: "REDUCE_ONLY",
+		PairStateSuspended:  "SUSPENDED",
+		PairStateDelisted:   "DELISTED",
+		PairState(255):      "UNKNOWN",
+	} {
+		assert.Equal(t, exp, s.String())
+		assert.Equal(t, exp != "UNKNOWN", s.IsValid())
+	}
+}
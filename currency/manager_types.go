@@ -2,6 +2,7 @@ package currency
 
 import (
 	"sync"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 )
@@ -15,6 +16,7 @@ type PairsManager struct {
 	LastUpdated                int64       `json:"lastUpdated,omitempty"`
 	Pairs                      FullStore   `json:"pairs"`
 	matcher                    map[key]*Pair
+	states                     map[key]PairState
 	mutex                      sync.RWMutex
 }
 
@@ -36,6 +38,10 @@ type PairFormat struct {
 	Uppercase bool   `json:"uppercase"`
 	Delimiter string `json:"delimiter,omitempty"`
 	Separator string `json:"separator,omitempty"`
+	// Grammar formats instrument pairs which a delimiter cannot, such as
+	// dated futures named differently to perpetuals. Pairs it cannot format
+	// fall back to the delimiter. It is set by exchanges and not configurable
+	Grammar *InstrumentGrammar `json:"-"`
 }
 
 // key is used to store the asset type and symbol in a map
@@ -43,3 +49,25 @@ type key struct {
 	Symbol string
 	Asset  asset.Item
 }
+
+// PairState defines the trading lifecycle state of a currency pair as reported
+// by an exchange
+type PairState uint8
+
+// Pair lifecycle states. Pairs without a stored state are tradeable
+const (
+	PairStateTradeable PairState = iota
+	PairStatePostOnly
+	PairStateReduceOnly
+	PairStateSuspended
+	PairStateDelisted
+)
+
+// PairStateChange defines a change in a currency pair's lifecycle state
+type PairStateChange struct {
+	Pair     Pair
+	Asset    asset.Item
+	Previous PairState
+	Current  PairState
+	Time     time.Time
+}
@@ -71,6 +71,15 @@ func (p Pair) MarshalJSON() ([]byte, error) {
 // Format changes the currency based on user preferences overriding the default
 // String() display
 func (p Pair) Format(pf PairFormat) Pair {
+	if pf.Grammar != nil {
+		if formatted, err := pf.Grammar.FormatPair(p); err == nil {
+			p = formatted
+			if pf.Uppercase {
+				return p.Upper()
+			}
+			return p.Lower()
+		}
+	}
 	p.Delimiter = pf.Delimiter
 	if pf.Uppercase {
 		return p.Upper()
@@ -60,6 +60,10 @@ func (p Pairs) Join() string {
 func (p Pairs) Format(pairFmt PairFormat) Pairs {
 	pairs := slices.Clone(p)
 	for x := range pairs {
+		if pairFmt.Grammar != nil {
+			pairs[x] = pairs[x].Format(pairFmt)
+			continue
+		}
 		pairs[x].Base.UpperCase = pairFmt.Uppercase
 		pairs[x].Quote.UpperCase = pairFmt.Uppercase
 		pairs[x].Delimiter = pairFmt.Delimiter
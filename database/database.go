@@ -3,6 +3,8 @@ package database
 import (
 	"database/sql"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -133,3 +135,46 @@ func (i *Instance) GetSQL() (*sql.DB, error) {
 	resp := i.SQL
 	return resp, nil
 }
+
+// CheckAndSetDefaults applies default values to empty SQLite options and
+// verifies the remaining options are supported
+func (s *SQLiteConfig) CheckAndSetDefaults() error {
+	if s == nil {
+		return ErrNilConfig
+	}
+	if s.JournalMode == "" {
+		s.JournalMode = DefaultSQLiteJournalMode
+	}
+	s.JournalMode = strings.ToUpper(s.JournalMode)
+	if !slices.Contains(sqliteJournalModes, s.JournalMode) {
+		return fmt.Errorf("%w journal mode %q", ErrInvalidSQLiteOption, s.JournalMode)
+	}
+	if s.Synchronous == "" {
+		s.Synchronous = DefaultSQLiteSynchronous
+	}
+	s.Synchronous = strings.ToUpper(s.Synchronous)
+	if !slices.Contains(sqliteSynchronous, s.Synchronous) {
+		return fmt.Errorf("%w synchronous %q", ErrInvalidSQLiteOption, s.Synchronous)
+	}
+	if s.BusyTimeout < 0 {
+		return fmt.Errorf("%w busy timeout %s", ErrInvalidSQLiteOption, s.BusyTimeout)
+	}
+	if s.BusyTimeout == 0 {
+		s.BusyTimeout = DefaultSQLiteBusyTimeout
+	}
+	return nil
+}
+
+// Stats returns connection pool statistics, including the number of
+// connections in use, idle and the time spent waiting for a connection
+func (i *Instance) Stats() (sql.DBStats, error) {
+	if i == nil {
+		return sql.DBStats{}, ErrNilInstance
+	}
+	i.m.RLock()
+	defer i.m.RUnlock()
+	if i.SQL == nil {
+		return sql.DBStats{}, errNilSQL
+	}
+	return i.SQL.Stats(), nil
+}
@@ -211,3 +211,60 @@ func TestGetSQL(t *testing.T) {
 		t.Errorf("received %v, expected %v", err, ErrNilInstance)
 	}
 }
+
+func TestSQLiteConfigCheckAndSetDefaults(t *testing.T) {
+	t.Parallel()
+	var s *SQLiteConfig
+	if err := s.CheckAndSetDefaults(); !errors.Is(err, ErrNilConfig) {
+		t.Errorf("received %v, expected %v", err, ErrNilConfig)
+	}
+
+	s = &SQLiteConfig{}
+	if err := s.CheckAndSetDefaults(); err != nil {
+		t.Fatal(err)
+	}
+	if s.JournalMode != DefaultSQLiteJournalMode || s.Synchronous != DefaultSQLiteSynchronous || s.BusyTimeout != DefaultSQLiteBusyTimeout {
+		t.Errorf("received %+v, expected defaults", s)
+	}
+
+	s = &SQLiteConfig{JournalMode: "delete", Synchronous: "full"}
+	if err := s.CheckAndSetDefaults(); err != nil {
+		t.Fatal(err)
+	}
+	if s.JournalMode != "DELETE" || s.Synchronous != "FULL" {
+		t.Errorf("received %+v, expected upper case options", s)
+	}
+
+	for _, bad := range []SQLiteConfig{{JournalMode: "bad"}, {Synchronous: "bad"}, {BusyTimeout: -1}} {
+		if err := bad.CheckAndSetDefaults(); !errors.Is(err, ErrInvalidSQLiteOption) {
+			t.Errorf("received %v, expected %v", err, ErrInvalidSQLiteOption)
+		}
+	}
+}
+
+func TestStats(t *testing.T) {
+	t.Parallel()
+	var inst *Instance
+	if _, err := inst.Stats(); !errors.Is(err, ErrNilInstance) {
+		t.Errorf("received %v, expected %v", err, ErrNilInstance)
+	}
+	inst = &Instance{}
+	if _, err := inst.Stats(); !errors.Is(err, errNilSQL) {
+		t.Errorf("received %v, expected %v", err, errNilSQL)
+	}
+	con, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "stats.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer con.Close()
+	if err = inst.SetSQLiteConnection(con); err != nil {
+		t.Fatal(err)
+	}
+	stats, err := inst.Stats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.MaxOpenConnections != 1 {
+		t.Errorf("received %v, expected %v", stats.MaxOpenConnections, 1)
+	}
+}
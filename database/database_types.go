@@ -6,6 +6,7 @@ import (
 	"errors"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/database/drivers"
 )
@@ -25,6 +26,16 @@ type Config struct {
 	Verbose                   bool   `json:"verbose"`
 	Driver                    string `json:"driver"`
 	drivers.ConnectionDetails `json:"connectionDetails"`
+	SQLite                    SQLiteConfig `json:"sqlite"`
+}
+
+// SQLiteConfig holds SQLite specific connection options. Empty values use the
+// driver defaults of WAL journaling, a five second busy timeout and NORMAL
+// synchronous mode
+type SQLiteConfig struct {
+	JournalMode string        `json:"journalMode"`
+	BusyTimeout time.Duration `json:"busyTimeout"`
+	Synchronous string        `json:"synchronous"`
 }
 
 var (
@@ -50,6 +61,8 @@ var (
 	ErrNilConfig  = errors.New("received nil config")
 	errNilSQL     = errors.New("database SQL connection is nil")
 	errFailedPing = errors.New("unable to verify database is connected, failed ping")
+	// ErrInvalidSQLiteOption for when a SQLite connection option is not supported
+	ErrInvalidSQLiteOption = errors.New("invalid SQLite option")
 )
 
 const (
@@ -61,6 +74,20 @@ const (
 	DBPostgreSQL = "postgres"
 	// DBInvalidDriver const string for invalid driver
 	DBInvalidDriver = "invalid driver"
+
+	// DefaultSQLiteJournalMode allows readers to continue while a write is
+	// in progress
+	DefaultSQLiteJournalMode = "WAL"
+	// DefaultSQLiteBusyTimeout is how long SQLite waits on a locked database
+	// before returning a busy error
+	DefaultSQLiteBusyTimeout = time.Second * 5
+	// DefaultSQLiteSynchronous is safe from corruption when using WAL
+	DefaultSQLiteSynchronous = "NORMAL"
+)
+
+var (
+	sqliteJournalModes = []string{"DELETE", "TRUNCATE", "PERSIST", "MEMORY", "WAL", "OFF"}
+	sqliteSynchronous  = []string{"OFF", "NORMAL", "FULL", "EXTRA"}
 )
 
 // IDatabase allows for the passing of a database struct
@@ -19,6 +19,23 @@ func Connect(cfg *database.Config) (*database.Instance, error) {
 	if !cfg.Enabled {
 		return nil, database.ErrDatabaseSupportDisabled
 	}
+	db, err := Open(cfg)
+	if err != nil {
+		return nil, err
+	}
+	err = database.DB.SetPostgresConnection(db)
+	if err != nil {
+		return nil, err
+	}
+	return database.DB, nil
+}
+
+// Open opens a connection to a Postgres database without altering the global
+// database instance
+func Open(cfg *database.Config) (*sql.DB, error) {
+	if cfg == nil {
+		return nil, database.ErrNilConfig
+	}
 	if cfg.SSLMode == "" {
 		cfg.SSLMode = "disable"
 	}
@@ -31,13 +48,5 @@ func Connect(cfg *database.Config) (*database.Instance, error) {
 		cfg.Database,
 		cfg.SSLMode)
 
-	db, err := sql.Open(database.DBPostgreSQL, configDSN)
-	if err != nil {
-		return nil, err
-	}
-	err = database.DB.SetPostgresConnection(db)
-	if err != nil {
-		return nil, err
-	}
-	return database.DB, nil
+	return sql.Open(database.DBPostgreSQL, configDSN)
 }
@@ -2,7 +2,9 @@ package sqlite
 
 import (
 	"database/sql"
+	"net/url"
 	"path/filepath"
+	"strconv"
 
 	// import sqlite3 driver
 	_ "github.com/mattn/go-sqlite3"
@@ -15,8 +17,11 @@ func Connect(db string) (*database.Instance, error) {
 		return nil, database.ErrNoDatabaseProvided
 	}
 
-	databaseFullLocation := filepath.Join(database.DB.DataPath, db)
-	dbConn, err := sql.Open("sqlite3", databaseFullLocation)
+	var opts database.SQLiteConfig
+	if cfg := database.DB.GetConfig(); cfg != nil {
+		opts = cfg.SQLite
+	}
+	dbConn, err := Open(filepath.Join(database.DB.DataPath, db), &opts)
 	if err != nil {
 		return nil, err
 	}
@@ -28,3 +33,35 @@ func Connect(db string) (*database.Instance, error) {
 
 	return database.DB, nil
 }
+
+// Open opens the sqlite database file at path with the supplied options
+// without altering the global database instance
+func Open(path string, opts *database.SQLiteConfig) (*sql.DB, error) {
+	if path == "" {
+		return nil, database.ErrNoDatabaseProvided
+	}
+	dsn, err := DSN(path, opts)
+	if err != nil {
+		return nil, err
+	}
+	return sql.Open(database.DBSQLite3, dsn)
+}
+
+// DSN returns the data source name for the sqlite database file at path.
+// Transactions take the write lock immediately so concurrent writers wait on
+// the busy timeout rather than failing when upgrading a read lock
+func DSN(path string, opts *database.SQLiteConfig) (string, error) {
+	var o database.SQLiteConfig
+	if opts != nil {
+		o = *opts
+	}
+	if err := o.CheckAndSetDefaults(); err != nil {
+		return "", err
+	}
+	params := url.Values{}
+	params.Set("_journal_mode", o.JournalMode)
+	params.Set("_busy_timeout", strconv.FormatInt(o.BusyTimeout.Milliseconds(), 10))
+	params.Set("_synchronous", o.Synchronous)
+	params.Set("_txlock", "immediate")
+	return path + "?" + params.Encode(), nil
+}
@@ -0,0 +1,24 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS fill
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    exchange_name_id uuid REFERENCES exchange(id) NOT NULL,
+    base varchar(30) NOT NULL,
+    quote varchar(30) NOT NULL,
+    asset varchar NOT NULL,
+    side varchar NOT NULL,
+    order_id varchar NOT NULL,
+    client_order_id varchar NOT NULL DEFAULT '',
+    trade_id varchar NOT NULL,
+    price DOUBLE PRECISION NOT NULL,
+    amount DOUBLE PRECISION NOT NULL,
+    fee DOUBLE PRECISION NOT NULL DEFAULT 0,
+    fee_asset varchar NOT NULL DEFAULT '',
+    source varchar NOT NULL,
+    timestamp TIMESTAMPTZ NOT NULL,
+    CONSTRAINT uniquefill
+        unique(exchange_name_id, asset, base, quote, trade_id)
+);
+CREATE INDEX fill_exchange_timestamp ON fill (exchange_name_id, asset, timestamp);
+-- +goose Down
+DROP TABLE fill;
@@ -0,0 +1,24 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS fill
+(
+    id text not null primary key,
+    exchange_name_id uuid REFERENCES exchange(id) NOT NULL,
+    base text NOT NULL,
+    quote text NOT NULL,
+    asset TEXT NOT NULL,
+    side TEXT NOT NULL,
+    order_id TEXT NOT NULL,
+    client_order_id TEXT NOT NULL DEFAULT '',
+    trade_id TEXT NOT NULL,
+    price REAL NOT NULL,
+    amount REAL NOT NULL,
+    fee REAL NOT NULL DEFAULT 0,
+    fee_asset TEXT NOT NULL DEFAULT '',
+    source TEXT NOT NULL,
+    timestamp TIMESTAMP NOT NULL,
+    CONSTRAINT uniquefill
+        unique(exchange_name_id, asset, base, quote, trade_id)
+);
+CREATE INDEX fill_exchange_timestamp ON fill (exchange_name_id, asset, timestamp);
+-- +goose Down
+DROP TABLE fill;
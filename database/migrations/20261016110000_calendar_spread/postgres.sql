@@ -0,0 +1,25 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS calendar_spread
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    underlying_base varchar(30) NOT NULL,
+    underlying_quote varchar(30) NOT NULL,
+    perpetual_exchange_name_id uuid REFERENCES exchange(id) NOT NULL,
+    perpetual_asset varchar NOT NULL,
+    perpetual_pair varchar NOT NULL,
+    dated_exchange_name_id uuid REFERENCES exchange(id) NOT NULL,
+    dated_asset varchar NOT NULL,
+    dated_pair varchar NOT NULL,
+    expiry TIMESTAMPTZ NOT NULL,
+    perpetual_price DOUBLE PRECISION NOT NULL,
+    dated_price DOUBLE PRECISION NOT NULL,
+    spread DOUBLE PRECISION NOT NULL,
+    annualised_spread DOUBLE PRECISION NOT NULL,
+    z_score DOUBLE PRECISION NOT NULL DEFAULT 0,
+    timestamp TIMESTAMPTZ NOT NULL,
+    CONSTRAINT uniquecalendarspread
+        unique(perpetual_exchange_name_id, perpetual_asset, perpetual_pair, dated_exchange_name_id, dated_asset, dated_pair, timestamp)
+);
+CREATE INDEX calendar_spread_underlying_timestamp ON calendar_spread (underlying_base, underlying_quote, timestamp);
+-- +goose Down
+DROP TABLE calendar_spread;
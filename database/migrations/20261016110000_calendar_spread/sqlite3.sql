@@ -0,0 +1,25 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS calendar_spread
+(
+    id text not null primary key,
+    underlying_base text NOT NULL,
+    underlying_quote text NOT NULL,
+    perpetual_exchange_name_id uuid REFERENCES exchange(id) NOT NULL,
+    perpetual_asset TEXT NOT NULL,
+    perpetual_pair TEXT NOT NULL,
+    dated_exchange_name_id uuid REFERENCES exchange(id) NOT NULL,
+    dated_asset TEXT NOT NULL,
+    dated_pair TEXT NOT NULL,
+    expiry TIMESTAMP NOT NULL,
+    perpetual_price REAL NOT NULL,
+    dated_price REAL NOT NULL,
+    spread REAL NOT NULL,
+    annualised_spread REAL NOT NULL,
+    z_score REAL NOT NULL DEFAULT 0,
+    timestamp TIMESTAMP NOT NULL,
+    CONSTRAINT uniquecalendarspread
+        unique(perpetual_exchange_name_id, perpetual_asset, perpetual_pair, dated_exchange_name_id, dated_asset, dated_pair, timestamp)
+);
+CREATE INDEX calendar_spread_underlying_timestamp ON calendar_spread (underlying_base, underlying_quote, timestamp);
+-- +goose Down
+DROP TABLE calendar_spread;
@@ -0,0 +1,6 @@
+-- +goose Up
+ALTER TABLE fill ADD COLUMN sequence BIGSERIAL;
+CREATE UNIQUE INDEX fill_sequence ON fill (sequence);
+-- +goose Down
+DROP INDEX fill_sequence;
+ALTER TABLE fill DROP COLUMN sequence;
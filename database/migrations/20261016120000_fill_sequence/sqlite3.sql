@@ -0,0 +1,14 @@
+-- +goose Up
+ALTER TABLE fill ADD COLUMN sequence INTEGER NOT NULL DEFAULT 0;
+UPDATE fill SET sequence = rowid;
+CREATE INDEX fill_sequence ON fill (sequence);
+-- +goose StatementBegin
+CREATE TRIGGER fill_sequence_insert AFTER INSERT ON fill
+BEGIN
+    UPDATE fill SET sequence = NEW.rowid WHERE rowid = NEW.rowid;
+END;
+-- +goose StatementEnd
+-- +goose Down
+DROP TRIGGER fill_sequence_insert;
+DROP INDEX fill_sequence;
+ALTER TABLE fill DROP COLUMN sequence;
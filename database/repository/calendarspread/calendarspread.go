@@ -0,0 +1,205 @@
+package calendarspread
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/repository"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/exchange"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+var columns = []string{
+	"id", "underlying_base", "underlying_quote", "perpetual_exchange_name_id", "perpetual_asset", "perpetual_pair",
+	"dated_exchange_name_id", "dated_asset", "dated_pair", "expiry", "perpetual_price", "dated_price", "spread",
+	"annualised_spread", "z_score", "timestamp",
+}
+
+// Insert saves calendar spread samples to the database. Samples which are
+// already stored are ignored. Missing exchanges are added
+func Insert(spreads ...Data) error {
+	if database.DB.SQL == nil {
+		return database.ErrDatabaseSupportDisabled
+	}
+	for i := range spreads {
+		if spreads[i].PerpetualPair == "" || spreads[i].DatedPair == "" {
+			return errPairNotSet
+		}
+		var err error
+		spreads[i].PerpetualExchangeNameID, err = exchangeNameID(spreads[i].PerpetualExchangeNameID, spreads[i].PerpetualExchange)
+		if err != nil {
+			return err
+		}
+		spreads[i].DatedExchangeNameID, err = exchangeNameID(spreads[i].DatedExchangeNameID, spreads[i].DatedExchange)
+		if err != nil {
+			return err
+		}
+	}
+
+	ctx := context.TODO()
+	tx, err := database.DB.SQL.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginTx %w", err)
+	}
+	defer func() {
+		if err != nil {
+			errRB := tx.Rollback()
+			if errRB != nil {
+				log.Errorf(log.DatabaseMgr, "Insert tx.Rollback %v", errRB)
+			}
+		}
+	}()
+
+	sqlite := isSQLite()
+	stmt, err := tx.PrepareContext(ctx, insertQuery())
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for i := range spreads {
+		if spreads[i].ID == "" {
+			var freshUUID uuid.UUID
+			freshUUID, err = uuid.NewV4()
+			if err != nil {
+				return err
+			}
+			spreads[i].ID = freshUUID.String()
+		}
+		var expiry, ts any = spreads[i].Expiry.UTC(), spreads[i].Timestamp.UTC()
+		if sqlite {
+			expiry, ts = spreads[i].Expiry.UTC().Format(time.RFC3339), spreads[i].Timestamp.UTC().Format(time.RFC3339)
+		}
+		_, err = stmt.ExecContext(ctx,
+			spreads[i].ID,
+			strings.ToUpper(spreads[i].UnderlyingBase),
+			strings.ToUpper(spreads[i].UnderlyingQuote),
+			spreads[i].PerpetualExchangeNameID,
+			strings.ToLower(spreads[i].PerpetualAsset),
+			strings.ToUpper(spreads[i].PerpetualPair),
+			spreads[i].DatedExchangeNameID,
+			strings.ToLower(spreads[i].DatedAsset),
+			strings.ToUpper(spreads[i].DatedPair),
+			expiry,
+			spreads[i].PerpetualPrice,
+			spreads[i].DatedPrice,
+			spreads[i].Spread,
+			spreads[i].AnnualisedSpread,
+			spreads[i].ZScore,
+			ts)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// GetInRange returns stored samples for a perpetual and dated contract between
+// the start and end dates ordered by time
+func GetInRange(perpetualExchange, perpetualAsset, perpetualPair, datedExchange, datedAsset, datedPair string, startDate, endDate time.Time) ([]Data, error) {
+	if database.DB.SQL == nil {
+		return nil, database.ErrDatabaseSupportDisabled
+	}
+	perpetualUUID, err := exchange.UUIDByName(perpetualExchange)
+	if err != nil {
+		return nil, err
+	}
+	datedUUID, err := exchange.UUIDByName(datedExchange)
+	if err != nil {
+		return nil, err
+	}
+	var start, end any = startDate.UTC(), endDate.UTC()
+	if isSQLite() {
+		start, end = startDate.UTC().Format(time.RFC3339), endDate.UTC().Format(time.RFC3339)
+	}
+	rows, err := database.DB.SQL.QueryContext(context.TODO(),
+		"SELECT "+strings.Join(columns, ", ")+" FROM calendar_spread WHERE perpetual_exchange_name_id = "+placeholder(1)+
+			" AND perpetual_asset = "+placeholder(2)+" AND perpetual_pair = "+placeholder(3)+
+			" AND dated_exchange_name_id = "+placeholder(4)+" AND dated_asset = "+placeholder(5)+" AND dated_pair = "+placeholder(6)+
+			" AND timestamp BETWEEN "+placeholder(7)+" AND "+placeholder(8)+" ORDER BY timestamp",
+		perpetualUUID.String(),
+		strings.ToLower(perpetualAsset),
+		strings.ToUpper(perpetualPair),
+		datedUUID.String(),
+		strings.ToLower(datedAsset),
+		strings.ToUpper(datedPair),
+		start,
+		end)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var spreads []Data
+	for rows.Next() {
+		var (
+			d          Data
+			expiry, ts string
+		)
+		err = rows.Scan(&d.ID, &d.UnderlyingBase, &d.UnderlyingQuote, &d.PerpetualExchangeNameID, &d.PerpetualAsset, &d.PerpetualPair,
+			&d.DatedExchangeNameID, &d.DatedAsset, &d.DatedPair, &expiry, &d.PerpetualPrice, &d.DatedPrice, &d.Spread,
+			&d.AnnualisedSpread, &d.ZScore, &ts)
+		if err != nil {
+			return nil, err
+		}
+		d.Expiry, err = time.Parse(time.RFC3339, expiry)
+		if err != nil {
+			return nil, err
+		}
+		d.Timestamp, err = time.Parse(time.RFC3339, ts)
+		if err != nil {
+			return nil, err
+		}
+		d.PerpetualExchange = strings.ToLower(perpetualExchange)
+		d.DatedExchange = strings.ToLower(datedExchange)
+		spreads = append(spreads, d)
+	}
+	return spreads, rows.Err()
+}
+
+// exchangeNameID returns the exchange UUID when set, or looks it up by name,
+// adding the exchange if it has not been stored
+func exchangeNameID(id, name string) (string, error) {
+	if id != "" {
+		return id, nil
+	}
+	if name == "" {
+		return "", errExchangeNotSet
+	}
+	u, err := exchange.UUIDByName(name)
+	if errors.Is(err, exchange.ErrNoExchangeFound) {
+		if err = exchange.Insert(exchange.Details{Name: name}); err != nil {
+			return "", err
+		}
+		u, err = exchange.UUIDByName(name)
+	}
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+// insertQuery returns an insert which ignores stored samples
+func insertQuery() string {
+	values := make([]string, len(columns))
+	for i := range columns {
+		values[i] = placeholder(i + 1)
+	}
+	return "INSERT INTO calendar_spread (" + strings.Join(columns, ", ") + ") VALUES (" + strings.Join(values, ", ") + ")" +
+		" ON CONFLICT (perpetual_exchange_name_id, perpetual_asset, perpetual_pair, dated_exchange_name_id, dated_asset, dated_pair, timestamp) DO NOTHING"
+}
+
+// placeholder returns a positional query parameter. SQLite accepts the
+// Postgres style so a single query serves both dialects
+func placeholder(i int) string {
+	return "$" + strconv.Itoa(i)
+}
+
+func isSQLite() bool {
+	return repository.GetSQLDialect() == database.DBSQLite3 || repository.GetSQLDialect() == database.DBSQLite
+}
@@ -0,0 +1,108 @@
+package calendarspread
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/drivers"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/exchange"
+	"github.com/thrasher-corp/gocryptotrader/database/testhelpers"
+)
+
+var verbose = false
+
+func TestMain(m *testing.M) {
+	if verbose {
+		err := testhelpers.EnableVerboseTestOutput()
+		if err != nil {
+			fmt.Printf("failed to enable verbose test output: %v", err)
+			os.Exit(1)
+		}
+	}
+	var err error
+	testhelpers.PostgresTestDatabase = testhelpers.GetConnectionDetails()
+	testhelpers.TempDir, err = os.MkdirTemp("", "gct-temp")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	exitCode := m.Run()
+	if err = os.RemoveAll(testhelpers.TempDir); err != nil {
+		fmt.Printf("failed to remove temp dir: %s", err)
+	}
+	os.Exit(exitCode)
+}
+
+func TestCalendarSpreads(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config *database.Config
+	}{
+		{
+			name:   "postgresql",
+			config: testhelpers.PostgresTestDatabase,
+		},
+		{
+			name: "SQLite",
+			config: &database.Config{
+				Driver:            database.DBSQLite3,
+				ConnectionDetails: drivers.ConnectionDetails{Database: "./testdb"},
+			},
+		},
+	}
+
+	for x := range testCases {
+		test := testCases[x]
+		t.Run(test.name, func(t *testing.T) {
+			if !testhelpers.CheckValidConfig(&test.config.ConnectionDetails) {
+				t.Skip("database not configured skipping test")
+			}
+			exchange.ResetExchangeCache()
+			dbConn, err := testhelpers.ConnectToDatabase(test.config)
+			require.NoError(t, err)
+			calendarSpreadSQLTester(t)
+			assert.NoError(t, testhelpers.CloseDatabase(dbConn))
+		})
+	}
+}
+
+func calendarSpreadSQLTester(t *testing.T) {
+	t.Helper()
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	expiry := time.Date(2020, 3, 27, 8, 0, 0, 0, time.UTC)
+
+	assert.ErrorIs(t, Insert(Data{PerpetualExchange: "perpexchange"}), errPairNotSet)
+	assert.ErrorIs(t, Insert(Data{PerpetualPair: "BTC-PERP", DatedPair: "BTC-0327"}), errExchangeNotSet)
+
+	// exchanges are not seeded and should be added on insert
+	spreads := []Data{
+		{UnderlyingBase: "btc", UnderlyingQuote: "usd", PerpetualExchange: "perpexchange", PerpetualAsset: "PERPETUALSWAP", PerpetualPair: "btc-perp",
+			DatedExchange: "datedexchange", DatedAsset: "futures", DatedPair: "btc-0327", Expiry: expiry, PerpetualPrice: 100, DatedPrice: 101,
+			Spread: 0.01, AnnualisedSpread: 0.04, Timestamp: start.Add(time.Minute)},
+		{UnderlyingBase: "btc", UnderlyingQuote: "usd", PerpetualExchange: "perpexchange", PerpetualAsset: "perpetualswap", PerpetualPair: "BTC-PERP",
+			DatedExchange: "datedexchange", DatedAsset: "futures", DatedPair: "BTC-0327", Expiry: expiry, PerpetualPrice: 100, DatedPrice: 102,
+			Spread: 0.02, AnnualisedSpread: 0.08, ZScore: 3.5, Timestamp: start.Add(time.Minute * 2)},
+	}
+	require.NoError(t, Insert(spreads...))
+
+	// duplicates are ignored
+	spreads[0].ID, spreads[1].ID = "", ""
+	require.NoError(t, Insert(spreads...))
+
+	results, err := GetInRange("perpexchange", "perpetualswap", "btc-perp", "datedexchange", "futures", "btc-0327", start, start.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, results, 2, "duplicate samples should not be stored")
+	assert.Equal(t, "BTC", results[0].UnderlyingBase)
+	assert.Equal(t, "BTC-PERP", results[0].PerpetualPair)
+	assert.Equal(t, "perpetualswap", results[0].PerpetualAsset)
+	assert.Equal(t, "datedexchange", results[0].DatedExchange)
+	assert.Equal(t, expiry, results[0].Expiry.UTC())
+	assert.Equal(t, start.Add(time.Minute), results[0].Timestamp.UTC())
+	assert.Equal(t, 3.5, results[1].ZScore)
+}
@@ -0,0 +1,34 @@
+package calendarspread
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	errExchangeNotSet = errors.New("exchange name/uuid not set, cannot insert")
+	errPairNotSet     = errors.New("contract pair not set, cannot insert")
+)
+
+// Data defines a calendar spread sample between a perpetual and a dated
+// futures contract for storage in the database
+type Data struct {
+	ID                      string
+	UnderlyingBase          string
+	UnderlyingQuote         string
+	PerpetualExchange       string
+	PerpetualExchangeNameID string
+	PerpetualAsset          string
+	PerpetualPair           string
+	DatedExchange           string
+	DatedExchangeNameID     string
+	DatedAsset              string
+	DatedPair               string
+	Expiry                  time.Time
+	PerpetualPrice          float64
+	DatedPrice              float64
+	Spread                  float64
+	AnnualisedSpread        float64
+	ZScore                  float64
+	Timestamp               time.Time
+}
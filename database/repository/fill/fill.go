@@ -0,0 +1,261 @@
+package fill
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/repository"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/exchange"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+var columns = []string{
+	"id", "exchange_name_id", "base", "quote", "asset", "side", "order_id", "client_order_id",
+	"trade_id", "price", "amount", "fee", "fee_asset", "source", "timestamp",
+}
+
+// Insert saves fills to the database. Fills which are already stored are
+// ignored, except to add a fee which was not known when first saved. Missing
+// exchanges are added. Returns the number of fills inserted or updated
+func Insert(fills ...Data) (int64, error) {
+	if database.DB.SQL == nil {
+		return 0, database.ErrDatabaseSupportDisabled
+	}
+	for i := range fills {
+		if fills[i].TradeID == "" {
+			return 0, errTradeIDNotSet
+		}
+		if fills[i].ExchangeNameID != "" {
+			continue
+		}
+		if fills[i].Exchange == "" {
+			return 0, errExchangeNotSet
+		}
+		exchangeUUID, err := exchangeUUIDByName(fills[i].Exchange)
+		if err != nil {
+			return 0, err
+		}
+		fills[i].ExchangeNameID = exchangeUUID.String()
+	}
+
+	ctx := context.TODO()
+	tx, err := database.DB.SQL.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("beginTx %w", err)
+	}
+	defer func() {
+		if err != nil {
+			errRB := tx.Rollback()
+			if errRB != nil {
+				log.Errorf(log.DatabaseMgr, "Insert tx.Rollback %v", errRB)
+			}
+		}
+	}()
+
+	sqlite := isSQLite()
+	stmt, err := tx.PrepareContext(ctx, insertQuery())
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	var stored int64
+	for i := range fills {
+		if fills[i].ID == "" {
+			var freshUUID uuid.UUID
+			freshUUID, err = uuid.NewV4()
+			if err != nil {
+				return 0, err
+			}
+			fills[i].ID = freshUUID.String()
+		}
+		var ts any = fills[i].Timestamp.UTC()
+		if sqlite {
+			ts = fills[i].Timestamp.UTC().Format(time.RFC3339)
+		}
+		var res sql.Result
+		res, err = stmt.ExecContext(ctx,
+			fills[i].ID,
+			fills[i].ExchangeNameID,
+			strings.ToUpper(fills[i].Base),
+			strings.ToUpper(fills[i].Quote),
+			strings.ToLower(fills[i].AssetType),
+			strings.ToUpper(fills[i].Side),
+			fills[i].OrderID,
+			fills[i].ClientOrderID,
+			fills[i].TradeID,
+			fills[i].Price,
+			fills[i].Amount,
+			fills[i].Fee,
+			strings.ToUpper(fills[i].FeeAsset),
+			fills[i].Source,
+			ts)
+		if err != nil {
+			return 0, err
+		}
+		var affected int64
+		affected, err = res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		stored += affected
+	}
+	return stored, tx.Commit()
+}
+
+// GetLatestTimestamp returns the time of the most recent stored fill for an
+// exchange asset, or a zero time when none are stored
+func GetLatestTimestamp(exchangeName, assetType string) (time.Time, error) {
+	if database.DB.SQL == nil {
+		return time.Time{}, database.ErrDatabaseSupportDisabled
+	}
+	exchangeUUID, err := exchange.UUIDByName(exchangeName)
+	if err != nil {
+		if errors.Is(err, exchange.ErrNoExchangeFound) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, err
+	}
+	var ts string
+	err = database.DB.SQL.QueryRowContext(context.TODO(),
+		"SELECT timestamp FROM fill WHERE exchange_name_id = "+placeholder(1)+" AND asset = "+placeholder(2)+" ORDER BY timestamp DESC LIMIT 1",
+		exchangeUUID.String(),
+		strings.ToLower(assetType)).Scan(&ts)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, err
+	}
+	return time.Parse(time.RFC3339, ts)
+}
+
+// GetInRange returns stored fills for an exchange asset between the start and
+// end dates ordered by time
+func GetInRange(exchangeName, assetType string, startDate, endDate time.Time) ([]Data, error) {
+	if database.DB.SQL == nil {
+		return nil, database.ErrDatabaseSupportDisabled
+	}
+	exchangeUUID, err := exchange.UUIDByName(exchangeName)
+	if err != nil {
+		return nil, err
+	}
+	var start, end any = startDate.UTC(), endDate.UTC()
+	if isSQLite() {
+		start, end = startDate.UTC().Format(time.RFC3339), endDate.UTC().Format(time.RFC3339)
+	}
+	rows, err := database.DB.SQL.QueryContext(context.TODO(),
+		"SELECT "+strings.Join(columns, ", ")+" FROM fill WHERE exchange_name_id = "+placeholder(1)+
+			" AND asset = "+placeholder(2)+" AND timestamp BETWEEN "+placeholder(3)+" AND "+placeholder(4)+" ORDER BY timestamp",
+		exchangeUUID.String(),
+		strings.ToLower(assetType),
+		start,
+		end)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var fills []Data
+	for rows.Next() {
+		var (
+			d  Data
+			ts string
+		)
+		err = rows.Scan(&d.ID, &d.ExchangeNameID, &d.Base, &d.Quote, &d.AssetType, &d.Side, &d.OrderID, &d.ClientOrderID,
+			&d.TradeID, &d.Price, &d.Amount, &d.Fee, &d.FeeAsset, &d.Source, &ts)
+		if err != nil {
+			return nil, err
+		}
+		d.Timestamp, err = time.Parse(time.RFC3339, ts)
+		if err != nil {
+			return nil, err
+		}
+		d.Exchange = strings.ToLower(exchangeName)
+		fills = append(fills, d)
+	}
+	return fills, rows.Err()
+}
+
+// GetSinceSequence returns up to limit stored fills across all exchanges with
+// a sequence greater than the one supplied, ordered by sequence
+func GetSinceSequence(sequence int64, limit int) ([]Data, error) {
+	if database.DB.SQL == nil {
+		return nil, database.ErrDatabaseSupportDisabled
+	}
+	if limit <= 0 {
+		return nil, errInvalidLimit
+	}
+	selected := make([]string, len(columns))
+	for i := range columns {
+		selected[i] = "f." + columns[i]
+	}
+	rows, err := database.DB.SQL.QueryContext(context.TODO(),
+		"SELECT f.sequence, e.name, "+strings.Join(selected, ", ")+" FROM fill f JOIN exchange e ON e.id = f.exchange_name_id"+
+			" WHERE f.sequence > "+placeholder(1)+" ORDER BY f.sequence LIMIT "+placeholder(2),
+		sequence,
+		limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var fills []Data
+	for rows.Next() {
+		var (
+			d  Data
+			ts string
+		)
+		err = rows.Scan(&d.Sequence, &d.Exchange, &d.ID, &d.ExchangeNameID, &d.Base, &d.Quote, &d.AssetType, &d.Side, &d.OrderID, &d.ClientOrderID,
+			&d.TradeID, &d.Price, &d.Amount, &d.Fee, &d.FeeAsset, &d.Source, &ts)
+		if err != nil {
+			return nil, err
+		}
+		d.Timestamp, err = time.Parse(time.RFC3339, ts)
+		if err != nil {
+			return nil, err
+		}
+		fills = append(fills, d)
+	}
+	return fills, rows.Err()
+}
+
+// exchangeUUIDByName returns the UUID of an exchange, adding the exchange if
+// it has not been stored
+func exchangeUUIDByName(name string) (uuid.UUID, error) {
+	u, err := exchange.UUIDByName(name)
+	if !errors.Is(err, exchange.ErrNoExchangeFound) {
+		return u, err
+	}
+	if err = exchange.Insert(exchange.Details{Name: name}); err != nil {
+		return uuid.UUID{}, err
+	}
+	return exchange.UUIDByName(name)
+}
+
+// insertQuery returns an insert which ignores stored fills unless a fee can be
+// added to them
+func insertQuery() string {
+	values := make([]string, len(columns))
+	for i := range columns {
+		values[i] = placeholder(i + 1)
+	}
+	return "INSERT INTO fill (" + strings.Join(columns, ", ") + ") VALUES (" + strings.Join(values, ", ") + ")" +
+		" ON CONFLICT (exchange_name_id, asset, base, quote, trade_id) DO UPDATE SET fee = excluded.fee, fee_asset = excluded.fee_asset" +
+		" WHERE fill.fee = 0 AND excluded.fee <> 0"
+}
+
+// placeholder returns a positional query parameter. SQLite accepts the
+// Postgres style so a single query serves both dialects
+func placeholder(i int) string {
+	return "$" + strconv.Itoa(i)
+}
+
+func isSQLite() bool {
+	return repository.GetSQLDialect() == database.DBSQLite3 || repository.GetSQLDialect() == database.DBSQLite
+}
@@ -0,0 +1,134 @@
+package fill
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/drivers"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/exchange"
+	"github.com/thrasher-corp/gocryptotrader/database/testhelpers"
+)
+
+var verbose = false
+
+func TestMain(m *testing.M) {
+	if verbose {
+		err := testhelpers.EnableVerboseTestOutput()
+		if err != nil {
+			fmt.Printf("failed to enable verbose test output: %v", err)
+			os.Exit(1)
+		}
+	}
+	var err error
+	testhelpers.PostgresTestDatabase = testhelpers.GetConnectionDetails()
+	testhelpers.TempDir, err = os.MkdirTemp("", "gct-temp")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	exitCode := m.Run()
+	if err = os.RemoveAll(testhelpers.TempDir); err != nil {
+		fmt.Printf("failed to remove temp dir: %s", err)
+	}
+	os.Exit(exitCode)
+}
+
+func TestFills(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config *database.Config
+	}{
+		{
+			name:   "postgresql",
+			config: testhelpers.PostgresTestDatabase,
+		},
+		{
+			name: "SQLite",
+			config: &database.Config{
+				Driver:            database.DBSQLite3,
+				ConnectionDetails: drivers.ConnectionDetails{Database: "./testdb"},
+			},
+		},
+	}
+
+	for x := range testCases {
+		test := testCases[x]
+		t.Run(test.name, func(t *testing.T) {
+			if !testhelpers.CheckValidConfig(&test.config.ConnectionDetails) {
+				t.Skip("database not configured skipping test")
+			}
+			exchange.ResetExchangeCache()
+			dbConn, err := testhelpers.ConnectToDatabase(test.config)
+			require.NoError(t, err)
+			fillSQLTester(t)
+			assert.NoError(t, testhelpers.CloseDatabase(dbConn))
+		})
+	}
+}
+
+func fillSQLTester(t *testing.T) {
+	t.Helper()
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	latest, err := GetLatestTimestamp("fillexchange", "spot")
+	require.NoError(t, err)
+	assert.True(t, latest.IsZero(), "no stored fills should return a zero time")
+
+	_, err = Insert(Data{Exchange: "fillexchange"})
+	assert.ErrorIs(t, err, errTradeIDNotSet)
+	_, err = Insert(Data{TradeID: "1"})
+	assert.ErrorIs(t, err, errExchangeNotSet)
+
+	// the exchange is not seeded and should be added on insert
+	fills := []Data{
+		{Exchange: "fillexchange", Base: "btc", Quote: "usdt", AssetType: "SPOT", Side: "buy", OrderID: "1", TradeID: "1", Price: 100, Amount: 1, Source: SourceWebsocket, Timestamp: start.Add(time.Minute)},
+		{Exchange: "fillexchange", Base: "btc", Quote: "usdt", AssetType: "spot", Side: "sell", OrderID: "2", TradeID: "2", Price: 110, Amount: 1, Fee: 0.1, FeeAsset: "usdt", Source: SourceREST, Timestamp: start.Add(time.Minute * 2)},
+	}
+	stored, err := Insert(fills...)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), stored)
+
+	// duplicates are ignored unless they add a missing fee
+	fills[0].ID, fills[1].ID = "", ""
+	fills[0].Fee, fills[0].FeeAsset, fills[0].Source = 0.2, "usdt", SourceREST
+	stored, err = Insert(fills...)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), stored, "only the fill missing a fee should be updated")
+
+	latest, err = GetLatestTimestamp("fillexchange", "spot")
+	require.NoError(t, err)
+	assert.Equal(t, start.Add(time.Minute*2), latest.UTC())
+
+	results, err := GetInRange("fillexchange", "spot", start, start.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "BTC", results[0].Base)
+	assert.Equal(t, "BUY", results[0].Side)
+	assert.Equal(t, 0.2, results[0].Fee, "missing fee should be added")
+	assert.Equal(t, SourceWebsocket, results[0].Source, "existing fill should retain its source")
+	assert.Equal(t, start.Add(time.Minute), results[0].Timestamp.UTC())
+
+	_, err = GetSinceSequence(0, 0)
+	assert.ErrorIs(t, err, errInvalidLimit)
+	sequenced, err := GetSinceSequence(0, 10)
+	require.NoError(t, err)
+	require.Len(t, sequenced, 2)
+	assert.Equal(t, "fillexchange", sequenced[0].Exchange)
+	assert.Equal(t, "1", sequenced[0].TradeID)
+	assert.Greater(t, sequenced[1].Sequence, sequenced[0].Sequence, "sequence must increase with each stored fill")
+
+	_, err = Insert(Data{Exchange: "fillexchange", Base: "eth", Quote: "usdt", AssetType: "spot", Side: "buy", OrderID: "3", TradeID: "3", Price: 10, Amount: 1, Source: SourceREST, Timestamp: start})
+	require.NoError(t, err)
+	sequenced, err = GetSinceSequence(sequenced[1].Sequence, 10)
+	require.NoError(t, err)
+	require.Len(t, sequenced, 1, "only fills stored after the sequence should be returned")
+	assert.Equal(t, "3", sequenced[0].TradeID)
+	sequenced, err = GetSinceSequence(0, 1)
+	require.NoError(t, err)
+	assert.Len(t, sequenced, 1, "results should be limited")
+}
@@ -0,0 +1,41 @@
+package fill
+
+import (
+	"errors"
+	"time"
+)
+
+// Fill sources
+const (
+	SourceREST      = "rest"
+	SourceWebsocket = "websocket"
+)
+
+var (
+	errExchangeNotSet = errors.New("exchange name/uuid not set, cannot insert")
+	errTradeIDNotSet  = errors.New("trade id not set, cannot insert")
+	errInvalidLimit   = errors.New("limit must be greater than zero")
+)
+
+// Data defines a user fill for storage in the database
+type Data struct {
+	ID             string
+	Exchange       string
+	ExchangeNameID string
+	Base           string
+	Quote          string
+	AssetType      string
+	Side           string
+	OrderID        string
+	ClientOrderID  string
+	TradeID        string
+	Price          float64
+	Amount         float64
+	Fee            float64
+	FeeAsset       string
+	Source         string
+	Timestamp      time.Time
+	// Sequence increases with each stored fill and is only populated when
+	// fills are read by sequence
+	Sequence int64
+}
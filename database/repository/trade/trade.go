@@ -340,6 +340,9 @@ func getInRangeSQLite(exchangeName, assetType, base, quote string, startDate, en
 		if result[i].Side.Valid {
 			t.Side = result[i].Side.String
 		}
+		if result[i].Tid.Valid {
+			t.TID = result[i].Tid.String
+		}
 		td = append(td, t)
 	}
 	return td, nil
@@ -379,6 +382,9 @@ func getInRangePostgres(exchangeName, assetType, base, quote string, startDate,
 		if result[i].Side.Valid {
 			t.Side = result[i].Side.String
 		}
+		if result[i].Tid.Valid {
+			t.TID = result[i].Tid.String
+		}
 		td = append(td, t)
 	}
 	return td, nil
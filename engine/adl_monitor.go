@@ -0,0 +1,288 @@
+package engine
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// SetupADLMonitor creates an ADL monitor subsystem
+func SetupADLMonitor(cfg *config.ADLMonitor, em iExchangeManager, comms iCommsManager) (*ADLMonitor, error) {
+	if cfg == nil {
+		return nil, errNilConfig
+	}
+	if em == nil {
+		return nil, errNilExchangeManager
+	}
+	if comms == nil {
+		return nil, errNilCommunicationsManager
+	}
+	if cfg.CheckInterval <= 0 {
+		return nil, fmt.Errorf("%w %v", errInvalidADLCheckInterval, cfg.CheckInterval)
+	}
+	if cfg.CriticalThreshold <= 0 || cfg.CriticalThreshold > 1 {
+		return nil, fmt.Errorf("%w critical threshold %v must be above zero and not exceed 1", errInvalidADLThreshold, cfg.CriticalThreshold)
+	}
+	if cfg.WarningThreshold <= 0 || cfg.WarningThreshold > cfg.CriticalThreshold {
+		return nil, fmt.Errorf("%w warning threshold %v must be above zero and not exceed critical threshold %v",
+			errInvalidADLThreshold, cfg.WarningThreshold, cfg.CriticalThreshold)
+	}
+	return &ADLMonitor{
+		verbose:           cfg.Verbose,
+		interval:          cfg.CheckInterval,
+		warningThreshold:  cfg.WarningThreshold,
+		criticalThreshold: cfg.CriticalThreshold,
+		exchangeManager:   em,
+		comms:             comms,
+		funds:             make(map[string]futures.InsuranceFund),
+		positions:         make(map[string]*adlPosition),
+	}, nil
+}
+
+// Start runs the subsystem
+func (m *ADLMonitor) Start() error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 0, 1) {
+		return ErrSubSystemAlreadyStarted
+	}
+	m.shutdown = make(chan struct{})
+	m.wg.Add(1)
+	go m.run()
+	log.Debugf(log.OrderMgr, "ADL monitor %s", MsgSubSystemStarted)
+	return nil
+}
+
+// IsRunning checks whether the subsystem is running
+func (m *ADLMonitor) IsRunning() bool {
+	if m == nil {
+		return false
+	}
+	return atomic.LoadInt32(&m.started) == 1
+}
+
+// Stop stops the subsystem
+func (m *ADLMonitor) Stop() error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 1, 0) {
+		return ErrSubSystemNotStarted
+	}
+	close(m.shutdown)
+	m.wg.Wait()
+	log.Debugf(log.OrderMgr, "ADL monitor %s", MsgSubSystemShutdown)
+	return nil
+}
+
+func (m *ADLMonitor) run() {
+	defer m.wg.Done()
+	runScheduledJobs(m.shutdown, &scheduledJob{interval: m.interval, immediate: true, run: func(ctx context.Context, _ time.Time) { m.check(ctx) }})
+}
+
+// check polls the enabled futures assets of every exchange for insurance
+// fund balances and ADL indicators
+func (m *ADLMonitor) check(ctx context.Context) {
+	exchanges, err := m.exchangeManager.GetExchanges()
+	if err != nil {
+		log.Errorf(log.OrderMgr, "ADL monitor cannot get exchanges: %v", err)
+		return
+	}
+	for x := range exchanges {
+		assets := exchanges[x].GetAssetTypes(true)
+		for y := range assets {
+			if !assets[y].IsFutures() {
+				continue
+			}
+			m.checkAsset(ctx, exchanges[x], assets[y])
+		}
+	}
+}
+
+// checkAsset updates the insurance funds and ADL indicators of an exchange
+// asset, ignoring exchanges which do not expose them
+func (m *ADLMonitor) checkAsset(ctx context.Context, exch exchange.IBotExchange, a asset.Item) {
+	funds, err := exch.GetInsuranceFunds(ctx, a)
+	switch {
+	case err == nil:
+		m.updateFunds(funds)
+	case !isFeatureUnsupported(err):
+		log.Errorf(log.OrderMgr, "ADL monitor cannot get %s %s insurance funds: %v", exch.GetName(), a, err)
+	}
+	if !exch.IsRESTAuthenticationSupported() {
+		return
+	}
+	indicators, err := exch.GetADLIndicators(ctx, a)
+	switch {
+	case err == nil:
+		m.updateIndicators(exch.GetName(), a, indicators)
+	case !isFeatureUnsupported(err):
+		log.Errorf(log.OrderMgr, "ADL monitor cannot get %s %s ADL indicators: %v", exch.GetName(), a, err)
+	}
+}
+
+// updateFunds stores the latest insurance fund balances
+func (m *ADLMonitor) updateFunds(funds []futures.InsuranceFund) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	for i := range funds {
+		if m.verbose {
+			log.Debugf(log.OrderMgr, "ADL monitor %s %s %s insurance fund balance %v value %v",
+				funds[i].Exchange, funds[i].Asset, funds[i].Currency, funds[i].Balance, funds[i].Value)
+		}
+		m.funds[strings.ToLower(funds[i].Exchange)+":"+funds[i].Asset.String()+":"+funds[i].Currency.Lower().String()] = funds[i]
+	}
+}
+
+// updateIndicators records the ADL indicators of an exchange asset's held
+// positions, alerting when a position's ADL level changes. Positions which
+// are no longer reported have been closed and their alerts are resolved
+func (m *ADLMonitor) updateIndicators(exchName string, a asset.Item, indicators []futures.ADLIndicator) {
+	prefix := strings.ToLower(exchName) + ":" + a.String() + ":"
+	seen := make(map[string]struct{}, len(indicators))
+	for i := range indicators {
+		k := adlPositionKey(&indicators[i])
+		seen[k] = struct{}{}
+		level := m.adlLevel(indicators[i].Risk())
+		if m.verbose {
+			log.Debugf(log.OrderMgr, "ADL monitor %s %s %s %s rank %d of %d",
+				indicators[i].Exchange, indicators[i].Asset, indicators[i].Pair, indicators[i].Side, indicators[i].Rank, indicators[i].MaxRank)
+		}
+		m.m.Lock()
+		pos, ok := m.positions[k]
+		if !ok {
+			pos = &adlPosition{}
+			m.positions[k] = pos
+		}
+		pos.indicator = indicators[i]
+		previous := pos.level
+		pos.level = level
+		m.m.Unlock()
+		if level != previous {
+			m.alert(&indicators[i], k, level)
+		}
+	}
+
+	var closed []adlPosition
+	m.m.Lock()
+	for k, pos := range m.positions {
+		if _, ok := seen[k]; ok || !strings.HasPrefix(k, prefix) {
+			continue
+		}
+		delete(m.positions, k)
+		if pos.level != adlHealthy {
+			closed = append(closed, *pos)
+		}
+	}
+	m.m.Unlock()
+	for i := range closed {
+		m.alert(&closed[i].indicator, adlPositionKey(&closed[i].indicator), adlHealthy)
+	}
+}
+
+// adlLevel returns the level of the highest threshold crossed
+func (m *ADLMonitor) adlLevel(risk float64) adlLevel {
+	switch {
+	case risk >= m.criticalThreshold:
+		return adlCritical
+	case risk >= m.warningThreshold:
+		return adlWarning
+	default:
+		return adlHealthy
+	}
+}
+
+// alert sends a communications event for a change in ADL level, resolving
+// the alert when the position's risk falls or the position is closed
+func (m *ADLMonitor) alert(ind *futures.ADLIndicator, k string, level adlLevel) {
+	rank := fmt.Sprintf("rank %d of %d", ind.Rank, ind.MaxRank)
+	e := base.Event{
+		Type:     adlEventType,
+		Exchange: ind.Exchange,
+		Key:      adlEventType + ":" + k,
+	}
+	switch level {
+	case adlHealthy:
+		e.Severity = base.SeverityInfo
+		e.Resolved = true
+		e.Message = fmt.Sprintf("ADL monitor %s %s %s %s position auto-deleverage risk recovered", ind.Exchange, ind.Asset, ind.Pair, ind.Side)
+		log.Infoln(log.OrderMgr, e.Message)
+	case adlWarning:
+		e.Severity = base.SeverityWarning
+		e.Message = fmt.Sprintf("ADL monitor %s %s %s %s position auto-deleverage warning threshold %v reached: %s", ind.Exchange, ind.Asset, ind.Pair, ind.Side, m.warningThreshold, rank)
+		log.Warnln(log.OrderMgr, e.Message)
+	case adlCritical:
+		e.Severity = base.SeverityCritical
+		e.Message = fmt.Sprintf("ADL monitor %s %s %s %s position auto-deleverage critical threshold %v reached: %s", ind.Exchange, ind.Asset, ind.Pair, ind.Side, m.criticalThreshold, rank)
+		log.Errorln(log.OrderMgr, e.Message)
+	}
+	m.comms.PushEvent(e)
+}
+
+// GetInsuranceFunds returns the latest insurance fund balances sorted by
+// exchange, asset and currency
+func (m *ADLMonitor) GetInsuranceFunds() ([]futures.InsuranceFund, error) {
+	if m == nil {
+		return nil, fmt.Errorf("%s %w", ADLMonitorName, ErrNilSubsystem)
+	}
+	if !m.IsRunning() {
+		return nil, fmt.Errorf("%s %w", ADLMonitorName, ErrSubSystemNotStarted)
+	}
+	m.m.Lock()
+	funds := make([]futures.InsuranceFund, 0, len(m.funds))
+	for _, f := range m.funds {
+		funds = append(funds, f)
+	}
+	m.m.Unlock()
+	slices.SortFunc(funds, func(a, b futures.InsuranceFund) int {
+		if c := strings.Compare(a.Exchange, b.Exchange); c != 0 {
+			return c
+		}
+		if a.Asset != b.Asset {
+			return strings.Compare(a.Asset.String(), b.Asset.String())
+		}
+		return strings.Compare(a.Currency.String(), b.Currency.String())
+	})
+	return funds, nil
+}
+
+// GetADLIndicators returns the latest ADL indicators of held positions with
+// the highest risk first
+func (m *ADLMonitor) GetADLIndicators() ([]futures.ADLIndicator, error) {
+	if m == nil {
+		return nil, fmt.Errorf("%s %w", ADLMonitorName, ErrNilSubsystem)
+	}
+	if !m.IsRunning() {
+		return nil, fmt.Errorf("%s %w", ADLMonitorName, ErrSubSystemNotStarted)
+	}
+	m.m.Lock()
+	indicators := make([]futures.ADLIndicator, 0, len(m.positions))
+	for _, pos := range m.positions {
+		indicators = append(indicators, pos.indicator)
+	}
+	m.m.Unlock()
+	slices.SortFunc(indicators, func(a, b futures.ADLIndicator) int {
+		if c := cmp.Compare(b.Risk(), a.Risk()); c != 0 {
+			return c
+		}
+		return strings.Compare(adlPositionKey(&a), adlPositionKey(&b))
+	})
+	return indicators, nil
+}
+
+// adlPositionKey returns a key unique to an exchange position
+func adlPositionKey(ind *futures.ADLIndicator) string {
+	return strings.ToLower(ind.Exchange) + ":" + ind.Asset.String() + ":" + ind.Pair.Lower().String() + ":" + ind.Side.Lower()
+}
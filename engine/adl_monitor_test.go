@@ -0,0 +1,193 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// adlExchange is a fake exchange exposing insurance funds and ADL indicators
+type adlExchange struct {
+	exchange.IBotExchange
+	name       string
+	funds      []futures.InsuranceFund
+	indicators []futures.ADLIndicator
+}
+
+func (f *adlExchange) GetName() string {
+	return f.name
+}
+
+func (f *adlExchange) GetAssetTypes(bool) asset.Items {
+	return asset.Items{asset.Spot, asset.USDTMarginedFutures}
+}
+
+func (f *adlExchange) IsRESTAuthenticationSupported() bool {
+	return true
+}
+
+func (f *adlExchange) GetInsuranceFunds(_ context.Context, a asset.Item) ([]futures.InsuranceFund, error) {
+	if a != asset.USDTMarginedFutures {
+		return nil, asset.ErrNotSupported
+	}
+	return f.funds, nil
+}
+
+func (f *adlExchange) GetADLIndicators(_ context.Context, a asset.Item) ([]futures.ADLIndicator, error) {
+	if a != asset.USDTMarginedFutures {
+		return nil, common.ErrFunctionNotSupported
+	}
+	return f.indicators, nil
+}
+
+func testADLMonitorConfig() *config.ADLMonitor {
+	return &config.ADLMonitor{
+		CheckInterval:     time.Hour,
+		WarningThreshold:  0.6,
+		CriticalThreshold: 0.8,
+	}
+}
+
+func testADLIndicator(p currency.Pair, rank int64) futures.ADLIndicator {
+	return futures.ADLIndicator{
+		Exchange: "adl",
+		Asset:    asset.USDTMarginedFutures,
+		Pair:     p,
+		Side:     order.Long,
+		Rank:     rank,
+		MaxRank:  5,
+	}
+}
+
+func testADLMonitorSetup(t *testing.T) (*ADLMonitor, *adlExchange, *fakeComms) {
+	t.Helper()
+	exch := &adlExchange{
+		name: "adl",
+		funds: []futures.InsuranceFund{
+			{Exchange: "adl", Asset: asset.USDTMarginedFutures, Currency: currency.USDT, Balance: 1000},
+			{Exchange: "adl", Asset: asset.USDTMarginedFutures, Currency: currency.BTC, Balance: 1},
+		},
+	}
+	em := NewExchangeManager()
+	require.NoError(t, em.Add(exch))
+	comms := &fakeComms{}
+	m, err := SetupADLMonitor(testADLMonitorConfig(), em, comms)
+	require.NoError(t, err)
+	return m, exch, comms
+}
+
+func TestSetupADLMonitor(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	_, err := SetupADLMonitor(nil, nil, nil)
+	assert.ErrorIs(t, err, errNilConfig)
+	_, err = SetupADLMonitor(&config.ADLMonitor{}, nil, nil)
+	assert.ErrorIs(t, err, errNilExchangeManager)
+	_, err = SetupADLMonitor(&config.ADLMonitor{}, em, nil)
+	assert.ErrorIs(t, err, errNilCommunicationsManager)
+	_, err = SetupADLMonitor(&config.ADLMonitor{}, em, &fakeComms{})
+	assert.ErrorIs(t, err, errInvalidADLCheckInterval)
+	cfg := testADLMonitorConfig()
+	cfg.CriticalThreshold = 1.5
+	_, err = SetupADLMonitor(cfg, em, &fakeComms{})
+	assert.ErrorIs(t, err, errInvalidADLThreshold, "critical threshold above 1 should error")
+	cfg = testADLMonitorConfig()
+	cfg.WarningThreshold = 0.9
+	_, err = SetupADLMonitor(cfg, em, &fakeComms{})
+	assert.ErrorIs(t, err, errInvalidADLThreshold, "warning threshold above critical threshold should error")
+	m, err := SetupADLMonitor(testADLMonitorConfig(), em, &fakeComms{})
+	require.NoError(t, err)
+	assert.NotNil(t, m)
+}
+
+func TestADLMonitorStartStop(t *testing.T) {
+	t.Parallel()
+	var m *ADLMonitor
+	assert.ErrorIs(t, m.Start(), ErrNilSubsystem)
+	assert.ErrorIs(t, m.Stop(), ErrNilSubsystem)
+	assert.False(t, m.IsRunning())
+
+	m, _, _ = testADLMonitorSetup(t)
+	assert.ErrorIs(t, m.Stop(), ErrSubSystemNotStarted)
+	require.NoError(t, m.Start())
+	assert.True(t, m.IsRunning())
+	assert.ErrorIs(t, m.Start(), ErrSubSystemAlreadyStarted)
+	require.NoError(t, m.Stop())
+	assert.False(t, m.IsRunning())
+}
+
+func TestADLMonitorCheck(t *testing.T) {
+	t.Parallel()
+	m, exch, comms := testADLMonitorSetup(t)
+	eth := currency.NewPair(currency.ETH, currency.USDT)
+	exch.indicators = []futures.ADLIndicator{
+		testADLIndicator(currency.NewBTCUSDT(), 1),
+		testADLIndicator(eth, 4),
+	}
+	m.check(context.Background())
+	require.Len(t, comms.events, 1, "only positions above the warning threshold should alert")
+	assert.Equal(t, base.SeverityCritical, comms.events[0].Severity)
+	assert.Equal(t, adlEventType, comms.events[0].Type)
+
+	m.check(context.Background())
+	assert.Len(t, comms.events, 1, "unchanged ADL levels should not alert again")
+
+	exch.indicators[0].Rank = 3
+	m.check(context.Background())
+	require.Len(t, comms.events, 2)
+	assert.Equal(t, base.SeverityWarning, comms.events[1].Severity)
+	assert.NotEqual(t, comms.events[0].Key, comms.events[1].Key, "alerts for different positions must not share a key")
+
+	exch.indicators = exch.indicators[:1]
+	m.check(context.Background())
+	require.Len(t, comms.events, 3)
+	assert.True(t, comms.events[2].Resolved, "closing a position must resolve its alert")
+	assert.Equal(t, comms.events[0].Key, comms.events[2].Key)
+
+	exch.indicators[0].Rank = 1
+	m.check(context.Background())
+	require.Len(t, comms.events, 4)
+	assert.True(t, comms.events[3].Resolved, "falling ADL risk must resolve the alert")
+}
+
+func TestADLMonitorGetters(t *testing.T) {
+	t.Parallel()
+	m, exch, _ := testADLMonitorSetup(t)
+	_, err := m.GetInsuranceFunds()
+	assert.ErrorIs(t, err, ErrSubSystemNotStarted)
+	_, err = m.GetADLIndicators()
+	assert.ErrorIs(t, err, ErrSubSystemNotStarted)
+	var nilMonitor *ADLMonitor
+	_, err = nilMonitor.GetInsuranceFunds()
+	assert.ErrorIs(t, err, ErrNilSubsystem)
+	_, err = nilMonitor.GetADLIndicators()
+	assert.ErrorIs(t, err, ErrNilSubsystem)
+
+	exch.indicators = []futures.ADLIndicator{
+		testADLIndicator(currency.NewBTCUSDT(), 1),
+		testADLIndicator(currency.NewPair(currency.ETH, currency.USDT), 4),
+	}
+	m.check(context.Background())
+	m.started = 1
+
+	funds, err := m.GetInsuranceFunds()
+	require.NoError(t, err)
+	require.Len(t, funds, 2)
+	assert.Equal(t, currency.BTC, funds[0].Currency, "insurance funds should be sorted by currency")
+
+	indicators, err := m.GetADLIndicators()
+	require.NoError(t, err)
+	require.Len(t, indicators, 2)
+	assert.Equal(t, int64(4), indicators[0].Rank, "the highest risk position should be first")
+}
@@ -0,0 +1,53 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
+)
+
+// ADLMonitorName is an exported subsystem name
+const ADLMonitorName = "adl_monitor"
+
+// adlEventType is the communications event type used for ADL alerts
+const adlEventType = "adl"
+
+var (
+	errInvalidADLCheckInterval = errors.New("ADL check interval must be greater than zero")
+	errInvalidADLThreshold     = errors.New("invalid ADL threshold")
+)
+
+// adlLevel defines how close a position is to being auto-deleveraged
+type adlLevel uint8
+
+const (
+	adlHealthy adlLevel = iota
+	adlWarning
+	adlCritical
+)
+
+// ADLMonitor polls exchanges for insurance fund balances and the
+// auto-deleveraging queue position of held positions, alerting when a
+// position's ADL risk rises
+type ADLMonitor struct {
+	started           int32
+	shutdown          chan struct{}
+	wg                sync.WaitGroup
+	verbose           bool
+	interval          time.Duration
+	warningThreshold  float64
+	criticalThreshold float64
+	exchangeManager   iExchangeManager
+	comms             iCommsManager
+	m                 sync.Mutex
+	funds             map[string]futures.InsuranceFund
+	positions         map[string]*adlPosition
+}
+
+// adlPosition holds the latest ADL indicator of a held position
+type adlPosition struct {
+	indicator futures.ADLIndicator
+	level     adlLevel
+}
@@ -0,0 +1,340 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	gctmath "github.com/thrasher-corp/gocryptotrader/common/math"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// SetupAnomalyDetector creates an anomaly detector subsystem
+func SetupAnomalyDetector(cfg *config.AnomalyDetector, comms iCommsManager) (*AnomalyDetector, error) {
+	if cfg == nil {
+		return nil, errNilConfig
+	}
+	if comms == nil {
+		return nil, errNilCommunicationsManager
+	}
+	if cfg.MaxDeviation <= 0 || cfg.MinDeviation <= 0 {
+		return nil, fmt.Errorf("%w max deviation %v and min deviation %v must be above zero",
+			errInvalidAnomalyDetectorConfig, cfg.MaxDeviation, cfg.MinDeviation)
+	}
+	if cfg.Window <= 1 || cfg.MinSamples <= 1 || cfg.MinSamples > cfg.Window {
+		return nil, fmt.Errorf("%w min samples %v must be above one and not exceed window %v",
+			errInvalidAnomalyDetectorConfig, cfg.MinSamples, cfg.Window)
+	}
+	if cfg.StaleAfter <= 0 || cfg.QuarantinePeriod <= 0 {
+		return nil, fmt.Errorf("%w stale after %v and quarantine period %v must be above zero",
+			errInvalidAnomalyDetectorConfig, cfg.StaleAfter, cfg.QuarantinePeriod)
+	}
+	return &AnomalyDetector{
+		verbose:          cfg.Verbose,
+		maxDeviation:     cfg.MaxDeviation,
+		minDeviation:     cfg.MinDeviation,
+		window:           cfg.Window,
+		minSamples:       cfg.MinSamples,
+		staleAfter:       cfg.StaleAfter,
+		quarantinePeriod: cfg.QuarantinePeriod,
+		comms:            comms,
+		markets:          make(map[string]*anomalyMarket),
+		quarantined:      make(map[string]*Quarantine),
+	}, nil
+}
+
+// Start runs the subsystem
+func (m *AnomalyDetector) Start() error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 0, 1) {
+		return ErrSubSystemAlreadyStarted
+	}
+	m.shutdown = make(chan struct{})
+	m.wg.Add(1)
+	go m.run()
+	log.Debugf(log.WebsocketMgr, "Anomaly detector %s", MsgSubSystemStarted)
+	return nil
+}
+
+// IsRunning checks whether the subsystem is running
+func (m *AnomalyDetector) IsRunning() bool {
+	if m == nil {
+		return false
+	}
+	return atomic.LoadInt32(&m.started) == 1
+}
+
+// Stop stops the subsystem
+func (m *AnomalyDetector) Stop() error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 1, 0) {
+		return ErrSubSystemNotStarted
+	}
+	close(m.shutdown)
+	m.wg.Wait()
+	log.Debugf(log.WebsocketMgr, "Anomaly detector %s", MsgSubSystemShutdown)
+	return nil
+}
+
+// IsQuarantined returns whether a pair's market data is withheld from
+// strategies following an anomaly
+func (m *AnomalyDetector) IsQuarantined(exchName string, a asset.Item, p currency.Pair) bool {
+	if !m.IsRunning() {
+		return false
+	}
+	m.m.Lock()
+	defer m.m.Unlock()
+	q, ok := m.quarantined[quarantineKey(exchName, a, p)]
+	return ok && time.Now().Before(q.Until)
+}
+
+// GetQuarantined returns the pairs currently quarantined, oldest first
+func (m *AnomalyDetector) GetQuarantined() []Quarantine {
+	if !m.IsRunning() {
+		return nil
+	}
+	now := time.Now()
+	m.m.Lock()
+	resp := make([]Quarantine, 0, len(m.quarantined))
+	for _, q := range m.quarantined {
+		if now.Before(q.Until) {
+			resp = append(resp, *q)
+		}
+	}
+	m.m.Unlock()
+	slices.SortFunc(resp, func(a, b Quarantine) int {
+		return a.Since.Compare(b.Since)
+	})
+	return resp
+}
+
+func (m *AnomalyDetector) run() {
+	defer m.wg.Done()
+	runScheduledJobs(m.shutdown, &scheduledJob{interval: anomalyReleaseInterval, run: func(_ context.Context, now time.Time) { m.release(now) }})
+}
+
+// websocketAnomalyHandler inspects tickers, orderbooks and trades received
+// via websocket
+func (m *AnomalyDetector) websocketAnomalyHandler(exchName string, data interface{}) error {
+	if !m.IsRunning() {
+		return nil
+	}
+	now := time.Now()
+	switch d := data.(type) {
+	case *ticker.Price:
+		m.inspectTicker(exchName, d, now)
+	case []ticker.Price:
+		for i := range d {
+			m.inspectTicker(exchName, &d[i], now)
+		}
+	case *orderbook.Depth:
+		// Invalid books are reported by the websocket data handler
+		if b, err := d.Retrieve(); err == nil {
+			m.inspectOrderbook(exchName, b, now)
+		}
+	case []trade.Data:
+		m.inspectTrades(exchName, d, now)
+	}
+	return nil
+}
+
+// inspectTicker flags a crossed bid and ask, and a mid or last price far from
+// the consolidated mid
+func (m *AnomalyDetector) inspectTicker(exchName string, t *ticker.Price, now time.Time) {
+	if t.Bid > 0 && t.Ask > 0 {
+		if t.Bid >= t.Ask {
+			m.flag(exchName, t.AssetType, t.Pair, AnomalyCrossedBook, fmt.Sprintf("ticker bid %v at or above ask %v", t.Bid, t.Ask), now)
+			return
+		}
+		m.checkPrice(exchName, t.AssetType, t.Pair, (t.Bid+t.Ask)/2, true, now)
+		return
+	}
+	if t.Last > 0 {
+		m.checkPrice(exchName, t.AssetType, t.Pair, t.Last, true, now)
+	}
+}
+
+// inspectOrderbook flags zero size or zero price levels, a crossed book and a
+// mid far from the consolidated mid
+func (m *AnomalyDetector) inspectOrderbook(exchName string, b *orderbook.Base, now time.Time) {
+	for _, side := range []struct {
+		name   string
+		levels orderbook.Items
+	}{{"bid", b.Bids}, {"ask", b.Asks}} {
+		for i := range side.levels {
+			if side.levels[i].Amount <= 0 || side.levels[i].Price <= 0 {
+				m.flag(exchName, b.Asset, b.Pair, AnomalyZeroSize,
+					fmt.Sprintf("orderbook %s level %d price %v size %v", side.name, i, side.levels[i].Price, side.levels[i].Amount), now)
+				return
+			}
+		}
+	}
+	if len(b.Bids) == 0 || len(b.Asks) == 0 {
+		return
+	}
+	if b.Bids[0].Price >= b.Asks[0].Price {
+		m.flag(exchName, b.Asset, b.Pair, AnomalyCrossedBook,
+			fmt.Sprintf("orderbook best bid %v at or above best ask %v", b.Bids[0].Price, b.Asks[0].Price), now)
+		return
+	}
+	m.checkPrice(exchName, b.Asset, b.Pair, (b.Bids[0].Price+b.Asks[0].Price)/2, true, now)
+}
+
+// inspectTrades flags zero size or zero price prints, and prints far from the
+// consolidated mid
+func (m *AnomalyDetector) inspectTrades(exchName string, trades []trade.Data, now time.Time) {
+	for i := range trades {
+		if trades[i].Amount == 0 || trades[i].Price <= 0 {
+			m.flag(exchName, trades[i].AssetType, trades[i].CurrencyPair, AnomalyZeroSize,
+				fmt.Sprintf("trade %s price %v size %v", trades[i].TID, trades[i].Price, trades[i].Amount), now)
+			continue
+		}
+		m.checkPrice(exchName, trades[i].AssetType, trades[i].CurrencyPair, trades[i].Price, false, now)
+	}
+}
+
+// checkPrice flags a price which deviates from the consolidated mid by more
+// than the maximum standard deviations of recent deviations. Accepted prices
+// are recorded, and accepted mids become the exchange's latest mid.
+// Flagged mids are not recorded, so a sustained move is accepted once the
+// exchange's previous mid becomes stale
+func (m *AnomalyDetector) checkPrice(exchName string, a asset.Item, p currency.Pair, price float64, isMid bool, now time.Time) {
+	m.m.Lock()
+	k := a.String() + " " + underlyingKey(p)
+	mkt, ok := m.markets[k]
+	if !ok {
+		mkt = &anomalyMarket{
+			mids:       make(map[string]anomalyMid),
+			deviations: make([]float64, 0, m.window),
+		}
+		m.markets[k] = mkt
+	}
+	var reason string
+	if mid := mkt.consolidatedMid(now, m.staleAfter); mid > 0 {
+		deviation := price/mid - 1
+		if len(mkt.deviations) >= m.minSamples {
+			stdDev, err := gctmath.PopulationStandardDeviation(mkt.deviations)
+			if err == nil && math.Abs(deviation) > max(m.maxDeviation*stdDev, m.minDeviation) {
+				reason = fmt.Sprintf("price %v deviates %.4f%% from consolidated mid %v, beyond %v standard deviations of %.4f%%",
+					price, deviation*100, mid, m.maxDeviation, stdDev*100)
+			}
+		}
+		if reason == "" {
+			mkt.record(deviation, m.window)
+		}
+	}
+	if reason == "" && isMid {
+		mkt.mids[strings.ToLower(exchName)] = anomalyMid{price: price, updated: now}
+	}
+	m.m.Unlock()
+	if reason != "" {
+		m.flag(exchName, a, p, AnomalyPriceDeviation, reason, now)
+	}
+}
+
+// consolidatedMid returns the median of each exchange's latest mid updated
+// within the stale period, or zero when there are none
+func (mkt *anomalyMarket) consolidatedMid(now time.Time, staleAfter time.Duration) float64 {
+	mids := make([]float64, 0, len(mkt.mids))
+	for _, mid := range mkt.mids {
+		if now.Sub(mid.updated) <= staleAfter {
+			mids = append(mids, mid.price)
+		}
+	}
+	if len(mids) == 0 {
+		return 0
+	}
+	slices.Sort(mids)
+	if len(mids)%2 == 1 {
+		return mids[len(mids)/2]
+	}
+	return (mids[len(mids)/2-1] + mids[len(mids)/2]) / 2
+}
+
+// record adds a deviation, replacing the oldest once the window is full
+func (mkt *anomalyMarket) record(deviation float64, window int) {
+	if len(mkt.deviations) < window {
+		mkt.deviations = append(mkt.deviations, deviation)
+		return
+	}
+	mkt.deviations[mkt.next] = deviation
+	mkt.next = (mkt.next + 1) % window
+}
+
+// flag quarantines a pair, alerting when it was not already quarantined and
+// extending the quarantine otherwise
+func (m *AnomalyDetector) flag(exchName string, a asset.Item, p currency.Pair, anomaly, reason string, now time.Time) {
+	k := quarantineKey(exchName, a, p)
+	m.m.Lock()
+	q, ok := m.quarantined[k]
+	if ok && now.Before(q.Until) {
+		q.Until = now.Add(m.quarantinePeriod)
+		q.Count++
+		m.m.Unlock()
+		if m.verbose {
+			log.Debugf(log.WebsocketMgr, "Anomaly detector %s %s %s %s: %s", exchName, a, p, anomaly, reason)
+		}
+		return
+	}
+	m.quarantined[k] = &Quarantine{
+		Exchange: exchName,
+		Asset:    a,
+		Pair:     p,
+		Anomaly:  anomaly,
+		Reason:   reason,
+		Since:    now,
+		Until:    now.Add(m.quarantinePeriod),
+		Count:    1,
+	}
+	m.m.Unlock()
+	msg := fmt.Sprintf("Anomaly detector quarantined %s %s %s market data for %s: %s %s", exchName, a, p, m.quarantinePeriod, anomaly, reason)
+	log.Warnln(log.WebsocketMgr, msg)
+	m.comms.PushEvent(base.Event{Type: anomalyEventType, Message: msg, Severity: base.SeverityWarning, Exchange: exchName, Key: anomalyEventType + ":" + k})
+}
+
+// release removes expired quarantines and resolves their alerts
+func (m *AnomalyDetector) release(now time.Time) {
+	var released []Quarantine
+	m.m.Lock()
+	for k, q := range m.quarantined {
+		if !now.Before(q.Until) {
+			released = append(released, *q)
+			delete(m.quarantined, k)
+		}
+	}
+	m.m.Unlock()
+	for i := range released {
+		q := &released[i]
+		msg := fmt.Sprintf("Anomaly detector released %s %s %s market data after %d anomalies", q.Exchange, q.Asset, q.Pair, q.Count)
+		log.Infoln(log.WebsocketMgr, msg)
+		m.comms.PushEvent(base.Event{
+			Type:     anomalyEventType,
+			Message:  msg,
+			Severity: base.SeverityInfo,
+			Exchange: q.Exchange,
+			Key:      anomalyEventType + ":" + quarantineKey(q.Exchange, q.Asset, q.Pair),
+			Resolved: true,
+		})
+	}
+}
+
+// quarantineKey returns a key identifying an exchange's pair which ignores
+// pair formatting
+func quarantineKey(exchName string, a asset.Item, p currency.Pair) string {
+	return strings.ToLower(exchName) + " " + a.String() + " " + underlyingKey(p)
+}
@@ -0,0 +1,214 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+)
+
+func testAnomalyDetectorConfig() *config.AnomalyDetector {
+	return &config.AnomalyDetector{
+		MaxDeviation:     4,
+		MinDeviation:     0.001,
+		Window:           10,
+		MinSamples:       5,
+		StaleAfter:       time.Second * 30,
+		QuarantinePeriod: time.Minute,
+	}
+}
+
+func testAnomalyDetectorSetup(t *testing.T) (*AnomalyDetector, *fakeComms) {
+	t.Helper()
+	comms := &fakeComms{}
+	m, err := SetupAnomalyDetector(testAnomalyDetectorConfig(), comms)
+	require.NoError(t, err, "SetupAnomalyDetector must not error")
+	require.NoError(t, m.Start(), "Start must not error")
+	t.Cleanup(func() { assert.NoError(t, m.Stop(), "Stop should not error") })
+	return m, comms
+}
+
+// seedTickers streams alternating mids around 100 from an exchange
+func seedTickers(m *AnomalyDetector, exchName string, p currency.Pair, n int, now time.Time) {
+	for i := range n {
+		spread := 0.01 * float64(i%2)
+		m.inspectTicker(exchName, &ticker.Price{Pair: p, AssetType: asset.Spot, Bid: 99.99 + spread, Ask: 100.01 + spread}, now)
+	}
+}
+
+func TestSetupAnomalyDetector(t *testing.T) {
+	t.Parallel()
+	_, err := SetupAnomalyDetector(nil, nil)
+	assert.ErrorIs(t, err, errNilConfig)
+	_, err = SetupAnomalyDetector(&config.AnomalyDetector{}, nil)
+	assert.ErrorIs(t, err, errNilCommunicationsManager)
+	_, err = SetupAnomalyDetector(&config.AnomalyDetector{}, &fakeComms{})
+	assert.ErrorIs(t, err, errInvalidAnomalyDetectorConfig)
+	cfg := testAnomalyDetectorConfig()
+	cfg.MinSamples = cfg.Window + 1
+	_, err = SetupAnomalyDetector(cfg, &fakeComms{})
+	assert.ErrorIs(t, err, errInvalidAnomalyDetectorConfig, "min samples above window should error")
+}
+
+func TestAnomalyDetectorStartStop(t *testing.T) {
+	t.Parallel()
+	m, err := SetupAnomalyDetector(testAnomalyDetectorConfig(), &fakeComms{})
+	require.NoError(t, err, "SetupAnomalyDetector must not error")
+	testStartStop(t, (*AnomalyDetector)(nil), m)
+	assert.False(t, (*AnomalyDetector)(nil).IsQuarantined("test", asset.Spot, currency.NewBTCUSDT()), "nil detector should not quarantine")
+}
+
+func TestAnomalyDetectorPriceDeviation(t *testing.T) {
+	t.Parallel()
+	m, comms := testAnomalyDetectorSetup(t)
+	p := currency.NewBTCUSDT()
+	now := time.Now()
+	seedTickers(m, "one", p, 10, now)
+	seedTickers(m, "two", p, 10, now)
+	assert.Empty(t, comms.events, "ordinary prices should not be flagged")
+
+	m.inspectTicker("one", &ticker.Price{Pair: p, AssetType: asset.Spot, Bid: 109.99, Ask: 110.01}, now)
+	assert.True(t, m.IsQuarantined("ONE", asset.Spot, currency.NewPairWithDelimiter("btc", "usdt", "/")),
+		"a mid far from the consolidated mid should quarantine the pair regardless of formatting")
+	assert.False(t, m.IsQuarantined("two", asset.Spot, p), "other exchanges should not be quarantined")
+	assert.False(t, m.IsQuarantined("one", asset.Futures, p), "other assets should not be quarantined")
+	require.Len(t, comms.events, 1)
+	assert.Equal(t, anomalyEventType, comms.events[0].Type)
+	assert.Contains(t, comms.events[0].Message, AnomalyPriceDeviation)
+
+	m.inspectTrades("one", []trade.Data{{CurrencyPair: p, AssetType: asset.Spot, Price: 90, Amount: 1}}, now)
+	assert.Len(t, comms.events, 1, "further anomalies should extend the quarantine without alerting")
+	q := m.GetQuarantined()
+	require.Len(t, q, 1)
+	assert.Equal(t, 2, q[0].Count)
+	assert.Equal(t, AnomalyPriceDeviation, q[0].Anomaly)
+
+	m.inspectTrades("two", []trade.Data{{CurrencyPair: p, AssetType: asset.Spot, Price: 100.005, Amount: 1}}, now)
+	assert.False(t, m.IsQuarantined("two", asset.Spot, p), "trades near the consolidated mid should not be flagged")
+
+	later := now.Add(time.Minute)
+	m.inspectTicker("three", &ticker.Price{Pair: p, AssetType: asset.Spot, Last: 110}, later)
+	assert.False(t, m.IsQuarantined("three", asset.Spot, p), "prices should be accepted once all mids are stale")
+}
+
+func TestAnomalyDetectorDeviationThresholds(t *testing.T) {
+	t.Parallel()
+	m, comms := testAnomalyDetectorSetup(t)
+	p := currency.NewBTCUSDT()
+	now := time.Now()
+	seedTickers(m, "one", p, 4, now)
+	m.inspectTicker("two", &ticker.Price{Pair: p, AssetType: asset.Spot, Last: 110}, now)
+	assert.False(t, m.IsQuarantined("two", asset.Spot, p), "prices should not be flagged before min samples are recorded")
+
+	m, comms = testAnomalyDetectorSetup(t)
+	for range 10 {
+		m.inspectTicker("one", &ticker.Price{Pair: p, AssetType: asset.Spot, Bid: 99.99, Ask: 100.01}, now)
+	}
+	m.inspectTicker("two", &ticker.Price{Pair: p, AssetType: asset.Spot, Last: 100.05}, now)
+	assert.False(t, m.IsQuarantined("two", asset.Spot, p), "deviations within the minimum deviation should not be flagged")
+	m.inspectTicker("two", &ticker.Price{Pair: p, AssetType: asset.Spot, Last: 100.2}, now)
+	assert.True(t, m.IsQuarantined("two", asset.Spot, p), "deviations beyond the minimum deviation should be flagged when prices do not vary")
+	assert.Len(t, comms.events, 1)
+}
+
+func TestConsolidatedMid(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	for _, tc := range []struct {
+		name string
+		mids map[string]anomalyMid
+		mid  float64
+	}{
+		{name: "none", mid: 0},
+		{name: "stale", mids: map[string]anomalyMid{"a": {price: 100, updated: now.Add(-time.Minute)}}, mid: 0},
+		{name: "odd", mids: map[string]anomalyMid{"a": {price: 100, updated: now}, "b": {price: 300, updated: now}, "c": {price: 101, updated: now}}, mid: 101},
+		{name: "even", mids: map[string]anomalyMid{"a": {price: 100, updated: now}, "b": {price: 102, updated: now}}, mid: 101},
+		{name: "stale excluded", mids: map[string]anomalyMid{"a": {price: 100, updated: now}, "b": {price: 200, updated: now.Add(-time.Minute)}}, mid: 100},
+	} {
+		mkt := &anomalyMarket{mids: tc.mids}
+		assert.Equal(t, tc.mid, mkt.consolidatedMid(now, time.Second*30), tc.name)
+	}
+}
+
+func TestAnomalyDetectorCrossedAndZeroSize(t *testing.T) {
+	t.Parallel()
+	m, comms := testAnomalyDetectorSetup(t)
+	p := currency.NewBTCUSDT()
+	now := time.Now()
+
+	m.inspectTicker("ticker", &ticker.Price{Pair: p, AssetType: asset.Spot, Bid: 101, Ask: 100}, now)
+	assert.True(t, m.IsQuarantined("ticker", asset.Spot, p), "a crossed ticker should be quarantined")
+
+	m.inspectOrderbook("crossed", &orderbook.Base{
+		Pair:  p,
+		Asset: asset.Spot,
+		Bids:  orderbook.Items{{Price: 100, Amount: 1}},
+		Asks:  orderbook.Items{{Price: 99, Amount: 1}},
+	}, now)
+	assert.True(t, m.IsQuarantined("crossed", asset.Spot, p), "a crossed orderbook should be quarantined")
+
+	m.inspectOrderbook("zero", &orderbook.Base{
+		Pair:  p,
+		Asset: asset.Spot,
+		Bids:  orderbook.Items{{Price: 99, Amount: 1}},
+		Asks:  orderbook.Items{{Price: 100, Amount: 1}, {Price: 101, Amount: 0}},
+	}, now)
+	assert.True(t, m.IsQuarantined("zero", asset.Spot, p), "a zero size level should be quarantined")
+
+	m.inspectTrades("trades", []trade.Data{{CurrencyPair: p, AssetType: asset.Spot, Price: 100}}, now)
+	assert.True(t, m.IsQuarantined("trades", asset.Spot, p), "a zero size trade should be quarantined")
+
+	require.Len(t, comms.events, 4)
+	assert.Contains(t, comms.events[0].Message, AnomalyCrossedBook)
+	assert.Contains(t, comms.events[1].Message, AnomalyCrossedBook)
+	assert.Contains(t, comms.events[2].Message, AnomalyZeroSize)
+	assert.Contains(t, comms.events[3].Message, AnomalyZeroSize)
+
+	m.inspectOrderbook("valid", &orderbook.Base{
+		Pair:  p,
+		Asset: asset.Spot,
+		Bids:  orderbook.Items{{Price: 99, Amount: 1}},
+		Asks:  orderbook.Items{{Price: 100, Amount: 1}},
+	}, now)
+	assert.False(t, m.IsQuarantined("valid", asset.Spot, p), "a valid orderbook should not be quarantined")
+}
+
+func TestAnomalyDetectorRelease(t *testing.T) {
+	t.Parallel()
+	m, comms := testAnomalyDetectorSetup(t)
+	p := currency.NewBTCUSDT()
+	now := time.Now()
+	m.flag("test", asset.Spot, p, AnomalyCrossedBook, "test", now.Add(-time.Minute))
+	m.flag("held", asset.Spot, p, AnomalyCrossedBook, "test", now)
+	assert.False(t, m.IsQuarantined("test", asset.Spot, p), "expired quarantines should not withhold data")
+
+	m.release(now)
+	require.Len(t, comms.events, 3)
+	assert.True(t, comms.events[2].Resolved, "released quarantines should resolve their alert")
+	assert.Equal(t, comms.events[0].Key, comms.events[2].Key, "resolution should use the quarantine alert key")
+	q := m.GetQuarantined()
+	require.Len(t, q, 1)
+	assert.Equal(t, "held", q[0].Exchange)
+}
+
+func TestWebsocketAnomalyHandler(t *testing.T) {
+	t.Parallel()
+	m, err := SetupAnomalyDetector(testAnomalyDetectorConfig(), &fakeComms{})
+	require.NoError(t, err)
+	p := currency.NewBTCUSDT()
+	crossed := &ticker.Price{Pair: p, AssetType: asset.Spot, Bid: 101, Ask: 100}
+	assert.NoError(t, m.websocketAnomalyHandler("test", crossed))
+	assert.Empty(t, m.quarantined, "data should not be inspected while stopped")
+
+	require.NoError(t, m.Start())
+	defer func() { assert.NoError(t, m.Stop()) }()
+	assert.NoError(t, m.websocketAnomalyHandler("test", []ticker.Price{*crossed}))
+	assert.True(t, m.IsQuarantined("test", asset.Spot, p))
+}
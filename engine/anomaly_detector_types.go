@@ -0,0 +1,84 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+// AnomalyDetectorName is an exported subsystem name
+const AnomalyDetectorName = "anomaly_detector"
+
+// anomalyEventType is the communications event type used for anomalous market
+// data alerts
+const anomalyEventType = "anomaly"
+
+// anomalyReleaseInterval is how often expired quarantines are released
+const anomalyReleaseInterval = time.Second * 5
+
+// Anomalies which can quarantine a pair's market data
+const (
+	AnomalyPriceDeviation = "price_deviation"
+	AnomalyCrossedBook    = "crossed_book"
+	AnomalyZeroSize       = "zero_size"
+)
+
+var errInvalidAnomalyDetectorConfig = errors.New("invalid anomaly detector config")
+
+// iMarketDataQuarantine defines the anomaly detector functions used by
+// strategies to withhold trades on suspect market data
+type iMarketDataQuarantine interface {
+	IsQuarantined(exchName string, a asset.Item, p currency.Pair) bool
+}
+
+// AnomalyDetector inspects streamed tickers, orderbooks and trades for prints
+// far from the consolidated mid of all exchanges, crossed books and zero size
+// levels, quarantining the affected pairs from strategies and alerting
+type AnomalyDetector struct {
+	started          int32
+	shutdown         chan struct{}
+	wg               sync.WaitGroup
+	verbose          bool
+	maxDeviation     float64
+	minDeviation     float64
+	window           int
+	minSamples       int
+	staleAfter       time.Duration
+	quarantinePeriod time.Duration
+	comms            iCommsManager
+	m                sync.Mutex
+	markets          map[string]*anomalyMarket
+	quarantined      map[string]*Quarantine
+}
+
+// anomalyMarket holds the latest mid of each exchange trading a pair and the
+// recent deviations of prices from their consolidated mid
+type anomalyMarket struct {
+	mids       map[string]anomalyMid
+	deviations []float64
+	next       int
+}
+
+// anomalyMid is an exchange's latest accepted mid price
+type anomalyMid struct {
+	price   float64
+	updated time.Time
+}
+
+// Quarantine is a pair whose market data is withheld from strategies after an
+// anomaly
+type Quarantine struct {
+	Exchange string
+	Asset    asset.Item
+	Pair     currency.Pair
+	// Anomaly is the first anomaly flagged, such as AnomalyCrossedBook
+	Anomaly string
+	Reason  string
+	Since   time.Time
+	// Until is extended by each further anomaly
+	Until time.Time
+	Count int
+}
@@ -0,0 +1,417 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/cache"
+	"github.com/thrasher-corp/gocryptotrader/common/key"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// SetupBasisHarvester creates a basis harvester subsystem. The margin monitor
+// is optional, when set positions are reduced and no new positions are opened
+// while the perpetual account's margin ratio exceeds the configured maximum.
+// The market data quarantine is optional, when set markets are not traded
+// while either leg's market data is quarantined
+func SetupBasisHarvester(cfg *config.BasisHarvester, em iExchangeManager, om iBasisOrderManager, comms iCommsManager, mm iBasisMarginMonitor, q iMarketDataQuarantine) (*BasisHarvester, error) {
+	if cfg == nil {
+		return nil, errNilConfig
+	}
+	if em == nil {
+		return nil, errNilExchangeManager
+	}
+	if om == nil {
+		return nil, errNilOrderManager
+	}
+	if comms == nil {
+		return nil, errNilCommunicationsManager
+	}
+	if cfg.CheckInterval <= 0 {
+		return nil, fmt.Errorf("%w check interval %v", errInvalidBasisHarvesterConfig, cfg.CheckInterval)
+	}
+	if cfg.FundingInterval <= 0 {
+		return nil, fmt.Errorf("%w funding interval %v", errInvalidBasisHarvesterConfig, cfg.FundingInterval)
+	}
+	if cfg.ExitThreshold >= cfg.EntryThreshold {
+		return nil, fmt.Errorf("%w exit threshold %v must be below entry threshold %v", errInvalidBasisHarvesterConfig, cfg.ExitThreshold, cfg.EntryThreshold)
+	}
+	if cfg.MaxMarginRatio <= 0 || cfg.MaxMarginRatio >= 1 {
+		return nil, fmt.Errorf("%w max margin ratio %v", errInvalidBasisHarvesterConfig, cfg.MaxMarginRatio)
+	}
+	if cfg.ReduceFraction <= 0 || cfg.ReduceFraction > 1 {
+		return nil, fmt.Errorf("%w reduce fraction %v", errInvalidBasisHarvesterConfig, cfg.ReduceFraction)
+	}
+	if len(cfg.Markets) == 0 {
+		return nil, fmt.Errorf("%w no markets configured", errInvalidBasisHarvesterConfig)
+	}
+	markets := make([]basisMarket, len(cfg.Markets))
+	for i := range cfg.Markets {
+		mkt, err := newBasisMarket(&cfg.Markets[i])
+		if err != nil {
+			return nil, fmt.Errorf("%w market %d: %w", errInvalidBasisHarvesterConfig, i, err)
+		}
+		markets[i] = mkt
+	}
+	return &BasisHarvester{
+		verbose:         cfg.Verbose,
+		dryRun:          cfg.DryRun == nil || *cfg.DryRun,
+		interval:        cfg.CheckInterval,
+		fundingInterval: cfg.FundingInterval,
+		entryThreshold:  cfg.EntryThreshold,
+		exitThreshold:   cfg.ExitThreshold,
+		maxMarginRatio:  cfg.MaxMarginRatio,
+		reduceFraction:  cfg.ReduceFraction,
+		markets:         markets,
+		exchangeManager: em,
+		orderManager:    om,
+		comms:           comms,
+		marginMonitor:   mm,
+		quarantine:      q,
+		reported:        make(map[string]struct{}),
+		latest:          make(map[string]Basis),
+	}, nil
+}
+
+// newBasisMarket validates a configured market
+func newBasisMarket(cfg *config.BasisMarket) (basisMarket, error) {
+	if cfg.Exchange == "" {
+		return basisMarket{}, ErrExchangeNameIsEmpty
+	}
+	if cfg.Amount <= 0 {
+		return basisMarket{}, fmt.Errorf("amount %v must be greater than zero", cfg.Amount)
+	}
+	spot, err := currency.NewPairFromString(cfg.Spot)
+	if err != nil {
+		return basisMarket{}, fmt.Errorf("spot pair %q: %w", cfg.Spot, err)
+	}
+	perpetual, err := currency.NewPairFromString(cfg.Perpetual)
+	if err != nil {
+		return basisMarket{}, fmt.Errorf("perpetual pair %q: %w", cfg.Perpetual, err)
+	}
+	a, err := asset.New(cfg.PerpetualAsset)
+	if err != nil {
+		return basisMarket{}, err
+	}
+	if !a.IsFutures() {
+		return basisMarket{}, fmt.Errorf("perpetual %w %s", futures.ErrNotFuturesAsset, a)
+	}
+	return basisMarket{
+		exchange:       cfg.Exchange,
+		spot:           spot,
+		perpetual:      perpetual,
+		perpetualAsset: a,
+		amount:         cfg.Amount,
+	}, nil
+}
+
+// Start runs the subsystem
+func (m *BasisHarvester) Start() error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 0, 1) {
+		return ErrSubSystemAlreadyStarted
+	}
+	m.shutdown = make(chan struct{})
+	m.wg.Add(1)
+	go m.run()
+	if m.dryRun {
+		log.Infoln(log.OrderMgr, "Basis harvester running in dry run mode, no orders will be placed")
+	}
+	log.Debugf(log.OrderMgr, "Basis harvester %s", MsgSubSystemStarted)
+	return nil
+}
+
+// IsRunning checks whether the subsystem is running
+func (m *BasisHarvester) IsRunning() bool {
+	if m == nil {
+		return false
+	}
+	return atomic.LoadInt32(&m.started) == 1
+}
+
+// Stop stops the subsystem
+func (m *BasisHarvester) Stop() error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 1, 0) {
+		return ErrSubSystemNotStarted
+	}
+	close(m.shutdown)
+	m.wg.Wait()
+	log.Debugf(log.OrderMgr, "Basis harvester %s", MsgSubSystemShutdown)
+	return nil
+}
+
+// GetBasis returns the latest basis of each configured market
+func (m *BasisHarvester) GetBasis() []Basis {
+	if m == nil {
+		return nil
+	}
+	m.m.RLock()
+	resp := make([]Basis, 0, len(m.latest))
+	for _, b := range m.latest {
+		resp = append(resp, b)
+	}
+	m.m.RUnlock()
+	slices.SortFunc(resp, func(a, b Basis) int {
+		return strings.Compare(basisMarketKey(a.Exchange, a.PerpetualAsset, a.Perpetual), basisMarketKey(b.Exchange, b.PerpetualAsset, b.Perpetual))
+	})
+	return resp
+}
+
+func (m *BasisHarvester) run() {
+	defer m.wg.Done()
+	runScheduledJobs(m.shutdown, &scheduledJob{interval: m.interval, immediate: true, run: func(ctx context.Context, _ time.Time) { m.checkMarkets(ctx) }})
+}
+
+// checkMarkets prices each market and opens, reduces or unwinds its basis
+// position as required
+func (m *BasisHarvester) checkMarkets(ctx context.Context) {
+	positions, err := m.orderManager.GetAllOpenFuturesPositions()
+	if err != nil {
+		log.Errorf(log.OrderMgr, "Basis harvester cannot get open positions: %v", err)
+		return
+	}
+	now := time.Now()
+	for i := range m.markets {
+		mkt := &m.markets[i]
+		if m.quarantine != nil &&
+			(m.quarantine.IsQuarantined(mkt.exchange, asset.Spot, mkt.spot) ||
+				m.quarantine.IsQuarantined(mkt.exchange, mkt.perpetualAsset, mkt.perpetual)) {
+			m.reportOnce(mkt, "quarantine", fmt.Sprintf("Basis harvester withholding %s %s trades while market data is quarantined", mkt.exchange, mkt.perpetual))
+			continue
+		}
+		b, err := m.basis(ctx, mkt, positions, now)
+		if err != nil {
+			m.reportOnce(mkt, "price"+err.Error(), fmt.Sprintf("Basis harvester cannot price %s %s: %v", mkt.exchange, mkt.perpetual, err))
+			continue
+		}
+		if m.verbose {
+			log.Debugf(log.OrderMgr, "Basis harvester %s %s %s premium %.6f annualised %.6f held %v",
+				b.Exchange, b.PerpetualAsset, b.Perpetual, b.Premium, b.AnnualisedBasis, b.Held)
+		}
+		action, amount := m.plan(mkt, b)
+		if action == basisHold {
+			continue
+		}
+		if err := m.execute(ctx, mkt, b, action, amount); err != nil {
+			msg := fmt.Sprintf("Basis harvester failed to %s %s %s basis position: %v", action, mkt.exchange, mkt.perpetual, err)
+			log.Errorln(log.OrderMgr, msg)
+			severity := base.SeverityError
+			if errors.Is(err, errBasisUnhedged) {
+				severity = base.SeverityCritical
+			}
+			m.comms.PushEvent(base.Event{Type: basisEventType, Message: msg, Severity: severity, Exchange: mkt.exchange})
+		}
+	}
+}
+
+// basis prices a market's legs, records the basis and returns it with the
+// size of the short perpetual position held
+func (m *BasisHarvester) basis(ctx context.Context, mkt *basisMarket, positions []futures.Position, now time.Time) (*Basis, error) {
+	exch, err := m.exchangeManager.GetExchangeByName(mkt.exchange)
+	if err != nil {
+		return nil, err
+	}
+	spotTicker, err := exch.FetchTicker(ctx, mkt.spot, asset.Spot)
+	if err != nil {
+		return nil, err
+	}
+	perpTicker, err := exch.FetchTicker(ctx, mkt.perpetual, mkt.perpetualAsset)
+	if err != nil {
+		return nil, err
+	}
+	if spotTicker.Last <= 0 || perpTicker.Last <= 0 {
+		return nil, errBasisPriceUnset
+	}
+	b := Basis{
+		Exchange:       exch.GetName(),
+		Spot:           mkt.spot,
+		Perpetual:      mkt.perpetual,
+		PerpetualAsset: mkt.perpetualAsset,
+		SpotPrice:      spotTicker.Last,
+		PerpetualPrice: perpTicker.Last,
+		Premium:        (perpTicker.Last - spotTicker.Last) / spotTicker.Last,
+		Time:           now,
+	}
+	b.AnnualisedBasis = b.Premium * float64(time.Hour*24*daysPerYear) / float64(m.fundingInterval)
+	for i := range positions {
+		if strings.EqualFold(positions[i].Exchange, mkt.exchange) &&
+			positions[i].Asset == mkt.perpetualAsset &&
+			positions[i].Pair.Equal(mkt.perpetual) &&
+			positions[i].LatestDirection.IsShort() {
+			b.Held += positions[i].LatestSize.Abs().InexactFloat64()
+		}
+	}
+	// Share the basis so other consumers need not recompute it
+	cache.Analytics.SetWithTTL(perpetualBasisKey(&b), b.AnnualisedBasis, m.interval*2)
+	m.m.Lock()
+	m.latest[basisMarketKey(b.Exchange, b.PerpetualAsset, b.Perpetual)] = b
+	m.m.Unlock()
+	return &b, nil
+}
+
+// plan returns the trade required for a market's basis position. Held
+// positions are unwound once the basis converges to the exit threshold and
+// reduced while the margin ratio is exceeded. New positions are opened when
+// the basis reaches the entry threshold and margin allows
+func (m *BasisHarvester) plan(mkt *basisMarket, b *Basis) (action basisAction, amount float64) {
+	var marginExceeded bool
+	if m.marginMonitor != nil {
+		ratio, ok := m.marginMonitor.MaintenanceMarginRatio(mkt.exchange, mkt.perpetualAsset)
+		marginExceeded = ok && ratio >= m.maxMarginRatio
+	}
+	switch {
+	case b.Held > 0 && b.AnnualisedBasis <= m.exitThreshold:
+		return basisUnwind, b.Held
+	case b.Held > 0 && marginExceeded:
+		return basisReduce, b.Held * m.reduceFraction
+	case b.Held == 0 && b.AnnualisedBasis >= m.entryThreshold && !marginExceeded:
+		return basisOpen, mkt.amount
+	default:
+		return basisHold, 0
+	}
+}
+
+// execute places both legs of a basis trade. In dry run mode the trade is
+// reported without placing orders
+func (m *BasisHarvester) execute(ctx context.Context, mkt *basisMarket, b *Basis, action basisAction, amount float64) error {
+	summary := fmt.Sprintf("%s %v %s spot and %s perpetual at annualised basis %.4f",
+		mkt.exchange, amount, mkt.spot, mkt.perpetual, b.AnnualisedBasis)
+	if m.dryRun {
+		m.reportOnce(mkt, action.String(), fmt.Sprintf("Basis harvester dry run would %s %s", action, summary))
+		return nil
+	}
+	spotLeg := &order.Submit{
+		Exchange:  mkt.exchange,
+		Pair:      mkt.spot,
+		AssetType: asset.Spot,
+		Side:      order.Buy,
+		Type:      order.Market,
+		Amount:    amount,
+	}
+	perpLeg := &order.Submit{
+		Exchange:  mkt.exchange,
+		Pair:      mkt.perpetual,
+		AssetType: mkt.perpetualAsset,
+		Side:      order.Sell,
+		Type:      order.Market,
+		Amount:    amount,
+	}
+	var err error
+	if action == basisOpen {
+		err = m.submitHedged(ctx, spotLeg, perpLeg)
+	} else {
+		// Close the short first so the spot hedge is never sold from under it
+		spotLeg.Side = order.Sell
+		perpLeg.Side = order.Buy
+		perpLeg.ReduceOnly = true
+		err = m.submitHedged(ctx, perpLeg, spotLeg)
+	}
+	if err != nil {
+		return err
+	}
+	msg := fmt.Sprintf("Basis harvester %s %s", action.pastTense(), summary)
+	log.Infoln(log.OrderMgr, msg)
+	m.comms.PushEvent(base.Event{Type: basisEventType, Message: msg, Severity: base.SeverityInfo, Exchange: mkt.exchange})
+	return nil
+}
+
+// submitHedged places the legs of a basis trade in order, reversing the first
+// leg when the second fails so no unhedged exposure is left
+func (m *BasisHarvester) submitHedged(ctx context.Context, first, second *order.Submit) error {
+	if _, err := m.orderManager.Submit(ctx, first); err != nil {
+		return fmt.Errorf("%s %s leg: %w", first.AssetType, first.Pair, err)
+	}
+	_, err := m.orderManager.Submit(ctx, second)
+	if err == nil {
+		return nil
+	}
+	reverse := *first
+	reverse.Side = order.Buy
+	if first.Side.IsLong() {
+		reverse.Side = order.Sell
+	}
+	reverse.ReduceOnly = first.AssetType.IsFutures() && !first.ReduceOnly
+	if _, reverseErr := m.orderManager.Submit(ctx, &reverse); reverseErr != nil {
+		return fmt.Errorf("%w, %s %s leg: %w and reversing %s %s leg: %w",
+			errBasisUnhedged, second.AssetType, second.Pair, err, first.AssetType, first.Pair, reverseErr)
+	}
+	return fmt.Errorf("%s %s leg: %w, %s %s leg reversed", second.AssetType, second.Pair, err, first.AssetType, first.Pair)
+}
+
+// reportOnce logs and sends a basis harvester message the first time it
+// occurs
+func (m *BasisHarvester) reportOnce(mkt *basisMarket, id, msg string) {
+	id = basisMarketKey(mkt.exchange, mkt.perpetualAsset, mkt.perpetual) + id
+	if _, ok := m.reported[id]; ok {
+		if m.verbose {
+			log.Debugln(log.OrderMgr, msg)
+		}
+		return
+	}
+	m.reported[id] = struct{}{}
+	log.Warnln(log.OrderMgr, msg)
+	m.comms.PushEvent(base.Event{Type: basisEventType, Message: msg, Severity: base.SeverityWarning, Exchange: mkt.exchange})
+}
+
+// String implements the stringer interface
+func (a basisAction) String() string {
+	switch a {
+	case basisOpen:
+		return "open"
+	case basisUnwind:
+		return "unwind"
+	case basisReduce:
+		return "reduce"
+	default:
+		return "hold"
+	}
+}
+
+// pastTense returns the action as it is reported once completed
+func (a basisAction) pastTense() string {
+	switch a {
+	case basisOpen:
+		return "opened"
+	case basisUnwind:
+		return "unwound"
+	case basisReduce:
+		return "reduced"
+	default:
+		return "held"
+	}
+}
+
+// basisMarketKey identifies a basis market by its perpetual contract
+func basisMarketKey(exchName string, a asset.Item, perpetual currency.Pair) string {
+	return strings.ToLower(exchName) + " " + a.String() + " " + perpetual.Upper().String()
+}
+
+// perpetualBasisKey returns the cache.Analytics key of a perpetual's basis
+// against spot
+func perpetualBasisKey(b *Basis) key.ExchangePairAssetMetric {
+	return key.ExchangePairAssetMetric{
+		ExchangePairAsset: key.ExchangePairAsset{
+			Exchange: b.Exchange,
+			Base:     b.Perpetual.Base.Item,
+			Quote:    b.Perpetual.Quote.Item,
+			Asset:    b.PerpetualAsset,
+		},
+		Metric: AnalyticsMetricBasis,
+	}
+}
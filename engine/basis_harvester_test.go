@@ -0,0 +1,288 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/common/cache"
+	"github.com/thrasher-corp/gocryptotrader/common/convert"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+)
+
+// basisExchange is a fake exchange with spot and perpetual prices
+type basisExchange struct {
+	exchange.IBotExchange
+	prices map[asset.Item]float64
+}
+
+func (f *basisExchange) GetName() string {
+	return "basis"
+}
+
+func (f *basisExchange) FetchTicker(_ context.Context, p currency.Pair, a asset.Item) (*ticker.Price, error) {
+	return &ticker.Price{Pair: p, AssetType: a, Last: f.prices[a]}, nil
+}
+
+type fakeBasisMarginMonitor struct {
+	ratio float64
+}
+
+func (f *fakeBasisMarginMonitor) MaintenanceMarginRatio(string, asset.Item) (float64, bool) {
+	return f.ratio, true
+}
+
+func testBasisHarvesterConfig(dryRun bool) *config.BasisHarvester {
+	return &config.BasisHarvester{
+		DryRun:          convert.BoolPtr(dryRun),
+		CheckInterval:   time.Minute,
+		FundingInterval: time.Hour * 8,
+		EntryThreshold:  0.15,
+		ExitThreshold:   0.02,
+		MaxMarginRatio:  0.5,
+		ReduceFraction:  0.5,
+		Markets: []config.BasisMarket{
+			{Exchange: "basis", Spot: "BTC-USDT", Perpetual: "BTC-USDT", PerpetualAsset: "usdtmarginedfutures", Amount: 2},
+		},
+	}
+}
+
+func testBasisHarvesterSetup(t *testing.T, dryRun bool) (*BasisHarvester, *basisExchange, *fakeFuturesOrderManager, *fakeComms) {
+	t.Helper()
+	exch := &basisExchange{prices: map[asset.Item]float64{asset.Spot: 100, asset.USDTMarginedFutures: 100.02}}
+	om := &fakeFuturesOrderManager{}
+	comms := &fakeComms{}
+	m, err := SetupBasisHarvester(testBasisHarvesterConfig(dryRun), testExchangeManager(t, exch), om, comms, nil, nil)
+	require.NoError(t, err)
+	return m, exch, om, comms
+}
+
+func TestSetupBasisHarvester(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	om := &fakeFuturesOrderManager{}
+	_, err := SetupBasisHarvester(nil, nil, nil, nil, nil, nil)
+	assert.ErrorIs(t, err, errNilConfig)
+	_, err = SetupBasisHarvester(&config.BasisHarvester{}, nil, nil, nil, nil, nil)
+	assert.ErrorIs(t, err, errNilExchangeManager)
+	_, err = SetupBasisHarvester(&config.BasisHarvester{}, em, nil, nil, nil, nil)
+	assert.ErrorIs(t, err, errNilOrderManager)
+	_, err = SetupBasisHarvester(&config.BasisHarvester{}, em, om, nil, nil, nil)
+	assert.ErrorIs(t, err, errNilCommunicationsManager)
+
+	for _, tc := range []struct {
+		name   string
+		modify func(*config.BasisHarvester)
+		err    error
+	}{
+		{name: "exit at entry", modify: func(c *config.BasisHarvester) { c.ExitThreshold = c.EntryThreshold }, err: errInvalidBasisHarvesterConfig},
+		{name: "no markets", modify: func(c *config.BasisHarvester) { c.Markets = nil }, err: errInvalidBasisHarvesterConfig},
+		{name: "no exchange", modify: func(c *config.BasisHarvester) { c.Markets[0].Exchange = "" }, err: ErrExchangeNameIsEmpty},
+		{name: "spot perpetual", modify: func(c *config.BasisHarvester) { c.Markets[0].PerpetualAsset = "spot" }, err: futures.ErrNotFuturesAsset},
+		{name: "no amount", modify: func(c *config.BasisHarvester) { c.Markets[0].Amount = 0 }, err: errInvalidBasisHarvesterConfig},
+	} {
+		cfg := testBasisHarvesterConfig(true)
+		tc.modify(cfg)
+		_, err = SetupBasisHarvester(cfg, em, om, &fakeComms{}, nil, nil)
+		assert.ErrorIs(t, err, tc.err, tc.name)
+	}
+
+	cfg := testBasisHarvesterConfig(true)
+	cfg.DryRun = nil
+	m, err := SetupBasisHarvester(cfg, em, om, &fakeComms{}, nil, nil)
+	require.NoError(t, err, "margin monitor should not be required")
+	assert.True(t, m.dryRun, "dry run should default to true")
+}
+
+func TestBasisHarvesterStartStop(t *testing.T) {
+	t.Parallel()
+	m, _, _, _ := testBasisHarvesterSetup(t, true)
+	testStartStop(t, (*BasisHarvester)(nil), m)
+	assert.Nil(t, (*BasisHarvester)(nil).GetBasis())
+}
+
+func TestBasisHarvesterBasis(t *testing.T) {
+	t.Parallel()
+	m, _, _, _ := testBasisHarvesterSetup(t, true)
+	positions := []futures.Position{
+		{Exchange: "BASIS", Asset: asset.USDTMarginedFutures, Pair: currency.NewBTCUSDT(), LatestDirection: order.Short, LatestSize: decimal.NewFromInt(-2)},
+		{Exchange: "basis", Asset: asset.USDTMarginedFutures, Pair: currency.NewBTCUSDT(), LatestDirection: order.Long, LatestSize: decimal.NewFromInt(5)},
+		{Exchange: "basis", Asset: asset.USDTMarginedFutures, Pair: currency.NewPair(currency.ETH, currency.USDT), LatestDirection: order.Short, LatestSize: decimal.NewFromInt(5)},
+	}
+	b, err := m.basis(context.Background(), &m.markets[0], positions, time.Now())
+	require.NoError(t, err)
+	assert.InDelta(t, 0.0002, b.Premium, 1e-9)
+	assert.InDelta(t, 0.0002*365*3, b.AnnualisedBasis, 1e-9, "basis should be annualised by funding intervals per year")
+	assert.Equal(t, 2.0, b.Held, "only short positions in the perpetual should be held")
+
+	basis, ok := cache.Analytics.Get(perpetualBasisKey(b))
+	require.True(t, ok, "basis must be shared via the analytics cache")
+	assert.Equal(t, b.AnnualisedBasis, basis)
+	latest := m.GetBasis()
+	require.Len(t, latest, 1)
+	assert.Equal(t, *b, latest[0])
+}
+
+func TestBasisHarvesterPlan(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name   string
+		held   float64
+		basis  float64
+		margin float64
+		action basisAction
+		amount float64
+	}{
+		{name: "open above entry", basis: 0.2, action: basisOpen, amount: 2},
+		{name: "wait below entry", basis: 0.1, action: basisHold},
+		{name: "margin prevents open", basis: 0.2, margin: 0.6, action: basisHold},
+		{name: "hold above exit", held: 2, basis: 0.1, action: basisHold},
+		{name: "unwind at convergence", held: 2, basis: 0.02, action: basisUnwind, amount: 2},
+		{name: "unwind negative basis", held: 2, basis: -0.05, margin: 0.6, action: basisUnwind, amount: 2},
+		{name: "reduce on margin", held: 2, basis: 0.1, margin: 0.5, action: basisReduce, amount: 1},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			m, err := SetupBasisHarvester(testBasisHarvesterConfig(true), NewExchangeManager(), &fakeFuturesOrderManager{}, &fakeComms{}, &fakeBasisMarginMonitor{ratio: tc.margin}, nil)
+			require.NoError(t, err)
+			action, amount := m.plan(&m.markets[0], &Basis{Held: tc.held, AnnualisedBasis: tc.basis})
+			assert.Equal(t, tc.action, action)
+			assert.Equal(t, tc.amount, amount)
+		})
+	}
+}
+
+func TestBasisHarvesterDryRun(t *testing.T) {
+	t.Parallel()
+	m, _, om, comms := testBasisHarvesterSetup(t, true)
+	m.checkMarkets(context.Background())
+	m.checkMarkets(context.Background())
+	assert.Empty(t, om.submitted, "dry run should not place orders")
+	require.Len(t, comms.events, 1, "dry run trades should only be reported once")
+	assert.Equal(t, basisEventType, comms.events[0].Type)
+	assert.Contains(t, comms.events[0].Message, "dry run would open")
+}
+
+func TestBasisHarvesterOpenAndUnwind(t *testing.T) {
+	t.Parallel()
+	m, exch, om, comms := testBasisHarvesterSetup(t, false)
+	m.checkMarkets(context.Background())
+	require.Len(t, om.submitted, 2, "spot and perpetual legs must be submitted")
+	assert.Equal(t, asset.Spot, om.submitted[0].AssetType)
+	assert.Equal(t, order.Buy, om.submitted[0].Side, "spot should be bought")
+	assert.Equal(t, asset.USDTMarginedFutures, om.submitted[1].AssetType)
+	assert.Equal(t, order.Sell, om.submitted[1].Side, "perpetual should be sold")
+	assert.Equal(t, 2.0, om.submitted[1].Amount)
+	require.Len(t, comms.events, 1)
+	assert.Contains(t, comms.events[0].Message, "opened")
+
+	om.submitted = nil
+	om.positions = []futures.Position{{Exchange: "basis", Asset: asset.USDTMarginedFutures, Pair: currency.NewBTCUSDT(), LatestDirection: order.Short, LatestSize: decimal.NewFromInt(2)}}
+	m.checkMarkets(context.Background())
+	assert.Empty(t, om.submitted, "positions should be held until the basis converges")
+
+	exch.prices[asset.USDTMarginedFutures] = 100.001
+	m.checkMarkets(context.Background())
+	require.Len(t, om.submitted, 2, "both legs must be unwound")
+	assert.Equal(t, asset.USDTMarginedFutures, om.submitted[0].AssetType, "the perpetual should be closed first")
+	assert.Equal(t, order.Buy, om.submitted[0].Side)
+	assert.True(t, om.submitted[0].ReduceOnly, "closing the perpetual should be reduce only")
+	assert.Equal(t, asset.Spot, om.submitted[1].AssetType)
+	assert.Equal(t, order.Sell, om.submitted[1].Side)
+	require.Len(t, comms.events, 2)
+	assert.Contains(t, comms.events[1].Message, "unwound")
+}
+
+func TestBasisHarvesterReduceOnMargin(t *testing.T) {
+	t.Parallel()
+	m, _, om, comms := testBasisHarvesterSetup(t, false)
+	m.marginMonitor = &fakeBasisMarginMonitor{ratio: 0.6}
+	m.checkMarkets(context.Background())
+	assert.Empty(t, om.submitted, "positions should not be opened while margin is exceeded")
+
+	om.positions = []futures.Position{{Exchange: "basis", Asset: asset.USDTMarginedFutures, Pair: currency.NewBTCUSDT(), LatestDirection: order.Short, LatestSize: decimal.NewFromInt(2)}}
+	m.checkMarkets(context.Background())
+	require.Len(t, om.submitted, 2, "both legs must be reduced")
+	assert.Equal(t, asset.USDTMarginedFutures, om.submitted[0].AssetType, "the perpetual should be reduced first")
+	assert.True(t, om.submitted[0].ReduceOnly, "reducing the perpetual should be reduce only")
+	assert.Equal(t, 1.0, om.submitted[0].Amount, "the held amount should be reduced by the reduce fraction")
+	assert.Equal(t, order.Sell, om.submitted[1].Side)
+	assert.Equal(t, 1.0, om.submitted[1].Amount)
+	require.Len(t, comms.events, 1)
+	assert.Contains(t, comms.events[0].Message, "reduced")
+}
+
+func TestBasisHarvesterQuarantine(t *testing.T) {
+	t.Parallel()
+	m, _, om, comms := testBasisHarvesterSetup(t, false)
+	m.quarantine = &fakeQuarantine{exchanges: []string{"basis"}}
+	m.checkMarkets(context.Background())
+	m.checkMarkets(context.Background())
+	assert.Empty(t, om.submitted, "markets with quarantined market data should not be traded")
+	require.Len(t, comms.events, 1, "withheld trades should only be reported once")
+	assert.Contains(t, comms.events[0].Message, "quarantined")
+
+	m.quarantine = &fakeQuarantine{}
+	m.checkMarkets(context.Background())
+	assert.Len(t, om.submitted, 2, "markets should be traded once released")
+}
+
+func TestSubmitHedged(t *testing.T) {
+	t.Parallel()
+	m, _, om, comms := testBasisHarvesterSetup(t, false)
+	om.reject = func(s *order.Submit) error {
+		if s.AssetType == asset.USDTMarginedFutures {
+			return errExpectedTestError
+		}
+		return nil
+	}
+	m.checkMarkets(context.Background())
+	require.Len(t, om.submitted, 2, "the spot leg must be reversed")
+	assert.Equal(t, order.Buy, om.submitted[0].Side)
+	assert.Equal(t, order.Sell, om.submitted[1].Side, "the spot purchase should be sold")
+	assert.False(t, om.submitted[1].ReduceOnly, "spot reversals should not be reduce only")
+	require.Len(t, comms.events, 1)
+	assert.Contains(t, comms.events[0].Message, "reversed")
+
+	om.submitted = nil
+	om.reject = func(s *order.Submit) error {
+		if s.AssetType == asset.Spot {
+			return errExpectedTestError
+		}
+		return nil
+	}
+	first := &order.Submit{Exchange: "basis", Pair: currency.NewBTCUSDT(), AssetType: asset.USDTMarginedFutures, Side: order.Buy, Amount: 1, ReduceOnly: true}
+	second := &order.Submit{Exchange: "basis", Pair: currency.NewBTCUSDT(), AssetType: asset.Spot, Side: order.Sell, Amount: 1}
+	err := m.submitHedged(context.Background(), first, second)
+	assert.ErrorIs(t, err, errExpectedTestError)
+	require.Len(t, om.submitted, 2)
+	assert.Equal(t, order.Sell, om.submitted[1].Side, "the closed short should be reopened")
+	assert.False(t, om.submitted[1].ReduceOnly, "reopening a short should not be reduce only")
+
+	om.submitted = nil
+	var calls int
+	om.reject = func(*order.Submit) error {
+		if calls++; calls > 1 {
+			return errExpectedTestError
+		}
+		return nil
+	}
+	err = m.submitHedged(context.Background(), first, second)
+	assert.ErrorIs(t, err, errBasisUnhedged, "failed reversals must be reported as unhedged")
+	assert.Len(t, om.submitted, 1)
+
+	om.submitted = nil
+	om.reject = func(*order.Submit) error { return errExpectedTestError }
+	err = m.submitHedged(context.Background(), first, second)
+	assert.ErrorIs(t, err, errExpectedTestError)
+	assert.Empty(t, om.submitted)
+}
@@ -0,0 +1,105 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// BasisHarvesterName is an exported subsystem name
+const BasisHarvesterName = "basis_harvester"
+
+// basisEventType is the communications event type used for basis harvester
+// trades
+const basisEventType = "basis"
+
+var (
+	errInvalidBasisHarvesterConfig = errors.New("invalid basis harvester config")
+	errBasisPriceUnset             = errors.New("basis harvester price unavailable")
+	errBasisUnhedged               = errors.New("basis position left unhedged")
+)
+
+// basisAction is the trade required to manage a basis position
+type basisAction uint8
+
+const (
+	basisHold basisAction = iota
+	basisOpen
+	basisUnwind
+	basisReduce
+)
+
+// iBasisOrderManager defines the order manager functions used to find and
+// trade basis positions
+type iBasisOrderManager interface {
+	GetAllOpenFuturesPositions() ([]futures.Position, error)
+	Submit(context.Context, *order.Submit) (*OrderSubmitResponse, error)
+}
+
+// iBasisMarginMonitor defines the margin monitor functions used to manage the
+// margin of perpetual positions
+type iBasisMarginMonitor interface {
+	MaintenanceMarginRatio(exchName string, a asset.Item) (float64, bool)
+}
+
+// BasisHarvester is a cash and carry strategy which buys spot and sells the
+// matching perpetual when the perpetual's annualised basis is high, then
+// unwinds both legs once the basis converges
+type BasisHarvester struct {
+	started         int32
+	shutdown        chan struct{}
+	wg              sync.WaitGroup
+	verbose         bool
+	dryRun          bool
+	interval        time.Duration
+	fundingInterval time.Duration
+	entryThreshold  float64
+	exitThreshold   float64
+	maxMarginRatio  float64
+	reduceFraction  float64
+	markets         []basisMarket
+	exchangeManager iExchangeManager
+	orderManager    iBasisOrderManager
+	comms           iCommsManager
+	marginMonitor   iBasisMarginMonitor
+	quarantine      iMarketDataQuarantine
+	// reported holds dry run trades and pricing failures which have been
+	// reported so they are not repeated every check
+	reported map[string]struct{}
+	m        sync.RWMutex
+	latest   map[string]Basis
+}
+
+// basisMarket is a spot pair and the perpetual contract hedging it
+type basisMarket struct {
+	exchange       string
+	spot           currency.Pair
+	perpetual      currency.Pair
+	perpetualAsset asset.Item
+	amount         float64
+}
+
+// Basis is the premium of a perpetual contract over its spot pair and the
+// size of the basis position held
+type Basis struct {
+	Exchange       string
+	Spot           currency.Pair
+	Perpetual      currency.Pair
+	PerpetualAsset asset.Item
+	SpotPrice      float64
+	PerpetualPrice float64
+	// Premium is the perpetual price premium relative to the spot price
+	Premium float64
+	// AnnualisedBasis is the premium scaled by the number of funding
+	// intervals in a year
+	AnnualisedBasis float64
+	// Held is the base currency amount of the short perpetual position
+	Held float64
+	Time time.Time
+}
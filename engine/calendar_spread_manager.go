@@ -0,0 +1,410 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/cache"
+	"github.com/thrasher-corp/gocryptotrader/common/key"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/calendarspread"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// SetupCalendarSpreadManager creates a calendar spread manager subsystem. A
+// database connection manager is only required when spread history is stored
+func SetupCalendarSpreadManager(cfg *config.CalendarSpreadManager, em iExchangeManager, comms iCommsManager, dcm iDatabaseConnectionManager) (*CalendarSpreadManager, error) {
+	if cfg == nil {
+		return nil, errNilConfig
+	}
+	if em == nil {
+		return nil, errNilExchangeManager
+	}
+	if comms == nil {
+		return nil, errNilCommunicationsManager
+	}
+	if cfg.StoreHistory && dcm == nil {
+		return nil, errNilDatabaseConnectionManager
+	}
+	if cfg.CheckInterval <= 0 {
+		return nil, fmt.Errorf("%w check interval %v", errInvalidCalendarSpreadConfig, cfg.CheckInterval)
+	}
+	if cfg.HistorySize <= 1 {
+		return nil, fmt.Errorf("%w history size %d", errInvalidCalendarSpreadConfig, cfg.HistorySize)
+	}
+	if cfg.MinSamples <= 1 || cfg.MinSamples > cfg.HistorySize {
+		return nil, fmt.Errorf("%w min samples %d", errInvalidCalendarSpreadConfig, cfg.MinSamples)
+	}
+	if cfg.ZScoreThreshold <= 0 {
+		return nil, fmt.Errorf("%w z-score threshold %v", errInvalidCalendarSpreadConfig, cfg.ZScoreThreshold)
+	}
+	m := &CalendarSpreadManager{
+		verbose:         cfg.Verbose,
+		interval:        cfg.CheckInterval,
+		historySize:     cfg.HistorySize,
+		minSamples:      cfg.MinSamples,
+		threshold:       cfg.ZScoreThreshold,
+		storeHistory:    cfg.StoreHistory,
+		exchangeManager: em,
+		comms:           comms,
+		spreadSaver:     calendarspread.Insert,
+		historyLoader:   calendarspread.GetInRange,
+		series:          make(map[string]*spreadSeries),
+	}
+	if dcm != nil {
+		m.database = dcm.GetInstance()
+	}
+	if len(cfg.Underlyings) > 0 {
+		m.underlyings = make(map[string]struct{}, len(cfg.Underlyings))
+		for i := range cfg.Underlyings {
+			p, err := currency.NewPairFromString(cfg.Underlyings[i])
+			if err != nil {
+				return nil, fmt.Errorf("%w underlying %q: %w", errInvalidCalendarSpreadConfig, cfg.Underlyings[i], err)
+			}
+			m.underlyings[underlyingKey(p)] = struct{}{}
+		}
+	}
+	return m, nil
+}
+
+// Start runs the subsystem
+func (m *CalendarSpreadManager) Start() error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 0, 1) {
+		return ErrSubSystemAlreadyStarted
+	}
+	m.shutdown = make(chan struct{})
+	m.wg.Add(1)
+	go m.run()
+	log.Debugf(log.ExchangeSys, "Calendar spread manager %s", MsgSubSystemStarted)
+	return nil
+}
+
+// IsRunning checks whether the subsystem is running
+func (m *CalendarSpreadManager) IsRunning() bool {
+	if m == nil {
+		return false
+	}
+	return atomic.LoadInt32(&m.started) == 1
+}
+
+// Stop stops the subsystem
+func (m *CalendarSpreadManager) Stop() error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 1, 0) {
+		return ErrSubSystemNotStarted
+	}
+	close(m.shutdown)
+	m.wg.Wait()
+	log.Debugf(log.ExchangeSys, "Calendar spread manager %s", MsgSubSystemShutdown)
+	return nil
+}
+
+// GetSpreads returns the latest spreads for an underlying, or all spreads when
+// the underlying is empty
+func (m *CalendarSpreadManager) GetSpreads(underlying currency.Pair) []CalendarSpread {
+	if m == nil {
+		return nil
+	}
+	m.m.RLock()
+	defer m.m.RUnlock()
+	spreads := make([]CalendarSpread, 0, len(m.series))
+	for _, s := range m.series {
+		if !underlying.IsEmpty() && underlyingKey(s.latest.Underlying) != underlyingKey(underlying) {
+			continue
+		}
+		spreads = append(spreads, s.latest)
+	}
+	slices.SortFunc(spreads, func(a, b CalendarSpread) int {
+		return strings.Compare(spreadSeriesKey(&a), spreadSeriesKey(&b))
+	})
+	return spreads
+}
+
+func (m *CalendarSpreadManager) run() {
+	defer m.wg.Done()
+	runScheduledJobs(m.shutdown, &scheduledJob{interval: m.interval, immediate: true, run: func(ctx context.Context, _ time.Time) { m.checkSpreads(ctx) }})
+}
+
+// checkSpreads calculates the spread of every perpetual and dated contract
+// pairing per underlying, alerts on extreme z-scores and stores the samples
+func (m *CalendarSpreadManager) checkSpreads(ctx context.Context) {
+	if !atomic.CompareAndSwapInt32(&m.processing, 0, 1) {
+		return
+	}
+	defer atomic.StoreInt32(&m.processing, 0)
+	perpetuals, dated := m.collectLegs(ctx)
+	now := time.Now()
+	var samples []calendarspread.Data
+	for k, perps := range perpetuals {
+		for i := range perps {
+			for j := range dated[k] {
+				if !dated[k][j].expiry.After(now) {
+					continue
+				}
+				s := m.recordSpread(&perps[i], &dated[k][j], now)
+				if m.verbose {
+					log.Debugf(log.ExchangeSys, "Calendar spread manager %s %s %s vs %s %s spread %.6f annualised %.6f z-score %.2f",
+						underlyingKey(s.Underlying), s.PerpetualExchange, s.Perpetual, s.DatedExchange, s.Dated, s.Spread, s.AnnualisedSpread, s.ZScore)
+				}
+				samples = append(samples, calendarspread.Data{
+					UnderlyingBase:    s.Underlying.Base.String(),
+					UnderlyingQuote:   s.Underlying.Quote.String(),
+					PerpetualExchange: s.PerpetualExchange,
+					PerpetualAsset:    s.PerpetualAsset.String(),
+					PerpetualPair:     s.Perpetual.String(),
+					DatedExchange:     s.DatedExchange,
+					DatedAsset:        s.DatedAsset.String(),
+					DatedPair:         s.Dated.String(),
+					Expiry:            s.Expiry,
+					PerpetualPrice:    s.PerpetualPrice,
+					DatedPrice:        s.DatedPrice,
+					Spread:            s.Spread,
+					AnnualisedSpread:  s.AnnualisedSpread,
+					ZScore:            s.ZScore,
+					Timestamp:         s.Time,
+				})
+			}
+		}
+	}
+	if len(samples) == 0 || !m.storeHistory {
+		return
+	}
+	if !m.database.IsConnected() {
+		if m.verbose {
+			log.Debugln(log.ExchangeSys, "Calendar spread manager skipping storage as the database is not connected")
+		}
+		return
+	}
+	if err := m.spreadSaver(samples...); err != nil {
+		log.Errorf(log.ExchangeSys, "Calendar spread manager unable to store spreads: %v", err)
+	}
+}
+
+// collectLegs returns priced perpetual and dated contracts of enabled pairs
+// keyed by underlying. Only underlyings with both a perpetual and a dated
+// contract are priced
+func (m *CalendarSpreadManager) collectLegs(ctx context.Context) (perpetuals, dated map[string][]spreadLeg) {
+	exchanges, err := m.exchangeManager.GetExchanges()
+	if err != nil {
+		log.Errorf(log.ExchangeSys, "Calendar spread manager cannot get exchanges: %v", err)
+		return nil, nil
+	}
+	perpetuals, dated = make(map[string][]spreadLeg), make(map[string][]spreadLeg)
+	for x := range exchanges {
+		assets := exchanges[x].GetAssetTypes(true)
+		for y := range assets {
+			if !assets[y].IsFutures() {
+				continue
+			}
+			enabled, err := exchanges[x].GetEnabledPairs(assets[y])
+			if err != nil || len(enabled) == 0 {
+				continue
+			}
+			contracts, err := exchanges[x].GetFuturesContractDetails(ctx, assets[y])
+			if err != nil {
+				if !errors.Is(err, common.ErrFunctionNotSupported) && !errors.Is(err, common.ErrNotYetImplemented) {
+					log.Errorf(log.ExchangeSys, "Calendar spread manager cannot get %s %s contract details: %v", exchanges[x].GetName(), assets[y], err)
+				}
+				continue
+			}
+			for i := range contracts {
+				if contracts[i].Underlying.IsEmpty() || !enabled.Contains(contracts[i].Name, true) {
+					continue
+				}
+				k := underlyingKey(contracts[i].Underlying)
+				if m.underlyings != nil {
+					if _, ok := m.underlyings[k]; !ok {
+						continue
+					}
+				}
+				leg := spreadLeg{
+					exch:       exchanges[x],
+					asset:      assets[y],
+					pair:       contracts[i].Name,
+					underlying: contracts[i].Underlying,
+					expiry:     contracts[i].EndDate,
+				}
+				if contracts[i].Type == futures.Perpetual || contracts[i].EndDate.IsZero() {
+					perpetuals[k] = append(perpetuals[k], leg)
+				} else {
+					dated[k] = append(dated[k], leg)
+				}
+			}
+		}
+	}
+	for k := range perpetuals {
+		if len(dated[k]) == 0 {
+			delete(perpetuals, k)
+			continue
+		}
+		perpetuals[k] = m.priceLegs(ctx, perpetuals[k])
+		dated[k] = m.priceLegs(ctx, dated[k])
+	}
+	return perpetuals, dated
+}
+
+// priceLegs sets the last traded price of each leg, dropping legs without a
+// price
+func (m *CalendarSpreadManager) priceLegs(ctx context.Context, legs []spreadLeg) []spreadLeg {
+	priced := legs[:0]
+	for i := range legs {
+		t, err := legs[i].exch.FetchTicker(ctx, legs[i].pair, legs[i].asset)
+		if err != nil {
+			log.Errorf(log.ExchangeSys, "Calendar spread manager cannot get %s %s %s price: %v", legs[i].exch.GetName(), legs[i].asset, legs[i].pair, err)
+			continue
+		}
+		if t.Last <= 0 {
+			continue
+		}
+		legs[i].price = t.Last
+		priced = append(priced, legs[i])
+	}
+	return priced
+}
+
+// recordSpread calculates the spread between a perpetual and dated leg, scores
+// it against the series history and alerts when the z-score crosses the
+// threshold
+func (m *CalendarSpreadManager) recordSpread(perp, dated *spreadLeg, now time.Time) CalendarSpread {
+	s := CalendarSpread{
+		Underlying:        perp.underlying,
+		PerpetualExchange: perp.exch.GetName(),
+		PerpetualAsset:    perp.asset,
+		Perpetual:         perp.pair,
+		DatedExchange:     dated.exch.GetName(),
+		DatedAsset:        dated.asset,
+		Dated:             dated.pair,
+		Expiry:            dated.expiry,
+		PerpetualPrice:    perp.price,
+		DatedPrice:        dated.price,
+		Spread:            (dated.price - perp.price) / perp.price,
+		Time:              now,
+	}
+	s.AnnualisedSpread = s.Spread * daysPerYear * 24 / dated.expiry.Sub(now).Hours()
+	if strings.EqualFold(s.PerpetualExchange, s.DatedExchange) {
+		// Share the basis so other consumers need not recompute it
+		cache.Analytics.SetWithTTL(basisKey(&s), s.AnnualisedSpread, m.interval*2)
+	}
+	k := spreadSeriesKey(&s)
+
+	m.m.RLock()
+	series, ok := m.series[k]
+	m.m.RUnlock()
+	if !ok {
+		series = &spreadSeries{samples: m.loadHistory(&s)}
+	}
+
+	m.m.Lock()
+	if len(series.samples) >= m.minSamples {
+		s.ZScore = zScore(series.samples, s.Spread)
+	}
+	series.samples = append(series.samples, s.Spread)
+	if len(series.samples) > m.historySize {
+		series.samples = series.samples[len(series.samples)-m.historySize:]
+	}
+	s.Samples = len(series.samples)
+	series.latest = s
+	extreme := math.Abs(s.ZScore) >= m.threshold
+	alert := extreme && !series.alerting
+	resolved := !extreme && series.alerting
+	series.alerting = extreme
+	m.series[k] = series
+	m.m.Unlock()
+
+	switch {
+	case alert:
+		msg := fmt.Sprintf("Calendar spread %s %s %s vs %s %s spread %.4f%% z-score %.2f exceeds %.2f",
+			underlyingKey(s.Underlying), s.PerpetualExchange, s.Perpetual, s.DatedExchange, s.Dated, s.Spread*100, s.ZScore, m.threshold)
+		log.Warnln(log.ExchangeSys, msg)
+		m.comms.PushEvent(base.Event{Type: calendarSpreadEventType, Message: msg, Severity: base.SeverityWarning, Exchange: s.DatedExchange, Key: k})
+	case resolved:
+		msg := fmt.Sprintf("Calendar spread %s %s %s vs %s %s spread %.4f%% z-score %.2f returned within %.2f",
+			underlyingKey(s.Underlying), s.PerpetualExchange, s.Perpetual, s.DatedExchange, s.Dated, s.Spread*100, s.ZScore, m.threshold)
+		log.Infoln(log.ExchangeSys, msg)
+		m.comms.PushEvent(base.Event{Type: calendarSpreadEventType, Message: msg, Severity: base.SeverityInfo, Exchange: s.DatedExchange, Key: k, Resolved: true})
+	}
+	return s
+}
+
+// loadHistory returns stored spreads of a new series so z-scores are available
+// after a restart
+func (m *CalendarSpreadManager) loadHistory(s *CalendarSpread) []float64 {
+	if !m.storeHistory || !m.database.IsConnected() {
+		return nil
+	}
+	data, err := m.historyLoader(s.PerpetualExchange, s.PerpetualAsset.String(), s.Perpetual.String(),
+		s.DatedExchange, s.DatedAsset.String(), s.Dated.String(),
+		s.Time.Add(-m.interval*time.Duration(m.historySize)), s.Time)
+	if err != nil {
+		if m.verbose {
+			log.Debugf(log.ExchangeSys, "Calendar spread manager cannot load %s history: %v", spreadSeriesKey(s), err)
+		}
+		return nil
+	}
+	if len(data) > m.historySize {
+		data = data[len(data)-m.historySize:]
+	}
+	samples := make([]float64, len(data))
+	for i := range data {
+		samples[i] = data[i].Spread
+	}
+	return samples
+}
+
+// zScore returns the number of standard deviations a value is from the mean
+// of samples, or zero when the samples do not vary
+func zScore(samples []float64, value float64) float64 {
+	var mean float64
+	for i := range samples {
+		mean += samples[i]
+	}
+	mean /= float64(len(samples))
+	var variance float64
+	for i := range samples {
+		variance += (samples[i] - mean) * (samples[i] - mean)
+	}
+	stdDev := math.Sqrt(variance / float64(len(samples)))
+	if stdDev == 0 {
+		return 0
+	}
+	return (value - mean) / stdDev
+}
+
+// underlyingKey returns an underlying key which ignores pair formatting
+func underlyingKey(p currency.Pair) string {
+	return p.Base.Upper().String() + "-" + p.Quote.Upper().String()
+}
+
+// basisKey returns the cache.Analytics key of a dated contract's basis
+func basisKey(s *CalendarSpread) key.ExchangePairAssetMetric {
+	return key.ExchangePairAssetMetric{
+		ExchangePairAsset: key.ExchangePairAsset{
+			Exchange: s.DatedExchange,
+			Base:     s.Dated.Base.Item,
+			Quote:    s.Dated.Quote.Item,
+			Asset:    s.DatedAsset,
+		},
+		Metric: AnalyticsMetricBasis,
+	}
+}
+
+// spreadSeriesKey identifies the perpetual and dated contracts of a spread
+func spreadSeriesKey(s *CalendarSpread) string {
+	return strings.ToLower(s.PerpetualExchange) + " " + s.PerpetualAsset.String() + " " + s.Perpetual.Upper().String() +
+		" " + strings.ToLower(s.DatedExchange) + " " + s.DatedAsset.String() + " " + s.Dated.Upper().String()
+}
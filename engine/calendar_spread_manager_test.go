@@ -0,0 +1,250 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/common/cache"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/calendarspread"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+)
+
+var (
+	btcPerpPair    = currency.NewPair(currency.BTC, currency.NewCode("USD_PERP"))
+	btcQuarterPair = currency.NewPair(currency.BTC, currency.NewCode("USD_QUARTER"))
+)
+
+// spreadExchange is a fake exchange with perpetual and dated contracts
+type spreadExchange struct {
+	exchange.IBotExchange
+	name      string
+	contracts map[asset.Item][]futures.Contract
+	prices    map[string]float64
+}
+
+func (f *spreadExchange) GetName() string {
+	return f.name
+}
+
+func (f *spreadExchange) GetAssetTypes(bool) asset.Items {
+	assets := asset.Items{asset.Spot}
+	for a := range f.contracts {
+		assets = append(assets, a)
+	}
+	return assets
+}
+
+func (f *spreadExchange) GetEnabledPairs(a asset.Item) (currency.Pairs, error) {
+	var pairs currency.Pairs
+	for i := range f.contracts[a] {
+		pairs = append(pairs, f.contracts[a][i].Name)
+	}
+	return pairs, nil
+}
+
+func (f *spreadExchange) GetFuturesContractDetails(_ context.Context, a asset.Item) ([]futures.Contract, error) {
+	return f.contracts[a], nil
+}
+
+func (f *spreadExchange) FetchTicker(_ context.Context, p currency.Pair, a asset.Item) (*ticker.Price, error) {
+	return &ticker.Price{Pair: p, AssetType: a, Last: f.prices[p.String()]}, nil
+}
+
+type spreadSaverRecorder struct {
+	saved []calendarspread.Data
+}
+
+func (f *spreadSaverRecorder) save(spreads ...calendarspread.Data) error {
+	f.saved = append(f.saved, spreads...)
+	return nil
+}
+
+func testCalendarSpreadConfig() *config.CalendarSpreadManager {
+	return &config.CalendarSpreadManager{
+		CheckInterval:   time.Minute,
+		HistorySize:     10,
+		MinSamples:      3,
+		ZScoreThreshold: 2,
+	}
+}
+
+func testCalendarSpreadSetup(t *testing.T, cfg *config.CalendarSpreadManager) (*CalendarSpreadManager, *spreadExchange, *spreadExchange, *fakeComms) {
+	t.Helper()
+	underlying := currency.NewPair(currency.BTC, currency.USD)
+	dated := &spreadExchange{
+		name: "dated",
+		contracts: map[asset.Item][]futures.Contract{
+			asset.PerpetualSwap: {{Name: btcPerpPair, Underlying: underlying, Type: futures.Perpetual}},
+			asset.Futures: {
+				{Name: btcQuarterPair, Underlying: underlying, Type: futures.Quarterly, EndDate: time.Now().Add(time.Hour * 24 * 73)},
+				{Name: currency.NewPair(currency.BTC, currency.NewCode("USD_OLD")), Underlying: underlying, Type: futures.Quarterly, EndDate: time.Now().Add(-time.Hour)},
+				{Name: currency.NewPair(currency.ETH, currency.NewCode("USD_QUARTER")), Underlying: currency.NewPair(currency.ETH, currency.USD), Type: futures.Quarterly, EndDate: time.Now().Add(time.Hour * 24 * 73)},
+			},
+		},
+		prices: map[string]float64{btcPerpPair.String(): 100, btcQuarterPair.String(): 102},
+	}
+	perp := &spreadExchange{
+		name: "perp",
+		contracts: map[asset.Item][]futures.Contract{
+			asset.USDTMarginedFutures: {{Name: btcPerpPair, Underlying: currency.NewPair(currency.BTC, currency.NewCode("usd")), Type: futures.Perpetual}},
+		},
+		prices: map[string]float64{btcPerpPair.String(): 101},
+	}
+	comms := &fakeComms{}
+	var dcm iDatabaseConnectionManager
+	if cfg.StoreHistory {
+		dcm = &DatabaseConnectionManager{}
+	}
+	m, err := SetupCalendarSpreadManager(cfg, testExchangeManager(t, dated, perp), comms, dcm)
+	require.NoError(t, err)
+	return m, dated, perp, comms
+}
+
+func TestSetupCalendarSpreadManager(t *testing.T) {
+	t.Parallel()
+	_, err := SetupCalendarSpreadManager(nil, nil, nil, nil)
+	assert.ErrorIs(t, err, errNilConfig)
+	cfg := testCalendarSpreadConfig()
+	cfg.MinSamples = cfg.HistorySize + 1
+	_, err = SetupCalendarSpreadManager(cfg, NewExchangeManager(), &fakeComms{}, nil)
+	assert.ErrorIs(t, err, errInvalidCalendarSpreadConfig, "min samples must not exceed history size")
+	cfg = testCalendarSpreadConfig()
+	cfg.Underlyings = []string{"btc-usdt"}
+	m, err := SetupCalendarSpreadManager(cfg, NewExchangeManager(), &fakeComms{}, nil)
+	require.NoError(t, err)
+	assert.Contains(t, m.underlyings, "BTC-USDT")
+}
+
+func TestCalendarSpreadManagerStartStop(t *testing.T) {
+	t.Parallel()
+	m, _, _, _ := testCalendarSpreadSetup(t, testCalendarSpreadConfig())
+	testStartStop(t, (*CalendarSpreadManager)(nil), m)
+}
+
+func TestCheckSpreads(t *testing.T) {
+	t.Parallel()
+	m, _, _, comms := testCalendarSpreadSetup(t, testCalendarSpreadConfig())
+	m.checkSpreads(context.Background())
+	spreads := m.GetSpreads(currency.NewPair(currency.BTC, currency.USD))
+	require.Len(t, spreads, 2, "dated contract should be compared against perpetuals on all exchanges")
+	assert.Equal(t, "dated", spreads[0].PerpetualExchange)
+	assert.Equal(t, asset.PerpetualSwap, spreads[0].PerpetualAsset)
+	assert.Equal(t, btcQuarterPair, spreads[0].Dated)
+	assert.InDelta(t, 0.02, spreads[0].Spread, 1e-9)
+	assert.InDelta(t, 0.1, spreads[0].AnnualisedSpread, 1e-3)
+	assert.Zero(t, spreads[0].ZScore, "z-score should not be set before min samples")
+	assert.Equal(t, 1, spreads[0].Samples)
+	basis, ok := cache.Analytics.Get(basisKey(&spreads[0]))
+	require.True(t, ok, "basis must be cached for a spread against a perpetual on the same exchange")
+	assert.IsType(t, float64(0), basis)
+	assert.Equal(t, "perp", spreads[1].PerpetualExchange)
+	assert.InDelta(t, 1.0/101, spreads[1].Spread, 1e-9)
+	assert.Empty(t, m.GetSpreads(currency.NewPair(currency.ETH, currency.USD)), "underlyings without a perpetual should be ignored")
+	assert.Len(t, m.GetSpreads(currency.EMPTYPAIR), 2)
+	assert.Empty(t, comms.events)
+
+	m.underlyings = map[string]struct{}{"ETH-USD": {}}
+	m.series = make(map[string]*spreadSeries)
+	m.checkSpreads(context.Background())
+	assert.Empty(t, m.GetSpreads(currency.EMPTYPAIR), "unlisted underlyings should be ignored")
+}
+
+func TestCalendarSpreadAlerts(t *testing.T) {
+	t.Parallel()
+	m, dated, perp, comms := testCalendarSpreadSetup(t, testCalendarSpreadConfig())
+	delete(perp.prices, btcPerpPair.String())
+	for _, p := range []float64{102, 102.1, 101.9, 102} {
+		dated.prices[btcQuarterPair.String()] = p
+		m.checkSpreads(context.Background())
+	}
+	require.Len(t, m.GetSpreads(currency.EMPTYPAIR), 1, "unpriced legs should be ignored")
+	assert.Empty(t, comms.events, "normal spreads should not alert")
+
+	dated.prices[btcQuarterPair.String()] = 105
+	m.checkSpreads(context.Background())
+	require.Len(t, comms.events, 1, "extreme spread should alert")
+	assert.Equal(t, calendarSpreadEventType, comms.events[0].Type)
+	assert.Equal(t, base.SeverityWarning, comms.events[0].Severity)
+	assert.False(t, comms.events[0].Resolved)
+	assert.Greater(t, m.GetSpreads(currency.EMPTYPAIR)[0].ZScore, 2.0)
+
+	dated.prices[btcQuarterPair.String()] = 108
+	m.checkSpreads(context.Background())
+	assert.Len(t, comms.events, 1, "ongoing extreme spread should not alert again")
+
+	dated.prices[btcQuarterPair.String()] = 104
+	m.checkSpreads(context.Background())
+	require.Len(t, comms.events, 2)
+	assert.True(t, comms.events[1].Resolved, "spread returning within threshold should resolve the alert")
+	assert.Equal(t, comms.events[0].Key, comms.events[1].Key)
+}
+
+func TestCalendarSpreadAlertsCollapse(t *testing.T) {
+	t.Parallel()
+	m, dated, perp, comms := testCalendarSpreadSetup(t, testCalendarSpreadConfig())
+	delete(perp.prices, btcPerpPair.String())
+	for _, p := range []float64{102, 102.1, 101.9, 102, 97} {
+		dated.prices[btcQuarterPair.String()] = p
+		m.checkSpreads(context.Background())
+	}
+	require.Len(t, comms.events, 1, "collapsing spreads should alert")
+	assert.Less(t, m.GetSpreads(currency.EMPTYPAIR)[0].ZScore, -2.0)
+}
+
+func TestCalendarSpreadHistorySize(t *testing.T) {
+	t.Parallel()
+	cfg := testCalendarSpreadConfig()
+	cfg.HistorySize = 3
+	m, dated, perp, _ := testCalendarSpreadSetup(t, cfg)
+	delete(perp.prices, btcPerpPair.String())
+	for _, p := range []float64{110, 101, 102, 101} {
+		dated.prices[btcQuarterPair.String()] = p
+		m.checkSpreads(context.Background())
+	}
+	assert.Equal(t, 3, m.GetSpreads(currency.EMPTYPAIR)[0].Samples, "samples should be capped at the history size")
+	dated.prices[btcQuarterPair.String()] = 103
+	m.checkSpreads(context.Background())
+	assert.Greater(t, m.GetSpreads(currency.EMPTYPAIR)[0].ZScore, 2.0, "spreads should only be scored against the retained history")
+}
+
+func TestCalendarSpreadHistory(t *testing.T) {
+	t.Parallel()
+	cfg := testCalendarSpreadConfig()
+	cfg.StoreHistory = true
+	m, _, _, _ := testCalendarSpreadSetup(t, cfg)
+	rec := &spreadSaverRecorder{}
+	m.spreadSaver = rec.save
+	m.database = &fillSyncDatabase{}
+	m.historyLoader = func(string, string, string, string, string, string, time.Time, time.Time) ([]calendarspread.Data, error) {
+		return []calendarspread.Data{{Spread: 0.01}, {Spread: 0.011}, {Spread: 0.009}}, nil
+	}
+	m.checkSpreads(context.Background())
+	assert.Empty(t, rec.saved, "spreads should not be stored while the database is disconnected")
+	assert.Equal(t, 1, m.GetSpreads(currency.EMPTYPAIR)[0].Samples, "history should not be loaded while the database is disconnected")
+
+	m.database = &fillSyncDatabase{connected: true}
+	m.series = make(map[string]*spreadSeries)
+	m.checkSpreads(context.Background())
+	require.Len(t, rec.saved, 2)
+	assert.Equal(t, "dated", rec.saved[0].DatedExchange)
+	assert.Equal(t, "BTC", rec.saved[0].UnderlyingBase)
+	spreads := m.GetSpreads(currency.EMPTYPAIR)
+	assert.Equal(t, 4, spreads[0].Samples, "stored history should seed new series")
+	assert.NotZero(t, spreads[0].ZScore, "seeded history should allow a z-score")
+}
+
+func TestZScore(t *testing.T) {
+	t.Parallel()
+	assert.Zero(t, zScore([]float64{1, 1, 1}, 2), "constant samples should not score")
+	assert.InDelta(t, 2.0, zScore([]float64{1, 3}, 4), 1e-9)
+	assert.InDelta(t, -2.0, zScore([]float64{1, 3}, 0), 1e-9)
+}
@@ -0,0 +1,96 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/calendarspread"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+// CalendarSpreadManagerName is an exported subsystem name
+const CalendarSpreadManagerName = "calendar_spread_manager"
+
+// calendarSpreadEventType is the communications event type used for spread
+// alerts
+const calendarSpreadEventType = "calendar_spread"
+
+// daysPerYear is used to annualise spreads by time to expiry
+const daysPerYear = 365
+
+// AnalyticsMetricBasis is the cache.Analytics metric holding the latest
+// annualised basis of a dated contract against the perpetual on its exchange,
+// or of a perpetual against spot when set by the basis harvester
+const AnalyticsMetricBasis = "basis"
+
+var errInvalidCalendarSpreadConfig = errors.New("invalid calendar spread manager config")
+
+// CalendarSpreadManager computes live spreads between perpetual and dated
+// futures contracts which share an underlying across all enabled exchanges,
+// stores spread history and alerts when a spread's z-score is extreme
+type CalendarSpreadManager struct {
+	started         int32
+	processing      int32
+	shutdown        chan struct{}
+	wg              sync.WaitGroup
+	verbose         bool
+	interval        time.Duration
+	underlyings     map[string]struct{}
+	historySize     int
+	minSamples      int
+	threshold       float64
+	storeHistory    bool
+	exchangeManager iExchangeManager
+	comms           iCommsManager
+	database        database.IDatabase
+	spreadSaver     func(...calendarspread.Data) error
+	historyLoader   func(perpetualExchange, perpetualAsset, perpetualPair, datedExchange, datedAsset, datedPair string, start, end time.Time) ([]calendarspread.Data, error)
+	m               sync.RWMutex
+	series          map[string]*spreadSeries
+}
+
+// spreadSeries holds the rolling spread history of a perpetual and dated
+// contract combination
+type spreadSeries struct {
+	samples  []float64
+	alerting bool
+	latest   CalendarSpread
+}
+
+// spreadLeg is a perpetual or dated contract with its latest price
+type spreadLeg struct {
+	exch       exchange.IBotExchange
+	asset      asset.Item
+	pair       currency.Pair
+	underlying currency.Pair
+	expiry     time.Time
+	price      float64
+}
+
+// CalendarSpread is the spread between a perpetual and a dated futures
+// contract on the same underlying
+type CalendarSpread struct {
+	Underlying        currency.Pair
+	PerpetualExchange string
+	PerpetualAsset    asset.Item
+	Perpetual         currency.Pair
+	DatedExchange     string
+	DatedAsset        asset.Item
+	Dated             currency.Pair
+	Expiry            time.Time
+	PerpetualPrice    float64
+	DatedPrice        float64
+	// Spread is the dated price premium relative to the perpetual price
+	Spread float64
+	// AnnualisedSpread is the spread scaled by the time to expiry
+	AnnualisedSpread float64
+	// ZScore is the spread's deviation from its rolling history, which is
+	// zero until enough samples are recorded
+	ZScore  float64
+	Samples int
+	Time    time.Time
+}
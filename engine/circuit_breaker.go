@@ -0,0 +1,75 @@
+package engine
+
+import (
+	"sync"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+const circuitBreakerEventType = "circuitbreaker"
+
+// circuitBreakerNotifier relays exchange circuit breaker state changes to the
+// log and communications manager
+type circuitBreakerNotifier struct {
+	commsM sync.Mutex
+	comms  iCommsManager
+}
+
+// setupCircuitBreaker enables circuit breakers on the REST requesters of
+// exchanges set up afterwards
+func setupCircuitBreaker(cfg *config.CircuitBreaker) (*circuitBreakerNotifier, error) {
+	n := &circuitBreakerNotifier{}
+	err := request.SetupGlobalCircuitBreaker(&request.CircuitBreakerSettings{
+		PublicFailureThreshold:  cfg.PublicFailureThreshold,
+		PrivateFailureThreshold: cfg.PrivateFailureThreshold,
+		Cooldown:                cfg.Cooldown,
+		OnStateChange:           n.onStateChange,
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Debugf(log.Global, "Circuit breaker enabled, opening after %d public or %d private consecutive failures with a cooldown of %v",
+		cfg.PublicFailureThreshold, cfg.PrivateFailureThreshold, cfg.Cooldown)
+	return n, nil
+}
+
+// setCommsManager sets the communications manager events are pushed to
+func (n *circuitBreakerNotifier) setCommsManager(comms iCommsManager) {
+	if n == nil {
+		return
+	}
+	n.commsM.Lock()
+	n.comms = comms
+	n.commsM.Unlock()
+}
+
+// onStateChange logs circuit state changes, pushing an event when a circuit
+// opens and resolving it once the circuit closes
+func (n *circuitBreakerNotifier) onStateChange(c request.CircuitStateChange) {
+	evt := base.Event{
+		Type:     circuitBreakerEventType,
+		Message:  c.String(),
+		Exchange: c.Exchange,
+		Key:      c.Exchange + " " + c.Class,
+	}
+	switch c.To {
+	case request.CircuitOpen:
+		log.Warnln(log.RequestSys, evt.Message)
+		evt.Severity = base.SeverityError
+	case request.CircuitClosed:
+		log.Infoln(log.RequestSys, evt.Message)
+		evt.Resolved = true
+	default:
+		log.Debugf(log.RequestSys, "%s %s circuit half-open, probing for recovery", c.Exchange, c.Class)
+		return
+	}
+	n.commsM.Lock()
+	comms := n.comms
+	n.commsM.Unlock()
+	if comms != nil {
+		comms.PushEvent(evt)
+	}
+}
@@ -0,0 +1,55 @@
+package engine
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
+)
+
+func TestSetupCircuitBreaker(t *testing.T) {
+	_, err := setupCircuitBreaker(&config.CircuitBreaker{})
+	assert.Error(t, err, "unset thresholds should error")
+
+	n, err := setupCircuitBreaker(&config.CircuitBreaker{PublicFailureThreshold: 1, PrivateFailureThreshold: 1, Cooldown: time.Minute})
+	require.NoError(t, err)
+	r, err := request.New("test", new(http.Client))
+	require.NoError(t, request.SetupGlobalCircuitBreaker(nil))
+	require.NoError(t, err)
+	assert.NotNil(t, n)
+	assert.Equal(t, request.CircuitClosed, r.CircuitState(request.PublicEndpoints))
+}
+
+func TestCircuitBreakerOnStateChange(t *testing.T) {
+	t.Parallel()
+	n := &circuitBreakerNotifier{}
+	change := request.CircuitStateChange{Exchange: "Bitstamp", Class: request.PublicEndpoints, To: request.CircuitOpen, Failures: 5, Err: errors.New("bad gateway")}
+	n.onStateChange(change)
+
+	comms := &fakeComms{}
+	n.setCommsManager(comms)
+	n.onStateChange(change)
+	change.From, change.To = request.CircuitOpen, request.CircuitHalfOpen
+	n.onStateChange(change)
+	change.From, change.To = request.CircuitHalfOpen, request.CircuitClosed
+	n.onStateChange(change)
+	require.Len(t, comms.events, 2, "half-open transitions should not push events")
+	assert.Equal(t, base.Event{
+		Type:     circuitBreakerEventType,
+		Message:  "Bitstamp public circuit open after 5 consecutive failures: bad gateway",
+		Severity: base.SeverityError,
+		Exchange: "Bitstamp",
+		Key:      "Bitstamp public",
+	}, comms.events[0])
+	assert.True(t, comms.events[1].Resolved, "closing the circuit should resolve the event")
+	assert.Equal(t, comms.events[0].Key, comms.events[1].Key)
+
+	var nilNotifier *circuitBreakerNotifier
+	nilNotifier.setCommsManager(comms)
+}
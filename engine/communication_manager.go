@@ -2,7 +2,9 @@ package engine
 
 import (
 	"fmt"
+	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/communications"
 	"github.com/thrasher-corp/gocryptotrader/communications/base"
@@ -12,12 +14,27 @@ import (
 // CommunicationsManagerName is an exported subsystem name
 const CommunicationsManagerName = "communications"
 
+// maxRecentEvents is the number of pushed events retained for review
+const maxRecentEvents = 200
+
 // CommunicationManager ensures operations of communications
 type CommunicationManager struct {
 	started  int32
 	shutdown chan struct{}
 	relayMsg chan base.Event
 	comms    *communications.Communications
+
+	recentMtx    sync.Mutex
+	recentEvents []RecentEvent
+
+	maintenanceMtx sync.RWMutex
+	maintenance    iMaintenanceSchedule
+}
+
+// RecentEvent is an event pushed to the communications relay
+type RecentEvent struct {
+	base.Event
+	Time time.Time
 }
 
 // SetupCommunicationManager creates a communications manager
@@ -88,6 +105,13 @@ func (m *CommunicationManager) PushEvent(evt base.Event) {
 	if !m.IsRunning() {
 		return
 	}
+	m.maintenanceMtx.RLock()
+	schedule := m.maintenance
+	m.maintenanceMtx.RUnlock()
+	if schedule != nil && schedule.SuppressEvent(&evt) {
+		return
+	}
+	m.recordEvent(evt)
 	select {
 	case m.relayMsg <- evt:
 	default:
@@ -95,6 +119,45 @@ func (m *CommunicationManager) PushEvent(evt base.Event) {
 	}
 }
 
+// setMaintenanceSchedule sets the maintenance schedule used to suppress
+// redundant events from exchanges under maintenance
+func (m *CommunicationManager) setMaintenanceSchedule(schedule iMaintenanceSchedule) {
+	if m == nil {
+		return
+	}
+	m.maintenanceMtx.Lock()
+	m.maintenance = schedule
+	m.maintenanceMtx.Unlock()
+}
+
+// recordEvent retains an event, discarding the oldest beyond maxRecentEvents
+func (m *CommunicationManager) recordEvent(evt base.Event) {
+	m.recentMtx.Lock()
+	defer m.recentMtx.Unlock()
+	if len(m.recentEvents) == maxRecentEvents {
+		m.recentEvents = append(m.recentEvents[:0], m.recentEvents[1:]...)
+	}
+	m.recentEvents = append(m.recentEvents, RecentEvent{Event: evt, Time: time.Now()})
+}
+
+// RecentEvents returns up to limit of the most recently pushed events, newest
+// first. A limit of 0 returns all retained events
+func (m *CommunicationManager) RecentEvents(limit int) []RecentEvent {
+	if m == nil {
+		return nil
+	}
+	m.recentMtx.Lock()
+	defer m.recentMtx.Unlock()
+	if limit <= 0 || limit > len(m.recentEvents) {
+		limit = len(m.recentEvents)
+	}
+	events := make([]RecentEvent, limit)
+	for i := range events {
+		events[i] = m.recentEvents[len(m.recentEvents)-1-i]
+	}
+	return events
+}
+
 // run takes awaiting messages and pushes them to be handled by communications
 func (m *CommunicationManager) run() {
 	log.Debugf(log.Global, "Communications manager %s", MsgSubSystemStarted)
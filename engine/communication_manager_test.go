@@ -2,8 +2,11 @@ package engine
 
 import (
 	"errors"
+	"strconv"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/thrasher-corp/gocryptotrader/communications"
 	"github.com/thrasher-corp/gocryptotrader/communications/base"
 )
@@ -154,3 +157,29 @@ func TestPushEvent(t *testing.T) {
 	m = nil
 	m.PushEvent(base.Event{})
 }
+
+func TestRecentEvents(t *testing.T) {
+	t.Parallel()
+	m, err := SetupCommunicationManager(&base.CommunicationsConfig{
+		SlackConfig: base.SlackConfig{
+			Enabled: true,
+		},
+	})
+	require.NoError(t, err, "SetupCommunicationManager must not error")
+	m.PushEvent(base.Event{Message: "dropped"})
+	assert.Empty(t, m.RecentEvents(0), "events should not be recorded while stopped")
+
+	require.NoError(t, m.Start(), "Start must not error")
+	for i := range maxRecentEvents + 1 {
+		m.PushEvent(base.Event{Message: strconv.Itoa(i)})
+	}
+	assert.Len(t, m.RecentEvents(0), maxRecentEvents, "events beyond the limit should be discarded")
+	events := m.RecentEvents(2)
+	require.Len(t, events, 2)
+	assert.Equal(t, strconv.Itoa(maxRecentEvents), events[0].Message, "the newest event should be first")
+	assert.Equal(t, strconv.Itoa(maxRecentEvents-1), events[1].Message)
+	assert.False(t, events[0].Time.IsZero(), "events should be timestamped")
+
+	m = nil
+	assert.Nil(t, m.RecentEvents(0))
+}
@@ -3,15 +3,22 @@ package engine
 import (
 	"errors"
 	"fmt"
+	"sync"
 	"sync/atomic"
 
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/connchecker"
 	"github.com/thrasher-corp/gocryptotrader/log"
 )
 
-// ConnectionManagerName is an exported subsystem name
-const ConnectionManagerName = "internet_monitor"
+const (
+	// ConnectionManagerName is an exported subsystem name
+	ConnectionManagerName = "internet_monitor"
+	// connectivityEventKey identifies connectivity loss events so incident
+	// managers can resolve them once connectivity is restored
+	connectivityEventKey = "internet_connectivity"
+)
 
 var errConnectionCheckerIsNil = errors.New("connection checker is nil")
 
@@ -20,6 +27,8 @@ type connectionManager struct {
 	started int32
 	conn    *connchecker.Checker
 	cfg     *config.ConnectionMonitorConfig
+	commsM  sync.Mutex
+	comms   iCommsManager
 }
 
 // IsRunning safely checks whether the subsystem is running
@@ -67,6 +76,7 @@ func (m *connectionManager) Start() error {
 		atomic.CompareAndSwapInt32(&m.started, 1, 0)
 		return err
 	}
+	m.conn.SetConnectionChangeHandler(m.onConnectionChange)
 
 	log.Debugln(log.ConnectionMgr, "Connection manager started.")
 	return nil
@@ -104,3 +114,34 @@ func (m *connectionManager) IsOnline() bool {
 
 	return m.conn.IsConnected()
 }
+
+// setCommsManager sets the communications manager used to alert on
+// connectivity loss and restoration
+func (m *connectionManager) setCommsManager(comms iCommsManager) {
+	if m == nil {
+		return
+	}
+	m.commsM.Lock()
+	m.comms = comms
+	m.commsM.Unlock()
+}
+
+// onConnectionChange pushes a critical event when connectivity is lost and
+// resolves it once connectivity is restored
+func (m *connectionManager) onConnectionChange(connected bool) {
+	m.commsM.Lock()
+	comms := m.comms
+	m.commsM.Unlock()
+	if comms == nil {
+		return
+	}
+	evt := base.Event{Type: "connectivity", Key: connectivityEventKey}
+	if connected {
+		evt.Message = connchecker.ConnRe
+		evt.Resolved = true
+	} else {
+		evt.Message = connchecker.ConnLost
+		evt.Severity = base.SeverityCritical
+	}
+	comms.PushEvent(evt)
+}
@@ -4,6 +4,9 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
 	"github.com/thrasher-corp/gocryptotrader/config"
 )
 
@@ -124,3 +127,20 @@ func TestConnectionMonitorIsOnline(t *testing.T) {
 		t.Error("expected false")
 	}
 }
+
+func TestConnectionManagerOnConnectionChange(t *testing.T) {
+	t.Parallel()
+	m := &connectionManager{}
+	m.onConnectionChange(false)
+
+	comms := &fakeComms{}
+	m.setCommsManager(comms)
+	m.onConnectionChange(false)
+	m.onConnectionChange(true)
+	require.Len(t, comms.events, 2)
+	assert.Equal(t, base.SeverityCritical, comms.events[0].Severity, "connectivity loss should be critical")
+	assert.Equal(t, connectivityEventKey, comms.events[0].Key)
+	assert.False(t, comms.events[0].Resolved)
+	assert.True(t, comms.events[1].Resolved, "connectivity restoration should resolve the incident")
+	assert.Equal(t, connectivityEventKey, comms.events[1].Key)
+}
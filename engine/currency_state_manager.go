@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
@@ -33,11 +34,16 @@ type CurrencyStateManager struct {
 	shutdown chan struct{}
 	wg       sync.WaitGroup
 	iExchangeManager
-	sleep time.Duration
+	sleep    time.Duration
+	comms    iCommsManager
+	m        sync.Mutex
+	states   map[string]map[asset.Item]map[*currency.Item]currencystate.Options
+	handlers []func(CurrencyStateChange)
 }
 
-// SetupCurrencyStateManager applies configuration parameters before running
-func SetupCurrencyStateManager(interval time.Duration, em iExchangeManager) (*CurrencyStateManager, error) {
+// SetupCurrencyStateManager applies configuration parameters before running.
+// State changes are pushed to the communications manager when it is not nil
+func SetupCurrencyStateManager(interval time.Duration, em iExchangeManager, comms iCommsManager) (*CurrencyStateManager, error) {
 	if em == nil {
 		return nil, errNilExchangeManager
 	}
@@ -50,6 +56,7 @@ func SetupCurrencyStateManager(interval time.Duration, em iExchangeManager) (*Cu
 	}
 	c.sleep = interval
 	c.iExchangeManager = em
+	c.comms = comms
 	c.shutdown = make(chan struct{})
 	return &c, nil
 }
@@ -123,8 +130,25 @@ func (c *CurrencyStateManager) monitor() {
 	}
 }
 
+// RegisterStateChangeHandler registers a function which is called with each
+// change of a currency's state, allowing subsystems such as the withdraw
+// manager to react to suspensions. Handlers must not block
+func (c *CurrencyStateManager) RegisterStateChangeHandler(fn func(CurrencyStateChange)) error {
+	if c == nil {
+		return fmt.Errorf("%s %w", CurrencyStateManagementName, ErrNilSubsystem)
+	}
+	if fn == nil {
+		return errNilStateChangeHandler
+	}
+	c.m.Lock()
+	c.handlers = append(c.handlers, fn)
+	c.m.Unlock()
+	return nil
+}
+
 func (c *CurrencyStateManager) update(exch exchange.IBotExchange, wg *sync.WaitGroup, enabledAssets asset.Items) {
 	defer wg.Done()
+	defer c.checkStates(exch)
 	for y := range enabledAssets {
 		err := exch.UpdateCurrencyStates(context.TODO(), enabledAssets[y])
 		if err != nil {
@@ -172,6 +196,117 @@ func (c *CurrencyStateManager) update(exch exchange.IBotExchange, wg *sync.WaitG
 	}
 }
 
+// checkStates compares an exchange's currency states against those last seen,
+// emitting a change for each operation which has been suspended or resumed.
+// Operations already impaired when a currency is first seen are emitted too
+func (c *CurrencyStateManager) checkStates(exch exchange.IBotExchange) {
+	sh, err := exch.GetCurrencyStateSnapshot()
+	if err != nil {
+		log.Errorf(log.ExchangeSys, "Currency state manager %s: %v", exch.GetName(), err)
+		return
+	}
+	name := exch.GetName()
+	now := time.Now()
+	var changes []CurrencyStateChange
+	c.m.Lock()
+	if c.states == nil {
+		c.states = make(map[string]map[asset.Item]map[*currency.Item]currencystate.Options)
+	}
+	seen, ok := c.states[name]
+	if !ok {
+		seen = make(map[asset.Item]map[*currency.Item]currencystate.Options)
+		c.states[name] = seen
+	}
+	for i := range sh {
+		m1, ok := seen[sh[i].Asset]
+		if !ok {
+			m1 = make(map[*currency.Item]currencystate.Options)
+			seen[sh[i].Asset] = m1
+		}
+		prev, known := m1[sh[i].Code.Item]
+		m1[sh[i].Code.Item] = sh[i].Options
+		for _, op := range []struct {
+			name             string
+			before, impaired bool
+		}{
+			{CurrencyStateDeposit, !isEnabled(prev.Deposit), !isEnabled(sh[i].Deposit)},
+			{CurrencyStateWithdraw, !isEnabled(prev.Withdraw), !isEnabled(sh[i].Withdraw)},
+			{CurrencyStateTrade, !isEnabled(prev.Trade), !isEnabled(sh[i].Trade)},
+			{CurrencyStateCongestion, isSet(prev.Congested), isSet(sh[i].Congested)},
+		} {
+			if (known && op.before == op.impaired) || (!known && !op.impaired) {
+				continue
+			}
+			changes = append(changes, CurrencyStateChange{
+				Exchange:  name,
+				Asset:     sh[i].Asset,
+				Currency:  sh[i].Code,
+				Operation: op.name,
+				Impaired:  op.impaired,
+				Time:      now,
+			})
+		}
+	}
+	handlers := c.handlers
+	c.m.Unlock()
+	for i := range changes {
+		c.emit(&changes[i], handlers)
+	}
+}
+
+// emit logs a currency state change, pushes it to the communications manager
+// and passes it to each registered handler
+func (c *CurrencyStateManager) emit(ch *CurrencyStateChange, handlers []func(CurrencyStateChange)) {
+	msg := ch.String()
+	evt := base.Event{
+		Type:     currencyStateEventType,
+		Message:  msg,
+		Exchange: ch.Exchange,
+		Key:      currencyStateEventType + ":" + ch.Exchange + " " + ch.Asset.String() + " " + ch.Currency.String() + " " + ch.Operation,
+		Resolved: !ch.Impaired,
+	}
+	if ch.Impaired {
+		log.Warnln(log.ExchangeSys, msg)
+		evt.Severity = base.SeverityWarning
+	} else {
+		log.Infoln(log.ExchangeSys, msg)
+	}
+	if c.comms != nil {
+		c.comms.PushEvent(evt)
+	}
+	for i := range handlers {
+		handlers[i](*ch)
+	}
+}
+
+// String returns a readable description of the state change
+func (ch *CurrencyStateChange) String() string {
+	var state string
+	switch {
+	case ch.Operation == CurrencyStateCongestion && ch.Impaired:
+		state = "network congested"
+	case ch.Operation == CurrencyStateCongestion:
+		state = "network congestion cleared"
+	case ch.Impaired:
+		state = ch.Operation + " suspended"
+	default:
+		state = ch.Operation + " resumed"
+	}
+	return fmt.Sprintf("%s %s %s %s", ch.Exchange, ch.Asset, ch.Currency, state)
+}
+
+// isEnabled returns whether an optional operation is enabled, defaulting to
+// true when unset
+func isEnabled(b *bool) bool {
+	return b == nil || *b
+}
+
+// isSet returns whether an optional flag is set, defaulting to false when
+// unset
+func isSet(b *bool) bool {
+	return b != nil && *b
+}
+
 // GetAllRPC returns a full snapshot of currency states, whether they are able
 // to be withdrawn, deposited or traded on an exchange for RPC.
 func (c *CurrencyStateManager) GetAllRPC(exchName string) (*gctrpc.CurrencyStateResponse, error) {
@@ -193,9 +328,9 @@ func (c *CurrencyStateManager) GetAllRPC(exchName string) (*gctrpc.CurrencyState
 		resp.CurrencyStates = append(resp.CurrencyStates, &gctrpc.CurrencyState{
 			Currency:        sh[x].Code.String(),
 			Asset:           sh[x].Asset.String(),
-			WithdrawEnabled: sh[x].Withdraw == nil || *sh[x].Withdraw,
-			DepositEnabled:  sh[x].Deposit == nil || *sh[x].Deposit,
-			TradingEnabled:  sh[x].Trade == nil || *sh[x].Trade,
+			WithdrawEnabled: isEnabled(sh[x].Withdraw),
+			DepositEnabled:  isEnabled(sh[x].Deposit),
+			TradingEnabled:  isEnabled(sh[x].Trade),
 		})
 	}
 	return resp, nil
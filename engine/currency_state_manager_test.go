@@ -7,21 +7,26 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/convert"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/currencystate"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
 )
 
 func TestSetupCurrencyStateManager(t *testing.T) {
 	t.Parallel()
-	_, err := SetupCurrencyStateManager(0, nil)
+	_, err := SetupCurrencyStateManager(0, nil, nil)
 	if !errors.Is(err, errNilExchangeManager) {
 		t.Fatalf("received: '%v' but expected: '%v'", err, errNilExchangeManager)
 	}
 
-	cm, err := SetupCurrencyStateManager(0, &ExchangeManager{})
+	cm, err := SetupCurrencyStateManager(0, &ExchangeManager{}, nil)
 	if !errors.Is(err, nil) {
 		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
 	}
@@ -93,6 +98,10 @@ func (f *fakerino) CanWithdraw(_ currency.Code, _ asset.Item) error {
 	return nil
 }
 
+func (f *fakerino) GetWithdrawalLocks(context.Context, currency.Code) ([]withdraw.Lock, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
 func (f *fakerino) CanDeposit(_ currency.Code, _ asset.Item) error {
 	if f.errorMe {
 		return errExchange
@@ -374,3 +383,71 @@ func TestUpdate(_ *testing.T) {
 	man.update(&fakerino{errorMe: true, GetBaseError: true}, &wg, asset.Items{asset.Spot})
 	man.update(&fakerino{errorMe: true}, &wg, asset.Items{asset.Spot})
 }
+
+type fakeStateExchange struct {
+	fakerino
+	states []currencystate.Snapshot
+}
+
+func (f *fakeStateExchange) GetCurrencyStateSnapshot() ([]currencystate.Snapshot, error) {
+	return f.states, nil
+}
+
+func TestRegisterStateChangeHandler(t *testing.T) {
+	t.Parallel()
+	err := (*CurrencyStateManager)(nil).RegisterStateChangeHandler(func(CurrencyStateChange) {})
+	assert.ErrorIs(t, err, ErrNilSubsystem)
+	m := &CurrencyStateManager{}
+	assert.ErrorIs(t, m.RegisterStateChangeHandler(nil), errNilStateChangeHandler)
+	require.NoError(t, m.RegisterStateChangeHandler(func(CurrencyStateChange) {}))
+	assert.Len(t, m.handlers, 1)
+}
+
+func TestCheckStates(t *testing.T) {
+	t.Parallel()
+	comms := &fakeComms{}
+	m := &CurrencyStateManager{comms: comms}
+	var changes []CurrencyStateChange
+	require.NoError(t, m.RegisterStateChangeHandler(func(ch CurrencyStateChange) {
+		changes = append(changes, ch)
+	}))
+
+	exch := &fakeStateExchange{states: []currencystate.Snapshot{
+		{Code: currency.BTC, Asset: asset.Spot},
+		{Code: currency.ETH, Asset: asset.Spot, Options: currencystate.Options{Deposit: convert.BoolPtr(false)}},
+	}}
+	m.checkStates(exch)
+	require.Len(t, changes, 1, "only operations impaired when first seen should be emitted")
+	assert.Equal(t, CurrencyStateChange{
+		Exchange:  exch.GetName(),
+		Asset:     asset.Spot,
+		Currency:  currency.ETH,
+		Operation: CurrencyStateDeposit,
+		Impaired:  true,
+		Time:      changes[0].Time,
+	}, changes[0])
+	require.Len(t, comms.events, 1)
+	assert.Equal(t, base.SeverityWarning, comms.events[0].Severity)
+	assert.False(t, comms.events[0].Resolved)
+
+	m.checkStates(exch)
+	assert.Len(t, changes, 1, "unchanged states should not be emitted")
+
+	exch.states = []currencystate.Snapshot{
+		{Code: currency.BTC, Asset: asset.Spot, Options: currencystate.Options{Withdraw: convert.BoolPtr(false), Congested: convert.BoolPtr(true)}},
+		{Code: currency.ETH, Asset: asset.Spot, Options: currencystate.Options{Deposit: convert.BoolPtr(true)}},
+	}
+	m.checkStates(exch)
+	require.Len(t, changes, 4)
+	assert.Equal(t, CurrencyStateWithdraw, changes[1].Operation)
+	assert.True(t, changes[1].Impaired)
+	assert.Equal(t, CurrencyStateCongestion, changes[2].Operation)
+	assert.True(t, changes[2].Impaired)
+	assert.Equal(t, CurrencyStateDeposit, changes[3].Operation)
+	assert.False(t, changes[3].Impaired, "resumed operations should be emitted")
+	require.Len(t, comms.events, 4)
+	assert.True(t, comms.events[3].Resolved, "resumed operations should resolve their event")
+	assert.Equal(t, comms.events[0].Key, comms.events[3].Key, "resumed operations should share their suspension's key")
+	assert.Equal(t, exch.GetName()+" spot BTC network congested", changes[2].String())
+	assert.Equal(t, exch.GetName()+" spot ETH deposit resumed", changes[3].String())
+}
@@ -0,0 +1,39 @@
+package engine
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+// currencyStateEventType is the communications event type used for currency
+// state changes
+const currencyStateEventType = "currencystate"
+
+// Currency operations whose state changes are emitted by the currency state
+// manager
+const (
+	CurrencyStateDeposit    = "deposit"
+	CurrencyStateWithdraw   = "withdraw"
+	CurrencyStateTrade      = "trade"
+	CurrencyStateCongestion = "congestion"
+)
+
+var errNilStateChangeHandler = errors.New("currency state change handler is nil")
+
+// CurrencyStateChange is emitted when an exchange suspends or resumes deposits,
+// withdrawals or trading of a currency, or reports its network as congested
+// or clear
+type CurrencyStateChange struct {
+	Exchange string
+	Asset    asset.Item
+	Currency currency.Code
+	// Operation is the affected operation, such as CurrencyStateDeposit
+	Operation string
+	// Impaired is true when the operation is suspended or the network is
+	// congested, and false once it has recovered
+	Impaired bool
+	Time     time.Time
+}
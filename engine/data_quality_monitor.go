@@ -0,0 +1,286 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/stream/buffer"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// SetupDataQualityMonitor creates a data quality monitor subsystem
+func SetupDataQualityMonitor(cfg *config.DataQualityMonitor, em iExchangeManager) (*DataQualityMonitor, error) {
+	if cfg == nil {
+		return nil, errNilConfig
+	}
+	if em == nil {
+		return nil, errNilExchangeManager
+	}
+	if cfg.Window < time.Minute {
+		return nil, fmt.Errorf("%w window %v must be at least a minute", errInvalidDataQualityConfig, cfg.Window)
+	}
+	if cfg.StaleTickThreshold <= 0 {
+		return nil, fmt.Errorf("%w stale tick threshold %v must be above zero", errInvalidDataQualityConfig, cfg.StaleTickThreshold)
+	}
+	return &DataQualityMonitor{
+		verbose:            cfg.Verbose,
+		window:             cfg.Window,
+		bucketWidth:        cfg.Window / dataQualityBuckets,
+		staleTickThreshold: cfg.StaleTickThreshold,
+		exchangeManager:    em,
+		exchanges:          make(map[string]*dataQualityExchange),
+	}, nil
+}
+
+// Start runs the subsystem
+func (m *DataQualityMonitor) Start() error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 0, 1) {
+		return ErrSubSystemAlreadyStarted
+	}
+	m.shutdown = make(chan struct{})
+	m.wg.Add(1)
+	go m.run()
+	log.Debugf(log.WebsocketMgr, "Data quality monitor %s", MsgSubSystemStarted)
+	return nil
+}
+
+// IsRunning checks whether the subsystem is running
+func (m *DataQualityMonitor) IsRunning() bool {
+	if m == nil {
+		return false
+	}
+	return atomic.LoadInt32(&m.started) == 1
+}
+
+// Stop stops the subsystem
+func (m *DataQualityMonitor) Stop() error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 1, 0) {
+		return ErrSubSystemNotStarted
+	}
+	close(m.shutdown)
+	m.wg.Wait()
+	log.Debugf(log.WebsocketMgr, "Data quality monitor %s", MsgSubSystemShutdown)
+	return nil
+}
+
+// GetScore returns an exchange's data quality score over the rolling window
+func (m *DataQualityMonitor) GetScore(exchName string) (DataQualityScore, error) {
+	if !m.IsRunning() {
+		return DataQualityScore{}, fmt.Errorf("%s %w", DataQualityMonitorName, ErrSubSystemNotStarted)
+	}
+	now := time.Now()
+	m.m.Lock()
+	defer m.m.Unlock()
+	e, ok := m.exchanges[strings.ToLower(exchName)]
+	if !ok {
+		return DataQualityScore{}, fmt.Errorf("%w for %s", errNoDataQualityScore, exchName)
+	}
+	return m.score(e, now), nil
+}
+
+// GetScores returns the data quality score of each monitored exchange, best
+// first
+func (m *DataQualityMonitor) GetScores() ([]DataQualityScore, error) {
+	if !m.IsRunning() {
+		return nil, fmt.Errorf("%s %w", DataQualityMonitorName, ErrSubSystemNotStarted)
+	}
+	now := time.Now()
+	m.m.Lock()
+	scores := make([]DataQualityScore, 0, len(m.exchanges))
+	for _, e := range m.exchanges {
+		scores = append(scores, m.score(e, now))
+	}
+	m.m.Unlock()
+	slices.SortFunc(scores, func(a, b DataQualityScore) int {
+		if a.Score != b.Score {
+			if a.Score > b.Score {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(a.Exchange, b.Exchange)
+	})
+	return scores, nil
+}
+
+func (m *DataQualityMonitor) run() {
+	defer m.wg.Done()
+	runScheduledJobs(m.shutdown, &scheduledJob{interval: m.bucketWidth, immediate: true, run: func(_ context.Context, now time.Time) { m.checkReconnects(now) }})
+}
+
+// checkReconnects records the reconnections of each exchange's websocket since
+// the previous check
+func (m *DataQualityMonitor) checkReconnects(now time.Time) {
+	exchs, err := m.exchangeManager.GetExchanges()
+	if err != nil {
+		log.Errorf(log.WebsocketMgr, "Data quality monitor unable to get exchanges: %s", err)
+		return
+	}
+	for i := range exchs {
+		if !exchs[i].SupportsWebsocket() || !exchs[i].IsWebsocketEnabled() {
+			continue
+		}
+		ws, err := exchs[i].GetWebsocket()
+		if err != nil {
+			continue
+		}
+		m.recordConnections(exchs[i].GetName(), ws.ConnectionCount(), now)
+	}
+}
+
+// recordConnections records an increase in an exchange's websocket connection
+// count as reconnections. Connections made before the exchange was first
+// checked are not counted
+func (m *DataQualityMonitor) recordConnections(exchName string, connections uint64, now time.Time) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	e := m.getExchange(exchName)
+	if e.connections > 0 && connections > e.connections {
+		reconnects := int64(connections - e.connections)
+		e.bucket(now, m.bucketWidth).Reconnects += reconnects
+		if m.verbose {
+			log.Debugf(log.WebsocketMgr, "Data quality monitor %s websocket reconnected %d times", exchName, reconnects)
+		}
+	}
+	e.connections = connections
+}
+
+// websocketDataQualityHandler counts tickers, orderbooks, stale channel
+// warnings and orderbook update gaps received via websocket
+func (m *DataQualityMonitor) websocketDataQualityHandler(exchName string, data interface{}) error {
+	if !m.IsRunning() {
+		return nil
+	}
+	now := time.Now()
+	switch d := data.(type) {
+	case *ticker.Price:
+		m.inspectTicker(exchName, d, now)
+	case []ticker.Price:
+		for i := range d {
+			m.inspectTicker(exchName, &d[i], now)
+		}
+	case *orderbook.Depth:
+		// Books invalidated by an update gap are counted by their error
+		if b, err := d.Retrieve(); err == nil {
+			m.inspectOrderbook(exchName, b, now)
+		}
+	case stream.StaleChannelWarning:
+		m.record(exchName, now, DataQualityCounts{StaleTicks: 1})
+	case error:
+		if errors.Is(d, buffer.ErrUpdateIDDiscontinuity) {
+			m.record(exchName, now, DataQualityCounts{Gaps: 1})
+		}
+	}
+	return nil
+}
+
+// inspectTicker counts a ticker update, and whether it is crossed or stale
+func (m *DataQualityMonitor) inspectTicker(exchName string, t *ticker.Price, now time.Time) {
+	c := DataQualityCounts{Updates: 1}
+	if t.Bid > 0 && t.Ask > 0 && t.Bid >= t.Ask {
+		c.CrossedBooks = 1
+	}
+	if m.isStale(t.LastUpdated, now) {
+		c.StaleTicks = 1
+	}
+	m.record(exchName, now, c)
+}
+
+// inspectOrderbook counts an orderbook update, and whether it is crossed or
+// stale
+func (m *DataQualityMonitor) inspectOrderbook(exchName string, b *orderbook.Base, now time.Time) {
+	c := DataQualityCounts{Updates: 1}
+	if len(b.Bids) > 0 && len(b.Asks) > 0 && b.Bids[0].Price >= b.Asks[0].Price {
+		c.CrossedBooks = 1
+	}
+	if m.isStale(b.LastUpdated, now) {
+		c.StaleTicks = 1
+	}
+	m.record(exchName, now, c)
+}
+
+// isStale returns whether an exchange timestamp lags its receipt by more than
+// the stale tick threshold
+func (m *DataQualityMonitor) isStale(updated, now time.Time) bool {
+	return !updated.IsZero() && now.Sub(updated) > m.staleTickThreshold
+}
+
+// record adds counts to an exchange's current bucket
+func (m *DataQualityMonitor) record(exchName string, now time.Time, c DataQualityCounts) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	b := m.getExchange(exchName).bucket(now, m.bucketWidth)
+	b.Updates += c.Updates
+	b.Gaps += c.Gaps
+	b.StaleTicks += c.StaleTicks
+	b.CrossedBooks += c.CrossedBooks
+	b.Reconnects += c.Reconnects
+}
+
+// getExchange returns an exchange's data quality state, creating it when it
+// is not yet monitored. The lock must be held
+func (m *DataQualityMonitor) getExchange(exchName string) *dataQualityExchange {
+	k := strings.ToLower(exchName)
+	e, ok := m.exchanges[k]
+	if !ok {
+		e = &dataQualityExchange{name: exchName}
+		m.exchanges[k] = e
+	}
+	return e
+}
+
+// bucket returns the bucket for a time, clearing it when it last held counts
+// from an earlier window
+func (e *dataQualityExchange) bucket(now time.Time, width time.Duration) *dataQualityBucket {
+	start := now.Truncate(width)
+	b := &e.buckets[(start.UnixNano()/int64(width))%dataQualityBuckets]
+	if !b.start.Equal(start) {
+		*b = dataQualityBucket{start: start}
+	}
+	return b
+}
+
+// score sums an exchange's buckets within the rolling window and scores them.
+// Crossed books and stale ticks are scored by their proportion of updates,
+// while gaps and reconnections are scored by their hourly rate. The lock must
+// be held
+func (m *DataQualityMonitor) score(e *dataQualityExchange, now time.Time) DataQualityScore {
+	resp := DataQualityScore{Exchange: e.name, Window: m.window}
+	cutoff := now.Truncate(m.bucketWidth).Add(-m.window)
+	for i := range e.buckets {
+		b := &e.buckets[i]
+		if !b.start.After(cutoff) {
+			continue
+		}
+		resp.Updates += b.Updates
+		resp.Gaps += b.Gaps
+		resp.StaleTicks += b.StaleTicks
+		resp.CrossedBooks += b.CrossedBooks
+		resp.Reconnects += b.Reconnects
+	}
+	perHour := float64(time.Hour) / float64(m.window)
+	resp.Continuity = 1 / (1 + float64(resp.Gaps)*perHour)
+	resp.Stability = 1 / (1 + float64(resp.Reconnects)*perHour)
+	if resp.Updates == 0 {
+		return resp
+	}
+	resp.Book = max(1-float64(resp.CrossedBooks)/float64(resp.Updates), 0)
+	resp.Freshness = max(1-float64(resp.StaleTicks)/float64(resp.Updates), 0)
+	resp.Score = 100 * resp.Book * resp.Freshness * resp.Continuity * resp.Stability
+	return resp
+}
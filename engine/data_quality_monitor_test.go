@@ -0,0 +1,173 @@
+package engine
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/stream/buffer"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+)
+
+func testDataQualityMonitorSetup(t *testing.T) *DataQualityMonitor {
+	t.Helper()
+	m, err := SetupDataQualityMonitor(&config.DataQualityMonitor{Window: time.Hour, StaleTickThreshold: time.Second * 5}, testExchangeManager(t))
+	require.NoError(t, err, "SetupDataQualityMonitor must not error")
+	require.NoError(t, m.Start(), "Start must not error")
+	t.Cleanup(func() { assert.NoError(t, m.Stop(), "Stop should not error") })
+	return m
+}
+
+func TestSetupDataQualityMonitor(t *testing.T) {
+	t.Parallel()
+	_, err := SetupDataQualityMonitor(nil, nil)
+	assert.ErrorIs(t, err, errNilConfig)
+	_, err = SetupDataQualityMonitor(&config.DataQualityMonitor{}, nil)
+	assert.ErrorIs(t, err, errNilExchangeManager)
+	_, err = SetupDataQualityMonitor(&config.DataQualityMonitor{Window: time.Second, StaleTickThreshold: time.Second}, NewExchangeManager())
+	assert.ErrorIs(t, err, errInvalidDataQualityConfig, "window below a minute should error")
+	m, err := SetupDataQualityMonitor(&config.DataQualityMonitor{Window: time.Hour, StaleTickThreshold: time.Second}, NewExchangeManager())
+	require.NoError(t, err, "SetupDataQualityMonitor must not error")
+	assert.Equal(t, time.Minute, m.bucketWidth, "bucket width should divide the window")
+}
+
+func TestDataQualityMonitorStartStop(t *testing.T) {
+	t.Parallel()
+	m, err := SetupDataQualityMonitor(&config.DataQualityMonitor{Window: time.Hour, StaleTickThreshold: time.Second}, NewExchangeManager())
+	require.NoError(t, err, "SetupDataQualityMonitor must not error")
+	_, err = m.GetScores()
+	assert.ErrorIs(t, err, ErrSubSystemNotStarted)
+	testStartStop(t, (*DataQualityMonitor)(nil), m)
+}
+
+func TestDataQualityInspectTicker(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	for _, tc := range []struct {
+		name   string
+		tick   ticker.Price
+		counts DataQualityCounts
+	}{
+		{name: "valid", tick: ticker.Price{Bid: 99, Ask: 101, LastUpdated: now}, counts: DataQualityCounts{Updates: 1}},
+		{name: "locked", tick: ticker.Price{Bid: 100, Ask: 100, LastUpdated: now}, counts: DataQualityCounts{Updates: 1, CrossedBooks: 1}},
+		{name: "one sided", tick: ticker.Price{Bid: 101, LastUpdated: now}, counts: DataQualityCounts{Updates: 1}},
+		{name: "at threshold", tick: ticker.Price{Bid: 99, Ask: 101, LastUpdated: now.Add(-time.Second * 5)}, counts: DataQualityCounts{Updates: 1}},
+		{name: "stale", tick: ticker.Price{Bid: 99, Ask: 101, LastUpdated: now.Add(-time.Second * 6)}, counts: DataQualityCounts{Updates: 1, StaleTicks: 1}},
+		{name: "no timestamp", tick: ticker.Price{Bid: 99, Ask: 101}, counts: DataQualityCounts{Updates: 1}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			m := testDataQualityMonitorSetup(t)
+			m.inspectTicker("test", &tc.tick, now)
+			s, err := m.GetScore("test")
+			require.NoError(t, err, "GetScore must not error")
+			assert.Equal(t, tc.counts, s.DataQualityCounts)
+		})
+	}
+}
+
+func TestWebsocketDataQualityHandler(t *testing.T) {
+	t.Parallel()
+	m := testDataQualityMonitorSetup(t)
+	now := time.Now()
+	p := currency.NewBTCUSDT()
+	for range 8 {
+		assert.NoError(t, m.websocketDataQualityHandler("Good", &ticker.Price{Pair: p, AssetType: asset.Spot, Bid: 99, Ask: 101, LastUpdated: now}))
+	}
+	assert.NoError(t, m.websocketDataQualityHandler("Bad", []ticker.Price{
+		{Pair: p, AssetType: asset.Spot, Bid: 99, Ask: 101, LastUpdated: now},
+		{Pair: p, AssetType: asset.Spot, Bid: 101, Ask: 99, LastUpdated: now},
+		{Pair: p, AssetType: asset.Spot, Bid: 99, Ask: 101, LastUpdated: now.Add(-time.Minute)},
+		{Pair: p, AssetType: asset.Spot, Bid: 99, Ask: 101},
+	}))
+	assert.NoError(t, m.websocketDataQualityHandler("Bad", stream.StaleChannelWarning{Exchange: "Bad"}))
+	assert.NoError(t, m.websocketDataQualityHandler("Bad", fmt.Errorf("bad websocket: %w", buffer.ErrUpdateIDDiscontinuity)))
+	assert.NoError(t, m.websocketDataQualityHandler("Bad", errNilConfig), "unrelated errors should be ignored")
+
+	good, err := m.GetScore("good")
+	require.NoError(t, err, "GetScore must not error")
+	assert.Equal(t, "Good", good.Exchange)
+	assert.Equal(t, int64(8), good.Updates)
+	assert.Equal(t, 100.0, good.Score, "flawless data should score 100")
+
+	bad, err := m.GetScore("Bad")
+	require.NoError(t, err, "GetScore must not error")
+	assert.Equal(t, DataQualityCounts{Updates: 4, Gaps: 1, StaleTicks: 2, CrossedBooks: 1}, bad.DataQualityCounts)
+	assert.Equal(t, 0.75, bad.Book)
+	assert.Equal(t, 0.5, bad.Freshness)
+	assert.Equal(t, 0.5, bad.Continuity)
+	assert.Equal(t, 1.0, bad.Stability)
+	assert.InDelta(t, 18.75, bad.Score, 1e-9)
+
+	_, err = m.GetScore("missing")
+	assert.ErrorIs(t, err, errNoDataQualityScore)
+
+	scores, err := m.GetScores()
+	require.NoError(t, err, "GetScores must not error")
+	require.Len(t, scores, 2)
+	assert.Equal(t, "Good", scores[0].Exchange, "scores should be sorted best first")
+}
+
+func TestDataQualityOrderbook(t *testing.T) {
+	t.Parallel()
+	m := testDataQualityMonitorSetup(t)
+	now := time.Now()
+	m.inspectOrderbook("ob", &orderbook.Base{
+		Bids:        orderbook.Items{{Price: 100, Amount: 1}},
+		Asks:        orderbook.Items{{Price: 101, Amount: 1}},
+		LastUpdated: now,
+	}, now)
+	m.inspectOrderbook("ob", &orderbook.Base{
+		Bids:        orderbook.Items{{Price: 101, Amount: 1}},
+		Asks:        orderbook.Items{{Price: 100, Amount: 1}},
+		LastUpdated: now.Add(-time.Minute),
+	}, now)
+	s, err := m.GetScore("ob")
+	require.NoError(t, err)
+	assert.Equal(t, DataQualityCounts{Updates: 2, StaleTicks: 1, CrossedBooks: 1}, s.DataQualityCounts)
+	assert.Equal(t, 25.0, s.Score)
+}
+
+func TestDataQualityReconnects(t *testing.T) {
+	t.Parallel()
+	m := testDataQualityMonitorSetup(t)
+	now := time.Now()
+	m.recordConnections("ws", 3, now)
+	s, err := m.GetScore("ws")
+	require.NoError(t, err)
+	assert.Zero(t, s.Reconnects, "connections before the first check should not count")
+	assert.Zero(t, s.Score, "exchanges without updates should score zero")
+
+	m.recordConnections("ws", 5, now)
+	m.recordConnections("ws", 5, now)
+	m.record("ws", now, DataQualityCounts{Updates: 1})
+	s, err = m.GetScore("ws")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), s.Reconnects)
+	assert.InDelta(t, 1.0/3, s.Stability, 1e-9)
+}
+
+func TestDataQualityWindow(t *testing.T) {
+	t.Parallel()
+	m := testDataQualityMonitorSetup(t)
+	now := time.Now()
+	m.record("window", now.Add(-time.Hour*2), DataQualityCounts{Updates: 5, CrossedBooks: 5})
+	m.record("window", now.Add(-time.Minute*30), DataQualityCounts{Updates: 1})
+	m.record("window", now, DataQualityCounts{Updates: 1})
+	s, err := m.GetScore("window")
+	require.NoError(t, err)
+	assert.Equal(t, DataQualityCounts{Updates: 2}, s.DataQualityCounts, "counts outside the window should be excluded")
+
+	m.record("window", now.Add(time.Hour), DataQualityCounts{Updates: 1})
+	m.m.Lock()
+	s = m.score(m.exchanges["window"], now.Add(time.Hour))
+	m.m.Unlock()
+	assert.Equal(t, int64(1), s.Updates, "buckets reused by a later window should be cleared")
+}
@@ -0,0 +1,73 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// DataQualityMonitorName is an exported subsystem name
+const DataQualityMonitorName = "data_quality_monitor"
+
+// dataQualityBuckets is the number of buckets each exchange's rolling window
+// is divided into
+const dataQualityBuckets = 60
+
+var (
+	errInvalidDataQualityConfig = errors.New("invalid data quality monitor config")
+	errNoDataQualityScore       = errors.New("no data quality score")
+)
+
+// DataQualityMonitor scores each exchange's streamed market data over a rolling
+// window by its orderbook update gaps, stale ticks, crossed books and
+// websocket reconnections
+type DataQualityMonitor struct {
+	started            int32
+	shutdown           chan struct{}
+	wg                 sync.WaitGroup
+	verbose            bool
+	window             time.Duration
+	bucketWidth        time.Duration
+	staleTickThreshold time.Duration
+	exchangeManager    iExchangeManager
+	m                  sync.Mutex
+	exchanges          map[string]*dataQualityExchange
+}
+
+// dataQualityExchange holds an exchange's event counts bucketed over the
+// rolling window
+type dataQualityExchange struct {
+	name        string
+	buckets     [dataQualityBuckets]dataQualityBucket
+	connections uint64
+}
+
+// dataQualityBucket holds the events counted within a bucket starting at start
+type dataQualityBucket struct {
+	start time.Time
+	DataQualityCounts
+}
+
+// DataQualityCounts holds counts of streamed updates and data quality events
+type DataQualityCounts struct {
+	Updates      int64
+	Gaps         int64
+	StaleTicks   int64
+	CrossedBooks int64
+	Reconnects   int64
+}
+
+// DataQualityScore is an exchange's data quality over the rolling window
+type DataQualityScore struct {
+	Exchange string
+	// Score ranges from 0 to 100, where 100 is flawless data. Exchanges which
+	// have streamed no updates within the window score 0
+	Score float64
+	// Component scores range from 0 to 1
+	Book       float64
+	Freshness  float64
+	Continuity float64
+	Stability  float64
+	Window     time.Duration
+	DataQualityCounts
+}
@@ -7,24 +7,13 @@ import (
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
 	"github.com/thrasher-corp/gocryptotrader/database"
 	dbpsql "github.com/thrasher-corp/gocryptotrader/database/drivers/postgres"
 	dbsqlite3 "github.com/thrasher-corp/gocryptotrader/database/drivers/sqlite3"
 	"github.com/thrasher-corp/gocryptotrader/log"
 )
 
-// DatabaseConnectionManagerName is an exported subsystem name
-const DatabaseConnectionManagerName = "database"
-
-// DatabaseConnectionManager holds the database connection and its status
-type DatabaseConnectionManager struct {
-	started  int32
-	shutdown chan struct{}
-	cfg      database.Config
-	wg       sync.WaitGroup
-	dbConn   *database.Instance
-}
-
 // IsRunning safely checks whether the subsystem is running
 func (m *DatabaseConnectionManager) IsRunning() bool {
 	if m == nil {
@@ -88,28 +77,12 @@ func (m *DatabaseConnectionManager) Start(wg *sync.WaitGroup) (err error) {
 
 	if m.cfg.Enabled {
 		m.shutdown = make(chan struct{})
-		switch m.cfg.Driver {
-		case database.DBPostgreSQL:
-			log.Debugf(log.DatabaseMgr,
-				"Attempting to establish database connection to host %s/%s utilising %s driver\n",
-				m.cfg.Host,
-				m.cfg.Database,
-				m.cfg.Driver)
-			m.dbConn, err = dbpsql.Connect(&m.cfg)
-		case database.DBSQLite,
-			database.DBSQLite3:
-			log.Debugf(log.DatabaseMgr,
-				"Attempting to establish database connection to %s utilising %s driver\n",
-				m.cfg.Database,
-				m.cfg.Driver)
-			m.dbConn, err = dbsqlite3.Connect(m.cfg.Database)
-		default:
-			return database.ErrNoDatabaseProvided
+		if err = m.connect(); err != nil {
+			return err
 		}
-		if err != nil {
-			return fmt.Errorf("%w: %v Some features that utilise a database will be unavailable", database.ErrFailedToConnect, err)
-		}
-		m.dbConn.SetConnected(true)
+		m.m.Lock()
+		m.resetHealth()
+		m.m.Unlock()
 		wg.Add(1)
 		m.wg.Add(1)
 		go m.run(wg)
@@ -119,6 +92,34 @@ func (m *DatabaseConnectionManager) Start(wg *sync.WaitGroup) (err error) {
 	return database.ErrDatabaseSupportDisabled
 }
 
+// connect opens the configured database driver connection
+func (m *DatabaseConnectionManager) connect() error {
+	var err error
+	switch m.cfg.Driver {
+	case database.DBPostgreSQL:
+		log.Debugf(log.DatabaseMgr,
+			"Attempting to establish database connection to host %s/%s utilising %s driver\n",
+			m.cfg.Host,
+			m.cfg.Database,
+			m.cfg.Driver)
+		m.dbConn, err = dbpsql.Connect(&m.cfg)
+	case database.DBSQLite,
+		database.DBSQLite3:
+		log.Debugf(log.DatabaseMgr,
+			"Attempting to establish database connection to %s utilising %s driver\n",
+			m.cfg.Database,
+			m.cfg.Driver)
+		m.dbConn, err = dbsqlite3.Connect(m.cfg.Database)
+	default:
+		return database.ErrNoDatabaseProvided
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v Some features that utilise a database will be unavailable", database.ErrFailedToConnect, err)
+	}
+	m.dbConn.SetConnected(true)
+	return nil
+}
+
 // Stop stops the database manager and closes the connection
 // Stop attempts to shutdown the subsystem
 func (m *DatabaseConnectionManager) Stop() error {
@@ -132,7 +133,9 @@ func (m *DatabaseConnectionManager) Stop() error {
 		atomic.CompareAndSwapInt32(&m.started, 1, 0)
 	}()
 
+	m.m.Lock()
 	err := m.dbConn.CloseConnection()
+	m.m.Unlock()
 	if err != nil {
 		log.Errorf(log.DatabaseMgr, "Failed to close database: %v", err)
 	}
@@ -144,7 +147,7 @@ func (m *DatabaseConnectionManager) Stop() error {
 
 func (m *DatabaseConnectionManager) run(wg *sync.WaitGroup) {
 	log.Debugln(log.DatabaseMgr, "Database manager started.")
-	t := time.NewTicker(time.Second * 2)
+	t := time.NewTicker(databaseCheckInterval)
 
 	defer func() {
 		t.Stop()
@@ -180,8 +183,21 @@ func (m *DatabaseConnectionManager) checkConnection() error {
 		return database.ErrNoDatabaseProvided
 	}
 
+	m.m.Lock()
+	defer m.m.Unlock()
+	if m.circuitOpen {
+		if time.Now().Before(m.nextReconnect) {
+			return errDatabaseCircuitOpen
+		}
+		return m.reconnect()
+	}
+
 	if err := m.dbConn.Ping(); err != nil {
 		m.dbConn.SetConnected(false)
+		m.recordFailure(err)
+		if m.consecutiveFailures >= databaseFailureThreshold {
+			m.openCircuit()
+		}
 		return err
 	}
 
@@ -189,5 +205,130 @@ func (m *DatabaseConnectionManager) checkConnection() error {
 		log.Infoln(log.DatabaseMgr, "Database connection reestablished")
 		m.dbConn.SetConnected(true)
 	}
+	m.consecutiveFailures = 0
+	m.lastConnected = time.Now()
+	m.checkPoolSaturation()
+	return nil
+}
+
+// reconnect attempts to replace the database connection while the circuit is
+// open. Failed attempts back off exponentially
+func (m *DatabaseConnectionManager) reconnect() error {
+	if err := m.dbConn.CloseConnection(); err != nil {
+		log.Debugf(log.DatabaseMgr, "Database close before reconnect failed: %v", err)
+	}
+	err := m.connect()
+	if err == nil {
+		err = m.dbConn.Ping()
+	}
+	if err != nil {
+		m.dbConn.SetConnected(false)
+		m.recordFailure(err)
+		m.backoff = min(m.backoff*2, databaseMaxReconnectBackoff)
+		m.nextReconnect = time.Now().Add(m.backoff)
+		log.Errorf(log.DatabaseMgr, "Database reconnect failed, retrying in %s: %v", m.backoff, err)
+		return err
+	}
+	m.reconnects++
+	m.resetHealth()
+	log.Infoln(log.DatabaseMgr, "Database connection reestablished")
+	m.pushEvent(base.Event{
+		Type:     DatabaseConnectionManagerName,
+		Message:  "Database connection reestablished",
+		Severity: base.SeverityInfo,
+		Key:      databaseConnectivityEventKey,
+		Resolved: true,
+	})
 	return nil
 }
+
+// openCircuit stops health checks pinging a failed connection and schedules
+// reconnection attempts
+func (m *DatabaseConnectionManager) openCircuit() {
+	m.circuitOpen = true
+	m.backoff = databaseReconnectBackoff
+	m.nextReconnect = time.Now().Add(m.backoff)
+	log.Errorf(log.DatabaseMgr, "Database unavailable after %d failed checks, reconnecting in %s", m.consecutiveFailures, m.backoff)
+	m.pushEvent(base.Event{
+		Type:     DatabaseConnectionManagerName,
+		Message:  fmt.Sprintf("Database connection lost: %v", m.lastError),
+		Severity: base.SeverityCritical,
+		Key:      databaseConnectivityEventKey,
+	})
+}
+
+func (m *DatabaseConnectionManager) recordFailure(err error) {
+	m.consecutiveFailures++
+	m.lastError = err
+	m.lastErrorTime = time.Now()
+}
+
+// resetHealth closes the circuit after a successful connection
+func (m *DatabaseConnectionManager) resetHealth() {
+	m.circuitOpen = false
+	m.consecutiveFailures = 0
+	m.backoff = 0
+	m.nextReconnect = time.Time{}
+	m.lastConnected = time.Now()
+	m.lastWaitDuration = 0
+}
+
+// checkPoolSaturation logs when connection requests have waited on the pool
+// for longer than the warning threshold since the last check
+func (m *DatabaseConnectionManager) checkPoolSaturation() {
+	stats, err := m.dbConn.Stats()
+	if err != nil {
+		return
+	}
+	if waited := stats.WaitDuration - m.lastWaitDuration; waited > databasePoolWaitWarning {
+		log.Warnf(log.DatabaseMgr, "Database connection pool saturated, requests waited %s for %d/%d connections",
+			waited, stats.InUse, stats.MaxOpenConnections)
+	}
+	m.lastWaitDuration = stats.WaitDuration
+}
+
+func (m *DatabaseConnectionManager) pushEvent(evt base.Event) {
+	if m.comms == nil {
+		return
+	}
+	m.comms.PushEvent(evt)
+}
+
+// setCommsManager sets the communications manager used to alert on database
+// outages and recovery
+func (m *DatabaseConnectionManager) setCommsManager(comms iCommsManager) {
+	if m == nil || comms == nil {
+		return
+	}
+	m.m.Lock()
+	m.comms = comms
+	m.m.Unlock()
+}
+
+// GetStatus returns the health of the database connection and its pool
+func (m *DatabaseConnectionManager) GetStatus() (*DatabaseStatus, error) {
+	if m == nil {
+		return nil, fmt.Errorf("%s %w", DatabaseConnectionManagerName, ErrNilSubsystem)
+	}
+	if atomic.LoadInt32(&m.started) == 0 {
+		return nil, fmt.Errorf("%s %w", DatabaseConnectionManagerName, ErrSubSystemNotStarted)
+	}
+	m.m.Lock()
+	defer m.m.Unlock()
+	s := &DatabaseStatus{
+		Driver:              m.cfg.Driver,
+		Connected:           m.dbConn.IsConnected(),
+		CircuitOpen:         m.circuitOpen,
+		ConsecutiveFailures: m.consecutiveFailures,
+		Reconnects:          m.reconnects,
+		LastErrorTime:       m.lastErrorTime,
+		LastConnected:       m.lastConnected,
+		NextReconnect:       m.nextReconnect,
+	}
+	if m.lastError != nil {
+		s.LastError = m.lastError.Error()
+	}
+	// pool stats are unavailable while reconnecting
+	s.Pool, _ = m.dbConn.Stats()
+	return s, nil
+}
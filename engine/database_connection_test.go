@@ -5,7 +5,11 @@ import (
 	"log"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
 	"github.com/thrasher-corp/gocryptotrader/database"
 	"github.com/thrasher-corp/gocryptotrader/database/drivers"
 )
@@ -261,3 +265,58 @@ func TestGetInstance(t *testing.T) {
 		t.Error("expected nil")
 	}
 }
+
+func TestDatabaseReconnectCircuit(t *testing.T) {
+	CreateDatabase(t)
+	m, err := SetupDatabaseConnectionManager(&database.Config{
+		Enabled: true,
+		Driver:  database.DBSQLite,
+		ConnectionDetails: drivers.ConnectionDetails{
+			Host:     "localhost",
+			Database: "test.db",
+		},
+	})
+	require.NoError(t, err)
+	comms := &fakeComms{}
+	m.setCommsManager(comms)
+
+	_, err = m.GetStatus()
+	assert.ErrorIs(t, err, ErrSubSystemNotStarted)
+
+	var wg sync.WaitGroup
+	require.NoError(t, m.Start(&wg))
+	defer func() { assert.NoError(t, m.Stop()) }()
+
+	s, err := m.GetStatus()
+	require.NoError(t, err)
+	assert.True(t, s.Connected)
+	assert.False(t, s.CircuitOpen)
+	assert.Equal(t, 1, s.Pool.MaxOpenConnections, "pool stats should be populated")
+
+	// simulate an outage by closing the underlying pool
+	require.NoError(t, m.dbConn.CloseConnection())
+	for range databaseFailureThreshold {
+		assert.Error(t, m.checkConnection())
+	}
+	s, err = m.GetStatus()
+	require.NoError(t, err)
+	assert.False(t, s.Connected)
+	assert.True(t, s.CircuitOpen, "circuit should open after consecutive failures")
+	assert.NotEmpty(t, s.LastError)
+	require.Len(t, comms.events, 1)
+	assert.Equal(t, base.SeverityCritical, comms.events[0].Severity)
+
+	assert.ErrorIs(t, m.checkConnection(), errDatabaseCircuitOpen, "checks should not retry before the backoff elapses")
+
+	m.m.Lock()
+	m.nextReconnect = time.Now().Add(-time.Second)
+	m.m.Unlock()
+	require.NoError(t, m.checkConnection(), "reconnect should succeed once the backoff elapses")
+	s, err = m.GetStatus()
+	require.NoError(t, err)
+	assert.True(t, s.Connected)
+	assert.False(t, s.CircuitOpen)
+	assert.Equal(t, int64(1), s.Reconnects)
+	require.Len(t, comms.events, 2)
+	assert.True(t, comms.events[1].Resolved, "recovery should resolve the outage event")
+}
@@ -0,0 +1,67 @@
+package engine
+
+import (
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+)
+
+const (
+	// DatabaseConnectionManagerName is an exported subsystem name
+	DatabaseConnectionManagerName = "database"
+
+	databaseCheckInterval = time.Second * 2
+	// databaseFailureThreshold is the number of consecutive failed health
+	// checks before the circuit opens and reconnection begins
+	databaseFailureThreshold = 3
+	// databaseReconnectBackoff is the initial delay between reconnection
+	// attempts, which doubles after each failure up to databaseMaxReconnectBackoff
+	databaseReconnectBackoff    = time.Second * 5
+	databaseMaxReconnectBackoff = time.Minute
+	// databasePoolWaitWarning is how long connection requests can wait on the
+	// pool between health checks before saturation is logged
+	databasePoolWaitWarning = time.Second
+
+	databaseConnectivityEventKey = "database_connectivity"
+)
+
+var errDatabaseCircuitOpen = errors.New("database circuit open, awaiting reconnect")
+
+// DatabaseConnectionManager holds the database connection and its status
+type DatabaseConnectionManager struct {
+	started  int32
+	shutdown chan struct{}
+	cfg      database.Config
+	wg       sync.WaitGroup
+	dbConn   *database.Instance
+
+	// m protects the health fields below
+	m                   sync.Mutex
+	consecutiveFailures int
+	circuitOpen         bool
+	backoff             time.Duration
+	nextReconnect       time.Time
+	reconnects          int64
+	lastError           error
+	lastErrorTime       time.Time
+	lastConnected       time.Time
+	lastWaitDuration    time.Duration
+	comms               iCommsManager
+}
+
+// DatabaseStatus holds the health of the database connection and its pool
+type DatabaseStatus struct {
+	Driver              string
+	Connected           bool
+	CircuitOpen         bool
+	ConsecutiveFailures int
+	Reconnects          int64
+	LastError           string
+	LastErrorTime       time.Time
+	LastConnected       time.Time
+	NextReconnect       time.Time
+	Pool                sql.DBStats
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"strconv"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -21,6 +22,7 @@ import (
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
 	"github.com/thrasher-corp/gocryptotrader/log"
 )
@@ -196,7 +198,7 @@ func (m *DataHistoryManager) compareJobsToData(jobs ...*DataHistoryJob) error {
 			if err != nil {
 				return err
 			}
-		case dataHistoryTradeDataType:
+		case dataHistoryTradeDataType, dataHistoryTradeBackfillDataType:
 			for x := range jobs[i].rangeHolder.Ranges {
 				results, ok := jobs[i].Results[jobs[i].rangeHolder.Ranges[x].Start.Time.Unix()]
 				if !ok {
@@ -358,7 +360,13 @@ func (m *DataHistoryManager) runDataJob(job *DataHistoryJob, exch exchange.IBotE
 	var err error
 	var result *DataHistoryJobResult
 ranges:
-	for i := range job.rangeHolder.Ranges {
+	for x := range job.rangeHolder.Ranges {
+		i := x
+		if job.DataType == dataHistoryTradeBackfillDataType {
+			// backfill jobs page backwards from the end date towards the
+			// target start date
+			i = len(job.rangeHolder.Ranges) - 1 - x
+		}
 		skipProcessing := true
 		for j := range job.rangeHolder.Ranges[i].Intervals {
 			if !job.rangeHolder.Ranges[i].Intervals[j].HasData {
@@ -450,6 +458,8 @@ ranges:
 			result, err = m.convertTradesToCandles(job, job.rangeHolder.Ranges[i].Start.Time, job.rangeHolder.Ranges[i].End.Time)
 		case dataHistoryConvertCandlesDataType:
 			result, err = m.convertCandleData(job, job.rangeHolder.Ranges[i].Start.Time, job.rangeHolder.Ranges[i].End.Time)
+		case dataHistoryTradeBackfillDataType:
+			result, err = m.processTradeBackfillData(job, exch, job.rangeHolder.Ranges[i].Start.Time, job.rangeHolder.Ranges[i].End.Time, int64(i))
 		default:
 			return errUnknownDataType
 		}
@@ -463,6 +473,11 @@ ranges:
 		lookup := job.Results[result.IntervalStartDate.Unix()]
 		lookup = append(lookup, *result)
 		job.Results[result.IntervalStartDate.Unix()] = lookup
+		if job.Status == dataHistoryStatusFailed {
+			// the job cannot continue, eg the exchange does not support
+			// fetching trade history
+			return nil
+		}
 	}
 	completed := true // nolint:ifshort,nolintlint // false positive and triggers only on Windows
 	allResultsSuccessful := true
@@ -710,7 +725,7 @@ func (m *DataHistoryManager) processCandleData(job *DataHistoryJob, exch exchang
 		Status:            dataHistoryStatusComplete,
 		Date:              time.Now(),
 	}
-	candles, err := exch.GetHistoricCandlesExtended(context.TODO(),
+	candles, err := exch.GetHistoricCandlesExtended(request.WithPriority(context.TODO(), request.PriorityBackfill),
 		job.Pair,
 		job.Asset,
 		job.Interval,
@@ -763,7 +778,7 @@ func (m *DataHistoryManager) processTradeData(job *DataHistoryJob, exch exchange
 		Status:            dataHistoryStatusComplete,
 		Date:              time.Now(),
 	}
-	trades, err := exch.GetHistoricTrades(context.TODO(),
+	trades, err := exch.GetHistoricTrades(request.WithPriority(context.TODO(), request.PriorityBackfill),
 		job.Pair,
 		job.Asset,
 		startRange,
@@ -791,6 +806,94 @@ func (m *DataHistoryManager) processTradeData(job *DataHistoryJob, exch exchange
 				job.rangeHolder.Ranges[intervalIndex].Intervals[i].End.Time.Format(common.SimpleTimeFormatWithTimezone))
 		}
 	}
+	m.saveTradesInBatches(trades, r)
+	return r, nil
+}
+
+// processTradeBackfillData fetches trades for a range and saves those which are
+// not already stored, noting intervals without trades as coverage gaps
+func (m *DataHistoryManager) processTradeBackfillData(job *DataHistoryJob, exch exchange.IBotExchange, startRange, endRange time.Time, intervalIndex int64) (*DataHistoryJobResult, error) {
+	if !m.IsRunning() {
+		return nil, ErrSubSystemNotStarted
+	}
+	if job == nil {
+		return nil, errNilJob
+	}
+	if exch == nil {
+		return nil, ErrExchangeNotFound
+	}
+	if err := common.StartEndTimeCheck(startRange, endRange); err != nil {
+		return nil, err
+	}
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	r := &DataHistoryJobResult{
+		ID:                id,
+		JobID:             job.ID,
+		IntervalStartDate: startRange,
+		IntervalEndDate:   endRange,
+		Status:            dataHistoryStatusComplete,
+		Date:              time.Now(),
+	}
+	trades, err := exch.GetHistoricTrades(request.WithPriority(context.TODO(), request.PriorityBackfill),
+		job.Pair,
+		job.Asset,
+		startRange,
+		endRange)
+	if err != nil {
+		r.Result += "could not get trades: " + err.Error() + ". "
+		r.Status = dataHistoryStatusFailed
+		if errors.Is(err, common.ErrFunctionNotSupported) || errors.Is(err, common.ErrNotYetImplemented) {
+			log.Errorf(log.DataHistory, "job %s %s does not support trade history, setting job as failed", job.Nickname, job.Exchange)
+			job.Status = dataHistoryStatusFailed
+		}
+		return r, nil //nolint:nilerr // error is returned in the job result
+	}
+	stored, err := m.tradeLoader(job.Exchange, job.Asset.String(), job.Pair.Base.String(), job.Pair.Quote.String(), startRange, endRange)
+	if err != nil {
+		r.Result += "could not load stored trades: " + err.Error() + ". "
+		r.Status = dataHistoryStatusFailed
+		return r, nil //nolint:nilerr // error is returned in the job result
+	}
+	seen := make(map[string]struct{}, len(stored)+len(trades))
+	for i := range stored {
+		seen[tradeDedupeKey(&stored[i])] = struct{}{}
+	}
+	newTrades := make([]trade.Data, 0, len(trades))
+	for i := range trades {
+		key := tradeDedupeKey(&trades[i])
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+		newTrades = append(newTrades, trades[i])
+	}
+	r.Result += fmt.Sprintf("saved %d new trades, skipped %d duplicates. ", len(newTrades), len(trades)-len(newTrades))
+
+	intervals := job.rangeHolder.Ranges[intervalIndex].Intervals
+	setHasDataFromTrades(intervals, append(stored, newTrades...))
+	for i := range intervals {
+		if !intervals[i].HasData {
+			// an interval without trades may be genuine inactivity, so gaps
+			// are reported rather than retried
+			r.Result += fmt.Sprintf("coverage gap %v - %v. ",
+				intervals[i].Start.Time.Format(common.SimpleTimeFormatWithTimezone),
+				intervals[i].End.Time.Format(common.SimpleTimeFormatWithTimezone))
+		}
+	}
+	m.saveTradesInBatches(newTrades, r)
+	return r, nil
+}
+
+// saveTradesInBatches saves trades in batches of maxResultInsertions, noting
+// any failures in the job result
+func (m *DataHistoryManager) saveTradesInBatches(trades []trade.Data, r *DataHistoryJobResult) {
+	if m.maxResultInsertions <= 0 {
+		m.maxResultInsertions = defaultMaxResultInsertions
+	}
+	var err error
 	for i := 0; i < len(trades); i += int(m.maxResultInsertions) {
 		if i+int(m.maxResultInsertions) > len(trades) {
 			if m.verbose {
@@ -812,7 +915,32 @@ func (m *DataHistoryManager) processTradeData(job *DataHistoryJob, exch exchange
 			r.Status = dataHistoryStatusFailed
 		}
 	}
-	return r, nil
+}
+
+// tradeDedupeKey returns a key identifying a trade. Exchange trade IDs are
+// used when available, otherwise trades are matched on their details to the
+// second as stored timestamp precision varies by database
+func tradeDedupeKey(t *trade.Data) string {
+	if t.TID != "" {
+		return t.TID
+	}
+	return strconv.FormatInt(t.Timestamp.Unix(), 10) + "-" +
+		strconv.FormatFloat(t.Price, 'f', -1, 64) + "-" +
+		strconv.FormatFloat(t.Amount, 'f', -1, 64) + "-" +
+		t.Side.String()
+}
+
+// setHasDataFromTrades flags each interval which contains at least one trade
+func setHasDataFromTrades(intervals []kline.IntervalData, trades []trade.Data) {
+	for i := range intervals {
+		intervals[i].HasData = false
+		for j := range trades {
+			if !trades[j].Timestamp.Before(intervals[i].Start.Time) && trades[j].Timestamp.Before(intervals[i].End.Time) {
+				intervals[i].HasData = true
+				break
+			}
+		}
+	}
 }
 
 func (m *DataHistoryManager) convertTradesToCandles(job *DataHistoryJob, startRange, endRange time.Time) (*DataHistoryJobResult, error) {
@@ -919,7 +1047,7 @@ func (m *DataHistoryManager) validateCandles(job *DataHistoryJob, exch exchange.
 		Date:              time.Now(),
 	}
 
-	apiCandles, err := exch.GetHistoricCandlesExtended(context.TODO(),
+	apiCandles, err := exch.GetHistoricCandlesExtended(request.WithPriority(context.TODO(), request.PriorityBackfill),
 		job.Pair,
 		job.Asset,
 		job.Interval,
@@ -1227,7 +1355,7 @@ func (m *DataHistoryManager) validateJob(job *DataHistoryJob) error {
 	if job.RequestSizeLimit <= 0 {
 		job.RequestSizeLimit = defaultDataHistoryRequestSizeLimit
 	}
-	if job.DataType == dataHistoryTradeDataType {
+	if job.DataType == dataHistoryTradeDataType || job.DataType == dataHistoryTradeBackfillDataType {
 		if job.Interval > kline.FourHour {
 			log.Warnf(log.DataHistory, "job %s interval %v above the limit of 4h, defaulting to %v interval size worth of trades to fetch", job.Nickname, job.Interval.Word(), defaultDataHistoryTradeInterval)
 			job.Interval = defaultDataHistoryTradeInterval
@@ -1463,6 +1591,18 @@ func (m *DataHistoryManager) GenerateJobSummary(nickname string) (*DataHistoryJo
 	if err != nil {
 		return nil, err
 	}
+	if job.DataType == dataHistoryTradeBackfillDataType {
+		// report coverage from stored trades so gaps are shown regardless of
+		// which job saved the data
+		var trades []trade.Data
+		trades, err = m.tradeLoader(job.Exchange, job.Asset.String(), job.Pair.Base.String(), job.Pair.Quote.String(), job.StartDate, job.EndDate)
+		if err != nil {
+			return nil, fmt.Errorf("job: %v could not load trades %w", nickname, err)
+		}
+		for i := range job.rangeHolder.Ranges {
+			setHasDataFromTrades(job.rangeHolder.Ranges[i].Intervals, trades)
+		}
+	}
 
 	return &DataHistoryJobSummary{
 		Nickname:     job.Nickname,
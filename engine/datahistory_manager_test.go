@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/common/convert"
 	"github.com/thrasher-corp/gocryptotrader/config"
@@ -1111,6 +1113,69 @@ func TestProcessTradeData(t *testing.T) {
 	}
 }
 
+func TestProcessTradeBackfillData(t *testing.T) {
+	t.Parallel()
+	m, _ := createDHM(t)
+	_, err := m.processTradeBackfillData(nil, nil, time.Time{}, time.Time{}, 0)
+	assert.ErrorIs(t, err, errNilJob)
+
+	start := time.Now().Add(-kline.OneHour.Duration() * 2).Truncate(kline.OneHour.Duration())
+	j := &DataHistoryJob{
+		Exchange:  testExchange,
+		Asset:     asset.Spot,
+		Pair:      currency.NewPair(currency.BTC, currency.USDT),
+		StartDate: start,
+		EndDate:   start.Add(kline.OneHour.Duration() * 2),
+		Interval:  kline.OneHour,
+		DataType:  dataHistoryTradeBackfillDataType,
+	}
+	_, err = m.processTradeBackfillData(j, nil, time.Time{}, time.Time{}, 0)
+	assert.ErrorIs(t, err, ErrExchangeNotFound)
+
+	em := NewExchangeManager()
+	exch, err := em.NewExchangeByName(testExchange)
+	require.NoError(t, err)
+	exch.SetDefaults()
+	j.rangeHolder, err = kline.CalculateCandleDateRanges(j.StartDate, j.EndDate, j.Interval, 2)
+	require.NoError(t, err)
+
+	// the first trade returned by the exchange is already stored
+	m.tradeLoader = func(exch, _, _, _ string, _, _ time.Time) ([]trade.Data, error) {
+		return []trade.Data{{Exchange: exch, Side: order.Buy, Price: 1337, Amount: 4, Timestamp: start.Add(time.Minute)}}, nil
+	}
+	var saved []trade.Data
+	m.tradeSaver = func(trades ...trade.Data) error {
+		saved = append(saved, trades...)
+		return nil
+	}
+	r, err := m.processTradeBackfillData(j, dhmExchange{IBotExchange: exch}, j.StartDate, j.EndDate, 0)
+	require.NoError(t, err)
+	assert.Equal(t, dataHistoryStatusComplete, r.Status, "coverage gaps should not fail the result")
+	require.Len(t, saved, 1, "stored trades should not be saved again")
+	assert.Equal(t, 1338.0, saved[0].Price)
+	assert.Contains(t, r.Result, "saved 1 new trades, skipped 1 duplicates")
+	assert.True(t, j.rangeHolder.Ranges[0].Intervals[0].HasData)
+	assert.False(t, j.rangeHolder.Ranges[0].Intervals[1].HasData)
+	assert.Contains(t, r.Result, "coverage gap "+start.Add(kline.OneHour.Duration()).Format(common.SimpleTimeFormatWithTimezone))
+
+	r, err = m.processTradeBackfillData(j, dhmUnsupportedExchange{IBotExchange: exch}, j.StartDate, j.EndDate, 0)
+	require.NoError(t, err)
+	assert.Equal(t, dataHistoryStatusFailed, r.Status)
+	assert.Equal(t, dataHistoryStatusFailed, j.Status, "unsupported trade history should fail the job")
+}
+
+func TestTradeDedupeKey(t *testing.T) {
+	t.Parallel()
+	ts := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	a := trade.Data{Price: 1, Amount: 2, Side: order.Buy, Timestamp: ts.Add(time.Millisecond * 5)}
+	b := trade.Data{Price: 1, Amount: 2, Side: order.Buy, Timestamp: ts}
+	assert.Equal(t, tradeDedupeKey(&a), tradeDedupeKey(&b), "trades should match to the second")
+	b.Side = order.Sell
+	assert.NotEqual(t, tradeDedupeKey(&a), tradeDedupeKey(&b))
+	a.TID, b.TID = "1", "1"
+	assert.Equal(t, tradeDedupeKey(&a), tradeDedupeKey(&b), "trade IDs should take precedence")
+}
+
 func TestConvertJobTradesToCandles(t *testing.T) {
 	t.Parallel()
 	m, _ := createDHM(t)
@@ -1617,3 +1682,12 @@ func (f dhmExchange) GetHistoricTrades(_ context.Context, p currency.Pair, a ass
 		},
 	}, nil
 }
+
+// dhmUnsupportedExchange is a fake exchange which does not support trade history
+type dhmUnsupportedExchange struct {
+	exchange.IBotExchange
+}
+
+func (f dhmUnsupportedExchange) GetHistoricTrades(context.Context, currency.Pair, asset.Item, time.Time, time.Time) ([]trade.Data, error) {
+	return nil, common.ErrFunctionNotSupported
+}
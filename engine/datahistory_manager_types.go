@@ -27,6 +27,7 @@ const (
 	dataHistoryConvertCandlesDataType
 	dataHistoryCandleValidationDataType
 	dataHistoryCandleValidationSecondarySourceType
+	dataHistoryTradeBackfillDataType
 )
 
 // DataHistoryJob status descriptors
@@ -79,13 +80,15 @@ func (d dataHistoryDataType) String() string {
 		return "conversion validation"
 	case 5:
 		return "conversion validation secondary source"
+	case 6:
+		return "trade backfill"
 	}
 	return ""
 }
 
 // Valid ensures the value set is legitimate
 func (d dataHistoryDataType) Valid() bool {
-	return int64(d) >= 0 && int64(d) <= 5
+	return int64(d) >= 0 && int64(d) <= 6
 }
 
 var (
@@ -8,6 +8,7 @@ import (
 
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/deposit"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/transfer"
 )
 
 // vars related to the deposit address helpers
@@ -71,6 +72,12 @@ func (m *DepositAddressManager) GetDepositAddressByExchangeAndCurrency(exchName,
 				return addr[x], nil
 			}
 		}
+		network := transfer.NormaliseNetwork(chain)
+		for x := range addr {
+			if transfer.NormaliseNetwork(addr[x].Chain) == network {
+				return addr[x], nil
+			}
+		}
 		return deposit.Address{}, errDepositAddressChainNotFound
 	}
 
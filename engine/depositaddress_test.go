@@ -153,6 +153,9 @@ func TestGetDepositAddressByExchangeAndCurrency(t *testing.T) {
 	if r, _ := m.GetDepositAddressByExchangeAndCurrency(bitStamp, "sOl", currency.USDT); r.Address != "ABsdZ" && r.Chain != "SOL" {
 		t.Error("unexpected values")
 	}
+	if r, _ := m.GetDepositAddressByExchangeAndCurrency(bitStamp, "USDT-ERC20", currency.USDT); r.Address != "0x1b" {
+		t.Error("chains should be matched by their normalised network")
+	}
 	if r, _ := m.GetDepositAddressByExchangeAndCurrency(bitStamp, "", currency.USDT); r.Address != "1asdad" && r.Chain != "USDT" {
 		t.Error("unexpected values")
 	}
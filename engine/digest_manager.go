@@ -0,0 +1,424 @@
+package engine
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/cron"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database/models/postgres"
+	"github.com/thrasher-corp/gocryptotrader/database/models/sqlite3"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/audit"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// SetupDigestManager creates a new digest manager from the supplied config
+func SetupDigestManager(cfg *config.DigestConfig, om iDigestOrderManager, comms iCommsManager) (*DigestManager, error) {
+	if cfg == nil {
+		return nil, errNilConfig
+	}
+	if om == nil {
+		return nil, errNilOrderManager
+	}
+	if comms == nil {
+		return nil, errNilCommunicationsManager
+	}
+	if cfg.TopN <= 0 {
+		return nil, errInvalidDigestTopN
+	}
+	if len(cfg.Reports) == 0 {
+		return nil, errNoDigestReports
+	}
+	m := &DigestManager{
+		shutdown:     make(chan struct{}),
+		verbose:      cfg.Verbose,
+		topN:         cfg.TopN,
+		maxEvents:    cfg.MaxEvents,
+		orderManager: om,
+		comms:        comms,
+		getEvents:    getAuditEvents,
+	}
+	for i := range cfg.Reports {
+		s, err := cron.Parse(cfg.Reports[i].Schedule)
+		if err != nil {
+			return nil, fmt.Errorf("digest report %q: %w", cfg.Reports[i].Name, err)
+		}
+		m.reports = append(m.reports, &digestReport{cfg: cfg.Reports[i], schedule: s})
+	}
+	return m, nil
+}
+
+// IsRunning safely checks whether the subsystem is running
+func (m *DigestManager) IsRunning() bool {
+	if m == nil {
+		return false
+	}
+	return atomic.LoadInt32(&m.started) == 1
+}
+
+// Start runs the subsystem
+func (m *DigestManager) Start() error {
+	if m == nil {
+		return fmt.Errorf("%s %w", DigestManagerName, ErrNilSubsystem)
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 0, 1) {
+		return fmt.Errorf("%s %w", DigestManagerName, ErrSubSystemAlreadyStarted)
+	}
+	log.Debugf(log.Global, "Digest manager %s", MsgSubSystemStarting)
+	now := time.Now()
+	for _, r := range m.reports {
+		r.nextRun = r.schedule.Next(now)
+	}
+	m.wg.Add(1)
+	go m.run()
+	log.Debugf(log.Global, "Digest manager %s", MsgSubSystemStarted)
+	return nil
+}
+
+// Stop stops the subsystem
+func (m *DigestManager) Stop() error {
+	if m == nil {
+		return fmt.Errorf("%s %w", DigestManagerName, ErrNilSubsystem)
+	}
+	if atomic.LoadInt32(&m.started) == 0 {
+		return fmt.Errorf("%s %w", DigestManagerName, ErrSubSystemNotStarted)
+	}
+	log.Debugf(log.Global, "Digest manager %s", MsgSubSystemShuttingDown)
+	close(m.shutdown)
+	m.wg.Wait()
+	m.shutdown = make(chan struct{})
+	atomic.StoreInt32(&m.started, 0)
+	log.Debugf(log.Global, "Digest manager %s", MsgSubSystemShutdown)
+	return nil
+}
+
+func (m *DigestManager) run() {
+	defer m.wg.Done()
+	runSchedule(m.shutdown, m.nextRun, m.runDue)
+}
+
+// nextRun returns the earliest scheduled time of all reports
+func (m *DigestManager) nextRun() time.Time {
+	runs := make([]time.Time, len(m.reports))
+	for i, r := range m.reports {
+		runs[i] = r.nextRun
+	}
+	return earliestRun(runs...)
+}
+
+// runDue composes and sends all reports which are due at the supplied time
+func (m *DigestManager) runDue(now time.Time) {
+	for _, r := range m.reports {
+		if r.nextRun.IsZero() || r.nextRun.After(now) {
+			continue
+		}
+		r.nextRun = r.schedule.Next(now)
+		if err := m.send(r.cfg.Name, now.Add(-r.cfg.Period), now); err != nil {
+			log.Errorf(log.Global, "Digest manager unable to compose %s digest: %v", r.cfg.Name, err)
+		}
+	}
+}
+
+// Send composes a digest covering the period up to now and sends it via the
+// communications manager outside of the configured report schedules
+func (m *DigestManager) Send(name string, period time.Duration) error {
+	if m == nil {
+		return fmt.Errorf("%s %w", DigestManagerName, ErrNilSubsystem)
+	}
+	now := time.Now()
+	return m.send(name, now.Add(-period), now)
+}
+
+func (m *DigestManager) send(name string, start, end time.Time) error {
+	d, err := m.Compose(name, start, end)
+	if err != nil {
+		return err
+	}
+	if m.verbose {
+		log.Debugf(log.Global, "Digest manager sending %s digest", name)
+	}
+	m.comms.PushEvent(base.Event{
+		Type:     digestEventType,
+		Message:  d.String(),
+		Severity: base.SeverityInfo,
+	})
+	return nil
+}
+
+// Compose builds a digest covering the supplied time range
+func (m *DigestManager) Compose(name string, start, end time.Time) (*Digest, error) {
+	if m == nil {
+		return nil, fmt.Errorf("%s %w", DigestManagerName, ErrNilSubsystem)
+	}
+	if err := common.StartEndTimeCheck(start, end); err != nil {
+		return nil, err
+	}
+	d := &Digest{
+		Name:        name,
+		Start:       start,
+		End:         end,
+		RealisedPNL: make(map[currency.Code]decimal.Decimal),
+		Fees:        make(map[currency.Code]decimal.Decimal),
+		OpenRisk: DigestOpenRisk{
+			ActiveOrderValue: make(map[currency.Code]decimal.Decimal),
+		},
+	}
+
+	orders := m.orderManager.GetOrdersSnapshot(order.AnyStatus)
+	sort.SliceStable(orders, func(i, j int) bool {
+		return orderTime(&orders[i]).Before(orderTime(&orders[j]))
+	})
+	trackers := make(map[string]*pnlTracker)
+	pairPNL := make(map[string]*DigestPairPNL)
+	for i := range orders {
+		o := &orders[i]
+		if o.IsActive() {
+			d.OpenRisk.ActiveOrders++
+			value := decimal.NewFromFloat(o.Price).Mul(decimal.NewFromFloat(o.Amount - o.ExecutedAmount))
+			d.OpenRisk.ActiveOrderValue[o.Pair.Quote] = d.OpenRisk.ActiveOrderValue[o.Pair.Quote].Add(value)
+		}
+		amount, price := executedAmountAndPrice(o)
+		if amount.IsZero() || price.IsZero() {
+			continue
+		}
+		key := o.Exchange + "|" + o.AssetType.String() + "|" + o.Pair.String()
+		t, ok := trackers[key]
+		if !ok {
+			t = &pnlTracker{}
+			trackers[key] = t
+		}
+		realised := t.apply(o.Side, amount, price)
+		ot := orderTime(o)
+		if ot.Before(start) || ot.After(end) {
+			continue
+		}
+		d.OrdersFilled++
+		if o.Fee > 0 {
+			feeAsset := o.FeeAsset
+			if feeAsset.IsEmpty() {
+				feeAsset = o.Pair.Quote
+			}
+			d.Fees[feeAsset] = d.Fees[feeAsset].Add(decimal.NewFromFloat(o.Fee))
+		}
+		if realised.IsZero() {
+			continue
+		}
+		p, ok := pairPNL[key]
+		if !ok {
+			p = &DigestPairPNL{Exchange: o.Exchange, Asset: o.AssetType, Pair: o.Pair, Quote: o.Pair.Quote}
+			pairPNL[key] = p
+		}
+		p.PNL = p.PNL.Add(realised)
+		d.RealisedPNL[o.Pair.Quote] = d.RealisedPNL[o.Pair.Quote].Add(realised)
+	}
+
+	ranked := make([]DigestPairPNL, 0, len(pairPNL))
+	for _, p := range pairPNL {
+		ranked = append(ranked, *p)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if !ranked[i].PNL.Equal(ranked[j].PNL) {
+			return ranked[i].PNL.GreaterThan(ranked[j].PNL)
+		}
+		return ranked[i].Pair.String() < ranked[j].Pair.String()
+	})
+	for i := range ranked {
+		if len(d.TopWinners) == m.topN || !ranked[i].PNL.IsPositive() {
+			break
+		}
+		d.TopWinners = append(d.TopWinners, ranked[i])
+	}
+	for i := len(ranked) - 1; i >= 0; i-- {
+		if len(d.TopLosers) == m.topN || !ranked[i].PNL.IsNegative() {
+			break
+		}
+		d.TopLosers = append(d.TopLosers, ranked[i])
+	}
+
+	positions, err := m.orderManager.GetAllOpenFuturesPositions()
+	if err != nil {
+		d.OpenRisk.PositionsFetchErr = err
+	}
+	for i := range positions {
+		d.OpenRisk.OpenPositions = append(d.OpenRisk.OpenPositions, DigestPosition{
+			Exchange:      positions[i].Exchange,
+			Asset:         positions[i].Asset,
+			Pair:          positions[i].Pair,
+			Side:          positions[i].LatestDirection,
+			Size:          positions[i].LatestSize,
+			Price:         positions[i].LatestPrice,
+			UnrealisedPNL: positions[i].UnrealisedPNL,
+		})
+		d.OpenRisk.UnrealisedPNL = d.OpenRisk.UnrealisedPNL.Add(positions[i].UnrealisedPNL)
+	}
+
+	if m.maxEvents > 0 && m.getEvents != nil {
+		events, err := m.getEvents(start, end, m.maxEvents)
+		if err != nil {
+			if m.verbose {
+				log.Warnf(log.Global, "Digest manager unable to fetch events: %v", err)
+			}
+		} else {
+			d.Events = events
+		}
+	}
+	return d, nil
+}
+
+// String renders the digest as a plain text message suitable for any
+// communication channel
+func (d *Digest) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s digest %s - %s\n", d.Name,
+		d.Start.UTC().Format(common.SimpleTimeFormatWithTimezone),
+		d.End.UTC().Format(common.SimpleTimeFormatWithTimezone))
+	fmt.Fprintf(&sb, "Orders filled: %d\n", d.OrdersFilled)
+	sb.WriteString("Realised P&L: " + formatCodeAmounts(d.RealisedPNL) + "\n")
+	sb.WriteString("Fees paid: " + formatCodeAmounts(d.Fees) + "\n")
+	writePairPNL(&sb, "Top winners", d.TopWinners)
+	writePairPNL(&sb, "Top losers", d.TopLosers)
+	fmt.Fprintf(&sb, "Open risk: %d active orders (%s), %d open positions, unrealised P&L %s\n",
+		d.OpenRisk.ActiveOrders,
+		formatCodeAmounts(d.OpenRisk.ActiveOrderValue),
+		len(d.OpenRisk.OpenPositions),
+		d.OpenRisk.UnrealisedPNL.String())
+	for i := range d.OpenRisk.OpenPositions {
+		p := &d.OpenRisk.OpenPositions[i]
+		fmt.Fprintf(&sb, "  %s %s %s %s %s @ %s, unrealised %s\n",
+			p.Exchange, p.Asset, p.Pair, p.Side, p.Size, p.Price, p.UnrealisedPNL)
+	}
+	if len(d.Events) > 0 {
+		sb.WriteString("Notable events:\n")
+		for i := range d.Events {
+			fmt.Fprintf(&sb, "  %s [%s] %s: %s\n",
+				d.Events[i].Time.UTC().Format(common.SimpleTimeFormatWithTimezone),
+				d.Events[i].Type,
+				d.Events[i].Identifier,
+				d.Events[i].Message)
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+func writePairPNL(sb *strings.Builder, title string, pnl []DigestPairPNL) {
+	if len(pnl) == 0 {
+		sb.WriteString(title + ": none\n")
+		return
+	}
+	sb.WriteString(title + ":\n")
+	for i := range pnl {
+		fmt.Fprintf(sb, "  %s %s %s %s %s\n", pnl[i].Exchange, pnl[i].Asset, pnl[i].Pair, pnl[i].PNL, pnl[i].Quote)
+	}
+}
+
+// formatCodeAmounts renders a currency amount map in a deterministic order
+func formatCodeAmounts(amounts map[currency.Code]decimal.Decimal) string {
+	if len(amounts) == 0 {
+		return "none"
+	}
+	out := make([]string, 0, len(amounts))
+	for code, amount := range amounts {
+		out = append(out, amount.String()+" "+code.String())
+	}
+	slices.Sort(out)
+	return strings.Join(out, ", ")
+}
+
+// orderTime returns the most relevant time an order was last traded
+func orderTime(o *order.Detail) time.Time {
+	if !o.LastUpdated.IsZero() {
+		return o.LastUpdated
+	}
+	return o.Date
+}
+
+// executedAmountAndPrice returns the amount and average price an order has
+// been filled at
+func executedAmountAndPrice(o *order.Detail) (amount, price decimal.Decimal) {
+	executed := o.ExecutedAmount
+	if executed == 0 && o.Status == order.Filled {
+		executed = o.Amount
+	}
+	p := o.AverageExecutedPrice
+	if p == 0 {
+		p = o.Price
+	}
+	return decimal.NewFromFloat(executed), decimal.NewFromFloat(p)
+}
+
+// apply adds a fill to the tracked position and returns any profit or loss
+// realised by reducing the position
+func (p *pnlTracker) apply(side order.Side, amount, price decimal.Decimal) decimal.Decimal {
+	qty := amount
+	switch {
+	case side.IsLong():
+	case side.IsShort():
+		qty = qty.Neg()
+	default:
+		return decimal.Zero
+	}
+	if p.size.IsZero() || p.size.IsPositive() == qty.IsPositive() {
+		total := p.size.Abs().Add(amount)
+		p.avgPrice = p.size.Abs().Mul(p.avgPrice).Add(amount.Mul(price)).Div(total)
+		p.size = p.size.Add(qty)
+		return decimal.Zero
+	}
+	closed := decimal.Min(amount, p.size.Abs())
+	realised := closed.Mul(price.Sub(p.avgPrice))
+	if p.size.IsNegative() {
+		realised = realised.Neg()
+	}
+	wasLong := p.size.IsPositive()
+	p.size = p.size.Add(qty)
+	switch {
+	case p.size.IsZero():
+		p.avgPrice = decimal.Zero
+	case p.size.IsPositive() != wasLong:
+		// position has flipped direction, the remainder opened at this price
+		p.avgPrice = price
+	}
+	return realised
+}
+
+// getAuditEvents fetches audit events stored in the database
+func getAuditEvents(start, end time.Time, limit int) ([]DigestEvent, error) {
+	events, err := audit.GetEvent(start, end, "desc", limit)
+	if err != nil {
+		return nil, err
+	}
+	var resp []DigestEvent
+	switch v := events.(type) {
+	case postgres.AuditEventSlice:
+		for x := range v {
+			resp = append(resp, DigestEvent{
+				Time:       v[x].CreatedAt,
+				Type:       v[x].Type,
+				Identifier: v[x].Identifier,
+				Message:    v[x].Message,
+			})
+		}
+	case sqlite3.AuditEventSlice:
+		for x := range v {
+			tm, err := time.Parse(time.RFC3339, v[x].CreatedAt)
+			if err != nil {
+				return nil, err
+			}
+			resp = append(resp, DigestEvent{
+				Time:       tm,
+				Type:       v[x].Type,
+				Identifier: v[x].Identifier,
+				Message:    v[x].Message,
+			})
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,169 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+type fakeDigestOrderManager struct {
+	orders    []order.Detail
+	positions []futures.Position
+	err       error
+}
+
+func (f *fakeDigestOrderManager) GetOrdersSnapshot(order.Status) []order.Detail {
+	return append([]order.Detail(nil), f.orders...)
+}
+
+func (f *fakeDigestOrderManager) GetAllOpenFuturesPositions() ([]futures.Position, error) {
+	return f.positions, f.err
+}
+
+func testDigestConfig() *config.DigestConfig {
+	return &config.DigestConfig{
+		TopN:      1,
+		MaxEvents: 5,
+		Reports:   []config.DigestReport{{Name: "Daily", Schedule: "@daily", Period: time.Hour * 24}},
+	}
+}
+
+func TestSetupDigestManager(t *testing.T) {
+	t.Parallel()
+	_, err := SetupDigestManager(nil, nil, nil)
+	assert.ErrorIs(t, err, errNilConfig)
+	_, err = SetupDigestManager(&config.DigestConfig{}, nil, nil)
+	assert.ErrorIs(t, err, errNilOrderManager)
+	_, err = SetupDigestManager(&config.DigestConfig{}, &fakeDigestOrderManager{}, nil)
+	assert.ErrorIs(t, err, errNilCommunicationsManager)
+	_, err = SetupDigestManager(&config.DigestConfig{}, &fakeDigestOrderManager{}, &fakeComms{})
+	assert.ErrorIs(t, err, errInvalidDigestTopN)
+	_, err = SetupDigestManager(&config.DigestConfig{TopN: 1}, &fakeDigestOrderManager{}, &fakeComms{})
+	assert.ErrorIs(t, err, errNoDigestReports)
+
+	cfg := testDigestConfig()
+	cfg.Reports[0].Schedule = "bad"
+	_, err = SetupDigestManager(cfg, &fakeDigestOrderManager{}, &fakeComms{})
+	assert.Error(t, err, "invalid schedule should error")
+
+	m, err := SetupDigestManager(testDigestConfig(), &fakeDigestOrderManager{}, &fakeComms{})
+	require.NoError(t, err)
+	require.Len(t, m.reports, 1)
+}
+
+func TestDigestManagerStartStop(t *testing.T) {
+	t.Parallel()
+	var m *DigestManager
+	assert.ErrorIs(t, m.Start(), ErrNilSubsystem)
+	assert.ErrorIs(t, m.Stop(), ErrNilSubsystem)
+	assert.False(t, m.IsRunning())
+
+	m, err := SetupDigestManager(testDigestConfig(), &fakeDigestOrderManager{}, &fakeComms{})
+	require.NoError(t, err)
+	assert.ErrorIs(t, m.Stop(), ErrSubSystemNotStarted)
+	require.NoError(t, m.Start())
+	assert.True(t, m.IsRunning())
+	assert.ErrorIs(t, m.Start(), ErrSubSystemAlreadyStarted)
+	assert.False(t, m.reports[0].nextRun.IsZero(), "Start should schedule the next run")
+	require.NoError(t, m.Stop())
+	assert.False(t, m.IsRunning())
+}
+
+func TestDigestCompose(t *testing.T) {
+	t.Parallel()
+	end := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	start := end.Add(-time.Hour * 24)
+	btc := currency.NewPair(currency.BTC, currency.USDT)
+	eth := currency.NewPair(currency.ETH, currency.USDT)
+	om := &fakeDigestOrderManager{
+		orders: []order.Detail{
+			// opening buy before the window establishes cost basis
+			{Exchange: "binance", AssetType: asset.Spot, Pair: btc, Side: order.Buy, Status: order.Filled, Amount: 2, ExecutedAmount: 2, Price: 100, LastUpdated: start.Add(-time.Hour)},
+			{Exchange: "binance", AssetType: asset.Spot, Pair: btc, Side: order.Sell, Status: order.Filled, Amount: 1, ExecutedAmount: 1, AverageExecutedPrice: 150, Fee: 0.5, FeeAsset: currency.USDT, LastUpdated: start.Add(time.Hour)},
+			{Exchange: "binance", AssetType: asset.Spot, Pair: eth, Side: order.Buy, Status: order.Filled, Amount: 1, ExecutedAmount: 1, Price: 10, Fee: 0.1, LastUpdated: start.Add(time.Hour * 2)},
+			{Exchange: "binance", AssetType: asset.Spot, Pair: eth, Side: order.Sell, Status: order.Filled, Amount: 1, ExecutedAmount: 1, Price: 8, LastUpdated: start.Add(time.Hour * 3)},
+			{Exchange: "binance", AssetType: asset.Spot, Pair: eth, Side: order.Buy, Status: order.Active, Amount: 3, Price: 5, LastUpdated: start.Add(time.Hour * 4)},
+			// outside of the window
+			{Exchange: "binance", AssetType: asset.Spot, Pair: btc, Side: order.Sell, Status: order.Filled, Amount: 1, ExecutedAmount: 1, Price: 200, LastUpdated: end.Add(time.Hour)},
+		},
+		positions: []futures.Position{
+			{Exchange: "binance", Asset: asset.USDTMarginedFutures, Pair: btc, LatestDirection: order.Long, LatestSize: decimal.NewFromInt(1), LatestPrice: decimal.NewFromInt(100), UnrealisedPNL: decimal.NewFromInt(-5)},
+		},
+	}
+	m, err := SetupDigestManager(testDigestConfig(), om, &fakeComms{})
+	require.NoError(t, err)
+	m.getEvents = func(s, e time.Time, limit int) ([]DigestEvent, error) {
+		assert.Equal(t, start, s)
+		assert.Equal(t, end, e)
+		assert.Equal(t, 5, limit)
+		return []DigestEvent{{Time: start.Add(time.Minute), Type: "order", Identifier: "binance", Message: "order placed"}}, nil
+	}
+
+	_, err = m.Compose("Daily", end, start)
+	assert.ErrorIs(t, err, common.ErrStartAfterEnd)
+
+	d, err := m.Compose("Daily", start, end)
+	require.NoError(t, err)
+	assert.Equal(t, 3, d.OrdersFilled)
+	assert.True(t, decimal.NewFromInt(48).Equal(d.RealisedPNL[currency.USDT]), "realised P&L should be 50 profit less 2 loss")
+	assert.True(t, decimal.NewFromFloat(0.6).Equal(d.Fees[currency.USDT]), "fees should default to the quote currency")
+	require.Len(t, d.TopWinners, 1)
+	assert.Equal(t, btc, d.TopWinners[0].Pair)
+	require.Len(t, d.TopLosers, 1)
+	assert.Equal(t, eth, d.TopLosers[0].Pair)
+	assert.Equal(t, 1, d.OpenRisk.ActiveOrders)
+	assert.True(t, decimal.NewFromInt(15).Equal(d.OpenRisk.ActiveOrderValue[currency.USDT]))
+	require.Len(t, d.OpenRisk.OpenPositions, 1)
+	assert.True(t, decimal.NewFromInt(-5).Equal(d.OpenRisk.UnrealisedPNL))
+	require.Len(t, d.Events, 1)
+
+	out := d.String()
+	assert.Contains(t, out, "Daily digest")
+	assert.Contains(t, out, "Realised P&L: 48 USDT")
+	assert.Contains(t, out, "order placed")
+
+	om.err = errors.New("futures tracking disabled")
+	d, err = m.Compose("Daily", start, end)
+	require.NoError(t, err)
+	assert.Error(t, d.OpenRisk.PositionsFetchErr)
+}
+
+func TestDigestRunDue(t *testing.T) {
+	t.Parallel()
+	comms := &fakeComms{}
+	m, err := SetupDigestManager(testDigestConfig(), &fakeDigestOrderManager{}, comms)
+	require.NoError(t, err)
+	m.getEvents = nil
+	now := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+	m.reports[0].nextRun = now.Add(time.Minute)
+	m.runDue(now)
+	assert.Empty(t, comms.events, "report should not run before it is due")
+
+	m.reports[0].nextRun = now
+	m.runDue(now)
+	require.Len(t, comms.events, 1)
+	assert.Equal(t, digestEventType, comms.events[0].Type)
+	assert.Equal(t, now.Add(time.Hour*24), m.reports[0].nextRun, "next run should be rescheduled")
+	assert.Equal(t, now.Add(time.Hour*24), m.nextRun())
+}
+
+func TestPNLTracker(t *testing.T) {
+	t.Parallel()
+	p := &pnlTracker{}
+	assert.True(t, p.apply(order.UnknownSide, decimal.NewFromInt(1), decimal.NewFromInt(1)).IsZero())
+	assert.True(t, p.apply(order.Sell, decimal.NewFromInt(2), decimal.NewFromInt(100)).IsZero(), "opening a short should not realise")
+	r := p.apply(order.Buy, decimal.NewFromInt(3), decimal.NewFromInt(90))
+	assert.True(t, decimal.NewFromInt(20).Equal(r), "closing a short lower should profit")
+	assert.True(t, decimal.NewFromInt(1).Equal(p.size), "position should flip long")
+	assert.True(t, decimal.NewFromInt(90).Equal(p.avgPrice), "flipped position should open at the fill price")
+}
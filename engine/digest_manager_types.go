@@ -0,0 +1,117 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/common/cron"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// DigestManagerName is an exported subsystem name
+const DigestManagerName = "digest_manager"
+
+// digestEventType is the communications event type used when sending digests
+const digestEventType = "digest"
+
+var (
+	errNilOrderManager   = errors.New("cannot start with nil order manager")
+	errNoDigestReports   = errors.New("no digest reports configured")
+	errInvalidDigestTopN = errors.New("digest top N must be greater than zero")
+)
+
+// iDigestOrderManager defines the order manager functions used to compose a
+// digest
+type iDigestOrderManager interface {
+	GetOrdersSnapshot(order.Status) []order.Detail
+	GetAllOpenFuturesPositions() ([]futures.Position, error)
+}
+
+// DigestManager periodically composes performance digests and sends them via
+// the communications manager
+type DigestManager struct {
+	started      int32
+	shutdown     chan struct{}
+	wg           sync.WaitGroup
+	verbose      bool
+	topN         int
+	maxEvents    int
+	reports      []*digestReport
+	orderManager iDigestOrderManager
+	comms        iCommsManager
+	// getEvents fetches notable events for the digest window. Defaults to
+	// reading audit events from the database
+	getEvents func(start, end time.Time, limit int) ([]DigestEvent, error)
+}
+
+// digestReport holds a parsed scheduled digest
+type digestReport struct {
+	cfg      config.DigestReport
+	schedule *cron.Schedule
+	nextRun  time.Time
+}
+
+// Digest holds a performance summary for a period of time
+type Digest struct {
+	Name         string
+	Start        time.Time
+	End          time.Time
+	OrdersFilled int
+	RealisedPNL  map[currency.Code]decimal.Decimal
+	Fees         map[currency.Code]decimal.Decimal
+	TopWinners   []DigestPairPNL
+	TopLosers    []DigestPairPNL
+	OpenRisk     DigestOpenRisk
+	Events       []DigestEvent
+}
+
+// DigestPairPNL holds the realised profit or loss for a single pair
+type DigestPairPNL struct {
+	Exchange string
+	Asset    asset.Item
+	Pair     currency.Pair
+	Quote    currency.Code
+	PNL      decimal.Decimal
+}
+
+// DigestOpenRisk summarises exposure which remains open at the end of a digest
+// period
+type DigestOpenRisk struct {
+	ActiveOrders      int
+	ActiveOrderValue  map[currency.Code]decimal.Decimal
+	OpenPositions     []DigestPosition
+	UnrealisedPNL     decimal.Decimal
+	PositionsFetchErr error
+}
+
+// DigestPosition holds a summary of an open futures position
+type DigestPosition struct {
+	Exchange      string
+	Asset         asset.Item
+	Pair          currency.Pair
+	Side          order.Side
+	Size          decimal.Decimal
+	Price         decimal.Decimal
+	UnrealisedPNL decimal.Decimal
+}
+
+// DigestEvent holds a notable event which occurred during the digest period
+type DigestEvent struct {
+	Time       time.Time
+	Type       string
+	Identifier string
+	Message    string
+}
+
+// pnlTracker tracks an average cost position so realised profit and loss can
+// be calculated from a sequence of fills
+type pnlTracker struct {
+	size     decimal.Decimal
+	avgPrice decimal.Decimal
+}
@@ -0,0 +1,306 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/file"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/earn"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// SetupEarnManager creates an earn manager subsystem, loading any previously
+// recorded rewards. The portfolio is optional, when set earn balances are
+// reported to it after each sync. When the config does not specify a ledger
+// file it is stored in the data directory
+func SetupEarnManager(cfg *config.EarnManager, dataDir string, em iExchangeManager, p iEarnPortfolio) (*EarnManager, error) {
+	if cfg == nil {
+		return nil, errNilConfig
+	}
+	if em == nil {
+		return nil, errNilExchangeManager
+	}
+	if cfg.SyncInterval <= 0 {
+		return nil, fmt.Errorf("%w sync interval %v", errInvalidEarnSyncDuration, cfg.SyncInterval)
+	}
+	if cfg.Lookback <= 0 {
+		return nil, fmt.Errorf("%w lookback %v", errInvalidEarnSyncDuration, cfg.Lookback)
+	}
+	m := &EarnManager{
+		verbose:         cfg.Verbose,
+		interval:        cfg.SyncInterval,
+		lookback:        cfg.Lookback,
+		ledgerFile:      cfg.LedgerFile,
+		exchangeManager: em,
+		portfolio:       p,
+		positions:       make(map[string][]earn.Position),
+		recorded:        make(map[string]struct{}),
+	}
+	if m.ledgerFile == "" {
+		m.ledgerFile = filepath.Join(dataDir, earnLedgerFile)
+	}
+	if err := m.loadLedger(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Start runs the subsystem
+func (m *EarnManager) Start() error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 0, 1) {
+		return ErrSubSystemAlreadyStarted
+	}
+	m.shutdown = make(chan struct{})
+	m.wg.Add(1)
+	go m.run()
+	log.Debugf(log.PortfolioMgr, "Earn manager %s", MsgSubSystemStarted)
+	return nil
+}
+
+// IsRunning checks whether the subsystem is running
+func (m *EarnManager) IsRunning() bool {
+	if m == nil {
+		return false
+	}
+	return atomic.LoadInt32(&m.started) == 1
+}
+
+// Stop stops the subsystem
+func (m *EarnManager) Stop() error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 1, 0) {
+		return ErrSubSystemNotStarted
+	}
+	close(m.shutdown)
+	m.wg.Wait()
+	log.Debugf(log.PortfolioMgr, "Earn manager %s", MsgSubSystemShutdown)
+	return nil
+}
+
+func (m *EarnManager) run() {
+	defer m.wg.Done()
+	runScheduledJobs(m.shutdown, &scheduledJob{interval: m.interval, immediate: true, run: func(ctx context.Context, _ time.Time) {
+		if err := m.SyncAll(ctx); err != nil {
+			log.Errorf(log.PortfolioMgr, "Earn manager: %v", err)
+		}
+	}})
+}
+
+// SyncAll immediately syncs the earn positions and rewards of every enabled
+// exchange with authenticated API support
+func (m *EarnManager) SyncAll(ctx context.Context) error {
+	if m == nil {
+		return fmt.Errorf("%s %w", EarnManagerName, ErrNilSubsystem)
+	}
+	exchanges, err := m.exchangeManager.GetExchanges()
+	if err != nil {
+		return fmt.Errorf("cannot get exchanges: %w", err)
+	}
+	var errs error
+	for x := range exchanges {
+		if !exchanges[x].IsEnabled() || !exchanges[x].IsRESTAuthenticationSupported() {
+			continue
+		}
+		if err := m.Sync(ctx, exchanges[x]); err != nil {
+			errs = common.AppendError(errs, fmt.Errorf("unable to sync %s earn products: %w", exchanges[x].GetName(), err))
+		}
+	}
+	return errs
+}
+
+// Sync syncs the earn positions and rewards of an exchange. Exchanges which
+// do not support earn products are skipped
+func (m *EarnManager) Sync(ctx context.Context, exch exchange.IBotExchange) error {
+	if m == nil {
+		return fmt.Errorf("%s %w", EarnManagerName, ErrNilSubsystem)
+	}
+	if exch == nil {
+		return ErrExchangeNotFound
+	}
+	positions, err := exch.GetEarnPositions(ctx)
+	if err != nil {
+		if isEarnUnsupported(err) {
+			if m.verbose {
+				log.Debugf(log.PortfolioMgr, "Earn manager %s earn products unsupported", exch.GetName())
+			}
+			return nil
+		}
+		return err
+	}
+	m.setPositions(exch.GetName(), positions)
+
+	end := time.Now()
+	start := end.Add(-m.lookback)
+	if latest := m.latestReward(exch.GetName()); !latest.IsZero() {
+		if from := latest.Add(-earnRewardOverlap); from.After(start) {
+			start = from
+		}
+	}
+	rewards, err := exch.GetEarnRewards(ctx, start, end)
+	if err != nil {
+		if isEarnUnsupported(err) {
+			return nil
+		}
+		return err
+	}
+	recorded, err := m.record(rewards)
+	if err != nil {
+		return err
+	}
+	if m.verbose || recorded > 0 {
+		log.Infof(log.PortfolioMgr, "Earn manager recorded %d %s rewards", recorded, exch.GetName())
+	}
+	return nil
+}
+
+// isEarnUnsupported returns whether the error is returned by exchanges which do
+// not support a wrapper function
+func isEarnUnsupported(err error) bool {
+	return errors.Is(err, common.ErrFunctionNotSupported) || errors.Is(err, common.ErrNotYetImplemented)
+}
+
+// setPositions stores the positions of an exchange and reports their balances
+// to the portfolio
+func (m *EarnManager) setPositions(exchName string, positions []earn.Position) {
+	balances := make(map[currency.Code]float64)
+	for i := range positions {
+		balances[positions[i].Currency.Upper()] += positions[i].Amount
+	}
+	m.m.Lock()
+	m.positions[exchName] = positions
+	m.m.Unlock()
+	if m.portfolio == nil {
+		return
+	}
+	if err := m.portfolio.SetEarnBalances(exchName, balances); err != nil {
+		log.Errorf(log.PortfolioMgr, "Earn manager unable to update %s portfolio balances: %v", exchName, err)
+	}
+}
+
+// latestReward returns the time of the most recent recorded reward of an
+// exchange
+func (m *EarnManager) latestReward(exchName string) time.Time {
+	m.m.RLock()
+	defer m.m.RUnlock()
+	var latest time.Time
+	for i := range m.ledger {
+		if strings.EqualFold(m.ledger[i].Exchange, exchName) && m.ledger[i].Time.After(latest) {
+			latest = m.ledger[i].Time
+		}
+	}
+	return latest
+}
+
+// record adds rewards which have not already been recorded to the ledger and
+// saves it. Returns the number of rewards recorded
+func (m *EarnManager) record(rewards []earn.Reward) (int, error) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	var recorded int
+	for i := range rewards {
+		k := rewardKey(&rewards[i])
+		if _, ok := m.recorded[k]; ok {
+			continue
+		}
+		m.recorded[k] = struct{}{}
+		m.ledger = append(m.ledger, rewards[i])
+		recorded++
+	}
+	if recorded == 0 {
+		return 0, nil
+	}
+	sort.SliceStable(m.ledger, func(i, j int) bool {
+		return m.ledger[i].Time.Before(m.ledger[j].Time)
+	})
+	return recorded, m.saveLedger()
+}
+
+// rewardKey returns a key which uniquely identifies a reward
+func rewardKey(r *earn.Reward) string {
+	return strings.ToLower(r.Exchange) + "|" + r.ProductID + "|" + r.Currency.Upper().String() + "|" +
+		strconv.FormatInt(r.Time.UnixNano(), 10) + "|" + strconv.FormatFloat(r.Amount, 'f', -1, 64)
+}
+
+// loadLedger reads previously recorded rewards from the ledger file
+func (m *EarnManager) loadLedger() error {
+	data, err := os.ReadFile(m.ledgerFile)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return nil
+	case err != nil:
+		return fmt.Errorf("unable to read earn ledger: %w", err)
+	}
+	if err := json.Unmarshal(data, &m.ledger); err != nil {
+		return fmt.Errorf("unable to parse earn ledger %s: %w", m.ledgerFile, err)
+	}
+	for i := range m.ledger {
+		m.recorded[rewardKey(&m.ledger[i])] = struct{}{}
+	}
+	return nil
+}
+
+// saveLedger writes the recorded rewards to the ledger file
+func (m *EarnManager) saveLedger() error {
+	data, err := json.MarshalIndent(m.ledger, "", " ")
+	if err != nil {
+		return err
+	}
+	return file.Write(m.ledgerFile, data)
+}
+
+// GetPositions returns the earn positions of an exchange as of the last sync
+func (m *EarnManager) GetPositions(exchName string) ([]earn.Position, error) {
+	if m == nil {
+		return nil, fmt.Errorf("%s %w", EarnManagerName, ErrNilSubsystem)
+	}
+	m.m.RLock()
+	defer m.m.RUnlock()
+	for k, positions := range m.positions {
+		if strings.EqualFold(k, exchName) {
+			return append([]earn.Position(nil), positions...), nil
+		}
+	}
+	return nil, fmt.Errorf("%s %w", exchName, errEarnPositionsNotSynced)
+}
+
+// GetRewards returns the recorded rewards between the start and end times,
+// filtered by exchange when set
+func (m *EarnManager) GetRewards(exchName string, start, end time.Time) ([]earn.Reward, error) {
+	if m == nil {
+		return nil, fmt.Errorf("%s %w", EarnManagerName, ErrNilSubsystem)
+	}
+	if err := common.StartEndTimeCheck(start, end); err != nil {
+		return nil, err
+	}
+	m.m.RLock()
+	defer m.m.RUnlock()
+	var rewards []earn.Reward
+	for i := range m.ledger {
+		if exchName != "" && !strings.EqualFold(m.ledger[i].Exchange, exchName) {
+			continue
+		}
+		if m.ledger[i].Time.Before(start) || m.ledger[i].Time.After(end) {
+			continue
+		}
+		rewards = append(rewards, m.ledger[i])
+	}
+	return rewards, nil
+}
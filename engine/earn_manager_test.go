@@ -0,0 +1,179 @@
+package engine
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/earn"
+)
+
+// earnExchange is a fake exchange with earn positions and rewards
+type earnExchange struct {
+	exchange.IBotExchange
+	name      string
+	positions []earn.Position
+	rewards   []earn.Reward
+	err       error
+}
+
+func (f *earnExchange) GetName() string {
+	return f.name
+}
+
+func (f *earnExchange) IsEnabled() bool {
+	return true
+}
+
+func (f *earnExchange) IsRESTAuthenticationSupported() bool {
+	return true
+}
+
+func (f *earnExchange) GetEarnPositions(context.Context) ([]earn.Position, error) {
+	return f.positions, f.err
+}
+
+func (f *earnExchange) GetEarnRewards(_ context.Context, start, end time.Time) ([]earn.Reward, error) {
+	var rewards []earn.Reward
+	for i := range f.rewards {
+		if !f.rewards[i].Time.Before(start) && !f.rewards[i].Time.After(end) {
+			rewards = append(rewards, f.rewards[i])
+		}
+	}
+	return rewards, f.err
+}
+
+// earnPortfolio is a fake portfolio which stores reported earn balances
+type earnPortfolio struct {
+	balances map[string]map[currency.Code]float64
+}
+
+func (f *earnPortfolio) SetEarnBalances(exchangeName string, balances map[currency.Code]float64) error {
+	if f.balances == nil {
+		f.balances = make(map[string]map[currency.Code]float64)
+	}
+	f.balances[exchangeName] = balances
+	return nil
+}
+
+func newEarnConfig(t *testing.T) *config.EarnManager {
+	t.Helper()
+	return &config.EarnManager{
+		SyncInterval: time.Hour,
+		Lookback:     time.Hour * 24 * 30,
+		LedgerFile:   filepath.Join(t.TempDir(), earnLedgerFile),
+	}
+}
+
+func TestSetupEarnManager(t *testing.T) {
+	t.Parallel()
+	_, err := SetupEarnManager(nil, "", NewExchangeManager(), nil)
+	assert.ErrorIs(t, err, errNilConfig)
+	_, err = SetupEarnManager(&config.EarnManager{}, "", nil, nil)
+	assert.ErrorIs(t, err, errNilExchangeManager)
+	_, err = SetupEarnManager(&config.EarnManager{}, "", NewExchangeManager(), nil)
+	assert.ErrorIs(t, err, errInvalidEarnSyncDuration)
+	_, err = SetupEarnManager(&config.EarnManager{SyncInterval: time.Minute}, "", NewExchangeManager(), nil)
+	assert.ErrorIs(t, err, errInvalidEarnSyncDuration)
+
+	dir := t.TempDir()
+	m, err := SetupEarnManager(&config.EarnManager{SyncInterval: time.Minute, Lookback: time.Hour}, dir, NewExchangeManager(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, earnLedgerFile), m.ledgerFile, "ledger file should default to the data directory")
+}
+
+func TestEarnManagerStartStop(t *testing.T) {
+	t.Parallel()
+	var m *EarnManager
+	assert.ErrorIs(t, m.Start(), ErrNilSubsystem)
+	assert.ErrorIs(t, m.Stop(), ErrNilSubsystem)
+	assert.False(t, m.IsRunning())
+
+	m, err := SetupEarnManager(newEarnConfig(t), "", NewExchangeManager(), nil)
+	require.NoError(t, err)
+	assert.ErrorIs(t, m.Stop(), ErrSubSystemNotStarted)
+	require.NoError(t, m.Start())
+	assert.True(t, m.IsRunning())
+	assert.ErrorIs(t, m.Start(), ErrSubSystemAlreadyStarted)
+	require.NoError(t, m.Stop())
+	assert.False(t, m.IsRunning())
+}
+
+func TestEarnManagerSync(t *testing.T) {
+	t.Parallel()
+	now := time.Now().Truncate(time.Second)
+	exch := &earnExchange{
+		name: "fake",
+		positions: []earn.Position{
+			{Exchange: "fake", ProductID: "1", Currency: currency.USDT, Amount: 100},
+			{Exchange: "fake", ProductID: "2", Currency: currency.USDT, Amount: 50},
+			{Exchange: "fake", ProductID: "3", Currency: currency.ETH, Amount: 2},
+		},
+		rewards: []earn.Reward{
+			{Exchange: "fake", ProductID: "1", Currency: currency.USDT, Amount: 0.1, Time: now.Add(-time.Hour)},
+			{Exchange: "fake", ProductID: "3", Currency: currency.ETH, Amount: 0.001, Time: now.Add(-time.Hour * 2)},
+		},
+	}
+	em := NewExchangeManager()
+	require.NoError(t, em.Add(exch))
+	p := &earnPortfolio{}
+	cfg := newEarnConfig(t)
+	m, err := SetupEarnManager(cfg, "", em, p)
+	require.NoError(t, err)
+
+	var nilManager *EarnManager
+	assert.ErrorIs(t, nilManager.Sync(context.Background(), exch), ErrNilSubsystem)
+	assert.ErrorIs(t, m.Sync(context.Background(), nil), ErrExchangeNotFound)
+
+	require.NoError(t, m.SyncAll(context.Background()))
+	positions, err := m.GetPositions("FAKE")
+	require.NoError(t, err)
+	assert.Len(t, positions, 3)
+	_, err = m.GetPositions("other")
+	assert.ErrorIs(t, err, errEarnPositionsNotSynced)
+	assert.Equal(t, map[currency.Code]float64{currency.USDT: 150, currency.ETH: 2}, p.balances["fake"], "balances should be summed by currency")
+
+	rewards, err := m.GetRewards("", now.Add(-time.Hour*24), now)
+	require.NoError(t, err)
+	require.Len(t, rewards, 2)
+	assert.True(t, rewards[0].Time.Before(rewards[1].Time), "rewards should be sorted by time")
+
+	require.NoError(t, m.Sync(context.Background(), exch))
+	rewards, err = m.GetRewards("fake", now.Add(-time.Hour*24), now)
+	require.NoError(t, err)
+	assert.Len(t, rewards, 2, "resyncing should not record rewards twice")
+
+	rewards, err = m.GetRewards("other", now.Add(-time.Hour*24), now)
+	require.NoError(t, err)
+	assert.Empty(t, rewards)
+	_, err = m.GetRewards("", now, now.Add(-time.Hour))
+	assert.ErrorIs(t, err, common.ErrStartAfterEnd)
+
+	m, err = SetupEarnManager(cfg, "", em, nil)
+	require.NoError(t, err)
+	rewards, err = m.GetRewards("fake", now.Add(-time.Hour*24), now)
+	require.NoError(t, err)
+	assert.Len(t, rewards, 2, "recorded rewards should be loaded from the ledger file")
+	exch.rewards = append(exch.rewards, earn.Reward{Exchange: "fake", ProductID: "1", Currency: currency.USDT, Amount: 0.1, Time: now.Add(-time.Minute)})
+	require.NoError(t, m.Sync(context.Background(), exch))
+	rewards, err = m.GetRewards("fake", now.Add(-time.Hour*24), now)
+	require.NoError(t, err)
+	assert.Len(t, rewards, 3, "only new rewards should be recorded after loading the ledger")
+}
+
+func TestEarnManagerSyncUnsupported(t *testing.T) {
+	t.Parallel()
+	m, err := SetupEarnManager(newEarnConfig(t), "", NewExchangeManager(), nil)
+	require.NoError(t, err)
+	assert.NoError(t, m.Sync(context.Background(), &earnExchange{name: "fake", err: common.ErrFunctionNotSupported}), "unsupported exchanges should be skipped")
+	_, err = m.GetPositions("fake")
+	assert.ErrorIs(t, err, errEarnPositionsNotSynced)
+	assert.ErrorIs(t, m.Sync(context.Background(), &earnExchange{name: "fake", err: errExpectedTestError}), errExpectedTestError)
+}
@@ -0,0 +1,52 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/earn"
+)
+
+// EarnManagerName is an exported subsystem name
+const EarnManagerName = "earn_manager"
+
+const (
+	// earnLedgerFile is the default file the reward ledger is stored in
+	earnLedgerFile = "earnrewards.json"
+	// earnRewardOverlap is subtracted from the most recent recorded reward
+	// time so rewards reported late by an exchange are not missed.
+	// Overlapping rewards are deduplicated
+	earnRewardOverlap = time.Hour * 24
+)
+
+var (
+	errInvalidEarnSyncDuration = errors.New("earn sync duration must be greater than zero")
+	errEarnPositionsNotSynced  = errors.New("earn positions have not been synced")
+)
+
+// iEarnPortfolio defines the portfolio manager function used to report earn
+// balances
+type iEarnPortfolio interface {
+	SetEarnBalances(exchangeName string, balances map[currency.Code]float64) error
+}
+
+// EarnManager periodically syncs the positions held in exchange earn and
+// staking products, reporting their balances to the portfolio and recording
+// paid rewards in a ledger which is kept between restarts
+type EarnManager struct {
+	started         int32
+	shutdown        chan struct{}
+	wg              sync.WaitGroup
+	verbose         bool
+	interval        time.Duration
+	lookback        time.Duration
+	ledgerFile      string
+	exchangeManager iExchangeManager
+	portfolio       iEarnPortfolio
+	m               sync.RWMutex
+	positions       map[string][]earn.Position
+	ledger          []earn.Reward
+	recorded        map[string]struct{}
+}
@@ -21,7 +21,9 @@ import (
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/alert"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/latency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
 	gctscript "github.com/thrasher-corp/gocryptotrader/gctscript/vm"
 	gctlog "github.com/thrasher-corp/gocryptotrader/log"
@@ -36,6 +38,7 @@ type Engine struct {
 	apiServer               *apiServerManager
 	CommunicationsManager   *CommunicationManager
 	connectionManager       *connectionManager
+	circuitBreaker          *circuitBreakerNotifier
 	currencyPairSyncer      *SyncManager
 	DatabaseManager         *DatabaseConnectionManager
 	DepositAddressManager   *DepositAddressManager
@@ -49,6 +52,26 @@ type Engine struct {
 	WithdrawManager         *WithdrawManager
 	dataHistoryManager      *DataHistoryManager
 	currencyStateManager    *CurrencyStateManager
+	digestManager           *DigestManager
+	fillSyncManager         *FillSyncManager
+	pairRefreshManager      *PairRefreshManager
+	earnManager             *EarnManager
+	lendingOptimizer        *LendingOptimizer
+	memoryManager           *MemoryManager
+	scheduler               *Scheduler
+	rolloverManager         *RolloverManager
+	calendarSpreadManager   *CalendarSpreadManager
+	volatilitySurface       *VolatilitySurfaceManager
+	marginMonitor           *MarginMonitor
+	adlMonitor              *ADLMonitor
+	exchangeCalendar        *ExchangeCalendar
+	maintenanceScheduler    *MaintenanceScheduler
+	basisHarvester          *BasisHarvester
+	anomalyDetector         *AnomalyDetector
+	dataQualityMonitor      *DataQualityMonitor
+	webhookListener         *WebhookListener
+	surveillanceManager     *SurveillanceManager
+	feeAccountingManager    *FeeAccountingManager
 	Settings                Settings
 	uptime                  time.Time
 	GRPCShutdownSignal      chan struct{}
@@ -166,6 +189,7 @@ func validateSettings(b *Engine, s *Settings, flagSet FlagSet) {
 
 	flagSet.WithBool("coinmarketcap", &b.Settings.EnableCoinmarketcapAnalysis, b.Config.Currency.CryptocurrencyProvider.Enabled)
 	flagSet.WithBool("ordermanager", &b.Settings.EnableOrderManager, b.Config.OrderManager.Enabled != nil && *b.Config.OrderManager.Enabled)
+	flagSet.WithBool("papertrading", &b.Settings.EnablePaperTrading, b.Config.OrderManager.PaperTrading.Enabled)
 
 	flagSet.WithBool("currencyconverter", &b.Settings.EnableCurrencyConverter, b.Config.Currency.ForexProviders.IsEnabled("currencyconverter"))
 
@@ -176,6 +200,28 @@ func validateSettings(b *Engine, s *Settings, flagSet FlagSet) {
 
 	flagSet.WithBool("datahistorymanager", &b.Settings.EnableDataHistoryManager, b.Config.DataHistoryManager.Enabled)
 	flagSet.WithBool("currencystatemanager", &b.Settings.EnableCurrencyStateManager, b.Config.CurrencyStateManager.Enabled != nil && *b.Config.CurrencyStateManager.Enabled)
+	flagSet.WithBool("digestmanager", &b.Settings.EnableDigestManager, b.Config.Digest.Enabled)
+	flagSet.WithBool("fillsyncmanager", &b.Settings.EnableFillSyncManager, b.Config.FillSyncManager.Enabled)
+	flagSet.WithBool("pairrefreshmanager", &b.Settings.EnablePairRefreshManager, b.Config.PairRefreshManager.Enabled)
+	flagSet.WithBool("earnmanager", &b.Settings.EnableEarnManager, b.Config.EarnManager.Enabled)
+	flagSet.WithBool("lendingoptimizer", &b.Settings.EnableLendingOptimizer, b.Config.LendingOptimizer.Enabled)
+	flagSet.WithBool("memorymanager", &b.Settings.EnableMemoryManager, b.Config.MemoryManager.Enabled)
+	flagSet.WithBool("scheduler", &b.Settings.EnableScheduler, b.Config.Scheduler.Enabled)
+	flagSet.WithBool("rollovermanager", &b.Settings.EnableRolloverManager, b.Config.RolloverManager.Enabled)
+	flagSet.WithBool("calendarspreadmanager", &b.Settings.EnableCalendarSpreadManager, b.Config.CalendarSpread.Enabled)
+	flagSet.WithBool("volatilitysurfacemanager", &b.Settings.EnableVolatilitySurfaceManager, b.Config.VolatilitySurface.Enabled)
+	flagSet.WithBool("marginmonitor", &b.Settings.EnableMarginMonitor, b.Config.MarginMonitor.Enabled)
+	flagSet.WithBool("adlmonitor", &b.Settings.EnableADLMonitor, b.Config.ADLMonitor.Enabled)
+	flagSet.WithBool("exchangecalendar", &b.Settings.EnableExchangeCalendar, b.Config.ExchangeCalendar.Enabled)
+	flagSet.WithBool("maintenancescheduler", &b.Settings.EnableMaintenanceScheduler, b.Config.MaintenanceScheduler.Enabled)
+	flagSet.WithBool("basisharvester", &b.Settings.EnableBasisHarvester, b.Config.BasisHarvester.Enabled)
+	flagSet.WithBool("anomalydetector", &b.Settings.EnableAnomalyDetector, b.Config.AnomalyDetector.Enabled)
+	flagSet.WithBool("dataqualitymonitor", &b.Settings.EnableDataQualityMonitor, b.Config.DataQuality.Enabled)
+	flagSet.WithBool("webhooklistener", &b.Settings.EnableWebhookListener, b.Config.WebhookListener.Enabled)
+	flagSet.WithBool("surveillancemanager", &b.Settings.EnableSurveillanceManager, b.Config.SurveillanceManager.Enabled)
+	flagSet.WithBool("feeaccounting", &b.Settings.EnableFeeAccountingManager, b.Config.FeeAccounting.Enabled)
+	flagSet.WithBool("latencysimulation", &b.Settings.EnableLatencySimulation, b.Config.LatencySimulation.Enabled)
+	flagSet.WithBool("circuitbreaker", &b.Settings.EnableCircuitBreaker, b.Config.CircuitBreaker.Enabled)
 	flagSet.WithBool("gctscriptmanager", &b.Settings.EnableGCTScriptManager, b.Config.GCTScript.Enabled)
 
 	flagSet.WithBool("tickersync", &b.Settings.EnableTickerSyncing, b.Config.SyncManagerConfig.SynchronizeTicker)
@@ -372,6 +418,20 @@ func (bot *Engine) Start() error {
 		bot.Config.PurgeExchangeAPICredentials()
 	}
 
+	if bot.Settings.EnableLatencySimulation {
+		if err := setupLatencySimulation(&bot.Config.LatencySimulation); err != nil {
+			gctlog.Errorf(gctlog.Global, "Latency simulation unable to setup: %s", err)
+		}
+	}
+
+	if bot.Settings.EnableCircuitBreaker {
+		if n, err := setupCircuitBreaker(&bot.Config.CircuitBreaker); err != nil {
+			gctlog.Errorf(gctlog.Global, "Circuit breaker unable to setup: %s", err)
+		} else {
+			bot.circuitBreaker = n
+		}
+	}
+
 	gctlog.Debugln(gctlog.Global, "Setting up exchanges..")
 	if err := bot.SetupExchanges(); err != nil {
 		return err
@@ -385,6 +445,9 @@ func (bot *Engine) Start() error {
 			if err := bot.CommunicationsManager.Start(); err != nil {
 				gctlog.Errorf(gctlog.Global, "Communications manager unable to start: %s", err)
 			}
+			bot.connectionManager.setCommsManager(bot.CommunicationsManager)
+			bot.DatabaseManager.setCommsManager(bot.CommunicationsManager)
+			bot.circuitBreaker.setCommsManager(bot.CommunicationsManager)
 		}
 	}
 
@@ -438,6 +501,11 @@ func (bot *Engine) Start() error {
 	} else { //nolint:revive // TODO: revive false positive, see https://github.com/mgechev/revive/pull/832 for more information
 		bot.WithdrawManager = w
 	}
+	if bot.Config.OfflineWithdrawals.Enabled {
+		if err := bot.WithdrawManager.SetupOfflineWithdrawals(&bot.Config.OfflineWithdrawals); err != nil {
+			return err
+		}
+	}
 
 	if bot.Settings.EnableDeprecatedRPC || bot.Settings.EnableWebsocketRPC {
 		if filePath, err := config.GetAndMigrateDefaultPath(bot.Settings.ConfigFile); err != nil {
@@ -471,6 +539,7 @@ func (bot *Engine) Start() error {
 	}
 
 	if bot.Settings.EnableOrderManager {
+		bot.Config.OrderManager.PaperTrading.Enabled = bot.Settings.EnablePaperTrading
 		if o, err := SetupOrderManager(
 			bot.ExchangeManager,
 			bot.CommunicationsManager,
@@ -478,6 +547,9 @@ func (bot *Engine) Start() error {
 			&bot.Config.OrderManager); err != nil {
 			gctlog.Errorf(gctlog.Global, "Order manager unable to setup: %s", err)
 		} else {
+			if err = o.setTrailingStopStateFile(filepath.Join(bot.Settings.DataDir, trailingStopStateFile)); err != nil {
+				gctlog.Errorf(gctlog.Global, "Order manager unable to restore trailing stops: %s", err)
+			}
 			bot.OrderManager = o
 			if err = bot.OrderManager.Start(); err != nil {
 				gctlog.Errorf(gctlog.Global, "Order manager unable to start: %s", err)
@@ -485,6 +557,201 @@ func (bot *Engine) Start() error {
 		}
 	}
 
+	if bot.Settings.EnableDigestManager {
+		if !bot.OrderManager.IsRunning() || !bot.CommunicationsManager.IsRunning() {
+			gctlog.Errorln(gctlog.Global, "Digest manager requires the order and communications managers to be running")
+		} else if d, err := SetupDigestManager(&bot.Config.Digest, bot.OrderManager, bot.CommunicationsManager); err != nil {
+			gctlog.Errorf(gctlog.Global, "Digest manager unable to setup: %s", err)
+		} else {
+			bot.digestManager = d
+			if err = bot.digestManager.Start(); err != nil {
+				gctlog.Errorf(gctlog.Global, "Digest manager unable to start: %s", err)
+			}
+		}
+	}
+
+	if bot.Settings.EnableSurveillanceManager {
+		if !bot.OrderManager.IsRunning() || !bot.CommunicationsManager.IsRunning() {
+			gctlog.Errorln(gctlog.Global, "Surveillance manager requires the order and communications managers to be running")
+		} else if s, err := SetupSurveillanceManager(&bot.Config.SurveillanceManager, bot.OrderManager, bot.CommunicationsManager); err != nil {
+			gctlog.Errorf(gctlog.Global, "Surveillance manager unable to setup: %s", err)
+		} else {
+			bot.surveillanceManager = s
+			if err = bot.surveillanceManager.Start(); err != nil {
+				gctlog.Errorf(gctlog.Global, "Surveillance manager unable to start: %s", err)
+			}
+		}
+	}
+
+	if bot.Settings.EnableFeeAccountingManager {
+		if !bot.OrderManager.IsRunning() || !bot.CommunicationsManager.IsRunning() {
+			gctlog.Errorln(gctlog.Global, "Fee accounting manager requires the order and communications managers to be running")
+		} else if f, err := SetupFeeAccountingManager(&bot.Config.FeeAccounting, bot.Settings.DataDir, bot.OrderManager, bot.CommunicationsManager); err != nil {
+			gctlog.Errorf(gctlog.Global, "Fee accounting manager unable to setup: %s", err)
+		} else {
+			bot.feeAccountingManager = f
+			if err = bot.feeAccountingManager.Start(); err != nil {
+				gctlog.Errorf(gctlog.Global, "Fee accounting manager unable to start: %s", err)
+			}
+		}
+	}
+
+	if bot.Settings.EnableAnomalyDetector {
+		if !bot.CommunicationsManager.IsRunning() {
+			gctlog.Errorln(gctlog.Global, "Anomaly detector requires the communications manager to be running")
+		} else if a, err := SetupAnomalyDetector(&bot.Config.AnomalyDetector, bot.CommunicationsManager); err != nil {
+			gctlog.Errorf(gctlog.Global, "Anomaly detector unable to setup: %s", err)
+		} else {
+			bot.anomalyDetector = a
+			if err = bot.anomalyDetector.Start(); err != nil {
+				gctlog.Errorf(gctlog.Global, "Anomaly detector unable to start: %s", err)
+			}
+		}
+	}
+
+	if bot.Settings.EnableDataQualityMonitor {
+		if d, err := SetupDataQualityMonitor(&bot.Config.DataQuality, bot.ExchangeManager); err != nil {
+			gctlog.Errorf(gctlog.Global, "Data quality monitor unable to setup: %s", err)
+		} else {
+			bot.dataQualityMonitor = d
+			if err = bot.dataQualityMonitor.Start(); err != nil {
+				gctlog.Errorf(gctlog.Global, "Data quality monitor unable to start: %s", err)
+			}
+		}
+	}
+
+	if bot.Settings.EnableExchangeCalendar {
+		var comms iCommsManager
+		if bot.CommunicationsManager.IsRunning() {
+			comms = bot.CommunicationsManager
+		}
+		if c, err := SetupExchangeCalendar(&bot.Config.ExchangeCalendar, bot.ExchangeManager, comms); err != nil {
+			gctlog.Errorf(gctlog.Global, "Exchange calendar unable to setup: %s", err)
+		} else {
+			bot.exchangeCalendar = c
+			if err = bot.exchangeCalendar.Start(); err != nil {
+				gctlog.Errorf(gctlog.Global, "Exchange calendar unable to start: %s", err)
+			}
+		}
+	}
+
+	if bot.Settings.EnableMaintenanceScheduler {
+		var comms iCommsManager
+		if bot.CommunicationsManager.IsRunning() {
+			comms = bot.CommunicationsManager
+		}
+		if m, err := SetupMaintenanceScheduler(&bot.Config.MaintenanceScheduler, bot.Config.Exchanges, bot.ExchangeManager, comms); err != nil {
+			gctlog.Errorf(gctlog.Global, "Maintenance scheduler unable to setup: %s", err)
+		} else {
+			bot.maintenanceScheduler = m
+			bot.OrderManager.setMaintenanceSchedule(m)
+			bot.CommunicationsManager.setMaintenanceSchedule(m)
+			if err = bot.maintenanceScheduler.Start(); err != nil {
+				gctlog.Errorf(gctlog.Global, "Maintenance scheduler unable to start: %s", err)
+			}
+		}
+	}
+
+	if bot.Settings.EnableRolloverManager {
+		if !bot.OrderManager.IsRunning() || !bot.CommunicationsManager.IsRunning() {
+			gctlog.Errorln(gctlog.Global, "Rollover manager requires the order and communications managers to be running")
+		} else if r, err := SetupRolloverManager(&bot.Config.RolloverManager, bot.ExchangeManager, bot.OrderManager, bot.CommunicationsManager, bot.rolloverCalendar(), bot.marketDataQuarantine()); err != nil {
+			gctlog.Errorf(gctlog.Global, "Rollover manager unable to setup: %s", err)
+		} else {
+			bot.rolloverManager = r
+			if err = bot.rolloverManager.Start(); err != nil {
+				gctlog.Errorf(gctlog.Global, "Rollover manager unable to start: %s", err)
+			}
+		}
+	}
+
+	if bot.Settings.EnableCalendarSpreadManager {
+		if !bot.CommunicationsManager.IsRunning() {
+			gctlog.Errorln(gctlog.Global, "Calendar spread manager requires the communications manager to be running")
+		} else if bot.Config.CalendarSpread.StoreHistory && !bot.DatabaseManager.IsRunning() {
+			gctlog.Errorln(gctlog.Global, "Calendar spread manager requires the database manager to be running to store history")
+		} else {
+			var dcm iDatabaseConnectionManager
+			if bot.Config.CalendarSpread.StoreHistory {
+				dcm = bot.DatabaseManager
+			}
+			if c, err := SetupCalendarSpreadManager(&bot.Config.CalendarSpread, bot.ExchangeManager, bot.CommunicationsManager, dcm); err != nil {
+				gctlog.Errorf(gctlog.Global, "Calendar spread manager unable to setup: %s", err)
+			} else {
+				bot.calendarSpreadManager = c
+				if err = bot.calendarSpreadManager.Start(); err != nil {
+					gctlog.Errorf(gctlog.Global, "Calendar spread manager unable to start: %s", err)
+				}
+			}
+		}
+	}
+
+	if bot.Settings.EnableVolatilitySurfaceManager {
+		if v, err := SetupVolatilitySurfaceManager(&bot.Config.VolatilitySurface, bot.ExchangeManager); err != nil {
+			gctlog.Errorf(gctlog.Global, "Volatility surface manager unable to setup: %s", err)
+		} else {
+			bot.volatilitySurface = v
+			if err = bot.volatilitySurface.Start(); err != nil {
+				gctlog.Errorf(gctlog.Global, "Volatility surface manager unable to start: %s", err)
+			}
+		}
+	}
+
+	if bot.Settings.EnableMarginMonitor {
+		switch {
+		case !bot.CommunicationsManager.IsRunning():
+			gctlog.Errorln(gctlog.Global, "Margin monitor requires the communications manager to be running")
+		case bot.WebsocketRoutineManager == nil:
+			gctlog.Errorln(gctlog.Global, "Margin monitor requires the websocket routine manager to receive account margin updates")
+		case bot.Config.MarginMonitor.Deleverage.Enabled && !bot.OrderManager.IsRunning():
+			gctlog.Errorln(gctlog.Global, "Margin monitor requires the order manager to be running to deleverage positions")
+		default:
+			var om iMarginOrderManager
+			if bot.Config.MarginMonitor.Deleverage.Enabled {
+				om = bot.OrderManager
+			}
+			if mm, err := SetupMarginMonitor(&bot.Config.MarginMonitor, om, bot.CommunicationsManager); err != nil {
+				gctlog.Errorf(gctlog.Global, "Margin monitor unable to setup: %s", err)
+			} else {
+				bot.marginMonitor = mm
+				if err = bot.WebsocketRoutineManager.registerWebsocketDataHandler(bot.marginMonitor.websocketMarginHandler, false); err != nil {
+					gctlog.Errorf(gctlog.Global, "Margin monitor unable to receive websocket margin updates: %s", err)
+				}
+				if err = bot.marginMonitor.Start(); err != nil {
+					gctlog.Errorf(gctlog.Global, "Margin monitor unable to start: %s", err)
+				}
+			}
+		}
+	}
+
+	if bot.Settings.EnableBasisHarvester {
+		if !bot.OrderManager.IsRunning() || !bot.CommunicationsManager.IsRunning() {
+			gctlog.Errorln(gctlog.Global, "Basis harvester requires the order and communications managers to be running")
+		} else if h, err := SetupBasisHarvester(&bot.Config.BasisHarvester, bot.ExchangeManager, bot.OrderManager, bot.CommunicationsManager, bot.basisMarginMonitor(), bot.marketDataQuarantine()); err != nil {
+			gctlog.Errorf(gctlog.Global, "Basis harvester unable to setup: %s", err)
+		} else {
+			bot.basisHarvester = h
+			if err = bot.basisHarvester.Start(); err != nil {
+				gctlog.Errorf(gctlog.Global, "Basis harvester unable to start: %s", err)
+			}
+		}
+	}
+
+	if bot.Settings.EnableADLMonitor {
+		if !bot.CommunicationsManager.IsRunning() {
+			gctlog.Errorln(gctlog.Global, "ADL monitor requires the communications manager to be running")
+		} else {
+			if a, err := SetupADLMonitor(&bot.Config.ADLMonitor, bot.ExchangeManager, bot.CommunicationsManager); err != nil {
+				gctlog.Errorf(gctlog.Global, "ADL monitor unable to setup: %s", err)
+			} else {
+				bot.adlMonitor = a
+				if err = bot.adlMonitor.Start(); err != nil {
+					gctlog.Errorf(gctlog.Global, "ADL monitor unable to start: %s", err)
+				}
+			}
+		}
+	}
+
 	if bot.Settings.EnableExchangeSyncManager {
 		cfg := bot.Config.SyncManagerConfig
 		cfg.SynchronizeTicker = bot.Settings.EnableTickerSyncing
@@ -541,6 +808,47 @@ func (bot *Engine) Start() error {
 			if err = bot.WebsocketRoutineManager.Start(); err != nil {
 				gctlog.Errorf(gctlog.Global, "failed to start websocket routine manager. Err: %s", err)
 			}
+			if bot.anomalyDetector != nil {
+				if err = bot.WebsocketRoutineManager.registerWebsocketDataHandler(bot.anomalyDetector.websocketAnomalyHandler, false); err != nil {
+					gctlog.Errorf(gctlog.Global, "Anomaly detector unable to inspect websocket market data: %s", err)
+				}
+			}
+			if bot.dataQualityMonitor != nil {
+				if err = bot.WebsocketRoutineManager.registerWebsocketDataHandler(bot.dataQualityMonitor.websocketDataQualityHandler, false); err != nil {
+					gctlog.Errorf(gctlog.Global, "Data quality monitor unable to inspect websocket market data: %s", err)
+				}
+			}
+		}
+	}
+
+	if bot.Settings.EnableFillSyncManager {
+		if !bot.DatabaseManager.IsRunning() {
+			gctlog.Errorln(gctlog.Global, "Fill sync manager requires the database manager to be running")
+		} else if f, err := SetupFillSyncManager(bot.ExchangeManager, bot.DatabaseManager, &bot.Config.FillSyncManager); err != nil {
+			gctlog.Errorf(gctlog.Global, "Fill sync manager unable to setup: %s", err)
+		} else {
+			bot.fillSyncManager = f
+			if bot.WebsocketRoutineManager != nil {
+				if err = bot.WebsocketRoutineManager.registerWebsocketDataHandler(bot.fillSyncManager.websocketFillHandler, false); err != nil {
+					gctlog.Errorf(gctlog.Global, "Fill sync manager unable to capture websocket fills: %s", err)
+				}
+			}
+			if err = bot.fillSyncManager.Start(); err != nil {
+				gctlog.Errorf(gctlog.Global, "Fill sync manager unable to start: %s", err)
+			}
+		}
+	}
+
+	if bot.Settings.EnableWebhookListener {
+		if bot.WebsocketRoutineManager == nil {
+			gctlog.Errorln(gctlog.Global, "Webhook listener requires the websocket routine manager to route account events")
+		} else if l, err := SetupWebhookListener(&bot.Config.WebhookListener, bot.WebsocketRoutineManager); err != nil {
+			gctlog.Errorf(gctlog.Global, "Webhook listener unable to setup: %s", err)
+		} else {
+			bot.webhookListener = l
+			if err = bot.webhookListener.Start(); err != nil {
+				gctlog.Errorf(gctlog.Global, "Webhook listener unable to start: %s", err)
+			}
 		}
 	}
 
@@ -559,6 +867,7 @@ func (bot *Engine) Start() error {
 		if c, err := SetupCurrencyStateManager(
 			bot.Config.CurrencyStateManager.Delay,
 			bot.ExchangeManager,
+			bot.CommunicationsManager,
 		); err != nil {
 			gctlog.Errorf(gctlog.Global,
 				"%s unable to setup: %s",
@@ -566,6 +875,11 @@ func (bot *Engine) Start() error {
 				err)
 		} else {
 			bot.currencyStateManager = c
+			if bot.WithdrawManager != nil {
+				if err := bot.currencyStateManager.RegisterStateChangeHandler(bot.WithdrawManager.onCurrencyStateChange); err != nil {
+					gctlog.Errorf(gctlog.Global, "Withdraw manager unable to track currency states: %s", err)
+				}
+			}
 			if err := bot.currencyStateManager.Start(); err != nil {
 				gctlog.Errorf(gctlog.Global,
 					"%s unable to start: %s",
@@ -575,6 +889,67 @@ func (bot *Engine) Start() error {
 		}
 	}
 
+	if bot.Settings.EnablePairRefreshManager {
+		if p, err := SetupPairRefreshManager(bot.ExchangeManager, &bot.Config.PairRefreshManager); err != nil {
+			gctlog.Errorf(gctlog.Global, "Pair refresh manager unable to setup: %s", err)
+		} else {
+			bot.pairRefreshManager = p
+			if err := bot.pairRefreshManager.Start(); err != nil {
+				gctlog.Errorf(gctlog.Global, "Pair refresh manager unable to start: %s", err)
+			}
+		}
+	}
+
+	if bot.Settings.EnableEarnManager {
+		if e, err := SetupEarnManager(&bot.Config.EarnManager, bot.Settings.DataDir, bot.ExchangeManager, bot.earnPortfolio()); err != nil {
+			gctlog.Errorf(gctlog.Global, "Earn manager unable to setup: %s", err)
+		} else {
+			bot.earnManager = e
+			if err := bot.earnManager.Start(); err != nil {
+				gctlog.Errorf(gctlog.Global, "Earn manager unable to start: %s", err)
+			}
+		}
+	}
+
+	if bot.Settings.EnableLendingOptimizer {
+		var comms iCommsManager
+		if bot.CommunicationsManager.IsRunning() {
+			comms = bot.CommunicationsManager
+		}
+		if bot.WithdrawManager == nil {
+			gctlog.Errorln(gctlog.Global, "Lending optimizer requires the withdraw manager to move funds")
+		} else if l, err := SetupLendingOptimizer(&bot.Config.LendingOptimizer, bot.ExchangeManager, bot.WithdrawManager, comms); err != nil {
+			gctlog.Errorf(gctlog.Global, "Lending optimizer unable to setup: %s", err)
+		} else {
+			bot.lendingOptimizer = l
+			if err := bot.lendingOptimizer.Start(); err != nil {
+				gctlog.Errorf(gctlog.Global, "Lending optimizer unable to start: %s", err)
+			}
+		}
+	}
+
+	if bot.Settings.EnableMemoryManager {
+		if m, err := SetupMemoryManager(&bot.Config.MemoryManager); err != nil {
+			gctlog.Errorf(gctlog.Global, "Memory manager unable to setup: %s", err)
+		} else {
+			bot.memoryManager = m
+			if err := bot.memoryManager.Start(); err != nil {
+				gctlog.Errorf(gctlog.Global, "Memory manager unable to start: %s", err)
+			}
+		}
+	}
+
+	if bot.Settings.EnableScheduler {
+		if s, err := SetupScheduler(&bot.Config.Scheduler, bot.schedulerTaskTypes()); err != nil {
+			gctlog.Errorf(gctlog.Global, "Scheduler unable to setup: %s", err)
+		} else {
+			bot.scheduler = s
+			if err := bot.scheduler.Start(); err != nil {
+				gctlog.Errorf(gctlog.Global, "Scheduler unable to start: %s", err)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -594,6 +969,76 @@ func (bot *Engine) Stop() {
 			gctlog.Errorf(gctlog.Global, "GCTScript manager unable to stop. Error: %v", err)
 		}
 	}
+	if bot.basisHarvester.IsRunning() {
+		if err := bot.basisHarvester.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Basis harvester unable to stop. Error: %v", err)
+		}
+	}
+	if bot.anomalyDetector.IsRunning() {
+		if err := bot.anomalyDetector.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Anomaly detector unable to stop. Error: %v", err)
+		}
+	}
+	if bot.webhookListener.IsRunning() {
+		if err := bot.webhookListener.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Webhook listener unable to stop. Error: %v", err)
+		}
+	}
+	if bot.dataQualityMonitor.IsRunning() {
+		if err := bot.dataQualityMonitor.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Data quality monitor unable to stop. Error: %v", err)
+		}
+	}
+	if bot.exchangeCalendar.IsRunning() {
+		if err := bot.exchangeCalendar.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Exchange calendar unable to stop. Error: %v", err)
+		}
+	}
+	if bot.maintenanceScheduler.IsRunning() {
+		if err := bot.maintenanceScheduler.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Maintenance scheduler unable to stop. Error: %v", err)
+		}
+	}
+	if bot.adlMonitor.IsRunning() {
+		if err := bot.adlMonitor.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "ADL monitor unable to stop. Error: %v", err)
+		}
+	}
+	if bot.marginMonitor.IsRunning() {
+		if err := bot.marginMonitor.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Margin monitor unable to stop. Error: %v", err)
+		}
+	}
+	if bot.calendarSpreadManager.IsRunning() {
+		if err := bot.calendarSpreadManager.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Calendar spread manager unable to stop. Error: %v", err)
+		}
+	}
+	if bot.volatilitySurface.IsRunning() {
+		if err := bot.volatilitySurface.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Volatility surface manager unable to stop. Error: %v", err)
+		}
+	}
+	if bot.rolloverManager.IsRunning() {
+		if err := bot.rolloverManager.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Rollover manager unable to stop. Error: %v", err)
+		}
+	}
+	if bot.digestManager.IsRunning() {
+		if err := bot.digestManager.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Digest manager unable to stop. Error: %v", err)
+		}
+	}
+	if bot.surveillanceManager.IsRunning() {
+		if err := bot.surveillanceManager.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Surveillance manager unable to stop. Error: %v", err)
+		}
+	}
+	if bot.feeAccountingManager.IsRunning() {
+		if err := bot.feeAccountingManager.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Fee accounting manager unable to stop. Error: %v", err)
+		}
+	}
 	if bot.OrderManager.IsRunning() {
 		if err := bot.OrderManager.Stop(); err != nil {
 			gctlog.Errorf(gctlog.Global, "Order manager unable to stop. Error: %v", err)
@@ -639,6 +1084,11 @@ func (bot *Engine) Stop() {
 			gctlog.Errorf(gctlog.DataHistory, "data history manager unable to stop. Error: %v", err)
 		}
 	}
+	if bot.fillSyncManager.IsRunning() {
+		if err := bot.fillSyncManager.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Fill sync manager unable to stop. Error: %v", err)
+		}
+	}
 	if bot.DatabaseManager.IsRunning() {
 		if err := bot.DatabaseManager.Stop(); err != nil {
 			gctlog.Errorf(gctlog.Global, "Database manager unable to stop. Error: %v", err)
@@ -661,6 +1111,31 @@ func (bot *Engine) Stop() {
 				err)
 		}
 	}
+	if bot.scheduler.IsRunning() {
+		if err := bot.scheduler.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Scheduler unable to stop. Error: %v", err)
+		}
+	}
+	if bot.pairRefreshManager.IsRunning() {
+		if err := bot.pairRefreshManager.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Pair refresh manager unable to stop. Error: %v", err)
+		}
+	}
+	if bot.earnManager.IsRunning() {
+		if err := bot.earnManager.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Earn manager unable to stop. Error: %v", err)
+		}
+	}
+	if bot.lendingOptimizer.IsRunning() {
+		if err := bot.lendingOptimizer.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Lending optimizer unable to stop. Error: %v", err)
+		}
+	}
+	if bot.memoryManager.IsRunning() {
+		if err := bot.memoryManager.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Memory manager unable to stop. Error: %v", err)
+		}
+	}
 
 	err := bot.ExchangeManager.Shutdown(bot.Settings.ExchangeShutdownTimeout)
 	if err != nil {
@@ -821,6 +1296,9 @@ func (bot *Engine) LoadExchange(name string) error {
 	}
 
 	base := exch.GetBase()
+	base.SetPairStateChangeHandler(func(exchangeName string, change currency.PairStateChange) {
+		bot.OrderManager.pairStateChanged(exchangeName, change)
+	})
 	if base.API.AuthenticatedSupport ||
 		base.API.AuthenticatedWebsocketSupport {
 		assetTypes := base.GetAssetTypes(false)
@@ -863,6 +1341,24 @@ func (bot *Engine) dryRunParamInteraction(param string) {
 	}
 }
 
+// setupLatencySimulation injects artificial latency into the REST requests and
+// websocket messages of exchanges set up afterwards
+func setupLatencySimulation(cfg *config.LatencySimulation) error {
+	restSimulator, err := latency.NewSimulator(cfg.RESTLatency, cfg.RESTJitter)
+	if err != nil {
+		return fmt.Errorf("REST %w", err)
+	}
+	wsSimulator, err := latency.NewSimulator(cfg.WebsocketLatency, cfg.WebsocketJitter)
+	if err != nil {
+		return fmt.Errorf("websocket %w", err)
+	}
+	request.SetupGlobalLatencySimulator(restSimulator)
+	stream.SetupGlobalLatencySimulator(wsSimulator)
+	gctlog.Warnf(gctlog.Global, "Latency simulation enabled, REST latency %v jitter %v, websocket latency %v jitter %v. Do not use when trading live",
+		cfg.RESTLatency, cfg.RESTJitter, cfg.WebsocketLatency, cfg.WebsocketJitter)
+	return nil
+}
+
 // SetupExchanges sets up the exchanges used by the Bot
 func (bot *Engine) SetupExchanges() error {
 	configs := bot.Config.GetAllExchangeConfigs()
@@ -416,3 +416,13 @@ func TestGetDefaultConfigurations(t *testing.T) {
 		})
 	}
 }
+
+func TestSetupLatencySimulation(t *testing.T) {
+	t.Parallel()
+	err := setupLatencySimulation(&config.LatencySimulation{RESTLatency: -time.Second})
+	assert.ErrorContains(t, err, "REST", "negative REST latency should error")
+	err = setupLatencySimulation(&config.LatencySimulation{WebsocketJitter: -time.Second})
+	assert.ErrorContains(t, err, "websocket", "negative websocket jitter should error")
+	// Zero latency is used so parallel tests are not delayed
+	assert.NoError(t, setupLatencySimulation(&config.LatencySimulation{}))
+}
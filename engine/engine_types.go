@@ -31,35 +31,58 @@ type Settings struct {
 
 // CoreSettings defines settings related to core engine operations
 type CoreSettings struct {
-	EnableDryRun                bool
-	EnableAllExchanges          bool
-	EnableAllPairs              bool
-	EnableCoinmarketcapAnalysis bool
-	EnablePortfolioManager      bool
-	EnableDataHistoryManager    bool
-	PortfolioManagerDelay       time.Duration
-	EnableGRPC                  bool
-	EnableGRPCProxy             bool
-	EnableGRPCShutdown          bool
-	EnableWebsocketRPC          bool
-	EnableDeprecatedRPC         bool
-	EnableCommsRelayer          bool
-	EnableExchangeSyncManager   bool
-	EnableDepositAddressManager bool
-	EnableEventManager          bool
-	EnableOrderManager          bool
-	EnableConnectivityMonitor   bool
-	EnableDatabaseManager       bool
-	EnableGCTScriptManager      bool
-	EnableNTPClient             bool
-	EnableWebsocketRoutine      bool
-	EnableCurrencyStateManager  bool
-	EventManagerDelay           time.Duration
-	EnableFuturesTracking       bool
-	Verbose                     bool
-	EnableDispatcher            bool
-	DispatchMaxWorkerAmount     int
-	DispatchJobsLimit           int
+	EnableDryRun                   bool
+	EnableAllExchanges             bool
+	EnableAllPairs                 bool
+	EnableCoinmarketcapAnalysis    bool
+	EnablePortfolioManager         bool
+	EnableDataHistoryManager       bool
+	PortfolioManagerDelay          time.Duration
+	EnableGRPC                     bool
+	EnableGRPCProxy                bool
+	EnableGRPCShutdown             bool
+	EnableWebsocketRPC             bool
+	EnableDeprecatedRPC            bool
+	EnableCommsRelayer             bool
+	EnableExchangeSyncManager      bool
+	EnableDepositAddressManager    bool
+	EnableEventManager             bool
+	EnableOrderManager             bool
+	EnablePaperTrading             bool
+	EnableConnectivityMonitor      bool
+	EnableDatabaseManager          bool
+	EnableGCTScriptManager         bool
+	EnableNTPClient                bool
+	EnableWebsocketRoutine         bool
+	EnableCurrencyStateManager     bool
+	EnableDigestManager            bool
+	EnableFillSyncManager          bool
+	EnablePairRefreshManager       bool
+	EnableEarnManager              bool
+	EnableLendingOptimizer         bool
+	EnableMemoryManager            bool
+	EnableScheduler                bool
+	EnableRolloverManager          bool
+	EnableCalendarSpreadManager    bool
+	EnableVolatilitySurfaceManager bool
+	EnableMarginMonitor            bool
+	EnableADLMonitor               bool
+	EnableExchangeCalendar         bool
+	EnableMaintenanceScheduler     bool
+	EnableBasisHarvester           bool
+	EnableAnomalyDetector          bool
+	EnableDataQualityMonitor       bool
+	EnableWebhookListener          bool
+	EnableSurveillanceManager      bool
+	EnableFeeAccountingManager     bool
+	EnableLatencySimulation        bool
+	EnableCircuitBreaker           bool
+	EventManagerDelay              time.Duration
+	EnableFuturesTracking          bool
+	Verbose                        bool
+	EnableDispatcher               bool
+	DispatchMaxWorkerAmount        int
+	DispatchJobsLimit              int
 }
 
 // ExchangeSyncerSettings defines settings for the exchange pair synchronisation
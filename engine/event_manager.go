@@ -98,7 +98,7 @@ func (m *eventManager) executeEvent(i int) {
 				m.events[i].Exchange, m.events[i].String(),
 			)
 			log.Infoln(log.EventMgr, msg)
-			m.comms.PushEvent(base.Event{Type: "event", Message: msg})
+			m.comms.PushEvent(base.Event{Type: "event", Message: msg, Exchange: m.events[i].Exchange})
 			m.events[i].Executed = true
 		} else if m.verbose {
 			log.Debugf(log.EventMgr, "%v", err)
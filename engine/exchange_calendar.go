@@ -0,0 +1,234 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/calendar"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// SetupExchangeCalendar creates an exchange calendar subsystem. The
+// communications manager is optional, when set newly scheduled delistings and
+// maintenance windows are announced
+func SetupExchangeCalendar(cfg *config.ExchangeCalendar, em iExchangeManager, comms iCommsManager) (*ExchangeCalendar, error) {
+	if cfg == nil {
+		return nil, errNilConfig
+	}
+	if em == nil {
+		return nil, errNilExchangeManager
+	}
+	if cfg.RefreshInterval <= 0 {
+		return nil, fmt.Errorf("%w refresh interval %v", errInvalidCalendarDuration, cfg.RefreshInterval)
+	}
+	if cfg.Horizon <= 0 {
+		return nil, fmt.Errorf("%w horizon %v", errInvalidCalendarDuration, cfg.Horizon)
+	}
+	return &ExchangeCalendar{
+		verbose:         cfg.Verbose,
+		interval:        cfg.RefreshInterval,
+		horizon:         cfg.Horizon,
+		exchangeManager: em,
+		comms:           comms,
+		events:          make(map[string][]calendar.Event),
+		announced:       make(map[string]struct{}),
+	}, nil
+}
+
+// Start runs the subsystem
+func (m *ExchangeCalendar) Start() error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 0, 1) {
+		return ErrSubSystemAlreadyStarted
+	}
+	m.shutdown = make(chan struct{})
+	m.wg.Add(1)
+	go m.run()
+	log.Debugf(log.ExchangeSys, "Exchange calendar %s", MsgSubSystemStarted)
+	return nil
+}
+
+// IsRunning checks whether the subsystem is running
+func (m *ExchangeCalendar) IsRunning() bool {
+	if m == nil {
+		return false
+	}
+	return atomic.LoadInt32(&m.started) == 1
+}
+
+// Stop stops the subsystem
+func (m *ExchangeCalendar) Stop() error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 1, 0) {
+		return ErrSubSystemNotStarted
+	}
+	close(m.shutdown)
+	m.wg.Wait()
+	log.Debugf(log.ExchangeSys, "Exchange calendar %s", MsgSubSystemShutdown)
+	return nil
+}
+
+func (m *ExchangeCalendar) run() {
+	defer m.wg.Done()
+	runScheduledJobs(m.shutdown, &scheduledJob{interval: m.interval, immediate: true, run: func(ctx context.Context, _ time.Time) { m.refresh(ctx) }})
+}
+
+// refresh replaces the scheduled events of each exchange. An exchange's
+// previous events are kept when its events cannot be retrieved
+func (m *ExchangeCalendar) refresh(ctx context.Context) {
+	exchanges, err := m.exchangeManager.GetExchanges()
+	if err != nil {
+		log.Errorf(log.ExchangeSys, "Exchange calendar cannot get exchanges: %v", err)
+		return
+	}
+	now := time.Now()
+	for x := range exchanges {
+		events, err := m.exchangeEvents(ctx, exchanges[x], now)
+		if err != nil {
+			log.Errorf(log.ExchangeSys, "Exchange calendar cannot get %s scheduled events: %v", exchanges[x].GetName(), err)
+			continue
+		}
+		if m.verbose {
+			log.Debugf(log.ExchangeSys, "Exchange calendar %s has %d scheduled events", exchanges[x].GetName(), len(events))
+		}
+		m.m.Lock()
+		m.events[strings.ToLower(exchanges[x].GetName())] = events
+		m.m.Unlock()
+		m.announce(events)
+	}
+}
+
+// exchangeEvents returns the contract expiries of an exchange's enabled pairs
+// and its announced listings, delistings and maintenance windows which have
+// not passed and start within the horizon
+func (m *ExchangeCalendar) exchangeEvents(ctx context.Context, exch exchange.IBotExchange, now time.Time) ([]calendar.Event, error) {
+	var events []calendar.Event
+	assets := exch.GetAssetTypes(true)
+	for _, a := range assets {
+		if !a.IsFutures() {
+			continue
+		}
+		enabled, err := exch.GetEnabledPairs(a)
+		if err != nil || len(enabled) == 0 {
+			continue
+		}
+		contracts, err := exch.GetFuturesContractDetails(ctx, a)
+		if err != nil {
+			if isFeatureUnsupported(err) {
+				continue
+			}
+			return nil, fmt.Errorf("%s contract details: %w", a, err)
+		}
+		for i := range contracts {
+			if contracts[i].EndDate.IsZero() || !enabled.Contains(contracts[i].Name, true) {
+				continue
+			}
+			events = append(events, calendar.Event{
+				Exchange: exch.GetName(),
+				Asset:    a,
+				Pair:     contracts[i].Name,
+				Type:     calendar.Expiry,
+				Start:    contracts[i].EndDate,
+			})
+		}
+	}
+	scheduled, err := exch.GetScheduledEvents(ctx)
+	if err != nil && !isFeatureUnsupported(err) {
+		return nil, err
+	}
+	events = append(events, scheduled...)
+	return slices.DeleteFunc(events, func(e calendar.Event) bool {
+		return !e.Occurs(now, now.Add(m.horizon))
+	}), nil
+}
+
+// announce sends newly scheduled delistings and maintenance windows to the
+// communications manager
+func (m *ExchangeCalendar) announce(events []calendar.Event) {
+	if m.comms == nil {
+		return
+	}
+	for i := range events {
+		if events[i].Type != calendar.Delisting && events[i].Type != calendar.Maintenance {
+			continue
+		}
+		k := events[i].Key()
+		m.m.Lock()
+		_, ok := m.announced[k]
+		m.announced[k] = struct{}{}
+		m.m.Unlock()
+		if ok {
+			continue
+		}
+		msg := "Exchange calendar " + eventSummary(&events[i])
+		log.Infoln(log.ExchangeSys, msg)
+		m.comms.PushEvent(base.Event{
+			Type:     calendarEventType,
+			Message:  msg,
+			Severity: base.SeverityWarning,
+			Exchange: events[i].Exchange,
+		})
+	}
+}
+
+// GetEvents returns the scheduled events matching the filter ordered by start
+// time
+func (m *ExchangeCalendar) GetEvents(f *calendar.Filter) ([]calendar.Event, error) {
+	if m == nil {
+		return nil, fmt.Errorf("%s %w", ExchangeCalendarName, ErrNilSubsystem)
+	}
+	if !m.IsRunning() {
+		return nil, fmt.Errorf("%s %w", ExchangeCalendarName, ErrSubSystemNotStarted)
+	}
+	var resp []calendar.Event
+	m.m.RLock()
+	for exch, events := range m.events {
+		if f != nil && f.Exchange != "" && !strings.EqualFold(f.Exchange, exch) {
+			continue
+		}
+		for i := range events {
+			if f.Match(&events[i]) {
+				resp = append(resp, events[i])
+			}
+		}
+	}
+	m.m.RUnlock()
+	slices.SortFunc(resp, func(a, b calendar.Event) int {
+		if c := a.Start.Compare(b.Start); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Key(), b.Key())
+	})
+	return resp, nil
+}
+
+// eventSummary returns a readable description of a scheduled event
+func eventSummary(e *calendar.Event) string {
+	subject := e.Exchange
+	if e.Asset != asset.Empty {
+		subject += " " + e.Asset.String()
+	}
+	if !e.Pair.IsEmpty() {
+		subject += " " + e.Pair.String()
+	}
+	summary := fmt.Sprintf("%s %s scheduled for %s", subject, e.Type, e.Start.UTC().Format(time.RFC3339))
+	if !e.End.IsZero() {
+		summary += " until " + e.End.UTC().Format(time.RFC3339)
+	}
+	if e.Description != "" {
+		summary += ": " + e.Description
+	}
+	return summary
+}
@@ -0,0 +1,185 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/calendar"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
+)
+
+// calendarExchange is a fake exchange with contract expiries and scheduled
+// events
+type calendarExchange struct {
+	exchange.IBotExchange
+	name      string
+	contracts []futures.Contract
+	enabled   currency.Pairs
+	scheduled []calendar.Event
+	err       error
+}
+
+func (f *calendarExchange) GetName() string {
+	return f.name
+}
+
+func (f *calendarExchange) GetAssetTypes(bool) asset.Items {
+	return asset.Items{asset.Spot, asset.Futures}
+}
+
+func (f *calendarExchange) GetEnabledPairs(asset.Item) (currency.Pairs, error) {
+	return f.enabled, nil
+}
+
+func (f *calendarExchange) GetFuturesContractDetails(_ context.Context, a asset.Item) ([]futures.Contract, error) {
+	if a != asset.Futures {
+		return nil, asset.ErrNotSupported
+	}
+	return f.contracts, nil
+}
+
+func (f *calendarExchange) GetScheduledEvents(context.Context) ([]calendar.Event, error) {
+	return f.scheduled, f.err
+}
+
+func testExchangeCalendarSetup(t *testing.T) (*ExchangeCalendar, *calendarExchange, *fakeComms) {
+	t.Helper()
+	now := time.Now()
+	near := currency.NewPair(currency.BTC, currency.NewCode("USD_NEAR"))
+	far := currency.NewPair(currency.BTC, currency.NewCode("USD_FAR"))
+	exch := &calendarExchange{
+		name: "calendar",
+		contracts: []futures.Contract{
+			{Name: far, EndDate: now.Add(time.Hour * 24 * 20)},
+			{Name: near, EndDate: now.Add(time.Hour * 24)},
+			{Name: currency.NewPair(currency.BTC, currency.NewCode("USD_PERP"))},
+			{Name: currency.NewPair(currency.ETH, currency.NewCode("USD_NEAR")), EndDate: now.Add(time.Hour * 24)},
+		},
+		enabled: currency.Pairs{near, far, currency.NewPair(currency.BTC, currency.NewCode("USD_PERP"))},
+		scheduled: []calendar.Event{
+			{Exchange: "calendar", Type: calendar.Maintenance, Start: now.Add(time.Hour), End: now.Add(time.Hour * 2), Description: "system upgrade"},
+			{Exchange: "calendar", Asset: asset.Spot, Pair: currency.NewBTCUSDT(), Type: calendar.Delisting, Start: now.Add(time.Hour * 72)},
+			{Exchange: "calendar", Type: calendar.Maintenance, Start: now.Add(-time.Hour * 2), End: now.Add(-time.Hour)},
+		},
+	}
+	comms := &fakeComms{}
+	m, err := SetupExchangeCalendar(&config.ExchangeCalendar{RefreshInterval: time.Hour, Horizon: time.Hour * 24 * 30}, testExchangeManager(t, exch), comms)
+	require.NoError(t, err)
+	return m, exch, comms
+}
+
+func TestSetupExchangeCalendar(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	_, err := SetupExchangeCalendar(nil, nil, nil)
+	assert.ErrorIs(t, err, errNilConfig)
+	_, err = SetupExchangeCalendar(&config.ExchangeCalendar{}, nil, nil)
+	assert.ErrorIs(t, err, errNilExchangeManager)
+	_, err = SetupExchangeCalendar(&config.ExchangeCalendar{}, em, nil)
+	assert.ErrorIs(t, err, errInvalidCalendarDuration)
+	_, err = SetupExchangeCalendar(&config.ExchangeCalendar{RefreshInterval: time.Hour, Horizon: time.Hour}, em, nil)
+	assert.NoError(t, err, "communications manager should not be required")
+}
+
+func TestExchangeCalendarStartStop(t *testing.T) {
+	t.Parallel()
+	m, _, _ := testExchangeCalendarSetup(t)
+	testStartStop(t, (*ExchangeCalendar)(nil), m)
+}
+
+func TestExchangeCalendarAnnounce(t *testing.T) {
+	t.Parallel()
+	m, _, comms := testExchangeCalendarSetup(t)
+	start := time.Date(2024, 6, 28, 8, 0, 0, 0, time.UTC)
+	m.announce([]calendar.Event{
+		{Exchange: "calendar", Type: calendar.Expiry, Start: start},
+		{Exchange: "calendar", Type: calendar.Listing, Start: start},
+		{Exchange: "calendar", Type: calendar.Delisting, Start: start},
+		{Exchange: "calendar", Type: calendar.Maintenance, Start: start},
+	})
+	require.Len(t, comms.events, 2, "only delistings and maintenance windows should be announced")
+	assert.Equal(t, base.SeverityWarning, comms.events[0].Severity)
+	assert.Equal(t, "calendar", comms.events[0].Exchange)
+
+	m.announce([]calendar.Event{{Exchange: "CALENDAR", Type: calendar.Maintenance, Start: start}})
+	assert.Len(t, comms.events, 2, "announced events should not be announced again")
+	m.announce([]calendar.Event{{Exchange: "calendar", Type: calendar.Maintenance, Start: start.Add(time.Hour)}})
+	assert.Len(t, comms.events, 3, "rescheduled events should be announced")
+
+	m.comms = nil
+	assert.NotPanics(t, func() {
+		m.announce([]calendar.Event{{Exchange: "calendar", Type: calendar.Delisting, Start: start.Add(time.Hour)}})
+	}, "announce should not panic without a communications manager")
+}
+
+func TestExchangeCalendarRefresh(t *testing.T) {
+	t.Parallel()
+	m, exch, comms := testExchangeCalendarSetup(t)
+	m.refresh(context.Background())
+	m.started = 1
+
+	events, err := m.GetEvents(nil)
+	require.NoError(t, err)
+	require.Len(t, events, 4, "only enabled expiries and events which have not passed should be stored")
+	assert.Equal(t, calendar.Maintenance, events[0].Type, "events should be ordered by start time")
+	assert.Equal(t, calendar.Expiry, events[1].Type)
+	assert.True(t, events[1].Pair.Equal(exch.contracts[1].Name))
+	assert.Equal(t, calendar.Delisting, events[2].Type)
+	assert.Equal(t, calendar.Expiry, events[3].Type)
+
+	require.Len(t, comms.events, 2, "new delistings and maintenance windows should be announced")
+	assert.Equal(t, calendarEventType, comms.events[0].Type)
+	assert.Contains(t, comms.events[0].Message, "system upgrade")
+	m.refresh(context.Background())
+	assert.Len(t, comms.events, 2, "events should only be announced once")
+
+	exch.err = errExpectedTestError
+	exch.scheduled = nil
+	m.refresh(context.Background())
+	events, err = m.GetEvents(nil)
+	require.NoError(t, err)
+	assert.Len(t, events, 4, "events should be retained when they cannot be refreshed")
+
+	exch.err = common.ErrFunctionNotSupported
+	m.refresh(context.Background())
+	events, err = m.GetEvents(nil)
+	require.NoError(t, err)
+	assert.Len(t, events, 2, "exchanges without scheduled events should still provide expiries")
+
+	m.horizon = time.Hour * 48
+	m.refresh(context.Background())
+	events, err = m.GetEvents(nil)
+	require.NoError(t, err)
+	assert.Len(t, events, 1, "events beyond the horizon should not be stored")
+}
+
+func TestExchangeCalendarGetEvents(t *testing.T) {
+	t.Parallel()
+	m, _, _ := testExchangeCalendarSetup(t)
+	_, err := m.GetEvents(nil)
+	assert.ErrorIs(t, err, ErrSubSystemNotStarted)
+	var nilCalendar *ExchangeCalendar
+	_, err = nilCalendar.GetEvents(nil)
+	assert.ErrorIs(t, err, ErrNilSubsystem)
+
+	m.refresh(context.Background())
+	m.started = 1
+	events, err := m.GetEvents(&calendar.Filter{Exchange: "CALENDAR", Types: []calendar.EventType{calendar.Expiry}})
+	require.NoError(t, err)
+	assert.Len(t, events, 2)
+	events, err = m.GetEvents(&calendar.Filter{Exchange: "bybit"})
+	require.NoError(t, err)
+	assert.Empty(t, events)
+	events, err = m.GetEvents(&calendar.Filter{Asset: asset.Spot, Pair: currency.NewBTCUSDT()})
+	require.NoError(t, err)
+	assert.Len(t, events, 2, "exchange wide maintenance should match any pair")
+}
@@ -0,0 +1,45 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/exchanges/calendar"
+)
+
+// ExchangeCalendarName is an exported subsystem name
+const ExchangeCalendarName = "exchange_calendar"
+
+// calendarEventType is the communications event type used for newly
+// scheduled delistings and maintenance windows
+const calendarEventType = "calendar"
+
+var errInvalidCalendarDuration = errors.New("exchange calendar duration must be greater than zero")
+
+// iExchangeCalendar defines the exchange calendar functions used by
+// subsystems which act on scheduled exchange events
+type iExchangeCalendar interface {
+	GetEvents(*calendar.Filter) ([]calendar.Event, error)
+}
+
+// ExchangeCalendar aggregates upcoming contract expiries, listings,
+// delistings and maintenance windows across exchanges into a queryable
+// schedule
+type ExchangeCalendar struct {
+	started         int32
+	shutdown        chan struct{}
+	wg              sync.WaitGroup
+	verbose         bool
+	interval        time.Duration
+	horizon         time.Duration
+	exchangeManager iExchangeManager
+	comms           iCommsManager
+	m               sync.RWMutex
+	// events holds each exchange's scheduled events keyed by lower case
+	// exchange name
+	events map[string][]calendar.Event
+	// announced holds the keys of delistings and maintenance windows which
+	// have been sent to the communications manager
+	announced map[string]struct{}
+}
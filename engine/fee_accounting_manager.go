@@ -0,0 +1,367 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/cron"
+	"github.com/thrasher-corp/gocryptotrader/common/file"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// SetupFeeAccountingManager creates a fee accounting manager from the supplied
+// config, loading any previously crystallised account state. When the config
+// does not specify a state file it is stored in the data directory
+func SetupFeeAccountingManager(cfg *config.FeeAccounting, dataDir string, om iFeeAccountingOrderManager, comms iCommsManager) (*FeeAccountingManager, error) {
+	if cfg == nil {
+		return nil, errNilConfig
+	}
+	if om == nil {
+		return nil, errNilOrderManager
+	}
+	if comms == nil {
+		return nil, errNilCommunicationsManager
+	}
+	if len(cfg.Accounts) == 0 {
+		return nil, errNoManagedAccounts
+	}
+	schedule, err := cron.Parse(cfg.Schedule)
+	if err != nil {
+		return nil, fmt.Errorf("fee accounting schedule: %w", err)
+	}
+	m := &FeeAccountingManager{
+		shutdown:     make(chan struct{}),
+		verbose:      cfg.Verbose,
+		schedule:     schedule,
+		stateFile:    cfg.StateFile,
+		orderManager: om,
+		comms:        comms,
+		state:        make(map[string]*ManagedAccountState),
+	}
+	if m.stateFile == "" {
+		m.stateFile = filepath.Join(dataDir, feeAccountingStateFile)
+	}
+	for i := range cfg.Accounts {
+		a, err := newManagedAccount(&cfg.Accounts[i])
+		if err != nil {
+			return nil, err
+		}
+		for j := range m.accounts {
+			if strings.EqualFold(m.accounts[j].name, a.name) {
+				return nil, fmt.Errorf("%w %s: duplicate name", errInvalidManagedAccount, a.name)
+			}
+		}
+		m.accounts = append(m.accounts, a)
+	}
+	if err := m.loadState(time.Now()); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// newManagedAccount validates and parses a managed account config
+func newManagedAccount(cfg *config.ManagedAccount) (*managedAccount, error) {
+	switch {
+	case cfg.Name == "":
+		return nil, fmt.Errorf("%w: name cannot be empty", errInvalidManagedAccount)
+	case cfg.Currency == "":
+		return nil, fmt.Errorf("%w %s: currency cannot be empty", errInvalidManagedAccount, cfg.Name)
+	case cfg.InitialCapital <= 0:
+		return nil, fmt.Errorf("%w %s: initial capital %v must be above zero", errInvalidManagedAccount, cfg.Name, cfg.InitialCapital)
+	case cfg.ManagementFeeRate < 0 || cfg.ManagementFeeRate >= 1:
+		return nil, fmt.Errorf("%w %s: management fee rate %v must be at least zero and below 1", errInvalidManagedAccount, cfg.Name, cfg.ManagementFeeRate)
+	case cfg.PerformanceFeeRate < 0 || cfg.PerformanceFeeRate >= 1:
+		return nil, fmt.Errorf("%w %s: performance fee rate %v must be at least zero and below 1", errInvalidManagedAccount, cfg.Name, cfg.PerformanceFeeRate)
+	}
+	a := &managedAccount{
+		name:               cfg.Name,
+		currency:           currency.NewCode(cfg.Currency),
+		strategyTags:       make(map[string]bool, len(cfg.StrategyTags)),
+		exchanges:          make(map[string]bool, len(cfg.Exchanges)),
+		initialCapital:     decimal.NewFromFloat(cfg.InitialCapital),
+		managementFeeRate:  decimal.NewFromFloat(cfg.ManagementFeeRate),
+		performanceFeeRate: decimal.NewFromFloat(cfg.PerformanceFeeRate),
+	}
+	for _, tag := range cfg.StrategyTags {
+		a.strategyTags[tag] = true
+	}
+	for _, exch := range cfg.Exchanges {
+		a.exchanges[strings.ToLower(exch)] = true
+	}
+	return a, nil
+}
+
+// loadState reads previously crystallised account state from the state file.
+// Accounts without state start at their initial capital from the supplied time
+func (m *FeeAccountingManager) loadState(now time.Time) error {
+	data, err := os.ReadFile(m.stateFile)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return fmt.Errorf("unable to read fee accounting state: %w", err)
+	default:
+		if err := json.Unmarshal(data, &m.state); err != nil {
+			return fmt.Errorf("unable to parse fee accounting state %s: %w", m.stateFile, err)
+		}
+	}
+	for _, a := range m.accounts {
+		if _, ok := m.state[a.name]; ok {
+			continue
+		}
+		m.state[a.name] = &ManagedAccountState{
+			NetAssetValue:    a.initialCapital,
+			HighWaterMark:    a.initialCapital,
+			LastCrystallised: now,
+		}
+	}
+	return nil
+}
+
+// saveState writes the account state to the state file
+func (m *FeeAccountingManager) saveState() error {
+	data, err := json.MarshalIndent(m.state, "", " ")
+	if err != nil {
+		return err
+	}
+	return file.Write(m.stateFile, data)
+}
+
+// IsRunning safely checks whether the subsystem is running
+func (m *FeeAccountingManager) IsRunning() bool {
+	if m == nil {
+		return false
+	}
+	return atomic.LoadInt32(&m.started) == 1
+}
+
+// Start runs the subsystem
+func (m *FeeAccountingManager) Start() error {
+	if m == nil {
+		return fmt.Errorf("%s %w", FeeAccountingManagerName, ErrNilSubsystem)
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 0, 1) {
+		return fmt.Errorf("%s %w", FeeAccountingManagerName, ErrSubSystemAlreadyStarted)
+	}
+	log.Debugf(log.Global, "Fee accounting manager %s", MsgSubSystemStarting)
+	m.m.Lock()
+	// new accounts are persisted so their accounting period begins now
+	// rather than at the next restart
+	if err := m.saveState(); err != nil {
+		log.Errorf(log.Global, "Fee accounting manager unable to save state: %v", err)
+	}
+	m.m.Unlock()
+	m.wg.Add(1)
+	go m.run()
+	log.Debugf(log.Global, "Fee accounting manager %s", MsgSubSystemStarted)
+	return nil
+}
+
+// Stop stops the subsystem
+func (m *FeeAccountingManager) Stop() error {
+	if m == nil {
+		return fmt.Errorf("%s %w", FeeAccountingManagerName, ErrNilSubsystem)
+	}
+	if atomic.LoadInt32(&m.started) == 0 {
+		return fmt.Errorf("%s %w", FeeAccountingManagerName, ErrSubSystemNotStarted)
+	}
+	log.Debugf(log.Global, "Fee accounting manager %s", MsgSubSystemShuttingDown)
+	close(m.shutdown)
+	m.wg.Wait()
+	m.shutdown = make(chan struct{})
+	atomic.StoreInt32(&m.started, 0)
+	log.Debugf(log.Global, "Fee accounting manager %s", MsgSubSystemShutdown)
+	return nil
+}
+
+func (m *FeeAccountingManager) run() {
+	defer m.wg.Done()
+	runScheduledJobs(m.shutdown, &scheduledJob{schedule: m.schedule, run: func(_ context.Context, now time.Time) { m.crystallise(now) }})
+}
+
+// crystallise charges the fees accrued by every account up to the supplied
+// time, updates their net asset value and high-water mark and sends each
+// account's statement
+func (m *FeeAccountingManager) crystallise(now time.Time) {
+	orders := m.sortedOrders()
+	m.m.Lock()
+	defer m.m.Unlock()
+	var updated bool
+	for _, a := range m.accounts {
+		s := m.state[a.name]
+		st, err := a.statement(s, orders, now)
+		if err != nil {
+			log.Errorf(log.Global, "Fee accounting manager unable to compose %s statement: %v", a.name, err)
+			continue
+		}
+		st.Crystallised = true
+		s.NetAssetValue = st.ClosingNAV
+		s.HighWaterMark = st.HighWaterMark
+		s.LastCrystallised = st.End
+		s.ManagementFeesCharged = s.ManagementFeesCharged.Add(st.ManagementFee)
+		s.PerformanceFeesCharged = s.PerformanceFeesCharged.Add(st.PerformanceFee)
+		updated = true
+		if m.verbose {
+			log.Debugf(log.Global, "Fee accounting manager sending %s statement", a.name)
+		}
+		m.comms.PushEvent(base.Event{
+			Type:     feeStatementEventType,
+			Message:  st.String(),
+			Severity: base.SeverityInfo,
+		})
+	}
+	if !updated {
+		return
+	}
+	if err := m.saveState(); err != nil {
+		log.Errorf(log.Global, "Fee accounting manager unable to save state: %v", err)
+	}
+}
+
+// Statement previews the named account's statement from its last
+// crystallisation until the supplied time without charging any fees
+func (m *FeeAccountingManager) Statement(account string, end time.Time) (*FeeStatement, error) {
+	if m == nil {
+		return nil, fmt.Errorf("%s %w", FeeAccountingManagerName, ErrNilSubsystem)
+	}
+	for _, a := range m.accounts {
+		if !strings.EqualFold(a.name, account) {
+			continue
+		}
+		orders := m.sortedOrders()
+		m.m.Lock()
+		defer m.m.Unlock()
+		return a.statement(m.state[a.name], orders, end)
+	}
+	return nil, fmt.Errorf("%w: %s", errManagedAccountNotFound, account)
+}
+
+// AccountState returns a copy of the named account's crystallised state
+func (m *FeeAccountingManager) AccountState(account string) (*ManagedAccountState, error) {
+	if m == nil {
+		return nil, fmt.Errorf("%s %w", FeeAccountingManagerName, ErrNilSubsystem)
+	}
+	m.m.Lock()
+	defer m.m.Unlock()
+	for _, a := range m.accounts {
+		if strings.EqualFold(a.name, account) {
+			s := *m.state[a.name]
+			return &s, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", errManagedAccountNotFound, account)
+}
+
+// sortedOrders returns the order manager's orders sorted by the time they
+// were last traded
+func (m *FeeAccountingManager) sortedOrders() []order.Detail {
+	orders := m.orderManager.GetOrdersSnapshot(order.AnyStatus)
+	sort.SliceStable(orders, func(i, j int) bool {
+		return orderTime(&orders[i]).Before(orderTime(&orders[j]))
+	})
+	return orders
+}
+
+// includes returns whether an order belongs to the account
+func (a *managedAccount) includes(o *order.Detail) bool {
+	if !o.Pair.Quote.Equal(a.currency) {
+		return false
+	}
+	if len(a.strategyTags) > 0 && !a.strategyTags[o.StrategyTag] {
+		return false
+	}
+	return len(a.exchanges) == 0 || a.exchanges[strings.ToLower(o.Exchange)]
+}
+
+// statement calculates the account's realised profit and loss after its last
+// crystallisation up to the supplied time, and the management and performance
+// fees due. Orders must be sorted by the time they were last traded
+func (a *managedAccount) statement(s *ManagedAccountState, orders []order.Detail, end time.Time) (*FeeStatement, error) {
+	if err := common.StartEndTimeCheck(s.LastCrystallised, end); err != nil {
+		return nil, err
+	}
+	st := &FeeStatement{
+		Account:              a.name,
+		Currency:             a.currency,
+		Start:                s.LastCrystallised,
+		End:                  end,
+		OpeningNAV:           s.NetAssetValue,
+		OpeningHighWaterMark: s.HighWaterMark,
+	}
+	trackers := make(map[string]*pnlTracker)
+	for i := range orders {
+		o := &orders[i]
+		if !a.includes(o) {
+			continue
+		}
+		amount, price := executedAmountAndPrice(o)
+		if amount.IsZero() || price.IsZero() {
+			continue
+		}
+		// fills before the period still build each pair's cost basis
+		key := o.Exchange + "|" + o.AssetType.String() + "|" + o.Pair.String()
+		t, ok := trackers[key]
+		if !ok {
+			t = &pnlTracker{}
+			trackers[key] = t
+		}
+		realised := t.apply(o.Side, amount, price)
+		ot := orderTime(o)
+		if !ot.After(st.Start) || ot.After(end) {
+			continue
+		}
+		st.OrdersFilled++
+		st.RealisedPNL = st.RealisedPNL.Add(realised)
+		if o.Fee > 0 && (o.FeeAsset.IsEmpty() || o.FeeAsset.Equal(a.currency)) {
+			st.TradingFees = st.TradingFees.Add(decimal.NewFromFloat(o.Fee))
+		}
+	}
+	st.GrossNAV = st.OpeningNAV.Add(st.RealisedPNL).Sub(st.TradingFees)
+	if st.OpeningNAV.IsPositive() {
+		elapsed := decimal.NewFromInt(int64(end.Sub(st.Start))).Div(decimal.NewFromInt(int64(feeAccountingYear)))
+		st.ManagementFee = st.OpeningNAV.Mul(a.managementFeeRate).Mul(elapsed).Round(8)
+	}
+	net := st.GrossNAV.Sub(st.ManagementFee)
+	if gain := net.Sub(st.OpeningHighWaterMark); gain.IsPositive() {
+		st.PerformanceFee = gain.Mul(a.performanceFeeRate).Round(8)
+	}
+	st.ClosingNAV = net.Sub(st.PerformanceFee)
+	st.HighWaterMark = decimal.Max(st.OpeningHighWaterMark, st.ClosingNAV)
+	return st, nil
+}
+
+// String renders the statement as a plain text message suitable for any
+// communication channel
+func (s *FeeStatement) String() string {
+	var sb strings.Builder
+	title := "Fee statement"
+	if !s.Crystallised {
+		title = "Fee statement preview"
+	}
+	fmt.Fprintf(&sb, "%s %s %s - %s\n", s.Account, title,
+		s.Start.UTC().Format(common.SimpleTimeFormatWithTimezone),
+		s.End.UTC().Format(common.SimpleTimeFormatWithTimezone))
+	fmt.Fprintf(&sb, "Orders filled: %d\n", s.OrdersFilled)
+	fmt.Fprintf(&sb, "Opening NAV: %s %s\n", s.OpeningNAV, s.Currency)
+	fmt.Fprintf(&sb, "Realised P&L: %s %s\n", s.RealisedPNL, s.Currency)
+	fmt.Fprintf(&sb, "Trading fees: %s %s\n", s.TradingFees, s.Currency)
+	fmt.Fprintf(&sb, "Gross NAV: %s %s\n", s.GrossNAV, s.Currency)
+	fmt.Fprintf(&sb, "Management fee: %s %s\n", s.ManagementFee, s.Currency)
+	fmt.Fprintf(&sb, "Performance fee: %s %s\n", s.PerformanceFee, s.Currency)
+	fmt.Fprintf(&sb, "Closing NAV: %s %s\n", s.ClosingNAV, s.Currency)
+	fmt.Fprintf(&sb, "High-water mark: %s -> %s %s", s.OpeningHighWaterMark, s.HighWaterMark, s.Currency)
+	return sb.String()
+}
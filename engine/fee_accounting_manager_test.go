@@ -0,0 +1,208 @@
+package engine
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func testFeeAccountingConfig(t *testing.T) *config.FeeAccounting {
+	t.Helper()
+	return &config.FeeAccounting{
+		Schedule:  "@monthly",
+		StateFile: filepath.Join(t.TempDir(), feeAccountingStateFile),
+		Accounts: []config.ManagedAccount{{
+			Name:               "alpha",
+			StrategyTags:       []string{"alpha"},
+			Exchanges:          []string{"Binance"},
+			Currency:           "USDT",
+			InitialCapital:     1000,
+			ManagementFeeRate:  0.02,
+			PerformanceFeeRate: 0.2,
+		}},
+	}
+}
+
+// testFeeAccountingOrders returns a filled buy before start and a filled sell
+// after start belonging to the alpha account, realising 300 USDT less a 1 USDT
+// fee, alongside fills which belong to other strategies, exchanges or quote
+// currencies
+func testFeeAccountingOrders(start time.Time) []order.Detail {
+	o := func(exch, tag string, p currency.Pair, side order.Side, price float64, at time.Time) order.Detail {
+		return order.Detail{
+			Exchange:       exch,
+			StrategyTag:    tag,
+			AssetType:      asset.Spot,
+			Pair:           p,
+			Side:           side,
+			Status:         order.Filled,
+			Price:          price,
+			Amount:         1,
+			ExecutedAmount: 1,
+			Date:           at,
+			LastUpdated:    at,
+		}
+	}
+	sell := o("binance", "alpha", btcusdtPair, order.Sell, 400, start.Add(time.Hour))
+	sell.Fee = 1
+	return []order.Detail{
+		sell,
+		o("binance", "alpha", btcusdtPair, order.Buy, 100, start.Add(-time.Hour)),
+		o("binance", "beta", btcusdtPair, order.Sell, 1000, start.Add(time.Hour)),
+		o("okx", "alpha", btcusdtPair, order.Sell, 1000, start.Add(time.Hour)),
+		o("binance", "alpha", btcusdPair, order.Sell, 1000, start.Add(time.Hour)),
+	}
+}
+
+func TestSetupFeeAccountingManager(t *testing.T) {
+	t.Parallel()
+	_, err := SetupFeeAccountingManager(nil, "", nil, nil)
+	assert.ErrorIs(t, err, errNilConfig)
+	_, err = SetupFeeAccountingManager(&config.FeeAccounting{}, "", nil, nil)
+	assert.ErrorIs(t, err, errNilOrderManager)
+	_, err = SetupFeeAccountingManager(&config.FeeAccounting{}, "", &fakeDigestOrderManager{}, nil)
+	assert.ErrorIs(t, err, errNilCommunicationsManager)
+	_, err = SetupFeeAccountingManager(&config.FeeAccounting{}, "", &fakeDigestOrderManager{}, &fakeComms{})
+	assert.ErrorIs(t, err, errNoManagedAccounts)
+
+	cfg := testFeeAccountingConfig(t)
+	cfg.Schedule = "bad"
+	_, err = SetupFeeAccountingManager(cfg, "", &fakeDigestOrderManager{}, &fakeComms{})
+	assert.Error(t, err, "invalid schedules should error")
+
+	for _, invalid := range []func(a *config.ManagedAccount){
+		func(a *config.ManagedAccount) { a.Name = "" },
+		func(a *config.ManagedAccount) { a.Currency = "" },
+		func(a *config.ManagedAccount) { a.InitialCapital = 0 },
+		func(a *config.ManagedAccount) { a.ManagementFeeRate = -0.1 },
+		func(a *config.ManagedAccount) { a.PerformanceFeeRate = 1 },
+	} {
+		cfg = testFeeAccountingConfig(t)
+		invalid(&cfg.Accounts[0])
+		_, err = SetupFeeAccountingManager(cfg, "", &fakeDigestOrderManager{}, &fakeComms{})
+		assert.ErrorIs(t, err, errInvalidManagedAccount)
+	}
+	cfg = testFeeAccountingConfig(t)
+	cfg.Accounts = append(cfg.Accounts, cfg.Accounts[0])
+	cfg.Accounts[1].Name = "ALPHA"
+	_, err = SetupFeeAccountingManager(cfg, "", &fakeDigestOrderManager{}, &fakeComms{})
+	assert.ErrorIs(t, err, errInvalidManagedAccount, "duplicate account names should error")
+
+	cfg = testFeeAccountingConfig(t)
+	require.NoError(t, os.WriteFile(cfg.StateFile, []byte("bad"), 0o600))
+	_, err = SetupFeeAccountingManager(cfg, "", &fakeDigestOrderManager{}, &fakeComms{})
+	assert.Error(t, err, "corrupt state should error")
+
+	cfg = testFeeAccountingConfig(t)
+	cfg.StateFile = ""
+	dir := t.TempDir()
+	m, err := SetupFeeAccountingManager(cfg, dir, &fakeDigestOrderManager{}, &fakeComms{})
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, feeAccountingStateFile), m.stateFile, "state file should default to the data directory")
+	s, err := m.AccountState("alpha")
+	require.NoError(t, err)
+	assert.True(t, s.NetAssetValue.Equal(decimal.NewFromInt(1000)), "new accounts should start at their initial capital")
+	assert.True(t, s.HighWaterMark.Equal(decimal.NewFromInt(1000)), "new accounts should start with a high-water mark of their initial capital")
+	_, err = m.AccountState("beta")
+	assert.ErrorIs(t, err, errManagedAccountNotFound)
+}
+
+func TestFeeAccountingManagerStartStop(t *testing.T) {
+	t.Parallel()
+	var m *FeeAccountingManager
+	assert.ErrorIs(t, m.Start(), ErrNilSubsystem)
+	assert.ErrorIs(t, m.Stop(), ErrNilSubsystem)
+	assert.False(t, m.IsRunning())
+
+	cfg := testFeeAccountingConfig(t)
+	m, err := SetupFeeAccountingManager(cfg, "", &fakeDigestOrderManager{}, &fakeComms{})
+	require.NoError(t, err)
+	assert.ErrorIs(t, m.Stop(), ErrSubSystemNotStarted)
+	require.NoError(t, m.Start())
+	assert.True(t, m.IsRunning())
+	assert.FileExists(t, cfg.StateFile, "state should be saved on start")
+	assert.ErrorIs(t, m.Start(), ErrSubSystemAlreadyStarted)
+	require.NoError(t, m.Stop())
+	assert.False(t, m.IsRunning())
+}
+
+func TestFeeAccountingStatement(t *testing.T) {
+	t.Parallel()
+	end := time.Now()
+	start := end.Add(-feeAccountingYear)
+	m, err := SetupFeeAccountingManager(testFeeAccountingConfig(t), "", &fakeDigestOrderManager{orders: testFeeAccountingOrders(start)}, &fakeComms{})
+	require.NoError(t, err)
+	m.state["alpha"].LastCrystallised = start
+
+	_, err = m.Statement("beta", end)
+	assert.ErrorIs(t, err, errManagedAccountNotFound)
+	_, err = m.Statement("alpha", start.Add(-time.Hour))
+	assert.Error(t, err, "statements ending before the last crystallisation should error")
+
+	st, err := m.Statement("ALPHA", end)
+	require.NoError(t, err)
+	assert.Equal(t, 1, st.OrdersFilled, "only the account's fills within the period should be counted")
+	assert.Equal(t, "300", st.RealisedPNL.String())
+	assert.Equal(t, "1", st.TradingFees.String())
+	assert.Equal(t, "1299", st.GrossNAV.String())
+	assert.Equal(t, "20", st.ManagementFee.String(), "a year's management fee should be charged on the opening NAV")
+	assert.Equal(t, "55.8", st.PerformanceFee.String(), "performance fees should be charged on gains above the high-water mark")
+	assert.Equal(t, "1223.2", st.ClosingNAV.String())
+	assert.Equal(t, "1223.2", st.HighWaterMark.String())
+	assert.False(t, st.Crystallised)
+	assert.Contains(t, st.String(), "alpha Fee statement preview")
+
+	s, err := m.AccountState("alpha")
+	require.NoError(t, err)
+	assert.Equal(t, "1000", s.NetAssetValue.String(), "previews should not update account state")
+}
+
+func TestFeeAccountingCrystallise(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	start := now.Add(-feeAccountingYear)
+	cfg := testFeeAccountingConfig(t)
+	om := &fakeDigestOrderManager{orders: testFeeAccountingOrders(start)}
+	comms := &fakeComms{}
+	m, err := SetupFeeAccountingManager(cfg, "", om, comms)
+	require.NoError(t, err)
+	m.state["alpha"].LastCrystallised = start
+
+	m.crystallise(now)
+	require.Len(t, comms.events, 1)
+	assert.Equal(t, feeStatementEventType, comms.events[0].Type)
+	assert.Contains(t, comms.events[0].Message, "alpha Fee statement ")
+	assert.NotContains(t, comms.events[0].Message, "preview")
+
+	// reloading from the state file should resume from the crystallised state
+	m, err = SetupFeeAccountingManager(cfg, "", om, comms)
+	require.NoError(t, err)
+	s, err := m.AccountState("alpha")
+	require.NoError(t, err)
+	assert.Equal(t, "1223.2", s.NetAssetValue.String())
+	assert.Equal(t, "1223.2", s.HighWaterMark.String())
+	assert.Equal(t, "20", s.ManagementFeesCharged.String())
+	assert.Equal(t, "55.8", s.PerformanceFeesCharged.String())
+	assert.True(t, s.LastCrystallised.Equal(now))
+
+	buy := testFeeAccountingOrders(now)[1]
+	buy.Price, buy.Date, buy.LastUpdated = 400, now.Add(time.Minute), now.Add(time.Minute)
+	sell := buy
+	sell.Side, sell.Price, sell.Date, sell.LastUpdated = order.Sell, 300, now.Add(time.Minute*2), now.Add(time.Minute*2)
+	om.orders = append(om.orders, buy, sell)
+	m.crystallise(now.Add(time.Hour))
+	s, err = m.AccountState("alpha")
+	require.NoError(t, err)
+	assert.True(t, s.NetAssetValue.LessThan(decimal.NewFromFloat(1123.2)), "losses and management fees should reduce NAV")
+	assert.Equal(t, "1223.2", s.HighWaterMark.String(), "losses should not lower the high-water mark")
+	assert.Equal(t, "55.8", s.PerformanceFeesCharged.String(), "no performance fee should be charged below the high-water mark")
+}
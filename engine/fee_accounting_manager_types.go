@@ -0,0 +1,99 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/common/cron"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// FeeAccountingManagerName is an exported subsystem name
+const FeeAccountingManagerName = "fee_accounting_manager"
+
+// feeStatementEventType is the communications event type used when sending
+// fee statements
+const feeStatementEventType = "fee_statement"
+
+// feeAccountingStateFile is the default state file name within the data
+// directory
+const feeAccountingStateFile = "feeaccounting.json"
+
+// feeAccountingYear is the period annual management fee rates are charged over
+const feeAccountingYear = time.Hour * 24 * 365
+
+var (
+	errNoManagedAccounts      = errors.New("no managed accounts configured")
+	errInvalidManagedAccount  = errors.New("invalid managed account")
+	errManagedAccountNotFound = errors.New("managed account not found")
+)
+
+// iFeeAccountingOrderManager defines the order manager functions used to
+// calculate managed account profit and loss
+type iFeeAccountingOrderManager interface {
+	GetOrdersSnapshot(order.Status) []order.Detail
+}
+
+// FeeAccountingManager tracks the net asset value and high-water mark of
+// managed accounts from their realised profit and loss, and periodically
+// crystallises management and performance fees into statements
+type FeeAccountingManager struct {
+	started      int32
+	shutdown     chan struct{}
+	wg           sync.WaitGroup
+	verbose      bool
+	schedule     *cron.Schedule
+	stateFile    string
+	accounts     []*managedAccount
+	orderManager iFeeAccountingOrderManager
+	comms        iCommsManager
+	m            sync.Mutex
+	state        map[string]*ManagedAccountState
+}
+
+// managedAccount holds a parsed managed account
+type managedAccount struct {
+	name               string
+	currency           currency.Code
+	strategyTags       map[string]bool
+	exchanges          map[string]bool
+	initialCapital     decimal.Decimal
+	managementFeeRate  decimal.Decimal
+	performanceFeeRate decimal.Decimal
+}
+
+// ManagedAccountState holds a managed account's position as of its last fee
+// crystallisation
+type ManagedAccountState struct {
+	NetAssetValue          decimal.Decimal `json:"netAssetValue"`
+	HighWaterMark          decimal.Decimal `json:"highWaterMark"`
+	LastCrystallised       time.Time       `json:"lastCrystallised"`
+	ManagementFeesCharged  decimal.Decimal `json:"managementFeesCharged"`
+	PerformanceFeesCharged decimal.Decimal `json:"performanceFeesCharged"`
+}
+
+// FeeStatement holds a managed account's profit and loss and the fees charged
+// over a period of time
+type FeeStatement struct {
+	Account      string
+	Currency     currency.Code
+	Start        time.Time
+	End          time.Time
+	OrdersFilled int
+	OpeningNAV   decimal.Decimal
+	RealisedPNL  decimal.Decimal
+	TradingFees  decimal.Decimal
+	// GrossNAV is the net asset value before management and performance fees
+	GrossNAV             decimal.Decimal
+	ManagementFee        decimal.Decimal
+	PerformanceFee       decimal.Decimal
+	ClosingNAV           decimal.Decimal
+	OpeningHighWaterMark decimal.Decimal
+	HighWaterMark        decimal.Decimal
+	// Crystallised is true when the fees have been charged and the account
+	// state updated, rather than the statement being a preview
+	Crystallised bool
+}
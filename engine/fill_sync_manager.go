@@ -0,0 +1,378 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	fillsql "github.com/thrasher-corp/gocryptotrader/database/repository/fill"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/fill"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// SetupFillSyncManager creates a fill sync manager subsystem
+func SetupFillSyncManager(em iExchangeManager, dcm iDatabaseConnectionManager, cfg *config.FillSyncManager) (*FillSyncManager, error) {
+	if em == nil {
+		return nil, errNilExchangeManager
+	}
+	if dcm == nil {
+		return nil, errNilDatabaseConnectionManager
+	}
+	if cfg == nil {
+		return nil, errNilConfig
+	}
+	if cfg.SyncInterval <= 0 {
+		return nil, fmt.Errorf("%w sync interval %v", errInvalidFillSyncDuration, cfg.SyncInterval)
+	}
+	if cfg.Lookback <= 0 {
+		return nil, fmt.Errorf("%w lookback %v", errInvalidFillSyncDuration, cfg.Lookback)
+	}
+	return &FillSyncManager{
+		verbose:         cfg.Verbose,
+		syncInterval:    cfg.SyncInterval,
+		lookback:        cfg.Lookback,
+		exchangeManager: em,
+		database:        dcm.GetInstance(),
+		flushSignal:     make(chan struct{}, 1),
+		stored:          make(chan struct{}),
+		fillSaver:       fillsql.Insert,
+		latestFill:      fillsql.GetLatestTimestamp,
+		fillsSince:      fillsql.GetSinceSequence,
+	}, nil
+}
+
+// Start runs the subsystem
+func (m *FillSyncManager) Start() error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if m.database == nil {
+		return errNilDatabaseConnectionManager
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 0, 1) {
+		return ErrSubSystemAlreadyStarted
+	}
+	m.shutdown = make(chan struct{})
+	m.wg.Add(1)
+	go m.run()
+	log.Debugf(log.OrderMgr, "Fill sync manager %s", MsgSubSystemStarted)
+	return nil
+}
+
+// IsRunning checks whether the subsystem is running
+func (m *FillSyncManager) IsRunning() bool {
+	if m == nil {
+		return false
+	}
+	return atomic.LoadInt32(&m.started) == 1
+}
+
+// Stop stops the subsystem, saving any buffered websocket fills
+func (m *FillSyncManager) Stop() error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 1, 0) {
+		return ErrSubSystemNotStarted
+	}
+	close(m.shutdown)
+	m.wg.Wait()
+	log.Debugf(log.OrderMgr, "Fill sync manager %s", MsgSubSystemShutdown)
+	return nil
+}
+
+// run syncs fills periodically. Unlike the periodic subsystems run on
+// runScheduledJobs, buffered websocket fills are also flushed as soon as they
+// are received and once more on shutdown so none are lost
+func (m *FillSyncManager) run() {
+	defer m.wg.Done()
+	syncTimer := time.NewTimer(0)
+	defer syncTimer.Stop()
+	flush := time.NewTicker(fillFlushInterval)
+	defer flush.Stop()
+	for {
+		select {
+		case <-m.shutdown:
+			m.flushWebsocketFills()
+			return
+		case <-syncTimer.C:
+			m.syncFills()
+			syncTimer.Reset(m.syncInterval)
+		case <-flush.C:
+			m.flushWebsocketFills()
+		case <-m.flushSignal:
+			m.flushWebsocketFills()
+		}
+	}
+}
+
+// syncFills pulls fill history for every enabled asset of all authenticated
+// exchanges
+func (m *FillSyncManager) syncFills() {
+	if !atomic.CompareAndSwapInt32(&m.processing, 0, 1) {
+		return
+	}
+	defer atomic.StoreInt32(&m.processing, 0)
+	if !m.database.IsConnected() {
+		if m.verbose {
+			log.Debugln(log.OrderMgr, "Fill sync manager skipping sync as the database is not connected")
+		}
+		return
+	}
+	exchanges, err := m.exchangeManager.GetExchanges()
+	if err != nil {
+		log.Errorf(log.OrderMgr, "Fill sync manager cannot get exchanges: %v", err)
+		return
+	}
+	for x := range exchanges {
+		if !exchanges[x].IsRESTAuthenticationSupported() {
+			continue
+		}
+		assets := exchanges[x].GetAssetTypes(true)
+		for y := range assets {
+			stored, err := m.syncExchangeAsset(context.TODO(), exchanges[x], assets[y])
+			if err != nil {
+				log.Errorf(log.OrderMgr, "Fill sync manager unable to sync %s %s fills: %v", exchanges[x].GetName(), assets[y], err)
+				continue
+			}
+			if m.verbose || stored > 0 {
+				log.Infof(log.OrderMgr, "Fill sync manager stored %d %s %s fills", stored, exchanges[x].GetName(), assets[y])
+			}
+		}
+	}
+}
+
+// syncExchangeAsset fetches order history since the most recent stored fill
+// and saves its fills. Returns the number of fills stored
+func (m *FillSyncManager) syncExchangeAsset(ctx context.Context, exch exchange.IBotExchange, a asset.Item) (int64, error) {
+	pairs, err := exch.GetEnabledPairs(a)
+	if err != nil {
+		return 0, err
+	}
+	if len(pairs) == 0 {
+		return 0, nil
+	}
+	end := time.Now()
+	start := end.Add(-m.lookback)
+	latest, err := m.latestFill(exch.GetName(), a.String())
+	if err != nil {
+		return 0, err
+	}
+	if from := latest.Add(-fillSyncOverlap); from.After(start) {
+		start = from
+	}
+	orders, err := exch.GetOrderHistory(ctx, &order.MultiOrderRequest{
+		Pairs:     pairs,
+		AssetType: a,
+		Type:      order.AnyType,
+		Side:      order.AnySide,
+		StartTime: start,
+		EndTime:   end,
+	})
+	if err != nil {
+		if errors.Is(err, common.ErrFunctionNotSupported) || errors.Is(err, common.ErrNotYetImplemented) {
+			if m.verbose {
+				log.Debugf(log.OrderMgr, "Fill sync manager %s %s order history unsupported", exch.GetName(), a)
+			}
+			return 0, nil
+		}
+		return 0, err
+	}
+	fills, missing := ordersToFills(exch.GetName(), orders)
+	if missing > 0 && m.verbose {
+		log.Debugf(log.OrderMgr, "Fill sync manager %s %s skipped %d executed orders without fill details", exch.GetName(), a, missing)
+	}
+	if len(fills) == 0 {
+		return 0, nil
+	}
+	return m.save(fills)
+}
+
+// websocketFillHandler buffers fills received via websocket for storage
+func (m *FillSyncManager) websocketFillHandler(_ string, data interface{}) error {
+	if !m.IsRunning() {
+		return nil
+	}
+	fills, ok := data.([]fill.Data)
+	if !ok {
+		return nil
+	}
+	m.m.Lock()
+	for i := range fills {
+		m.buffer = append(m.buffer, websocketFillToData(&fills[i]))
+	}
+	m.m.Unlock()
+	// Flush straight away so fill streams receive websocket fills promptly
+	select {
+	case m.flushSignal <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// flushWebsocketFills saves buffered websocket fills, retaining them while
+// the database is unavailable
+func (m *FillSyncManager) flushWebsocketFills() {
+	m.m.Lock()
+	defer m.m.Unlock()
+	if len(m.buffer) == 0 {
+		return
+	}
+	if !m.database.IsConnected() {
+		if excess := len(m.buffer) - maxBufferedFills; excess > 0 {
+			log.Errorf(log.OrderMgr, "Fill sync manager buffer exceeded %d fills, dropping %d oldest fills", maxBufferedFills, excess)
+			m.buffer = m.buffer[excess:]
+		}
+		return
+	}
+	if _, err := m.save(m.buffer); err != nil {
+		log.Errorf(log.OrderMgr, "Fill sync manager unable to save %d websocket fills: %v", len(m.buffer), err)
+	}
+	m.buffer = nil
+}
+
+// save stores fills, waking fill streams when any are stored
+func (m *FillSyncManager) save(fills []fillsql.Data) (int64, error) {
+	stored, err := m.fillSaver(fills...)
+	if stored > 0 {
+		m.storedMtx.Lock()
+		if m.stored != nil {
+			close(m.stored)
+		}
+		m.stored = make(chan struct{})
+		m.storedMtx.Unlock()
+	}
+	return stored, err
+}
+
+// fillsStored returns a channel which is closed the next time fills are stored
+func (m *FillSyncManager) fillsStored() <-chan struct{} {
+	m.storedMtx.Lock()
+	defer m.storedMtx.Unlock()
+	if m.stored == nil {
+		m.stored = make(chan struct{})
+	}
+	return m.stored
+}
+
+// StreamFills calls fn for every stored fill with a sequence greater than the
+// one supplied, replaying stored fills before following newly stored fills
+// until the context is cancelled, fn errors or the subsystem is stopped
+func (m *FillSyncManager) StreamFills(ctx context.Context, sequence int64, fn func(*fillsql.Data) error) error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if fn == nil {
+		return errNilFillStreamHandler
+	}
+	poll := time.NewTicker(fillStreamPollInterval)
+	defer poll.Stop()
+	for {
+		if !m.IsRunning() {
+			return fmt.Errorf("%s %w", FillSyncManagerName, ErrSubSystemNotStarted)
+		}
+		if !m.database.IsConnected() {
+			return database.ErrDatabaseNotConnected
+		}
+		// Taken before reading so fills stored during the read are not missed
+		stored := m.fillsStored()
+		fills, err := m.fillsSince(sequence, fillStreamBatchSize)
+		if err != nil {
+			return err
+		}
+		for i := range fills {
+			if err := fn(&fills[i]); err != nil {
+				return err
+			}
+			sequence = fills[i].Sequence
+		}
+		if len(fills) == fillStreamBatchSize {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-stored:
+		case <-poll.C:
+		}
+	}
+}
+
+// ordersToFills converts the trades of each order to fills. Returns the number
+// of executed orders which have no trades to convert
+func ordersToFills(exchName string, orders []order.Detail) (fills []fillsql.Data, missing int) {
+	for i := range orders {
+		if len(orders[i].Trades) == 0 {
+			if orders[i].ExecutedAmount > 0 {
+				missing++
+			}
+			continue
+		}
+		for j := range orders[i].Trades {
+			t := &orders[i].Trades[j]
+			side := t.Side
+			if side == order.UnknownSide {
+				side = orders[i].Side
+			}
+			ts := t.Timestamp
+			if ts.IsZero() {
+				ts = orders[i].LastUpdated
+			}
+			fills = append(fills, fillsql.Data{
+				Exchange:      exchName,
+				Base:          orders[i].Pair.Base.String(),
+				Quote:         orders[i].Pair.Quote.String(),
+				AssetType:     orders[i].AssetType.String(),
+				Side:          side.String(),
+				OrderID:       orders[i].OrderID,
+				ClientOrderID: orders[i].ClientOrderID,
+				TradeID:       fillTradeID(t.TID, orders[i].OrderID, ts, t.Price, t.Amount),
+				Price:         t.Price,
+				Amount:        t.Amount,
+				Fee:           t.Fee,
+				FeeAsset:      t.FeeAsset,
+				Source:        fillsql.SourceREST,
+				Timestamp:     ts,
+			})
+		}
+	}
+	return fills, missing
+}
+
+func websocketFillToData(f *fill.Data) fillsql.Data {
+	return fillsql.Data{
+		Exchange:      f.Exchange,
+		Base:          f.CurrencyPair.Base.String(),
+		Quote:         f.CurrencyPair.Quote.String(),
+		AssetType:     f.AssetType.String(),
+		Side:          f.Side.String(),
+		OrderID:       f.OrderID,
+		ClientOrderID: f.ClientOrderID,
+		TradeID:       fillTradeID(f.TradeID, f.OrderID, f.Timestamp, f.Price, f.Amount),
+		Price:         f.Price,
+		Amount:        f.Amount,
+		Source:        fillsql.SourceWebsocket,
+		Timestamp:     f.Timestamp,
+	}
+}
+
+// fillTradeID returns the exchange trade ID, or when unavailable an ID derived
+// from the fill details so websocket and REST fills can be matched. Times are
+// matched to the second as precision varies between exchange APIs
+func fillTradeID(tradeID, orderID string, ts time.Time, price, amount float64) string {
+	if tradeID != "" {
+		return tradeID
+	}
+	return orderID + "-" + strconv.FormatInt(ts.Unix(), 10) + "-" +
+		strconv.FormatFloat(price, 'f', -1, 64) + "-" +
+		strconv.FormatFloat(amount, 'f', -1, 64)
+}
@@ -0,0 +1,306 @@
+package engine
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	fillsql "github.com/thrasher-corp/gocryptotrader/database/repository/fill"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/fill"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+type fillSyncDatabase struct {
+	connected bool
+}
+
+func (f *fillSyncDatabase) IsConnected() bool {
+	return f.connected
+}
+
+func (f *fillSyncDatabase) GetSQL() (*sql.DB, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fillSyncDatabase) GetConfig() *database.Config {
+	return nil
+}
+
+// fillSyncExchange is a fake exchange which returns order history with fills
+type fillSyncExchange struct {
+	exchange.IBotExchange
+	orders []order.Detail
+	err    error
+	req    *order.MultiOrderRequest
+}
+
+func (f *fillSyncExchange) GetName() string {
+	return testExchange
+}
+
+func (f *fillSyncExchange) GetEnabledPairs(asset.Item) (currency.Pairs, error) {
+	return currency.Pairs{currency.NewPair(currency.BTC, currency.USDT)}, nil
+}
+
+func (f *fillSyncExchange) GetOrderHistory(_ context.Context, req *order.MultiOrderRequest) (order.FilteredOrders, error) {
+	f.req = req
+	return f.orders, f.err
+}
+
+type fillSaverRecorder struct {
+	m     sync.Mutex
+	saved []fillsql.Data
+}
+
+func (f *fillSaverRecorder) save(fills ...fillsql.Data) (int64, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	for i := range fills {
+		fills[i].Sequence = int64(len(f.saved) + 1)
+		f.saved = append(f.saved, fills[i])
+	}
+	return int64(len(fills)), nil
+}
+
+func (f *fillSaverRecorder) since(sequence int64, limit int) ([]fillsql.Data, error) {
+	f.m.Lock()
+	defer f.m.Unlock()
+	if sequence >= int64(len(f.saved)) {
+		return nil, nil
+	}
+	fills := f.saved[sequence:]
+	return append([]fillsql.Data(nil), fills[:min(limit, len(fills))]...), nil
+}
+
+func testFillSyncManager(t *testing.T, connected bool) (*FillSyncManager, *fillSaverRecorder) {
+	t.Helper()
+	rec := &fillSaverRecorder{}
+	return &FillSyncManager{
+		syncInterval: time.Hour,
+		lookback:     time.Hour * 24,
+		database:     &fillSyncDatabase{connected: connected},
+		fillSaver:    rec.save,
+		fillsSince:   rec.since,
+		latestFill: func(string, string) (time.Time, error) {
+			return time.Time{}, nil
+		},
+	}, rec
+}
+
+func TestSetupFillSyncManager(t *testing.T) {
+	t.Parallel()
+	_, err := SetupFillSyncManager(nil, nil, nil)
+	assert.ErrorIs(t, err, errNilExchangeManager)
+	_, err = SetupFillSyncManager(NewExchangeManager(), nil, nil)
+	assert.ErrorIs(t, err, errNilDatabaseConnectionManager)
+	_, err = SetupFillSyncManager(NewExchangeManager(), &DatabaseConnectionManager{}, nil)
+	assert.ErrorIs(t, err, errNilConfig)
+	_, err = SetupFillSyncManager(NewExchangeManager(), &DatabaseConnectionManager{}, &config.FillSyncManager{})
+	assert.ErrorIs(t, err, errInvalidFillSyncDuration)
+	_, err = SetupFillSyncManager(NewExchangeManager(), &DatabaseConnectionManager{}, &config.FillSyncManager{SyncInterval: time.Minute})
+	assert.ErrorIs(t, err, errInvalidFillSyncDuration)
+
+	m, err := SetupFillSyncManager(NewExchangeManager(), &DatabaseConnectionManager{}, &config.FillSyncManager{SyncInterval: time.Minute, Lookback: time.Hour})
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, m.syncInterval)
+	assert.Equal(t, time.Hour, m.lookback)
+}
+
+func TestFillSyncManagerStartStop(t *testing.T) {
+	t.Parallel()
+	var m *FillSyncManager
+	assert.ErrorIs(t, m.Start(), ErrNilSubsystem)
+	assert.ErrorIs(t, m.Stop(), ErrNilSubsystem)
+	assert.False(t, m.IsRunning())
+
+	assert.ErrorIs(t, (&FillSyncManager{}).Start(), errNilDatabaseConnectionManager)
+
+	m, _ = testFillSyncManager(t, false)
+	m.exchangeManager = NewExchangeManager()
+	assert.ErrorIs(t, m.Stop(), ErrSubSystemNotStarted)
+	require.NoError(t, m.Start())
+	assert.True(t, m.IsRunning())
+	assert.ErrorIs(t, m.Start(), ErrSubSystemAlreadyStarted)
+	require.NoError(t, m.Stop())
+	assert.False(t, m.IsRunning())
+}
+
+func TestSyncExchangeAsset(t *testing.T) {
+	t.Parallel()
+	m, rec := testFillSyncManager(t, true)
+	latest := time.Now().Add(-time.Hour).Truncate(time.Second)
+	m.latestFill = func(string, string) (time.Time, error) {
+		return latest, nil
+	}
+	pair := currency.NewPair(currency.BTC, currency.USDT)
+	exch := &fillSyncExchange{
+		orders: []order.Detail{
+			{
+				OrderID:        "1",
+				Pair:           pair,
+				AssetType:      asset.Spot,
+				Side:           order.Buy,
+				ExecutedAmount: 2,
+				Trades: []order.TradeHistory{
+					{TID: "a", Price: 100, Amount: 1, Fee: 0.1, FeeAsset: "usdt", Timestamp: latest.Add(time.Minute)},
+					{TID: "b", Price: 101, Amount: 1, Timestamp: latest.Add(time.Minute * 2)},
+				},
+			},
+		},
+	}
+	stored, err := m.syncExchangeAsset(context.Background(), exch, asset.Spot)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), stored)
+	require.Len(t, rec.saved, 2)
+	assert.Equal(t, "a", rec.saved[0].TradeID)
+	assert.Equal(t, fillsql.SourceREST, rec.saved[0].Source)
+	require.NotNil(t, exch.req)
+	assert.Equal(t, latest.Add(-fillSyncOverlap), exch.req.StartTime, "sync should resume from the latest stored fill")
+
+	m.latestFill = func(string, string) (time.Time, error) {
+		return time.Time{}, nil
+	}
+	_, err = m.syncExchangeAsset(context.Background(), exch, asset.Spot)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(-m.lookback), exch.req.StartTime, time.Minute, "sync should start from the lookback")
+
+	exch.err = common.ErrFunctionNotSupported
+	stored, err = m.syncExchangeAsset(context.Background(), exch, asset.Spot)
+	assert.NoError(t, err, "unsupported order history should not error")
+	assert.Zero(t, stored)
+
+	exch.err = errExpectedTestError
+	_, err = m.syncExchangeAsset(context.Background(), exch, asset.Spot)
+	assert.ErrorIs(t, err, errExpectedTestError)
+}
+
+func TestOrdersToFills(t *testing.T) {
+	t.Parallel()
+	ts := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fills, missing := ordersToFills(testExchange, []order.Detail{
+		{OrderID: "1", Side: order.Sell, ExecutedAmount: 1, LastUpdated: ts, Trades: []order.TradeHistory{{Price: 1, Amount: 1}}},
+		{OrderID: "2", ExecutedAmount: 1},
+		{OrderID: "3"},
+	})
+	assert.Equal(t, 1, missing, "executed order without trades should be counted")
+	require.Len(t, fills, 1)
+	assert.Equal(t, order.Sell.String(), fills[0].Side, "trade side should default to the order side")
+	assert.Equal(t, ts, fills[0].Timestamp, "trade time should default to the order update time")
+	assert.Equal(t, fillTradeID("", "1", ts, 1, 1), fills[0].TradeID)
+}
+
+func TestWebsocketFillHandler(t *testing.T) {
+	t.Parallel()
+	m, rec := testFillSyncManager(t, false)
+	f := []fill.Data{{Exchange: testExchange, AssetType: asset.Spot, CurrencyPair: currency.NewPair(currency.BTC, currency.USDT), OrderID: "1", TradeID: "1", Price: 1, Amount: 1}}
+	require.NoError(t, m.websocketFillHandler("", f))
+	assert.Empty(t, m.buffer, "fills should not be buffered when not running")
+
+	m.started = 1
+	require.NoError(t, m.websocketFillHandler("", "not a fill"))
+	m.flushSignal = make(chan struct{}, 1)
+	require.NoError(t, m.websocketFillHandler("", f))
+	require.Len(t, m.buffer, 1)
+	assert.Len(t, m.flushSignal, 1, "websocket fills should trigger a flush")
+
+	m.flushWebsocketFills()
+	assert.Len(t, m.buffer, 1, "fills should be retained while the database is disconnected")
+	assert.Empty(t, rec.saved)
+
+	m.database = &fillSyncDatabase{connected: true}
+	m.flushWebsocketFills()
+	assert.Empty(t, m.buffer)
+	require.Len(t, rec.saved, 1)
+	assert.Equal(t, fillsql.SourceWebsocket, rec.saved[0].Source)
+}
+
+func TestFillTradeID(t *testing.T) {
+	t.Parallel()
+	ts := time.Unix(1700000000, 5)
+	assert.Equal(t, "tid", fillTradeID("tid", "1", ts, 1, 2))
+	assert.Equal(t, "1-1700000000-1.5-2", fillTradeID("", "1", ts, 1.5, 2))
+	assert.Equal(t, fillTradeID("", "1", ts, 1.5, 2), fillTradeID("", "1", ts.Add(time.Millisecond), 1.5, 2), "sub-second precision should be ignored")
+}
+
+func TestFillsStored(t *testing.T) {
+	t.Parallel()
+	m, _ := testFillSyncManager(t, true)
+	stored := m.fillsStored()
+	_, err := m.save(nil)
+	require.NoError(t, err)
+	select {
+	case <-stored:
+		t.Fatal("saving no fills should not notify")
+	default:
+	}
+	_, err = m.save([]fillsql.Data{{TradeID: "1"}})
+	require.NoError(t, err)
+	select {
+	case <-stored:
+	default:
+		t.Fatal("storing fills should notify")
+	}
+	assert.NotEqual(t, stored, m.fillsStored(), "a new channel should be used after notifying")
+}
+
+func TestFillSyncManagerStreamFills(t *testing.T) {
+	t.Parallel()
+	var m *FillSyncManager
+	assert.ErrorIs(t, m.StreamFills(context.Background(), 0, nil), ErrNilSubsystem)
+
+	m, rec := testFillSyncManager(t, false)
+	assert.ErrorIs(t, m.StreamFills(context.Background(), 0, nil), errNilFillStreamHandler)
+	ignore := func(*fillsql.Data) error { return nil }
+	assert.ErrorIs(t, m.StreamFills(context.Background(), 0, ignore), ErrSubSystemNotStarted)
+	m.started = 1
+	assert.ErrorIs(t, m.StreamFills(context.Background(), 0, ignore), database.ErrDatabaseNotConnected)
+
+	m.database = &fillSyncDatabase{connected: true}
+	for i := range fillStreamBatchSize + 2 {
+		_, err := m.save([]fillsql.Data{{TradeID: strconv.Itoa(i)}})
+		require.NoError(t, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	received := make(chan fillsql.Data)
+	errs := make(chan error, 1)
+	go func() {
+		errs <- m.StreamFills(ctx, 1, func(f *fillsql.Data) error {
+			received <- *f
+			return nil
+		})
+	}()
+	for i := int64(2); i <= fillStreamBatchSize+2; i++ {
+		f := <-received
+		require.Equal(t, i, f.Sequence, "stored fills should be replayed in order after the resume token")
+	}
+
+	_, err := m.save([]fillsql.Data{{TradeID: "live"}})
+	require.NoError(t, err)
+	select {
+	case f := <-received:
+		assert.Equal(t, "live", f.TradeID, "newly stored fills should be streamed")
+		assert.Equal(t, int64(fillStreamBatchSize+3), f.Sequence)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for a newly stored fill")
+	}
+	cancel()
+	assert.ErrorIs(t, <-errs, context.Canceled)
+
+	assert.ErrorIs(t, m.StreamFills(context.Background(), 0, func(*fillsql.Data) error {
+		return errExpectedTestError
+	}), errExpectedTestError)
+	assert.Len(t, rec.saved, fillStreamBatchSize+3)
+}
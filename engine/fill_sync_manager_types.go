@@ -0,0 +1,60 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+	fillsql "github.com/thrasher-corp/gocryptotrader/database/repository/fill"
+)
+
+// FillSyncManagerName is an exported subsystem name
+const FillSyncManagerName = "fill_sync_manager"
+
+const (
+	// fillSyncOverlap is subtracted from the most recent stored fill time so
+	// fills reported late by an exchange are not missed. Overlapping fills
+	// are deduplicated on insert
+	fillSyncOverlap = time.Minute * 5
+	// fillFlushInterval is how often buffered websocket fills are retried
+	// while the database is unavailable
+	fillFlushInterval = time.Second * 10
+	// maxBufferedFills limits websocket fills held while the database is
+	// unavailable
+	maxBufferedFills = 10000
+	// fillStreamBatchSize limits the fills read from the database at once
+	// when streaming
+	fillStreamBatchSize = 500
+	// fillStreamPollInterval is how often streams check the database for
+	// fills stored by another process
+	fillStreamPollInterval = time.Second * 5
+)
+
+var (
+	errInvalidFillSyncDuration = errors.New("fill sync duration must be greater than zero")
+	errNilFillStreamHandler    = errors.New("fill stream handler is nil")
+)
+
+// FillSyncManager periodically pulls authenticated fill history from enabled
+// exchanges and stores it alongside fills captured via websocket, so fills
+// which occurred while the bot was offline are accounted for
+type FillSyncManager struct {
+	started         int32
+	processing      int32
+	shutdown        chan struct{}
+	wg              sync.WaitGroup
+	verbose         bool
+	syncInterval    time.Duration
+	lookback        time.Duration
+	exchangeManager iExchangeManager
+	database        database.IDatabase
+	m               sync.Mutex
+	buffer          []fillsql.Data
+	flushSignal     chan struct{}
+	storedMtx       sync.Mutex
+	stored          chan struct{}
+	fillSaver       func(...fillsql.Data) (int64, error)
+	latestFill      func(exchangeName, assetType string) (time.Time, error)
+	fillsSince      func(sequence int64, limit int) ([]fillsql.Data, error)
+}
@@ -54,6 +54,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/poloniex"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/stats"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/transfer"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/yobit"
 	"github.com/thrasher-corp/gocryptotrader/gctscript/vm"
 	"github.com/thrasher-corp/gocryptotrader/log"
@@ -85,6 +86,26 @@ func (bot *Engine) GetSubsystemsStatus() map[string]bool {
 		dispatch.Name:                 dispatch.IsRunning(),
 		dataHistoryManagerName:        bot.dataHistoryManager.IsRunning(),
 		CurrencyStateManagementName:   bot.currencyStateManager.IsRunning(),
+		DigestManagerName:             bot.digestManager.IsRunning(),
+		FillSyncManagerName:           bot.fillSyncManager.IsRunning(),
+		PairRefreshManagerName:        bot.pairRefreshManager.IsRunning(),
+		EarnManagerName:               bot.earnManager.IsRunning(),
+		LendingOptimizerName:          bot.lendingOptimizer.IsRunning(),
+		MemoryManagerName:             bot.memoryManager.IsRunning(),
+		SchedulerName:                 bot.scheduler.IsRunning(),
+		RolloverManagerName:           bot.rolloverManager.IsRunning(),
+		CalendarSpreadManagerName:     bot.calendarSpreadManager.IsRunning(),
+		VolatilitySurfaceManagerName:  bot.volatilitySurface.IsRunning(),
+		MarginMonitorName:             bot.marginMonitor.IsRunning(),
+		ADLMonitorName:                bot.adlMonitor.IsRunning(),
+		ExchangeCalendarName:          bot.exchangeCalendar.IsRunning(),
+		MaintenanceSchedulerName:      bot.maintenanceScheduler.IsRunning(),
+		BasisHarvesterName:            bot.basisHarvester.IsRunning(),
+		AnomalyDetectorName:           bot.anomalyDetector.IsRunning(),
+		DataQualityMonitorName:        bot.dataQualityMonitor.IsRunning(),
+		WebhookListenerName:           bot.webhookListener.IsRunning(),
+		SurveillanceManagerName:       bot.surveillanceManager.IsRunning(),
+		FeeAccountingManagerName:      bot.feeAccountingManager.IsRunning(),
 	}
 }
 
@@ -157,10 +178,14 @@ func (bot *Engine) SetSubsystem(subSystemName string, enable bool) error {
 	case OrderManagerName:
 		if enable {
 			if bot.OrderManager == nil {
-				bot.OrderManager, err = SetupOrderManager(bot.ExchangeManager, bot.CommunicationsManager, &bot.ServicesWG, &bot.Config.OrderManager)
+				o, err := SetupOrderManager(bot.ExchangeManager, bot.CommunicationsManager, &bot.ServicesWG, &bot.Config.OrderManager)
 				if err != nil {
 					return err
 				}
+				if err = o.setTrailingStopStateFile(filepath.Join(bot.Settings.DataDir, trailingStopStateFile)); err != nil {
+					return err
+				}
+				bot.OrderManager = o
 			}
 			return bot.OrderManager.Start()
 		}
@@ -300,14 +325,343 @@ func (bot *Engine) SetSubsystem(subSystemName string, enable bool) error {
 			if bot.currencyStateManager == nil {
 				bot.currencyStateManager, err = SetupCurrencyStateManager(
 					bot.Config.CurrencyStateManager.Delay,
-					bot.ExchangeManager)
+					bot.ExchangeManager,
+					bot.CommunicationsManager)
 				if err != nil {
 					return err
 				}
+				if bot.WithdrawManager != nil {
+					err = bot.currencyStateManager.RegisterStateChangeHandler(bot.WithdrawManager.onCurrencyStateChange)
+					if err != nil {
+						return err
+					}
+				}
 			}
 			return bot.currencyStateManager.Start()
 		}
 		return bot.currencyStateManager.Stop()
+	case DigestManagerName:
+		if enable {
+			if bot.digestManager == nil {
+				if !bot.OrderManager.IsRunning() {
+					return fmt.Errorf("%s %w", OrderManagerName, ErrSubSystemNotStarted)
+				}
+				if !bot.CommunicationsManager.IsRunning() {
+					return fmt.Errorf("%s %w", CommunicationsManagerName, ErrSubSystemNotStarted)
+				}
+				bot.digestManager, err = SetupDigestManager(&bot.Config.Digest, bot.OrderManager, bot.CommunicationsManager)
+				if err != nil {
+					return err
+				}
+			}
+			return bot.digestManager.Start()
+		}
+		return bot.digestManager.Stop()
+	case FillSyncManagerName:
+		if enable {
+			if bot.fillSyncManager == nil {
+				if !bot.DatabaseManager.IsRunning() {
+					return fmt.Errorf("%s %w", DatabaseConnectionManagerName, ErrSubSystemNotStarted)
+				}
+				bot.fillSyncManager, err = SetupFillSyncManager(bot.ExchangeManager, bot.DatabaseManager, &bot.Config.FillSyncManager)
+				if err != nil {
+					return err
+				}
+				if bot.WebsocketRoutineManager != nil {
+					err = bot.WebsocketRoutineManager.registerWebsocketDataHandler(bot.fillSyncManager.websocketFillHandler, false)
+					if err != nil {
+						return err
+					}
+				}
+			}
+			return bot.fillSyncManager.Start()
+		}
+		return bot.fillSyncManager.Stop()
+	case PairRefreshManagerName:
+		if enable {
+			if bot.pairRefreshManager == nil {
+				bot.pairRefreshManager, err = SetupPairRefreshManager(bot.ExchangeManager, &bot.Config.PairRefreshManager)
+				if err != nil {
+					return err
+				}
+			}
+			return bot.pairRefreshManager.Start()
+		}
+		return bot.pairRefreshManager.Stop()
+	case EarnManagerName:
+		if enable {
+			if bot.earnManager == nil {
+				bot.earnManager, err = SetupEarnManager(&bot.Config.EarnManager, bot.Settings.DataDir, bot.ExchangeManager, bot.earnPortfolio())
+				if err != nil {
+					return err
+				}
+			}
+			return bot.earnManager.Start()
+		}
+		return bot.earnManager.Stop()
+	case LendingOptimizerName:
+		if enable {
+			if bot.lendingOptimizer == nil {
+				if bot.WithdrawManager == nil {
+					return fmt.Errorf("%s %w", LendingOptimizerName, errNilWithdrawManager)
+				}
+				var comms iCommsManager
+				if bot.CommunicationsManager.IsRunning() {
+					comms = bot.CommunicationsManager
+				}
+				bot.lendingOptimizer, err = SetupLendingOptimizer(&bot.Config.LendingOptimizer, bot.ExchangeManager, bot.WithdrawManager, comms)
+				if err != nil {
+					return err
+				}
+			}
+			return bot.lendingOptimizer.Start()
+		}
+		return bot.lendingOptimizer.Stop()
+	case MemoryManagerName:
+		if enable {
+			if bot.memoryManager == nil {
+				bot.memoryManager, err = SetupMemoryManager(&bot.Config.MemoryManager)
+				if err != nil {
+					return err
+				}
+			}
+			return bot.memoryManager.Start()
+		}
+		return bot.memoryManager.Stop()
+	case SchedulerName:
+		if enable {
+			if bot.scheduler == nil {
+				bot.scheduler, err = SetupScheduler(&bot.Config.Scheduler, bot.schedulerTaskTypes())
+				if err != nil {
+					return err
+				}
+			}
+			return bot.scheduler.Start()
+		}
+		return bot.scheduler.Stop()
+	case RolloverManagerName:
+		if enable {
+			if bot.rolloverManager == nil {
+				if !bot.OrderManager.IsRunning() {
+					return fmt.Errorf("%s %w", OrderManagerName, ErrSubSystemNotStarted)
+				}
+				if !bot.CommunicationsManager.IsRunning() {
+					return fmt.Errorf("%s %w", CommunicationsManagerName, ErrSubSystemNotStarted)
+				}
+				bot.rolloverManager, err = SetupRolloverManager(&bot.Config.RolloverManager, bot.ExchangeManager, bot.OrderManager, bot.CommunicationsManager, bot.rolloverCalendar(), bot.marketDataQuarantine())
+				if err != nil {
+					return err
+				}
+			}
+			return bot.rolloverManager.Start()
+		}
+		return bot.rolloverManager.Stop()
+	case CalendarSpreadManagerName:
+		if enable {
+			if bot.calendarSpreadManager == nil {
+				if !bot.CommunicationsManager.IsRunning() {
+					return fmt.Errorf("%s %w", CommunicationsManagerName, ErrSubSystemNotStarted)
+				}
+				var dcm iDatabaseConnectionManager
+				if bot.Config.CalendarSpread.StoreHistory {
+					if !bot.DatabaseManager.IsRunning() {
+						return fmt.Errorf("%s %w", DatabaseConnectionManagerName, ErrSubSystemNotStarted)
+					}
+					dcm = bot.DatabaseManager
+				}
+				bot.calendarSpreadManager, err = SetupCalendarSpreadManager(&bot.Config.CalendarSpread, bot.ExchangeManager, bot.CommunicationsManager, dcm)
+				if err != nil {
+					return err
+				}
+			}
+			return bot.calendarSpreadManager.Start()
+		}
+		return bot.calendarSpreadManager.Stop()
+	case VolatilitySurfaceManagerName:
+		if enable {
+			if bot.volatilitySurface == nil {
+				bot.volatilitySurface, err = SetupVolatilitySurfaceManager(&bot.Config.VolatilitySurface, bot.ExchangeManager)
+				if err != nil {
+					return err
+				}
+			}
+			return bot.volatilitySurface.Start()
+		}
+		return bot.volatilitySurface.Stop()
+	case MarginMonitorName:
+		if enable {
+			if bot.marginMonitor == nil {
+				if !bot.CommunicationsManager.IsRunning() {
+					return fmt.Errorf("%s %w", CommunicationsManagerName, ErrSubSystemNotStarted)
+				}
+				var om iMarginOrderManager
+				if bot.Config.MarginMonitor.Deleverage.Enabled {
+					if !bot.OrderManager.IsRunning() {
+						return fmt.Errorf("%s %w", OrderManagerName, ErrSubSystemNotStarted)
+					}
+					om = bot.OrderManager
+				}
+				var mm *MarginMonitor
+				mm, err = SetupMarginMonitor(&bot.Config.MarginMonitor, om, bot.CommunicationsManager)
+				if err != nil {
+					return err
+				}
+				if err = bot.WebsocketRoutineManager.registerWebsocketDataHandler(mm.websocketMarginHandler, false); err != nil {
+					return err
+				}
+				bot.marginMonitor = mm
+			}
+			return bot.marginMonitor.Start()
+		}
+		return bot.marginMonitor.Stop()
+	case ADLMonitorName:
+		if enable {
+			if bot.adlMonitor == nil {
+				if !bot.CommunicationsManager.IsRunning() {
+					return fmt.Errorf("%s %w", CommunicationsManagerName, ErrSubSystemNotStarted)
+				}
+				bot.adlMonitor, err = SetupADLMonitor(&bot.Config.ADLMonitor, bot.ExchangeManager, bot.CommunicationsManager)
+				if err != nil {
+					return err
+				}
+			}
+			return bot.adlMonitor.Start()
+		}
+		return bot.adlMonitor.Stop()
+	case ExchangeCalendarName:
+		if enable {
+			if bot.exchangeCalendar == nil {
+				var comms iCommsManager
+				if bot.CommunicationsManager.IsRunning() {
+					comms = bot.CommunicationsManager
+				}
+				bot.exchangeCalendar, err = SetupExchangeCalendar(&bot.Config.ExchangeCalendar, bot.ExchangeManager, comms)
+				if err != nil {
+					return err
+				}
+			}
+			return bot.exchangeCalendar.Start()
+		}
+		return bot.exchangeCalendar.Stop()
+	case MaintenanceSchedulerName:
+		if enable {
+			if bot.maintenanceScheduler == nil {
+				var comms iCommsManager
+				if bot.CommunicationsManager.IsRunning() {
+					comms = bot.CommunicationsManager
+				}
+				bot.maintenanceScheduler, err = SetupMaintenanceScheduler(&bot.Config.MaintenanceScheduler, bot.Config.Exchanges, bot.ExchangeManager, comms)
+				if err != nil {
+					return err
+				}
+				bot.OrderManager.setMaintenanceSchedule(bot.maintenanceScheduler)
+				bot.CommunicationsManager.setMaintenanceSchedule(bot.maintenanceScheduler)
+			}
+			return bot.maintenanceScheduler.Start()
+		}
+		return bot.maintenanceScheduler.Stop()
+	case BasisHarvesterName:
+		if enable {
+			if bot.basisHarvester == nil {
+				if !bot.OrderManager.IsRunning() {
+					return fmt.Errorf("%s %w", OrderManagerName, ErrSubSystemNotStarted)
+				}
+				if !bot.CommunicationsManager.IsRunning() {
+					return fmt.Errorf("%s %w", CommunicationsManagerName, ErrSubSystemNotStarted)
+				}
+				bot.basisHarvester, err = SetupBasisHarvester(&bot.Config.BasisHarvester, bot.ExchangeManager, bot.OrderManager, bot.CommunicationsManager, bot.basisMarginMonitor(), bot.marketDataQuarantine())
+				if err != nil {
+					return err
+				}
+			}
+			return bot.basisHarvester.Start()
+		}
+		return bot.basisHarvester.Stop()
+	case AnomalyDetectorName:
+		if enable {
+			if bot.anomalyDetector == nil {
+				if !bot.CommunicationsManager.IsRunning() {
+					return fmt.Errorf("%s %w", CommunicationsManagerName, ErrSubSystemNotStarted)
+				}
+				var a *AnomalyDetector
+				a, err = SetupAnomalyDetector(&bot.Config.AnomalyDetector, bot.CommunicationsManager)
+				if err != nil {
+					return err
+				}
+				if err = bot.WebsocketRoutineManager.registerWebsocketDataHandler(a.websocketAnomalyHandler, false); err != nil {
+					return err
+				}
+				bot.anomalyDetector = a
+			}
+			return bot.anomalyDetector.Start()
+		}
+		return bot.anomalyDetector.Stop()
+	case DataQualityMonitorName:
+		if enable {
+			if bot.dataQualityMonitor == nil {
+				var d *DataQualityMonitor
+				d, err = SetupDataQualityMonitor(&bot.Config.DataQuality, bot.ExchangeManager)
+				if err != nil {
+					return err
+				}
+				if err = bot.WebsocketRoutineManager.registerWebsocketDataHandler(d.websocketDataQualityHandler, false); err != nil {
+					return err
+				}
+				bot.dataQualityMonitor = d
+			}
+			return bot.dataQualityMonitor.Start()
+		}
+		return bot.dataQualityMonitor.Stop()
+	case WebhookListenerName:
+		if enable {
+			if bot.webhookListener == nil {
+				if bot.WebsocketRoutineManager == nil {
+					return fmt.Errorf("%s requires the websocket routine manager: %w", WebhookListenerName, ErrNilSubsystem)
+				}
+				var l *WebhookListener
+				l, err = SetupWebhookListener(&bot.Config.WebhookListener, bot.WebsocketRoutineManager)
+				if err != nil {
+					return err
+				}
+				bot.webhookListener = l
+			}
+			return bot.webhookListener.Start()
+		}
+		return bot.webhookListener.Stop()
+	case SurveillanceManagerName:
+		if enable {
+			if bot.surveillanceManager == nil {
+				if !bot.OrderManager.IsRunning() {
+					return fmt.Errorf("%s %w", OrderManagerName, ErrSubSystemNotStarted)
+				}
+				if !bot.CommunicationsManager.IsRunning() {
+					return fmt.Errorf("%s %w", CommunicationsManagerName, ErrSubSystemNotStarted)
+				}
+				bot.surveillanceManager, err = SetupSurveillanceManager(&bot.Config.SurveillanceManager, bot.OrderManager, bot.CommunicationsManager)
+				if err != nil {
+					return err
+				}
+			}
+			return bot.surveillanceManager.Start()
+		}
+		return bot.surveillanceManager.Stop()
+	case FeeAccountingManagerName:
+		if enable {
+			if bot.feeAccountingManager == nil {
+				if !bot.OrderManager.IsRunning() {
+					return fmt.Errorf("%s %w", OrderManagerName, ErrSubSystemNotStarted)
+				}
+				if !bot.CommunicationsManager.IsRunning() {
+					return fmt.Errorf("%s %w", CommunicationsManagerName, ErrSubSystemNotStarted)
+				}
+				bot.feeAccountingManager, err = SetupFeeAccountingManager(&bot.Config.FeeAccounting, bot.Settings.DataDir, bot.OrderManager, bot.CommunicationsManager)
+				if err != nil {
+					return err
+				}
+			}
+			return bot.feeAccountingManager.Start()
+		}
+		return bot.feeAccountingManager.Stop()
 	}
 	return fmt.Errorf("%s: %w", subSystemName, errSubsystemNotFound)
 }
@@ -712,9 +1066,37 @@ func (bot *Engine) GetExchangeCryptocurrencyDepositAddress(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+	c, err := getTransferNetwork(ctx, exch, item, chain)
+	if err != nil {
+		return nil, err
+	}
+	if c != nil {
+		if err = c.CanDeposit(); err != nil {
+			return nil, err
+		}
+		chain = c.Name
+	}
 	return exch.GetDepositAddress(ctx, item, accountID, chain)
 }
 
+// getTransferNetwork returns the exchange's chain matching a normalised
+// network or exchange chain name. A nil chain is returned when no chain is
+// specified or the exchange does not support transfer networks, in which case
+// the chain is passed to the exchange as is
+func getTransferNetwork(ctx context.Context, exch exchange.IBotExchange, c currency.Code, chain string) (*transfer.Chain, error) {
+	if chain == "" {
+		return nil, nil
+	}
+	chains, err := exch.GetTransferNetworks(ctx, c)
+	if err != nil {
+		if errors.Is(err, common.ErrFunctionNotSupported) || errors.Is(err, common.ErrNotYetImplemented) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return transfer.FindChain(chains, chain)
+}
+
 // GetAllExchangeCryptocurrencyDepositAddresses obtains an exchanges deposit cryptocurrency list
 func (bot *Engine) GetAllExchangeCryptocurrencyDepositAddresses() map[string]map[string][]deposit.Address {
 	result := make(map[string]map[string][]deposit.Address)
@@ -1064,3 +1446,48 @@ func NewExchangeByNameWithDefaults(ctx context.Context, name string) (exchange.I
 	}
 	return exch, nil
 }
+
+// rolloverCalendar returns the exchange calendar used by the rollover manager,
+// or nil when the calendar has not been set up
+func (bot *Engine) rolloverCalendar() iExchangeCalendar {
+	if bot.exchangeCalendar == nil {
+		return nil
+	}
+	return bot.exchangeCalendar
+}
+
+// earnPortfolio returns the portfolio earn balances are reported to, or nil
+// when the portfolio manager is not running
+func (bot *Engine) earnPortfolio() iEarnPortfolio {
+	if !bot.portfolioManager.IsRunning() {
+		return nil
+	}
+	return bot.portfolioManager
+}
+
+// basisMarginMonitor returns the margin monitor used by the basis harvester,
+// or nil when the monitor has not been set up
+func (bot *Engine) basisMarginMonitor() iBasisMarginMonitor {
+	if bot.marginMonitor == nil {
+		return nil
+	}
+	return bot.marginMonitor
+}
+
+// marketDataQuarantine returns the anomaly detector used by strategies to
+// withhold trades on suspect market data, or nil when the detector has not
+// been set up
+func (bot *Engine) marketDataQuarantine() iMarketDataQuarantine {
+	if bot.anomalyDetector == nil {
+		return nil
+	}
+	return bot.anomalyDetector
+}
+
+// isFeatureUnsupported returns whether an error is due to an exchange or asset
+// not supporting the requested functionality
+func isFeatureUnsupported(err error) bool {
+	return errors.Is(err, common.ErrFunctionNotSupported) ||
+		errors.Is(err, common.ErrNotYetImplemented) ||
+		errors.Is(err, asset.ErrNotSupported)
+}
@@ -18,6 +18,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/common/convert"
 	"github.com/thrasher-corp/gocryptotrader/common/file"
@@ -34,6 +35,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/protocol"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/stats"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/transfer"
 	"github.com/thrasher-corp/gocryptotrader/gctscript/vm"
 	"github.com/thrasher-corp/gocryptotrader/log"
 )
@@ -100,8 +102,8 @@ func CreateTestBot(tb testing.TB) *Engine {
 
 func TestGetSubsystemsStatus(t *testing.T) {
 	m := (&Engine{}).GetSubsystemsStatus()
-	if len(m) != 15 {
-		t.Fatalf("subsystem count is wrong expecting: %d but received: %d", 15, len(m))
+	if len(m) != 35 {
+		t.Fatalf("subsystem count is wrong expecting: %d but received: %d", 35, len(m))
 	}
 }
 
@@ -213,6 +215,126 @@ func TestSetSubsystem(t *testing.T) { //nolint // TO-DO: Fix race t.Parallel() u
 			EnableError:  nil,
 			DisableError: nil,
 		},
+		{
+			Subsystem:    DigestManagerName,
+			Engine:       &Engine{Config: &config.Config{}},
+			EnableError:  ErrSubSystemNotStarted,
+			DisableError: ErrNilSubsystem,
+		},
+		{
+			Subsystem:    FillSyncManagerName,
+			Engine:       &Engine{Config: &config.Config{}},
+			EnableError:  ErrSubSystemNotStarted,
+			DisableError: ErrNilSubsystem,
+		},
+		{
+			Subsystem:    PairRefreshManagerName,
+			Engine:       &Engine{Config: &config.Config{}},
+			EnableError:  errInvalidPairRefreshInterval,
+			DisableError: ErrNilSubsystem,
+		},
+		{
+			Subsystem:    EarnManagerName,
+			Engine:       &Engine{Config: &config.Config{}},
+			EnableError:  errInvalidEarnSyncDuration,
+			DisableError: ErrNilSubsystem,
+		},
+		{
+			Subsystem:    LendingOptimizerName,
+			Engine:       &Engine{Config: &config.Config{}},
+			EnableError:  errNilWithdrawManager,
+			DisableError: ErrNilSubsystem,
+		},
+		{
+			Subsystem:    MemoryManagerName,
+			Engine:       &Engine{Config: &config.Config{}},
+			EnableError:  errInvalidMemoryCheckInterval,
+			DisableError: ErrNilSubsystem,
+		},
+		{
+			Subsystem:    SchedulerName,
+			Engine:       &Engine{Config: &config.Config{}},
+			EnableError:  nil,
+			DisableError: nil,
+		},
+		{
+			Subsystem:    RolloverManagerName,
+			Engine:       &Engine{Config: &config.Config{}},
+			EnableError:  ErrSubSystemNotStarted,
+			DisableError: ErrNilSubsystem,
+		},
+		{
+			Subsystem:    CalendarSpreadManagerName,
+			Engine:       &Engine{Config: &config.Config{}},
+			EnableError:  ErrSubSystemNotStarted,
+			DisableError: ErrNilSubsystem,
+		},
+		{
+			Subsystem:    VolatilitySurfaceManagerName,
+			Engine:       &Engine{Config: &config.Config{}, ExchangeManager: NewExchangeManager()},
+			EnableError:  errInvalidVolatilitySurfaceConfig,
+			DisableError: ErrNilSubsystem,
+		},
+		{
+			Subsystem:    MarginMonitorName,
+			Engine:       &Engine{Config: &config.Config{}},
+			EnableError:  ErrSubSystemNotStarted,
+			DisableError: ErrNilSubsystem,
+		},
+		{
+			Subsystem:    ADLMonitorName,
+			Engine:       &Engine{Config: &config.Config{}},
+			EnableError:  ErrSubSystemNotStarted,
+			DisableError: ErrNilSubsystem,
+		},
+		{
+			Subsystem:    ExchangeCalendarName,
+			Engine:       &Engine{Config: &config.Config{}},
+			EnableError:  errInvalidCalendarDuration,
+			DisableError: ErrNilSubsystem,
+		},
+		{
+			Subsystem:    MaintenanceSchedulerName,
+			Engine:       &Engine{Config: &config.Config{}},
+			EnableError:  errInvalidMaintenanceConfig,
+			DisableError: ErrNilSubsystem,
+		},
+		{
+			Subsystem:    BasisHarvesterName,
+			Engine:       &Engine{Config: &config.Config{}},
+			EnableError:  ErrSubSystemNotStarted,
+			DisableError: ErrNilSubsystem,
+		},
+		{
+			Subsystem:    AnomalyDetectorName,
+			Engine:       &Engine{Config: &config.Config{}},
+			EnableError:  ErrSubSystemNotStarted,
+			DisableError: ErrNilSubsystem,
+		},
+		{
+			Subsystem:    DataQualityMonitorName,
+			Engine:       &Engine{Config: &config.Config{}},
+			EnableError:  errInvalidDataQualityConfig,
+			DisableError: ErrNilSubsystem,
+		},
+		{
+			Subsystem:    WebhookListenerName,
+			Engine:       &Engine{Config: &config.Config{}},
+			EnableError:  ErrNilSubsystem,
+			DisableError: ErrNilSubsystem,
+		},
+		{
+			Subsystem:    SurveillanceManagerName,
+			Engine:       &Engine{Config: &config.Config{}},
+			EnableError:  ErrSubSystemNotStarted,
+			DisableError: ErrNilSubsystem,
+		},
+		{
+			Subsystem:    FeeAccountingManagerName,
+			Engine:       &Engine{Config: &config.Config{}},
+			EnableError:  ErrSubSystemNotStarted,
+			DisableError: ErrNilSubsystem,
+		},
 	}
 
 	for _, tt := range testCases {
@@ -1036,11 +1158,21 @@ func (f fakeDepositExchange) GetAvailableTransferChains(_ context.Context, c cur
 	return []string{"BITCOIN"}, nil
 }
 
-func (f fakeDepositExchange) GetDepositAddress(_ context.Context, _ currency.Code, _, _ string) (*deposit.Address, error) {
+func (f fakeDepositExchange) GetTransferNetworks(_ context.Context, c currency.Code) ([]transfer.Chain, error) {
+	if !c.Equal(currency.USDT) {
+		return nil, common.ErrFunctionNotSupported
+	}
+	return []transfer.Chain{
+		{Network: transfer.ERC20, Name: "USDT-ERC20", DepositEnabled: true},
+		{Network: transfer.TRC20, Name: "USDT-TRC20"},
+	}, nil
+}
+
+func (f fakeDepositExchange) GetDepositAddress(_ context.Context, _ currency.Code, _, chain string) (*deposit.Address, error) {
 	if f.ThrowDepositAddressError {
 		return nil, errors.New("unable to get deposit address")
 	}
-	return &deposit.Address{Address: "fakeaddr"}, nil
+	return &deposit.Address{Address: "fakeaddr", Chain: chain}, nil
 }
 
 func createDepositEngine(opts *fakeDepositExchangeOpts) *Engine {
@@ -1136,6 +1268,21 @@ func TestGetExchangeCryptocurrencyDepositAddress(t *testing.T) {
 	}
 }
 
+func TestGetExchangeCryptocurrencyDepositAddressNetwork(t *testing.T) {
+	t.Parallel()
+	e := createDepositEngine(&fakeDepositExchangeOpts{SupportsAuth: true, SupportsMultiChain: true})
+	r, err := e.GetExchangeCryptocurrencyDepositAddress(context.Background(), "fake", "", "ethereum", currency.USDT, false)
+	require.NoError(t, err)
+	assert.Equal(t, "USDT-ERC20", r.Chain, "normalised networks should be requested with the exchange's chain name")
+	_, err = e.GetExchangeCryptocurrencyDepositAddress(context.Background(), "fake", "", "TRX", currency.USDT, false)
+	assert.ErrorIs(t, err, transfer.ErrDepositsDisabled)
+	_, err = e.GetExchangeCryptocurrencyDepositAddress(context.Background(), "fake", "", "SOL", currency.USDT, false)
+	assert.ErrorIs(t, err, transfer.ErrNetworkNotFound)
+	r, err = e.GetExchangeCryptocurrencyDepositAddress(context.Background(), "fake", "", "bitcoin", currency.BTC, false)
+	require.NoError(t, err)
+	assert.Equal(t, "bitcoin", r.Chain, "chains should be passed through when transfer networks are not supported")
+}
+
 func TestGetAllExchangeCryptocurrencyDepositAddresses(t *testing.T) {
 	t.Parallel()
 	e := createDepositEngine(&fakeDepositExchangeOpts{})
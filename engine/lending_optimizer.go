@@ -0,0 +1,424 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/earn"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
+)
+
+// SetupLendingOptimizer creates a lending optimizer subsystem. The
+// communications manager is optional, when set transfers and subscriptions
+// are reported through it
+func SetupLendingOptimizer(cfg *config.LendingOptimizer, em iExchangeManager, wm iLendingWithdrawManager, comms iCommsManager) (*LendingOptimizer, error) {
+	if cfg == nil {
+		return nil, errNilConfig
+	}
+	if em == nil {
+		return nil, errNilExchangeManager
+	}
+	if wm == nil {
+		return nil, errNilWithdrawManager
+	}
+	if cfg.CheckInterval <= 0 {
+		return nil, fmt.Errorf("%w check interval %v", errInvalidLendingOptimizerConfig, cfg.CheckInterval)
+	}
+	if len(cfg.Currencies) == 0 {
+		return nil, fmt.Errorf("%w no currencies configured", errInvalidLendingOptimizerConfig)
+	}
+	if cfg.MinRateImprovement <= 0 {
+		return nil, fmt.Errorf("%w min rate improvement %v", errInvalidLendingOptimizerConfig, cfg.MinRateImprovement)
+	}
+	if cfg.MinAmount < 0 || cfg.MaxTransferAmount < 0 || cfg.Reserve < 0 {
+		return nil, fmt.Errorf("%w amounts cannot be negative", errInvalidLendingOptimizerConfig)
+	}
+	currencies := make([]currency.Code, len(cfg.Currencies))
+	for i := range cfg.Currencies {
+		if cfg.Currencies[i] == "" {
+			return nil, fmt.Errorf("%w currency %d unset", errInvalidLendingOptimizerConfig, i)
+		}
+		currencies[i] = currency.NewCode(strings.ToUpper(cfg.Currencies[i]))
+	}
+	return &LendingOptimizer{
+		verbose:            cfg.Verbose,
+		dryRun:             cfg.DryRun == nil || *cfg.DryRun,
+		interval:           cfg.CheckInterval,
+		currencies:         currencies,
+		exchanges:          cfg.Exchanges,
+		minRateImprovement: cfg.MinRateImprovement,
+		minAmount:          cfg.MinAmount,
+		maxTransferAmount:  cfg.MaxTransferAmount,
+		reserve:            cfg.Reserve,
+		network:            cfg.Network,
+		exchangeManager:    em,
+		withdrawManager:    wm,
+		comms:              comms,
+	}, nil
+}
+
+// Start runs the subsystem
+func (m *LendingOptimizer) Start() error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 0, 1) {
+		return ErrSubSystemAlreadyStarted
+	}
+	m.shutdown = make(chan struct{})
+	m.wg.Add(1)
+	go m.run()
+	if m.dryRun {
+		log.Infoln(log.PortfolioMgr, "Lending optimizer running in dry run mode, no funds will be moved")
+	}
+	log.Debugf(log.PortfolioMgr, "Lending optimizer %s", MsgSubSystemStarted)
+	return nil
+}
+
+// IsRunning checks whether the subsystem is running
+func (m *LendingOptimizer) IsRunning() bool {
+	if m == nil {
+		return false
+	}
+	return atomic.LoadInt32(&m.started) == 1
+}
+
+// Stop stops the subsystem
+func (m *LendingOptimizer) Stop() error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 1, 0) {
+		return ErrSubSystemNotStarted
+	}
+	close(m.shutdown)
+	m.wg.Wait()
+	log.Debugf(log.PortfolioMgr, "Lending optimizer %s", MsgSubSystemShutdown)
+	return nil
+}
+
+func (m *LendingOptimizer) run() {
+	defer m.wg.Done()
+	runScheduledJobs(m.shutdown, &scheduledJob{interval: m.interval, immediate: true, run: func(ctx context.Context, _ time.Time) {
+		if err := m.Check(ctx); err != nil {
+			log.Errorf(log.PortfolioMgr, "Lending optimizer: %v", err)
+		}
+	}})
+}
+
+// lendingBalance is an idle balance available to lend
+type lendingBalance struct {
+	exchange string
+	currency currency.Code
+	amount   float64
+}
+
+// Check compares the flexible earn rates of every venue, then subscribes or
+// moves idle balances which can earn a better rate
+func (m *LendingOptimizer) Check(ctx context.Context) error {
+	if m == nil {
+		return fmt.Errorf("%s %w", LendingOptimizerName, ErrNilSubsystem)
+	}
+	exchanges, err := m.venues()
+	if err != nil {
+		return err
+	}
+	var errs error
+	var rates []LendingRate
+	var balances []lendingBalance
+	for i := range exchanges {
+		r, err := m.exchangeRates(ctx, exchanges[i])
+		if err != nil {
+			if !isEarnUnsupported(err) {
+				errs = common.AppendError(errs, fmt.Errorf("unable to get %s lending rates: %w", exchanges[i].GetName(), err))
+			}
+			continue
+		}
+		rates = append(rates, r...)
+		b, err := m.idleBalances(ctx, exchanges[i])
+		if err != nil {
+			errs = common.AppendError(errs, fmt.Errorf("unable to get %s idle balances: %w", exchanges[i].GetName(), err))
+			continue
+		}
+		balances = append(balances, b...)
+	}
+
+	opportunities := findLendingOpportunities(rates, balances, m.minRateImprovement, m.maxTransferAmount, m.network != "")
+	for i := range opportunities {
+		if err := m.act(ctx, &opportunities[i]); err != nil {
+			opportunities[i].Status = err.Error()
+			errs = common.AppendError(errs, fmt.Errorf("unable to lend %v %s on %s: %w", opportunities[i].Amount, opportunities[i].Currency, opportunities[i].To, err))
+		}
+	}
+
+	m.m.Lock()
+	m.rates = rates
+	m.opportunities = opportunities
+	m.checked = time.Now()
+	m.m.Unlock()
+	return errs
+}
+
+// venues returns the enabled exchanges with authenticated API support which
+// are compared
+func (m *LendingOptimizer) venues() ([]exchange.IBotExchange, error) {
+	exchanges, err := m.exchangeManager.GetExchanges()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get exchanges: %w", err)
+	}
+	venues := make([]exchange.IBotExchange, 0, len(exchanges))
+	for i := range exchanges {
+		if !exchanges[i].IsEnabled() || !exchanges[i].IsRESTAuthenticationSupported() {
+			continue
+		}
+		if len(m.exchanges) != 0 && !slices.ContainsFunc(m.exchanges, func(e string) bool {
+			return strings.EqualFold(e, exchanges[i].GetName())
+		}) {
+			continue
+		}
+		venues = append(venues, exchanges[i])
+	}
+	return venues, nil
+}
+
+// exchangeRates returns the best available flexible product of each currency
+// offered by an exchange
+func (m *LendingOptimizer) exchangeRates(ctx context.Context, exch exchange.IBotExchange) ([]LendingRate, error) {
+	var rates []LendingRate
+	for _, c := range m.currencies {
+		products, err := exch.GetEarnProducts(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+		var best *earn.Product
+		for i := range products {
+			if products[i].Type != earn.Flexible || !products[i].Available || !products[i].Currency.Equal(c) {
+				continue
+			}
+			if best == nil || products[i].APR > best.APR {
+				best = &products[i]
+			}
+		}
+		if best == nil {
+			continue
+		}
+		rates = append(rates, LendingRate{
+			Exchange:  exch.GetName(),
+			Currency:  c,
+			ProductID: best.ID,
+			APR:       best.APR,
+		})
+	}
+	return rates, nil
+}
+
+// idleBalances returns the free spot balances of an exchange, less the
+// reserve, which are large enough to lend
+func (m *LendingOptimizer) idleBalances(ctx context.Context, exch exchange.IBotExchange) ([]lendingBalance, error) {
+	holdings, err := exch.UpdateAccountInfo(ctx, asset.Spot)
+	if err != nil {
+		return nil, err
+	}
+	var balances []lendingBalance
+	for _, c := range m.currencies {
+		var free float64
+		for i := range holdings.Accounts {
+			if holdings.Accounts[i].AssetType != asset.Spot {
+				continue
+			}
+			for j := range holdings.Accounts[i].Currencies {
+				if holdings.Accounts[i].Currencies[j].Currency.Equal(c) {
+					free += holdings.Accounts[i].Currencies[j].Free
+				}
+			}
+		}
+		if amount := free - m.reserve; amount > 0 && amount >= m.minAmount {
+			balances = append(balances, lendingBalance{exchange: exch.GetName(), currency: c, amount: amount})
+		}
+	}
+	return balances, nil
+}
+
+// findLendingOpportunities returns how each idle balance should be lent. A
+// balance is moved to the exchange offering the best rate when transfers are
+// allowed and the rate improves on the exchange holding it by at least the
+// minimum, otherwise it is subscribed where it is held
+func findLendingOpportunities(rates []LendingRate, balances []lendingBalance, minRateImprovement, maxTransferAmount float64, transfers bool) []LendingOpportunity {
+	var opportunities []LendingOpportunity
+	for i := range balances {
+		var local, best *LendingRate
+		for j := range rates {
+			if !rates[j].Currency.Equal(balances[i].currency) {
+				continue
+			}
+			if rates[j].Exchange == balances[i].exchange {
+				local = &rates[j]
+			}
+			if best == nil || rates[j].APR > best.APR {
+				best = &rates[j]
+			}
+		}
+		var localAPR float64
+		if local != nil {
+			localAPR = local.APR
+		}
+		switch {
+		case transfers && best != nil && best.Exchange != balances[i].exchange && best.APR-localAPR >= minRateImprovement:
+			amount := balances[i].amount
+			if maxTransferAmount > 0 {
+				amount = min(amount, maxTransferAmount)
+			}
+			opportunities = append(opportunities, LendingOpportunity{
+				Currency:  balances[i].currency,
+				From:      balances[i].exchange,
+				To:        best.Exchange,
+				ProductID: best.ProductID,
+				FromAPR:   localAPR,
+				ToAPR:     best.APR,
+				Amount:    amount,
+			})
+		case local != nil:
+			opportunities = append(opportunities, LendingOpportunity{
+				Currency:  balances[i].currency,
+				From:      balances[i].exchange,
+				To:        balances[i].exchange,
+				ProductID: local.ProductID,
+				FromAPR:   localAPR,
+				ToAPR:     localAPR,
+				Amount:    balances[i].amount,
+			})
+		}
+	}
+	return opportunities
+}
+
+// act subscribes or transfers the idle balance of an opportunity
+func (m *LendingOptimizer) act(ctx context.Context, o *LendingOpportunity) error {
+	if o.IsTransfer() {
+		return m.transfer(ctx, o)
+	}
+	if m.dryRun {
+		o.Status = "dry run"
+		if m.verbose {
+			log.Infof(log.PortfolioMgr, "Lending optimizer would subscribe %v %s to %s product %s at %.2f%% APR", o.Amount, o.Currency, o.To, o.ProductID, o.ToAPR*100)
+		}
+		return nil
+	}
+	exch, err := m.exchangeManager.GetExchangeByName(o.To)
+	if err != nil {
+		return err
+	}
+	if _, err = exch.SubscribeEarnProduct(ctx, &earn.SubscribeRequest{
+		ProductID: o.ProductID,
+		Currency:  o.Currency,
+		Type:      earn.Flexible,
+		Amount:    o.Amount,
+	}); err != nil {
+		return err
+	}
+	o.Status = "subscribed"
+	m.notify(o.To, fmt.Sprintf("Lending optimizer subscribed %v %s to %s product %s at %.2f%% APR", o.Amount, o.Currency, o.To, o.ProductID, o.ToAPR*100))
+	return nil
+}
+
+// transfer withdraws an idle balance to the deposit address of the exchange
+// offering the best rate. Transfers are skipped when the withdrawal fee
+// exceeds a year of additional interest
+func (m *LendingOptimizer) transfer(ctx context.Context, o *LendingOpportunity) error {
+	from, err := m.exchangeManager.GetExchangeByName(o.From)
+	if err != nil {
+		return err
+	}
+	to, err := m.exchangeManager.GetExchangeByName(o.To)
+	if err != nil {
+		return err
+	}
+	src, err := getTransferNetwork(ctx, from, o.Currency, m.network)
+	if err != nil {
+		return err
+	}
+	if src != nil {
+		if err = src.CanWithdraw(o.Amount); err != nil {
+			return err
+		}
+		o.Fee = src.WithdrawFee
+	}
+	if o.Fee >= o.Amount*(o.ToAPR-o.FromAPR) {
+		o.Status = "skipped, withdrawal fee exceeds a year of additional interest"
+		return nil
+	}
+	chain := m.network
+	dst, err := getTransferNetwork(ctx, to, o.Currency, m.network)
+	if err != nil {
+		return err
+	}
+	if dst != nil {
+		if err = dst.CanDeposit(); err != nil {
+			return err
+		}
+		chain = dst.Name
+	}
+	addr, err := to.GetDepositAddress(ctx, o.Currency, "", chain)
+	if err != nil {
+		return err
+	}
+	if m.dryRun {
+		o.Status = "dry run"
+		if m.verbose {
+			log.Infof(log.PortfolioMgr, "Lending optimizer would move %v %s from %s to %s for %.2f%% APR over %.2f%%", o.Amount, o.Currency, o.From, o.To, o.ToAPR*100, o.FromAPR*100)
+		}
+		return nil
+	}
+	resp, err := m.withdrawManager.SubmitWithdrawal(ctx, &withdraw.Request{
+		Exchange:    o.From,
+		Currency:    o.Currency,
+		Description: "lending optimizer transfer to " + o.To,
+		Amount:      o.Amount,
+		Type:        withdraw.Crypto,
+		Crypto: withdraw.CryptoRequest{
+			Address:    addr.Address,
+			AddressTag: addr.Tag,
+			Chain:      m.network,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	o.Status = "transferred"
+	m.notify(o.From, fmt.Sprintf("Lending optimizer moved %v %s from %s to %s for %.2f%% APR over %.2f%%, withdrawal %s", o.Amount, o.Currency, o.From, o.To, o.ToAPR*100, o.FromAPR*100, resp.ID))
+	return nil
+}
+
+// notify logs a transfer or subscription and reports it to the
+// communications manager when set
+func (m *LendingOptimizer) notify(exchName, msg string) {
+	log.Infoln(log.PortfolioMgr, msg)
+	if m.comms != nil {
+		m.comms.PushEvent(base.Event{Type: lendingEventType, Message: msg, Severity: base.SeverityInfo, Exchange: exchName})
+	}
+}
+
+// GetLendingOpportunities returns the lending rates and opportunities found
+// by the last check, and when it ran
+func (m *LendingOptimizer) GetLendingOpportunities() ([]LendingRate, []LendingOpportunity, time.Time, error) {
+	if m == nil {
+		return nil, nil, time.Time{}, fmt.Errorf("%s %w", LendingOptimizerName, ErrNilSubsystem)
+	}
+	m.m.RLock()
+	defer m.m.RUnlock()
+	if m.checked.IsZero() {
+		return nil, nil, time.Time{}, errLendingNotChecked
+	}
+	return slices.Clone(m.rates), slices.Clone(m.opportunities), m.checked, nil
+}
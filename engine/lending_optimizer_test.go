@@ -0,0 +1,238 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/convert"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/deposit"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/earn"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/transfer"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
+)
+
+// lendingExchange is a fake exchange with flexible earn products and spot
+// balances
+type lendingExchange struct {
+	exchange.IBotExchange
+	name       string
+	apr        float64
+	free       float64
+	fee        float64
+	subscribed []earn.SubscribeRequest
+}
+
+func (f *lendingExchange) GetName() string {
+	return f.name
+}
+
+func (f *lendingExchange) IsEnabled() bool {
+	return true
+}
+
+func (f *lendingExchange) IsRESTAuthenticationSupported() bool {
+	return true
+}
+
+func (f *lendingExchange) GetEarnProducts(_ context.Context, c currency.Code) ([]earn.Product, error) {
+	if f.apr == 0 {
+		return nil, common.ErrFunctionNotSupported
+	}
+	return []earn.Product{
+		{Exchange: f.name, ID: c.String() + "001", Currency: c, Type: earn.Flexible, APR: f.apr, Available: true},
+		{Exchange: f.name, ID: c.String() + "002", Currency: c, Type: earn.Flexible, APR: f.apr * 2},
+		{Exchange: f.name, ID: c.String() + "090", Currency: c, Type: earn.Locked, APR: f.apr * 3, Available: true},
+	}, nil
+}
+
+func (f *lendingExchange) UpdateAccountInfo(context.Context, asset.Item) (account.Holdings, error) {
+	return account.Holdings{
+		Exchange: f.name,
+		Accounts: []account.SubAccount{
+			{AssetType: asset.Spot, Currencies: []account.Balance{{Currency: currency.USDT, Total: f.free + 5, Hold: 5, Free: f.free}}},
+			{AssetType: asset.Margin, Currencies: []account.Balance{{Currency: currency.USDT, Total: 1000, Free: 1000}}},
+		},
+	}, nil
+}
+
+func (f *lendingExchange) GetTransferNetworks(context.Context, currency.Code) ([]transfer.Chain, error) {
+	return []transfer.Chain{
+		{Network: transfer.TRC20, Name: "TRX", DepositEnabled: true, WithdrawEnabled: true, WithdrawFee: f.fee},
+	}, nil
+}
+
+func (f *lendingExchange) GetDepositAddress(_ context.Context, c currency.Code, _, chain string) (*deposit.Address, error) {
+	return &deposit.Address{Address: f.name + "-" + c.String() + "-" + chain}, nil
+}
+
+func (f *lendingExchange) SubscribeEarnProduct(_ context.Context, req *earn.SubscribeRequest) (*earn.Result, error) {
+	f.subscribed = append(f.subscribed, *req)
+	return &earn.Result{Exchange: f.name, ProductID: req.ProductID, Currency: req.Currency, Amount: req.Amount, Success: true}, nil
+}
+
+// lendingWithdrawManager is a fake withdraw manager which stores submitted
+// withdrawals
+type lendingWithdrawManager struct {
+	requests []withdraw.Request
+}
+
+func (f *lendingWithdrawManager) SubmitWithdrawal(_ context.Context, req *withdraw.Request) (*withdraw.Response, error) {
+	f.requests = append(f.requests, *req)
+	return &withdraw.Response{RequestDetails: *req}, nil
+}
+
+func newLendingConfig() *config.LendingOptimizer {
+	return &config.LendingOptimizer{
+		DryRun:             convert.BoolPtr(false),
+		CheckInterval:      time.Hour,
+		Currencies:         []string{"usdt"},
+		MinRateImprovement: 0.01,
+		MinAmount:          100,
+		Network:            "TRC20",
+	}
+}
+
+func TestSetupLendingOptimizer(t *testing.T) {
+	t.Parallel()
+	_, err := SetupLendingOptimizer(nil, nil, nil, nil)
+	assert.ErrorIs(t, err, errNilConfig)
+	cfg := &config.LendingOptimizer{}
+	_, err = SetupLendingOptimizer(cfg, nil, nil, nil)
+	assert.ErrorIs(t, err, errNilExchangeManager)
+	_, err = SetupLendingOptimizer(cfg, NewExchangeManager(), nil, nil)
+	assert.ErrorIs(t, err, errNilWithdrawManager)
+	wm := &lendingWithdrawManager{}
+	_, err = SetupLendingOptimizer(cfg, NewExchangeManager(), wm, nil)
+	assert.ErrorIs(t, err, errInvalidLendingOptimizerConfig)
+	cfg.CheckInterval = time.Hour
+	_, err = SetupLendingOptimizer(cfg, NewExchangeManager(), wm, nil)
+	assert.ErrorIs(t, err, errInvalidLendingOptimizerConfig)
+	cfg.Currencies = []string{""}
+	_, err = SetupLendingOptimizer(cfg, NewExchangeManager(), wm, nil)
+	assert.ErrorIs(t, err, errInvalidLendingOptimizerConfig)
+	cfg.MinRateImprovement = 0.01
+	_, err = SetupLendingOptimizer(cfg, NewExchangeManager(), wm, nil)
+	assert.ErrorIs(t, err, errInvalidLendingOptimizerConfig)
+	cfg.Currencies = []string{"usdt"}
+	cfg.Reserve = -1
+	_, err = SetupLendingOptimizer(cfg, NewExchangeManager(), wm, nil)
+	assert.ErrorIs(t, err, errInvalidLendingOptimizerConfig)
+	cfg.Reserve = 0
+
+	m, err := SetupLendingOptimizer(cfg, NewExchangeManager(), wm, nil)
+	require.NoError(t, err)
+	assert.True(t, m.dryRun, "dry run should default to true")
+	assert.Equal(t, []currency.Code{currency.USDT}, m.currencies)
+}
+
+func TestLendingOptimizerStartStop(t *testing.T) {
+	t.Parallel()
+	var m *LendingOptimizer
+	assert.ErrorIs(t, m.Start(), ErrNilSubsystem)
+	assert.ErrorIs(t, m.Stop(), ErrNilSubsystem)
+	assert.False(t, m.IsRunning())
+
+	m, err := SetupLendingOptimizer(newLendingConfig(), NewExchangeManager(), &lendingWithdrawManager{}, nil)
+	require.NoError(t, err)
+	assert.ErrorIs(t, m.Stop(), ErrSubSystemNotStarted)
+	require.NoError(t, m.Start())
+	assert.True(t, m.IsRunning())
+	assert.ErrorIs(t, m.Start(), ErrSubSystemAlreadyStarted)
+	require.NoError(t, m.Stop())
+	assert.False(t, m.IsRunning())
+}
+
+func TestFindLendingOpportunities(t *testing.T) {
+	t.Parallel()
+	rates := []LendingRate{
+		{Exchange: "a", Currency: currency.USDT, ProductID: "a1", APR: 0.05},
+		{Exchange: "b", Currency: currency.USDT, ProductID: "b1", APR: 0.055},
+		{Exchange: "c", Currency: currency.USDT, ProductID: "c1", APR: 0.08},
+		{Exchange: "c", Currency: currency.USDC, ProductID: "c2", APR: 0.03},
+	}
+	balances := []lendingBalance{
+		{exchange: "a", currency: currency.USDT, amount: 1000},
+		{exchange: "c", currency: currency.USDT, amount: 500},
+		{exchange: "d", currency: currency.USDT, amount: 200},
+		{exchange: "d", currency: currency.USDC, amount: 200},
+		{exchange: "a", currency: currency.DAI, amount: 200},
+	}
+	opportunities := findLendingOpportunities(rates, balances, 0.01, 600, true)
+	require.Len(t, opportunities, 4, "balances without a rate must be skipped")
+	assert.Equal(t, LendingOpportunity{Currency: currency.USDT, From: "a", To: "c", ProductID: "c1", FromAPR: 0.05, ToAPR: 0.08, Amount: 600}, opportunities[0], "transfers should be capped")
+	assert.Equal(t, LendingOpportunity{Currency: currency.USDT, From: "c", To: "c", ProductID: "c1", FromAPR: 0.08, ToAPR: 0.08, Amount: 500}, opportunities[1], "balances on the best venue should be subscribed")
+	assert.Equal(t, LendingOpportunity{Currency: currency.USDT, From: "d", To: "c", ProductID: "c1", ToAPR: 0.08, Amount: 200}, opportunities[2], "balances without a local product should be moved")
+	assert.Equal(t, "c2", opportunities[3].ProductID)
+
+	opportunities = findLendingOpportunities(rates, balances[:1], 0.05, 0, true)
+	require.Len(t, opportunities, 1)
+	assert.False(t, opportunities[0].IsTransfer(), "balances should be subscribed locally when the improvement is below the minimum")
+
+	opportunities = findLendingOpportunities(rates, balances[:3], 0.01, 0, false)
+	require.Len(t, opportunities, 2, "balances without a local product cannot be lent when transfers are disabled")
+	assert.False(t, opportunities[0].IsTransfer(), "balances should be subscribed locally when transfers are disabled")
+	assert.Equal(t, 1000.0, opportunities[0].Amount)
+}
+
+func TestLendingOptimizerCheck(t *testing.T) {
+	t.Parallel()
+	low := &lendingExchange{name: "low", apr: 0.02, free: 1000, fee: 1}
+	high := &lendingExchange{name: "high", apr: 0.06, free: 150}
+	none := &lendingExchange{name: "none", free: 1000}
+	em := NewExchangeManager()
+	require.NoError(t, em.Add(low))
+	require.NoError(t, em.Add(high))
+	require.NoError(t, em.Add(none))
+	wm := &lendingWithdrawManager{}
+	comms := &fakeComms{}
+	cfg := newLendingConfig()
+	cfg.Reserve = 100
+	cfg.MaxTransferAmount = 500
+	m, err := SetupLendingOptimizer(cfg, em, wm, comms)
+	require.NoError(t, err)
+
+	_, _, _, err = m.GetLendingOpportunities()
+	assert.ErrorIs(t, err, errLendingNotChecked)
+
+	require.NoError(t, m.Check(context.Background()))
+	require.Len(t, wm.requests, 1, "the idle balance on the low rate exchange must be moved")
+	assert.Equal(t, "low", wm.requests[0].Exchange)
+	assert.Equal(t, 500.0, wm.requests[0].Amount, "transfers should be capped")
+	assert.Equal(t, "high-USDT-TRX", wm.requests[0].Crypto.Address, "funds should be sent to the exchange's deposit address on the configured network")
+	assert.Empty(t, high.subscribed, "balances below the minimum after the reserve must not be subscribed")
+	assert.Len(t, comms.events, 1)
+
+	rates, opportunities, checked, err := m.GetLendingOpportunities()
+	require.NoError(t, err)
+	assert.Len(t, rates, 2, "exchanges without earn products should be skipped")
+	require.Len(t, opportunities, 1)
+	assert.Equal(t, "transferred", opportunities[0].Status)
+	assert.Equal(t, 1.0, opportunities[0].Fee)
+	assert.False(t, checked.IsZero(), "checked time should be set")
+
+	high.free = 600
+	low.fee = 100
+	require.NoError(t, m.Check(context.Background()))
+	assert.Len(t, wm.requests, 1, "transfers should be skipped when the fee exceeds a year of additional interest")
+	require.Len(t, high.subscribed, 1)
+	assert.Equal(t, earn.SubscribeRequest{ProductID: "USDT001", Currency: currency.USDT, Type: earn.Flexible, Amount: 500}, high.subscribed[0], "the best available flexible product should be subscribed")
+
+	m.dryRun = true
+	low.fee = 1
+	require.NoError(t, m.Check(context.Background()))
+	assert.Len(t, wm.requests, 1, "dry runs must not move funds")
+	assert.Len(t, high.subscribed, 1, "dry runs must not subscribe funds")
+	_, opportunities, _, err = m.GetLendingOpportunities()
+	require.NoError(t, err)
+	require.Len(t, opportunities, 2)
+	assert.Equal(t, "dry run", opportunities[0].Status)
+}
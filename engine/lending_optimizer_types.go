@@ -0,0 +1,91 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
+)
+
+// LendingOptimizerName is an exported subsystem name
+const LendingOptimizerName = "lending_optimizer"
+
+// lendingEventType is the communications event type used for lending
+// optimizer transfers and subscriptions
+const lendingEventType = "lending"
+
+var (
+	errInvalidLendingOptimizerConfig = errors.New("invalid lending optimizer config")
+	errNilWithdrawManager            = errors.New("cannot start with nil withdraw manager")
+	errLendingNotChecked             = errors.New("lending rates have not been checked")
+)
+
+// iLendingWithdrawManager defines the withdraw manager function used to move
+// funds between exchanges
+type iLendingWithdrawManager interface {
+	SubmitWithdrawal(context.Context, *withdraw.Request) (*withdraw.Response, error)
+}
+
+// LendingOptimizer compares the flexible earn rates offered for stablecoins
+// across exchanges, subscribing idle balances to the best product on the
+// exchange holding them or moving them to an exchange offering a better rate
+type LendingOptimizer struct {
+	started            int32
+	shutdown           chan struct{}
+	wg                 sync.WaitGroup
+	verbose            bool
+	dryRun             bool
+	interval           time.Duration
+	currencies         []currency.Code
+	exchanges          []string
+	minRateImprovement float64
+	minAmount          float64
+	maxTransferAmount  float64
+	reserve            float64
+	network            string
+	exchangeManager    iExchangeManager
+	withdrawManager    iLendingWithdrawManager
+	comms              iCommsManager
+	m                  sync.RWMutex
+	rates              []LendingRate
+	opportunities      []LendingOpportunity
+	checked            time.Time
+}
+
+// LendingRate is the best flexible earn rate an exchange offers for a
+// currency
+type LendingRate struct {
+	Exchange  string
+	Currency  currency.Code
+	ProductID string
+	// APR is the annual percentage rate as a decimal, 0.05 being 5%
+	APR float64
+}
+
+// LendingOpportunity is an idle balance which can earn a better rate, either
+// by subscribing it on the exchange holding it or by moving it to another
+// exchange
+type LendingOpportunity struct {
+	Currency currency.Code
+	// From is the exchange holding the idle balance
+	From string
+	// To is the exchange offering the best rate, the same as From when the
+	// balance is subscribed where it is held
+	To        string
+	ProductID string
+	FromAPR   float64
+	ToAPR     float64
+	Amount    float64
+	// Fee is the withdrawal fee of a transfer
+	Fee float64
+	// Status is the outcome of acting on the opportunity
+	Status string
+}
+
+// IsTransfer returns whether the opportunity moves funds between exchanges
+func (o *LendingOpportunity) IsTransfer() bool {
+	return o.From != o.To
+}
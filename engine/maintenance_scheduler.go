@@ -0,0 +1,351 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/cron"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// SetupMaintenanceScheduler creates a maintenance scheduler subsystem from
+// the maintenance windows of each exchange config. The communications manager
+// is optional, when set the start and end of maintenance are announced
+func SetupMaintenanceScheduler(cfg *config.MaintenanceScheduler, exchanges []config.Exchange, em iExchangeManager, comms iCommsManager) (*MaintenanceScheduler, error) {
+	if cfg == nil {
+		return nil, errNilConfig
+	}
+	if em == nil {
+		return nil, errNilExchangeManager
+	}
+	if cfg.CheckInterval <= 0 {
+		return nil, fmt.Errorf("%w check interval %v must be above zero", errInvalidMaintenanceConfig, cfg.CheckInterval)
+	}
+	if cfg.ReconnectInterval <= 0 {
+		return nil, fmt.Errorf("%w reconnect interval %v must be above zero", errInvalidMaintenanceConfig, cfg.ReconnectInterval)
+	}
+	m := &MaintenanceScheduler{
+		verbose:           cfg.Verbose,
+		interval:          cfg.CheckInterval,
+		reconnectInterval: cfg.ReconnectInterval,
+		exchangeManager:   em,
+		comms:             comms,
+		schedules:         make(map[string]*maintenanceSchedule),
+	}
+	now := time.Now()
+	for i := range exchanges {
+		if len(exchanges[i].MaintenanceWindows) == 0 {
+			continue
+		}
+		s := &maintenanceSchedule{exchange: exchanges[i].Name}
+		for j := range exchanges[i].MaintenanceWindows {
+			w, err := parseMaintenanceWindow(&exchanges[i].MaintenanceWindows[j], now)
+			if err != nil {
+				return nil, fmt.Errorf("%s maintenance window %q: %w", exchanges[i].Name, exchanges[i].MaintenanceWindows[j].Name, err)
+			}
+			s.windows = append(s.windows, w)
+		}
+		m.schedules[strings.ToLower(exchanges[i].Name)] = s
+	}
+	return m, nil
+}
+
+// parseMaintenanceWindow validates a configured maintenance window
+func parseMaintenanceWindow(cfg *config.MaintenanceWindow, now time.Time) (maintenanceWindow, error) {
+	w := maintenanceWindow{name: cfg.Name}
+	if cfg.Schedule == "" {
+		if cfg.Start == nil || cfg.End == nil {
+			return w, fmt.Errorf("%w: a start and end or a schedule must be set", errInvalidMaintenanceWindow)
+		}
+		if !cfg.End.After(*cfg.Start) {
+			return w, fmt.Errorf("%w: end %v must be after start %v", errInvalidMaintenanceWindow, cfg.End, cfg.Start)
+		}
+		w.start, w.end = *cfg.Start, *cfg.End
+		return w, nil
+	}
+	if cfg.Start != nil || cfg.End != nil {
+		return w, fmt.Errorf("%w: a start and end cannot be set with a schedule", errInvalidMaintenanceWindow)
+	}
+	if cfg.Duration <= 0 {
+		return w, fmt.Errorf("%w: scheduled windows require a duration above zero", errInvalidMaintenanceWindow)
+	}
+	s, err := cron.Parse(cfg.Schedule)
+	if err != nil {
+		return w, err
+	}
+	if s.Next(now.UTC()).IsZero() {
+		return w, fmt.Errorf("%w: schedule %q will never run", errInvalidMaintenanceWindow, cfg.Schedule)
+	}
+	w.schedule, w.duration = s, cfg.Duration
+	return w, nil
+}
+
+// Start runs the subsystem
+func (m *MaintenanceScheduler) Start() error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 0, 1) {
+		return ErrSubSystemAlreadyStarted
+	}
+	m.shutdown = make(chan struct{})
+	m.wg.Add(1)
+	go m.run()
+	log.Debugf(log.ExchangeSys, "Maintenance scheduler %s", MsgSubSystemStarted)
+	return nil
+}
+
+// IsRunning checks whether the subsystem is running
+func (m *MaintenanceScheduler) IsRunning() bool {
+	if m == nil {
+		return false
+	}
+	return atomic.LoadInt32(&m.started) == 1
+}
+
+// Stop stops the subsystem, resuming any exchange under maintenance
+func (m *MaintenanceScheduler) Stop() error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 1, 0) {
+		return ErrSubSystemNotStarted
+	}
+	close(m.shutdown)
+	m.wg.Wait()
+	m.m.Lock()
+	var ended []*maintenanceSchedule
+	for _, s := range m.schedules {
+		if s.active != nil {
+			ended = append(ended, s)
+		}
+	}
+	m.m.Unlock()
+	for _, s := range ended {
+		m.exitMaintenance(s)
+	}
+	log.Debugf(log.ExchangeSys, "Maintenance scheduler %s", MsgSubSystemShutdown)
+	return nil
+}
+
+// IsUnderMaintenance returns whether an exchange is within one of its
+// maintenance windows
+func (m *MaintenanceScheduler) IsUnderMaintenance(exchName string) bool {
+	if !m.IsRunning() {
+		return false
+	}
+	m.m.RLock()
+	defer m.m.RUnlock()
+	s, ok := m.schedules[strings.ToLower(exchName)]
+	return ok && s.active != nil
+}
+
+// SuppressEvent returns whether an event should not be relayed because its
+// exchange is under maintenance, counting each suppressed event. The
+// scheduler's own events and resolutions are never suppressed so incidents
+// raised before maintenance can still be resolved
+func (m *MaintenanceScheduler) SuppressEvent(evt *base.Event) bool {
+	if evt == nil || evt.Exchange == "" || evt.Resolved || evt.Type == maintenanceEventType || !m.IsRunning() {
+		return false
+	}
+	m.m.Lock()
+	defer m.m.Unlock()
+	s, ok := m.schedules[strings.ToLower(evt.Exchange)]
+	if !ok || s.active == nil {
+		return false
+	}
+	s.suppressed++
+	return true
+}
+
+// GetStatus returns each exchange's active maintenance window, or its next
+// window when not under maintenance, ordered by exchange name
+func (m *MaintenanceScheduler) GetStatus() ([]MaintenanceStatus, error) {
+	if !m.IsRunning() {
+		return nil, fmt.Errorf("%s %w", MaintenanceSchedulerName, ErrSubSystemNotStarted)
+	}
+	now := time.Now()
+	m.m.RLock()
+	statuses := make([]MaintenanceStatus, 0, len(m.schedules))
+	for _, s := range m.schedules {
+		status := MaintenanceStatus{Exchange: s.exchange}
+		p := s.active
+		if p != nil {
+			status.Active = true
+			status.Suppressed = s.suppressed
+		} else {
+			p = s.next(now)
+		}
+		if p != nil {
+			status.Window, status.Start, status.End = p.name, p.start, p.end
+		}
+		statuses = append(statuses, status)
+	}
+	m.m.RUnlock()
+	slices.SortFunc(statuses, func(a, b MaintenanceStatus) int {
+		return strings.Compare(a.Exchange, b.Exchange)
+	})
+	return statuses, nil
+}
+
+func (m *MaintenanceScheduler) run() {
+	defer m.wg.Done()
+	runScheduledJobs(m.shutdown, &scheduledJob{interval: m.interval, immediate: true, run: func(_ context.Context, now time.Time) { m.check(now) }})
+}
+
+// check starts and ends each exchange's maintenance as its windows open and
+// close
+func (m *MaintenanceScheduler) check(now time.Time) {
+	var started, ended []*maintenanceSchedule
+	m.m.Lock()
+	for _, s := range m.schedules {
+		p := s.current(now)
+		switch {
+		case p != nil && s.active == nil:
+			s.active = p
+			s.suppressed = 0
+			started = append(started, s)
+		case p == nil && s.active != nil:
+			ended = append(ended, s)
+		case p != nil:
+			// An overlapping window may extend the maintenance
+			s.active = p
+		}
+	}
+	m.m.Unlock()
+	// Events are pushed once unlocked as the communications manager checks
+	// whether they are suppressed
+	for _, s := range started {
+		m.enterMaintenance(s)
+	}
+	for _, s := range ended {
+		m.exitMaintenance(s)
+	}
+}
+
+// enterMaintenance slows an exchange's websocket reconnection attempts and
+// announces its maintenance
+func (m *MaintenanceScheduler) enterMaintenance(s *maintenanceSchedule) {
+	m.m.RLock()
+	p := *s.active
+	m.m.RUnlock()
+	m.setReconnectDelay(s.exchange, m.reconnectInterval)
+	msg := fmt.Sprintf("Exchange %s under scheduled maintenance %s until %s, order routing paused",
+		s.exchange, p.name, p.end.UTC().Format(time.RFC3339))
+	log.Warnln(log.ExchangeSys, msg)
+	if m.comms != nil {
+		m.comms.PushEvent(base.Event{
+			Type:     maintenanceEventType,
+			Message:  msg,
+			Severity: base.SeverityWarning,
+			Exchange: s.exchange,
+			Key:      maintenanceEventType + "_" + strings.ToLower(s.exchange),
+		})
+	}
+}
+
+// exitMaintenance restores an exchange's websocket reconnection attempts and
+// announces the end of its maintenance
+func (m *MaintenanceScheduler) exitMaintenance(s *maintenanceSchedule) {
+	m.m.Lock()
+	p := *s.active
+	suppressed := s.suppressed
+	s.active = nil
+	s.suppressed = 0
+	m.m.Unlock()
+	m.setReconnectDelay(s.exchange, 0)
+	msg := fmt.Sprintf("Exchange %s scheduled maintenance %s ended, order routing resumed, %d alerts suppressed",
+		s.exchange, p.name, suppressed)
+	log.Infoln(log.ExchangeSys, msg)
+	if m.comms != nil {
+		m.comms.PushEvent(base.Event{
+			Type:     maintenanceEventType,
+			Message:  msg,
+			Exchange: s.exchange,
+			Key:      maintenanceEventType + "_" + strings.ToLower(s.exchange),
+			Resolved: true,
+		})
+	}
+}
+
+// setReconnectDelay sets the delay between an exchange's websocket
+// reconnection attempts, where zero restores its configured delay
+func (m *MaintenanceScheduler) setReconnectDelay(exchName string, d time.Duration) {
+	exch, err := m.exchangeManager.GetExchangeByName(exchName)
+	if err != nil {
+		if m.verbose {
+			log.Debugf(log.ExchangeSys, "Maintenance scheduler cannot get exchange %s: %v", exchName, err)
+		}
+		return
+	}
+	ws, err := exch.GetWebsocket()
+	if err != nil {
+		return
+	}
+	if err := ws.SetReconnectDelay(d); err != nil {
+		log.Errorf(log.ExchangeSys, "Maintenance scheduler cannot set %s reconnect delay: %v", exchName, err)
+	}
+}
+
+// current returns the maintenance period in effect, choosing the period
+// ending last when windows overlap, or nil
+func (s *maintenanceSchedule) current(now time.Time) *maintenancePeriod {
+	var current *maintenancePeriod
+	for i := range s.windows {
+		p := s.windows[i].current(now)
+		if p != nil && (current == nil || p.end.After(current.end)) {
+			current = p
+		}
+	}
+	return current
+}
+
+// next returns the earliest upcoming maintenance period, or nil
+func (s *maintenanceSchedule) next(now time.Time) *maintenancePeriod {
+	var next *maintenancePeriod
+	for i := range s.windows {
+		p := s.windows[i].next(now)
+		if p != nil && (next == nil || p.start.Before(next.start)) {
+			next = p
+		}
+	}
+	return next
+}
+
+// current returns the window's period in effect at now, or nil
+func (w *maintenanceWindow) current(now time.Time) *maintenancePeriod {
+	if w.schedule == nil {
+		if now.Before(w.start) || !now.Before(w.end) {
+			return nil
+		}
+		return &maintenancePeriod{name: w.name, start: w.start, end: w.end}
+	}
+	// The latest occurrence still in effect is the first to start after
+	// the window's duration before now
+	start := w.schedule.Next(now.UTC().Add(-w.duration))
+	if start.IsZero() || start.After(now) {
+		return nil
+	}
+	return &maintenancePeriod{name: w.name, start: start, end: start.Add(w.duration)}
+}
+
+// next returns the window's next period starting after now, or nil
+func (w *maintenanceWindow) next(now time.Time) *maintenancePeriod {
+	if w.schedule == nil {
+		if !w.start.After(now) {
+			return nil
+		}
+		return &maintenancePeriod{name: w.name, start: w.start, end: w.end}
+	}
+	start := w.schedule.Next(now.UTC())
+	if start.IsZero() {
+		return nil
+	}
+	return &maintenancePeriod{name: w.name, start: start, end: start.Add(w.duration)}
+}
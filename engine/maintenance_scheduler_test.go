@@ -0,0 +1,253 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+)
+
+// testMaintenanceScheduler returns a scheduler with the windows on the
+// exchange "Maintained" and an exchange "Unmaintained" without any
+func testMaintenanceScheduler(t *testing.T, comms iCommsManager, windows ...config.MaintenanceWindow) *MaintenanceScheduler {
+	t.Helper()
+	m, err := SetupMaintenanceScheduler(&config.MaintenanceScheduler{CheckInterval: time.Minute, ReconnectInterval: time.Minute},
+		[]config.Exchange{{Name: "Maintained", MaintenanceWindows: windows}, {Name: "Unmaintained"}}, testExchangeManager(t), comms)
+	require.NoError(t, err, "SetupMaintenanceScheduler must not error")
+	return m
+}
+
+func testMaintenanceSchedulerSetup(t *testing.T, start, end time.Time) (*MaintenanceScheduler, *fakeComms) {
+	t.Helper()
+	comms := &fakeComms{}
+	return testMaintenanceScheduler(t, comms, config.MaintenanceWindow{Name: "upgrade", Start: &start, End: &end}), comms
+}
+
+func TestSetupMaintenanceScheduler(t *testing.T) {
+	t.Parallel()
+	em := testExchangeManager(t)
+	_, err := SetupMaintenanceScheduler(nil, nil, em, nil)
+	assert.ErrorIs(t, err, errNilConfig)
+	_, err = SetupMaintenanceScheduler(&config.MaintenanceScheduler{}, nil, nil, nil)
+	assert.ErrorIs(t, err, errNilExchangeManager)
+	for _, cfg := range []config.MaintenanceScheduler{{}, {CheckInterval: time.Second}} {
+		_, err = SetupMaintenanceScheduler(&cfg, nil, em, nil)
+		assert.ErrorIs(t, err, errInvalidMaintenanceConfig)
+	}
+
+	cfg := &config.MaintenanceScheduler{CheckInterval: time.Second, ReconnectInterval: time.Minute}
+	now := time.Now()
+	later := now.Add(time.Hour)
+	for _, w := range []config.MaintenanceWindow{
+		{Name: "no times"},
+		{Name: "end before start", Start: &later, End: &now},
+		{Name: "schedule with times", Schedule: "@daily", Start: &now, Duration: time.Hour},
+		{Name: "no duration", Schedule: "@daily"},
+		{Name: "never", Schedule: "0 0 31 2 *", Duration: time.Hour},
+	} {
+		_, err = SetupMaintenanceScheduler(cfg, []config.Exchange{{Name: "test", MaintenanceWindows: []config.MaintenanceWindow{w}}}, em, nil)
+		assert.ErrorIs(t, err, errInvalidMaintenanceWindow, w.Name)
+	}
+	_, err = SetupMaintenanceScheduler(cfg, []config.Exchange{{Name: "test", MaintenanceWindows: []config.MaintenanceWindow{{Schedule: "bad", Duration: time.Hour}}}}, em, nil)
+	assert.Error(t, err, "invalid schedules should error")
+
+	m := testMaintenanceScheduler(t, nil, config.MaintenanceWindow{Schedule: "@daily", Duration: time.Hour}, config.MaintenanceWindow{Start: &now, End: &later})
+	assert.Len(t, m.schedules, 1, "exchanges without maintenance windows should not be scheduled")
+	assert.Len(t, m.schedules["maintained"].windows, 2)
+}
+
+func TestMaintenanceSchedulerStartStop(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	m, _ := testMaintenanceSchedulerSetup(t, now.Add(time.Hour), now.Add(time.Hour*2))
+	testStartStop(t, (*MaintenanceScheduler)(nil), m)
+
+	m, comms := testMaintenanceSchedulerSetup(t, now.Add(-time.Minute), now.Add(time.Hour))
+	require.NoError(t, m.Start(), "Start must not error")
+	assert.Eventually(t, func() bool { return m.IsUnderMaintenance("maintained") }, time.Second*5, time.Millisecond*10, "maintenance should start when the window opens")
+	require.NoError(t, m.Stop(), "Stop must not error")
+	m.started = 1
+	assert.False(t, m.IsUnderMaintenance("maintained"), "stopping should resume exchanges under maintenance")
+	require.Len(t, comms.events, 2)
+	assert.True(t, comms.events[1].Resolved)
+}
+
+func TestMaintenanceSchedulerCheck(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	m, comms := testMaintenanceSchedulerSetup(t, now.Add(time.Hour), now.Add(time.Hour*2))
+	assert.False(t, m.IsUnderMaintenance("maintained"), "exchanges should not be under maintenance when not running")
+	m.started = 1
+
+	m.check(now)
+	assert.False(t, m.IsUnderMaintenance("maintained"))
+	assert.Empty(t, comms.events)
+
+	m.check(now.Add(time.Hour))
+	assert.True(t, m.IsUnderMaintenance("MAINTAINED"), "maintenance should start when the window opens")
+	assert.False(t, m.IsUnderMaintenance("unmaintained"))
+	require.Len(t, comms.events, 1)
+	assert.Equal(t, maintenanceEventType, comms.events[0].Type)
+	assert.Equal(t, base.SeverityWarning, comms.events[0].Severity)
+	assert.Equal(t, "Maintained", comms.events[0].Exchange)
+	assert.Contains(t, comms.events[0].Message, "upgrade")
+
+	assert.True(t, m.SuppressEvent(&base.Event{Exchange: "maintained", Type: "data_quality"}), "alerts should be suppressed during maintenance")
+	assert.False(t, m.SuppressEvent(&base.Event{Exchange: "maintained", Type: "data_quality", Resolved: true}), "resolutions should not be suppressed")
+	assert.False(t, m.SuppressEvent(&base.Event{Exchange: "maintained", Type: maintenanceEventType}), "maintenance events should not be suppressed")
+	assert.False(t, m.SuppressEvent(&base.Event{Exchange: "unmaintained", Type: "data_quality"}))
+	assert.False(t, m.SuppressEvent(&base.Event{Type: "data_quality"}), "events without an exchange should not be suppressed")
+	assert.False(t, m.SuppressEvent(nil))
+
+	m.check(now.Add(time.Hour + time.Minute))
+	assert.Len(t, comms.events, 1, "maintenance should only be announced once")
+
+	m.check(now.Add(time.Hour * 2))
+	assert.False(t, m.IsUnderMaintenance("maintained"), "maintenance should end when the window closes")
+	require.Len(t, comms.events, 2)
+	assert.True(t, comms.events[1].Resolved)
+	assert.Equal(t, comms.events[0].Key, comms.events[1].Key, "the end of maintenance should resolve its start")
+	assert.Contains(t, comms.events[1].Message, "1 alerts suppressed")
+	assert.False(t, m.SuppressEvent(&base.Event{Exchange: "maintained", Type: "data_quality"}))
+}
+
+func TestMaintenanceSchedulerOverlappingWindows(t *testing.T) {
+	t.Parallel()
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	aStart, aEnd := day.Add(time.Hour), day.Add(time.Hour*3)
+	bStart, bEnd := day.Add(time.Hour*2), day.Add(time.Hour*4)
+	comms := &fakeComms{}
+	m := testMaintenanceScheduler(t, comms,
+		config.MaintenanceWindow{Name: "first", Start: &aStart, End: &aEnd},
+		config.MaintenanceWindow{Name: "second", Start: &bStart, End: &bEnd})
+	m.started = 1
+
+	m.check(aStart)
+	require.Len(t, comms.events, 1)
+	assert.Contains(t, comms.events[0].Message, "first")
+	m.check(bStart)
+	m.check(aEnd)
+	assert.True(t, m.IsUnderMaintenance("maintained"), "overlapping windows should extend the maintenance")
+	assert.Len(t, comms.events, 1, "extended maintenance should not be announced again")
+	m.check(bEnd)
+	assert.False(t, m.IsUnderMaintenance("maintained"))
+	require.Len(t, comms.events, 2)
+	assert.Contains(t, comms.events[1].Message, "second", "the end of maintenance should name the window which ended it")
+}
+
+func TestMaintenanceSchedulerRecurringWindows(t *testing.T) {
+	t.Parallel()
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	comms := &fakeComms{}
+	m := testMaintenanceScheduler(t, comms, config.MaintenanceWindow{Name: "nightly", Schedule: "0 2 * * *", Duration: time.Hour})
+	m.started = 1
+
+	for _, d := range []time.Duration{0, time.Hour * 24} {
+		m.check(day.Add(d + time.Hour*2))
+		assert.True(t, m.IsUnderMaintenance("maintained"), "maintenance should start at each occurrence")
+		if d == 0 {
+			assert.True(t, m.SuppressEvent(&base.Event{Exchange: "maintained", Type: "data_quality"}))
+		}
+		m.check(day.Add(d + time.Hour*3))
+		assert.False(t, m.IsUnderMaintenance("maintained"), "maintenance should end after each occurrence")
+	}
+	require.Len(t, comms.events, 4)
+	assert.Contains(t, comms.events[1].Message, "1 alerts suppressed")
+	assert.Contains(t, comms.events[3].Message, "0 alerts suppressed", "suppressed alerts should be counted per occurrence")
+}
+
+func TestMaintenanceSchedulerWithoutComms(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	end := now.Add(time.Hour)
+	m := testMaintenanceScheduler(t, nil, config.MaintenanceWindow{Name: "upgrade", Start: &now, End: &end})
+	m.started = 1
+	require.NotPanics(t, func() { m.check(now) }, "maintenance must start without a communications manager")
+	assert.True(t, m.IsUnderMaintenance("maintained"))
+	require.NotPanics(t, func() { m.check(end) }, "maintenance must end without a communications manager")
+	assert.False(t, m.IsUnderMaintenance("maintained"))
+}
+
+func TestMaintenanceWindowCurrentNext(t *testing.T) {
+	t.Parallel()
+	w, err := parseMaintenanceWindow(&config.MaintenanceWindow{Name: "daily", Schedule: "0 2 * * *", Duration: time.Hour}, time.Now())
+	require.NoError(t, err)
+	day := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Nil(t, w.current(day.Add(time.Hour)))
+	p := w.current(day.Add(time.Hour*2 + time.Minute*30))
+	require.NotNil(t, p, "the occurrence in effect must be returned")
+	assert.Equal(t, day.Add(time.Hour*2), p.start)
+	assert.Equal(t, day.Add(time.Hour*3), p.end)
+	assert.Nil(t, w.current(day.Add(time.Hour*3)), "the window should end at its duration")
+
+	p = w.next(day.Add(time.Hour * 2))
+	require.NotNil(t, p)
+	assert.Equal(t, day.Add(time.Hour*26), p.start, "the next occurrence should start after now")
+
+	start, end := day, day.Add(time.Hour)
+	w, err = parseMaintenanceWindow(&config.MaintenanceWindow{Name: "once", Start: &start, End: &end}, time.Now())
+	require.NoError(t, err)
+	assert.NotNil(t, w.current(day))
+	assert.Nil(t, w.current(end))
+	assert.NotNil(t, w.next(day.Add(-time.Second)))
+	assert.Nil(t, w.next(day), "one-off windows which have started should not be next")
+
+	s := &maintenanceSchedule{windows: []maintenanceWindow{
+		{name: "short", start: day, end: day.Add(time.Hour)},
+		{name: "long", start: day.Add(time.Minute), end: day.Add(time.Hour * 2)},
+	}}
+	p = s.current(day.Add(time.Minute * 30))
+	require.NotNil(t, p)
+	assert.Equal(t, "long", p.name, "overlapping windows should use the window ending last")
+	p = s.next(day.Add(-time.Hour))
+	require.NotNil(t, p)
+	assert.Equal(t, "short", p.name, "the earliest window should be next")
+}
+
+func TestMaintenanceSchedulerGetStatus(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	m, _ := testMaintenanceSchedulerSetup(t, now.Add(time.Hour), now.Add(time.Hour*2))
+	_, err := m.GetStatus()
+	assert.ErrorIs(t, err, ErrSubSystemNotStarted)
+
+	m.started = 1
+	statuses, err := m.GetStatus()
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	assert.False(t, statuses[0].Active)
+	assert.Equal(t, "upgrade", statuses[0].Window, "the next window should be returned when not under maintenance")
+
+	m.check(now.Add(time.Hour))
+	m.SuppressEvent(&base.Event{Exchange: "maintained", Type: "data_quality"})
+	statuses, err = m.GetStatus()
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	assert.True(t, statuses[0].Active)
+	assert.Equal(t, int64(1), statuses[0].Suppressed)
+}
+
+func TestMaintenancePausesOrderRoutingAndAlerts(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	m, _ := testMaintenanceSchedulerSetup(t, now.Add(time.Hour), now.Add(time.Hour*2))
+	m.started = 1
+	om := &OrderManager{}
+	assert.NoError(t, om.checkMaintenance("maintained"), "exchanges should be routable without a maintenance schedule")
+	om.setMaintenanceSchedule(m)
+	assert.NoError(t, om.checkMaintenance("maintained"))
+	m.check(now.Add(time.Hour))
+	assert.ErrorIs(t, om.checkMaintenance("maintained"), errExchangeUnderMaintenance)
+	assert.NoError(t, om.checkMaintenance("unmaintained"))
+
+	cm := &CommunicationManager{started: 1, relayMsg: make(chan base.Event, 1)}
+	cm.setMaintenanceSchedule(m)
+	cm.PushEvent(base.Event{Exchange: "maintained", Type: "data_quality", Message: "stale"})
+	assert.Empty(t, cm.RecentEvents(0), "events from exchanges under maintenance should be suppressed")
+	cm.PushEvent(base.Event{Exchange: "unmaintained", Type: "data_quality", Message: "stale"})
+	assert.Len(t, cm.RecentEvents(0), 1)
+}
@@ -0,0 +1,87 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/cron"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+)
+
+// MaintenanceSchedulerName is an exported subsystem name
+const MaintenanceSchedulerName = "maintenance_scheduler"
+
+// maintenanceEventType is the communications event type used when an
+// exchange's scheduled maintenance starts and ends
+const maintenanceEventType = "maintenance"
+
+var (
+	errInvalidMaintenanceConfig = errors.New("invalid maintenance scheduler config")
+	errInvalidMaintenanceWindow = errors.New("invalid maintenance window")
+	errExchangeUnderMaintenance = errors.New("exchange is under scheduled maintenance")
+)
+
+// iMaintenanceSchedule defines the maintenance scheduler functions used by
+// subsystems which pause while an exchange is under maintenance
+type iMaintenanceSchedule interface {
+	IsUnderMaintenance(exchName string) bool
+	SuppressEvent(evt *base.Event) bool
+}
+
+// MaintenanceScheduler pauses order routing, slows websocket reconnection
+// attempts and suppresses redundant alerts for each exchange during its
+// configured maintenance windows, resuming the exchange once a window ends
+type MaintenanceScheduler struct {
+	started           int32
+	shutdown          chan struct{}
+	wg                sync.WaitGroup
+	verbose           bool
+	interval          time.Duration
+	reconnectInterval time.Duration
+	exchangeManager   iExchangeManager
+	comms             iCommsManager
+	m                 sync.RWMutex
+	// schedules holds each exchange's maintenance windows keyed by lower
+	// case exchange name
+	schedules map[string]*maintenanceSchedule
+}
+
+// maintenanceSchedule holds an exchange's maintenance windows and whether it
+// is under maintenance
+type maintenanceSchedule struct {
+	exchange string
+	windows  []maintenanceWindow
+	// active is the window the exchange is under maintenance for, or nil
+	active *maintenancePeriod
+	// suppressed counts the alerts suppressed during the active window
+	suppressed int64
+}
+
+// maintenanceWindow is a parsed one-off or recurring maintenance window
+type maintenanceWindow struct {
+	name     string
+	start    time.Time
+	end      time.Time
+	schedule *cron.Schedule
+	duration time.Duration
+}
+
+// maintenancePeriod is a single occurrence of a maintenance window
+type maintenancePeriod struct {
+	name  string
+	start time.Time
+	end   time.Time
+}
+
+// MaintenanceStatus holds an exchange's current maintenance window, or its
+// next window when it is not under maintenance
+type MaintenanceStatus struct {
+	Exchange string
+	Window   string
+	Start    time.Time
+	End      time.Time
+	Active   bool
+	// Suppressed is the number of alerts suppressed during the active window
+	Suppressed int64
+}
@@ -0,0 +1,305 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// SetupMarginMonitor creates a margin monitor subsystem. The order manager is
+// only required when deleveraging is enabled
+func SetupMarginMonitor(cfg *config.MarginMonitor, om iMarginOrderManager, comms iCommsManager) (*MarginMonitor, error) {
+	if cfg == nil {
+		return nil, errNilConfig
+	}
+	if comms == nil {
+		return nil, errNilCommunicationsManager
+	}
+	if cfg.InitialMarginThreshold <= 0 {
+		return nil, fmt.Errorf("%w initial margin threshold %v", errInvalidMarginThreshold, cfg.InitialMarginThreshold)
+	}
+	if cfg.WarningThreshold <= 0 || cfg.WarningThreshold > cfg.CriticalThreshold {
+		return nil, fmt.Errorf("%w warning threshold %v must be above zero and not exceed critical threshold %v",
+			errInvalidMarginThreshold, cfg.WarningThreshold, cfg.CriticalThreshold)
+	}
+	if cfg.Deleverage.Enabled {
+		if om == nil {
+			return nil, errNilOrderManager
+		}
+		if cfg.Deleverage.ReduceFraction <= 0 || cfg.Deleverage.ReduceFraction > 1 {
+			return nil, fmt.Errorf("%w reduce fraction %v", errInvalidDeleverageConfig, cfg.Deleverage.ReduceFraction)
+		}
+		if cfg.Deleverage.Cooldown <= 0 {
+			return nil, fmt.Errorf("%w cooldown %v", errInvalidDeleverageConfig, cfg.Deleverage.Cooldown)
+		}
+	}
+	return &MarginMonitor{
+		verbose:           cfg.Verbose,
+		initialThreshold:  cfg.InitialMarginThreshold,
+		warningThreshold:  cfg.WarningThreshold,
+		criticalThreshold: cfg.CriticalThreshold,
+		deleverage:        cfg.Deleverage.Enabled,
+		dryRun:            cfg.Deleverage.DryRun == nil || *cfg.Deleverage.DryRun,
+		reduceFraction:    cfg.Deleverage.ReduceFraction,
+		cooldown:          cfg.Deleverage.Cooldown,
+		orderManager:      om,
+		comms:             comms,
+		accounts:          make(map[string]*marginAccount),
+		pending:           make(chan account.MarginStatus, maxPendingDeleverages),
+	}, nil
+}
+
+// Start runs the subsystem
+func (m *MarginMonitor) Start() error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 0, 1) {
+		return ErrSubSystemAlreadyStarted
+	}
+	m.shutdown = make(chan struct{})
+	m.wg.Add(1)
+	go m.run()
+	if m.deleverage && m.dryRun {
+		log.Infoln(log.OrderMgr, "Margin monitor deleveraging running in dry run mode, no orders will be placed")
+	}
+	log.Debugf(log.OrderMgr, "Margin monitor %s", MsgSubSystemStarted)
+	return nil
+}
+
+// IsRunning checks whether the subsystem is running
+func (m *MarginMonitor) IsRunning() bool {
+	if m == nil {
+		return false
+	}
+	return atomic.LoadInt32(&m.started) == 1
+}
+
+// Stop stops the subsystem
+func (m *MarginMonitor) Stop() error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 1, 0) {
+		return ErrSubSystemNotStarted
+	}
+	close(m.shutdown)
+	m.wg.Wait()
+	log.Debugf(log.OrderMgr, "Margin monitor %s", MsgSubSystemShutdown)
+	return nil
+}
+
+// MaintenanceMarginRatio returns the highest maintenance margin to equity
+// ratio reported by an exchange's accounts for an asset, or false when no
+// margin status has been received
+func (m *MarginMonitor) MaintenanceMarginRatio(exchName string, a asset.Item) (float64, bool) {
+	if !m.IsRunning() {
+		return 0, false
+	}
+	var ratio float64
+	var found bool
+	m.m.Lock()
+	defer m.m.Unlock()
+	for _, acc := range m.accounts {
+		if !strings.EqualFold(acc.status.Exchange, exchName) || acc.status.Asset != a {
+			continue
+		}
+		ratio = max(ratio, acc.status.MaintenanceMarginRatio())
+		found = true
+	}
+	return ratio, found
+}
+
+func (m *MarginMonitor) run() {
+	defer m.wg.Done()
+	for {
+		select {
+		case <-m.shutdown:
+			return
+		case s := <-m.pending:
+			if err := m.deleverageAccount(context.TODO(), &s); err != nil {
+				msg := fmt.Sprintf("Margin monitor failed to deleverage %s %s account %s: %v", s.Exchange, s.Asset, s.Account, err)
+				log.Errorln(log.OrderMgr, msg)
+				m.comms.PushEvent(base.Event{Type: marginEventType, Message: msg, Severity: base.SeverityError, Exchange: s.Exchange})
+			}
+		}
+	}
+}
+
+// websocketMarginHandler processes account margin updates received via
+// websocket
+func (m *MarginMonitor) websocketMarginHandler(_ string, data interface{}) error {
+	if !m.IsRunning() {
+		return nil
+	}
+	switch d := data.(type) {
+	case account.MarginStatus:
+		m.update(&d)
+	case []account.MarginStatus:
+		for i := range d {
+			m.update(&d[i])
+		}
+	}
+	return nil
+}
+
+// update records an account's margin status, alerting when its margin level
+// changes and queueing a deleverage when the critical threshold is reached
+func (m *MarginMonitor) update(s *account.MarginStatus) {
+	initialRatio, maintenanceRatio := s.InitialMarginRatio(), s.MaintenanceMarginRatio()
+	level := m.marginLevel(initialRatio, maintenanceRatio)
+	if m.verbose {
+		log.Debugf(log.OrderMgr, "Margin monitor %s %s account %s initial margin ratio %.4f maintenance margin ratio %.4f",
+			s.Exchange, s.Asset, s.Account, initialRatio, maintenanceRatio)
+	}
+	k := marginAccountKey(s)
+	now := time.Now()
+	m.m.Lock()
+	acc, ok := m.accounts[k]
+	if !ok {
+		acc = &marginAccount{}
+		m.accounts[k] = acc
+	}
+	acc.status = *s
+	previous := acc.level
+	acc.level = level
+	deleverage := m.deleverage && level == marginCritical && now.Sub(acc.lastDeleverage) >= m.cooldown
+	if deleverage {
+		acc.lastDeleverage = now
+	}
+	m.m.Unlock()
+
+	if level != previous {
+		m.alert(s, k, level, initialRatio, maintenanceRatio)
+	}
+	if !deleverage {
+		return
+	}
+	select {
+	case m.pending <- *s:
+	default:
+		log.Errorf(log.OrderMgr, "Margin monitor deleverage queue full, skipping %s %s account %s", s.Exchange, s.Asset, s.Account)
+	}
+}
+
+// marginLevel returns the level of the highest threshold crossed
+func (m *MarginMonitor) marginLevel(initialRatio, maintenanceRatio float64) marginLevel {
+	switch {
+	case maintenanceRatio >= m.criticalThreshold:
+		return marginCritical
+	case maintenanceRatio >= m.warningThreshold:
+		return marginWarning
+	case initialRatio >= m.initialThreshold:
+		return marginInitialExceeded
+	default:
+		return marginHealthy
+	}
+}
+
+// alert sends a communications event for a change in margin level, resolving
+// the alert when the account returns to a healthy level
+func (m *MarginMonitor) alert(s *account.MarginStatus, k string, level marginLevel, initialRatio, maintenanceRatio float64) {
+	ratios := fmt.Sprintf("initial margin ratio %.4f maintenance margin ratio %.4f equity %v %s",
+		initialRatio, maintenanceRatio, s.Equity, s.Currency)
+	e := base.Event{
+		Type:     marginEventType,
+		Exchange: s.Exchange,
+		Key:      marginEventType + ":" + k,
+	}
+	switch level {
+	case marginHealthy:
+		e.Severity = base.SeverityInfo
+		e.Resolved = true
+		e.Message = fmt.Sprintf("Margin monitor %s %s account %s margin utilisation recovered: %s", s.Exchange, s.Asset, s.Account, ratios)
+		log.Infoln(log.OrderMgr, e.Message)
+	case marginInitialExceeded:
+		e.Severity = base.SeverityWarning
+		e.Message = fmt.Sprintf("Margin monitor %s %s account %s initial margin threshold %v reached: %s", s.Exchange, s.Asset, s.Account, m.initialThreshold, ratios)
+		log.Warnln(log.OrderMgr, e.Message)
+	case marginWarning:
+		e.Severity = base.SeverityWarning
+		e.Message = fmt.Sprintf("Margin monitor %s %s account %s maintenance margin warning threshold %v reached: %s", s.Exchange, s.Asset, s.Account, m.warningThreshold, ratios)
+		log.Warnln(log.OrderMgr, e.Message)
+	case marginCritical:
+		e.Severity = base.SeverityCritical
+		e.Message = fmt.Sprintf("Margin monitor %s %s account %s maintenance margin critical threshold %v reached: %s", s.Exchange, s.Asset, s.Account, m.criticalThreshold, ratios)
+		log.Errorln(log.OrderMgr, e.Message)
+	}
+	m.comms.PushEvent(e)
+}
+
+// deleverageAccount reduces the largest open futures position held on the
+// account's exchange by the configured fraction using a reduce only market
+// order. In dry run mode the reduction is reported without placing orders
+func (m *MarginMonitor) deleverageAccount(ctx context.Context, s *account.MarginStatus) error {
+	positions, err := m.orderManager.GetAllOpenFuturesPositions()
+	if err != nil {
+		return err
+	}
+	pos := largestPosition(s.Exchange, positions)
+	if pos == nil {
+		return fmt.Errorf("%w on %s", errNoPositionToDeleverage, s.Exchange)
+	}
+	amount := pos.LatestSize.Abs().Mul(decimal.NewFromFloat(m.reduceFraction)).InexactFloat64()
+	side := order.Sell
+	if pos.LatestDirection.IsShort() {
+		side = order.Buy
+	}
+	summary := fmt.Sprintf("%s %s %s %s position by %v with a %s order", pos.Exchange, pos.Asset, pos.Pair, pos.LatestDirection, amount, side)
+	if m.dryRun {
+		msg := "Margin monitor dry run would reduce " + summary
+		log.Warnln(log.OrderMgr, msg)
+		m.comms.PushEvent(base.Event{Type: marginEventType, Message: msg, Severity: base.SeverityWarning, Exchange: pos.Exchange})
+		return nil
+	}
+	_, err = m.orderManager.Submit(ctx, &order.Submit{
+		Exchange:   pos.Exchange,
+		Pair:       pos.Pair,
+		AssetType:  pos.Asset,
+		Side:       side,
+		Type:       order.Market,
+		Amount:     amount,
+		ReduceOnly: true,
+	})
+	if err != nil {
+		return fmt.Errorf("reducing %s %s %s: %w", pos.Exchange, pos.Asset, pos.Pair, err)
+	}
+	msg := "Margin monitor reduced " + summary
+	log.Warnln(log.OrderMgr, msg)
+	m.comms.PushEvent(base.Event{Type: marginEventType, Message: msg, Severity: base.SeverityWarning, Exchange: pos.Exchange})
+	return nil
+}
+
+// largestPosition returns the open position with the largest notional value
+// on an exchange
+func largestPosition(exchName string, positions []futures.Position) *futures.Position {
+	var largest *futures.Position
+	var largestNotional decimal.Decimal
+	for i := range positions {
+		if !strings.EqualFold(positions[i].Exchange, exchName) || positions[i].LatestSize.IsZero() {
+			continue
+		}
+		notional := positions[i].LatestSize.Mul(positions[i].LatestPrice).Abs()
+		if largest == nil || notional.GreaterThan(largestNotional) {
+			largest = &positions[i]
+			largestNotional = notional
+		}
+	}
+	return largest
+}
+
+// marginAccountKey returns a key unique to an exchange account
+func marginAccountKey(s *account.MarginStatus) string {
+	return strings.ToLower(s.Exchange) + ":" + s.Asset.String() + ":" + s.Account
+}
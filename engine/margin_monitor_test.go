@@ -0,0 +1,208 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/common/convert"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func testMarginMonitorConfig(deleverage, dryRun bool) *config.MarginMonitor {
+	return &config.MarginMonitor{
+		InitialMarginThreshold: 0.9,
+		WarningThreshold:       0.5,
+		CriticalThreshold:      0.8,
+		Deleverage: config.MarginDeleverage{
+			Enabled:        deleverage,
+			DryRun:         convert.BoolPtr(dryRun),
+			ReduceFraction: 0.25,
+			Cooldown:       time.Hour,
+		},
+	}
+}
+
+func testMarginStatus(initialMargin, maintenanceMargin float64) *account.MarginStatus {
+	return &account.MarginStatus{
+		Exchange:          "margin",
+		Asset:             asset.Spot,
+		Account:           "UNIFIED",
+		Currency:          currency.USD,
+		Equity:            1000,
+		InitialMargin:     initialMargin,
+		MaintenanceMargin: maintenanceMargin,
+	}
+}
+
+func TestSetupMarginMonitor(t *testing.T) {
+	t.Parallel()
+	_, err := SetupMarginMonitor(nil, nil, nil)
+	assert.ErrorIs(t, err, errNilConfig)
+	_, err = SetupMarginMonitor(&config.MarginMonitor{}, nil, nil)
+	assert.ErrorIs(t, err, errNilCommunicationsManager)
+	_, err = SetupMarginMonitor(&config.MarginMonitor{}, nil, &fakeComms{})
+	assert.ErrorIs(t, err, errInvalidMarginThreshold)
+	cfg := testMarginMonitorConfig(false, true)
+	cfg.WarningThreshold = 0.9
+	_, err = SetupMarginMonitor(cfg, nil, &fakeComms{})
+	assert.ErrorIs(t, err, errInvalidMarginThreshold, "warning threshold above critical threshold should error")
+
+	cfg = testMarginMonitorConfig(true, true)
+	_, err = SetupMarginMonitor(cfg, nil, &fakeComms{})
+	assert.ErrorIs(t, err, errNilOrderManager, "deleveraging requires an order manager")
+	cfg.Deleverage.ReduceFraction = 1.5
+	_, err = SetupMarginMonitor(cfg, &fakeFuturesOrderManager{}, &fakeComms{})
+	assert.ErrorIs(t, err, errInvalidDeleverageConfig)
+	cfg.Deleverage.ReduceFraction = 0.5
+	cfg.Deleverage.Cooldown = 0
+	_, err = SetupMarginMonitor(cfg, &fakeFuturesOrderManager{}, &fakeComms{})
+	assert.ErrorIs(t, err, errInvalidDeleverageConfig)
+
+	cfg = testMarginMonitorConfig(false, true)
+	cfg.Deleverage.DryRun = nil
+	m, err := SetupMarginMonitor(cfg, nil, &fakeComms{})
+	require.NoError(t, err, "order manager should not be required without deleveraging")
+	assert.True(t, m.dryRun, "dry run should default to true")
+}
+
+func TestMarginMonitorStartStop(t *testing.T) {
+	t.Parallel()
+	var m *MarginMonitor
+	assert.ErrorIs(t, m.Start(), ErrNilSubsystem)
+	assert.ErrorIs(t, m.Stop(), ErrNilSubsystem)
+	assert.False(t, m.IsRunning())
+
+	m, err := SetupMarginMonitor(testMarginMonitorConfig(false, true), nil, &fakeComms{})
+	require.NoError(t, err)
+	assert.ErrorIs(t, m.Stop(), ErrSubSystemNotStarted)
+	require.NoError(t, m.Start())
+	assert.True(t, m.IsRunning())
+	assert.ErrorIs(t, m.Start(), ErrSubSystemAlreadyStarted)
+	require.NoError(t, m.Stop())
+	assert.False(t, m.IsRunning())
+}
+
+func TestMarginMonitorUpdate(t *testing.T) {
+	t.Parallel()
+	comms := &fakeComms{}
+	m, err := SetupMarginMonitor(testMarginMonitorConfig(false, true), nil, comms)
+	require.NoError(t, err)
+
+	m.update(testMarginStatus(100, 10))
+	assert.Empty(t, comms.events, "healthy accounts should not alert")
+
+	m.update(testMarginStatus(950, 10))
+	require.Len(t, comms.events, 1)
+	assert.Equal(t, base.SeverityWarning, comms.events[0].Severity)
+	assert.Contains(t, comms.events[0].Message, "initial margin threshold")
+
+	m.update(testMarginStatus(950, 850))
+	require.Len(t, comms.events, 2)
+	assert.Equal(t, base.SeverityCritical, comms.events[1].Severity)
+	assert.Equal(t, comms.events[0].Key, comms.events[1].Key, "alerts for the same account must share a key")
+
+	m.update(testMarginStatus(950, 900))
+	assert.Len(t, comms.events, 2, "unchanged margin levels should not alert again")
+
+	m.update(testMarginStatus(100, 10))
+	require.Len(t, comms.events, 3)
+	assert.True(t, comms.events[2].Resolved, "recovery must resolve the alert")
+	assert.Equal(t, marginEventType, comms.events[2].Type)
+	assert.Empty(t, m.pending, "deleveraging should not be queued when disabled")
+}
+
+func TestMaintenanceMarginRatio(t *testing.T) {
+	t.Parallel()
+	m, err := SetupMarginMonitor(testMarginMonitorConfig(false, true), nil, &fakeComms{})
+	require.NoError(t, err)
+	m.update(testMarginStatus(100, 300))
+	_, ok := m.MaintenanceMarginRatio("margin", asset.Spot)
+	assert.False(t, ok, "a stopped monitor should not report margin")
+
+	m.started = 1
+	s := testMarginStatus(100, 100)
+	s.Account = "SUB"
+	m.update(s)
+	ratio, ok := m.MaintenanceMarginRatio("MARGIN", asset.Spot)
+	require.True(t, ok, "margin must be reported")
+	assert.Equal(t, 0.3, ratio, "the highest account ratio should be returned")
+	_, ok = m.MaintenanceMarginRatio("margin", asset.Futures)
+	assert.False(t, ok, "other assets should not report margin")
+}
+
+func TestMarginMonitorWebsocketHandler(t *testing.T) {
+	t.Parallel()
+	comms := &fakeComms{}
+	m, err := SetupMarginMonitor(testMarginMonitorConfig(false, true), nil, comms)
+	require.NoError(t, err)
+	require.NoError(t, m.websocketMarginHandler("margin", []account.MarginStatus{*testMarginStatus(100, 600)}))
+	assert.Empty(t, comms.events, "updates should be ignored when not running")
+
+	require.NoError(t, m.Start())
+	defer func() {
+		assert.NoError(t, m.Stop())
+	}()
+	require.NoError(t, m.websocketMarginHandler("margin", []account.MarginStatus{*testMarginStatus(100, 600)}))
+	require.NoError(t, m.websocketMarginHandler("margin", "not a margin update"))
+	require.Len(t, comms.events, 1)
+	assert.Equal(t, base.SeverityWarning, comms.events[0].Severity)
+	assert.Contains(t, comms.events[0].Message, "maintenance margin warning threshold")
+}
+
+func TestMarginMonitorDeleverageQueue(t *testing.T) {
+	t.Parallel()
+	m, err := SetupMarginMonitor(testMarginMonitorConfig(true, true), &fakeFuturesOrderManager{}, &fakeComms{})
+	require.NoError(t, err)
+	m.update(testMarginStatus(950, 850))
+	m.update(testMarginStatus(950, 900))
+	assert.Len(t, m.pending, 1, "deleveraging should not repeat within the cooldown")
+
+	m.accounts[marginAccountKey(testMarginStatus(0, 0))].lastDeleverage = time.Now().Add(-time.Hour * 2)
+	m.update(testMarginStatus(950, 900))
+	assert.Len(t, m.pending, 2, "deleveraging should repeat once the cooldown elapses")
+}
+
+func TestDeleverageAccount(t *testing.T) {
+	t.Parallel()
+	om := &fakeFuturesOrderManager{
+		positions: []futures.Position{
+			{Exchange: "margin", Asset: asset.USDTMarginedFutures, Pair: btcusdtPair, LatestDirection: order.Long, LatestSize: decimal.NewFromInt(1), LatestPrice: decimal.NewFromInt(100)},
+			{Exchange: "margin", Asset: asset.USDTMarginedFutures, Pair: ethusdtPair, LatestDirection: order.Short, LatestSize: decimal.NewFromInt(-4), LatestPrice: decimal.NewFromInt(50)},
+			{Exchange: "other", Asset: asset.USDTMarginedFutures, Pair: btcusdtPair, LatestDirection: order.Long, LatestSize: decimal.NewFromInt(100), LatestPrice: decimal.NewFromInt(100)},
+		},
+	}
+	comms := &fakeComms{}
+	m, err := SetupMarginMonitor(testMarginMonitorConfig(true, true), om, comms)
+	require.NoError(t, err)
+
+	require.NoError(t, m.deleverageAccount(context.Background(), testMarginStatus(950, 850)))
+	assert.Empty(t, om.submitted, "dry run should not place orders")
+	require.Len(t, comms.events, 1)
+	assert.Contains(t, comms.events[0].Message, "dry run")
+
+	m.dryRun = false
+	require.NoError(t, m.deleverageAccount(context.Background(), testMarginStatus(950, 850)))
+	require.Len(t, om.submitted, 1)
+	assert.Equal(t, ethusdtPair, om.submitted[0].Pair, "the largest notional position should be reduced")
+	assert.Equal(t, order.Buy, om.submitted[0].Side, "short position should be reduced with a buy")
+	assert.True(t, om.submitted[0].ReduceOnly, "deleverage orders must be reduce only")
+	assert.Equal(t, order.Market, om.submitted[0].Type)
+	assert.Equal(t, 1.0, om.submitted[0].Amount)
+
+	om.reject = func(*order.Submit) error { return errExpectedTestError }
+	assert.ErrorIs(t, m.deleverageAccount(context.Background(), testMarginStatus(950, 850)), errExpectedTestError)
+
+	s := testMarginStatus(950, 850)
+	s.Exchange = "none"
+	assert.ErrorIs(t, m.deleverageAccount(context.Background(), s), errNoPositionToDeleverage)
+}
@@ -0,0 +1,75 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// MarginMonitorName is an exported subsystem name
+const MarginMonitorName = "margin_monitor"
+
+const (
+	// marginEventType is the communications event type used for margin alerts
+	marginEventType = "margin"
+	// maxPendingDeleverages limits deleverage requests queued while a
+	// previous deleverage is being placed
+	maxPendingDeleverages = 100
+)
+
+var (
+	errInvalidMarginThreshold  = errors.New("invalid margin threshold")
+	errInvalidDeleverageConfig = errors.New("invalid deleverage config")
+	errNoPositionToDeleverage  = errors.New("no open position to deleverage")
+)
+
+// marginLevel defines how close an account is to its margin limits
+type marginLevel uint8
+
+const (
+	marginHealthy marginLevel = iota
+	marginInitialExceeded
+	marginWarning
+	marginCritical
+)
+
+// iMarginOrderManager defines the order manager functions used to find and
+// reduce positions
+type iMarginOrderManager interface {
+	GetAllOpenFuturesPositions() ([]futures.Position, error)
+	Submit(context.Context, *order.Submit) (*OrderSubmitResponse, error)
+}
+
+// MarginMonitor tracks margin utilisation reported by exchange account
+// streams, alerting when thresholds are crossed and optionally reducing the
+// largest open position when an account approaches liquidation
+type MarginMonitor struct {
+	started           int32
+	shutdown          chan struct{}
+	wg                sync.WaitGroup
+	verbose           bool
+	initialThreshold  float64
+	warningThreshold  float64
+	criticalThreshold float64
+	deleverage        bool
+	dryRun            bool
+	reduceFraction    float64
+	cooldown          time.Duration
+	orderManager      iMarginOrderManager
+	comms             iCommsManager
+	m                 sync.Mutex
+	accounts          map[string]*marginAccount
+	pending           chan account.MarginStatus
+}
+
+// marginAccount holds the latest margin status of an exchange account
+type marginAccount struct {
+	status         account.MarginStatus
+	level          marginLevel
+	lastDeleverage time.Time
+}
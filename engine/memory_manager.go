@@ -0,0 +1,170 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// SetupMemoryManager creates a memory manager subsystem which accounts for the
+// orderbook, ticker and trade buffer caches
+func SetupMemoryManager(cfg *config.MemoryManager) (*MemoryManager, error) {
+	if cfg == nil {
+		return nil, errNilConfig
+	}
+	if cfg.CheckInterval <= 0 {
+		return nil, fmt.Errorf("%w %v", errInvalidMemoryCheckInterval, cfg.CheckInterval)
+	}
+	return &MemoryManager{
+		verbose:  cfg.Verbose,
+		interval: cfg.CheckInterval,
+		trims:    make(map[string]int64),
+		caches: []memoryCache{
+			{
+				name:   orderbookCache,
+				budget: cfg.OrderbookBudgetMB * bytesPerMB,
+				usage:  orderbook.MemoryUsage,
+				trim: func(budget int64) (string, error) {
+					return fmt.Sprintf("evicted %d least recently updated orderbooks", orderbook.EvictStale(budget)), nil
+				},
+			},
+			{
+				name:   tickerCache,
+				budget: cfg.TickerBudgetMB * bytesPerMB,
+				usage:  ticker.MemoryUsage,
+				trim: func(budget int64) (string, error) {
+					length, err := ticker.TrimHistory(budget)
+					return fmt.Sprintf("reduced ticker history to %d ticks per pair", length), err
+				},
+			},
+			{
+				name:   tradeBufferCache,
+				budget: cfg.TradeBufferBudgetMB * bytesPerMB,
+				usage:  trade.BufferMemoryUsage,
+				trim: func(budget int64) (string, error) {
+					return fmt.Sprintf("dropped %d oldest buffered trades", trade.TrimBuffer(budget)), nil
+				},
+			},
+		},
+	}, nil
+}
+
+// Start runs the subsystem
+func (m *MemoryManager) Start() error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 0, 1) {
+		return ErrSubSystemAlreadyStarted
+	}
+	m.shutdown = make(chan struct{})
+	m.wg.Add(1)
+	go m.run()
+	log.Debugf(log.Global, "Memory manager %s", MsgSubSystemStarted)
+	return nil
+}
+
+// IsRunning checks whether the subsystem is running
+func (m *MemoryManager) IsRunning() bool {
+	if m == nil {
+		return false
+	}
+	return atomic.LoadInt32(&m.started) == 1
+}
+
+// Stop stops the subsystem
+func (m *MemoryManager) Stop() error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 1, 0) {
+		return ErrSubSystemNotStarted
+	}
+	close(m.shutdown)
+	m.wg.Wait()
+	log.Debugf(log.Global, "Memory manager %s", MsgSubSystemShutdown)
+	return nil
+}
+
+func (m *MemoryManager) run() {
+	defer m.wg.Done()
+	runScheduledJobs(m.shutdown, &scheduledJob{interval: m.interval, immediate: true, run: func(context.Context, time.Time) {
+		if err := m.Check(); err != nil {
+			log.Errorf(log.Global, "Memory manager: %v", err)
+		}
+	}})
+}
+
+// Check attributes the approximate memory used by each cache, trimming any
+// cache which exceeds its budget
+func (m *MemoryManager) Check() error {
+	if m == nil {
+		return fmt.Errorf("%s %w", MemoryManagerName, ErrNilSubsystem)
+	}
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	report := &MemoryReport{
+		Caches:    make([]CacheMemoryUsage, len(m.caches)),
+		HeapAlloc: stats.HeapAlloc,
+		HeapSys:   stats.HeapSys,
+		Checked:   time.Now(),
+	}
+
+	m.m.Lock()
+	defer m.m.Unlock()
+	var errs error
+	for i := range m.caches {
+		c := &m.caches[i]
+		usage := CacheMemoryUsage{
+			Name:   c.name,
+			Usage:  c.usage(),
+			Budget: c.budget,
+		}
+		if c.budget > 0 && usage.Usage > c.budget {
+			action, err := c.trim(c.budget)
+			if err != nil {
+				errs = common.AppendError(errs, fmt.Errorf("cannot trim %s: %w", c.name, err))
+			} else {
+				usage.Action = action
+				m.trims[c.name]++
+				log.Warnf(log.Global, "Memory manager: %s using %s exceeds budget of %s, %s",
+					c.name, formatMemory(usage.Usage), formatMemory(c.budget), action)
+			}
+		} else if m.verbose {
+			log.Debugf(log.Global, "Memory manager: %s using %s", c.name, formatMemory(usage.Usage))
+		}
+		usage.Trims = m.trims[c.name]
+		report.Caches[i] = usage
+	}
+	m.report = report
+	return errs
+}
+
+// GetReport returns the result of the last memory check
+func (m *MemoryManager) GetReport() (*MemoryReport, error) {
+	if m == nil {
+		return nil, fmt.Errorf("%s %w", MemoryManagerName, ErrNilSubsystem)
+	}
+	m.m.RLock()
+	defer m.m.RUnlock()
+	if m.report == nil {
+		return nil, errMemoryNotChecked
+	}
+	report := *m.report
+	report.Caches = append([]CacheMemoryUsage(nil), m.report.Caches...)
+	return &report, nil
+}
+
+// formatMemory formats bytes as megabytes
+func formatMemory(b int64) string {
+	return fmt.Sprintf("%.2fMB", float64(b)/bytesPerMB)
+}
@@ -0,0 +1,102 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/config"
+)
+
+func TestSetupMemoryManager(t *testing.T) {
+	t.Parallel()
+	_, err := SetupMemoryManager(nil)
+	assert.ErrorIs(t, err, errNilConfig)
+	_, err = SetupMemoryManager(&config.MemoryManager{})
+	assert.ErrorIs(t, err, errInvalidMemoryCheckInterval)
+
+	m, err := SetupMemoryManager(&config.MemoryManager{CheckInterval: time.Minute, OrderbookBudgetMB: 2})
+	require.NoError(t, err)
+	require.Len(t, m.caches, 3)
+	assert.Equal(t, orderbookCache, m.caches[0].name)
+	assert.Equal(t, int64(2*bytesPerMB), m.caches[0].budget, "budgets should be converted to bytes")
+	assert.Zero(t, m.caches[1].budget)
+}
+
+func TestMemoryManagerStartStop(t *testing.T) {
+	t.Parallel()
+	var m *MemoryManager
+	assert.ErrorIs(t, m.Start(), ErrNilSubsystem)
+	assert.ErrorIs(t, m.Stop(), ErrNilSubsystem)
+	assert.False(t, m.IsRunning())
+
+	m, err := SetupMemoryManager(&config.MemoryManager{CheckInterval: time.Hour})
+	require.NoError(t, err)
+	assert.ErrorIs(t, m.Stop(), ErrSubSystemNotStarted)
+	require.NoError(t, m.Start())
+	assert.True(t, m.IsRunning())
+	assert.ErrorIs(t, m.Start(), ErrSubSystemAlreadyStarted)
+	require.NoError(t, m.Stop())
+	assert.False(t, m.IsRunning())
+}
+
+func TestMemoryManagerCheck(t *testing.T) {
+	t.Parallel()
+	var nilManager *MemoryManager
+	assert.ErrorIs(t, nilManager.Check(), ErrNilSubsystem)
+	_, err := nilManager.GetReport()
+	assert.ErrorIs(t, err, ErrNilSubsystem)
+
+	m, err := SetupMemoryManager(&config.MemoryManager{CheckInterval: time.Hour})
+	require.NoError(t, err)
+	_, err = m.GetReport()
+	assert.ErrorIs(t, err, errMemoryNotChecked)
+
+	var used int64 = 100
+	var trimmedTo int64
+	m.caches = []memoryCache{
+		{
+			name:   "budgeted",
+			budget: 50,
+			usage:  func() int64 { return used },
+			trim: func(budget int64) (string, error) {
+				trimmedTo = budget
+				used = budget
+				return "trimmed", nil
+			},
+		},
+		{
+			name:  "unbudgeted",
+			usage: func() int64 { return 1000 },
+			trim: func(int64) (string, error) {
+				return "", errExpectedTestError
+			},
+		},
+		{
+			name:   "failing",
+			budget: 1,
+			usage:  func() int64 { return 2 },
+			trim: func(int64) (string, error) {
+				return "", errExpectedTestError
+			},
+		},
+	}
+	assert.ErrorIs(t, m.Check(), errExpectedTestError)
+	assert.Equal(t, int64(50), trimmedTo, "caches over budget should be trimmed to the budget")
+
+	report, err := m.GetReport()
+	require.NoError(t, err)
+	assert.NotZero(t, report.HeapAlloc, "HeapAlloc should be set")
+	assert.False(t, report.Checked.IsZero(), "Checked should be set")
+	require.Len(t, report.Caches, 3)
+	assert.Equal(t, CacheMemoryUsage{Name: "budgeted", Usage: 100, Budget: 50, Action: "trimmed", Trims: 1}, report.Caches[0])
+	assert.Equal(t, CacheMemoryUsage{Name: "unbudgeted", Usage: 1000}, report.Caches[1], "caches without a budget must not be trimmed")
+	assert.Equal(t, CacheMemoryUsage{Name: "failing", Usage: 2, Budget: 1}, report.Caches[2], "failed trims should not be recorded")
+
+	m.caches = m.caches[:1]
+	require.NoError(t, m.Check())
+	report, err = m.GetReport()
+	require.NoError(t, err)
+	assert.Equal(t, CacheMemoryUsage{Name: "budgeted", Usage: 50, Budget: 50, Trims: 1}, report.Caches[0], "caches within budget should retain their trim count")
+}
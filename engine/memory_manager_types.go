@@ -0,0 +1,70 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// MemoryManagerName is an exported subsystem name
+const MemoryManagerName = "memory_manager"
+
+const (
+	orderbookCache   = "orderbooks"
+	tickerCache      = "tickers"
+	tradeBufferCache = "trade_buffer"
+	bytesPerMB       = 1 << 20
+)
+
+var (
+	errInvalidMemoryCheckInterval = errors.New("memory check interval must be greater than zero")
+	errMemoryNotChecked           = errors.New("memory usage has not been checked")
+)
+
+// memoryCache defines a cache whose approximate memory usage is attributed by
+// the memory manager
+type memoryCache struct {
+	name string
+	// budget is the memory the cache may use in bytes, zero disables trimming
+	budget int64
+	usage  func() int64
+	// trim reduces the cache to within the budget, returning a description of
+	// the action taken
+	trim func(budget int64) (string, error)
+}
+
+// MemoryManager periodically attributes approximate memory usage to the
+// orderbook, ticker and trade buffer caches, trimming any cache which exceeds
+// its budget
+type MemoryManager struct {
+	started  int32
+	shutdown chan struct{}
+	wg       sync.WaitGroup
+	verbose  bool
+	interval time.Duration
+	caches   []memoryCache
+	m        sync.RWMutex
+	trims    map[string]int64
+	report   *MemoryReport
+}
+
+// MemoryReport holds the result of a memory check
+type MemoryReport struct {
+	Caches []CacheMemoryUsage
+	// HeapAlloc and HeapSys are the runtime's allocated and reserved heap
+	// bytes, for comparison against the attributed usage
+	HeapAlloc uint64
+	HeapSys   uint64
+	Checked   time.Time
+}
+
+// CacheMemoryUsage holds the approximate memory used by a cache in bytes
+type CacheMemoryUsage struct {
+	Name   string
+	Usage  int64
+	Budget int64
+	// Action is the trim taken by the check when the budget was exceeded
+	Action string
+	// Trims is the number of times the cache has been trimmed
+	Trims int64
+}
@@ -2,9 +2,15 @@ package engine
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"math"
+	"os"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -13,15 +19,21 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/shopspring/decimal"
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/file"
+	"github.com/thrasher-corp/gocryptotrader/common/key"
 	"github.com/thrasher-corp/gocryptotrader/communications/base"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/currencystate"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/fundingrate"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/options"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 	"github.com/thrasher-corp/gocryptotrader/log"
 )
 
@@ -44,10 +56,30 @@ func SetupOrderManager(exchangeManager iExchangeManager, communicationsManager i
 	if cfg.RespectOrderHistoryLimits != nil {
 		respectOrderHistoryLimits = *cfg.RespectOrderHistoryLimits
 	}
+	stp, err := order.StringToSelfTradePrevention(cfg.SelfTradePrevention)
+	if err != nil {
+		return nil, err
+	}
+	priceBands, err := setupPriceBands(cfg.PriceBands)
+	if err != nil {
+		return nil, err
+	}
+	maxLeverage, err := setupMaxLeverage(cfg.MaxLeverage)
+	if err != nil {
+		return nil, err
+	}
+	paper, err := setupPaperTrader(&cfg.PaperTrading)
+	if err != nil {
+		return nil, err
+	}
 	om := &OrderManager{
 		shutdown:                      make(chan struct{}),
 		activelyTrackFuturesPositions: cfg.ActivelyTrackFuturesPositions,
 		respectOrderHistoryLimits:     respectOrderHistoryLimits,
+		icebergs:                      make(map[string]*icebergOrder),
+		ocos:                          make(map[string]*ocoOrder),
+		trailingStops:                 make(map[string]*TrailingStop),
+		trailingWatches:               make(map[key.ExchangePairAsset]bool),
 		orderStore: store{
 			Orders:                    make(map[string][]*order.Detail),
 			exchangeManager:           exchangeManager,
@@ -58,8 +90,13 @@ func SetupOrderManager(exchangeManager iExchangeManager, communicationsManager i
 		verbose: cfg.Verbose,
 		cfg: orderManagerConfig{
 			CancelOrdersOnShutdown: cfg.CancelOrdersOnShutdown,
+			SelfTradePrevention:    stp,
+			PriceBands:             priceBands,
+			MaxLeverage:            maxLeverage,
 		},
+		paper: paper,
 	}
+	om.ctx, om.cancel = context.WithCancel(context.Background())
 	if cfg.ActivelyTrackFuturesPositions {
 		if cfg.FuturesTrackingSeekDuration > 0 {
 			cfg.FuturesTrackingSeekDuration *= -1
@@ -72,6 +109,56 @@ func SetupOrderManager(exchangeManager iExchangeManager, communicationsManager i
 	return om, nil
 }
 
+// setupPriceBands validates the configured price bands for each asset,
+// defaulting references to the mark then index price
+func setupPriceBands(cfg map[string]config.PriceBand) (map[asset.Item]priceBand, error) {
+	bands := make(map[asset.Item]priceBand, len(cfg))
+	for k, v := range cfg {
+		a, err := asset.New(k)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %w", errInvalidPriceBand, k, err)
+		}
+		if v.MaxDeviation <= 0 {
+			return nil, fmt.Errorf("%w %s max deviation %v must be above zero", errInvalidPriceBand, a, v.MaxDeviation)
+		}
+		band := priceBand{
+			references:             []string{markPriceReference, indexPriceReference},
+			maxDeviation:           v.MaxDeviation,
+			maxAge:                 v.MaxAge,
+			rejectWithoutReference: v.RejectWithoutReference,
+		}
+		if len(v.References) > 0 {
+			band.references = make([]string, len(v.References))
+			for i := range v.References {
+				band.references[i] = strings.ToLower(v.References[i])
+				switch band.references[i] {
+				case markPriceReference, indexPriceReference, lastPriceReference:
+				default:
+					return nil, fmt.Errorf("%w %s unrecognised reference %q", errInvalidPriceBand, a, v.References[i])
+				}
+			}
+		}
+		bands[a] = band
+	}
+	return bands, nil
+}
+
+// setupMaxLeverage validates the configured max leverage for each asset
+func setupMaxLeverage(cfg map[string]float64) (map[asset.Item]float64, error) {
+	limits := make(map[asset.Item]float64, len(cfg))
+	for k, v := range cfg {
+		a, err := asset.New(k)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %w", errInvalidMaxLeverage, k, err)
+		}
+		if v <= 0 {
+			return nil, fmt.Errorf("%w %s %v must be above zero", errInvalidMaxLeverage, a, v)
+		}
+		limits[a] = v
+	}
+	return limits, nil
+}
+
 // IsRunning safely checks whether the subsystem is running
 func (m *OrderManager) IsRunning() bool {
 	return m != nil && atomic.LoadInt32(&m.started) == 1
@@ -86,7 +173,11 @@ func (m *OrderManager) Start() error {
 		return fmt.Errorf("order manager %w", ErrSubSystemAlreadyStarted)
 	}
 	log.Debugln(log.OrderMgr, "Order manager starting...")
+	if m.paper != nil {
+		log.Warnln(log.OrderMgr, "Order manager paper trading enabled, orders will be filled against live orderbooks and not sent to exchanges")
+	}
 	m.shutdown = make(chan struct{})
+	m.ctx, m.cancel = context.WithCancel(context.Background())
 	m.orderStore.wg.Add(1)
 	go m.run()
 	return nil
@@ -117,23 +208,39 @@ func (m *OrderManager) gracefulShutdown() {
 		log.Errorf(log.OrderMgr, "Order manager cannot get exchanges: %v", err)
 		return
 	}
-	m.CancelAllOrders(context.TODO(), exchanges)
+	m.CancelAllOrders(m.ctx, exchanges)
 }
 
 // run will periodically process orders
 func (m *OrderManager) run() {
 	log.Debugln(log.OrderMgr, "Order manager started.")
 	m.processOrders()
+	var match <-chan time.Time
+	if m.paper != nil {
+		t := time.NewTicker(m.paper.matchInterval)
+		defer t.Stop()
+		match = t.C
+	}
+	trigger := time.NewTicker(stopTriggerInterval)
+	defer trigger.Stop()
 	for {
 		select {
 		case <-m.shutdown:
 			m.gracefulShutdown()
+			m.cancel()
 			m.orderStore.wg.Done()
 			log.Debugln(log.OrderMgr, "Order manager shutdown.")
 			return
 		case <-time.After(orderManagerInterval):
 			// Process orders go routine allows shutdown procedures to continue
 			go m.processOrders()
+		case <-match:
+			m.processPaperOrders()
+		case <-trigger.C:
+			// OCO orders are processed in the background so shutdown is not
+			// held up by submitting their stop legs
+			go m.processOCOs(m.ctx)
+			m.watchTrailingStops()
 		}
 	}
 }
@@ -181,10 +288,11 @@ func (m *OrderManager) Cancel(ctx context.Context, cancel *order.Cancel) error {
 	var err error
 	defer func() {
 		if err != nil {
-			m.orderStore.commsManager.PushEvent(base.Event{
-				Type:    "order",
-				Message: err.Error(),
-			})
+			evt := base.Event{Type: "order", Message: err.Error(), Severity: base.SeverityError}
+			if cancel != nil {
+				evt.Exchange = cancel.Exchange
+			}
+			m.orderStore.commsManager.PushEvent(evt)
 		}
 	}()
 
@@ -213,7 +321,11 @@ func (m *OrderManager) Cancel(ctx context.Context, cancel *order.Cancel) error {
 	log.Debugf(log.OrderMgr, "Cancelling order ID %v [%+v]",
 		cancel.OrderID, cancel)
 
-	err = exch.CancelOrder(ctx, cancel)
+	if m.paper != nil {
+		err = m.cancelPaperOrder(ctx, cancel)
+	} else {
+		err = exch.CancelOrder(request.WithPriority(ctx, request.PriorityOrder), cancel)
+	}
 	if err != nil {
 		err = fmt.Errorf("%v - Failed to cancel order: %w", cancel.Exchange, err)
 		return err
@@ -230,14 +342,239 @@ func (m *OrderManager) Cancel(ctx context.Context, cancel *order.Cancel) error {
 		err = fmt.Errorf("%v - Failed to update existing order when cancelled: %w", cancel.Exchange, err)
 		return err
 	}
+	m.checkOCOLeg(od)
 
 	msg := fmt.Sprintf("Exchange %s order ID=%v cancelled.",
 		od.Exchange, od.OrderID)
 	log.Debugln(log.OrderMgr, msg)
-	m.orderStore.commsManager.PushEvent(base.Event{Type: "order", Message: msg})
+	m.orderStore.commsManager.PushEvent(base.Event{Type: "order", Message: msg, Exchange: od.Exchange})
 	return nil
 }
 
+// CancelAllOrdersFiltered cancels all active orders tracked by the order
+// manager that match the scope filter, returning the outcome of each order.
+// Orders are grouped by exchange, asset and pair. When the scope only narrows
+// by exchange, asset or pair the exchange's cancel all endpoint is used,
+// otherwise the matching orders are cancelled in a batch
+func (m *OrderManager) CancelAllOrdersFiltered(ctx context.Context, scope *order.Filter) ([]CancelResult, error) {
+	if m == nil {
+		return nil, fmt.Errorf("order manager %w", ErrNilSubsystem)
+	}
+	if atomic.LoadInt32(&m.started) == 0 {
+		return nil, fmt.Errorf("order manager %w", ErrSubSystemNotStarted)
+	}
+	if scope == nil {
+		return nil, errNilCancelScope
+	}
+
+	orders := m.orderStore.getActiveOrders(scope)
+	groups := make(map[cancelGroupKey][]order.Detail)
+	keys := make([]cancelGroupKey, 0)
+	for i := range orders {
+		k := cancelGroupKey{
+			Exchange: strings.ToLower(orders[i].Exchange),
+			Asset:    orders[i].AssetType,
+			Pair:     orders[i].Pair.String(),
+		}
+		if _, ok := groups[k]; !ok {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], orders[i])
+	}
+
+	var results []CancelResult
+	useCancelAll := isCancelAllScope(scope)
+	for _, k := range keys {
+		group := groups[k]
+		var groupResults []CancelResult
+		exch, err := m.orderStore.exchangeManager.GetExchangeByName(group[0].Exchange)
+		if err != nil {
+			groupResults = cancelResults(group, nil, err, cancelBatchEndpoint)
+		} else {
+			groupResults = m.cancelGroup(ctx, exch, group, useCancelAll)
+		}
+		for i := range groupResults {
+			if groupResults[i].Cancelled && groupResults[i].OrderID != "" {
+				m.orderCancelled(groupResults[i].Exchange, groupResults[i].OrderID)
+			}
+		}
+		results = append(results, groupResults...)
+	}
+	return results, nil
+}
+
+// cancelGroup cancels orders for a single exchange, asset and pair, falling
+// back to a batch cancel when the exchange does not support cancelling all
+// orders for the pair
+func (m *OrderManager) cancelGroup(ctx context.Context, exch exchange.IBotExchange, group []order.Detail, useCancelAll bool) []CancelResult {
+	if m.paper != nil {
+		results := make([]CancelResult, 0, len(group))
+		for i := range group {
+			c, err := group[i].DeriveCancel()
+			if err == nil {
+				err = m.cancelPaperOrder(ctx, c)
+			}
+			results = append(results, cancelResults(group[i:i+1], nil, err, paperCancelEndpoint)...)
+		}
+		return results
+	}
+	if useCancelAll {
+		if m.verbose {
+			log.Debugf(log.OrderMgr, "Cancelling all %s %s %s orders", exch.GetName(), group[0].AssetType, group[0].Pair)
+		}
+		resp, err := exch.CancelAllOrders(request.WithPriority(ctx, request.PriorityOrder), &order.Cancel{
+			Exchange:  exch.GetName(),
+			AssetType: group[0].AssetType,
+			Pair:      group[0].Pair,
+		})
+		if !errors.Is(err, common.ErrFunctionNotSupported) && !errors.Is(err, common.ErrNotYetImplemented) {
+			results := cancelResults(group, resp.Status, err, cancelAllEndpoint)
+			if err == nil {
+				results = append(results, untrackedCancelResults(group, resp.Status)...)
+			}
+			return results
+		}
+	}
+
+	cancels := make([]order.Cancel, 0, len(group))
+	pending := make([]order.Detail, 0, len(group))
+	var results []CancelResult
+	for i := range group {
+		c, err := group[i].DeriveCancel()
+		if err == nil && c.OrderID == "" && c.ClientOrderID == "" {
+			err = ErrOrderIDCannotBeEmpty
+		}
+		if err != nil {
+			results = append(results, cancelResults(group[i:i+1], nil, err, cancelBatchEndpoint)...)
+			continue
+		}
+		cancels = append(cancels, *c)
+		pending = append(pending, group[i])
+	}
+	if len(cancels) == 0 {
+		return results
+	}
+	if m.verbose {
+		log.Debugf(log.OrderMgr, "Cancelling %d %s %s %s orders", len(cancels), exch.GetName(), group[0].AssetType, group[0].Pair)
+	}
+	resp, err := exchange.CancelOrders(ctx, exch, cancels)
+	var status map[string]string
+	if resp != nil {
+		status = resp.Status
+	}
+	return append(results, cancelResults(pending, status, err, cancelBatchEndpoint)...)
+}
+
+// orderCancelled updates a tracked order's status to cancelled and notifies
+// the communications manager
+func (m *OrderManager) orderCancelled(exchName, orderID string) {
+	od, err := m.orderStore.getByExchangeAndID(exchName, orderID)
+	if err != nil {
+		log.Errorf(log.OrderMgr, "%v - Failed to retrieve order %v to update cancelled status: %v", exchName, orderID, err)
+		return
+	}
+	od.Status = order.Cancelled
+	if err := m.orderStore.updateExisting(od); err != nil {
+		log.Errorf(log.OrderMgr, "%v - Failed to update existing order when cancelled: %v", exchName, err)
+		return
+	}
+	m.checkOCOLeg(od)
+	msg := fmt.Sprintf("Exchange %s order ID=%v cancelled.", od.Exchange, od.OrderID)
+	log.Debugln(log.OrderMgr, msg)
+	m.orderStore.commsManager.PushEvent(base.Event{Type: "order", Message: msg, Exchange: od.Exchange})
+}
+
+// isCancelAllScope returns whether a scope only narrows orders by exchange,
+// asset or pair so that every order of a pair can be cancelled at once
+func isCancelAllScope(f *order.Filter) bool {
+	return f.OrderID == "" &&
+		f.ClientOrderID == "" &&
+		f.StrategyTag == "" &&
+		f.AccountID == "" &&
+		f.ClientID == "" &&
+		f.WalletAddress == "" &&
+		f.InternalOrderID.IsNil() &&
+		(f.Type == order.UnknownType || f.Type == order.AnyType) &&
+		(f.Side == order.UnknownSide || f.Side == order.AnySide) &&
+		(f.Status == order.UnknownStatus || f.Status == order.AnyStatus)
+}
+
+// cancelResults builds the cancel result of each order from an exchange status
+// map keyed by order ID or client order ID. Orders missing from the map are
+// considered cancelled unless the request failed
+func cancelResults(orders []order.Detail, status map[string]string, err error, endpoint string) []CancelResult {
+	results := make([]CancelResult, len(orders))
+	for i := range orders {
+		results[i] = CancelResult{
+			Exchange:      orders[i].Exchange,
+			AssetType:     orders[i].AssetType,
+			Pair:          orders[i].Pair,
+			OrderID:       orders[i].OrderID,
+			ClientOrderID: orders[i].ClientOrderID,
+			StrategyTag:   orders[i].StrategyTag,
+			Endpoint:      endpoint,
+		}
+		s, ok := status[orders[i].OrderID]
+		if !ok && orders[i].OrderID == "" {
+			s, ok = status[orders[i].ClientOrderID]
+		}
+		switch {
+		case ok:
+			results[i].Cancelled = isCancelledStatus(s)
+			results[i].Status = s
+		case err != nil:
+			results[i].Status = err.Error()
+		default:
+			results[i].Cancelled = true
+		}
+		if results[i].Status == "" && results[i].Cancelled {
+			results[i].Status = order.Cancelled.String()
+		}
+	}
+	return results
+}
+
+// untrackedCancelResults returns the cancel results of orders reported by the
+// exchange that are not tracked by the order manager
+func untrackedCancelResults(tracked []order.Detail, status map[string]string) []CancelResult {
+	ids := make([]string, 0, len(status))
+	for id := range status {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	var results []CancelResult
+	for _, id := range ids {
+		if slices.ContainsFunc(tracked, func(d order.Detail) bool { return d.OrderID == id || d.ClientOrderID == id }) {
+			continue
+		}
+		results = append(results, CancelResult{
+			Exchange:  tracked[0].Exchange,
+			AssetType: tracked[0].AssetType,
+			Pair:      tracked[0].Pair,
+			OrderID:   id,
+			Cancelled: isCancelledStatus(status[id]),
+			Status:    status[id],
+			Endpoint:  cancelAllEndpoint,
+		})
+	}
+	return results
+}
+
+// isCancelledStatus returns whether an exchange cancel status indicates the
+// order was cancelled. Exchanges report either an order status or a short
+// success message for cancelled orders and an error message otherwise
+func isCancelledStatus(s string) bool {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "success", "true", "ok":
+		return true
+	}
+	status, err := order.StringToOrderStatus(s)
+	if err != nil {
+		return false
+	}
+	return status == order.Cancelled || status == order.PartiallyCancelled || status == order.PartiallyFilledCancelled
+}
+
 // GetFuturesPositionsForExchange returns futures positions stored within
 // the order manager's futures position tracker that match the provided params
 func (m *OrderManager) GetFuturesPositionsForExchange(exch string, item asset.Item, pair currency.Pair) ([]futures.Position, error) {
@@ -321,7 +658,8 @@ func (m *OrderManager) UpdateOpenPositionUnrealisedPNL(e string, item asset.Item
 }
 
 // GetOrderInfo calls the exchange's wrapper GetOrderInfo function
-// and stores the result in the order manager
+// and stores the result in the order manager. When paper trading the
+// order is returned from the order manager
 func (m *OrderManager) GetOrderInfo(ctx context.Context, exchangeName, orderID string, cp currency.Pair, a asset.Item) (order.Detail, error) {
 	if m == nil {
 		return order.Detail{}, fmt.Errorf("order manager %w", ErrNilSubsystem)
@@ -334,6 +672,14 @@ func (m *OrderManager) GetOrderInfo(ctx context.Context, exchangeName, orderID s
 		return order.Detail{}, ErrOrderIDCannotBeEmpty
 	}
 
+	if m.paper != nil {
+		d, err := m.orderStore.getByExchangeAndID(exchangeName, orderID)
+		if err != nil {
+			return order.Detail{}, err
+		}
+		return *d, nil
+	}
+
 	exch, err := m.orderStore.exchangeManager.GetExchangeByName(exchangeName)
 	if err != nil {
 		return order.Detail{}, err
@@ -365,6 +711,10 @@ func (m *OrderManager) validate(newOrder *order.Submit) error {
 		return fmt.Errorf("order manager: %w", err)
 	}
 
+	if err := m.CheckLeverage(newOrder.AssetType, newOrder.Leverage); err != nil {
+		return err
+	}
+
 	if m.cfg.EnforceLimitConfig {
 		if !m.cfg.AllowMarketOrders && newOrder.Type == order.Market {
 			return errors.New("order market type is not allowed")
@@ -396,6 +746,10 @@ func (m *OrderManager) Modify(ctx context.Context, mod *order.Modify) (*order.Mo
 		return nil, fmt.Errorf("order manager %w", ErrSubSystemNotStarted)
 	}
 
+	if err := m.checkMaintenance(mod.Exchange); err != nil {
+		return nil, err
+	}
+
 	// Fetch details from locally managed order store.
 	det, err := m.orderStore.getByExchangeAndID(mod.Exchange, mod.OrderID)
 	if det == nil || err != nil {
@@ -404,10 +758,13 @@ func (m *OrderManager) Modify(ctx context.Context, mod *order.Modify) (*order.Mo
 
 	// Populate additional Modify fields as some of them are required by various
 	// exchange implementations.
-	mod.Pair = det.Pair                           // Used by Bithumb.
-	mod.Side = det.Side                           // Used by Bithumb.
-	mod.PostOnly = det.PostOnly                   // Used by Poloniex.
-	mod.ImmediateOrCancel = det.ImmediateOrCancel // Used by Poloniex.
+	mod.Pair = det.Pair               // Used by Bithumb.
+	mod.Side = det.Side               // Used by Bithumb.
+	mod.PostOnly = det.PostOnly       // Used by Poloniex.
+	mod.TimeInForce = det.TimeInForce // Used by Poloniex.
+	if mod.ClientOrderID == "" {
+		mod.ClientOrderID = det.ClientOrderID // Used by HitBTC.
+	}
 
 	// Following is just a precaution to not modify orders by mistake if exchange
 	// implementations do not check fields of the Modify struct for zero values.
@@ -423,7 +780,17 @@ func (m *OrderManager) Modify(ctx context.Context, mod *order.Modify) (*order.Mo
 	if err != nil {
 		return nil, err
 	}
-	res, err := exch.ModifyOrder(ctx, mod)
+	if det.Type == order.Limit && mod.Price != det.Price {
+		if err := m.checkPriceBand(ctx, exch, det.AssetType, det.Pair, mod.Price); err != nil {
+			return nil, err
+		}
+	}
+	var res *order.ModifyResponse
+	if m.paper != nil {
+		res, err = m.modifyPaperOrder(ctx, mod)
+	} else {
+		res, err = exch.ModifyOrder(request.WithPriority(ctx, request.PriorityOrder), mod)
+	}
 	if err != nil {
 		message := fmt.Sprintf(
 			"Exchange %s order ID=%v: failed to modify",
@@ -431,8 +798,10 @@ func (m *OrderManager) Modify(ctx context.Context, mod *order.Modify) (*order.Mo
 			mod.OrderID,
 		)
 		m.orderStore.commsManager.PushEvent(base.Event{
-			Type:    "order",
-			Message: message,
+			Type:     "order",
+			Message:  message,
+			Severity: base.SeverityError,
+			Exchange: mod.Exchange,
 		})
 		return nil, err
 	}
@@ -451,8 +820,9 @@ func (m *OrderManager) Modify(ctx context.Context, mod *order.Modify) (*order.Mo
 		message = "Exchange %s order ID=%v: modified successfully"
 	}
 	m.orderStore.commsManager.PushEvent(base.Event{
-		Type:    "order",
-		Message: fmt.Sprintf(message, mod.Exchange, res.OrderID),
+		Type:     "order",
+		Message:  fmt.Sprintf(message, mod.Exchange, res.OrderID),
+		Exchange: mod.Exchange,
 	})
 	return &order.ModifyResponse{OrderID: res.OrderID}, err
 }
@@ -471,10 +841,15 @@ func (m *OrderManager) Submit(ctx context.Context, newOrder *order.Submit) (*Ord
 	if err != nil {
 		return nil, err
 	}
+	err = m.checkMaintenance(newOrder.Exchange)
+	if err != nil {
+		return nil, err
+	}
 	exch, err := m.orderStore.exchangeManager.GetExchangeByName(newOrder.Exchange)
 	if err != nil {
 		return nil, err
 	}
+	newOrder = conformToExecutionLimits(exch, newOrder)
 	// Checks for exchange min max limits for order amounts before order
 	// execution can occur
 	err = exch.CheckOrderExecutionLimits(newOrder.AssetType,
@@ -497,121 +872,1355 @@ func (m *OrderManager) Submit(ctx context.Context, newOrder *order.Submit) (*Ord
 			newOrder.AssetType,
 			err)
 	}
-
-	result, err := exch.SubmitOrder(ctx, newOrder)
+	err = checkPairState(exch, newOrder)
+	if err != nil {
+		return nil, err
+	}
+	if newOrder.Type == order.Limit {
+		if err := m.checkPriceBand(ctx, exch, newOrder.AssetType, newOrder.Pair, newOrder.Price); err != nil {
+			return nil, err
+		}
+	}
+	newOrder, err = m.preventSelfTrade(ctx, exch, newOrder)
 	if err != nil {
 		return nil, err
 	}
+	if newOrder.DisplayAmount > 0 {
+		if b := exch.GetBase(); b == nil || !b.Features.Supports.IcebergOrders[newOrder.AssetType] {
+			return m.submitIceberg(ctx, exch, newOrder)
+		}
+	}
 
-	return m.processSubmittedOrder(result)
+	resp, err := m.placeOrder(ctx, exch, newOrder)
+	if newOrder.DisplayAmount > 0 && errors.Is(err, order.ErrUnsupportedDisplayAmount) {
+		return m.submitIceberg(ctx, exch, newOrder)
+	}
+	return resp, err
 }
 
-// SubmitFakeOrder runs through the same process as order submission
-// but does not touch live endpoints
-func (m *OrderManager) SubmitFakeOrder(newOrder *order.Submit, resultingOrder *order.SubmitResponse, checkExchangeLimits bool) (*OrderSubmitResponse, error) {
-	if m == nil {
-		return nil, fmt.Errorf("order manager %w", ErrNilSubsystem)
-	}
-	if atomic.LoadInt32(&m.started) == 0 {
-		return nil, fmt.Errorf("order manager %w", ErrSubSystemNotStarted)
+// placeOrder submits an order to the exchange, or simulates it when paper
+// trading, and tracks the result
+func (m *OrderManager) placeOrder(ctx context.Context, exch exchange.IBotExchange, s *order.Submit) (*OrderSubmitResponse, error) {
+	if m.paper != nil {
+		return m.placePaperOrder(ctx, s)
 	}
-
-	err := m.validate(newOrder)
+	result, err := exch.SubmitOrder(request.WithPriority(ctx, request.PriorityOrder), s)
 	if err != nil {
 		return nil, err
 	}
-	exch, err := m.orderStore.exchangeManager.GetExchangeByName(newOrder.Exchange)
+	if result != nil {
+		// Strategy tags are not sent to exchanges so may not be carried over
+		result.StrategyTag = s.StrategyTag
+	}
+	return m.processSubmittedOrder(result)
+}
+
+// submitIceberg emulates an iceberg order for an exchange which does not
+// support display amounts by submitting its first display sized slice. The
+// remaining amount is placed a slice at a time by processIcebergs as each
+// slice is filled. The response is for the first slice
+func (m *OrderManager) submitIceberg(ctx context.Context, exch exchange.IBotExchange, s *order.Submit) (*OrderSubmitResponse, error) {
+	ice := &icebergOrder{submit: *s, remaining: decimal.NewFromFloat(s.Amount)}
+	resp, amount, err := m.placeIcebergSlice(ctx, exch, ice)
 	if err != nil {
 		return nil, err
 	}
+	m.icebergMtx.Lock()
+	ice.placed(resp, amount)
+	if ice.remaining.IsPositive() {
+		m.icebergs[resp.InternalOrderID] = ice
+	}
+	m.icebergMtx.Unlock()
+	if m.verbose {
+		log.Debugf(log.OrderMgr, "Order manager emulating %s %s %s %s iceberg order amount %v display amount %v",
+			s.Exchange, s.AssetType, s.Pair, s.Side, s.Amount, s.DisplayAmount)
+	}
+	return resp, nil
+}
 
-	if checkExchangeLimits {
-		// Checks for exchange min max limits for order amounts before order
-		// execution can occur
-		err = exch.CheckOrderExecutionLimits(newOrder.AssetType,
-			newOrder.Pair,
-			newOrder.Price,
-			newOrder.Amount,
-			newOrder.Type)
-		if err != nil {
-			return nil, fmt.Errorf("order manager: exchange %s unable to place order: %w",
-				newOrder.Exchange,
-				err)
-		}
+// placeIcebergSlice submits the next slice of an emulated iceberg order,
+// returning the amount placed. Slices are suffixed with their number when a
+// client order ID is set so each slice remains unique. The iceberg lock must
+// not be held, as the slice is submitted to the exchange
+func (m *OrderManager) placeIcebergSlice(ctx context.Context, exch exchange.IBotExchange, ice *icebergOrder) (*OrderSubmitResponse, decimal.Decimal, error) {
+	slice := ice.submit
+	slice.DisplayAmount = 0
+	amount := icebergSliceAmount(exch, ice)
+	slice.Amount = amount.InexactFloat64()
+	if slice.ClientOrderID != "" {
+		slice.ClientOrderID += "-" + strconv.Itoa(ice.slices+1)
+	}
+	slice = *conformToExecutionLimits(exch, &slice)
+	if err := exch.CheckOrderExecutionLimits(slice.AssetType, slice.Pair, slice.Price, slice.Amount, slice.Type); err != nil {
+		return nil, decimal.Zero, fmt.Errorf("order manager: iceberg order slice %d: %w", ice.slices+1, err)
+	}
+	resp, err := m.placeOrder(ctx, exch, &slice)
+	if err != nil {
+		return nil, decimal.Zero, err
 	}
-	return m.processSubmittedOrder(resultingOrder)
+	return resp, amount, nil
 }
 
-// GetOrdersSnapshot returns a snapshot of all orders in the orderstore. It optionally filters any orders that do not match the status
-// but a status of "" or ANY will include all
-// the time adds contexts for when the snapshot is relevant for
-func (m *OrderManager) GetOrdersSnapshot(s order.Status) []order.Detail {
-	if m == nil || atomic.LoadInt32(&m.started) == 0 {
-		return nil
+// icebergSliceAmount returns the amount of the next slice of an iceberg order,
+// its display amount or the remaining amount if less. Slices are floored onto
+// the exchange's amount step so the remainder stays on the step, and a
+// remainder below the minimum amount is merged into the slice so it is never
+// left unplaceable
+func icebergSliceAmount(exch exchange.IBotExchange, ice *icebergOrder) decimal.Decimal {
+	amount := decimal.Min(decimal.NewFromFloat(ice.submit.DisplayAmount), ice.remaining)
+	limits, err := exch.GetOrderExecutionLimits(ice.submit.AssetType, ice.submit.Pair)
+	if err != nil {
+		return amount
 	}
-	var os []order.Detail
-	for _, v := range m.orderStore.Orders {
-		for i := range v {
-			if s != v[i].Status && s != order.AnyStatus && s != order.UnknownStatus {
-				continue
-			}
-			os = append(os, *v[i])
-		}
+	if floored := limits.ConformToDecimalAmount(amount); floored.IsPositive() {
+		amount = floored
 	}
+	minimum := decimal.NewFromFloat(limits.MinimumBaseAmount)
+	if ice.submit.Type == order.Market && limits.MarketMinQty > 0 {
+		minimum = decimal.NewFromFloat(limits.MarketMinQty)
+	}
+	if tail := ice.remaining.Sub(amount); tail.IsPositive() && tail.LessThan(minimum) {
+		amount = ice.remaining
+	}
+	return amount
+}
 
-	return os
+// placed records a slice of the iceberg order being placed. m.icebergMtx must
+// be held once the iceberg order is tracked
+func (ice *icebergOrder) placed(resp *OrderSubmitResponse, amount decimal.Decimal) {
+	ice.remaining = ice.remaining.Sub(amount)
+	ice.sliceID = resp.OrderID
+	ice.slices++
 }
 
-// GetOrdersFiltered returns a snapshot of all orders in the order store.
-// Filtering is applied based on the order.Filter unless entries are empty
-func (m *OrderManager) GetOrdersFiltered(f *order.Filter) ([]order.Detail, error) {
-	if m == nil {
-		return nil, fmt.Errorf("order manager %w", ErrNilSubsystem)
+// processIcebergs places the next slice of each emulated iceberg order whose
+// current slice has been filled, and stops emulating iceberg orders whose
+// slice was cancelled or rejected. Slices which fail to be placed with a
+// transient error are retried once the error's retry hint allows. Slices are
+// submitted without holding the iceberg lock
+func (m *OrderManager) processIcebergs(ctx context.Context) {
+	type pending struct {
+		id   string
+		ice  *icebergOrder
+		exch exchange.IBotExchange
+	}
+	var ready []pending
+	m.icebergMtx.Lock()
+	for id, ice := range m.icebergs {
+		if ice.placing {
+			continue
+		}
+		slice, err := m.orderStore.getByExchangeAndID(ice.submit.Exchange, ice.sliceID)
+		if err != nil {
+			log.Errorf(log.OrderMgr, "Order manager unable to find %s iceberg order slice %s: %v", ice.submit.Exchange, ice.sliceID, err)
+			continue
+		}
+		if slice.Status != order.Filled && (slice.Amount <= 0 || slice.ExecutedAmount < slice.Amount) {
+			if slice.IsInactive() {
+				log.Warnf(log.OrderMgr, "Order manager stopped %s %s %s iceberg order with %v remaining as slice %s is %s",
+					ice.submit.Exchange, ice.submit.AssetType, ice.submit.Pair, ice.remaining, ice.sliceID, slice.Status)
+				delete(m.icebergs, id)
+			}
+			continue
+		}
+		if m.checkMaintenance(ice.submit.Exchange) != nil {
+			// The next slice is placed once maintenance ends
+			continue
+		}
+		if time.Now().Before(ice.retryAt) {
+			continue
+		}
+		exch, err := m.orderStore.exchangeManager.GetExchangeByName(ice.submit.Exchange)
+		if err != nil {
+			log.Errorln(log.OrderMgr, err)
+			continue
+		}
+		ice.placing = true
+		ready = append(ready, pending{id: id, ice: ice, exch: exch})
+	}
+	m.icebergMtx.Unlock()
+
+	for _, p := range ready {
+		ice := p.ice
+		resp, amount, err := m.placeIcebergSlice(ctx, p.exch, ice)
+		m.icebergMtx.Lock()
+		ice.placing = false
+		switch {
+		case err == nil:
+			ice.placed(resp, amount)
+			if !ice.remaining.IsPositive() {
+				delete(m.icebergs, p.id)
+			}
+		case request.IsTransient(err):
+			hint, _ := request.GetRetryHint(err)
+			ice.retryAt = time.Now().Add(hint.RetryAfter)
+			log.Warnf(log.OrderMgr, "Order manager unable to place %s %s %s iceberg order slice %d, retrying after %s: %v",
+				ice.submit.Exchange, ice.submit.AssetType, ice.submit.Pair, ice.slices+1, hint.RetryAfter, err)
+		default:
+			log.Errorf(log.OrderMgr, "Order manager unable to place %s %s %s iceberg order slice %d: %v",
+				ice.submit.Exchange, ice.submit.AssetType, ice.submit.Pair, ice.slices+1, err)
+			delete(m.icebergs, p.id)
+		}
+		m.icebergMtx.Unlock()
 	}
-	if atomic.LoadInt32(&m.started) == 0 {
-		return nil, fmt.Errorf("order manager %w", ErrSubSystemNotStarted)
+}
+
+// isIcebergSlice returns whether an order is the current slice of an emulated
+// iceberg order
+func (m *OrderManager) isIcebergSlice(d *order.Detail) bool {
+	m.icebergMtx.Lock()
+	defer m.icebergMtx.Unlock()
+	for _, ice := range m.icebergs {
+		if ice.sliceID == d.OrderID && strings.EqualFold(ice.submit.Exchange, d.Exchange) {
+			return true
+		}
 	}
-	return m.orderStore.getFilteredOrders(f)
+	return false
 }
 
-// GetOrdersActive returns a snapshot of all orders in the order store
-// that have a status that indicates it's currently tradable
-func (m *OrderManager) GetOrdersActive(f *order.Filter) ([]order.Detail, error) {
+// SubmitOptionStrategy submits a multi-leg option strategy. Exchanges which
+// support combo orders receive the strategy as a single order so its legs are
+// filled together. Elsewhere each leg is submitted in turn, bought legs first,
+// and the legs already placed are cancelled or reversed when a leg fails or is
+// not filled within the fill timeout so no partial strategy is left open
+func (m *OrderManager) SubmitOptionStrategy(ctx context.Context, exchName string, a asset.Item, s *options.Strategy, amount float64, controls LegRiskControls) (*StrategySubmitResponse, error) {
 	if m == nil {
 		return nil, fmt.Errorf("order manager %w", ErrNilSubsystem)
 	}
 	if atomic.LoadInt32(&m.started) == 0 {
 		return nil, fmt.Errorf("order manager %w", ErrSubSystemNotStarted)
 	}
-	return m.orderStore.getActiveOrders(f), nil
-}
-
-// processSubmittedOrder adds a new order to the manager
-func (m *OrderManager) processSubmittedOrder(newOrderResp *order.SubmitResponse) (*OrderSubmitResponse, error) {
-	if newOrderResp == nil {
-		return nil, order.ErrOrderDetailIsNil
+	if a != asset.Options {
+		return nil, fmt.Errorf("%w: %s", errStrategyNotOptions, a)
 	}
-
-	id, err := uuid.NewV4()
+	if controls.FillTimeout < 0 {
+		return nil, fmt.Errorf("%w: %s", errInvalidLegFillTimeout, controls.FillTimeout)
+	}
+	if err := m.checkMaintenance(exchName); err != nil {
+		return nil, err
+	}
+	legs, err := s.Orders(exchName, a, amount)
 	if err != nil {
-		log.Warnf(log.OrderMgr, "Unable to generate UUID. Err: %s", err)
+		return nil, err
+	}
+	for i := range legs {
+		if err := m.validate(legs[i]); err != nil {
+			return nil, err
+		}
+	}
+	exch, err := m.orderStore.exchangeManager.GetExchangeByName(exchName)
+	if err != nil {
+		return nil, err
 	}
 
-	detail, err := newOrderResp.DeriveDetail(id)
+	if m.paper != nil {
+		// Combo orders are not simulated so each leg is filled in turn
+		return m.submitStrategyLegs(ctx, legs, controls)
+	}
+	comboPair, err := s.Pair()
 	if err != nil {
 		return nil, err
 	}
+	c := &options.ComboOrder{
+		Exchange: exchName,
+		Asset:    asset.OptionCombo,
+		Pair:     comboPair,
+		Strategy: s,
+		Amount:   amount,
+		Price:    s.NetPrice(),
+	}
+	combo, err := exch.SubmitComboOrder(request.WithPriority(ctx, request.PriorityOrder), c)
+	switch {
+	case err == nil:
+		log.Debugf(log.OrderMgr, "Exchange %s submitted %s option strategy combo ID=%v order ID=%v pair=%v amount=%v",
+			exchName, s.Type, combo.ComboID, combo.OrderID, comboPair, amount)
+		if err := m.orderStore.add(comboDetail(c, combo)); err != nil {
+			log.Errorf(log.OrderMgr, "Order manager unable to track %s combo order %s: %v", exchName, combo.OrderID, err)
+		}
+		return &StrategySubmitResponse{Combo: combo}, nil
+	case !errors.Is(err, common.ErrFunctionNotSupported):
+		return nil, err
+	}
+	return m.submitStrategyLegs(ctx, legs, controls)
+}
 
-	if err := m.orderStore.add(detail.CopyToPointer()); errors.Is(err, ErrOrdersAlreadyExists) {
-		// Streamed by ws before we got here. Details from ws supersede since they are more recent.
-		detail = m.orderStore.getByDetail(detail)
-	} else if err != nil {
-		// Non-fatal error: Unable to store order, but error does not need to be returned to caller
-		log.Errorf(log.OrderMgr, "Unable to add %v order %v to orderStore: %s", detail.Exchange, detail.OrderID, err)
+// comboDetail returns the order detail a placed combo order is tracked by. The
+// combo is bought, with the direction of each leg defined by the strategy
+func comboDetail(c *options.ComboOrder, resp *options.ComboResponse) *order.Detail {
+	d := &order.Detail{
+		Exchange:        c.Exchange,
+		OrderID:         resp.OrderID,
+		ClientOrderID:   c.ClientOrderID,
+		Pair:            c.Pair,
+		AssetType:       c.Asset,
+		Side:            order.Buy,
+		Type:            order.Market,
+		Amount:          c.Amount,
+		RemainingAmount: c.Amount,
+		Price:           c.Price,
+		Status:          resp.Status,
+		Date:            resp.Time,
+		LastUpdated:     time.Now(),
+	}
+	if c.Price != 0 {
+		d.Type = order.Limit
+	}
+	if d.Date.IsZero() {
+		d.Date = d.LastUpdated
+	}
+	return d
+}
+
+// submitStrategyLegs submits the legs of a strategy in order, waiting for each
+// to fill when a fill timeout is set before placing the next
+func (m *OrderManager) submitStrategyLegs(ctx context.Context, legs []*order.Submit, controls LegRiskControls) (*StrategySubmitResponse, error) {
+	resp := &StrategySubmitResponse{Legs: make([]*OrderSubmitResponse, 0, len(legs))}
+	for i := range legs {
+		placed, err := m.Submit(ctx, legs[i])
+		if err == nil {
+			resp.Legs = append(resp.Legs, placed)
+			if controls.FillTimeout > 0 {
+				err = m.awaitLegFill(ctx, placed, controls.FillTimeout)
+			}
+		}
+		if err == nil {
+			continue
+		}
+		err = fmt.Errorf("%s %s %s leg: %w", legs[i].AssetType, legs[i].Pair, legs[i].Side, err)
+		if unwindErr := m.unwindStrategyLegs(ctx, resp.Legs); unwindErr != nil {
+			return resp, fmt.Errorf("%w, %w and unwinding placed legs: %w", errStrategyLegsExposed, err, unwindErr)
+		}
+		resp.Unwound = len(resp.Legs) > 0
+		return resp, err
 	}
+	return resp, nil
+}
 
-	msg := fmt.Sprintf("Exchange %s submitted order ID=%v [Ours: %v] pair=%v price=%v amount=%v quoteAmount=%v side=%v type=%v for time %v.",
-		detail.Exchange,
+// awaitLegFill polls the exchange until a placed leg is filled, updating its
+// details. errLegNotFilled is returned when the order is closed without being
+// filled or the timeout elapses
+func (m *OrderManager) awaitLegFill(ctx context.Context, leg *OrderSubmitResponse, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		d, err := m.GetOrderInfo(ctx, leg.Exchange, leg.OrderID, leg.Pair, leg.AssetType)
+		if err != nil {
+			return err
+		}
+		leg.Detail = &d
+		if d.Status == order.Filled || (d.Amount > 0 && d.ExecutedAmount >= d.Amount) {
+			return nil
+		}
+		if d.Status != order.UnknownStatus && d.Status.IsInactive() {
+			return fmt.Errorf("%w: order %s", errLegNotFilled, d.Status)
+		}
+		wait := time.Until(deadline)
+		if wait <= 0 {
+			return fmt.Errorf("%w within %s", errLegNotFilled, timeout)
+		}
+		t := time.NewTimer(min(wait, strategyLegPollInterval))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// unwindStrategyLegs cancels the unfilled remainder of placed legs and
+// reverses any executed amount with market orders, working back from the last
+// leg placed. Market orders are assumed to be filled unless the exchange
+// reports otherwise
+func (m *OrderManager) unwindStrategyLegs(ctx context.Context, legs []*OrderSubmitResponse) error {
+	var errs error
+	for i := len(legs) - 1; i >= 0; i-- {
+		d := legs[i].Detail
+		if d.Type != order.Market && d.IsActive() {
+			cancel, err := d.DeriveCancel()
+			if err == nil {
+				err = m.Cancel(ctx, cancel)
+			}
+			if err != nil {
+				errs = errors.Join(errs, fmt.Errorf("cancelling %s %s leg: %w", d.Pair, d.Side, err))
+				continue
+			}
+		}
+		executed := d.ExecutedAmount
+		switch {
+		case executed > 0:
+		case d.Status == order.Filled,
+			d.Type == order.Market && d.Status != order.Cancelled && d.Status != order.Rejected:
+			executed = d.Amount
+		}
+		if executed <= 0 {
+			continue
+		}
+		reverse := &order.Submit{
+			Exchange:  d.Exchange,
+			Pair:      d.Pair,
+			AssetType: d.AssetType,
+			Side:      order.Buy,
+			Type:      order.Market,
+			Amount:    executed,
+		}
+		if d.Side.IsLong() {
+			reverse.Side = order.Sell
+		}
+		if _, err := m.Submit(ctx, reverse); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("reversing %s %s leg: %w", d.Pair, d.Side, err))
+		}
+	}
+	return errs
+}
+
+// SubmitOCO submits a one-cancels-the-other order. Exchanges which support OCO
+// orders receive both legs as a single order list. Elsewhere the limit leg is
+// placed on the exchange and the stop leg is held by the order manager, which
+// cancels the limit leg and submits the stop leg once the ticker reaches the
+// trigger price. The held stop leg is dropped when updates to the limit leg
+// show it executing or closing
+func (m *OrderManager) SubmitOCO(ctx context.Context, o *order.OCOSubmit) (*OCOSubmitResponse, error) {
+	if m == nil {
+		return nil, fmt.Errorf("order manager %w", ErrNilSubsystem)
+	}
+	if atomic.LoadInt32(&m.started) == 0 {
+		return nil, fmt.Errorf("order manager %w", ErrSubSystemNotStarted)
+	}
+	if err := o.Validate(); err != nil {
+		return nil, fmt.Errorf("order manager: %w", err)
+	}
+	if m.paper != nil {
+		return nil, fmt.Errorf("%w: %w", common.ErrFunctionNotSupported, errOCONotSimulated)
+	}
+	limit, stop := o.Legs()
+	triggered := ocoStopOrder(stop)
+	for _, leg := range []*order.Submit{limit, &triggered} {
+		if err := m.validate(leg); err != nil {
+			return nil, err
+		}
+	}
+	if err := m.checkMaintenance(o.Exchange); err != nil {
+		return nil, err
+	}
+	exch, err := m.orderStore.exchangeManager.GetExchangeByName(o.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	if err := exch.CanTradePair(o.Pair, o.AssetType); err != nil {
+		return nil, fmt.Errorf("order manager: exchange %s cannot trade pair %s %s: %w", o.Exchange, o.Pair, o.AssetType, err)
+	}
+	if err := checkPairState(exch, limit); err != nil {
+		return nil, err
+	}
+
+	native, err := exch.SubmitOCOOrder(request.WithPriority(ctx, request.PriorityOrder), o)
+	switch {
+	case err == nil:
+		return m.processSubmittedOCO(native)
+	case !errors.Is(err, common.ErrFunctionNotSupported):
+		return nil, err
+	}
+
+	placed, err := m.Submit(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	resp := &OCOSubmitResponse{Limit: placed, Emulated: true}
+	if placed.ExecutedAmount > 0 || placed.Status.IsInactive() {
+		// The limit leg executing immediately cancels the stop leg
+		return resp, nil
+	}
+	m.ocoMtx.Lock()
+	m.ocos[placed.OrderID] = &ocoOrder{
+		exchange:     placed.Exchange,
+		limitID:      placed.OrderID,
+		triggerPrice: o.TriggerPrice,
+		stop:         triggered,
+	}
+	m.ocoMtx.Unlock()
+	log.Debugf(log.OrderMgr, "Order manager emulating %s %s %s %s OCO order limit order ID=%v trigger price=%v",
+		o.Exchange, o.AssetType, o.Pair, o.Side, placed.OrderID, o.TriggerPrice)
+	return resp, nil
+}
+
+// processSubmittedOCO tracks both legs of a natively submitted OCO order
+func (m *OrderManager) processSubmittedOCO(native *order.OCOSubmitResponse) (*OCOSubmitResponse, error) {
+	limit, err := m.processSubmittedOrder(native.Limit)
+	if err != nil {
+		return nil, err
+	}
+	stop, err := m.processSubmittedOrder(native.Stop)
+	if err != nil {
+		return nil, err
+	}
+	return &OCOSubmitResponse{ListID: native.ListID, Limit: limit, Stop: stop}, nil
+}
+
+// ocoStopOrder returns the order submitted when an emulated stop leg triggers,
+// a market order or a limit order at the stop limit price
+func ocoStopOrder(stop *order.Submit) order.Submit {
+	triggered := *stop
+	triggered.TriggerPrice = 0
+	triggered.Type = order.Market
+	if stop.Type == order.StopLimit {
+		triggered.Type = order.Limit
+	}
+	return triggered
+}
+
+// checkOCOLeg drops the held stop leg of an emulated OCO order once its limit
+// leg has executed or closed
+func (m *OrderManager) checkOCOLeg(d *order.Detail) {
+	if d.ExecutedAmount <= 0 && !d.IsInactive() {
+		return
+	}
+	m.ocoMtx.Lock()
+	oco, ok := m.ocos[d.OrderID]
+	if ok && strings.EqualFold(oco.exchange, d.Exchange) {
+		delete(m.ocos, d.OrderID)
+	}
+	m.ocoMtx.Unlock()
+	if ok {
+		log.Infof(log.OrderMgr, "Order manager cancelled %s %s %s OCO stop leg as limit order ID=%v is %s",
+			d.Exchange, d.AssetType, d.Pair, d.OrderID, d.Status)
+	}
+}
+
+// processOCOs submits the held stop legs of emulated OCO orders whose trigger
+// price has been reached by the last traded price. The limit leg is cancelled
+// first so both legs cannot execute. Calls made while OCO orders are being
+// processed return immediately
+func (m *OrderManager) processOCOs(ctx context.Context) {
+	if !atomic.CompareAndSwapInt32(&m.processingOCOs, 0, 1) {
+		return
+	}
+	defer atomic.StoreInt32(&m.processingOCOs, 0)
+	var triggered []*ocoOrder
+	m.ocoMtx.Lock()
+	for id, oco := range m.ocos {
+		t, err := ticker.GetTicker(oco.exchange, oco.stop.Pair, oco.stop.AssetType)
+		if err != nil || t.Last <= 0 {
+			continue
+		}
+		if oco.stop.Side.IsLong() && t.Last < oco.triggerPrice ||
+			oco.stop.Side.IsShort() && t.Last > oco.triggerPrice {
+			continue
+		}
+		delete(m.ocos, id)
+		triggered = append(triggered, oco)
+	}
+	m.ocoMtx.Unlock()
+	for _, oco := range triggered {
+		if err := m.triggerOCOStop(ctx, oco); err != nil {
+			log.Errorf(log.OrderMgr, "Order manager unable to trigger %s %s %s OCO stop leg of limit order ID=%v: %v",
+				oco.exchange, oco.stop.AssetType, oco.stop.Pair, oco.limitID, err)
+		}
+	}
+}
+
+// triggerOCOStop cancels the limit leg of a triggered OCO order and submits
+// its stop leg. The stop leg is not submitted if the limit leg has executed
+func (m *OrderManager) triggerOCOStop(ctx context.Context, oco *ocoOrder) error {
+	limit, err := m.orderStore.getByExchangeAndID(oco.exchange, oco.limitID)
+	if err != nil {
+		return err
+	}
+	if limit.ExecutedAmount <= 0 && !limit.IsInactive() {
+		cancel, err := limit.DeriveCancel()
+		if err != nil {
+			return err
+		}
+		if err := m.Cancel(ctx, cancel); err != nil {
+			return fmt.Errorf("cancelling limit leg: %w", err)
+		}
+		if limit, err = m.orderStore.getByExchangeAndID(oco.exchange, oco.limitID); err != nil {
+			return err
+		}
+	}
+	if limit.ExecutedAmount > 0 || limit.Status == order.Filled {
+		log.Infof(log.OrderMgr, "Order manager not submitting %s %s %s OCO stop leg as limit order ID=%v has executed",
+			oco.exchange, oco.stop.AssetType, oco.stop.Pair, oco.limitID)
+		return nil
+	}
+	resp, err := m.Submit(ctx, &oco.stop)
+	if err != nil {
+		return err
+	}
+	log.Infof(log.OrderMgr, "Order manager triggered %s %s %s OCO stop leg order ID=%v at trigger price %v",
+		oco.exchange, oco.stop.AssetType, oco.stop.Pair, resp.OrderID, oco.triggerPrice)
+	return nil
+}
+
+// SubmitTrailingStop submits a trailing stop order emulated by the order
+// manager for exchanges without native trailing stops. The stop price starts
+// trailing the last traded price, follows the price as the pair's ticker
+// stream moves in the order's favour and submits the order once the last price
+// breaches it. Trailing stops are persisted so they resume after a restart
+func (m *OrderManager) SubmitTrailingStop(ts *order.TrailingStopSubmit) (*TrailingStop, error) {
+	if m == nil {
+		return nil, fmt.Errorf("order manager %w", ErrNilSubsystem)
+	}
+	if atomic.LoadInt32(&m.started) == 0 {
+		return nil, fmt.Errorf("order manager %w", ErrSubSystemNotStarted)
+	}
+	if err := ts.Validate(); err != nil {
+		return nil, fmt.Errorf("order manager: %w", err)
+	}
+	if err := m.checkMaintenance(ts.Exchange); err != nil {
+		return nil, err
+	}
+	exch, err := m.orderStore.exchangeManager.GetExchangeByName(ts.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	if err := exch.CanTradePair(ts.Pair, ts.AssetType); err != nil {
+		return nil, fmt.Errorf("order manager: exchange %s cannot trade pair %s %s: %w", ts.Exchange, ts.Pair, ts.AssetType, err)
+	}
+	t, err := ticker.GetTicker(ts.Exchange, ts.Pair, ts.AssetType)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errNoReferencePrice, err)
+	}
+	if t.Last <= 0 {
+		return nil, fmt.Errorf("%w, ticker last price unset", errNoReferencePrice)
+	}
+	stopPrice := ts.StopPrice(t.Last)
+	if stopPrice <= 0 {
+		return nil, fmt.Errorf("%w: stop price %v from last price %v", order.ErrTrailInvalid, stopPrice, t.Last)
+	}
+	triggered := ts.Order(stopPrice)
+	if err := m.validate(triggered); err != nil {
+		return nil, err
+	}
+	if err := checkPairState(exch, triggered); err != nil {
+		return nil, err
+	}
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	stop := &TrailingStop{
+		ID:                 id.String(),
+		TrailingStopSubmit: *ts,
+		BestPrice:          t.Last,
+		StopPrice:          stopPrice,
+		Created:            time.Now(),
+		Status:             TrailingStopActive,
+	}
+	m.trailingMtx.Lock()
+	m.trailingStops[stop.ID] = stop
+	if err := m.saveTrailingStops(); err != nil {
+		delete(m.trailingStops, stop.ID)
+		m.trailingMtx.Unlock()
+		return nil, fmt.Errorf("unable to persist trailing stop: %w", err)
+	}
+	cpy := *stop
+	m.trailingMtx.Unlock()
+	m.watchTrailingStops()
+	log.Debugf(log.OrderMgr, "Order manager emulating %s %s %s %s trailing stop ID=%v stop price=%v",
+		ts.Exchange, ts.AssetType, ts.Pair, ts.Side, stop.ID, stopPrice)
+	return &cpy, nil
+}
+
+// CancelTrailingStop stops emulating a trailing stop order. Trailing stops
+// cannot be cancelled while their order is being submitted
+func (m *OrderManager) CancelTrailingStop(id string) error {
+	if m == nil {
+		return fmt.Errorf("order manager %w", ErrNilSubsystem)
+	}
+	m.trailingMtx.Lock()
+	defer m.trailingMtx.Unlock()
+	stop, ok := m.trailingStops[id]
+	if !ok {
+		return fmt.Errorf("%w: %s", errTrailingStopNotFound, id)
+	}
+	if stop.Status == TrailingStopTriggering {
+		return fmt.Errorf("%w: %s", errTrailingStopTriggering, id)
+	}
+	delete(m.trailingStops, id)
+	if err := m.saveTrailingStops(); err != nil {
+		m.trailingStops[id] = stop
+		return fmt.Errorf("unable to persist trailing stop: %w", err)
+	}
+	return nil
+}
+
+// GetTrailingStops returns the trailing stop orders being emulated, oldest
+// first
+func (m *OrderManager) GetTrailingStops() []TrailingStop {
+	if m == nil {
+		return nil
+	}
+	m.trailingMtx.Lock()
+	defer m.trailingMtx.Unlock()
+	stops := make([]TrailingStop, 0, len(m.trailingStops))
+	for _, ts := range m.trailingStops {
+		stops = append(stops, *ts)
+	}
+	sort.Slice(stops, func(i, j int) bool {
+		return stops[i].Created.Before(stops[j].Created)
+	})
+	return stops
+}
+
+// watchTrailingStops subscribes to the ticker stream of each pair with active
+// trailing stops which is not already being streamed. Pairs whose ticker is
+// unavailable, such as trailing stops restored before their ticker is
+// fetched, are retried on the next call
+func (m *OrderManager) watchTrailingStops() {
+	m.trailingMtx.Lock()
+	defer m.trailingMtx.Unlock()
+	for _, ts := range m.trailingStops {
+		k := ts.key()
+		if ts.Status != TrailingStopActive || m.trailingWatches[k] {
+			continue
+		}
+		pipe, err := ticker.SubscribeTicker(ts.Exchange, ts.Pair, ts.AssetType)
+		if err != nil {
+			if m.verbose {
+				log.Debugf(log.OrderMgr, "Order manager unable to stream %s %s %s ticker for trailing stops: %v", ts.Exchange, ts.AssetType, ts.Pair, err)
+			}
+			continue
+		}
+		m.trailingWatches[k] = true
+		go m.streamTrailingStops(m.ctx, m.shutdown, k, pipe)
+	}
+}
+
+// streamTrailingStops processes the trailing stops of a pair as its ticker is
+// updated, until the pair has no active trailing stops or the order manager
+// shuts down
+func (m *OrderManager) streamTrailingStops(ctx context.Context, shutdown <-chan struct{}, k key.ExchangePairAsset, pipe dispatch.Pipe) {
+	defer func() {
+		m.trailingMtx.Lock()
+		delete(m.trailingWatches, k)
+		m.trailingMtx.Unlock()
+		if err := pipe.Release(); err != nil {
+			log.Errorf(log.OrderMgr, "Order manager unable to release %s %s ticker stream: %v", k.Exchange, k.Asset, err)
+		}
+	}()
+	for {
+		select {
+		case <-shutdown:
+			return
+		case data, ok := <-pipe.Channel():
+			if !ok {
+				return
+			}
+			t, ok := data.(*ticker.Price)
+			if !ok {
+				log.Errorln(log.OrderMgr, common.GetTypeAssertError("*ticker.Price", data))
+				continue
+			}
+			m.processTrailingStops(ctx, t)
+			if !m.keepTrailingWatch(k) {
+				return
+			}
+		}
+	}
+}
+
+// keepTrailingWatch returns whether a pair still has active trailing stops,
+// removing its ticker stream otherwise so a later trailing stop streams it
+// again
+func (m *OrderManager) keepTrailingWatch(k key.ExchangePairAsset) bool {
+	m.trailingMtx.Lock()
+	defer m.trailingMtx.Unlock()
+	for _, ts := range m.trailingStops {
+		if ts.Status == TrailingStopActive && ts.key() == k {
+			return true
+		}
+	}
+	delete(m.trailingWatches, k)
+	return false
+}
+
+// processTrailingStops moves the stop price of each active trailing stop of a
+// ticker's pair as the last traded price improves, and submits the orders of
+// trailing stops whose stop price has been breached. Breached trailing stops
+// are persisted as triggering before their order is submitted and removed once
+// it is submitted
+func (m *OrderManager) processTrailingStops(ctx context.Context, t *ticker.Price) {
+	if t == nil || t.Last <= 0 {
+		return
+	}
+	k := key.ExchangePairAsset{Exchange: strings.ToLower(t.ExchangeName), Base: t.Pair.Base.Item, Quote: t.Pair.Quote.Item, Asset: t.AssetType}
+	var triggered []*TrailingStop
+	var changed bool
+	m.trailingMtx.Lock()
+	for _, ts := range m.trailingStops {
+		if ts.Status != TrailingStopActive || ts.key() != k {
+			continue
+		}
+		if ts.Improves(t.Last, ts.BestPrice) {
+			ts.BestPrice, ts.StopPrice = t.Last, ts.TrailingStopSubmit.StopPrice(t.Last)
+			changed = true
+			continue
+		}
+		if !ts.Breached(t.Last, ts.StopPrice) || m.checkMaintenance(ts.Exchange) != nil {
+			continue
+		}
+		ts.Status = TrailingStopTriggering
+		triggered = append(triggered, ts)
+		changed = true
+	}
+	if changed {
+		if err := m.saveTrailingStops(); err != nil {
+			// Orders are only submitted once their trailing stop is known to
+			// be triggering, so a restart cannot submit them again
+			log.Errorf(log.OrderMgr, "Order manager unable to persist trailing stops, delaying triggered orders: %v", err)
+			for _, ts := range triggered {
+				ts.Status = TrailingStopActive
+			}
+			triggered = nil
+		}
+	}
+	m.trailingMtx.Unlock()
+	for _, ts := range triggered {
+		m.submitTrailingStopOrder(ctx, ts)
+	}
+}
+
+// submitTrailingStopOrder submits the order of a triggering trailing stop. The
+// trailing stop is removed once its order is submitted. Orders which fail with
+// a transient error keep trailing and are retried while the stop price remains
+// breached, while other failures leave the trailing stop failed until it is
+// cancelled and push an order event
+func (m *OrderManager) submitTrailingStopOrder(ctx context.Context, ts *TrailingStop) {
+	resp, err := m.Submit(ctx, ts.Order(ts.StopPrice))
+	m.trailingMtx.Lock()
+	switch {
+	case err == nil:
+		delete(m.trailingStops, ts.ID)
+		log.Infof(log.OrderMgr, "Order manager triggered %s %s %s trailing stop ID=%v order ID=%v at stop price %v",
+			ts.Exchange, ts.AssetType, ts.Pair, ts.ID, resp.OrderID, ts.StopPrice)
+	case request.IsTransient(err):
+		ts.Status = TrailingStopActive
+		log.Warnf(log.OrderMgr, "Order manager unable to submit %s %s %s trailing stop ID=%v order, retrying: %v",
+			ts.Exchange, ts.AssetType, ts.Pair, ts.ID, err)
+	default:
+		ts.Status = TrailingStopFailed
+		ts.Error = err.Error()
+	}
+	if saveErr := m.saveTrailingStops(); saveErr != nil {
+		log.Errorf(log.OrderMgr, "Order manager unable to persist trailing stops: %v", saveErr)
+	}
+	m.trailingMtx.Unlock()
+	if err != nil && !request.IsTransient(err) {
+		msg := fmt.Sprintf("Exchange %s trailing stop ID=%v: failed to submit order: %v", ts.Exchange, ts.ID, err)
+		log.Errorln(log.OrderMgr, msg)
+		m.orderStore.commsManager.PushEvent(base.Event{Type: "order", Message: msg, Severity: base.SeverityError, Exchange: ts.Exchange})
+	}
+}
+
+// key returns the exchange, pair and asset whose ticker a trailing stop trails
+func (ts *TrailingStop) key() key.ExchangePairAsset {
+	return key.ExchangePairAsset{Exchange: strings.ToLower(ts.Exchange), Base: ts.Pair.Base.Item, Quote: ts.Pair.Quote.Item, Asset: ts.AssetType}
+}
+
+// setTrailingStopStateFile sets the file trailing stops are persisted to and
+// restores the trailing stops saved before a restart
+func (m *OrderManager) setTrailingStopStateFile(path string) error {
+	data, err := os.ReadFile(path)
+	var records []trailingStopRecord
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return fmt.Errorf("unable to read trailing stop state: %w", err)
+	default:
+		if err := json.Unmarshal(data, &records); err != nil {
+			return fmt.Errorf("unable to parse trailing stop state %s: %w", path, err)
+		}
+	}
+	stops := make(map[string]*TrailingStop, len(records))
+	for i := range records {
+		side, err := order.StringToOrderSide(records[i].Side)
+		if err != nil {
+			return fmt.Errorf("unable to parse trailing stop %s: %w", records[i].ID, err)
+		}
+		stops[records[i].ID] = &TrailingStop{
+			ID: records[i].ID,
+			TrailingStopSubmit: order.TrailingStopSubmit{
+				Exchange:      records[i].Exchange,
+				Pair:          currency.NewPairWithDelimiter(records[i].Base, records[i].Quote, records[i].Delimiter),
+				AssetType:     records[i].AssetType,
+				Side:          side,
+				Amount:        records[i].Amount,
+				TrailAmount:   records[i].TrailAmount,
+				TrailPercent:  records[i].TrailPercent,
+				LimitOffset:   records[i].LimitOffset,
+				ClientOrderID: records[i].ClientOrderID,
+				StrategyTag:   records[i].StrategyTag,
+			},
+			BestPrice: records[i].BestPrice,
+			StopPrice: records[i].StopPrice,
+			Created:   records[i].Created,
+			Status:    records[i].Status,
+			Error:     records[i].Error,
+		}
+		switch records[i].Status {
+		case "":
+			stops[records[i].ID].Status = TrailingStopActive
+		case TrailingStopTriggering:
+			// The order may have been submitted before the restart, so it is
+			// not submitted again
+			stops[records[i].ID].Status = TrailingStopFailed
+			stops[records[i].ID].Error = "interrupted while submitting order, check the exchange for the order"
+		}
+	}
+	m.trailingMtx.Lock()
+	maps.Copy(m.trailingStops, stops)
+	m.trailingStopFile = path
+	m.trailingMtx.Unlock()
+	if len(stops) > 0 {
+		log.Infof(log.OrderMgr, "Order manager restored %d trailing stops", len(stops))
+	}
+	return nil
+}
+
+// saveTrailingStops writes the trailing stops to the state file when set.
+// m.trailingMtx must be held
+func (m *OrderManager) saveTrailingStops() error {
+	if m.trailingStopFile == "" {
+		return nil
+	}
+	records := make([]trailingStopRecord, 0, len(m.trailingStops))
+	for _, ts := range m.trailingStops {
+		records = append(records, trailingStopRecord{
+			ID:            ts.ID,
+			Exchange:      ts.Exchange,
+			Base:          ts.Pair.Base.String(),
+			Quote:         ts.Pair.Quote.String(),
+			Delimiter:     ts.Pair.Delimiter,
+			AssetType:     ts.AssetType,
+			Side:          ts.Side.String(),
+			Amount:        ts.Amount,
+			TrailAmount:   ts.TrailAmount,
+			TrailPercent:  ts.TrailPercent,
+			LimitOffset:   ts.LimitOffset,
+			ClientOrderID: ts.ClientOrderID,
+			StrategyTag:   ts.StrategyTag,
+			BestPrice:     ts.BestPrice,
+			StopPrice:     ts.StopPrice,
+			Created:       ts.Created,
+			Status:        ts.Status,
+			Error:         ts.Error,
+		})
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Created.Before(records[j].Created)
+	})
+	data, err := json.MarshalIndent(records, "", " ")
+	if err != nil {
+		return err
+	}
+	return file.Write(m.trailingStopFile, data)
+}
+
+// CheckLeverage rejects leverage above the configured max leverage for the
+// asset type. Assets without a configured max are not limited
+func (m *OrderManager) CheckLeverage(a asset.Item, leverage float64) error {
+	if m == nil {
+		return fmt.Errorf("order manager %w", ErrNilSubsystem)
+	}
+	if limit, ok := m.cfg.MaxLeverage[a]; ok && leverage > limit {
+		return fmt.Errorf("%w %s leverage %v exceeds %v", errLeverageAboveMax, a, leverage, limit)
+	}
+	return nil
+}
+
+// checkPriceBand rejects a limit price which deviates from the asset's
+// reference price by more than its configured price band. Orders are allowed
+// when no reference price is available unless the band requires one
+func (m *OrderManager) checkPriceBand(ctx context.Context, exch exchange.IBotExchange, a asset.Item, p currency.Pair, price float64) error {
+	band, ok := m.cfg.PriceBands[a]
+	if !ok {
+		return nil
+	}
+	ref, source, err := referencePrice(ctx, exch, a, p, &band)
+	if err != nil {
+		if band.rejectWithoutReference {
+			return fmt.Errorf("order manager: %s %s %s price band check failed: %w", exch.GetName(), a, p, err)
+		}
+		log.Warnf(log.OrderMgr, "Order manager skipping %s %s %s price band check: %v", exch.GetName(), a, p, err)
+		return nil
+	}
+	if deviation := math.Abs(price-ref) / ref; deviation > band.maxDeviation {
+		return fmt.Errorf("%w %s %s %s price %v deviates %.4f from %s price %v, max deviation %v",
+			errPriceOutsideBand, exch.GetName(), a, p, price, deviation, source, ref, band.maxDeviation)
+	}
+	return nil
+}
+
+// referencePrice returns the first available reference price of a price band
+// from the exchange ticker along with which reference it is
+func referencePrice(ctx context.Context, exch exchange.IBotExchange, a asset.Item, p currency.Pair, band *priceBand) (price float64, source string, err error) {
+	t, err := exch.FetchTicker(ctx, p, a)
+	if err != nil {
+		return 0, "", fmt.Errorf("%w: %w", errNoReferencePrice, err)
+	}
+	if band.maxAge > 0 && time.Since(t.LastUpdated) > band.maxAge {
+		return 0, "", fmt.Errorf("%w, ticker last updated %s", errNoReferencePrice, t.LastUpdated)
+	}
+	for _, r := range band.references {
+		switch r {
+		case markPriceReference:
+			price = t.MarkPrice
+		case indexPriceReference:
+			price = t.IndexPrice
+		case lastPriceReference:
+			price = t.Last
+		}
+		if price > 0 {
+			return price, r, nil
+		}
+	}
+	return 0, "", fmt.Errorf("%w for references %s", errNoReferencePrice, strings.Join(band.references, ", "))
+}
+
+// preventSelfTrade emulates self trade prevention against resting orders
+// tracked by the order manager when the exchange does not support the mode
+// natively. The order manager's default mode is used when the submission does
+// not set one. It returns the submission to send, which may have a reduced
+// amount when decrementing
+func (m *OrderManager) preventSelfTrade(ctx context.Context, exch exchange.IBotExchange, s *order.Submit) (*order.Submit, error) {
+	stp := s.SelfTradePrevention
+	if stp == order.UnsetSelfTradePrevention {
+		stp = m.cfg.SelfTradePrevention
+	}
+	if stp == order.UnsetSelfTradePrevention {
+		return s, nil
+	}
+	emulated := *s
+	if b := exch.GetBase(); b != nil && b.Features.Supports.SelfTradePrevention[s.AssetType]&stp != 0 {
+		emulated.SelfTradePrevention = stp
+		return &emulated, nil
+	}
+	emulated.SelfTradePrevention = order.UnsetSelfTradePrevention
+	if s.PostOnly {
+		return &emulated, nil
+	}
+	resting := m.crossingOrders(s)
+	if len(resting) == 0 {
+		return &emulated, nil
+	}
+	if m.verbose {
+		log.Debugf(log.OrderMgr, "Order manager emulating %s self trade prevention for %s %s %s %s order matching %d resting orders",
+			stp, s.Exchange, s.AssetType, s.Pair, s.Side, len(resting))
+	}
+	switch stp {
+	case order.STPCancelTaker:
+		return nil, fmt.Errorf("%w %s %s %s %s order would match %d resting orders",
+			errSelfTradePrevented, s.Exchange, s.AssetType, s.Pair, s.Side, len(resting))
+	case order.STPCancelMaker, order.STPCancelBoth:
+		for i := range resting {
+			if err := m.cancelRestingOrder(ctx, &resting[i]); err != nil {
+				return nil, err
+			}
+		}
+		if stp == order.STPCancelBoth {
+			return nil, fmt.Errorf("%w %s %s %s %s order cancelled with %d resting orders",
+				errSelfTradePrevented, s.Exchange, s.AssetType, s.Pair, s.Side, len(resting))
+		}
+	case order.STPDecrement:
+		if s.Amount <= 0 {
+			return nil, fmt.Errorf("%w %s for quote amount orders", order.ErrUnsupportedSTPMode, stp)
+		}
+		amount := decimal.NewFromFloat(emulated.Amount)
+		for i := range resting {
+			executed := decimal.NewFromFloat(resting[i].ExecutedAmount)
+			remaining := decimal.NewFromFloat(resting[i].Amount).Sub(executed)
+			if !remaining.IsPositive() {
+				continue
+			}
+			if remaining.GreaterThan(amount) {
+				if err := m.decrementRestingOrder(ctx, &resting[i], executed.Add(remaining.Sub(amount))); err != nil {
+					return nil, err
+				}
+				amount = decimal.Zero
+				break
+			}
+			if err := m.cancelRestingOrder(ctx, &resting[i]); err != nil {
+				return nil, err
+			}
+			amount = amount.Sub(remaining)
+		}
+		if !amount.IsPositive() {
+			return nil, fmt.Errorf("%w %s %s %s %s order amount offset by resting orders",
+				errSelfTradePrevented, s.Exchange, s.AssetType, s.Pair, s.Side)
+		}
+		emulated.Amount = amount.InexactFloat64()
+	}
+	return &emulated, nil
+}
+
+// crossingOrders returns active orders tracked by the order manager which an
+// order submission would trade with, best priced first
+func (m *OrderManager) crossingOrders(s *order.Submit) []order.Detail {
+	active := m.orderStore.getActiveOrders(&order.Filter{
+		Exchange:  s.Exchange,
+		AssetType: s.AssetType,
+		Pair:      s.Pair,
+	})
+	crossing := make([]order.Detail, 0, len(active))
+	for i := range active {
+		if active[i].Price <= 0 {
+			continue
+		}
+		switch {
+		case s.Side.IsLong() && active[i].Side.IsShort() && (s.Type == order.Market || active[i].Price <= s.Price),
+			s.Side.IsShort() && active[i].Side.IsLong() && (s.Type == order.Market || active[i].Price >= s.Price):
+			crossing = append(crossing, active[i])
+		}
+	}
+	sort.Slice(crossing, func(i, j int) bool {
+		if s.Side.IsLong() {
+			return crossing[i].Price < crossing[j].Price
+		}
+		return crossing[i].Price > crossing[j].Price
+	})
+	return crossing
+}
+
+// cancelRestingOrder cancels a resting order to prevent a self trade
+func (m *OrderManager) cancelRestingOrder(ctx context.Context, d *order.Detail) error {
+	c, err := d.DeriveCancel()
+	if err != nil {
+		return err
+	}
+	if err := m.Cancel(ctx, c); err != nil {
+		return fmt.Errorf("%w: %w", errSelfTradePreventionFailed, err)
+	}
+	return nil
+}
+
+// decrementRestingOrder reduces the unfilled amount of a resting order to
+// prevent a self trade. Modified amounts are the order's total amount, so the
+// amount passed includes what has already executed
+func (m *OrderManager) decrementRestingOrder(ctx context.Context, d *order.Detail, amount decimal.Decimal) error {
+	mod, err := d.DeriveModify()
+	if err != nil {
+		return err
+	}
+	mod.Amount = amount.InexactFloat64()
+	if _, err := m.Modify(ctx, mod); err != nil {
+		return fmt.Errorf("%w: %w", errSelfTradePreventionFailed, err)
+	}
+	return nil
+}
+
+// conformToExecutionLimits returns a copy of an order with its prices and
+// amounts snapped onto the exchange's increments when they are off them only
+// by float rounding error, so that precise tick and step sizes do not reject
+// the order. The supplied order is not modified
+func conformToExecutionLimits(exch exchange.IBotExchange, newOrder *order.Submit) *order.Submit {
+	conformed := *newOrder
+	limits, err := exch.GetOrderExecutionLimits(newOrder.AssetType, newOrder.Pair)
+	if err != nil {
+		return &conformed
+	}
+	conformed.ConformToLimits(&limits)
+	return &conformed
+}
+
+// conformSubmitResponse returns a copy of a submit response carrying the
+// prices and amounts adjusted when its order was conformed to execution limits
+func conformSubmitResponse(resp *order.SubmitResponse, original, conformed *order.Submit) *order.SubmitResponse {
+	if resp == nil {
+		return nil
+	}
+	adjusted := *resp
+	if conformed.Price != original.Price {
+		adjusted.Price = conformed.Price
+	}
+	if conformed.TriggerPrice != original.TriggerPrice {
+		adjusted.TriggerPrice = conformed.TriggerPrice
+	}
+	if conformed.Amount != original.Amount {
+		adjusted.Amount = conformed.Amount
+	}
+	return &adjusted
+}
+
+// checkPairState ensures the lifecycle state of the order pair permits the
+// order
+func checkPairState(exch exchange.IBotExchange, newOrder *order.Submit) error {
+	state, err := exch.GetPairState(newOrder.Pair, newOrder.AssetType)
+	if err != nil {
+		return err
+	}
+	switch {
+	case state == currency.PairStateSuspended, state == currency.PairStateDelisted:
+		err = errPairNotTradeable
+	case state == currency.PairStatePostOnly && !newOrder.PostOnly:
+		err = errPairPostOnly
+	case state == currency.PairStateReduceOnly && !newOrder.ReduceOnly:
+		err = errPairReduceOnly
+	default:
+		return nil
+	}
+	return fmt.Errorf("order manager: exchange %s %s %s %s: %w",
+		newOrder.Exchange,
+		newOrder.Pair,
+		newOrder.AssetType,
+		state,
+		err)
+}
+
+// setMaintenanceSchedule sets the maintenance schedule used to pause order
+// routing to exchanges under maintenance
+func (m *OrderManager) setMaintenanceSchedule(schedule iMaintenanceSchedule) {
+	if m == nil {
+		return
+	}
+	m.maintenanceMtx.Lock()
+	m.maintenance = schedule
+	m.maintenanceMtx.Unlock()
+}
+
+// checkMaintenance returns an error when an exchange is under scheduled
+// maintenance. Cancellations are not checked so orders can still be pulled
+func (m *OrderManager) checkMaintenance(exchName string) error {
+	m.maintenanceMtx.RLock()
+	schedule := m.maintenance
+	m.maintenanceMtx.RUnlock()
+	if schedule == nil || !schedule.IsUnderMaintenance(exchName) {
+		return nil
+	}
+	return fmt.Errorf("order manager: exchange %s %w", exchName, errExchangeUnderMaintenance)
+}
+
+// pairStateChanged notifies of a currency pair lifecycle state change
+func (m *OrderManager) pairStateChanged(exchangeName string, change currency.PairStateChange) {
+	if m == nil || atomic.LoadInt32(&m.started) == 0 {
+		return
+	}
+	msg := fmt.Sprintf("Exchange %s %s %s pair state changed from %s to %s",
+		exchangeName,
+		change.Asset,
+		change.Pair,
+		change.Previous,
+		change.Current)
+	if change.Current == currency.PairStateSuspended || change.Current == currency.PairStateDelisted {
+		log.Warnln(log.OrderMgr, msg)
+	} else if m.verbose {
+		log.Debugln(log.OrderMgr, msg)
+	}
+	m.orderStore.commsManager.PushEvent(base.Event{Type: "pair_state", Message: msg, Exchange: exchangeName})
+}
+
+// SubmitFakeOrder runs through the same process as order submission
+// but does not touch live endpoints
+func (m *OrderManager) SubmitFakeOrder(newOrder *order.Submit, resultingOrder *order.SubmitResponse, checkExchangeLimits bool) (*OrderSubmitResponse, error) {
+	if m == nil {
+		return nil, fmt.Errorf("order manager %w", ErrNilSubsystem)
+	}
+	if atomic.LoadInt32(&m.started) == 0 {
+		return nil, fmt.Errorf("order manager %w", ErrSubSystemNotStarted)
+	}
+
+	err := m.validate(newOrder)
+	if err != nil {
+		return nil, err
+	}
+	exch, err := m.orderStore.exchangeManager.GetExchangeByName(newOrder.Exchange)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkPairState(exch, newOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	if checkExchangeLimits {
+		conformed := conformToExecutionLimits(exch, newOrder)
+		// Checks for exchange min max limits for order amounts before order
+		// execution can occur
+		err = exch.CheckOrderExecutionLimits(conformed.AssetType,
+			conformed.Pair,
+			conformed.Price,
+			conformed.Amount,
+			conformed.Type)
+		if err != nil {
+			return nil, fmt.Errorf("order manager: exchange %s unable to place order: %w",
+				conformed.Exchange,
+				err)
+		}
+		resultingOrder = conformSubmitResponse(resultingOrder, newOrder, conformed)
+	}
+	return m.processSubmittedOrder(resultingOrder)
+}
+
+// GetOrdersSnapshot returns a snapshot of all orders in the orderstore. It optionally filters any orders that do not match the status
+// but a status of "" or ANY will include all
+// the time adds contexts for when the snapshot is relevant for
+func (m *OrderManager) GetOrdersSnapshot(s order.Status) []order.Detail {
+	if m == nil || atomic.LoadInt32(&m.started) == 0 {
+		return nil
+	}
+	var os []order.Detail
+	for _, v := range m.orderStore.Orders {
+		for i := range v {
+			if s != v[i].Status && s != order.AnyStatus && s != order.UnknownStatus {
+				continue
+			}
+			os = append(os, *v[i])
+		}
+	}
+
+	return os
+}
+
+// GetOrdersFiltered returns a snapshot of all orders in the order store.
+// Filtering is applied based on the order.Filter unless entries are empty
+func (m *OrderManager) GetOrdersFiltered(f *order.Filter) ([]order.Detail, error) {
+	if m == nil {
+		return nil, fmt.Errorf("order manager %w", ErrNilSubsystem)
+	}
+	if atomic.LoadInt32(&m.started) == 0 {
+		return nil, fmt.Errorf("order manager %w", ErrSubSystemNotStarted)
+	}
+	return m.orderStore.getFilteredOrders(f)
+}
+
+// GetOrdersActive returns a snapshot of all orders in the order store
+// that have a status that indicates it's currently tradable
+func (m *OrderManager) GetOrdersActive(f *order.Filter) ([]order.Detail, error) {
+	if m == nil {
+		return nil, fmt.Errorf("order manager %w", ErrNilSubsystem)
+	}
+	if atomic.LoadInt32(&m.started) == 0 {
+		return nil, fmt.Errorf("order manager %w", ErrSubSystemNotStarted)
+	}
+	return m.orderStore.getActiveOrders(f), nil
+}
+
+// processSubmittedOrder adds a new order to the manager
+func (m *OrderManager) processSubmittedOrder(newOrderResp *order.SubmitResponse) (*OrderSubmitResponse, error) {
+	if newOrderResp == nil {
+		return nil, order.ErrOrderDetailIsNil
+	}
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		log.Warnf(log.OrderMgr, "Unable to generate UUID. Err: %s", err)
+	}
+
+	detail, err := newOrderResp.DeriveDetail(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.orderStore.add(detail.CopyToPointer()); errors.Is(err, ErrOrdersAlreadyExists) {
+		// Streamed by ws before we got here. Details from ws supersede since they are more recent.
+		detail = m.orderStore.getByDetail(detail)
+	} else if err != nil {
+		// Non-fatal error: Unable to store order, but error does not need to be returned to caller
+		log.Errorf(log.OrderMgr, "Unable to add %v order %v to orderStore: %s", detail.Exchange, detail.OrderID, err)
+	}
+
+	msg := fmt.Sprintf("Exchange %s submitted order ID=%v [Ours: %v] pair=%v price=%v amount=%v quoteAmount=%v side=%v type=%v for time %v.",
+		detail.Exchange,
 		detail.OrderID,
 		detail.InternalOrderID.String(),
 		detail.Pair,
@@ -624,7 +2233,7 @@ func (m *OrderManager) processSubmittedOrder(newOrderResp *order.SubmitResponse)
 
 	log.Debugln(log.OrderMgr, msg)
 	if m.orderStore.commsManager != nil {
-		m.orderStore.commsManager.PushEvent(base.Event{Type: "order", Message: msg})
+		m.orderStore.commsManager.PushEvent(base.Event{Type: "order", Message: msg, Exchange: detail.Exchange})
 	}
 
 	return &OrderSubmitResponse{Detail: detail, InternalOrderID: detail.InternalOrderID.String()}, nil
@@ -637,11 +2246,18 @@ func (m *OrderManager) processOrders() {
 		return
 	}
 	defer atomic.StoreInt32(&m.processingOrders, 0)
+	if m.paper != nil {
+		// Paper orders are never sent to exchanges so there are no exchange
+		// orders to sync
+		m.processIcebergs(context.TODO())
+		return
+	}
 	exchanges, err := m.orderStore.exchangeManager.GetExchanges()
 	if err != nil {
 		log.Errorf(log.OrderMgr, "order manager cannot get exchanges: %v", err)
 		return
 	}
+	defer m.processIcebergs(context.TODO())
 	var wg sync.WaitGroup
 	for x := range exchanges {
 		if !exchanges[x].IsRESTAuthenticationSupported() {
@@ -888,7 +2504,11 @@ func (m *OrderManager) UpdateExistingOrder(od *order.Detail) error {
 	if atomic.LoadInt32(&m.started) == 0 {
 		return fmt.Errorf("order manager %w", ErrSubSystemNotStarted)
 	}
-	return m.orderStore.updateExisting(od)
+	if err := m.orderStore.updateExisting(od); err != nil {
+		return err
+	}
+	m.checkOCOLeg(od)
+	return nil
 }
 
 // UpsertOrder updates an existing order or adds a new one to the orderstore
@@ -909,8 +2529,9 @@ func (m *OrderManager) UpsertOrder(od *order.Detail) (resp *OrderUpsertResponse,
 			return
 		}
 		m.orderStore.commsManager.PushEvent(base.Event{
-			Type:    "order",
-			Message: *message,
+			Type:     "order",
+			Message:  *message,
+			Exchange: od.Exchange,
 		})
 	}(&msg)
 
@@ -922,6 +2543,11 @@ func (m *OrderManager) UpsertOrder(od *order.Detail) (resp *OrderUpsertResponse,
 		return nil, err
 	}
 
+	if !upsertResponse.IsNewOrder && upsertResponse.OrderDetails.IsInactive() && m.isIcebergSlice(&upsertResponse.OrderDetails) {
+		go m.processIcebergs(context.TODO())
+	}
+	m.checkOCOLeg(&upsertResponse.OrderDetails)
+
 	status := "updated"
 	if upsertResponse.IsNewOrder {
 		status = "added"
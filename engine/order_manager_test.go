@@ -2,7 +2,11 @@ package engine
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
@@ -11,15 +15,19 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/common/convert"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/options"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/protocol"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 )
 
@@ -559,6 +567,108 @@ func TestCancelAllOrders(t *testing.T) {
 	}
 }
 
+// cancelScopeExchange overrides cancel functions to report scoped cancel
+// results without API calls
+type cancelScopeExchange struct {
+	omfExchange
+	cancelAllStatus map[string]string
+	cancelAllErr    error
+	cancelAllCalls  int
+}
+
+func (f *cancelScopeExchange) CancelAllOrders(_ context.Context, _ *order.Cancel) (order.CancelAllResponse, error) {
+	f.cancelAllCalls++
+	return order.CancelAllResponse{Status: f.cancelAllStatus}, f.cancelAllErr
+}
+
+func (f *cancelScopeExchange) CancelOrder(_ context.Context, c *order.Cancel) error {
+	if c.OrderID == "reject" {
+		return errExpectedTestError
+	}
+	return nil
+}
+
+func TestCancelAllOrdersFiltered(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	exch, err := em.NewExchangeByName(testExchange)
+	require.NoError(t, err)
+	exch.SetDefaults()
+	fake := &cancelScopeExchange{omfExchange: omfExchange{IBotExchange: exch}}
+	require.NoError(t, em.Add(fake))
+	m, err := SetupOrderManager(em, &CommunicationManager{}, &sync.WaitGroup{}, &config.OrderManager{})
+	require.NoError(t, err)
+	m.started = 1
+
+	ethusdPair := currency.NewPair(currency.ETH, currency.USD)
+	for _, o := range []*order.Detail{
+		{Exchange: testExchange, OrderID: "1", AssetType: asset.Spot, Pair: btcusdPair, Side: order.Buy, Amount: 1, Status: order.New, StrategyTag: "grid"},
+		{Exchange: testExchange, OrderID: "2", AssetType: asset.Spot, Pair: btcusdPair, Side: order.Sell, Amount: 1, Status: order.New},
+		{Exchange: testExchange, OrderID: "reject", AssetType: asset.Spot, Pair: ethusdPair, Side: order.Buy, Amount: 1, Status: order.New, StrategyTag: "grid"},
+		{Exchange: testExchange, OrderID: "3", AssetType: asset.Spot, Pair: ethusdPair, Side: order.Buy, Amount: 1, Status: order.Filled, StrategyTag: "grid"},
+	} {
+		require.NoError(t, m.orderStore.add(o))
+	}
+
+	_, err = m.CancelAllOrdersFiltered(context.Background(), nil)
+	assert.ErrorIs(t, err, errNilCancelScope)
+
+	results, err := m.CancelAllOrdersFiltered(context.Background(), &order.Filter{Exchange: testExchange, StrategyTag: "grid"})
+	require.NoError(t, err)
+	require.Len(t, results, 2, "inactive orders should not be cancelled")
+	assert.Zero(t, fake.cancelAllCalls, "strategy scoped cancels must not cancel all orders for a pair")
+	for _, r := range results {
+		assert.Equal(t, cancelBatchEndpoint, r.Endpoint)
+		assert.Equal(t, "grid", r.StrategyTag)
+		switch r.OrderID {
+		case "1":
+			assert.True(t, r.Cancelled)
+			assert.Equal(t, order.Cancelled.String(), r.Status)
+		case "reject":
+			assert.False(t, r.Cancelled)
+			assert.Contains(t, r.Status, errExpectedTestError.Error())
+		default:
+			t.Errorf("unexpected order %s cancelled", r.OrderID)
+		}
+	}
+	od, err := m.orderStore.getByExchangeAndID(testExchange, "1")
+	require.NoError(t, err)
+	assert.Equal(t, order.Cancelled, od.Status)
+	od, err = m.orderStore.getByExchangeAndID(testExchange, "reject")
+	require.NoError(t, err)
+	assert.Equal(t, order.New, od.Status, "failed cancels should not update the order status")
+
+	require.NoError(t, m.orderStore.add(&order.Detail{Exchange: testExchange, OrderID: "4", AssetType: asset.Spot, Pair: btcusdPair, Amount: 1, Status: order.New}))
+	fake.cancelAllStatus = map[string]string{"4": "unknown order", "5": "CANCELED"}
+	results, err = m.CancelAllOrdersFiltered(context.Background(), &order.Filter{Exchange: testExchange, AssetType: asset.Spot, Pair: btcusdPair})
+	require.NoError(t, err)
+	assert.Equal(t, 1, fake.cancelAllCalls)
+	require.Len(t, results, 3, "untracked orders cancelled by the exchange should be reported")
+	for _, r := range results {
+		assert.Equal(t, cancelAllEndpoint, r.Endpoint)
+		switch r.OrderID {
+		case "2", "5":
+			assert.True(t, r.Cancelled, r.OrderID)
+		case "4":
+			assert.False(t, r.Cancelled)
+			assert.Equal(t, "unknown order", r.Status)
+		default:
+			t.Errorf("unexpected order %s cancelled", r.OrderID)
+		}
+	}
+
+	fake.cancelAllErr = common.ErrFunctionNotSupported
+	results, err = m.CancelAllOrdersFiltered(context.Background(), &order.Filter{Exchange: testExchange, Pair: btcusdPair})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, cancelBatchEndpoint, results[0].Endpoint, "unsupported cancel all should fall back to batch cancels")
+	assert.True(t, results[0].Cancelled)
+
+	m.started = 0
+	_, err = m.CancelAllOrdersFiltered(context.Background(), &order.Filter{})
+	assert.ErrorIs(t, err, ErrSubSystemNotStarted)
+}
+
 func TestSubmit(t *testing.T) {
 	m := OrdersSetup(t)
 	_, err := m.Submit(context.Background(), nil)
@@ -759,6 +869,62 @@ func TestOrderManager_Modify(t *testing.T) {
 	f(five, false, 8, 128)
 }
 
+// modifyRecorderExchange records the modification sent to the exchange
+type modifyRecorderExchange struct {
+	omfExchange
+	modified *order.Modify
+}
+
+func (f *modifyRecorderExchange) ModifyOrder(ctx context.Context, action *order.Modify) (*order.ModifyResponse, error) {
+	f.modified = action
+	return f.omfExchange.ModifyOrder(ctx, action)
+}
+
+func TestOrderManagerModifyPopulatesOrderFields(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	exch, err := em.NewExchangeByName(testExchange)
+	require.NoError(t, err, "NewExchangeByName must not error")
+	exch.SetDefaults()
+	fake := &modifyRecorderExchange{omfExchange: omfExchange{IBotExchange: exch}}
+	require.NoError(t, em.Add(fake), "Add must not error")
+	m, err := SetupOrderManager(em, &CommunicationManager{}, &sync.WaitGroup{}, &config.OrderManager{})
+	require.NoError(t, err, "SetupOrderManager must not error")
+	m.started = 1
+	require.NoError(t, m.orderStore.add(&order.Detail{
+		Exchange:      testExchange,
+		AssetType:     asset.Spot,
+		Pair:          btcusdPair,
+		OrderID:       "TestOrderManagerModifyPopulatesOrderFields",
+		ClientOrderID: "client",
+		Side:          order.Buy,
+		TimeInForce:   order.IOC,
+		Price:         8,
+		Amount:        128,
+	}), "add must not error")
+
+	_, err = m.Modify(context.Background(), &order.Modify{Exchange: testExchange, AssetType: asset.Spot, Pair: btcusdPair, OrderID: "TestOrderManagerModifyPopulatesOrderFields", Price: 16})
+	require.NoError(t, err, "Modify must not error")
+	require.NotNil(t, fake.modified, "the modification must be sent to the exchange")
+	assert.Equal(t, "client", fake.modified.ClientOrderID, "the stored client order ID should be sent to exchanges which replace by it")
+	assert.Equal(t, order.Buy, fake.modified.Side)
+	assert.Equal(t, order.IOC, fake.modified.TimeInForce)
+	assert.Equal(t, 128.0, fake.modified.Amount, "unset amounts should keep the order amount")
+
+	require.NoError(t, m.orderStore.add(&order.Detail{
+		Exchange:      testExchange,
+		AssetType:     asset.Spot,
+		Pair:          btcusdPair,
+		OrderID:       "TestOrderManagerModifyPopulatesOrderFields2",
+		ClientOrderID: "stored",
+		Price:         8,
+		Amount:        128,
+	}), "add must not error")
+	_, err = m.Modify(context.Background(), &order.Modify{Exchange: testExchange, AssetType: asset.Spot, Pair: btcusdPair, OrderID: "TestOrderManagerModifyPopulatesOrderFields2", ClientOrderID: "supplied", Price: 16})
+	require.NoError(t, err, "Modify must not error")
+	assert.Equal(t, "supplied", fake.modified.ClientOrderID, "a supplied client order ID should not be replaced")
+}
+
 func TestProcessOrders(t *testing.T) {
 	var wg sync.WaitGroup
 	em := NewExchangeManager()
@@ -1362,6 +1528,31 @@ func TestSubmitFakeOrder(t *testing.T) {
 	}
 }
 
+func TestConformToExecutionLimits(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	exch, err := em.NewExchangeByName(testExchange)
+	require.NoError(t, err, "NewExchangeByName must not error")
+	exch.SetDefaults()
+	p := currency.NewPair(currency.BTC, currency.USD)
+	tenth, fifth := 0.1, 0.2 // Variables so that arithmetic is not folded into exact constants
+	ord := &order.Submit{Exchange: testExchange, AssetType: asset.Spot, Pair: p, Type: order.Limit, Price: tenth + fifth, Amount: 1}
+	assert.Equal(t, tenth+fifth, conformToExecutionLimits(exch, ord).Price, "orders should be unchanged without limits")
+
+	require.NoError(t, exch.GetBase().LoadLimits([]order.MinMaxLevel{{Pair: p, Asset: asset.Spot, PriceStepIncrementSize: 0.1, AmountStepIncrementSize: 0.01}}), "LoadLimits must not error")
+	conformed := conformToExecutionLimits(exch, ord)
+	assert.Equal(t, 0.3, conformed.Price, "prices off a tick by float rounding error should be snapped")
+	assert.Equal(t, tenth+fifth, ord.Price, "the supplied order should not be modified")
+	assert.NoError(t, exch.CheckOrderExecutionLimits(conformed.AssetType, conformed.Pair, conformed.Price, conformed.Amount, conformed.Type), "conformed orders should pass the limits")
+
+	resp := &order.SubmitResponse{Price: ord.Price, Amount: 2}
+	adjusted := conformSubmitResponse(resp, ord, conformed)
+	assert.Equal(t, 0.3, adjusted.Price, "adjusted prices should be returned in the response")
+	assert.Equal(t, 2.0, adjusted.Amount, "unadjusted amounts should be kept")
+	assert.Equal(t, tenth+fifth, resp.Price, "the supplied response should not be modified")
+	assert.Nil(t, conformSubmitResponse(nil, ord, conformed))
+}
+
 func TestGetOrdersSnapshot(t *testing.T) {
 	t.Parallel()
 	o := &OrderManager{}
@@ -1467,6 +1658,33 @@ func TestOrderManagerAdd(t *testing.T) {
 	}
 }
 
+func TestOrdersUpsertMultiLeg(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	exch, err := em.NewExchangeByName(testExchange)
+	require.NoError(t, err)
+	exch.SetDefaults()
+	require.NoError(t, em.Add(omfExchange{IBotExchange: exch}))
+	m, err := SetupOrderManager(em, &CommunicationManager{}, &sync.WaitGroup{}, &config.OrderManager{})
+	require.NoError(t, err)
+	m.orderStore.futuresPositionController = futures.SetupPositionController()
+	resp, err := m.orderStore.upsert(&order.Detail{
+		Exchange:  testExchange,
+		AssetType: asset.OptionCombo,
+		Pair:      currency.NewPair(currency.BTC, currency.NewCode("28JUN24-60000-C_28JUN24-70000-C")),
+		OrderID:   "TestOrdersUpsertMultiLeg",
+		Side:      order.Buy,
+		Status:    order.Filled,
+		Amount:    1,
+		Price:     100,
+		Date:      time.Now(),
+	})
+	require.NoError(t, err, "upsert must not error for option combos")
+	assert.True(t, resp.IsNewOrder, "upsert should store a new order")
+	_, err = m.orderStore.futuresPositionController.GetAllOpenPositions()
+	assert.ErrorIs(t, err, futures.ErrNoPositionsFound, "multi-leg orders should not be tracked as futures positions")
+}
+
 func TestGetAllOpenFuturesPositions(t *testing.T) {
 	t.Parallel()
 	wg := &sync.WaitGroup{}
@@ -1727,3 +1945,971 @@ func TestGetByDetail(t *testing.T) {
 		assert.Equal(t, od.ClientOrderID, byID.ClientOrderID, "Retrieve by id pointer should contain the correct ClientOrderID")
 	}
 }
+
+// pairStateExchange is a fake exchange which returns a fixed pair state
+type pairStateExchange struct {
+	exchange.IBotExchange
+	state currency.PairState
+}
+
+func (f pairStateExchange) GetPairState(currency.Pair, asset.Item) (currency.PairState, error) {
+	return f.state, nil
+}
+
+func TestCheckPairState(t *testing.T) {
+	t.Parallel()
+	o := &order.Submit{Exchange: testExchange, Pair: btcusdPair, AssetType: asset.Spot}
+	for _, tc := range []struct {
+		state      currency.PairState
+		postOnly   bool
+		reduceOnly bool
+		err        error
+	}{
+		{state: currency.PairStateTradeable},
+		{state: currency.PairStatePostOnly, err: errPairPostOnly},
+		{state: currency.PairStatePostOnly, postOnly: true},
+		{state: currency.PairStateReduceOnly, err: errPairReduceOnly},
+		{state: currency.PairStateReduceOnly, reduceOnly: true},
+		{state: currency.PairStateSuspended, postOnly: true, reduceOnly: true, err: errPairNotTradeable},
+		{state: currency.PairStateDelisted, err: errPairNotTradeable},
+	} {
+		o.PostOnly, o.ReduceOnly = tc.postOnly, tc.reduceOnly
+		err := checkPairState(pairStateExchange{state: tc.state}, o)
+		if tc.err == nil {
+			assert.NoErrorf(t, err, "%s post only %v reduce only %v should not error", tc.state, tc.postOnly, tc.reduceOnly)
+		} else {
+			assert.ErrorIsf(t, err, tc.err, "%s post only %v reduce only %v should error", tc.state, tc.postOnly, tc.reduceOnly)
+		}
+	}
+}
+
+func TestPairStateChanged(t *testing.T) {
+	t.Parallel()
+	change := currency.PairStateChange{
+		Pair:     btcusdPair,
+		Asset:    asset.Spot,
+		Previous: currency.PairStateTradeable,
+		Current:  currency.PairStateSuspended,
+	}
+	var m *OrderManager
+	m.pairStateChanged(testExchange, change)
+
+	comms := &fakeComms{}
+	m = &OrderManager{orderStore: store{commsManager: comms}}
+	m.pairStateChanged(testExchange, change)
+	assert.Empty(t, comms.events, "no event should be pushed when not running")
+
+	m.started = 1
+	m.pairStateChanged(testExchange, change)
+	require.Len(t, comms.events, 1)
+	assert.Equal(t, "pair_state", comms.events[0].Type)
+	assert.Equal(t, testExchange, comms.events[0].Exchange)
+	assert.Contains(t, comms.events[0].Message, "SUSPENDED")
+}
+
+func (f *cancelScopeExchange) ModifyOrder(_ context.Context, action *order.Modify) (*order.ModifyResponse, error) {
+	if action.OrderID == "reject" {
+		return nil, errExpectedTestError
+	}
+	return action.DeriveModifyResponse()
+}
+
+func TestPreventSelfTrade(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	exch, err := em.NewExchangeByName(testExchange)
+	require.NoError(t, err)
+	exch.SetDefaults()
+	fake := &cancelScopeExchange{omfExchange: omfExchange{IBotExchange: exch}}
+	require.NoError(t, em.Add(fake))
+	_, err = SetupOrderManager(em, &CommunicationManager{}, &sync.WaitGroup{}, &config.OrderManager{SelfTradePrevention: "allow"})
+	assert.ErrorContains(t, err, "unrecognised self trade prevention mode")
+	m, err := SetupOrderManager(em, &CommunicationManager{}, &sync.WaitGroup{}, &config.OrderManager{})
+	require.NoError(t, err)
+	m.started = 1
+
+	addResting := func() {
+		t.Helper()
+		m.orderStore.m.Lock()
+		m.orderStore.Orders = make(map[string][]*order.Detail)
+		m.orderStore.m.Unlock()
+		for _, o := range []*order.Detail{
+			{Exchange: testExchange, OrderID: "ask1", AssetType: asset.Spot, Pair: btcusdPair, Side: order.Sell, Price: 101, Amount: 1, Status: order.New},
+			{Exchange: testExchange, OrderID: "ask2", AssetType: asset.Spot, Pair: btcusdPair, Side: order.Sell, Price: 100, Amount: 2, ExecutedAmount: 1, Status: order.PartiallyFilled},
+			{Exchange: testExchange, OrderID: "ask3", AssetType: asset.Spot, Pair: btcusdPair, Side: order.Sell, Price: 110, Amount: 1, Status: order.New},
+			{Exchange: testExchange, OrderID: "bid1", AssetType: asset.Spot, Pair: btcusdPair, Side: order.Buy, Price: 90, Amount: 1, Status: order.New},
+		} {
+			require.NoError(t, m.orderStore.add(o))
+		}
+	}
+	status := func(id string) order.Status {
+		t.Helper()
+		od, err := m.orderStore.getByExchangeAndID(testExchange, id)
+		require.NoError(t, err)
+		return od.Status
+	}
+	submit := func(stp order.SelfTradePrevention, amount float64) *order.Submit {
+		return &order.Submit{Exchange: testExchange, AssetType: asset.Spot, Pair: btcusdPair, Side: order.Buy, Type: order.Limit, Price: 105, Amount: amount, SelfTradePrevention: stp}
+	}
+	ctx := context.Background()
+
+	addResting()
+	s := submit(order.UnsetSelfTradePrevention, 1)
+	result, err := m.preventSelfTrade(ctx, fake, s)
+	require.NoError(t, err)
+	assert.Same(t, s, result, "orders without self trade prevention should be unchanged")
+
+	_, err = m.preventSelfTrade(ctx, fake, submit(order.STPCancelTaker, 1))
+	assert.ErrorIs(t, err, errSelfTradePrevented)
+	assert.Equal(t, order.New, status("ask1"), "cancel taker must not cancel resting orders")
+
+	s = submit(order.STPCancelTaker, 1)
+	s.Price = 95
+	result, err = m.preventSelfTrade(ctx, fake, s)
+	require.NoError(t, err, "orders which do not cross should be placed")
+	assert.Equal(t, order.UnsetSelfTradePrevention, result.SelfTradePrevention, "emulated modes should not be sent to the exchange")
+
+	result, err = m.preventSelfTrade(ctx, fake, submit(order.STPCancelMaker, 1))
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, result.Amount)
+	assert.Equal(t, order.Cancelled, status("ask1"))
+	assert.Equal(t, order.Cancelled, status("ask2"))
+	assert.Equal(t, order.New, status("ask3"), "orders priced beyond the new order should not be cancelled")
+	assert.Equal(t, order.New, status("bid1"), "same side orders should not be cancelled")
+
+	addResting()
+	_, err = m.preventSelfTrade(ctx, fake, submit(order.STPCancelBoth, 1))
+	assert.ErrorIs(t, err, errSelfTradePrevented)
+	assert.Equal(t, order.Cancelled, status("ask1"))
+
+	addResting()
+	m.cfg.SelfTradePrevention = order.STPDecrement
+	result, err = m.preventSelfTrade(ctx, fake, submit(order.UnsetSelfTradePrevention, 3))
+	require.NoError(t, err, "the default mode should apply to orders without one")
+	assert.Equal(t, 1.0, result.Amount, "amount should be decremented by the remaining amount of resting orders")
+	assert.Equal(t, order.Cancelled, status("ask2"))
+	assert.Equal(t, order.Cancelled, status("ask1"))
+
+	addResting()
+	_, err = m.preventSelfTrade(ctx, fake, submit(order.STPDecrement, 1.5))
+	assert.ErrorIs(t, err, errSelfTradePrevented)
+	assert.Equal(t, order.Cancelled, status("ask2"), "best priced resting order should be decremented first")
+	od, err := m.orderStore.getByExchangeAndID(testExchange, "ask1")
+	require.NoError(t, err)
+	assert.Equal(t, 0.5, od.Amount, "larger resting orders should be reduced")
+
+	addResting()
+	_, err = m.preventSelfTrade(ctx, fake, submit(order.STPDecrement, 0.25))
+	assert.ErrorIs(t, err, errSelfTradePrevented)
+	od, err = m.orderStore.getByExchangeAndID(testExchange, "ask2")
+	require.NoError(t, err)
+	assert.Equal(t, 1.75, od.Amount, "only the unfilled amount of partially filled orders should be decremented")
+	assert.Equal(t, order.PartiallyFilled, od.Status)
+
+	addResting()
+	require.NoError(t, m.orderStore.add(&order.Detail{Exchange: testExchange, OrderID: "reject", AssetType: asset.Spot, Pair: btcusdPair, Side: order.Sell, Price: 99, Amount: 5, Status: order.New}))
+	_, err = m.preventSelfTrade(ctx, fake, submit(order.STPDecrement, 1))
+	assert.ErrorIs(t, err, errSelfTradePreventionFailed, "orders must not be submitted when resting orders cannot be decremented")
+	addResting()
+	require.NoError(t, m.orderStore.add(&order.Detail{Exchange: testExchange, OrderID: "reject", AssetType: asset.Spot, Pair: btcusdPair, Side: order.Sell, Price: 99, Amount: 1, Status: order.New}))
+	_, err = m.preventSelfTrade(ctx, fake, submit(order.STPDecrement, 2))
+	assert.ErrorIs(t, err, errSelfTradePreventionFailed, "orders must not be submitted when resting orders cannot be cancelled")
+
+	s = submit(order.STPDecrement, 0)
+	s.QuoteAmount = 100
+	addResting()
+	_, err = m.preventSelfTrade(ctx, fake, s)
+	assert.ErrorIs(t, err, order.ErrUnsupportedSTPMode)
+
+	fake.GetBase().Features.Supports.SelfTradePrevention = map[asset.Item]order.SelfTradePrevention{asset.Spot: order.STPDecrement}
+	result, err = m.preventSelfTrade(ctx, fake, submit(order.UnsetSelfTradePrevention, 3))
+	require.NoError(t, err)
+	assert.Equal(t, order.STPDecrement, result.SelfTradePrevention, "natively supported modes should be sent to the exchange")
+	assert.Equal(t, 3.0, result.Amount)
+	assert.Equal(t, order.New, status("ask1"))
+}
+
+// icebergExchange records submitted orders to test iceberg slicing
+type icebergExchange struct {
+	omfExchange
+	submitted []order.Submit
+	submitErr error
+	limits    *order.MinMaxLevel
+	// onSubmit is called whenever an order is submitted
+	onSubmit func()
+}
+
+func (f *icebergExchange) GetOrderExecutionLimits(a asset.Item, p currency.Pair) (order.MinMaxLevel, error) {
+	if f.limits == nil {
+		return f.omfExchange.GetOrderExecutionLimits(a, p)
+	}
+	return *f.limits, nil
+}
+
+func (f *icebergExchange) CheckOrderExecutionLimits(a asset.Item, p currency.Pair, price, amount float64, t order.Type) error {
+	if f.limits == nil {
+		return f.omfExchange.CheckOrderExecutionLimits(a, p, price, amount, t)
+	}
+	return f.limits.Conforms(price, amount, t)
+}
+
+func (f *icebergExchange) SubmitOrder(_ context.Context, s *order.Submit) (*order.SubmitResponse, error) {
+	if f.onSubmit != nil {
+		f.onSubmit()
+	}
+	if f.submitErr != nil {
+		return nil, f.submitErr
+	}
+	f.submitted = append(f.submitted, *s)
+	return s.DeriveSubmitResponse(strconv.Itoa(len(f.submitted)))
+}
+
+func TestSubmitIceberg(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	exch, err := em.NewExchangeByName(testExchange)
+	require.NoError(t, err)
+	exch.SetDefaults()
+	fake := &icebergExchange{omfExchange: omfExchange{IBotExchange: exch}}
+	require.NoError(t, em.Add(fake))
+	m, err := SetupOrderManager(em, &CommunicationManager{}, &sync.WaitGroup{}, &config.OrderManager{})
+	require.NoError(t, err)
+	m.started = 1
+	ctx := context.Background()
+
+	fillSlice := func(id string, status order.Status) {
+		t.Helper()
+		od, err := m.orderStore.getByExchangeAndID(testExchange, id)
+		require.NoError(t, err)
+		od.Status = status
+		if status == order.Filled {
+			od.ExecutedAmount = od.Amount
+		}
+		require.NoError(t, m.orderStore.updateExisting(od))
+	}
+
+	s := &order.Submit{Exchange: testExchange, AssetType: asset.Spot, Pair: btcusdPair, Side: order.Buy, Type: order.Limit, Price: 100, Amount: 2.5, DisplayAmount: 1, ClientOrderID: "ice"}
+	resp, err := m.submitIceberg(ctx, fake, s)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, resp.Amount, "the first slice should be the display amount")
+	require.Len(t, fake.submitted, 1)
+	assert.Zero(t, fake.submitted[0].DisplayAmount, "emulated slices must not be sent with a display amount")
+	assert.Equal(t, "ice-1", fake.submitted[0].ClientOrderID)
+	assert.Len(t, m.icebergs, 1)
+
+	m.processIcebergs(ctx)
+	assert.Len(t, fake.submitted, 1, "the next slice must not be placed until the current slice is filled")
+
+	fillSlice("1", order.Filled)
+	m.processIcebergs(ctx)
+	require.Len(t, fake.submitted, 2)
+	assert.Equal(t, 1.0, fake.submitted[1].Amount)
+	assert.Equal(t, "ice-2", fake.submitted[1].ClientOrderID)
+
+	fillSlice("2", order.Filled)
+	m.processIcebergs(ctx)
+	require.Len(t, fake.submitted, 3)
+	assert.Equal(t, 0.5, fake.submitted[2].Amount, "the last slice should be the remaining amount")
+	assert.Empty(t, m.icebergs, "completed iceberg orders should no longer be tracked")
+
+	_, err = m.submitIceberg(ctx, fake, s)
+	require.NoError(t, err)
+	fillSlice("4", order.Cancelled)
+	m.processIcebergs(ctx)
+	assert.Len(t, fake.submitted, 4, "cancelled slices should not be replaced")
+	assert.Empty(t, m.icebergs, "cancelled iceberg orders should no longer be tracked")
+
+	_, err = m.submitIceberg(ctx, fake, s)
+	require.NoError(t, err)
+	fillSlice("5", order.Filled)
+	fake.submitErr = request.WithRetryHint(errExpectedTestError, request.RetryHint{RetryAfter: time.Hour})
+	m.processIcebergs(ctx)
+	require.Len(t, m.icebergs, 1, "iceberg orders should be kept when a slice fails with a transient error")
+	fake.submitErr = nil
+	m.processIcebergs(ctx)
+	assert.Len(t, fake.submitted, 5, "slices must not be retried before the retry hint allows")
+	for _, ice := range m.icebergs {
+		ice.retryAt = time.Time{}
+	}
+	m.processIcebergs(ctx)
+	assert.Len(t, fake.submitted, 6, "slices should be retried once the retry hint allows")
+
+	fillSlice("6", order.Filled)
+	fake.submitErr = request.WithRetryHint(errExpectedTestError, request.RetryHint{Permanent: true})
+	m.processIcebergs(ctx)
+	assert.Empty(t, m.icebergs, "iceberg orders should no longer be tracked when a slice cannot be placed")
+
+	_, err = m.submitIceberg(ctx, fake, s)
+	assert.ErrorIs(t, err, errExpectedTestError)
+	assert.Empty(t, m.icebergs)
+}
+
+func TestIcebergSliceLimits(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	exch, err := em.NewExchangeByName(testExchange)
+	require.NoError(t, err)
+	exch.SetDefaults()
+	fake := &icebergExchange{
+		omfExchange: omfExchange{IBotExchange: exch},
+		limits:      &order.MinMaxLevel{AmountStepIncrementSize: 0.1, MinimumBaseAmount: 0.2},
+	}
+	require.NoError(t, em.Add(fake))
+	m, err := SetupOrderManager(em, &CommunicationManager{}, &sync.WaitGroup{}, &config.OrderManager{})
+	require.NoError(t, err)
+	m.started = 1
+	ctx := context.Background()
+	fillSlices := func() {
+		t.Helper()
+		for _, ice := range m.icebergs {
+			od, err := m.orderStore.getByExchangeAndID(testExchange, ice.sliceID)
+			require.NoError(t, err)
+			od.Status = order.Filled
+			od.ExecutedAmount = od.Amount
+			require.NoError(t, m.orderStore.updateExisting(od))
+		}
+	}
+
+	s := &order.Submit{Exchange: testExchange, AssetType: asset.Spot, Pair: btcusdPair, Side: order.Buy, Type: order.Limit, Price: 100, Amount: 1, DisplayAmount: 0.35}
+	_, err = m.submitIceberg(ctx, fake, s)
+	require.NoError(t, err)
+	for range 3 {
+		fillSlices()
+		m.processIcebergs(ctx)
+	}
+	assert.Empty(t, m.icebergs)
+	amounts := make([]float64, len(fake.submitted))
+	for i := range fake.submitted {
+		amounts[i] = fake.submitted[i].Amount
+	}
+	assert.Equal(t, []float64{0.3, 0.3, 0.4}, amounts, "slices should stay on the amount step and merge a tail below the minimum amount")
+
+	fake.submitted = nil
+	fake.limits.MinimumBaseAmount = 0.5
+	_, err = m.submitIceberg(ctx, fake, s)
+	assert.ErrorIs(t, err, order.ErrAmountBelowMin, "slices must be checked against execution limits")
+	assert.Empty(t, fake.submitted)
+}
+
+// ocoExchange records orders to test OCO orders, submitting them natively
+// when native is set
+type ocoExchange struct {
+	icebergExchange
+	native    bool
+	cancelled []string
+}
+
+func (f *ocoExchange) CanTradePair(currency.Pair, asset.Item) error {
+	return nil
+}
+
+func (f *ocoExchange) CheckOrderExecutionLimits(asset.Item, currency.Pair, float64, float64, order.Type) error {
+	return nil
+}
+
+func (f *ocoExchange) CancelOrder(_ context.Context, c *order.Cancel) error {
+	f.cancelled = append(f.cancelled, c.OrderID)
+	return nil
+}
+
+func (f *ocoExchange) SubmitOCOOrder(ctx context.Context, o *order.OCOSubmit) (*order.OCOSubmitResponse, error) {
+	if !f.native {
+		return f.icebergExchange.SubmitOCOOrder(ctx, o)
+	}
+	limit, stop := o.Legs()
+	limitResp, err := limit.DeriveSubmitResponse("limit")
+	if err != nil {
+		return nil, err
+	}
+	stopResp, err := stop.DeriveSubmitResponse("stop")
+	if err != nil {
+		return nil, err
+	}
+	return &order.OCOSubmitResponse{ListID: "list", Limit: limitResp, Stop: stopResp}, nil
+}
+
+func ocoSetup(t *testing.T) (*OrderManager, *ocoExchange) {
+	t.Helper()
+	em := NewExchangeManager()
+	exch, err := em.NewExchangeByName(testExchange)
+	require.NoError(t, err)
+	exch.SetDefaults()
+	fake := &ocoExchange{icebergExchange: icebergExchange{omfExchange: omfExchange{IBotExchange: exch}}}
+	require.NoError(t, em.Add(fake))
+	m, err := SetupOrderManager(em, &CommunicationManager{}, &sync.WaitGroup{}, &config.OrderManager{})
+	require.NoError(t, err)
+	m.started = 1
+	return m, fake
+}
+
+func TestSubmitOCO(t *testing.T) {
+	t.Parallel()
+	var m *OrderManager
+	_, err := m.SubmitOCO(context.Background(), &order.OCOSubmit{})
+	assert.ErrorIs(t, err, ErrNilSubsystem)
+
+	m, fake := ocoSetup(t)
+	_, err = m.SubmitOCO(context.Background(), nil)
+	assert.ErrorIs(t, err, order.ErrOCOIsNil)
+	o := &order.OCOSubmit{Exchange: testExchange, Pair: btcusdPair, AssetType: asset.Spot, Side: order.Sell, Amount: 1, Price: 110, TriggerPrice: 100}
+
+	m.cfg.EnforceLimitConfig = true
+	_, err = m.SubmitOCO(context.Background(), o)
+	assert.Error(t, err, "stop legs which trigger market orders should be rejected when market orders are not allowed")
+	m.cfg.EnforceLimitConfig = false
+
+	fake.native = true
+	resp, err := m.SubmitOCO(context.Background(), o)
+	require.NoError(t, err)
+	assert.False(t, resp.Emulated)
+	assert.Equal(t, "list", resp.ListID)
+	require.NotNil(t, resp.Stop, "natively submitted stop legs must be returned")
+	assert.Equal(t, order.Stop, resp.Stop.Type)
+	_, err = m.orderStore.getByExchangeAndID(testExchange, "stop")
+	assert.NoError(t, err, "natively submitted legs should be tracked")
+	assert.Empty(t, m.ocos, "native OCO orders should not be emulated")
+
+	fake.native = false
+	resp, err = m.SubmitOCO(context.Background(), o)
+	require.NoError(t, err)
+	assert.True(t, resp.Emulated)
+	assert.Nil(t, resp.Stop, "emulated stop legs must not be submitted until triggered")
+	require.Len(t, fake.submitted, 1)
+	assert.Equal(t, order.Limit, fake.submitted[0].Type)
+	assert.Len(t, m.ocos, 1)
+
+	m.paper = &paperTrader{}
+	_, err = m.SubmitOCO(context.Background(), o)
+	assert.ErrorIs(t, err, errOCONotSimulated)
+}
+
+func TestEmulatedOCOLimitLegCancelsStop(t *testing.T) {
+	t.Parallel()
+	m, fake := ocoSetup(t)
+	o := &order.OCOSubmit{Exchange: testExchange, Pair: btcusdPair, AssetType: asset.Spot, Side: order.Sell, Amount: 1, Price: 110, TriggerPrice: 100}
+	resp, err := m.SubmitOCO(context.Background(), o)
+	require.NoError(t, err)
+
+	d, err := m.GetByExchangeAndID(testExchange, resp.Limit.OrderID)
+	require.NoError(t, err)
+	require.NoError(t, m.UpdateExistingOrder(d))
+	assert.Len(t, m.ocos, 1, "stop legs should be held while the limit leg is open")
+
+	d.Status = order.PartiallyFilled
+	d.ExecutedAmount = 0.1
+	require.NoError(t, m.UpdateExistingOrder(d))
+	assert.Empty(t, m.ocos, "the limit leg executing should cancel the stop leg")
+
+	resp, err = m.SubmitOCO(context.Background(), o)
+	require.NoError(t, err)
+	require.NoError(t, m.Cancel(context.Background(), &order.Cancel{Exchange: testExchange, OrderID: resp.Limit.OrderID, Pair: btcusdPair, AssetType: asset.Spot}))
+	assert.Empty(t, m.ocos, "cancelling the limit leg should cancel the stop leg")
+	assert.Len(t, fake.submitted, 2)
+}
+
+func TestProcessOCOs(t *testing.T) {
+	t.Parallel()
+	m, fake := ocoSetup(t)
+	pair := currency.NewPair(currency.NewCode("OCOTEST"), currency.USD)
+	o := &order.OCOSubmit{Exchange: testExchange, Pair: pair, AssetType: asset.Spot, Side: order.Sell, Amount: 1, Price: 110, TriggerPrice: 100, StopLimitPrice: 99}
+	resp, err := m.SubmitOCO(context.Background(), o)
+	require.NoError(t, err)
+
+	m.processOCOs(context.Background())
+	assert.Len(t, m.ocos, 1, "stop legs should not trigger without a ticker")
+
+	require.NoError(t, ticker.ProcessTicker(&ticker.Price{ExchangeName: testExchange, Pair: pair, AssetType: asset.Spot, Last: 101}))
+	m.processOCOs(context.Background())
+	assert.Len(t, m.ocos, 1, "stop legs should not trigger above the trigger price when selling")
+
+	require.NoError(t, ticker.ProcessTicker(&ticker.Price{ExchangeName: testExchange, Pair: pair, AssetType: asset.Spot, Last: 100}))
+	m.processOCOs(context.Background())
+	assert.Empty(t, m.ocos)
+	assert.Equal(t, []string{resp.Limit.OrderID}, fake.cancelled, "the limit leg must be cancelled before the stop leg is submitted")
+	require.Len(t, fake.submitted, 2)
+	assert.Equal(t, order.Limit, fake.submitted[1].Type, "stop limit legs should be submitted as limit orders")
+	assert.Equal(t, 99.0, fake.submitted[1].Price)
+	assert.Zero(t, fake.submitted[1].TriggerPrice)
+
+	resp, err = m.SubmitOCO(context.Background(), o)
+	require.NoError(t, err)
+	d, err := m.GetByExchangeAndID(testExchange, resp.Limit.OrderID)
+	require.NoError(t, err)
+	d.ExecutedAmount = 0.5
+	require.NoError(t, m.orderStore.updateExisting(d))
+	m.processOCOs(context.Background())
+	assert.Empty(t, m.ocos)
+	assert.Len(t, fake.submitted, 3, "stop legs must not be submitted once the limit leg has executed")
+	assert.Len(t, fake.cancelled, 1)
+}
+
+func TestSubmitTrailingStop(t *testing.T) {
+	t.Parallel()
+	var m *OrderManager
+	_, err := m.SubmitTrailingStop(nil)
+	assert.ErrorIs(t, err, ErrNilSubsystem)
+	assert.ErrorIs(t, m.CancelTrailingStop(""), ErrNilSubsystem)
+	assert.Nil(t, m.GetTrailingStops())
+
+	m, _ = ocoSetup(t)
+	m.started = 0
+	_, err = m.SubmitTrailingStop(nil)
+	assert.ErrorIs(t, err, ErrSubSystemNotStarted)
+	m.started = 1
+	_, err = m.SubmitTrailingStop(nil)
+	assert.ErrorIs(t, err, order.ErrTrailingStopIsNil)
+
+	pair := currency.NewPair(currency.NewCode("TRAILSUBMIT"), currency.USD)
+	ts := &order.TrailingStopSubmit{Exchange: testExchange, Pair: pair, AssetType: asset.Spot, Side: order.Sell, Amount: 1, TrailAmount: 10}
+	_, err = m.SubmitTrailingStop(ts)
+	assert.ErrorIs(t, err, errNoReferencePrice, "trailing stops must start from a last price")
+
+	require.NoError(t, ticker.ProcessTicker(&ticker.Price{ExchangeName: testExchange, Pair: pair, AssetType: asset.Spot, Last: 5}))
+	_, err = m.SubmitTrailingStop(ts)
+	assert.ErrorIs(t, err, order.ErrTrailInvalid, "stop prices must be above zero")
+
+	require.NoError(t, ticker.ProcessTicker(&ticker.Price{ExchangeName: testExchange, Pair: pair, AssetType: asset.Spot, Last: 100}))
+	stop, err := m.SubmitTrailingStop(ts)
+	require.NoError(t, err)
+	assert.NotEmpty(t, stop.ID)
+	assert.Equal(t, 100.0, stop.BestPrice)
+	assert.Equal(t, 90.0, stop.StopPrice)
+	stops := m.GetTrailingStops()
+	require.Len(t, stops, 1)
+	assert.Equal(t, stop.ID, stops[0].ID)
+
+	assert.ErrorIs(t, m.CancelTrailingStop("bananas"), errTrailingStopNotFound)
+	require.NoError(t, m.CancelTrailingStop(stop.ID))
+	assert.Empty(t, m.GetTrailingStops())
+}
+
+func TestProcessTrailingStops(t *testing.T) {
+	t.Parallel()
+	m, fake := ocoSetup(t)
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), trailingStopStateFile)
+	require.NoError(t, m.setTrailingStopStateFile(path))
+	pair := currency.NewPair(currency.NewCode("TRAILTEST"), currency.USD)
+	last := func(price float64) *ticker.Price {
+		return &ticker.Price{ExchangeName: testExchange, Pair: pair, AssetType: asset.Spot, Last: price}
+	}
+	require.NoError(t, ticker.ProcessTicker(last(100)))
+	stop, err := m.SubmitTrailingStop(&order.TrailingStopSubmit{Exchange: testExchange, Pair: pair, AssetType: asset.Spot, Side: order.Sell, Amount: 1, TrailPercent: 10, LimitOffset: 1})
+	require.NoError(t, err)
+	assert.Equal(t, TrailingStopActive, stop.Status)
+
+	m.processTrailingStops(ctx, last(120))
+	require.Len(t, m.trailingStops, 1)
+	assert.Equal(t, 120.0, m.trailingStops[stop.ID].BestPrice)
+	assert.Equal(t, 108.0, m.trailingStops[stop.ID].StopPrice, "the stop price should follow the best price")
+
+	m.processTrailingStops(ctx, last(110))
+	assert.Equal(t, 108.0, m.trailingStops[stop.ID].StopPrice, "the stop price must not move against the order")
+	m.processTrailingStops(ctx, &ticker.Price{ExchangeName: testExchange, Pair: btcusdPair, AssetType: asset.Spot, Last: 1})
+	assert.Equal(t, TrailingStopActive, m.trailingStops[stop.ID].Status, "tickers of other pairs must not breach the stop price")
+	assert.Empty(t, fake.submitted)
+
+	fake.submitErr = request.WithRetryHint(errExpectedTestError, request.RetryHint{})
+	m.processTrailingStops(ctx, last(107))
+	require.Len(t, m.trailingStops, 1, "trailing stops should be kept when the order fails with a transient error")
+	assert.Equal(t, TrailingStopActive, m.trailingStops[stop.ID].Status, "trailing stops should keep trailing after a transient error")
+
+	fake.submitErr = nil
+	fake.onSubmit = func() {
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		var records []trailingStopRecord
+		require.NoError(t, json.Unmarshal(data, &records))
+		require.Len(t, records, 1)
+		assert.Equal(t, TrailingStopTriggering, records[0].Status, "trailing stops must be persisted as triggering before their order is submitted")
+		assert.ErrorIs(t, m.CancelTrailingStop(stop.ID), errTrailingStopTriggering, "trailing stops must not be cancelled while their order is submitted")
+	}
+	m.processTrailingStops(ctx, last(107))
+	assert.Empty(t, m.trailingStops, "trailing stops should be removed once their order is submitted")
+	require.Len(t, fake.submitted, 1)
+	assert.Equal(t, order.Limit, fake.submitted[0].Type)
+	assert.Equal(t, order.Sell, fake.submitted[0].Side)
+	assert.Equal(t, 107.0, fake.submitted[0].Price, "limit orders should be offset beyond the stop price")
+
+	stop, err = m.SubmitTrailingStop(&order.TrailingStopSubmit{Exchange: testExchange, Pair: pair, AssetType: asset.Spot, Side: order.Buy, Amount: 1, TrailAmount: 5})
+	require.NoError(t, err)
+	var attempts int
+	fake.onSubmit = func() { attempts++ }
+	fake.submitErr = request.WithRetryHint(errExpectedTestError, request.RetryHint{Permanent: true})
+	m.processTrailingStops(ctx, last(112))
+	stops := m.GetTrailingStops()
+	require.Len(t, stops, 1, "trailing stops should be kept when their order cannot be submitted")
+	assert.Equal(t, TrailingStopFailed, stops[0].Status)
+	assert.Contains(t, stops[0].Error, errExpectedTestError.Error(), "the reason the order failed should be recorded")
+	m.processTrailingStops(ctx, last(120))
+	assert.Equal(t, 1, attempts, "failed trailing stops must not be submitted again")
+	require.NoError(t, m.CancelTrailingStop(stop.ID), "failed trailing stops should be cancellable")
+}
+
+func TestStreamTrailingStops(t *testing.T) {
+	// Not parallel as the global dispatcher is started to stream tickers
+	if !dispatch.IsRunning() {
+		require.NoError(t, dispatch.Start(1, dispatch.DefaultJobsLimit))
+		t.Cleanup(func() { assert.NoError(t, dispatch.Stop()) })
+	}
+	m, fake := ocoSetup(t)
+	pair := currency.NewPair(currency.NewCode("TRAILSTREAM"), currency.USD)
+	last := func(price float64) *ticker.Price {
+		return &ticker.Price{ExchangeName: testExchange, Pair: pair, AssetType: asset.Spot, Last: price}
+	}
+	require.NoError(t, ticker.ProcessTicker(last(100)))
+	stop, err := m.SubmitTrailingStop(&order.TrailingStopSubmit{Exchange: testExchange, Pair: pair, AssetType: asset.Spot, Side: order.Sell, Amount: 1, TrailAmount: 10})
+	require.NoError(t, err)
+	watching := func() bool {
+		m.trailingMtx.Lock()
+		defer m.trailingMtx.Unlock()
+		return m.trailingWatches[stop.key()]
+	}
+	assert.True(t, watching(), "submitting a trailing stop should stream its ticker")
+
+	require.NoError(t, ticker.ProcessTicker(last(120)))
+	assert.Eventually(t, func() bool {
+		stops := m.GetTrailingStops()
+		return len(stops) == 1 && stops[0].StopPrice == 110
+	}, time.Second, time.Millisecond, "ticker updates should move the stop price")
+
+	require.NoError(t, ticker.ProcessTicker(last(109)))
+	assert.Eventually(t, func() bool {
+		return len(m.GetTrailingStops()) == 0 && !watching()
+	}, time.Second, time.Millisecond, "breaching ticker updates should trigger the trailing stop and end the stream")
+	require.Len(t, fake.submitted, 1)
+	assert.Equal(t, order.Market, fake.submitted[0].Type)
+}
+
+func TestWatchTrailingStops(t *testing.T) {
+	t.Parallel()
+	m, _ := ocoSetup(t)
+	pair := currency.NewPair(currency.NewCode("TRAILWATCH"), currency.USD)
+	ts := &TrailingStop{ID: "1", TrailingStopSubmit: order.TrailingStopSubmit{Exchange: testExchange, Pair: pair, AssetType: asset.Spot}, Status: TrailingStopActive}
+	m.trailingStops[ts.ID] = ts
+	m.watchTrailingStops()
+	assert.Empty(t, m.trailingWatches, "pairs without a ticker should not be streamed")
+
+	m.trailingWatches[ts.key()] = true
+	assert.True(t, m.keepTrailingWatch(ts.key()), "streams should be kept while their pair has active trailing stops")
+	ts.Status = TrailingStopFailed
+	assert.False(t, m.keepTrailingWatch(ts.key()), "streams should end once their pair has no active trailing stops")
+	assert.Empty(t, m.trailingWatches)
+}
+
+func TestTrailingStopPersistence(t *testing.T) {
+	t.Parallel()
+	m, _ := ocoSetup(t)
+	path := filepath.Join(t.TempDir(), trailingStopStateFile)
+	require.NoError(t, m.setTrailingStopStateFile(path), "missing state files should be ignored")
+
+	pair := currency.NewPair(currency.NewCode("TRAILSAVE"), currency.USD)
+	require.NoError(t, ticker.ProcessTicker(&ticker.Price{ExchangeName: testExchange, Pair: pair, AssetType: asset.Spot, Last: 100}))
+	stop, err := m.SubmitTrailingStop(&order.TrailingStopSubmit{Exchange: testExchange, Pair: pair, AssetType: asset.Spot, Side: order.Buy, Amount: 2, TrailAmount: 5, StrategyTag: "trail"})
+	require.NoError(t, err)
+
+	restarted, _ := ocoSetup(t)
+	require.NoError(t, restarted.setTrailingStopStateFile(path))
+	stops := restarted.GetTrailingStops()
+	require.Len(t, stops, 1, "trailing stops must be restored after a restart")
+	assert.Equal(t, stop.ID, stops[0].ID)
+	assert.Equal(t, order.Buy, stops[0].Side)
+	assert.True(t, pair.Equal(stops[0].Pair))
+	assert.Equal(t, asset.Spot, stops[0].AssetType)
+	assert.Equal(t, 105.0, stops[0].StopPrice)
+	assert.Equal(t, "trail", stops[0].StrategyTag)
+
+	require.NoError(t, restarted.CancelTrailingStop(stop.ID))
+	require.NoError(t, m.setTrailingStopStateFile(path))
+	assert.Len(t, m.GetTrailingStops(), 1, "restoring should not drop trailing stops already tracked")
+	restarted, _ = ocoSetup(t)
+	require.NoError(t, restarted.setTrailingStopStateFile(path))
+	assert.Empty(t, restarted.GetTrailingStops(), "cancelled trailing stops must not be restored")
+
+	m.trailingMtx.Lock()
+	for _, ts := range m.trailingStops {
+		ts.Status = TrailingStopTriggering
+	}
+	require.NoError(t, m.saveTrailingStops())
+	m.trailingMtx.Unlock()
+	restarted, _ = ocoSetup(t)
+	require.NoError(t, restarted.setTrailingStopStateFile(path))
+	stops = restarted.GetTrailingStops()
+	require.Len(t, stops, 1)
+	assert.Equal(t, TrailingStopFailed, stops[0].Status, "trailing stops interrupted while triggering must not be submitted again")
+	assert.NotEmpty(t, stops[0].Error)
+
+	require.NoError(t, os.WriteFile(path, []byte(`[{"id":"1","exchange":"Bitstamp","base":"BTC","quote":"USD","asset":"spot","side":"SELL","amount":1,"trailAmount":1,"bestPrice":10,"stopPrice":9}]`), 0o600))
+	restarted, _ = ocoSetup(t)
+	require.NoError(t, restarted.setTrailingStopStateFile(path))
+	stops = restarted.GetTrailingStops()
+	require.Len(t, stops, 1)
+	assert.Equal(t, TrailingStopActive, stops[0].Status, "trailing stops saved without a status should be active")
+
+	require.NoError(t, os.WriteFile(path, []byte("bananas"), 0o600))
+	assert.Error(t, restarted.setTrailingStopStateFile(path))
+}
+
+// priceBandExchange returns a set ticker to test price bands
+type priceBandExchange struct {
+	omfExchange
+	ticker    *ticker.Price
+	tickerErr error
+}
+
+func (f *priceBandExchange) FetchTicker(context.Context, currency.Pair, asset.Item) (*ticker.Price, error) {
+	return f.ticker, f.tickerErr
+}
+
+func TestSetupPriceBands(t *testing.T) {
+	t.Parallel()
+	_, err := setupPriceBands(map[string]config.PriceBand{"bananas": {MaxDeviation: 0.1}})
+	assert.ErrorIs(t, err, errInvalidPriceBand)
+	_, err = setupPriceBands(map[string]config.PriceBand{"options": {}})
+	assert.ErrorIs(t, err, errInvalidPriceBand, "max deviation must be set")
+	_, err = setupPriceBands(map[string]config.PriceBand{"options": {MaxDeviation: 0.1, References: []string{"mark", "mid"}}})
+	assert.ErrorIs(t, err, errInvalidPriceBand, "unrecognised references should error")
+
+	bands, err := setupPriceBands(map[string]config.PriceBand{
+		"options": {MaxDeviation: 0.1},
+		"spot":    {MaxDeviation: 0.05, References: []string{"Last"}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{markPriceReference, indexPriceReference}, bands[asset.Options].references, "references should default to mark then index")
+	assert.Equal(t, []string{lastPriceReference}, bands[asset.Spot].references)
+}
+
+func TestSetupMaxLeverage(t *testing.T) {
+	t.Parallel()
+	_, err := setupMaxLeverage(map[string]float64{"bananas": 10})
+	assert.ErrorIs(t, err, errInvalidMaxLeverage)
+	_, err = setupMaxLeverage(map[string]float64{"usdtmarginedfutures": 0})
+	assert.ErrorIs(t, err, errInvalidMaxLeverage, "max leverage must be above zero")
+
+	limits, err := setupMaxLeverage(map[string]float64{"USDTMarginedFutures": 20})
+	require.NoError(t, err)
+	assert.Equal(t, 20.0, limits[asset.USDTMarginedFutures])
+}
+
+func TestCheckLeverage(t *testing.T) {
+	t.Parallel()
+	var m *OrderManager
+	assert.ErrorIs(t, m.CheckLeverage(asset.Futures, 1), ErrNilSubsystem)
+
+	m, err := SetupOrderManager(NewExchangeManager(), &CommunicationManager{}, &sync.WaitGroup{}, &config.OrderManager{
+		MaxLeverage: map[string]float64{"futures": 10},
+	})
+	require.NoError(t, err)
+	m.started = 1
+	assert.NoError(t, m.CheckLeverage(asset.Futures, 10))
+	assert.ErrorIs(t, m.CheckLeverage(asset.Futures, 10.5), errLeverageAboveMax)
+	assert.NoError(t, m.CheckLeverage(asset.USDTMarginedFutures, 100), "assets without a max leverage should not be limited")
+
+	_, err = m.Submit(context.Background(), &order.Submit{
+		Exchange:  testExchange,
+		AssetType: asset.Futures,
+		Pair:      btcusdPair,
+		Side:      order.Long,
+		Type:      order.Market,
+		Amount:    1,
+		Leverage:  20,
+	})
+	assert.ErrorIs(t, err, errLeverageAboveMax, "orders should be rejected above the max leverage")
+}
+
+func TestCheckPriceBand(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	exch, err := em.NewExchangeByName(testExchange)
+	require.NoError(t, err)
+	exch.SetDefaults()
+	fake := &priceBandExchange{omfExchange: omfExchange{IBotExchange: exch}}
+	require.NoError(t, em.Add(fake))
+	m, err := SetupOrderManager(em, &CommunicationManager{}, &sync.WaitGroup{}, &config.OrderManager{
+		PriceBands: map[string]config.PriceBand{"options": {MaxDeviation: 0.1, MaxAge: time.Minute}},
+	})
+	require.NoError(t, err)
+	m.started = 1
+	ctx := context.Background()
+
+	assert.NoError(t, m.checkPriceBand(ctx, fake, asset.Spot, btcusdPair, 1), "assets without a band should not be checked")
+
+	fake.tickerErr = errExpectedTestError
+	assert.NoError(t, m.checkPriceBand(ctx, fake, asset.Options, btcusdPair, 100), "orders should be allowed without a reference price")
+	band := m.cfg.PriceBands[asset.Options]
+	band.rejectWithoutReference = true
+	m.cfg.PriceBands[asset.Options] = band
+	assert.ErrorIs(t, m.checkPriceBand(ctx, fake, asset.Options, btcusdPair, 100), errExpectedTestError)
+
+	fake.tickerErr = nil
+	fake.ticker = &ticker.Price{Last: 100, MarkPrice: 50, IndexPrice: 200, LastUpdated: time.Now().Add(-time.Hour)}
+	assert.ErrorIs(t, m.checkPriceBand(ctx, fake, asset.Options, btcusdPair, 100), errNoReferencePrice, "stale tickers should not be used as a reference")
+
+	fake.ticker.LastUpdated = time.Now()
+	assert.ErrorIs(t, m.checkPriceBand(ctx, fake, asset.Options, btcusdPair, 100), errPriceOutsideBand, "the mark price should be used instead of the last price")
+	assert.NoError(t, m.checkPriceBand(ctx, fake, asset.Options, btcusdPair, 54))
+	assert.ErrorIs(t, m.checkPriceBand(ctx, fake, asset.Options, btcusdPair, 44), errPriceOutsideBand)
+
+	fake.ticker.MarkPrice = 0
+	assert.NoError(t, m.checkPriceBand(ctx, fake, asset.Options, btcusdPair, 190), "the index price should be used when there is no mark price")
+	fake.ticker.IndexPrice = 0
+	assert.ErrorIs(t, m.checkPriceBand(ctx, fake, asset.Options, btcusdPair, 100), errNoReferencePrice)
+
+	fake.ticker.MarkPrice = 50
+	require.NoError(t, m.orderStore.add(&order.Detail{Exchange: testExchange, OrderID: "1", AssetType: asset.Options, Pair: btcusdPair, Side: order.Buy, Type: order.Limit, Price: 50, Amount: 1, Status: order.New}))
+	_, err = m.Modify(ctx, &order.Modify{Exchange: testExchange, OrderID: "1", Price: 500})
+	assert.ErrorIs(t, err, errPriceOutsideBand, "modified prices should be checked")
+}
+
+// strategyExchange records strategy legs and returns set fills to test option
+// strategy submission
+type strategyExchange struct {
+	omfExchange
+	comboErr  error
+	submitErr map[currency.Pair]error
+	statuses  map[string]order.Status
+	submitted []order.Submit
+	cancelled []string
+	combo     *options.ComboOrder
+}
+
+func (f *strategyExchange) GetAssetTypes(bool) asset.Items {
+	return asset.Items{asset.Options}
+}
+
+func (f *strategyExchange) CanTradePair(currency.Pair, asset.Item) error {
+	return nil
+}
+
+func (f *strategyExchange) CheckOrderExecutionLimits(asset.Item, currency.Pair, float64, float64, order.Type) error {
+	return nil
+}
+
+func (f *strategyExchange) SubmitComboOrder(_ context.Context, c *options.ComboOrder) (*options.ComboResponse, error) {
+	if f.comboErr != nil {
+		return nil, f.comboErr
+	}
+	f.combo = c
+	return &options.ComboResponse{Exchange: c.Exchange, OrderID: "combo", ComboID: "BTC-CS-27DEC24-60000_70000", Status: order.New}, nil
+}
+
+func (f *strategyExchange) SubmitOrder(_ context.Context, s *order.Submit) (*order.SubmitResponse, error) {
+	if err := f.submitErr[s.Pair]; err != nil {
+		return nil, err
+	}
+	f.submitted = append(f.submitted, *s)
+	return s.DeriveSubmitResponse(strconv.Itoa(len(f.submitted)))
+}
+
+func (f *strategyExchange) GetOrderInfo(_ context.Context, orderID string, _ currency.Pair, _ asset.Item) (*order.Detail, error) {
+	i, err := strconv.Atoi(orderID)
+	if err != nil || i > len(f.submitted) {
+		return nil, ErrOrderNotFound
+	}
+	s := f.submitted[i-1]
+	d := &order.Detail{Exchange: s.Exchange, OrderID: orderID, Pair: s.Pair, AssetType: s.AssetType, Side: s.Side, Type: s.Type, Amount: s.Amount, Price: s.Price, Status: order.New}
+	if status, ok := f.statuses[orderID]; ok {
+		d.Status = status
+	}
+	if d.Status == order.Filled {
+		d.ExecutedAmount = d.Amount
+	}
+	return d, nil
+}
+
+func (f *strategyExchange) CancelOrder(_ context.Context, c *order.Cancel) error {
+	f.cancelled = append(f.cancelled, c.OrderID)
+	return nil
+}
+
+func TestSubmitOptionStrategy(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	exch, err := em.NewExchangeByName(testExchange)
+	require.NoError(t, err)
+	exch.SetDefaults()
+	fake := &strategyExchange{omfExchange: omfExchange{IBotExchange: exch}}
+	require.NoError(t, em.Add(fake))
+	m, err := SetupOrderManager(em, &CommunicationManager{}, &sync.WaitGroup{}, &config.OrderManager{})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	long := currency.NewPair(currency.BTC, currency.NewCode("27DEC24-60000-C"))
+	short := currency.NewPair(currency.BTC, currency.NewCode("27DEC24-70000-C"))
+	s, err := options.NewVertical(long, short)
+	require.NoError(t, err)
+
+	_, err = m.SubmitOptionStrategy(ctx, testExchange, asset.Options, s, 1, LegRiskControls{})
+	assert.ErrorIs(t, err, ErrSubSystemNotStarted)
+	m.started = 1
+
+	_, err = m.SubmitOptionStrategy(ctx, testExchange, asset.Spot, s, 1, LegRiskControls{})
+	assert.ErrorIs(t, err, errStrategyNotOptions)
+	_, err = m.SubmitOptionStrategy(ctx, testExchange, asset.Options, s, 1, LegRiskControls{FillTimeout: -time.Second})
+	assert.ErrorIs(t, err, errInvalidLegFillTimeout)
+	_, err = m.SubmitOptionStrategy(ctx, testExchange, asset.Options, nil, 1, LegRiskControls{})
+	assert.ErrorIs(t, err, options.ErrNilStrategy)
+
+	resp, err := m.SubmitOptionStrategy(ctx, testExchange, asset.Options, s, 1, LegRiskControls{})
+	require.NoError(t, err)
+	require.NotNil(t, resp.Combo, "combo orders should be used when supported")
+	assert.Equal(t, "combo", resp.Combo.OrderID)
+	assert.Empty(t, fake.submitted, "legs should not be placed for combo orders")
+	require.NotNil(t, fake.combo)
+	assert.Equal(t, asset.OptionCombo, fake.combo.Asset, "combos should be submitted as option combos")
+	assert.Equal(t, "BTC-27DEC24-60000-C_27DEC24-70000-C", fake.combo.Pair.String(), "combos should be submitted with the strategy pair")
+	d, err := m.orderStore.getByExchangeAndID(testExchange, "combo")
+	require.NoError(t, err, "combo orders must be tracked")
+	assert.Equal(t, asset.OptionCombo, d.AssetType, "combo orders should be tracked as option combos")
+	assert.Equal(t, fake.combo.Pair, d.Pair)
+	assert.Equal(t, order.Market, d.Type, "unpriced combos should be market orders")
+	assert.Equal(t, 1.0, d.Amount)
+
+	fake.comboErr = errExpectedTestError
+	_, err = m.SubmitOptionStrategy(ctx, testExchange, asset.Options, s, 1, LegRiskControls{})
+	assert.ErrorIs(t, err, errExpectedTestError, "combo errors should not fall back to legs")
+	assert.Empty(t, fake.submitted)
+
+	fake.comboErr = common.ErrFunctionNotSupported
+	resp, err = m.SubmitOptionStrategy(ctx, testExchange, asset.Options, s, 1, LegRiskControls{})
+	require.NoError(t, err)
+	assert.Nil(t, resp.Combo)
+	require.Len(t, resp.Legs, 2, "legs should be placed when combo orders are not supported")
+	require.Len(t, fake.submitted, 2)
+	assert.Equal(t, long, fake.submitted[0].Pair, "the bought leg should be placed first")
+	assert.Equal(t, order.Sell, fake.submitted[1].Side)
+
+	fake.submitErr = map[currency.Pair]error{short: errExpectedTestError}
+	resp, err = m.SubmitOptionStrategy(ctx, testExchange, asset.Options, s, 1, LegRiskControls{})
+	assert.ErrorIs(t, err, errExpectedTestError)
+	assert.True(t, resp.Unwound, "placed legs should be unwound when a later leg fails")
+	require.Len(t, fake.submitted, 4)
+	assert.Equal(t, long, fake.submitted[3].Pair, "the executed market leg should be reversed")
+	assert.Equal(t, order.Sell, fake.submitted[3].Side)
+	assert.Equal(t, 1.0, fake.submitted[3].Amount)
+
+	fake.submitErr = nil
+	s.Legs[0].Price = 0.05
+	s.Legs[1].Price = 0.02
+	fake.statuses = map[string]order.Status{"5": order.Filled}
+	resp, err = m.SubmitOptionStrategy(ctx, testExchange, asset.Options, s, 1, LegRiskControls{FillTimeout: time.Millisecond})
+	require.ErrorIs(t, err, errLegNotFilled, "legs not filled within the timeout should error")
+	assert.True(t, resp.Unwound)
+	assert.Equal(t, []string{"6"}, fake.cancelled, "the unfilled leg should be cancelled")
+	require.Len(t, fake.submitted, 7)
+	assert.Equal(t, long, fake.submitted[6].Pair, "the filled leg should be reversed")
+	assert.Equal(t, order.Market, fake.submitted[6].Type)
+
+	fake.cancelled = nil
+	fake.statuses = map[string]order.Status{"8": order.Filled, "9": order.Filled}
+	resp, err = m.SubmitOptionStrategy(ctx, testExchange, asset.Options, s, 1, LegRiskControls{FillTimeout: time.Second})
+	require.NoError(t, err)
+	assert.False(t, resp.Unwound)
+	assert.Equal(t, order.Filled, resp.Legs[1].Status, "leg details should be updated once filled")
+	assert.Empty(t, fake.cancelled)
+
+	fake.submitErr = map[currency.Pair]error{long: errExpectedTestError}
+	resp, err = m.SubmitOptionStrategy(ctx, testExchange, asset.Options, s, 1, LegRiskControls{})
+	assert.ErrorIs(t, err, errExpectedTestError)
+	assert.False(t, resp.Unwound, "nothing should be unwound when the first leg fails")
+}
@@ -1,18 +1,40 @@
 package engine
 
 import (
+	"context"
 	"errors"
 	"sync"
 	"time"
 
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/common/key"
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/options"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 )
 
 // OrderManagerName is an exported subsystem name
 const OrderManagerName = "orders"
 
+// Cancel endpoints used to cancel orders within a scope
+const (
+	cancelAllEndpoint   = "cancel_all"
+	cancelBatchEndpoint = "cancel_batch"
+)
+
+// Ticker prices which limit order prices can be banded against
+const (
+	markPriceReference  = "mark"
+	indexPriceReference = "index"
+	lastPriceReference  = "last"
+)
+
+// trailingStopStateFile is the trailing stop state file name within the data
+// directory
+const trailingStopStateFile = "trailingstops.json"
+
 // vars for the fund manager package
 var (
 	// ErrOrdersAlreadyExists occurs when the order already exists in the manager
@@ -22,11 +44,31 @@ var (
 	// ErrOrderNotFound occurs when an order is not found in the orderstore
 	ErrOrderNotFound = errors.New("order does not exist")
 
-	errNilCommunicationsManager = errors.New("cannot start with nil communications manager")
-	errNilOrder                 = errors.New("nil order received")
-	errFuturesTrackingDisabled  = errors.New("tracking futures positions disabled. enable it via config under orderManager activelyTrackFuturesPositions")
-	orderManagerInterval        = time.Second * 10
-	defaultOrderSeekTime        = -time.Hour * 24 * 365
+	errNilCommunicationsManager  = errors.New("cannot start with nil communications manager")
+	errNilOrder                  = errors.New("nil order received")
+	errFuturesTrackingDisabled   = errors.New("tracking futures positions disabled. enable it via config under orderManager activelyTrackFuturesPositions")
+	errPairNotTradeable          = errors.New("pair is not tradeable")
+	errPairPostOnly              = errors.New("pair only accepts post only orders")
+	errPairReduceOnly            = errors.New("pair only accepts reduce only orders")
+	errNilCancelScope            = errors.New("nil cancel scope")
+	errSelfTradePrevented        = errors.New("order prevented from trading with own resting orders")
+	errSelfTradePreventionFailed = errors.New("self trade prevention failed")
+	errInvalidPriceBand          = errors.New("invalid price band")
+	errPriceOutsideBand          = errors.New("order price outside of price band")
+	errNoReferencePrice          = errors.New("no reference price available")
+	errInvalidMaxLeverage        = errors.New("invalid max leverage")
+	errLeverageAboveMax          = errors.New("leverage above max allowed")
+	errStrategyNotOptions        = errors.New("option strategies must be submitted for the options asset")
+	errInvalidLegFillTimeout     = errors.New("invalid leg fill timeout")
+	errLegNotFilled              = errors.New("strategy leg not filled")
+	errStrategyLegsExposed       = errors.New("strategy legs left exposed")
+	errOCONotSimulated           = errors.New("OCO orders are not simulated by paper trading")
+	errTrailingStopNotFound      = errors.New("trailing stop not found")
+	errTrailingStopTriggering    = errors.New("trailing stop order is being submitted")
+	strategyLegPollInterval      = time.Second
+	stopTriggerInterval          = time.Second
+	orderManagerInterval         = time.Second * 10
+	defaultOrderSeekTime         = -time.Hour * 24 * 365
 )
 
 type orderManagerConfig struct {
@@ -37,19 +79,66 @@ type orderManagerConfig struct {
 	AllowedPairs           currency.Pairs
 	AllowedExchanges       []string
 	OrderSubmissionRetries int64
+	SelfTradePrevention    order.SelfTradePrevention
+	PriceBands             map[asset.Item]priceBand
+	MaxLeverage            map[asset.Item]float64
+}
+
+// priceBand holds how far a limit order price may deviate from the first
+// available reference price for an asset
+type priceBand struct {
+	references             []string
+	maxDeviation           float64
+	maxAge                 time.Duration
+	rejectWithoutReference bool
 }
 
 // OrderManager processes and stores orders across enabled exchanges
 type OrderManager struct {
 	started                       int32
 	processingOrders              int32
+	processingOCOs                int32
 	shutdown                      chan struct{}
+	ctx                           context.Context
+	cancel                        context.CancelFunc
 	orderStore                    store
 	cfg                           orderManagerConfig
 	verbose                       bool
 	activelyTrackFuturesPositions bool
 	futuresPositionSeekDuration   time.Duration
 	respectOrderHistoryLimits     bool
+	icebergMtx                    sync.Mutex
+	icebergs                      map[string]*icebergOrder
+	ocoMtx                        sync.Mutex
+	ocos                          map[string]*ocoOrder
+	trailingMtx                   sync.Mutex
+	trailingStops                 map[string]*TrailingStop
+	// trailingWatches holds the pairs whose ticker stream is processing
+	// trailing stops
+	trailingWatches map[key.ExchangePairAsset]bool
+	maintenanceMtx  sync.RWMutex
+	maintenance     iMaintenanceSchedule
+	// paper simulates orders against live orderbooks instead of sending
+	// them to exchanges when paper trading is enabled
+	paper *paperTrader
+	// trailingStopFile persists trailing stops across restarts when set
+	trailingStopFile string
+}
+
+// icebergOrder tracks an order with a display amount which is emulated by
+// submitting its amount in display sized slices, placing the next slice once
+// the current slice is filled
+type icebergOrder struct {
+	submit    order.Submit
+	remaining decimal.Decimal
+	sliceID   string
+	slices    int
+	// placing is set while the next slice is being submitted, so that the
+	// slice is not placed twice while the iceberg lock is released
+	placing bool
+	// retryAt is when placing the next slice is retried after it failed
+	// with a transient error
+	retryAt time.Time
 }
 
 // store holds all orders by exchange
@@ -68,9 +157,133 @@ type OrderSubmitResponse struct {
 	InternalOrderID string
 }
 
+// LegRiskControls limit the exposure left while a strategy is submitted as
+// individual legs
+type LegRiskControls struct {
+	// FillTimeout is how long each leg may take to fill before the next is
+	// placed, after which the strategy is unwound. Legs are placed without
+	// waiting for fills when unset
+	FillTimeout time.Duration
+}
+
+// StrategySubmitResponse holds the result of a submitted option strategy,
+// either the combo order or each leg placed
+type StrategySubmitResponse struct {
+	Combo *options.ComboResponse
+	Legs  []*OrderSubmitResponse
+	// Unwound is set when placed legs were cancelled or reversed after a
+	// later leg failed
+	Unwound bool
+}
+
+// OCOSubmitResponse holds the result of a submitted OCO order
+type OCOSubmitResponse struct {
+	// ListID is the exchange's ID for the pair of orders when submitted
+	// natively
+	ListID string
+	Limit  *OrderSubmitResponse
+	// Stop is nil when the stop leg is emulated, as it is only submitted
+	// once triggered
+	Stop *OrderSubmitResponse
+	// Emulated is set when the exchange does not support OCO orders so the
+	// stop leg is held by the order manager
+	Emulated bool
+}
+
+// ocoOrder tracks an emulated OCO order whose limit leg rests on the exchange
+// and whose stop leg is held until the trigger price is reached
+type ocoOrder struct {
+	exchange     string
+	limitID      string
+	triggerPrice float64
+	// stop is the order submitted when triggered, a market order or a limit
+	// order at the stop limit price
+	stop order.Submit
+}
+
+// TrailingStopStatus is the state of an emulated trailing stop
+type TrailingStopStatus string
+
+// Trailing stop statuses
+const (
+	// TrailingStopActive trails the last price until it breaches the stop
+	// price
+	TrailingStopActive TrailingStopStatus = "active"
+	// TrailingStopTriggering has been breached and its order is being
+	// submitted
+	TrailingStopTriggering TrailingStopStatus = "triggering"
+	// TrailingStopFailed could not submit its order and is kept until it is
+	// cancelled
+	TrailingStopFailed TrailingStopStatus = "failed"
+)
+
+// TrailingStop is a trailing stop order emulated by the order manager, which
+// submits its order once the last price breaches the stop price
+type TrailingStop struct {
+	ID string
+	order.TrailingStopSubmit
+	// BestPrice is the highest last price since submission when selling and
+	// the lowest when buying
+	BestPrice float64
+	StopPrice float64
+	Created   time.Time
+	Status    TrailingStopStatus
+	// Error is why the order could not be submitted when failed
+	Error string
+}
+
+// trailingStopRecord is the persisted state of a trailing stop. The pair is
+// stored as its base, quote and delimiter, as pairs without a delimiter cannot
+// be split when parsed
+type trailingStopRecord struct {
+	ID            string     `json:"id"`
+	Exchange      string     `json:"exchange"`
+	Base          string     `json:"base"`
+	Quote         string     `json:"quote"`
+	Delimiter     string     `json:"delimiter,omitempty"`
+	AssetType     asset.Item `json:"asset"`
+	Side          string     `json:"side"`
+	Amount        float64    `json:"amount"`
+	TrailAmount   float64    `json:"trailAmount,omitempty"`
+	TrailPercent  float64    `json:"trailPercent,omitempty"`
+	LimitOffset   float64    `json:"limitOffset,omitempty"`
+	ClientOrderID string     `json:"clientOrderID,omitempty"`
+	StrategyTag   string     `json:"strategyTag,omitempty"`
+	BestPrice     float64    `json:"bestPrice"`
+	StopPrice     float64    `json:"stopPrice"`
+	Created       time.Time  `json:"created"`
+	// Status is unset for trailing stops persisted before statuses were
+	// recorded, which are active
+	Status TrailingStopStatus `json:"status,omitempty"`
+	Error  string             `json:"error,omitempty"`
+}
+
 // OrderUpsertResponse contains a copy of the resulting order details and a bool
 // indicating if the order details were inserted (true) or updated (false)
 type OrderUpsertResponse struct {
 	OrderDetails order.Detail
 	IsNewOrder   bool
 }
+
+// CancelResult contains the outcome of cancelling an order within a scope
+type CancelResult struct {
+	Exchange      string
+	AssetType     asset.Item
+	Pair          currency.Pair
+	OrderID       string
+	ClientOrderID string
+	StrategyTag   string
+	Cancelled     bool
+	// Status is the status reported by the exchange or the reason the order
+	// failed to cancel
+	Status string
+	// Endpoint is the exchange endpoint used to cancel the order
+	Endpoint string
+}
+
+// cancelGroupKey groups orders that can be cancelled together
+type cancelGroupKey struct {
+	Exchange string
+	Asset    asset.Item
+	Pair     string
+}
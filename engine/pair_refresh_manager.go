@@ -0,0 +1,268 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// SetupPairRefreshManager creates a pair refresh manager subsystem
+func SetupPairRefreshManager(em iExchangeManager, cfg *config.PairRefreshManager) (*PairRefreshManager, error) {
+	if em == nil {
+		return nil, errNilExchangeManager
+	}
+	if cfg == nil {
+		return nil, errNilConfig
+	}
+	if cfg.Interval <= 0 {
+		return nil, fmt.Errorf("%w %v", errInvalidPairRefreshInterval, cfg.Interval)
+	}
+	return &PairRefreshManager{
+		verbose:         cfg.Verbose,
+		interval:        cfg.Interval,
+		exchangeManager: em,
+	}, nil
+}
+
+// Start runs the subsystem
+func (m *PairRefreshManager) Start() error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 0, 1) {
+		return ErrSubSystemAlreadyStarted
+	}
+	m.shutdown = make(chan struct{})
+	m.wg.Add(1)
+	go m.run()
+	log.Debugf(log.ExchangeSys, "Pair refresh manager %s", MsgSubSystemStarted)
+	return nil
+}
+
+// IsRunning checks whether the subsystem is running
+func (m *PairRefreshManager) IsRunning() bool {
+	if m == nil {
+		return false
+	}
+	return atomic.LoadInt32(&m.started) == 1
+}
+
+// Stop stops the subsystem
+func (m *PairRefreshManager) Stop() error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 1, 0) {
+		return ErrSubSystemNotStarted
+	}
+	close(m.shutdown)
+	m.wg.Wait()
+	log.Debugf(log.ExchangeSys, "Pair refresh manager %s", MsgSubSystemShutdown)
+	return nil
+}
+
+func (m *PairRefreshManager) run() {
+	defer m.wg.Done()
+	runScheduledJobs(m.shutdown, &scheduledJob{interval: m.interval, immediate: true, run: func(ctx context.Context, _ time.Time) { m.refreshAll(ctx) }})
+}
+
+// refreshAll refreshes the pairs of every exchange with pair rules
+func (m *PairRefreshManager) refreshAll(ctx context.Context) {
+	if err := m.RefreshAll(ctx); err != nil {
+		log.Errorf(log.ExchangeSys, "Pair refresh manager: %v", err)
+	}
+}
+
+// RefreshAll immediately refreshes the pairs of every exchange with pair rules
+func (m *PairRefreshManager) RefreshAll(ctx context.Context) error {
+	if m == nil {
+		return fmt.Errorf("%s %w", PairRefreshManagerName, ErrNilSubsystem)
+	}
+	exchanges, err := m.exchangeManager.GetExchanges()
+	if err != nil {
+		return fmt.Errorf("cannot get exchanges: %w", err)
+	}
+	var errs error
+	for x := range exchanges {
+		if err := m.refresh(ctx, exchanges[x]); err != nil {
+			errs = common.AppendError(errs, fmt.Errorf("unable to refresh %s pairs: %w", exchanges[x].GetName(), err))
+		}
+	}
+	return errs
+}
+
+// refresh updates the available pairs of an exchange and enables the pairs
+// which match its pair rules
+func (m *PairRefreshManager) refresh(ctx context.Context, exch exchange.IBotExchange) error {
+	b := exch.GetBase()
+	if b == nil || b.Config == nil || b.Config.PairRules == nil || len(b.Config.PairRules.Rules) == 0 {
+		return nil
+	}
+	if exch.GetEnabledFeatures().AutoPairUpdates {
+		if err := exch.UpdateTradablePairs(ctx, false); err != nil {
+			return err
+		}
+	}
+
+	rules := make(map[asset.Item][]config.PairRule)
+	for i := range b.Config.PairRules.Rules {
+		a, err := asset.New(b.Config.PairRules.Rules[i].Asset)
+		if err != nil {
+			return err
+		}
+		rules[a] = append(rules[a], b.Config.PairRules.Rules[i])
+	}
+
+	var errs error
+	var changed bool
+	for a, assetRules := range rules {
+		updated, err := m.applyRules(ctx, exch, a, assetRules, b.Config.PairRules.DisableUnmatched)
+		if err != nil {
+			errs = common.AppendError(errs, fmt.Errorf("%s %w", a, err))
+		}
+		changed = changed || updated
+	}
+	if changed && exch.IsWebsocketEnabled() && b.Websocket != nil && b.Websocket.IsConnected() {
+		errs = common.AppendError(errs, exch.FlushWebsocketChannels())
+	}
+	return errs
+}
+
+// applyRules enables the available pairs of an asset which match any of its
+// rules. Returns whether the enabled pairs changed
+func (m *PairRefreshManager) applyRules(ctx context.Context, exch exchange.IBotExchange, a asset.Item, rules []config.PairRule, disableUnmatched bool) (bool, error) {
+	matched, err := matchPairRules(ctx, exch, a, rules)
+	if err != nil {
+		return false, err
+	}
+	enabled, err := exch.GetEnabledPairs(a)
+	if err != nil {
+		return false, err
+	}
+	updated := matched
+	if !disableUnmatched {
+		updated = append(currency.Pairs{}, enabled...)
+		for i := range matched {
+			if !updated.Contains(matched[i], true) {
+				updated = append(updated, matched[i])
+			}
+		}
+	}
+	var added, removed currency.Pairs
+	for i := range updated {
+		if !enabled.Contains(updated[i], true) {
+			added = append(added, updated[i])
+		}
+	}
+	for i := range enabled {
+		if !updated.Contains(enabled[i], true) {
+			removed = append(removed, enabled[i])
+		}
+	}
+	if len(added) == 0 && len(removed) == 0 {
+		if m.verbose {
+			log.Debugf(log.ExchangeSys, "Pair refresh manager %s %s enabled pairs unchanged", exch.GetName(), a)
+		}
+		return false, nil
+	}
+	if len(updated) == 0 {
+		return false, errNoPairsMatched
+	}
+	if err := exch.SetPairs(updated, a, true); err != nil {
+		return false, err
+	}
+	if len(added) > 0 {
+		log.Infof(log.ExchangeSys, "Pair refresh manager %s %s enabled pairs: %s", exch.GetName(), a, added)
+	}
+	if len(removed) > 0 {
+		log.Infof(log.ExchangeSys, "Pair refresh manager %s %s disabled unmatched pairs: %s", exch.GetName(), a, removed)
+	}
+	return true, nil
+}
+
+// matchPairRules returns the available pairs of an asset which match any of
+// the rules
+func matchPairRules(ctx context.Context, exch exchange.IBotExchange, a asset.Item, rules []config.PairRule) (currency.Pairs, error) {
+	available, err := exch.GetAvailablePairs(a)
+	if err != nil {
+		return nil, err
+	}
+	var needVolume, needExpiry bool
+	for i := range rules {
+		needVolume = needVolume || rules[i].MinQuoteVolume > 0
+		needExpiry = needExpiry || rules[i].MaxTimeToExpiry > 0
+	}
+	if needVolume {
+		if err = exch.UpdateTickers(ctx, a); err != nil {
+			return nil, err
+		}
+	}
+	var expiries map[string]time.Time
+	if needExpiry {
+		contracts, err := exch.GetFuturesContractDetails(ctx, a)
+		if err != nil {
+			return nil, err
+		}
+		expiries = make(map[string]time.Time, len(contracts))
+		for i := range contracts {
+			expiries[pairRuleKey(contracts[i].Name)] = contracts[i].EndDate
+		}
+	}
+	now := time.Now()
+	var matched currency.Pairs
+	for i := range available {
+		for j := range rules {
+			if pairMatchesRule(exch.GetName(), available[i], a, &rules[j], expiries, now) {
+				matched = append(matched, available[i])
+				break
+			}
+		}
+	}
+	return matched, nil
+}
+
+// pairMatchesRule returns whether a pair satisfies every condition of a rule
+func pairMatchesRule(exchName string, p currency.Pair, a asset.Item, rule *config.PairRule, expiries map[string]time.Time, now time.Time) bool {
+	if len(rule.Bases) > 0 && !common.StringDataCompareInsensitive(rule.Bases, p.Base.String()) {
+		return false
+	}
+	if len(rule.Quotes) > 0 && !common.StringDataCompareInsensitive(rule.Quotes, p.Quote.String()) {
+		return false
+	}
+	if rule.MinQuoteVolume > 0 {
+		t, err := ticker.GetTicker(exchName, p, a)
+		if err != nil {
+			return false
+		}
+		volume := t.QuoteVolume
+		if volume == 0 {
+			volume = t.Volume * t.Last
+		}
+		if volume < rule.MinQuoteVolume {
+			return false
+		}
+	}
+	if rule.MaxTimeToExpiry > 0 {
+		expiry, ok := expiries[pairRuleKey(p)]
+		if !ok || !expiry.After(now) || expiry.Sub(now) > rule.MaxTimeToExpiry {
+			return false
+		}
+	}
+	return true
+}
+
+// pairRuleKey returns a format agnostic key for matching contract details to
+// available pairs
+func pairRuleKey(p currency.Pair) string {
+	return strings.ToUpper(p.Base.String() + p.Quote.String())
+}
@@ -0,0 +1,169 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+)
+
+var (
+	btcusdtPair = currency.NewPair(currency.BTC, currency.USDT)
+	ethusdtPair = currency.NewPair(currency.ETH, currency.USDT)
+)
+
+// prExchange is a fake exchange with pair rules, tickers and contract details
+type prExchange struct {
+	exchange.IBotExchange
+	name      string
+	base      *exchange.Base
+	available currency.Pairs
+	enabled   currency.Pairs
+	volumes   map[currency.Pair]float64
+	contracts []futures.Contract
+}
+
+func (f *prExchange) GetName() string {
+	return f.name
+}
+
+func (f *prExchange) GetBase() *exchange.Base {
+	return f.base
+}
+
+func (f *prExchange) GetEnabledFeatures() exchange.FeaturesEnabled {
+	return exchange.FeaturesEnabled{}
+}
+
+func (f *prExchange) IsWebsocketEnabled() bool {
+	return false
+}
+
+func (f *prExchange) GetAvailablePairs(asset.Item) (currency.Pairs, error) {
+	return f.available, nil
+}
+
+func (f *prExchange) GetEnabledPairs(asset.Item) (currency.Pairs, error) {
+	return f.enabled, nil
+}
+
+func (f *prExchange) SetPairs(pairs currency.Pairs, _ asset.Item, _ bool) error {
+	f.enabled = pairs
+	return nil
+}
+
+func (f *prExchange) UpdateTickers(_ context.Context, a asset.Item) error {
+	for p, v := range f.volumes {
+		if err := ticker.ProcessTicker(&ticker.Price{ExchangeName: f.name, Pair: p, AssetType: a, Last: 10, Volume: v}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *prExchange) GetFuturesContractDetails(context.Context, asset.Item) ([]futures.Contract, error) {
+	return f.contracts, nil
+}
+
+func newPRExchange(name string, rules *config.PairRules) *prExchange {
+	return &prExchange{
+		name: name,
+		base: &exchange.Base{Name: name, Config: &config.Exchange{PairRules: rules}},
+	}
+}
+
+func TestSetupPairRefreshManager(t *testing.T) {
+	t.Parallel()
+	_, err := SetupPairRefreshManager(nil, nil)
+	assert.ErrorIs(t, err, errNilExchangeManager)
+	_, err = SetupPairRefreshManager(NewExchangeManager(), nil)
+	assert.ErrorIs(t, err, errNilConfig)
+	_, err = SetupPairRefreshManager(NewExchangeManager(), &config.PairRefreshManager{})
+	assert.ErrorIs(t, err, errInvalidPairRefreshInterval)
+
+	m, err := SetupPairRefreshManager(NewExchangeManager(), &config.PairRefreshManager{Interval: time.Minute})
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, m.interval)
+}
+
+func TestPairRefreshManagerStartStop(t *testing.T) {
+	t.Parallel()
+	var m *PairRefreshManager
+	assert.ErrorIs(t, m.Start(), ErrNilSubsystem)
+	assert.ErrorIs(t, m.Stop(), ErrNilSubsystem)
+	assert.False(t, m.IsRunning())
+
+	m, err := SetupPairRefreshManager(NewExchangeManager(), &config.PairRefreshManager{Interval: time.Hour})
+	require.NoError(t, err)
+	assert.ErrorIs(t, m.Stop(), ErrSubSystemNotStarted)
+	require.NoError(t, m.Start())
+	assert.True(t, m.IsRunning())
+	assert.ErrorIs(t, m.Start(), ErrSubSystemAlreadyStarted)
+	require.NoError(t, m.Stop())
+	assert.False(t, m.IsRunning())
+}
+
+func TestPairRefreshVolumeRule(t *testing.T) {
+	t.Parallel()
+	rules := &config.PairRules{Rules: []config.PairRule{{Asset: "spot", Quotes: []string{"usdt"}, MinQuoteVolume: 1000}}}
+	exch := newPRExchange("prvolume", rules)
+	exch.available = currency.Pairs{btcusdtPair, ethusdtPair, btcusdPair}
+	exch.enabled = currency.Pairs{btcusdPair}
+	exch.volumes = map[currency.Pair]float64{btcusdtPair: 500, ethusdtPair: 1, btcusdPair: 500}
+
+	m := &PairRefreshManager{}
+	require.NoError(t, m.refresh(context.Background(), exch))
+	assert.Equal(t, currency.Pairs{btcusdPair, btcusdtPair}, exch.enabled, "matching pairs should be added to enabled pairs")
+
+	rules.DisableUnmatched = true
+	require.NoError(t, m.refresh(context.Background(), exch))
+	assert.Equal(t, currency.Pairs{btcusdtPair}, exch.enabled, "unmatched pairs should be disabled")
+
+	changed, err := m.applyRules(context.Background(), exch, asset.Spot, rules.Rules, true)
+	require.NoError(t, err)
+	assert.False(t, changed, "unchanged pairs should not be set")
+
+	exch.volumes = map[currency.Pair]float64{btcusdtPair: 1}
+	_, err = m.applyRules(context.Background(), exch, asset.Spot, rules.Rules, true)
+	assert.ErrorIs(t, err, errNoPairsMatched, "all enabled pairs should not be disabled")
+
+	rules.Rules[0].Asset = "bad"
+	assert.ErrorIs(t, m.refresh(context.Background(), exch), asset.ErrNotSupported)
+}
+
+func TestPairRefreshExpiryRule(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	btcNear := currency.NewPair(currency.BTC, currency.NewCode("USD-NEAR"))
+	ethFar := currency.NewPair(currency.ETH, currency.NewCode("USD-FAR"))
+	btcExpired := currency.NewPair(currency.BTC, currency.NewCode("USD-OLD"))
+	ltcNear := currency.NewPair(currency.LTC, currency.NewCode("USD-NEAR"))
+	exch := newPRExchange("prexpiry", nil)
+	exch.available = currency.Pairs{btcNear, ethFar, btcExpired, ltcNear}
+	exch.contracts = []futures.Contract{
+		{Name: btcNear, EndDate: now.Add(time.Hour * 24 * 30)},
+		{Name: ethFar, EndDate: now.Add(time.Hour * 24 * 90)},
+		{Name: btcExpired, EndDate: now.Add(-time.Hour)},
+		{Name: ltcNear, EndDate: now.Add(time.Hour * 24 * 30)},
+	}
+	matched, err := matchPairRules(context.Background(), exch, asset.Options, []config.PairRule{
+		{Asset: "options", Bases: []string{"BTC", "ETH"}, MaxTimeToExpiry: time.Hour * 24 * 60},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, currency.Pairs{btcNear}, matched, "only unexpired contracts within the expiry window should match")
+}
+
+func TestPairRefreshNoRules(t *testing.T) {
+	t.Parallel()
+	m := &PairRefreshManager{}
+	assert.NoError(t, m.refresh(context.Background(), newPRExchange("prnorules", nil)))
+	assert.NoError(t, m.refresh(context.Background(), newPRExchange("prnorules", &config.PairRules{})))
+}
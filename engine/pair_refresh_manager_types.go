@@ -0,0 +1,27 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// PairRefreshManagerName is an exported subsystem name
+const PairRefreshManagerName = "pair_refresh_manager"
+
+var (
+	errInvalidPairRefreshInterval = errors.New("pair refresh interval must be greater than zero")
+	errNoPairsMatched             = errors.New("no pairs matched pair rules")
+)
+
+// PairRefreshManager periodically refreshes exchange pair lists and enables
+// pairs which match the exchange's configured pair rules, so pairs do not
+// require manual curation
+type PairRefreshManager struct {
+	started         int32
+	shutdown        chan struct{}
+	wg              sync.WaitGroup
+	verbose         bool
+	interval        time.Duration
+	exchangeManager iExchangeManager
+}
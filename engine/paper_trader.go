@@ -0,0 +1,426 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/common/key"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/latency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// setupPaperTrader validates the paper trading config. Nil is returned when
+// paper trading is disabled
+func setupPaperTrader(cfg *config.PaperTrading) (*paperTrader, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	l, err := latency.NewSimulator(cfg.Latency, cfg.Jitter)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidPaperTradingConfig, err)
+	}
+	p := &paperTrader{
+		latency:         l,
+		slippageModel:   strings.ToLower(cfg.SlippageModel),
+		slippage:        decimal.NewFromFloat(cfg.SlippageBasisPoints).Div(decimal.NewFromInt(10000)),
+		matchInterval:   cfg.MatchInterval,
+		maxOrderbookAge: cfg.MaxOrderbookAge,
+		getOrderbook:    orderbook.Get,
+		depth:           make(map[key.ExchangePairAsset]*paperDepth),
+	}
+	switch p.slippageModel {
+	case "":
+		p.slippageModel = paperSlippageDepth
+	case paperSlippageDepth, paperSlippageFixed:
+	default:
+		return nil, fmt.Errorf("%w: unknown slippage model %q", errInvalidPaperTradingConfig, cfg.SlippageModel)
+	}
+	if cfg.SlippageBasisPoints < 0 {
+		return nil, fmt.Errorf("%w: slippage basis points %v must not be negative", errInvalidPaperTradingConfig, cfg.SlippageBasisPoints)
+	}
+	if cfg.MatchInterval < 0 || cfg.MaxOrderbookAge < 0 {
+		return nil, fmt.Errorf("%w: match interval and max orderbook age must not be negative", errInvalidPaperTradingConfig)
+	}
+	if p.matchInterval == 0 {
+		p.matchInterval = defaultMatchInterval
+	}
+	return p, nil
+}
+
+// getBook returns the orderbook an order is matched against, erroring when it
+// has not been updated within the max orderbook age
+func (p *paperTrader) getBook(exch string, cp currency.Pair, a asset.Item) (*orderbook.Base, error) {
+	book, err := p.getOrderbook(exch, cp, a)
+	if err != nil {
+		return nil, err
+	}
+	if p.maxOrderbookAge > 0 && time.Since(book.LastUpdated) > p.maxOrderbookAge {
+		return nil, fmt.Errorf("%w: %s %s %s last updated %s", errPaperOrderbookStale, exch, a, cp, book.LastUpdated)
+	}
+	return book, nil
+}
+
+// consumed returns the liquidity consumed by paper fills from the side of an
+// orderbook an order trades against. Consumption is reset whenever the
+// orderbook is updated. p.mtx must be held
+func (p *paperTrader) consumed(exch string, cp currency.Pair, a asset.Item, side order.Side, book *orderbook.Base) map[float64]decimal.Decimal {
+	k := key.ExchangePairAsset{Exchange: strings.ToLower(exch), Base: cp.Base.Item, Quote: cp.Quote.Item, Asset: a}
+	d, ok := p.depth[k]
+	if !ok || !d.lastUpdated.Equal(book.LastUpdated) || d.lastUpdateID != book.LastUpdateID {
+		d = &paperDepth{
+			lastUpdated:  book.LastUpdated,
+			lastUpdateID: book.LastUpdateID,
+			bids:         make(map[float64]decimal.Decimal),
+			asks:         make(map[float64]decimal.Decimal),
+		}
+		p.depth[k] = d
+	}
+	if side.IsLong() {
+		return d.asks
+	}
+	return d.bids
+}
+
+// match returns the fill of an order against the orderbook without consuming
+// its liquidity, where the liquidity already consumed from each level by price
+// is unavailable. Takers fill at the orderbook's prices moved against them by
+// the configured slippage, while resting makers fill at their limit price. A
+// zero limit matches at any price and a positive quote amount limits the fill
+// by cost instead of amount
+func (p *paperTrader) match(book *orderbook.Base, side order.Side, limit, amount, quote decimal.Decimal, consumed map[float64]decimal.Decimal, maker bool) paperFill {
+	levels := book.Bids
+	if side.IsLong() {
+		levels = book.Asks
+	}
+	var f paperFill
+	for i := range levels {
+		levelPrice := decimal.NewFromFloat(levels[i].Price)
+		if !paperPriceCrosses(side, levelPrice, limit) {
+			break
+		}
+		price := limit
+		if !maker {
+			price = p.slip(side, levelPrice, limit)
+		}
+		if !price.IsPositive() {
+			break
+		}
+		take, cost := amount.Sub(f.amount), decimal.Zero
+		if quote.IsPositive() {
+			// The remaining quote is spent exactly so that the fill's cost is
+			// not short of the quote amount by division rounding
+			cost = quote.Sub(f.cost)
+			take = cost.Div(price)
+		}
+		if p.slippageModel != paperSlippageFixed {
+			available := decimal.NewFromFloat(levels[i].Amount).Sub(consumed[levels[i].Price])
+			if !available.IsPositive() {
+				continue
+			}
+			if available.LessThan(take) {
+				take, cost = available, decimal.Zero
+			}
+		}
+		if cost.IsZero() {
+			cost = take.Mul(price)
+		}
+		if f.levels == nil {
+			f.levels = make(map[float64]decimal.Decimal)
+		}
+		f.levels[levels[i].Price] = f.levels[levels[i].Price].Add(take)
+		f.amount = f.amount.Add(take)
+		f.cost = f.cost.Add(cost)
+		if p.slippageModel == paperSlippageFixed ||
+			(quote.IsPositive() && f.cost.GreaterThanOrEqual(quote)) ||
+			(!quote.IsPositive() && f.amount.GreaterThanOrEqual(amount)) {
+			break
+		}
+	}
+	return f
+}
+
+// slip moves a fill price against the order by the configured slippage,
+// never beyond its limit price
+func (p *paperTrader) slip(side order.Side, price, limit decimal.Decimal) decimal.Decimal {
+	if side.IsLong() {
+		price = price.Mul(decimal.NewFromInt(1).Add(p.slippage))
+		if limit.IsPositive() {
+			price = decimal.Min(price, limit)
+		}
+		return price
+	}
+	price = price.Mul(decimal.NewFromInt(1).Sub(p.slippage))
+	if limit.IsPositive() {
+		price = decimal.Max(price, limit)
+	}
+	return price
+}
+
+// paperPriceCrosses returns whether an orderbook price can be traded by an
+// order with a limit price, where a zero limit crosses any price
+func paperPriceCrosses(side order.Side, price, limit decimal.Decimal) bool {
+	if limit.IsZero() {
+		return true
+	}
+	if side.IsLong() {
+		return price.LessThanOrEqual(limit)
+	}
+	return price.GreaterThanOrEqual(limit)
+}
+
+// placePaperOrder simulates an order against the live orderbook instead of
+// submitting it to the exchange. Market orders and the marketable amount of
+// limit orders are filled immediately, while the remainder of a limit order
+// rests until it is matched by processPaperOrders
+func (m *OrderManager) placePaperOrder(ctx context.Context, s *order.Submit) (*OrderSubmitResponse, error) {
+	if err := m.paper.latency.Wait(ctx); err != nil {
+		return nil, err
+	}
+	book, err := m.paper.getBook(s.Exchange, s.Pair, s.AssetType)
+	if err != nil {
+		return nil, err
+	}
+	if s.PostOnly {
+		levels := book.Bids
+		if s.Side.IsLong() {
+			levels = book.Asks
+		}
+		if len(levels) > 0 && paperPriceCrosses(s.Side, decimal.NewFromFloat(levels[0].Price), decimal.NewFromFloat(s.Price)) {
+			return nil, fmt.Errorf("%s %s %s %w", s.Exchange, s.AssetType, s.Pair, errPaperPostOnlyCrosses)
+		}
+	}
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.DeriveSubmitResponse(paperOrderIDPrefix + id.String())
+	if err != nil {
+		return nil, err
+	}
+	resp.Status = order.New
+	if resp.Amount == 0 && s.Type == order.Limit {
+		// Limit orders rest by amount so the quote amount is converted at
+		// the limit price
+		resp.Amount = decimal.NewFromFloat(s.QuoteAmount).Div(decimal.NewFromFloat(s.Price)).InexactFloat64()
+		resp.QuoteAmount = 0
+	}
+	result, err := m.processSubmittedOrder(resp)
+	if err != nil {
+		return nil, err
+	}
+	d, err := m.matchPaperOrder(result.Detail, book, false)
+	if err != nil {
+		return nil, err
+	}
+	result.Detail = d
+	return result, nil
+}
+
+// matchPaperOrder fills a stored paper order against the orderbook, returning
+// its updated details. Market, immediate or cancel and fill or kill orders are
+// cancelled when they cannot be completely filled
+func (m *OrderManager) matchPaperOrder(o *order.Detail, book *orderbook.Base, resting bool) (*order.Detail, error) {
+	m.paper.mtx.Lock()
+	defer m.paper.mtx.Unlock()
+	d, err := m.orderStore.getByExchangeAndID(o.Exchange, o.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if d.Status.IsInactive() {
+		return d, nil
+	}
+	var limit, quote decimal.Decimal
+	if d.Type == order.Limit {
+		limit = decimal.NewFromFloat(d.Price)
+	}
+	remaining := decimal.NewFromFloat(d.Amount).Sub(decimal.NewFromFloat(d.ExecutedAmount))
+	if d.Amount == 0 {
+		quote = decimal.NewFromFloat(d.QuoteAmount)
+	}
+	consumed := m.paper.consumed(d.Exchange, d.Pair, d.AssetType, d.Side, book)
+	fill := m.paper.match(book, d.Side, limit, remaining, quote, consumed, resting)
+	filled := fill.amount.GreaterThanOrEqual(remaining)
+	if quote.IsPositive() {
+		filled = fill.cost.GreaterThanOrEqual(quote)
+	}
+	var status order.Status
+	switch {
+	case d.TimeInForce == order.FOK && !filled:
+		fill = paperFill{}
+		status = order.Cancelled
+	case filled:
+		status = order.Filled
+	case d.Type == order.Market || d.TimeInForce == order.IOC:
+		status = order.Cancelled
+		if fill.amount.IsPositive() {
+			status = order.PartiallyFilledCancelled
+		}
+	case fill.amount.IsPositive():
+		status = order.PartiallyFilled
+	default:
+		return d, nil
+	}
+	for price, amount := range fill.levels {
+		consumed[price] = consumed[price].Add(amount)
+	}
+	return m.orderStore.applyPaperFill(d.Exchange, d.OrderID, fill, status, resting)
+}
+
+// processPaperOrders matches resting paper orders against their orderbooks
+func (m *OrderManager) processPaperOrders() {
+	orders := m.orderStore.getActiveOrders(nil)
+	for i := range orders {
+		if !strings.HasPrefix(orders[i].OrderID, paperOrderIDPrefix) {
+			continue
+		}
+		book, err := m.paper.getBook(orders[i].Exchange, orders[i].Pair, orders[i].AssetType)
+		if err != nil {
+			if m.verbose {
+				log.Debugf(log.OrderMgr, "Order manager unable to match %s paper order %s: %v", orders[i].Exchange, orders[i].OrderID, err)
+			}
+			continue
+		}
+		d, err := m.matchPaperOrder(&orders[i], book, true)
+		if err != nil {
+			log.Errorf(log.OrderMgr, "Order manager unable to match %s paper order %s: %v", orders[i].Exchange, orders[i].OrderID, err)
+			continue
+		}
+		if d.ExecutedAmount <= orders[i].ExecutedAmount {
+			continue
+		}
+		msg := fmt.Sprintf("Exchange %s paper order ID=%v filled %v of %v at %v, status %s",
+			d.Exchange, d.OrderID, decimal.NewFromFloat(d.ExecutedAmount).Sub(decimal.NewFromFloat(orders[i].ExecutedAmount)), d.Amount, d.Price, d.Status)
+		log.Debugln(log.OrderMgr, msg)
+		m.orderStore.commsManager.PushEvent(base.Event{Type: "order", Message: msg, Exchange: d.Exchange})
+	}
+}
+
+// modifyPaperOrder simulates modifying a resting paper order, applying its
+// new price and amount to the stored order. The amount must exceed the amount
+// already executed
+func (m *OrderManager) modifyPaperOrder(ctx context.Context, mod *order.Modify) (*order.ModifyResponse, error) {
+	if err := m.paper.latency.Wait(ctx); err != nil {
+		return nil, err
+	}
+	m.paper.mtx.Lock()
+	defer m.paper.mtx.Unlock()
+	d, err := m.orderStore.getByExchangeAndID(mod.Exchange, mod.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if !d.IsActive() {
+		return nil, fmt.Errorf("%s order %s %w: %s", mod.Exchange, mod.OrderID, errPaperOrderNotActive, d.Status)
+	}
+	amount, executed := decimal.NewFromFloat(mod.Amount), decimal.NewFromFloat(d.ExecutedAmount)
+	if mod.Amount > 0 && amount.LessThanOrEqual(executed) {
+		return nil, fmt.Errorf("%s order %s %w: amount %v executed %v", mod.Exchange, mod.OrderID, errPaperAmountBelowExecuted, mod.Amount, d.ExecutedAmount)
+	}
+	if mod.Price > 0 {
+		d.Price = mod.Price
+	}
+	if mod.Amount > 0 {
+		d.Amount = mod.Amount
+		d.RemainingAmount = amount.Sub(executed).InexactFloat64()
+	}
+	d.LastUpdated = time.Now()
+	if err := m.orderStore.updateExisting(d); err != nil {
+		return nil, err
+	}
+	resp, err := mod.DeriveModifyResponse()
+	if err != nil {
+		return nil, err
+	}
+	resp.Price = d.Price
+	resp.Amount = d.Amount
+	resp.RemainingAmount = d.RemainingAmount
+	resp.LastUpdated = d.LastUpdated
+	return resp, nil
+}
+
+// cancelPaperOrder simulates cancelling a resting paper order
+func (m *OrderManager) cancelPaperOrder(ctx context.Context, cancel *order.Cancel) error {
+	if err := m.paper.latency.Wait(ctx); err != nil {
+		return err
+	}
+	m.paper.mtx.Lock()
+	defer m.paper.mtx.Unlock()
+	d, err := m.orderStore.getByExchangeAndID(cancel.Exchange, cancel.OrderID)
+	if err != nil {
+		return err
+	}
+	if !d.IsActive() {
+		return fmt.Errorf("%s order %s %w: %s", cancel.Exchange, cancel.OrderID, errPaperOrderNotActive, d.Status)
+	}
+	d.Status = order.Cancelled
+	return m.orderStore.updateExisting(d)
+}
+
+// applyPaperFill records a simulated fill against a stored paper order as a
+// single trade at the fill's average price, returning the order's details
+func (s *store) applyPaperFill(exch, id string, fill paperFill, status order.Status, isMaker bool) (*order.Detail, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	r, ok := s.Orders[strings.ToLower(exch)]
+	if !ok {
+		return nil, ErrExchangeNotFound
+	}
+	for x := range r {
+		if r[x].OrderID != id {
+			continue
+		}
+		d := r[x]
+		now := time.Now()
+		executed := decimal.NewFromFloat(d.ExecutedAmount)
+		if fill.amount.IsPositive() {
+			d.Trades = append(d.Trades, order.TradeHistory{
+				Price:     fill.cost.Div(fill.amount).InexactFloat64(),
+				Amount:    fill.amount.InexactFloat64(),
+				Exchange:  d.Exchange,
+				TID:       d.OrderID + "-" + strconv.Itoa(len(d.Trades)+1),
+				Type:      d.Type,
+				Side:      d.Side,
+				Timestamp: now,
+				IsMaker:   isMaker,
+				Total:     fill.cost.InexactFloat64(),
+			})
+			executed = executed.Add(fill.amount)
+			cost := decimal.NewFromFloat(d.Cost).Add(fill.cost)
+			d.Cost = cost.InexactFloat64()
+			d.AverageExecutedPrice = cost.Div(executed).InexactFloat64()
+		}
+		if d.Amount == 0 {
+			// Quote amount orders are complete once matched so their amount
+			// is what was executed
+			d.Amount = executed.InexactFloat64()
+		}
+		amount := decimal.NewFromFloat(d.Amount)
+		if status == order.Filled {
+			executed = decimal.Max(executed, amount)
+		}
+		d.ExecutedAmount = executed.InexactFloat64()
+		d.RemainingAmount = decimal.Max(amount.Sub(executed), decimal.Zero).InexactFloat64()
+		d.Status = status
+		d.LastUpdated = now
+		if fill.amount.IsPositive() && d.AssetType.IsFutures() {
+			err := s.futuresPositionController.TrackNewOrder(d)
+			if err != nil && !errors.Is(err, futures.ErrPositionClosed) {
+				return nil, err
+			}
+		}
+		return d.CopyToPointer(), nil
+	}
+	return nil, ErrOrderNotFound
+}
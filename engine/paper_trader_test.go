@@ -0,0 +1,367 @@
+package engine
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+)
+
+// paperBook is an orderbook which paper orders are matched against in tests
+type paperBook struct {
+	m    sync.Mutex
+	book orderbook.Base
+}
+
+func (b *paperBook) set(bids, asks []orderbook.Item) {
+	b.m.Lock()
+	defer b.m.Unlock()
+	b.book = orderbook.Base{Bids: bids, Asks: asks, LastUpdated: time.Now()}
+}
+
+func (b *paperBook) get(string, currency.Pair, asset.Item) (*orderbook.Base, error) {
+	b.m.Lock()
+	defer b.m.Unlock()
+	cpy := b.book
+	return &cpy, nil
+}
+
+// paperExchange errors when orders are sent to it to ensure paper orders are
+// never sent to exchanges
+type paperExchange struct {
+	omfExchange
+}
+
+func (f *paperExchange) CanTradePair(currency.Pair, asset.Item) error {
+	return nil
+}
+
+func (f *paperExchange) CheckOrderExecutionLimits(asset.Item, currency.Pair, float64, float64, order.Type) error {
+	return nil
+}
+
+func (f *paperExchange) SubmitOrder(context.Context, *order.Submit) (*order.SubmitResponse, error) {
+	return nil, errExpectedTestError
+}
+
+func (f *paperExchange) ModifyOrder(context.Context, *order.Modify) (*order.ModifyResponse, error) {
+	return nil, errExpectedTestError
+}
+
+func (f *paperExchange) CancelOrder(context.Context, *order.Cancel) error {
+	return errExpectedTestError
+}
+
+func (f *paperExchange) CancelAllOrders(context.Context, *order.Cancel) (order.CancelAllResponse, error) {
+	return order.CancelAllResponse{}, errExpectedTestError
+}
+
+// reset updates the orderbook to its initial levels
+func (b *paperBook) reset() {
+	b.set(
+		[]orderbook.Item{{Price: 99, Amount: 1}, {Price: 98, Amount: 1}},
+		[]orderbook.Item{{Price: 100, Amount: 1}, {Price: 101, Amount: 1}},
+	)
+}
+
+func paperTradingSetup(t *testing.T) (*OrderManager, *paperBook) {
+	t.Helper()
+	em := NewExchangeManager()
+	exch, err := em.NewExchangeByName(testExchange)
+	require.NoError(t, err)
+	exch.SetDefaults()
+	require.NoError(t, em.Add(&paperExchange{omfExchange: omfExchange{IBotExchange: exch}}))
+	m, err := SetupOrderManager(em, &CommunicationManager{}, &sync.WaitGroup{}, &config.OrderManager{PaperTrading: config.PaperTrading{Enabled: true}})
+	require.NoError(t, err)
+	m.started = 1
+	p := m.paper
+	require.NotNil(t, p, "paper trading must be setup when enabled")
+	book := &paperBook{}
+	book.reset()
+	p.getOrderbook = book.get
+	return m, book
+}
+
+func TestSetupPaperTrader(t *testing.T) {
+	t.Parallel()
+	p, err := setupPaperTrader(&config.PaperTrading{})
+	require.NoError(t, err)
+	assert.Nil(t, p, "paper trading should not be setup when disabled")
+
+	for _, cfg := range []config.PaperTrading{
+		{Enabled: true, Latency: -time.Second},
+		{Enabled: true, SlippageModel: "magic"},
+		{Enabled: true, SlippageBasisPoints: -1},
+		{Enabled: true, MatchInterval: -time.Second},
+		{Enabled: true, MaxOrderbookAge: -time.Second},
+	} {
+		_, err = setupPaperTrader(&cfg)
+		assert.ErrorIs(t, err, errInvalidPaperTradingConfig)
+	}
+
+	p, err = setupPaperTrader(&config.PaperTrading{Enabled: true, SlippageModel: "FIXED", SlippageBasisPoints: 25})
+	require.NoError(t, err)
+	assert.Equal(t, paperSlippageFixed, p.slippageModel)
+	assert.Equal(t, "0.0025", p.slippage.String())
+	assert.Equal(t, defaultMatchInterval, p.matchInterval)
+	p, err = setupPaperTrader(&config.PaperTrading{Enabled: true})
+	require.NoError(t, err)
+	assert.Equal(t, paperSlippageDepth, p.slippageModel, "depth should be the default slippage model")
+
+	_, err = SetupOrderManager(NewExchangeManager(), &CommunicationManager{}, &sync.WaitGroup{}, &config.OrderManager{PaperTrading: config.PaperTrading{Enabled: true, SlippageModel: "magic"}})
+	assert.ErrorIs(t, err, errInvalidPaperTradingConfig)
+}
+
+func TestPaperTraderMatch(t *testing.T) {
+	t.Parallel()
+	book := &orderbook.Base{
+		Bids: []orderbook.Item{{Price: 99, Amount: 1}, {Price: 98, Amount: 1}},
+		Asks: []orderbook.Item{{Price: 100, Amount: 1}, {Price: 101, Amount: 1}},
+	}
+	p := &paperTrader{slippageModel: paperSlippageDepth}
+
+	d := decimal.NewFromFloat
+	f := p.match(book, order.Buy, d(0), d(1.5), d(0), nil, false)
+	assert.Equal(t, "1.5", f.amount.String())
+	assert.Equal(t, "150.5", f.cost.String(), "market orders should walk the orderbook levels")
+	f = p.match(book, order.Sell, d(0), d(5), d(0), nil, false)
+	assert.Equal(t, "2", f.amount.String(), "fills should be limited by the orderbook's liquidity")
+	assert.Equal(t, "197", f.cost.String())
+	f = p.match(book, order.Buy, d(100), d(5), d(0), nil, false)
+	assert.Equal(t, "1", f.amount.String(), "limit orders should not fill beyond their price")
+	f = p.match(book, order.Buy, d(99), d(5), d(0), nil, false)
+	assert.True(t, f.amount.IsZero())
+	f = p.match(book, order.Buy, d(0), d(0), d(150.5), nil, false)
+	assert.Equal(t, "1.5", f.amount.String(), "quote amounts should limit the fill by cost")
+	f = p.match(book, order.Buy, d(0), d(0), d(100.1), nil, false)
+	assert.Equal(t, "100.1", f.cost.String(), "quote amounts should be spent exactly")
+	f = p.match(book, order.Buy, d(101), d(1), d(0), nil, true)
+	assert.Equal(t, "101", f.cost.String(), "makers should fill at their limit price")
+	f = p.match(book, order.Buy, d(0), d(1.5), d(0), map[float64]decimal.Decimal{100: d(0.75), 101: d(1)}, false)
+	assert.Equal(t, "0.25", f.amount.String(), "consumed liquidity should not be filled")
+	assert.Equal(t, map[float64]decimal.Decimal{100: d(0.25)}, f.levels, "the amount taken from each level should be recorded")
+
+	p.slippage = d(0.01)
+	f = p.match(book, order.Buy, d(0), d(1), d(0), nil, false)
+	assert.Equal(t, "101", f.cost.String(), "fill prices should slip against takers")
+	f = p.match(book, order.Sell, d(0), d(1), d(0), nil, false)
+	assert.Equal(t, "98.01", f.cost.String(), "fill prices should slip against takers")
+	f = p.match(book, order.Buy, d(100.5), d(1), d(0), nil, false)
+	assert.Equal(t, "100.5", f.cost.String(), "slippage should not move fill prices beyond the limit price")
+
+	p.slippage = decimal.Zero
+	p.slippageModel = paperSlippageFixed
+	f = p.match(book, order.Buy, d(0), d(5), d(0), nil, false)
+	assert.Equal(t, "5", f.amount.String(), "the fixed model should fill the whole amount")
+	assert.Equal(t, "500", f.cost.String(), "the fixed model should fill at the best price")
+	f = p.match(book, order.Sell, d(100), d(5), d(0), nil, false)
+	assert.True(t, f.amount.IsZero())
+}
+
+func TestPaperTradingSubmit(t *testing.T) {
+	t.Parallel()
+	m, book := paperTradingSetup(t)
+	// Each order is submitted against a fresh orderbook update so that its
+	// liquidity has not been consumed by the previous order
+	submit := func(s *order.Submit) (*OrderSubmitResponse, error) {
+		s.Exchange = testExchange
+		s.Pair = btcusdPair
+		s.AssetType = asset.Spot
+		book.reset()
+		return m.Submit(context.Background(), s)
+	}
+
+	resp, err := submit(&order.Submit{Side: order.Buy, Type: order.Market, Amount: 1.5})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(resp.OrderID, paperOrderIDPrefix), "paper orders should be identifiable")
+	assert.Equal(t, order.Filled, resp.Status)
+	assert.Equal(t, 1.5, resp.ExecutedAmount)
+	assert.InDelta(t, 150.5/1.5, resp.AverageExecutedPrice, 1e-9)
+	require.Len(t, resp.Trades, 1)
+	assert.False(t, resp.Trades[0].IsMaker)
+
+	resp, err = submit(&order.Submit{Side: order.Sell, Type: order.Market, Amount: 5})
+	require.NoError(t, err)
+	assert.Equal(t, order.PartiallyFilledCancelled, resp.Status, "the unfilled remainder of market orders should be cancelled")
+	assert.Equal(t, 2.0, resp.ExecutedAmount)
+
+	resp, err = submit(&order.Submit{Side: order.Buy, Type: order.Market, QuoteAmount: 100})
+	require.NoError(t, err)
+	assert.Equal(t, order.Filled, resp.Status)
+	assert.Equal(t, 1.0, resp.Amount, "quote amount orders should report the amount executed")
+
+	_, err = submit(&order.Submit{Side: order.Buy, Type: order.Limit, Price: 100, Amount: 1, PostOnly: true})
+	assert.ErrorIs(t, err, errPaperPostOnlyCrosses)
+
+	resp, err = submit(&order.Submit{Side: order.Buy, Type: order.Limit, Price: 100, Amount: 2, TimeInForce: order.IOC})
+	require.NoError(t, err)
+	assert.Equal(t, order.PartiallyFilledCancelled, resp.Status)
+	assert.Equal(t, 1.0, resp.ExecutedAmount)
+
+	resp, err = submit(&order.Submit{Side: order.Buy, Type: order.Limit, Price: 100, Amount: 2, TimeInForce: order.FOK})
+	require.NoError(t, err)
+	assert.Equal(t, order.Cancelled, resp.Status, "fill or kill orders should not be partially filled")
+	assert.Zero(t, resp.ExecutedAmount)
+
+	resting, err := submit(&order.Submit{Side: order.Buy, Type: order.Limit, Price: 100, Amount: 2})
+	require.NoError(t, err)
+	assert.Equal(t, order.PartiallyFilled, resting.Status, "the remainder of limit orders should rest")
+	assert.Equal(t, 1.0, resting.ExecutedAmount)
+
+	book.set(nil, []orderbook.Item{{Price: 99, Amount: 5}})
+	m.processPaperOrders()
+	d, err := m.GetOrderInfo(context.Background(), testExchange, resting.OrderID, btcusdPair, asset.Spot)
+	require.NoError(t, err)
+	assert.Equal(t, order.Filled, d.Status, "resting orders should be filled once the orderbook crosses them")
+	assert.Equal(t, 2.0, d.ExecutedAmount)
+	require.Len(t, d.Trades, 2)
+	assert.Equal(t, 100.0, d.Trades[1].Price, "resting orders should fill at their limit price")
+	assert.True(t, d.Trades[1].IsMaker)
+
+	m.paper.maxOrderbookAge = time.Minute
+	book.m.Lock()
+	book.book.LastUpdated = time.Now().Add(-time.Hour)
+	book.m.Unlock()
+	_, err = m.Submit(context.Background(), &order.Submit{Exchange: testExchange, Pair: btcusdPair, AssetType: asset.Spot, Side: order.Buy, Type: order.Market, Amount: 1})
+	assert.ErrorIs(t, err, errPaperOrderbookStale)
+}
+
+func TestPaperTradingModifyCancel(t *testing.T) {
+	t.Parallel()
+	m, _ := paperTradingSetup(t)
+	resting, err := m.Submit(context.Background(), &order.Submit{
+		Exchange:  testExchange,
+		Pair:      btcusdPair,
+		AssetType: asset.Spot,
+		Side:      order.Sell,
+		Type:      order.Limit,
+		Price:     110,
+		Amount:    1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, order.New, resting.Status)
+	assert.Zero(t, resting.ExecutedAmount)
+
+	_, err = m.Modify(context.Background(), &order.Modify{Exchange: testExchange, OrderID: resting.OrderID, Price: 105})
+	require.NoError(t, err)
+	d, err := m.GetOrderInfo(context.Background(), testExchange, resting.OrderID, btcusdPair, asset.Spot)
+	require.NoError(t, err)
+	assert.Equal(t, 105.0, d.Price)
+	assert.Equal(t, 1.0, d.Amount, "an unset amount should not be modified")
+
+	partial, err := m.Submit(context.Background(), &order.Submit{
+		Exchange:  testExchange,
+		Pair:      btcusdPair,
+		AssetType: asset.Spot,
+		Side:      order.Buy,
+		Type:      order.Limit,
+		Price:     100,
+		Amount:    2,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1.0, partial.ExecutedAmount)
+	_, err = m.Modify(context.Background(), &order.Modify{Exchange: testExchange, OrderID: partial.OrderID, Amount: 1})
+	assert.ErrorIs(t, err, errPaperAmountBelowExecuted)
+	_, err = m.Modify(context.Background(), &order.Modify{Exchange: testExchange, OrderID: partial.OrderID, Price: 99.5, Amount: 3})
+	require.NoError(t, err)
+	d, err = m.GetOrderInfo(context.Background(), testExchange, partial.OrderID, btcusdPair, asset.Spot)
+	require.NoError(t, err)
+	assert.Equal(t, 99.5, d.Price, "the modified price should be applied to the stored order")
+	assert.Equal(t, 3.0, d.Amount, "the modified amount should be applied to the stored order")
+	assert.Equal(t, 2.0, d.RemainingAmount, "the remaining amount should follow the modified amount")
+	assert.Equal(t, order.PartiallyFilled, d.Status)
+
+	results, err := m.CancelAllOrdersFiltered(context.Background(), &order.Filter{Exchange: testExchange})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.True(t, results[0].Cancelled)
+	assert.Equal(t, paperCancelEndpoint, results[0].Endpoint)
+	d, err = m.GetOrderInfo(context.Background(), testExchange, resting.OrderID, btcusdPair, asset.Spot)
+	require.NoError(t, err)
+	assert.Equal(t, order.Cancelled, d.Status)
+
+	err = m.Cancel(context.Background(), &order.Cancel{Exchange: testExchange, OrderID: resting.OrderID, Pair: btcusdPair, AssetType: asset.Spot})
+	assert.ErrorIs(t, err, errPaperOrderNotActive, "inactive paper orders should not be cancelled")
+	_, err = m.Modify(context.Background(), &order.Modify{Exchange: testExchange, OrderID: resting.OrderID, Price: 100})
+	assert.ErrorIs(t, err, errPaperOrderNotActive, "inactive paper orders should not be modified")
+}
+
+func TestPaperTradingDecimalFills(t *testing.T) {
+	t.Parallel()
+	m, book := paperTradingSetup(t)
+	book.set(nil, []orderbook.Item{{Price: 100, Amount: 0.1}})
+	resting, err := m.Submit(context.Background(), &order.Submit{
+		Exchange:  testExchange,
+		Pair:      btcusdPair,
+		AssetType: asset.Spot,
+		Side:      order.Buy,
+		Type:      order.Limit,
+		Price:     100,
+		Amount:    0.3,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, order.PartiallyFilled, resting.Status)
+	assert.Equal(t, 0.2, resting.RemainingAmount, "remaining amounts should not carry float rounding error")
+
+	book.set(nil, []orderbook.Item{{Price: 99, Amount: 0.2}})
+	m.processPaperOrders()
+	d, err := m.GetOrderInfo(context.Background(), testExchange, resting.OrderID, btcusdPair, asset.Spot)
+	require.NoError(t, err)
+	assert.Equal(t, order.Filled, d.Status, "fills summing exactly to the amount should fill the order")
+	assert.Equal(t, 0.3, d.ExecutedAmount, "executed amounts should not carry float rounding error")
+	assert.Zero(t, d.RemainingAmount)
+	assert.Equal(t, 30.0, d.Cost)
+	assert.Equal(t, 100.0, d.AverageExecutedPrice)
+}
+
+func TestPaperTradingConsumedDepth(t *testing.T) {
+	t.Parallel()
+	m, book := paperTradingSetup(t)
+	resting, err := m.Submit(context.Background(), &order.Submit{
+		Exchange:  testExchange,
+		Pair:      btcusdPair,
+		AssetType: asset.Spot,
+		Side:      order.Buy,
+		Type:      order.Limit,
+		Price:     99.5,
+		Amount:    3,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, order.New, resting.Status)
+
+	book.set(nil, []orderbook.Item{{Price: 99, Amount: 1}})
+	m.processPaperOrders()
+	m.processPaperOrders()
+	d, err := m.GetOrderInfo(context.Background(), testExchange, resting.OrderID, btcusdPair, asset.Spot)
+	require.NoError(t, err)
+	assert.Equal(t, order.PartiallyFilled, d.Status)
+	assert.Equal(t, 1.0, d.ExecutedAmount, "matching twice against an unchanged orderbook should not fill its liquidity twice")
+
+	taker, err := m.Submit(context.Background(), &order.Submit{
+		Exchange:  testExchange,
+		Pair:      btcusdPair,
+		AssetType: asset.Spot,
+		Side:      order.Buy,
+		Type:      order.Market,
+		Amount:    1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, order.Cancelled, taker.Status, "takers should not fill liquidity consumed by resting orders")
+	assert.Zero(t, taker.ExecutedAmount)
+
+	book.set(nil, []orderbook.Item{{Price: 99, Amount: 1}})
+	m.processPaperOrders()
+	d, err = m.GetOrderInfo(context.Background(), testExchange, resting.OrderID, btcusdPair, asset.Spot)
+	require.NoError(t, err)
+	assert.Equal(t, 2.0, d.ExecutedAmount, "an orderbook update should make its liquidity available again")
+}
@@ -0,0 +1,71 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/common/key"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/latency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+)
+
+// Slippage models used to derive paper trading fill prices
+const (
+	paperSlippageDepth = "depth"
+	paperSlippageFixed = "fixed"
+)
+
+const (
+	// paperOrderIDPrefix identifies orders simulated by paper trading
+	paperOrderIDPrefix = "paper-"
+	// paperCancelEndpoint is the cancel endpoint reported for paper orders
+	paperCancelEndpoint  = "paper"
+	defaultMatchInterval = time.Second
+)
+
+var (
+	errInvalidPaperTradingConfig = errors.New("invalid paper trading config")
+	errPaperOrderbookStale       = errors.New("paper trading orderbook is stale")
+	errPaperPostOnlyCrosses      = errors.New("post only order would cross the orderbook")
+	errPaperOrderNotActive       = errors.New("paper order is not active")
+	errPaperAmountBelowExecuted  = errors.New("paper order amount must exceed its executed amount")
+)
+
+// paperTrader simulates order execution against live orderbooks so that
+// orders are never sent to exchanges
+type paperTrader struct {
+	latency         *latency.Simulator
+	slippageModel   string
+	slippage        decimal.Decimal
+	matchInterval   time.Duration
+	maxOrderbookAge time.Duration
+	// getOrderbook returns the orderbook orders are matched against
+	getOrderbook func(exch string, p currency.Pair, a asset.Item) (*orderbook.Base, error)
+	// depth holds the liquidity consumed by paper fills from each orderbook
+	// since it was last updated, so the same liquidity is never filled twice
+	depth map[key.ExchangePairAsset]*paperDepth
+	// mtx serialises matching, modifying and cancelling paper orders so an
+	// order is never filled twice
+	mtx sync.Mutex
+}
+
+// paperDepth is the amount consumed from each price level of an orderbook
+// update by paper fills
+type paperDepth struct {
+	lastUpdated  time.Time
+	lastUpdateID int64
+	bids         map[float64]decimal.Decimal
+	asks         map[float64]decimal.Decimal
+}
+
+// paperFill is the result of matching an order against an orderbook
+type paperFill struct {
+	amount decimal.Decimal
+	cost   decimal.Decimal
+	// levels holds the amount taken from each orderbook level by price
+	levels map[float64]decimal.Decimal
+}
@@ -2,6 +2,7 @@ package engine
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"sync/atomic"
@@ -11,6 +12,7 @@ import (
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 	"github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/thrasher-corp/gocryptotrader/portfolio"
 )
@@ -21,6 +23,8 @@ const PortfolioManagerName = "portfolio"
 var (
 	// PortfolioSleepDelay defines the default sleep time between portfolio manager runs
 	PortfolioSleepDelay = time.Minute
+
+	errNoUSDPrice = errors.New("no USD price available")
 )
 
 // portfolioManager routinely retrieves a user's holdings through exchange APIs as well
@@ -52,6 +56,7 @@ func setupPortfolioManager(e *ExchangeManager, portfolioManagerDelay time.Durati
 		shutdown:              make(chan struct{}),
 		base:                  cfg,
 	}
+	cfg.SetUSDPricer(m.usdPrice)
 	return m, nil
 }
 
@@ -148,6 +153,9 @@ func (m *portfolioManager) processPortfolio() {
 			key,
 			value)
 	}
+	if err := m.base.UpdateChainAddresses(context.TODO()); err != nil {
+		log.Errorf(log.PortfolioMgr, "Portfolio manager chain address error %s\n", err)
+	}
 	atomic.CompareAndSwapInt32(&m.processing, 1, 0)
 }
 
@@ -293,6 +301,20 @@ func (m *portfolioManager) AddAddress(address, description string, coinType curr
 	return m.base.AddAddress(address, description, coinType, balance)
 }
 
+// AddChainAddress adds an address whose native and token balances are fetched
+// from a chain such as solana, tron, arbitrum or base
+func (m *portfolioManager) AddChainAddress(address, chain, description string, coinType currency.Code) error {
+	if m == nil {
+		return fmt.Errorf("portfolio manager %w", ErrNilSubsystem)
+	}
+	if !m.IsRunning() {
+		return fmt.Errorf("portfolio manager %w", ErrSubSystemNotStarted)
+	}
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.base.AddChainAddress(address, chain, description, coinType)
+}
+
 // RemoveAddress removes a portfolio address
 func (m *portfolioManager) RemoveAddress(address, description string, coinType currency.Code) error {
 	if m == nil {
@@ -306,6 +328,19 @@ func (m *portfolioManager) RemoveAddress(address, description string, coinType c
 	return m.base.RemoveAddress(address, description, coinType)
 }
 
+// SetEarnBalances replaces the earn and staking balances of an exchange
+func (m *portfolioManager) SetEarnBalances(exchangeName string, balances map[currency.Code]float64) error {
+	if m == nil {
+		return fmt.Errorf("portfolio manager %w", ErrNilSubsystem)
+	}
+	if !m.IsRunning() {
+		return fmt.Errorf("portfolio manager %w", ErrSubSystemNotStarted)
+	}
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.base.SetEarnBalances(exchangeName, balances)
+}
+
 // GetPortfolioSummary returns a summary of all portfolio holdings
 func (m *portfolioManager) GetPortfolioSummary() portfolio.Summary {
 	if m == nil || !m.IsRunning() {
@@ -347,3 +382,38 @@ func (m *portfolioManager) IsExchangeSupported(exchange, address string) bool {
 	}
 	return m.base.IsExchangeSupported(exchange, address)
 }
+
+// usdPrice returns the USD price of a coin from the last ticker price of the
+// first exchange trading it against USD or a USD stablecoin. Fiat currencies
+// without a ticker are converted using the forex provider and stablecoins are
+// valued at 1
+func (m *portfolioManager) usdPrice(c currency.Code) (float64, error) {
+	if c.Equal(currency.USD) {
+		return 1, nil
+	}
+	exchanges, err := m.exchangeManager.GetExchanges()
+	if err != nil {
+		return 0, err
+	}
+	for _, quote := range []currency.Code{currency.USD, currency.USDT, currency.USDC} {
+		if c.Equal(quote) {
+			continue
+		}
+		p := currency.NewPair(c, quote)
+		for i := range exchanges {
+			t, err := ticker.GetTicker(exchanges[i].GetName(), p, asset.Spot)
+			if err == nil && t.Last > 0 {
+				return t.Last, nil
+			}
+		}
+	}
+	if c.IsStableCurrency() || c.Equal(currency.USDT) || c.Equal(currency.USDC) {
+		return 1, nil
+	}
+	if c.IsFiatCurrency() {
+		if rate, err := currency.ConvertFiat(1, c, currency.USD); err == nil && rate > 0 {
+			return rate, nil
+		}
+	}
+	return 0, fmt.Errorf("%w for %s", errNoUSDPrice, c)
+}
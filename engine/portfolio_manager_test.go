@@ -4,6 +4,13 @@ import (
 	"errors"
 	"sync"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/portfolio"
 )
 
 func TestSetupPortfolioManager(t *testing.T) {
@@ -118,3 +125,39 @@ func TestProcessPortfolio(t *testing.T) {
 
 	m.processPortfolio()
 }
+
+func TestPortfolioUSDPrice(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	exch, err := em.NewExchangeByName("Bitstamp")
+	require.NoError(t, err)
+	exch.SetDefaults()
+	require.NoError(t, em.Add(exch))
+	m, err := setupPortfolioManager(em, 0, nil)
+	require.NoError(t, err)
+
+	price, err := m.usdPrice(currency.USD)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, price)
+
+	coin := currency.NewCode("PORTFOLIOUSDTEST")
+	_, err = m.usdPrice(coin)
+	assert.ErrorIs(t, err, errNoUSDPrice)
+
+	require.NoError(t, ticker.ProcessTicker(&ticker.Price{
+		ExchangeName: exch.GetName(),
+		Pair:         currency.NewPair(coin, currency.USDT),
+		AssetType:    asset.Spot,
+		Last:         42,
+	}))
+	price, err = m.usdPrice(coin)
+	require.NoError(t, err)
+	assert.Equal(t, 42.0, price, "USD stablecoin quoted tickers should be used")
+
+	price, err = m.usdPrice(currency.USDC)
+	require.NoError(t, err)
+	assert.NotZero(t, price, "USD stablecoins should be priced")
+
+	m.base.Addresses = append(m.base.Addresses, portfolio.Address{Address: "Bitstamp", CoinType: coin, Balance: 2, Description: portfolio.ExchangeAddress})
+	assert.Equal(t, 84.0, m.base.GetPortfolioSummary().TotalUSDValue, "summaries should be valued in USD")
+}
@@ -0,0 +1,347 @@
+package engine
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/file"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const (
+	cpuProfile                = "cpu"
+	profilesDir               = "profiles"
+	defaultCPUProfileDuration = time.Second * 10
+	maxCPUProfileDuration     = time.Minute * 5
+	profileTimeFormat         = "20060102-150405.000"
+)
+
+var (
+	errProfilerDisabled       = errors.New("profiler is disabled")
+	errUnsupportedProfile     = errors.New("unsupported profile")
+	errInvalidProfileDuration = errors.New("invalid profile duration")
+	errMalformedProfile       = errors.New("malformed profile")
+)
+
+// ProfileCapture holds the details of a saved runtime profile
+type ProfileCapture struct {
+	Kind string
+	Path string
+	// FlamegraphPath is the folded stack file which can be loaded by
+	// flamegraph tools such as speedscope or flamegraph.pl
+	FlamegraphPath string
+	Size           int64
+	Duration       time.Duration
+	Captured       time.Time
+}
+
+// CaptureProfile captures a runtime profile and saves it to the directory in
+// the pprof format. The cpu profile samples for the duration, all other
+// profiles are snapshots. A folded stack file for flamegraph tools is saved
+// alongside the profile when flamegraph is set
+func CaptureProfile(ctx context.Context, dir, kind string, duration time.Duration, flamegraph bool) (*ProfileCapture, error) {
+	kind = strings.ToLower(kind)
+	if kind != cpuProfile && pprof.Lookup(kind) == nil {
+		return nil, fmt.Errorf("%w %q", errUnsupportedProfile, kind)
+	}
+	if kind == cpuProfile {
+		if duration == 0 {
+			duration = defaultCPUProfileDuration
+		}
+		if duration < 0 || duration > maxCPUProfileDuration {
+			return nil, fmt.Errorf("%w %s, must be up to %s", errInvalidProfileDuration, duration, maxCPUProfileDuration)
+		}
+	} else {
+		duration = 0
+	}
+
+	captured := time.Now()
+	path := filepath.Join(dir, kind+"-"+captured.UTC().Format(profileTimeFormat)+".pb.gz")
+	f, err := file.Writer(path)
+	if err != nil {
+		return nil, err
+	}
+	err = writeProfile(ctx, f, kind, duration)
+	if closeErr := f.Close(); closeErr != nil {
+		err = errors.Join(err, closeErr)
+	}
+	if err != nil {
+		return nil, errors.Join(err, os.Remove(path))
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	p := &ProfileCapture{
+		Kind:     kind,
+		Path:     path,
+		Size:     info.Size(),
+		Duration: duration,
+		Captured: captured,
+	}
+	if flamegraph {
+		p.FlamegraphPath = strings.TrimSuffix(path, ".pb.gz") + ".folded"
+		if err := writeFoldedProfile(path, p.FlamegraphPath); err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// writeProfile writes the profile to w, sampling cpu usage for the duration
+func writeProfile(ctx context.Context, w io.Writer, kind string, duration time.Duration) error {
+	if kind != cpuProfile {
+		if kind == "heap" {
+			// Ensures in use statistics are up to date
+			runtime.GC()
+		}
+		return pprof.Lookup(kind).WriteTo(w, 0)
+	}
+	if err := pprof.StartCPUProfile(w); err != nil {
+		return err
+	}
+	t := time.NewTimer(duration)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		pprof.StopCPUProfile()
+		return nil
+	case <-ctx.Done():
+		pprof.StopCPUProfile()
+		return ctx.Err()
+	}
+}
+
+// writeFoldedProfile converts a pprof profile to folded stacks
+func writeFoldedProfile(profilePath, foldedPath string) error {
+	data, err := os.ReadFile(profilePath)
+	if err != nil {
+		return err
+	}
+	f, err := file.Writer(foldedPath)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	err = foldProfile(data, w)
+	if err == nil {
+		err = w.Flush()
+	}
+	if closeErr := f.Close(); closeErr != nil {
+		err = errors.Join(err, closeErr)
+	}
+	return err
+}
+
+// foldProfile writes a pprof profile as folded stacks, one line per unique
+// stack listing its functions from root to leaf separated by semicolons and
+// followed by the summed sample value. The default sample type is used,
+// falling back to the last sample type as the pprof tool does
+func foldProfile(data []byte, w io.Writer) error {
+	if len(data) > 1 && data[0] == 0x1f && data[1] == 0x8b {
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		if data, err = io.ReadAll(gz); err != nil {
+			return err
+		}
+	}
+
+	var (
+		strs              []string
+		sampleTypes       []int64
+		defaultSampleType int64
+		samples           [][]byte
+		locations         = make(map[uint64][]uint64)
+		functions         = make(map[uint64]int64)
+	)
+	err := protoFields(data, func(num protowire.Number, _ protowire.Type, v uint64, b []byte) error {
+		switch num {
+		case 1: // sample_type
+			return protoFields(b, func(num protowire.Number, _ protowire.Type, v uint64, _ []byte) error {
+				if num == 1 {
+					sampleTypes = append(sampleTypes, int64(v))
+				}
+				return nil
+			})
+		case 2: // sample
+			samples = append(samples, b)
+		case 4: // location
+			var id uint64
+			var funcs []uint64
+			if err := protoFields(b, func(num protowire.Number, _ protowire.Type, v uint64, b []byte) error {
+				switch num {
+				case 1:
+					id = v
+				case 4: // line, inlined functions are listed before their callers
+					return protoFields(b, func(num protowire.Number, _ protowire.Type, v uint64, _ []byte) error {
+						if num == 1 {
+							funcs = append(funcs, v)
+						}
+						return nil
+					})
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+			locations[id] = funcs
+		case 5: // function
+			var id uint64
+			var name int64
+			if err := protoFields(b, func(num protowire.Number, _ protowire.Type, v uint64, _ []byte) error {
+				switch num {
+				case 1:
+					id = v
+				case 2:
+					name = int64(v)
+				}
+				return nil
+			}); err != nil {
+				return err
+			}
+			functions[id] = name
+		case 6: // string_table
+			strs = append(strs, string(b))
+		case 14: // default_sample_type
+			defaultSampleType = int64(v)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(sampleTypes) == 0 {
+		return fmt.Errorf("%w: no sample types", errMalformedProfile)
+	}
+
+	valueIndex := len(sampleTypes) - 1
+	if defaultSampleType != 0 {
+		for i := range sampleTypes {
+			if sampleTypes[i] == defaultSampleType {
+				valueIndex = i
+			}
+		}
+	}
+
+	funcName := func(id uint64) string {
+		if idx, ok := functions[id]; ok && idx >= 0 && idx < int64(len(strs)) && strs[idx] != "" {
+			return strs[idx]
+		}
+		return "unknown"
+	}
+
+	stacks := make(map[string]int64)
+	for i := range samples {
+		var locIDs []uint64
+		var values []int64
+		if err := protoFields(samples[i], func(num protowire.Number, typ protowire.Type, v uint64, b []byte) error {
+			switch num {
+			case 1:
+				var err error
+				locIDs, err = appendPackedVarints(locIDs, typ, v, b)
+				return err
+			case 2:
+				vals, err := appendPackedVarints(nil, typ, v, b)
+				for j := range vals {
+					values = append(values, int64(vals[j]))
+				}
+				return err
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+		if valueIndex >= len(values) || values[valueIndex] == 0 {
+			continue
+		}
+		// Locations are ordered from leaf to root
+		var frames []string
+		for j := len(locIDs) - 1; j >= 0; j-- {
+			funcs := locations[locIDs[j]]
+			for k := len(funcs) - 1; k >= 0; k-- {
+				frames = append(frames, funcName(funcs[k]))
+			}
+		}
+		if len(frames) == 0 {
+			continue
+		}
+		stacks[strings.Join(frames, ";")] += values[valueIndex]
+	}
+
+	keys := make([]string, 0, len(stacks))
+	for k := range stacks {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if _, err := io.WriteString(w, k+" "+strconv.FormatInt(stacks[k], 10)+"\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// protoFields calls fn for each field of an encoded protobuf message, varint
+// values are passed as v and length delimited values as b
+func protoFields(data []byte, fn func(num protowire.Number, typ protowire.Type, v uint64, b []byte) error) error {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return fmt.Errorf("%w: %w", errMalformedProfile, protowire.ParseError(n))
+		}
+		data = data[n:]
+		var v uint64
+		var b []byte
+		switch typ {
+		case protowire.VarintType:
+			v, n = protowire.ConsumeVarint(data)
+		case protowire.BytesType:
+			b, n = protowire.ConsumeBytes(data)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, data)
+		}
+		if n < 0 {
+			return fmt.Errorf("%w: %w", errMalformedProfile, protowire.ParseError(n))
+		}
+		data = data[n:]
+		if typ != protowire.VarintType && typ != protowire.BytesType {
+			continue
+		}
+		if err := fn(num, typ, v, b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendPackedVarints appends a repeated varint field which may be packed
+func appendPackedVarints(dst []uint64, typ protowire.Type, v uint64, b []byte) ([]uint64, error) {
+	if typ == protowire.VarintType {
+		return append(dst, v), nil
+	}
+	for len(b) > 0 {
+		v, n := protowire.ConsumeVarint(b)
+		if n < 0 {
+			return dst, fmt.Errorf("%w: %w", errMalformedProfile, protowire.ParseError(n))
+		}
+		dst = append(dst, v)
+		b = b[n:]
+	}
+	return dst, nil
+}
@@ -0,0 +1,65 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCaptureProfile(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	_, err := CaptureProfile(context.Background(), dir, "fake", 0, false)
+	assert.ErrorIs(t, err, errUnsupportedProfile)
+	_, err = CaptureProfile(context.Background(), dir, cpuProfile, -time.Second, false)
+	assert.ErrorIs(t, err, errInvalidProfileDuration)
+	_, err = CaptureProfile(context.Background(), dir, cpuProfile, maxCPUProfileDuration+time.Second, false)
+	assert.ErrorIs(t, err, errInvalidProfileDuration)
+
+	p, err := CaptureProfile(context.Background(), dir, "Goroutine", time.Minute, true)
+	require.NoError(t, err)
+	assert.Equal(t, "goroutine", p.Kind)
+	assert.Zero(t, p.Duration, "snapshot profiles should not have a duration")
+	assert.Positive(t, p.Size)
+	assert.FileExists(t, p.Path)
+	folded, err := os.ReadFile(p.FlamegraphPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(folded), "engine.TestCaptureProfile", "folded stacks should contain the test goroutine")
+
+	p, err = CaptureProfile(context.Background(), dir, cpuProfile, time.Millisecond*50, false)
+	require.NoError(t, err)
+	assert.Equal(t, time.Millisecond*50, p.Duration)
+	assert.FileExists(t, p.Path)
+	assert.Empty(t, p.FlamegraphPath)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = CaptureProfile(ctx, dir, cpuProfile, time.Minute, false)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestFoldProfile(t *testing.T) {
+	t.Parallel()
+	var w bytes.Buffer
+	assert.ErrorIs(t, foldProfile([]byte{0xff}, &w), errMalformedProfile)
+	assert.ErrorIs(t, foldProfile(nil, &w), errMalformedProfile, "profiles without sample types should error")
+
+	p, err := CaptureProfile(context.Background(), t.TempDir(), "goroutine", 0, false)
+	require.NoError(t, err)
+	data, err := os.ReadFile(p.Path)
+	require.NoError(t, err)
+	require.NoError(t, foldProfile(data, &w))
+	for _, line := range strings.Split(strings.TrimSpace(w.String()), "\n") {
+		stack, value, ok := strings.Cut(line, " ")
+		require.True(t, ok, "each line should contain a stack and value")
+		assert.NotEmpty(t, stack)
+		assert.NotEqual(t, "0", value, "stacks without samples should be skipped")
+	}
+	assert.Contains(t, w.String(), "testing.tRunner;github.com/thrasher-corp/gocryptotrader/engine.TestFoldProfile;", "stacks should be ordered from root to leaf")
+}
@@ -0,0 +1,354 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/calendar"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// SetupRolloverManager creates a rollover manager subsystem. The exchange
+// calendar is optional, when set rollovers are deferred during exchange
+// maintenance and positions are rolled ahead of scheduled delistings. The
+// market data quarantine is optional, when set rollovers are deferred while
+// either contract's market data is quarantined
+func SetupRolloverManager(cfg *config.RolloverManager, em iExchangeManager, om iRolloverOrderManager, comms iCommsManager, cal iExchangeCalendar, q iMarketDataQuarantine) (*RolloverManager, error) {
+	if cfg == nil {
+		return nil, errNilConfig
+	}
+	if em == nil {
+		return nil, errNilExchangeManager
+	}
+	if om == nil {
+		return nil, errNilOrderManager
+	}
+	if comms == nil {
+		return nil, errNilCommunicationsManager
+	}
+	if cfg.CheckInterval <= 0 {
+		return nil, fmt.Errorf("%w check interval %v", errInvalidRolloverDuration, cfg.CheckInterval)
+	}
+	if cfg.RolloverWindow <= 0 {
+		return nil, fmt.Errorf("%w rollover window %v", errInvalidRolloverDuration, cfg.RolloverWindow)
+	}
+	if cfg.MaxSpread <= 0 {
+		return nil, fmt.Errorf("%w %v", errInvalidRolloverSpread, cfg.MaxSpread)
+	}
+	return &RolloverManager{
+		verbose:         cfg.Verbose,
+		dryRun:          cfg.DryRun == nil || *cfg.DryRun,
+		interval:        cfg.CheckInterval,
+		window:          cfg.RolloverWindow,
+		maxSpread:       cfg.MaxSpread,
+		exchangeManager: em,
+		orderManager:    om,
+		comms:           comms,
+		calendar:        cal,
+		quarantine:      q,
+		reported:        make(map[string]struct{}),
+	}, nil
+}
+
+// Start runs the subsystem
+func (m *RolloverManager) Start() error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 0, 1) {
+		return ErrSubSystemAlreadyStarted
+	}
+	m.shutdown = make(chan struct{})
+	m.wg.Add(1)
+	go m.run()
+	if m.dryRun {
+		log.Infoln(log.OrderMgr, "Rollover manager running in dry run mode, no orders will be placed")
+	}
+	log.Debugf(log.OrderMgr, "Rollover manager %s", MsgSubSystemStarted)
+	return nil
+}
+
+// IsRunning checks whether the subsystem is running
+func (m *RolloverManager) IsRunning() bool {
+	if m == nil {
+		return false
+	}
+	return atomic.LoadInt32(&m.started) == 1
+}
+
+// Stop stops the subsystem
+func (m *RolloverManager) Stop() error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 1, 0) {
+		return ErrSubSystemNotStarted
+	}
+	close(m.shutdown)
+	m.wg.Wait()
+	log.Debugf(log.OrderMgr, "Rollover manager %s", MsgSubSystemShutdown)
+	return nil
+}
+
+func (m *RolloverManager) run() {
+	defer m.wg.Done()
+	runScheduledJobs(m.shutdown, &scheduledJob{interval: m.interval, immediate: true, run: func(ctx context.Context, _ time.Time) { m.checkPositions(ctx) }})
+}
+
+// checkPositions rolls all open positions in contracts which expire within the
+// rollover window
+func (m *RolloverManager) checkPositions(ctx context.Context) {
+	positions, err := m.orderManager.GetAllOpenFuturesPositions()
+	if err != nil {
+		log.Errorf(log.OrderMgr, "Rollover manager cannot get open positions: %v", err)
+		return
+	}
+	contracts := make(map[string][]futures.Contract)
+	now := time.Now()
+	for i := range positions {
+		if maintenance := m.activeMaintenance(positions[i].Exchange, now); maintenance != nil {
+			m.reportOnce(positions[i].Exchange, positions[i].Asset, maintenance.Key(),
+				"Rollover manager deferring rollovers during "+eventSummary(maintenance))
+			continue
+		}
+		exch, err := m.exchangeManager.GetExchangeByName(positions[i].Exchange)
+		if err != nil {
+			log.Errorf(log.OrderMgr, "Rollover manager %v", err)
+			continue
+		}
+		k := positions[i].Exchange + positions[i].Asset.String()
+		cs, ok := contracts[k]
+		if !ok {
+			cs, err = exch.GetFuturesContractDetails(ctx, positions[i].Asset)
+			if err != nil {
+				log.Errorf(log.OrderMgr, "Rollover manager cannot get %s %s contract details: %v", positions[i].Exchange, positions[i].Asset, err)
+				continue
+			}
+			contracts[k] = cs
+		}
+		plan, err := m.planRollover(ctx, exch, &positions[i], cs, now)
+		if err != nil {
+			m.reportOnce(positions[i].Exchange, positions[i].Asset, positions[i].Pair.String()+err.Error(),
+				fmt.Sprintf("Rollover manager unable to roll %s %s %s: %v", positions[i].Exchange, positions[i].Asset, positions[i].Pair, err))
+			continue
+		}
+		if plan == nil {
+			continue
+		}
+		if m.quarantine != nil &&
+			(m.quarantine.IsQuarantined(plan.Exchange, plan.Asset, plan.Near) || m.quarantine.IsQuarantined(plan.Exchange, plan.Asset, plan.Far)) {
+			m.reportOnce(plan.Exchange, plan.Asset, plan.Near.String()+"quarantine",
+				fmt.Sprintf("Rollover manager deferring %s %s %s rollover while market data is quarantined", plan.Exchange, plan.Asset, plan.Near))
+			continue
+		}
+		if err := m.executeRollover(ctx, plan); err != nil {
+			msg := fmt.Sprintf("Rollover manager failed to roll %s %s %s to %s: %v", plan.Exchange, plan.Asset, plan.Near, plan.Far, err)
+			log.Errorln(log.OrderMgr, msg)
+			m.comms.PushEvent(base.Event{Type: rolloverEventType, Message: msg, Exchange: plan.Exchange})
+		}
+	}
+}
+
+// planRollover returns a rollover plan for a position when its contract
+// expires within the rollover window, or nil when no rollover is due
+func (m *RolloverManager) planRollover(ctx context.Context, exch exchange.IBotExchange, pos *futures.Position, contracts []futures.Contract, now time.Time) (*RolloverPlan, error) {
+	var near *futures.Contract
+	for i := range contracts {
+		if contracts[i].Name.Equal(pos.Pair) {
+			near = &contracts[i]
+			break
+		}
+	}
+	if near == nil {
+		return nil, nil
+	}
+	expiry := m.nearExpiry(pos, near)
+	if expiry.IsZero() || !expiry.After(now) || expiry.Sub(now) > m.window {
+		return nil, nil
+	}
+	if pos.LatestSize.IsZero() {
+		return nil, nil
+	}
+	var far *futures.Contract
+	for i := range contracts {
+		if !contracts[i].IsActive ||
+			!contracts[i].EndDate.After(expiry) ||
+			!contracts[i].Underlying.Equal(near.Underlying) ||
+			contracts[i].SettlementType != near.SettlementType {
+			continue
+		}
+		if far == nil || contracts[i].EndDate.Before(far.EndDate) {
+			far = &contracts[i]
+		}
+	}
+	if far == nil {
+		return nil, errNoRolloverContract
+	}
+	nearPrice, err := rolloverPrice(ctx, exch, near.Name, pos.Asset)
+	if err != nil {
+		return nil, err
+	}
+	farPrice, err := rolloverPrice(ctx, exch, far.Name, pos.Asset)
+	if err != nil {
+		return nil, err
+	}
+	plan := &RolloverPlan{
+		Exchange:   pos.Exchange,
+		Asset:      pos.Asset,
+		Near:       near.Name,
+		Far:        far.Name,
+		NearExpiry: expiry,
+		FarExpiry:  far.EndDate,
+		Side:       pos.LatestDirection,
+		Amount:     pos.LatestSize.Abs().InexactFloat64(),
+		NearPrice:  nearPrice,
+		FarPrice:   farPrice,
+		Spread:     (farPrice - nearPrice) / nearPrice,
+	}
+	if math.Abs(plan.Spread) > m.maxSpread {
+		return nil, fmt.Errorf("%w %s to %s spread %.4f max %.4f", errRolloverSpreadExceeded, plan.Near, plan.Far, plan.Spread, m.maxSpread)
+	}
+	return plan, nil
+}
+
+// executeRollover closes the near leg and opens the far leg of a plan. In dry
+// run mode the plan is reported without placing orders
+func (m *RolloverManager) executeRollover(ctx context.Context, plan *RolloverPlan) error {
+	summary := fmt.Sprintf("%s %s %s %v %s expiring %s to %s expiring %s at spread %.4f",
+		plan.Exchange,
+		plan.Asset,
+		plan.Side,
+		plan.Amount,
+		plan.Near,
+		plan.NearExpiry.UTC().Format(time.RFC3339),
+		plan.Far,
+		plan.FarExpiry.UTC().Format(time.RFC3339),
+		plan.Spread)
+	if m.dryRun {
+		m.reportOnce(plan.Exchange, plan.Asset, plan.Near.String()+plan.Far.String(), "Rollover manager dry run would roll "+summary)
+		return nil
+	}
+	closeSide, openSide := order.Sell, order.Buy
+	if plan.Side.IsShort() {
+		closeSide, openSide = order.Buy, order.Sell
+	}
+	_, err := m.orderManager.Submit(ctx, &order.Submit{
+		Exchange:   plan.Exchange,
+		Pair:       plan.Near,
+		AssetType:  plan.Asset,
+		Side:       closeSide,
+		Type:       order.Market,
+		Amount:     plan.Amount,
+		ReduceOnly: true,
+	})
+	if err != nil {
+		return fmt.Errorf("closing near leg: %w", err)
+	}
+	_, err = m.orderManager.Submit(ctx, &order.Submit{
+		Exchange:  plan.Exchange,
+		Pair:      plan.Far,
+		AssetType: plan.Asset,
+		Side:      openSide,
+		Type:      order.Market,
+		Amount:    plan.Amount,
+	})
+	if err != nil {
+		return fmt.Errorf("near leg closed but opening far leg failed: %w", err)
+	}
+	msg := "Rollover manager rolled " + summary
+	log.Infoln(log.OrderMgr, msg)
+	m.comms.PushEvent(base.Event{Type: rolloverEventType, Message: msg, Exchange: plan.Exchange})
+	return nil
+}
+
+// reportOnce logs and sends a rollover message the first time it occurs
+func (m *RolloverManager) reportOnce(exchName string, a asset.Item, id, msg string) {
+	id = exchName + a.String() + id
+	if _, ok := m.reported[id]; ok {
+		if m.verbose {
+			log.Debugln(log.OrderMgr, msg)
+		}
+		return
+	}
+	m.reported[id] = struct{}{}
+	log.Warnln(log.OrderMgr, msg)
+	m.comms.PushEvent(base.Event{Type: rolloverEventType, Message: msg, Exchange: exchName})
+}
+
+// nearExpiry returns when a position's contract stops trading, being the
+// earlier of its expiry and any delisting scheduled in the exchange calendar
+func (m *RolloverManager) nearExpiry(pos *futures.Position, near *futures.Contract) time.Time {
+	expiry := near.EndDate
+	if m.calendar == nil {
+		return expiry
+	}
+	delistings, err := m.calendar.GetEvents(&calendar.Filter{
+		Exchange: pos.Exchange,
+		Asset:    pos.Asset,
+		Pair:     pos.Pair,
+		Types:    []calendar.EventType{calendar.Delisting},
+	})
+	if err != nil {
+		if m.verbose {
+			log.Debugf(log.OrderMgr, "Rollover manager cannot get scheduled delistings: %v", err)
+		}
+		return expiry
+	}
+	for i := range delistings {
+		if delistings[i].Pair.IsEmpty() {
+			continue
+		}
+		if expiry.IsZero() || delistings[i].Start.Before(expiry) {
+			expiry = delistings[i].Start
+		}
+	}
+	return expiry
+}
+
+// activeMaintenance returns the exchange calendar maintenance window in
+// progress on an exchange, or nil when there is none
+func (m *RolloverManager) activeMaintenance(exchName string, now time.Time) *calendar.Event {
+	if m.calendar == nil {
+		return nil
+	}
+	windows, err := m.calendar.GetEvents(&calendar.Filter{
+		Exchange: exchName,
+		Types:    []calendar.EventType{calendar.Maintenance},
+		Start:    now,
+		End:      now,
+	})
+	if err != nil {
+		if m.verbose {
+			log.Debugf(log.OrderMgr, "Rollover manager cannot get scheduled maintenance: %v", err)
+		}
+		return nil
+	}
+	for i := range windows {
+		if windows[i].Active(now) {
+			return &windows[i]
+		}
+	}
+	return nil
+}
+
+// rolloverPrice returns the last traded price of a contract
+func rolloverPrice(ctx context.Context, exch exchange.IBotExchange, p currency.Pair, a asset.Item) (float64, error) {
+	t, err := exch.FetchTicker(ctx, p, a)
+	if err != nil {
+		return 0, err
+	}
+	if t.Last <= 0 {
+		return 0, fmt.Errorf("%w %s", errRolloverPriceUnset, p)
+	}
+	return t.Last, nil
+}
@@ -0,0 +1,264 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/common/convert"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/calendar"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+)
+
+// rolloverExchange is a fake exchange with contract details and prices
+type rolloverExchange struct {
+	exchange.IBotExchange
+	contracts []futures.Contract
+	prices    map[string]float64
+}
+
+func (f *rolloverExchange) GetName() string {
+	return "rollover"
+}
+
+func (f *rolloverExchange) GetFuturesContractDetails(context.Context, asset.Item) ([]futures.Contract, error) {
+	return f.contracts, nil
+}
+
+func (f *rolloverExchange) FetchTicker(_ context.Context, p currency.Pair, a asset.Item) (*ticker.Price, error) {
+	return &ticker.Price{Pair: p, AssetType: a, Last: f.prices[p.String()]}, nil
+}
+
+func testRolloverSetup(t *testing.T, dryRun bool) (*RolloverManager, *rolloverExchange, *fakeFuturesOrderManager, *fakeComms) {
+	t.Helper()
+	now := time.Now()
+	underlying := currency.NewPair(currency.BTC, currency.USD)
+	near := currency.NewPair(currency.BTC, currency.NewCode("USD_NEAR"))
+	next := currency.NewPair(currency.BTC, currency.NewCode("USD_NEXT"))
+	later := currency.NewPair(currency.BTC, currency.NewCode("USD_LATER"))
+	exch := &rolloverExchange{
+		contracts: []futures.Contract{
+			{Name: later, Underlying: underlying, EndDate: now.Add(time.Hour * 24 * 180), IsActive: true},
+			{Name: near, Underlying: underlying, EndDate: now.Add(time.Hour), IsActive: true},
+			{Name: next, Underlying: underlying, EndDate: now.Add(time.Hour * 24 * 90), IsActive: true},
+		},
+		prices: map[string]float64{near.String(): 100, next.String(): 101, later.String(): 110},
+	}
+	om := &fakeFuturesOrderManager{
+		positions: []futures.Position{{
+			Exchange:        "rollover",
+			Asset:           asset.Futures,
+			Pair:            near,
+			LatestDirection: order.Short,
+			LatestSize:      decimal.NewFromInt(2),
+		}},
+	}
+	comms := &fakeComms{}
+	m, err := SetupRolloverManager(&config.RolloverManager{
+		DryRun:         convert.BoolPtr(dryRun),
+		CheckInterval:  time.Minute,
+		RolloverWindow: time.Hour * 24,
+		MaxSpread:      0.02,
+	}, testExchangeManager(t, exch), om, comms, nil, nil)
+	require.NoError(t, err, "SetupRolloverManager must not error")
+	return m, exch, om, comms
+}
+
+func TestSetupRolloverManager(t *testing.T) {
+	t.Parallel()
+	_, err := SetupRolloverManager(nil, nil, nil, nil, nil, nil)
+	assert.ErrorIs(t, err, errNilConfig)
+	_, err = SetupRolloverManager(&config.RolloverManager{CheckInterval: time.Minute, RolloverWindow: time.Hour}, NewExchangeManager(), &fakeFuturesOrderManager{}, &fakeComms{}, nil, nil)
+	assert.ErrorIs(t, err, errInvalidRolloverSpread)
+	m, err := SetupRolloverManager(&config.RolloverManager{CheckInterval: time.Minute, RolloverWindow: time.Hour, MaxSpread: 0.01}, NewExchangeManager(), &fakeFuturesOrderManager{}, &fakeComms{}, nil, nil)
+	require.NoError(t, err)
+	assert.True(t, m.dryRun, "dry run should default to true")
+}
+
+func TestRolloverManagerStartStop(t *testing.T) {
+	t.Parallel()
+	m, _, _, _ := testRolloverSetup(t, true)
+	testStartStop(t, (*RolloverManager)(nil), m)
+}
+
+func TestPlanRollover(t *testing.T) {
+	t.Parallel()
+	m, exch, om, _ := testRolloverSetup(t, true)
+	now := time.Now()
+	pos := &om.positions[0]
+	plan, err := m.planRollover(context.Background(), exch, pos, exch.contracts, now)
+	require.NoError(t, err)
+	require.NotNil(t, plan)
+	assert.Equal(t, exch.contracts[2].Name, plan.Far, "the nearest later expiry should be chosen")
+	assert.Equal(t, 2.0, plan.Amount)
+	assert.InDelta(t, 0.01, plan.Spread, 1e-9)
+
+}
+
+func TestCheckPositionsDryRun(t *testing.T) {
+	t.Parallel()
+	m, _, om, comms := testRolloverSetup(t, true)
+	m.checkPositions(context.Background())
+	m.checkPositions(context.Background())
+	assert.Empty(t, om.submitted, "dry run should not place orders")
+	require.Len(t, comms.events, 1, "dry run rollovers should only be reported once")
+	assert.Equal(t, rolloverEventType, comms.events[0].Type)
+	assert.Contains(t, comms.events[0].Message, "dry run")
+}
+
+func TestCheckPositionsRollover(t *testing.T) {
+	t.Parallel()
+	m, exch, om, comms := testRolloverSetup(t, false)
+	m.checkPositions(context.Background())
+	require.Len(t, om.submitted, 2, "near and far legs must be submitted")
+	assert.Equal(t, exch.contracts[1].Name, om.submitted[0].Pair)
+	assert.Equal(t, order.Buy, om.submitted[0].Side, "short position should be closed with a buy")
+	assert.True(t, om.submitted[0].ReduceOnly, "near leg should be reduce only")
+	assert.Equal(t, exch.contracts[2].Name, om.submitted[1].Pair)
+	assert.Equal(t, order.Sell, om.submitted[1].Side, "far leg should reopen the short")
+	assert.Equal(t, 2.0, om.submitted[1].Amount)
+	require.Len(t, comms.events, 1)
+
+	om.submitted = nil
+	om.reject = func(*order.Submit) error { return errExpectedTestError }
+	m.checkPositions(context.Background())
+	assert.Empty(t, om.submitted)
+	require.Len(t, comms.events, 2)
+	assert.Contains(t, comms.events[1].Message, "failed to roll")
+
+	om.reject = func(s *order.Submit) error {
+		if s.ReduceOnly {
+			return nil
+		}
+		return errExpectedTestError
+	}
+	m.checkPositions(context.Background())
+	require.Len(t, om.submitted, 1, "the near leg should be closed")
+	require.Len(t, comms.events, 3)
+	assert.Contains(t, comms.events[2].Message, "near leg closed but opening far leg failed", "a half completed rollover must be reported")
+}
+
+func TestCheckPositionsRolloverLong(t *testing.T) {
+	t.Parallel()
+	m, _, om, _ := testRolloverSetup(t, false)
+	om.positions[0].LatestDirection = order.Long
+	m.checkPositions(context.Background())
+	require.Len(t, om.submitted, 2)
+	assert.Equal(t, order.Sell, om.submitted[0].Side, "long position should be closed with a sell")
+	assert.Equal(t, order.Buy, om.submitted[1].Side, "far leg should reopen the long")
+}
+
+func TestPlanRolloverTriggers(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	underlying := currency.NewPair(currency.BTC, currency.USD)
+	near := currency.NewPair(currency.BTC, currency.NewCode("USD_NEAR"))
+	far := currency.NewPair(currency.BTC, currency.NewCode("USD_FAR"))
+	farContract := futures.Contract{Name: far, Underlying: underlying, EndDate: now.Add(time.Hour * 24 * 90), IsActive: true}
+	for _, tc := range []struct {
+		name     string
+		nearEnd  time.Time
+		size     int64
+		far      func(*futures.Contract)
+		farPrice float64
+		due      bool
+		err      error
+	}{
+		{name: "within window", nearEnd: now.Add(time.Hour), size: 1, farPrice: 101, due: true},
+		{name: "outside window", nearEnd: now.Add(time.Hour * 25), size: 1, farPrice: 101},
+		{name: "expired", nearEnd: now.Add(-time.Minute), size: 1, farPrice: 101},
+		{name: "perpetual", size: 1, farPrice: 101},
+		{name: "flat position", nearEnd: now.Add(time.Hour), farPrice: 101},
+		{name: "inactive far contract", nearEnd: now.Add(time.Hour), size: 1, farPrice: 101, far: func(c *futures.Contract) { c.IsActive = false }, err: errNoRolloverContract},
+		{name: "other underlying", nearEnd: now.Add(time.Hour), size: 1, farPrice: 101, far: func(c *futures.Contract) { c.Underlying = currency.NewPair(currency.ETH, currency.USD) }, err: errNoRolloverContract},
+		{name: "other settlement", nearEnd: now.Add(time.Hour), size: 1, farPrice: 101, far: func(c *futures.Contract) { c.SettlementType = futures.Inverse }, err: errNoRolloverContract},
+		{name: "far expires first", nearEnd: now.Add(time.Hour), size: 1, farPrice: 101, far: func(c *futures.Contract) { c.EndDate = now.Add(time.Minute) }, err: errNoRolloverContract},
+		{name: "far price unset", nearEnd: now.Add(time.Hour), size: 1, err: errRolloverPriceUnset},
+		{name: "spread exceeded", nearEnd: now.Add(time.Hour), size: 1, farPrice: 97, err: errRolloverSpreadExceeded},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			m, _, _, _ := testRolloverSetup(t, true)
+			fc := farContract
+			if tc.far != nil {
+				tc.far(&fc)
+			}
+			exch := &rolloverExchange{prices: map[string]float64{near.String(): 100, far.String(): tc.farPrice}}
+			contracts := []futures.Contract{{Name: near, Underlying: underlying, EndDate: tc.nearEnd, IsActive: true}, fc}
+			pos := &futures.Position{Exchange: "rollover", Asset: asset.Futures, Pair: near, LatestDirection: order.Long, LatestSize: decimal.NewFromInt(tc.size)}
+			plan, err := m.planRollover(context.Background(), exch, pos, contracts, now)
+			require.ErrorIs(t, err, tc.err)
+			if !tc.due {
+				assert.Nil(t, plan, "no rollover should be planned")
+				return
+			}
+			require.NotNil(t, plan, "a rollover must be planned")
+			assert.Equal(t, far, plan.Far)
+			assert.Equal(t, 1.0, plan.Amount)
+		})
+	}
+}
+
+func TestRolloverMaintenance(t *testing.T) {
+	t.Parallel()
+	m, _, om, comms := testRolloverSetup(t, false)
+	now := time.Now()
+	m.calendar = &fakeCalendar{events: []calendar.Event{
+		{Exchange: "rollover", Type: calendar.Maintenance, Start: now.Add(-time.Minute), End: now.Add(time.Hour)},
+	}}
+	m.checkPositions(context.Background())
+	m.checkPositions(context.Background())
+	assert.Empty(t, om.submitted, "positions should not be rolled during maintenance")
+	require.Len(t, comms.events, 1, "deferred rollovers should only be reported once")
+	assert.Contains(t, comms.events[0].Message, "deferring rollovers")
+
+	m.calendar = &fakeCalendar{events: []calendar.Event{
+		{Exchange: "rollover", Type: calendar.Maintenance, Start: now.Add(time.Hour), End: now.Add(time.Hour * 2)},
+	}}
+	m.checkPositions(context.Background())
+	assert.Len(t, om.submitted, 2, "upcoming maintenance should not defer rollovers")
+}
+
+func TestRolloverQuarantine(t *testing.T) {
+	t.Parallel()
+	m, _, om, comms := testRolloverSetup(t, false)
+	m.quarantine = &fakeQuarantine{exchanges: []string{"rollover"}}
+	m.checkPositions(context.Background())
+	assert.Empty(t, om.submitted, "positions should not be rolled while market data is quarantined")
+	require.Len(t, comms.events, 1)
+	assert.Contains(t, comms.events[0].Message, "quarantined")
+}
+
+func TestRolloverDelisting(t *testing.T) {
+	t.Parallel()
+	m, exch, om, _ := testRolloverSetup(t, true)
+	now := time.Now()
+	perp := currency.NewPair(currency.BTC, currency.NewCode("USD_PERP"))
+	exch.contracts = append(exch.contracts, futures.Contract{Name: perp, Underlying: exch.contracts[0].Underlying, IsActive: true})
+	exch.prices[perp.String()] = 100.5
+	pos := &om.positions[0]
+	pos.Pair = perp
+
+	plan, err := m.planRollover(context.Background(), exch, pos, exch.contracts, now)
+	require.NoError(t, err)
+	assert.Nil(t, plan, "perpetual positions should not be rolled without a scheduled delisting")
+
+	delisting := now.Add(time.Hour * 2)
+	m.calendar = &fakeCalendar{events: []calendar.Event{
+		{Exchange: "rollover", Asset: asset.Futures, Pair: perp, Type: calendar.Delisting, Start: delisting},
+	}}
+	plan, err = m.planRollover(context.Background(), exch, pos, exch.contracts, now)
+	require.NoError(t, err)
+	require.NotNil(t, plan, "perpetual positions must be rolled ahead of a scheduled delisting")
+	assert.Equal(t, delisting, plan.NearExpiry, "near expiry should be the delisting time")
+	assert.Equal(t, exch.contracts[2].Name, plan.Far, "the nearest expiry after the delisting should be chosen")
+}
@@ -0,0 +1,71 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// RolloverManagerName is an exported subsystem name
+const RolloverManagerName = "rollover_manager"
+
+// rolloverEventType is the communications event type used for rollovers
+const rolloverEventType = "rollover"
+
+var (
+	errInvalidRolloverDuration = errors.New("rollover duration must be greater than zero")
+	errInvalidRolloverSpread   = errors.New("rollover max spread must be greater than zero")
+	errNoRolloverContract      = errors.New("no later expiry contract found")
+	errRolloverSpreadExceeded  = errors.New("rollover spread exceeds limit")
+	errRolloverPriceUnset      = errors.New("rollover contract price unavailable")
+)
+
+// iRolloverOrderManager defines the order manager functions used to find and
+// roll positions
+type iRolloverOrderManager interface {
+	GetAllOpenFuturesPositions() ([]futures.Position, error)
+	Submit(context.Context, *order.Submit) (*OrderSubmitResponse, error)
+}
+
+// RolloverManager detects open positions in contracts nearing expiry and rolls
+// them to the next expiry by closing the near leg and opening the far leg
+type RolloverManager struct {
+	started         int32
+	shutdown        chan struct{}
+	wg              sync.WaitGroup
+	verbose         bool
+	dryRun          bool
+	interval        time.Duration
+	window          time.Duration
+	maxSpread       float64
+	exchangeManager iExchangeManager
+	orderManager    iRolloverOrderManager
+	comms           iCommsManager
+	calendar        iExchangeCalendar
+	quarantine      iMarketDataQuarantine
+	// reported holds dry run and rejected rollovers which have been reported
+	// so they are not repeated every check
+	reported map[string]struct{}
+}
+
+// RolloverPlan defines how a position is rolled to a later expiry
+type RolloverPlan struct {
+	Exchange   string
+	Asset      asset.Item
+	Near       currency.Pair
+	Far        currency.Pair
+	NearExpiry time.Time
+	FarExpiry  time.Time
+	Side       order.Side
+	Amount     float64
+	NearPrice  float64
+	FarPrice   float64
+	// Spread is the relative price difference of the far leg to the near leg
+	Spread float64
+}
@@ -2,13 +2,16 @@ package engine
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"net"
 	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -26,20 +29,27 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/common/file/archive"
 	"github.com/thrasher-corp/gocryptotrader/common/key"
 	"github.com/thrasher-corp/gocryptotrader/common/timeperiods"
+	"github.com/thrasher-corp/gocryptotrader/core"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/database"
 	"github.com/thrasher-corp/gocryptotrader/database/models/postgres"
 	"github.com/thrasher-corp/gocryptotrader/database/models/sqlite3"
 	"github.com/thrasher-corp/gocryptotrader/database/repository/audit"
 	exchangeDB "github.com/thrasher-corp/gocryptotrader/database/repository/exchange"
+	fillsql "github.com/thrasher-corp/gocryptotrader/database/repository/fill"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/calendar"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/collateral"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/conversion"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/earn"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/fundingrate"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/margin"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/options"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
@@ -80,8 +90,44 @@ var (
 	errInvalidStrategy         = errors.New("invalid strategy")
 	errSpecificPairNotEnabled  = errors.New("specified pair is not enabled")
 	errPairNotEnabled          = errors.New("pair is not enabled")
+	errGreeksPriceUnavailable  = errors.New("mark or underlying price unavailable")
+	errInvalidResumeToken      = errors.New("resume token must not be negative")
+	errUnsupportedAPIVersion   = errors.New("unsupported gRPC API version")
+	errUnknownDashboardSection = errors.New("unknown dashboard section")
 )
 
+// Dashboard snapshot sections
+const (
+	dashboardTickers    = "tickers"
+	dashboardBalances   = "balances"
+	dashboardOrders     = "orders"
+	dashboardPositions  = "positions"
+	dashboardSubsystems = "subsystems"
+	dashboardEvents     = "events"
+)
+
+var dashboardSections = []string{dashboardTickers, dashboardBalances, dashboardOrders, dashboardPositions, dashboardSubsystems, dashboardEvents}
+
+// rpcDeprecation describes a gRPC method which is due to be removed or to
+// change incompatibly
+type rpcDeprecation struct {
+	since          int64
+	removalVersion int64
+	replacement    string
+	notice         string
+}
+
+// deprecatedRPCs holds the deprecated gRPC methods by full method name, clients
+// are told via response metadata and GetRPCCapabilities
+var deprecatedRPCs = map[string]rpcDeprecation{
+	gctrpc.GoCryptoTraderService_GetAvailableTransferChains_FullMethodName: {
+		since:          1,
+		removalVersion: 2,
+		replacement:    gctrpc.GoCryptoTraderService_GetTransferNetworks_FullMethodName,
+		notice:         "returns exchange specific chain names, use GetTransferNetworks for normalised transfer networks",
+	},
+}
+
 // RPCServer struct
 type RPCServer struct {
 	gctrpc.UnimplementedGoCryptoTraderServiceServer
@@ -127,6 +173,70 @@ func (s *RPCServer) authenticateClient(ctx context.Context) (context.Context, er
 	return ctx, nil
 }
 
+// checkAPIVersion ensures the API version requested by the client, if any, is
+// served by this build
+func checkAPIVersion(ctx context.Context) error {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+	requested := md.Get(gctrpc.APIVersionMetadataKey)
+	if len(requested) == 0 {
+		return nil
+	}
+	version, err := strconv.ParseInt(requested[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w %q", errUnsupportedAPIVersion, requested[0])
+	}
+	if version < gctrpc.MinimumAPIVersion || version > gctrpc.APIVersion {
+		return fmt.Errorf("%w %d, supported versions are %d to %d", errUnsupportedAPIVersion, version, gctrpc.MinimumAPIVersion, gctrpc.APIVersion)
+	}
+	return nil
+}
+
+// rpcVersionHeader returns the API version and any deprecation metadata for
+// a gRPC method
+func rpcVersionHeader(method string) metadata.MD {
+	md := metadata.Pairs(gctrpc.APIVersionMetadataKey, strconv.FormatInt(gctrpc.APIVersion, 10))
+	d, ok := deprecatedRPCs[method]
+	if !ok {
+		return md
+	}
+	md.Set(gctrpc.DeprecatedMetadataKey, "true")
+	md.Set(gctrpc.DeprecationNoticeMetadataKey, d.notice)
+	if d.removalVersion > 0 {
+		md.Set(gctrpc.RemovalVersionMetadataKey, strconv.FormatInt(d.removalVersion, 10))
+	}
+	if d.replacement != "" {
+		md.Set(gctrpc.ReplacementMetadataKey, d.replacement)
+	}
+	return md
+}
+
+// versionUnaryInterceptor rejects unsupported API versions and returns version
+// and deprecation metadata in the response headers of unary calls
+func versionUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := checkAPIVersion(ctx); err != nil {
+		return nil, err
+	}
+	if err := grpc.SetHeader(ctx, rpcVersionHeader(info.FullMethod)); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// versionStreamInterceptor rejects unsupported API versions and returns
+// version and deprecation metadata in the response headers of streams
+func versionStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := checkAPIVersion(ss.Context()); err != nil {
+		return err
+	}
+	if err := ss.SetHeader(rpcVersionHeader(info.FullMethod)); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
 // StartRPCServer starts a gRPC server with TLS auth
 func StartRPCServer(engine *Engine) {
 	targetDir := utils.GetTLSDir(engine.Settings.DataDir)
@@ -150,8 +260,8 @@ func StartRPCServer(engine *Engine) {
 	s := RPCServer{Engine: engine}
 	opts := []grpc.ServerOption{
 		grpc.Creds(creds),
-		grpc.UnaryInterceptor(grpcauth.UnaryServerInterceptor(s.authenticateClient)),
-		grpc.StreamInterceptor(grpcauth.StreamServerInterceptor(s.authenticateClient)),
+		grpc.ChainUnaryInterceptor(grpcauth.UnaryServerInterceptor(s.authenticateClient), versionUnaryInterceptor),
+		grpc.ChainStreamInterceptor(grpcauth.StreamServerInterceptor(s.authenticateClient), versionStreamInterceptor),
 	}
 	server := grpc.NewServer(opts...)
 	gctrpc.RegisterGoCryptoTraderServiceServer(server, &s)
@@ -291,6 +401,79 @@ func (s *RPCServer) GetRPCEndpoints(_ context.Context, _ *gctrpc.GetRPCEndpoints
 	return &gctrpc.GetRPCEndpointsResponse{Endpoints: endpoint}, err
 }
 
+// GetRPCCapabilities returns the API versions served and every gRPC method
+// along with its deprecation status
+func (s *RPCServer) GetRPCCapabilities(_ context.Context, _ *gctrpc.GetRPCCapabilitiesRequest) (*gctrpc.GetRPCCapabilitiesResponse, error) {
+	desc := gctrpc.GoCryptoTraderService_ServiceDesc
+	methods := make([]*gctrpc.RPCMethod, 0, len(desc.Methods)+len(desc.Streams))
+	for i := range desc.Methods {
+		methods = append(methods, &gctrpc.RPCMethod{Name: "/" + desc.ServiceName + "/" + desc.Methods[i].MethodName})
+	}
+	for i := range desc.Streams {
+		methods = append(methods, &gctrpc.RPCMethod{Name: "/" + desc.ServiceName + "/" + desc.Streams[i].StreamName, Streaming: true})
+	}
+	for i := range methods {
+		d, ok := deprecatedRPCs[methods[i].Name]
+		if !ok {
+			continue
+		}
+		methods[i].Deprecated = true
+		methods[i].DeprecatedSince = d.since
+		methods[i].RemovalVersion = d.removalVersion
+		methods[i].Replacement = d.replacement
+		methods[i].Notice = d.notice
+	}
+	sort.Slice(methods, func(i, j int) bool {
+		return methods[i].Name < methods[j].Name
+	})
+
+	return &gctrpc.GetRPCCapabilitiesResponse{
+		ApiVersion:        gctrpc.APIVersion,
+		MinimumApiVersion: gctrpc.MinimumAPIVersion,
+		BotVersion:        "v" + core.MajorVersion + "." + core.MinorVersion,
+		Methods:           methods,
+	}, nil
+}
+
+// CaptureProfile captures a cpu, heap, goroutine or other runtime profile of
+// the running engine, saving it to the configured profiler output path
+func (s *RPCServer) CaptureProfile(ctx context.Context, r *gctrpc.CaptureProfileRequest) (*gctrpc.CaptureProfileResponse, error) {
+	if r == nil {
+		return nil, errNilRequestData
+	}
+	if !s.Config.Profiler.Enabled {
+		return nil, errProfilerDisabled
+	}
+
+	var duration time.Duration
+	if r.Duration != "" {
+		var err error
+		duration, err = time.ParseDuration(r.Duration)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	dir := s.Config.Profiler.OutputPath
+	if dir == "" {
+		dir = filepath.Join(s.Settings.DataDir, profilesDir)
+	}
+	p, err := CaptureProfile(ctx, dir, r.Kind, duration, r.Flamegraph)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof(log.GRPCSys, "Captured %s profile %s\n", p.Kind, p.Path)
+
+	return &gctrpc.CaptureProfileResponse{
+		Kind:           p.Kind,
+		Path:           p.Path,
+		FlamegraphPath: p.FlamegraphPath,
+		Size:           p.Size,
+		Duration:       p.Duration.String(),
+		Captured:       s.unixTimestamp(p.Captured),
+	}, nil
+}
+
 // GetCommunicationRelayers returns the status of the engines communication relayers
 func (s *RPCServer) GetCommunicationRelayers(_ context.Context, _ *gctrpc.GetCommunicationRelayersRequest) (*gctrpc.GetCommunicationRelayersResponse, error) {
 	relayers, err := s.CommunicationsManager.GetStatus()
@@ -453,22 +636,7 @@ func (s *RPCServer) GetTickers(ctx context.Context, _ *gctrpc.GetTickersRequest)
 			Tickers:  make([]*gctrpc.TickerResponse, len(activeTickers[x].ExchangeValues)),
 		}
 		for y := range activeTickers[x].ExchangeValues {
-			val := activeTickers[x].ExchangeValues[y]
-			t.Tickers[y] = &gctrpc.TickerResponse{
-				Pair: &gctrpc.CurrencyPair{
-					Delimiter: val.Pair.Delimiter,
-					Base:      val.Pair.Base.String(),
-					Quote:     val.Pair.Quote.String(),
-				},
-				LastUpdated: s.unixTimestamp(val.LastUpdated),
-				Last:        val.Last,
-				High:        val.High,
-				Low:         val.Low,
-				Bid:         val.Bid,
-				Ask:         val.Ask,
-				Volume:      val.Volume,
-				PriceAth:    val.PriceATH,
-			}
+			t.Tickers[y] = s.tickerResponse(&activeTickers[x].ExchangeValues[y])
 		}
 		tickers[x] = t
 	}
@@ -476,6 +644,25 @@ func (s *RPCServer) GetTickers(ctx context.Context, _ *gctrpc.GetTickersRequest)
 	return &gctrpc.GetTickersResponse{Tickers: tickers}, nil
 }
 
+// tickerResponse converts a ticker price to its RPC response
+func (s *RPCServer) tickerResponse(val *ticker.Price) *gctrpc.TickerResponse {
+	return &gctrpc.TickerResponse{
+		Pair: &gctrpc.CurrencyPair{
+			Delimiter: val.Pair.Delimiter,
+			Base:      val.Pair.Base.String(),
+			Quote:     val.Pair.Quote.String(),
+		},
+		LastUpdated: s.unixTimestamp(val.LastUpdated),
+		Last:        val.Last,
+		High:        val.High,
+		Low:         val.Low,
+		Bid:         val.Bid,
+		Ask:         val.Ask,
+		Volume:      val.Volume,
+		PriceAth:    val.PriceATH,
+	}
+}
+
 // GetOrderbook returns an orderbook for a specific exchange, currency pair
 // and asset type
 func (s *RPCServer) GetOrderbook(ctx context.Context, r *gctrpc.GetOrderbookRequest) (*gctrpc.OrderbookResponse, error) {
@@ -805,10 +992,12 @@ func (s *RPCServer) GetPortfolioSummary(_ context.Context, _ *gctrpc.GetPortfoli
 		for x := range coins {
 			c = append(c,
 				&gctrpc.Coin{
-					Coin:       coins[x].Coin.String(),
-					Balance:    coins[x].Balance,
-					Address:    coins[x].Address,
-					Percentage: coins[x].Percentage,
+					Coin:           coins[x].Coin.String(),
+					Balance:        coins[x].Balance,
+					Address:        coins[x].Address,
+					Percentage:     coins[x].Percentage,
+					UsdValue:       coins[x].USDValue,
+					ReportingValue: coins[x].ReportingValue,
 				},
 			)
 		}
@@ -817,6 +1006,7 @@ func (s *RPCServer) GetPortfolioSummary(_ context.Context, _ *gctrpc.GetPortfoli
 
 	resp.CoinTotals = p(result.Totals)
 	resp.CoinsOffline = p(result.Offline)
+	resp.CoinsEarn = p(result.Earn)
 	resp.CoinsOfflineSummary = make(map[string]*gctrpc.OfflineCoins)
 	for k, v := range result.OfflineSummary {
 		var o []*gctrpc.OfflineCoinSummary
@@ -826,6 +1016,8 @@ func (s *RPCServer) GetPortfolioSummary(_ context.Context, _ *gctrpc.GetPortfoli
 					Address:    v[x].Address,
 					Balance:    v[x].Balance,
 					Percentage: v[x].Percentage,
+					Chain:      v[x].Chain,
+					UsdValue:   v[x].USDValue,
 				},
 			)
 		}
@@ -841,18 +1033,38 @@ func (s *RPCServer) GetPortfolioSummary(_ context.Context, _ *gctrpc.GetPortfoli
 			o[x.String()] = &gctrpc.OnlineCoinSummary{
 				Balance:    y.Balance,
 				Percentage: y.Percentage,
+				UsdValue:   y.USDValue,
 			}
 		}
 		resp.CoinsOnlineSummary[k] = &gctrpc.OnlineCoins{
 			Coins: o,
 		}
 	}
+	resp.TotalUsdValue = result.TotalUSDValue
+	resp.ReportingCurrency = result.ReportingCurrency.String()
+	resp.ReportingRate = result.ReportingRate
+	resp.TotalReportingValue = result.TotalReportingValue
+	if len(result.ConversionRates) != 0 {
+		resp.ConversionRates = make(map[string]float64, len(result.ConversionRates))
+		for c, rate := range result.ConversionRates {
+			resp.ConversionRates[c.String()] = rate
+		}
+	}
+	if !result.ValuedAt.IsZero() {
+		resp.ValuedAt = timestamppb.New(result.ValuedAt)
+	}
 
 	return &resp, nil
 }
 
 // AddPortfolioAddress adds an address to the portfoliomanager manager
 func (s *RPCServer) AddPortfolioAddress(_ context.Context, r *gctrpc.AddPortfolioAddressRequest) (*gctrpc.GenericResponse, error) {
+	if r.Chain != "" {
+		if err := s.portfolioManager.AddChainAddress(r.Address, r.Chain, r.Description, currency.NewCode(r.CoinType)); err != nil {
+			return nil, err
+		}
+		return &gctrpc.GenericResponse{Status: MsgStatusSuccess}, nil
+	}
 	err := s.portfolioManager.AddAddress(r.Address,
 		r.Description,
 		currency.NewCode(r.CoinType),
@@ -999,52 +1211,57 @@ func (s *RPCServer) GetOrders(ctx context.Context, r *gctrpc.GetOrdersRequest) (
 
 	orders := make([]*gctrpc.OrderDetails, len(resp))
 	for x := range resp {
-		trades := make([]*gctrpc.TradeHistory, len(resp[x].Trades))
-		for i := range resp[x].Trades {
-			t := &gctrpc.TradeHistory{
-				Id:        resp[x].Trades[i].TID,
-				Price:     resp[x].Trades[i].Price,
-				Amount:    resp[x].Trades[i].Amount,
-				Exchange:  r.Exchange,
-				AssetType: a.String(),
-				OrderSide: resp[x].Trades[i].Side.String(),
-				Fee:       resp[x].Trades[i].Fee,
-				Total:     resp[x].Trades[i].Total,
-			}
-			if !resp[x].Trades[i].Timestamp.IsZero() {
-				t.CreationTime = s.unixTimestamp(resp[x].Trades[i].Timestamp)
-			}
-			trades[i] = t
-		}
-		o := &gctrpc.OrderDetails{
-			Exchange:      r.Exchange,
-			Id:            resp[x].OrderID,
-			ClientOrderId: resp[x].ClientOrderID,
-			BaseCurrency:  resp[x].Pair.Base.String(),
-			QuoteCurrency: resp[x].Pair.Quote.String(),
-			AssetType:     resp[x].AssetType.String(),
-			OrderSide:     resp[x].Side.String(),
-			OrderType:     resp[x].Type.String(),
-			Status:        resp[x].Status.String(),
-			Price:         resp[x].Price,
-			Amount:        resp[x].Amount,
-			OpenVolume:    resp[x].Amount - resp[x].ExecutedAmount,
-			Fee:           resp[x].Fee,
-			Cost:          resp[x].Cost,
-			Trades:        trades,
-		}
-		if !resp[x].Date.IsZero() {
-			o.CreationTime = resp[x].Date.Format(common.SimpleTimeFormatWithTimezone)
-		}
-		if !resp[x].LastUpdated.IsZero() {
-			o.UpdateTime = resp[x].LastUpdated.Format(common.SimpleTimeFormatWithTimezone)
-		}
-		orders[x] = o
+		orders[x] = s.orderDetailsResponse(r.Exchange, a, &resp[x])
 	}
 
 	return &gctrpc.GetOrdersResponse{Orders: orders}, nil
 }
 
+// orderDetailsResponse converts an order to its RPC response
+func (s *RPCServer) orderDetailsResponse(exchName string, a asset.Item, d *order.Detail) *gctrpc.OrderDetails {
+	trades := make([]*gctrpc.TradeHistory, len(d.Trades))
+	for i := range d.Trades {
+		t := &gctrpc.TradeHistory{
+			Id:        d.Trades[i].TID,
+			Price:     d.Trades[i].Price,
+			Amount:    d.Trades[i].Amount,
+			Exchange:  exchName,
+			AssetType: a.String(),
+			OrderSide: d.Trades[i].Side.String(),
+			Fee:       d.Trades[i].Fee,
+			Total:     d.Trades[i].Total,
+		}
+		if !d.Trades[i].Timestamp.IsZero() {
+			t.CreationTime = s.unixTimestamp(d.Trades[i].Timestamp)
+		}
+		trades[i] = t
+	}
+	o := &gctrpc.OrderDetails{
+		Exchange:      exchName,
+		Id:            d.OrderID,
+		ClientOrderId: d.ClientOrderID,
+		BaseCurrency:  d.Pair.Base.String(),
+		QuoteCurrency: d.Pair.Quote.String(),
+		AssetType:     d.AssetType.String(),
+		OrderSide:     d.Side.String(),
+		OrderType:     d.Type.String(),
+		Status:        d.Status.String(),
+		Price:         d.Price,
+		Amount:        d.Amount,
+		OpenVolume:    d.Amount - d.ExecutedAmount,
+		Fee:           d.Fee,
+		Cost:          d.Cost,
+		Trades:        trades,
+	}
+	if !d.Date.IsZero() {
+		o.CreationTime = d.Date.Format(common.SimpleTimeFormatWithTimezone)
+	}
+	if !d.LastUpdated.IsZero() {
+		o.UpdateTime = d.LastUpdated.Format(common.SimpleTimeFormatWithTimezone)
+	}
+	return o
+}
+
 // GetManagedOrders returns all orders from the Order Manager for the provided exchange,
 // asset type  and currency pair
 func (s *RPCServer) GetManagedOrders(_ context.Context, r *gctrpc.GetOrdersRequest) (*gctrpc.GetOrdersResponse, error) {
@@ -1088,47 +1305,7 @@ func (s *RPCServer) GetManagedOrders(_ context.Context, r *gctrpc.GetOrdersReque
 
 	orders := make([]*gctrpc.OrderDetails, len(resp))
 	for x := range resp {
-		trades := make([]*gctrpc.TradeHistory, len(resp[x].Trades))
-		for i := range resp[x].Trades {
-			t := &gctrpc.TradeHistory{
-				Id:        resp[x].Trades[i].TID,
-				Price:     resp[x].Trades[i].Price,
-				Amount:    resp[x].Trades[i].Amount,
-				Exchange:  r.Exchange,
-				AssetType: a.String(),
-				OrderSide: resp[x].Trades[i].Side.String(),
-				Fee:       resp[x].Trades[i].Fee,
-				Total:     resp[x].Trades[i].Total,
-			}
-			if !resp[x].Trades[i].Timestamp.IsZero() {
-				t.CreationTime = s.unixTimestamp(resp[x].Trades[i].Timestamp)
-			}
-			trades[i] = t
-		}
-		o := &gctrpc.OrderDetails{
-			Exchange:      r.Exchange,
-			Id:            resp[x].OrderID,
-			ClientOrderId: resp[x].ClientOrderID,
-			BaseCurrency:  resp[x].Pair.Base.String(),
-			QuoteCurrency: resp[x].Pair.Quote.String(),
-			AssetType:     resp[x].AssetType.String(),
-			OrderSide:     resp[x].Side.String(),
-			OrderType:     resp[x].Type.String(),
-			Status:        resp[x].Status.String(),
-			Price:         resp[x].Price,
-			Amount:        resp[x].Amount,
-			OpenVolume:    resp[x].Amount - resp[x].ExecutedAmount,
-			Fee:           resp[x].Fee,
-			Cost:          resp[x].Cost,
-			Trades:        trades,
-		}
-		if !resp[x].Date.IsZero() {
-			o.CreationTime = resp[x].Date.Format(common.SimpleTimeFormatWithTimezone)
-		}
-		if !resp[x].LastUpdated.IsZero() {
-			o.UpdateTime = resp[x].LastUpdated.Format(common.SimpleTimeFormatWithTimezone)
-		}
-		orders[x] = o
+		orders[x] = s.orderDetailsResponse(r.Exchange, a, &resp[x])
 	}
 
 	return &gctrpc.GetOrdersResponse{Orders: orders}, nil
@@ -1261,16 +1438,24 @@ func (s *RPCServer) SubmitOrder(ctx context.Context, r *gctrpc.SubmitOrderReques
 		return nil, err
 	}
 
+	stp, err := order.StringToSelfTradePrevention(r.SelfTradePrevention)
+	if err != nil {
+		return nil, err
+	}
+
 	submission := &order.Submit{
-		Pair:          p,
-		Side:          side,
-		Type:          oType,
-		Amount:        r.Amount,
-		Price:         r.Price,
-		ClientID:      r.ClientId,
-		ClientOrderID: r.ClientId,
-		Exchange:      r.Exchange,
-		AssetType:     a,
+		Pair:                p,
+		Side:                side,
+		Type:                oType,
+		Amount:              r.Amount,
+		DisplayAmount:       r.DisplayAmount,
+		Price:               r.Price,
+		ClientID:            r.ClientId,
+		ClientOrderID:       r.ClientId,
+		StrategyTag:         r.StrategyTag,
+		Exchange:            r.Exchange,
+		AssetType:           a,
+		SelfTradePrevention: stp,
 	}
 	if r.MarginType != "" {
 		submission.MarginType = marginType
@@ -1298,6 +1483,72 @@ func (s *RPCServer) SubmitOrder(ctx context.Context, r *gctrpc.SubmitOrderReques
 	}, nil
 }
 
+// SubmitOptionStrategy submits a multi-leg option strategy as a combo order
+// where the exchange supports it, otherwise as individual legs
+func (s *RPCServer) SubmitOptionStrategy(ctx context.Context, r *gctrpc.SubmitOptionStrategyRequest) (*gctrpc.SubmitOptionStrategyResponse, error) {
+	exch, err := s.GetExchangeByName(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	strategyType, err := options.StringToStrategyType(r.Strategy)
+	if err != nil {
+		return nil, err
+	}
+	var controls LegRiskControls
+	if r.FillTimeout != "" {
+		if controls.FillTimeout, err = time.ParseDuration(r.FillTimeout); err != nil {
+			return nil, err
+		}
+	}
+
+	legs := make([]options.Leg, len(r.Legs))
+	for i := range r.Legs {
+		if r.Legs[i] == nil {
+			return nil, errCurrencyPairUnset
+		}
+		p, err := currency.NewPairDelimiter(r.Legs[i].Pair, currency.DashDelimiter)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkParams(r.Exchange, exch, asset.Options, p); err != nil {
+			return nil, err
+		}
+		side, err := order.StringToOrderSide(r.Legs[i].Side)
+		if err != nil {
+			return nil, err
+		}
+		legs[i] = options.Leg{Pair: p, Side: side, Ratio: r.Legs[i].Ratio, Price: r.Legs[i].Price}
+	}
+	strategy, err := options.NewStrategy(strategyType, legs)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.OrderManager.SubmitOptionStrategy(ctx, exch.GetName(), asset.Options, strategy, r.Amount, controls)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.SubmitOptionStrategyResponse{
+		Legs:    make([]*gctrpc.OptionStrategyLegOrder, len(result.Legs)),
+		Unwound: result.Unwound,
+	}
+	if result.Combo != nil {
+		resp.ComboId = result.Combo.ComboID
+		resp.ComboOrderId = result.Combo.OrderID
+	}
+	for i := range result.Legs {
+		resp.Legs[i] = &gctrpc.OptionStrategyLegOrder{
+			Pair:    result.Legs[i].Pair.String(),
+			Side:    result.Legs[i].Side.String(),
+			OrderId: result.Legs[i].OrderID,
+			Amount:  result.Legs[i].Amount,
+			Price:   result.Legs[i].Price,
+			Status:  result.Legs[i].Status.String(),
+		}
+	}
+	return resp, nil
+}
+
 // SimulateOrder simulates an order specified by exchange, currency pair and asset
 // type
 func (s *RPCServer) SimulateOrder(ctx context.Context, r *gctrpc.SimulateOrderRequest) (*gctrpc.SimulateOrderResponse, error) {
@@ -1522,22 +1773,93 @@ func (s *RPCServer) CancelBatchOrders(ctx context.Context, r *gctrpc.CancelBatch
 	}, nil
 }
 
-// CancelAllOrders cancels all orders, filterable by exchange
+// CancelAllOrders cancels all orders on an exchange. When scoped by asset,
+// pair, side, order type or strategy tag only matching orders tracked by the
+// order manager are cancelled and the result of each order is returned
 func (s *RPCServer) CancelAllOrders(ctx context.Context, r *gctrpc.CancelAllOrdersRequest) (*gctrpc.CancelAllOrdersResponse, error) {
 	exch, err := s.GetExchangeByName(r.Exchange)
 	if err != nil {
 		return nil, err
 	}
 
-	// TODO: Change to order manager
-	resp, err := exch.CancelAllOrders(ctx, nil)
+	if r.Asset == "" && r.Pair == nil && r.Side == "" && r.OrderType == "" && r.StrategyTag == "" {
+		resp, err := exch.CancelAllOrders(ctx, nil)
+		if err != nil {
+			return &gctrpc.CancelAllOrdersResponse{}, err
+		}
+
+		return &gctrpc.CancelAllOrdersResponse{
+			Count: resp.Count, // count of deleted orders
+		}, nil
+	}
+
+	scope := &order.Filter{
+		Exchange:    exch.GetName(),
+		StrategyTag: r.StrategyTag,
+	}
+	if r.Asset != "" {
+		scope.AssetType, err = asset.New(r.Asset)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if r.Pair != nil {
+		scope.Pair = currency.Pair{
+			Delimiter: r.Pair.Delimiter,
+			Base:      currency.NewCode(r.Pair.Base),
+			Quote:     currency.NewCode(r.Pair.Quote),
+		}
+	}
+	err = checkParams(r.Exchange, exch, scope.AssetType, scope.Pair)
 	if err != nil {
-		return &gctrpc.CancelAllOrdersResponse{}, err
+		return nil, err
+	}
+	if r.Side != "" {
+		scope.Side, err = order.StringToOrderSide(r.Side)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if r.OrderType != "" {
+		scope.Type, err = order.StringToOrderType(r.OrderType)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	return &gctrpc.CancelAllOrdersResponse{
-		Count: resp.Count, // count of deleted orders
-	}, nil
+	results, err := s.OrderManager.CancelAllOrdersFiltered(ctx, scope)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.CancelAllOrdersResponse{
+		Orders: []*gctrpc.Orders{{
+			Exchange:    exch.GetName(),
+			OrderStatus: make(map[string]string, len(results)),
+		}},
+		Results: make([]*gctrpc.CancelOrderResult, len(results)),
+	}
+	for i := range results {
+		if results[i].Cancelled {
+			resp.Count++
+		}
+		id := results[i].OrderID
+		if id == "" {
+			id = results[i].ClientOrderID
+		}
+		resp.Orders[0].OrderStatus[id] = results[i].Status
+		resp.Results[i] = &gctrpc.CancelOrderResult{
+			Exchange:      results[i].Exchange,
+			Asset:         results[i].AssetType.String(),
+			Pair:          results[i].Pair.String(),
+			OrderId:       results[i].OrderID,
+			ClientOrderId: results[i].ClientOrderID,
+			StrategyTag:   results[i].StrategyTag,
+			Cancelled:     results[i].Cancelled,
+			Status:        results[i].Status,
+			Endpoint:      results[i].Endpoint,
+		}
+	}
+	return resp, nil
 }
 
 // ModifyOrder modifies an existing order if it exists
@@ -1716,137 +2038,603 @@ func (s *RPCServer) GetAvailableTransferChains(ctx context.Context, r *gctrpc.Ge
 	}, nil
 }
 
-// WithdrawCryptocurrencyFunds withdraws cryptocurrency funds specified by
-// exchange
-func (s *RPCServer) WithdrawCryptocurrencyFunds(ctx context.Context, r *gctrpc.WithdrawCryptoRequest) (*gctrpc.WithdrawResponse, error) {
-	_, err := s.GetExchangeByName(r.Exchange)
+// GetTransferNetworks returns the normalised transfer networks of a
+// cryptocurrency on an exchange, including each network's withdrawal fee and
+// minimums
+func (s *RPCServer) GetTransferNetworks(ctx context.Context, r *gctrpc.GetTransferNetworksRequest) (*gctrpc.GetTransferNetworksResponse, error) {
+	exch, err := s.GetExchangeByName(r.Exchange)
 	if err != nil {
 		return nil, err
 	}
 
-	req := &withdraw.Request{
-		Exchange:    r.Exchange,
-		Amount:      r.Amount,
-		Currency:    currency.NewCode(strings.ToUpper(r.Currency)),
-		Type:        withdraw.Crypto,
-		Description: r.Description,
-		Crypto: withdraw.CryptoRequest{
-			Address:    r.Address,
-			AddressTag: r.AddressTag,
-			FeeAmount:  r.Fee,
-			Chain:      r.Chain,
-		},
+	curr := currency.NewCode(r.Cryptocurrency)
+	if curr.IsEmpty() {
+		return nil, errCurrencyNotSpecified
 	}
 
-	exchCfg, err := s.Config.GetExchangeConfig(r.Exchange)
+	chains, err := exch.GetTransferNetworks(ctx, curr)
 	if err != nil {
 		return nil, err
 	}
 
-	if exchCfg.API.Credentials.OTPSecret != "" {
-		code, errOTP := totp.GenerateCode(exchCfg.API.Credentials.OTPSecret, time.Now())
-		if errOTP != nil {
-			return nil, errOTP
+	resp := &gctrpc.GetTransferNetworksResponse{Networks: make([]*gctrpc.TransferNetwork, len(chains))}
+	for i := range chains {
+		resp.Networks[i] = &gctrpc.TransferNetwork{
+			Network:         string(chains[i].Network),
+			Chain:           chains[i].Name,
+			DepositEnabled:  chains[i].DepositEnabled,
+			WithdrawEnabled: chains[i].WithdrawEnabled,
+			WithdrawFee:     chains[i].WithdrawFee,
+			MinWithdrawal:   chains[i].MinWithdrawal,
+			MinDeposit:      chains[i].MinDeposit,
 		}
+	}
+	return resp, nil
+}
 
-		codeNum, errOTP := strconv.ParseInt(code, 10, 64)
-		if errOTP != nil {
-			return nil, errOTP
-		}
-		req.OneTimePassword = codeNum
+// ConvertCurrency requests a quote from an exchange's native conversion
+// service and accepts it when requested
+func (s *RPCServer) ConvertCurrency(ctx context.Context, r *gctrpc.ConvertCurrencyRequest) (*gctrpc.ConvertCurrencyResponse, error) {
+	exch, err := s.GetExchangeByName(r.Exchange)
+	if err != nil {
+		return nil, err
 	}
 
-	if exchCfg.API.Credentials.PIN != "" {
-		pinCode, errPin := strconv.ParseInt(exchCfg.API.Credentials.PIN, 10, 64)
-		if err != nil {
-			return nil, errPin
-		}
-		req.PIN = pinCode
+	q, err := exch.GetConversionQuote(ctx, &conversion.QuoteRequest{
+		From:   currency.NewCode(strings.ToUpper(r.From)),
+		To:     currency.NewCode(strings.ToUpper(r.To)),
+		Amount: r.Amount,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	req.TradePassword = exchCfg.API.Credentials.TradePassword
+	resp := &gctrpc.ConvertCurrencyResponse{
+		Quote: &gctrpc.ConversionQuote{
+			Id:         q.ID,
+			From:       q.From.String(),
+			To:         q.To.String(),
+			FromAmount: q.FromAmount,
+			ToAmount:   q.ToAmount,
+			Rate:       q.Rate,
+		},
+	}
+	if !q.Expires.IsZero() {
+		resp.Quote.Expires = q.Expires.Format(common.SimpleTimeFormatWithTimezone)
+	}
+	if !r.Accept {
+		return resp, nil
+	}
 
-	resp, err := s.Engine.WithdrawManager.SubmitWithdrawal(ctx, req)
+	result, err := exch.AcceptConversionQuote(ctx, q)
 	if err != nil {
 		return nil, err
 	}
-
-	return &gctrpc.WithdrawResponse{
-		Id:     resp.ID.String(),
-		Status: resp.Exchange.Status,
-	}, nil
+	log.Infof(log.GRPCSys, "%s converted %v %s to %v %s, quote: %s order: %s status: %s\n",
+		exch.GetName(), result.FromAmount, result.From, result.ToAmount, result.To, result.QuoteID, result.OrderID, result.Status)
+	resp.Result = &gctrpc.ConversionResult{
+		OrderId:    result.OrderID,
+		From:       result.From.String(),
+		To:         result.To.String(),
+		FromAmount: result.FromAmount,
+		ToAmount:   result.ToAmount,
+		Status:     result.Status,
+		Time:       result.Time.Format(common.SimpleTimeFormatWithTimezone),
+	}
+	return resp, nil
 }
 
-// WithdrawFiatFunds withdraws fiat funds specified by exchange
-func (s *RPCServer) WithdrawFiatFunds(ctx context.Context, r *gctrpc.WithdrawFiatRequest) (*gctrpc.WithdrawResponse, error) {
+// SweepDust converts balances too small to trade into a single currency. All
+// eligible balances are swept when no currencies are specified, and a dry run
+// only returns the balances which would be swept
+func (s *RPCServer) SweepDust(ctx context.Context, r *gctrpc.SweepDustRequest) (*gctrpc.SweepDustResponse, error) {
 	exch, err := s.GetExchangeByName(r.Exchange)
 	if err != nil {
 		return nil, err
 	}
 
-	bankAccount, err := banking.GetBankAccountByID(r.BankAccountId)
-	if err != nil {
-		base := exch.GetBase()
-		if base == nil {
-			return nil, errExchangeBaseNotFound
-		}
-		bankAccount, err = base.GetExchangeBankAccounts(r.BankAccountId,
-			r.Currency)
+	req := &conversion.DustRequest{
+		Currencies: make([]currency.Code, len(r.Currencies)),
+		To:         currency.NewCode(strings.ToUpper(r.To)),
+	}
+	for i := range r.Currencies {
+		req.Currencies[i] = currency.NewCode(strings.ToUpper(r.Currencies[i]))
+	}
+
+	resp := &gctrpc.SweepDustResponse{}
+	if len(req.Currencies) == 0 || r.DryRun {
+		balances, err := exch.GetDustBalances(ctx)
 		if err != nil {
 			return nil, err
 		}
+		for i := range balances {
+			if len(r.Currencies) != 0 && !slices.ContainsFunc(req.Currencies, balances[i].Currency.Equal) {
+				continue
+			}
+			if len(r.Currencies) == 0 {
+				req.Currencies = append(req.Currencies, balances[i].Currency)
+			}
+			targets := make([]string, len(balances[i].Targets))
+			for j := range balances[i].Targets {
+				targets[j] = balances[i].Targets[j].String()
+			}
+			resp.Balances = append(resp.Balances, &gctrpc.DustBalance{
+				Currency: balances[i].Currency.String(),
+				Amount:   balances[i].Amount,
+				Targets:  targets,
+				Estimate: balances[i].Estimate,
+			})
+		}
 	}
-
-	req := &withdraw.Request{
-		Exchange:    r.Exchange,
-		Amount:      r.Amount,
-		Currency:    currency.NewCode(strings.ToUpper(r.Currency)),
-		Type:        withdraw.Fiat,
-		Description: r.Description,
-		Fiat: withdraw.FiatRequest{
-			Bank: *bankAccount,
-		},
+	if r.DryRun {
+		return resp, nil
 	}
 
-	exchCfg, err := s.Config.GetExchangeConfig(r.Exchange)
+	result, err := exch.ConvertDust(ctx, req)
 	if err != nil {
 		return nil, err
 	}
-
-	if exchCfg.API.Credentials.OTPSecret != "" {
-		code, errOTP := totp.GenerateCode(exchCfg.API.Credentials.OTPSecret, time.Now())
-		if err != nil {
-			return nil, errOTP
+	log.Infof(log.GRPCSys, "%s swept %d dust balances into %v %s\n", exch.GetName(), len(result.Conversions), result.Total, result.To)
+	resp.To = result.To.String()
+	resp.Total = result.Total
+	resp.Fee = result.Fee
+	if !result.Time.IsZero() {
+		resp.Time = result.Time.Format(common.SimpleTimeFormatWithTimezone)
+	}
+	resp.Conversions = make([]*gctrpc.DustConversion, len(result.Conversions))
+	for i := range result.Conversions {
+		resp.Conversions[i] = &gctrpc.DustConversion{
+			Currency:  result.Conversions[i].Currency.String(),
+			Amount:    result.Conversions[i].Amount,
+			Converted: result.Conversions[i].Converted,
+			Fee:       result.Conversions[i].Fee,
 		}
-
-		codeNum, errOTP := strconv.ParseInt(code, 10, 64)
+	}
+	return resp, nil
+}
+
+// GetEarnProducts returns the earn and staking products offered by an
+// exchange, filtered by currency when set
+func (s *RPCServer) GetEarnProducts(ctx context.Context, r *gctrpc.GetEarnProductsRequest) (*gctrpc.GetEarnProductsResponse, error) {
+	exch, err := s.GetExchangeByName(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := exch.GetEarnProducts(ctx, currency.NewCode(strings.ToUpper(r.Currency)))
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetEarnProductsResponse{Products: make([]*gctrpc.EarnProduct, len(products))}
+	for i := range products {
+		resp.Products[i] = &gctrpc.EarnProduct{
+			Id:        products[i].ID,
+			Currency:  products[i].Currency.String(),
+			Type:      string(products[i].Type),
+			Apr:       products[i].APR,
+			MinAmount: products[i].MinAmount,
+			Available: products[i].Available,
+		}
+		if products[i].Duration > 0 {
+			resp.Products[i].Duration = products[i].Duration.String()
+		}
+	}
+	return resp, nil
+}
+
+// GetEarnPositions returns the amounts held in an exchange's earn and staking
+// products
+func (s *RPCServer) GetEarnPositions(ctx context.Context, r *gctrpc.GetEarnPositionsRequest) (*gctrpc.GetEarnPositionsResponse, error) {
+	exch, err := s.GetExchangeByName(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+
+	positions, err := exch.GetEarnPositions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetEarnPositionsResponse{Positions: make([]*gctrpc.EarnPosition, len(positions))}
+	for i := range positions {
+		resp.Positions[i] = &gctrpc.EarnPosition{
+			ProductId:      positions[i].ProductID,
+			PositionId:     positions[i].PositionID,
+			Currency:       positions[i].Currency.String(),
+			Type:           string(positions[i].Type),
+			Amount:         positions[i].Amount,
+			AccruedRewards: positions[i].AccruedRewards,
+			Apr:            positions[i].APR,
+		}
+		if !positions[i].Matures.IsZero() {
+			resp.Positions[i].Matures = positions[i].Matures.Format(common.SimpleTimeFormatWithTimezone)
+		}
+	}
+	return resp, nil
+}
+
+// SubscribeEarnProduct subscribes an amount to an exchange earn or staking
+// product
+func (s *RPCServer) SubscribeEarnProduct(ctx context.Context, r *gctrpc.SubscribeEarnProductRequest) (*gctrpc.EarnResult, error) {
+	exch, err := s.GetExchangeByName(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	productType, err := earn.ParseProductType(r.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := exch.SubscribeEarnProduct(ctx, &earn.SubscribeRequest{
+		ProductID: r.ProductId,
+		Currency:  currency.NewCode(strings.ToUpper(r.Currency)),
+		Type:      productType,
+		Amount:    r.Amount,
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Infof(log.GRPCSys, "%s subscribed %v %s to earn product %s\n", exch.GetName(), result.Amount, result.Currency, result.ProductID)
+	s.syncEarn(ctx, exch)
+	return earnResultToRPC(result), nil
+}
+
+// RedeemEarnProduct redeems an amount, or the full position, from an exchange
+// earn or staking product
+func (s *RPCServer) RedeemEarnProduct(ctx context.Context, r *gctrpc.RedeemEarnProductRequest) (*gctrpc.EarnResult, error) {
+	exch, err := s.GetExchangeByName(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	productType, err := earn.ParseProductType(r.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := exch.RedeemEarnProduct(ctx, &earn.RedeemRequest{
+		ProductID:  r.ProductId,
+		PositionID: r.PositionId,
+		Currency:   currency.NewCode(strings.ToUpper(r.Currency)),
+		Type:       productType,
+		Amount:     r.Amount,
+		All:        r.All,
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Infof(log.GRPCSys, "%s redeemed %v %s from earn product %s\n", exch.GetName(), result.Amount, result.Currency, result.ProductID)
+	s.syncEarn(ctx, exch)
+	return earnResultToRPC(result), nil
+}
+
+// syncEarn resyncs an exchange's earn positions after a subscription or
+// redemption so the portfolio reflects the change before the next scheduled
+// sync
+func (s *RPCServer) syncEarn(ctx context.Context, exch exchange.IBotExchange) {
+	if !s.earnManager.IsRunning() {
+		return
+	}
+	if err := s.earnManager.Sync(ctx, exch); err != nil {
+		log.Errorf(log.GRPCSys, "Unable to sync %s earn positions: %v\n", exch.GetName(), err)
+	}
+}
+
+func earnResultToRPC(result *earn.Result) *gctrpc.EarnResult {
+	resp := &gctrpc.EarnResult{
+		ProductId: result.ProductID,
+		Id:        result.ID,
+		Currency:  result.Currency.String(),
+		Amount:    result.Amount,
+		Success:   result.Success,
+	}
+	if !result.Time.IsZero() {
+		resp.Time = result.Time.Format(common.SimpleTimeFormatWithTimezone)
+	}
+	return resp
+}
+
+// GetEarnRewards returns the earn and staking rewards paid between the start
+// and end times. Rewards are returned from the earn manager's ledger when it is
+// running, which allows every exchange to be queried by leaving the exchange
+// unset, otherwise they are fetched from the exchange
+func (s *RPCServer) GetEarnRewards(ctx context.Context, r *gctrpc.GetEarnRewardsRequest) (*gctrpc.GetEarnRewardsResponse, error) {
+	start, err := time.Parse(common.SimpleTimeFormatWithTimezone, r.Start)
+	if err != nil {
+		return nil, fmt.Errorf("%w cannot parse start time %v", errInvalidTimes, err)
+	}
+	end, err := time.Parse(common.SimpleTimeFormatWithTimezone, r.End)
+	if err != nil {
+		return nil, fmt.Errorf("%w cannot parse end time %v", errInvalidTimes, err)
+	}
+	err = common.StartEndTimeCheck(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	var rewards []earn.Reward
+	if s.earnManager.IsRunning() {
+		if r.Exchange != "" {
+			if _, err = s.GetExchangeByName(r.Exchange); err != nil {
+				return nil, err
+			}
+		}
+		rewards, err = s.earnManager.GetRewards(r.Exchange, start, end)
+	} else {
+		var exch exchange.IBotExchange
+		exch, err = s.GetExchangeByName(r.Exchange)
+		if err != nil {
+			return nil, err
+		}
+		rewards, err = exch.GetEarnRewards(ctx, start, end)
+	}
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetEarnRewardsResponse{Rewards: make([]*gctrpc.EarnReward, len(rewards))}
+	for i := range rewards {
+		resp.Rewards[i] = &gctrpc.EarnReward{
+			Exchange:  rewards[i].Exchange,
+			ProductId: rewards[i].ProductID,
+			Currency:  rewards[i].Currency.String(),
+			Amount:    rewards[i].Amount,
+			Time:      rewards[i].Time.Format(common.SimpleTimeFormatWithTimezone),
+		}
+	}
+	return resp, nil
+}
+
+// GetLendingOpportunities returns the flexible earn rates compared by the
+// lending optimizer and the idle balances found by its last check
+func (s *RPCServer) GetLendingOpportunities(_ context.Context, _ *gctrpc.GetLendingOpportunitiesRequest) (*gctrpc.GetLendingOpportunitiesResponse, error) {
+	if !s.lendingOptimizer.IsRunning() {
+		return nil, fmt.Errorf("%s %w", LendingOptimizerName, ErrSubSystemNotStarted)
+	}
+	rates, opportunities, checked, err := s.lendingOptimizer.GetLendingOpportunities()
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetLendingOpportunitiesResponse{
+		Rates:         make([]*gctrpc.LendingRate, len(rates)),
+		Opportunities: make([]*gctrpc.LendingOpportunity, len(opportunities)),
+		Checked:       checked.Format(common.SimpleTimeFormatWithTimezone),
+	}
+	for i := range rates {
+		resp.Rates[i] = &gctrpc.LendingRate{
+			Exchange:  rates[i].Exchange,
+			Currency:  rates[i].Currency.String(),
+			ProductId: rates[i].ProductID,
+			Apr:       rates[i].APR,
+		}
+	}
+	for i := range opportunities {
+		resp.Opportunities[i] = &gctrpc.LendingOpportunity{
+			Currency:  opportunities[i].Currency.String(),
+			From:      opportunities[i].From,
+			To:        opportunities[i].To,
+			ProductId: opportunities[i].ProductID,
+			FromApr:   opportunities[i].FromAPR,
+			ToApr:     opportunities[i].ToAPR,
+			Amount:    opportunities[i].Amount,
+			Fee:       opportunities[i].Fee,
+			Status:    opportunities[i].Status,
+		}
+	}
+	return resp, nil
+}
+
+// GetMemoryUsage returns the approximate memory attributed to each cache by the
+// memory manager's last check
+func (s *RPCServer) GetMemoryUsage(_ context.Context, _ *gctrpc.GetMemoryUsageRequest) (*gctrpc.GetMemoryUsageResponse, error) {
+	if !s.memoryManager.IsRunning() {
+		return nil, fmt.Errorf("%s %w", MemoryManagerName, ErrSubSystemNotStarted)
+	}
+	report, err := s.memoryManager.GetReport()
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetMemoryUsageResponse{
+		Caches:    make([]*gctrpc.CacheMemoryUsage, len(report.Caches)),
+		HeapAlloc: report.HeapAlloc,
+		HeapSys:   report.HeapSys,
+		Checked:   report.Checked.Format(common.SimpleTimeFormatWithTimezone),
+	}
+	for i := range report.Caches {
+		resp.Caches[i] = &gctrpc.CacheMemoryUsage{
+			Name:   report.Caches[i].Name,
+			Usage:  report.Caches[i].Usage,
+			Budget: report.Caches[i].Budget,
+			Action: report.Caches[i].Action,
+			Trims:  report.Caches[i].Trims,
+		}
+	}
+	return resp, nil
+}
+
+// WithdrawCryptocurrencyFunds withdraws cryptocurrency funds specified by
+// exchange
+func (s *RPCServer) WithdrawCryptocurrencyFunds(ctx context.Context, r *gctrpc.WithdrawCryptoRequest) (*gctrpc.WithdrawResponse, error) {
+	_, err := s.GetExchangeByName(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &withdraw.Request{
+		Exchange:    r.Exchange,
+		Amount:      r.Amount,
+		Currency:    currency.NewCode(strings.ToUpper(r.Currency)),
+		Type:        withdraw.Crypto,
+		Description: r.Description,
+		Crypto: withdraw.CryptoRequest{
+			Address:    r.Address,
+			AddressTag: r.AddressTag,
+			FeeAmount:  r.Fee,
+			Chain:      r.Chain,
+		},
+	}
+
+	if err = s.setWithdrawalCredentials(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.Engine.WithdrawManager.SubmitWithdrawal(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gctrpc.WithdrawResponse{
+		Id:     resp.ID.String(),
+		Status: resp.Exchange.Status,
+	}, nil
+}
+
+// WithdrawFiatFunds withdraws fiat funds specified by exchange
+func (s *RPCServer) WithdrawFiatFunds(ctx context.Context, r *gctrpc.WithdrawFiatRequest) (*gctrpc.WithdrawResponse, error) {
+	exch, err := s.GetExchangeByName(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+
+	bankAccount, err := banking.GetBankAccountByID(r.BankAccountId)
+	if err != nil {
+		base := exch.GetBase()
+		if base == nil {
+			return nil, errExchangeBaseNotFound
+		}
+		bankAccount, err = base.GetExchangeBankAccounts(r.BankAccountId,
+			r.Currency)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	req := &withdraw.Request{
+		Exchange:    r.Exchange,
+		Amount:      r.Amount,
+		Currency:    currency.NewCode(strings.ToUpper(r.Currency)),
+		Type:        withdraw.Fiat,
+		Description: r.Description,
+		Fiat: withdraw.FiatRequest{
+			Bank: *bankAccount,
+		},
+	}
+
+	if err = s.setWithdrawalCredentials(req); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.Engine.WithdrawManager.SubmitWithdrawal(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gctrpc.WithdrawResponse{
+		Id:     resp.ID.String(),
+		Status: resp.Exchange.Status,
+	}, nil
+}
+
+// setWithdrawalCredentials sets the one time password, PIN and trade password
+// configured for an exchange on a withdraw request
+func (s *RPCServer) setWithdrawalCredentials(req *withdraw.Request) error {
+	exchCfg, err := s.Config.GetExchangeConfig(req.Exchange)
+	if err != nil {
+		return err
+	}
+
+	if exchCfg.API.Credentials.OTPSecret != "" {
+		code, err := totp.GenerateCode(exchCfg.API.Credentials.OTPSecret, time.Now())
 		if err != nil {
-			return nil, errOTP
+			return err
+		}
+
+		codeNum, err := strconv.ParseInt(code, 10, 64)
+		if err != nil {
+			return err
 		}
 		req.OneTimePassword = codeNum
 	}
 
 	if exchCfg.API.Credentials.PIN != "" {
-		pinCode, errPIN := strconv.ParseInt(exchCfg.API.Credentials.PIN, 10, 64)
+		pinCode, err := strconv.ParseInt(exchCfg.API.Credentials.PIN, 10, 64)
 		if err != nil {
-			return nil, errPIN
+			return err
 		}
 		req.PIN = pinCode
 	}
 
 	req.TradePassword = exchCfg.API.Credentials.TradePassword
+	return nil
+}
 
-	resp, err := s.Engine.WithdrawManager.SubmitWithdrawal(ctx, req)
+// GetWithdrawalSigningPayload returns the payload to be signed offline to
+// approve a withdrawal awaiting offline approval
+func (s *RPCServer) GetWithdrawalSigningPayload(_ context.Context, r *gctrpc.GetWithdrawalSigningPayloadRequest) (*gctrpc.GetWithdrawalSigningPayloadResponse, error) {
+	p, err := s.WithdrawManager.WithdrawalSigningPayload(r.Id)
 	if err != nil {
 		return nil, err
 	}
+	return &gctrpc.GetWithdrawalSigningPayloadResponse{
+		Id:      p.ID.String(),
+		Payload: string(p.Payload),
+		Sha256:  p.SHA256,
+		Expires: p.Expires.Format(common.SimpleTimeFormatWithTimezone),
+	}, nil
+}
 
+// SubmitSignedWithdrawal verifies the offline signature of a withdrawal
+// payload and submits the approved withdrawal
+func (s *RPCServer) SubmitSignedWithdrawal(ctx context.Context, r *gctrpc.SubmitSignedWithdrawalRequest) (*gctrpc.WithdrawResponse, error) {
+	signature, err := hex.DecodeString(r.Signature)
+	if err != nil {
+		return nil, err
+	}
+	req, err := s.WithdrawManager.ApproveWithdrawal([]byte(r.Payload), signature)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.setWithdrawalCredentials(req); err != nil {
+		return nil, err
+	}
+	resp, err := s.WithdrawManager.submit(ctx, req)
+	if err != nil {
+		return nil, err
+	}
 	return &gctrpc.WithdrawResponse{
 		Id:     resp.ID.String(),
 		Status: resp.Exchange.Status,
 	}, nil
 }
 
+// GetWithdrawalLocks returns the active withdrawal locks and delays known for
+// an exchange, fetching the exchange's current locks when a currency is set
+func (s *RPCServer) GetWithdrawalLocks(ctx context.Context, r *gctrpc.GetWithdrawalLocksRequest) (*gctrpc.GetWithdrawalLocksResponse, error) {
+	if r.Currency != "" {
+		if r.Exchange == "" {
+			return nil, ErrExchangeNameIsEmpty
+		}
+		_, err := s.WithdrawManager.UpdateWithdrawalLocks(ctx, r.Exchange, currency.NewCode(r.Currency))
+		if err != nil && !errors.Is(err, common.ErrFunctionNotSupported) && !errors.Is(err, common.ErrNotYetImplemented) {
+			return nil, err
+		}
+	}
+	locks, err := s.WithdrawManager.WithdrawalLocks(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetWithdrawalLocksResponse{Locks: make([]*gctrpc.WithdrawalLock, len(locks))}
+	for i := range locks {
+		resp.Locks[i] = &gctrpc.WithdrawalLock{
+			Exchange: locks[i].Exchange,
+			Currency: locks[i].Currency.String(),
+			Address:  locks[i].Address,
+			Reason:   locks[i].Reason,
+		}
+		if !locks[i].Until.IsZero() {
+			resp.Locks[i].Until = locks[i].Until.Format(common.SimpleTimeFormatWithTimezone)
+		}
+	}
+	return resp, nil
+}
+
 // WithdrawalEventByID returns previous withdrawal request details
 func (s *RPCServer) WithdrawalEventByID(_ context.Context, r *gctrpc.WithdrawalEventByIDRequest) (*gctrpc.WithdrawalEventByIDResponse, error) {
 	if !s.Config.Database.Enabled {
@@ -2900,14 +3688,93 @@ func (s *RPCServer) GCTScriptAutoLoadToggle(_ context.Context, r *gctrpc.GCTScri
 	return &gctrpc.GenericResponse{Status: "success", Data: "script " + r.Script + " added to autoload list"}, nil
 }
 
-// SetExchangeAsset enables or disables an exchanges asset type
-func (s *RPCServer) SetExchangeAsset(_ context.Context, r *gctrpc.SetExchangeAssetRequest) (*gctrpc.GenericResponse, error) {
-	exch, err := s.GetExchangeByName(r.Exchange)
-	if err != nil {
-		return nil, err
+// GCTScriptScheduleAdd schedules a script to be executed on a cron-style
+// schedule
+func (s *RPCServer) GCTScriptScheduleAdd(_ context.Context, r *gctrpc.GCTScriptScheduleAddRequest) (*gctrpc.GCTScriptSchedule, error) {
+	if !s.gctScriptManager.IsRunning() {
+		return nil, gctscript.ErrScriptingDisabled
 	}
 
-	exchCfg, err := s.Config.GetExchangeConfig(r.Exchange)
+	var timeout time.Duration
+	if r.Timeout != "" {
+		var err error
+		timeout, err = time.ParseDuration(r.Timeout)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sched, err := s.gctScriptManager.AddSchedule(gctscript.Schedule{
+		Script:   r.Script,
+		Schedule: r.Schedule,
+		Timeout:  timeout,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return scheduledScriptToRPC(sched), nil
+}
+
+// GCTScriptScheduleRemove removes a scheduled script
+func (s *RPCServer) GCTScriptScheduleRemove(_ context.Context, r *gctrpc.GCTScriptScheduleRemoveRequest) (*gctrpc.GenericResponse, error) {
+	if !s.gctScriptManager.IsRunning() {
+		return &gctrpc.GenericResponse{Status: gctscript.ErrScriptingDisabled.Error()}, nil
+	}
+
+	id, err := uuid.FromString(r.Id)
+	if err != nil {
+		return &gctrpc.GenericResponse{Status: MsgStatusError, Data: err.Error()}, nil //nolint:nilerr // error is returned in the generic response
+	}
+
+	err = s.gctScriptManager.RemoveSchedule(id)
+	if err != nil {
+		return &gctrpc.GenericResponse{Status: MsgStatusError, Data: err.Error()}, nil //nolint:nilerr // error is returned in the generic response
+	}
+	return &gctrpc.GenericResponse{Status: MsgStatusOK, Data: "schedule " + r.Id + " removed"}, nil
+}
+
+// GCTScriptScheduleList lists all scheduled scripts
+func (s *RPCServer) GCTScriptScheduleList(context.Context, *gctrpc.GCTScriptScheduleListRequest) (*gctrpc.GCTScriptScheduleListResponse, error) {
+	if !s.gctScriptManager.IsRunning() {
+		return &gctrpc.GCTScriptScheduleListResponse{Status: gctscript.ErrScriptingDisabled.Error()}, nil
+	}
+
+	schedules := s.gctScriptManager.GetSchedules()
+	resp := &gctrpc.GCTScriptScheduleListResponse{
+		Status:    MsgStatusOK,
+		Schedules: make([]*gctrpc.GCTScriptSchedule, len(schedules)),
+	}
+	for i := range schedules {
+		resp.Schedules[i] = scheduledScriptToRPC(&schedules[i])
+	}
+	return resp, nil
+}
+
+func scheduledScriptToRPC(s *gctscript.ScheduledScript) *gctrpc.GCTScriptSchedule {
+	resp := &gctrpc.GCTScriptSchedule{
+		Id:        s.ID.String(),
+		Script:    s.Script,
+		Schedule:  s.Schedule,
+		Timeout:   s.Timeout.String(),
+		NextRun:   s.NextRun.Format(common.SimpleTimeFormatWithTimezone),
+		LastError: s.LastError,
+		Running:   s.Running,
+		Skipped:   s.Skipped,
+	}
+	if !s.LastRun.IsZero() {
+		resp.LastRun = s.LastRun.Format(common.SimpleTimeFormatWithTimezone)
+	}
+	return resp
+}
+
+// SetExchangeAsset enables or disables an exchanges asset type
+func (s *RPCServer) SetExchangeAsset(_ context.Context, r *gctrpc.SetExchangeAssetRequest) (*gctrpc.GenericResponse, error) {
+	exch, err := s.GetExchangeByName(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+
+	exchCfg, err := s.Config.GetExchangeConfig(r.Exchange)
 	if err != nil {
 		return nil, err
 	}
@@ -4403,6 +5270,471 @@ func (s *RPCServer) GetAllManagedPositions(_ context.Context, r *gctrpc.GetAllMa
 	return &gctrpc.GetManagedPositionsResponse{Positions: response}, nil
 }
 
+// greekPosition is a held futures or options position used to calculate
+// portfolio greeks
+type greekPosition struct {
+	exchange   string
+	asset      asset.Item
+	pair       currency.Pair
+	underlying currency.Code
+	contract   *options.Contract
+	size       float64
+}
+
+// GetPortfolioGreeks returns greeks aggregated per underlying for open futures
+// positions tracked by the order manager and options positions netted from
+// filled options orders, using live mark and index prices
+func (s *RPCServer) GetPortfolioGreeks(ctx context.Context, r *gctrpc.GetPortfolioGreeksRequest) (*gctrpc.GetPortfolioGreeksResponse, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w GetPortfolioGreeksRequest", common.ErrNilPointer)
+	}
+	held, warnings, err := s.heldGreekPositions(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetPortfolioGreeksResponse{Warnings: warnings}
+
+	underlyings := make(map[string]*gctrpc.UnderlyingGreeks)
+	now := time.Now()
+	for i := range held {
+		if r.Underlying != "" && !strings.EqualFold(held[i].underlying.String(), r.Underlying) {
+			continue
+		}
+		pg, err := s.positionGreeks(ctx, &held[i], r.RiskFreeRate, now)
+		if err != nil {
+			resp.Warnings = append(resp.Warnings, fmt.Sprintf("%s %s %s: %v", held[i].exchange, held[i].asset, held[i].pair, err))
+			continue
+		}
+		u, ok := underlyings[held[i].underlying.Upper().String()]
+		if !ok {
+			u = &gctrpc.UnderlyingGreeks{Underlying: held[i].underlying.Upper().String()}
+			underlyings[u.Underlying] = u
+			resp.Underlyings = append(resp.Underlyings, u)
+		}
+		u.Delta += pg.Delta
+		u.DeltaNotional += pg.Delta * pg.UnderlyingPrice
+		u.Gamma += pg.Gamma
+		u.Vega += pg.Vega
+		u.Theta += pg.Theta
+		u.Positions = append(u.Positions, pg)
+	}
+	sort.Slice(resp.Underlyings, func(i, j int) bool {
+		return resp.Underlyings[i].Underlying < resp.Underlyings[j].Underlying
+	})
+	return resp, nil
+}
+
+// heldGreekPositions returns open futures positions tracked by the order
+// manager and options positions netted from filled options orders, optionally
+// filtered by exchange. Options which cannot be parsed are returned as warnings
+func (s *RPCServer) heldGreekPositions(exchange string) ([]greekPosition, []string, error) {
+	positions, err := s.OrderManager.GetAllOpenFuturesPositions()
+	if err != nil && !errors.Is(err, errFuturesTrackingDisabled) {
+		return nil, nil, err
+	}
+	optionOrders, err := s.OrderManager.GetOrdersFiltered(&order.Filter{Exchange: exchange, AssetType: asset.Options})
+	if err != nil {
+		return nil, nil, err
+	}
+	var warnings []string
+	held := make([]greekPosition, 0, len(positions))
+	for i := range positions {
+		if exchange != "" && !strings.EqualFold(positions[i].Exchange, exchange) {
+			continue
+		}
+		size := positions[i].LatestSize.Abs().InexactFloat64()
+		if positions[i].LatestDirection.IsShort() {
+			size = -size
+		}
+		held = append(held, greekPosition{
+			exchange:   positions[i].Exchange,
+			asset:      positions[i].Asset,
+			pair:       positions[i].Pair,
+			underlying: positions[i].Pair.Base,
+			size:       size,
+		})
+	}
+	for _, p := range netOptionPositions(optionOrders) {
+		p.contract, err = options.ParseContract(p.pair)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s %s %s: %v", p.exchange, p.asset, p.pair, err))
+			continue
+		}
+		p.underlying = p.contract.Underlying
+		held = append(held, p)
+	}
+	return held, warnings, nil
+}
+
+// positionGreeks calculates the greeks of a position scaled by its size.
+// Futures carry a delta of their size, options are priced with an implied
+// volatility solved from the mark price
+func (s *RPCServer) positionGreeks(ctx context.Context, p *greekPosition, riskFreeRate float64, now time.Time) (*gctrpc.PositionGreeks, error) {
+	exch, err := s.GetExchangeByName(p.exchange)
+	if err != nil {
+		return nil, err
+	}
+	t, err := exch.FetchTicker(ctx, p.pair, p.asset)
+	if err != nil {
+		return nil, err
+	}
+	pg := &gctrpc.PositionGreeks{
+		Exchange:        p.exchange,
+		Asset:           p.asset.String(),
+		Pair:            &gctrpc.CurrencyPair{Delimiter: p.pair.Delimiter, Base: p.pair.Base.String(), Quote: p.pair.Quote.String()},
+		Size:            p.size,
+		MarkPrice:       t.MarkPrice,
+		UnderlyingPrice: t.IndexPrice,
+	}
+	if pg.MarkPrice <= 0 {
+		pg.MarkPrice = t.Last
+	}
+	if p.contract == nil {
+		if pg.UnderlyingPrice <= 0 {
+			pg.UnderlyingPrice = pg.MarkPrice
+		}
+		pg.Delta = p.size
+		return pg, nil
+	}
+	if pg.MarkPrice <= 0 || pg.UnderlyingPrice <= 0 {
+		return nil, errGreeksPriceUnavailable
+	}
+	pg.OptionType = p.contract.Type.String()
+	pg.Strike = p.contract.Strike
+	pg.Expiry = p.contract.Expiry.Format(common.SimpleTimeFormatWithTimezone)
+	params := &options.Params{
+		Type:         p.contract.Type,
+		Underlying:   pg.UnderlyingPrice,
+		Strike:       p.contract.Strike,
+		TimeToExpiry: options.TimeToExpiry(p.contract.Expiry, now),
+		RiskFreeRate: riskFreeRate,
+	}
+	params.Volatility, err = options.ImpliedVolatility(pg.MarkPrice, params)
+	if err != nil {
+		return nil, err
+	}
+	g, err := options.CalculateGreeks(params)
+	if err != nil {
+		return nil, err
+	}
+	pg.ImpliedVolatility = params.Volatility
+	pg.Delta = g.Delta * p.size
+	pg.Gamma = g.Gamma * p.size
+	pg.Vega = g.Vega * p.size
+	pg.Theta = g.Theta * p.size
+	return pg, nil
+}
+
+// SimulatePortfolioMargin estimates the margin required on a portfolio
+// margin venue before and after a hypothetical trade using the venue's risk
+// scenarios, and the largest amount of the trade which fits within available
+// margin. Held positions on the trade's underlying are included when requested
+func (s *RPCServer) SimulatePortfolioMargin(ctx context.Context, r *gctrpc.SimulatePortfolioMarginRequest) (*gctrpc.SimulatePortfolioMarginResponse, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w SimulatePortfolioMarginRequest", common.ErrNilPointer)
+	}
+	if r.Pair == nil {
+		return nil, errCurrencyPairUnset
+	}
+	if r.Amount <= 0 {
+		return nil, order.ErrAmountIsInvalid
+	}
+	a, err := asset.New(r.Asset)
+	if err != nil {
+		return nil, err
+	}
+	if !a.IsFutures() && a != asset.Options {
+		return nil, fmt.Errorf("%w %v", asset.ErrNotSupported, a)
+	}
+	side, err := order.StringToOrderSide(r.Side)
+	if err != nil {
+		return nil, err
+	}
+	if !side.IsLong() && !side.IsShort() {
+		return nil, fmt.Errorf("%w %v", order.ErrSideIsInvalid, side)
+	}
+	if _, err = s.GetExchangeByName(r.Exchange); err != nil {
+		return nil, err
+	}
+	modelName := r.Model
+	if modelName == "" {
+		modelName = r.Exchange
+	}
+	model, err := options.GetPortfolioModel(modelName)
+	if err != nil {
+		return nil, err
+	}
+
+	trade := greekPosition{
+		exchange: r.Exchange,
+		asset:    a,
+		pair:     currency.NewPairWithDelimiter(r.Pair.Base, r.Pair.Quote, r.Pair.Delimiter),
+		size:     r.Amount,
+	}
+	if side.IsShort() {
+		trade.size = -trade.size
+	}
+	trade.underlying = trade.pair.Base
+	if a == asset.Options {
+		if trade.contract, err = options.ParseContract(trade.pair); err != nil {
+			return nil, err
+		}
+		trade.underlying = trade.contract.Underlying
+	}
+	now := time.Now()
+	pg, err := s.positionGreeks(ctx, &trade, r.RiskFreeRate, now)
+	if err != nil {
+		return nil, err
+	}
+	market := &options.PortfolioMarket{UnderlyingPrice: pg.UnderlyingPrice, RiskFreeRate: r.RiskFreeRate, Time: now}
+	tradePosition := portfolioPosition(&trade, pg)
+
+	resp := &gctrpc.SimulatePortfolioMarginResponse{
+		Model:           model.Name,
+		Underlying:      trade.underlying.Upper().String(),
+		UnderlyingPrice: market.UnderlyingPrice,
+	}
+	var positions []options.PortfolioPosition
+	if r.IncludePositions {
+		held, warnings, err := s.heldGreekPositions(r.Exchange)
+		if err != nil {
+			return nil, err
+		}
+		resp.Warnings = warnings
+		for i := range held {
+			if !held[i].underlying.Equal(trade.underlying) {
+				continue
+			}
+			pg, err := s.positionGreeks(ctx, &held[i], r.RiskFreeRate, now)
+			if err != nil {
+				resp.Warnings = append(resp.Warnings, fmt.Sprintf("%s %s %s: %v", held[i].exchange, held[i].asset, held[i].pair, err))
+				continue
+			}
+			positions = append(positions, portfolioPosition(&held[i], pg))
+		}
+	}
+
+	sim, err := model.SimulateTrade(market, positions, &tradePosition)
+	if err != nil {
+		return nil, err
+	}
+	resp.Before = portfolioMarginRequirement(&sim.Before)
+	resp.After = portfolioMarginRequirement(&sim.After)
+	resp.IncrementalInitialMargin = sim.IncrementalInitialMargin
+	resp.IncrementalMaintenanceMargin = sim.IncrementalMaintenanceMargin
+	if r.AvailableMargin > 0 {
+		if resp.MaxAmount, err = model.MaxTradeSize(market, positions, &tradePosition, r.AvailableMargin); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// portfolioPosition converts a held position and its greeks to a portfolio
+// margin position
+func portfolioPosition(p *greekPosition, pg *gctrpc.PositionGreeks) options.PortfolioPosition {
+	return options.PortfolioPosition{
+		Pair:       p.pair,
+		Asset:      p.asset,
+		Size:       p.size,
+		Contract:   p.contract,
+		Volatility: pg.ImpliedVolatility,
+	}
+}
+
+// portfolioMarginRequirement converts a portfolio margin requirement to its
+// RPC representation
+func portfolioMarginRequirement(req *options.PortfolioRequirement) *gctrpc.PortfolioMarginRequirement {
+	return &gctrpc.PortfolioMarginRequirement{
+		InitialMargin:        req.InitialMargin,
+		MaintenanceMargin:    req.MaintenanceMargin,
+		Contingency:          req.Contingency,
+		WorstPriceMove:       req.WorstScenario.PriceMove,
+		WorstVolatilityShock: req.WorstScenario.VolatilityShock,
+		WorstScenarioPnl:     req.WorstScenario.PNL,
+	}
+}
+
+// GetVolatilitySurface returns the implied volatility surface built by the
+// volatility surface manager for an exchange and underlying, optionally as
+// of a historic snapshot time
+func (s *RPCServer) GetVolatilitySurface(_ context.Context, r *gctrpc.GetVolatilitySurfaceRequest) (*gctrpc.GetVolatilitySurfaceResponse, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w GetVolatilitySurfaceRequest", common.ErrNilPointer)
+	}
+	surface, err := s.getVolatilitySurface(r.Exchange, r.Underlying, r.At)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetVolatilitySurfaceResponse{
+		Exchange:      r.Exchange,
+		Underlying:    surface.Underlying.String(),
+		Forward:       surface.Forward,
+		Interpolation: surface.Method.String(),
+		Built:         surface.Built.Format(common.SimpleTimeFormatWithTimezone),
+		Slices:        make([]*gctrpc.VolatilitySurfaceSlice, len(surface.Slices)),
+	}
+	for i := range surface.Slices {
+		sl := &surface.Slices[i]
+		slice := &gctrpc.VolatilitySurfaceSlice{
+			Expiry:       sl.Expiry.Format(common.SimpleTimeFormatWithTimezone),
+			TimeToExpiry: sl.TimeToExpiry,
+			Quotes:       make([]*gctrpc.VolatilitySurfaceQuote, len(sl.Quotes)),
+		}
+		for j := range sl.Quotes {
+			slice.Quotes[j] = &gctrpc.VolatilitySurfaceQuote{
+				Strike:            sl.Quotes[j].Strike,
+				ImpliedVolatility: sl.Quotes[j].Volatility,
+			}
+		}
+		if sl.SVI != nil {
+			slice.Svi = &gctrpc.SVIParameters{
+				A:     sl.SVI.A,
+				B:     sl.SVI.B,
+				Rho:   sl.SVI.Rho,
+				M:     sl.SVI.M,
+				Sigma: sl.SVI.Sigma,
+				Rmse:  sl.SVI.RMSE,
+			}
+		}
+		resp.Slices[i] = slice
+	}
+	snapshots := s.volatilitySurface.GetSurfaceHistory(r.Exchange, surface.Underlying)
+	resp.Snapshots = make([]string, len(snapshots))
+	for i := range snapshots {
+		resp.Snapshots[i] = snapshots[i].Format(common.SimpleTimeFormatWithTimezone)
+	}
+	return resp, nil
+}
+
+// GetImpliedVolatility returns the implied volatility interpolated from an
+// exchange and underlying's volatility surface at a strike and expiry
+func (s *RPCServer) GetImpliedVolatility(_ context.Context, r *gctrpc.GetImpliedVolatilityRequest) (*gctrpc.GetImpliedVolatilityResponse, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w GetImpliedVolatilityRequest", common.ErrNilPointer)
+	}
+	expiry, err := time.Parse(common.SimpleTimeFormatWithTimezone, r.Expiry)
+	if err != nil {
+		return nil, err
+	}
+	surface, err := s.getVolatilitySurface(r.Exchange, r.Underlying, r.At)
+	if err != nil {
+		return nil, err
+	}
+	iv, err := surface.ImpliedVolatility(r.Strike, expiry)
+	if err != nil {
+		return nil, err
+	}
+	return &gctrpc.GetImpliedVolatilityResponse{
+		ImpliedVolatility: iv,
+		Forward:           surface.Forward,
+		Interpolation:     surface.Method.String(),
+		SurfaceBuilt:      surface.Built.Format(common.SimpleTimeFormatWithTimezone),
+	}, nil
+}
+
+// getVolatilitySurface returns the latest surface, or the snapshot at the
+// supplied time when set
+func (s *RPCServer) getVolatilitySurface(exch, underlying, at string) (*options.Surface, error) {
+	if !s.volatilitySurface.IsRunning() {
+		return nil, fmt.Errorf("%s %w", VolatilitySurfaceManagerName, ErrSubSystemNotStarted)
+	}
+	if _, err := s.GetExchangeByName(exch); err != nil {
+		return nil, err
+	}
+	if underlying == "" {
+		return nil, currency.ErrCurrencyCodeEmpty
+	}
+	var atTime time.Time
+	if at != "" {
+		var err error
+		if atTime, err = time.Parse(common.SimpleTimeFormatWithTimezone, at); err != nil {
+			return nil, err
+		}
+	}
+	return s.volatilitySurface.GetSurface(exch, currency.NewCode(underlying), atTime)
+}
+
+// StreamFills streams normalised fills from the fill sync manager's store.
+// Fills stored after the resume token are replayed before new fills are
+// streamed as they are stored, each carrying the token to resume from
+func (s *RPCServer) StreamFills(r *gctrpc.StreamFillsRequest, stream gctrpc.GoCryptoTraderService_StreamFillsServer) error {
+	if r == nil {
+		return fmt.Errorf("%w StreamFillsRequest", common.ErrNilPointer)
+	}
+	if !s.fillSyncManager.IsRunning() {
+		return fmt.Errorf("%s %w", FillSyncManagerName, ErrSubSystemNotStarted)
+	}
+	if r.ResumeToken < 0 {
+		return fmt.Errorf("%w %d", errInvalidResumeToken, r.ResumeToken)
+	}
+	var a asset.Item
+	if r.Asset != "" {
+		var err error
+		if a, err = asset.New(r.Asset); err != nil {
+			return err
+		}
+	}
+	return s.fillSyncManager.StreamFills(stream.Context(), r.ResumeToken, func(f *fillsql.Data) error {
+		if r.Exchange != "" && !strings.EqualFold(f.Exchange, r.Exchange) {
+			return nil
+		}
+		if a != asset.Empty && !strings.EqualFold(f.AssetType, a.String()) {
+			return nil
+		}
+		return stream.Send(&gctrpc.FillResponse{
+			ResumeToken:   f.Sequence,
+			Exchange:      f.Exchange,
+			Asset:         f.AssetType,
+			Pair:          &gctrpc.CurrencyPair{Base: f.Base, Quote: f.Quote},
+			Side:          f.Side,
+			OrderId:       f.OrderID,
+			ClientOrderId: f.ClientOrderID,
+			TradeId:       f.TradeID,
+			Price:         f.Price,
+			Amount:        f.Amount,
+			Fee:           f.Fee,
+			FeeAsset:      f.FeeAsset,
+			Source:        f.Source,
+			Timestamp:     s.unixTimestamp(f.Timestamp),
+		})
+	})
+}
+
+// netOptionPositions nets the executed amounts of options orders into
+// positions per exchange and contract, ignoring closed positions
+func netOptionPositions(orders []order.Detail) []greekPosition {
+	var positions []greekPosition
+	index := make(map[string]int)
+	for i := range orders {
+		if orders[i].ExecutedAmount == 0 {
+			continue
+		}
+		size := orders[i].ExecutedAmount
+		switch {
+		case orders[i].Side.IsShort():
+			size = -size
+		case !orders[i].Side.IsLong():
+			continue
+		}
+		k := strings.ToLower(orders[i].Exchange) + " " + orders[i].Pair.Upper().String()
+		if x, ok := index[k]; ok {
+			positions[x].size += size
+			continue
+		}
+		index[k] = len(positions)
+		positions = append(positions, greekPosition{
+			exchange: orders[i].Exchange,
+			asset:    orders[i].AssetType,
+			pair:     orders[i].Pair,
+			size:     size,
+		})
+	}
+	return slices.DeleteFunc(positions, func(p greekPosition) bool {
+		return math.Abs(p.size) < 1e-12
+	})
+}
+
 // GetFuturesPositionsSummary returns a summary of futures positions for an exchange asset pair from the API
 func (s *RPCServer) GetFuturesPositionsSummary(ctx context.Context, r *gctrpc.GetFuturesPositionsSummaryRequest) (*gctrpc.GetFuturesPositionsSummaryResponse, error) {
 	if r == nil {
@@ -5792,6 +7124,47 @@ func (s *RPCServer) SetMarginType(ctx context.Context, r *gctrpc.SetMarginTypeRe
 	}, nil
 }
 
+// GetMarginType returns the margin type for the account asset pair
+func (s *RPCServer) GetMarginType(ctx context.Context, r *gctrpc.GetMarginTypeRequest) (*gctrpc.GetMarginTypeResponse, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w GetMarginTypeRequest", common.ErrNilPointer)
+	}
+	if r.Pair == nil {
+		return nil, currency.ErrCurrencyPairEmpty
+	}
+	exch, err := s.GetExchangeByName(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	if !exch.IsEnabled() {
+		return nil, fmt.Errorf("%s %w", r.Exchange, errExchangeNotEnabled)
+	}
+	ai, err := asset.New(r.Asset)
+	if err != nil {
+		return nil, err
+	}
+	enabledPairs, err := exch.GetEnabledPairs(ai)
+	if err != nil {
+		return nil, err
+	}
+	cp, err := enabledPairs.DeriveFrom(r.Pair.Base + r.Pair.Quote)
+	if err != nil {
+		return nil, err
+	}
+
+	mt, err := exch.GetMarginType(ctx, ai, cp)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gctrpc.GetMarginTypeResponse{
+		Exchange:   r.Exchange,
+		Asset:      r.Asset,
+		Pair:       r.Pair,
+		MarginType: mt.String(),
+	}, nil
+}
+
 // GetLeverage returns the leverage for the account asset pair
 func (s *RPCServer) GetLeverage(ctx context.Context, r *gctrpc.GetLeverageRequest) (*gctrpc.GetLeverageResponse, error) {
 	if r == nil {
@@ -5897,6 +7270,11 @@ func (s *RPCServer) SetLeverage(ctx context.Context, r *gctrpc.SetLeverageReques
 		}
 	}
 
+	err = s.OrderManager.CheckLeverage(ai, r.Leverage)
+	if err != nil {
+		return nil, err
+	}
+
 	err = exch.SetLeverage(ctx, ai, cp, mt, r.Leverage, orderSide)
 	if err != nil {
 		return nil, err
@@ -6016,3 +7394,720 @@ func (s *RPCServer) GetOpenInterest(ctx context.Context, r *gctrpc.GetOpenIntere
 		Data: resp,
 	}, nil
 }
+
+// GetScheduledTasks returns the configuration, next run and recent run history
+// of every scheduler task
+func (s *RPCServer) GetScheduledTasks(context.Context, *gctrpc.GetScheduledTasksRequest) (*gctrpc.GetScheduledTasksResponse, error) {
+	tasks, err := s.scheduler.GetTasks()
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetScheduledTasksResponse{Tasks: make([]*gctrpc.ScheduledTask, len(tasks))}
+	for i := range tasks {
+		t := &tasks[i]
+		task := &gctrpc.ScheduledTask{
+			Name:     t.Name,
+			Type:     t.Type,
+			Schedule: t.Schedule,
+			Overlap:  t.Overlap,
+			Jitter:   t.Jitter.String(),
+			Timeout:  t.Timeout.String(),
+			Running:  t.Running,
+			Queued:   t.Queued,
+			Skipped:  t.Skipped,
+			History:  make([]*gctrpc.ScheduledTaskRun, len(t.History)),
+		}
+		if !t.NextRun.IsZero() {
+			task.NextRun = t.NextRun.Format(common.SimpleTimeFormatWithTimezone)
+		}
+		for j := range t.History {
+			task.History[j] = &gctrpc.ScheduledTaskRun{
+				Start:    t.History[j].Start.Format(common.SimpleTimeFormatWithTimezone),
+				End:      t.History[j].End.Format(common.SimpleTimeFormatWithTimezone),
+				Duration: t.History[j].End.Sub(t.History[j].Start).String(),
+				Error:    t.History[j].Error,
+			}
+		}
+		resp.Tasks[i] = task
+	}
+	return resp, nil
+}
+
+// GetRateLimitStatus returns the recent rate limit usage of each endpoint of an
+// exchange, or of all enabled exchanges if no exchange is specified
+func (s *RPCServer) GetRateLimitStatus(_ context.Context, r *gctrpc.GetRateLimitStatusRequest) (*gctrpc.GetRateLimitStatusResponse, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w GetRateLimitStatusRequest", common.ErrNilPointer)
+	}
+	var exchanges []exchange.IBotExchange
+	if r.Exchange != "" {
+		exch, err := s.GetExchangeByName(r.Exchange)
+		if err != nil {
+			return nil, err
+		}
+		exchanges = append(exchanges, exch)
+	} else {
+		all, err := s.ExchangeManager.GetExchanges()
+		if err != nil {
+			return nil, err
+		}
+		for _, exch := range all {
+			if exch.IsEnabled() {
+				exchanges = append(exchanges, exch)
+			}
+		}
+	}
+
+	resp := &gctrpc.GetRateLimitStatusResponse{Exchanges: make([]*gctrpc.ExchangeRateLimitStatus, 0, len(exchanges))}
+	for _, exch := range exchanges {
+		status, err := exch.GetBase().Requester.GetRateLimitStatus()
+		if err != nil {
+			return nil, fmt.Errorf("%s %w", exch.GetName(), err)
+		}
+		exchStatus := &gctrpc.ExchangeRateLimitStatus{
+			Exchange:  exch.GetName(),
+			Endpoints: make([]*gctrpc.RateLimitEndpointStatus, len(status)),
+		}
+		for i := range status {
+			e := &gctrpc.RateLimitEndpointStatus{
+				Endpoint:    int64(status[i].Endpoint),
+				Requests:    int64(status[i].Requests),
+				RequestRate: status[i].RequestRate,
+				LastWait:    status[i].LastWait.String(),
+				AverageWait: status[i].AverageWait.String(),
+				MaxWait:     status[i].MaxWait.String(),
+				TokensKnown: status[i].TokensKnown,
+				Tokens:      status[i].Tokens,
+				Limit:       status[i].Limit,
+				Burst:       int64(status[i].Burst),
+				Cost:        int64(status[i].Cost),
+				Exhausting:  status[i].Exhausting,
+			}
+			if !status[i].LastRequest.IsZero() {
+				e.LastRequest = status[i].LastRequest.Format(common.SimpleTimeFormatWithTimezone)
+			}
+			if status[i].Exhausting {
+				e.TimeToExhaustion = status[i].TimeToExhaustion.String()
+			}
+			exchStatus.Endpoints[i] = e
+		}
+		resp.Exchanges = append(resp.Exchanges, exchStatus)
+	}
+	return resp, nil
+}
+
+// GetExchangeCalendar returns upcoming expiries, listings, delistings and
+// maintenance windows from the exchange calendar
+func (s *RPCServer) GetExchangeCalendar(_ context.Context, r *gctrpc.GetExchangeCalendarRequest) (*gctrpc.GetExchangeCalendarResponse, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w GetExchangeCalendarRequest", common.ErrNilPointer)
+	}
+	f := &calendar.Filter{Exchange: r.Exchange}
+	var err error
+	if r.Asset != "" {
+		f.Asset, err = asset.New(r.Asset)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if r.Pair != nil && (r.Pair.Base != "" || r.Pair.Quote != "") {
+		f.Pair, err = currency.NewPairFromStrings(r.Pair.Base, r.Pair.Quote)
+		if err != nil {
+			return nil, err
+		}
+		f.Pair.Delimiter = r.Pair.Delimiter
+	}
+	for _, t := range r.Types {
+		eventType, err := calendar.StringToEventType(t)
+		if err != nil {
+			return nil, err
+		}
+		f.Types = append(f.Types, eventType)
+	}
+	if r.StartDate != "" {
+		f.Start, err = time.Parse(common.SimpleTimeFormatWithTimezone, r.StartDate)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if r.EndDate != "" {
+		f.End, err = time.Parse(common.SimpleTimeFormatWithTimezone, r.EndDate)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !f.Start.IsZero() && !f.End.IsZero() {
+		if err := common.StartEndTimeCheck(f.Start, f.End); err != nil {
+			return nil, err
+		}
+	}
+
+	events, err := s.exchangeCalendar.GetEvents(f)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetExchangeCalendarResponse{Events: make([]*gctrpc.CalendarEvent, len(events))}
+	for i := range events {
+		e := &gctrpc.CalendarEvent{
+			Exchange:    events[i].Exchange,
+			Type:        events[i].Type.String(),
+			Start:       events[i].Start.Format(common.SimpleTimeFormatWithTimezone),
+			Description: events[i].Description,
+		}
+		if events[i].Asset != asset.Empty {
+			e.Asset = events[i].Asset.String()
+		}
+		if !events[i].Pair.IsEmpty() {
+			e.Pair = &gctrpc.CurrencyPair{
+				Delimiter: events[i].Pair.Delimiter,
+				Base:      events[i].Pair.Base.String(),
+				Quote:     events[i].Pair.Quote.String(),
+			}
+		}
+		if !events[i].End.IsZero() {
+			e.End = events[i].End.Format(common.SimpleTimeFormatWithTimezone)
+		}
+		resp.Events[i] = e
+	}
+	return resp, nil
+}
+
+// GetVolumeProfile returns the volume and market profile of each session of
+// stored candles or trades
+func (s *RPCServer) GetVolumeProfile(_ context.Context, r *gctrpc.GetVolumeProfileRequest) (*gctrpc.GetVolumeProfileResponse, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w GetVolumeProfileRequest", common.ErrNilPointer)
+	}
+	if r.Pair == nil {
+		return nil, errCurrencyPairUnset
+	}
+	start, err := time.Parse(common.SimpleTimeFormatWithTimezone, r.Start)
+	if err != nil {
+		return nil, fmt.Errorf("%w cannot parse start time %v", errInvalidTimes, err)
+	}
+	end, err := time.Parse(common.SimpleTimeFormatWithTimezone, r.End)
+	if err != nil {
+		return nil, fmt.Errorf("%w cannot parse end time %v", errInvalidTimes, err)
+	}
+	err = common.StartEndTimeCheck(start, end)
+	if err != nil {
+		return nil, err
+	}
+	pair := currency.Pair{
+		Delimiter: r.Pair.Delimiter,
+		Base:      currency.NewCode(r.Pair.Base),
+		Quote:     currency.NewCode(r.Pair.Quote),
+	}
+	a, err := asset.New(r.AssetType)
+	if err != nil {
+		return nil, err
+	}
+	exch, err := s.GetExchangeByName(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	err = checkParams(r.Exchange, exch, a, pair)
+	if err != nil {
+		return nil, err
+	}
+	valueArea := r.ValueArea
+	if valueArea == 0 {
+		valueArea = kline.DefaultValueArea
+	}
+
+	var profiles []kline.VolumeProfile
+	if r.UseTrades {
+		var trades []trade.Data
+		trades, err = trade.GetTradesInRange(r.Exchange, r.AssetType, r.Pair.Base, r.Pair.Quote, start, end)
+		if err != nil {
+			return nil, err
+		}
+		profiles, err = trade.ConvertTradesToVolumeProfiles(time.Duration(r.Session), time.Duration(r.TpoPeriod), r.TickSize, valueArea, trades...)
+	} else {
+		var klineItem *kline.Item
+		klineItem, err = kline.LoadFromDatabase(r.Exchange, pair, a, kline.Interval(r.TimeInterval), start, end)
+		if err != nil {
+			return nil, err
+		}
+		profiles, err = klineItem.GetVolumeProfiles(time.Duration(r.Session), r.TickSize, valueArea)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &gctrpc.GetVolumeProfileResponse{
+		Exchange:  r.Exchange,
+		Pair:      r.Pair,
+		AssetType: r.AssetType,
+		Profiles:  make([]*gctrpc.VolumeProfile, len(profiles)),
+	}
+	for i := range profiles {
+		p := &gctrpc.VolumeProfile{
+			Start:             profiles[i].Start.UTC().Format(common.SimpleTimeFormatWithTimezone),
+			End:               profiles[i].End.UTC().Format(common.SimpleTimeFormatWithTimezone),
+			TickSize:          profiles[i].TickSize,
+			Levels:            make([]*gctrpc.ProfileLevel, len(profiles[i].Levels)),
+			TotalVolume:       profiles[i].TotalVolume,
+			PointOfControl:    profiles[i].PointOfControl,
+			ValueAreaHigh:     profiles[i].ValueAreaHigh,
+			ValueAreaLow:      profiles[i].ValueAreaLow,
+			ValueAreaVolume:   profiles[i].ValueAreaVolume,
+			TpoPointOfControl: profiles[i].TPOPointOfControl,
+		}
+		for j := range profiles[i].Levels {
+			p.Levels[j] = &gctrpc.ProfileLevel{
+				Price:  profiles[i].Levels[j].Price,
+				Volume: profiles[i].Levels[j].Volume,
+				Tpos:   profiles[i].Levels[j].TPOs,
+			}
+		}
+		resp.Profiles[i] = p
+	}
+	return resp, nil
+}
+
+// GetVolatilityCorrelation returns the historical volatility of each pair's
+// stored candles over the lookback, rolling over a window of returns when one
+// is set, and the correlation matrix of the pairs' returns
+func (s *RPCServer) GetVolatilityCorrelation(_ context.Context, r *gctrpc.GetVolatilityCorrelationRequest) (*gctrpc.GetVolatilityCorrelationResponse, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w GetVolatilityCorrelationRequest", common.ErrNilPointer)
+	}
+	if len(r.Pairs) == 0 {
+		return nil, errCurrencyPairUnset
+	}
+	start, err := time.Parse(common.SimpleTimeFormatWithTimezone, r.Start)
+	if err != nil {
+		return nil, fmt.Errorf("%w cannot parse start time %v", errInvalidTimes, err)
+	}
+	end, err := time.Parse(common.SimpleTimeFormatWithTimezone, r.End)
+	if err != nil {
+		return nil, fmt.Errorf("%w cannot parse end time %v", errInvalidTimes, err)
+	}
+	err = common.StartEndTimeCheck(start, end)
+	if err != nil {
+		return nil, err
+	}
+	a, err := asset.New(r.AssetType)
+	if err != nil {
+		return nil, err
+	}
+	exch, err := s.GetExchangeByName(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &gctrpc.GetVolatilityCorrelationResponse{
+		Exchange:          r.Exchange,
+		AssetType:         r.AssetType,
+		Volatilities:      make([]*gctrpc.PairVolatility, len(r.Pairs)),
+		CorrelationMatrix: make([]*gctrpc.CorrelationRow, len(r.Pairs)),
+	}
+	items := make([]*kline.Item, len(r.Pairs))
+	for i := range r.Pairs {
+		if r.Pairs[i] == nil {
+			return nil, errCurrencyPairUnset
+		}
+		pair := currency.Pair{
+			Delimiter: r.Pairs[i].Delimiter,
+			Base:      currency.NewCode(r.Pairs[i].Base),
+			Quote:     currency.NewCode(r.Pairs[i].Quote),
+		}
+		err = checkParams(r.Exchange, exch, a, pair)
+		if err != nil {
+			return nil, err
+		}
+		items[i], err = kline.LoadFromDatabase(r.Exchange, pair, a, kline.Interval(r.TimeInterval), start, end)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", pair, err)
+		}
+		var lookback []kline.VolatilityPoint
+		lookback, err = items[i].GetHistoricalVolatility(0)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", pair, err)
+		}
+		v := &gctrpc.PairVolatility{
+			Pair:       r.Pairs[i],
+			Volatility: lookback[0].Volatility,
+		}
+		if r.Window > 0 {
+			var rolling []kline.VolatilityPoint
+			rolling, err = items[i].GetHistoricalVolatility(int(r.Window))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", pair, err)
+			}
+			v.Rolling = make([]*gctrpc.VolatilityPoint, len(rolling))
+			for j := range rolling {
+				v.Rolling[j] = &gctrpc.VolatilityPoint{
+					Time:       rolling[j].Time.UTC().Format(common.SimpleTimeFormatWithTimezone),
+					Volatility: rolling[j].Volatility,
+				}
+			}
+		}
+		resp.Volatilities[i] = v
+	}
+
+	matrix, err := kline.CalculateCorrelationMatrix(items...)
+	if err != nil {
+		return nil, err
+	}
+	for i := range matrix.Matrix {
+		resp.CorrelationMatrix[i] = &gctrpc.CorrelationRow{Correlations: matrix.Matrix[i]}
+	}
+	resp.Observations = int64(matrix.Observations)
+	resp.Start = matrix.Start.Format(common.SimpleTimeFormatWithTimezone)
+	resp.End = matrix.End.Format(common.SimpleTimeFormatWithTimezone)
+	return resp, nil
+}
+
+// GetDataQualityScores returns the streamed market data quality score of each
+// monitored exchange, or a single exchange when specified
+func (s *RPCServer) GetDataQualityScores(_ context.Context, r *gctrpc.GetDataQualityScoresRequest) (*gctrpc.GetDataQualityScoresResponse, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w GetDataQualityScoresRequest", common.ErrNilPointer)
+	}
+	if !s.dataQualityMonitor.IsRunning() {
+		return nil, fmt.Errorf("%s %w", DataQualityMonitorName, ErrSubSystemNotStarted)
+	}
+	var scores []DataQualityScore
+	if r.Exchange != "" {
+		score, err := s.dataQualityMonitor.GetScore(r.Exchange)
+		if err != nil {
+			return nil, err
+		}
+		scores = []DataQualityScore{score}
+	} else {
+		var err error
+		scores, err = s.dataQualityMonitor.GetScores()
+		if err != nil {
+			return nil, err
+		}
+	}
+	resp := &gctrpc.GetDataQualityScoresResponse{
+		Scores: make([]*gctrpc.DataQualityScore, len(scores)),
+		Window: s.dataQualityMonitor.window.String(),
+	}
+	for i := range scores {
+		resp.Scores[i] = &gctrpc.DataQualityScore{
+			Exchange:     scores[i].Exchange,
+			Score:        scores[i].Score,
+			Book:         scores[i].Book,
+			Freshness:    scores[i].Freshness,
+			Continuity:   scores[i].Continuity,
+			Stability:    scores[i].Stability,
+			Updates:      scores[i].Updates,
+			Gaps:         scores[i].Gaps,
+			StaleTicks:   scores[i].StaleTicks,
+			CrossedBooks: scores[i].CrossedBooks,
+			Reconnects:   scores[i].Reconnects,
+		}
+	}
+	return resp, nil
+}
+
+// GetDashboardSnapshot returns the requested sections of tickers, balances,
+// open orders, positions, subsystem status and recent events in one call.
+// Only data already held by the engine is returned, exchanges are not queried,
+// and a section which cannot be gathered is reported as a warning
+func (s *RPCServer) GetDashboardSnapshot(ctx context.Context, r *gctrpc.GetDashboardSnapshotRequest) (*gctrpc.GetDashboardSnapshotResponse, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w GetDashboardSnapshotRequest", common.ErrNilPointer)
+	}
+	sections := r.Sections
+	if len(sections) == 0 {
+		sections = dashboardSections
+	}
+	for i := range sections {
+		if !slices.Contains(dashboardSections, strings.ToLower(sections[i])) {
+			return nil, fmt.Errorf("%w %q, must be one of %s", errUnknownDashboardSection, sections[i], strings.Join(dashboardSections, ", "))
+		}
+	}
+	include := func(section string) bool {
+		return slices.ContainsFunc(sections, func(s string) bool { return strings.EqualFold(s, section) })
+	}
+
+	var exchanges []exchange.IBotExchange
+	if len(r.Exchanges) == 0 {
+		exchanges = s.Engine.GetExchanges()
+	} else {
+		exchanges = make([]exchange.IBotExchange, len(r.Exchanges))
+		for i := range r.Exchanges {
+			exch, err := s.GetExchangeByName(r.Exchanges[i])
+			if err != nil {
+				return nil, err
+			}
+			exchanges[i] = exch
+		}
+	}
+	sort.Slice(exchanges, func(i, j int) bool {
+		return exchanges[i].GetName() < exchanges[j].GetName()
+	})
+
+	resp := &gctrpc.GetDashboardSnapshotResponse{Timestamp: s.unixTimestamp(time.Now())}
+	for _, exch := range exchanges {
+		if include(dashboardTickers) {
+			if t := s.snapshotTickers(exch); len(t.Tickers) > 0 {
+				resp.Tickers = append(resp.Tickers, t)
+			}
+		}
+		if include(dashboardBalances) {
+			resp.Balances = append(resp.Balances, snapshotBalances(ctx, exch)...)
+		}
+		if include(dashboardOrders) && s.OrderManager.IsRunning() {
+			active, err := s.OrderManager.GetOrdersActive(&order.Filter{Exchange: exch.GetName()})
+			if err != nil {
+				resp.Warnings = append(resp.Warnings, fmt.Sprintf("%s %s: %v", exch.GetName(), dashboardOrders, err))
+			}
+			for i := range active {
+				resp.OpenOrders = append(resp.OpenOrders, s.orderDetailsResponse(exch.GetName(), active[i].AssetType, &active[i]))
+			}
+		}
+	}
+
+	if include(dashboardOrders) && !s.OrderManager.IsRunning() {
+		resp.Warnings = append(resp.Warnings, fmt.Sprintf("%s: %s %v", dashboardOrders, OrderManagerName, ErrSubSystemNotStarted))
+	}
+
+	if include(dashboardPositions) {
+		positions, err := s.OrderManager.GetAllOpenFuturesPositions()
+		if err != nil && !errors.Is(err, errFuturesTrackingDisabled) {
+			resp.Warnings = append(resp.Warnings, fmt.Sprintf("%s: %v", dashboardPositions, err))
+		}
+		sort.Slice(positions, func(i, j int) bool {
+			return positions[i].OpeningDate.Before(positions[j].OpeningDate)
+		})
+		for i := range positions {
+			if slices.ContainsFunc(exchanges, func(e exchange.IBotExchange) bool { return strings.EqualFold(e.GetName(), positions[i].Exchange) }) {
+				resp.Positions = append(resp.Positions, s.buildFuturePosition(&positions[i], false, false, false, false))
+			}
+		}
+	}
+
+	if include(dashboardSubsystems) {
+		resp.SubsystemsStatus = s.GetSubsystemsStatus()
+	}
+
+	if include(dashboardEvents) {
+		events := s.CommunicationsManager.RecentEvents(0)
+		for i := range events {
+			if events[i].Exchange != "" && !slices.ContainsFunc(exchanges, func(e exchange.IBotExchange) bool { return strings.EqualFold(e.GetName(), events[i].Exchange) }) {
+				continue
+			}
+			if r.EventLimit > 0 && int64(len(resp.Events)) == r.EventLimit {
+				break
+			}
+			resp.Events = append(resp.Events, &gctrpc.DashboardEvent{
+				Timestamp: s.unixTimestamp(events[i].Time),
+				Type:      events[i].Type,
+				Exchange:  events[i].Exchange,
+				Severity:  events[i].Severity.String(),
+				Message:   events[i].Message,
+			})
+		}
+	}
+	return resp, nil
+}
+
+// snapshotTickers returns an exchange's stored tickers for its enabled pairs
+func (s *RPCServer) snapshotTickers(exch exchange.IBotExchange) *gctrpc.Tickers {
+	t := &gctrpc.Tickers{Exchange: exch.GetName()}
+	for _, a := range exch.GetAssetTypes(true) {
+		pairs, err := exch.GetEnabledPairs(a)
+		if err != nil {
+			continue
+		}
+		for i := range pairs {
+			tp, err := ticker.GetTicker(exch.GetName(), pairs[i], a)
+			if err != nil {
+				continue
+			}
+			t.Tickers = append(t.Tickers, s.tickerResponse(tp))
+		}
+	}
+	return t
+}
+
+// snapshotBalances returns an exchange's stored balances for each enabled
+// asset, exchanges without credentials have none
+func snapshotBalances(ctx context.Context, exch exchange.IBotExchange) []*gctrpc.DashboardBalances {
+	creds, err := exch.GetCredentials(ctx)
+	if err != nil {
+		return nil
+	}
+	var balances []*gctrpc.DashboardBalances
+	for _, a := range exch.GetAssetTypes(true) {
+		h, err := account.GetHoldings(exch.GetName(), creds, a)
+		if err != nil {
+			continue
+		}
+		info, err := createAccountInfoRequest(h)
+		if err != nil {
+			continue
+		}
+		balances = append(balances, &gctrpc.DashboardBalances{
+			Exchange: exch.GetName(),
+			Asset:    a.String(),
+			Accounts: info.Accounts,
+		})
+	}
+	return balances
+}
+
+// GetStreamedFundingRates returns the latest perpetual funding rates streamed
+// over exchange websockets, optionally filtered by exchange and asset
+func (s *RPCServer) GetStreamedFundingRates(_ context.Context, r *gctrpc.GetStreamedFundingRatesRequest) (*gctrpc.GetStreamedFundingRatesResponse, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w GetStreamedFundingRatesRequest", common.ErrNilPointer)
+	}
+	if r.Exchange != "" {
+		if _, err := s.GetExchangeByName(r.Exchange); err != nil {
+			return nil, err
+		}
+	}
+	a, err := streamedFundingRateAsset(r.Asset)
+	if err != nil {
+		return nil, err
+	}
+	updates := fundingrate.GetAllLatest(r.Exchange)
+	sort.Slice(updates, func(i, j int) bool {
+		if updates[i].Exchange != updates[j].Exchange {
+			return updates[i].Exchange < updates[j].Exchange
+		}
+		if updates[i].Asset != updates[j].Asset {
+			return updates[i].Asset < updates[j].Asset
+		}
+		return updates[i].Pair.String() < updates[j].Pair.String()
+	})
+	resp := &gctrpc.GetStreamedFundingRatesResponse{Rates: make([]*gctrpc.StreamedFundingRate, 0, len(updates))}
+	for i := range updates {
+		if a != asset.Empty && updates[i].Asset != a {
+			continue
+		}
+		resp.Rates = append(resp.Rates, s.streamedFundingRate(&updates[i]))
+	}
+	return resp, nil
+}
+
+// GetStreamedFundingRateHistory returns the changes to a perpetual's funding
+// rate streamed over an exchange's websocket, oldest first
+func (s *RPCServer) GetStreamedFundingRateHistory(_ context.Context, r *gctrpc.GetStreamedFundingRateHistoryRequest) (*gctrpc.GetStreamedFundingRateHistoryResponse, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w GetStreamedFundingRateHistoryRequest", common.ErrNilPointer)
+	}
+	if r.Pair == nil {
+		return nil, errCurrencyPairUnset
+	}
+	exch, err := s.GetExchangeByName(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	a, err := asset.New(r.Asset)
+	if err != nil {
+		return nil, err
+	}
+	if !a.IsFutures() {
+		return nil, fmt.Errorf("%s %w", a, futures.ErrNotFuturesAsset)
+	}
+	cp, err := exch.MatchSymbolWithAvailablePairs(r.Pair.Base+r.Pair.Quote, a, false)
+	if err != nil {
+		return nil, err
+	}
+	var start time.Time
+	if r.StartDate != "" {
+		start, err = time.Parse(common.SimpleTimeFormatWithTimezone, r.StartDate)
+		if err != nil {
+			return nil, err
+		}
+	}
+	history, err := fundingrate.GetHistory(exch.GetName(), cp, a, start)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetStreamedFundingRateHistoryResponse{Rates: make([]*gctrpc.StreamedFundingRate, len(history))}
+	for i := range history {
+		resp.Rates[i] = s.streamedFundingRate(&history[i])
+	}
+	return resp, nil
+}
+
+// StreamFundingRates streams perpetual funding rates as they are received
+// over exchange websockets, optionally filtered by exchange and asset
+func (s *RPCServer) StreamFundingRates(r *gctrpc.StreamFundingRatesRequest, stream gctrpc.GoCryptoTraderService_StreamFundingRatesServer) error {
+	if r == nil {
+		return fmt.Errorf("%w StreamFundingRatesRequest", common.ErrNilPointer)
+	}
+	a, err := streamedFundingRateAsset(r.Asset)
+	if err != nil {
+		return err
+	}
+	var pipe dispatch.Pipe
+	if r.Exchange == "" {
+		pipe, err = fundingrate.SubscribeToAll()
+	} else {
+		if _, err = s.GetExchangeByName(r.Exchange); err != nil {
+			return err
+		}
+		pipe, err = fundingrate.SubscribeToExchange(r.Exchange)
+	}
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if pipeErr := pipe.Release(); pipeErr != nil {
+			log.Errorln(log.DispatchMgr, pipeErr)
+		}
+	}()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case data, ok := <-pipe.Channel():
+			if !ok {
+				return errDispatchSystem
+			}
+			u, ok := data.(fundingrate.Update)
+			if !ok {
+				return common.GetTypeAssertError("fundingrate.Update", data)
+			}
+			if a != asset.Empty && u.Asset != a {
+				continue
+			}
+			if err := stream.Send(s.streamedFundingRate(&u)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// streamedFundingRateAsset parses an optional futures asset filter
+func streamedFundingRateAsset(assetType string) (asset.Item, error) {
+	if assetType == "" {
+		return asset.Empty, nil
+	}
+	a, err := asset.New(assetType)
+	if err != nil {
+		return asset.Empty, err
+	}
+	if !a.IsFutures() {
+		return asset.Empty, fmt.Errorf("%s %w", a, futures.ErrNotFuturesAsset)
+	}
+	return a, nil
+}
+
+// streamedFundingRate converts a streamed funding rate to its RPC type
+func (s *RPCServer) streamedFundingRate(u *fundingrate.Update) *gctrpc.StreamedFundingRate {
+	return &gctrpc.StreamedFundingRate{
+		Exchange: u.Exchange,
+		Asset:    u.Asset.String(),
+		Pair: &gctrpc.CurrencyPair{
+			Delimiter: u.Pair.Delimiter,
+			Base:      u.Pair.Base.String(),
+			Quote:     u.Pair.Quote.String(),
+		},
+		Rate:           u.Rate.String(),
+		PredictedRate:  u.PredictedRate.String(),
+		TimeOfNextRate: s.unixTimestamp(u.TimeOfNextRate),
+		UpdatedAt:      s.unixTimestamp(u.UpdatedAt),
+	}
+}
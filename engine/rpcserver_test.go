@@ -15,6 +15,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -28,31 +29,40 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/common/convert"
 	"github.com/thrasher-corp/gocryptotrader/common/key"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/database"
 	"github.com/thrasher-corp/gocryptotrader/database/drivers"
 	"github.com/thrasher-corp/gocryptotrader/database/repository"
 	dbexchange "github.com/thrasher-corp/gocryptotrader/database/repository/exchange"
+	fillsql "github.com/thrasher-corp/gocryptotrader/database/repository/fill"
 	sqltrade "github.com/thrasher-corp/gocryptotrader/database/repository/trade"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/binance"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/calendar"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/collateral"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/conversion"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/currencystate"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/earn"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/fundingrate"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/margin"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/options"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/transfer"
 	"github.com/thrasher-corp/gocryptotrader/gctrpc"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
 	"github.com/thrasher-corp/goose"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 )
 
@@ -110,6 +120,10 @@ func (f fExchange) SetMarginType(_ context.Context, _ asset.Item, _ currency.Pai
 	return nil
 }
 
+func (f fExchange) GetMarginType(_ context.Context, _ asset.Item, _ currency.Pair) (margin.Type, error) {
+	return margin.Isolated, nil
+}
+
 func (f fExchange) SetCollateralMode(_ context.Context, _ asset.Item, _ collateral.Mode) error {
 	return nil
 }
@@ -416,6 +430,119 @@ func (f fExchange) GetCurrencyStateSnapshot() ([]currencystate.Snapshot, error)
 	}, nil
 }
 
+// GetTransferNetworks overrides interface function
+func (f fExchange) GetTransferNetworks(context.Context, currency.Code) ([]transfer.Chain, error) {
+	return []transfer.Chain{
+		{Network: transfer.TRC20, Name: "USDT-TRC20", DepositEnabled: true, WithdrawEnabled: true, WithdrawFee: 1, MinWithdrawal: 2, MinDeposit: 0.1},
+	}, nil
+}
+
+// GetConversionQuote overrides interface function
+func (f fExchange) GetConversionQuote(_ context.Context, req *conversion.QuoteRequest) (*conversion.Quote, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return &conversion.Quote{
+		Exchange:   fakeExchangeName,
+		ID:         "1337",
+		From:       req.From,
+		To:         req.To,
+		FromAmount: req.Amount,
+		ToAmount:   req.Amount * 2,
+		Rate:       2,
+		Expires:    time.Now().Add(time.Minute),
+	}, nil
+}
+
+// AcceptConversionQuote overrides interface function
+func (f fExchange) AcceptConversionQuote(_ context.Context, q *conversion.Quote) (*conversion.Result, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+	return &conversion.Result{
+		Exchange:   fakeExchangeName,
+		QuoteID:    q.ID,
+		OrderID:    "1338",
+		From:       q.From,
+		To:         q.To,
+		FromAmount: q.FromAmount,
+		ToAmount:   q.ToAmount,
+		Status:     "SUCCESS",
+		Time:       time.Now(),
+	}, nil
+}
+
+// GetDustBalances overrides interface function
+func (f fExchange) GetDustBalances(context.Context) ([]conversion.DustBalance, error) {
+	return []conversion.DustBalance{
+		{Currency: currency.ADA, Amount: 0.5, Targets: []currency.Code{currency.BNB}, Estimate: 0.001},
+		{Currency: currency.XRP, Amount: 1, Targets: []currency.Code{currency.BNB}, Estimate: 0.002},
+	}, nil
+}
+
+// ConvertDust overrides interface function
+func (f fExchange) ConvertDust(_ context.Context, req *conversion.DustRequest) (*conversion.DustResult, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	result := &conversion.DustResult{Exchange: fakeExchangeName, To: currency.BNB, Time: time.Now()}
+	for i := range req.Currencies {
+		result.Conversions = append(result.Conversions, conversion.DustConversion{Currency: req.Currencies[i], Amount: 1, Converted: 0.001, Fee: 0.0001})
+		result.Total += 0.001
+		result.Fee += 0.0001
+	}
+	return result, nil
+}
+
+// GetEarnProducts overrides interface function
+func (f fExchange) GetEarnProducts(_ context.Context, c currency.Code) ([]earn.Product, error) {
+	products := []earn.Product{
+		{Exchange: fakeExchangeName, ID: "USDT001", Currency: currency.USDT, Type: earn.Flexible, APR: 0.05, Available: true},
+		{Exchange: fakeExchangeName, ID: "ETH90", Currency: currency.ETH, Type: earn.Locked, APR: 0.04, Duration: time.Hour * 24 * 90, MinAmount: 0.1, Available: true},
+	}
+	if c.IsEmpty() {
+		return products, nil
+	}
+	var filtered []earn.Product
+	for i := range products {
+		if products[i].Currency.Equal(c) {
+			filtered = append(filtered, products[i])
+		}
+	}
+	return filtered, nil
+}
+
+// GetEarnPositions overrides interface function
+func (f fExchange) GetEarnPositions(context.Context) ([]earn.Position, error) {
+	return []earn.Position{
+		{Exchange: fakeExchangeName, ProductID: "USDT001", Currency: currency.USDT, Type: earn.Flexible, Amount: 100, APR: 0.05},
+		{Exchange: fakeExchangeName, ProductID: "ETH90", PositionID: "1337", Currency: currency.ETH, Type: earn.Locked, Amount: 1, APR: 0.04, Matures: time.Now().Add(time.Hour)},
+	}, nil
+}
+
+// SubscribeEarnProduct overrides interface function
+func (f fExchange) SubscribeEarnProduct(_ context.Context, req *earn.SubscribeRequest) (*earn.Result, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return &earn.Result{Exchange: fakeExchangeName, ProductID: req.ProductID, ID: "1338", Currency: req.Currency, Amount: req.Amount, Success: true, Time: time.Now()}, nil
+}
+
+// RedeemEarnProduct overrides interface function
+func (f fExchange) RedeemEarnProduct(_ context.Context, req *earn.RedeemRequest) (*earn.Result, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return &earn.Result{Exchange: fakeExchangeName, ProductID: req.ProductID, ID: "1339", Currency: req.Currency, Amount: req.Amount, Success: true}, nil
+}
+
+// GetEarnRewards overrides interface function
+func (f fExchange) GetEarnRewards(_ context.Context, start, _ time.Time) ([]earn.Reward, error) {
+	return []earn.Reward{
+		{Exchange: fakeExchangeName, ProductID: "USDT001", Currency: currency.USDT, Amount: 0.01, Time: start.Add(time.Hour)},
+	}, nil
+}
+
 // CanTradePair overrides interface function
 func (f fExchange) CanTradePair(_ currency.Pair, _ asset.Item) error {
 	return nil
@@ -3825,7 +3952,11 @@ func TestSetLeverage(t *testing.T) {
 		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
 	}
 
-	s := RPCServer{Engine: &Engine{ExchangeManager: em}}
+	om, err := SetupOrderManager(em, &CommunicationManager{}, &sync.WaitGroup{}, &config.OrderManager{
+		MaxLeverage: map[string]float64{asset.USDTMarginedFutures.String(): 2000},
+	})
+	require.NoError(t, err)
+	s := RPCServer{Engine: &Engine{ExchangeManager: em, OrderManager: om}}
 	_, err = s.SetLeverage(context.Background(), nil)
 	if !errors.Is(err, common.ErrNilPointer) {
 		t.Errorf("received '%v' expected '%v'", err, common.ErrNilPointer)
@@ -3867,6 +3998,10 @@ func TestSetLeverage(t *testing.T) {
 	if !errors.Is(err, nil) {
 		t.Error(err)
 	}
+
+	req.Leverage = 2001
+	_, err = s.SetLeverage(context.Background(), req)
+	assert.ErrorIs(t, err, errLeverageAboveMax, "leverage above the configured max should be rejected")
 }
 
 func TestGetLeverage(t *testing.T) {
@@ -4009,6 +4144,60 @@ func TestSetMarginType(t *testing.T) {
 	}
 }
 
+func TestGetMarginType(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	exch, err := em.NewExchangeByName("binance")
+	if err != nil {
+		t.Fatal(err)
+	}
+	exch.SetDefaults()
+	b := exch.GetBase()
+	b.Name = fakeExchangeName
+	b.Enabled = true
+
+	cp, err := currency.NewPairFromString("btc-mad")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b.CurrencyPairs.Pairs = make(map[asset.Item]*currency.PairStore)
+	b.CurrencyPairs.Pairs[asset.USDTMarginedFutures] = &currency.PairStore{
+		AssetEnabled:  convert.BoolPtr(true),
+		ConfigFormat:  &currency.PairFormat{Delimiter: "/"},
+		RequestFormat: &currency.PairFormat{Delimiter: "/"},
+		Available:     currency.Pairs{cp},
+		Enabled:       currency.Pairs{cp},
+	}
+
+	fakeExchange := fExchange{
+		IBotExchange: exch,
+	}
+	err = em.Add(fakeExchange)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+
+	s := RPCServer{Engine: &Engine{ExchangeManager: em}}
+	_, err = s.GetMarginType(context.Background(), nil)
+	assert.ErrorIs(t, err, common.ErrNilPointer)
+
+	req := &gctrpc.GetMarginTypeRequest{}
+	_, err = s.GetMarginType(context.Background(), req)
+	assert.ErrorIs(t, err, currency.ErrCurrencyPairEmpty)
+
+	req.Exchange = fakeExchangeName
+	req.Pair = &gctrpc.CurrencyPair{
+		Delimiter: "-",
+		Base:      cp.Base.String(),
+		Quote:     cp.Quote.String(),
+	}
+	req.Asset = asset.USDTMarginedFutures.String()
+	resp, err := s.GetMarginType(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, margin.Isolated.String(), resp.MarginType)
+}
+
 func TestSetCollateralMode(t *testing.T) {
 	t.Parallel()
 	em := NewExchangeManager()
@@ -4290,3 +4479,1153 @@ func TestRPCProxyAuthClient(t *testing.T) {
 		})
 	}
 }
+
+// greeksExchange is a fake exchange returning fixed mark and index prices
+type greeksExchange struct {
+	exchange.IBotExchange
+	tickers map[string]*ticker.Price
+}
+
+func (g *greeksExchange) GetName() string {
+	return "greeks"
+}
+
+func (g *greeksExchange) FetchTicker(_ context.Context, p currency.Pair, _ asset.Item) (*ticker.Price, error) {
+	t, ok := g.tickers[p.String()]
+	if !ok {
+		return nil, errExpectedTestError
+	}
+	return t, nil
+}
+
+func TestGetPortfolioGreeks(t *testing.T) {
+	t.Parallel()
+	expiry := time.Now().UTC().AddDate(0, 0, 30)
+	call := currency.NewPair(currency.BTC, currency.NewCode("USD-"+expiry.Format("060102")+"-60000-C"))
+	put := currency.NewPair(currency.BTC, currency.NewCode("USD-"+expiry.Format("060102")+"-50000-P"))
+	ethCall := currency.NewPair(currency.ETH, currency.NewCode("USD-"+expiry.Format("060102")+"-3000-C"))
+	closed := currency.NewPair(currency.BTC, currency.NewCode("USD-"+expiry.Format("060102")+"-70000-C"))
+	unparsable := currency.NewPair(currency.BTC, currency.NewCode("USD-OPTION"))
+
+	callParams := &options.Params{Type: options.Call, Underlying: 60000, Strike: 60000, TimeToExpiry: options.TimeToExpiry(expiry.Truncate(time.Hour*24).Add(time.Hour*8), time.Now()), Volatility: 0.5}
+	callPrice, err := options.Price(callParams)
+	require.NoError(t, err)
+	exch := &greeksExchange{tickers: map[string]*ticker.Price{
+		call.String():    {MarkPrice: callPrice, IndexPrice: 60000},
+		put.String():     {Last: 200, IndexPrice: 60000},
+		ethCall.String(): {MarkPrice: 100},
+	}}
+	em := NewExchangeManager()
+	require.NoError(t, em.Add(exch))
+	var wg sync.WaitGroup
+	om, err := SetupOrderManager(em, &CommunicationManager{}, &wg, &config.OrderManager{})
+	require.NoError(t, err)
+	om.started = 1
+	for i, o := range []order.Detail{
+		{Pair: call, Side: order.Buy, ExecutedAmount: 3},
+		{Pair: call, Side: order.Sell, ExecutedAmount: 1},
+		{Pair: put, Side: order.Sell, ExecutedAmount: 1},
+		{Pair: ethCall, Side: order.Buy, ExecutedAmount: 1},
+		{Pair: closed, Side: order.Buy, ExecutedAmount: 1},
+		{Pair: closed, Side: order.Sell, ExecutedAmount: 1},
+		{Pair: unparsable, Side: order.Buy, ExecutedAmount: 1},
+		{Pair: call, Side: order.Buy},
+	} {
+		o.Exchange = exch.GetName()
+		o.AssetType = asset.Options
+		o.OrderID = strconv.Itoa(i)
+		require.NoError(t, om.orderStore.add(&o))
+	}
+	s := RPCServer{Engine: &Engine{ExchangeManager: em, OrderManager: om}}
+
+	_, err = s.GetPortfolioGreeks(context.Background(), nil)
+	assert.ErrorIs(t, err, common.ErrNilPointer)
+
+	resp, err := s.GetPortfolioGreeks(context.Background(), &gctrpc.GetPortfolioGreeksRequest{})
+	require.NoError(t, err)
+	assert.Len(t, resp.Warnings, 2, "unparsable contracts and missing index prices should be reported")
+	require.Len(t, resp.Underlyings, 1)
+	btc := resp.Underlyings[0]
+	assert.Equal(t, "BTC", btc.Underlying)
+	require.Len(t, btc.Positions, 2, "closed positions should be ignored")
+	callGreeks := btc.Positions[0]
+	assert.Equal(t, 2.0, callGreeks.Size, "option orders should be netted")
+	assert.InDelta(t, 0.5, callGreeks.ImpliedVolatility, 1e-4)
+	assert.Equal(t, "call", callGreeks.OptionType)
+	assert.Greater(t, callGreeks.Delta, 1.0)
+	assert.Greater(t, callGreeks.Gamma, 0.0)
+	assert.Less(t, callGreeks.Theta, 0.0)
+	putGreeks := btc.Positions[1]
+	assert.Equal(t, -1.0, putGreeks.Size)
+	assert.Positive(t, putGreeks.Delta, "short put should have positive delta")
+	assert.Equal(t, 200.0, putGreeks.MarkPrice, "last price should be used without a mark price")
+	assert.InDelta(t, callGreeks.Delta+putGreeks.Delta, btc.Delta, 1e-9)
+	assert.InDelta(t, btc.Delta*60000, btc.DeltaNotional, 1e-6)
+
+	resp, err = s.GetPortfolioGreeks(context.Background(), &gctrpc.GetPortfolioGreeksRequest{Underlying: "eth"})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Underlyings)
+	assert.Len(t, resp.Warnings, 2)
+
+	resp, err = s.GetPortfolioGreeks(context.Background(), &gctrpc.GetPortfolioGreeksRequest{Exchange: "other"})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Underlyings)
+	assert.Empty(t, resp.Warnings)
+}
+
+func TestPositionGreeksFutures(t *testing.T) {
+	t.Parallel()
+	perp := currency.NewPair(currency.BTC, currency.USDT)
+	exch := &greeksExchange{tickers: map[string]*ticker.Price{perp.String(): {Last: 60000}}}
+	em := NewExchangeManager()
+	require.NoError(t, em.Add(exch))
+	s := RPCServer{Engine: &Engine{ExchangeManager: em}}
+	pg, err := s.positionGreeks(context.Background(), &greekPosition{exchange: exch.GetName(), asset: asset.USDTMarginedFutures, pair: perp, size: -2}, 0, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, -2.0, pg.Delta, "futures delta should be the position size")
+	assert.Equal(t, 60000.0, pg.UnderlyingPrice, "mark price should be used without an index price")
+	assert.Zero(t, pg.Gamma)
+
+	_, err = s.positionGreeks(context.Background(), &greekPosition{exchange: exch.GetName(), pair: currency.NewPair(currency.ETH, currency.USDT)}, 0, time.Now())
+	assert.ErrorIs(t, err, errExpectedTestError)
+}
+
+func TestSimulatePortfolioMargin(t *testing.T) {
+	t.Parallel()
+	expiry := time.Now().UTC().AddDate(0, 0, 30)
+	call := currency.NewPair(currency.BTC, currency.NewCode("USD-"+expiry.Format("060102")+"-60000-C"))
+	perp := currency.NewPair(currency.BTC, currency.USD)
+	callPrice, err := options.Price(&options.Params{Type: options.Call, Underlying: 60000, Strike: 60000, TimeToExpiry: options.TimeToExpiry(expiry.Truncate(time.Hour*24).Add(time.Hour*8), time.Now()), Volatility: 0.5})
+	require.NoError(t, err)
+	exch := &greeksExchange{tickers: map[string]*ticker.Price{
+		call.String(): {MarkPrice: callPrice, IndexPrice: 60000},
+		perp.String(): {MarkPrice: 60000, IndexPrice: 60000},
+	}}
+	em := NewExchangeManager()
+	require.NoError(t, em.Add(exch))
+	var wg sync.WaitGroup
+	om, err := SetupOrderManager(em, &CommunicationManager{}, &wg, &config.OrderManager{})
+	require.NoError(t, err)
+	om.started = 1
+	require.NoError(t, om.orderStore.add(&order.Detail{Exchange: exch.GetName(), AssetType: asset.Options, Pair: call, Side: order.Sell, ExecutedAmount: 1, OrderID: "1"}))
+	s := RPCServer{Engine: &Engine{ExchangeManager: em, OrderManager: om}}
+
+	_, err = s.SimulatePortfolioMargin(context.Background(), nil)
+	assert.ErrorIs(t, err, common.ErrNilPointer)
+	req := &gctrpc.SimulatePortfolioMarginRequest{Exchange: exch.GetName(), Asset: asset.Options.String(), Side: order.Sell.String(), Amount: 1}
+	_, err = s.SimulatePortfolioMargin(context.Background(), req)
+	assert.ErrorIs(t, err, errCurrencyPairUnset)
+	req.Pair = &gctrpc.CurrencyPair{Base: call.Base.String(), Quote: call.Quote.String()}
+	req.Asset = asset.Spot.String()
+	_, err = s.SimulatePortfolioMargin(context.Background(), req)
+	assert.ErrorIs(t, err, asset.ErrNotSupported)
+	req.Asset = asset.Options.String()
+	_, err = s.SimulatePortfolioMargin(context.Background(), req)
+	assert.ErrorIs(t, err, options.ErrUnknownPortfolioModel, "the exchange name should be used without a model")
+	req.Model = "deribit"
+
+	resp, err := s.SimulatePortfolioMargin(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "deribit", resp.Model)
+	assert.Equal(t, "BTC", resp.Underlying)
+	assert.Equal(t, 60000.0, resp.UnderlyingPrice)
+	assert.Zero(t, resp.Before.InitialMargin, "held positions should be excluded by default")
+	assert.InDelta(t, 0.16, resp.After.WorstPriceMove, 1e-9)
+	assert.InDelta(t, resp.After.InitialMargin, resp.IncrementalInitialMargin, 1e-9)
+	assert.Zero(t, resp.MaxAmount, "max amount should only be searched with available margin")
+
+	req.IncludePositions = true
+	req.AvailableMargin = resp.After.InitialMargin * 3
+	resp, err = s.SimulatePortfolioMargin(context.Background(), req)
+	require.NoError(t, err)
+	assert.Positive(t, resp.Before.InitialMargin, "held short call should require margin")
+	assert.InDelta(t, resp.Before.InitialMargin, resp.IncrementalInitialMargin, 1e-6)
+	assert.InDelta(t, 2, resp.MaxAmount, 1e-6)
+
+	resp, err = s.SimulatePortfolioMargin(context.Background(), &gctrpc.SimulatePortfolioMarginRequest{
+		Exchange:         exch.GetName(),
+		Model:            "okx",
+		Pair:             &gctrpc.CurrencyPair{Base: perp.Base.String(), Quote: perp.Quote.String()},
+		Asset:            asset.PerpetualContract.String(),
+		Side:             order.Buy.String(),
+		Amount:           0.5,
+		IncludePositions: true,
+	})
+	require.NoError(t, err)
+	assert.Negative(t, resp.IncrementalInitialMargin, "buying a perpetual should hedge the short call")
+}
+
+// fillStream is a fake StreamFills server stream recording sent fills
+type fillStream struct {
+	gctrpc.GoCryptoTraderService_StreamFillsServer
+	ctx  context.Context
+	sent []*gctrpc.FillResponse
+}
+
+func (f *fillStream) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fillStream) Send(r *gctrpc.FillResponse) error {
+	f.sent = append(f.sent, r)
+	return nil
+}
+
+func TestStreamFills(t *testing.T) {
+	t.Parallel()
+	s := RPCServer{Engine: &Engine{Config: &config.Config{}}}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+	stream := &fillStream{ctx: ctx}
+	assert.ErrorIs(t, s.StreamFills(nil, stream), common.ErrNilPointer)
+	assert.ErrorIs(t, s.StreamFills(&gctrpc.StreamFillsRequest{}, stream), ErrSubSystemNotStarted)
+
+	m, _ := testFillSyncManager(t, true)
+	m.started = 1
+	for _, f := range []fillsql.Data{
+		{Exchange: testExchange, AssetType: asset.Spot.String(), Base: "BTC", Quote: "USDT", TradeID: "1", Timestamp: time.Unix(1700000000, 0)},
+		{Exchange: "other", AssetType: asset.Spot.String(), TradeID: "2"},
+		{Exchange: testExchange, AssetType: asset.Futures.String(), TradeID: "3"},
+		{Exchange: testExchange, AssetType: asset.Spot.String(), TradeID: "4"},
+	} {
+		_, err := m.save([]fillsql.Data{f})
+		require.NoError(t, err)
+	}
+	s.fillSyncManager = m
+	assert.ErrorIs(t, s.StreamFills(&gctrpc.StreamFillsRequest{ResumeToken: -1}, stream), errInvalidResumeToken)
+	assert.ErrorIs(t, s.StreamFills(&gctrpc.StreamFillsRequest{Asset: "fake"}, stream), asset.ErrNotSupported)
+
+	err := s.StreamFills(&gctrpc.StreamFillsRequest{Exchange: strings.ToUpper(testExchange), Asset: "spot"}, stream)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Len(t, stream.sent, 2, "fills should be filtered by exchange and asset")
+	assert.Equal(t, int64(1), stream.sent[0].ResumeToken)
+	assert.Equal(t, "1", stream.sent[0].TradeId)
+	assert.Equal(t, "BTC", stream.sent[0].Pair.Base)
+	assert.Equal(t, int64(1700000000), stream.sent[0].Timestamp)
+	assert.Equal(t, int64(4), stream.sent[1].ResumeToken)
+
+	stream.sent = nil
+	ctx, cancel = context.WithTimeout(context.Background(), time.Millisecond*100)
+	defer cancel()
+	stream.ctx = ctx
+	assert.ErrorIs(t, s.StreamFills(&gctrpc.StreamFillsRequest{ResumeToken: 3}, stream), context.DeadlineExceeded)
+	require.Len(t, stream.sent, 1, "only fills after the resume token should be replayed")
+	assert.Equal(t, "4", stream.sent[0].TradeId)
+}
+
+func TestCancelAllOrdersScoped(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	exch, err := em.NewExchangeByName("Binance")
+	require.NoError(t, err)
+	exch.SetDefaults()
+	b := exch.GetBase()
+	b.Enabled = true
+	cp := currency.NewPair(currency.BTC, currency.USDT)
+	b.CurrencyPairs.Pairs = map[asset.Item]*currency.PairStore{
+		asset.Spot: {
+			Available:     currency.Pairs{cp},
+			Enabled:       currency.Pairs{cp},
+			AssetEnabled:  convert.BoolPtr(true),
+			ConfigFormat:  &currency.PairFormat{Uppercase: true},
+			RequestFormat: &currency.PairFormat{Uppercase: true},
+		},
+	}
+	require.NoError(t, em.Add(&cancelScopeExchange{omfExchange: omfExchange{IBotExchange: exch}}))
+	om, err := SetupOrderManager(em, &CommunicationManager{}, &sync.WaitGroup{}, &config.OrderManager{})
+	require.NoError(t, err)
+	om.started = 1
+	require.NoError(t, om.orderStore.add(&order.Detail{Exchange: "Binance", OrderID: "1", AssetType: asset.Spot, Pair: cp, Side: order.Buy, Type: order.Limit, Amount: 1, Status: order.New, StrategyTag: "grid"}))
+	require.NoError(t, om.orderStore.add(&order.Detail{Exchange: "Binance", OrderID: "2", AssetType: asset.Spot, Pair: cp, Side: order.Sell, Type: order.Limit, Amount: 1, Status: order.New, StrategyTag: "grid"}))
+	s := RPCServer{Engine: &Engine{ExchangeManager: em, OrderManager: om}}
+
+	_, err = s.CancelAllOrders(context.Background(), &gctrpc.CancelAllOrdersRequest{Exchange: "Binance", Asset: "bad"})
+	assert.ErrorIs(t, err, asset.ErrNotSupported)
+	_, err = s.CancelAllOrders(context.Background(), &gctrpc.CancelAllOrdersRequest{Exchange: "Binance", Side: "sideways"})
+	assert.ErrorIs(t, err, order.ErrSideIsInvalid)
+
+	resp, err := s.CancelAllOrders(context.Background(), &gctrpc.CancelAllOrdersRequest{
+		Exchange:    "Binance",
+		Asset:       asset.Spot.String(),
+		Pair:        &gctrpc.CurrencyPair{Base: "BTC", Quote: "USDT"},
+		Side:        order.Buy.String(),
+		StrategyTag: "grid",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), resp.Count)
+	require.Len(t, resp.Results, 1, "only the buy order should be cancelled")
+	assert.Equal(t, "1", resp.Results[0].OrderId)
+	assert.True(t, resp.Results[0].Cancelled)
+	assert.Equal(t, "grid", resp.Results[0].StrategyTag)
+	require.Len(t, resp.Orders, 1)
+	assert.Equal(t, order.Cancelled.String(), resp.Orders[0].OrderStatus["1"])
+}
+
+func TestGetScheduledTasks(t *testing.T) {
+	t.Parallel()
+	s := RPCServer{Engine: &Engine{}}
+	_, err := s.GetScheduledTasks(context.Background(), nil)
+	assert.ErrorIs(t, err, ErrNilSubsystem)
+
+	s.scheduler, err = SetupScheduler(testSchedulerConfig(config.TaskOverlapSkip), map[string]TaskFunc{
+		"test": func(context.Context, map[string]string) error { return errTaskTest },
+	})
+	require.NoError(t, err)
+	s.scheduler.ctx, s.scheduler.cancel = context.WithCancel(context.Background())
+	defer s.scheduler.cancel()
+	now := time.Now()
+	s.scheduler.tasks[0].nextRun = now
+	s.scheduler.runDue(now)
+	s.scheduler.wg.Wait()
+
+	resp, err := s.GetScheduledTasks(context.Background(), nil)
+	require.NoError(t, err)
+	require.Len(t, resp.Tasks, 1)
+	assert.Equal(t, "test", resp.Tasks[0].Name)
+	assert.NotEmpty(t, resp.Tasks[0].NextRun, "NextRun should be set")
+	require.Len(t, resp.Tasks[0].History, 1)
+	assert.Equal(t, errTaskTest.Error(), resp.Tasks[0].History[0].Error)
+}
+
+func TestGetRateLimitStatus(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	exch, err := em.NewExchangeByName("binance")
+	require.NoError(t, err)
+	exch.SetDefaults()
+	b := exch.GetBase()
+	b.Enabled = true
+	require.NoError(t, em.Add(exch))
+
+	s := RPCServer{Engine: &Engine{ExchangeManager: em}}
+	_, err = s.GetRateLimitStatus(context.Background(), nil)
+	assert.ErrorIs(t, err, common.ErrNilPointer)
+	_, err = s.GetRateLimitStatus(context.Background(), &gctrpc.GetRateLimitStatusRequest{Exchange: "bob"})
+	assert.ErrorIs(t, err, ErrExchangeNotFound)
+
+	require.NoError(t, b.Requester.InitiateRateLimit(context.Background(), request.Unset))
+	resp, err := s.GetRateLimitStatus(context.Background(), &gctrpc.GetRateLimitStatusRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Exchanges, 1)
+	assert.Equal(t, b.Name, resp.Exchanges[0].Exchange)
+	require.Len(t, resp.Exchanges[0].Endpoints, 1)
+	e := resp.Exchanges[0].Endpoints[0]
+	assert.Equal(t, int64(1), e.Requests)
+	assert.NotEmpty(t, e.LastRequest, "LastRequest should be set")
+	assert.True(t, e.TokensKnown, "binance should report its tokens")
+	assert.Positive(t, e.Limit)
+	assert.Equal(t, int64(1), e.Cost)
+}
+
+func TestGetExchangeCalendar(t *testing.T) {
+	t.Parallel()
+	m, _, _ := testExchangeCalendarSetup(t)
+	s := RPCServer{Engine: &Engine{exchangeCalendar: m}}
+	_, err := s.GetExchangeCalendar(context.Background(), nil)
+	assert.ErrorIs(t, err, common.ErrNilPointer)
+	_, err = s.GetExchangeCalendar(context.Background(), &gctrpc.GetExchangeCalendarRequest{})
+	assert.ErrorIs(t, err, ErrSubSystemNotStarted)
+
+	m.refresh(context.Background())
+	m.started = 1
+	_, err = s.GetExchangeCalendar(context.Background(), &gctrpc.GetExchangeCalendarRequest{Asset: "bob"})
+	assert.ErrorIs(t, err, asset.ErrNotSupported)
+	_, err = s.GetExchangeCalendar(context.Background(), &gctrpc.GetExchangeCalendarRequest{Types: []string{"bob"}})
+	assert.ErrorIs(t, err, calendar.ErrInvalidEventType)
+
+	resp, err := s.GetExchangeCalendar(context.Background(), &gctrpc.GetExchangeCalendarRequest{
+		Exchange: "calendar",
+		Asset:    "spot",
+		Pair:     &gctrpc.CurrencyPair{Base: "BTC", Quote: "USDT"},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Events, 2)
+	assert.Equal(t, "maintenance", resp.Events[0].Type)
+	assert.Empty(t, resp.Events[0].Asset, "exchange wide events should not have an asset")
+	assert.NotEmpty(t, resp.Events[0].End, "maintenance windows should have an end")
+	assert.Equal(t, "delisting", resp.Events[1].Type)
+	assert.Equal(t, "spot", resp.Events[1].Asset)
+	require.NotNil(t, resp.Events[1].Pair)
+	assert.Equal(t, "USDT", resp.Events[1].Pair.Quote)
+	assert.Empty(t, resp.Events[1].End)
+
+	resp, err = s.GetExchangeCalendar(context.Background(), &gctrpc.GetExchangeCalendarRequest{Types: []string{"expiry"}})
+	require.NoError(t, err)
+	assert.Len(t, resp.Events, 2)
+}
+
+func TestGetVolumeProfile(t *testing.T) {
+	engerino := RPCTestSetup(t)
+	defer CleanRPCTest(t, engerino)
+	s := RPCServer{Engine: engerino}
+	_, err := s.GetVolumeProfile(context.Background(), nil)
+	assert.ErrorIs(t, err, common.ErrNilPointer)
+	_, err = s.GetVolumeProfile(context.Background(), &gctrpc.GetVolumeProfileRequest{})
+	assert.ErrorIs(t, err, errCurrencyPairUnset)
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	req := &gctrpc.GetVolumeProfileRequest{
+		Exchange: testExchange,
+		Pair: &gctrpc.CurrencyPair{
+			Delimiter: currency.DashDelimiter,
+			Base:      currency.BTC.String(),
+			Quote:     currency.USD.String(),
+		},
+		AssetType: asset.Spot.String(),
+		Start:     start.Format(common.SimpleTimeFormatWithTimezone),
+		End:       start.Add(time.Hour * 2).Format(common.SimpleTimeFormatWithTimezone),
+		Session:   int64(time.Hour),
+		TickSize:  1,
+		UseTrades: true,
+	}
+	_, err = s.GetVolumeProfile(context.Background(), &gctrpc.GetVolumeProfileRequest{Pair: req.Pair, Start: "bad"})
+	assert.ErrorIs(t, err, errInvalidTimes)
+	_, err = s.GetVolumeProfile(context.Background(), req)
+	assert.ErrorIs(t, err, trade.ErrNoTradesSupplied)
+
+	for i, price := range []float64{1337, 1337, 1338, 1336} {
+		err = sqltrade.Insert(sqltrade.Data{
+			Timestamp: start.Add(time.Minute * time.Duration(i+1)),
+			Exchange:  testExchange,
+			Base:      currency.BTC.String(),
+			Quote:     currency.USD.String(),
+			AssetType: asset.Spot.String(),
+			Price:     price,
+			Amount:    1,
+			Side:      order.Buy.String(),
+		})
+		require.NoError(t, err, "Insert must not error")
+	}
+	resp, err := s.GetVolumeProfile(context.Background(), req)
+	require.NoError(t, err, "GetVolumeProfile must not error")
+	require.Len(t, resp.Profiles, 1, "GetVolumeProfile must return one profile")
+	assert.Equal(t, start.Format(common.SimpleTimeFormatWithTimezone), resp.Profiles[0].Start, "Start should be the session start")
+	assert.Len(t, resp.Profiles[0].Levels, 3, "Levels should contain each traded price")
+	assert.Equal(t, 1337.0, resp.Profiles[0].PointOfControl, "PointOfControl should be the most traded price")
+	assert.Equal(t, 4.0, resp.Profiles[0].TotalVolume, "TotalVolume should be the total amount traded")
+
+	req.UseTrades = false
+	req.TimeInterval = int64(kline.OneMin)
+	_, err = s.GetVolumeProfile(context.Background(), req)
+	assert.Error(t, err, "GetVolumeProfile should error without stored candles")
+}
+
+func TestGetVolatilityCorrelation(t *testing.T) {
+	engerino := RPCTestSetup(t)
+	defer CleanRPCTest(t, engerino)
+	s := RPCServer{Engine: engerino}
+	_, err := s.GetVolatilityCorrelation(context.Background(), nil)
+	assert.ErrorIs(t, err, common.ErrNilPointer)
+	_, err = s.GetVolatilityCorrelation(context.Background(), &gctrpc.GetVolatilityCorrelationRequest{})
+	assert.ErrorIs(t, err, errCurrencyPairUnset)
+
+	exch, err := s.GetExchangeByName(testExchange)
+	require.NoError(t, err, "GetExchangeByName must not error")
+	eth := currency.NewPair(currency.ETH, currency.USD)
+	ps := exch.GetBase().CurrencyPairs.Pairs[asset.Spot]
+	ps.Available = append(ps.Available, eth)
+	ps.Enabled = append(ps.Enabled, eth)
+
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	req := &gctrpc.GetVolatilityCorrelationRequest{
+		Exchange: testExchange,
+		Pairs: []*gctrpc.CurrencyPair{
+			{Delimiter: currency.DashDelimiter, Base: currency.BTC.String(), Quote: currency.USD.String()},
+			{Delimiter: currency.DashDelimiter, Base: currency.ETH.String(), Quote: currency.USD.String()},
+		},
+		AssetType:    asset.Spot.String(),
+		Start:        start.Format(common.SimpleTimeFormatWithTimezone),
+		End:          start.Add(time.Hour * 5).Format(common.SimpleTimeFormatWithTimezone),
+		TimeInterval: int64(kline.OneHour),
+		Window:       2,
+	}
+	_, err = s.GetVolatilityCorrelation(context.Background(), &gctrpc.GetVolatilityCorrelationRequest{Pairs: req.Pairs, Start: "bad"})
+	assert.ErrorIs(t, err, errInvalidTimes)
+	_, err = s.GetVolatilityCorrelation(context.Background(), req)
+	assert.Error(t, err, "GetVolatilityCorrelation should error without stored candles")
+
+	for p, closes := range map[currency.Pair][]float64{
+		currency.NewPair(currency.BTC, currency.USD): {100, 110, 99, 108.9, 98.01},
+		eth: {10, 11, 9.9, 10.89, 9.801},
+	} {
+		item := &kline.Item{Exchange: testExchange, Pair: p, Asset: asset.Spot, Interval: kline.OneHour}
+		for i := range closes {
+			item.Candles = append(item.Candles, kline.Candle{
+				Time:   start.Add(time.Hour * time.Duration(i)),
+				Open:   closes[i],
+				High:   closes[i],
+				Low:    closes[i],
+				Close:  closes[i],
+				Volume: 1,
+			})
+		}
+		_, err = kline.StoreInDatabase(item, false)
+		require.NoError(t, err, "StoreInDatabase must not error")
+	}
+	resp, err := s.GetVolatilityCorrelation(context.Background(), req)
+	require.NoError(t, err, "GetVolatilityCorrelation must not error")
+	require.Len(t, resp.Volatilities, 2, "GetVolatilityCorrelation must return each pair's volatility")
+	assert.Positive(t, resp.Volatilities[0].Volatility, "Volatility should be set over the lookback")
+	assert.Len(t, resp.Volatilities[0].Rolling, 3, "Rolling should contain a point for each full window")
+	require.Len(t, resp.CorrelationMatrix, 2, "GetVolatilityCorrelation must return a row for each pair")
+	assert.InDelta(t, 1, resp.CorrelationMatrix[0].Correlations[1], 1e-9, "pairs moving in proportion should be perfectly correlated")
+	assert.Equal(t, int64(4), resp.Observations)
+}
+
+func TestGetTransferNetworks(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	exch, err := em.NewExchangeByName("binance")
+	require.NoError(t, err)
+	exch.SetDefaults()
+	exch.GetBase().Name = fakeExchangeName
+	require.NoError(t, em.Add(fExchange{IBotExchange: exch}))
+	s := RPCServer{Engine: &Engine{ExchangeManager: em}}
+
+	_, err = s.GetTransferNetworks(context.Background(), &gctrpc.GetTransferNetworksRequest{})
+	assert.ErrorIs(t, err, ErrExchangeNameIsEmpty)
+	_, err = s.GetTransferNetworks(context.Background(), &gctrpc.GetTransferNetworksRequest{Exchange: fakeExchangeName})
+	assert.ErrorIs(t, err, errCurrencyNotSpecified)
+	resp, err := s.GetTransferNetworks(context.Background(), &gctrpc.GetTransferNetworksRequest{Exchange: fakeExchangeName, Cryptocurrency: "usdt"})
+	require.NoError(t, err)
+	require.Len(t, resp.Networks, 1)
+	assert.Equal(t, "TRC20", resp.Networks[0].Network)
+	assert.Equal(t, "USDT-TRC20", resp.Networks[0].Chain)
+	assert.True(t, resp.Networks[0].DepositEnabled)
+	assert.True(t, resp.Networks[0].WithdrawEnabled)
+	assert.Equal(t, 1.0, resp.Networks[0].WithdrawFee)
+	assert.Equal(t, 2.0, resp.Networks[0].MinWithdrawal)
+	assert.Equal(t, 0.1, resp.Networks[0].MinDeposit)
+}
+
+func TestConvertCurrency(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	exch, err := em.NewExchangeByName("binance")
+	require.NoError(t, err)
+	exch.SetDefaults()
+	exch.GetBase().Name = fakeExchangeName
+	require.NoError(t, em.Add(fExchange{IBotExchange: exch}))
+	s := RPCServer{Engine: &Engine{ExchangeManager: em}}
+
+	_, err = s.ConvertCurrency(context.Background(), &gctrpc.ConvertCurrencyRequest{})
+	assert.ErrorIs(t, err, ErrExchangeNameIsEmpty)
+	_, err = s.ConvertCurrency(context.Background(), &gctrpc.ConvertCurrencyRequest{Exchange: fakeExchangeName, From: "btc", To: "usdt"})
+	assert.ErrorIs(t, err, conversion.ErrInvalidAmount)
+
+	resp, err := s.ConvertCurrency(context.Background(), &gctrpc.ConvertCurrencyRequest{Exchange: fakeExchangeName, From: "btc", To: "usdt", Amount: 1})
+	require.NoError(t, err)
+	require.NotNil(t, resp.Quote)
+	assert.Equal(t, "1337", resp.Quote.Id)
+	assert.Equal(t, 2.0, resp.Quote.ToAmount)
+	assert.NotEmpty(t, resp.Quote.Expires, "Expires should be set")
+	assert.Nil(t, resp.Result, "Result should be nil when the quote is not accepted")
+
+	resp, err = s.ConvertCurrency(context.Background(), &gctrpc.ConvertCurrencyRequest{Exchange: fakeExchangeName, From: "btc", To: "usdt", Amount: 1, Accept: true})
+	require.NoError(t, err)
+	require.NotNil(t, resp.Result, "Result must be set when the quote is accepted")
+	assert.Equal(t, "1338", resp.Result.OrderId)
+	assert.Equal(t, "SUCCESS", resp.Result.Status)
+}
+
+func TestSweepDust(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	exch, err := em.NewExchangeByName("binance")
+	require.NoError(t, err)
+	exch.SetDefaults()
+	exch.GetBase().Name = fakeExchangeName
+	require.NoError(t, em.Add(fExchange{IBotExchange: exch}))
+	s := RPCServer{Engine: &Engine{ExchangeManager: em}}
+
+	_, err = s.SweepDust(context.Background(), &gctrpc.SweepDustRequest{})
+	assert.ErrorIs(t, err, ErrExchangeNameIsEmpty)
+
+	resp, err := s.SweepDust(context.Background(), &gctrpc.SweepDustRequest{Exchange: fakeExchangeName, DryRun: true})
+	require.NoError(t, err)
+	assert.Len(t, resp.Balances, 2, "A dry run should return all dust balances")
+	assert.Empty(t, resp.Conversions, "A dry run should not convert dust")
+
+	resp, err = s.SweepDust(context.Background(), &gctrpc.SweepDustRequest{Exchange: fakeExchangeName, Currencies: []string{"xrp"}, DryRun: true})
+	require.NoError(t, err)
+	require.Len(t, resp.Balances, 1, "A dry run must only return the requested balances")
+	assert.Equal(t, "XRP", resp.Balances[0].Currency)
+
+	resp, err = s.SweepDust(context.Background(), &gctrpc.SweepDustRequest{Exchange: fakeExchangeName})
+	require.NoError(t, err)
+	assert.Len(t, resp.Conversions, 2, "All dust balances should be swept when no currencies are specified")
+	assert.Equal(t, "BNB", resp.To)
+
+	resp, err = s.SweepDust(context.Background(), &gctrpc.SweepDustRequest{Exchange: fakeExchangeName, Currencies: []string{"ada"}})
+	require.NoError(t, err)
+	require.Len(t, resp.Conversions, 1)
+	assert.Equal(t, "ADA", resp.Conversions[0].Currency)
+}
+
+func TestRPCServerSubmitOptionStrategy(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	exch, err := em.NewExchangeByName("binance")
+	require.NoError(t, err)
+	exch.SetDefaults()
+	exch.GetBase().Name = fakeExchangeName
+	require.NoError(t, em.Add(fExchange{IBotExchange: exch}))
+	s := RPCServer{Engine: &Engine{ExchangeManager: em}}
+
+	_, err = s.SubmitOptionStrategy(context.Background(), &gctrpc.SubmitOptionStrategyRequest{})
+	assert.ErrorIs(t, err, ErrExchangeNameIsEmpty)
+
+	req := &gctrpc.SubmitOptionStrategyRequest{Exchange: fakeExchangeName, Strategy: "butterfly"}
+	_, err = s.SubmitOptionStrategy(context.Background(), req)
+	assert.ErrorContains(t, err, "unsupported option strategy")
+
+	req.Strategy = "vertical"
+	req.FillTimeout = "soon"
+	_, err = s.SubmitOptionStrategy(context.Background(), req)
+	assert.Error(t, err, "invalid fill timeouts should error")
+
+	req.FillTimeout = "10s"
+	req.Legs = []*gctrpc.OptionStrategyLeg{{Pair: "BTC-241227-60000-C", Side: "buy"}, {Pair: "BTC-241227-70000-C", Side: "sell"}}
+	_, err = s.SubmitOptionStrategy(context.Background(), req)
+	assert.ErrorIs(t, err, currency.ErrAssetNotFound, "legs must be enabled option pairs")
+}
+
+func TestGetEarnProducts(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	exch, err := em.NewExchangeByName("binance")
+	require.NoError(t, err)
+	exch.SetDefaults()
+	exch.GetBase().Name = fakeExchangeName
+	require.NoError(t, em.Add(fExchange{IBotExchange: exch}))
+	s := RPCServer{Engine: &Engine{ExchangeManager: em}}
+
+	_, err = s.GetEarnProducts(context.Background(), &gctrpc.GetEarnProductsRequest{})
+	assert.ErrorIs(t, err, ErrExchangeNameIsEmpty)
+
+	resp, err := s.GetEarnProducts(context.Background(), &gctrpc.GetEarnProductsRequest{Exchange: fakeExchangeName})
+	require.NoError(t, err)
+	require.Len(t, resp.Products, 2)
+	assert.Empty(t, resp.Products[0].Duration, "Flexible products should not have a duration")
+	assert.Equal(t, "2160h0m0s", resp.Products[1].Duration)
+
+	resp, err = s.GetEarnProducts(context.Background(), &gctrpc.GetEarnProductsRequest{Exchange: fakeExchangeName, Currency: "eth"})
+	require.NoError(t, err)
+	require.Len(t, resp.Products, 1, "Products must be filtered by currency")
+	assert.Equal(t, "locked", resp.Products[0].Type)
+}
+
+func TestGetEarnPositions(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	exch, err := em.NewExchangeByName("binance")
+	require.NoError(t, err)
+	exch.SetDefaults()
+	exch.GetBase().Name = fakeExchangeName
+	require.NoError(t, em.Add(fExchange{IBotExchange: exch}))
+	s := RPCServer{Engine: &Engine{ExchangeManager: em}}
+
+	_, err = s.GetEarnPositions(context.Background(), &gctrpc.GetEarnPositionsRequest{})
+	assert.ErrorIs(t, err, ErrExchangeNameIsEmpty)
+
+	resp, err := s.GetEarnPositions(context.Background(), &gctrpc.GetEarnPositionsRequest{Exchange: fakeExchangeName})
+	require.NoError(t, err)
+	require.Len(t, resp.Positions, 2)
+	assert.Empty(t, resp.Positions[0].Matures, "Flexible positions should not mature")
+	assert.NotEmpty(t, resp.Positions[1].Matures, "Locked positions should mature")
+	assert.Equal(t, "1337", resp.Positions[1].PositionId)
+}
+
+func TestSubscribeEarnProduct(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	exch, err := em.NewExchangeByName("binance")
+	require.NoError(t, err)
+	exch.SetDefaults()
+	exch.GetBase().Name = fakeExchangeName
+	require.NoError(t, em.Add(fExchange{IBotExchange: exch}))
+	s := RPCServer{Engine: &Engine{ExchangeManager: em}}
+
+	_, err = s.SubscribeEarnProduct(context.Background(), &gctrpc.SubscribeEarnProductRequest{})
+	assert.ErrorIs(t, err, ErrExchangeNameIsEmpty)
+	_, err = s.SubscribeEarnProduct(context.Background(), &gctrpc.SubscribeEarnProductRequest{Exchange: fakeExchangeName, Type: "staked"})
+	assert.ErrorIs(t, err, earn.ErrInvalidType)
+	_, err = s.SubscribeEarnProduct(context.Background(), &gctrpc.SubscribeEarnProductRequest{Exchange: fakeExchangeName, ProductId: "USDT001", Currency: "usdt"})
+	assert.ErrorIs(t, err, earn.ErrInvalidAmount)
+
+	resp, err := s.SubscribeEarnProduct(context.Background(), &gctrpc.SubscribeEarnProductRequest{Exchange: fakeExchangeName, ProductId: "USDT001", Currency: "usdt", Amount: 10})
+	require.NoError(t, err)
+	assert.Equal(t, "1338", resp.Id)
+	assert.Equal(t, "USDT", resp.Currency)
+	assert.True(t, resp.Success)
+	assert.NotEmpty(t, resp.Time, "Time should be set")
+}
+
+func TestRedeemEarnProduct(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	exch, err := em.NewExchangeByName("binance")
+	require.NoError(t, err)
+	exch.SetDefaults()
+	exch.GetBase().Name = fakeExchangeName
+	require.NoError(t, em.Add(fExchange{IBotExchange: exch}))
+	s := RPCServer{Engine: &Engine{ExchangeManager: em}}
+
+	_, err = s.RedeemEarnProduct(context.Background(), &gctrpc.RedeemEarnProductRequest{})
+	assert.ErrorIs(t, err, ErrExchangeNameIsEmpty)
+	_, err = s.RedeemEarnProduct(context.Background(), &gctrpc.RedeemEarnProductRequest{Exchange: fakeExchangeName, ProductId: "USDT001", Currency: "usdt", Amount: 1, All: true})
+	assert.ErrorIs(t, err, earn.ErrAmountAndAllSet)
+
+	resp, err := s.RedeemEarnProduct(context.Background(), &gctrpc.RedeemEarnProductRequest{Exchange: fakeExchangeName, ProductId: "ETH90", PositionId: "1337", Currency: "eth", Type: "locked", All: true})
+	require.NoError(t, err)
+	assert.Equal(t, "1339", resp.Id)
+	assert.Empty(t, resp.Time, "Time should be empty when not returned by the exchange")
+}
+
+func TestGetEarnRewards(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	exch, err := em.NewExchangeByName("binance")
+	require.NoError(t, err)
+	exch.SetDefaults()
+	exch.GetBase().Name = fakeExchangeName
+	require.NoError(t, em.Add(fExchange{IBotExchange: exch}))
+	s := RPCServer{Engine: &Engine{ExchangeManager: em}}
+
+	end := time.Now().Truncate(time.Hour)
+	start := end.Add(-time.Hour * 24)
+	_, err = s.GetEarnRewards(context.Background(), &gctrpc.GetEarnRewardsRequest{Exchange: fakeExchangeName})
+	assert.ErrorIs(t, err, errInvalidTimes)
+	_, err = s.GetEarnRewards(context.Background(), &gctrpc.GetEarnRewardsRequest{
+		Start: start.Format(common.SimpleTimeFormatWithTimezone),
+		End:   end.Format(common.SimpleTimeFormatWithTimezone),
+	})
+	assert.ErrorIs(t, err, ErrExchangeNameIsEmpty, "An exchange must be set when the earn manager is not running")
+
+	req := &gctrpc.GetEarnRewardsRequest{
+		Exchange: fakeExchangeName,
+		Start:    start.Format(common.SimpleTimeFormatWithTimezone),
+		End:      end.Format(common.SimpleTimeFormatWithTimezone),
+	}
+	resp, err := s.GetEarnRewards(context.Background(), req)
+	require.NoError(t, err)
+	require.Len(t, resp.Rewards, 1, "Rewards must be fetched from the exchange when the earn manager is not running")
+	assert.Equal(t, "USDT", resp.Rewards[0].Currency)
+
+	s.earnManager, err = SetupEarnManager(&config.EarnManager{SyncInterval: time.Hour, Lookback: time.Hour * 24, LedgerFile: filepath.Join(t.TempDir(), earnLedgerFile)}, "", em, nil)
+	require.NoError(t, err)
+	_, err = s.earnManager.record([]earn.Reward{
+		{Exchange: fakeExchangeName, ProductID: "USDT001", Currency: currency.USDT, Amount: 0.01, Time: start.Add(time.Hour)},
+		{Exchange: fakeExchangeName, ProductID: "USDT001", Currency: currency.USDT, Amount: 0.01, Time: start.Add(time.Hour * 2)},
+	})
+	require.NoError(t, err)
+	s.earnManager.started = 1
+	req.Exchange = ""
+	resp, err = s.GetEarnRewards(context.Background(), req)
+	require.NoError(t, err)
+	assert.Len(t, resp.Rewards, 2, "Rewards should be returned from the ledger when the earn manager is running")
+}
+
+func TestGetLendingOpportunities(t *testing.T) {
+	t.Parallel()
+	s := RPCServer{Engine: &Engine{}}
+	_, err := s.GetLendingOpportunities(context.Background(), &gctrpc.GetLendingOpportunitiesRequest{})
+	assert.ErrorIs(t, err, ErrSubSystemNotStarted)
+
+	em := NewExchangeManager()
+	require.NoError(t, em.Add(&lendingExchange{name: "low", apr: 0.02, free: 1000}))
+	require.NoError(t, em.Add(&lendingExchange{name: "high", apr: 0.06}))
+	s.lendingOptimizer, err = SetupLendingOptimizer(newLendingConfig(), em, &lendingWithdrawManager{}, nil)
+	require.NoError(t, err)
+	s.lendingOptimizer.started = 1
+	_, err = s.GetLendingOpportunities(context.Background(), &gctrpc.GetLendingOpportunitiesRequest{})
+	assert.ErrorIs(t, err, errLendingNotChecked)
+
+	s.lendingOptimizer.dryRun = true
+	require.NoError(t, s.lendingOptimizer.Check(context.Background()))
+	resp, err := s.GetLendingOpportunities(context.Background(), &gctrpc.GetLendingOpportunitiesRequest{})
+	require.NoError(t, err)
+	assert.Len(t, resp.Rates, 2)
+	require.Len(t, resp.Opportunities, 1)
+	assert.Equal(t, "low", resp.Opportunities[0].From)
+	assert.Equal(t, "high", resp.Opportunities[0].To)
+	assert.Equal(t, "dry run", resp.Opportunities[0].Status)
+	assert.NotEmpty(t, resp.Checked, "Checked should be set")
+}
+
+func TestGetMemoryUsage(t *testing.T) {
+	t.Parallel()
+	s := RPCServer{Engine: &Engine{}}
+	_, err := s.GetMemoryUsage(context.Background(), &gctrpc.GetMemoryUsageRequest{})
+	assert.ErrorIs(t, err, ErrSubSystemNotStarted)
+
+	s.memoryManager, err = SetupMemoryManager(&config.MemoryManager{CheckInterval: time.Minute})
+	require.NoError(t, err)
+	s.memoryManager.started = 1
+	_, err = s.GetMemoryUsage(context.Background(), &gctrpc.GetMemoryUsageRequest{})
+	assert.ErrorIs(t, err, errMemoryNotChecked)
+
+	require.NoError(t, s.memoryManager.Check())
+	resp, err := s.GetMemoryUsage(context.Background(), &gctrpc.GetMemoryUsageRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.Caches, 3)
+	assert.Equal(t, orderbookCache, resp.Caches[0].Name)
+	assert.Empty(t, resp.Caches[0].Action, "caches without a budget should not be trimmed")
+	assert.NotZero(t, resp.HeapAlloc, "HeapAlloc should be set")
+	assert.NotEmpty(t, resp.Checked, "Checked should be set")
+}
+
+func TestGetVolatilitySurface(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	require.NoError(t, em.Add(newSurfaceExchange(t)))
+	s := RPCServer{Engine: &Engine{ExchangeManager: em}}
+	_, err := s.GetVolatilitySurface(context.Background(), nil)
+	assert.ErrorIs(t, err, common.ErrNilPointer)
+	_, err = s.GetVolatilitySurface(context.Background(), &gctrpc.GetVolatilitySurfaceRequest{Exchange: "surface", Underlying: "BTC"})
+	assert.ErrorIs(t, err, ErrSubSystemNotStarted)
+
+	s.volatilitySurface, err = SetupVolatilitySurfaceManager(testVolatilitySurfaceConfig(), em)
+	require.NoError(t, err)
+	s.volatilitySurface.started = 1
+	_, err = s.GetVolatilitySurface(context.Background(), &gctrpc.GetVolatilitySurfaceRequest{Exchange: "surface"})
+	assert.ErrorIs(t, err, currency.ErrCurrencyCodeEmpty)
+	_, err = s.GetVolatilitySurface(context.Background(), &gctrpc.GetVolatilitySurfaceRequest{Exchange: "surface", Underlying: "BTC", At: "yesterday"})
+	assert.Error(t, err, "invalid times should error")
+
+	s.volatilitySurface.buildSurfaces(context.Background())
+	resp, err := s.GetVolatilitySurface(context.Background(), &gctrpc.GetVolatilitySurfaceRequest{Exchange: "surface", Underlying: "BTC"})
+	require.NoError(t, err)
+	assert.Equal(t, "BTC", resp.Underlying)
+	assert.Equal(t, "spline", resp.Interpolation)
+	require.Len(t, resp.Slices, 2)
+	require.Len(t, resp.Slices[0].Quotes, 5)
+	assert.InDelta(t, 0.5, resp.Slices[0].Quotes[0].ImpliedVolatility, 1e-6)
+	assert.Nil(t, resp.Slices[0].Svi, "spline slices should not have SVI parameters")
+	assert.Len(t, resp.Snapshots, 1)
+}
+
+func TestGetImpliedVolatility(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	require.NoError(t, em.Add(newSurfaceExchange(t)))
+	s := RPCServer{Engine: &Engine{ExchangeManager: em}}
+	_, err := s.GetImpliedVolatility(context.Background(), nil)
+	assert.ErrorIs(t, err, common.ErrNilPointer)
+
+	s.volatilitySurface, err = SetupVolatilitySurfaceManager(testVolatilitySurfaceConfig(), em)
+	require.NoError(t, err)
+	s.volatilitySurface.started = 1
+	s.volatilitySurface.buildSurfaces(context.Background())
+	req := &gctrpc.GetImpliedVolatilityRequest{Exchange: "surface", Underlying: "BTC", Strike: 65000}
+	_, err = s.GetImpliedVolatility(context.Background(), req)
+	assert.Error(t, err, "missing expiry should error")
+
+	req.Expiry = time.Now().AddDate(0, 0, 40).Format(common.SimpleTimeFormatWithTimezone)
+	resp, err := s.GetImpliedVolatility(context.Background(), req)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.5, resp.ImpliedVolatility, 1e-3)
+	assert.Equal(t, 60000.0, resp.Forward)
+	assert.NotEmpty(t, resp.SurfaceBuilt, "SurfaceBuilt should be set")
+
+	req.Underlying = "ETH"
+	_, err = s.GetImpliedVolatility(context.Background(), req)
+	assert.ErrorIs(t, err, errVolatilitySurfaceNotFound)
+}
+
+// headerStream is a fake server transport stream recording set headers
+type headerStream struct {
+	grpc.ServerTransportStream
+	header metadata.MD
+}
+
+func (h *headerStream) SetHeader(md metadata.MD) error {
+	h.header = metadata.Join(h.header, md)
+	return nil
+}
+
+func TestCheckAPIVersion(t *testing.T) {
+	t.Parallel()
+	assert.NoError(t, checkAPIVersion(context.Background()), "calls without metadata should be accepted")
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.MD{})
+	assert.NoError(t, checkAPIVersion(ctx), "calls without a requested version should be accepted")
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(gctrpc.APIVersionMetadataKey, strconv.Itoa(gctrpc.APIVersion)))
+	assert.NoError(t, checkAPIVersion(ctx))
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(gctrpc.APIVersionMetadataKey, "v1"))
+	assert.ErrorIs(t, checkAPIVersion(ctx), errUnsupportedAPIVersion)
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(gctrpc.APIVersionMetadataKey, strconv.Itoa(gctrpc.APIVersion+1)))
+	assert.ErrorIs(t, checkAPIVersion(ctx), errUnsupportedAPIVersion)
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs(gctrpc.APIVersionMetadataKey, strconv.Itoa(gctrpc.MinimumAPIVersion-1)))
+	assert.ErrorIs(t, checkAPIVersion(ctx), errUnsupportedAPIVersion)
+}
+
+func TestVersionUnaryInterceptor(t *testing.T) {
+	t.Parallel()
+	var called bool
+	handler := func(context.Context, any) (any, error) {
+		called = true
+		return nil, nil
+	}
+	stream := &headerStream{}
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+	_, err := versionUnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: gctrpc.GoCryptoTraderService_GetInfo_FullMethodName}, handler)
+	require.NoError(t, err)
+	assert.True(t, called, "handler should be called")
+	assert.Equal(t, []string{strconv.Itoa(gctrpc.APIVersion)}, stream.header.Get(gctrpc.APIVersionMetadataKey))
+	assert.Empty(t, stream.header.Get(gctrpc.DeprecatedMetadataKey), "methods which are not deprecated must not be flagged")
+
+	stream = &headerStream{}
+	ctx = grpc.NewContextWithServerTransportStream(context.Background(), stream)
+	_, err = versionUnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: gctrpc.GoCryptoTraderService_GetAvailableTransferChains_FullMethodName}, handler)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"true"}, stream.header.Get(gctrpc.DeprecatedMetadataKey))
+	assert.Equal(t, []string{gctrpc.GoCryptoTraderService_GetTransferNetworks_FullMethodName}, stream.header.Get(gctrpc.ReplacementMetadataKey))
+	assert.NotEmpty(t, stream.header.Get(gctrpc.DeprecationNoticeMetadataKey), "deprecation notice should be set")
+	assert.NotEmpty(t, stream.header.Get(gctrpc.RemovalVersionMetadataKey), "removal version should be set")
+
+	called = false
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(gctrpc.APIVersionMetadataKey, strconv.Itoa(gctrpc.APIVersion+1)))
+	_, err = versionUnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: gctrpc.GoCryptoTraderService_GetInfo_FullMethodName}, handler)
+	assert.ErrorIs(t, err, errUnsupportedAPIVersion)
+	assert.False(t, called, "handler must not be called for unsupported versions")
+}
+
+func TestGetRPCCapabilities(t *testing.T) {
+	t.Parallel()
+	s := RPCServer{Engine: &Engine{}}
+	resp, err := s.GetRPCCapabilities(context.Background(), &gctrpc.GetRPCCapabilitiesRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(gctrpc.APIVersion), resp.ApiVersion)
+	assert.Equal(t, int64(gctrpc.MinimumAPIVersion), resp.MinimumApiVersion)
+	assert.NotEmpty(t, resp.BotVersion, "BotVersion should be set")
+	assert.Len(t, resp.Methods, len(gctrpc.GoCryptoTraderService_ServiceDesc.Methods)+len(gctrpc.GoCryptoTraderService_ServiceDesc.Streams))
+	assert.True(t, slices.IsSortedFunc(resp.Methods, func(a, b *gctrpc.RPCMethod) int {
+		return strings.Compare(a.Name, b.Name)
+	}), "methods should be sorted by name")
+
+	var deprecated, streaming int
+	for _, m := range resp.Methods {
+		if m.Deprecated {
+			deprecated++
+			assert.Equal(t, gctrpc.GoCryptoTraderService_GetAvailableTransferChains_FullMethodName, m.Name)
+			assert.Equal(t, int64(1), m.DeprecatedSince)
+			assert.Equal(t, gctrpc.GoCryptoTraderService_GetTransferNetworks_FullMethodName, m.Replacement)
+		}
+		if m.Name == gctrpc.GoCryptoTraderService_StreamFills_FullMethodName {
+			assert.True(t, m.Streaming, "streams should be flagged")
+		}
+		if m.Streaming {
+			streaming++
+		}
+	}
+	assert.Equal(t, len(deprecatedRPCs), deprecated)
+	assert.Equal(t, len(gctrpc.GoCryptoTraderService_ServiceDesc.Streams), streaming)
+}
+
+func TestCaptureProfileRPC(t *testing.T) {
+	t.Parallel()
+	s := RPCServer{Engine: &Engine{Config: &config.Config{}}}
+	_, err := s.CaptureProfile(context.Background(), nil)
+	assert.ErrorIs(t, err, errNilRequestData)
+	_, err = s.CaptureProfile(context.Background(), &gctrpc.CaptureProfileRequest{Kind: "heap"})
+	assert.ErrorIs(t, err, errProfilerDisabled)
+
+	s.Config.Profiler = config.Profiler{Enabled: true, OutputPath: t.TempDir()}
+	_, err = s.CaptureProfile(context.Background(), &gctrpc.CaptureProfileRequest{Kind: cpuProfile, Duration: "soon"})
+	assert.Error(t, err, "invalid durations should error")
+
+	resp, err := s.CaptureProfile(context.Background(), &gctrpc.CaptureProfileRequest{Kind: "heap", Flamegraph: true})
+	require.NoError(t, err)
+	assert.Equal(t, "heap", resp.Kind)
+	assert.True(t, strings.HasPrefix(resp.Path, s.Config.Profiler.OutputPath), "profiles should be saved to the output path")
+	assert.FileExists(t, resp.FlamegraphPath)
+	assert.Positive(t, resp.Size)
+	assert.NotZero(t, resp.Captured, "Captured should be set")
+}
+
+func TestGetDataQualityScores(t *testing.T) {
+	t.Parallel()
+	s := RPCServer{Engine: &Engine{}}
+	_, err := s.GetDataQualityScores(context.Background(), nil)
+	assert.ErrorIs(t, err, common.ErrNilPointer)
+	_, err = s.GetDataQualityScores(context.Background(), &gctrpc.GetDataQualityScoresRequest{})
+	assert.ErrorIs(t, err, ErrSubSystemNotStarted)
+
+	s.dataQualityMonitor = testDataQualityMonitorSetup(t)
+	now := time.Now()
+	s.dataQualityMonitor.record("Good", now, DataQualityCounts{Updates: 10})
+	s.dataQualityMonitor.record("Crossed", now, DataQualityCounts{Updates: 10, CrossedBooks: 5})
+
+	resp, err := s.GetDataQualityScores(context.Background(), &gctrpc.GetDataQualityScoresRequest{})
+	require.NoError(t, err, "GetDataQualityScores must not error")
+	require.Len(t, resp.Scores, 2)
+	assert.Equal(t, "Good", resp.Scores[0].Exchange, "scores should be sorted best first")
+	assert.Equal(t, "1h0m0s", resp.Window)
+	assert.Equal(t, 50.0, resp.Scores[1].Score)
+	assert.Equal(t, int64(5), resp.Scores[1].CrossedBooks)
+
+	resp, err = s.GetDataQualityScores(context.Background(), &gctrpc.GetDataQualityScoresRequest{Exchange: "crossed"})
+	require.NoError(t, err, "GetDataQualityScores must not error")
+	require.Len(t, resp.Scores, 1)
+	assert.Equal(t, "Crossed", resp.Scores[0].Exchange)
+
+	_, err = s.GetDataQualityScores(context.Background(), &gctrpc.GetDataQualityScoresRequest{Exchange: "missing"})
+	assert.ErrorIs(t, err, errNoDataQualityScore)
+}
+
+func TestGetDashboardSnapshot(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	exch, err := em.NewExchangeByName("Binance")
+	require.NoError(t, err)
+	exch.SetDefaults()
+	b := exch.GetBase()
+	b.Name = "DashboardSnapshot"
+	b.Enabled = true
+	cp := currency.NewPair(currency.BTC, currency.USDT)
+	b.CurrencyPairs.Pairs = map[asset.Item]*currency.PairStore{
+		asset.Spot: {
+			Available:     currency.Pairs{cp},
+			Enabled:       currency.Pairs{cp},
+			AssetEnabled:  convert.BoolPtr(true),
+			ConfigFormat:  &currency.PairFormat{Uppercase: true},
+			RequestFormat: &currency.PairFormat{Uppercase: true},
+		},
+	}
+	b.SetCredentials("dashboardkey", "dashboardsecret", "", "", "", "")
+	require.NoError(t, em.Add(exch))
+
+	require.NoError(t, ticker.ProcessTicker(&ticker.Price{ExchangeName: b.Name, Pair: cp, AssetType: asset.Spot, Last: 42000}))
+	creds, err := exch.GetCredentials(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, account.Process(&account.Holdings{
+		Exchange: b.Name,
+		Accounts: []account.SubAccount{{AssetType: asset.Spot, Currencies: []account.Balance{{Currency: currency.BTC, Total: 2, Free: 1.5, Hold: 0.5}}}},
+	}, creds))
+
+	om, err := SetupOrderManager(em, &CommunicationManager{}, &sync.WaitGroup{}, &config.OrderManager{})
+	require.NoError(t, err)
+	om.started = 1
+	require.NoError(t, om.orderStore.add(&order.Detail{Exchange: b.Name, OrderID: "1", AssetType: asset.Spot, Pair: cp, Side: order.Buy, Type: order.Limit, Amount: 1, Status: order.New}))
+	require.NoError(t, om.orderStore.add(&order.Detail{Exchange: b.Name, OrderID: "2", AssetType: asset.Spot, Pair: cp, Side: order.Buy, Type: order.Limit, Amount: 1, Status: order.Filled}))
+
+	comms := &CommunicationManager{}
+	comms.recordEvent(base.Event{Type: "order", Exchange: "other", Message: "elsewhere"})
+	comms.recordEvent(base.Event{Type: "order", Exchange: b.Name, Message: "filled", Severity: base.SeverityWarning})
+	comms.recordEvent(base.Event{Type: "system", Message: "started"})
+
+	s := RPCServer{Engine: &Engine{Config: &config.Config{}, ExchangeManager: em, OrderManager: om, CommunicationsManager: comms}}
+	_, err = s.GetDashboardSnapshot(context.Background(), nil)
+	assert.ErrorIs(t, err, common.ErrNilPointer)
+	_, err = s.GetDashboardSnapshot(context.Background(), &gctrpc.GetDashboardSnapshotRequest{Sections: []string{"charts"}})
+	assert.ErrorIs(t, err, errUnknownDashboardSection)
+	_, err = s.GetDashboardSnapshot(context.Background(), &gctrpc.GetDashboardSnapshotRequest{Exchanges: []string{"missing"}})
+	assert.ErrorIs(t, err, ErrExchangeNotFound)
+
+	resp, err := s.GetDashboardSnapshot(context.Background(), &gctrpc.GetDashboardSnapshotRequest{Exchanges: []string{b.Name}})
+	require.NoError(t, err, "GetDashboardSnapshot must not error")
+	assert.NotZero(t, resp.Timestamp)
+	require.Len(t, resp.Tickers, 1)
+	require.Len(t, resp.Tickers[0].Tickers, 1)
+	assert.Equal(t, 42000.0, resp.Tickers[0].Tickers[0].Last)
+	require.Len(t, resp.Balances, 1)
+	require.Len(t, resp.Balances[0].Accounts, 1)
+	require.Len(t, resp.Balances[0].Accounts[0].Currencies, 1)
+	assert.Equal(t, 2.0, resp.Balances[0].Accounts[0].Currencies[0].TotalValue)
+	require.Len(t, resp.OpenOrders, 1, "only active orders should be returned")
+	assert.Equal(t, "1", resp.OpenOrders[0].Id)
+	assert.Empty(t, resp.Positions)
+	assert.True(t, resp.SubsystemsStatus[OrderManagerName])
+	require.Len(t, resp.Events, 2, "events for other exchanges should be excluded")
+	assert.Equal(t, "started", resp.Events[0].Message, "events should be newest first")
+	assert.Equal(t, "warning", resp.Events[1].Severity)
+	assert.Empty(t, resp.Warnings)
+
+	resp, err = s.GetDashboardSnapshot(context.Background(), &gctrpc.GetDashboardSnapshotRequest{Sections: []string{"Events"}, EventLimit: 1})
+	require.NoError(t, err, "GetDashboardSnapshot must not error")
+	assert.Empty(t, resp.Tickers, "only requested sections should be returned")
+	assert.Empty(t, resp.SubsystemsStatus, "only requested sections should be returned")
+	require.Len(t, resp.Events, 1, "events should be limited")
+
+	om.started = 0
+	resp, err = s.GetDashboardSnapshot(context.Background(), &gctrpc.GetDashboardSnapshotRequest{Sections: []string{dashboardOrders, dashboardPositions}})
+	require.NoError(t, err, "GetDashboardSnapshot must not error when a section is unavailable")
+	assert.Len(t, resp.Warnings, 2, "unavailable sections should be reported as warnings")
+}
+
+func TestGetStreamedFundingRates(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	exch, err := em.NewExchangeByName("Binance")
+	require.NoError(t, err)
+	exch.SetDefaults()
+	b := exch.GetBase()
+	b.Name = "StreamedFundingRates"
+	b.Enabled = true
+	cp := currency.NewPair(currency.BTC, currency.USDT)
+	b.CurrencyPairs.Pairs = make(map[asset.Item]*currency.PairStore)
+	require.NoError(t, b.CurrencyPairs.Store(asset.USDTMarginedFutures, &currency.PairStore{
+		Available:     currency.Pairs{cp},
+		Enabled:       currency.Pairs{cp},
+		AssetEnabled:  convert.BoolPtr(true),
+		ConfigFormat:  &currency.PairFormat{Uppercase: true},
+		RequestFormat: &currency.PairFormat{Uppercase: true},
+	}))
+	require.NoError(t, em.Add(exch))
+	s := RPCServer{Engine: &Engine{ExchangeManager: em, Config: &config.Config{}}}
+
+	_, err = s.GetStreamedFundingRates(context.Background(), nil)
+	assert.ErrorIs(t, err, common.ErrNilPointer)
+	_, err = s.GetStreamedFundingRates(context.Background(), &gctrpc.GetStreamedFundingRatesRequest{Exchange: "fake"})
+	assert.ErrorIs(t, err, ErrExchangeNotFound)
+	_, err = s.GetStreamedFundingRates(context.Background(), &gctrpc.GetStreamedFundingRatesRequest{Asset: "spot"})
+	assert.ErrorIs(t, err, futures.ErrNotFuturesAsset)
+
+	next := time.Now().Add(time.Hour).Truncate(time.Second)
+	for _, rate := range []float64{0.0001, 0.0002} {
+		require.NoError(t, fundingrate.Process(&fundingrate.Update{
+			Exchange:       b.Name,
+			Asset:          asset.USDTMarginedFutures,
+			Pair:           cp,
+			Rate:           decimal.NewFromFloat(rate),
+			TimeOfNextRate: next,
+		}))
+	}
+	resp, err := s.GetStreamedFundingRates(context.Background(), &gctrpc.GetStreamedFundingRatesRequest{Exchange: b.Name, Asset: asset.USDTMarginedFutures.String()})
+	require.NoError(t, err)
+	require.Len(t, resp.Rates, 1)
+	assert.Equal(t, "0.0002", resp.Rates[0].Rate)
+	assert.Equal(t, next.Unix(), resp.Rates[0].TimeOfNextRate)
+	resp, err = s.GetStreamedFundingRates(context.Background(), &gctrpc.GetStreamedFundingRatesRequest{Exchange: b.Name, Asset: asset.CoinMarginedFutures.String()})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Rates, "rates should be filtered by asset")
+
+	_, err = s.GetStreamedFundingRateHistory(context.Background(), nil)
+	assert.ErrorIs(t, err, common.ErrNilPointer)
+	_, err = s.GetStreamedFundingRateHistory(context.Background(), &gctrpc.GetStreamedFundingRateHistoryRequest{Exchange: b.Name})
+	assert.ErrorIs(t, err, errCurrencyPairUnset)
+	pair := &gctrpc.CurrencyPair{Base: cp.Base.String(), Quote: cp.Quote.String()}
+	_, err = s.GetStreamedFundingRateHistory(context.Background(), &gctrpc.GetStreamedFundingRateHistoryRequest{Exchange: b.Name, Asset: "spot", Pair: pair})
+	assert.ErrorIs(t, err, futures.ErrNotFuturesAsset)
+	_, err = s.GetStreamedFundingRateHistory(context.Background(), &gctrpc.GetStreamedFundingRateHistoryRequest{Exchange: b.Name, Asset: asset.USDTMarginedFutures.String(), Pair: pair, StartDate: "bad"})
+	assert.Error(t, err, "an invalid start date should error")
+	history, err := s.GetStreamedFundingRateHistory(context.Background(), &gctrpc.GetStreamedFundingRateHistoryRequest{Exchange: b.Name, Asset: asset.USDTMarginedFutures.String(), Pair: pair})
+	require.NoError(t, err)
+	require.Len(t, history.Rates, 2)
+	assert.Equal(t, "0.0001", history.Rates[0].Rate, "history should be oldest first")
+}
+
+// fundingRateStream is a fake StreamFundingRates server stream
+type fundingRateStream struct {
+	gctrpc.GoCryptoTraderService_StreamFundingRatesServer
+}
+
+func TestStreamFundingRates(t *testing.T) {
+	t.Parallel()
+	s := RPCServer{Engine: &Engine{ExchangeManager: NewExchangeManager(), Config: &config.Config{}}}
+	stream := &fundingRateStream{}
+	assert.ErrorIs(t, s.StreamFundingRates(nil, stream), common.ErrNilPointer)
+	assert.ErrorIs(t, s.StreamFundingRates(&gctrpc.StreamFundingRatesRequest{Asset: "fake"}, stream), asset.ErrNotSupported)
+	assert.ErrorIs(t, s.StreamFundingRates(&gctrpc.StreamFundingRatesRequest{Asset: "spot"}, stream), futures.ErrNotFuturesAsset)
+	assert.ErrorIs(t, s.StreamFundingRates(&gctrpc.StreamFundingRatesRequest{Exchange: "fake"}, stream), ErrExchangeNotFound)
+}
@@ -0,0 +1,399 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/cron"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// SetupScheduler creates a new scheduler from the supplied config. Each
+// configured task type must have a matching entry in taskTypes
+func SetupScheduler(cfg *config.SchedulerConfig, taskTypes map[string]TaskFunc) (*Scheduler, error) {
+	if cfg == nil {
+		return nil, errNilConfig
+	}
+	if len(taskTypes) == 0 {
+		return nil, errNoTaskTypes
+	}
+	m := &Scheduler{
+		shutdown:     make(chan struct{}),
+		verbose:      cfg.Verbose,
+		historyLimit: cfg.HistoryLimit,
+		jitter:       randomJitter,
+	}
+	now := time.Now()
+	names := make(map[string]bool, len(cfg.Tasks))
+	for i := range cfg.Tasks {
+		t := cfg.Tasks[i]
+		if names[t.Name] {
+			return nil, fmt.Errorf("%w: %q", errDuplicateTaskName, t.Name)
+		}
+		names[t.Name] = true
+		fn, ok := taskTypes[t.Type]
+		if !ok {
+			return nil, fmt.Errorf("scheduled task %q: %w %q", t.Name, errUnknownTaskType, t.Type)
+		}
+		switch t.Overlap {
+		case config.TaskOverlapSkip, config.TaskOverlapAllow, config.TaskOverlapQueue:
+		default:
+			return nil, fmt.Errorf("scheduled task %q: %w %q", t.Name, errInvalidOverlap, t.Overlap)
+		}
+		s, err := cron.Parse(t.Schedule)
+		if err != nil {
+			return nil, fmt.Errorf("scheduled task %q: %w", t.Name, err)
+		}
+		if s.Next(now).IsZero() {
+			return nil, fmt.Errorf("scheduled task %q: %w", t.Name, errTaskScheduleNeverRun)
+		}
+		m.tasks = append(m.tasks, &scheduledTask{cfg: t, schedule: s, fn: fn})
+	}
+	return m, nil
+}
+
+// IsRunning safely checks whether the subsystem is running
+func (m *Scheduler) IsRunning() bool {
+	if m == nil {
+		return false
+	}
+	return atomic.LoadInt32(&m.started) == 1
+}
+
+// Start runs the subsystem
+func (m *Scheduler) Start() error {
+	if m == nil {
+		return fmt.Errorf("%s %w", SchedulerName, ErrNilSubsystem)
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 0, 1) {
+		return fmt.Errorf("%s %w", SchedulerName, ErrSubSystemAlreadyStarted)
+	}
+	log.Debugf(log.Global, "Scheduler %s", MsgSubSystemStarting)
+	m.ctx, m.cancel = context.WithCancel(context.Background())
+	now := time.Now()
+	m.mtx.Lock()
+	for _, t := range m.tasks {
+		t.nextRun = m.next(t, now)
+	}
+	m.mtx.Unlock()
+	m.wg.Add(1)
+	go m.run()
+	log.Debugf(log.Global, "Scheduler %s", MsgSubSystemStarted)
+	return nil
+}
+
+// Stop stops the subsystem, cancelling and waiting for any running tasks
+func (m *Scheduler) Stop() error {
+	if m == nil {
+		return fmt.Errorf("%s %w", SchedulerName, ErrNilSubsystem)
+	}
+	if atomic.LoadInt32(&m.started) == 0 {
+		return fmt.Errorf("%s %w", SchedulerName, ErrSubSystemNotStarted)
+	}
+	log.Debugf(log.Global, "Scheduler %s", MsgSubSystemShuttingDown)
+	m.cancel()
+	close(m.shutdown)
+	m.wg.Wait()
+	m.shutdown = make(chan struct{})
+	atomic.StoreInt32(&m.started, 0)
+	log.Debugf(log.Global, "Scheduler %s", MsgSubSystemShutdown)
+	return nil
+}
+
+// GetTasks returns the configuration and run state of every scheduled task
+func (m *Scheduler) GetTasks() ([]ScheduledTaskStatus, error) {
+	if m == nil {
+		return nil, fmt.Errorf("%s %w", SchedulerName, ErrNilSubsystem)
+	}
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	resp := make([]ScheduledTaskStatus, len(m.tasks))
+	for i, t := range m.tasks {
+		resp[i] = ScheduledTaskStatus{
+			Name:     t.cfg.Name,
+			Type:     t.cfg.Type,
+			Schedule: t.schedule.String(),
+			Overlap:  t.cfg.Overlap,
+			Jitter:   t.cfg.Jitter,
+			Timeout:  t.cfg.Timeout,
+			NextRun:  t.nextRun,
+			Running:  t.running > 0,
+			Queued:   t.queued,
+			Skipped:  t.skipped,
+			History:  append([]TaskRun(nil), t.history...),
+		}
+	}
+	return resp, nil
+}
+
+func (m *Scheduler) run() {
+	defer m.wg.Done()
+	runSchedule(m.shutdown, m.nextRun, m.runDue)
+}
+
+// nextRun returns the earliest scheduled time of all tasks
+func (m *Scheduler) nextRun() time.Time {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	runs := make([]time.Time, len(m.tasks))
+	for i, t := range m.tasks {
+		runs[i] = t.nextRun
+	}
+	return earliestRun(runs...)
+}
+
+// runSchedule calls runDue each time the time returned by nextRun is reached
+// until shutdown is closed. It is the timer loop shared by every subsystem
+// which runs work on a schedule
+func runSchedule(shutdown <-chan struct{}, nextRun func() time.Time, runDue func(now time.Time)) {
+	timer := time.NewTimer(time.Until(nextRun()))
+	defer timer.Stop()
+	for {
+		select {
+		case <-shutdown:
+			return
+		case <-timer.C:
+			runDue(time.Now())
+			timer.Reset(time.Until(nextRun()))
+		}
+	}
+}
+
+// earliestRun returns the earliest non-zero run time supplied
+func earliestRun(runs ...time.Time) time.Time {
+	var next time.Time
+	for _, r := range runs {
+		if r.IsZero() {
+			continue
+		}
+		if next.IsZero() || r.Before(next) {
+			next = r
+		}
+	}
+	if next.IsZero() {
+		// schedules which can never run again are checked daily so the
+		// routine does not spin
+		return time.Now().Add(time.Hour * 24)
+	}
+	return next
+}
+
+// scheduledJob is work run by runScheduledJobs each time it falls due, either
+// on a cron schedule or at a fixed interval. Immediate jobs first run as soon
+// as the schedule starts rather than once their first interval has passed
+type scheduledJob struct {
+	schedule  *cron.Schedule
+	interval  time.Duration
+	immediate bool
+	run       func(ctx context.Context, now time.Time)
+	nextRun   time.Time
+}
+
+// next returns the next run time of the job after the supplied time
+func (j *scheduledJob) next(now time.Time) time.Time {
+	if j.schedule != nil {
+		return j.schedule.Next(now)
+	}
+	return now.Add(j.interval)
+}
+
+// runScheduledJobs runs each job in turn as it falls due until shutdown is
+// closed. It is the loop every periodic subsystem runs its work on. Jobs are
+// supplied a context which is cancelled on shutdown so in flight requests do
+// not hold up stopping the subsystem
+func runScheduledJobs(shutdown <-chan struct{}, jobs ...*scheduledJob) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-shutdown:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	now := time.Now()
+	for _, j := range jobs {
+		if j.immediate {
+			j.nextRun = now
+			continue
+		}
+		j.nextRun = j.next(now)
+	}
+	runSchedule(shutdown, func() time.Time {
+		runs := make([]time.Time, len(jobs))
+		for i, j := range jobs {
+			runs[i] = j.nextRun
+		}
+		return earliestRun(runs...)
+	}, func(now time.Time) {
+		for _, j := range jobs {
+			if j.nextRun.IsZero() || j.nextRun.After(now) {
+				continue
+			}
+			j.nextRun = j.next(now)
+			j.run(ctx, now)
+		}
+	})
+}
+
+// next returns the next run time of a task after the supplied time, delayed by
+// a random amount up to the task's jitter
+func (m *Scheduler) next(t *scheduledTask, now time.Time) time.Time {
+	n := t.schedule.Next(now)
+	if n.IsZero() || t.cfg.Jitter <= 0 {
+		return n
+	}
+	return n.Add(m.jitter(t.cfg.Jitter))
+}
+
+// runDue executes all tasks which are due at the supplied time, applying each
+// task's overlap policy when a previous run is still in progress
+func (m *Scheduler) runDue(now time.Time) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	for _, t := range m.tasks {
+		if t.nextRun.IsZero() || t.nextRun.After(now) {
+			continue
+		}
+		t.nextRun = m.next(t, now)
+		if t.running > 0 {
+			switch t.cfg.Overlap {
+			case config.TaskOverlapQueue:
+				t.queued = true
+				continue
+			case config.TaskOverlapSkip:
+				t.skipped++
+				if m.verbose {
+					log.Debugf(log.Global, "Scheduler skipping task %s as it is still running", t.cfg.Name)
+				}
+				continue
+			}
+		}
+		m.launch(t)
+	}
+}
+
+// launch starts a task execution, m.mtx must be held
+func (m *Scheduler) launch(t *scheduledTask) {
+	t.running++
+	m.wg.Add(1)
+	go m.execute(t)
+}
+
+// execute runs a single execution of a task and records the result
+func (m *Scheduler) execute(t *scheduledTask) {
+	defer m.wg.Done()
+	ctx := m.ctx
+	if t.cfg.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, t.cfg.Timeout)
+		defer cancel()
+	}
+	if m.verbose {
+		log.Debugf(log.Global, "Scheduler running task %s", t.cfg.Name)
+	}
+	run := TaskRun{Start: time.Now()}
+	err := t.fn(ctx, t.cfg.Args)
+	run.End = time.Now()
+	if err != nil {
+		run.Error = err.Error()
+		log.Errorf(log.Global, "Scheduler task %s failed: %v", t.cfg.Name, err)
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	t.history = append(t.history, run)
+	if m.historyLimit > 0 && len(t.history) > m.historyLimit {
+		t.history = t.history[len(t.history)-m.historyLimit:]
+	}
+	t.running--
+	if t.queued && t.running == 0 && m.ctx.Err() == nil {
+		t.queued = false
+		m.launch(t)
+	}
+}
+
+func randomJitter(maxDelay time.Duration) time.Duration {
+	return time.Duration(rand.Int63n(int64(maxDelay))) //nolint:gosec // jitter does not require crypto/rand
+}
+
+// schedulerTaskTypes returns the built in scheduled task types backed by the
+// engine's subsystems
+func (bot *Engine) schedulerTaskTypes() map[string]TaskFunc {
+	return map[string]TaskFunc{
+		TaskTypeBalanceSnapshot: bot.snapshotBalances,
+		TaskTypePairRefresh: func(ctx context.Context, _ map[string]string) error {
+			m := bot.pairRefreshManager
+			if m == nil {
+				var err error
+				if m, err = SetupPairRefreshManager(bot.ExchangeManager, &bot.Config.PairRefreshManager); err != nil {
+					return err
+				}
+			}
+			return m.RefreshAll(ctx)
+		},
+		TaskTypeReport: func(_ context.Context, args map[string]string) error {
+			if !bot.digestManager.IsRunning() {
+				return fmt.Errorf("%s %w", DigestManagerName, ErrSubSystemNotStarted)
+			}
+			name, period := args["name"], time.Hour*24
+			if name == "" {
+				name = "Scheduled"
+			}
+			if p, ok := args["period"]; ok {
+				var err error
+				if period, err = time.ParseDuration(p); err != nil {
+					return fmt.Errorf("invalid report period: %w", err)
+				}
+			}
+			return bot.digestManager.Send(name, period)
+		},
+		TaskTypeScript: func(ctx context.Context, args map[string]string) error {
+			script := args["script"]
+			if script == "" {
+				return fmt.Errorf("%w: script", errMissingTaskArg)
+			}
+			var timeout time.Duration
+			if deadline, ok := ctx.Deadline(); ok {
+				timeout = time.Until(deadline)
+			}
+			return bot.gctScriptManager.RunScript(script, timeout)
+		},
+	}
+}
+
+// snapshotBalances updates the account holdings of every enabled exchange with
+// authenticated API support, or only of the exchange given by the exchange
+// argument. Updated holdings are published to balance change subscribers
+func (bot *Engine) snapshotBalances(ctx context.Context, args map[string]string) error {
+	exchanges, err := bot.ExchangeManager.GetExchanges()
+	if err != nil {
+		return err
+	}
+	var errs error
+	for _, exch := range exchanges {
+		if !exch.IsEnabled() || !exch.IsRESTAuthenticationSupported() {
+			continue
+		}
+		if name := args["exchange"]; name != "" && !strings.EqualFold(name, exch.GetName()) {
+			continue
+		}
+		assetTypes := asset.Items{asset.Spot}
+		if exch.HasAssetTypeAccountSegregation() {
+			assetTypes = exch.GetAssetTypes(true)
+		}
+		for _, a := range assetTypes {
+			if _, err := exch.UpdateAccountInfo(ctx, a); err != nil {
+				errs = common.AppendError(errs, fmt.Errorf("%s %s: %w", exch.GetName(), a, err))
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,239 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/common/cron"
+	"github.com/thrasher-corp/gocryptotrader/config"
+)
+
+var errTaskTest = errors.New("task test error")
+
+func testSchedulerConfig(overlap string) *config.SchedulerConfig {
+	return &config.SchedulerConfig{
+		HistoryLimit: 2,
+		Tasks: []config.ScheduledTask{
+			{Name: "test", Type: "test", Schedule: "@every 1h", Overlap: overlap},
+		},
+	}
+}
+
+// blockingTask returns a task which signals on started and blocks until
+// release is closed
+func blockingTask(started chan<- struct{}, release <-chan struct{}) map[string]TaskFunc {
+	return map[string]TaskFunc{
+		"test": func(context.Context, map[string]string) error {
+			started <- struct{}{}
+			<-release
+			return errTaskTest
+		},
+	}
+}
+
+func TestSetupScheduler(t *testing.T) {
+	t.Parallel()
+	noop := map[string]TaskFunc{"test": func(context.Context, map[string]string) error { return nil }}
+	_, err := SetupScheduler(nil, noop)
+	assert.ErrorIs(t, err, errNilConfig)
+	_, err = SetupScheduler(&config.SchedulerConfig{}, nil)
+	assert.ErrorIs(t, err, errNoTaskTypes)
+
+	cfg := testSchedulerConfig(config.TaskOverlapSkip)
+	cfg.Tasks = append(cfg.Tasks, cfg.Tasks[0])
+	_, err = SetupScheduler(cfg, noop)
+	assert.ErrorIs(t, err, errDuplicateTaskName)
+
+	cfg = testSchedulerConfig(config.TaskOverlapSkip)
+	cfg.Tasks[0].Type = "missing"
+	_, err = SetupScheduler(cfg, noop)
+	assert.ErrorIs(t, err, errUnknownTaskType)
+
+	_, err = SetupScheduler(testSchedulerConfig("bad"), noop)
+	assert.ErrorIs(t, err, errInvalidOverlap)
+
+	cfg = testSchedulerConfig(config.TaskOverlapSkip)
+	cfg.Tasks[0].Schedule = "61 * * * *"
+	_, err = SetupScheduler(cfg, noop)
+	assert.ErrorIs(t, err, cron.ErrInvalidSchedule)
+
+	cfg.Tasks[0].Schedule = "0 0 31 2 *"
+	_, err = SetupScheduler(cfg, noop)
+	assert.ErrorIs(t, err, errTaskScheduleNeverRun)
+
+	m, err := SetupScheduler(testSchedulerConfig(config.TaskOverlapSkip), noop)
+	require.NoError(t, err)
+	assert.Len(t, m.tasks, 1)
+}
+
+func TestSchedulerStartStop(t *testing.T) {
+	t.Parallel()
+	var m *Scheduler
+	assert.ErrorIs(t, m.Start(), ErrNilSubsystem)
+	assert.ErrorIs(t, m.Stop(), ErrNilSubsystem)
+	assert.False(t, m.IsRunning())
+
+	cfg := testSchedulerConfig(config.TaskOverlapSkip)
+	cfg.Tasks[0].Jitter = time.Minute
+	m, err := SetupScheduler(cfg, map[string]TaskFunc{"test": func(context.Context, map[string]string) error { return nil }})
+	require.NoError(t, err)
+	m.jitter = func(time.Duration) time.Duration { return time.Second }
+	assert.ErrorIs(t, m.Stop(), ErrSubSystemNotStarted)
+	require.NoError(t, m.Start())
+	assert.ErrorIs(t, m.Start(), ErrSubSystemAlreadyStarted)
+	assert.True(t, m.IsRunning())
+
+	tasks, err := m.GetTasks()
+	require.NoError(t, err)
+	require.Len(t, tasks, 1)
+	assert.Equal(t, "@every 1h", tasks[0].Schedule)
+	assert.True(t, tasks[0].NextRun.After(time.Now()), "NextRun should be in the future")
+	now := time.Now()
+	assert.Equal(t, m.tasks[0].schedule.Next(now).Add(time.Second), m.next(m.tasks[0], now), "next should add jitter")
+	require.NoError(t, m.Stop())
+	assert.False(t, m.IsRunning())
+}
+
+func TestSchedulerOverlapPolicies(t *testing.T) {
+	t.Parallel()
+	for _, overlap := range []string{config.TaskOverlapSkip, config.TaskOverlapAllow, config.TaskOverlapQueue} {
+		t.Run(overlap, func(t *testing.T) {
+			t.Parallel()
+			started, release := make(chan struct{}, 3), make(chan struct{})
+			m, err := SetupScheduler(testSchedulerConfig(overlap), blockingTask(started, release))
+			require.NoError(t, err)
+			m.ctx, m.cancel = context.WithCancel(context.Background())
+			defer m.cancel()
+
+			now := time.Now()
+			m.tasks[0].nextRun = now
+			m.runDue(now)
+			<-started
+			m.tasks[0].nextRun = now
+			m.runDue(now)
+
+			m.mtx.Lock()
+			switch overlap {
+			case config.TaskOverlapSkip:
+				assert.Equal(t, int64(1), m.tasks[0].skipped, "overlapping run should be skipped")
+				assert.Equal(t, 1, m.tasks[0].running)
+			case config.TaskOverlapAllow:
+				assert.Equal(t, 2, m.tasks[0].running, "overlapping run should execute concurrently")
+			case config.TaskOverlapQueue:
+				assert.True(t, m.tasks[0].queued, "overlapping run should be queued")
+				assert.Equal(t, 1, m.tasks[0].running)
+			}
+			m.mtx.Unlock()
+
+			close(release)
+			if overlap != config.TaskOverlapSkip {
+				<-started
+			}
+			m.wg.Wait()
+
+			tasks, err := m.GetTasks()
+			require.NoError(t, err)
+			require.Len(t, tasks, 1)
+			assert.False(t, tasks[0].Running)
+			assert.False(t, tasks[0].Queued)
+			if overlap == config.TaskOverlapSkip {
+				assert.Len(t, tasks[0].History, 1)
+			} else {
+				assert.Len(t, tasks[0].History, 2, "overlapping run should be recorded")
+			}
+			assert.Equal(t, errTaskTest.Error(), tasks[0].History[0].Error, "task error should be recorded")
+		})
+	}
+}
+
+func TestSchedulerHistoryLimit(t *testing.T) {
+	t.Parallel()
+	var timedOut error
+	m, err := SetupScheduler(testSchedulerConfig(config.TaskOverlapSkip), map[string]TaskFunc{
+		"test": func(ctx context.Context, _ map[string]string) error {
+			<-ctx.Done()
+			timedOut = ctx.Err()
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	m.tasks[0].cfg.Timeout = time.Millisecond
+	m.ctx, m.cancel = context.WithCancel(context.Background())
+	defer m.cancel()
+	for range 3 {
+		now := time.Now()
+		m.tasks[0].nextRun = now
+		m.runDue(now)
+		m.wg.Wait()
+	}
+	assert.ErrorIs(t, timedOut, context.DeadlineExceeded, "task context should time out")
+	tasks, err := m.GetTasks()
+	require.NoError(t, err)
+	assert.Len(t, tasks[0].History, 2, "history should be bounded by the history limit")
+}
+
+func TestSchedulerTaskTypes(t *testing.T) {
+	t.Parallel()
+	bot := &Engine{Config: &config.Config{}, ExchangeManager: NewExchangeManager()}
+	types := bot.schedulerTaskTypes()
+	for _, k := range []string{TaskTypeBalanceSnapshot, TaskTypePairRefresh, TaskTypeReport, TaskTypeScript} {
+		assert.Contains(t, types, k)
+	}
+	assert.NoError(t, types[TaskTypeBalanceSnapshot](context.Background(), nil), "balance snapshot should not error without exchanges")
+	assert.ErrorIs(t, types[TaskTypePairRefresh](context.Background(), nil), errInvalidPairRefreshInterval)
+	assert.ErrorIs(t, types[TaskTypeReport](context.Background(), nil), ErrSubSystemNotStarted)
+	assert.ErrorIs(t, types[TaskTypeScript](context.Background(), nil), errMissingTaskArg)
+}
+
+func TestEarliestRun(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	assert.Equal(t, now, earliestRun(time.Time{}, now.Add(time.Hour), now))
+	assert.WithinDuration(t, time.Now().Add(time.Hour*24), earliestRun(time.Time{}), time.Minute, "schedules which never run should be checked daily")
+}
+
+func TestRunScheduledJobs(t *testing.T) {
+	t.Parallel()
+	shutdown := make(chan struct{})
+	ran := make(chan time.Time, 1)
+	started := make(chan context.Context, 1)
+	done := make(chan struct{})
+	never, err := cron.Parse("0 0 30 2 *")
+	require.NoError(t, err)
+	go func() {
+		runScheduledJobs(shutdown,
+			&scheduledJob{interval: time.Millisecond, run: func(_ context.Context, now time.Time) {
+				select {
+				case ran <- now:
+				default:
+				}
+			}},
+			&scheduledJob{interval: time.Hour, immediate: true, run: func(ctx context.Context, _ time.Time) { started <- ctx }},
+			&scheduledJob{schedule: never, run: func(context.Context, time.Time) { assert.Fail(t, "jobs which are never due must not run") }},
+		)
+		close(done)
+	}()
+	var ctx context.Context
+	select {
+	case ctx = <-started:
+	case <-time.After(time.Second * 5):
+		require.Fail(t, "immediate jobs must run when the schedule starts")
+	}
+	select {
+	case <-ran:
+	case <-time.After(time.Second * 5):
+		require.Fail(t, "interval jobs must run when due")
+	}
+	assert.NoError(t, ctx.Err())
+	close(shutdown)
+	select {
+	case <-done:
+	case <-time.After(time.Second * 5):
+		require.Fail(t, "runScheduledJobs must return on shutdown")
+	}
+	assert.ErrorIs(t, ctx.Err(), context.Canceled, "job contexts should be cancelled on shutdown")
+}
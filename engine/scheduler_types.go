@@ -0,0 +1,86 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/cron"
+	"github.com/thrasher-corp/gocryptotrader/config"
+)
+
+// SchedulerName is an exported subsystem name
+const SchedulerName = "scheduler"
+
+// Built in scheduled task types
+const (
+	TaskTypeBalanceSnapshot = "balance_snapshot"
+	TaskTypePairRefresh     = "pair_refresh"
+	TaskTypeReport          = "report"
+	TaskTypeScript          = "script"
+)
+
+var (
+	errNoTaskTypes          = errors.New("no task types registered")
+	errUnknownTaskType      = errors.New("unknown task type")
+	errDuplicateTaskName    = errors.New("duplicate task name")
+	errInvalidOverlap       = errors.New("invalid overlap policy")
+	errTaskScheduleNeverRun = errors.New("schedule will never run")
+	errMissingTaskArg       = errors.New("missing task argument")
+)
+
+// TaskFunc executes a scheduled task with the task's configured arguments. The
+// context is cancelled when the task times out or the scheduler is stopped
+type TaskFunc func(ctx context.Context, args map[string]string) error
+
+// Scheduler runs user defined tasks on cron schedules with optional jitter,
+// overlap policies and a bounded run history per task
+type Scheduler struct {
+	started      int32
+	shutdown     chan struct{}
+	wg           sync.WaitGroup
+	verbose      bool
+	historyLimit int
+	ctx          context.Context
+	cancel       context.CancelFunc
+	mtx          sync.Mutex
+	tasks        []*scheduledTask
+	// jitter returns a random delay up to the supplied maximum
+	jitter func(max time.Duration) time.Duration
+}
+
+// scheduledTask holds a parsed scheduled task and its run state
+type scheduledTask struct {
+	cfg      config.ScheduledTask
+	schedule *cron.Schedule
+	fn       TaskFunc
+	nextRun  time.Time
+	running  int
+	queued   bool
+	skipped  int64
+	history  []TaskRun
+}
+
+// TaskRun holds the result of a single scheduled task execution
+type TaskRun struct {
+	Start time.Time
+	End   time.Time
+	Error string
+}
+
+// ScheduledTaskStatus holds a snapshot of a scheduled task's configuration and
+// run state
+type ScheduledTaskStatus struct {
+	Name     string
+	Type     string
+	Schedule string
+	Overlap  string
+	Jitter   time.Duration
+	Timeout  time.Duration
+	NextRun  time.Time
+	Running  bool
+	Queued   bool
+	Skipped  int64
+	History  []TaskRun
+}
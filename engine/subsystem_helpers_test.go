@@ -0,0 +1,109 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/calendar"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// fakeComms records the events pushed by a subsystem
+type fakeComms struct {
+	events []base.Event
+}
+
+func (f *fakeComms) PushEvent(evt base.Event) {
+	f.events = append(f.events, evt)
+}
+
+// fakeFuturesOrderManager holds open futures positions and records submitted
+// orders, failing those rejected by reject
+type fakeFuturesOrderManager struct {
+	positions []futures.Position
+	submitted []order.Submit
+	reject    func(*order.Submit) error
+}
+
+func (f *fakeFuturesOrderManager) GetAllOpenFuturesPositions() ([]futures.Position, error) {
+	return f.positions, nil
+}
+
+func (f *fakeFuturesOrderManager) Submit(_ context.Context, s *order.Submit) (*OrderSubmitResponse, error) {
+	if f.reject != nil {
+		if err := f.reject(s); err != nil {
+			return nil, err
+		}
+	}
+	f.submitted = append(f.submitted, *s)
+	return &OrderSubmitResponse{}, nil
+}
+
+// fakeCalendar is an exchange calendar holding fixed events
+type fakeCalendar struct {
+	events []calendar.Event
+}
+
+func (f *fakeCalendar) GetEvents(filter *calendar.Filter) ([]calendar.Event, error) {
+	var resp []calendar.Event
+	for i := range f.events {
+		if filter.Match(&f.events[i]) {
+			resp = append(resp, f.events[i])
+		}
+	}
+	return resp, nil
+}
+
+// fakeQuarantine quarantines every pair of the listed exchanges
+type fakeQuarantine struct {
+	exchanges []string
+}
+
+func (f *fakeQuarantine) IsQuarantined(exchName string, _ asset.Item, _ currency.Pair) bool {
+	for i := range f.exchanges {
+		if f.exchanges[i] == exchName {
+			return true
+		}
+	}
+	return false
+}
+
+// testExchangeManager returns an exchange manager holding the supplied
+// exchanges
+func testExchangeManager(t *testing.T, exchs ...exchange.IBotExchange) *ExchangeManager {
+	t.Helper()
+	em := NewExchangeManager()
+	for _, e := range exchs {
+		require.NoError(t, em.Add(e), "Add must not error")
+	}
+	return em
+}
+
+// startStopper is a subsystem which is started and stopped by the engine
+type startStopper interface {
+	Start() error
+	Stop() error
+	IsRunning() bool
+}
+
+// testStartStop checks the start and stop states of a subsystem, and that a
+// nil subsystem of the same type errors
+func testStartStop(t *testing.T, nilSubsystem, s startStopper) {
+	t.Helper()
+	assert.ErrorIs(t, nilSubsystem.Start(), ErrNilSubsystem)
+	assert.ErrorIs(t, nilSubsystem.Stop(), ErrNilSubsystem)
+	assert.False(t, nilSubsystem.IsRunning(), "nil subsystems should not be running")
+	assert.ErrorIs(t, s.Stop(), ErrSubSystemNotStarted)
+	require.NoError(t, s.Start(), "Start must not error")
+	assert.True(t, s.IsRunning(), "IsRunning should return true once started")
+	assert.ErrorIs(t, s.Start(), ErrSubSystemAlreadyStarted)
+	require.NoError(t, s.Stop(), "Stop must not error")
+	assert.False(t, s.IsRunning(), "IsRunning should return false once stopped")
+}
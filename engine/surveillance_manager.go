@@ -0,0 +1,350 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/cron"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// SetupSurveillanceManager creates a surveillance manager from the supplied
+// config
+func SetupSurveillanceManager(cfg *config.SurveillanceManager, om iSurveillanceOrderManager, comms iCommsManager) (*SurveillanceManager, error) {
+	if cfg == nil {
+		return nil, errNilConfig
+	}
+	if om == nil {
+		return nil, errNilOrderManager
+	}
+	if comms == nil {
+		return nil, errNilCommunicationsManager
+	}
+	switch {
+	case cfg.CheckInterval <= 0, cfg.Window <= 0, cfg.ReportPeriod <= 0:
+		return nil, fmt.Errorf("%w check interval %v, window %v and report period %v must be above zero",
+			errInvalidSurveillanceConfig, cfg.CheckInterval, cfg.Window, cfg.ReportPeriod)
+	case cfg.MinOrders <= 0:
+		return nil, fmt.Errorf("%w min orders %v must be above zero", errInvalidSurveillanceConfig, cfg.MinOrders)
+	case cfg.MaxCancelRatio <= 0 || cfg.MaxCancelRatio > 1:
+		return nil, fmt.Errorf("%w max cancel ratio %v must be above zero and not exceed 1", errInvalidSurveillanceConfig, cfg.MaxCancelRatio)
+	case cfg.MaxOrderToFillRatio <= 0:
+		return nil, fmt.Errorf("%w max order to fill ratio %v must be above zero", errInvalidSurveillanceConfig, cfg.MaxOrderToFillRatio)
+	case cfg.WashTradePriceTolerance < 0:
+		return nil, fmt.Errorf("%w wash trade price tolerance %v cannot be negative", errInvalidSurveillanceConfig, cfg.WashTradePriceTolerance)
+	case cfg.SpoofMaxLifetime <= 0 || cfg.SpoofMinOccurrences <= 0:
+		return nil, fmt.Errorf("%w spoof max lifetime %v and min occurrences %v must be above zero",
+			errInvalidSurveillanceConfig, cfg.SpoofMaxLifetime, cfg.SpoofMinOccurrences)
+	}
+	schedule, err := cron.Parse(cfg.ReportSchedule)
+	if err != nil {
+		return nil, fmt.Errorf("surveillance report schedule: %w", err)
+	}
+	return &SurveillanceManager{
+		shutdown:                make(chan struct{}),
+		verbose:                 cfg.Verbose,
+		checkInterval:           cfg.CheckInterval,
+		window:                  cfg.Window,
+		minOrders:               cfg.MinOrders,
+		maxCancelRatio:          cfg.MaxCancelRatio,
+		maxOrderToFillRatio:     cfg.MaxOrderToFillRatio,
+		washTradeWindow:         cfg.WashTradeWindow,
+		washTradePriceTolerance: cfg.WashTradePriceTolerance,
+		spoofMaxLifetime:        cfg.SpoofMaxLifetime,
+		spoofMinOccurrences:     cfg.SpoofMinOccurrences,
+		reportSchedule:          schedule,
+		reportPeriod:            cfg.ReportPeriod,
+		orderManager:            om,
+		comms:                   comms,
+		flagged:                 make(map[string]bool),
+	}, nil
+}
+
+// IsRunning safely checks whether the subsystem is running
+func (m *SurveillanceManager) IsRunning() bool {
+	if m == nil {
+		return false
+	}
+	return atomic.LoadInt32(&m.started) == 1
+}
+
+// Start runs the subsystem
+func (m *SurveillanceManager) Start() error {
+	if m == nil {
+		return fmt.Errorf("%s %w", SurveillanceManagerName, ErrNilSubsystem)
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 0, 1) {
+		return fmt.Errorf("%s %w", SurveillanceManagerName, ErrSubSystemAlreadyStarted)
+	}
+	log.Debugf(log.OrderMgr, "Surveillance manager %s", MsgSubSystemStarting)
+	m.wg.Add(1)
+	go m.run()
+	log.Debugf(log.OrderMgr, "Surveillance manager %s", MsgSubSystemStarted)
+	return nil
+}
+
+// Stop stops the subsystem
+func (m *SurveillanceManager) Stop() error {
+	if m == nil {
+		return fmt.Errorf("%s %w", SurveillanceManagerName, ErrNilSubsystem)
+	}
+	if atomic.LoadInt32(&m.started) == 0 {
+		return fmt.Errorf("%s %w", SurveillanceManagerName, ErrSubSystemNotStarted)
+	}
+	log.Debugf(log.OrderMgr, "Surveillance manager %s", MsgSubSystemShuttingDown)
+	close(m.shutdown)
+	m.wg.Wait()
+	m.shutdown = make(chan struct{})
+	atomic.StoreInt32(&m.started, 0)
+	log.Debugf(log.OrderMgr, "Surveillance manager %s", MsgSubSystemShutdown)
+	return nil
+}
+
+func (m *SurveillanceManager) run() {
+	defer m.wg.Done()
+	runScheduledJobs(m.shutdown,
+		&scheduledJob{interval: m.checkInterval, run: func(_ context.Context, now time.Time) { m.check(now) }},
+		&scheduledJob{schedule: m.reportSchedule, run: func(_ context.Context, now time.Time) { m.sendReport(now) }},
+	)
+}
+
+// check analyses activity within the window, alerting on newly flagged
+// patterns and resolving alerts for patterns which are no longer found
+func (m *SurveillanceManager) check(now time.Time) {
+	r, err := m.Analyse(now.Add(-m.window), now)
+	if err != nil {
+		log.Errorf(log.OrderMgr, "Surveillance manager unable to analyse activity: %v", err)
+		return
+	}
+	m.m.Lock()
+	defer m.m.Unlock()
+	current := make(map[string]bool, len(r.Flags))
+	for i := range r.Flags {
+		f := &r.Flags[i]
+		k := f.Check + ":" + strings.ToLower(f.Exchange) + ":" + f.Asset.String() + ":" + f.Pair.String()
+		current[k] = true
+		if m.flagged[k] {
+			continue
+		}
+		m.flagged[k] = true
+		msg := fmt.Sprintf("Surveillance manager flagged %s %s %s %s: %s", f.Exchange, f.Asset, f.Pair, f.Check, f.Message)
+		log.Warnln(log.OrderMgr, msg)
+		m.comms.PushEvent(base.Event{
+			Type:     surveillanceEventType,
+			Message:  msg,
+			Severity: base.SeverityWarning,
+			Exchange: f.Exchange,
+			Key:      surveillanceEventType + ":" + k,
+		})
+	}
+	for k := range m.flagged {
+		if current[k] {
+			continue
+		}
+		delete(m.flagged, k)
+		msg := "Surveillance manager pattern no longer found: " + k
+		log.Infoln(log.OrderMgr, msg)
+		m.comms.PushEvent(base.Event{
+			Type:     surveillanceEventType,
+			Message:  msg,
+			Severity: base.SeverityInfo,
+			Key:      surveillanceEventType + ":" + k,
+			Resolved: true,
+		})
+	}
+}
+
+// sendReport sends a report of activity over the report period
+func (m *SurveillanceManager) sendReport(now time.Time) {
+	r, err := m.Analyse(now.Add(-m.reportPeriod), now)
+	if err != nil {
+		log.Errorf(log.OrderMgr, "Surveillance manager unable to compose report: %v", err)
+		return
+	}
+	if m.verbose {
+		log.Debugf(log.OrderMgr, "Surveillance manager sending report with %d flags", len(r.Flags))
+	}
+	m.comms.PushEvent(base.Event{
+		Type:     surveillanceEventType,
+		Message:  r.String(),
+		Severity: base.SeverityInfo,
+	})
+}
+
+// Analyse calculates each pair's order, cancel and fill activity within the
+// supplied time range and flags patterns resembling wash trading, spoofing or
+// excessive cancelling
+func (m *SurveillanceManager) Analyse(start, end time.Time) (*SurveillanceReport, error) {
+	if m == nil {
+		return nil, fmt.Errorf("%s %w", SurveillanceManagerName, ErrNilSubsystem)
+	}
+	if err := common.StartEndTimeCheck(start, end); err != nil {
+		return nil, err
+	}
+	inRange := func(t time.Time) bool {
+		return !t.Before(start) && !t.After(end)
+	}
+	activity := make(map[string]*SurveillanceActivity)
+	fills := make(map[string][]surveillanceFill)
+	cancels := make(map[string][]*order.Detail)
+	orders := m.orderManager.GetOrdersSnapshot(order.AnyStatus)
+	for i := range orders {
+		o := &orders[i]
+		key := o.Exchange + "|" + o.AssetType.String() + "|" + o.Pair.String()
+		a, ok := activity[key]
+		if !ok {
+			a = &SurveillanceActivity{Exchange: o.Exchange, Asset: o.AssetType, Pair: o.Pair}
+			activity[key] = a
+		}
+		if inRange(o.Date) {
+			a.Placed++
+		}
+		t := orderTime(o)
+		if !inRange(t) {
+			continue
+		}
+		if amount, price := executedAmountAndPrice(o); amount.IsPositive() && price.IsPositive() {
+			a.Filled++
+			fills[key] = append(fills[key], surveillanceFill{side: o.Side, price: price.InexactFloat64(), time: t})
+		}
+		switch o.Status {
+		case order.Cancelled, order.PartiallyCancelled, order.PartiallyFilledCancelled:
+			a.Cancelled++
+			if o.ExecutedAmount == 0 && !o.Date.IsZero() && t.Sub(o.Date) <= m.spoofMaxLifetime {
+				cancels[key] = append(cancels[key], o)
+			}
+		}
+	}
+
+	r := &SurveillanceReport{Start: start, End: end}
+	for key, a := range activity {
+		if a.Placed == 0 && a.Filled == 0 && a.Cancelled == 0 {
+			continue
+		}
+		f := fills[key]
+		sort.Slice(f, func(i, j int) bool { return f[i].time.Before(f[j].time) })
+		a.WashTrades = m.washTrades(f)
+		a.SpoofLikeCancels = spoofLikeCancels(cancels[key], f)
+		if a.Placed > 0 {
+			a.CancelRatio = float64(a.Cancelled) / float64(a.Placed)
+			a.OrderToFillRatio = float64(a.Placed) / float64(max(a.Filled, 1))
+		}
+		r.Activity = append(r.Activity, *a)
+	}
+	sort.Slice(r.Activity, func(i, j int) bool {
+		if r.Activity[i].Exchange != r.Activity[j].Exchange {
+			return r.Activity[i].Exchange < r.Activity[j].Exchange
+		}
+		if r.Activity[i].Asset != r.Activity[j].Asset {
+			return r.Activity[i].Asset < r.Activity[j].Asset
+		}
+		return r.Activity[i].Pair.String() < r.Activity[j].Pair.String()
+	})
+	for i := range r.Activity {
+		r.Flags = append(r.Flags, m.flags(&r.Activity[i])...)
+	}
+	return r, nil
+}
+
+// flags returns the patterns found in a pair's activity
+func (m *SurveillanceManager) flags(a *SurveillanceActivity) []SurveillanceFlag {
+	var flags []SurveillanceFlag
+	add := func(check, msg string) {
+		flags = append(flags, SurveillanceFlag{Check: check, Exchange: a.Exchange, Asset: a.Asset, Pair: a.Pair, Message: msg})
+	}
+	if a.Placed >= m.minOrders {
+		if a.CancelRatio > m.maxCancelRatio {
+			add(SurveillanceCancelRatio, fmt.Sprintf("%d of %d orders cancelled, ratio %.4f exceeds %v",
+				a.Cancelled, a.Placed, a.CancelRatio, m.maxCancelRatio))
+		}
+		if a.OrderToFillRatio > m.maxOrderToFillRatio {
+			add(SurveillanceOrderToFillRatio, fmt.Sprintf("%d orders placed for %d filled, ratio %.2f exceeds %v",
+				a.Placed, a.Filled, a.OrderToFillRatio, m.maxOrderToFillRatio))
+		}
+	}
+	if a.WashTrades > 0 {
+		add(SurveillanceWashTrade, fmt.Sprintf("%d opposing fills at matching prices within %s", a.WashTrades, m.washTradeWindow))
+	}
+	if a.SpoofLikeCancels >= m.spoofMinOccurrences {
+		add(SurveillanceSpoofing, fmt.Sprintf("%d unfilled orders cancelled within %s of an opposing fill", a.SpoofLikeCancels, m.spoofMaxLifetime))
+	}
+	return flags
+}
+
+// washTrades returns the number of buy and sell fills, sorted by time, which
+// match each other within the wash trade window and price tolerance. Each
+// fill is matched at most once
+func (m *SurveillanceManager) washTrades(fills []surveillanceFill) int {
+	var count int
+	matched := make([]bool, len(fills))
+	for i := range fills {
+		if matched[i] {
+			continue
+		}
+		for j := i + 1; j < len(fills) && fills[j].time.Sub(fills[i].time) <= m.washTradeWindow; j++ {
+			if matched[j] || fills[i].side.IsLong() == fills[j].side.IsLong() {
+				continue
+			}
+			if math.Abs(fills[j].price-fills[i].price) <= fills[i].price*m.washTradePriceTolerance {
+				matched[i], matched[j] = true, true
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+// spoofLikeCancels returns the number of short lived unfilled orders which
+// were cancelled after an order on the opposing side filled during their
+// lifetime
+func spoofLikeCancels(cancels []*order.Detail, fills []surveillanceFill) int {
+	var count int
+	for _, c := range cancels {
+		cancelled := orderTime(c)
+		for i := range fills {
+			if fills[i].side.IsLong() != c.Side.IsLong() && !fills[i].time.Before(c.Date) && !fills[i].time.After(cancelled) {
+				count++
+				break
+			}
+		}
+	}
+	return count
+}
+
+// String renders the report as a plain text message suitable for any
+// communication channel
+func (r *SurveillanceReport) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Surveillance report %s - %s\n",
+		r.Start.UTC().Format(common.SimpleTimeFormatWithTimezone),
+		r.End.UTC().Format(common.SimpleTimeFormatWithTimezone))
+	if len(r.Flags) == 0 {
+		sb.WriteString("Flags: none\n")
+	} else {
+		sb.WriteString("Flags:\n")
+		for i := range r.Flags {
+			fmt.Fprintf(&sb, "  %s %s %s [%s] %s\n", r.Flags[i].Exchange, r.Flags[i].Asset, r.Flags[i].Pair, r.Flags[i].Check, r.Flags[i].Message)
+		}
+	}
+	if len(r.Activity) == 0 {
+		sb.WriteString("Activity: none\n")
+	} else {
+		sb.WriteString("Activity:\n")
+		for i := range r.Activity {
+			a := &r.Activity[i]
+			fmt.Fprintf(&sb, "  %s %s %s placed %d cancelled %d filled %d, cancel ratio %.4f, order to fill ratio %.2f, wash trades %d, spoof-like cancels %d\n",
+				a.Exchange, a.Asset, a.Pair, a.Placed, a.Cancelled, a.Filled, a.CancelRatio, a.OrderToFillRatio, a.WashTrades, a.SpoofLikeCancels)
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
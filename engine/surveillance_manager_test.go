@@ -0,0 +1,179 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func testSurveillanceConfig() *config.SurveillanceManager {
+	return &config.SurveillanceManager{
+		CheckInterval:           time.Minute,
+		Window:                  time.Hour,
+		MinOrders:               4,
+		MaxCancelRatio:          0.5,
+		MaxOrderToFillRatio:     3,
+		WashTradeWindow:         time.Minute,
+		WashTradePriceTolerance: 0.001,
+		SpoofMaxLifetime:        time.Second * 10,
+		SpoofMinOccurrences:     2,
+		ReportSchedule:          "@daily",
+		ReportPeriod:            time.Hour * 24,
+	}
+}
+
+// testSurveillanceOrders returns orders on btcusd which are spoof-like and
+// excessively cancelled, and orders on ethusdt which resemble wash trades
+func testSurveillanceOrders(now time.Time) []order.Detail {
+	o := func(pair string, side order.Side, status order.Status, price, executed float64, placed, updated time.Duration) order.Detail {
+		p := btcusdPair
+		if pair == "eth" {
+			p = ethusdtPair
+		}
+		return order.Detail{
+			Exchange:       "surveil",
+			AssetType:      asset.Spot,
+			Pair:           p,
+			Side:           side,
+			Status:         status,
+			Price:          price,
+			Amount:         1,
+			ExecutedAmount: executed,
+			Date:           now.Add(-placed),
+			LastUpdated:    now.Add(-updated),
+		}
+	}
+	return []order.Detail{
+		o("btc", order.Sell, order.Cancelled, 110, 0, time.Minute*10, time.Minute*10-time.Second*5),
+		o("btc", order.Sell, order.Cancelled, 111, 0, time.Minute*5, time.Minute*5-time.Second*5),
+		o("btc", order.Buy, order.Filled, 100, 1, time.Minute*10, time.Minute*10-time.Second*2),
+		o("btc", order.Buy, order.Filled, 100, 1, time.Minute*5, time.Minute*5-time.Second*2),
+		o("btc", order.Sell, order.Cancelled, 120, 0, time.Minute*30, time.Minute*20),
+		o("btc", order.Sell, order.Cancelled, 120, 0, time.Hour*3, time.Hour*3),
+		o("eth", order.Buy, order.Filled, 2000, 1, time.Minute*3, time.Minute*2),
+		o("eth", order.Sell, order.Filled, 2001, 1, time.Minute*3, time.Minute*2-time.Second*30),
+	}
+}
+
+func TestSetupSurveillanceManager(t *testing.T) {
+	t.Parallel()
+	_, err := SetupSurveillanceManager(nil, nil, nil)
+	assert.ErrorIs(t, err, errNilConfig)
+	_, err = SetupSurveillanceManager(&config.SurveillanceManager{}, nil, nil)
+	assert.ErrorIs(t, err, errNilOrderManager)
+	_, err = SetupSurveillanceManager(&config.SurveillanceManager{}, &fakeDigestOrderManager{}, nil)
+	assert.ErrorIs(t, err, errNilCommunicationsManager)
+	_, err = SetupSurveillanceManager(&config.SurveillanceManager{}, &fakeDigestOrderManager{}, &fakeComms{})
+	assert.ErrorIs(t, err, errInvalidSurveillanceConfig)
+
+	cfg := testSurveillanceConfig()
+	cfg.MaxCancelRatio = 1.5
+	_, err = SetupSurveillanceManager(cfg, &fakeDigestOrderManager{}, &fakeComms{})
+	assert.ErrorIs(t, err, errInvalidSurveillanceConfig)
+	cfg = testSurveillanceConfig()
+	cfg.SpoofMinOccurrences = 0
+	_, err = SetupSurveillanceManager(cfg, &fakeDigestOrderManager{}, &fakeComms{})
+	assert.ErrorIs(t, err, errInvalidSurveillanceConfig)
+	cfg = testSurveillanceConfig()
+	cfg.ReportSchedule = "bad"
+	_, err = SetupSurveillanceManager(cfg, &fakeDigestOrderManager{}, &fakeComms{})
+	assert.Error(t, err, "invalid report schedules should error")
+
+	_, err = SetupSurveillanceManager(testSurveillanceConfig(), &fakeDigestOrderManager{}, &fakeComms{})
+	assert.NoError(t, err)
+}
+
+func TestSurveillanceManagerStartStop(t *testing.T) {
+	t.Parallel()
+	var m *SurveillanceManager
+	assert.ErrorIs(t, m.Start(), ErrNilSubsystem)
+	assert.ErrorIs(t, m.Stop(), ErrNilSubsystem)
+	assert.False(t, m.IsRunning())
+
+	m, err := SetupSurveillanceManager(testSurveillanceConfig(), &fakeDigestOrderManager{}, &fakeComms{})
+	require.NoError(t, err)
+	assert.ErrorIs(t, m.Stop(), ErrSubSystemNotStarted)
+	require.NoError(t, m.Start())
+	assert.True(t, m.IsRunning())
+	assert.ErrorIs(t, m.Start(), ErrSubSystemAlreadyStarted)
+	require.NoError(t, m.Stop())
+	assert.False(t, m.IsRunning())
+}
+
+func TestSurveillanceAnalyse(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	m, err := SetupSurveillanceManager(testSurveillanceConfig(), &fakeDigestOrderManager{orders: testSurveillanceOrders(now)}, &fakeComms{})
+	require.NoError(t, err)
+	_, err = m.Analyse(now, now.Add(-time.Hour))
+	assert.Error(t, err, "start after end should error")
+
+	r, err := m.Analyse(now.Add(-time.Hour), now)
+	require.NoError(t, err)
+	require.Len(t, r.Activity, 2, "the order outside the window should not create activity")
+	btc, eth := r.Activity[0], r.Activity[1]
+	assert.Equal(t, btcusdPair, btc.Pair)
+	assert.Equal(t, 5, btc.Placed)
+	assert.Equal(t, 3, btc.Cancelled)
+	assert.Equal(t, 2, btc.Filled)
+	assert.Equal(t, 0.6, btc.CancelRatio)
+	assert.Equal(t, 2.5, btc.OrderToFillRatio)
+	assert.Equal(t, 2, btc.SpoofLikeCancels, "only short lived cancels around an opposing fill should be spoof-like")
+	assert.Zero(t, btc.WashTrades, "same side fills should not be wash trades")
+	assert.Equal(t, 1, eth.WashTrades)
+
+	checks := make(map[string]bool)
+	for _, f := range r.Flags {
+		checks[f.Check+":"+f.Pair.String()] = true
+	}
+	assert.Equal(t, map[string]bool{
+		SurveillanceCancelRatio + ":" + btcusdPair.String(): true,
+		SurveillanceSpoofing + ":" + btcusdPair.String():    true,
+		SurveillanceWashTrade + ":" + ethusdtPair.String():  true,
+	}, checks)
+	assert.Contains(t, r.String(), "wash trades 1")
+
+	m.washTradePriceTolerance = 0
+	r, err = m.Analyse(now.Add(-time.Hour), now)
+	require.NoError(t, err)
+	assert.Zero(t, r.Activity[1].WashTrades, "fills outside the price tolerance should not be wash trades")
+}
+
+func TestSurveillanceCheck(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	om := &fakeDigestOrderManager{orders: testSurveillanceOrders(now)}
+	comms := &fakeComms{}
+	m, err := SetupSurveillanceManager(testSurveillanceConfig(), om, comms)
+	require.NoError(t, err)
+
+	m.check(now)
+	require.Len(t, comms.events, 3)
+	for _, e := range comms.events {
+		assert.Equal(t, surveillanceEventType, e.Type)
+		assert.Equal(t, base.SeverityWarning, e.Severity)
+		assert.NotEmpty(t, e.Key)
+	}
+
+	m.check(now)
+	assert.Len(t, comms.events, 3, "patterns already flagged should not alert again")
+
+	om.orders = nil
+	m.check(now)
+	require.Len(t, comms.events, 6)
+	for _, e := range comms.events[3:] {
+		assert.True(t, e.Resolved, "patterns no longer found should be resolved")
+	}
+	assert.Empty(t, m.flagged)
+
+	m.sendReport(now)
+	require.Len(t, comms.events, 7)
+	assert.Contains(t, comms.events[6].Message, "Surveillance report")
+	assert.Contains(t, comms.events[6].Message, "Flags: none")
+}
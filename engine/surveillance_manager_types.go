@@ -0,0 +1,99 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/cron"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// SurveillanceManagerName is an exported subsystem name
+const SurveillanceManagerName = "surveillance_manager"
+
+// surveillanceEventType is the communications event type used for
+// surveillance alerts and reports
+const surveillanceEventType = "surveillance"
+
+// Surveillance checks which can flag a pair's trading activity
+const (
+	SurveillanceCancelRatio      = "cancel_ratio"
+	SurveillanceOrderToFillRatio = "order_to_fill_ratio"
+	SurveillanceWashTrade        = "wash_trade"
+	SurveillanceSpoofing         = "spoofing"
+)
+
+var errInvalidSurveillanceConfig = errors.New("invalid surveillance config")
+
+// iSurveillanceOrderManager defines the order manager functions used to
+// analyse trading activity
+type iSurveillanceOrderManager interface {
+	GetOrdersSnapshot(order.Status) []order.Detail
+}
+
+// SurveillanceManager periodically analyses the bot's own order, cancel and
+// fill activity, alerting when it resembles wash trading, spoofing or
+// excessive cancelling and sending scheduled reports
+type SurveillanceManager struct {
+	started                 int32
+	shutdown                chan struct{}
+	wg                      sync.WaitGroup
+	verbose                 bool
+	checkInterval           time.Duration
+	window                  time.Duration
+	minOrders               int
+	maxCancelRatio          float64
+	maxOrderToFillRatio     float64
+	washTradeWindow         time.Duration
+	washTradePriceTolerance float64
+	spoofMaxLifetime        time.Duration
+	spoofMinOccurrences     int
+	reportSchedule          *cron.Schedule
+	reportPeriod            time.Duration
+	orderManager            iSurveillanceOrderManager
+	comms                   iCommsManager
+	m                       sync.Mutex
+	flagged                 map[string]bool
+}
+
+// SurveillanceReport holds the trading activity of each pair over a period
+// of time and any patterns flagged
+type SurveillanceReport struct {
+	Start    time.Time
+	End      time.Time
+	Activity []SurveillanceActivity
+	Flags    []SurveillanceFlag
+}
+
+// SurveillanceActivity holds the order, cancel and fill counts of a pair
+type SurveillanceActivity struct {
+	Exchange         string
+	Asset            asset.Item
+	Pair             currency.Pair
+	Placed           int
+	Cancelled        int
+	Filled           int
+	CancelRatio      float64
+	OrderToFillRatio float64
+	WashTrades       int
+	SpoofLikeCancels int
+}
+
+// SurveillanceFlag holds a pattern found in a pair's trading activity
+type SurveillanceFlag struct {
+	Check    string
+	Exchange string
+	Asset    asset.Item
+	Pair     currency.Pair
+	Message  string
+}
+
+// surveillanceFill holds an order fill used to match opposing fills
+type surveillanceFill struct {
+	side  order.Side
+	price float64
+	time  time.Time
+}
@@ -0,0 +1,249 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/options"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// SetupVolatilitySurfaceManager creates a volatility surface manager subsystem
+func SetupVolatilitySurfaceManager(cfg *config.VolatilitySurfaceManager, em iExchangeManager) (*VolatilitySurfaceManager, error) {
+	if cfg == nil {
+		return nil, errNilConfig
+	}
+	if em == nil {
+		return nil, errNilExchangeManager
+	}
+	if cfg.CheckInterval <= 0 {
+		return nil, fmt.Errorf("%w check interval %v", errInvalidVolatilitySurfaceConfig, cfg.CheckInterval)
+	}
+	if cfg.HistorySize <= 0 {
+		return nil, fmt.Errorf("%w history size %d", errInvalidVolatilitySurfaceConfig, cfg.HistorySize)
+	}
+	method, err := options.StringToInterpolationMethod(cfg.Interpolation)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidVolatilitySurfaceConfig, err)
+	}
+	m := &VolatilitySurfaceManager{
+		verbose:         cfg.Verbose,
+		interval:        cfg.CheckInterval,
+		method:          method,
+		riskFreeRate:    cfg.RiskFreeRate,
+		historySize:     cfg.HistorySize,
+		exchangeManager: em,
+		surfaces:        make(map[string][]*options.Surface),
+	}
+	if len(cfg.Exchanges) > 0 {
+		m.exchanges = make(map[string]struct{}, len(cfg.Exchanges))
+		for i := range cfg.Exchanges {
+			m.exchanges[strings.ToLower(cfg.Exchanges[i])] = struct{}{}
+		}
+	}
+	if len(cfg.Underlyings) > 0 {
+		m.underlyings = make(map[string]struct{}, len(cfg.Underlyings))
+		for i := range cfg.Underlyings {
+			m.underlyings[strings.ToUpper(cfg.Underlyings[i])] = struct{}{}
+		}
+	}
+	return m, nil
+}
+
+// Start runs the subsystem
+func (m *VolatilitySurfaceManager) Start() error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 0, 1) {
+		return ErrSubSystemAlreadyStarted
+	}
+	m.shutdown = make(chan struct{})
+	m.wg.Add(1)
+	go m.run()
+	log.Debugf(log.ExchangeSys, "Volatility surface manager %s", MsgSubSystemStarted)
+	return nil
+}
+
+// IsRunning checks whether the subsystem is running
+func (m *VolatilitySurfaceManager) IsRunning() bool {
+	if m == nil {
+		return false
+	}
+	return atomic.LoadInt32(&m.started) == 1
+}
+
+// Stop stops the subsystem
+func (m *VolatilitySurfaceManager) Stop() error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 1, 0) {
+		return ErrSubSystemNotStarted
+	}
+	close(m.shutdown)
+	m.wg.Wait()
+	log.Debugf(log.ExchangeSys, "Volatility surface manager %s", MsgSubSystemShutdown)
+	return nil
+}
+
+func (m *VolatilitySurfaceManager) run() {
+	defer m.wg.Done()
+	runScheduledJobs(m.shutdown, &scheduledJob{interval: m.interval, immediate: true, run: func(ctx context.Context, _ time.Time) { m.buildSurfaces(ctx) }})
+}
+
+// buildSurfaces solves the implied volatility of every enabled option pair
+// from its mark price and builds a surface per exchange and underlying
+func (m *VolatilitySurfaceManager) buildSurfaces(ctx context.Context) {
+	if !atomic.CompareAndSwapInt32(&m.processing, 0, 1) {
+		return
+	}
+	defer atomic.StoreInt32(&m.processing, 0)
+	inputs := m.collectQuotes(ctx)
+	now := time.Now()
+	for k, in := range inputs {
+		forward := in.forwardSum / float64(len(in.quotes))
+		s, err := options.NewSurface(currency.NewCode(in.underlying), forward, in.quotes, m.method, now)
+		if err != nil {
+			log.Errorf(log.ExchangeSys, "Volatility surface manager cannot build %s %s surface: %v", in.exchange, in.underlying, err)
+			continue
+		}
+		if m.verbose {
+			log.Debugf(log.ExchangeSys, "Volatility surface manager built %s %s surface from %d quotes across %d expiries", in.exchange, in.underlying, len(in.quotes), len(s.Slices))
+		}
+		m.m.Lock()
+		history := append(m.surfaces[k], s)
+		if len(history) > m.historySize {
+			history = slices.Delete(history, 0, len(history)-m.historySize)
+		}
+		m.surfaces[k] = history
+		m.m.Unlock()
+	}
+}
+
+// collectQuotes returns the implied volatilities of enabled option pairs keyed
+// by exchange and underlying
+func (m *VolatilitySurfaceManager) collectQuotes(ctx context.Context) map[string]*surfaceInput {
+	exchanges, err := m.exchangeManager.GetExchanges()
+	if err != nil {
+		log.Errorf(log.ExchangeSys, "Volatility surface manager cannot get exchanges: %v", err)
+		return nil
+	}
+	now := time.Now()
+	inputs := make(map[string]*surfaceInput)
+	for x := range exchanges {
+		name := exchanges[x].GetName()
+		if m.exchanges != nil {
+			if _, ok := m.exchanges[strings.ToLower(name)]; !ok {
+				continue
+			}
+		}
+		if !exchanges[x].GetAssetTypes(true).Contains(asset.Options) {
+			continue
+		}
+		pairs, err := exchanges[x].GetEnabledPairs(asset.Options)
+		if err != nil {
+			continue
+		}
+		for i := range pairs {
+			contract, err := options.ParseContract(pairs[i])
+			if err != nil {
+				continue
+			}
+			underlying := contract.Underlying.Upper().String()
+			if m.underlyings != nil {
+				if _, ok := m.underlyings[underlying]; !ok {
+					continue
+				}
+			}
+			t, err := exchanges[x].FetchTicker(ctx, pairs[i], asset.Options)
+			if err != nil {
+				log.Errorf(log.ExchangeSys, "Volatility surface manager cannot get %s %s %s price: %v", name, asset.Options, pairs[i], err)
+				continue
+			}
+			mark := t.MarkPrice
+			if mark <= 0 {
+				mark = t.Last
+			}
+			if mark <= 0 || t.IndexPrice <= 0 {
+				continue
+			}
+			params := &options.Params{
+				Type:         contract.Type,
+				Underlying:   t.IndexPrice,
+				Strike:       contract.Strike,
+				TimeToExpiry: options.TimeToExpiry(contract.Expiry, now),
+				RiskFreeRate: m.riskFreeRate,
+			}
+			iv, err := options.ImpliedVolatility(mark, params)
+			if err != nil {
+				if m.verbose {
+					log.Debugf(log.ExchangeSys, "Volatility surface manager cannot solve %s %s implied volatility: %v", name, pairs[i], err)
+				}
+				continue
+			}
+			k := surfaceKey(name, contract.Underlying)
+			in, ok := inputs[k]
+			if !ok {
+				in = &surfaceInput{exchange: name, underlying: underlying}
+				inputs[k] = in
+			}
+			in.quotes = append(in.quotes, options.Quote{Strike: contract.Strike, Expiry: contract.Expiry, Volatility: iv})
+			in.forwardSum += t.IndexPrice
+		}
+	}
+	return inputs
+}
+
+// GetSurface returns the latest surface for an exchange and underlying, or the
+// most recent snapshot built at or before the time when set
+func (m *VolatilitySurfaceManager) GetSurface(exch string, underlying currency.Code, at time.Time) (*options.Surface, error) {
+	if m == nil {
+		return nil, fmt.Errorf("%s %w", VolatilitySurfaceManagerName, ErrNilSubsystem)
+	}
+	m.m.RLock()
+	defer m.m.RUnlock()
+	history := m.surfaces[surfaceKey(exch, underlying)]
+	if len(history) == 0 {
+		return nil, fmt.Errorf("%w for %s %s", errVolatilitySurfaceNotFound, exch, underlying)
+	}
+	if at.IsZero() {
+		return history[len(history)-1], nil
+	}
+	i := sort.Search(len(history), func(i int) bool {
+		return history[i].Built.After(at)
+	})
+	if i == 0 {
+		return nil, fmt.Errorf("%w for %s %s at %s", errVolatilitySurfaceNotFound, exch, underlying, at)
+	}
+	return history[i-1], nil
+}
+
+// GetSurfaceHistory returns the times of retained surface snapshots for an
+// exchange and underlying
+func (m *VolatilitySurfaceManager) GetSurfaceHistory(exch string, underlying currency.Code) []time.Time {
+	if m == nil {
+		return nil
+	}
+	m.m.RLock()
+	defer m.m.RUnlock()
+	history := m.surfaces[surfaceKey(exch, underlying)]
+	times := make([]time.Time, len(history))
+	for i := range history {
+		times[i] = history[i].Built
+	}
+	return times
+}
+
+// surfaceKey returns the key of a surface by exchange and underlying
+func surfaceKey(exch string, underlying currency.Code) string {
+	return strings.ToLower(exch) + "/" + underlying.Upper().String()
+}
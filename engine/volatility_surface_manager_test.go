@@ -0,0 +1,161 @@
+package engine
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/options"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+)
+
+// surfaceExchange is a fake exchange with option tickers priced at a fixed
+// volatility
+type surfaceExchange struct {
+	exchange.IBotExchange
+	pairs   currency.Pairs
+	tickers map[string]*ticker.Price
+}
+
+func (f *surfaceExchange) GetName() string {
+	return "surface"
+}
+
+func (f *surfaceExchange) GetAssetTypes(bool) asset.Items {
+	return asset.Items{asset.Spot, asset.Options}
+}
+
+func (f *surfaceExchange) GetEnabledPairs(asset.Item) (currency.Pairs, error) {
+	return f.pairs, nil
+}
+
+func (f *surfaceExchange) FetchTicker(_ context.Context, p currency.Pair, _ asset.Item) (*ticker.Price, error) {
+	t, ok := f.tickers[p.String()]
+	if !ok {
+		return nil, errExpectedTestError
+	}
+	return t, nil
+}
+
+// newSurfaceExchange returns a fake exchange with BTC options over two expiries
+// marked at 50% volatility, and an ETH option without an index price
+func newSurfaceExchange(t *testing.T) *surfaceExchange {
+	t.Helper()
+	f := &surfaceExchange{tickers: make(map[string]*ticker.Price)}
+	now := time.Now().UTC()
+	for _, days := range []int{30, 60} {
+		expiry := now.AddDate(0, 0, days)
+		for _, strike := range []string{"40000", "50000", "60000", "70000", "80000"} {
+			p := currency.NewPair(currency.BTC, currency.NewCode("USD-"+expiry.Format("060102")+"-"+strike+"-C"))
+			c, err := options.ParseContract(p)
+			require.NoError(t, err)
+			price, err := options.Price(&options.Params{Type: c.Type, Underlying: 60000, Strike: c.Strike, TimeToExpiry: options.TimeToExpiry(c.Expiry, now), Volatility: 0.5})
+			require.NoError(t, err)
+			f.pairs = append(f.pairs, p)
+			f.tickers[p.String()] = &ticker.Price{MarkPrice: price, IndexPrice: 60000}
+		}
+	}
+	eth := currency.NewPair(currency.ETH, currency.NewCode("USD-"+now.AddDate(0, 0, 30).Format("060102")+"-3000-C"))
+	f.pairs = append(f.pairs, eth, currency.NewPair(currency.BTC, currency.NewCode("USD-OPTION")))
+	f.tickers[eth.String()] = &ticker.Price{MarkPrice: 100}
+	return f
+}
+
+func testVolatilitySurfaceConfig() *config.VolatilitySurfaceManager {
+	return &config.VolatilitySurfaceManager{
+		CheckInterval: time.Minute,
+		Interpolation: "spline",
+		HistorySize:   2,
+	}
+}
+
+func TestSetupVolatilitySurfaceManager(t *testing.T) {
+	t.Parallel()
+	_, err := SetupVolatilitySurfaceManager(nil, nil)
+	assert.ErrorIs(t, err, errNilConfig)
+	cfg := &config.VolatilitySurfaceManager{}
+	_, err = SetupVolatilitySurfaceManager(cfg, nil)
+	assert.ErrorIs(t, err, errNilExchangeManager)
+	em := NewExchangeManager()
+	_, err = SetupVolatilitySurfaceManager(cfg, em)
+	assert.ErrorIs(t, err, errInvalidVolatilitySurfaceConfig)
+	cfg.CheckInterval = time.Minute
+	_, err = SetupVolatilitySurfaceManager(cfg, em)
+	assert.ErrorIs(t, err, errInvalidVolatilitySurfaceConfig)
+	cfg.HistorySize = 1
+	_, err = SetupVolatilitySurfaceManager(cfg, em)
+	assert.ErrorIs(t, err, errInvalidVolatilitySurfaceConfig, "unset interpolation should error")
+	cfg.Interpolation = "svi"
+	cfg.Exchanges = []string{"Surface"}
+	cfg.Underlyings = []string{"btc"}
+	m, err := SetupVolatilitySurfaceManager(cfg, em)
+	require.NoError(t, err)
+	assert.Equal(t, options.SVIInterpolation, m.method)
+	assert.Contains(t, m.exchanges, "surface")
+	assert.Contains(t, m.underlyings, "BTC")
+}
+
+func TestVolatilitySurfaceManagerStartStop(t *testing.T) {
+	t.Parallel()
+	var m *VolatilitySurfaceManager
+	assert.ErrorIs(t, m.Start(), ErrNilSubsystem)
+	assert.ErrorIs(t, m.Stop(), ErrNilSubsystem)
+	assert.False(t, m.IsRunning())
+
+	m, err := SetupVolatilitySurfaceManager(testVolatilitySurfaceConfig(), NewExchangeManager())
+	require.NoError(t, err)
+	require.NoError(t, m.Start())
+	assert.True(t, m.IsRunning())
+	assert.ErrorIs(t, m.Start(), ErrSubSystemAlreadyStarted)
+	require.NoError(t, m.Stop())
+	assert.ErrorIs(t, m.Stop(), ErrSubSystemNotStarted)
+}
+
+func TestBuildSurfaces(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	require.NoError(t, em.Add(newSurfaceExchange(t)))
+	m, err := SetupVolatilitySurfaceManager(testVolatilitySurfaceConfig(), em)
+	require.NoError(t, err)
+
+	_, err = m.GetSurface("surface", currency.BTC, time.Time{})
+	assert.ErrorIs(t, err, errVolatilitySurfaceNotFound)
+
+	m.buildSurfaces(context.Background())
+	s, err := m.GetSurface("SURFACE", currency.NewCode("btc"), time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, 60000.0, s.Forward)
+	require.Len(t, s.Slices, 2)
+	assert.Len(t, s.Slices[0].Quotes, 5)
+	iv, err := s.ImpliedVolatility(55000, time.Now().AddDate(0, 0, 45))
+	require.NoError(t, err)
+	assert.InDelta(t, 0.5, iv, 1e-3, "a flat surface should interpolate to its volatility")
+	_, err = m.GetSurface("surface", currency.ETH, time.Time{})
+	assert.ErrorIs(t, err, errVolatilitySurfaceNotFound, "options without an index price should be skipped")
+
+	first := s.Built
+	for range 2 {
+		m.buildSurfaces(context.Background())
+	}
+	history := m.GetSurfaceHistory("surface", currency.BTC)
+	require.Len(t, history, 2, "history should be limited to the history size")
+	assert.True(t, history[0].After(first), "oldest snapshots should be dropped")
+	old, err := m.GetSurface("surface", currency.BTC, history[0])
+	require.NoError(t, err)
+	assert.Equal(t, history[0], old.Built, "snapshots at or before the time should be returned")
+	_, err = m.GetSurface("surface", currency.BTC, first)
+	assert.ErrorIs(t, err, errVolatilitySurfaceNotFound, "times before retained history should error")
+
+	cfg := testVolatilitySurfaceConfig()
+	cfg.Exchanges = []string{"other"}
+	m, err = SetupVolatilitySurfaceManager(cfg, em)
+	require.NoError(t, err)
+	m.buildSurfaces(context.Background())
+	assert.Empty(t, m.GetSurfaceHistory("surface", currency.BTC), "unlisted exchanges should be ignored")
+}
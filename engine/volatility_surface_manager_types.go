@@ -0,0 +1,47 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/exchanges/options"
+)
+
+// VolatilitySurfaceManagerName is an exported subsystem name
+const VolatilitySurfaceManagerName = "volatility_surface_manager"
+
+var (
+	errInvalidVolatilitySurfaceConfig = errors.New("invalid volatility surface manager config")
+	errVolatilitySurfaceNotFound      = errors.New("volatility surface not found")
+)
+
+// VolatilitySurfaceManager periodically builds an implied volatility surface
+// per exchange and underlying from the mark prices of enabled option pairs,
+// retaining a history of surface snapshots
+type VolatilitySurfaceManager struct {
+	started         int32
+	processing      int32
+	shutdown        chan struct{}
+	wg              sync.WaitGroup
+	verbose         bool
+	interval        time.Duration
+	method          options.InterpolationMethod
+	riskFreeRate    float64
+	historySize     int
+	exchanges       map[string]struct{}
+	underlyings     map[string]struct{}
+	exchangeManager iExchangeManager
+	m               sync.RWMutex
+	// surfaces holds snapshots keyed by exchange and underlying, ordered from
+	// oldest to newest
+	surfaces map[string][]*options.Surface
+}
+
+// surfaceInput holds the quotes and underlying prices collected for a surface
+type surfaceInput struct {
+	exchange   string
+	underlying string
+	quotes     []options.Quote
+	forwardSum float64
+}
@@ -0,0 +1,323 @@
+package engine
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/crypto"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/fill"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/transfer"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// RegisterWebhookParser adds a parser for a provider's callback format, which
+// webhook sources select by name. Parsers must be registered before the
+// webhook listener is setup
+func RegisterWebhookParser(format string, p WebhookParser) error {
+	if format == "" {
+		return fmt.Errorf("%w: empty format", errUnknownWebhookFormat)
+	}
+	if p == nil {
+		return fmt.Errorf("%w WebhookParser", common.ErrNilPointer)
+	}
+	format = strings.ToLower(format)
+	webhookParsersMtx.Lock()
+	defer webhookParsersMtx.Unlock()
+	if _, ok := webhookParsers[format]; ok {
+		return fmt.Errorf("%w: %s", errWebhookFormatExists, format)
+	}
+	webhookParsers[format] = p
+	return nil
+}
+
+// SetupWebhookListener creates a webhook listener subsystem
+func SetupWebhookListener(cfg *config.WebhookListener, router iWebhookDataRouter) (*WebhookListener, error) {
+	if cfg == nil {
+		return nil, errNilConfig
+	}
+	if router == nil {
+		return nil, errNilWebhookRouter
+	}
+	if cfg.ListenAddress == "" {
+		return nil, fmt.Errorf("%w: listen address unset", errInvalidWebhookConfig)
+	}
+	if cfg.MaxBodySize <= 0 {
+		return nil, fmt.Errorf("%w max body size %d must be above zero", errInvalidWebhookConfig, cfg.MaxBodySize)
+	}
+	if len(cfg.Sources) == 0 {
+		return nil, errNoWebhookSources
+	}
+	sources := make(map[string]*webhookSource, len(cfg.Sources))
+	webhookParsersMtx.RLock()
+	defer webhookParsersMtx.RUnlock()
+	for i := range cfg.Sources {
+		s := &cfg.Sources[i]
+		if s.Name == "" || s.Exchange == "" || s.Secret == "" {
+			return nil, fmt.Errorf("%w #%d: name, exchange and secret must be set", errInvalidWebhookSource, i)
+		}
+		k := strings.ToLower(s.Name)
+		if _, ok := sources[k]; ok {
+			return nil, fmt.Errorf("%w: %s", errDuplicateWebhookSource, s.Name)
+		}
+		p, ok := webhookParsers[strings.ToLower(s.Format)]
+		if !ok {
+			return nil, fmt.Errorf("%w %q for source %s", errUnknownWebhookFormat, s.Format, s.Name)
+		}
+		sources[k] = &webhookSource{
+			name:     s.Name,
+			exchange: s.Exchange,
+			secret:   []byte(s.Secret),
+			parser:   p,
+		}
+	}
+	return &WebhookListener{
+		verbose:       cfg.Verbose,
+		listenAddress: cfg.ListenAddress,
+		maxBodySize:   cfg.MaxBodySize,
+		sources:       sources,
+		router:        router,
+	}, nil
+}
+
+// Start runs the subsystem
+func (l *WebhookListener) Start() error {
+	if l == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&l.started, 0, 1) {
+		return ErrSubSystemAlreadyStarted
+	}
+	ln, err := net.Listen("tcp", l.listenAddress)
+	if err != nil {
+		atomic.StoreInt32(&l.started, 0)
+		return err
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(http.MethodPost+" "+webhookPath+"{source}", l.handleWebhook)
+	l.server = &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: webhookReadTimeout,
+	}
+	l.wg.Add(1)
+	go func() {
+		defer l.wg.Done()
+		if err := l.server.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Errorf(log.Global, "Webhook listener stopped serving: %v", err)
+		}
+	}()
+	log.Debugf(log.Global, "Webhook listener %s listening on %s", MsgSubSystemStarted, ln.Addr())
+	return nil
+}
+
+// IsRunning checks whether the subsystem is running
+func (l *WebhookListener) IsRunning() bool {
+	if l == nil {
+		return false
+	}
+	return atomic.LoadInt32(&l.started) == 1
+}
+
+// Stop stops the subsystem
+func (l *WebhookListener) Stop() error {
+	if l == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&l.started, 1, 0) {
+		return ErrSubSystemNotStarted
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), webhookStopTimeout)
+	defer cancel()
+	err := l.server.Shutdown(ctx)
+	l.wg.Wait()
+	log.Debugf(log.Global, "Webhook listener %s", MsgSubSystemShutdown)
+	return err
+}
+
+// handleWebhook verifies a source's signed callback, normalises its account
+// events and routes them alongside websocket data
+func (l *WebhookListener) handleWebhook(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("source")
+	src, ok := l.sources[strings.ToLower(name)]
+	if !ok {
+		http.Error(w, errUnknownWebhookSource.Error(), http.StatusNotFound)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, l.maxBodySize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err = src.verify(body, r.Header.Get(WebhookSignatureHeader)); err != nil {
+		log.Warnf(log.Global, "Webhook listener rejected %s callback from %s: %v", src.name, r.RemoteAddr, err)
+		http.Error(w, errInvalidWebhookSignature.Error(), http.StatusUnauthorized)
+		return
+	}
+	events, err := src.parser(src.exchange, body)
+	if err != nil {
+		log.Errorf(log.Global, "Webhook listener unable to parse %s callback: %v", src.name, err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err = l.route(src, events); err != nil {
+		log.Errorf(log.Global, "Webhook listener unable to route %s callback: %v", src.name, err)
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// route passes a callback's fills and transfers to the data router
+func (l *WebhookListener) route(src *webhookSource, events *WebhookEvents) error {
+	if events == nil {
+		return nil
+	}
+	if l.verbose {
+		log.Debugf(log.Global, "Webhook listener received %d fills and %d transfers from %s", len(events.Fills), len(events.Transfers), src.name)
+	}
+	if len(events.Fills) > 0 {
+		if err := l.router.RouteExternalData(src.exchange, events.Fills); err != nil {
+			return err
+		}
+	}
+	if len(events.Transfers) > 0 {
+		return l.router.RouteExternalData(src.exchange, events.Transfers)
+	}
+	return nil
+}
+
+// verify checks a callback body's HMAC-SHA256 signature against the source's
+// secret
+func (s *webhookSource) verify(body []byte, signature string) error {
+	if signature == "" {
+		return fmt.Errorf("%w: %s header unset", errInvalidWebhookSignature, WebhookSignatureHeader)
+	}
+	received, err := hex.DecodeString(strings.TrimPrefix(strings.ToLower(signature), webhookSignaturePre))
+	if err != nil {
+		return fmt.Errorf("%w: %w", errInvalidWebhookSignature, err)
+	}
+	expected, err := crypto.GetHMAC(crypto.HashSHA256, body, s.secret)
+	if err != nil {
+		return err
+	}
+	if !hmac.Equal(received, expected) {
+		return errInvalidWebhookSignature
+	}
+	return nil
+}
+
+// parseGCTWebhook parses a callback in the gct webhook format. Events without
+// a timestamp are stamped with their time of receipt
+func parseGCTWebhook(exchName string, body []byte) (*WebhookEvents, error) {
+	var payload gctWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	events := &WebhookEvents{}
+	for i := range payload.Events {
+		e := &payload.Events[i]
+		if e.ID == "" {
+			return nil, fmt.Errorf("%w #%d: id unset", errInvalidWebhookEvent, i)
+		}
+		if e.Amount <= 0 {
+			return nil, fmt.Errorf("%w %s: %w", errInvalidWebhookEvent, e.ID, order.ErrAmountIsInvalid)
+		}
+		if e.Timestamp.IsZero() {
+			e.Timestamp = now
+		}
+		switch strings.ToLower(e.Type) {
+		case "fill":
+			f, err := e.toFill(exchName)
+			if err != nil {
+				return nil, fmt.Errorf("%w %s: %w", errInvalidWebhookEvent, e.ID, err)
+			}
+			events.Fills = append(events.Fills, *f)
+		case "transfer":
+			t, err := e.toTransfer(exchName)
+			if err != nil {
+				return nil, fmt.Errorf("%w %s: %w", errInvalidWebhookEvent, e.ID, err)
+			}
+			events.Transfers = append(events.Transfers, *t)
+		default:
+			return nil, fmt.Errorf("%w %s: unknown type %q", errInvalidWebhookEvent, e.ID, e.Type)
+		}
+	}
+	return events, nil
+}
+
+func (e *gctWebhookEvent) toFill(exchName string) (*fill.Data, error) {
+	a, err := asset.New(e.Asset)
+	if err != nil {
+		return nil, err
+	}
+	p, err := currency.NewPairFromString(e.Pair)
+	if err != nil {
+		return nil, err
+	}
+	side, err := order.StringToOrderSide(e.Side)
+	if err != nil {
+		return nil, err
+	}
+	if e.Price <= 0 {
+		return nil, errInvalidWebhookPrice
+	}
+	return &fill.Data{
+		ID:            e.ID,
+		Timestamp:     e.Timestamp,
+		Exchange:      exchName,
+		AssetType:     a,
+		CurrencyPair:  p,
+		Side:          side,
+		OrderID:       e.OrderID,
+		ClientOrderID: e.ClientOrderID,
+		TradeID:       e.TradeID,
+		Price:         e.Price,
+		Amount:        e.Amount,
+	}, nil
+}
+
+func (e *gctWebhookEvent) toTransfer(exchName string) (*transfer.Event, error) {
+	if e.Currency == "" {
+		return nil, currency.ErrCurrencyCodeEmpty
+	}
+	d, err := transfer.ParseDirection(e.Direction)
+	if err != nil {
+		return nil, err
+	}
+	t := &transfer.Event{
+		Exchange:  exchName,
+		ID:        e.ID,
+		Currency:  currency.NewCode(e.Currency),
+		Direction: d,
+		Status:    e.Status,
+		Amount:    e.Amount,
+		Fee:       e.Fee,
+		Address:   e.Address,
+		TxID:      e.TxID,
+		Timestamp: e.Timestamp,
+	}
+	if e.Network != "" {
+		t.Network = transfer.NormaliseNetwork(e.Network)
+	}
+	return t, nil
+}
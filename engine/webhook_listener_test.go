@@ -0,0 +1,201 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/crypto"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/fill"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/transfer"
+)
+
+type webhookRouterTester struct {
+	mu   sync.Mutex
+	data map[string][]interface{}
+	err  error
+}
+
+func (r *webhookRouterTester) RouteExternalData(exchName string, data interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return r.err
+	}
+	if r.data == nil {
+		r.data = make(map[string][]interface{})
+	}
+	r.data[exchName] = append(r.data[exchName], data)
+	return nil
+}
+
+func testWebhookConfig() *config.WebhookListener {
+	return &config.WebhookListener{
+		ListenAddress: "localhost:0",
+		MaxBodySize:   1024,
+		Sources: []config.WebhookSource{
+			{Name: "custodian", Exchange: "Custodian", Format: GCTWebhookFormat, Secret: "secret"},
+		},
+	}
+}
+
+func signWebhook(t *testing.T, body []byte, secret string) string {
+	t.Helper()
+	sig, err := crypto.GetHMAC(crypto.HashSHA256, body, []byte(secret))
+	require.NoError(t, err, "GetHMAC must not error")
+	return hex.EncodeToString(sig)
+}
+
+func TestRegisterWebhookParser(t *testing.T) {
+	t.Parallel()
+	assert.ErrorIs(t, RegisterWebhookParser("", parseGCTWebhook), errUnknownWebhookFormat)
+	assert.ErrorIs(t, RegisterWebhookParser("test", nil), common.ErrNilPointer)
+	assert.ErrorIs(t, RegisterWebhookParser("GCT", parseGCTWebhook), errWebhookFormatExists)
+	require.NoError(t, RegisterWebhookParser("TestRegisterWebhookParser", func(string, []byte) (*WebhookEvents, error) { return nil, nil }))
+
+	cfg := testWebhookConfig()
+	cfg.Sources[0].Format = "testregisterwebhookparser"
+	_, err := SetupWebhookListener(cfg, &webhookRouterTester{})
+	assert.NoError(t, err, "sources should select registered formats")
+}
+
+func TestSetupWebhookListener(t *testing.T) {
+	t.Parallel()
+	_, err := SetupWebhookListener(nil, nil)
+	assert.ErrorIs(t, err, errNilConfig)
+	_, err = SetupWebhookListener(&config.WebhookListener{}, nil)
+	assert.ErrorIs(t, err, errNilWebhookRouter)
+	_, err = SetupWebhookListener(&config.WebhookListener{}, &webhookRouterTester{})
+	assert.ErrorIs(t, err, errInvalidWebhookConfig)
+	_, err = SetupWebhookListener(&config.WebhookListener{ListenAddress: "localhost:0"}, &webhookRouterTester{})
+	assert.ErrorIs(t, err, errInvalidWebhookConfig)
+	_, err = SetupWebhookListener(&config.WebhookListener{ListenAddress: "localhost:0", MaxBodySize: 1}, &webhookRouterTester{})
+	assert.ErrorIs(t, err, errNoWebhookSources)
+
+	cfg := testWebhookConfig()
+	cfg.Sources[0].Secret = ""
+	_, err = SetupWebhookListener(cfg, &webhookRouterTester{})
+	assert.ErrorIs(t, err, errInvalidWebhookSource)
+
+	cfg = testWebhookConfig()
+	cfg.Sources = append(cfg.Sources, config.WebhookSource{Name: "CUSTODIAN", Exchange: "Other", Format: GCTWebhookFormat, Secret: "secret"})
+	_, err = SetupWebhookListener(cfg, &webhookRouterTester{})
+	assert.ErrorIs(t, err, errDuplicateWebhookSource)
+
+	cfg = testWebhookConfig()
+	cfg.Sources[0].Format = "unknown"
+	_, err = SetupWebhookListener(cfg, &webhookRouterTester{})
+	assert.ErrorIs(t, err, errUnknownWebhookFormat)
+
+	l, err := SetupWebhookListener(testWebhookConfig(), &webhookRouterTester{})
+	require.NoError(t, err)
+	assert.Contains(t, l.sources, "custodian")
+}
+
+func TestWebhookListenerStartStop(t *testing.T) {
+	t.Parallel()
+	var l *WebhookListener
+	assert.ErrorIs(t, l.Start(), ErrNilSubsystem)
+	assert.ErrorIs(t, l.Stop(), ErrNilSubsystem)
+	assert.False(t, l.IsRunning())
+
+	l, err := SetupWebhookListener(testWebhookConfig(), &webhookRouterTester{})
+	require.NoError(t, err)
+	assert.ErrorIs(t, l.Stop(), ErrSubSystemNotStarted)
+	require.NoError(t, l.Start())
+	assert.ErrorIs(t, l.Start(), ErrSubSystemAlreadyStarted)
+	assert.True(t, l.IsRunning())
+	require.NoError(t, l.Stop())
+	assert.False(t, l.IsRunning())
+}
+
+func TestHandleWebhook(t *testing.T) {
+	t.Parallel()
+	r := &webhookRouterTester{}
+	l, err := SetupWebhookListener(testWebhookConfig(), r)
+	require.NoError(t, err)
+	mux := http.NewServeMux()
+	mux.HandleFunc(http.MethodPost+" "+webhookPath+"{source}", l.handleWebhook)
+
+	post := func(source string, body []byte, signature string) int {
+		req := httptest.NewRequest(http.MethodPost, webhookPath+source, bytes.NewReader(body))
+		if signature != "" {
+			req.Header.Set(WebhookSignatureHeader, signature)
+		}
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	body := []byte(`{"events":[` +
+		`{"type":"fill","id":"1","timestamp":"2024-01-01T00:00:00Z","asset":"spot","pair":"BTC-USDT","side":"buy","orderID":"o1","price":100,"amount":2},` +
+		`{"type":"transfer","id":"2","currency":"usdt","direction":"deposit","network":"ETH","status":"completed","amount":50,"txID":"0xabc"}]}`)
+	sig := signWebhook(t, body, "secret")
+	assert.Equal(t, http.StatusNotFound, post("unknown", body, sig))
+	assert.Equal(t, http.StatusUnauthorized, post("custodian", body, ""), "unsigned callbacks should be rejected")
+	assert.Equal(t, http.StatusUnauthorized, post("custodian", body, signWebhook(t, body, "wrong")), "callbacks signed with another secret should be rejected")
+	assert.Equal(t, http.StatusUnauthorized, post("custodian", body, "nothex"))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, post("custodian", make([]byte, 2048), sig))
+	bad := []byte(`{"events":[{"type":"margin","id":"1","amount":1}]}`)
+	assert.Equal(t, http.StatusBadRequest, post("custodian", bad, signWebhook(t, bad, "secret")))
+	assert.Empty(t, r.data, "rejected callbacks must not be routed")
+
+	require.Equal(t, http.StatusNoContent, post("Custodian", body, webhookSignaturePre+sig))
+	require.Len(t, r.data["Custodian"], 2, "fills and transfers must be routed under the source's exchange")
+	fills, ok := r.data["Custodian"][0].([]fill.Data)
+	require.True(t, ok, "fills must be routed as []fill.Data")
+	assert.Equal(t, []fill.Data{{
+		ID:           "1",
+		Timestamp:    time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Exchange:     "Custodian",
+		AssetType:    asset.Spot,
+		CurrencyPair: currency.NewPairWithDelimiter("BTC", "USDT", "-"),
+		Side:         order.Buy,
+		OrderID:      "o1",
+		Price:        100,
+		Amount:       2,
+	}}, fills)
+	transfers, ok := r.data["Custodian"][1].([]transfer.Event)
+	require.True(t, ok, "transfers must be routed as []transfer.Event")
+	require.Len(t, transfers, 1)
+	assert.Equal(t, transfer.Deposit, transfers[0].Direction)
+	assert.Equal(t, transfer.ERC20, transfers[0].Network, "networks should be normalised")
+	assert.True(t, transfers[0].Currency.Equal(currency.USDT))
+	assert.False(t, transfers[0].Timestamp.IsZero(), "events without a timestamp should be stamped")
+
+	r.err = ErrSubSystemNotStarted
+	assert.Equal(t, http.StatusServiceUnavailable, post("custodian", body, sig))
+}
+
+func TestParseGCTWebhook(t *testing.T) {
+	t.Parallel()
+	for name, body := range map[string]string{
+		"no id":          `{"events":[{"type":"fill","amount":1}]}`,
+		"no amount":      `{"events":[{"type":"fill","id":"1"}]}`,
+		"bad asset":      `{"events":[{"type":"fill","id":"1","amount":1,"asset":"bad","pair":"BTC-USDT","side":"buy","price":1}]}`,
+		"bad side":       `{"events":[{"type":"fill","id":"1","amount":1,"asset":"spot","pair":"BTC-USDT","side":"up","price":1}]}`,
+		"no price":       `{"events":[{"type":"fill","id":"1","amount":1,"asset":"spot","pair":"BTC-USDT","side":"buy"}]}`,
+		"no currency":    `{"events":[{"type":"transfer","id":"1","amount":1,"direction":"deposit"}]}`,
+		"bad direction":  `{"events":[{"type":"transfer","id":"1","amount":1,"currency":"BTC","direction":"up"}]}`,
+		"unknown type":   `{"events":[{"type":"margin","id":"1","amount":1}]}`,
+		"malformed json": `{"events":`,
+	} {
+		_, err := parseGCTWebhook("test", []byte(body))
+		assert.Errorf(t, err, "parseGCTWebhook should error for %s", name)
+	}
+	e, err := parseGCTWebhook("test", []byte(`{"events":[]}`))
+	require.NoError(t, err)
+	assert.Empty(t, e.Fills)
+	assert.Empty(t, e.Transfers)
+}
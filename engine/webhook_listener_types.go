@@ -0,0 +1,113 @@
+package engine
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/exchanges/fill"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/transfer"
+)
+
+// WebhookListenerName is an exported subsystem name
+const WebhookListenerName = "webhook_listener"
+
+// WebhookSignatureHeader is the header holding the hex encoded HMAC-SHA256
+// signature of a callback body, optionally prefixed with "sha256="
+const WebhookSignatureHeader = "X-GCT-Signature"
+
+const (
+	// GCTWebhookFormat is the webhook listener's own format of normalised
+	// fill and transfer events
+	GCTWebhookFormat    = "gct"
+	webhookPath         = "/webhooks/"
+	webhookReadTimeout  = time.Second * 10
+	webhookStopTimeout  = time.Second * 5
+	webhookSignaturePre = "sha256="
+)
+
+var (
+	errInvalidWebhookConfig    = errors.New("invalid webhook listener config")
+	errNoWebhookSources        = errors.New("no webhook sources configured")
+	errInvalidWebhookSource    = errors.New("invalid webhook source")
+	errDuplicateWebhookSource  = errors.New("duplicate webhook source")
+	errUnknownWebhookFormat    = errors.New("unknown webhook format")
+	errWebhookFormatExists     = errors.New("webhook format already registered")
+	errUnknownWebhookSource    = errors.New("unknown webhook source")
+	errInvalidWebhookSignature = errors.New("invalid webhook signature")
+	errInvalidWebhookEvent     = errors.New("invalid webhook event")
+	errInvalidWebhookPrice     = errors.New("fill price must be above zero")
+	errNilWebhookRouter        = errors.New("cannot start with nil webhook data router")
+
+	webhookParsersMtx sync.RWMutex
+	webhookParsers    = map[string]WebhookParser{GCTWebhookFormat: parseGCTWebhook}
+)
+
+// WebhookParser normalises a source's callback body into fill and transfer
+// events attributed to the exchange
+type WebhookParser func(exchName string, body []byte) (*WebhookEvents, error)
+
+// WebhookEvents holds the account events normalised from a callback
+type WebhookEvents struct {
+	Fills     []fill.Data
+	Transfers []transfer.Event
+}
+
+// iWebhookDataRouter routes normalised account events to the same handlers as
+// websocket data
+type iWebhookDataRouter interface {
+	RouteExternalData(exchName string, data interface{}) error
+}
+
+// WebhookListener receives account events pushed by exchanges and providers
+// via signed HTTP callbacks, normalises them and routes them alongside
+// websocket data
+type WebhookListener struct {
+	started       int32
+	verbose       bool
+	listenAddress string
+	maxBodySize   int64
+	sources       map[string]*webhookSource
+	router        iWebhookDataRouter
+	server        *http.Server
+	wg            sync.WaitGroup
+}
+
+// webhookSource is a provider permitted to push account events
+type webhookSource struct {
+	name     string
+	exchange string
+	secret   []byte
+	parser   WebhookParser
+}
+
+// gctWebhookPayload is a batch of events in the gct webhook format
+type gctWebhookPayload struct {
+	Events []gctWebhookEvent `json:"events"`
+}
+
+// gctWebhookEvent is a fill or transfer in the gct webhook format. Fields
+// which do not apply to the event's type are ignored
+type gctWebhookEvent struct {
+	Type      string    `json:"type"`
+	ID        string    `json:"id"`
+	Timestamp time.Time `json:"timestamp"`
+	Amount    float64   `json:"amount"`
+	// Fill fields
+	Asset         string  `json:"asset"`
+	Pair          string  `json:"pair"`
+	Side          string  `json:"side"`
+	OrderID       string  `json:"orderID"`
+	ClientOrderID string  `json:"clientOrderID"`
+	TradeID       string  `json:"tradeID"`
+	Price         float64 `json:"price"`
+	// Transfer fields
+	Currency  string  `json:"currency"`
+	Direction string  `json:"direction"`
+	Network   string  `json:"network"`
+	Status    string  `json:"status"`
+	Fee       float64 `json:"fee"`
+	Address   string  `json:"address"`
+	TxID      string  `json:"txID"`
+}
@@ -4,16 +4,21 @@ import (
 	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/fill"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/fundingrate"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/rfq"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/transfer"
 	"github.com/thrasher-corp/gocryptotrader/log"
 )
 
@@ -177,20 +182,39 @@ func (m *WebsocketRoutineManager) websocketDataReceiver(ws *stream.Websocket) er
 				if data == nil {
 					log.Errorf(log.WebsocketMgr, "exchange %s nil data sent to websocket", ws.GetName())
 				}
-				m.mu.RLock()
-				for x := range m.dataHandlers {
-					err := m.dataHandlers[x](ws.GetName(), data)
-					if err != nil {
-						log.Errorln(log.WebsocketMgr, err)
-					}
-				}
-				m.mu.RUnlock()
+				m.routeData(ws.GetName(), data)
 			}
 		}
 	}()
 	return nil
 }
 
+// routeData passes data to each registered data handler
+func (m *WebsocketRoutineManager) routeData(exchName string, data interface{}) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for x := range m.dataHandlers {
+		err := m.dataHandlers[x](exchName, data)
+		if err != nil {
+			log.Errorln(log.WebsocketMgr, err)
+		}
+	}
+}
+
+// RouteExternalData passes account data received from outside of an
+// exchange's websocket, such as via webhook, to the same data handlers as
+// websocket data
+func (m *WebsocketRoutineManager) RouteExternalData(exchName string, data interface{}) error {
+	if m == nil {
+		return fmt.Errorf("websocket routine manager %w", ErrNilSubsystem)
+	}
+	if atomic.LoadInt32(&m.state) == stoppedState {
+		return errRoutineManagerNotStarted
+	}
+	m.routeData(exchName, data)
+	return nil
+}
+
 // websocketDataHandler is the default central point for exchange websocket
 // implementations to send processed data which will then pass that to an
 // appropriate handler.
@@ -208,6 +232,18 @@ func (m *WebsocketRoutineManager) websocketDataHandler(exchName string, data int
 				d.AssetType,
 				d)
 		}
+	case *fundingrate.Update:
+		if err := fundingrate.Process(d); err != nil {
+			return err
+		}
+		m.printFundingRateSummary(d)
+	case []fundingrate.Update:
+		for x := range d {
+			if err := fundingrate.Process(&d[x]); err != nil {
+				return err
+			}
+			m.printFundingRateSummary(&d[x])
+		}
 	case *ticker.Price:
 		if m.syncer.IsRunning() {
 			err := m.syncer.WebsocketUpdate(exchName,
@@ -244,7 +280,8 @@ func (m *WebsocketRoutineManager) websocketDataHandler(exchName string, data int
 		}
 	case order.Detail,
 		ticker.Price,
-		orderbook.Depth:
+		orderbook.Depth,
+		fundingrate.Update:
 		return errUseAPointer
 	case stream.KlineData:
 		if m.verbose {
@@ -254,6 +291,7 @@ func (m *WebsocketRoutineManager) websocketDataHandler(exchName string, data int
 				d.AssetType,
 				d)
 		}
+		return m.processStreamedKline(exchName, &d)
 	case []stream.KlineData:
 		for x := range d {
 			if m.verbose {
@@ -263,6 +301,9 @@ func (m *WebsocketRoutineManager) websocketDataHandler(exchName string, data int
 					d[x].AssetType,
 					d)
 			}
+			if err := m.processStreamedKline(exchName, &d[x]); err != nil {
+				return err
+			}
 		}
 	case *orderbook.Depth:
 		base, err := d.Retrieve()
@@ -337,6 +378,11 @@ func (m *WebsocketRoutineManager) websocketDataHandler(exchName string, data int
 		return fmt.Errorf("%w %s", d.Err, d.Error())
 	case stream.UnhandledMessageWarning:
 		log.Warnln(log.WebsocketMgr, d.Message)
+	case stream.StaleChannelWarning:
+		log.Warnf(log.WebsocketMgr, "%s websocket channel %s has not received a message since %s",
+			d.Exchange,
+			d.Subscription.String(),
+			d.LastMessage)
 	case account.Change:
 		if m.verbose {
 			m.printAccountHoldingsChangeSummary(d)
@@ -347,6 +393,16 @@ func (m *WebsocketRoutineManager) websocketDataHandler(exchName string, data int
 				m.printAccountHoldingsChangeSummary(d[x])
 			}
 		}
+	case account.MarginStatus:
+		if m.verbose {
+			m.printAccountMarginSummary(&d)
+		}
+	case []account.MarginStatus:
+		if m.verbose {
+			for x := range d {
+				m.printAccountMarginSummary(&d[x])
+			}
+		}
 	case []trade.Data:
 		if m.verbose {
 			log.Infof(log.Trade, "%+v", d)
@@ -355,6 +411,29 @@ func (m *WebsocketRoutineManager) websocketDataHandler(exchName string, data int
 		if m.verbose {
 			log.Infof(log.Fill, "%+v", d)
 		}
+	case []transfer.Event:
+		if m.verbose {
+			for x := range d {
+				log.Infof(log.WebsocketMgr, "%s %s %s %v %s %s",
+					d[x].Exchange,
+					d[x].Direction,
+					d[x].ID,
+					d[x].Amount,
+					d[x].Currency,
+					d[x].Status)
+			}
+		}
+	case []rfq.Request:
+		if m.verbose {
+			for x := range d {
+				log.Infof(log.WebsocketMgr, "%s RFQ %s %s with %d legs valid until %s",
+					d[x].Exchange,
+					d[x].ID,
+					d[x].State,
+					len(d[x].Legs),
+					d[x].ValidUntil)
+			}
+		}
 	default:
 		if m.verbose {
 			log.Warnf(log.WebsocketMgr,
@@ -366,6 +445,53 @@ func (m *WebsocketRoutineManager) websocketDataHandler(exchName string, data int
 	return nil
 }
 
+// printFundingRateSummary outputs a streamed funding rate when verbose
+func (m *WebsocketRoutineManager) printFundingRateSummary(u *fundingrate.Update) {
+	if !m.verbose {
+		return
+	}
+	log.Infof(log.WebsocketMgr, "%s websocket %s %s funding rate %s next at %s predicted %s",
+		u.Exchange,
+		m.FormatCurrency(u.Pair),
+		u.Asset,
+		u.Rate,
+		u.TimeOfNextRate,
+		u.PredictedRate)
+}
+
+// processStreamedKline passes a streamed kline to candlestick pattern
+// detection, logging any patterns completed when verbose
+func (m *WebsocketRoutineManager) processStreamedKline(exchName string, d *stream.KlineData) error {
+	if d.StartTime.IsZero() {
+		return nil
+	}
+	interval := kline.Interval(max(d.CloseTime.Sub(d.StartTime).Round(time.Second), 0))
+	patterns, err := kline.ProcessStreamedCandle(exchName, d.Pair, d.AssetType, interval, &kline.Candle{
+		Time:   d.StartTime,
+		Open:   d.OpenPrice,
+		High:   d.HighPrice,
+		Low:    d.LowPrice,
+		Close:  d.ClosePrice,
+		Volume: d.Volume,
+	})
+	if err != nil {
+		return err
+	}
+	if !m.verbose {
+		return nil
+	}
+	for i := range patterns {
+		log.Infof(log.WebsocketMgr, "%s websocket %s %s %s %s pattern detected at %s",
+			exchName,
+			m.FormatCurrency(patterns[i].Pair),
+			patterns[i].Asset,
+			patterns[i].Bias,
+			patterns[i].Type,
+			patterns[i].End)
+	}
+	return nil
+}
+
 // FormatCurrency is a method that formats and returns a currency pair
 // based on the user currency display preferences
 func (m *WebsocketRoutineManager) FormatCurrency(p currency.Pair) currency.Pair {
@@ -419,6 +545,21 @@ func (m *WebsocketRoutineManager) printAccountHoldingsChangeSummary(o account.Ch
 		o.Account)
 }
 
+func (m *WebsocketRoutineManager) printAccountMarginSummary(o *account.MarginStatus) {
+	if m == nil || atomic.LoadInt32(&m.state) == stoppedState {
+		return
+	}
+	log.Debugf(log.WebsocketMgr,
+		"Account Margin Updated: %s %s account: %s equity %f %s initial margin ratio %.4f maintenance margin ratio %.4f",
+		o.Exchange,
+		o.Asset,
+		o.Account,
+		o.Equity,
+		o.Currency,
+		o.InitialMarginRatio(),
+		o.MaintenanceMarginRatio())
+}
+
 // registerWebsocketDataHandler registers an externally (GCT Library) defined
 // dedicated filter specific data types for internal & external strategy use.
 // InterceptorOnly as true will purge all other registered handlers
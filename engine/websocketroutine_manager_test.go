@@ -7,11 +7,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/fundingrate"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/rfq"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 )
@@ -175,6 +179,27 @@ func TestWebsocketRoutineManagerHandleData(t *testing.T) {
 	if err != nil {
 		t.Error(err)
 	}
+	klineStart := time.Now().Truncate(time.Minute)
+	err = m.websocketDataHandler(exchName, []stream.KlineData{
+		{Pair: currency.NewPair(currency.BTC, currency.USDT), AssetType: asset.Spot, StartTime: klineStart, CloseTime: klineStart.Add(time.Minute - time.Millisecond)},
+		{AssetType: asset.Spot, StartTime: klineStart},
+	})
+	if !errors.Is(err, currency.ErrCurrencyPairEmpty) {
+		t.Errorf("error '%v', expected '%v'", err, currency.ErrCurrencyPairEmpty)
+	}
+	err = m.websocketDataHandler(exchName, &fundingrate.Update{
+		Exchange:       exchName,
+		Pair:           currency.NewPair(currency.BTC, currency.USDT),
+		Asset:          asset.PerpetualSwap,
+		TimeOfNextRate: time.Now(),
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	err = m.websocketDataHandler(exchName, []fundingrate.Update{{Exchange: exchName}})
+	if !errors.Is(err, currency.ErrCurrencyPairEmpty) {
+		t.Errorf("error '%v', expected '%v'", err, currency.ErrCurrencyPairEmpty)
+	}
 	origOrder := &order.Detail{
 		Exchange: exchName,
 		OrderID:  orderID,
@@ -251,6 +276,10 @@ func TestWebsocketRoutineManagerHandleData(t *testing.T) {
 	if err != nil {
 		t.Error(err)
 	}
+	err = m.websocketDataHandler(exchName, []rfq.Request{{Exchange: exchName, ID: "1", Legs: []rfq.Leg{{Side: order.Buy, Amount: 1}}}})
+	if err != nil {
+		t.Error(err)
+	}
 	err = m.websocketDataHandler(exchName, "this is a test string")
 	if err != nil {
 		t.Error(err)
@@ -357,3 +386,21 @@ func TestSetWebsocketDataHandler(t *testing.T) {
 		t.Fatal("unexpected data handler count")
 	}
 }
+
+func TestRouteExternalData(t *testing.T) {
+	t.Parallel()
+	var m *WebsocketRoutineManager
+	assert.ErrorIs(t, m.RouteExternalData("test", "data"), ErrNilSubsystem)
+
+	m = new(WebsocketRoutineManager)
+	assert.ErrorIs(t, m.RouteExternalData("test", "data"), errRoutineManagerNotStarted)
+
+	var received []interface{}
+	require.NoError(t, m.registerWebsocketDataHandler(func(exchName string, data interface{}) error {
+		received = append(received, exchName, data)
+		return nil
+	}, true))
+	m.state = readyState
+	require.NoError(t, m.RouteExternalData("test", "data"), "RouteExternalData must not error")
+	assert.Equal(t, []interface{}{"test", "data"}, received, "data should be passed to registered handlers")
+}
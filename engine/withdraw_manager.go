@@ -1,15 +1,28 @@
 package engine
 
 import (
+	"bytes"
 	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"net"
+	"strings"
 	"time"
 
+	"github.com/gofrs/uuid"
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
 	dbwithdraw "github.com/thrasher-corp/gocryptotrader/database/repository/withdraw"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/currencystate"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/transfer"
 	"github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
 )
@@ -23,6 +36,8 @@ func SetupWithdrawManager(em iExchangeManager, pm iPortfolioManager, isDryRun bo
 		exchangeManager:  em,
 		portfolioManager: pm,
 		isDryRun:         isDryRun,
+		submitAttempts:   withdrawalSubmitAttempts,
+		retryDelay:       withdrawalRetryDelay,
 	}, nil
 }
 
@@ -35,12 +50,40 @@ func (m *WithdrawManager) SubmitWithdrawal(ctx context.Context, req *withdraw.Re
 	if req == nil {
 		return nil, withdraw.ErrRequestCannotBeNil
 	}
+	if m.requiresOfflineApproval(req) {
+		if _, err := m.exchangeManager.GetExchangeByName(req.Exchange); err != nil {
+			return nil, err
+		}
+		return m.holdForOfflineApproval(req)
+	}
+	return m.submit(ctx, req)
+}
 
+// submit submits a withdraw request to exchange without checking whether it
+// requires offline approval
+func (m *WithdrawManager) submit(ctx context.Context, req *withdraw.Request) (*withdraw.Response, error) {
 	exch, err := m.exchangeManager.GetExchangeByName(req.Exchange)
 	if err != nil {
 		return nil, err
 	}
 
+	if req.Type == withdraw.Crypto {
+		var c *transfer.Chain
+		c, err = getTransferNetwork(ctx, exch, req.Currency, req.Crypto.Chain)
+		if err != nil {
+			return nil, err
+		}
+		if c != nil {
+			if err = c.CanWithdraw(req.Amount); err != nil {
+				return nil, err
+			}
+			req.Crypto.Chain = c.Name
+			if req.Crypto.FeeAmount == 0 {
+				req.Crypto.FeeAmount = c.WithdrawFee
+			}
+		}
+	}
+
 	resp := &withdraw.Response{
 		Exchange: withdraw.ExchangeResponse{
 			Name: req.Exchange,
@@ -54,6 +97,18 @@ func (m *WithdrawManager) SubmitWithdrawal(ctx context.Context, req *withdraw.Re
 		return nil, errF
 	}
 
+	if _, errL := m.updateWithdrawalLocks(ctx, exch, req.Currency); errL != nil &&
+		!errors.Is(errL, common.ErrFunctionNotSupported) &&
+		!errors.Is(errL, common.ErrNotYetImplemented) {
+		log.Warnf(log.Global, "%s unable to update withdrawal locks, using last known locks: %v", exch.GetName(), errL)
+	}
+	if l := m.activeWithdrawalLock(req); l != nil {
+		return nil, &withdraw.LockError{Lock: *l}
+	}
+	if err = m.checkCurrencyStates(req); err != nil {
+		return nil, err
+	}
+
 	if m.isDryRun {
 		log.Warnln(log.Global, "Dry run enabled, no withdrawal request will be submitted or have an event created")
 		resp.ID = withdraw.DryRunID
@@ -69,22 +124,23 @@ func (m *WithdrawManager) SubmitWithdrawal(ctx context.Context, req *withdraw.Re
 				return nil, withdraw.ErrStrExchangeNotSupportedByAddress
 			}
 		}
-		if req.Type == withdraw.Fiat {
-			ret, err = exch.WithdrawFiatFunds(ctx, req)
-			if err != nil {
-				resp.Exchange.Status = err.Error()
-			} else {
-				resp.Exchange.Status = ret.Status
-				resp.Exchange.ID = ret.ID
-			}
-		} else if req.Type == withdraw.Crypto {
-			ret, err = exch.WithdrawCryptocurrencyFunds(ctx, req)
-			if err != nil {
-				resp.Exchange.Status = err.Error()
-			} else {
-				resp.Exchange.Status = ret.Status
-				resp.Exchange.ID = ret.ID
+		if req.ClientOrderID == "" {
+			var id uuid.UUID
+			if id, err = uuid.NewV4(); err != nil {
+				return nil, err
 			}
+			req.ClientOrderID = strings.ReplaceAll(id.String(), "-", "")
+			resp.RequestDetails.ClientOrderID = req.ClientOrderID
+		}
+		ret, err = m.submitWithRetry(ctx, exch, req)
+		if err != nil {
+			resp.Exchange.Status = err.Error()
+		} else {
+			resp.Exchange.Status = ret.Status
+			resp.Exchange.ID = ret.ID
+		}
+		if errors.Is(err, withdraw.ErrWithdrawalLocked) {
+			m.addWithdrawalLock(req, err)
 		}
 	}
 	dbwithdraw.Event(resp)
@@ -94,6 +150,420 @@ func (m *WithdrawManager) SubmitWithdrawal(ctx context.Context, req *withdraw.Re
 	return resp, err
 }
 
+// submitWithRetry sends the withdrawal to the exchange. When a submission fails
+// without the exchange confirming whether it was processed, such as a timeout
+// or dropped connection, the exchange's withdrawal history is checked for the
+// withdrawal before it is resubmitted so it cannot be duplicated. If the
+// history cannot be checked the withdrawal is not resubmitted
+func (m *WithdrawManager) submitWithRetry(ctx context.Context, exch exchange.IBotExchange, req *withdraw.Request) (*withdraw.ExchangeResponse, error) {
+	submitted := time.Now()
+	for attempt := 1; ; attempt++ {
+		var ret *withdraw.ExchangeResponse
+		var err error
+		switch req.Type {
+		case withdraw.Fiat:
+			ret, err = exch.WithdrawFiatFunds(ctx, req)
+		case withdraw.Crypto:
+			ret, err = exch.WithdrawCryptocurrencyFunds(ctx, req)
+		default:
+			return nil, withdraw.ErrInvalidRequest
+		}
+		if err == nil || !isUncertainWithdrawalError(err) {
+			return ret, err
+		}
+		log.Warnf(log.Global, "%s withdrawal %s failed without confirmation, checking withdrawal history: %v", exch.GetName(), req.ClientOrderID, err)
+
+		t := time.NewTimer(m.retryDelay)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return nil, fmt.Errorf("%w: %w", errWithdrawalOutcomeUnknown, err)
+		case <-t.C:
+		}
+		found, errH := findSubmittedWithdrawal(ctx, exch, req, submitted)
+		if errH != nil {
+			return nil, fmt.Errorf("%w: %w, unable to check withdrawal history: %w", errWithdrawalOutcomeUnknown, err, errH)
+		}
+		if found != nil {
+			log.Infof(log.Global, "%s withdrawal %s found in withdrawal history as %s, not resubmitting", exch.GetName(), req.ClientOrderID, found.TransferID)
+			return &withdraw.ExchangeResponse{ID: found.TransferID, Status: found.Status}, nil
+		}
+		if attempt >= m.submitAttempts {
+			return nil, err
+		}
+	}
+}
+
+// findSubmittedWithdrawal returns the exchange's withdrawal history entry for
+// the request. Entries are matched by the request's client ID when the exchange
+// reports client IDs, otherwise by amount, destination and submission time
+func findSubmittedWithdrawal(ctx context.Context, exch exchange.IBotExchange, req *withdraw.Request, submitted time.Time) (*exchange.WithdrawalHistory, error) {
+	history, err := exch.GetWithdrawalsHistory(ctx, req.Currency, asset.Spot)
+	if err != nil {
+		return nil, err
+	}
+	for i := range history {
+		if history[i].ClientID != "" {
+			if history[i].ClientID == req.ClientOrderID {
+				return &history[i], nil
+			}
+			continue
+		}
+		if history[i].Timestamp.Before(submitted.Add(-withdrawalHistorySkew)) ||
+			!strings.EqualFold(history[i].Currency, req.Currency.String()) ||
+			(history[i].Amount != req.Amount && history[i].Amount+history[i].Fee != req.Amount) {
+			continue
+		}
+		if req.Type == withdraw.Crypto && history[i].CryptoToAddress != "" && history[i].CryptoToAddress != req.Crypto.Address {
+			continue
+		}
+		return &history[i], nil
+	}
+	return nil, nil
+}
+
+// isUncertainWithdrawalError returns whether a submission error leaves it
+// unknown if the exchange processed the withdrawal
+func isUncertainWithdrawalError(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
+
+// UpdateWithdrawalLocks fetches the withdrawal locks and delays an exchange is
+// currently enforcing for a currency
+func (m *WithdrawManager) UpdateWithdrawalLocks(ctx context.Context, exchName string, c currency.Code) ([]withdraw.Lock, error) {
+	if m == nil {
+		return nil, ErrNilSubsystem
+	}
+	exch, err := m.exchangeManager.GetExchangeByName(exchName)
+	if err != nil {
+		return nil, err
+	}
+	return m.updateWithdrawalLocks(ctx, exch, c)
+}
+
+// updateWithdrawalLocks replaces the previously fetched withdrawal locks of an
+// exchange's currency with its current locks
+func (m *WithdrawManager) updateWithdrawalLocks(ctx context.Context, exch exchange.IBotExchange, c currency.Code) ([]withdraw.Lock, error) {
+	locks, err := exch.GetWithdrawalLocks(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+	name := exch.GetName()
+	m.locksMtx.Lock()
+	defer m.locksMtx.Unlock()
+	tracked := make([]trackedWithdrawalLock, 0, len(m.locks)+len(locks))
+	for i := range m.locks {
+		if m.locks[i].fetched &&
+			strings.EqualFold(m.locks[i].Exchange, name) &&
+			(m.locks[i].Currency.IsEmpty() || m.locks[i].Currency.Equal(c)) {
+			continue
+		}
+		tracked = append(tracked, m.locks[i])
+	}
+	for i := range locks {
+		if locks[i].Exchange == "" {
+			locks[i].Exchange = name
+		}
+		tracked = append(tracked, trackedWithdrawalLock{Lock: locks[i], fetched: true})
+	}
+	m.locks = tracked
+	return locks, nil
+}
+
+// addWithdrawalLock records the lock an exchange rejected a withdraw request
+// with, so further requests are rejected until it is lifted rather than
+// failing repeatedly at the exchange
+func (m *WithdrawManager) addWithdrawalLock(req *withdraw.Request, err error) {
+	var l withdraw.Lock
+	var lockErr *withdraw.LockError
+	if errors.As(err, &lockErr) {
+		l = lockErr.Lock
+	} else {
+		l = withdraw.Lock{Currency: req.Currency, Reason: err.Error()}
+	}
+	if l.Exchange == "" {
+		l.Exchange = req.Exchange
+	}
+	if l.Until.IsZero() {
+		l.Until = time.Now().Add(withdrawalLockRetryDelay)
+	}
+	log.Warnf(log.Global, "%s", &withdraw.LockError{Lock: l})
+	m.locksMtx.Lock()
+	m.locks = append(m.locks, trackedWithdrawalLock{Lock: l})
+	m.locksMtx.Unlock()
+}
+
+// activeWithdrawalLock returns an active lock which prevents a withdraw
+// request, removing locks which have been lifted
+func (m *WithdrawManager) activeWithdrawalLock(req *withdraw.Request) *withdraw.Lock {
+	m.locksMtx.Lock()
+	defer m.locksMtx.Unlock()
+	m.pruneWithdrawalLocks(time.Now())
+	for i := range m.locks {
+		if m.locks[i].Applies(req) {
+			l := m.locks[i].Lock
+			return &l
+		}
+	}
+	return nil
+}
+
+// pruneWithdrawalLocks removes locks which have been lifted. Must be called
+// with the locks mutex held
+func (m *WithdrawManager) pruneWithdrawalLocks(t time.Time) {
+	active := m.locks[:0]
+	for i := range m.locks {
+		if m.locks[i].IsActive(t) {
+			active = append(active, m.locks[i])
+		}
+	}
+	m.locks = active
+}
+
+// WithdrawalLocks returns the active withdrawal locks known for an exchange,
+// or for all exchanges when exchName is empty
+func (m *WithdrawManager) WithdrawalLocks(exchName string) ([]withdraw.Lock, error) {
+	if m == nil {
+		return nil, ErrNilSubsystem
+	}
+	m.locksMtx.Lock()
+	defer m.locksMtx.Unlock()
+	m.pruneWithdrawalLocks(time.Now())
+	var locks []withdraw.Lock
+	for i := range m.locks {
+		if exchName == "" || strings.EqualFold(m.locks[i].Exchange, exchName) {
+			locks = append(locks, m.locks[i].Lock)
+		}
+	}
+	return locks, nil
+}
+
+// onCurrencyStateChange tracks currency state changes from the currency state
+// manager, locking withdrawals while an exchange has suspended them and
+// recording suspended deposits and congested networks for later requests
+func (m *WithdrawManager) onCurrencyStateChange(ch CurrencyStateChange) {
+	if ch.Asset != asset.Spot {
+		return
+	}
+	k := impairedCurrency{exchange: strings.ToLower(ch.Exchange), item: ch.Currency.Item, operation: ch.Operation}
+	m.locksMtx.Lock()
+	defer m.locksMtx.Unlock()
+	if ch.Impaired {
+		if m.impaired == nil {
+			m.impaired = make(map[impairedCurrency]struct{})
+		}
+		m.impaired[k] = struct{}{}
+	} else {
+		delete(m.impaired, k)
+	}
+	if ch.Operation != CurrencyStateWithdraw {
+		return
+	}
+	tracked := m.locks[:0]
+	for i := range m.locks {
+		if m.locks[i].currencyState &&
+			strings.EqualFold(m.locks[i].Exchange, ch.Exchange) &&
+			m.locks[i].Currency.Equal(ch.Currency) {
+			continue
+		}
+		tracked = append(tracked, m.locks[i])
+	}
+	m.locks = tracked
+	if ch.Impaired {
+		m.locks = append(m.locks, trackedWithdrawalLock{
+			Lock: withdraw.Lock{
+				Exchange: ch.Exchange,
+				Currency: ch.Currency,
+				Reason:   "withdrawals suspended by exchange",
+			},
+			currencyState: true,
+		})
+	}
+}
+
+// checkCurrencyStates rejects crypto withdrawals to an address supported by an
+// exchange which has suspended deposits of the currency, and warns when the
+// currency's network is congested
+func (m *WithdrawManager) checkCurrencyStates(req *withdraw.Request) error {
+	m.locksMtx.Lock()
+	defer m.locksMtx.Unlock()
+	for k := range m.impaired {
+		if k.item != req.Currency.Item {
+			continue
+		}
+		switch k.operation {
+		case CurrencyStateDeposit:
+			if req.Type == withdraw.Crypto && m.portfolioManager != nil &&
+				m.portfolioManager.IsExchangeSupported(k.exchange, req.Crypto.Address) {
+				return fmt.Errorf("%w: %s %s", errDestinationDepositsSuspended, k.exchange, req.Currency)
+			}
+		case CurrencyStateCongestion:
+			if strings.EqualFold(k.exchange, req.Exchange) {
+				log.Warnf(log.Global, "%s %s network is congested, withdrawal may be delayed", req.Exchange, req.Currency)
+			}
+		}
+	}
+	return nil
+}
+
+// SetupOfflineWithdrawals holds withdrawals at or above the configured
+// thresholds until a payload exported for them is signed by one of the
+// configured approval keys and re-imported
+func (m *WithdrawManager) SetupOfflineWithdrawals(cfg *config.OfflineWithdrawals) error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if cfg == nil {
+		return errNilConfig
+	}
+	if len(cfg.PublicKeys) == 0 {
+		return errNoApprovalKeys
+	}
+	if cfg.Expiry <= 0 {
+		return errInvalidApprovalExpiry
+	}
+	o := &offlineWithdrawals{
+		keys:       make([]ed25519.PublicKey, len(cfg.PublicKeys)),
+		thresholds: make(map[string]float64, len(cfg.Thresholds)),
+		expiry:     cfg.Expiry,
+		pending:    make(map[uuid.UUID]*pendingWithdrawal),
+	}
+	for i := range cfg.PublicKeys {
+		key, err := hex.DecodeString(cfg.PublicKeys[i])
+		if err != nil {
+			return fmt.Errorf("%w %q: %w", errInvalidApprovalKey, cfg.PublicKeys[i], err)
+		}
+		if len(key) != ed25519.PublicKeySize {
+			return fmt.Errorf("%w %q: must be %d bytes", errInvalidApprovalKey, cfg.PublicKeys[i], ed25519.PublicKeySize)
+		}
+		o.keys[i] = key
+	}
+	for code, amount := range cfg.Thresholds {
+		o.thresholds[strings.ToUpper(code)] = amount
+	}
+	m.offline = o
+	return nil
+}
+
+// requiresOfflineApproval returns whether a withdraw request must be approved
+// offline before it is submitted
+func (m *WithdrawManager) requiresOfflineApproval(req *withdraw.Request) bool {
+	if m.offline == nil {
+		return false
+	}
+	threshold, ok := m.offline.thresholds[req.Currency.Upper().String()]
+	return !ok || req.Amount >= threshold
+}
+
+// holdForOfflineApproval stores a withdraw request and creates the payload
+// which must be signed for it to be submitted
+func (m *WithdrawManager) holdForOfflineApproval(req *withdraw.Request) (*withdraw.Response, error) {
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	held := *req
+	held.TradePassword, held.OneTimePassword, held.PIN = "", 0, 0
+	now := time.Now()
+	payload, err := json.Marshal(withdrawalSigningPayload{
+		ID:      id,
+		Created: now,
+		Expires: now.Add(m.offline.expiry),
+		Request: held,
+	})
+	if err != nil {
+		return nil, err
+	}
+	m.offline.m.Lock()
+	for k, v := range m.offline.pending {
+		if now.After(v.expires) {
+			delete(m.offline.pending, k)
+		}
+	}
+	m.offline.pending[id] = &pendingWithdrawal{request: &held, payload: payload, expires: now.Add(m.offline.expiry)}
+	m.offline.m.Unlock()
+	log.Warnf(log.Global, "Withdrawal %s of %v %s from %s is awaiting offline approval", id, req.Amount, req.Currency, req.Exchange)
+	return &withdraw.Response{
+		ID: id,
+		Exchange: withdraw.ExchangeResponse{
+			Name:   req.Exchange,
+			Status: offlineApprovalStatus,
+		},
+		RequestDetails: held,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}, nil
+}
+
+// WithdrawalSigningPayload returns the payload to be signed offline to
+// approve a pending withdrawal
+func (m *WithdrawManager) WithdrawalSigningPayload(id string) (*WithdrawalSigningPayload, error) {
+	if m == nil {
+		return nil, ErrNilSubsystem
+	}
+	if m.offline == nil {
+		return nil, errOfflineWithdrawalsNotActive
+	}
+	u, err := uuid.FromString(id)
+	if err != nil {
+		return nil, err
+	}
+	m.offline.m.Lock()
+	defer m.offline.m.Unlock()
+	p, ok := m.offline.pending[u]
+	if !ok || time.Now().After(p.expires) {
+		return nil, fmt.Errorf("%w %v", errPendingWithdrawalNotFound, id)
+	}
+	digest := sha256.Sum256(p.payload)
+	return &WithdrawalSigningPayload{
+		ID:      u,
+		Payload: p.payload,
+		SHA256:  hex.EncodeToString(digest[:]),
+		Expires: p.expires,
+	}, nil
+}
+
+// ApproveWithdrawal verifies the signature of an exported withdrawal payload
+// and returns its withdraw request, which can then be submitted. A pending
+// withdrawal can only be approved once
+func (m *WithdrawManager) ApproveWithdrawal(payload, signature []byte) (*withdraw.Request, error) {
+	if m == nil {
+		return nil, ErrNilSubsystem
+	}
+	if m.offline == nil {
+		return nil, errOfflineWithdrawalsNotActive
+	}
+	var p withdrawalSigningPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, err
+	}
+	m.offline.m.Lock()
+	defer m.offline.m.Unlock()
+	pending, ok := m.offline.pending[p.ID]
+	if !ok {
+		return nil, fmt.Errorf("%w %v", errPendingWithdrawalNotFound, p.ID)
+	}
+	if time.Now().After(pending.expires) {
+		delete(m.offline.pending, p.ID)
+		return nil, fmt.Errorf("%w %v", errWithdrawalApprovalExpired, p.ID)
+	}
+	if !bytes.Equal(pending.payload, payload) {
+		return nil, errWithdrawalPayloadMismatch
+	}
+	for i := range m.offline.keys {
+		if ed25519.Verify(m.offline.keys[i], payload, signature) {
+			delete(m.offline.pending, p.ID)
+			req := *pending.request
+			return &req, nil
+		}
+	}
+	return nil, errInvalidWithdrawalSignature
+}
+
 // WithdrawalEventByID returns a withdrawal request by ID
 func (m *WithdrawManager) WithdrawalEventByID(id string) (*withdraw.Response, error) {
 	if m == nil {
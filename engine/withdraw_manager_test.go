@@ -1,16 +1,29 @@
 package engine
 
 import (
+	"bytes"
 	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
 	"sync"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/okx"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/transfer"
 	"github.com/thrasher-corp/gocryptotrader/portfolio"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
@@ -222,3 +235,404 @@ func TestWithdrawalEventByExchangeID(t *testing.T) {
 			ErrExchangeNotFound)
 	}
 }
+
+func TestSetupOfflineWithdrawals(t *testing.T) {
+	t.Parallel()
+	var m *WithdrawManager
+	assert.ErrorIs(t, m.SetupOfflineWithdrawals(&config.OfflineWithdrawals{}), ErrNilSubsystem)
+
+	m, err := SetupWithdrawManager(&fakeExchangeManagerino{}, nil, true)
+	require.NoError(t, err)
+	assert.ErrorIs(t, m.SetupOfflineWithdrawals(nil), errNilConfig)
+	assert.ErrorIs(t, m.SetupOfflineWithdrawals(&config.OfflineWithdrawals{}), errNoApprovalKeys)
+	pub, _, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	assert.ErrorIs(t, m.SetupOfflineWithdrawals(&config.OfflineWithdrawals{PublicKeys: []string{hex.EncodeToString(pub)}}), errInvalidApprovalExpiry)
+	for _, k := range []string{"bad", hex.EncodeToString(pub[:16])} {
+		err = m.SetupOfflineWithdrawals(&config.OfflineWithdrawals{PublicKeys: []string{k}, Expiry: time.Hour})
+		assert.ErrorIs(t, err, errInvalidApprovalKey)
+	}
+	assert.Nil(t, m.offline, "offline withdrawals should not be set up on error")
+	require.NoError(t, m.SetupOfflineWithdrawals(&config.OfflineWithdrawals{
+		PublicKeys: []string{hex.EncodeToString(pub)},
+		Thresholds: map[string]float64{"btc": 1},
+		Expiry:     time.Hour,
+	}))
+	assert.Equal(t, 1.0, m.offline.thresholds["BTC"], "thresholds should be keyed by upper case currency code")
+}
+
+func TestOfflineWithdrawalApproval(t *testing.T) {
+	t.Parallel()
+	m, err := SetupWithdrawManager(&fakeExchangeManagerino{}, nil, true)
+	require.NoError(t, err)
+	pub, priv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	_, otherPriv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	_, err = m.WithdrawalSigningPayload("")
+	assert.ErrorIs(t, err, errOfflineWithdrawalsNotActive)
+	_, err = m.ApproveWithdrawal(nil, nil)
+	assert.ErrorIs(t, err, errOfflineWithdrawalsNotActive)
+
+	require.NoError(t, m.SetupOfflineWithdrawals(&config.OfflineWithdrawals{
+		PublicKeys: []string{hex.EncodeToString(pub)},
+		Thresholds: map[string]float64{"BTC": 1},
+		Expiry:     time.Hour,
+	}))
+	req := &withdraw.Request{
+		Exchange:      withdrawManagerTestExchangeName,
+		Currency:      currency.BTC,
+		Amount:        0.5,
+		Type:          withdraw.Crypto,
+		TradePassword: "hunter2",
+		Crypto:        withdraw.CryptoRequest{Address: "1337"},
+	}
+	resp, err := m.SubmitWithdrawal(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "dryrun", resp.Exchange.Status, "withdrawals below the threshold should be submitted")
+
+	req.Amount = 2
+	resp, err = m.SubmitWithdrawal(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, offlineApprovalStatus, resp.Exchange.Status, "withdrawals at or above the threshold should await approval")
+	assert.Empty(t, resp.RequestDetails.TradePassword, "held withdrawals should not retain credentials")
+
+	_, err = m.WithdrawalSigningPayload("bad")
+	assert.Error(t, err, "invalid ids should error")
+	_, err = m.WithdrawalSigningPayload(withdraw.DryRunID.String())
+	assert.ErrorIs(t, err, errPendingWithdrawalNotFound)
+	p, err := m.WithdrawalSigningPayload(resp.ID.String())
+	require.NoError(t, err)
+	assert.Equal(t, resp.ID, p.ID)
+	assert.NotContains(t, string(p.Payload), "hunter2", "payloads should not contain credentials")
+	digest := sha256.Sum256(p.Payload)
+	assert.Equal(t, hex.EncodeToString(digest[:]), p.SHA256)
+
+	_, err = m.ApproveWithdrawal([]byte("bad"), nil)
+	assert.Error(t, err, "invalid payloads should error")
+	tampered := bytes.Replace(p.Payload, []byte(`"amount":2`), []byte(`"amount":20`), 1)
+	require.NotEqual(t, p.Payload, tampered)
+	_, err = m.ApproveWithdrawal(tampered, ed25519.Sign(priv, tampered))
+	assert.ErrorIs(t, err, errWithdrawalPayloadMismatch)
+	_, err = m.ApproveWithdrawal(p.Payload, ed25519.Sign(otherPriv, p.Payload))
+	assert.ErrorIs(t, err, errInvalidWithdrawalSignature)
+
+	approved, err := m.ApproveWithdrawal(p.Payload, ed25519.Sign(priv, p.Payload))
+	require.NoError(t, err)
+	assert.Equal(t, 2.0, approved.Amount)
+	assert.Equal(t, "1337", approved.Crypto.Address)
+	_, err = m.ApproveWithdrawal(p.Payload, ed25519.Sign(priv, p.Payload))
+	assert.ErrorIs(t, err, errPendingWithdrawalNotFound, "withdrawals should only be approved once")
+
+	req.Currency, req.Amount = currency.ETH, 0.01
+	resp, err = m.SubmitWithdrawal(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, offlineApprovalStatus, resp.Exchange.Status, "currencies without a threshold should always await approval")
+	p, err = m.WithdrawalSigningPayload(resp.ID.String())
+	require.NoError(t, err)
+	m.offline.pending[resp.ID].expires = time.Now().Add(-time.Second)
+	_, err = m.ApproveWithdrawal(p.Payload, ed25519.Sign(priv, p.Payload))
+	assert.ErrorIs(t, err, errWithdrawalApprovalExpired)
+	assert.Empty(t, m.offline.pending, "expired withdrawals should be removed")
+}
+
+type fakeWithdrawalPortfolio struct{}
+
+func (fakeWithdrawalPortfolio) GetPortfolioSummary() portfolio.Summary {
+	return portfolio.Summary{}
+}
+
+func (fakeWithdrawalPortfolio) IsWhiteListed(string) bool {
+	return true
+}
+
+func (fakeWithdrawalPortfolio) IsExchangeSupported(string, string) bool {
+	return true
+}
+
+type fakeWithdrawalLockExchange struct {
+	fakerino
+	locks       []withdraw.Lock
+	locksErr    error
+	withdrawErr error
+	withdrawals int
+	networks    []transfer.Chain
+	submitted   withdraw.CryptoRequest
+	clientIDs   []string
+	history     []exchange.WithdrawalHistory
+	historyErr  error
+}
+
+func (f *fakeWithdrawalLockExchange) GetTransferNetworks(context.Context, currency.Code) ([]transfer.Chain, error) {
+	if f.networks == nil {
+		return nil, common.ErrFunctionNotSupported
+	}
+	return f.networks, nil
+}
+
+func (f *fakeWithdrawalLockExchange) GetWithdrawalLocks(context.Context, currency.Code) ([]withdraw.Lock, error) {
+	return f.locks, f.locksErr
+}
+
+func (f *fakeWithdrawalLockExchange) WithdrawCryptocurrencyFunds(_ context.Context, req *withdraw.Request) (*withdraw.ExchangeResponse, error) {
+	f.withdrawals++
+	f.submitted = req.Crypto
+	f.clientIDs = append(f.clientIDs, req.ClientOrderID)
+	if f.withdrawErr != nil {
+		return nil, f.withdrawErr
+	}
+	return &withdraw.ExchangeResponse{ID: "1", Status: "ok"}, nil
+}
+
+func (f *fakeWithdrawalLockExchange) GetWithdrawalsHistory(context.Context, currency.Code, asset.Item) ([]exchange.WithdrawalHistory, error) {
+	return f.history, f.historyErr
+}
+
+func TestWithdrawalLocks(t *testing.T) {
+	t.Parallel()
+	exch := &fakeWithdrawalLockExchange{locksErr: common.ErrFunctionNotSupported}
+	em := NewExchangeManager()
+	require.NoError(t, em.Add(exch))
+	m, err := SetupWithdrawManager(em, fakeWithdrawalPortfolio{}, false)
+	require.NoError(t, err)
+	req := &withdraw.Request{
+		Exchange: exch.GetName(),
+		Currency: currency.BTC,
+		Amount:   1,
+		Type:     withdraw.Crypto,
+		Crypto:   withdraw.CryptoRequest{Address: "1337"},
+	}
+
+	_, err = m.SubmitWithdrawal(context.Background(), req)
+	require.NoError(t, err, "exchanges which do not support withdrawal locks should submit")
+	assert.Equal(t, 1, exch.withdrawals)
+
+	exch.withdrawErr = fmt.Errorf("cooldown: %w", withdraw.ErrWithdrawalLocked)
+	_, err = m.SubmitWithdrawal(context.Background(), req)
+	assert.ErrorIs(t, err, withdraw.ErrWithdrawalLocked)
+	_, err = m.SubmitWithdrawal(context.Background(), req)
+	var lockErr *withdraw.LockError
+	require.ErrorAs(t, err, &lockErr, "locks learned from rejections should reject further withdrawals")
+	assert.Equal(t, 2, exch.withdrawals, "locked withdrawals should not be sent to the exchange")
+	assert.WithinDuration(t, time.Now().Add(withdrawalLockRetryDelay), lockErr.Lock.Until, time.Minute, "locks without an expiry should be retried after a delay")
+	req.Currency = currency.ETH
+	exch.withdrawErr = nil
+	_, err = m.SubmitWithdrawal(context.Background(), req)
+	require.NoError(t, err, "locks should only apply to their currency")
+
+	locks, err := m.WithdrawalLocks("")
+	require.NoError(t, err)
+	require.Len(t, locks, 1)
+	m.locks[0].Until = time.Now().Add(-time.Second)
+	locks, err = m.WithdrawalLocks(exch.GetName())
+	require.NoError(t, err)
+	assert.Empty(t, locks, "lifted locks should be removed")
+
+	exch.locksErr = nil
+	exch.locks = []withdraw.Lock{{Reason: "account locked"}}
+	_, err = m.SubmitWithdrawal(context.Background(), req)
+	require.ErrorAs(t, err, &lockErr, "fetched locks should reject withdrawals")
+	assert.Equal(t, exch.GetName(), lockErr.Lock.Exchange, "fetched locks should default to the exchange name")
+	assert.Equal(t, 3, exch.withdrawals)
+
+	exch.locks = nil
+	locks, err = m.UpdateWithdrawalLocks(context.Background(), exch.GetName(), currency.ETH)
+	require.NoError(t, err)
+	assert.Empty(t, locks)
+	locks, err = m.WithdrawalLocks(exch.GetName())
+	require.NoError(t, err)
+	assert.Empty(t, locks, "fetched locks should be replaced when updated")
+	_, err = m.UpdateWithdrawalLocks(context.Background(), "bad", currency.ETH)
+	assert.ErrorIs(t, err, ErrExchangeNotFound)
+
+	exch.withdrawErr = &withdraw.LockError{Lock: withdraw.Lock{Address: "1337", Until: time.Now().Add(time.Hour), Reason: "whitelist cooldown"}}
+	_, err = m.SubmitWithdrawal(context.Background(), req)
+	assert.ErrorIs(t, err, withdraw.ErrWithdrawalLocked)
+	locks, err = m.WithdrawalLocks(exch.GetName())
+	require.NoError(t, err)
+	require.Len(t, locks, 1)
+	assert.Equal(t, "whitelist cooldown", locks[0].Reason, "lock errors returned by exchanges should be recorded")
+	req.Crypto.Address = "other"
+	exch.withdrawErr = nil
+	_, err = m.SubmitWithdrawal(context.Background(), req)
+	assert.NoError(t, err, "address locks should only apply to their address")
+
+	var nilManager *WithdrawManager
+	_, err = nilManager.WithdrawalLocks("")
+	assert.ErrorIs(t, err, ErrNilSubsystem)
+	_, err = nilManager.UpdateWithdrawalLocks(context.Background(), "", currency.BTC)
+	assert.ErrorIs(t, err, ErrNilSubsystem)
+}
+
+func TestWithdrawManagerCurrencyStateChanges(t *testing.T) {
+	t.Parallel()
+	exch := &fakeWithdrawalLockExchange{locksErr: common.ErrFunctionNotSupported}
+	em := NewExchangeManager()
+	require.NoError(t, em.Add(exch))
+	m, err := SetupWithdrawManager(em, fakeWithdrawalPortfolio{}, false)
+	require.NoError(t, err)
+	req := &withdraw.Request{
+		Exchange: exch.GetName(),
+		Currency: currency.BTC,
+		Amount:   1,
+		Type:     withdraw.Crypto,
+		Crypto:   withdraw.CryptoRequest{Address: "1337"},
+	}
+
+	m.onCurrencyStateChange(CurrencyStateChange{Exchange: exch.GetName(), Asset: asset.Futures, Currency: currency.BTC, Operation: CurrencyStateWithdraw, Impaired: true})
+	assert.Empty(t, m.locks, "non spot currency states should be ignored")
+
+	m.onCurrencyStateChange(CurrencyStateChange{Exchange: exch.GetName(), Asset: asset.Spot, Currency: currency.BTC, Operation: CurrencyStateWithdraw, Impaired: true})
+	_, err = m.SubmitWithdrawal(context.Background(), req)
+	var lockErr *withdraw.LockError
+	require.ErrorAs(t, err, &lockErr, "suspended withdrawals should lock withdrawals")
+	assert.Zero(t, exch.withdrawals)
+	m.onCurrencyStateChange(CurrencyStateChange{Exchange: exch.GetName(), Asset: asset.Spot, Currency: currency.BTC, Operation: CurrencyStateWithdraw, Impaired: true})
+	locks, err := m.WithdrawalLocks(exch.GetName())
+	require.NoError(t, err)
+	assert.Len(t, locks, 1, "repeated suspensions should not duplicate locks")
+	m.onCurrencyStateChange(CurrencyStateChange{Exchange: exch.GetName(), Asset: asset.Spot, Currency: currency.BTC, Operation: CurrencyStateWithdraw})
+	_, err = m.SubmitWithdrawal(context.Background(), req)
+	require.NoError(t, err, "resumed withdrawals should be unlocked")
+
+	m.onCurrencyStateChange(CurrencyStateChange{Exchange: "Destination", Asset: asset.Spot, Currency: currency.BTC, Operation: CurrencyStateDeposit, Impaired: true})
+	_, err = m.SubmitWithdrawal(context.Background(), req)
+	assert.ErrorIs(t, err, errDestinationDepositsSuspended)
+	req.Currency = currency.ETH
+	_, err = m.SubmitWithdrawal(context.Background(), req)
+	assert.NoError(t, err, "suspended deposits should only apply to their currency")
+	req.Currency = currency.BTC
+	m.onCurrencyStateChange(CurrencyStateChange{Exchange: "Destination", Asset: asset.Spot, Currency: currency.BTC, Operation: CurrencyStateDeposit})
+	m.onCurrencyStateChange(CurrencyStateChange{Exchange: exch.GetName(), Asset: asset.Spot, Currency: currency.BTC, Operation: CurrencyStateCongestion, Impaired: true})
+	_, err = m.SubmitWithdrawal(context.Background(), req)
+	assert.NoError(t, err, "congested networks should not reject withdrawals")
+	assert.Equal(t, 3, exch.withdrawals)
+}
+
+func TestSubmitWithdrawalTransferNetwork(t *testing.T) {
+	t.Parallel()
+	exch := &fakeWithdrawalLockExchange{locksErr: common.ErrFunctionNotSupported}
+	em := NewExchangeManager()
+	require.NoError(t, em.Add(exch))
+	m, err := SetupWithdrawManager(em, fakeWithdrawalPortfolio{}, false)
+	require.NoError(t, err)
+	req := &withdraw.Request{
+		Exchange: exch.GetName(),
+		Currency: currency.USDT,
+		Amount:   5,
+		Type:     withdraw.Crypto,
+		Crypto:   withdraw.CryptoRequest{Address: "1337", Chain: "TRC20"},
+	}
+
+	_, err = m.SubmitWithdrawal(context.Background(), req)
+	require.NoError(t, err, "chains should be passed through when transfer networks are not supported")
+	assert.Equal(t, "TRC20", exch.submitted.Chain)
+
+	exch.networks = []transfer.Chain{
+		{Network: transfer.ERC20, Name: "USDT-ERC20", WithdrawFee: 3, MinWithdrawal: 10},
+		{Network: transfer.TRC20, Name: "USDT-TRC20", WithdrawEnabled: true, WithdrawFee: 1, MinWithdrawal: 2},
+	}
+	_, err = m.SubmitWithdrawal(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "USDT-TRC20", exch.submitted.Chain, "normalised networks should be submitted with the exchange's chain name")
+	assert.Equal(t, 1.0, exch.submitted.FeeAmount, "unset fees should default to the network's withdrawal fee")
+
+	req.Crypto.Chain, req.Crypto.FeeAmount = "tron", 1.5
+	_, err = m.SubmitWithdrawal(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, 1.5, exch.submitted.FeeAmount, "set fees should not be replaced")
+
+	req.Amount = 1
+	_, err = m.SubmitWithdrawal(context.Background(), req)
+	assert.ErrorIs(t, err, transfer.ErrBelowMinimumWithdraw)
+	req.Amount, req.Crypto.Chain = 20, "ERC20"
+	_, err = m.SubmitWithdrawal(context.Background(), req)
+	assert.ErrorIs(t, err, transfer.ErrWithdrawalsDisabled)
+	req.Crypto.Chain = "SOL"
+	_, err = m.SubmitWithdrawal(context.Background(), req)
+	assert.ErrorIs(t, err, transfer.ErrNetworkNotFound)
+	assert.Equal(t, 3, exch.withdrawals)
+}
+
+func TestSubmitWithdrawalRetry(t *testing.T) {
+	t.Parallel()
+	exch := &fakeWithdrawalLockExchange{
+		locksErr:    common.ErrFunctionNotSupported,
+		withdrawErr: &net.OpError{Op: "read", Net: "tcp", Err: os.ErrDeadlineExceeded},
+	}
+	em := NewExchangeManager()
+	require.NoError(t, em.Add(exch))
+	m, err := SetupWithdrawManager(em, fakeWithdrawalPortfolio{}, false)
+	require.NoError(t, err)
+	m.retryDelay = 0
+	req := &withdraw.Request{
+		Exchange: exch.GetName(),
+		Currency: currency.USDT,
+		Amount:   5,
+		Type:     withdraw.Crypto,
+		Crypto:   withdraw.CryptoRequest{Address: "1337"},
+	}
+
+	resp, err := m.SubmitWithdrawal(context.Background(), req)
+	assert.ErrorIs(t, err, os.ErrDeadlineExceeded)
+	assert.Equal(t, withdrawalSubmitAttempts, exch.withdrawals, "withdrawals not found in the history should be resubmitted")
+	require.NotEmpty(t, req.ClientOrderID, "a client ID should be generated")
+	assert.Len(t, req.ClientOrderID, 32, "generated client IDs should fit exchange limits")
+	assert.Equal(t, req.ClientOrderID, resp.RequestDetails.ClientOrderID, "the client ID should be recorded")
+	for i := range exch.clientIDs {
+		assert.Equal(t, req.ClientOrderID, exch.clientIDs[i], "resubmissions should use the same client ID")
+	}
+
+	exch.withdrawals = 0
+	exch.history = []exchange.WithdrawalHistory{
+		{TransferID: "other", ClientID: "someoneelse", Currency: "USDT", Amount: 5, Timestamp: time.Now()},
+		{TransferID: "1", ClientID: req.ClientOrderID, Status: "processing"},
+	}
+	resp, err = m.SubmitWithdrawal(context.Background(), req)
+	require.NoError(t, err, "withdrawals found in the history should succeed")
+	assert.Equal(t, 1, exch.withdrawals, "withdrawals found in the history should not be resubmitted")
+	assert.Equal(t, "1", resp.Exchange.ID, "should match the history entry by client ID")
+	assert.Equal(t, "processing", resp.Exchange.Status)
+
+	exch.withdrawals = 0
+	exch.history = []exchange.WithdrawalHistory{
+		{TransferID: "stale", Currency: "USDT", Amount: 5, CryptoToAddress: "1337", Timestamp: time.Now().Add(-time.Hour)},
+		{TransferID: "elsewhere", Currency: "USDT", Amount: 5, CryptoToAddress: "7331", Timestamp: time.Now()},
+		{TransferID: "2", Currency: "usdt", Amount: 4, Fee: 1, CryptoToAddress: "1337", Timestamp: time.Now()},
+	}
+	resp, err = m.SubmitWithdrawal(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, 1, exch.withdrawals)
+	assert.Equal(t, "2", resp.Exchange.ID, "should match the history entry by amount, address and time without client IDs")
+
+	exch.withdrawals = 0
+	exch.historyErr = common.ErrFunctionNotSupported
+	_, err = m.SubmitWithdrawal(context.Background(), req)
+	assert.ErrorIs(t, err, errWithdrawalOutcomeUnknown)
+	assert.ErrorIs(t, err, common.ErrFunctionNotSupported)
+	assert.Equal(t, 1, exch.withdrawals, "withdrawals should not be resubmitted when the history cannot be checked")
+
+	exch.withdrawals = 0
+	exch.withdrawErr = errors.New("insufficient balance")
+	_, err = m.SubmitWithdrawal(context.Background(), req)
+	assert.ErrorIs(t, err, exch.withdrawErr)
+	assert.Equal(t, 1, exch.withdrawals, "rejected withdrawals should not be resubmitted")
+
+	exch.withdrawals = 0
+	exch.withdrawErr = context.DeadlineExceeded
+	m.retryDelay = time.Hour
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = m.SubmitWithdrawal(ctx, req)
+	assert.ErrorIs(t, err, errWithdrawalOutcomeUnknown, "cancelled contexts should not wait to check the history")
+	assert.Equal(t, 1, exch.withdrawals)
+}
+
+func TestIsUncertainWithdrawalError(t *testing.T) {
+	t.Parallel()
+	assert.True(t, isUncertainWithdrawalError(&net.OpError{Op: "dial", Err: os.ErrDeadlineExceeded}), "network errors should be uncertain")
+	assert.True(t, isUncertainWithdrawalError(fmt.Errorf("request: %w", context.DeadlineExceeded)), "timeouts should be uncertain")
+	assert.True(t, isUncertainWithdrawalError(io.ErrUnexpectedEOF), "dropped connections should be uncertain")
+	assert.False(t, isUncertainWithdrawalError(withdraw.ErrWithdrawalLocked), "rejections should not be uncertain")
+}
@@ -1,12 +1,48 @@
 package engine
 
 import (
+	"crypto/ed25519"
 	"errors"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
 )
 
+// offlineApprovalStatus is the status of withdrawals held for offline approval
+const offlineApprovalStatus = "awaiting offline approval"
+
+// Withdrawals which fail without the exchange confirming whether they were
+// processed are checked against the exchange's withdrawal history before being
+// resubmitted, up to withdrawalSubmitAttempts times
+const (
+	withdrawalSubmitAttempts = 3
+	withdrawalRetryDelay     = time.Second * 5
+	// withdrawalHistorySkew allows for clock differences when matching
+	// withdrawal history entries without a client ID by time
+	withdrawalHistorySkew = time.Minute
+)
+
+// withdrawalLockRetryDelay is how long withdrawals are rejected for after an
+// exchange reports a lock without stating when it is lifted
+const withdrawalLockRetryDelay = time.Minute * 15
+
 var (
 	// ErrWithdrawRequestNotFound message to display when no record is found
 	ErrWithdrawRequestNotFound = errors.New("request not found")
+
+	errNoApprovalKeys               = errors.New("no offline approval public keys configured")
+	errInvalidApprovalKey           = errors.New("invalid offline approval public key")
+	errInvalidApprovalExpiry        = errors.New("offline approval expiry must be greater than zero")
+	errPendingWithdrawalNotFound    = errors.New("pending withdrawal not found")
+	errWithdrawalPayloadMismatch    = errors.New("payload does not match the exported withdrawal payload")
+	errWithdrawalApprovalExpired    = errors.New("withdrawal approval has expired")
+	errInvalidWithdrawalSignature   = errors.New("withdrawal signature is not valid for any approval key")
+	errOfflineWithdrawalsNotActive  = errors.New("offline withdrawals are not enabled")
+	errDestinationDepositsSuspended = errors.New("destination exchange has suspended deposits")
+	errWithdrawalOutcomeUnknown     = errors.New("withdrawal outcome unknown, check the exchange before resubmitting")
 )
 
 // WithdrawManager is responsible for performing withdrawal requests and
@@ -15,4 +51,67 @@ type WithdrawManager struct {
 	exchangeManager  iExchangeManager
 	portfolioManager iPortfolioManager
 	isDryRun         bool
+	submitAttempts   int
+	retryDelay       time.Duration
+	offline          *offlineWithdrawals
+	locksMtx         sync.Mutex
+	locks            []trackedWithdrawalLock
+	// impaired holds currency operations reported suspended or congested by
+	// the currency state manager
+	impaired map[impairedCurrency]struct{}
+}
+
+// trackedWithdrawalLock holds a withdrawal lock and whether it was fetched
+// from the exchange or set from a currency state change, rather than learned
+// from a rejected withdrawal
+type trackedWithdrawalLock struct {
+	withdraw.Lock
+	fetched       bool
+	currencyState bool
+}
+
+// impairedCurrency is an exchange's currency operation which is suspended or
+// congested
+type impairedCurrency struct {
+	exchange  string
+	item      *currency.Item
+	operation string
+}
+
+// offlineWithdrawals holds withdrawals awaiting a signature from an offline
+// approval key before they are submitted
+type offlineWithdrawals struct {
+	keys       []ed25519.PublicKey
+	thresholds map[string]float64
+	expiry     time.Duration
+	m          sync.Mutex
+	pending    map[uuid.UUID]*pendingWithdrawal
+}
+
+// pendingWithdrawal holds a withdrawal request and the payload exported for
+// it to be signed
+type pendingWithdrawal struct {
+	request *withdraw.Request
+	payload []byte
+	expires time.Time
+}
+
+// withdrawalSigningPayload is the signed representation of a withdrawal
+// request. Credentials are never included
+type withdrawalSigningPayload struct {
+	ID      uuid.UUID        `json:"id"`
+	Created time.Time        `json:"created"`
+	Expires time.Time        `json:"expires"`
+	Request withdraw.Request `json:"request"`
+}
+
+// WithdrawalSigningPayload holds a payload to be signed offline to approve a
+// withdrawal
+type WithdrawalSigningPayload struct {
+	ID      uuid.UUID
+	Payload []byte
+	// SHA256 is the hex encoded digest of the payload, used to confirm the
+	// payload on the signing device matches the exported payload
+	SHA256  string
+	Expires time.Time
 }
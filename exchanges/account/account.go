@@ -65,6 +65,21 @@ func SubscribeToExchangeAccount(exchange string) (dispatch.Pipe, error) {
 	return service.mux.Subscribe(accounts.ID)
 }
 
+// SubscribeToBalanceChanges subscribes to balance change events for an
+// exchange. Each update publishes a []BalanceChange of the balances it altered
+func SubscribeToBalanceChanges(exchange string) (dispatch.Pipe, error) {
+	exchange = strings.ToLower(exchange)
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	accounts, ok := service.exchangeAccounts[exchange]
+	if !ok {
+		return dispatch.Pipe{}, fmt.Errorf("cannot subscribe to balance changes %s %w",
+			exchange,
+			errExchangeAccountsNotFound)
+	}
+	return service.mux.Subscribe(accounts.ChangesID)
+}
+
 // Process processes new account holdings updates
 func Process(h *Holdings, c *Credentials) error {
 	return service.Update(h, c)
@@ -208,14 +223,21 @@ func (s *Service) Update(incoming *Holdings, creds *Credentials) error {
 		if err != nil {
 			return err
 		}
+		changesID, err := s.mux.GetID()
+		if err != nil {
+			return err
+		}
 		accounts = &Accounts{
 			ID:          id,
+			ChangesID:   changesID,
 			SubAccounts: make(map[Credentials]map[key.SubAccountCurrencyAsset]*ProtectedBalance),
 		}
 		s.exchangeAccounts[exch] = accounts
 	}
 
 	var errs error
+	var changes []BalanceChange
+	now := time.Now()
 	for x := range incoming.Accounts {
 		if !incoming.Accounts[x].AssetType.IsValid() {
 			errs = common.AppendError(errs, fmt.Errorf("cannot load sub account holdings for %s [%s] %w",
@@ -258,7 +280,18 @@ func (s *Service) Update(incoming *Holdings, creds *Credentials) error {
 					Asset:      incoming.Accounts[x].AssetType,
 				}] = bal
 			}
-			bal.load(incoming.Accounts[x].Currencies[y])
+			if delta, changed := bal.load(incoming.Accounts[x].Currencies[y]); changed {
+				changes = append(changes, BalanceChange{
+					Exchange:   exch,
+					Account:    incoming.Accounts[x].ID,
+					Asset:      incoming.Accounts[x].AssetType,
+					Currency:   incoming.Accounts[x].Currencies[y].Currency,
+					Total:      incoming.Accounts[x].Currencies[y].Total,
+					Delta:      delta,
+					Reason:     incoming.Reason,
+					UpdateTime: now,
+				})
+			}
 		}
 	}
 
@@ -267,12 +300,19 @@ func (s *Service) Update(incoming *Holdings, creds *Credentials) error {
 		return err
 	}
 
+	if len(changes) != 0 {
+		if err = s.mux.Publish(changes, accounts.ChangesID); err != nil {
+			return err
+		}
+	}
+
 	return errs
 }
 
 // load checks to see if there is a change from incoming balance, if there is a
-// change it will change then alert external routines.
-func (b *ProtectedBalance) load(change Balance) {
+// change it will change then alert external routines. It returns the change in
+// total and whether any balance value changed.
+func (b *ProtectedBalance) load(change Balance) (delta float64, changed bool) {
 	b.m.Lock()
 	defer b.m.Unlock()
 	if b.total == change.Total &&
@@ -280,14 +320,16 @@ func (b *ProtectedBalance) load(change Balance) {
 		b.free == change.Free &&
 		b.availableWithoutBorrow == change.AvailableWithoutBorrow &&
 		b.borrowed == change.Borrowed {
-		return
+		return 0, false
 	}
+	delta = change.Total - b.total
 	b.total = change.Total
 	b.hold = change.Hold
 	b.free = change.Free
 	b.availableWithoutBorrow = change.AvailableWithoutBorrow
 	b.borrowed = change.Borrowed
 	b.notice.Alert()
+	return delta, true
 }
 
 // Wait waits for a change in amounts for an asset type. This will pause
@@ -320,3 +362,25 @@ func (b *ProtectedBalance) GetFree() float64 {
 	defer b.m.Unlock()
 	return b.free
 }
+
+// InitialMarginRatio returns the initial margin requirement as a proportion of
+// account equity. A ratio at or above 1 prevents new positions being opened
+func (m *MarginStatus) InitialMarginRatio() float64 {
+	return marginRatio(m.InitialMargin, m.Equity)
+}
+
+// MaintenanceMarginRatio returns the maintenance margin requirement as a
+// proportion of account equity. A ratio at or above 1 results in liquidation
+func (m *MarginStatus) MaintenanceMarginRatio() float64 {
+	return marginRatio(m.MaintenanceMargin, m.Equity)
+}
+
+func marginRatio(margin, equity float64) float64 {
+	if margin <= 0 {
+		return 0
+	}
+	if equity <= 0 {
+		return 1
+	}
+	return margin / equity
+}
@@ -6,6 +6,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/thrasher-corp/gocryptotrader/common/key"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/dispatch"
@@ -486,3 +488,60 @@ func TestUpdate(t *testing.T) {
 		t.Errorf("expecting 20 but received %f", b.hold)
 	}
 }
+
+func TestSubscribeToBalanceChanges(t *testing.T) {
+	t.Parallel()
+	_, err := SubscribeToBalanceChanges("nonsense")
+	assert.ErrorIs(t, err, errExchangeAccountsNotFound, "SubscribeToBalanceChanges should error for an unknown exchange")
+
+	h := &Holdings{
+		Exchange: "ChangeTest",
+		Accounts: []SubAccount{{
+			ID:         "1337",
+			AssetType:  asset.Spot,
+			Currencies: []Balance{{Currency: currency.BTC, Total: 10, Free: 10}},
+		}},
+	}
+	require.NoError(t, Process(h, happyCredentials), "Process must not error")
+
+	p, err := SubscribeToBalanceChanges("changetest")
+	require.NoError(t, err, "SubscribeToBalanceChanges must not error")
+
+	h.Accounts[0].Currencies = []Balance{{Currency: currency.BTC, Total: 7.5, Free: 7.5}}
+	h.Reason = FillChange
+	require.NoError(t, Process(h, happyCredentials), "Process must not error")
+
+	select {
+	case data := <-p.Channel():
+		changes, ok := data.([]BalanceChange)
+		require.True(t, ok, "Balance change events must be a []BalanceChange")
+		require.Len(t, changes, 1, "Only the changed balance must be published")
+		assert.Equal(t, currency.BTC, changes[0].Currency, "Currency should be set")
+		assert.Equal(t, 7.5, changes[0].Total, "Total should be the new balance")
+		assert.Equal(t, -2.5, changes[0].Delta, "Delta should be the change in total")
+		assert.Equal(t, FillChange, changes[0].Reason, "Reason should be set from the holdings")
+		assert.Equal(t, "1337", changes[0].Account, "Account should be set")
+	case <-time.After(time.Second * 5):
+		assert.Fail(t, "Balance change should be published")
+	}
+	require.NoError(t, p.Release(), "Release must not error")
+}
+
+func TestMarginStatusRatios(t *testing.T) {
+	t.Parallel()
+	m := MarginStatus{Equity: 1000, InitialMargin: 250, MaintenanceMargin: 50}
+	if r := m.InitialMarginRatio(); r != 0.25 {
+		t.Errorf("expecting 0.25 but received %f", r)
+	}
+	if r := m.MaintenanceMarginRatio(); r != 0.05 {
+		t.Errorf("expecting 0.05 but received %f", r)
+	}
+	m.Equity = 0
+	if r := m.MaintenanceMarginRatio(); r != 1 {
+		t.Errorf("expecting 1 when margin is required without equity but received %f", r)
+	}
+	m = MarginStatus{}
+	if r := m.InitialMarginRatio(); r != 0 {
+		t.Errorf("expecting 0 when no margin is required but received %f", r)
+	}
+}
@@ -3,6 +3,7 @@ package account
 import (
 	"errors"
 	"sync"
+	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/thrasher-corp/gocryptotrader/common/key"
@@ -28,6 +29,8 @@ type Service struct {
 // Accounts holds a stream ID and a map to the exchange holdings
 type Accounts struct {
 	ID uuid.UUID
+	// ChangesID is the stream ID for normalised balance change events
+	ChangesID uuid.UUID
 	// NOTE: Credentials is a place holder for a future interface type, which
 	// will need -
 	// TODO: Credential tracker to match to keys that are managed and return
@@ -41,6 +44,34 @@ type Accounts struct {
 type Holdings struct {
 	Exchange string
 	Accounts []SubAccount
+	// Reason is the cause of any balance changes in this update, if known
+	Reason ChangeReason
+}
+
+// ChangeReason defines the cause of a balance change
+type ChangeReason string
+
+// Balance change reasons
+const (
+	UnknownChange  ChangeReason = ""
+	FillChange     ChangeReason = "fill"
+	FundingChange  ChangeReason = "funding"
+	TransferChange ChangeReason = "transfer"
+)
+
+// BalanceChange is a normalised balance change event published when stored
+// holdings are updated
+type BalanceChange struct {
+	Exchange string
+	Account  string
+	Asset    asset.Item
+	Currency currency.Code
+	// Total is the new total balance and Delta its change from the previous
+	// stored total
+	Total      float64
+	Delta      float64
+	Reason     ChangeReason
+	UpdateTime time.Time
 }
 
 // SubAccount defines a singular account type with associated currency balances
@@ -70,6 +101,21 @@ type Change struct {
 	Account  string
 }
 
+// MarginStatus defines an account's margin requirements relative to its equity
+// as reported by an exchange account stream
+type MarginStatus struct {
+	Exchange string
+	Asset    asset.Item
+	Account  string
+	// Currency is the currency equity and margin values are denominated in
+	Currency          currency.Code
+	Equity            float64
+	InitialMargin     float64
+	MaintenanceMargin float64
+	AvailableBalance  float64
+	UpdateTime        time.Time
+}
+
 // ProtectedBalance stores the full balance information for that specific asset
 type ProtectedBalance struct {
 	total                  float64
@@ -93,6 +93,7 @@ func TestNew(t *testing.T) {
 		{Input: "CoinMarginedFutures", Expected: CoinMarginedFutures},
 		{Input: "USDTMarginedFutures", Expected: USDTMarginedFutures},
 		{Input: "USDCMarginedFutures", Expected: USDCMarginedFutures},
+		{Input: "OptionCombo", Expected: OptionCombo},
 	}
 
 	for x := range cases {
@@ -182,6 +183,10 @@ func TestIsFutures(t *testing.T) {
 			item:      USDCMarginedFutures,
 			isFutures: true,
 		},
+		{
+			item:      OptionCombo,
+			isFutures: false,
+		},
 	}
 	for _, s := range scenarios {
 		testScenario := s
@@ -61,6 +61,7 @@ const (
 	// Authenticated endpoints
 	newOrderTest      = "/api/v3/order/test"
 	orderEndpoint     = "/api/v3/order"
+	ocoOrderEndpoint  = "/api/v3/order/oco"
 	openOrders        = "/api/v3/openOrders"
 	allOrders         = "/api/v3/allOrders"
 	accountInfo       = "/api/v3/account"
@@ -98,7 +99,36 @@ const (
 	flexibleLoanAssetsData           = "/sapi/v1/loan/flexible/loanable/data"
 	flexibleLoanCollateralAssetsData = "/sapi/v1/loan/flexible/collateral/data"
 
+	// Convert endpoints
+	convertGetQuote    = "/sapi/v1/convert/getQuote"
+	convertAcceptQuote = "/sapi/v1/convert/acceptQuote"
+	dustAssets         = "/sapi/v1/asset/dust-btc"
+	dustTransfer       = "/sapi/v1/asset/dust"
+
+	// Simple Earn endpoints
+	simpleEarnFlexibleList      = "/sapi/v1/simple-earn/flexible/list"
+	simpleEarnLockedList        = "/sapi/v1/simple-earn/locked/list"
+	simpleEarnFlexibleSubscribe = "/sapi/v1/simple-earn/flexible/subscribe"
+	simpleEarnLockedSubscribe   = "/sapi/v1/simple-earn/locked/subscribe"
+	simpleEarnFlexibleRedeem    = "/sapi/v1/simple-earn/flexible/redeem"
+	simpleEarnLockedRedeem      = "/sapi/v1/simple-earn/locked/redeem"
+	simpleEarnFlexiblePosition  = "/sapi/v1/simple-earn/flexible/position"
+	simpleEarnLockedPosition    = "/sapi/v1/simple-earn/locked/position"
+	simpleEarnFlexibleRewards   = "/sapi/v1/simple-earn/flexible/history/rewardsRecord"
+	simpleEarnLockedRewards     = "/sapi/v1/simple-earn/locked/history/rewardsRecord"
+
 	defaultRecvWindow = 5 * time.Second
+
+	// maxFuturesBatchOrders is the maximum number of orders in a futures
+	// batch order request
+	maxFuturesBatchOrders = 5
+	// maxFuturesBatchCancels is the maximum number of orders in a futures
+	// batch cancel request
+	maxFuturesBatchCancels = 10
+
+	// supportedSTPModes are the self trade prevention modes supported by spot
+	// and margin orders
+	supportedSTPModes = order.STPCancelMaker | order.STPCancelTaker | order.STPCancelBoth
 )
 
 var (
@@ -108,6 +138,18 @@ var (
 	errOrderIDMustBeSet                       = errors.New("orderID must be set")
 	errAmountMustBeSet                        = errors.New("amount must not be <= 0")
 	errEitherLoanOrCollateralAmountsMustBeSet = errors.New("either loan or collateral amounts must be set")
+	errBatchOrderLimitExceeded                = errors.New("batch order limit exceeded")
+	errBatchOrderResponseMismatch             = errors.New("batch order response count mismatch")
+	errFromAssetMustBeSet                     = errors.New("from asset must be set")
+	errToAssetMustBeSet                       = errors.New("to asset must be set")
+	errEitherFromOrToAmountMustBeSet          = errors.New("either from or to amount must be set")
+	errQuoteIDMustBeSet                       = errors.New("quote ID must be set")
+	errDustAssetsMustBeSet                    = errors.New("dust assets must be set")
+	errProductIDMustBeSet                     = errors.New("product ID must be set")
+	errProjectIDMustBeSet                     = errors.New("project ID must be set")
+	errPositionIDMustBeSet                    = errors.New("position ID must be set")
+	errRewardTypeMustBeSet                    = errors.New("reward type must be set")
+	errRedeemAmountOrAllMustBeSet             = errors.New("either redeem amount or redeem all must be set")
 )
 
 var subscriptionNames = map[string]string{
@@ -621,9 +663,43 @@ func (b *Binance) newOrder(ctx context.Context, api string, o *NewOrderRequest,
 	if o.NewOrderRespType != "" {
 		params.Set("newOrderRespType", o.NewOrderRespType)
 	}
+
+	if o.SelfTradePreventionMode != "" {
+		params.Set("selfTradePreventionMode", o.SelfTradePreventionMode)
+	}
 	return b.SendAuthHTTPRequest(ctx, exchange.RestSpotSupplementary, http.MethodPost, api, params, spotOrderRate, resp)
 }
 
+// NewOCOOrder sends a one-cancels-the-other order list to Binance, made up of a
+// limit maker order and a stop loss or stop loss limit order
+func (b *Binance) NewOCOOrder(ctx context.Context, o *NewOCOOrderRequest) (*NewOCOOrderResponse, error) {
+	symbol, err := b.FormatSymbol(o.Symbol, asset.Spot)
+	if err != nil {
+		return nil, err
+	}
+	params := url.Values{}
+	params.Set("symbol", symbol)
+	params.Set("side", o.Side)
+	params.Set("quantity", strconv.FormatFloat(o.Quantity, 'f', -1, 64))
+	params.Set("price", strconv.FormatFloat(o.Price, 'f', -1, 64))
+	params.Set("stopPrice", strconv.FormatFloat(o.StopPrice, 'f', -1, 64))
+	if o.StopLimitPrice != 0 {
+		params.Set("stopLimitPrice", strconv.FormatFloat(o.StopLimitPrice, 'f', -1, 64))
+		params.Set("stopLimitTimeInForce", string(BinanceRequestParamsTimeGTC))
+	}
+	if o.ListClientOrderID != "" {
+		params.Set("listClientOrderId", o.ListClientOrderID)
+	}
+	if o.LimitClientOrderID != "" {
+		params.Set("limitClientOrderId", o.LimitClientOrderID)
+	}
+	if o.StopClientOrderID != "" {
+		params.Set("stopClientOrderId", o.StopClientOrderID)
+	}
+	var resp *NewOCOOrderResponse
+	return resp, b.SendAuthHTTPRequest(ctx, exchange.RestSpotSupplementary, http.MethodPost, ocoOrderEndpoint, params, spotOrderRate, &resp)
+}
+
 // CancelExistingOrder sends a cancel order to Binance
 func (b *Binance) CancelExistingOrder(ctx context.Context, symbol currency.Pair, orderID int64, origClientOrderID string) (CancelOrderResponse, error) {
 	var resp CancelOrderResponse
@@ -1747,3 +1823,213 @@ func (b *Binance) FlexibleCollateralAssetsData(ctx context.Context, collateralCo
 	var resp FlexibleCollateralAssetsData
 	return &resp, b.SendAuthHTTPRequest(ctx, exchange.RestSpotSupplementary, http.MethodGet, flexibleLoanCollateralAssetsData, params, spotDefaultRate, &resp)
 }
+
+// GetConvertQuote requests a quote to convert one asset into another. Either
+// the from or to amount must be set, and the quote must be accepted with
+// AcceptConvertQuote before it expires
+func (b *Binance) GetConvertQuote(ctx context.Context, fromAsset, toAsset currency.Code, fromAmount, toAmount float64) (*ConvertQuote, error) {
+	if fromAsset.IsEmpty() {
+		return nil, errFromAssetMustBeSet
+	}
+	if toAsset.IsEmpty() {
+		return nil, errToAssetMustBeSet
+	}
+	if (fromAmount <= 0) == (toAmount <= 0) {
+		return nil, errEitherFromOrToAmountMustBeSet
+	}
+
+	params := url.Values{}
+	params.Set("fromAsset", fromAsset.String())
+	params.Set("toAsset", toAsset.String())
+	if fromAmount > 0 {
+		params.Set("fromAmount", strconv.FormatFloat(fromAmount, 'f', -1, 64))
+	} else {
+		params.Set("toAmount", strconv.FormatFloat(toAmount, 'f', -1, 64))
+	}
+
+	var resp ConvertQuote
+	return &resp, b.SendAuthHTTPRequest(ctx, exchange.RestSpotSupplementary, http.MethodPost, convertGetQuote, params, spotDefaultRate, &resp)
+}
+
+// AcceptConvertQuote accepts a quote returned by GetConvertQuote
+func (b *Binance) AcceptConvertQuote(ctx context.Context, quoteID string) (*ConvertQuoteAcceptance, error) {
+	if quoteID == "" {
+		return nil, errQuoteIDMustBeSet
+	}
+
+	params := url.Values{}
+	params.Set("quoteId", quoteID)
+
+	var resp ConvertQuoteAcceptance
+	return &resp, b.SendAuthHTTPRequest(ctx, exchange.RestSpotSupplementary, http.MethodPost, convertAcceptQuote, params, spotDefaultRate, &resp)
+}
+
+// GetDustAssets returns the assets which can be converted into BNB
+func (b *Binance) GetDustAssets(ctx context.Context) (*DustAssets, error) {
+	var resp DustAssets
+	return &resp, b.SendAuthHTTPRequest(ctx, exchange.RestSpotSupplementary, http.MethodPost, dustAssets, nil, spotDefaultRate, &resp)
+}
+
+// DustTransfer converts small balances of the given assets into BNB
+func (b *Binance) DustTransfer(ctx context.Context, assets []currency.Code) (*DustTransferResult, error) {
+	if len(assets) == 0 {
+		return nil, errDustAssetsMustBeSet
+	}
+
+	params := url.Values{}
+	for i := range assets {
+		params.Add("asset", assets[i].String())
+	}
+
+	var resp DustTransferResult
+	return &resp, b.SendAuthHTTPRequest(ctx, exchange.RestSpotSupplementary, http.MethodPost, dustTransfer, params, spotDefaultRate, &resp)
+}
+
+// simpleEarnPageParams returns the common asset and pagination parameters of
+// the Simple Earn list endpoints
+func simpleEarnPageParams(asset currency.Code, current, size int64) url.Values {
+	params := url.Values{}
+	if !asset.IsEmpty() {
+		params.Set("asset", asset.String())
+	}
+	if current != 0 {
+		params.Set("current", strconv.FormatInt(current, 10))
+	}
+	if size != 0 {
+		params.Set("size", strconv.FormatInt(size, 10))
+	}
+	return params
+}
+
+// GetSimpleEarnFlexibleProducts returns the flexible Simple Earn products,
+// optionally filtered by asset
+func (b *Binance) GetSimpleEarnFlexibleProducts(ctx context.Context, asset currency.Code, current, size int64) (*SimpleEarnFlexibleProducts, error) {
+	var resp SimpleEarnFlexibleProducts
+	return &resp, b.SendAuthHTTPRequest(ctx, exchange.RestSpotSupplementary, http.MethodGet, simpleEarnFlexibleList, simpleEarnPageParams(asset, current, size), spotDefaultRate, &resp)
+}
+
+// GetSimpleEarnLockedProducts returns the locked Simple Earn products,
+// optionally filtered by asset
+func (b *Binance) GetSimpleEarnLockedProducts(ctx context.Context, asset currency.Code, current, size int64) (*SimpleEarnLockedProducts, error) {
+	var resp SimpleEarnLockedProducts
+	return &resp, b.SendAuthHTTPRequest(ctx, exchange.RestSpotSupplementary, http.MethodGet, simpleEarnLockedList, simpleEarnPageParams(asset, current, size), spotDefaultRate, &resp)
+}
+
+// SubscribeSimpleEarnFlexible subscribes an amount to a flexible Simple Earn
+// product
+func (b *Binance) SubscribeSimpleEarnFlexible(ctx context.Context, productID string, amount float64) (*SimpleEarnSubscription, error) {
+	if productID == "" {
+		return nil, errProductIDMustBeSet
+	}
+	if amount <= 0 {
+		return nil, errAmountMustBeSet
+	}
+
+	params := url.Values{}
+	params.Set("productId", productID)
+	params.Set("amount", strconv.FormatFloat(amount, 'f', -1, 64))
+
+	var resp SimpleEarnSubscription
+	return &resp, b.SendAuthHTTPRequest(ctx, exchange.RestSpotSupplementary, http.MethodPost, simpleEarnFlexibleSubscribe, params, spotDefaultRate, &resp)
+}
+
+// SubscribeSimpleEarnLocked subscribes an amount to a locked Simple Earn
+// project
+func (b *Binance) SubscribeSimpleEarnLocked(ctx context.Context, projectID string, amount float64) (*SimpleEarnSubscription, error) {
+	if projectID == "" {
+		return nil, errProjectIDMustBeSet
+	}
+	if amount <= 0 {
+		return nil, errAmountMustBeSet
+	}
+
+	params := url.Values{}
+	params.Set("projectId", projectID)
+	params.Set("amount", strconv.FormatFloat(amount, 'f', -1, 64))
+
+	var resp SimpleEarnSubscription
+	return &resp, b.SendAuthHTTPRequest(ctx, exchange.RestSpotSupplementary, http.MethodPost, simpleEarnLockedSubscribe, params, spotDefaultRate, &resp)
+}
+
+// RedeemSimpleEarnFlexible redeems an amount, or the full position when
+// redeemAll is set, from a flexible Simple Earn product
+func (b *Binance) RedeemSimpleEarnFlexible(ctx context.Context, productID string, amount float64, redeemAll bool) (*SimpleEarnRedemption, error) {
+	if productID == "" {
+		return nil, errProductIDMustBeSet
+	}
+	if (amount <= 0) != redeemAll {
+		return nil, errRedeemAmountOrAllMustBeSet
+	}
+
+	params := url.Values{}
+	params.Set("productId", productID)
+	if redeemAll {
+		params.Set("redeemAll", "true")
+	} else {
+		params.Set("amount", strconv.FormatFloat(amount, 'f', -1, 64))
+	}
+
+	var resp SimpleEarnRedemption
+	return &resp, b.SendAuthHTTPRequest(ctx, exchange.RestSpotSupplementary, http.MethodPost, simpleEarnFlexibleRedeem, params, spotDefaultRate, &resp)
+}
+
+// RedeemSimpleEarnLocked redeems a locked Simple Earn position early
+func (b *Binance) RedeemSimpleEarnLocked(ctx context.Context, positionID string) (*SimpleEarnRedemption, error) {
+	if positionID == "" {
+		return nil, errPositionIDMustBeSet
+	}
+
+	params := url.Values{}
+	params.Set("positionId", positionID)
+
+	var resp SimpleEarnRedemption
+	return &resp, b.SendAuthHTTPRequest(ctx, exchange.RestSpotSupplementary, http.MethodPost, simpleEarnLockedRedeem, params, spotDefaultRate, &resp)
+}
+
+// GetSimpleEarnFlexiblePositions returns the flexible Simple Earn positions,
+// optionally filtered by asset
+func (b *Binance) GetSimpleEarnFlexiblePositions(ctx context.Context, asset currency.Code, current, size int64) (*SimpleEarnFlexiblePositions, error) {
+	var resp SimpleEarnFlexiblePositions
+	return &resp, b.SendAuthHTTPRequest(ctx, exchange.RestSpotSupplementary, http.MethodGet, simpleEarnFlexiblePosition, simpleEarnPageParams(asset, current, size), spotDefaultRate, &resp)
+}
+
+// GetSimpleEarnLockedPositions returns the locked Simple Earn positions,
+// optionally filtered by asset
+func (b *Binance) GetSimpleEarnLockedPositions(ctx context.Context, asset currency.Code, current, size int64) (*SimpleEarnLockedPositions, error) {
+	var resp SimpleEarnLockedPositions
+	return &resp, b.SendAuthHTTPRequest(ctx, exchange.RestSpotSupplementary, http.MethodGet, simpleEarnLockedPosition, simpleEarnPageParams(asset, current, size), spotDefaultRate, &resp)
+}
+
+// GetSimpleEarnFlexibleRewards returns the flexible Simple Earn rewards of a
+// reward type, being BONUS, REALTIME, REWARDS or ALL
+func (b *Binance) GetSimpleEarnFlexibleRewards(ctx context.Context, asset currency.Code, rewardType string, startTime, endTime time.Time, current, size int64) (*SimpleEarnFlexibleRewards, error) {
+	if rewardType == "" {
+		return nil, errRewardTypeMustBeSet
+	}
+
+	params := simpleEarnPageParams(asset, current, size)
+	params.Set("type", rewardType)
+	if !startTime.IsZero() {
+		params.Set("startTime", strconv.FormatInt(startTime.UnixMilli(), 10))
+	}
+	if !endTime.IsZero() {
+		params.Set("endTime", strconv.FormatInt(endTime.UnixMilli(), 10))
+	}
+
+	var resp SimpleEarnFlexibleRewards
+	return &resp, b.SendAuthHTTPRequest(ctx, exchange.RestSpotSupplementary, http.MethodGet, simpleEarnFlexibleRewards, params, spotDefaultRate, &resp)
+}
+
+// GetSimpleEarnLockedRewards returns the locked Simple Earn rewards
+func (b *Binance) GetSimpleEarnLockedRewards(ctx context.Context, asset currency.Code, startTime, endTime time.Time, current, size int64) (*SimpleEarnLockedRewards, error) {
+	params := simpleEarnPageParams(asset, current, size)
+	if !startTime.IsZero() {
+		params.Set("startTime", strconv.FormatInt(startTime.UnixMilli(), 10))
+	}
+	if !endTime.IsZero() {
+		params.Set("endTime", strconv.FormatInt(endTime.UnixMilli(), 10))
+	}
+
+	var resp SimpleEarnLockedRewards
+	return &resp, b.SendAuthHTTPRequest(ctx, exchange.RestSpotSupplementary, http.MethodGet, simpleEarnLockedRewards, params, spotDefaultRate, &resp)
+}
@@ -22,6 +22,8 @@ import (
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/collateral"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/conversion"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/earn"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/fundingrate"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
@@ -1650,6 +1652,108 @@ func TestSubmitOrder(t *testing.T) {
 	}
 }
 
+func TestSubmitOCOOrder(t *testing.T) {
+	t.Parallel()
+	_, err := b.SubmitOCOOrder(context.Background(), nil)
+	assert.ErrorIs(t, err, order.ErrOCOIsNil)
+	o := &order.OCOSubmit{
+		Exchange:     b.Name,
+		Pair:         currency.NewPair(currency.LTC, currency.BTC),
+		AssetType:    asset.USDTMarginedFutures,
+		Side:         order.Sell,
+		Amount:       1,
+		Price:        1,
+		TriggerPrice: 0.0001,
+	}
+	_, err = b.SubmitOCOOrder(context.Background(), o)
+	assert.ErrorIs(t, err, asset.ErrNotSupported)
+
+	if mockTests {
+		t.Skip("OCO orders are not in the mock data, skipping")
+	}
+	sharedtestvalues.SkipTestIfCannotManipulateOrders(t, b, canManipulateRealOrders)
+	o.AssetType = asset.Spot
+	_, err = b.SubmitOCOOrder(context.Background(), o)
+	assert.NoError(t, err)
+}
+
+func TestSubmitOrders(t *testing.T) {
+	t.Parallel()
+	_, err := b.SubmitOrders(context.Background(), nil)
+	assert.ErrorIs(t, err, order.ErrSubmissionIsNil)
+	_, err = b.SubmitOrders(context.Background(), []order.Submit{{AssetType: asset.Spot}})
+	assert.ErrorIs(t, err, common.ErrFunctionNotSupported, "spot batches should fall back to individual orders")
+	s := make([]order.Submit, maxFuturesBatchOrders+1)
+	for i := range s {
+		s[i] = order.Submit{Exchange: b.Name, Pair: currency.NewPair(currency.BTC, currency.USDT), AssetType: asset.USDTMarginedFutures, Side: order.Buy, Type: order.Limit, Price: 1, Amount: 1}
+	}
+	_, err = b.SubmitOrders(context.Background(), s)
+	assert.ErrorIs(t, err, errBatchOrderLimitExceeded)
+	s = s[:2]
+	s[1].AssetType = asset.CoinMarginedFutures
+	_, err = b.SubmitOrders(context.Background(), s)
+	assert.ErrorIs(t, err, common.ErrFunctionNotSupported, "mixed asset batches should fall back to individual orders")
+	s[1].AssetType = asset.USDTMarginedFutures
+	s[1].TimeInForce = order.GTD
+	_, err = b.SubmitOrders(context.Background(), s)
+	assert.ErrorIs(t, err, common.ErrFunctionNotSupported, "good till date batches should fall back to individual orders")
+	s[1].TimeInForce = order.UnsetTimeInForce
+	s[1].Side = order.Long
+	_, err = b.SubmitOrders(context.Background(), s)
+	assert.ErrorContains(t, err, "invalid side")
+}
+
+func TestSelfTradePreventionMode(t *testing.T) {
+	t.Parallel()
+	for stp, expected := range map[order.SelfTradePrevention]string{
+		order.UnsetSelfTradePrevention: "",
+		order.STPCancelMaker:           "EXPIRE_MAKER",
+		order.STPCancelTaker:           "EXPIRE_TAKER",
+		order.STPCancelBoth:            "EXPIRE_BOTH",
+		order.STPDecrement:             "",
+	} {
+		assert.Equal(t, expected, selfTradePreventionMode(stp), stp.String())
+	}
+	s := &order.Submit{Exchange: b.Name, Pair: currency.NewPair(currency.BTC, currency.USDT), AssetType: asset.USDTMarginedFutures, Side: order.Buy, Type: order.Market, Amount: 1, SelfTradePrevention: order.STPCancelMaker}
+	_, err := b.SubmitOrder(context.Background(), s)
+	assert.ErrorIs(t, err, order.ErrUnsupportedSTPMode, "futures orders should reject self trade prevention")
+	s.AssetType, s.SelfTradePrevention = asset.Spot, order.STPDecrement
+	_, err = b.SubmitOrder(context.Background(), s)
+	assert.ErrorIs(t, err, order.ErrUnsupportedSTPMode)
+}
+
+func TestSubmitOrderDisplayAmount(t *testing.T) {
+	t.Parallel()
+	s := &order.Submit{Exchange: b.Name, Pair: currency.NewPair(currency.BTC, currency.USDT), AssetType: asset.USDTMarginedFutures, Side: order.Buy, Type: order.Limit, Price: 1, Amount: 1, DisplayAmount: 0.1}
+	_, err := b.SubmitOrder(context.Background(), s)
+	assert.ErrorIs(t, err, order.ErrUnsupportedDisplayAmount, "futures orders should reject a display amount")
+	s.AssetType, s.TimeInForce = asset.Spot, order.IOC
+	_, err = b.SubmitOrder(context.Background(), s)
+	assert.ErrorIs(t, err, order.ErrUnsupportedDisplayAmount, "immediate or cancel iceberg orders should be rejected")
+}
+
+func TestCancelBatchOrders(t *testing.T) {
+	t.Parallel()
+	_, err := b.CancelBatchOrders(context.Background(), nil)
+	assert.ErrorIs(t, err, order.ErrCancelOrderIsNil)
+	_, err = b.CancelBatchOrders(context.Background(), []order.Cancel{{AssetType: asset.Spot, OrderID: "1"}})
+	assert.ErrorIs(t, err, common.ErrFunctionNotSupported, "spot batches should fall back to individual cancellations")
+	c := make([]order.Cancel, maxFuturesBatchCancels+1)
+	for i := range c {
+		c[i] = order.Cancel{Pair: currency.NewPair(currency.BTC, currency.USDT), AssetType: asset.USDTMarginedFutures, OrderID: "1"}
+	}
+	_, err = b.CancelBatchOrders(context.Background(), c)
+	assert.ErrorIs(t, err, errBatchOrderLimitExceeded)
+	c = c[:2]
+	c[1].Pair = currency.NewPair(currency.ETH, currency.USDT)
+	_, err = b.CancelBatchOrders(context.Background(), c)
+	assert.ErrorIs(t, err, common.ErrFunctionNotSupported, "batches across pairs should fall back to individual cancellations")
+	c[1].Pair = c[0].Pair
+	c[1].OrderID = ""
+	_, err = b.CancelBatchOrders(context.Background(), c)
+	assert.ErrorIs(t, err, common.ErrFunctionNotSupported, "batches without order IDs should fall back to individual cancellations")
+}
+
 func TestCancelExchangeOrder(t *testing.T) {
 	t.Parallel()
 
@@ -2000,9 +2104,9 @@ func TestSubscribe(t *testing.T) {
 	} else {
 		testexch.SetupWs(t, b)
 	}
-	err := b.Subscribe(channels)
+	err := b.Subscribe(context.Background(), channels)
 	require.NoError(t, err, "Subscribe should not error")
-	err = b.Unsubscribe(channels)
+	err = b.Unsubscribe(context.Background(), channels)
 	require.NoError(t, err, "Unsubscribe should not error")
 }
 
@@ -2018,12 +2122,18 @@ func TestSubscribeBadResp(t *testing.T) {
 		return w.WriteMessage(websocket.TextMessage, []byte(fmt.Sprintf(`{"result":{"error":"carrots"},"id":%d}`, req.ID)))
 	}
 	b := testexch.MockWsInstance[Binance](t, testexch.CurryWsMockUpgrader(t, mock)) //nolint:govet // Intentional shadow to avoid future copy/paste mistakes
-	err := b.Subscribe(channels)
+	err := b.Subscribe(context.Background(), channels)
 	assert.ErrorIs(t, err, stream.ErrSubscriptionFailure, "Subscribe should error ErrSubscriptionFailure")
 	assert.ErrorIs(t, err, errUnknownError, "Subscribe should error errUnknownError")
 	assert.ErrorContains(t, err, "carrots", "Subscribe should error containing the carrots")
 }
 
+func TestWsMessageKey(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "btcusdt", wsMessageKey([]byte(`{"stream":"btcusdt@depth@100ms","data":{}}`)), "key should be the stream's instrument")
+	assert.Empty(t, wsMessageKey([]byte(`{"result":null,"id":1}`)), "messages without a stream should have an empty key")
+}
+
 func TestWsTickerUpdate(t *testing.T) {
 	t.Parallel()
 	pressXToJSON := []byte(`{"stream":"btcusdt@ticker","data":{"e":"24hrTicker","E":1580254809477,"s":"BTCUSDT","p":"420.97000000","P":"4.720","w":"9058.27981278","x":"8917.98000000","c":"9338.96000000","Q":"0.17246300","b":"9338.03000000","B":"0.18234600","a":"9339.70000000","A":"0.14097600","o":"8917.99000000","h":"9373.19000000","l":"8862.40000000","v":"72229.53692000","q":"654275356.16896672","O":1580168409456,"C":1580254809456,"F":235294268,"L":235894703,"n":600436}}`)
@@ -2424,6 +2534,19 @@ func TestGetAvailableTransferChains(t *testing.T) {
 	}
 }
 
+func TestGetTransferNetworks(t *testing.T) {
+	t.Parallel()
+	if !mockTests {
+		sharedtestvalues.SkipTestIfCredentialsUnset(t, b)
+	}
+	chains, err := b.GetTransferNetworks(context.Background(), currency.BTC)
+	require.NoError(t, err, "GetTransferNetworks must not error")
+	for i := range chains {
+		assert.NotEmpty(t, chains[i].Name, "Name should be set")
+		assert.NotEmpty(t, chains[i].Network, "Network should be set")
+	}
+}
+
 func TestSeedLocalCache(t *testing.T) {
 	t.Parallel()
 	err := b.SeedLocalCache(context.Background(), currency.NewPair(currency.BTC, currency.USDT))
@@ -3151,6 +3274,28 @@ func TestSetMarginType(t *testing.T) {
 	}
 }
 
+func TestGetMarginType(t *testing.T) {
+	t.Parallel()
+	sharedtestvalues.SkipTestIfCredentialsUnset(t, b)
+	_, err := b.GetMarginType(context.Background(), asset.USDTMarginedFutures, currency.NewBTCUSDT())
+	if err != nil {
+		t.Error(err)
+	}
+
+	p, err := currency.NewPairFromString("BTCUSD_PERP")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = b.GetMarginType(context.Background(), asset.CoinMarginedFutures, p)
+	if err != nil {
+		t.Error(err)
+	}
+	_, err = b.GetMarginType(context.Background(), asset.Spot, currency.NewBTCUSDT())
+	if !errors.Is(err, asset.ErrNotSupported) {
+		t.Error(err)
+	}
+}
+
 func TestGetLeverage(t *testing.T) {
 	t.Parallel()
 	sharedtestvalues.SkipTestIfCredentialsUnset(t, b)
@@ -3489,3 +3634,181 @@ func TestGetOpenInterest(t *testing.T) {
 	})
 	assert.ErrorIs(t, err, asset.ErrNotSupported)
 }
+
+func TestFuturesTimeInForce(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		s        *order.Submit
+		expected RequestParamsTimeForceType
+	}{
+		{&order.Submit{}, BinanceRequestParamsTimeGTC},
+		{&order.Submit{TimeInForce: order.IOC}, BinanceRequestParamsTimeIOC},
+		{&order.Submit{TimeInForce: order.FOK}, BinanceRequestParamsTimeFOK},
+		{&order.Submit{TimeInForce: order.GTD, Expiry: time.Now().Add(time.Hour)}, BinanceRequestParamsTimeGTD},
+		{&order.Submit{TimeInForce: order.GTC, PostOnly: true}, BinanceRequestParamsTimeGTX},
+	} {
+		assert.Equal(t, tc.expected, futuresTimeInForce(tc.s))
+	}
+}
+
+func TestGetConvertQuote(t *testing.T) {
+	t.Parallel()
+	_, err := b.GetConvertQuote(context.Background(), currency.EMPTYCODE, currency.USDT, 1, 0)
+	assert.ErrorIs(t, err, errFromAssetMustBeSet)
+	_, err = b.GetConvertQuote(context.Background(), currency.BTC, currency.EMPTYCODE, 1, 0)
+	assert.ErrorIs(t, err, errToAssetMustBeSet)
+	_, err = b.GetConvertQuote(context.Background(), currency.BTC, currency.USDT, 0, 0)
+	assert.ErrorIs(t, err, errEitherFromOrToAmountMustBeSet)
+	_, err = b.GetConvertQuote(context.Background(), currency.BTC, currency.USDT, 1, 1)
+	assert.ErrorIs(t, err, errEitherFromOrToAmountMustBeSet)
+
+	sharedtestvalues.SkipTestIfCredentialsUnset(t, b, canManipulateRealOrders)
+	_, err = b.GetConvertQuote(context.Background(), currency.BTC, currency.USDT, 0.0001, 0)
+	assert.NoError(t, err, "GetConvertQuote should not error")
+}
+
+func TestAcceptConvertQuote(t *testing.T) {
+	t.Parallel()
+	_, err := b.AcceptConvertQuote(context.Background(), "")
+	assert.ErrorIs(t, err, errQuoteIDMustBeSet)
+}
+
+func TestGetDustAssets(t *testing.T) {
+	t.Parallel()
+	sharedtestvalues.SkipTestIfCredentialsUnset(t, b, canManipulateRealOrders)
+	_, err := b.GetDustAssets(context.Background())
+	assert.NoError(t, err, "GetDustAssets should not error")
+}
+
+func TestDustTransfer(t *testing.T) {
+	t.Parallel()
+	_, err := b.DustTransfer(context.Background(), nil)
+	assert.ErrorIs(t, err, errDustAssetsMustBeSet)
+}
+
+func TestGetConversionQuote(t *testing.T) {
+	t.Parallel()
+	_, err := b.GetConversionQuote(context.Background(), nil)
+	assert.ErrorIs(t, err, conversion.ErrNilRequest)
+
+	sharedtestvalues.SkipTestIfCredentialsUnset(t, b, canManipulateRealOrders)
+	q, err := b.GetConversionQuote(context.Background(), &conversion.QuoteRequest{From: currency.USDT, To: currency.BTC, Amount: 10})
+	require.NoError(t, err, "GetConversionQuote must not error")
+	assert.NotEmpty(t, q.ID, "ID should be set")
+}
+
+func TestAcceptConversionQuote(t *testing.T) {
+	t.Parallel()
+	_, err := b.AcceptConversionQuote(context.Background(), &conversion.Quote{ID: "1337", From: currency.BTC, To: currency.USDT, FromAmount: 1, Expires: time.Now().Add(-time.Second)})
+	assert.ErrorIs(t, err, conversion.ErrQuoteExpired)
+}
+
+func TestConvertDust(t *testing.T) {
+	t.Parallel()
+	_, err := b.ConvertDust(context.Background(), &conversion.DustRequest{})
+	assert.ErrorIs(t, err, conversion.ErrNoDustCurrencies)
+	_, err = b.ConvertDust(context.Background(), &conversion.DustRequest{Currencies: []currency.Code{currency.ADA}, To: currency.USDT})
+	assert.ErrorIs(t, err, conversion.ErrUnsupportedDustTarget)
+
+	sharedtestvalues.SkipTestIfCredentialsUnset(t, b, canManipulateRealOrders)
+	_, err = b.ConvertDust(context.Background(), &conversion.DustRequest{Currencies: []currency.Code{currency.ADA}})
+	assert.NoError(t, err, "ConvertDust should not error")
+}
+
+func TestGetDustBalances(t *testing.T) {
+	t.Parallel()
+	sharedtestvalues.SkipTestIfCredentialsUnset(t, b, canManipulateRealOrders)
+	_, err := b.GetDustBalances(context.Background())
+	assert.NoError(t, err, "GetDustBalances should not error")
+}
+
+func TestSubscribeSimpleEarnFlexible(t *testing.T) {
+	t.Parallel()
+	_, err := b.SubscribeSimpleEarnFlexible(context.Background(), "", 1)
+	assert.ErrorIs(t, err, errProductIDMustBeSet)
+	_, err = b.SubscribeSimpleEarnFlexible(context.Background(), "USDT001", 0)
+	assert.ErrorIs(t, err, errAmountMustBeSet)
+}
+
+func TestSubscribeSimpleEarnLocked(t *testing.T) {
+	t.Parallel()
+	_, err := b.SubscribeSimpleEarnLocked(context.Background(), "", 1)
+	assert.ErrorIs(t, err, errProjectIDMustBeSet)
+	_, err = b.SubscribeSimpleEarnLocked(context.Background(), "Bnb*120", 0)
+	assert.ErrorIs(t, err, errAmountMustBeSet)
+}
+
+func TestRedeemSimpleEarnFlexible(t *testing.T) {
+	t.Parallel()
+	_, err := b.RedeemSimpleEarnFlexible(context.Background(), "", 1, false)
+	assert.ErrorIs(t, err, errProductIDMustBeSet)
+	_, err = b.RedeemSimpleEarnFlexible(context.Background(), "USDT001", 0, false)
+	assert.ErrorIs(t, err, errRedeemAmountOrAllMustBeSet)
+	_, err = b.RedeemSimpleEarnFlexible(context.Background(), "USDT001", 1, true)
+	assert.ErrorIs(t, err, errRedeemAmountOrAllMustBeSet)
+}
+
+func TestRedeemSimpleEarnLocked(t *testing.T) {
+	t.Parallel()
+	_, err := b.RedeemSimpleEarnLocked(context.Background(), "")
+	assert.ErrorIs(t, err, errPositionIDMustBeSet)
+}
+
+func TestGetSimpleEarnFlexibleRewards(t *testing.T) {
+	t.Parallel()
+	_, err := b.GetSimpleEarnFlexibleRewards(context.Background(), currency.EMPTYCODE, "", time.Time{}, time.Time{}, 0, 0)
+	assert.ErrorIs(t, err, errRewardTypeMustBeSet)
+}
+
+func TestGetEarnProducts(t *testing.T) {
+	t.Parallel()
+	sharedtestvalues.SkipTestIfCredentialsUnset(t, b, canManipulateRealOrders)
+	_, err := b.GetEarnProducts(context.Background(), currency.USDT)
+	assert.NoError(t, err, "GetEarnProducts should not error")
+}
+
+func TestGetEarnPositions(t *testing.T) {
+	t.Parallel()
+	sharedtestvalues.SkipTestIfCredentialsUnset(t, b, canManipulateRealOrders)
+	_, err := b.GetEarnPositions(context.Background())
+	assert.NoError(t, err, "GetEarnPositions should not error")
+}
+
+func TestSubscribeEarnProduct(t *testing.T) {
+	t.Parallel()
+	_, err := b.SubscribeEarnProduct(context.Background(), nil)
+	assert.ErrorIs(t, err, earn.ErrNilRequest)
+}
+
+func TestRedeemEarnProduct(t *testing.T) {
+	t.Parallel()
+	_, err := b.RedeemEarnProduct(context.Background(), nil)
+	assert.ErrorIs(t, err, earn.ErrNilRequest)
+	_, err = b.RedeemEarnProduct(context.Background(), &earn.RedeemRequest{ProductID: "Bnb*120", PositionID: "1337", Currency: currency.BNB, Type: earn.Locked, Amount: 1})
+	assert.ErrorIs(t, err, errRedeemAmountOrAllMustBeSet, "RedeemEarnProduct should not allow partial locked redemptions")
+}
+
+func TestGetEarnRewards(t *testing.T) {
+	t.Parallel()
+	_, err := b.GetEarnRewards(context.Background(), time.Time{}, time.Now())
+	assert.ErrorIs(t, err, common.ErrDateUnset)
+
+	sharedtestvalues.SkipTestIfCredentialsUnset(t, b, canManipulateRealOrders)
+	_, err = b.GetEarnRewards(context.Background(), time.Now().AddDate(0, 0, -30), time.Now())
+	assert.NoError(t, err, "GetEarnRewards should not error")
+}
+
+func TestSimpleEarnPositionUnmarshal(t *testing.T) {
+	t.Parallel()
+	var resp SimpleEarnLockedPositions
+	err := json.Unmarshal([]byte(`{"rows":[{"positionId":123123,"projectId":"Axs*90","asset":"AXS","amount":"122.09202928","purchaseTime":"1646182276000","duration":"60","accrualDays":"4","rewardAsset":"AXS","APY":"0.2032","rewardAmt":"5.17181528","deliverDate":"1651449600000","canRedeemEarly":true}],"total":1}`), &resp)
+	require.NoError(t, err, "Unmarshal must not error")
+	require.Len(t, resp.Rows, 1)
+	assert.Equal(t, "123123", resp.Rows[0].PositionID.String(), "PositionID should unmarshal numeric IDs")
+	assert.Equal(t, int64(60), resp.Rows[0].Duration)
+	assert.Equal(t, 5.17181528, resp.Rows[0].RewardAmount)
+
+	var sub SimpleEarnSubscription
+	require.NoError(t, json.Unmarshal([]byte(`{"purchaseId":40607,"positionId":"12345","success":true}`), &sub), "Unmarshal must not error")
+	assert.Equal(t, "12345", sub.PositionID.String(), "PositionID should unmarshal string IDs")
+}
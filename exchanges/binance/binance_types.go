@@ -1,6 +1,7 @@
 package binance
 
 import (
+	"encoding/json"
 	"sync"
 	"time"
 
@@ -384,6 +385,9 @@ type NewOrderRequest struct {
 	StopPrice        float64 // Used with STOP_LOSS, STOP_LOSS_LIMIT, TAKE_PROFIT, and TAKE_PROFIT_LIMIT orders.
 	IcebergQty       float64 // Used with LIMIT, STOP_LOSS_LIMIT, and TAKE_PROFIT_LIMIT to create an iceberg order.
 	NewOrderRespType string
+	// SelfTradePreventionMode is one of EXPIRE_TAKER, EXPIRE_MAKER or
+	// EXPIRE_BOTH. The account default is used when empty
+	SelfTradePreventionMode string
 }
 
 // NewOrderResponse is the return structured response from the exchange
@@ -411,6 +415,34 @@ type NewOrderResponse struct {
 	} `json:"fills"`
 }
 
+// NewOCOOrderRequest holds the parameters of a one-cancels-the-other order list
+type NewOCOOrderRequest struct {
+	Symbol   currency.Pair
+	Side     string
+	Quantity float64
+	// Price is the limit maker order's price
+	Price float64
+	// StopPrice triggers the stop order
+	StopPrice float64
+	// StopLimitPrice makes the stop order a stop loss limit order when set
+	StopLimitPrice     float64
+	ListClientOrderID  string
+	LimitClientOrderID string
+	StopClientOrderID  string
+}
+
+// NewOCOOrderResponse is the response to placing a one-cancels-the-other
+// order list
+type NewOCOOrderResponse struct {
+	OrderListID       int64              `json:"orderListId"`
+	ContingencyType   string             `json:"contingencyType"`
+	ListStatusType    string             `json:"listStatusType"`
+	ListOrderStatus   string             `json:"listOrderStatus"`
+	ListClientOrderID string             `json:"listClientOrderId"`
+	Symbol            string             `json:"symbol"`
+	OrderReports      []NewOrderResponse `json:"orderReports"`
+}
+
 // CancelOrderResponse is the return structured response from the exchange
 type CancelOrderResponse struct {
 	Symbol            string `json:"symbol"`
@@ -497,6 +529,12 @@ var (
 
 	// BinanceRequestParamsTimeFOK FOK
 	BinanceRequestParamsTimeFOK = RequestParamsTimeForceType("FOK")
+
+	// BinanceRequestParamsTimeGTX GTX post only, futures only
+	BinanceRequestParamsTimeGTX = RequestParamsTimeForceType("GTX")
+
+	// BinanceRequestParamsTimeGTD GTD, USDT margined futures only
+	BinanceRequestParamsTimeGTD = RequestParamsTimeForceType("GTD")
 )
 
 // RequestParamsOrderType trade order type
@@ -876,7 +914,7 @@ type WsListStatusData struct {
 // WsPayload defines the payload through the websocket connection
 type WsPayload struct {
 	Method string   `json:"method"`
-	Params []string `json:"params"`
+	Params []string `json:"params,omitempty"`
 	ID     int64    `json:"id"`
 }
 
@@ -1234,3 +1272,194 @@ type FlexibleCollateralAssetsData struct {
 	Rows  []FlexibleCollateralAssetsDataItem `json:"rows"`
 	Total int64                              `json:"total"`
 }
+
+// ConvertQuote stores a convert quote
+type ConvertQuote struct {
+	QuoteID        string      `json:"quoteId"`
+	Ratio          float64     `json:"ratio,string"`
+	InverseRatio   float64     `json:"inverseRatio,string"`
+	ValidTimestamp binanceTime `json:"validTimestamp"`
+	ToAmount       float64     `json:"toAmount,string"`
+	FromAmount     float64     `json:"fromAmount,string"`
+}
+
+// ConvertQuoteAcceptance stores the order created by accepting a convert quote
+type ConvertQuoteAcceptance struct {
+	OrderID     string      `json:"orderId"`
+	CreateTime  binanceTime `json:"createTime"`
+	OrderStatus string      `json:"orderStatus"`
+}
+
+// DustAsset stores an asset which can be converted into BNB
+type DustAsset struct {
+	Asset            currency.Code `json:"asset"`
+	AssetFullName    string        `json:"assetFullName"`
+	AmountFree       float64       `json:"amountFree,string"`
+	ToBTC            float64       `json:"toBTC,string"`
+	ToBNB            float64       `json:"toBNB,string"`
+	ToBNBOffExchange float64       `json:"toBNBOffExchange,string"`
+	Exchange         float64       `json:"exchange,string"`
+}
+
+// DustAssets stores the assets which can be converted into BNB
+type DustAssets struct {
+	Details            []DustAsset `json:"details"`
+	TotalTransferBTC   float64     `json:"totalTransferBtc,string"`
+	TotalTransferBNB   float64     `json:"totalTransferBNB,string"`
+	DribbletPercentage float64     `json:"dribbletPercentage,string"`
+}
+
+// DustTransferItem stores the conversion of a single dust asset
+type DustTransferItem struct {
+	Amount              float64       `json:"amount,string"`
+	FromAsset           currency.Code `json:"fromAsset"`
+	OperateTime         binanceTime   `json:"operateTime"`
+	ServiceChargeAmount float64       `json:"serviceChargeAmount,string"`
+	TransactionID       int64         `json:"tranId"`
+	TransferedAmount    float64       `json:"transferedAmount,string"`
+}
+
+// DustTransferResult stores the result of a dust transfer
+type DustTransferResult struct {
+	TotalServiceCharge float64            `json:"totalServiceCharge,string"`
+	TotalTransfered    float64            `json:"totalTransfered,string"`
+	TransferResult     []DustTransferItem `json:"transferResult"`
+}
+
+// SimpleEarnFlexibleProduct stores a flexible Simple Earn product
+type SimpleEarnFlexibleProduct struct {
+	Asset                      currency.Code `json:"asset"`
+	LatestAnnualPercentageRate float64       `json:"latestAnnualPercentageRate,string"`
+	CanPurchase                bool          `json:"canPurchase"`
+	CanRedeem                  bool          `json:"canRedeem"`
+	IsSoldOut                  bool          `json:"isSoldOut"`
+	Hot                        bool          `json:"hot"`
+	MinPurchaseAmount          float64       `json:"minPurchaseAmount,string"`
+	ProductID                  string        `json:"productId"`
+	SubscriptionStartTime      binanceTime   `json:"subscriptionStartTime"`
+	Status                     string        `json:"status"`
+}
+
+// SimpleEarnFlexibleProducts stores a page of flexible Simple Earn products
+type SimpleEarnFlexibleProducts struct {
+	Rows  []SimpleEarnFlexibleProduct `json:"rows"`
+	Total int64                       `json:"total"`
+}
+
+// SimpleEarnLockedProduct stores a locked Simple Earn project
+type SimpleEarnLockedProduct struct {
+	ProjectID string `json:"projectId"`
+	Detail    struct {
+		Asset                 currency.Code `json:"asset"`
+		RewardAsset           currency.Code `json:"rewardAsset"`
+		Duration              int64         `json:"duration"`
+		Renewable             bool          `json:"renewable"`
+		IsSoldOut             bool          `json:"isSoldOut"`
+		APR                   float64       `json:"apr,string"`
+		Status                string        `json:"status"`
+		SubscriptionStartTime binanceTime   `json:"subscriptionStartTime"`
+	} `json:"detail"`
+	Quota struct {
+		TotalPersonalQuota float64 `json:"totalPersonalQuota,string"`
+		Minimum            float64 `json:"minimum,string"`
+	} `json:"quota"`
+}
+
+// SimpleEarnLockedProducts stores a page of locked Simple Earn projects
+type SimpleEarnLockedProducts struct {
+	Rows  []SimpleEarnLockedProduct `json:"rows"`
+	Total int64                     `json:"total"`
+}
+
+// SimpleEarnSubscription stores the result of a Simple Earn subscription
+type SimpleEarnSubscription struct {
+	PurchaseID int64       `json:"purchaseId"`
+	PositionID json.Number `json:"positionId"`
+	Success    bool        `json:"success"`
+}
+
+// SimpleEarnRedemption stores the result of a Simple Earn redemption
+type SimpleEarnRedemption struct {
+	RedeemID int64 `json:"redeemId"`
+	Success  bool  `json:"success"`
+}
+
+// SimpleEarnFlexiblePosition stores a flexible Simple Earn position
+type SimpleEarnFlexiblePosition struct {
+	TotalAmount                float64       `json:"totalAmount,string"`
+	LatestAnnualPercentageRate float64       `json:"latestAnnualPercentageRate,string"`
+	Asset                      currency.Code `json:"asset"`
+	AirDropAsset               currency.Code `json:"airDropAsset"`
+	CanRedeem                  bool          `json:"canRedeem"`
+	CollateralAmount           float64       `json:"collateralAmount,string"`
+	ProductID                  string        `json:"productId"`
+	YesterdayRealTimeRewards   float64       `json:"yesterdayRealTimeRewards,string"`
+	CumulativeBonusRewards     float64       `json:"cumulativeBonusRewards,string"`
+	CumulativeRealTimeRewards  float64       `json:"cumulativeRealTimeRewards,string"`
+	CumulativeTotalRewards     float64       `json:"cumulativeTotalRewards,string"`
+	AutoSubscribe              bool          `json:"autoSubscribe"`
+}
+
+// SimpleEarnFlexiblePositions stores a page of flexible Simple Earn positions
+type SimpleEarnFlexiblePositions struct {
+	Rows  []SimpleEarnFlexiblePosition `json:"rows"`
+	Total int64                        `json:"total"`
+}
+
+// SimpleEarnLockedPosition stores a locked Simple Earn position
+type SimpleEarnLockedPosition struct {
+	PositionID     json.Number   `json:"positionId"`
+	ProjectID      string        `json:"projectId"`
+	Asset          currency.Code `json:"asset"`
+	Amount         float64       `json:"amount,string"`
+	PurchaseTime   binanceTime   `json:"purchaseTime"`
+	Duration       int64         `json:"duration,string"`
+	AccrualDays    int64         `json:"accrualDays,string"`
+	RewardAsset    currency.Code `json:"rewardAsset"`
+	APY            float64       `json:"APY,string"`
+	RewardAmount   float64       `json:"rewardAmt,string"`
+	NextPay        float64       `json:"nextPay,string"`
+	NextPayDate    binanceTime   `json:"nextPayDate"`
+	RewardsEndDate binanceTime   `json:"rewardsEndDate"`
+	DeliverDate    binanceTime   `json:"deliverDate"`
+	CanRedeemEarly bool          `json:"canRedeemEarly"`
+	AutoSubscribe  bool          `json:"autoSubscribe"`
+	Type           string        `json:"type"`
+	Status         string        `json:"status"`
+}
+
+// SimpleEarnLockedPositions stores a page of locked Simple Earn positions
+type SimpleEarnLockedPositions struct {
+	Rows  []SimpleEarnLockedPosition `json:"rows"`
+	Total int64                      `json:"total"`
+}
+
+// SimpleEarnFlexibleReward stores a flexible Simple Earn reward
+type SimpleEarnFlexibleReward struct {
+	Asset     currency.Code `json:"asset"`
+	Rewards   float64       `json:"rewards,string"`
+	ProjectID string        `json:"projectId"`
+	Type      string        `json:"type"`
+	Time      binanceTime   `json:"time"`
+}
+
+// SimpleEarnFlexibleRewards stores a page of flexible Simple Earn rewards
+type SimpleEarnFlexibleRewards struct {
+	Rows  []SimpleEarnFlexibleReward `json:"rows"`
+	Total int64                      `json:"total"`
+}
+
+// SimpleEarnLockedReward stores a locked Simple Earn reward
+type SimpleEarnLockedReward struct {
+	PositionID json.Number   `json:"positionId"`
+	Time       binanceTime   `json:"time"`
+	Asset      currency.Code `json:"asset"`
+	LockPeriod int64         `json:"lockPeriod,string"`
+	Amount     float64       `json:"amount,string"`
+}
+
+// SimpleEarnLockedRewards stores a page of locked Simple Earn rewards
+type SimpleEarnLockedRewards struct {
+	Rows  []SimpleEarnLockedReward `json:"rows"`
+	Total int64                    `json:"total"`
+}
@@ -689,6 +689,9 @@ func (b *Binance) UFuturesNewOrder(ctx context.Context, data *UFuturesNewOrderRe
 	if data.CallbackRate != 0 {
 		params.Set("callbackRate", strconv.FormatFloat(data.CallbackRate, 'f', -1, 64))
 	}
+	if !data.GoodTillDate.IsZero() {
+		params.Set("goodTillDate", strconv.FormatInt(data.GoodTillDate.UnixMilli(), 10))
+	}
 	return resp, b.SendAuthHTTPRequest(ctx, exchange.RestUSDTMargined, http.MethodPost, ufuturesOrder, params, uFuturesOrdersDefaultRate, &resp)
 }
 
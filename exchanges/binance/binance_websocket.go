@@ -48,7 +48,7 @@ var (
 )
 
 // WsConnect initiates a websocket connection
-func (b *Binance) WsConnect() error {
+func (b *Binance) WsConnect(ctx context.Context) error {
 	if !b.Websocket.IsEnabled() || !b.IsEnabled() {
 		return stream.ErrWebsocketNotEnabled
 	}
@@ -58,7 +58,7 @@ func (b *Binance) WsConnect() error {
 	dialer.Proxy = http.ProxyFromEnvironment
 	var err error
 	if b.Websocket.CanUseAuthenticatedEndpoints() {
-		listenKey, err = b.GetWsAuthStreamKey(context.TODO())
+		listenKey, err = b.GetWsAuthStreamKey(ctx)
 		if err != nil {
 			b.Websocket.SetCanUseAuthenticatedEndpoints(false)
 			log.Errorf(log.ExchangeSys,
@@ -84,7 +84,7 @@ func (b *Binance) WsConnect() error {
 	}
 
 	if b.Websocket.CanUseAuthenticatedEndpoints() {
-		go b.KeepAuthKeyAlive()
+		go b.KeepAuthKeyAlive(ctx)
 	}
 
 	b.Websocket.Conn.SetupPingHandler(stream.PingHandler{
@@ -100,6 +100,28 @@ func (b *Binance) WsConnect() error {
 	return nil
 }
 
+// wsConnectShard dials a connection which a share of the websocket's
+// subscriptions are sharded to
+func (b *Binance) wsConnectShard(_ context.Context, conn stream.Connection) error {
+	var dialer websocket.Dialer
+	dialer.HandshakeTimeout = b.Config.HTTPTimeout
+	dialer.Proxy = http.ProxyFromEnvironment
+	if err := conn.Dial(&dialer, http.Header{}); err != nil {
+		return fmt.Errorf("%v - Unable to connect to Websocket shard. Error: %w", b.Name, err)
+	}
+	conn.SetupPingHandler(stream.PingHandler{
+		UseGorillaHandler: true,
+		MessageType:       websocket.PongMessage,
+		Delay:             pingDelay,
+	})
+	return nil
+}
+
+// wsHandleShardData handles data read from a websocket shard
+func (b *Binance) wsHandleShardData(_ stream.Connection, respRaw []byte) error {
+	return b.wsHandleData(respRaw)
+}
+
 func (b *Binance) setupOrderbookManager() {
 	if b.obm == nil {
 		b.obm = &orderbookManager{
@@ -127,7 +149,7 @@ func (b *Binance) setupOrderbookManager() {
 
 // KeepAuthKeyAlive will continuously send messages to
 // keep the WS auth key active
-func (b *Binance) KeepAuthKeyAlive() {
+func (b *Binance) KeepAuthKeyAlive(ctx context.Context) {
 	b.Websocket.Wg.Add(1)
 	defer b.Websocket.Wg.Done()
 	ticks := time.NewTicker(time.Minute * 30)
@@ -137,7 +159,7 @@ func (b *Binance) KeepAuthKeyAlive() {
 			ticks.Stop()
 			return
 		case <-ticks.C:
-			err := b.MaintainWsAuthStreamKey(context.TODO())
+			err := b.MaintainWsAuthStreamKey(ctx)
 			if err != nil {
 				b.Websocket.DataHandler <- err
 				log.Warnf(log.ExchangeSys,
@@ -151,18 +173,29 @@ func (b *Binance) KeepAuthKeyAlive() {
 func (b *Binance) wsReadData() {
 	defer b.Websocket.Wg.Done()
 
+	processor := b.Websocket.NewMessageProcessor(b.wsHandleData)
+	defer processor.Close()
 	for {
 		resp := b.Websocket.Conn.ReadMessage()
 		if resp.Raw == nil {
 			return
 		}
-		err := b.wsHandleData(resp.Raw)
-		if err != nil {
-			b.Websocket.DataHandler <- err
-		}
+		processor.Process(wsMessageKey(resp.Raw), resp.Raw)
 	}
 }
 
+// wsMessageKey returns the instrument stream a message relates to so messages
+// for the same pair are processed in order, e.g. "btcusdt" for the stream
+// "btcusdt@depth@100ms". Messages without a stream share the empty key
+func wsMessageKey(respRaw []byte) string {
+	streamStr, err := jsonparser.GetString(respRaw, "stream")
+	if err != nil {
+		return ""
+	}
+	key, _, _ := strings.Cut(streamStr, "@")
+	return key
+}
+
 func (b *Binance) wsHandleData(respRaw []byte) error {
 	if id, err := jsonparser.GetInt(respRaw, "id"); err == nil {
 		if b.Websocket.Match.IncomingWithData(id, respRaw) {
@@ -321,6 +354,8 @@ func (b *Binance) wsHandleData(respRaw []byte) error {
 	if !isEnabled {
 		return nil
 	}
+	// streamStr is unsafe and references respRaw, so must be cloned before being retained
+	b.Websocket.RecordSubscriptionActivity(subscription.DefaultKey{Channel: strings.Clone(streamStr), Pair: pair, Asset: asset.Spot})
 	switch streamType[1] {
 	case "trade":
 		saveTradeData := b.IsSaveTradeDataEnabled()
@@ -504,26 +539,22 @@ func (b *Binance) UpdateLocalBuffer(wsdp *WebsocketDepthStream) (bool, error) {
 
 // GenerateSubscriptions generates the default subscription set
 func (b *Binance) GenerateSubscriptions() ([]subscription.Subscription, error) {
-	var channels = make([]string, 0, len(b.Features.Subscriptions))
-	for i := range b.Features.Subscriptions {
-		name, err := channelName(b.Features.Subscriptions[i])
-		if err != nil {
-			return nil, err
-		}
-		channels = append(channels, name)
-	}
 	var subscriptions []subscription.Subscription
 	pairs, err := b.GetEnabledPairs(asset.Spot)
 	if err != nil {
 		return nil, err
 	}
-	for y := range pairs {
-		for z := range channels {
-			lp := pairs[y].Lower()
+	for i := range b.Features.Subscriptions {
+		for _, s := range b.Features.Subscriptions[i].ExpandPairs(pairs) {
+			name, err := channelName(&s)
+			if err != nil {
+				return nil, err
+			}
+			lp := s.Pair.Lower()
 			lp.Delimiter = ""
 			subscriptions = append(subscriptions, subscription.Subscription{
-				Channel: lp.String() + "@" + channels[z],
-				Pair:    pairs[y],
+				Channel: lp.String() + "@" + name,
+				Pair:    s.Pair,
 				Asset:   asset.Spot,
 			})
 		}
@@ -555,14 +586,21 @@ func channelName(s *subscription.Subscription) (string, error) {
 }
 
 // Subscribe subscribes to a set of channels
-func (b *Binance) Subscribe(channels []subscription.Subscription) error {
-	return b.ParallelChanOp(channels, b.subscribeToChan, 50)
+func (b *Binance) Subscribe(ctx context.Context, channels []subscription.Subscription) error {
+	return b.subscribeOn(ctx, b.Websocket.Conn, channels)
+}
+
+// subscribeOn subscribes to a set of channels on a websocket connection
+func (b *Binance) subscribeOn(ctx context.Context, conn stream.Connection, channels []subscription.Subscription) error {
+	return b.ParallelChanOp(ctx, channels, func(ctx context.Context, chans []subscription.Subscription) error {
+		return b.subscribeToChan(ctx, conn, chans)
+	}, 50)
 }
 
 // subscribeToChan handles a single subscription and parses the result
 // on success it adds the subscription to the websocket
-func (b *Binance) subscribeToChan(chans []subscription.Subscription) error {
-	id := b.Websocket.Conn.GenerateMessageID(false)
+func (b *Binance) subscribeToChan(ctx context.Context, conn stream.Connection, chans []subscription.Subscription) error {
+	id := conn.GenerateMessageID(false)
 
 	cNames := make([]string, len(chans))
 	for i := range chans {
@@ -580,7 +618,7 @@ func (b *Binance) subscribeToChan(chans []subscription.Subscription) error {
 		ID:     id,
 	}
 
-	respRaw, err := b.Websocket.Conn.SendMessageReturnResponse(id, req)
+	respRaw, err := conn.SendMessageReturnResponseWithContext(ctx, id, req)
 	if err == nil {
 		if v, d, _, rErr := jsonparser.Get(respRaw, "result"); rErr != nil {
 			err = rErr
@@ -601,13 +639,20 @@ func (b *Binance) subscribeToChan(chans []subscription.Subscription) error {
 }
 
 // Unsubscribe unsubscribes from a set of channels
-func (b *Binance) Unsubscribe(channels []subscription.Subscription) error {
-	return b.ParallelChanOp(channels, b.unsubscribeFromChan, 50)
+func (b *Binance) Unsubscribe(ctx context.Context, channels []subscription.Subscription) error {
+	return b.unsubscribeOn(ctx, b.Websocket.Conn, channels)
+}
+
+// unsubscribeOn unsubscribes from a set of channels on a websocket connection
+func (b *Binance) unsubscribeOn(ctx context.Context, conn stream.Connection, channels []subscription.Subscription) error {
+	return b.ParallelChanOp(ctx, channels, func(ctx context.Context, chans []subscription.Subscription) error {
+		return b.unsubscribeFromChan(ctx, conn, chans)
+	}, 50)
 }
 
 // unsubscribeFromChan sends a websocket message to stop receiving data from a channel
-func (b *Binance) unsubscribeFromChan(chans []subscription.Subscription) error {
-	id := b.Websocket.Conn.GenerateMessageID(false)
+func (b *Binance) unsubscribeFromChan(ctx context.Context, conn stream.Connection, chans []subscription.Subscription) error {
+	id := conn.GenerateMessageID(false)
 
 	cNames := make([]string, len(chans))
 	for i := range chans {
@@ -620,7 +665,7 @@ func (b *Binance) unsubscribeFromChan(chans []subscription.Subscription) error {
 		ID:     id,
 	}
 
-	respRaw, err := b.Websocket.Conn.SendMessageReturnResponse(id, req)
+	respRaw, err := conn.SendMessageReturnResponseWithContext(ctx, id, req)
 	if err == nil {
 		if v, d, _, rErr := jsonparser.Get(respRaw, "result"); rErr != nil {
 			err = rErr
@@ -639,6 +684,37 @@ func (b *Binance) unsubscribeFromChan(chans []subscription.Subscription) error {
 	return nil
 }
 
+// ListSubscriptions returns the websocket subscriptions which the exchange
+// reports as active on the connection
+func (b *Binance) ListSubscriptions(ctx context.Context) ([]subscription.Subscription, error) {
+	id := b.Websocket.Conn.GenerateMessageID(false)
+	respRaw, err := b.Websocket.Conn.SendMessageReturnResponseWithContext(ctx, id, WsPayload{
+		Method: wsListSubscriptionsMethod,
+		ID:     id,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Result []string `json:"result"`
+	}
+	if err = json.Unmarshal(respRaw, &resp); err != nil {
+		return nil, err
+	}
+	active := make(map[string]struct{}, len(resp.Result))
+	for i := range resp.Result {
+		active[resp.Result[i]] = struct{}{}
+	}
+	subs := b.Websocket.GetSubscriptions()
+	reported := subs[:0]
+	for i := range subs {
+		if _, ok := active[subs[i].Channel]; ok {
+			reported = append(reported, subs[i])
+		}
+	}
+	return reported, nil
+}
+
 // ProcessUpdate processes the websocket orderbook update
 func (b *Binance) ProcessUpdate(cp currency.Pair, a asset.Item, ws *WebsocketDepthStream) error {
 	updateBid := make([]orderbook.Item, len(ws.UpdateBids))
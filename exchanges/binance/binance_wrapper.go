@@ -18,7 +18,9 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/collateral"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/conversion"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/deposit"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/earn"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/fundingrate"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
@@ -32,6 +34,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/subscription"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/transfer"
 	"github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
 )
@@ -144,6 +147,14 @@ func (b *Binance) SetDefaults() {
 			},
 			WithdrawPermissions: exchange.AutoWithdrawCrypto |
 				exchange.NoFiatWithdrawals,
+			SelfTradePrevention: map[asset.Item]order.SelfTradePrevention{
+				asset.Spot:   supportedSTPModes,
+				asset.Margin: supportedSTPModes,
+			},
+			IcebergOrders: map[asset.Item]bool{
+				asset.Spot:   true,
+				asset.Margin: true,
+			},
 			Kline: kline.ExchangeCapabilitiesSupported{
 				DateRanges: true,
 				Intervals:  true,
@@ -246,7 +257,15 @@ func (b *Binance) Setup(exch *config.Exchange) error {
 		Subscriber:            b.Subscribe,
 		Unsubscriber:          b.Unsubscribe,
 		GenerateSubscriptions: b.GenerateSubscriptions,
-		Features:              &b.Features.Supports.WebsocketCapabilities,
+		SubscriptionLister:    b.ListSubscriptions,
+		ShardConnector:        b.wsConnectShard,
+		ShardSubscriber:       b.subscribeOn,
+		ShardUnsubscriber:     b.unsubscribeOn,
+		ShardHandler:          b.wsHandleShardData,
+		// Shards carry market data only, the user data stream listen key
+		// remains on the primary connection
+		ShardURL: ePoint,
+		Features: &b.Features.Supports.WebsocketCapabilities,
 		OrderbookBufferConfig: buffer.Config{
 			SortBuffer:            true,
 			SortBufferByUpdateIDs: true,
@@ -754,6 +773,7 @@ func (b *Binance) GetWithdrawalsHistory(ctx context.Context, c currency.Code, _
 		resp[i] = exchange.WithdrawalHistory{
 			Status:          strconv.FormatInt(withdrawals[i].Status, 10),
 			TransferID:      withdrawals[i].ID,
+			ClientID:        withdrawals[i].WithdrawOrderID,
 			Currency:        withdrawals[i].Coin,
 			Amount:          withdrawals[i].Amount,
 			Fee:             withdrawals[i].TransactionFee,
@@ -880,9 +900,35 @@ func (b *Binance) GetHistoricTrades(ctx context.Context, p currency.Pair, a asse
 	return result, nil
 }
 
+// selfTradePreventionMode returns the spot self trade prevention mode for an
+// order submission, leaving it empty for the account default when unset
+func selfTradePreventionMode(stp order.SelfTradePrevention) string {
+	switch stp {
+	case order.STPCancelMaker:
+		return "EXPIRE_MAKER"
+	case order.STPCancelTaker:
+		return "EXPIRE_TAKER"
+	case order.STPCancelBoth:
+		return "EXPIRE_BOTH"
+	default:
+		return ""
+	}
+}
+
 // SubmitOrder submits a new order
 func (b *Binance) SubmitOrder(ctx context.Context, s *order.Submit) (*order.SubmitResponse, error) {
-	if err := s.Validate(); err != nil {
+	supportedTIF := order.GTC | order.IOC | order.FOK
+	if s != nil && s.AssetType == asset.USDTMarginedFutures {
+		supportedTIF |= order.GTD
+	}
+	var supportedSTP order.SelfTradePrevention
+	var supportsIceberg bool
+	if s != nil && (s.AssetType == asset.Spot || s.AssetType == asset.Margin) {
+		supportedSTP = supportedSTPModes
+		// iceberg orders must rest on the book so are GTC only
+		supportsIceberg = s.TimeInForce != order.IOC && s.TimeInForce != order.FOK
+	}
+	if err := s.Validate(s.TimeInForceSupported(supportedTIF, true), s.SelfTradePreventionSupported(supportedSTP), s.DisplayAmountSupported(supportsIceberg)); err != nil {
 		return nil, err
 	}
 	var orderID string
@@ -906,22 +952,33 @@ func (b *Binance) SubmitOrder(ctx context.Context, s *order.Submit) (*order.Subm
 			timeInForce = ""
 			requestParamsOrderType = BinanceRequestParamsOrderMarket
 		case order.Limit:
-			if s.ImmediateOrCancel {
+			switch {
+			case s.PostOnly:
+				timeInForce = ""
+				requestParamsOrderType = BinanceRequestParamsOrderLimitMarker
+			case s.TimeInForce == order.IOC:
 				timeInForce = BinanceRequestParamsTimeIOC
+				requestParamsOrderType = BinanceRequestParamsOrderLimit
+			case s.TimeInForce == order.FOK:
+				timeInForce = BinanceRequestParamsTimeFOK
+				requestParamsOrderType = BinanceRequestParamsOrderLimit
+			default:
+				requestParamsOrderType = BinanceRequestParamsOrderLimit
 			}
-			requestParamsOrderType = BinanceRequestParamsOrderLimit
 		default:
 			return nil, fmt.Errorf("%w %v", order.ErrUnsupportedOrderType, s.Type)
 		}
 
 		var orderRequest = NewOrderRequest{
-			Symbol:           s.Pair,
-			Side:             sideType,
-			Price:            s.Price,
-			Quantity:         s.Amount,
-			TradeType:        requestParamsOrderType,
-			TimeInForce:      timeInForce,
-			NewClientOrderID: s.ClientOrderID,
+			Symbol:                  s.Pair,
+			Side:                    sideType,
+			Price:                   s.Price,
+			Quantity:                s.Amount,
+			TradeType:               requestParamsOrderType,
+			TimeInForce:             timeInForce,
+			NewClientOrderID:        s.ClientOrderID,
+			IcebergQty:              s.DisplayAmount,
+			SelfTradePreventionMode: selfTradePreventionMode(s.SelfTradePrevention),
 		}
 		response, err := b.NewOrder(ctx, &orderRequest)
 		if err != nil {
@@ -943,39 +1000,17 @@ func (b *Binance) SubmitOrder(ctx context.Context, s *order.Submit) (*order.Subm
 			}
 		}
 	case asset.CoinMarginedFutures:
-		var reqSide string
-		switch s.Side {
-		case order.Buy:
-			reqSide = "BUY"
-		case order.Sell:
-			reqSide = "SELL"
-		default:
-			return nil, errors.New("invalid side")
+		reqSide, err := futuresOrderSide(s.Side)
+		if err != nil {
+			return nil, err
 		}
-
-		var (
-			oType       string
-			timeInForce RequestParamsTimeForceType
-		)
-
-		switch s.Type {
-		case order.Limit:
-			oType = cfuturesLimit
-			timeInForce = BinanceRequestParamsTimeGTC
-		case order.Market:
-			oType = cfuturesMarket
-		case order.Stop:
-			oType = cfuturesStop
-		case order.TakeProfit:
-			oType = cfuturesTakeProfit
-		case order.StopMarket:
-			oType = cfuturesStopMarket
-		case order.TakeProfitMarket:
-			oType = cfuturesTakeProfitMarket
-		case order.TrailingStop:
-			oType = cfuturesTrailingStopMarket
-		default:
-			return nil, errors.New("invalid type, check api docs for updates")
+		oType, err := futuresOrderType(s.Type)
+		if err != nil {
+			return nil, err
+		}
+		var timeInForce RequestParamsTimeForceType
+		if s.Type == order.Limit {
+			timeInForce = futuresTimeInForce(s)
 		}
 
 		o, err := b.FuturesNewOrder(
@@ -996,44 +1031,25 @@ func (b *Binance) SubmitOrder(ctx context.Context, s *order.Submit) (*order.Subm
 		}
 		orderID = strconv.FormatInt(o.OrderID, 10)
 	case asset.USDTMarginedFutures:
-		var reqSide string
-		switch s.Side {
-		case order.Buy:
-			reqSide = "BUY"
-		case order.Sell:
-			reqSide = "SELL"
-		default:
-			return nil, errors.New("invalid side")
+		reqSide, err := futuresOrderSide(s.Side)
+		if err != nil {
+			return nil, err
 		}
-		var oType string
-		switch s.Type {
-		case order.Limit:
-			oType = "LIMIT"
-		case order.Market:
-			oType = "MARKET"
-		case order.Stop:
-			oType = "STOP"
-		case order.TakeProfit:
-			oType = "TAKE_PROFIT"
-		case order.StopMarket:
-			oType = "STOP_MARKET"
-		case order.TakeProfitMarket:
-			oType = "TAKE_PROFIT_MARKET"
-		case order.TrailingStop:
-			oType = "TRAILING_STOP_MARKET"
-		default:
-			return nil, errors.New("invalid type, check api docs for updates")
+		oType, err := futuresOrderType(s.Type)
+		if err != nil {
+			return nil, err
 		}
 		o, err := b.UFuturesNewOrder(ctx,
 			&UFuturesNewOrderRequest{
 				Symbol:           s.Pair,
 				Side:             reqSide,
 				OrderType:        oType,
-				TimeInForce:      "GTC",
+				TimeInForce:      string(futuresTimeInForce(s)),
 				NewClientOrderID: s.ClientOrderID,
 				Quantity:         s.Amount,
 				Price:            s.Price,
 				ReduceOnly:       s.ReduceOnly,
+				GoodTillDate:     s.Expiry,
 			},
 		)
 		if err != nil {
@@ -1053,6 +1069,207 @@ func (b *Binance) SubmitOrder(ctx context.Context, s *order.Submit) (*order.Subm
 	return resp, nil
 }
 
+// SubmitOCOOrder submits a one-cancels-the-other spot order list. The limit leg
+// is placed as a limit maker order and the stop leg as a stop loss order, or a
+// stop loss limit order when a stop limit price is set
+func (b *Binance) SubmitOCOOrder(ctx context.Context, o *order.OCOSubmit) (*order.OCOSubmitResponse, error) {
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+	if o.AssetType != asset.Spot {
+		return nil, fmt.Errorf("%w %v", asset.ErrNotSupported, o.AssetType)
+	}
+	limit, stop := o.Legs()
+	side := order.Sell.String()
+	if o.Side.IsLong() {
+		side = order.Buy.String()
+	}
+	resp, err := b.NewOCOOrder(ctx, &NewOCOOrderRequest{
+		Symbol:             o.Pair,
+		Side:               side,
+		Quantity:           o.Amount,
+		Price:              o.Price,
+		StopPrice:          o.TriggerPrice,
+		StopLimitPrice:     o.StopLimitPrice,
+		ListClientOrderID:  o.ClientOrderID,
+		LimitClientOrderID: limit.ClientOrderID,
+		StopClientOrderID:  stop.ClientOrderID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := &order.OCOSubmitResponse{ListID: strconv.FormatInt(resp.OrderListID, 10)}
+	for i := range resp.OrderReports {
+		report := &resp.OrderReports[i]
+		leg := stop
+		if report.Type == "LIMIT_MAKER" {
+			leg = limit
+		}
+		legResp, err := leg.DeriveSubmitResponse(strconv.FormatInt(report.OrderID, 10))
+		if err != nil {
+			return nil, err
+		}
+		if report.OrigQty > 0 && report.ExecutedQty == report.OrigQty {
+			legResp.Status = order.Filled
+		}
+		if leg == limit {
+			result.Limit = legResp
+		} else {
+			result.Stop = legResp
+		}
+	}
+	if result.Limit == nil || result.Stop == nil {
+		return nil, fmt.Errorf("%w: order list %v is missing legs", order.ErrUnableToPlaceOrder, resp.OrderListID)
+	}
+	return result, nil
+}
+
+// SubmitOrders submits up to five USDT or coin margined futures orders of the
+// same asset type in a single batch request. Good till date orders are not
+// supported by batch requests
+func (b *Binance) SubmitOrders(ctx context.Context, s []order.Submit) ([]*order.SubmitResponse, error) {
+	if len(s) == 0 {
+		return nil, fmt.Errorf("%w, must have at least 1 order", order.ErrSubmissionIsNil)
+	}
+	a := s[0].AssetType
+	if a != asset.USDTMarginedFutures && a != asset.CoinMarginedFutures {
+		return nil, fmt.Errorf("%w for %s batch orders", common.ErrFunctionNotSupported, a)
+	}
+	if len(s) > maxFuturesBatchOrders {
+		return nil, fmt.Errorf("%w, cannot submit more than %d orders", errBatchOrderLimitExceeded, maxFuturesBatchOrders)
+	}
+	data := make([]PlaceBatchOrderData, len(s))
+	for i := range s {
+		if s[i].AssetType != a {
+			return nil, fmt.Errorf("%w for batch orders with mixed asset types", common.ErrFunctionNotSupported)
+		}
+		if s[i].TimeInForce == order.GTD {
+			return nil, fmt.Errorf("%w for batch orders good till date", common.ErrFunctionNotSupported)
+		}
+		if err := s[i].Validate(s[i].TimeInForceSupported(order.GTC|order.IOC|order.FOK, true), s[i].SelfTradePreventionSupported(order.UnsetSelfTradePrevention), s[i].DisplayAmountSupported(false)); err != nil {
+			return nil, err
+		}
+		if s[i].Leverage != 0 && s[i].Leverage != 1 {
+			return nil, fmt.Errorf("%w received '%v'", order.ErrSubmitLeverageNotSupported, s[i].Leverage)
+		}
+		reqSide, err := futuresOrderSide(s[i].Side)
+		if err != nil {
+			return nil, err
+		}
+		oType, err := futuresOrderType(s[i].Type)
+		if err != nil {
+			return nil, err
+		}
+		data[i] = PlaceBatchOrderData{
+			Symbol:           s[i].Pair.String(),
+			Side:             reqSide,
+			OrderType:        oType,
+			Quantity:         s[i].Amount,
+			Price:            s[i].Price,
+			NewClientOrderID: s[i].ClientOrderID,
+		}
+		if s[i].Type == order.Limit {
+			data[i].TimeInForce = string(futuresTimeInForce(&s[i]))
+		}
+		if s[i].ReduceOnly {
+			data[i].ReduceOnly = "true"
+		}
+	}
+	orderIDs := make([]int64, len(s))
+	errs := make([]error, len(s))
+	if a == asset.USDTMarginedFutures {
+		placed, err := b.UPlaceBatchOrders(ctx, data)
+		if err != nil {
+			return nil, err
+		}
+		if len(placed) != len(s) {
+			return nil, fmt.Errorf("%w, expected %d order responses, received %d", errBatchOrderResponseMismatch, len(s), len(placed))
+		}
+		for i := range placed {
+			orderIDs[i] = placed[i].OrderID
+			if placed[i].Code != 0 {
+				errs[i] = fmt.Errorf("code: %d message: %s", placed[i].Code, placed[i].Message)
+			}
+		}
+	} else {
+		placed, err := b.FuturesBatchOrder(ctx, data)
+		if err != nil {
+			return nil, err
+		}
+		if len(placed) != len(s) {
+			return nil, fmt.Errorf("%w, expected %d order responses, received %d", errBatchOrderResponseMismatch, len(s), len(placed))
+		}
+		for i := range placed {
+			orderIDs[i] = placed[i].OrderID
+			if placed[i].Code != 0 {
+				errs[i] = fmt.Errorf("code: %d message: %s", placed[i].Code, placed[i].Message)
+			}
+		}
+	}
+	resp := make([]*order.SubmitResponse, len(s))
+	var err error
+	for i := range s {
+		if errs[i] == nil {
+			resp[i], errs[i] = s[i].DeriveSubmitResponse(strconv.FormatInt(orderIDs[i], 10))
+		}
+		if errs[i] != nil {
+			err = common.AppendError(err, fmt.Errorf("order %d: %w", i, errs[i]))
+		}
+	}
+	return resp, err
+}
+
+// futuresOrderSide returns the futures order side for an order side
+func futuresOrderSide(side order.Side) (string, error) {
+	switch side {
+	case order.Buy:
+		return "BUY", nil
+	case order.Sell:
+		return "SELL", nil
+	default:
+		return "", errors.New("invalid side")
+	}
+}
+
+// futuresOrderType returns the futures order type for an order type
+func futuresOrderType(t order.Type) (string, error) {
+	switch t {
+	case order.Limit:
+		return cfuturesLimit, nil
+	case order.Market:
+		return cfuturesMarket, nil
+	case order.Stop:
+		return cfuturesStop, nil
+	case order.TakeProfit:
+		return cfuturesTakeProfit, nil
+	case order.StopMarket:
+		return cfuturesStopMarket, nil
+	case order.TakeProfitMarket:
+		return cfuturesTakeProfitMarket, nil
+	case order.TrailingStop:
+		return cfuturesTrailingStopMarket, nil
+	default:
+		return "", errors.New("invalid type, check api docs for updates")
+	}
+}
+
+// futuresTimeInForce returns the futures time in force for an order
+// submission, defaulting to good till cancelled
+func futuresTimeInForce(s *order.Submit) RequestParamsTimeForceType {
+	switch {
+	case s.PostOnly:
+		return BinanceRequestParamsTimeGTX
+	case s.TimeInForce == order.IOC:
+		return BinanceRequestParamsTimeIOC
+	case s.TimeInForce == order.FOK:
+		return BinanceRequestParamsTimeFOK
+	case s.TimeInForce == order.GTD:
+		return BinanceRequestParamsTimeGTD
+	default:
+		return BinanceRequestParamsTimeGTC
+	}
+}
+
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (b *Binance) ModifyOrder(_ context.Context, _ *order.Modify) (*order.ModifyResponse, error) {
@@ -1091,9 +1308,63 @@ func (b *Binance) CancelOrder(ctx context.Context, o *order.Cancel) error {
 	return nil
 }
 
-// CancelBatchOrders cancels an orders by their corresponding ID numbers
-func (b *Binance) CancelBatchOrders(_ context.Context, _ []order.Cancel) (*order.CancelBatchResponse, error) {
-	return nil, common.ErrFunctionNotSupported
+// CancelBatchOrders cancels up to ten USDT or coin margined futures orders of
+// the same asset type and pair by their order IDs in a single batch request
+func (b *Binance) CancelBatchOrders(ctx context.Context, o []order.Cancel) (*order.CancelBatchResponse, error) {
+	if len(o) == 0 {
+		return nil, order.ErrCancelOrderIsNil
+	}
+	a, pair := o[0].AssetType, o[0].Pair
+	if a != asset.USDTMarginedFutures && a != asset.CoinMarginedFutures {
+		return nil, fmt.Errorf("%w for %s batch cancellations", common.ErrFunctionNotSupported, a)
+	}
+	if len(o) > maxFuturesBatchCancels {
+		return nil, fmt.Errorf("%w, cannot cancel more than %d orders", errBatchOrderLimitExceeded, maxFuturesBatchCancels)
+	}
+	orderIDs := make([]string, len(o))
+	for i := range o {
+		if o[i].AssetType != a || !o[i].Pair.Equal(pair) {
+			return nil, fmt.Errorf("%w for batch cancellations with mixed asset types or pairs", common.ErrFunctionNotSupported)
+		}
+		if o[i].OrderID == "" {
+			return nil, fmt.Errorf("%w for batch cancellations without order IDs", common.ErrFunctionNotSupported)
+		}
+		orderIDs[i] = o[i].OrderID
+	}
+	resp := &order.CancelBatchResponse{Status: make(map[string]string, len(o))}
+	var errs error
+	setStatus := func(i int, code int64, msg string) {
+		if code != 0 {
+			resp.Status[orderIDs[i]] = msg
+			errs = common.AppendError(errs, fmt.Errorf("order %s code: %d message: %s", orderIDs[i], code, msg))
+			return
+		}
+		resp.Status[orderIDs[i]] = order.Cancelled.String()
+	}
+	if a == asset.USDTMarginedFutures {
+		cancelled, err := b.UCancelBatchOrders(ctx, pair, orderIDs, nil)
+		if err != nil {
+			return nil, err
+		}
+		if len(cancelled) != len(o) {
+			return nil, fmt.Errorf("%w, expected %d cancel responses, received %d", errBatchOrderResponseMismatch, len(o), len(cancelled))
+		}
+		for i := range cancelled {
+			setStatus(i, cancelled[i].Code, cancelled[i].Message)
+		}
+		return resp, errs
+	}
+	cancelled, err := b.FuturesBatchCancelOrders(ctx, pair, orderIDs, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(cancelled) != len(o) {
+		return nil, fmt.Errorf("%w, expected %d cancel responses, received %d", errBatchOrderResponseMismatch, len(o), len(cancelled))
+	}
+	for i := range cancelled {
+		setStatus(i, cancelled[i].Code, cancelled[i].Msg)
+	}
+	return resp, errs
 }
 
 // CancelAllOrders cancels all orders associated with a currency pair
@@ -1296,7 +1567,7 @@ func (b *Binance) WithdrawCryptocurrencyFunds(ctx context.Context, withdrawReque
 	amountStr := strconv.FormatFloat(withdrawRequest.Amount, 'f', -1, 64)
 	v, err := b.WithdrawCrypto(ctx,
 		withdrawRequest.Currency.String(),
-		"", // withdrawal order ID
+		withdrawRequest.ClientOrderID,
 		withdrawRequest.Crypto.Chain,
 		withdrawRequest.Crypto.Address,
 		withdrawRequest.Crypto.AddressTag,
@@ -1914,6 +2185,331 @@ func (b *Binance) GetAvailableTransferChains(ctx context.Context, cryptocurrency
 	return availableChains, nil
 }
 
+// GetTransferNetworks returns the normalised transfer networks of a currency,
+// including each network's withdrawal fee and minimums
+func (b *Binance) GetTransferNetworks(ctx context.Context, cryptocurrency currency.Code) ([]transfer.Chain, error) {
+	coinInfo, err := b.GetAllCoinsInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var chains []transfer.Chain
+	for x := range coinInfo {
+		if !strings.EqualFold(coinInfo[x].Coin, cryptocurrency.String()) {
+			continue
+		}
+		for y := range coinInfo[x].NetworkList {
+			n := &coinInfo[x].NetworkList[y]
+			chains = append(chains, transfer.Chain{
+				Network:         transfer.NormaliseNetwork(n.Network),
+				Name:            n.Network,
+				DepositEnabled:  n.DepositEnable,
+				WithdrawEnabled: n.WithdrawEnable,
+				WithdrawFee:     n.WithdrawFee,
+				MinWithdrawal:   n.WithdrawMinimum,
+			})
+		}
+	}
+	return chains, nil
+}
+
+// GetConversionQuote requests a quote from Binance Convert, which must be
+// accepted with AcceptConversionQuote before it expires
+func (b *Binance) GetConversionQuote(ctx context.Context, req *conversion.QuoteRequest) (*conversion.Quote, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	q, err := b.GetConvertQuote(ctx, req.From, req.To, req.Amount, 0)
+	if err != nil {
+		return nil, err
+	}
+	return &conversion.Quote{
+		Exchange:   b.Name,
+		ID:         q.QuoteID,
+		From:       req.From,
+		To:         req.To,
+		FromAmount: q.FromAmount,
+		ToAmount:   q.ToAmount,
+		Rate:       q.Ratio,
+		Expires:    q.ValidTimestamp.Time(),
+	}, nil
+}
+
+// AcceptConversionQuote accepts a quote returned by GetConversionQuote
+func (b *Binance) AcceptConversionQuote(ctx context.Context, q *conversion.Quote) (*conversion.Result, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+	resp, err := b.AcceptConvertQuote(ctx, q.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &conversion.Result{
+		Exchange:   b.Name,
+		QuoteID:    q.ID,
+		OrderID:    resp.OrderID,
+		From:       q.From,
+		To:         q.To,
+		FromAmount: q.FromAmount,
+		ToAmount:   q.ToAmount,
+		Status:     resp.OrderStatus,
+		Time:       resp.CreateTime.Time(),
+	}, nil
+}
+
+// GetDustBalances returns balances which can be converted into BNB
+func (b *Binance) GetDustBalances(ctx context.Context) ([]conversion.DustBalance, error) {
+	resp, err := b.GetDustAssets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	balances := make([]conversion.DustBalance, len(resp.Details))
+	for i := range resp.Details {
+		balances[i] = conversion.DustBalance{
+			Currency: resp.Details[i].Asset,
+			Amount:   resp.Details[i].AmountFree,
+			Targets:  []currency.Code{currency.BNB},
+			Estimate: resp.Details[i].ToBNB,
+		}
+	}
+	return balances, nil
+}
+
+// ConvertDust converts dust balances into BNB, which is the only target
+// Binance supports
+func (b *Binance) ConvertDust(ctx context.Context, req *conversion.DustRequest) (*conversion.DustResult, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if !req.To.IsEmpty() && !req.To.Equal(currency.BNB) {
+		return nil, fmt.Errorf("%w: %s", conversion.ErrUnsupportedDustTarget, req.To)
+	}
+	resp, err := b.DustTransfer(ctx, req.Currencies)
+	if err != nil {
+		return nil, err
+	}
+	result := &conversion.DustResult{
+		Exchange:    b.Name,
+		To:          currency.BNB,
+		Conversions: make([]conversion.DustConversion, len(resp.TransferResult)),
+		Total:       resp.TotalTransfered,
+		Fee:         resp.TotalServiceCharge,
+	}
+	for i := range resp.TransferResult {
+		result.Conversions[i] = conversion.DustConversion{
+			Currency:  resp.TransferResult[i].FromAsset,
+			Amount:    resp.TransferResult[i].Amount,
+			Converted: resp.TransferResult[i].TransferedAmount,
+			Fee:       resp.TransferResult[i].ServiceChargeAmount,
+		}
+		if t := resp.TransferResult[i].OperateTime.Time(); t.After(result.Time) {
+			result.Time = t
+		}
+	}
+	return result, nil
+}
+
+// simpleEarnPageSize is the maximum page size of the Simple Earn list endpoints
+const simpleEarnPageSize = 100
+
+// GetEarnProducts returns the flexible and locked Simple Earn products for a
+// currency, or all products when the currency is empty
+func (b *Binance) GetEarnProducts(ctx context.Context, c currency.Code) ([]earn.Product, error) {
+	var products []earn.Product
+	for page, fetched := int64(1), int64(0); ; page++ {
+		resp, err := b.GetSimpleEarnFlexibleProducts(ctx, c, page, simpleEarnPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for i := range resp.Rows {
+			products = append(products, earn.Product{
+				Exchange:  b.Name,
+				ID:        resp.Rows[i].ProductID,
+				Currency:  resp.Rows[i].Asset,
+				Type:      earn.Flexible,
+				APR:       resp.Rows[i].LatestAnnualPercentageRate,
+				MinAmount: resp.Rows[i].MinPurchaseAmount,
+				Available: resp.Rows[i].CanPurchase && !resp.Rows[i].IsSoldOut,
+			})
+		}
+		if fetched += int64(len(resp.Rows)); len(resp.Rows) == 0 || fetched >= resp.Total {
+			break
+		}
+	}
+	for page, fetched := int64(1), int64(0); ; page++ {
+		resp, err := b.GetSimpleEarnLockedProducts(ctx, c, page, simpleEarnPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for i := range resp.Rows {
+			products = append(products, earn.Product{
+				Exchange:  b.Name,
+				ID:        resp.Rows[i].ProjectID,
+				Currency:  resp.Rows[i].Detail.Asset,
+				Type:      earn.Locked,
+				APR:       resp.Rows[i].Detail.APR,
+				Duration:  time.Duration(resp.Rows[i].Detail.Duration) * kline.OneDay.Duration(),
+				MinAmount: resp.Rows[i].Quota.Minimum,
+				Available: !resp.Rows[i].Detail.IsSoldOut,
+			})
+		}
+		if fetched += int64(len(resp.Rows)); len(resp.Rows) == 0 || fetched >= resp.Total {
+			break
+		}
+	}
+	return products, nil
+}
+
+// GetEarnPositions returns the amounts held in flexible and locked Simple Earn
+// products
+func (b *Binance) GetEarnPositions(ctx context.Context) ([]earn.Position, error) {
+	var positions []earn.Position
+	for page, fetched := int64(1), int64(0); ; page++ {
+		resp, err := b.GetSimpleEarnFlexiblePositions(ctx, currency.EMPTYCODE, page, simpleEarnPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for i := range resp.Rows {
+			positions = append(positions, earn.Position{
+				Exchange:  b.Name,
+				ProductID: resp.Rows[i].ProductID,
+				Currency:  resp.Rows[i].Asset,
+				Type:      earn.Flexible,
+				Amount:    resp.Rows[i].TotalAmount,
+				APR:       resp.Rows[i].LatestAnnualPercentageRate,
+			})
+		}
+		if fetched += int64(len(resp.Rows)); len(resp.Rows) == 0 || fetched >= resp.Total {
+			break
+		}
+	}
+	for page, fetched := int64(1), int64(0); ; page++ {
+		resp, err := b.GetSimpleEarnLockedPositions(ctx, currency.EMPTYCODE, page, simpleEarnPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for i := range resp.Rows {
+			positions = append(positions, earn.Position{
+				Exchange:       b.Name,
+				ProductID:      resp.Rows[i].ProjectID,
+				PositionID:     resp.Rows[i].PositionID.String(),
+				Currency:       resp.Rows[i].Asset,
+				Type:           earn.Locked,
+				Amount:         resp.Rows[i].Amount,
+				AccruedRewards: resp.Rows[i].RewardAmount,
+				APR:            resp.Rows[i].APY,
+				Matures:        resp.Rows[i].DeliverDate.Time(),
+			})
+		}
+		if fetched += int64(len(resp.Rows)); len(resp.Rows) == 0 || fetched >= resp.Total {
+			break
+		}
+	}
+	return positions, nil
+}
+
+// SubscribeEarnProduct subscribes an amount to a flexible Simple Earn product
+// or locked Simple Earn project
+func (b *Binance) SubscribeEarnProduct(ctx context.Context, req *earn.SubscribeRequest) (*earn.Result, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var resp *SimpleEarnSubscription
+	var err error
+	if req.Type == earn.Locked {
+		resp, err = b.SubscribeSimpleEarnLocked(ctx, req.ProductID, req.Amount)
+	} else {
+		resp, err = b.SubscribeSimpleEarnFlexible(ctx, req.ProductID, req.Amount)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &earn.Result{
+		Exchange:  b.Name,
+		ProductID: req.ProductID,
+		ID:        strconv.FormatInt(resp.PurchaseID, 10),
+		Currency:  req.Currency,
+		Amount:    req.Amount,
+		Success:   resp.Success,
+		Time:      time.Now(),
+	}, nil
+}
+
+// RedeemEarnProduct redeems an amount from a flexible Simple Earn product or
+// the full amount of a locked Simple Earn position
+func (b *Binance) RedeemEarnProduct(ctx context.Context, req *earn.RedeemRequest) (*earn.Result, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	var resp *SimpleEarnRedemption
+	var err error
+	if req.Type == earn.Locked {
+		if !req.All {
+			return nil, fmt.Errorf("%w: locked positions can only be redeemed in full", errRedeemAmountOrAllMustBeSet)
+		}
+		resp, err = b.RedeemSimpleEarnLocked(ctx, req.PositionID)
+	} else {
+		resp, err = b.RedeemSimpleEarnFlexible(ctx, req.ProductID, req.Amount, req.All)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &earn.Result{
+		Exchange:  b.Name,
+		ProductID: req.ProductID,
+		ID:        strconv.FormatInt(resp.RedeemID, 10),
+		Currency:  req.Currency,
+		Amount:    req.Amount,
+		Success:   resp.Success,
+		Time:      time.Now(),
+	}, nil
+}
+
+// GetEarnRewards returns the rewards paid out by flexible and locked Simple
+// Earn products between the start and end times
+func (b *Binance) GetEarnRewards(ctx context.Context, start, end time.Time) ([]earn.Reward, error) {
+	if err := common.StartEndTimeCheck(start, end); err != nil {
+		return nil, err
+	}
+	var rewards []earn.Reward
+	for page, fetched := int64(1), int64(0); ; page++ {
+		resp, err := b.GetSimpleEarnFlexibleRewards(ctx, currency.EMPTYCODE, "ALL", start, end, page, simpleEarnPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for i := range resp.Rows {
+			rewards = append(rewards, earn.Reward{
+				Exchange:  b.Name,
+				ProductID: resp.Rows[i].ProjectID,
+				Currency:  resp.Rows[i].Asset,
+				Amount:    resp.Rows[i].Rewards,
+				Time:      resp.Rows[i].Time.Time(),
+			})
+		}
+		if fetched += int64(len(resp.Rows)); len(resp.Rows) == 0 || fetched >= resp.Total {
+			break
+		}
+	}
+	for page, fetched := int64(1), int64(0); ; page++ {
+		resp, err := b.GetSimpleEarnLockedRewards(ctx, currency.EMPTYCODE, start, end, page, simpleEarnPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for i := range resp.Rows {
+			rewards = append(rewards, earn.Reward{
+				Exchange:  b.Name,
+				ProductID: resp.Rows[i].PositionID.String(),
+				Currency:  resp.Rows[i].Asset,
+				Amount:    resp.Rows[i].Amount,
+				Time:      resp.Rows[i].Time.Time(),
+			})
+		}
+		if fetched += int64(len(resp.Rows)); len(resp.Rows) == 0 || fetched >= resp.Total {
+			break
+		}
+	}
+	return rewards, nil
+}
+
 // FormatExchangeCurrency is a method that formats and returns a currency pair
 // based on the user currency display preferences
 // overrides default implementation to use optional delimiter
@@ -2342,6 +2938,38 @@ func (b *Binance) SetMarginType(ctx context.Context, item asset.Item, pair curre
 	return nil
 }
 
+// GetMarginType returns the account's margin type for the asset type and pair
+func (b *Binance) GetMarginType(ctx context.Context, item asset.Item, pair currency.Pair) (margin.Type, error) {
+	if pair.IsEmpty() {
+		return margin.Unset, currency.ErrCurrencyPairEmpty
+	}
+	var mt string
+	switch item {
+	case asset.USDTMarginedFutures:
+		resp, err := b.UPositionsInfoV2(ctx, pair)
+		if err != nil {
+			return margin.Unset, err
+		}
+		if len(resp) == 0 {
+			return margin.Unset, fmt.Errorf("%w %v %v", futures.ErrPositionNotFound, item, pair)
+		}
+		// margin type is the same across positions
+		mt = resp[0].MarginType
+	case asset.CoinMarginedFutures:
+		resp, err := b.FuturesPositionsInfo(ctx, "", pair.Base.String())
+		if err != nil {
+			return margin.Unset, err
+		}
+		if len(resp) == 0 {
+			return margin.Unset, fmt.Errorf("%w %v %v", futures.ErrPositionNotFound, item, pair)
+		}
+		mt = resp[0].MarginType
+	default:
+		return margin.Unset, fmt.Errorf("%w %v", asset.ErrNotSupported, item)
+	}
+	return margin.StringToMarginType(mt)
+}
+
 // ChangePositionMargin will modify a position/currencies margin parameters
 func (b *Binance) ChangePositionMargin(ctx context.Context, req *margin.PositionChangeRequest) (*margin.PositionChangeResponse, error) {
 	if req == nil {
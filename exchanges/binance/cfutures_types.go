@@ -252,6 +252,8 @@ type FuturesOrderPlaceData struct {
 	UpdateTime    int64   `json:"updateTime"`
 	WorkingType   string  `json:"workingType"`
 	PriceProtect  bool    `json:"priceProtect"`
+	Code          int64   `json:"code"`
+	Message       string  `json:"msg"`
 }
 
 // FuturesOrderGetData stores futures order data for get requests
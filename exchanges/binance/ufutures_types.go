@@ -455,4 +455,5 @@ type UFuturesNewOrderRequest struct {
 	ActivationPrice  float64       `json:"activation_price"`
 	CallbackRate     float64       `json:"callback_rate"`
 	ReduceOnly       bool          `json:"reduce_only"`
+	GoodTillDate     time.Time     `json:"good_till_date"`
 }
@@ -43,7 +43,7 @@ var (
 )
 
 // WsConnect initiates a websocket connection
-func (bi *Binanceus) WsConnect() error {
+func (bi *Binanceus) WsConnect(ctx context.Context) error {
 	if !bi.Websocket.IsEnabled() || !bi.IsEnabled() {
 		return stream.ErrWebsocketNotEnabled
 	}
@@ -52,7 +52,7 @@ func (bi *Binanceus) WsConnect() error {
 	dialer.Proxy = http.ProxyFromEnvironment
 	var err error
 	if bi.Websocket.CanUseAuthenticatedEndpoints() {
-		listenKey, err = bi.GetWsAuthStreamKey(context.TODO())
+		listenKey, err = bi.GetWsAuthStreamKey(ctx)
 		if err != nil {
 			bi.Websocket.SetCanUseAuthenticatedEndpoints(false)
 			log.Errorf(log.ExchangeSys,
@@ -78,7 +78,7 @@ func (bi *Binanceus) WsConnect() error {
 
 	if bi.Websocket.CanUseAuthenticatedEndpoints() {
 		bi.Websocket.Wg.Add(1)
-		go bi.KeepAuthKeyAlive()
+		go bi.KeepAuthKeyAlive(ctx)
 	}
 
 	bi.Websocket.Conn.SetupPingHandler(stream.PingHandler{
@@ -96,7 +96,7 @@ func (bi *Binanceus) WsConnect() error {
 
 // KeepAuthKeyAlive will continuously send messages to
 // keep the WS auth key active
-func (bi *Binanceus) KeepAuthKeyAlive() {
+func (bi *Binanceus) KeepAuthKeyAlive(ctx context.Context) {
 	defer bi.Websocket.Wg.Done()
 	// ClosUserDataStream closes the User data stream and remove the listen key when closing the websocket.
 	defer func() {
@@ -114,7 +114,7 @@ func (bi *Binanceus) KeepAuthKeyAlive() {
 			ticks.Stop()
 			return
 		case <-ticks.C:
-			err := bi.MaintainWsAuthStreamKey(context.TODO())
+			err := bi.MaintainWsAuthStreamKey(ctx)
 			if err != nil {
 				bi.Websocket.DataHandler <- err
 				log.Warnf(log.ExchangeSys,
@@ -570,7 +570,7 @@ subs:
 }
 
 // Subscribe subscribes to a set of channels
-func (bi *Binanceus) Subscribe(channelsToSubscribe []subscription.Subscription) error {
+func (bi *Binanceus) Subscribe(ctx context.Context, channelsToSubscribe []subscription.Subscription) error {
 	payload := WebsocketPayload{
 		Method: "SUBSCRIBE",
 	}
@@ -595,7 +595,7 @@ func (bi *Binanceus) Subscribe(channelsToSubscribe []subscription.Subscription)
 }
 
 // Unsubscribe unsubscribes from a set of channels
-func (bi *Binanceus) Unsubscribe(channelsToUnsubscribe []subscription.Subscription) error {
+func (bi *Binanceus) Unsubscribe(ctx context.Context, channelsToUnsubscribe []subscription.Subscription) error {
 	payload := WebsocketPayload{
 		Method: "UNSUBSCRIBE",
 	}
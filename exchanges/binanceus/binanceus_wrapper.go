@@ -530,7 +530,7 @@ func (bi *Binanceus) SubmitOrder(ctx context.Context, s *order.Submit) (*order.S
 	var submitOrderResponse order.SubmitResponse
 	var timeInForce RequestParamsTimeForceType
 	var sideType string
-	err := s.Validate()
+	err := s.Validate(s.TimeInForceSupported(order.GTC|order.IOC|order.FOK, true))
 	if err != nil {
 		return nil, err
 	}
@@ -547,8 +547,19 @@ func (bi *Binanceus) SubmitOrder(ctx context.Context, s *order.Submit) (*order.S
 	case order.Market:
 		requestParamOrderType = BinanceRequestParamsOrderMarket
 	case order.Limit:
-		timeInForce = BinanceRequestParamsTimeGTC
-		requestParamOrderType = BinanceRequestParamsOrderLimit
+		switch {
+		case s.PostOnly:
+			requestParamOrderType = BinanceRequestParamsOrderLimitMarker
+		case s.TimeInForce == order.IOC:
+			timeInForce = BinanceRequestParamsTimeIOC
+			requestParamOrderType = BinanceRequestParamsOrderLimit
+		case s.TimeInForce == order.FOK:
+			timeInForce = BinanceRequestParamsTimeFOK
+			requestParamOrderType = BinanceRequestParamsOrderLimit
+		default:
+			timeInForce = BinanceRequestParamsTimeGTC
+			requestParamOrderType = BinanceRequestParamsOrderLimit
+		}
 	default:
 		return nil, fmt.Errorf("%w %v", order.ErrUnsupportedOrderType, s.Type)
 	}
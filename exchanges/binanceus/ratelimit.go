@@ -2,7 +2,6 @@ package binanceus
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
@@ -86,26 +85,7 @@ func (r *RateLimit) Limit(ctx context.Context, f request.EndpointLimit) error {
 	default:
 		limiter, tokens = r.SpotRate, 1
 	}
-	var finalDelay time.Duration
-	var reserves = make([]*rate.Reservation, tokens)
-	for i := 0; i < tokens; i++ {
-		// Consume tokens 1 at a time as this avoids needing burst capacity in the limiter,
-		// which would otherwise allow the rate limit to be exceeded over short periods
-		reserves[i] = limiter.Reserve()
-		finalDelay = reserves[i].Delay()
-	}
-	if dl, ok := ctx.Deadline(); ok && dl.Before(time.Now().Add(finalDelay)) {
-		// Cancel all potential reservations to free up rate limiter if deadline
-		// is exceeded.
-		for x := range reserves {
-			reserves[x].Cancel()
-		}
-		return fmt.Errorf("rate limit delay of %s will exceed deadline: %w",
-			finalDelay,
-			context.DeadlineExceeded)
-	}
-	time.Sleep(finalDelay)
-	return nil
+	return request.WaitForTokens(ctx, limiter, tokens)
 }
 
 // SetRateLimit returns the rate limit for the exchange
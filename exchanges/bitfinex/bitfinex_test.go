@@ -1148,7 +1148,7 @@ func TestWsAuth(t *testing.T) {
 // See also TestSubscribeReq which covers key and symbol conversion
 func TestWsSubscribe(t *testing.T) {
 	setupWs(t)
-	err := b.Subscribe([]subscription.Subscription{{Channel: wsTicker, Pair: currency.NewPair(currency.BTC, currency.USD), Asset: asset.Spot}})
+	err := b.Subscribe(context.Background(), []subscription.Subscription{{Channel: wsTicker, Pair: currency.NewPair(currency.BTC, currency.USD), Asset: asset.Spot}})
 	assert.NoError(t, err, "Subrcribe should not error")
 	catcher := func() (ok bool) {
 		i := <-b.Websocket.DataHandler
@@ -1161,7 +1161,7 @@ func TestWsSubscribe(t *testing.T) {
 	assert.NoError(t, err, "GetSubscriptions should not error")
 	assert.Len(t, subs, 1, "We should only have 1 subscription; subID subscription should have been Removed by subscribeToChan")
 
-	err = b.Subscribe([]subscription.Subscription{{Channel: wsTicker, Pair: currency.NewPair(currency.BTC, currency.USD), Asset: asset.Spot}})
+	err = b.Subscribe(context.Background(), []subscription.Subscription{{Channel: wsTicker, Pair: currency.NewPair(currency.BTC, currency.USD), Asset: asset.Spot}})
 	assert.ErrorIs(t, err, stream.ErrSubscriptionFailure, "Duplicate subscription should error correctly")
 	catcher = func() bool {
 		i := <-b.Websocket.DataHandler
@@ -1179,18 +1179,18 @@ func TestWsSubscribe(t *testing.T) {
 	assert.NoError(t, err, "GetSubscriptions should not error")
 	assert.Len(t, subs, 1, "We should only have one subscription after an error attempt")
 
-	err = b.Unsubscribe(subs)
+	err = b.Unsubscribe(context.Background(), subs)
 	assert.NoError(t, err, "Unsubscribing should not error")
 
 	chanID, ok := subs[0].Key.(int)
 	assert.True(t, ok, "sub.Key should be an int")
 
-	err = b.Unsubscribe(subs)
+	err = b.Unsubscribe(context.Background(), subs)
 	assert.ErrorIs(t, err, stream.ErrUnsubscribeFailure, "Unsubscribe should error")
 	assert.ErrorContains(t, err, strconv.Itoa(chanID), "Unsubscribe should contain correct chanId")
 	assert.ErrorContains(t, err, "unsubscribe: invalid (code: 10400)", "Unsubscribe should contain correct upstream error")
 
-	err = b.Subscribe([]subscription.Subscription{{
+	err = b.Subscribe(context.Background(), []subscription.Subscription{{
 		Channel: wsTicker,
 		Pair:    currency.NewPair(currency.BTC, currency.USD),
 		Asset:   asset.Spot,
@@ -2002,7 +2002,7 @@ func setupWs(tb testing.TB) {
 		return
 	}
 	// We don't use b.websocket.Connect() because it'd subscribe to channels
-	err := b.WsConnect()
+	err := b.WsConnect(context.Background())
 	if !assert.NoError(tb, err, "WsConnect should not error") {
 		tb.FailNow()
 	}
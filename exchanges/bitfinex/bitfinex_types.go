@@ -53,6 +53,14 @@ type WalletDataV2 struct {
 	UnsettledInterest float64
 }
 
+const (
+	// wsOrderFlagPostOnly flags a websocket order as post only
+	wsOrderFlagPostOnly = 4096
+	// wsOrderTimeInForceLayout is the UTC datetime layout used for good till
+	// date websocket orders
+	wsOrderTimeInForceLayout = "2006-01-02 15:04:05"
+)
+
 // AcceptedOrderType defines the accepted market types, exchange strings denote non-contract order types.
 var AcceptedOrderType = []string{"market", "limit", "stop", "trailing-stop",
 	"fill-or-kill", "exchange market", "exchange limit", "exchange stop",
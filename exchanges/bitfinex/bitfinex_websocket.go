@@ -41,7 +41,7 @@ var checksumStore = make(map[int]*checksum)
 var cMtx sync.Mutex
 
 // WsConnect starts a new websocket connection
-func (b *Bitfinex) WsConnect() error {
+func (b *Bitfinex) WsConnect(ctx context.Context) error {
 	if !b.Websocket.IsEnabled() || !b.IsEnabled() {
 		return stream.ErrWebsocketNotEnabled
 	}
@@ -66,7 +66,7 @@ func (b *Bitfinex) WsConnect() error {
 		}
 		b.Websocket.Wg.Add(1)
 		go b.wsReadData(b.Websocket.AuthConn)
-		err = b.WsSendAuth(context.TODO())
+		err = b.WsSendAuth(ctx)
 		if err != nil {
 			log.Errorf(log.ExchangeSys,
 				"%v - authentication failed: %v\n",
@@ -1665,18 +1665,18 @@ func (b *Bitfinex) ConfigureWS() error {
 }
 
 // Subscribe sends a websocket message to receive data from channels
-func (b *Bitfinex) Subscribe(channels []subscription.Subscription) error {
-	return b.ParallelChanOp(channels, b.subscribeToChan, 1)
+func (b *Bitfinex) Subscribe(ctx context.Context, channels []subscription.Subscription) error {
+	return b.ParallelChanOp(ctx, channels, b.subscribeToChan, 1)
 }
 
 // Unsubscribe sends a websocket message to stop receiving data from channels
-func (b *Bitfinex) Unsubscribe(channels []subscription.Subscription) error {
-	return b.ParallelChanOp(channels, b.unsubscribeFromChan, 1)
+func (b *Bitfinex) Unsubscribe(ctx context.Context, channels []subscription.Subscription) error {
+	return b.ParallelChanOp(ctx, channels, b.unsubscribeFromChan, 1)
 }
 
 // subscribeToChan handles a single subscription and parses the result
 // on success it adds the subscription to the websocket
-func (b *Bitfinex) subscribeToChan(chans []subscription.Subscription) error {
+func (b *Bitfinex) subscribeToChan(ctx context.Context, chans []subscription.Subscription) error {
 	if len(chans) != 1 {
 		return errors.New("subscription batching limited to 1")
 	}
@@ -1705,7 +1705,7 @@ func (b *Bitfinex) subscribeToChan(chans []subscription.Subscription) error {
 	// Always remove the temporary subscription keyed by subID
 	defer b.Websocket.RemoveSubscriptions(c)
 
-	respRaw, err := b.Websocket.Conn.SendMessageReturnResponse("subscribe:"+subID, req)
+	respRaw, err := b.Websocket.Conn.SendMessageReturnResponseWithContext(ctx, "subscribe:"+subID, req)
 	if err != nil {
 		return fmt.Errorf("%w: %w; Channel: %s Pair: %s", stream.ErrSubscriptionFailure, err, c.Channel, c.Pair)
 	}
@@ -1776,7 +1776,7 @@ func subscribeReq(c *subscription.Subscription) (map[string]interface{}, error)
 }
 
 // unsubscribeFromChan sends a websocket message to stop receiving data from a channel
-func (b *Bitfinex) unsubscribeFromChan(chans []subscription.Subscription) error {
+func (b *Bitfinex) unsubscribeFromChan(ctx context.Context, chans []subscription.Subscription) error {
 	if len(chans) != 1 {
 		return errors.New("subscription batching limited to 1")
 	}
@@ -1791,7 +1791,7 @@ func (b *Bitfinex) unsubscribeFromChan(chans []subscription.Subscription) error
 		"chanId": chanID,
 	}
 
-	respRaw, err := b.Websocket.Conn.SendMessageReturnResponse("unsubscribe:"+strconv.Itoa(chanID), req)
+	respRaw, err := b.Websocket.Conn.SendMessageReturnResponseWithContext(ctx, "unsubscribe:"+strconv.Itoa(chanID), req)
 	if err != nil {
 		return err
 	}
@@ -608,7 +608,14 @@ allTrades:
 
 // SubmitOrder submits a new order
 func (b *Bitfinex) SubmitOrder(ctx context.Context, o *order.Submit) (*order.SubmitResponse, error) {
-	if err := o.Validate(); err != nil {
+	useWebsocket := b.Websocket.CanUseAuthenticatedWebsocketForWrapper()
+	// Time in force and post only are only exposed by the v2 websocket API,
+	// the v1 REST API only supports fill or kill orders
+	supportedTIF, postOnly := order.GTC|order.FOK, false
+	if useWebsocket {
+		supportedTIF, postOnly = order.GTC|order.GTD|order.IOC|order.FOK, true
+	}
+	if err := o.Validate(o.TimeInForceSupported(supportedTIF, postOnly)); err != nil {
 		return nil, err
 	}
 
@@ -619,12 +626,20 @@ func (b *Bitfinex) SubmitOrder(ctx context.Context, o *order.Submit) (*order.Sub
 
 	var orderID string
 	status := order.New
-	if b.Websocket.CanUseAuthenticatedWebsocketForWrapper() {
+	if useWebsocket {
 		symbolStr, err := b.fixCasing(fPair, o.AssetType) //nolint:govet // intentional shadow of err
 		if err != nil {
 			return nil, err
 		}
 		orderType := strings.ToUpper(o.Type.String())
+		if o.Type == order.Limit {
+			switch o.TimeInForce {
+			case order.IOC:
+				orderType = "IOC"
+			case order.FOK:
+				orderType = "FOK"
+			}
+		}
 		if o.AssetType == asset.Spot {
 			orderType = "EXCHANGE " + orderType
 		}
@@ -634,6 +649,12 @@ func (b *Bitfinex) SubmitOrder(ctx context.Context, o *order.Submit) (*order.Sub
 			Amount: o.Amount,
 			Price:  o.Price,
 		}
+		if o.PostOnly {
+			req.Flags |= wsOrderFlagPostOnly
+		}
+		if o.TimeInForce == order.GTD {
+			req.TimeInForce = o.Expiry.UTC().Format(wsOrderTimeInForceLayout)
+		}
 		if o.Side.IsShort() && o.Amount > 0 {
 			// All v2 apis use negatives for Short side
 			req.Amount *= -1
@@ -646,6 +667,9 @@ func (b *Bitfinex) SubmitOrder(ctx context.Context, o *order.Submit) (*order.Sub
 		var response Order
 		b.appendOptionalDelimiter(&fPair)
 		orderType := o.Type.Lower()
+		if o.Type == order.Limit && o.TimeInForce == order.FOK {
+			orderType = "fill-or-kill"
+		}
 		if o.AssetType == asset.Spot {
 			orderType = "exchange " + orderType
 		}
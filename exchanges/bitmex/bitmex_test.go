@@ -11,6 +11,7 @@ import (
 
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/common/key"
 	"github.com/thrasher-corp/gocryptotrader/config"
@@ -1342,3 +1343,19 @@ func TestGetOpenInterest(t *testing.T) {
 	})
 	assert.ErrorIs(t, err, asset.ErrNotSupported)
 }
+
+func TestGetWithdrawalLocks(t *testing.T) {
+	t.Parallel()
+	sharedtestvalues.SkipTestIfCredentialsUnset(t, b)
+	_, err := b.GetWithdrawalLocks(context.Background(), currency.BTC)
+	assert.NoError(t, err, "GetWithdrawalLocks should not error")
+}
+
+func TestWalletWithdrawalLocks(t *testing.T) {
+	t.Parallel()
+	locks := walletWithdrawalLocks(b.Name, currency.BTC, &WalletInfo{})
+	assert.Empty(t, locks, "wallets without withdrawal locks should return no locks")
+	locks = walletWithdrawalLocks(b.Name, currency.BTC, &WalletInfo{WithdrawalLock: []string{"2FA reset"}})
+	require.Len(t, locks, 1)
+	assert.Equal(t, withdraw.Lock{Exchange: b.Name, Currency: currency.BTC, Reason: "2FA reset"}, locks[0])
+}
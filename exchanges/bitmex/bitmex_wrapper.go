@@ -679,7 +679,7 @@ allTrades:
 
 // SubmitOrder submits a new order
 func (b *Bitmex) SubmitOrder(ctx context.Context, s *order.Submit) (*order.SubmitResponse, error) {
-	if err := s.Validate(); err != nil {
+	if err := s.Validate(s.TimeInForceSupported(order.GTC|order.IOC|order.FOK, true)); err != nil {
 		return nil, err
 	}
 
@@ -704,6 +704,18 @@ func (b *Bitmex) SubmitOrder(ctx context.Context, s *order.Submit) (*order.Submi
 		orderNewParams.Price = s.Price
 	}
 
+	switch s.TimeInForce {
+	case order.GTC:
+		orderNewParams.TimeInForce = "GoodTillCancel"
+	case order.IOC:
+		orderNewParams.TimeInForce = "ImmediateOrCancel"
+	case order.FOK:
+		orderNewParams.TimeInForce = "FillOrKill"
+	}
+	if s.PostOnly {
+		orderNewParams.ExecInst = "ParticipateDoNotInitiate"
+	}
+
 	response, err := b.CreateOrder(ctx, &orderNewParams)
 	if err != nil {
 		return nil, err
@@ -904,6 +916,34 @@ func (b *Bitmex) WithdrawFiatFundsToInternationalBank(_ context.Context, _ *with
 	return nil, common.ErrFunctionNotSupported
 }
 
+// GetWithdrawalLocks returns the withdrawal locks on the account's wallet for a
+// currency
+func (b *Bitmex) GetWithdrawalLocks(ctx context.Context, c currency.Code) ([]withdraw.Lock, error) {
+	walletCurrency := c.String()
+	if c.Equal(currency.BTC) || c.Equal(currency.XBT) {
+		walletCurrency = "XBt"
+	}
+	wallet, err := b.GetWalletInfo(ctx, walletCurrency)
+	if err != nil {
+		return nil, err
+	}
+	return walletWithdrawalLocks(b.Name, c, &wallet), nil
+}
+
+// walletWithdrawalLocks converts the withdrawal locks of a wallet to withdraw
+// locks. Bitmex does not return when locks are lifted
+func walletWithdrawalLocks(exch string, c currency.Code, w *WalletInfo) []withdraw.Lock {
+	locks := make([]withdraw.Lock, len(w.WithdrawalLock))
+	for i := range w.WithdrawalLock {
+		locks[i] = withdraw.Lock{
+			Exchange: exch,
+			Currency: c,
+			Reason:   w.WithdrawalLock[i],
+		}
+	}
+	return locks
+}
+
 // GetFeeByType returns an estimate of fee based on type of transaction
 func (b *Bitmex) GetFeeByType(ctx context.Context, feeBuilder *exchange.FeeBuilder) (float64, error) {
 	if feeBuilder == nil {
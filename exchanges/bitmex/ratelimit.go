@@ -23,10 +23,16 @@ type RateLimit struct {
 
 // Limit limits outbound calls
 func (r *RateLimit) Limit(ctx context.Context, f request.EndpointLimit) error {
+	limiter, _ := r.LimiterFor(f)
+	return limiter.Wait(ctx)
+}
+
+// LimiterFor returns the rate limiter of an endpoint
+func (r *RateLimit) LimiterFor(f request.EndpointLimit) (limiter *rate.Limiter, cost int) {
 	if f == request.Auth {
-		return r.Auth.Wait(ctx)
+		return r.Auth, 1
 	}
-	return r.UnAuth.Wait(ctx)
+	return r.UnAuth, 1
 }
 
 // SetRateLimit returns the rate limit for the exchange
@@ -2,6 +2,7 @@ package bitstamp
 
 import (
 	"context"
+	"os"
 	"testing"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/sharedtestvalues"
+	testexch "github.com/thrasher-corp/gocryptotrader/internal/testing/exchange"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
 )
@@ -202,6 +204,41 @@ func TestGetOrderbook(t *testing.T) {
 	}
 }
 
+// TestRESTContract replays recorded public REST responses through a separate
+// instance to verify parsing independently of the mock server. Set
+// GCT_RECORD_FIXTURES to re-record them and check for response drift
+func TestRESTContract(t *testing.T) {
+	t.Parallel()
+	e := new(Bitstamp)
+	require.NoError(t, testexch.TestInstance(e), "TestInstance must not error")
+	testexch.FixtureHTTPInstance(t, e, "testdata/rest_fixtures.json")
+
+	tick, err := e.GetTicker(context.Background(), "btcusd", false)
+	require.NoError(t, err, "GetTicker must not error")
+	if os.Getenv(testexch.FixtureRecordEnv) == "" {
+		assert.Equal(t, &Ticker{
+			Last:      2211,
+			High:      2811,
+			Low:       2188.97,
+			Vwap:      2189.8,
+			Volume:    213.268011,
+			Bid:       2188.97,
+			Ask:       2211,
+			Timestamp: 1643640186,
+			Open:      2211,
+			Side:      orderSide(order.Buy),
+		}, tick, "GetTicker should parse the recorded response")
+	}
+
+	ob, err := e.GetOrderbook(context.Background(), "btcusd")
+	require.NoError(t, err, "GetOrderbook must not error")
+	if os.Getenv(testexch.FixtureRecordEnv) == "" {
+		assert.Equal(t, int64(1643640186), ob.Timestamp)
+		assert.Equal(t, []OrderbookBase{{Price: 2188.97, Amount: 0.5}, {Price: 2188, Amount: 1.25}}, ob.Bids)
+		assert.Equal(t, []OrderbookBase{{Price: 2211, Amount: 0.75}}, ob.Asks)
+	}
+}
+
 func TestGetTradingPairs(t *testing.T) {
 	t.Parallel()
 
@@ -93,8 +93,9 @@ func (p *TradingPair) UnmarshalJSON(data []byte) error {
 type orderSide order.Side
 
 func (s *orderSide) UnmarshalJSON(data []byte) error {
-	var i int64
-	if err := json.Unmarshal(data, &i); err != nil {
+	// REST responses quote the side whereas websocket messages do not
+	i, err := strconv.ParseInt(strings.Trim(string(data), `"`), 10, 64)
+	if err != nil {
 		return err
 	}
 	switch i {
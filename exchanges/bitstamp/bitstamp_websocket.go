@@ -43,7 +43,7 @@ var (
 )
 
 // WsConnect connects to a websocket feed
-func (b *Bitstamp) WsConnect() error {
+func (b *Bitstamp) WsConnect(ctx context.Context) error {
 	if !b.Websocket.IsEnabled() || !b.IsEnabled() {
 		return stream.ErrWebsocketNotEnabled
 	}
@@ -60,7 +60,7 @@ func (b *Bitstamp) WsConnect() error {
 		Message:     hbMsg,
 		Delay:       hbInterval,
 	})
-	err = b.seedOrderBook(context.TODO())
+	err = b.seedOrderBook(ctx)
 	if err != nil {
 		b.Websocket.DataHandler <- err
 	}
@@ -266,14 +266,14 @@ func (b *Bitstamp) generateDefaultSubscriptions() ([]subscription.Subscription,
 }
 
 // Subscribe sends a websocket message to receive data from the channel
-func (b *Bitstamp) Subscribe(channelsToSubscribe []subscription.Subscription) error {
+func (b *Bitstamp) Subscribe(ctx context.Context, channelsToSubscribe []subscription.Subscription) error {
 	var errs error
 	var auth *WebsocketAuthResponse
 
 	for i := range channelsToSubscribe {
 		if _, ok := channelsToSubscribe[i].Params["auth"]; ok {
 			var err error
-			auth, err = b.FetchWSAuth(context.TODO())
+			auth, err = b.FetchWSAuth(ctx)
 			if err != nil {
 				errs = common.AppendError(errs, err)
 			}
@@ -304,7 +304,7 @@ func (b *Bitstamp) Subscribe(channelsToSubscribe []subscription.Subscription) er
 }
 
 // Unsubscribe sends a websocket message to stop receiving data from the channel
-func (b *Bitstamp) Unsubscribe(channelsToUnsubscribe []subscription.Subscription) error {
+func (b *Bitstamp) Unsubscribe(ctx context.Context, channelsToUnsubscribe []subscription.Subscription) error {
 	var errs error
 	for i := range channelsToUnsubscribe {
 		req := websocketEventRequest{
@@ -376,10 +376,10 @@ func (b *BTCMarkets) formatOrderSide(o order.Side) (string, error) {
 
 // getTimeInForce returns a string depending on the options in order.Submit
 func (b *BTCMarkets) getTimeInForce(s *order.Submit) string {
-	if s.ImmediateOrCancel {
+	switch s.TimeInForce {
+	case order.IOC:
 		return immediateOrCancel
-	}
-	if s.FillOrKill {
+	case order.FOK:
 		return fillOrKill
 	}
 	return "" // GTC (good till cancelled, default value)
@@ -541,7 +541,7 @@ func TestWsTicker(t *testing.T) {
     "volume24h": "299.12936654",
     "messageType": "tick"
   }`)
-	err := b.wsHandleData(pressXToJSON)
+	err := b.wsHandleData(context.Background(), pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -556,7 +556,7 @@ func TestWsTrade(t *testing.T) {
     "side": "Ask",
     "messageType": "trade"
   }`)
-	err := b.wsHandleData(pressXToJSON)
+	err := b.wsHandleData(context.Background(), pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -573,7 +573,7 @@ func TestWsFundChange(t *testing.T) {
   "fee": "0",
   "messageType": "fundChange"
 }`)
-	err := b.wsHandleData(pressXToJSON)
+	err := b.wsHandleData(context.Background(), pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -595,7 +595,7 @@ func TestWsOrderbookUpdate(t *testing.T) {
           [ "101", "6.32", 2 ] ],
       "messageType": "orderbookUpdate"
   }`)
-	err := b.wsHandleData(pressXToJSON)
+	err := b.wsHandleData(context.Background(), pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -608,7 +608,7 @@ func TestWsOrderbookUpdate(t *testing.T) {
     "messageType": "orderbookUpdate",
 	"checksum": "2513007604"
   }`)
-	err = b.wsHandleData(pressXToJSON)
+	err = b.wsHandleData(context.Background(), pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -620,7 +620,7 @@ func TestWsHeartbeats(t *testing.T) {
   "code": 3,
   "message": "invalid channel names"
 }`)
-	err := b.wsHandleData(pressXToJSON)
+	err := b.wsHandleData(context.Background(), pressXToJSON)
 	if err == nil {
 		t.Error("expected error")
 	}
@@ -630,7 +630,7 @@ func TestWsHeartbeats(t *testing.T) {
 "code": 3,
 "message": "invalid marketIds"
 }`)
-	err = b.wsHandleData(pressXToJSON)
+	err = b.wsHandleData(context.Background(), pressXToJSON)
 	if err == nil {
 		t.Error("expected error")
 	}
@@ -640,7 +640,7 @@ func TestWsHeartbeats(t *testing.T) {
 "code": 1,
 "message": "authentication failed. invalid key"
 }`)
-	err = b.wsHandleData(pressXToJSON)
+	err = b.wsHandleData(context.Background(), pressXToJSON)
 	if err == nil {
 		t.Error("expected error")
 	}
@@ -659,7 +659,7 @@ func TestWsOrders(t *testing.T) {
     "timestamp": "2019-04-08T20:41:19.339Z",
     "messageType": "orderChange"
   }`)
-	err := b.wsHandleData(pressXToJSON)
+	err := b.wsHandleData(context.Background(), pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -682,7 +682,7 @@ func TestWsOrders(t *testing.T) {
     "timestamp": "2019-04-08T20:50:39.658Z",
     "messageType": "orderChange"
   }`)
-	err = b.wsHandleData(pressXToJSON)
+	err = b.wsHandleData(context.Background(), pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -699,7 +699,7 @@ func TestWsOrders(t *testing.T) {
     "timestamp": "2019-04-08T20:41:41.857Z",
     "messageType": "orderChange"
   }`)
-	err = b.wsHandleData(pressXToJSON)
+	err = b.wsHandleData(context.Background(), pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -722,7 +722,7 @@ func TestWsOrders(t *testing.T) {
 	"timestamp": "2019-04-08T20:41:41.857Z",
     "messageType": "orderChange"
   }`)
-	err = b.wsHandleData(pressXToJSON)
+	err = b.wsHandleData(context.Background(), pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -739,7 +739,7 @@ func TestWsOrders(t *testing.T) {
     "timestamp": "2019-04-08T20:41:41.857Z",
     "messageType": "orderChange"
   }`)
-	err = b.wsHandleData(pressXToJSON)
+	err = b.wsHandleData(context.Background(), pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -972,12 +972,12 @@ func TestGetTimeInForce(t *testing.T) {
 		t.Fatal("unexpected value")
 	}
 
-	f = b.getTimeInForce(&order.Submit{ImmediateOrCancel: true})
+	f = b.getTimeInForce(&order.Submit{TimeInForce: order.IOC})
 	if f != immediateOrCancel {
 		t.Fatalf("received: '%v' but expected: '%v'", f, immediateOrCancel)
 	}
 
-	f = b.getTimeInForce(&order.Submit{FillOrKill: true})
+	f = b.getTimeInForce(&order.Submit{TimeInForce: order.FOK})
 	if f != fillOrKill {
 		t.Fatalf("received: '%v' but expected: '%v'", f, fillOrKill)
 	}
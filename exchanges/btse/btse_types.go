@@ -7,7 +7,9 @@ import (
 
 const (
 	// Default order type is good till cancel (or filled)
-	goodTillCancel = "GTC"
+	goodTillCancel    = "GTC"
+	immediateOrCancel = "IOC"
+	fillOrKill        = "FOK"
 
 	orderInserted  = 2
 	orderCancelled = 6
@@ -535,7 +535,7 @@ func (b *BTSE) GetHistoricTrades(_ context.Context, _ currency.Pair, _ asset.Ite
 
 // SubmitOrder submits a new order
 func (b *BTSE) SubmitOrder(ctx context.Context, s *order.Submit) (*order.SubmitResponse, error) {
-	if err := s.Validate(); err != nil {
+	if err := s.Validate(s.TimeInForceSupported(order.GTC|order.IOC|order.FOK, true)); err != nil {
 		return nil, err
 	}
 
@@ -548,14 +548,22 @@ func (b *BTSE) SubmitOrder(ctx context.Context, s *order.Submit) (*order.SubmitR
 		return nil, err
 	}
 
+	timeInForce := goodTillCancel
+	switch s.TimeInForce {
+	case order.IOC:
+		timeInForce = immediateOrCancel
+	case order.FOK:
+		timeInForce = fillOrKill
+	}
+
 	r, err := b.CreateOrder(ctx,
 		s.ClientID, 0.0,
-		false,
+		s.PostOnly,
 		s.Price,
 		s.Side.String(),
 		s.Amount, 0, 0,
 		fPair.String(),
-		goodTillCancel,
+		timeInForce,
 		0.0,
 		s.TriggerPrice,
 		"",
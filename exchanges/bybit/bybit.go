@@ -47,6 +47,11 @@ const (
 	accountTypeUnified = 1 // 1: unified trade account
 
 	longDatedFormat = "02Jan06"
+
+	// maxADLRank is the highest position adlRankIndicator
+	maxADLRank = 5
+
+	supportedSMPTypes = order.STPCancelMaker | order.STPCancelTaker | order.STPCancelBoth
 )
 
 var (
@@ -89,6 +94,8 @@ var (
 	errAPIKeyIsNotUnified                      = errors.New("api key is not unified")
 	errEndpointAvailableForNormalAPIKeyHolders = errors.New("endpoint available for normal API key holders only")
 	errInvalidContractLength                   = errors.New("contract length cannot be less than or equal to zero")
+	errBatchOrderLimitExceeded                 = errors.New("batch order limit exceeded")
+	errBatchOrderRejected                      = errors.New("batch order rejected")
 )
 
 var (
@@ -12,7 +12,7 @@ import (
 )
 
 // WsLinearConnect connects to linear a websocket feed
-func (by *Bybit) WsLinearConnect() error {
+func (by *Bybit) WsLinearConnect(ctx context.Context) error {
 	if !by.Websocket.IsEnabled() || !by.IsEnabled() || !by.IsAssetWebsocketSupported(asset.LinearContract) {
 		return stream.ErrWebsocketNotEnabled
 	}
@@ -31,7 +31,7 @@ func (by *Bybit) WsLinearConnect() error {
 	by.Websocket.Wg.Add(1)
 	go by.wsReadData(asset.LinearContract, by.Websocket.Conn)
 	if by.IsWebsocketAuthenticationSupported() {
-		err = by.WsAuth(context.TODO())
+		err = by.WsAuth(ctx)
 		if err != nil {
 			by.Websocket.DataHandler <- err
 			by.Websocket.SetCanUseAuthenticatedEndpoints(false)
@@ -5,15 +5,20 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/common/key"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/calendar"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/fundingrate"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
@@ -273,6 +278,39 @@ func TestSubmitOrder(t *testing.T) {
 	}
 }
 
+func TestSubmitOrders(t *testing.T) {
+	t.Parallel()
+	_, err := b.SubmitOrders(context.Background(), nil)
+	assert.ErrorIs(t, err, order.ErrSubmissionIsNil)
+	_, err = b.SubmitOrders(context.Background(), []order.Submit{{AssetType: asset.Spot}})
+	assert.ErrorIs(t, err, common.ErrFunctionNotSupported, "spot batches should fall back to individual orders")
+	_, err = b.SubmitOrders(context.Background(), make([]order.Submit, 11))
+	assert.ErrorIs(t, err, common.ErrFunctionNotSupported)
+	s := make([]order.Submit, 11)
+	for i := range s {
+		s[i] = order.Submit{Exchange: b.GetName(), Pair: usdtMarginedTradablePair, AssetType: asset.USDTMarginedFutures, Side: order.Buy, Type: order.Limit, Price: 1, Amount: 1, ClientOrderID: strconv.Itoa(i)}
+	}
+	_, err = b.SubmitOrders(context.Background(), s)
+	assert.ErrorIs(t, err, errBatchOrderLimitExceeded)
+	s = s[:2]
+	s[1].AssetType = asset.Options
+	_, err = b.SubmitOrders(context.Background(), s)
+	assert.ErrorIs(t, err, common.ErrFunctionNotSupported, "mixed asset batches should fall back to individual orders")
+	s[1].AssetType = asset.USDTMarginedFutures
+	s[1].ClientOrderID = ""
+	_, err = b.SubmitOrders(context.Background(), s)
+	assert.ErrorIs(t, err, errOrderLinkIDMissing)
+
+	if mockTests {
+		t.Skip(skipAuthenticatedFunctionsForMockTesting)
+	}
+	sharedtestvalues.SkipTestIfCredentialsUnset(t, b, canManipulateRealOrders)
+	s[1].ClientOrderID = "1"
+	resp, err := b.SubmitOrders(context.Background(), s)
+	require.NoError(t, err)
+	assert.Len(t, resp, len(s))
+}
+
 func TestModifyOrder(t *testing.T) {
 	t.Parallel()
 	if mockTests {
@@ -744,6 +782,22 @@ func TestGetInsurance(t *testing.T) {
 	}
 }
 
+func TestGetInsuranceFunds(t *testing.T) {
+	t.Parallel()
+	_, err := b.GetInsuranceFunds(context.Background(), asset.Spot)
+	assert.ErrorIs(t, err, asset.ErrNotSupported)
+
+	funds, err := b.GetInsuranceFunds(context.Background(), asset.USDTMarginedFutures)
+	require.NoError(t, err)
+	require.NotEmpty(t, funds, "GetInsuranceFunds must return the USDT insurance pool")
+	for i := range funds {
+		assert.Equal(t, currency.USDT, funds[i].Currency, "only the USDT insurance pool should be returned")
+	}
+	if mockTests {
+		assert.Equal(t, 253082258.27858183, funds[0].Balance)
+	}
+}
+
 func TestGetDeliveryPrice(t *testing.T) {
 	t.Parallel()
 	_, err := b.GetDeliveryPrice(context.Background(), "spot", spotTradablePair.String(), "", "", 200)
@@ -1255,6 +1309,37 @@ func TestSetDisconnectCancelAll(t *testing.T) {
 	}
 }
 
+func TestGetADLIndicators(t *testing.T) {
+	t.Parallel()
+	_, err := b.GetADLIndicators(context.Background(), asset.Spot)
+	assert.ErrorIs(t, err, asset.ErrNotSupported)
+
+	if !mockTests {
+		sharedtestvalues.SkipTestIfCredentialsUnset(t, b)
+	}
+	indicators, err := b.GetADLIndicators(context.Background(), asset.USDTMarginedFutures)
+	require.NoError(t, err)
+	if mockTests {
+		require.Len(t, indicators, 1, "GetADLIndicators must only return open positions")
+		assert.True(t, indicators[0].Pair.Equal(currency.NewBTCUSDT()), "pair should match the position symbol")
+		assert.Equal(t, order.Long, indicators[0].Side)
+		assert.Equal(t, 0.6, indicators[0].Risk())
+	}
+}
+
+func TestGetScheduledEvents(t *testing.T) {
+	t.Parallel()
+	events, err := b.GetScheduledEvents(context.Background())
+	require.NoError(t, err)
+	if mockTests {
+		require.Len(t, events, 1, "GetScheduledEvents must only return contracts yet to launch")
+		assert.Equal(t, calendar.Listing, events[0].Type)
+		assert.Equal(t, asset.USDTMarginedFutures, events[0].Asset)
+		assert.True(t, events[0].Pair.Equal(currency.NewPair(currency.NewCode("MEOW"), currency.USDT)), "pair should be derived from the contract symbol")
+		assert.Equal(t, int64(4102444800000), events[0].Start.UnixMilli())
+	}
+}
+
 func TestGetPositionInfo(t *testing.T) {
 	t.Parallel()
 	if !mockTests {
@@ -3054,7 +3139,7 @@ func TestWsConnect(t *testing.T) {
 	if mockTests {
 		t.Skip(skippingWebsocketFunctionsForMockTesting)
 	}
-	err := b.WsConnect()
+	err := b.WsConnect(context.Background())
 	if err != nil {
 		t.Error(err)
 	}
@@ -3064,7 +3149,7 @@ func TestWsLinearConnect(t *testing.T) {
 	if mockTests {
 		t.Skip(skippingWebsocketFunctionsForMockTesting)
 	}
-	err := b.WsLinearConnect()
+	err := b.WsLinearConnect(context.Background())
 	if err != nil && !errors.Is(err, stream.ErrWebsocketNotEnabled) {
 		t.Error(err)
 	}
@@ -3119,6 +3204,28 @@ func TestPushData(t *testing.T) {
 	}
 }
 
+func TestWsProcessWalletPushData(t *testing.T) {
+	t.Parallel()
+	by := &Bybit{}
+	by.Name = "Bybit"
+	by.Websocket = sharedtestvalues.NewTestWebsocket()
+	err := by.wsProcessWalletPushData(asset.Spot, []byte(pushDataMap["Private Wallet"]))
+	require.NoError(t, err)
+	require.Len(t, by.Websocket.DataHandler, 2, "balance changes and margin status must be sent")
+	changes, ok := (<-by.Websocket.DataHandler).([]account.Change)
+	require.True(t, ok, "first message must be balance changes")
+	assert.Len(t, changes, 6)
+	margins, ok := (<-by.Websocket.DataHandler).([]account.MarginStatus)
+	require.True(t, ok, "second message must be margin status")
+	require.Len(t, margins, 1)
+	assert.Equal(t, "UNIFIED", margins[0].Account)
+	assert.Equal(t, 12837.78330188, margins[0].Equity)
+	assert.Equal(t, 205.72562486, margins[0].InitialMargin)
+	assert.Equal(t, 39.42876721, margins[0].MaintenanceMargin)
+	assert.Equal(t, 12632.05767702, margins[0].AvailableBalance)
+	assert.InDelta(t, 0.003, margins[0].MaintenanceMarginRatio(), 0.0001)
+}
+
 func TestGetFeeByTypeOfflineTradeFee(t *testing.T) {
 	t.Parallel()
 	var feeBuilder = &exchange.FeeBuilder{
@@ -3467,10 +3574,43 @@ func TestUpdateOptionsTickerInformation(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	_, err = b.updateOptionsTickerInformation(&resultOptions, cp)
-	if err != nil {
-		t.Fatal(err)
-	}
+	tick, err := b.updateOptionsTickerInformation(&resultOptions, cp)
+	require.NoError(t, err, "updateOptionsTickerInformation must not error")
+	assert.Equal(t, 0.047831, tick.Delta, "Delta should be correct")
+	assert.Equal(t, 0.00021453, tick.Gamma, "Gamma should be correct")
+	assert.Equal(t, 0.81351067, tick.Vega, "Vega should be correct")
+	assert.Equal(t, -19.9115368, tick.Theta, "Theta should be correct")
+	assert.Equal(t, 0.4896, tick.ImpliedVolatility, "ImpliedVolatility should be correct")
+}
+
+func TestWsProcessFundingRate(t *testing.T) {
+	t.Parallel()
+	by := &Bybit{}
+	by.Name = "Bybit"
+	by.Websocket = sharedtestvalues.NewTestWebsocket()
+	cp := currency.NewPair(currency.XRP, currency.USDT)
+	snapshot := &WebsocketResponse{Data: []byte(`{"symbol":"XRPUSDT","lastPrice":"0.5","nextFundingTime":"1673280000000","fundingRate":"-0.000212"}`)}
+	var result WsLinearTicker
+	require.NoError(t, json.Unmarshal(snapshot.Data, &result), "Unmarshal must not error")
+	by.wsProcessFundingRate(asset.USDTMarginedFutures, cp, &result, snapshot)
+	require.Len(t, by.Websocket.DataHandler, 1, "funding rate must be sent")
+	u, ok := (<-by.Websocket.DataHandler).(*fundingrate.Update)
+	require.True(t, ok, "message must be a funding rate update")
+	assert.Equal(t, "-0.000212", u.Rate.String())
+	assert.Equal(t, int64(1673280000000), u.TimeOfNextRate.UnixMilli())
+	assert.Equal(t, asset.USDTMarginedFutures, u.Asset)
+
+	delta := &WebsocketResponse{Data: []byte(`{"symbol":"XRPUSDT","lastPrice":"0.6"}`)}
+	result = WsLinearTicker{}
+	require.NoError(t, json.Unmarshal(delta.Data, &result), "Unmarshal must not error")
+	by.wsProcessFundingRate(asset.USDTMarginedFutures, cp, &result, delta)
+	assert.Empty(t, by.Websocket.DataHandler, "deltas without funding fields should not be sent")
+
+	dated := &WebsocketResponse{Data: []byte(`{"symbol":"XRPUSDT","nextFundingTime":"","fundingRate":""}`)}
+	result = WsLinearTicker{}
+	require.NoError(t, json.Unmarshal(dated.Data, &result), "Unmarshal must not error")
+	by.wsProcessFundingRate(asset.USDTMarginedFutures, cp, &result, dated)
+	assert.Empty(t, by.Websocket.DataHandler, "contracts without funding should not be sent")
 }
 
 func TestGetOpenInterest(t *testing.T) {
@@ -3530,3 +3670,35 @@ func TestIsPerpetualFutureCurrency(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, is, fmt.Sprintf("%s %s should be a perp", asset.USDCMarginedFutures, usdcMarginedTradablePair))
 }
+
+func TestTimeInForceToString(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		s        *order.Submit
+		expected string
+	}{
+		{&order.Submit{}, ""},
+		{&order.Submit{TimeInForce: order.GTC}, "GTC"},
+		{&order.Submit{TimeInForce: order.IOC}, "IOC"},
+		{&order.Submit{TimeInForce: order.FOK}, "FOK"},
+		{&order.Submit{TimeInForce: order.GTC, PostOnly: true}, "PostOnly"},
+	} {
+		assert.Equal(t, tc.expected, timeInForceToString(tc.s))
+	}
+}
+
+func TestSMPTypeToString(t *testing.T) {
+	t.Parallel()
+	for stp, expected := range map[order.SelfTradePrevention]string{
+		order.UnsetSelfTradePrevention: "",
+		order.STPCancelMaker:           "CancelMaker",
+		order.STPCancelTaker:           "CancelTaker",
+		order.STPCancelBoth:            "CancelBoth",
+		order.STPDecrement:             "",
+	} {
+		assert.Equal(t, expected, smpTypeToString(stp), stp.String())
+	}
+	s := &order.Submit{Exchange: b.Name, Pair: spotTradablePair, AssetType: asset.Spot, Side: order.Buy, Type: order.Market, Amount: 1, SelfTradePrevention: order.STPDecrement}
+	_, err := b.SubmitOrder(context.Background(), s)
+	assert.ErrorIs(t, err, order.ErrUnsupportedSTPMode)
+}
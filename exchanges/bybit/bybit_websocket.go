@@ -9,12 +9,14 @@ import (
 	"strings"
 	"time"
 
+	"github.com/buger/jsonparser"
 	"github.com/gorilla/websocket"
 	"github.com/thrasher-corp/gocryptotrader/common/crypto"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/fill"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/fundingrate"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
@@ -55,7 +57,7 @@ const (
 )
 
 // WsConnect connects to a websocket feed
-func (by *Bybit) WsConnect() error {
+func (by *Bybit) WsConnect(ctx context.Context) error {
 	if !by.Websocket.IsEnabled() || !by.IsEnabled() || !by.IsAssetWebsocketSupported(asset.Spot) {
 		return stream.ErrWebsocketNotEnabled
 	}
@@ -73,7 +75,7 @@ func (by *Bybit) WsConnect() error {
 	by.Websocket.Wg.Add(1)
 	go by.wsReadData(asset.Spot, by.Websocket.Conn)
 	if by.Websocket.CanUseAuthenticatedEndpoints() {
-		err = by.WsAuth(context.TODO())
+		err = by.WsAuth(ctx)
 		if err != nil {
 			by.Websocket.DataHandler <- err
 			by.Websocket.SetCanUseAuthenticatedEndpoints(false)
@@ -134,8 +136,8 @@ func (by *Bybit) WsAuth(ctx context.Context) error {
 }
 
 // Subscribe sends a websocket message to receive data from the channel
-func (by *Bybit) Subscribe(channelsToSubscribe []subscription.Subscription) error {
-	return by.handleSpotSubscription("subscribe", channelsToSubscribe)
+func (by *Bybit) Subscribe(ctx context.Context, channelsToSubscribe []subscription.Subscription) error {
+	return by.handleSpotSubscription(ctx, "subscribe", channelsToSubscribe)
 }
 
 func (by *Bybit) handleSubscriptions(assetType asset.Item, operation string, channelsToSubscribe []subscription.Subscription) ([]SubscriptionArgument, error) {
@@ -204,11 +206,11 @@ func (by *Bybit) handleSubscriptions(assetType asset.Item, operation string, cha
 }
 
 // Unsubscribe sends a websocket message to stop receiving data from the channel
-func (by *Bybit) Unsubscribe(channelsToUnsubscribe []subscription.Subscription) error {
-	return by.handleSpotSubscription("unsubscribe", channelsToUnsubscribe)
+func (by *Bybit) Unsubscribe(ctx context.Context, channelsToUnsubscribe []subscription.Subscription) error {
+	return by.handleSpotSubscription(ctx, "unsubscribe", channelsToUnsubscribe)
 }
 
-func (by *Bybit) handleSpotSubscription(operation string, channelsToSubscribe []subscription.Subscription) error {
+func (by *Bybit) handleSpotSubscription(ctx context.Context, operation string, channelsToSubscribe []subscription.Subscription) error {
 	payloads, err := by.handleSubscriptions(asset.Spot, operation, channelsToSubscribe)
 	if err != nil {
 		return err
@@ -216,12 +218,12 @@ func (by *Bybit) handleSpotSubscription(operation string, channelsToSubscribe []
 	for a := range payloads {
 		var response []byte
 		if payloads[a].auth {
-			response, err = by.Websocket.AuthConn.SendMessageReturnResponse(payloads[a].RequestID, payloads[a])
+			response, err = by.Websocket.AuthConn.SendMessageReturnResponseWithContext(ctx, payloads[a].RequestID, payloads[a])
 			if err != nil {
 				return err
 			}
 		} else {
-			response, err = by.Websocket.Conn.SendMessageReturnResponse(payloads[a].RequestID, payloads[a])
+			response, err = by.Websocket.Conn.SendMessageReturnResponseWithContext(ctx, payloads[a].RequestID, payloads[a])
 			if err != nil {
 				return err
 			}
@@ -381,7 +383,19 @@ func (by *Bybit) wsProcessWalletPushData(assetType asset.Item, resp []byte) erro
 		return err
 	}
 	accounts := []account.Change{}
+	margins := make([]account.MarginStatus, 0, len(result.Data))
 	for x := range result.Data {
+		margins = append(margins, account.MarginStatus{
+			Exchange:          by.Name,
+			Asset:             assetType,
+			Account:           result.Data[x].AccountType,
+			Currency:          currency.USD,
+			Equity:            result.Data[x].TotalMarginBalance.Float64(),
+			InitialMargin:     result.Data[x].TotalInitialMargin.Float64(),
+			MaintenanceMargin: result.Data[x].TotalMaintenanceMargin.Float64(),
+			AvailableBalance:  result.Data[x].TotalAvailableBalance.Float64(),
+			UpdateTime:        result.CreationTime.Time(),
+		})
 		for y := range result.Data[x].Coin {
 			accounts = append(accounts, account.Change{
 				Exchange: by.Name,
@@ -392,6 +406,7 @@ func (by *Bybit) wsProcessWalletPushData(assetType asset.Item, resp []byte) erro
 		}
 	}
 	by.Websocket.DataHandler <- accounts
+	by.Websocket.DataHandler <- margins
 	return nil
 }
 
@@ -638,6 +653,7 @@ func (by *Bybit) wsProcessPublicTicker(assetType asset.Item, resp *WebsocketResp
 			return err
 		}
 		cp = cp.Format(format)
+		by.wsProcessFundingRate(assetType, cp, &result, resp)
 		if resp.Type == "snapshot" {
 			return ticker.ProcessTicker(&ticker.Price{
 				Last:         result.LastPrice.Float64(),
@@ -674,17 +690,22 @@ func (by *Bybit) wsProcessPublicTicker(assetType asset.Item, resp *WebsocketResp
 		cp = cp.Format(format)
 		if resp.Type == "snapshot" {
 			return ticker.ProcessTicker(&ticker.Price{
-				Last:         result.LastPrice.Float64(),
-				High:         result.HighPrice24H.Float64(),
-				Low:          result.LastPrice.Float64(),
-				Bid:          result.BidPrice.Float64(),
-				BidSize:      result.BidSize.Float64(),
-				Ask:          result.AskPrice.Float64(),
-				AskSize:      result.AskSize.Float64(),
-				Volume:       result.Volume24H.Float64(),
-				Pair:         cp,
-				ExchangeName: by.Name,
-				AssetType:    assetType,
+				Last:              result.LastPrice.Float64(),
+				High:              result.HighPrice24H.Float64(),
+				Low:               result.LastPrice.Float64(),
+				Bid:               result.BidPrice.Float64(),
+				BidSize:           result.BidSize.Float64(),
+				Ask:               result.AskPrice.Float64(),
+				AskSize:           result.AskSize.Float64(),
+				Volume:            result.Volume24H.Float64(),
+				Delta:             result.Delta.Float64(),
+				Gamma:             result.Gamma.Float64(),
+				Vega:              result.Vega.Float64(),
+				Theta:             result.Theta.Float64(),
+				ImpliedVolatility: result.MarkPriceIv.Float64(),
+				Pair:              cp,
+				ExchangeName:      by.Name,
+				AssetType:         assetType,
 			})
 		}
 		tickerData, err := by.updateOptionsTickerInformation(&result, cp)
@@ -719,6 +740,38 @@ func (by *Bybit) updateSpotTickerInformation(result *WsSpotTicker, cp currency.P
 	return tickerData, nil
 }
 
+// wsProcessFundingRate sends a perpetual's funding rate to the data handler
+// when a ticker snapshot or delta carries it. Fields absent from a delta are
+// filled from the latest streamed rate
+func (by *Bybit) wsProcessFundingRate(assetType asset.Item, cp currency.Pair, result *WsLinearTicker, resp *WebsocketResponse) {
+	_, rateErr := jsonparser.GetUnsafeString(resp.Data, "fundingRate")
+	_, timeErr := jsonparser.GetUnsafeString(resp.Data, "nextFundingTime")
+	if rateErr != nil && timeErr != nil {
+		return
+	}
+	u := &fundingrate.Update{
+		Exchange:  by.Name,
+		Asset:     assetType,
+		Pair:      cp,
+		UpdatedAt: resp.Timestamp.Time(),
+	}
+	if latest, err := fundingrate.GetLatest(by.Name, cp, assetType); err == nil {
+		u.Rate = latest.Rate
+		u.TimeOfNextRate = latest.TimeOfNextRate
+	}
+	if rateErr == nil {
+		u.Rate = result.FundingRate.Decimal()
+	}
+	if timeErr == nil {
+		u.TimeOfNextRate = result.NextFundingTime.Time()
+	}
+	// Dated futures have no funding
+	if u.TimeOfNextRate.IsZero() {
+		return
+	}
+	by.Websocket.DataHandler <- u
+}
+
 func (by *Bybit) updateTickerInformation(result *WsLinearTicker, cp currency.Pair, assetType asset.Item) (*ticker.Price, error) {
 	tickerData, err := ticker.GetTicker(by.Name, cp, assetType)
 	if err != nil {
@@ -780,6 +833,21 @@ func (by *Bybit) updateOptionsTickerInformation(result *WsOptionTicker, cp curre
 	if result.AskSize.Float64() != 0 {
 		tickerData.AskSize = result.AskSize.Float64()
 	}
+	if result.Delta.Float64() != 0 {
+		tickerData.Delta = result.Delta.Float64()
+	}
+	if result.Gamma.Float64() != 0 {
+		tickerData.Gamma = result.Gamma.Float64()
+	}
+	if result.Vega.Float64() != 0 {
+		tickerData.Vega = result.Vega.Float64()
+	}
+	if result.Theta.Float64() != 0 {
+		tickerData.Theta = result.Theta.Float64()
+	}
+	if result.MarkPriceIv.Float64() != 0 {
+		tickerData.ImpliedVolatility = result.MarkPriceIv.Float64()
+	}
 	return tickerData, nil
 }
 
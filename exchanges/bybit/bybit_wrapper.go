@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -16,6 +17,7 @@ import (
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/calendar"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/deposit"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/fundingrate"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
@@ -53,8 +55,19 @@ func (by *Bybit) SetDefaults() {
 	if err != nil {
 		log.Errorf(log.ExchangeSys, "%v %v", asset.USDTMarginedFutures, err)
 	}
-	usdcMarginedFutures := currency.PairStore{RequestFormat: requestFormat,
-		ConfigFormat: &currency.PairFormat{Uppercase: true, Delimiter: currency.DashDelimiter}}
+	// USDC margined perpetuals are named e.g. BTCPERP while dated futures
+	// are named e.g. BTC-28JUN24
+	usdcFuturesGrammar, err := currency.NewInstrumentGrammar(
+		currency.InstrumentPattern{Kind: currency.InstrumentPerpetual, Template: "{base}PERP"},
+		currency.InstrumentPattern{Kind: currency.InstrumentFuture, Template: "{base}-{expiry:2Jan06}"},
+	)
+	if err != nil {
+		log.Errorf(log.ExchangeSys, "%v %v", asset.USDCMarginedFutures, err)
+	}
+	usdcMarginedFutures := currency.PairStore{
+		RequestFormat: &currency.PairFormat{Uppercase: true, Grammar: usdcFuturesGrammar},
+		ConfigFormat:  &currency.PairFormat{Uppercase: true, Delimiter: currency.DashDelimiter},
+	}
 	err = by.StoreAssetPairFormat(asset.USDCMarginedFutures, usdcMarginedFutures)
 	if err != nil {
 		log.Errorf(log.ExchangeSys, "%v %v", asset.USDCMarginedFutures, err)
@@ -132,6 +145,13 @@ func (by *Bybit) SetDefaults() {
 			},
 			WithdrawPermissions: exchange.AutoWithdrawCrypto |
 				exchange.AutoWithdrawFiat,
+			SelfTradePrevention: map[asset.Item]order.SelfTradePrevention{
+				asset.Spot:                supportedSMPTypes,
+				asset.USDTMarginedFutures: supportedSMPTypes,
+				asset.USDCMarginedFutures: supportedSMPTypes,
+				asset.CoinMarginedFutures: supportedSMPTypes,
+				asset.Options:             supportedSMPTypes,
+			},
 			Kline: kline.ExchangeCapabilitiesSupported{
 				Intervals: true,
 			},
@@ -324,16 +344,15 @@ func (by *Bybit) FetchTradablePairs(ctx context.Context, a asset.Item) (currency
 			pairs = append(pairs, pair)
 		}
 	case asset.USDCMarginedFutures:
+		requestFormat, err := by.GetPairFormat(a, true)
+		if err != nil {
+			return nil, err
+		}
 		for x := range allPairs {
 			if allPairs[x].Status != "Trading" || allPairs[x].QuoteCoin != "USDC" {
 				continue
 			}
-			if strings.EqualFold(allPairs[x].ContractType, "linearfutures") {
-				// long-dated contracts have a delimiter
-				pair, err = currency.NewPairFromString(allPairs[x].Symbol)
-			} else {
-				pair, err = currency.NewPairFromStrings(allPairs[x].BaseCoin, allPairs[x].Symbol[len(allPairs[x].BaseCoin):])
-			}
+			pair, err = requestFormat.Grammar.ParsePair(allPairs[x].Symbol)
 			if err != nil {
 				return nil, err
 			}
@@ -448,17 +467,22 @@ func (by *Bybit) UpdateTickers(ctx context.Context, assetType asset.Item) error
 					continue
 				}
 				err = ticker.ProcessTicker(&ticker.Price{
-					Last:         ticks.List[x].LastPrice.Float64(),
-					High:         ticks.List[x].HighPrice24H.Float64(),
-					Low:          ticks.List[x].LowPrice24H.Float64(),
-					Bid:          ticks.List[x].Bid1Price.Float64(),
-					BidSize:      ticks.List[x].Bid1Size.Float64(),
-					Ask:          ticks.List[x].Ask1Price.Float64(),
-					AskSize:      ticks.List[x].Ask1Size.Float64(),
-					Volume:       ticks.List[x].Volume24H.Float64(),
-					Pair:         pair.Format(format),
-					ExchangeName: by.Name,
-					AssetType:    assetType,
+					Last:              ticks.List[x].LastPrice.Float64(),
+					High:              ticks.List[x].HighPrice24H.Float64(),
+					Low:               ticks.List[x].LowPrice24H.Float64(),
+					Bid:               ticks.List[x].Bid1Price.Float64(),
+					BidSize:           ticks.List[x].Bid1Size.Float64(),
+					Ask:               ticks.List[x].Ask1Price.Float64(),
+					AskSize:           ticks.List[x].Ask1Size.Float64(),
+					Volume:            ticks.List[x].Volume24H.Float64(),
+					Delta:             ticks.List[x].Delta.Float64(),
+					Gamma:             ticks.List[x].Gamma.Float64(),
+					Vega:              ticks.List[x].Vega.Float64(),
+					Theta:             ticks.List[x].Theta.Float64(),
+					ImpliedVolatility: ticks.List[x].MarkIv.Float64(),
+					Pair:              pair.Format(format),
+					ExchangeName:      by.Name,
+					AssetType:         assetType,
 				})
 				if err != nil {
 					return err
@@ -522,9 +546,6 @@ func (by *Bybit) UpdateOrderbook(ctx context.Context, p currency.Pair, assetType
 		asset.USDCMarginedFutures,
 		asset.CoinMarginedFutures,
 		asset.Options:
-		if assetType == asset.USDCMarginedFutures && !p.Quote.Equal(currency.PERP) {
-			p.Delimiter = currency.DashDelimiter
-		}
 		orderbookNew, err = by.GetOrderBook(ctx, getCategoryName(assetType), p.String(), 0)
 	default:
 		return nil, fmt.Errorf("%s %w", assetType, asset.ErrNotSupported)
@@ -683,9 +704,6 @@ func (by *Bybit) GetRecentTrades(ctx context.Context, p currency.Pair, assetType
 	var tradeData *TradingHistory
 	switch assetType {
 	case asset.Spot, asset.USDTMarginedFutures, asset.USDCMarginedFutures, asset.CoinMarginedFutures:
-		if assetType == asset.USDCMarginedFutures && !p.Quote.Equal(currency.PERP) {
-			formattedPair.Delimiter = currency.DashDelimiter
-		}
 		tradeData, err = by.GetPublicTradingHistory(ctx, getCategoryName(assetType), formattedPair.String(), "", "", limit)
 	case asset.Options:
 		tradeData, err = by.GetPublicTradingHistory(ctx, getCategoryName(assetType), formattedPair.String(), formattedPair.Base.String(), "", limit)
@@ -738,9 +756,6 @@ func (by *Bybit) GetHistoricTrades(ctx context.Context, p currency.Pair, assetTy
 	var tradeHistoryResponse *TradingHistory
 	switch assetType {
 	case asset.Spot, asset.USDTMarginedFutures, asset.USDCMarginedFutures, asset.CoinMarginedFutures:
-		if assetType == asset.USDCMarginedFutures && !p.Quote.Equal(currency.PERP) {
-			p.Delimiter = currency.DashDelimiter
-		}
 		tradeHistoryResponse, err = by.GetPublicTradingHistory(ctx, getCategoryName(assetType), p.String(), "", "", limit)
 		if err != nil {
 			return nil, err
@@ -784,9 +799,41 @@ func orderTypeToString(oType order.Type) string {
 	}
 }
 
+// smpTypeToString returns the self trade prevention type for an order
+// submission, leaving it empty for the account default when unset
+func smpTypeToString(stp order.SelfTradePrevention) string {
+	switch stp {
+	case order.STPCancelMaker:
+		return "CancelMaker"
+	case order.STPCancelTaker:
+		return "CancelTaker"
+	case order.STPCancelBoth:
+		return "CancelBoth"
+	default:
+		return ""
+	}
+}
+
+// timeInForceToString returns the time in force for an order submission,
+// leaving it empty for the exchange default when unset
+func timeInForceToString(s *order.Submit) string {
+	switch {
+	case s.PostOnly:
+		return "PostOnly"
+	case s.TimeInForce == order.IOC:
+		return "IOC"
+	case s.TimeInForce == order.FOK:
+		return "FOK"
+	case s.TimeInForce == order.GTC:
+		return "GTC"
+	default:
+		return ""
+	}
+}
+
 // SubmitOrder submits a new order
 func (by *Bybit) SubmitOrder(ctx context.Context, s *order.Submit) (*order.SubmitResponse, error) {
-	err := s.Validate()
+	err := s.Validate(s.TimeInForceSupported(order.GTC|order.IOC|order.FOK, true), s.SelfTradePreventionSupported(supportedSMPTypes))
 	if err != nil {
 		return nil, err
 	}
@@ -806,20 +853,19 @@ func (by *Bybit) SubmitOrder(ctx context.Context, s *order.Submit) (*order.Submi
 	status := order.New
 	switch s.AssetType {
 	case asset.Spot, asset.Options, asset.USDTMarginedFutures, asset.USDCMarginedFutures, asset.CoinMarginedFutures:
-		if s.AssetType == asset.USDCMarginedFutures && !formattedPair.Quote.Equal(currency.PERP) {
-			formattedPair.Delimiter = currency.DashDelimiter
-		}
 		var response *OrderResponse
 		arg := &PlaceOrderParams{
-			Category:        getCategoryName(s.AssetType),
-			Symbol:          formattedPair,
-			Side:            sideType,
-			OrderType:       orderTypeToString(s.Type),
-			OrderQuantity:   s.Amount,
-			Price:           s.Price,
-			OrderLinkID:     s.ClientOrderID,
-			WhetherToBorrow: s.AssetType == asset.Margin,
-			ReduceOnly:      s.ReduceOnly,
+			Category:         getCategoryName(s.AssetType),
+			Symbol:           formattedPair,
+			Side:             sideType,
+			OrderType:        orderTypeToString(s.Type),
+			TimeInForce:      timeInForceToString(s),
+			SMPExecutionType: smpTypeToString(s.SelfTradePrevention),
+			OrderQuantity:    s.Amount,
+			Price:            s.Price,
+			OrderLinkID:      s.ClientOrderID,
+			WhetherToBorrow:  s.AssetType == asset.Margin,
+			ReduceOnly:       s.ReduceOnly,
 			OrderFilter: func() string {
 				if s.RiskManagementModes.TakeProfit.Price != 0 || s.RiskManagementModes.TakeProfit.LimitPrice != 0 ||
 					s.RiskManagementModes.StopLoss.Price != 0 || s.RiskManagementModes.StopLoss.LimitPrice != 0 {
@@ -861,6 +907,87 @@ func (by *Bybit) SubmitOrder(ctx context.Context, s *order.Submit) (*order.Submi
 	}
 }
 
+// SubmitOrders submits USDT or USDC margined futures or options orders of the
+// same asset type in a single batch request. Each order requires a client
+// order ID
+func (by *Bybit) SubmitOrders(ctx context.Context, s []order.Submit) ([]*order.SubmitResponse, error) {
+	if len(s) == 0 {
+		return nil, fmt.Errorf("%w, must have at least 1 order", order.ErrSubmissionIsNil)
+	}
+	category := getCategoryName(s[0].AssetType)
+	maxOrders := 10
+	switch category {
+	case cLinear:
+	case cOption:
+		maxOrders = 20
+	default:
+		return nil, fmt.Errorf("%w for %s batch orders", common.ErrFunctionNotSupported, s[0].AssetType)
+	}
+	if len(s) > maxOrders {
+		return nil, fmt.Errorf("%w, cannot submit more than %d %s orders", errBatchOrderLimitExceeded, maxOrders, s[0].AssetType)
+	}
+	arg := &PlaceBatchOrderParam{
+		Category: category,
+		Request:  make([]BatchOrderItemParam, len(s)),
+	}
+	for i := range s {
+		if s[i].AssetType != s[0].AssetType {
+			return nil, fmt.Errorf("%w for batch orders with mixed asset types", common.ErrFunctionNotSupported)
+		}
+		if err := s[i].Validate(s[i].TimeInForceSupported(order.GTC|order.IOC|order.FOK, true), s[i].SelfTradePreventionSupported(supportedSMPTypes)); err != nil {
+			return nil, err
+		}
+		if s[i].ClientOrderID == "" {
+			return nil, errOrderLinkIDMissing
+		}
+		var sideType string
+		switch {
+		case s[i].Side.IsLong():
+			sideType = sideBuy
+		case s[i].Side.IsShort():
+			sideType = sideSell
+		default:
+			return nil, order.ErrSideIsInvalid
+		}
+		formattedPair, err := by.FormatExchangeCurrency(s[i].Pair, s[i].AssetType)
+		if err != nil {
+			return nil, err
+		}
+		arg.Request[i] = BatchOrderItemParam{
+			Symbol:        formattedPair,
+			OrderType:     orderTypeToString(s[i].Type),
+			Side:          sideType,
+			OrderQuantity: s[i].Amount,
+			Price:         s[i].Price,
+			TimeInForce:   timeInForceToString(&s[i]),
+			SMPType:       smpTypeToString(s[i].SelfTradePrevention),
+			OrderLinkID:   s[i].ClientOrderID,
+			ReduceOnly:    s[i].ReduceOnly,
+		}
+	}
+	placed, err := by.PlaceBatchOrder(ctx, arg)
+	if err != nil {
+		return nil, err
+	}
+	orderIDs := make(map[string]string, len(placed))
+	for i := range placed {
+		orderIDs[placed[i].OrderLinkID] = placed[i].OrderID
+	}
+	resp := make([]*order.SubmitResponse, len(s))
+	var errs error
+	for i := range s {
+		orderID := orderIDs[s[i].ClientOrderID]
+		if orderID == "" {
+			errs = common.AppendError(errs, fmt.Errorf("order %d client order ID %s: %w", i, s[i].ClientOrderID, errBatchOrderRejected))
+			continue
+		}
+		if resp[i], err = s[i].DeriveSubmitResponse(orderID); err != nil {
+			errs = common.AppendError(errs, fmt.Errorf("order %d: %w", i, err))
+		}
+	}
+	return resp, errs
+}
+
 func getOrderTypeString(oType order.Type) string {
 	switch oType {
 	case order.UnknownType:
@@ -886,9 +1013,6 @@ func (by *Bybit) ModifyOrder(ctx context.Context, action *order.Modify) (*order.
 	}
 	switch action.AssetType {
 	case asset.Spot, asset.USDTMarginedFutures, asset.USDCMarginedFutures, asset.CoinMarginedFutures, asset.Options:
-		if action.AssetType == asset.USDCMarginedFutures && !action.Pair.Quote.Equal(currency.PERP) {
-			action.Pair.Delimiter = currency.DashDelimiter
-		}
 		arg := &AmendOrderParams{
 			Category:             getCategoryName(action.AssetType),
 			Symbol:               action.Pair,
@@ -934,9 +1058,6 @@ func (by *Bybit) CancelOrder(ctx context.Context, ord *order.Cancel) error {
 	}
 	switch ord.AssetType {
 	case asset.Spot, asset.USDTMarginedFutures, asset.USDCMarginedFutures, asset.CoinMarginedFutures, asset.Options:
-		if ord.AssetType == asset.USDCMarginedFutures && !ord.Pair.Quote.Equal(currency.PERP) {
-			ord.Pair.Delimiter = currency.DashDelimiter
-		}
 		_, err = by.CancelTradeOrder(ctx, &CancelOrderParams{
 			Category:    getCategoryName(ord.AssetType),
 			Symbol:      ord.Pair.Format(format),
@@ -1011,9 +1132,6 @@ func (by *Bybit) CancelAllOrders(ctx context.Context, orderCancellation *order.C
 	cancelAllOrdersResponse.Status = make(map[string]string)
 	switch orderCancellation.AssetType {
 	case asset.Spot, asset.USDTMarginedFutures, asset.USDCMarginedFutures, asset.CoinMarginedFutures, asset.Options:
-		if orderCancellation.AssetType == asset.USDCMarginedFutures && !orderCancellation.Pair.Quote.Equal(currency.PERP) {
-			orderCancellation.Pair.Delimiter = currency.DashDelimiter
-		}
 		activeOrder, err := by.CancelAllTradeOrders(ctx, &CancelAllOrdersParam{
 			Category: getCategoryName(orderCancellation.AssetType),
 			Symbol:   orderCancellation.Pair,
@@ -1046,9 +1164,6 @@ func (by *Bybit) GetOrderInfo(ctx context.Context, orderID string, pair currency
 
 	switch assetType {
 	case asset.Spot, asset.USDTMarginedFutures, asset.USDCMarginedFutures, asset.CoinMarginedFutures, asset.Options:
-		if assetType == asset.USDCMarginedFutures && !pair.Quote.Equal(currency.PERP) {
-			pair.Delimiter = currency.DashDelimiter
-		}
 		resp, err := by.GetOpenOrders(ctx, getCategoryName(assetType), pair.String(), "", "", orderID, "", "", "", 0, 1)
 		if err != nil {
 			return nil, err
@@ -1195,9 +1310,6 @@ func (by *Bybit) GetActiveOrders(ctx context.Context, req *order.MultiOrderReque
 			orders = append(orders, newOpenOrders...)
 		} else {
 			for y := range req.Pairs {
-				if req.AssetType == asset.USDCMarginedFutures && !req.Pairs[y].Quote.Equal(currency.PERP) {
-					req.Pairs[y].Delimiter = currency.DashDelimiter
-				}
 				openOrders, err := by.GetOpenOrders(ctx, getCategoryName(req.AssetType), req.Pairs[y].String(), "", "", req.FromOrderID, "", "", "", 0, 50)
 				if err != nil {
 					return nil, err
@@ -1447,9 +1559,6 @@ func (by *Bybit) GetHistoricCandles(ctx context.Context, pair currency.Pair, a a
 			return nil, err
 		}
 		var timeSeries []kline.Candle
-		if a == asset.USDCMarginedFutures && !pair.Quote.Equal(currency.PERP) {
-			req.RequestFormatted.Delimiter = currency.DashDelimiter
-		}
 		var candles []KlineItem
 		candles, err = by.GetKlines(ctx, getCategoryName(req.Asset), req.RequestFormatted.String(), req.ExchangeInterval, req.Start, req.End, req.RequestLimit)
 		if err != nil {
@@ -1483,9 +1592,6 @@ func (by *Bybit) GetHistoricCandlesExtended(ctx context.Context, pair currency.P
 		}
 		timeSeries := make([]kline.Candle, 0, req.Size())
 		for x := range req.RangeHolder.Ranges {
-			if req.Asset == asset.USDCMarginedFutures && !req.RequestFormatted.Quote.Equal(currency.PERP) {
-				req.RequestFormatted.Delimiter = currency.DashDelimiter
-			}
 			var klineItems []KlineItem
 			klineItems, err = by.GetKlines(ctx,
 				getCategoryName(req.Asset),
@@ -1583,9 +1689,6 @@ func (by *Bybit) SetLeverage(ctx context.Context, item asset.Item, pair currency
 		if err != nil {
 			return err
 		}
-		if item == asset.USDCMarginedFutures && !pair.Quote.Equal(currency.PERP) {
-			pair.Delimiter = currency.DashDelimiter
-		}
 		params := &SetLeverageParams{
 			Category: getCategoryName(item),
 			Symbol:   pair.String(),
@@ -2024,3 +2127,155 @@ func (by *Bybit) GetOpenInterest(ctx context.Context, k ...key.PairAsset) ([]fut
 	}
 	return resp, nil
 }
+
+// GetInsuranceFunds returns the insurance pool balances which settle the
+// asset type's contracts
+func (by *Bybit) GetInsuranceFunds(ctx context.Context, item asset.Item) ([]futures.InsuranceFund, error) {
+	if item != asset.USDTMarginedFutures && item != asset.USDCMarginedFutures && item != asset.CoinMarginedFutures {
+		return nil, fmt.Errorf("%w %v", asset.ErrNotSupported, item)
+	}
+	resp, err := by.GetInsurance(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	funds := make([]futures.InsuranceFund, 0, len(resp.List))
+	for i := range resp.List {
+		code := currency.NewCode(resp.List[i].Coin)
+		isStable := code.Equal(currency.USDT) || code.Equal(currency.USDC)
+		switch {
+		case item == asset.USDTMarginedFutures && !code.Equal(currency.USDT),
+			item == asset.USDCMarginedFutures && !code.Equal(currency.USDC),
+			item == asset.CoinMarginedFutures && isStable:
+			continue
+		}
+		funds = append(funds, futures.InsuranceFund{
+			Exchange: by.Name,
+			Asset:    item,
+			Currency: code,
+			Balance:  resp.List[i].Balance.Float64(),
+			Value:    resp.List[i].Value.Float64(),
+			Time:     resp.UpdatedTime.Time(),
+		})
+	}
+	return funds, nil
+}
+
+// GetADLIndicators returns the auto-deleveraging rank of the account's open
+// positions for the asset type
+func (by *Bybit) GetADLIndicators(ctx context.Context, item asset.Item) ([]futures.ADLIndicator, error) {
+	var settleCoins []string
+	switch item {
+	case asset.USDTMarginedFutures:
+		settleCoins = []string{currency.USDT.String()}
+	case asset.USDCMarginedFutures:
+		settleCoins = []string{currency.USDC.String()}
+	case asset.CoinMarginedFutures:
+		// inverse contracts settle in their base currency
+		enabled, err := by.GetEnabledPairs(item)
+		if err != nil {
+			return nil, err
+		}
+		for i := range enabled {
+			if code := enabled[i].Base.Upper().String(); !slices.Contains(settleCoins, code) {
+				settleCoins = append(settleCoins, code)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%w %v", asset.ErrNotSupported, item)
+	}
+	var resp []futures.ADLIndicator
+	for i := range settleCoins {
+		var cursor string
+		for {
+			positions, err := by.GetPositionInfo(ctx, getCategoryName(item), "", "", settleCoins[i], cursor, 200)
+			if err != nil {
+				return nil, err
+			}
+			for j := range positions.List {
+				if positions.List[j].Size.Float64() == 0 {
+					continue
+				}
+				// only long-dated contracts have a delimiter
+				pair, err := by.MatchSymbolWithAvailablePairs(positions.List[j].Symbol, item, strings.Contains(positions.List[j].Symbol, currency.DashDelimiter))
+				if err != nil {
+					return nil, err
+				}
+				side := order.Long
+				if positions.List[j].Side == sideSell {
+					side = order.Short
+				}
+				resp = append(resp, futures.ADLIndicator{
+					Exchange: by.Name,
+					Asset:    item,
+					Pair:     pair,
+					Side:     side,
+					Rank:     positions.List[j].ADLRankIndicator,
+					MaxRank:  maxADLRank,
+					Time:     positions.List[j].UpdatedTime.Time(),
+				})
+			}
+			if positions.NextPageCursor == "" || positions.NextPageCursor == cursor {
+				break
+			}
+			cursor = positions.NextPageCursor
+		}
+	}
+	return resp, nil
+}
+
+// GetScheduledEvents returns contracts awaiting launch and perpetual contracts
+// with a delivery time, which Bybit sets when a perpetual is to be delisted.
+// Dated futures expiries are returned by GetFuturesContractDetails
+func (by *Bybit) GetScheduledEvents(ctx context.Context) ([]calendar.Event, error) {
+	enabled := by.GetAssetTypes(true)
+	var events []calendar.Event
+	now := time.Now()
+	for _, category := range []string{"linear", "inverse"} {
+		if !slices.ContainsFunc(enabled, func(a asset.Item) bool { return getCategoryName(a) == category }) {
+			continue
+		}
+		for _, status := range []string{"PreLaunch", "Trading"} {
+			resp, err := by.GetInstrumentInfo(ctx, category, "", status, "", "", 1000)
+			if err != nil {
+				return nil, err
+			}
+			for i := range resp.List {
+				inst := &resp.List[i]
+				e := calendar.Event{Exchange: by.Name, Asset: instrumentAsset(category, inst.SettleCoin)}
+				if !slices.Contains(enabled, e.Asset) {
+					continue
+				}
+				switch {
+				case status == "PreLaunch" && inst.LaunchTime.Time().After(now):
+					e.Type, e.Start = calendar.Listing, inst.LaunchTime.Time()
+				case strings.HasSuffix(inst.ContractType, "Perpetual") && inst.DeliveryTime.Time().After(now):
+					e.Type, e.Start = calendar.Delisting, inst.DeliveryTime.Time()
+				default:
+					continue
+				}
+				e.Pair, err = by.MatchSymbolWithAvailablePairs(inst.Symbol, e.Asset, strings.Contains(inst.Symbol, currency.DashDelimiter))
+				if err != nil {
+					// contracts awaiting launch are not yet available pairs
+					e.Pair, err = currency.NewPairFromStrings(inst.BaseCoin, inst.Symbol[len(inst.BaseCoin):])
+					if err != nil {
+						return nil, err
+					}
+				}
+				events = append(events, e)
+			}
+		}
+	}
+	return events, nil
+}
+
+// instrumentAsset returns the asset type of a linear or inverse instrument
+func instrumentAsset(category, settleCoin string) asset.Item {
+	switch {
+	case category == "inverse":
+		return asset.CoinMarginedFutures
+	case settleCoin == "USDC":
+		return asset.USDCMarginedFutures
+	default:
+		return asset.USDTMarginedFutures
+	}
+}
@@ -0,0 +1,84 @@
+package calendar
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+// String returns the string representation of the event type
+func (t EventType) String() string {
+	switch t {
+	case Expiry:
+		return expiryStr
+	case Listing:
+		return listingStr
+	case Delisting:
+		return delistingStr
+	case Maintenance:
+		return maintenanceStr
+	}
+	return unknownStr
+}
+
+// StringToEventType converts a string to an event type
+func StringToEventType(s string) (EventType, error) {
+	switch strings.ToLower(s) {
+	case expiryStr:
+		return Expiry, nil
+	case listingStr:
+		return Listing, nil
+	case delistingStr:
+		return Delisting, nil
+	case maintenanceStr:
+		return Maintenance, nil
+	}
+	return UnknownEvent, fmt.Errorf("%w %q", ErrInvalidEventType, s)
+}
+
+// Key returns a key unique to a scheduled event
+func (e *Event) Key() string {
+	return strings.ToLower(e.Exchange) + ":" + e.Asset.String() + ":" + e.Pair.Lower().String() + ":" + e.Type.String() + ":" + e.Start.UTC().Format(time.RFC3339)
+}
+
+// Occurs returns whether the event takes place within a period. Events
+// without an end occur only at their start. A zero start or end leaves that
+// side of the period unbounded
+func (e *Event) Occurs(start, end time.Time) bool {
+	eventEnd := e.End
+	if eventEnd.IsZero() {
+		eventEnd = e.Start
+	}
+	if !start.IsZero() && eventEnd.Before(start) {
+		return false
+	}
+	return end.IsZero() || !e.Start.After(end)
+}
+
+// Active returns whether a window event such as maintenance is in progress
+func (e *Event) Active(t time.Time) bool {
+	return !e.End.IsZero() && !t.Before(e.Start) && t.Before(e.End)
+}
+
+// Match returns whether an event matches the filter
+func (f *Filter) Match(e *Event) bool {
+	if f == nil {
+		return true
+	}
+	if f.Exchange != "" && !strings.EqualFold(f.Exchange, e.Exchange) {
+		return false
+	}
+	if f.Asset != asset.Empty && e.Asset != asset.Empty && f.Asset != e.Asset {
+		return false
+	}
+	if !f.Pair.IsEmpty() && !e.Pair.IsEmpty() && !f.Pair.Equal(e.Pair) {
+		return false
+	}
+	if len(f.Types) > 0 && !slices.Contains(f.Types, e.Type) {
+		return false
+	}
+	return e.Occurs(f.Start, f.End)
+}
@@ -0,0 +1,87 @@
+package calendar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+func TestEventTypeString(t *testing.T) {
+	t.Parallel()
+	for _, et := range []EventType{Expiry, Listing, Delisting, Maintenance} {
+		got, err := StringToEventType(et.String())
+		require.NoError(t, err, "StringToEventType must not error")
+		assert.Equal(t, et, got, "StringToEventType should return the original event type")
+	}
+	assert.Equal(t, unknownStr, UnknownEvent.String())
+	got, err := StringToEventType("MAINTENANCE")
+	require.NoError(t, err, "StringToEventType must not error")
+	assert.Equal(t, Maintenance, got, "StringToEventType should be case insensitive")
+	_, err = StringToEventType("meow")
+	assert.ErrorIs(t, err, ErrInvalidEventType)
+}
+
+func TestEventKey(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2024, 6, 28, 8, 0, 0, 0, time.UTC)
+	a := Event{Exchange: "Okx", Asset: asset.Futures, Pair: currency.NewBTCUSDT(), Type: Expiry, Start: start}
+	b := Event{Exchange: "okx", Asset: asset.Futures, Pair: currency.NewBTCUSDT(), Type: Expiry, Start: start.In(time.FixedZone("x", 3600))}
+	assert.Equal(t, a.Key(), b.Key(), "Key should ignore exchange case and time zone")
+	b.Type = Delisting
+	assert.NotEqual(t, a.Key(), b.Key(), "Key should differ by event type")
+}
+
+func TestEventOccurs(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2024, 6, 28, 8, 0, 0, 0, time.UTC)
+	point := Event{Start: start}
+	assert.True(t, point.Occurs(time.Time{}, time.Time{}), "unbounded periods should contain all events")
+	assert.True(t, point.Occurs(start.Add(-time.Hour), start.Add(time.Hour)))
+	assert.False(t, point.Occurs(start.Add(time.Minute), time.Time{}), "events before the period should not occur")
+	assert.False(t, point.Occurs(time.Time{}, start.Add(-time.Minute)), "events after the period should not occur")
+
+	window := Event{Start: start, End: start.Add(time.Hour * 2)}
+	assert.True(t, window.Occurs(start.Add(time.Hour), start.Add(time.Hour*3)), "overlapping windows should occur")
+	assert.False(t, window.Occurs(start.Add(time.Hour*3), time.Time{}))
+}
+
+func TestEventActive(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2024, 6, 28, 8, 0, 0, 0, time.UTC)
+	window := Event{Start: start, End: start.Add(time.Hour)}
+	assert.True(t, window.Active(start))
+	assert.True(t, window.Active(start.Add(time.Minute)))
+	assert.False(t, window.Active(start.Add(time.Hour)), "windows should not be active at their end")
+	assert.False(t, window.Active(start.Add(-time.Minute)))
+	point := Event{Start: start}
+	assert.False(t, point.Active(start), "events without an end should never be active")
+}
+
+func TestFilterMatch(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2024, 6, 28, 8, 0, 0, 0, time.UTC)
+	expiry := &Event{Exchange: "okx", Asset: asset.Futures, Pair: currency.NewBTCUSDT(), Type: Expiry, Start: start}
+	maintenance := &Event{Exchange: "okx", Type: Maintenance, Start: start, End: start.Add(time.Hour)}
+
+	var f *Filter
+	assert.True(t, f.Match(expiry), "nil filters should match all events")
+	f = &Filter{Exchange: "OKX", Asset: asset.Futures, Pair: currency.NewBTCUSDT()}
+	assert.True(t, f.Match(expiry))
+	assert.True(t, f.Match(maintenance), "exchange wide events should match any asset and pair")
+	f.Exchange = "bybit"
+	assert.False(t, f.Match(expiry))
+	f = &Filter{Asset: asset.PerpetualSwap}
+	assert.False(t, f.Match(expiry))
+	f = &Filter{Pair: currency.NewPair(currency.ETH, currency.USDT)}
+	assert.False(t, f.Match(expiry))
+	f = &Filter{Types: []EventType{Maintenance}}
+	assert.False(t, f.Match(expiry))
+	assert.True(t, f.Match(maintenance))
+	f = &Filter{Start: start.Add(time.Minute)}
+	assert.False(t, f.Match(expiry), "events before the filter period should not match")
+	assert.True(t, f.Match(maintenance), "windows overlapping the filter period should match")
+}
@@ -0,0 +1,60 @@
+package calendar
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+// ErrInvalidEventType is returned when an event type is not recognised
+var ErrInvalidEventType = errors.New("invalid calendar event type")
+
+// EventType is the kind of a scheduled exchange event
+type EventType uint8
+
+// Event types
+const (
+	UnknownEvent EventType = iota
+	Expiry
+	Listing
+	Delisting
+	Maintenance
+)
+
+const (
+	expiryStr      = "expiry"
+	listingStr     = "listing"
+	delistingStr   = "delisting"
+	maintenanceStr = "maintenance"
+	unknownStr     = "unknown"
+)
+
+// Event is a scheduled exchange event such as a contract expiry, a pair
+// listing or delisting, or a maintenance window
+type Event struct {
+	Exchange string
+	// Asset and Pair are empty for events affecting a whole exchange
+	Asset asset.Item
+	Pair  currency.Pair
+	Type  EventType
+	Start time.Time
+	// End is the end of a window such as maintenance, and is zero for events
+	// which occur at a point in time or when the end is unknown
+	End         time.Time
+	Description string
+}
+
+// Filter limits the events returned from a calendar. Empty fields match all
+// events and events affecting a whole exchange or asset match any asset or
+// pair
+type Filter struct {
+	Exchange string
+	Asset    asset.Item
+	Pair     currency.Pair
+	Types    []EventType
+	// Start and End restrict events to those occurring within the period
+	Start time.Time
+	End   time.Time
+}
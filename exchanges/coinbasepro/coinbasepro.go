@@ -50,6 +50,8 @@ const (
 	coinbaseproWithdrawalCrypto        = "withdrawals/crypto"
 	coinbaseproCoinbaseAccounts        = "coinbase-accounts"
 	coinbaseproTrailingVolume          = "users/self/trailing-volume"
+
+	supportedSTPModes = order.STPCancelMaker | order.STPCancelTaker | order.STPCancelBoth | order.STPDecrement
 )
 
 // CoinbasePro is the overarching type across the coinbasepro package
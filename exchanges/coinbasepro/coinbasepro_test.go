@@ -688,9 +688,9 @@ func TestWsAuth(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	go c.wsReadData()
+	go c.wsReadData(context.Background())
 
-	err = c.Subscribe([]subscription.Subscription{
+	err = c.Subscribe(context.Background(), []subscription.Subscription{
 		{
 			Channel: "user",
 			Pair:    testPair,
@@ -736,7 +736,7 @@ func TestWsSubscribe(t *testing.T) {
 			}
 		]
 	}`)
-	err := c.wsHandleData(pressXToJSON)
+	err := c.wsHandleData(context.Background(), pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -750,7 +750,7 @@ func TestWsHeartbeat(t *testing.T) {
 		"product_id": "BTC-USD",
 		"time": "2014-11-07T08:19:28.464459Z"
 	}`)
-	err := c.wsHandleData(pressXToJSON)
+	err := c.wsHandleData(context.Background(), pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -808,7 +808,7 @@ func TestWsStatus(t *testing.T) {
         }
     ]
 }`)
-	err := c.wsHandleData(pressXToJSON)
+	err := c.wsHandleData(context.Background(), pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -827,7 +827,7 @@ func TestWsTicker(t *testing.T) {
     "best_bid": "4388",
     "best_ask": "4388.01"
 }`)
-	err := c.wsHandleData(pressXToJSON)
+	err := c.wsHandleData(context.Background(), pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -841,7 +841,7 @@ func TestWsOrderbook(t *testing.T) {
     "asks": [["10102.55", "0.57753524"]],
 	"time":"2023-08-15T06:46:55.376250Z"
 }`)
-	err := c.wsHandleData(pressXToJSON)
+	err := c.wsHandleData(context.Background(), pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -858,7 +858,7 @@ func TestWsOrderbook(t *testing.T) {
     ]
   ]
 }`)
-	err = c.wsHandleData(pressXToJSON)
+	err = c.wsHandleData(context.Background(), pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -876,7 +876,7 @@ func TestWsOrders(t *testing.T) {
     "side": "buy",
     "order_type": "limit"
 }`)
-	err := c.wsHandleData(pressXToJSON)
+	err := c.wsHandleData(context.Background(), pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -891,7 +891,7 @@ func TestWsOrders(t *testing.T) {
     "side": "buy",
     "order_type": "market"
 }`)
-	err = c.wsHandleData(pressXToJSON)
+	err = c.wsHandleData(context.Background(), pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -906,7 +906,7 @@ func TestWsOrders(t *testing.T) {
     "remaining_size": "1.00",
     "side": "sell"
 }`)
-	err = c.wsHandleData(pressXToJSON)
+	err = c.wsHandleData(context.Background(), pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -922,7 +922,7 @@ func TestWsOrders(t *testing.T) {
     "side": "sell",
     "remaining_size": "0"
 }`)
-	err = c.wsHandleData(pressXToJSON)
+	err = c.wsHandleData(context.Background(), pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -939,7 +939,7 @@ func TestWsOrders(t *testing.T) {
     "price": "400.23",
     "side": "sell"
 }`)
-	err = c.wsHandleData(pressXToJSON)
+	err = c.wsHandleData(context.Background(), pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -955,7 +955,7 @@ func TestWsOrders(t *testing.T) {
     "price": "400.23",
     "side": "sell"
 }`)
-	err = c.wsHandleData(pressXToJSON)
+	err = c.wsHandleData(context.Background(), pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -970,7 +970,7 @@ func TestWsOrders(t *testing.T) {
     "price": "400.23",
     "side": "sell"
 }`)
-	err = c.wsHandleData(pressXToJSON)
+	err = c.wsHandleData(context.Background(), pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -989,7 +989,7 @@ func TestWsOrders(t *testing.T) {
   "taker_fee_rate": "0.0025",
   "private": true
 }`)
-	err = c.wsHandleData(pressXToJSON)
+	err = c.wsHandleData(context.Background(), pressXToJSON)
 	if err != nil {
 		t.Error(err)
 	}
@@ -1056,3 +1056,18 @@ func TestGetTransfers(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestSTPToString(t *testing.T) {
+	t.Parallel()
+	for stp, expected := range map[order.SelfTradePrevention]string{
+		order.UnsetSelfTradePrevention: "",
+		order.STPCancelMaker:           "co",
+		order.STPCancelTaker:           "cn",
+		order.STPCancelBoth:            "cb",
+		order.STPDecrement:             "dc",
+	} {
+		if result := stpToString(stp); result != expected {
+			t.Errorf("received '%v' expected '%v' for %s", result, expected, stp)
+		}
+	}
+}
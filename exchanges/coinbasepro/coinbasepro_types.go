@@ -498,6 +498,9 @@ var (
 
 	// CoinbaseRequestParamsTimeIOC IOC
 	CoinbaseRequestParamsTimeIOC = RequestParamsTimeForceType("IOC")
+
+	// CoinbaseRequestParamsTimeFOK FOK
+	CoinbaseRequestParamsTimeFOK = RequestParamsTimeForceType("FOK")
 )
 
 // TransferHistory returns wallet transfer history
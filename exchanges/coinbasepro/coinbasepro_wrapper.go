@@ -86,6 +86,9 @@ func (c *CoinbasePro) SetDefaults() {
 			},
 			WithdrawPermissions: exchange.AutoWithdrawCryptoWithAPIPermission |
 				exchange.AutoWithdrawFiatWithAPIPermission,
+			SelfTradePrevention: map[asset.Item]order.SelfTradePrevention{
+				asset.Spot: supportedSTPModes,
+			},
 			Kline: kline.ExchangeCapabilitiesSupported{
 				DateRanges: true,
 				Intervals:  true,
@@ -432,7 +435,7 @@ func (c *CoinbasePro) GetHistoricTrades(_ context.Context, _ currency.Pair, _ as
 
 // SubmitOrder submits a new order
 func (c *CoinbasePro) SubmitOrder(ctx context.Context, s *order.Submit) (*order.SubmitResponse, error) {
-	if err := s.Validate(); err != nil {
+	if err := s.Validate(s.TimeInForceSupported(order.GTC|order.IOC|order.FOK, true), s.SelfTradePreventionSupported(supportedSTPModes)); err != nil {
 		return nil, err
 	}
 
@@ -450,11 +453,14 @@ func (c *CoinbasePro) SubmitOrder(ctx context.Context, s *order.Submit) (*order.
 			s.QuoteAmount,
 			s.Side.Lower(),
 			fPair.String(),
-			"")
+			stpToString(s.SelfTradePrevention))
 	case order.Limit:
 		timeInForce := CoinbaseRequestParamsTimeGTC
-		if s.ImmediateOrCancel {
+		switch s.TimeInForce {
+		case order.IOC:
 			timeInForce = CoinbaseRequestParamsTimeIOC
+		case order.FOK:
+			timeInForce = CoinbaseRequestParamsTimeFOK
 		}
 		orderID, err = c.PlaceLimitOrder(ctx,
 			"",
@@ -464,8 +470,8 @@ func (c *CoinbasePro) SubmitOrder(ctx context.Context, s *order.Submit) (*order.
 			timeInForce,
 			"",
 			fPair.String(),
-			"",
-			false)
+			stpToString(s.SelfTradePrevention),
+			s.PostOnly)
 	default:
 		err = fmt.Errorf("%w %v", order.ErrUnsupportedOrderType, s.Type)
 	}
@@ -884,3 +890,20 @@ func (c *CoinbasePro) GetFuturesContractDetails(context.Context, asset.Item) ([]
 func (c *CoinbasePro) UpdateOrderExecutionLimits(_ context.Context, _ asset.Item) error {
 	return common.ErrNotYetImplemented
 }
+
+// stpToString returns the self trade prevention flag for an order submission,
+// leaving it empty for the exchange default when unset
+func stpToString(stp order.SelfTradePrevention) string {
+	switch stp {
+	case order.STPCancelMaker:
+		return "co"
+	case order.STPCancelTaker:
+		return "cn"
+	case order.STPCancelBoth:
+		return "cb"
+	case order.STPDecrement:
+		return "dc"
+	default:
+		return ""
+	}
+}
@@ -40,7 +40,7 @@ var (
 // wss://wsapi-eu.coinut.com
 
 // WsConnect initiates a websocket connection
-func (c *COINUT) WsConnect() error {
+func (c *COINUT) WsConnect(ctx context.Context) error {
 	if !c.Websocket.IsEnabled() || !c.IsEnabled() {
 		return stream.ErrWebsocketNotEnabled
 	}
@@ -51,7 +51,7 @@ func (c *COINUT) WsConnect() error {
 	}
 
 	c.Websocket.Wg.Add(1)
-	go c.wsReadData()
+	go c.wsReadData(ctx)
 
 	if !c.instrumentMap.IsLoaded() {
 		_, err = c.WsGetInstruments()
@@ -61,7 +61,7 @@ func (c *COINUT) WsConnect() error {
 	}
 
 	if c.IsWebsocketAuthenticationSupported() {
-		if err = c.wsAuthenticate(context.TODO()); err != nil {
+		if err = c.wsAuthenticate(ctx); err != nil {
 			c.Websocket.SetCanUseAuthenticatedEndpoints(false)
 			log.Errorln(log.WebsocketMgr, c.Name+" "+err.Error())
 		}
@@ -75,11 +75,9 @@ func (c *COINUT) WsConnect() error {
 }
 
 // wsReadData receives and passes on websocket messages for processing
-func (c *COINUT) wsReadData() {
+func (c *COINUT) wsReadData(ctx context.Context) {
 	defer c.Websocket.Wg.Done()
 
-	ctx := context.TODO()
-
 	for {
 		resp := c.Websocket.Conn.ReadMessage()
 		if resp.Raw == nil {
@@ -600,7 +598,7 @@ func (c *COINUT) GenerateDefaultSubscriptions() ([]subscription.Subscription, er
 }
 
 // Subscribe sends a websocket message to receive data from the channel
-func (c *COINUT) Subscribe(channelsToSubscribe []subscription.Subscription) error {
+func (c *COINUT) Subscribe(ctx context.Context, channelsToSubscribe []subscription.Subscription) error {
 	var errs error
 	for i := range channelsToSubscribe {
 		fPair, err := c.FormatExchangeCurrency(channelsToSubscribe[i].Pair, asset.Spot)
@@ -629,7 +627,7 @@ func (c *COINUT) Subscribe(channelsToSubscribe []subscription.Subscription) erro
 }
 
 // Unsubscribe sends a websocket message to stop receiving data from the channel
-func (c *COINUT) Unsubscribe(channelToUnsubscribe []subscription.Subscription) error {
+func (c *COINUT) Unsubscribe(ctx context.Context, channelToUnsubscribe []subscription.Subscription) error {
 	var errs error
 	for i := range channelToUnsubscribe {
 		fPair, err := c.FormatExchangeCurrency(channelToUnsubscribe[i].Pair, asset.Spot)
@@ -644,7 +642,7 @@ func (c *COINUT) Unsubscribe(channelToUnsubscribe []subscription.Subscription) e
 			Subscribe:    false,
 			Nonce:        getNonce(),
 		}
-		resp, err := c.Websocket.Conn.SendMessageReturnResponse(subscribe.Nonce, subscribe)
+		resp, err := c.Websocket.Conn.SendMessageReturnResponseWithContext(ctx, subscribe.Nonce, subscribe)
 		if err != nil {
 			errs = common.AppendError(errs, err)
 			continue
@@ -0,0 +1,68 @@
+package conversion
+
+import (
+	"fmt"
+	"time"
+)
+
+// Validate checks the quote request for missing or invalid fields
+func (r *QuoteRequest) Validate() error {
+	if r == nil {
+		return ErrNilRequest
+	}
+	if r.From.IsEmpty() || r.To.IsEmpty() {
+		return ErrCurrencyUnset
+	}
+	if r.From.Equal(r.To) {
+		return fmt.Errorf("%w: %s", ErrSameCurrency, r.From)
+	}
+	if r.Amount <= 0 {
+		return fmt.Errorf("%w: %v", ErrInvalidAmount, r.Amount)
+	}
+	return nil
+}
+
+// Validate checks that the quote can still be accepted
+func (q *Quote) Validate() error {
+	if q == nil {
+		return ErrNilQuote
+	}
+	if q.ID == "" {
+		return ErrQuoteIDUnset
+	}
+	if q.From.IsEmpty() || q.To.IsEmpty() {
+		return ErrCurrencyUnset
+	}
+	if q.FromAmount <= 0 {
+		return fmt.Errorf("%w: %v", ErrInvalidAmount, q.FromAmount)
+	}
+	if q.IsExpired() {
+		return fmt.Errorf("%w: %s expired at %s", ErrQuoteExpired, q.ID, q.Expires)
+	}
+	return nil
+}
+
+// IsExpired returns true once the quote's expiry has passed. Quotes without
+// an expiry never expire
+func (q *Quote) IsExpired() bool {
+	return !q.Expires.IsZero() && !time.Now().Before(q.Expires)
+}
+
+// Validate checks the dust request for missing currencies
+func (r *DustRequest) Validate() error {
+	if r == nil {
+		return ErrNilRequest
+	}
+	if len(r.Currencies) == 0 {
+		return ErrNoDustCurrencies
+	}
+	for i := range r.Currencies {
+		if r.Currencies[i].IsEmpty() {
+			return ErrCurrencyUnset
+		}
+		if r.Currencies[i].Equal(r.To) {
+			return fmt.Errorf("%w: %s", ErrSameCurrency, r.To)
+		}
+	}
+	return nil
+}
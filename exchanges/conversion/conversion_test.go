@@ -0,0 +1,64 @@
+package conversion
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+func TestQuoteRequestValidate(t *testing.T) {
+	t.Parallel()
+	var r *QuoteRequest
+	assert.ErrorIs(t, r.Validate(), ErrNilRequest)
+	r = &QuoteRequest{From: currency.BTC}
+	assert.ErrorIs(t, r.Validate(), ErrCurrencyUnset)
+	r.To = currency.BTC
+	assert.ErrorIs(t, r.Validate(), ErrSameCurrency)
+	r.To = currency.USDT
+	assert.ErrorIs(t, r.Validate(), ErrInvalidAmount)
+	r.Amount = 1
+	assert.NoError(t, r.Validate())
+}
+
+func TestQuoteValidate(t *testing.T) {
+	t.Parallel()
+	var q *Quote
+	assert.ErrorIs(t, q.Validate(), ErrNilQuote)
+	q = &Quote{}
+	assert.ErrorIs(t, q.Validate(), ErrQuoteIDUnset)
+	q.ID = "1337"
+	assert.ErrorIs(t, q.Validate(), ErrCurrencyUnset)
+	q.From, q.To = currency.BTC, currency.USDT
+	assert.ErrorIs(t, q.Validate(), ErrInvalidAmount)
+	q.FromAmount = 1
+	assert.NoError(t, q.Validate(), "Quotes without an expiry should be valid")
+	q.Expires = time.Now().Add(-time.Second)
+	assert.ErrorIs(t, q.Validate(), ErrQuoteExpired)
+	q.Expires = time.Now().Add(time.Minute)
+	assert.NoError(t, q.Validate())
+}
+
+func TestQuoteIsExpired(t *testing.T) {
+	t.Parallel()
+	assert.False(t, (&Quote{}).IsExpired(), "IsExpired should return false without an expiry")
+	assert.True(t, (&Quote{Expires: time.Now().Add(-time.Second)}).IsExpired(), "IsExpired should return true after expiry")
+	assert.False(t, (&Quote{Expires: time.Now().Add(time.Minute)}).IsExpired(), "IsExpired should return false before expiry")
+}
+
+func TestDustRequestValidate(t *testing.T) {
+	t.Parallel()
+	var r *DustRequest
+	assert.ErrorIs(t, r.Validate(), ErrNilRequest)
+	r = &DustRequest{To: currency.BNB}
+	assert.ErrorIs(t, r.Validate(), ErrNoDustCurrencies)
+	r.Currencies = []currency.Code{currency.EMPTYCODE}
+	assert.ErrorIs(t, r.Validate(), ErrCurrencyUnset)
+	r.Currencies = []currency.Code{currency.ADA, currency.BNB}
+	assert.ErrorIs(t, r.Validate(), ErrSameCurrency)
+	r.Currencies = []currency.Code{currency.ADA}
+	assert.NoError(t, r.Validate())
+	r.To = currency.EMPTYCODE
+	assert.NoError(t, r.Validate(), "Validate should allow an unset target")
+}
@@ -0,0 +1,98 @@
+package conversion
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+// Conversion errors
+var (
+	ErrNilRequest            = errors.New("conversion request is nil")
+	ErrNilQuote              = errors.New("conversion quote is nil")
+	ErrCurrencyUnset         = errors.New("conversion currency unset")
+	ErrSameCurrency          = errors.New("conversion currencies must differ")
+	ErrInvalidAmount         = errors.New("conversion amount must be greater than zero")
+	ErrQuoteIDUnset          = errors.New("conversion quote ID unset")
+	ErrQuoteExpired          = errors.New("conversion quote has expired")
+	ErrNoDustCurrencies      = errors.New("no dust currencies to convert")
+	ErrUnsupportedDustTarget = errors.New("dust cannot be converted to currency")
+)
+
+// QuoteRequest requests a quote to convert an amount of one currency into
+// another using an exchange's native conversion service
+type QuoteRequest struct {
+	From currency.Code
+	To   currency.Code
+	// Amount is the amount of From to convert
+	Amount float64
+}
+
+// Quote is an exchange's offer to convert one currency into another, which
+// must be accepted before it expires
+type Quote struct {
+	Exchange   string
+	ID         string
+	From       currency.Code
+	To         currency.Code
+	FromAmount float64
+	ToAmount   float64
+	// Rate is the amount of To received for each unit of From
+	Rate float64
+	// Pair is the exchange's conversion pair the quote is priced against,
+	// for exchanges which require it when accepting a quote
+	Pair    currency.Pair
+	Expires time.Time
+}
+
+// Result holds the outcome of an accepted quote
+type Result struct {
+	Exchange   string
+	QuoteID    string
+	OrderID    string
+	From       currency.Code
+	To         currency.Code
+	FromAmount float64
+	ToAmount   float64
+	Status     string
+	Time       time.Time
+}
+
+// DustBalance is a balance too small to trade which the exchange can convert
+// in bulk
+type DustBalance struct {
+	Currency currency.Code
+	Amount   float64
+	// Targets are the currencies the balance can be converted into
+	Targets []currency.Code
+	// Estimate is the estimated amount of the first target received, zero
+	// when the exchange does not provide one
+	Estimate float64
+}
+
+// DustRequest requests the conversion of dust balances
+type DustRequest struct {
+	Currencies []currency.Code
+	// To is the currency to convert into. The exchange's default target is
+	// used when unset
+	To currency.Code
+}
+
+// DustConversion holds the outcome of converting a single dust balance
+type DustConversion struct {
+	Currency  currency.Code
+	Amount    float64
+	Converted float64
+	Fee       float64
+}
+
+// DustResult holds the outcome of a dust conversion
+type DustResult struct {
+	Exchange    string
+	To          currency.Code
+	Conversions []DustConversion
+	Total       float64
+	Fee         float64
+	Time        time.Time
+}
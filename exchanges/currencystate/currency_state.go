@@ -210,6 +210,7 @@ type Currency struct {
 	depositAlerts  alert.Notice
 	trading        bool
 	tradingAlerts  alert.Notice
+	congested      bool
 	mtx            sync.RWMutex
 }
 
@@ -239,6 +240,8 @@ func (c *Currency) update(o Options) {
 		c.trading = *o.Trade
 		c.tradingAlerts.Alert()
 	}
+
+	c.congested = o.Congested != nil && *o.Congested
 	c.mtx.Unlock()
 }
 
@@ -263,6 +266,14 @@ func (c *Currency) CanDeposit() bool {
 	return c.deposits
 }
 
+// IsCongested returns if the exchange has reported the currency's network as
+// congested, delaying deposits and withdrawals
+func (c *Currency) IsCongested() bool {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	return c.congested
+}
+
 // WaitTrading allows a routine to wait until a trading change of state occurs
 func (c *Currency) WaitTrading(kick <-chan struct{}) <-chan bool {
 	c.mtx.RLock()
@@ -289,19 +300,21 @@ func (c *Currency) GetState() Options {
 	c.mtx.RLock()
 	defer c.mtx.RUnlock()
 	return Options{
-		Withdraw: convert.BoolPtr(c.withdrawals),
-		Deposit:  convert.BoolPtr(c.deposits),
-		Trade:    convert.BoolPtr(c.trading),
+		Withdraw:  convert.BoolPtr(c.withdrawals),
+		Deposit:   convert.BoolPtr(c.deposits),
+		Trade:     convert.BoolPtr(c.trading),
+		Congested: convert.BoolPtr(c.congested),
 	}
 }
 
 // Options defines the current allowable options for a currency, using a bool
 // pointer for optional setting for incomplete data, so we can default to true
-// on nil values.
+// on nil values. Congested defaults to false on nil values.
 type Options struct {
-	Withdraw *bool
-	Deposit  *bool
-	Trade    *bool
+	Withdraw  *bool
+	Deposit   *bool
+	Trade     *bool
+	Congested *bool
 }
 
 // Snapshot defines a snapshot of the internal asset for exportation
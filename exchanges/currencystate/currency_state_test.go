@@ -296,11 +296,26 @@ func TestStatesGet(t *testing.T) {
 
 func TestCurrencyGetState(t *testing.T) {
 	o := (&Currency{}).GetState()
-	if *o.Deposit || *o.Trade || *o.Withdraw {
+	if *o.Deposit || *o.Trade || *o.Withdraw || *o.Congested {
 		t.Fatal("unexpected values")
 	}
 }
 
+func TestCurrencyIsCongested(t *testing.T) {
+	c := Currency{}
+	c.update(Options{Congested: convert.BoolPtr(true)})
+	if !c.IsCongested() {
+		t.Fatal("expected congested")
+	}
+	if !*c.GetState().Congested {
+		t.Fatal("expected congested state")
+	}
+	c.update(Options{})
+	if c.IsCongested() {
+		t.Fatal("expected congestion to default to false when unset")
+	}
+}
+
 func TestAlerting(_ *testing.T) {
 	c := Currency{}
 	var start, finish sync.WaitGroup
@@ -0,0 +1,64 @@
+package earn
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseProductType returns the product type matching the string, defaulting
+// to Flexible when empty
+func ParseProductType(s string) (ProductType, error) {
+	switch ProductType(strings.ToLower(s)) {
+	case "", Flexible:
+		return Flexible, nil
+	case Locked:
+		return Locked, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrInvalidType, s)
+}
+
+// Validate checks the subscription request for missing or invalid fields
+func (r *SubscribeRequest) Validate() error {
+	if r == nil {
+		return ErrNilRequest
+	}
+	if r.ProductID == "" {
+		return ErrProductIDUnset
+	}
+	if r.Currency.IsEmpty() {
+		return ErrCurrencyUnset
+	}
+	if r.Type != Flexible && r.Type != Locked {
+		return fmt.Errorf("%w: %q", ErrInvalidType, r.Type)
+	}
+	if r.Amount <= 0 {
+		return fmt.Errorf("%w: %v", ErrInvalidAmount, r.Amount)
+	}
+	return nil
+}
+
+// Validate checks the redemption request for missing or invalid fields
+func (r *RedeemRequest) Validate() error {
+	if r == nil {
+		return ErrNilRequest
+	}
+	if r.ProductID == "" {
+		return ErrProductIDUnset
+	}
+	if r.Currency.IsEmpty() {
+		return ErrCurrencyUnset
+	}
+	if r.Type != Flexible && r.Type != Locked {
+		return fmt.Errorf("%w: %q", ErrInvalidType, r.Type)
+	}
+	if r.All {
+		if r.Amount != 0 {
+			return ErrAmountAndAllSet
+		}
+		return nil
+	}
+	if r.Amount <= 0 {
+		return fmt.Errorf("%w: %v", ErrInvalidAmount, r.Amount)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package earn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+func TestParseProductType(t *testing.T) {
+	t.Parallel()
+	for s, expected := range map[string]ProductType{
+		"":         Flexible,
+		"flexible": Flexible,
+		"LOCKED":   Locked,
+	} {
+		p, err := ParseProductType(s)
+		require.NoErrorf(t, err, "ParseProductType must not error for %q", s)
+		assert.Equalf(t, expected, p, "ParseProductType should parse %q", s)
+	}
+	_, err := ParseProductType("staked")
+	assert.ErrorIs(t, err, ErrInvalidType)
+}
+
+func TestSubscribeRequestValidate(t *testing.T) {
+	t.Parallel()
+	var r *SubscribeRequest
+	assert.ErrorIs(t, r.Validate(), ErrNilRequest)
+	r = &SubscribeRequest{}
+	assert.ErrorIs(t, r.Validate(), ErrProductIDUnset)
+	r.ProductID = "USDT001"
+	assert.ErrorIs(t, r.Validate(), ErrCurrencyUnset)
+	r.Currency = currency.USDT
+	assert.ErrorIs(t, r.Validate(), ErrInvalidType)
+	r.Type = Flexible
+	assert.ErrorIs(t, r.Validate(), ErrInvalidAmount)
+	r.Amount = 1
+	assert.NoError(t, r.Validate())
+}
+
+func TestRedeemRequestValidate(t *testing.T) {
+	t.Parallel()
+	var r *RedeemRequest
+	assert.ErrorIs(t, r.Validate(), ErrNilRequest)
+	r = &RedeemRequest{}
+	assert.ErrorIs(t, r.Validate(), ErrProductIDUnset)
+	r.ProductID = "USDT001"
+	assert.ErrorIs(t, r.Validate(), ErrCurrencyUnset)
+	r.Currency = currency.USDT
+	assert.ErrorIs(t, r.Validate(), ErrInvalidType)
+	r.Type = Locked
+	assert.ErrorIs(t, r.Validate(), ErrInvalidAmount)
+	r.Amount = 1
+	assert.NoError(t, r.Validate())
+	r.All = true
+	assert.ErrorIs(t, r.Validate(), ErrAmountAndAllSet)
+	r.Amount = 0
+	assert.NoError(t, r.Validate(), "Validate should allow redeeming all without an amount")
+}
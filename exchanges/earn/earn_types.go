@@ -0,0 +1,105 @@
+package earn
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+// Earn errors
+var (
+	ErrNilRequest      = errors.New("earn request is nil")
+	ErrProductIDUnset  = errors.New("earn product ID unset")
+	ErrCurrencyUnset   = errors.New("earn currency unset")
+	ErrInvalidAmount   = errors.New("earn amount must be greater than zero")
+	ErrInvalidType     = errors.New("invalid earn product type")
+	ErrAmountAndAllSet = errors.New("earn redemption amount cannot be set when redeeming all")
+)
+
+// ProductType is the type of an earn product
+type ProductType string
+
+// Earn product types
+const (
+	// Flexible products can be redeemed at any time
+	Flexible ProductType = "flexible"
+	// Locked products are held until they mature
+	Locked ProductType = "locked"
+)
+
+// Product is an earn or staking product offered by an exchange
+type Product struct {
+	Exchange string
+	ID       string
+	Currency currency.Code
+	Type     ProductType
+	// APR is the annual percentage rate as a decimal, 0.05 being 5%
+	APR float64
+	// Duration is the lock period of a locked product, zero for flexible
+	// products
+	Duration  time.Duration
+	MinAmount float64
+	// Available is false when the product is sold out or not accepting
+	// subscriptions
+	Available bool
+}
+
+// Position is an amount held in an earn product
+type Position struct {
+	Exchange  string
+	ProductID string
+	// PositionID identifies a locked position on exchanges which track each
+	// subscription separately
+	PositionID string
+	Currency   currency.Code
+	Type       ProductType
+	Amount     float64
+	// AccruedRewards are rewards earned but not yet paid out
+	AccruedRewards float64
+	APR            float64
+	// Matures is when a locked position is released, zero for flexible
+	// positions
+	Matures time.Time
+}
+
+// Reward is a reward paid out by an earn product
+type Reward struct {
+	Exchange  string
+	ProductID string
+	Currency  currency.Code
+	Amount    float64
+	Time      time.Time
+}
+
+// SubscribeRequest subscribes an amount to an earn product
+type SubscribeRequest struct {
+	ProductID string
+	Currency  currency.Code
+	Type      ProductType
+	Amount    float64
+}
+
+// RedeemRequest redeems an amount from an earn product
+type RedeemRequest struct {
+	ProductID string
+	// PositionID selects a locked position on exchanges which track each
+	// subscription separately
+	PositionID string
+	Currency   currency.Code
+	Type       ProductType
+	Amount     float64
+	// All redeems the full position, Amount must be unset
+	All bool
+}
+
+// Result holds the outcome of a subscription or redemption
+type Result struct {
+	Exchange  string
+	ProductID string
+	ID        string
+	Currency  currency.Code
+	Amount    float64
+	Success   bool
+	Time      time.Time
+}
@@ -19,12 +19,16 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/calendar"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/collateral"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/conversion"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/currencystate"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/earn"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/fundingrate"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/margin"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/options"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/protocol"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
@@ -32,8 +36,10 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/subscription"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/transfer"
 	"github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
 )
 
 const (
@@ -46,6 +52,9 @@ const (
 	DefaultWebsocketResponseMaxLimit = time.Second * 7
 	// DefaultWebsocketOrderbookBufferLimit is the maximum number of orderbook updates that get stored before being applied
 	DefaultWebsocketOrderbookBufferLimit = 5
+	// maxParallelOrderRequests limits the individual order requests in flight
+	// when an exchange does not support batch order endpoints
+	maxParallelOrderRequests = 5
 )
 
 var (
@@ -61,6 +70,7 @@ var (
 	errAssetConfigFormatIsNil            = errors.New("asset type config format is nil")
 	errSetDefaultsNotCalled              = errors.New("set defaults not called")
 	errExchangeIsNil                     = errors.New("exchange is nil")
+	errNoOrders                          = errors.New("no orders supplied")
 	errBatchSizeZero                     = errors.New("batch size cannot be 0")
 )
 
@@ -758,6 +768,12 @@ func (b *Base) UpdatePairs(incoming currency.Pairs, a asset.Item, enabled, force
 		if err != nil {
 			return err
 		}
+		if !enabled {
+			err = b.updateListingStates(a, diff)
+			if err != nil {
+				return err
+			}
+		}
 	}
 
 	if enabled {
@@ -1786,6 +1802,11 @@ func (b *Base) SetMarginType(_ context.Context, _ asset.Item, _ currency.Pair, _
 	return common.ErrNotYetImplemented
 }
 
+// GetMarginType returns the account's margin type for the asset type and pair
+func (b *Base) GetMarginType(_ context.Context, _ asset.Item, _ currency.Pair) (margin.Type, error) {
+	return margin.Unset, common.ErrNotYetImplemented
+}
+
 // ChangePositionMargin changes the margin type for a position
 func (b *Base) ChangePositionMargin(_ context.Context, _ *margin.PositionChangeRequest) (*margin.PositionChangeResponse, error) {
 	return nil, common.ErrNotYetImplemented
@@ -1836,13 +1857,165 @@ func (b *Base) IsPairEnabled(pair currency.Pair, a asset.Item) (bool, error) {
 	return b.CurrencyPairs.IsPairEnabled(pair, a)
 }
 
+// GetPairState returns the lifecycle state of a currency pair
+func (b *Base) GetPairState(pair currency.Pair, a asset.Item) (currency.PairState, error) {
+	return b.CurrencyPairs.GetPairState(pair, a)
+}
+
+// SetPairState sets the lifecycle state of a currency pair derived from
+// exchange metadata and notifies the pair state change handler on change
+func (b *Base) SetPairState(pair currency.Pair, a asset.Item, state currency.PairState) error {
+	change, err := b.CurrencyPairs.SetPairState(pair, a, state)
+	if err != nil || change == nil {
+		return err
+	}
+	log.Infof(log.ExchangeSys, "%s %s %s pair state changed from %s to %s",
+		b.Name, a, pair, change.Previous, change.Current)
+	b.settingsMutex.RLock()
+	handler := b.pairStateChangeHandler
+	b.settingsMutex.RUnlock()
+	if handler != nil {
+		handler(b.Name, *change)
+	}
+	return nil
+}
+
+// SetPairStateChangeHandler sets the function called when a currency pair
+// lifecycle state changes
+func (b *Base) SetPairStateChangeHandler(fn func(exchangeName string, change currency.PairStateChange)) {
+	b.settingsMutex.Lock()
+	b.pairStateChangeHandler = fn
+	b.settingsMutex.Unlock()
+}
+
+// updateListingStates marks pairs removed from the available list as delisted
+// and returns relisted pairs to tradeable
+func (b *Base) updateListingStates(a asset.Item, diff currency.PairDifference) error {
+	for x := range diff.Remove {
+		if err := b.SetPairState(diff.Remove[x], a, currency.PairStateDelisted); err != nil {
+			return err
+		}
+	}
+	for x := range diff.New {
+		state, err := b.CurrencyPairs.GetPairState(diff.New[x], a)
+		if err != nil {
+			return err
+		}
+		if state != currency.PairStateDelisted {
+			continue
+		}
+		if err = b.SetPairState(diff.New[x], a, currency.PairStateTradeable); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SubmitOrders submits multiple orders using a batch order endpoint. Use the
+// package SubmitOrders function to fall back to individual submissions when
+// unsupported
+func (b *Base) SubmitOrders(context.Context, []order.Submit) ([]*order.SubmitResponse, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetTransferNetworks returns the normalised transfer networks of a currency,
+// including each network's withdrawal fee and minimums
+func (b *Base) GetTransferNetworks(context.Context, currency.Code) ([]transfer.Chain, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetWithdrawalLocks returns withdrawal locks and delays currently enforced by
+// the exchange for a currency
+func (b *Base) GetWithdrawalLocks(context.Context, currency.Code) ([]withdraw.Lock, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetConversionQuote requests a quote from the exchange's native conversion
+// service, which must be accepted with AcceptConversionQuote before it expires
+func (b *Base) GetConversionQuote(context.Context, *conversion.QuoteRequest) (*conversion.Quote, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// AcceptConversionQuote accepts a quote returned by GetConversionQuote
+func (b *Base) AcceptConversionQuote(context.Context, *conversion.Quote) (*conversion.Result, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetDustBalances returns balances which are too small to trade and can be
+// converted with ConvertDust
+func (b *Base) GetDustBalances(context.Context) ([]conversion.DustBalance, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// ConvertDust converts dust balances into a single currency
+func (b *Base) ConvertDust(context.Context, *conversion.DustRequest) (*conversion.DustResult, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetEarnProducts returns the earn and staking products offered for a
+// currency, or all products when the currency is empty
+func (b *Base) GetEarnProducts(context.Context, currency.Code) ([]earn.Product, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetEarnPositions returns the amounts held in earn and staking products
+func (b *Base) GetEarnPositions(context.Context) ([]earn.Position, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// SubscribeEarnProduct subscribes an amount to an earn or staking product
+func (b *Base) SubscribeEarnProduct(context.Context, *earn.SubscribeRequest) (*earn.Result, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// RedeemEarnProduct redeems an amount from an earn or staking product
+func (b *Base) RedeemEarnProduct(context.Context, *earn.RedeemRequest) (*earn.Result, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetEarnRewards returns the rewards paid out by earn and staking products
+// between the start and end times
+func (b *Base) GetEarnRewards(context.Context, time.Time, time.Time) ([]earn.Reward, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// SubmitComboOrder submits a multi-leg option strategy as a single order, so
+// all legs are filled together or not at all
+func (b *Base) SubmitComboOrder(context.Context, *options.ComboOrder) (*options.ComboResponse, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// SubmitOCOOrder submits a one-cancels-the-other order as a single order list,
+// so the exchange cancels either leg when the other executes
+func (b *Base) SubmitOCOOrder(context.Context, *order.OCOSubmit) (*order.OCOSubmitResponse, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
 // GetOpenInterest returns the open interest rate for a given asset pair
 func (b *Base) GetOpenInterest(context.Context, ...key.PairAsset) ([]futures.OpenInterest, error) {
 	return nil, common.ErrFunctionNotSupported
 }
 
+// GetInsuranceFunds returns the exchange's insurance fund balances for the
+// asset type
+func (b *Base) GetInsuranceFunds(context.Context, asset.Item) ([]futures.InsuranceFund, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetADLIndicators returns the auto-deleveraging indicators of the account's
+// open positions for the asset type
+func (b *Base) GetADLIndicators(context.Context, asset.Item) ([]futures.ADLIndicator, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
+// GetScheduledEvents returns upcoming listings, delistings and maintenance
+// windows announced by the exchange
+func (b *Base) GetScheduledEvents(context.Context) ([]calendar.Event, error) {
+	return nil, common.ErrFunctionNotSupported
+}
+
 // ParallelChanOp performs a single method call in parallel across streams and waits to return any errors
-func (b *Base) ParallelChanOp(channels []subscription.Subscription, m func([]subscription.Subscription) error, batchSize int) error {
+func (b *Base) ParallelChanOp(ctx context.Context, channels []subscription.Subscription, m func(context.Context, []subscription.Subscription) error, batchSize int) error {
 	wg := sync.WaitGroup{}
 	errC := make(chan error, len(channels))
 	if batchSize == 0 {
@@ -1858,7 +2031,7 @@ func (b *Base) ParallelChanOp(channels []subscription.Subscription, m func([]sub
 		wg.Add(1)
 		go func(c []subscription.Subscription) {
 			defer wg.Done()
-			if err := m(c); err != nil {
+			if err := m(ctx, c); err != nil {
 				errC <- err
 			}
 		}(channels[i:j])
@@ -1975,3 +2148,105 @@ func GetDefaultConfig(ctx context.Context, exch IBotExchange) (*config.Exchange,
 
 	return exchCfg, nil
 }
+
+// SubmitOrders submits multiple orders using the exchange's batch order
+// endpoint. When batch submission is not supported orders are submitted
+// individually in parallel, bounded so requests queue on the exchange's rate
+// limiter in submission order. Responses match the order of submissions, with
+// nil entries for orders which failed
+func SubmitOrders(ctx context.Context, exch IBotExchange, s []order.Submit) ([]*order.SubmitResponse, error) {
+	if exch == nil {
+		return nil, errExchangeIsNil
+	}
+	if len(s) == 0 {
+		return nil, fmt.Errorf("%w to submit", errNoOrders)
+	}
+	resp, err := exch.SubmitOrders(ctx, s)
+	if !errors.Is(err, common.ErrFunctionNotSupported) && !errors.Is(err, common.ErrNotYetImplemented) {
+		return resp, err
+	}
+	resp = make([]*order.SubmitResponse, len(s))
+	errs := parallelOrderRequests(ctx, len(s), func(i int) (err error) {
+		resp[i], err = exch.SubmitOrder(ctx, &s[i])
+		return err
+	})
+	return resp, orderRequestErrors(errs)
+}
+
+// CancelOrders cancels multiple orders using the exchange's batch cancel
+// endpoint. When batch cancellation is not supported orders are cancelled
+// individually in parallel, bounded so requests queue on the exchange's rate
+// limiter
+func CancelOrders(ctx context.Context, exch IBotExchange, c []order.Cancel) (*order.CancelBatchResponse, error) {
+	if exch == nil {
+		return nil, errExchangeIsNil
+	}
+	if len(c) == 0 {
+		return nil, fmt.Errorf("%w to cancel", errNoOrders)
+	}
+	resp, err := exch.CancelBatchOrders(ctx, c)
+	if !errors.Is(err, common.ErrFunctionNotSupported) && !errors.Is(err, common.ErrNotYetImplemented) {
+		return resp, err
+	}
+	errs := parallelOrderRequests(ctx, len(c), func(i int) error {
+		return exch.CancelOrder(ctx, &c[i])
+	})
+	resp = &order.CancelBatchResponse{Status: make(map[string]string, len(c))}
+	for i := range c {
+		id := c[i].OrderID
+		if id == "" {
+			id = c[i].ClientOrderID
+		}
+		if errs[i] != nil {
+			resp.Status[id] = errs[i].Error()
+		} else {
+			resp.Status[id] = order.Cancelled.String()
+		}
+	}
+	return resp, orderRequestErrors(errs)
+}
+
+// parallelOrderRequests calls fn for each request index with at most
+// maxParallelOrderRequests running at once, returning the error of each
+// request. Requests not started before the context is done are not sent
+func parallelOrderRequests(ctx context.Context, count int, fn func(i int) error) []error {
+	errs := make([]error, count)
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxParallelOrderRequests)
+	for i := range count {
+		if ctx.Err() == nil {
+			select {
+			case <-ctx.Done():
+			case sem <- struct{}{}:
+			}
+		}
+		if ctx.Err() != nil {
+			for j := i; j < count; j++ {
+				errs[j] = ctx.Err()
+			}
+			break
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			errs[i] = fn(i)
+		}(i)
+	}
+	wg.Wait()
+	return errs
+}
+
+// orderRequestErrors combines the errors of individual order requests,
+// identifying each by its position in the request
+func orderRequestErrors(errs []error) error {
+	var err error
+	for i := range errs {
+		if errs[i] != nil {
+			err = common.AppendError(err, fmt.Errorf("order %d: %w", i, errs[i]))
+		}
+	}
+	return err
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"net"
+	"strconv"
+	"sync"
 	"testing"
 	"time"
 
@@ -17,7 +19,9 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/collateral"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/conversion"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/deposit"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/earn"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/fundingrate"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
@@ -930,9 +934,9 @@ func TestSetupDefaults(t *testing.T) {
 		Features:              &protocol.Features{},
 		DefaultURL:            "ws://something.com",
 		RunningURL:            "ws://something.com",
-		Connector:             func() error { return nil },
+		Connector:             func(context.Context) error { return nil },
 		GenerateSubscriptions: func() ([]subscription.Subscription, error) { return []subscription.Subscription{}, nil },
-		Subscriber:            func([]subscription.Subscription) error { return nil },
+		Subscriber:            func(context.Context, []subscription.Subscription) error { return nil },
 	})
 	if err != nil {
 		t.Fatal(err)
@@ -1257,9 +1261,9 @@ func TestIsWebsocketEnabled(t *testing.T) {
 		Features:              &protocol.Features{},
 		DefaultURL:            "ws://something.com",
 		RunningURL:            "ws://something.com",
-		Connector:             func() error { return nil },
+		Connector:             func(context.Context) error { return nil },
 		GenerateSubscriptions: func() ([]subscription.Subscription, error) { return nil, nil },
-		Subscriber:            func([]subscription.Subscription) error { return nil },
+		Subscriber:            func(context.Context, []subscription.Subscription) error { return nil },
 	})
 	if err != nil {
 		t.Error(err)
@@ -2628,6 +2632,15 @@ func TestChangePositionMargin(t *testing.T) {
 	}
 }
 
+func TestGetMarginType(t *testing.T) {
+	t.Parallel()
+	b := Base{}
+	_, err := b.GetMarginType(context.Background(), asset.Spot, currency.NewBTCUSD())
+	if !errors.Is(err, common.ErrNotYetImplemented) {
+		t.Error(err)
+	}
+}
+
 func TestSetLeverage(t *testing.T) {
 	t.Parallel()
 	b := Base{}
@@ -2861,6 +2874,56 @@ func TestIsPairEnabled(t *testing.T) {
 	}
 }
 
+func TestSetPairState(t *testing.T) {
+	t.Parallel()
+	b := Base{Name: "test"}
+	cp := currency.NewPair(currency.BTC, currency.USDT)
+	var changes []currency.PairStateChange
+	b.SetPairStateChangeHandler(func(exchangeName string, change currency.PairStateChange) {
+		assert.Equal(t, "test", exchangeName)
+		changes = append(changes, change)
+	})
+	assert.ErrorIs(t, b.SetPairState(cp, asset.Spot, currency.PairState(255)), currency.ErrInvalidPairState)
+	require.NoError(t, b.SetPairState(cp, asset.Spot, currency.PairStatePostOnly))
+	require.NoError(t, b.SetPairState(cp, asset.Spot, currency.PairStatePostOnly))
+	require.Len(t, changes, 1, "handler must only be called on change")
+	assert.Equal(t, currency.PairStatePostOnly, changes[0].Current)
+	state, err := b.GetPairState(cp, asset.Spot)
+	require.NoError(t, err)
+	assert.Equal(t, currency.PairStatePostOnly, state)
+}
+
+func TestUpdatePairsListingStates(t *testing.T) {
+	t.Parallel()
+	b := Base{
+		Name: "test",
+		CurrencyPairs: currency.PairsManager{
+			Pairs: map[asset.Item]*currency.PairStore{
+				asset.Spot: {AssetEnabled: convert.BoolPtr(true)},
+			},
+			ConfigFormat:    &currency.PairFormat{Uppercase: true},
+			RequestFormat:   &currency.PairFormat{Uppercase: true},
+			UseGlobalFormat: true,
+		},
+		Config: &config.Exchange{CurrencyPairs: &currency.PairsManager{}},
+	}
+	btc := currency.NewPair(currency.BTC, currency.USDT)
+	ltc := currency.NewPair(currency.LTC, currency.USDT)
+	require.NoError(t, b.UpdatePairs(currency.Pairs{btc, ltc}, asset.Spot, false, false))
+	require.NoError(t, b.UpdatePairs(currency.Pairs{btc}, asset.Spot, false, false))
+	state, err := b.GetPairState(ltc, asset.Spot)
+	require.NoError(t, err)
+	assert.Equal(t, currency.PairStateDelisted, state, "pair removed by the exchange should be delisted")
+	state, err = b.GetPairState(btc, asset.Spot)
+	require.NoError(t, err)
+	assert.Equal(t, currency.PairStateTradeable, state)
+
+	require.NoError(t, b.UpdatePairs(currency.Pairs{btc, ltc}, asset.Spot, false, false))
+	state, err = b.GetPairState(ltc, asset.Spot)
+	require.NoError(t, err)
+	assert.Equal(t, currency.PairStateTradeable, state, "relisted pair should be tradeable")
+}
+
 func TestGetOpenInterest(t *testing.T) {
 	t.Parallel()
 	var b Base
@@ -2869,6 +2932,27 @@ func TestGetOpenInterest(t *testing.T) {
 	}
 }
 
+func TestGetInsuranceFunds(t *testing.T) {
+	t.Parallel()
+	var b Base
+	_, err := b.GetInsuranceFunds(context.Background(), asset.USDTMarginedFutures)
+	assert.ErrorIs(t, err, common.ErrFunctionNotSupported)
+}
+
+func TestGetADLIndicators(t *testing.T) {
+	t.Parallel()
+	var b Base
+	_, err := b.GetADLIndicators(context.Background(), asset.USDTMarginedFutures)
+	assert.ErrorIs(t, err, common.ErrFunctionNotSupported)
+}
+
+func TestGetScheduledEvents(t *testing.T) {
+	t.Parallel()
+	var b Base
+	_, err := b.GetScheduledEvents(context.Background())
+	assert.ErrorIs(t, err, common.ErrFunctionNotSupported)
+}
+
 func TestGetCachedOpenInterest(t *testing.T) {
 	t.Parallel()
 	var b FakeBase
@@ -2937,7 +3021,7 @@ func TestParallelChanOp(t *testing.T) {
 	b := Base{}
 	errC := make(chan error, 1)
 	go func() {
-		errC <- b.ParallelChanOp(c, func(c []subscription.Subscription) error {
+		errC <- b.ParallelChanOp(context.Background(), c, func(_ context.Context, c []subscription.Subscription) error {
 			time.Sleep(300 * time.Millisecond)
 			run <- struct{}{}
 			switch c[0].Channel {
@@ -3136,3 +3220,154 @@ func (f *FakeBase) GetLatestFundingRates(context.Context, *fundingrate.LatestRat
 func (f *FakeBase) GetFuturesContractDetails(context.Context, asset.Item) ([]futures.Contract, error) {
 	return nil, common.ErrFunctionNotSupported
 }
+
+// batchOrderExchange is a fake exchange with optional batch order support
+type batchOrderExchange struct {
+	FakeBase
+	batch    bool
+	m        sync.Mutex
+	inFlight int
+	maxSeen  int
+}
+
+func (b *batchOrderExchange) SubmitOrders(context.Context, []order.Submit) ([]*order.SubmitResponse, error) {
+	if !b.batch {
+		return nil, common.ErrFunctionNotSupported
+	}
+	return []*order.SubmitResponse{{OrderID: "batch"}}, nil
+}
+
+func (b *batchOrderExchange) SubmitOrder(_ context.Context, s *order.Submit) (*order.SubmitResponse, error) {
+	b.m.Lock()
+	b.inFlight++
+	b.maxSeen = max(b.maxSeen, b.inFlight)
+	b.m.Unlock()
+	time.Sleep(time.Millisecond)
+	b.m.Lock()
+	b.inFlight--
+	b.m.Unlock()
+	if s.Amount <= 0 {
+		return nil, order.ErrAmountIsInvalid
+	}
+	return &order.SubmitResponse{OrderID: s.ClientOrderID}, nil
+}
+
+func (b *batchOrderExchange) CancelBatchOrders(context.Context, []order.Cancel) (*order.CancelBatchResponse, error) {
+	if !b.batch {
+		return nil, common.ErrNotYetImplemented
+	}
+	return &order.CancelBatchResponse{Status: map[string]string{"batch": order.Cancelled.String()}}, nil
+}
+
+func (b *batchOrderExchange) CancelOrder(_ context.Context, c *order.Cancel) error {
+	if c.OrderID == "" && c.ClientOrderID == "" {
+		return order.ErrOrderIDNotSet
+	}
+	return nil
+}
+
+func TestSubmitOrders(t *testing.T) {
+	t.Parallel()
+	_, err := SubmitOrders(context.Background(), nil, []order.Submit{{}})
+	assert.ErrorIs(t, err, errExchangeIsNil)
+	exch := &batchOrderExchange{batch: true}
+	_, err = SubmitOrders(context.Background(), exch, nil)
+	assert.ErrorIs(t, err, errNoOrders)
+
+	resp, err := SubmitOrders(context.Background(), exch, []order.Submit{{}})
+	require.NoError(t, err)
+	require.Len(t, resp, 1)
+	assert.Equal(t, "batch", resp[0].OrderID, "batch endpoint should be used when supported")
+
+	exch.batch = false
+	s := make([]order.Submit, maxParallelOrderRequests*3)
+	for i := range s {
+		s[i] = order.Submit{Amount: 1, ClientOrderID: strconv.Itoa(i)}
+	}
+	s[4].Amount = 0
+	resp, err = SubmitOrders(context.Background(), exch, s)
+	assert.ErrorIs(t, err, order.ErrAmountIsInvalid)
+	assert.ErrorContains(t, err, "order 4:")
+	require.Len(t, resp, len(s))
+	assert.Nil(t, resp[4], "failed orders should have a nil response")
+	for i := range resp {
+		if i != 4 {
+			assert.Equal(t, strconv.Itoa(i), resp[i].OrderID, "responses should match the order of submissions")
+		}
+	}
+	assert.LessOrEqual(t, exch.maxSeen, maxParallelOrderRequests, "individual requests should be bounded")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	resp, err = SubmitOrders(ctx, exch, s)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, resp, len(s))
+	assert.Nil(t, resp[0], "orders should not be sent once the context is done")
+}
+
+func TestCancelOrders(t *testing.T) {
+	t.Parallel()
+	_, err := CancelOrders(context.Background(), nil, []order.Cancel{{}})
+	assert.ErrorIs(t, err, errExchangeIsNil)
+	exch := &batchOrderExchange{batch: true}
+	_, err = CancelOrders(context.Background(), exch, nil)
+	assert.ErrorIs(t, err, errNoOrders)
+
+	resp, err := CancelOrders(context.Background(), exch, []order.Cancel{{OrderID: "1"}})
+	require.NoError(t, err)
+	assert.Contains(t, resp.Status, "batch", "batch endpoint should be used when supported")
+
+	exch.batch = false
+	resp, err = CancelOrders(context.Background(), exch, []order.Cancel{{OrderID: "1"}, {ClientOrderID: "2"}, {}})
+	assert.ErrorIs(t, err, order.ErrOrderIDNotSet)
+	require.Len(t, resp.Status, 3)
+	assert.Equal(t, order.Cancelled.String(), resp.Status["1"])
+	assert.Equal(t, order.Cancelled.String(), resp.Status["2"], "client order ID should be used when order ID is unset")
+	assert.Equal(t, order.ErrOrderIDNotSet.Error(), resp.Status[""])
+}
+
+func TestGetWithdrawalLocks(t *testing.T) {
+	t.Parallel()
+	_, err := (&Base{}).GetWithdrawalLocks(context.Background(), currency.BTC)
+	assert.ErrorIs(t, err, common.ErrFunctionNotSupported)
+}
+
+func TestGetTransferNetworks(t *testing.T) {
+	t.Parallel()
+	_, err := (&Base{}).GetTransferNetworks(context.Background(), currency.USDT)
+	assert.ErrorIs(t, err, common.ErrFunctionNotSupported)
+}
+
+func TestConversion(t *testing.T) {
+	t.Parallel()
+	b := &Base{}
+	_, err := b.GetConversionQuote(context.Background(), &conversion.QuoteRequest{})
+	assert.ErrorIs(t, err, common.ErrFunctionNotSupported)
+	_, err = b.AcceptConversionQuote(context.Background(), &conversion.Quote{})
+	assert.ErrorIs(t, err, common.ErrFunctionNotSupported)
+	_, err = b.GetDustBalances(context.Background())
+	assert.ErrorIs(t, err, common.ErrFunctionNotSupported)
+	_, err = b.ConvertDust(context.Background(), &conversion.DustRequest{})
+	assert.ErrorIs(t, err, common.ErrFunctionNotSupported)
+}
+
+func TestEarn(t *testing.T) {
+	t.Parallel()
+	b := &Base{}
+	_, err := b.GetEarnProducts(context.Background(), currency.USDT)
+	assert.ErrorIs(t, err, common.ErrFunctionNotSupported)
+	_, err = b.GetEarnPositions(context.Background())
+	assert.ErrorIs(t, err, common.ErrFunctionNotSupported)
+	_, err = b.SubscribeEarnProduct(context.Background(), &earn.SubscribeRequest{})
+	assert.ErrorIs(t, err, common.ErrFunctionNotSupported)
+	_, err = b.RedeemEarnProduct(context.Background(), &earn.RedeemRequest{})
+	assert.ErrorIs(t, err, common.ErrFunctionNotSupported)
+	_, err = b.GetEarnRewards(context.Background(), time.Time{}, time.Time{})
+	assert.ErrorIs(t, err, common.ErrFunctionNotSupported)
+}
+
+func TestSubmitOCOOrder(t *testing.T) {
+	t.Parallel()
+	_, err := (&Base{}).SubmitOCOOrder(context.Background(), &order.OCOSubmit{})
+	assert.ErrorIs(t, err, common.ErrFunctionNotSupported)
+}
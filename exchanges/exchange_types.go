@@ -133,8 +133,11 @@ type FundingHistory struct {
 
 // WithdrawalHistory holds exchange Withdrawal history data
 type WithdrawalHistory struct {
-	Status          string
-	TransferID      string
+	Status     string
+	TransferID string
+	// ClientID is the client provided identifier submitted with the
+	// withdrawal, when supported by the exchange
+	ClientID        string
 	Description     string
 	Timestamp       time.Time
 	Currency        string
@@ -175,6 +178,12 @@ type FeaturesSupported struct {
 	MaximumOrderHistory        time.Duration
 	FuturesCapabilities        FuturesCapabilities
 	OfflineFuturesCapabilities FuturesCapabilities
+	// SelfTradePrevention holds the self trade prevention modes supported
+	// natively on order submission for each asset
+	SelfTradePrevention map[asset.Item]order.SelfTradePrevention
+	// IcebergOrders holds the assets which natively support submitting limit
+	// orders with a display amount
+	IcebergOrders map[asset.Item]bool
 }
 
 // FuturesCapabilities stores the exchange's futures capabilities
@@ -248,6 +257,9 @@ type Base struct {
 	*request.Requester
 	Config        *config.Exchange
 	settingsMutex sync.RWMutex
+	// pairStateChangeHandler is called when a currency pair lifecycle state
+	// changes
+	pairStateChangeHandler func(exchangeName string, change currency.PairStateChange)
 	// CanVerifyOrderbook determines if the orderbook verification can be bypassed,
 	// increasing potential update speed but decreasing confidence in orderbook
 	// integrity.
@@ -0,0 +1,246 @@
+package fundingrate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/common/key"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+// DefaultHistoryLength is the number of funding rate changes retained per
+// contract unless set otherwise
+const DefaultHistoryLength = 500
+
+var (
+	// ErrNoStreamedRate is returned when a contract's funding rate has not
+	// been streamed
+	ErrNoStreamedRate = errors.New("no streamed funding rate")
+
+	errUpdateIsNil          = errors.New("funding rate update is nil")
+	errExchangeNameEmpty    = errors.New("exchange name is empty")
+	errNotFutures           = errors.New("asset is not a futures contract")
+	errTimeOfNextRateUnset  = errors.New("time of next funding rate is unset")
+	errInvalidHistoryLength = errors.New("invalid funding rate history length")
+)
+
+var streamed = newCollector()
+
+func newCollector() *collector {
+	return &collector{
+		rates:         make(map[key.ExchangePairAsset]*streamedRate),
+		exchanges:     make(map[string]uuid.UUID),
+		mux:           dispatch.GetNewMux(nil),
+		historyLength: DefaultHistoryLength,
+	}
+}
+
+// Process stores a streamed funding rate and publishes it to subscribers
+func Process(u *Update) error {
+	return streamed.process(u)
+}
+
+// GetLatest returns the latest streamed funding rate for a contract
+func GetLatest(exchange string, p currency.Pair, a asset.Item) (*Update, error) {
+	return streamed.getLatest(exchange, p, a)
+}
+
+// GetAllLatest returns the latest streamed funding rate of every contract,
+// restricted to an exchange when one is provided
+func GetAllLatest(exchange string) []Update {
+	return streamed.getAllLatest(exchange)
+}
+
+// GetHistory returns the funding rate changes streamed for a contract since
+// start, oldest first. A zero start returns all retained changes
+func GetHistory(exchange string, p currency.Pair, a asset.Item, start time.Time) ([]Update, error) {
+	return streamed.getHistory(exchange, p, a, start)
+}
+
+// SubscribeToAll subscribes to the funding rates streamed from every exchange
+func SubscribeToAll() (dispatch.Pipe, error) {
+	return streamed.subscribe("")
+}
+
+// SubscribeToExchange subscribes to the funding rates streamed from an
+// exchange, including exchanges yet to stream a rate
+func SubscribeToExchange(exchange string) (dispatch.Pipe, error) {
+	if exchange == "" {
+		return dispatch.Pipe{}, errExchangeNameEmpty
+	}
+	return streamed.subscribe(exchange)
+}
+
+// SetHistoryLength sets the number of funding rate changes retained per
+// contract. Setting zero disables retention and clears any stored history
+func SetHistoryLength(n int) error {
+	return streamed.setHistoryLength(n)
+}
+
+func (c *collector) process(u *Update) error {
+	if u == nil {
+		return errUpdateIsNil
+	}
+	if u.Exchange == "" {
+		return errExchangeNameEmpty
+	}
+	if u.Pair.IsEmpty() {
+		return fmt.Errorf("%s %w", u.Exchange, currency.ErrCurrencyPairEmpty)
+	}
+	if !u.Asset.IsFutures() {
+		return fmt.Errorf("%s %s %w: %v", u.Exchange, u.Pair, errNotFutures, u.Asset)
+	}
+	if u.TimeOfNextRate.IsZero() {
+		return fmt.Errorf("%s %s %s %w", u.Exchange, u.Pair, u.Asset, errTimeOfNextRateUnset)
+	}
+	if u.UpdatedAt.IsZero() {
+		u.UpdatedAt = time.Now()
+	}
+
+	exch := strings.ToLower(u.Exchange)
+	mapKey := key.ExchangePairAsset{
+		Exchange: exch,
+		Base:     u.Pair.Base.Item,
+		Quote:    u.Pair.Quote.Item,
+		Asset:    u.Asset,
+	}
+	c.mu.Lock()
+	r, ok := c.rates[mapKey]
+	if !ok {
+		r = &streamedRate{}
+		c.rates[mapKey] = r
+	}
+	changed := !ok || !r.latest.Rate.Equal(u.Rate) || !r.latest.TimeOfNextRate.Equal(u.TimeOfNextRate)
+	r.latest = *u
+	if changed && c.historyLength > 0 {
+		r.history = append(r.history, *u)
+		if over := len(r.history) - c.historyLength; over > 0 {
+			r.history = append(r.history[:0], r.history[over:]...)
+		}
+	}
+	ids, err := c.getIDs(exch)
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	return c.mux.Publish(*u, ids...)
+}
+
+// getIDs returns the dispatch IDs an exchange's funding rates are published
+// to. c.mu must be held
+func (c *collector) getIDs(exch string) ([]uuid.UUID, error) {
+	if c.allID.IsNil() {
+		id, err := c.mux.GetID()
+		if err != nil {
+			return nil, err
+		}
+		c.allID = id
+	}
+	exchID, ok := c.exchanges[exch]
+	if !ok {
+		var err error
+		exchID, err = c.mux.GetID()
+		if err != nil {
+			return nil, err
+		}
+		c.exchanges[exch] = exchID
+	}
+	return []uuid.UUID{c.allID, exchID}, nil
+}
+
+func (c *collector) subscribe(exchange string) (dispatch.Pipe, error) {
+	c.mu.Lock()
+	ids, err := c.getIDs(strings.ToLower(exchange))
+	c.mu.Unlock()
+	if err != nil {
+		return dispatch.Pipe{}, err
+	}
+	if exchange == "" {
+		return c.mux.Subscribe(ids[0])
+	}
+	return c.mux.Subscribe(ids[1])
+}
+
+func (c *collector) get(exchange string, p currency.Pair, a asset.Item) (*streamedRate, error) {
+	if exchange == "" {
+		return nil, errExchangeNameEmpty
+	}
+	if p.IsEmpty() {
+		return nil, currency.ErrCurrencyPairEmpty
+	}
+	r, ok := c.rates[key.ExchangePairAsset{
+		Exchange: strings.ToLower(exchange),
+		Base:     p.Base.Item,
+		Quote:    p.Quote.Item,
+		Asset:    a,
+	}]
+	if !ok {
+		return nil, fmt.Errorf("%w for %s %s %s", ErrNoStreamedRate, exchange, p, a)
+	}
+	return r, nil
+}
+
+func (c *collector) getLatest(exchange string, p currency.Pair, a asset.Item) (*Update, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	r, err := c.get(exchange, p, a)
+	if err != nil {
+		return nil, err
+	}
+	cpy := r.latest
+	return &cpy, nil
+}
+
+func (c *collector) getAllLatest(exchange string) []Update {
+	exchange = strings.ToLower(exchange)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	updates := make([]Update, 0, len(c.rates))
+	for k, r := range c.rates {
+		if exchange != "" && k.Exchange != exchange {
+			continue
+		}
+		updates = append(updates, r.latest)
+	}
+	return updates
+}
+
+func (c *collector) getHistory(exchange string, p currency.Pair, a asset.Item, start time.Time) ([]Update, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	r, err := c.get(exchange, p, a)
+	if err != nil {
+		return nil, err
+	}
+	history := make([]Update, 0, len(r.history))
+	for i := range r.history {
+		if r.history[i].UpdatedAt.Before(start) {
+			continue
+		}
+		history = append(history, r.history[i])
+	}
+	return history, nil
+}
+
+func (c *collector) setHistoryLength(n int) error {
+	if n < 0 {
+		return fmt.Errorf("%w: %d", errInvalidHistoryLength, n)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.historyLength = n
+	for _, r := range c.rates {
+		if over := len(r.history) - n; over > 0 {
+			r.history = append(r.history[:0], r.history[over:]...)
+		}
+		if n == 0 {
+			r.history = nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package fundingrate
+
+import (
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+func TestMain(m *testing.M) {
+	if err := dispatch.Start(1, dispatch.DefaultJobsLimit); err != nil {
+		log.Fatal(err)
+	}
+	os.Exit(m.Run())
+}
+
+func newTestUpdate(rate float64, next time.Time) *Update {
+	return &Update{
+		Exchange:       "Test",
+		Asset:          asset.PerpetualSwap,
+		Pair:           currency.NewPair(currency.BTC, currency.USDT),
+		Rate:           decimal.NewFromFloat(rate),
+		TimeOfNextRate: next,
+	}
+}
+
+func TestProcess(t *testing.T) {
+	t.Parallel()
+	c := newCollector()
+	next := time.Now().Add(time.Hour)
+	assert.ErrorIs(t, c.process(nil), errUpdateIsNil)
+	u := newTestUpdate(0.0001, next)
+	u.Exchange = ""
+	assert.ErrorIs(t, c.process(u), errExchangeNameEmpty)
+	u = newTestUpdate(0.0001, next)
+	u.Pair = currency.EMPTYPAIR
+	assert.ErrorIs(t, c.process(u), currency.ErrCurrencyPairEmpty)
+	u = newTestUpdate(0.0001, next)
+	u.Asset = asset.Spot
+	assert.ErrorIs(t, c.process(u), errNotFutures)
+	assert.ErrorIs(t, c.process(newTestUpdate(0.0001, time.Time{})), errTimeOfNextRateUnset)
+
+	u = newTestUpdate(0.0001, next)
+	require.NoError(t, c.process(u), "process must not error")
+	assert.False(t, u.UpdatedAt.IsZero(), "UpdatedAt should be set when unset")
+	require.NoError(t, c.process(newTestUpdate(0.0001, next)), "process must not error")
+	require.NoError(t, c.process(newTestUpdate(0.0002, next)), "process must not error")
+	require.NoError(t, c.process(newTestUpdate(0.0002, next.Add(time.Hour*8))), "process must not error")
+
+	latest, err := c.getLatest("test", u.Pair, u.Asset)
+	require.NoError(t, err, "getLatest must not error")
+	assert.Equal(t, "0.0002", latest.Rate.String())
+	assert.True(t, latest.TimeOfNextRate.Equal(next.Add(time.Hour*8)), "latest should be the last processed rate")
+
+	history, err := c.getHistory("TEST", u.Pair, u.Asset, time.Time{})
+	require.NoError(t, err, "getHistory must not error")
+	assert.Len(t, history, 3, "history should only retain changes to the rate")
+
+	history, err = c.getHistory("test", u.Pair, u.Asset, time.Now().Add(time.Minute))
+	require.NoError(t, err, "getHistory must not error")
+	assert.Empty(t, history, "history should be filtered by start")
+}
+
+func TestGetLatest(t *testing.T) {
+	t.Parallel()
+	c := newCollector()
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	_, err := c.getLatest("", p, asset.PerpetualSwap)
+	assert.ErrorIs(t, err, errExchangeNameEmpty)
+	_, err = c.getLatest("test", currency.EMPTYPAIR, asset.PerpetualSwap)
+	assert.ErrorIs(t, err, currency.ErrCurrencyPairEmpty)
+	_, err = c.getLatest("test", p, asset.PerpetualSwap)
+	assert.ErrorIs(t, err, ErrNoStreamedRate)
+
+	require.NoError(t, c.process(newTestUpdate(0.0001, time.Now())), "process must not error")
+	other := newTestUpdate(0.0003, time.Now())
+	other.Exchange = "Other"
+	require.NoError(t, c.process(other), "process must not error")
+	assert.Len(t, c.getAllLatest(""), 2, "all exchanges' rates should be returned")
+	assert.Len(t, c.getAllLatest("OTHER"), 1, "rates should be filtered by exchange")
+	assert.Empty(t, c.getAllLatest("missing"))
+}
+
+func TestSetHistoryLength(t *testing.T) {
+	t.Parallel()
+	c := newCollector()
+	assert.ErrorIs(t, c.setHistoryLength(-1), errInvalidHistoryLength)
+	require.NoError(t, c.setHistoryLength(2), "setHistoryLength must not error")
+	next := time.Now()
+	for i := range 4 {
+		require.NoError(t, c.process(newTestUpdate(float64(i), next)), "process must not error")
+	}
+	u := newTestUpdate(0, next)
+	history, err := c.getHistory(u.Exchange, u.Pair, u.Asset, time.Time{})
+	require.NoError(t, err, "getHistory must not error")
+	require.Len(t, history, 2, "history must be bounded")
+	assert.Equal(t, "3", history[1].Rate.String(), "the oldest changes should be dropped")
+
+	require.NoError(t, c.setHistoryLength(0), "setHistoryLength must not error")
+	history, err = c.getHistory(u.Exchange, u.Pair, u.Asset, time.Time{})
+	require.NoError(t, err, "getHistory must not error")
+	assert.Empty(t, history, "history should be cleared when disabled")
+}
+
+func TestSubscribe(t *testing.T) {
+	t.Parallel()
+	c := newCollector()
+	_, err := SubscribeToExchange("")
+	assert.ErrorIs(t, err, errExchangeNameEmpty)
+
+	all, err := c.subscribe("")
+	require.NoError(t, err, "subscribe must not error")
+	exch, err := c.subscribe("TEST")
+	require.NoError(t, err, "subscribe must not error")
+	other, err := c.subscribe("other")
+	require.NoError(t, err, "subscribe must not error")
+
+	require.NoError(t, c.process(newTestUpdate(0.0001, time.Now())), "process must not error")
+	for _, p := range []dispatch.Pipe{all, exch} {
+		select {
+		case data := <-p.Channel():
+			u, ok := data.(Update)
+			require.True(t, ok, "published data must be an Update")
+			assert.Equal(t, "Test", u.Exchange)
+		case <-time.After(time.Second * 5):
+			require.Fail(t, "update must be published to subscribers")
+		}
+	}
+	select {
+	case <-other.Channel():
+		assert.Fail(t, "updates should not be published to other exchanges' subscribers")
+	case <-time.After(time.Millisecond * 100):
+	}
+}
@@ -2,10 +2,14 @@ package fundingrate
 
 import (
 	"errors"
+	"sync"
 	"time"
 
+	"github.com/gofrs/uuid"
 	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/common/key"
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 )
 
@@ -75,3 +79,38 @@ type Rate struct {
 	Rate    decimal.Decimal
 	Payment decimal.Decimal
 }
+
+// Update is a perpetual contract's funding rate streamed from an exchange,
+// normalised across exchanges
+type Update struct {
+	Exchange string
+	Asset    asset.Item
+	Pair     currency.Pair
+	// Rate is the rate to be applied at TimeOfNextRate, which exchanges may
+	// revise until it is applied
+	Rate decimal.Decimal
+	// PredictedRate is the rate expected for the funding period following
+	// TimeOfNextRate, where the exchange provides it
+	PredictedRate  decimal.Decimal
+	TimeOfNextRate time.Time
+	UpdatedAt      time.Time
+}
+
+// collector holds the latest funding rate and a bounded history of changes
+// for each streamed contract
+type collector struct {
+	mu            sync.Mutex
+	rates         map[key.ExchangePairAsset]*streamedRate
+	exchanges     map[string]uuid.UUID
+	allID         uuid.UUID
+	mux           *dispatch.Mux
+	historyLength int
+}
+
+// streamedRate is a contract's latest funding rate and history of changes,
+// oldest first
+type streamedRate struct {
+	latest  Update
+	history []Update
+	id      uuid.UUID
+}
@@ -1135,3 +1135,12 @@ func checkTrackerPrerequisitesLowerExchange(exch string, item asset.Item, cp cur
 	}
 	return exch, nil
 }
+
+// Risk returns the position's auto-deleveraging rank as a fraction of the
+// exchange's highest rank, where 1 is the front of the deleverage queue
+func (a *ADLIndicator) Risk() float64 {
+	if a.MaxRank <= 0 {
+		return 0
+	}
+	return float64(a.Rank) / float64(a.MaxRank)
+}
@@ -1589,3 +1589,15 @@ func TestCheckTrackerPrerequisitesLowerExchange(t *testing.T) {
 		t.Error("expected lowercase")
 	}
 }
+
+func TestADLIndicatorRisk(t *testing.T) {
+	t.Parallel()
+	a := &ADLIndicator{Rank: 4}
+	if r := a.Risk(); r != 0 {
+		t.Errorf("received '%v' expected '%v'", r, 0)
+	}
+	a.MaxRank = 5
+	if r := a.Risk(); r != 0.8 {
+		t.Errorf("received '%v' expected '%v'", r, 0.8)
+	}
+}
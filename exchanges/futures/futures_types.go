@@ -205,6 +205,32 @@ type OpenInterest struct {
 	OpenInterest float64
 }
 
+// InsuranceFund holds an exchange insurance fund balance, which covers the
+// losses of liquidated positions before profitable positions are
+// auto-deleveraged
+type InsuranceFund struct {
+	Exchange string
+	Asset    asset.Item
+	Currency currency.Code
+	Balance  float64
+	// Value is the USD value of the balance when provided by the exchange
+	Value float64
+	Time  time.Time
+}
+
+// ADLIndicator holds the auto-deleveraging queue position of a held
+// position. Rank runs from zero up to MaxRank, where positions with the
+// highest rank are the first to be deleveraged
+type ADLIndicator struct {
+	Exchange string
+	Asset    asset.Item
+	Pair     currency.Pair
+	Side     order.Side
+	Rank     int64
+	MaxRank  int64
+	Time     time.Time
+}
+
 // PNLCalculator implements the PNLCalculation interface
 // to call CalculatePNL and is used when a user wishes to have a
 // consistent method of calculating PNL across different exchanges
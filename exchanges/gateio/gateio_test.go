@@ -18,12 +18,15 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/core"
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/fundingrate"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/sharedtestvalues"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 	testexch "github.com/thrasher-corp/gocryptotrader/internal/testing/exchange"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
 )
@@ -2771,6 +2774,21 @@ func TestOptionsContractTickerPushData(t *testing.T) {
 	}
 }
 
+func TestProcessOptionsContractTickersGreeks(t *testing.T) {
+	t.Parallel()
+	e := &Gateio{Base: exchange.Base{Name: "test", Websocket: &stream.Websocket{DataHandler: make(chan any, 1)}}}
+	require.NoError(t, e.processOptionsContractTickers([]byte(`{"name":"BTC_USDT-20211231-59800-P","last_price":"11349.5","mark_price":"11170.19","vega":"34.8731","theta":"-72.80588","rho":"-28.53331","gamma":"0.00003","delta":"-0.78311","mark_iv":"0.86695"}`)))
+	tick, ok := (<-e.Websocket.DataHandler).(*ticker.Price)
+	require.True(t, ok, "ticker data must be sent to the data handler")
+	assert.Equal(t, 11170.19, tick.MarkPrice)
+	assert.Equal(t, -0.78311, tick.Delta)
+	assert.Equal(t, 0.00003, tick.Gamma)
+	assert.Equal(t, 34.8731, tick.Vega)
+	assert.Equal(t, -72.80588, tick.Theta)
+	assert.Equal(t, -28.53331, tick.Rho, "rho should be populated")
+	assert.Equal(t, 0.86695, tick.ImpliedVolatility)
+}
+
 const optionsUnderlyingTickerPushDataJSON = `{"time": 1630576352,	"channel": "options.ul_tickers",	"event": "update",	"result": {	   "trade_put": 800,	   "trade_call": 41700,	   "index_price": "50695.43",	   "name": "BTC_USDT"	}}`
 
 func TestOptionsUnderlyingTickerPushData(t *testing.T) {
@@ -3546,9 +3564,13 @@ func TestGetTimeInForce(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "gtc", ret)
 
-	ret, err = getTimeInForce(&order.Submit{Type: order.Market, FillOrKill: true})
+	ret, err = getTimeInForce(&order.Submit{Type: order.Market, TimeInForce: order.FOK})
 	require.NoError(t, err)
 	assert.Equal(t, "fok", ret)
+
+	ret, err = getTimeInForce(&order.Submit{Type: order.Limit, TimeInForce: order.IOC})
+	require.NoError(t, err)
+	assert.Equal(t, "ioc", ret)
 }
 
 func TestProcessFuturesOrdersPushData(t *testing.T) {
@@ -927,11 +927,11 @@ type OptionsTicker struct {
 	Ask1Price             types.Number  `json:"ask1_price"`
 	Bid1Size              float64       `json:"bid1_size"`
 	Bid1Price             types.Number  `json:"bid1_price"`
-	Vega                  string        `json:"vega"`
-	Theta                 string        `json:"theta"`
-	Rho                   string        `json:"rho"`
-	Gamma                 string        `json:"gamma"`
-	Delta                 string        `json:"delta"`
+	Vega                  types.Number  `json:"vega"`
+	Theta                 types.Number  `json:"theta"`
+	Rho                   types.Number  `json:"rho"`
+	Gamma                 types.Number  `json:"gamma"`
+	Delta                 types.Number  `json:"delta"`
 	MarkImpliedVolatility types.Number  `json:"mark_iv"`
 	BidImpliedVolatility  types.Number  `json:"bid_iv"`
 	AskImpliedVolatility  types.Number  `json:"ask_iv"`
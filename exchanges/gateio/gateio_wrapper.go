@@ -317,14 +317,21 @@ func (g *Gateio) UpdateTicker(ctx context.Context, p currency.Pair, a asset.Item
 				return nil, err
 			}
 			tickerData = &ticker.Price{
-				Pair:         tickers[x].Name,
-				Last:         tickers[x].LastPrice.Float64(),
-				Bid:          tickers[x].Bid1Price.Float64(),
-				Ask:          tickers[x].Ask1Price.Float64(),
-				AskSize:      tickers[x].Ask1Size,
-				BidSize:      tickers[x].Bid1Size,
-				ExchangeName: g.Name,
-				AssetType:    a,
+				Pair:              tickers[x].Name,
+				Last:              tickers[x].LastPrice.Float64(),
+				Bid:               tickers[x].Bid1Price.Float64(),
+				Ask:               tickers[x].Ask1Price.Float64(),
+				AskSize:           tickers[x].Ask1Size,
+				BidSize:           tickers[x].Bid1Size,
+				MarkPrice:         tickers[x].MarkPrice.Float64(),
+				Delta:             tickers[x].Delta.Float64(),
+				Gamma:             tickers[x].Gamma.Float64(),
+				Vega:              tickers[x].Vega.Float64(),
+				Theta:             tickers[x].Theta.Float64(),
+				Rho:               tickers[x].Rho.Float64(),
+				ImpliedVolatility: tickers[x].MarkImpliedVolatility.Float64(),
+				ExchangeName:      g.Name,
+				AssetType:         a,
 			}
 			err = ticker.ProcessTicker(tickerData)
 			if err != nil {
@@ -984,7 +991,7 @@ func (g *Gateio) GetHistoricTrades(_ context.Context, _ currency.Pair, _ asset.I
 // SubmitOrder submits a new order
 // TODO: support multiple order types (IOC)
 func (g *Gateio) SubmitOrder(ctx context.Context, s *order.Submit) (*order.SubmitResponse, error) {
-	err := s.Validate()
+	err := s.Validate(s.TimeInForceSupported(order.GTC|order.IOC|order.FOK, true))
 	if err != nil {
 		return nil, err
 	}
@@ -2540,18 +2547,18 @@ var errPostOnlyOrderTypeUnsupported = errors.New("post only is only supported fo
 // getTimeInForce returns the time in force for a given order. If Market order
 // IOC
 func getTimeInForce(s *order.Submit) (string, error) {
-	timeInForce := "gtc" // limit order taker/maker
-	if s.Type == order.Market || s.ImmediateOrCancel {
-		timeInForce = "ioc" // market taker only
-	}
 	if s.PostOnly {
 		if s.Type != order.Limit {
 			return "", fmt.Errorf("%w not for %v", errPostOnlyOrderTypeUnsupported, s.Type)
 		}
-		timeInForce = "poc" // limit order maker only
+		return "poc", nil // limit order maker only
 	}
-	if s.FillOrKill {
-		timeInForce = "fok" // market order entire fill or kill
+	switch {
+	case s.TimeInForce == order.FOK:
+		return "fok", nil // market order entire fill or kill
+	case s.Type == order.Market || s.TimeInForce == order.IOC:
+		return "ioc", nil // market taker only
+	default:
+		return "gtc", nil // limit order taker/maker
 	}
-	return timeInForce, nil
 }
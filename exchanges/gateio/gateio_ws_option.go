@@ -407,14 +407,21 @@ func (g *Gateio) processOptionsContractTickers(incoming []byte) error {
 		return err
 	}
 	g.Websocket.DataHandler <- &ticker.Price{
-		Pair:         data.Name,
-		Last:         data.LastPrice.Float64(),
-		Bid:          data.Bid1Price.Float64(),
-		Ask:          data.Ask1Price.Float64(),
-		AskSize:      data.Ask1Size,
-		BidSize:      data.Bid1Size,
-		ExchangeName: g.Name,
-		AssetType:    asset.Options,
+		Pair:              data.Name,
+		Last:              data.LastPrice.Float64(),
+		Bid:               data.Bid1Price.Float64(),
+		Ask:               data.Ask1Price.Float64(),
+		AskSize:           data.Ask1Size,
+		BidSize:           data.Bid1Size,
+		MarkPrice:         data.MarkPrice.Float64(),
+		Delta:             data.Delta.Float64(),
+		Gamma:             data.Gamma.Float64(),
+		Vega:              data.Vega.Float64(),
+		Theta:             data.Theta.Float64(),
+		Rho:               data.Rho.Float64(),
+		ImpliedVolatility: data.MarkImpliedVolatility.Float64(),
+		ExchangeName:      g.Name,
+		AssetType:         asset.Options,
 	}
 	return nil
 }
@@ -2,7 +2,6 @@ package gateio
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
@@ -82,23 +81,7 @@ func (r *RateLimitter) Limit(ctx context.Context, epl request.EndpointLimit) err
 		return r.Withdrawal.Wait(ctx)
 	default:
 	}
-	var finalDelay time.Duration
-	var reserves = make([]*rate.Reservation, tokens)
-	for i := 0; i < tokens; i++ {
-		reserves[i] = limiter.Reserve()
-		finalDelay = reserves[i].Delay()
-	}
-	if dl, ok := ctx.Deadline(); ok && dl.Before(time.Now().Add(finalDelay)) {
-		for x := range reserves {
-			reserves[x].Cancel()
-		}
-		return fmt.Errorf("rate limit delay of %s will exceed deadline: %w",
-			finalDelay,
-			context.DeadlineExceeded)
-	}
-
-	time.Sleep(finalDelay)
-	return nil
+	return request.WaitForTokens(ctx, limiter, tokens)
 }
 
 // SetRateLimit returns the rate limiter for the exchange
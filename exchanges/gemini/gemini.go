@@ -172,13 +172,16 @@ func (g *Gemini) GetAuctionHistory(ctx context.Context, currencyPair string, par
 
 // NewOrder Only limit orders are supported through the API at present.
 // returns order ID if successful
-func (g *Gemini) NewOrder(ctx context.Context, symbol string, amount, price float64, side, orderType string) (int64, error) {
+func (g *Gemini) NewOrder(ctx context.Context, symbol string, amount, price float64, side, orderType string, options []string) (int64, error) {
 	req := make(map[string]interface{})
 	req["symbol"] = symbol
 	req["amount"] = strconv.FormatFloat(amount, 'f', -1, 64)
 	req["price"] = strconv.FormatFloat(price, 'f', -1, 64)
 	req["side"] = side
 	req["type"] = orderType
+	if len(options) > 0 {
+		req["options"] = options
+	}
 
 	response := Order{}
 	err := g.SendAuthenticatedHTTPRequest(ctx, exchange.RestSpot, http.MethodPost, geminiOrderNew, req, &response)
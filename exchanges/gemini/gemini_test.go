@@ -117,7 +117,8 @@ func TestNewOrder(t *testing.T) {
 		1,
 		9000000,
 		order.Sell.Lower(),
-		"exchange limit")
+		"exchange limit",
+		nil)
 	if err != nil && mockTests {
 		t.Error("NewOrder() error", err)
 	} else if err == nil && !mockTests {
@@ -498,7 +498,7 @@ allTrades:
 
 // SubmitOrder submits a new order
 func (g *Gemini) SubmitOrder(ctx context.Context, s *order.Submit) (*order.SubmitResponse, error) {
-	if err := s.Validate(); err != nil {
+	if err := s.Validate(s.TimeInForceSupported(order.GTC|order.IOC|order.FOK, true)); err != nil {
 		return nil, err
 	}
 
@@ -511,12 +511,23 @@ func (g *Gemini) SubmitOrder(ctx context.Context, s *order.Submit) (*order.Submi
 		return nil, err
 	}
 
+	var options []string
+	switch {
+	case s.PostOnly:
+		options = []string{"maker-or-cancel"}
+	case s.TimeInForce == order.IOC:
+		options = []string{"immediate-or-cancel"}
+	case s.TimeInForce == order.FOK:
+		options = []string{"fill-or-kill"}
+	}
+
 	response, err := g.NewOrder(ctx,
 		fPair.String(),
 		s.Amount,
 		s.Price,
 		s.Side.String(),
-		"exchange limit")
+		"exchange limit",
+		options)
 	if err != nil {
 		return nil, err
 	}
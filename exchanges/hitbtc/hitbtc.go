@@ -44,6 +44,8 @@ const (
 	transferBalance     = "transferBalance"
 )
 
+var errClientOrderIDRequired = errors.New("client order id required for websocket order entry")
+
 // HitBTC is the overarching type across the hitbtc package
 type HitBTC struct {
 	exchange.Base
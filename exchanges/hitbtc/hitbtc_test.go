@@ -495,7 +495,7 @@ func TestWsCancelOrder(t *testing.T) {
 	if !canManipulateRealOrders {
 		t.Skip("canManipulateRealOrders false, skipping test")
 	}
-	_, err := h.wsCancelOrder("ImNotARealOrderID")
+	_, err := h.wsCancelOrder(context.Background(), "ImNotARealOrderID")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -507,8 +507,10 @@ func TestWsPlaceOrder(t *testing.T) {
 	if !canManipulateRealOrders {
 		t.Skip("canManipulateRealOrders false, skipping test")
 	}
-	_, err := h.wsPlaceOrder(currency.NewPair(currency.LTC, currency.BTC),
+	_, err := h.wsPlaceOrder(context.Background(), currency.NewPair(currency.LTC, currency.BTC),
 		order.Buy.String(),
+		order.Limit.String(),
+		"",
 		1,
 		1)
 	if err != nil {
@@ -522,7 +524,7 @@ func TestWsReplaceOrder(t *testing.T) {
 	if !canManipulateRealOrders {
 		t.Skip("canManipulateRealOrders false, skipping test")
 	}
-	_, err := h.wsReplaceOrder("ImNotARealOrderID", 1, 1)
+	_, err := h.wsReplaceOrder(context.Background(), "ImNotARealOrderID", 1, 1)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -531,7 +533,7 @@ func TestWsReplaceOrder(t *testing.T) {
 // TestWsGetActiveOrders dials websocket, sends get active orders request.
 func TestWsGetActiveOrders(t *testing.T) {
 	setupWsAuth(t)
-	if _, err := h.wsGetActiveOrders(); err != nil {
+	if _, err := h.wsGetActiveOrders(context.Background()); err != nil {
 		t.Fatal(err)
 	}
 }
@@ -539,7 +541,7 @@ func TestWsGetActiveOrders(t *testing.T) {
 // TestWsGetTradingBalance dials websocket, sends get trading balance request.
 func TestWsGetTradingBalance(t *testing.T) {
 	setupWsAuth(t)
-	if _, err := h.wsGetTradingBalance(); err != nil {
+	if _, err := h.wsGetTradingBalance(context.Background()); err != nil {
 		t.Fatal(err)
 	}
 }
@@ -547,7 +549,7 @@ func TestWsGetTradingBalance(t *testing.T) {
 // TestWsGetTradingBalance dials websocket, sends get trading balance request.
 func TestWsGetTrades(t *testing.T) {
 	setupWsAuth(t)
-	_, err := h.wsGetTrades(currency.NewPair(currency.ETH, currency.BTC), 1000, "ASC", "id")
+	_, err := h.wsGetTrades(context.Background(), currency.NewPair(currency.ETH, currency.BTC), 1000, "ASC", "id")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -556,7 +558,7 @@ func TestWsGetTrades(t *testing.T) {
 // TestWsGetTradingBalance dials websocket, sends get trading balance request.
 func TestWsGetSymbols(t *testing.T) {
 	setupWsAuth(t)
-	_, err := h.wsGetSymbols(currency.NewPair(currency.ETH, currency.BTC))
+	_, err := h.wsGetSymbols(context.Background(), currency.NewPair(currency.ETH, currency.BTC))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -565,7 +567,7 @@ func TestWsGetSymbols(t *testing.T) {
 // TestWsGetCurrencies dials websocket, sends get trading balance request.
 func TestWsGetCurrencies(t *testing.T) {
 	setupWsAuth(t)
-	_, err := h.wsGetCurrencies(currency.BTC)
+	_, err := h.wsGetCurrencies(context.Background(), currency.BTC)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -279,35 +279,12 @@ type LendingHistory struct {
 	Close    string  `json:"close"`
 }
 
-type capture struct {
-	Method string        `json:"method,omitempty"`
-	Result interface{}   `json:"result"`
-	Error  ResponseError `json:"error,omitempty"`
-	ID     int64         `json:"id,omitempty"`
-}
-
 // ResponseError contains error codes from JSON responses
 type ResponseError struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
 }
 
-// WsRequest defines a request obj for the JSON-RPC and gets a websocket
-// response
-type WsRequest struct {
-	Method string `json:"method"`
-	Params Params `json:"params,omitempty"`
-	ID     int64  `json:"id"`
-}
-
-// WsNotification defines a notification obj for the JSON-RPC this does not get
-// a websocket response
-type WsNotification struct {
-	JSONRPCVersion string `json:"jsonrpc,omitempty"`
-	Method         string `json:"method"`
-	Params         Params `json:"params"`
-}
-
 // Params is params
 type Params struct {
 	Symbol  string   `json:"symbol,omitempty"`
@@ -363,14 +340,7 @@ type WsTrade struct {
 	} `json:"params"`
 }
 
-// WsLoginRequest defines login requirements for ws
-type WsLoginRequest struct {
-	Method string      `json:"method"`
-	Params WsLoginData `json:"params"`
-	ID     int64       `json:"id,omitempty"`
-}
-
-// WsLoginData sets credentials for WsLoginRequest
+// WsLoginData sets credentials for the websocket login request
 type WsLoginData struct {
 	Algo      string `json:"algo"`
 	PKey      string `json:"pKey"`
@@ -450,19 +420,13 @@ type WsReportResponseData struct {
 	TradeFee      float64   `json:"tradeFee,string"`
 }
 
-// WsSubmitOrderRequest WS request
-type WsSubmitOrderRequest struct {
-	Method string                   `json:"method"`
-	Params WsSubmitOrderRequestData `json:"params"`
-	ID     int64                    `json:"id"`
-}
-
 // WsSubmitOrderRequestData WS request data
 type WsSubmitOrderRequestData struct {
-	ClientOrderID int64   `json:"clientOrderId,string,omitempty"`
+	ClientOrderID string  `json:"clientOrderId,omitempty"`
 	Symbol        string  `json:"symbol"`
 	Side          string  `json:"side"`
-	Price         float64 `json:"price,string"`
+	Type          string  `json:"type,omitempty"`
+	Price         float64 `json:"price,string,omitempty"`
 	Quantity      float64 `json:"quantity,string"`
 }
 
@@ -595,25 +559,11 @@ type WsGetTradingBalanceResponseData struct {
 	Reserved  float64       `json:"reserved,string"`
 }
 
-// WsCancelOrderRequest WS request
-type WsCancelOrderRequest struct {
-	Method string                   `json:"method"`
-	Params WsCancelOrderRequestData `json:"params"`
-	ID     int64                    `json:"id"`
-}
-
 // WsCancelOrderRequestData WS request data
 type WsCancelOrderRequestData struct {
 	ClientOrderID string `json:"clientOrderId"`
 }
 
-// WsReplaceOrderRequest WS request
-type WsReplaceOrderRequest struct {
-	Method string                    `json:"method"`
-	Params WsReplaceOrderRequestData `json:"params"`
-	ID     int64                     `json:"id,omitempty"`
-}
-
 // WsReplaceOrderRequestData WS request data
 type WsReplaceOrderRequestData struct {
 	ClientOrderID   string  `json:"clientOrderId,omitempty"`
@@ -622,13 +572,6 @@ type WsReplaceOrderRequestData struct {
 	Price           float64 `json:"price,string,omitempty"`
 }
 
-// WsGetCurrenciesRequest gets currencies
-type WsGetCurrenciesRequest struct {
-	Method string                           `json:"method"`
-	Params WsGetCurrenciesRequestParameters `json:"params"`
-	ID     int64                            `json:"id"`
-}
-
 // WsGetCurrenciesRequestParameters parameters
 type WsGetCurrenciesRequestParameters struct {
 	Currency currency.Code `json:"currency"`
@@ -656,13 +599,6 @@ type WsGetCurrenciesResponseData struct {
 	PayoutFee          string        `json:"payoutFee"`
 }
 
-// WsGetSymbolsRequest request data
-type WsGetSymbolsRequest struct {
-	Method string                        `json:"method"`
-	Params WsGetSymbolsRequestParameters `json:"params"`
-	ID     int64                         `json:"id"`
-}
-
 // WsGetSymbolsRequestParameters request parameters
 type WsGetSymbolsRequestParameters struct {
 	Symbol string `json:"symbol"`
@@ -687,13 +623,6 @@ type WsGetSymbolsResponseData struct {
 	FeeCurrency          currency.Code `json:"feeCurrency"`
 }
 
-// WsGetTradesRequest trade request
-type WsGetTradesRequest struct {
-	Method string                       `json:"method"`
-	Params WsGetTradesRequestParameters `json:"params"`
-	ID     int64                        `json:"id"`
-}
-
 // WsGetTradesRequestParameters trade request params
 type WsGetTradesRequestParameters struct {
 	Symbol string `json:"symbol"`
@@ -26,13 +26,12 @@ import (
 
 const (
 	hitbtcWebsocketAddress = "wss://api.hitbtc.com/api/2/ws"
-	rpcVersion             = "2.0"
 	rateLimit              = 20
 	errAuthFailed          = 1002
 )
 
 // WsConnect starts a new connection with the websocket API
-func (h *HitBTC) WsConnect() error {
+func (h *HitBTC) WsConnect(ctx context.Context) error {
 	if !h.Websocket.IsEnabled() || !h.IsEnabled() {
 		return stream.ErrWebsocketNotEnabled
 	}
@@ -46,7 +45,7 @@ func (h *HitBTC) WsConnect() error {
 	go h.wsReadData()
 
 	if h.Websocket.CanUseAuthenticatedEndpoints() {
-		err = h.wsLogin(context.TODO())
+		err = h.wsLogin(ctx)
 		if err != nil {
 			log.Errorf(log.ExchangeSys, "%v - authentication failed: %v\n", h.Name, err)
 		}
@@ -73,31 +72,33 @@ func (h *HitBTC) wsReadData() {
 }
 
 func (h *HitBTC) wsGetTableName(respRaw []byte) (string, error) {
-	var init capture
-	err := json.Unmarshal(respRaw, &init)
+	msg, err := h.wsRPC().HandleMessage(respRaw)
 	if err != nil {
+		var rpcErr *stream.JSONRPCError
+		if errors.As(err, &rpcErr) && rpcErr.Code == errAuthFailed {
+			h.Websocket.SetCanUseAuthenticatedEndpoints(false)
+		}
 		return "", err
 	}
-	if init.Error.Code == errAuthFailed {
-		h.Websocket.SetCanUseAuthenticatedEndpoints(false)
+	if msg == nil {
+		return "", nil
 	}
-	if init.ID > 0 {
-		if h.Websocket.Match.IncomingWithData(init.ID, respRaw) {
-			return "", nil
+	if msg.Method != "" {
+		return msg.Method, nil
+	}
+	var result interface{}
+	if len(msg.Result) != 0 {
+		if err = json.Unmarshal(msg.Result, &result); err != nil {
+			return "", err
 		}
 	}
-	if init.Error.Message != "" || init.Error.Code != 0 {
-		return "", fmt.Errorf("code: %d, Message: %s",
-			init.Error.Code,
-			init.Error.Message)
+	var id int64
+	if msg.ID != nil {
+		id = *msg.ID
 	}
-	if _, ok := init.Result.(bool); ok {
+	switch resultType := result.(type) {
+	case bool:
 		return "", nil
-	}
-	if init.Method != "" {
-		return init.Method, nil
-	}
-	switch resultType := init.Result.(type) {
 	case map[string]interface{}:
 		if reportType, ok := resultType["reportType"].(string); ok {
 			return reportType, nil
@@ -111,7 +112,7 @@ func (h *HitBTC) wsGetTableName(respRaw []byte) (string, error) {
 		}
 	case []interface{}:
 		if len(resultType) == 0 {
-			h.Websocket.DataHandler <- fmt.Sprintf("No data returned. ID: %v", init.ID)
+			h.Websocket.DataHandler <- fmt.Sprintf("No data returned. ID: %v", id)
 			return "", nil
 		}
 
@@ -500,74 +501,60 @@ func (h *HitBTC) GenerateDefaultSubscriptions() ([]subscription.Subscription, er
 	return subscriptions, nil
 }
 
+// wsRPC returns a JSON-RPC client for the websocket connection
+func (h *HitBTC) wsRPC() *stream.JSONRPCClient {
+	return stream.NewJSONRPCClient(h.Websocket.Conn, h.Websocket.Match)
+}
+
+// channelParams returns the request parameters for a subscription channel
+func channelParams(s *subscription.Subscription) Params {
+	var p Params
+	if s.Pair.String() != "" {
+		p.Symbol = s.Pair.String()
+	}
+	switch {
+	case strings.HasSuffix(s.Channel, "Trades"):
+		p.Limit = 100
+	case strings.HasSuffix(s.Channel, "Candles"):
+		p.Period = "M30"
+		p.Limit = 100
+	}
+	return p
+}
+
 // Subscribe sends a websocket message to receive data from the channel
-func (h *HitBTC) Subscribe(channelsToSubscribe []subscription.Subscription) error {
+func (h *HitBTC) Subscribe(ctx context.Context, channelsToSubscribe []subscription.Subscription) error {
 	var errs error
 	for i := range channelsToSubscribe {
-		subscribe := WsRequest{
-			Method: channelsToSubscribe[i].Channel,
-			ID:     h.Websocket.Conn.GenerateMessageID(false),
-		}
-
-		if channelsToSubscribe[i].Pair.String() != "" {
-			subscribe.Params.Symbol = channelsToSubscribe[i].Pair.String()
-		}
-		if strings.EqualFold(channelsToSubscribe[i].Channel, "subscribeTrades") {
-			subscribe.Params.Limit = 100
-		} else if strings.EqualFold(channelsToSubscribe[i].Channel, "subscribeCandles") {
-			subscribe.Params.Period = "M30"
-			subscribe.Params.Limit = 100
-		}
-
-		err := h.Websocket.Conn.SendJSONMessage(subscribe)
+		err := h.wsRPC().Call(ctx, channelsToSubscribe[i].Channel, channelParams(&channelsToSubscribe[i]), nil)
 		if err != nil {
 			errs = common.AppendError(errs, err)
 			continue
 		}
 		h.Websocket.AddSuccessfulSubscriptions(channelsToSubscribe[i])
 	}
-	if errs != nil {
-		return errs
-	}
-	return nil
+	return errs
 }
 
 // Unsubscribe sends a websocket message to stop receiving data from the channel
-func (h *HitBTC) Unsubscribe(channelsToUnsubscribe []subscription.Subscription) error {
+func (h *HitBTC) Unsubscribe(_ context.Context, channelsToUnsubscribe []subscription.Subscription) error {
 	var errs error
 	for i := range channelsToUnsubscribe {
 		unsubscribeChannel := strings.Replace(channelsToUnsubscribe[i].Channel,
 			"subscribe",
 			"unsubscribe",
 			1)
-
-		unsubscribe := WsNotification{
-			JSONRPCVersion: rpcVersion,
-			Method:         unsubscribeChannel,
-		}
-
-		unsubscribe.Params.Symbol = channelsToUnsubscribe[i].Pair.String()
-		if strings.EqualFold(unsubscribeChannel, "unsubscribeTrades") {
-			unsubscribe.Params.Limit = 100
-		} else if strings.EqualFold(unsubscribeChannel, "unsubscribeCandles") {
-			unsubscribe.Params.Period = "M30"
-			unsubscribe.Params.Limit = 100
-		}
-
-		err := h.Websocket.Conn.SendJSONMessage(unsubscribe)
+		err := h.wsRPC().Notify(unsubscribeChannel, channelParams(&channelsToUnsubscribe[i]))
 		if err != nil {
 			errs = common.AppendError(errs, err)
 			continue
 		}
 		h.Websocket.RemoveSubscriptions(channelsToUnsubscribe[i])
 	}
-	if errs != nil {
-		return errs
-	}
-	return nil
+	return errs
 }
 
-// Unsubscribe sends a websocket message to stop receiving data from the channel
+// wsLogin authenticates the websocket connection
 func (h *HitBTC) wsLogin(ctx context.Context) error {
 	if !h.IsWebsocketAuthenticationSupported() {
 		return fmt.Errorf("%v AuthenticatedWebsocketAPISupport not enabled", h.Name)
@@ -585,252 +572,156 @@ func (h *HitBTC) wsLogin(ctx context.Context) error {
 		return err
 	}
 
-	request := WsLoginRequest{
-		Method: "login",
-		Params: WsLoginData{
-			Algo:      "HS256",
-			PKey:      creds.Key,
-			Nonce:     n,
-			Signature: crypto.HexEncodeToString(hmac),
-		},
-		ID: h.Websocket.Conn.GenerateMessageID(false),
-	}
-
-	err = h.Websocket.Conn.SendJSONMessage(request)
+	err = h.wsRPC().Call(ctx, "login", WsLoginData{
+		Algo:      "HS256",
+		PKey:      creds.Key,
+		Nonce:     n,
+		Signature: crypto.HexEncodeToString(hmac),
+	}, nil)
 	if err != nil {
 		h.Websocket.SetCanUseAuthenticatedEndpoints(false)
 		return err
 	}
+	return nil
+}
 
+// wsCall sends a JSON-RPC request and unmarshals the full response
+func (h *HitBTC) wsCall(ctx context.Context, method string, params, response any) error {
+	resp, err := h.wsRPC().CallRaw(ctx, method, params)
+	if err != nil {
+		return fmt.Errorf("%v %w", h.Name, err)
+	}
+	if err = json.Unmarshal(resp, response); err != nil {
+		return fmt.Errorf("%v %w", h.Name, err)
+	}
 	return nil
 }
 
-// wsPlaceOrder sends a websocket message to submit an order
-func (h *HitBTC) wsPlaceOrder(pair currency.Pair, side string, price, quantity float64) (*WsSubmitOrderSuccessResponse, error) {
+// wsPlaceOrder sends a websocket message to submit an order. A client order ID
+// is generated when one is not provided, and is required to cancel or replace
+// the order via websocket
+func (h *HitBTC) wsPlaceOrder(ctx context.Context, pair currency.Pair, side, orderType, clientOrderID string, price, quantity float64) (*WsSubmitOrderSuccessResponse, error) {
 	if !h.Websocket.CanUseAuthenticatedEndpoints() {
 		return nil, fmt.Errorf("%v not authenticated, cannot place order", h.Name)
 	}
 
-	id := h.Websocket.Conn.GenerateMessageID(false)
 	fPair, err := h.FormatExchangeCurrency(pair, asset.Spot)
 	if err != nil {
 		return nil, err
 	}
 
-	request := WsSubmitOrderRequest{
-		Method: "newOrder",
-		Params: WsSubmitOrderRequestData{
-			ClientOrderID: id,
-			Symbol:        fPair.String(),
-			Side:          strings.ToLower(side),
-			Price:         price,
-			Quantity:      quantity,
-		},
-		ID: id,
-	}
-	resp, err := h.Websocket.Conn.SendMessageReturnResponse(id, request)
-	if err != nil {
-		return nil, fmt.Errorf("%v %v", h.Name, err)
+	if clientOrderID == "" {
+		clientOrderID = strconv.FormatInt(h.Websocket.Conn.GenerateMessageID(false), 10)
 	}
 	var response WsSubmitOrderSuccessResponse
-	err = json.Unmarshal(resp, &response)
-	if err != nil {
-		return nil, fmt.Errorf("%v %v", h.Name, err)
-	}
-	if response.Error.Code > 0 || response.Error.Message != "" {
-		return &response, fmt.Errorf("%v Error:%v Message:%v", h.Name, response.Error.Code, response.Error.Message)
+	if err = h.wsCall(ctx, "newOrder", WsSubmitOrderRequestData{
+		ClientOrderID: clientOrderID,
+		Symbol:        fPair.String(),
+		Side:          strings.ToLower(side),
+		Type:          strings.ToLower(orderType),
+		Price:         price,
+		Quantity:      quantity,
+	}, &response); err != nil {
+		return nil, err
 	}
 	return &response, nil
 }
 
 // wsCancelOrder sends a websocket message to cancel an order
-func (h *HitBTC) wsCancelOrder(clientOrderID string) (*WsCancelOrderResponse, error) {
+func (h *HitBTC) wsCancelOrder(ctx context.Context, clientOrderID string) (*WsCancelOrderResponse, error) {
 	if !h.Websocket.CanUseAuthenticatedEndpoints() {
 		return nil, fmt.Errorf("%v not authenticated, cannot place order", h.Name)
 	}
-	request := WsCancelOrderRequest{
-		Method: "cancelOrder",
-		Params: WsCancelOrderRequestData{
-			ClientOrderID: clientOrderID,
-		},
-		ID: h.Websocket.Conn.GenerateMessageID(false),
-	}
-	resp, err := h.Websocket.Conn.SendMessageReturnResponse(request.ID, request)
-	if err != nil {
-		return nil, fmt.Errorf("%v %v", h.Name, err)
-	}
 	var response WsCancelOrderResponse
-	err = json.Unmarshal(resp, &response)
-	if err != nil {
-		return nil, fmt.Errorf("%v %v", h.Name, err)
-	}
-	if response.Error.Code > 0 || response.Error.Message != "" {
-		return &response, fmt.Errorf("%v Error:%v Message:%v", h.Name, response.Error.Code, response.Error.Message)
+	if err := h.wsCall(ctx, "cancelOrder", WsCancelOrderRequestData{
+		ClientOrderID: clientOrderID,
+	}, &response); err != nil {
+		return nil, err
 	}
 	return &response, nil
 }
 
 // wsReplaceOrder sends a websocket message to replace an order
-func (h *HitBTC) wsReplaceOrder(clientOrderID string, quantity, price float64) (*WsReplaceOrderResponse, error) {
+func (h *HitBTC) wsReplaceOrder(ctx context.Context, clientOrderID string, quantity, price float64) (*WsReplaceOrderResponse, error) {
 	if !h.Websocket.CanUseAuthenticatedEndpoints() {
 		return nil, fmt.Errorf("%v not authenticated, cannot place order", h.Name)
 	}
-	request := WsReplaceOrderRequest{
-		Method: "cancelReplaceOrder",
-		Params: WsReplaceOrderRequestData{
-			ClientOrderID:   clientOrderID,
-			RequestClientID: strconv.FormatInt(time.Now().Unix(), 10),
-			Quantity:        quantity,
-			Price:           price,
-		},
-		ID: h.Websocket.Conn.GenerateMessageID(false),
-	}
-	resp, err := h.Websocket.Conn.SendMessageReturnResponse(request.ID, request)
-	if err != nil {
-		return nil, fmt.Errorf("%v %v", h.Name, err)
-	}
 	var response WsReplaceOrderResponse
-	err = json.Unmarshal(resp, &response)
-	if err != nil {
-		return nil, fmt.Errorf("%v %v", h.Name, err)
-	}
-	if response.Error.Code > 0 || response.Error.Message != "" {
-		return &response, fmt.Errorf("%v Error:%v Message:%v", h.Name, response.Error.Code, response.Error.Message)
+	if err := h.wsCall(ctx, "cancelReplaceOrder", WsReplaceOrderRequestData{
+		ClientOrderID:   clientOrderID,
+		RequestClientID: strconv.FormatInt(time.Now().Unix(), 10),
+		Quantity:        quantity,
+		Price:           price,
+	}, &response); err != nil {
+		return nil, err
 	}
 	return &response, nil
 }
 
 // wsGetActiveOrders sends a websocket message to get all active orders
-func (h *HitBTC) wsGetActiveOrders() (*wsActiveOrdersResponse, error) {
+func (h *HitBTC) wsGetActiveOrders(ctx context.Context) (*wsActiveOrdersResponse, error) {
 	if !h.Websocket.CanUseAuthenticatedEndpoints() {
 		return nil, fmt.Errorf("%v not authenticated, cannot get active orders", h.Name)
 	}
-	request := WsReplaceOrderRequest{
-		Method: "getOrders",
-		Params: WsReplaceOrderRequestData{},
-		ID:     h.Websocket.Conn.GenerateMessageID(false),
-	}
-	resp, err := h.Websocket.Conn.SendMessageReturnResponse(request.ID, request)
-	if err != nil {
-		return nil, fmt.Errorf("%v %v", h.Name, err)
-	}
 	var response wsActiveOrdersResponse
-	err = json.Unmarshal(resp, &response)
-	if err != nil {
-		return nil, fmt.Errorf("%v %v", h.Name, err)
-	}
-	if response.Error.Code > 0 || response.Error.Message != "" {
-		return &response, fmt.Errorf("%v Error:%v Message:%v", h.Name, response.Error.Code, response.Error.Message)
+	if err := h.wsCall(ctx, "getOrders", WsReplaceOrderRequestData{}, &response); err != nil {
+		return nil, err
 	}
 	return &response, nil
 }
 
 // wsGetTradingBalance sends a websocket message to get trading balance
-func (h *HitBTC) wsGetTradingBalance() (*WsGetTradingBalanceResponse, error) {
+func (h *HitBTC) wsGetTradingBalance(ctx context.Context) (*WsGetTradingBalanceResponse, error) {
 	if !h.Websocket.CanUseAuthenticatedEndpoints() {
 		return nil, fmt.Errorf("%v not authenticated, cannot place order", h.Name)
 	}
-	request := WsReplaceOrderRequest{
-		Method: "getTradingBalance",
-		Params: WsReplaceOrderRequestData{},
-		ID:     h.Websocket.Conn.GenerateMessageID(false),
-	}
-	resp, err := h.Websocket.Conn.SendMessageReturnResponse(request.ID, request)
-	if err != nil {
-		return nil, fmt.Errorf("%v %v", h.Name, err)
-	}
 	var response WsGetTradingBalanceResponse
-	err = json.Unmarshal(resp, &response)
-	if err != nil {
-		return nil, fmt.Errorf("%v %v", h.Name, err)
-	}
-	if response.Error.Code > 0 || response.Error.Message != "" {
-		return &response, fmt.Errorf("%v Error:%v Message:%v", h.Name, response.Error.Code, response.Error.Message)
+	if err := h.wsCall(ctx, "getTradingBalance", WsReplaceOrderRequestData{}, &response); err != nil {
+		return nil, err
 	}
 	return &response, nil
 }
 
 // wsGetCurrencies sends a websocket message to get trading balance
-func (h *HitBTC) wsGetCurrencies(currencyItem currency.Code) (*WsGetCurrenciesResponse, error) {
-	request := WsGetCurrenciesRequest{
-		Method: "getCurrency",
-		Params: WsGetCurrenciesRequestParameters{
-			Currency: currencyItem,
-		},
-		ID: h.Websocket.Conn.GenerateMessageID(false),
-	}
-	resp, err := h.Websocket.Conn.SendMessageReturnResponse(request.ID, request)
-	if err != nil {
-		return nil, fmt.Errorf("%v %v", h.Name, err)
-	}
+func (h *HitBTC) wsGetCurrencies(ctx context.Context, currencyItem currency.Code) (*WsGetCurrenciesResponse, error) {
 	var response WsGetCurrenciesResponse
-	err = json.Unmarshal(resp, &response)
-	if err != nil {
-		return nil, fmt.Errorf("%v %v", h.Name, err)
-	}
-	if response.Error.Code > 0 || response.Error.Message != "" {
-		return &response, fmt.Errorf("%v Error:%v Message:%v", h.Name, response.Error.Code, response.Error.Message)
+	if err := h.wsCall(ctx, "getCurrency", WsGetCurrenciesRequestParameters{
+		Currency: currencyItem,
+	}, &response); err != nil {
+		return nil, err
 	}
 	return &response, nil
 }
 
 // wsGetSymbols sends a websocket message to get trading balance
-func (h *HitBTC) wsGetSymbols(c currency.Pair) (*WsGetSymbolsResponse, error) {
+func (h *HitBTC) wsGetSymbols(ctx context.Context, c currency.Pair) (*WsGetSymbolsResponse, error) {
 	fPair, err := h.FormatExchangeCurrency(c, asset.Spot)
 	if err != nil {
 		return nil, err
 	}
-
-	request := WsGetSymbolsRequest{
-		Method: "getSymbol",
-		Params: WsGetSymbolsRequestParameters{
-			Symbol: fPair.String(),
-		},
-		ID: h.Websocket.Conn.GenerateMessageID(false),
-	}
-	resp, err := h.Websocket.Conn.SendMessageReturnResponse(request.ID, request)
-	if err != nil {
-		return nil, fmt.Errorf("%v %v", h.Name, err)
-	}
 	var response WsGetSymbolsResponse
-	err = json.Unmarshal(resp, &response)
-	if err != nil {
-		return nil, fmt.Errorf("%v %v", h.Name, err)
-	}
-	if response.Error.Code > 0 || response.Error.Message != "" {
-		return &response, fmt.Errorf("%v Error:%v Message:%v", h.Name, response.Error.Code, response.Error.Message)
+	if err = h.wsCall(ctx, "getSymbol", WsGetSymbolsRequestParameters{
+		Symbol: fPair.String(),
+	}, &response); err != nil {
+		return nil, err
 	}
 	return &response, nil
 }
 
-// wsGetSymbols sends a websocket message to get trading balance
-func (h *HitBTC) wsGetTrades(c currency.Pair, limit int64, sort, by string) (*WsGetTradesResponse, error) {
+// wsGetTrades sends a websocket message to get trades
+func (h *HitBTC) wsGetTrades(ctx context.Context, c currency.Pair, limit int64, sort, by string) (*WsGetTradesResponse, error) {
 	fPair, err := h.FormatExchangeCurrency(c, asset.Spot)
 	if err != nil {
 		return nil, err
 	}
-
-	request := WsGetTradesRequest{
-		Method: "getTrades",
-		Params: WsGetTradesRequestParameters{
-			Symbol: fPair.String(),
-			Limit:  limit,
-			Sort:   sort,
-			By:     by,
-		},
-		ID: h.Websocket.Conn.GenerateMessageID(false),
-	}
-	resp, err := h.Websocket.Conn.SendMessageReturnResponse(request.ID, request)
-	if err != nil {
-		return nil, fmt.Errorf("%v %v", h.Name, err)
-	}
 	var response WsGetTradesResponse
-	err = json.Unmarshal(resp, &response)
-	if err != nil {
-		return nil, fmt.Errorf("%v %v", h.Name, err)
-	}
-	if response.Error.Code > 0 || response.Error.Message != "" {
-		return &response, fmt.Errorf("%v Error:%v Message:%v", h.Name, response.Error.Code, response.Error.Message)
+	if err = h.wsCall(ctx, "getTrades", WsGetTradesRequestParameters{
+		Symbol: fPair.String(),
+		Limit:  limit,
+		Sort:   sort,
+		By:     by,
+	}, &response); err != nil {
+		return nil, err
 	}
 	return &response, nil
 }
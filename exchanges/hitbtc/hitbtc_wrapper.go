@@ -460,20 +460,21 @@ allTrades:
 
 // SubmitOrder submits a new order
 func (h *HitBTC) SubmitOrder(ctx context.Context, o *order.Submit) (*order.SubmitResponse, error) {
-	err := o.Validate()
+	err := o.Validate(o.TimeInForceSupported(order.GTC, false))
 	if err != nil {
 		return nil, err
 	}
 
-	var orderID string
+	var orderID, clientOrderID string
 	status := order.New
-	if h.Websocket.IsConnected() && h.Websocket.CanUseAuthenticatedEndpoints() {
+	if h.Websocket.CanUseWebsocketOrderEntry() {
 		var response *WsSubmitOrderSuccessResponse
-		response, err = h.wsPlaceOrder(o.Pair, o.Side.String(), o.Amount, o.Price)
+		response, err = h.wsPlaceOrder(ctx, o.Pair, o.Side.String(), o.Type.String(), o.ClientOrderID, o.Price, o.Amount)
 		if err != nil {
 			return nil, err
 		}
-		orderID = strconv.FormatInt(response.ID, 10)
+		orderID = response.Result.ID
+		clientOrderID = response.Result.ClientOrderID
 		if response.Result.CumQuantity == o.Amount {
 			status = order.Filled
 		}
@@ -504,17 +505,47 @@ func (h *HitBTC) SubmitOrder(ctx context.Context, o *order.Submit) (*order.Submi
 		return nil, err
 	}
 	resp.Status = status
+	if clientOrderID != "" {
+		resp.ClientOrderID = clientOrderID
+	}
 	return resp, nil
 }
 
 // ModifyOrder will allow of changing orderbook placement and limit to
-// market conversion
-func (h *HitBTC) ModifyOrder(_ context.Context, _ *order.Modify) (*order.ModifyResponse, error) {
-	return nil, common.ErrFunctionNotSupported
+// market conversion. Orders can only be replaced via websocket order entry, by
+// their client order ID
+func (h *HitBTC) ModifyOrder(ctx context.Context, action *order.Modify) (*order.ModifyResponse, error) {
+	if !h.Websocket.CanUseWebsocketOrderEntry() {
+		return nil, common.ErrFunctionNotSupported
+	}
+	if err := action.Validate(); err != nil {
+		return nil, err
+	}
+	if action.ClientOrderID == "" {
+		return nil, errClientOrderIDRequired
+	}
+	response, err := h.wsReplaceOrder(ctx, action.ClientOrderID, action.Amount, action.Price)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := action.DeriveModifyResponse()
+	if err != nil {
+		return nil, err
+	}
+	resp.OrderID = response.Result.ID
+	resp.ClientOrderID = response.Result.ClientOrderID
+	return resp, nil
 }
 
 // CancelOrder cancels an order by its corresponding ID number
 func (h *HitBTC) CancelOrder(ctx context.Context, o *order.Cancel) error {
+	if err := o.Validate(); err != nil {
+		return err
+	}
+	if h.Websocket.CanUseWebsocketOrderEntry() && o.ClientOrderID != "" {
+		_, err := h.wsCancelOrder(ctx, o.ClientOrderID)
+		return err
+	}
 	if err := o.Validate(o.StandardCancel()); err != nil {
 		return err
 	}
@@ -2992,3 +2992,38 @@ func TestContractOpenInterestUSDT(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotEmpty(t, resp)
 }
+
+func TestSpotOrderType(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		s        *order.Submit
+		expected SpotNewOrderRequestParamsType
+	}{
+		{&order.Submit{Side: order.Buy, Type: order.Market}, SpotNewOrderRequestTypeBuyMarket},
+		{&order.Submit{Side: order.Sell, Type: order.Limit}, SpotNewOrderRequestTypeSellLimit},
+		{&order.Submit{Side: order.Buy, Type: order.Limit, PostOnly: true}, SpotNewOrderRequestTypeBuyLimitMaker},
+		{&order.Submit{Side: order.Sell, Type: order.Limit, TimeInForce: order.IOC}, SpotNewOrderRequestTypeSellIOC},
+		{&order.Submit{Side: order.Buy, Type: order.Limit, TimeInForce: order.FOK}, SpotNewOrderRequestTypeBuyLimitFOK},
+	} {
+		assert.Equal(t, tc.expected, spotOrderType(tc.s))
+	}
+}
+
+func TestFuturesOrderType(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		s        *order.Submit
+		expected string
+	}{
+		{&order.Submit{Type: order.Market}, "optimal_20"},
+		{&order.Submit{Type: order.Market, TimeInForce: order.IOC}, "optimal_20_ioc"},
+		{&order.Submit{Type: order.Market, TimeInForce: order.FOK}, "optimal_20_fok"},
+		{&order.Submit{Type: order.Limit}, "limit"},
+		{&order.Submit{Type: order.Limit, PostOnly: true}, "post_only"},
+		{&order.Submit{Type: order.Limit, TimeInForce: order.IOC}, "ioc"},
+		{&order.Submit{Type: order.Limit, TimeInForce: order.FOK}, "fok"},
+		{&order.Submit{Type: order.PostOnly}, "post_only"},
+	} {
+		assert.Equal(t, tc.expected, futuresOrderType(tc.s))
+	}
+}
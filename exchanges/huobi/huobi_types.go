@@ -796,6 +796,24 @@ var (
 
 	// SpotNewOrderRequestTypeSellLimit sell limit order
 	SpotNewOrderRequestTypeSellLimit = SpotNewOrderRequestParamsType("sell-limit")
+
+	// SpotNewOrderRequestTypeBuyIOC buy immediate or cancel order
+	SpotNewOrderRequestTypeBuyIOC = SpotNewOrderRequestParamsType("buy-ioc")
+
+	// SpotNewOrderRequestTypeSellIOC sell immediate or cancel order
+	SpotNewOrderRequestTypeSellIOC = SpotNewOrderRequestParamsType("sell-ioc")
+
+	// SpotNewOrderRequestTypeBuyLimitFOK buy fill or kill limit order
+	SpotNewOrderRequestTypeBuyLimitFOK = SpotNewOrderRequestParamsType("buy-limit-fok")
+
+	// SpotNewOrderRequestTypeSellLimitFOK sell fill or kill limit order
+	SpotNewOrderRequestTypeSellLimitFOK = SpotNewOrderRequestParamsType("sell-limit-fok")
+
+	// SpotNewOrderRequestTypeBuyLimitMaker buy post only limit order
+	SpotNewOrderRequestTypeBuyLimitMaker = SpotNewOrderRequestParamsType("buy-limit-maker")
+
+	// SpotNewOrderRequestTypeSellLimitMaker sell post only limit order
+	SpotNewOrderRequestTypeSellLimitMaker = SpotNewOrderRequestParamsType("sell-limit-maker")
 )
 
 //-----------
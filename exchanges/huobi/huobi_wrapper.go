@@ -993,7 +993,7 @@ func (h *HUOBI) GetHistoricTrades(_ context.Context, _ currency.Pair, _ asset.It
 
 // SubmitOrder submits a new order
 func (h *HUOBI) SubmitOrder(ctx context.Context, s *order.Submit) (*order.SubmitResponse, error) {
-	if err := s.Validate(); err != nil {
+	if err := s.Validate(s.TimeInForceSupported(order.GTC|order.IOC|order.FOK, true)); err != nil {
 		return nil, err
 	}
 
@@ -1005,26 +1005,16 @@ func (h *HUOBI) SubmitOrder(ctx context.Context, s *order.Submit) (*order.Submit
 		if err != nil {
 			return nil, err
 		}
-		var formattedType SpotNewOrderRequestParamsType
 		var params = SpotNewOrderRequestParams{
 			Amount:    s.Amount,
 			Source:    "api",
 			Symbol:    s.Pair,
 			AccountID: int(accountID),
+			Type:      spotOrderType(s),
 		}
-		switch {
-		case s.Side.IsLong() && s.Type == order.Market:
-			formattedType = SpotNewOrderRequestTypeBuyMarket
-		case s.Side.IsShort() && s.Type == order.Market:
-			formattedType = SpotNewOrderRequestTypeSellMarket
-		case s.Side.IsLong() && s.Type == order.Limit:
-			formattedType = SpotNewOrderRequestTypeBuyLimit
-			params.Price = s.Price
-		case s.Side.IsShort() && s.Type == order.Limit:
-			formattedType = SpotNewOrderRequestTypeSellLimit
+		if s.Type == order.Limit {
 			params.Price = s.Price
 		}
-		params.Type = formattedType
 		response, err := h.SpotNewOrder(ctx, &params)
 		if err != nil {
 			return nil, err
@@ -1042,13 +1032,7 @@ func (h *HUOBI) SubmitOrder(ctx context.Context, s *order.Submit) (*order.Submit
 		case s.Side.IsShort():
 			oDirection = "SELL"
 		}
-		var oType string
-		switch s.Type {
-		case order.Limit:
-			oType = "limit"
-		case order.PostOnly:
-			oType = "post_only"
-		}
+		oType := futuresOrderType(s)
 		offset := "open"
 		if s.ReduceOnly {
 			offset = "close"
@@ -1074,26 +1058,7 @@ func (h *HUOBI) SubmitOrder(ctx context.Context, s *order.Submit) (*order.Submit
 		case s.Side.IsShort():
 			oDirection = "SELL"
 		}
-		var oType string
-		switch s.Type {
-		case order.Market:
-			// https://huobiapi.github.io/docs/dm/v1/en/#order-and-trade
-			// At present, Huobi Futures does not support market price when placing an order.
-			// To increase the probability of a transaction, users can choose to place an order based on BBO price (opponent),
-			// optimal 5 (optimal_5), optimal 10 (optimal_10), optimal 20 (optimal_20), among which the success probability of
-			// optimal 20 is the largest, while the slippage always is the largest as well.
-			//
-			// It is important to note that the above methods will not guarantee the order to be filled in 100%.
-			// The system will obtain the optimal N price at that moment and place the order.
-			oType = "optimal_20"
-			if s.ImmediateOrCancel {
-				oType = "optimal_20_ioc"
-			}
-		case order.Limit:
-			oType = "limit"
-		case order.PostOnly:
-			oType = "post_only"
-		}
+		oType := futuresOrderType(s)
 		offset := "open"
 		if s.ReduceOnly {
 			offset = "close"
@@ -1122,6 +1087,70 @@ func (h *HUOBI) SubmitOrder(ctx context.Context, s *order.Submit) (*order.Submit
 	return resp, nil
 }
 
+// spotOrderType returns the spot order type for an order submission, combining
+// the order side, type and time in force
+func spotOrderType(s *order.Submit) SpotNewOrderRequestParamsType {
+	long := s.Side.IsLong()
+	switch {
+	case s.Type == order.Market && long:
+		return SpotNewOrderRequestTypeBuyMarket
+	case s.Type == order.Market:
+		return SpotNewOrderRequestTypeSellMarket
+	case s.PostOnly && long:
+		return SpotNewOrderRequestTypeBuyLimitMaker
+	case s.PostOnly:
+		return SpotNewOrderRequestTypeSellLimitMaker
+	case s.TimeInForce == order.IOC && long:
+		return SpotNewOrderRequestTypeBuyIOC
+	case s.TimeInForce == order.IOC:
+		return SpotNewOrderRequestTypeSellIOC
+	case s.TimeInForce == order.FOK && long:
+		return SpotNewOrderRequestTypeBuyLimitFOK
+	case s.TimeInForce == order.FOK:
+		return SpotNewOrderRequestTypeSellLimitFOK
+	case long:
+		return SpotNewOrderRequestTypeBuyLimit
+	default:
+		return SpotNewOrderRequestTypeSellLimit
+	}
+}
+
+// futuresOrderType returns the futures order price type for an order
+// submission, combining the order type and time in force
+func futuresOrderType(s *order.Submit) string {
+	switch s.Type {
+	case order.Market:
+		// https://huobiapi.github.io/docs/dm/v1/en/#order-and-trade
+		// At present, Huobi Futures does not support market price when placing an order.
+		// To increase the probability of a transaction, users can choose to place an order based on BBO price (opponent),
+		// optimal 5 (optimal_5), optimal 10 (optimal_10), optimal 20 (optimal_20), among which the success probability of
+		// optimal 20 is the largest, while the slippage always is the largest as well.
+		//
+		// It is important to note that the above methods will not guarantee the order to be filled in 100%.
+		// The system will obtain the optimal N price at that moment and place the order.
+		switch s.TimeInForce {
+		case order.IOC:
+			return "optimal_20_ioc"
+		case order.FOK:
+			return "optimal_20_fok"
+		}
+		return "optimal_20"
+	case order.Limit:
+		switch {
+		case s.PostOnly:
+			return "post_only"
+		case s.TimeInForce == order.IOC:
+			return "ioc"
+		case s.TimeInForce == order.FOK:
+			return "fok"
+		}
+		return "limit"
+	case order.PostOnly:
+		return "post_only"
+	}
+	return ""
+}
+
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (h *HUOBI) ModifyOrder(_ context.Context, _ *order.Modify) (*order.ModifyResponse, error) {
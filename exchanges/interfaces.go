@@ -9,19 +9,24 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/calendar"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/collateral"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/conversion"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/currencystate"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/deposit"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/earn"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/fundingrate"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/margin"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/options"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/subscription"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/transfer"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
 )
 
@@ -56,10 +61,21 @@ type IBotExchange interface {
 	GetAccountFundingHistory(ctx context.Context) ([]FundingHistory, error)
 	GetDepositAddress(ctx context.Context, cryptocurrency currency.Code, accountID, chain string) (*deposit.Address, error)
 	GetAvailableTransferChains(ctx context.Context, cryptocurrency currency.Code) ([]string, error)
+	GetTransferNetworks(ctx context.Context, cryptocurrency currency.Code) ([]transfer.Chain, error)
 	GetWithdrawalsHistory(ctx context.Context, code currency.Code, a asset.Item) ([]WithdrawalHistory, error)
 	WithdrawCryptocurrencyFunds(ctx context.Context, withdrawRequest *withdraw.Request) (*withdraw.ExchangeResponse, error)
 	WithdrawFiatFunds(ctx context.Context, withdrawRequest *withdraw.Request) (*withdraw.ExchangeResponse, error)
 	WithdrawFiatFundsToInternationalBank(ctx context.Context, withdrawRequest *withdraw.Request) (*withdraw.ExchangeResponse, error)
+	GetWithdrawalLocks(ctx context.Context, c currency.Code) ([]withdraw.Lock, error)
+	GetConversionQuote(ctx context.Context, req *conversion.QuoteRequest) (*conversion.Quote, error)
+	AcceptConversionQuote(ctx context.Context, q *conversion.Quote) (*conversion.Result, error)
+	GetDustBalances(ctx context.Context) ([]conversion.DustBalance, error)
+	ConvertDust(ctx context.Context, req *conversion.DustRequest) (*conversion.DustResult, error)
+	GetEarnProducts(ctx context.Context, c currency.Code) ([]earn.Product, error)
+	GetEarnPositions(ctx context.Context) ([]earn.Position, error)
+	SubscribeEarnProduct(ctx context.Context, req *earn.SubscribeRequest) (*earn.Result, error)
+	RedeemEarnProduct(ctx context.Context, req *earn.RedeemRequest) (*earn.Result, error)
+	GetEarnRewards(ctx context.Context, start, end time.Time) ([]earn.Reward, error)
 	SetHTTPClientUserAgent(ua string) error
 	GetHTTPClientUserAgent() (string, error)
 	SetClientProxyAddress(addr string) error
@@ -112,11 +128,19 @@ type IBotExchange interface {
 	MatchSymbolCheckEnabled(symbol string, a asset.Item, hasDelimiter bool) (pair currency.Pair, enabled bool, err error)
 	// IsPairEnabled checks if a pair is enabled for an enabled asset type
 	IsPairEnabled(pair currency.Pair, a asset.Item) (bool, error)
+	// GetPairState returns the lifecycle state of a currency pair
+	GetPairState(pair currency.Pair, a asset.Item) (currency.PairState, error)
+	// GetScheduledEvents returns upcoming listings, delistings and maintenance
+	// windows announced by the exchange
+	GetScheduledEvents(ctx context.Context) ([]calendar.Event, error)
 }
 
 // OrderManagement defines functionality for order management
 type OrderManagement interface {
 	SubmitOrder(ctx context.Context, s *order.Submit) (*order.SubmitResponse, error)
+	SubmitOrders(ctx context.Context, s []order.Submit) ([]*order.SubmitResponse, error)
+	SubmitComboOrder(ctx context.Context, c *options.ComboOrder) (*options.ComboResponse, error)
+	SubmitOCOOrder(ctx context.Context, o *order.OCOSubmit) (*order.OCOSubmitResponse, error)
 	ModifyOrder(ctx context.Context, action *order.Modify) (*order.ModifyResponse, error)
 	CancelOrder(ctx context.Context, o *order.Cancel) error
 	CancelBatchOrders(ctx context.Context, o []order.Cancel) (*order.CancelBatchResponse, error)
@@ -177,11 +201,14 @@ type FuturesManagement interface {
 	GetCollateralMode(ctx context.Context, item asset.Item) (collateral.Mode, error)
 	SetLeverage(ctx context.Context, item asset.Item, pair currency.Pair, marginType margin.Type, amount float64, orderSide order.Side) error
 	GetLeverage(ctx context.Context, item asset.Item, pair currency.Pair, marginType margin.Type, orderSide order.Side) (float64, error)
+	GetInsuranceFunds(ctx context.Context, item asset.Item) ([]futures.InsuranceFund, error)
+	GetADLIndicators(ctx context.Context, item asset.Item) ([]futures.ADLIndicator, error)
 }
 
 // MarginManagement manages margin positions and rates
 type MarginManagement interface {
 	SetMarginType(ctx context.Context, item asset.Item, pair currency.Pair, tp margin.Type) error
+	GetMarginType(ctx context.Context, item asset.Item, pair currency.Pair) (margin.Type, error)
 	ChangePositionMargin(ctx context.Context, change *margin.PositionChangeRequest) (*margin.PositionChangeResponse, error)
 	GetMarginRatesHistory(context.Context, *margin.RateHistoryRequest) (*margin.RateHistoryResponse, error)
 	futures.PNLCalculation
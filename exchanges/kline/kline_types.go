@@ -2,10 +2,13 @@ package kline
 
 import (
 	"errors"
+	"sync"
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/common/key"
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 )
 
@@ -210,3 +213,123 @@ type IntervalCapacity struct {
 	Interval Interval
 	Capacity int64
 }
+
+// PriceVolume is volume traded at a price, or spread evenly across a price
+// range when High exceeds Low, used to build volume profiles
+type PriceVolume struct {
+	Time   time.Time
+	Low    float64
+	High   float64
+	Volume float64
+}
+
+// VolumeProfile holds the volume and market profile of a session
+type VolumeProfile struct {
+	Start time.Time
+	End   time.Time
+	// TickSize is the price range of each level
+	TickSize float64
+	// Levels are ordered by ascending price
+	Levels      []ProfileLevel
+	TotalVolume float64
+	// PointOfControl is the price level with the most volume
+	PointOfControl float64
+	// ValueAreaHigh and ValueAreaLow bound the levels around the point of
+	// control containing the value area proportion of volume
+	ValueAreaHigh   float64
+	ValueAreaLow    float64
+	ValueAreaVolume float64
+	// TPOPointOfControl is the price level traded in the most periods
+	TPOPointOfControl float64
+}
+
+// ProfileLevel is the volume and number of time price opportunities (TPOs)
+// of a price level, where a TPO is a period in which the level traded
+type ProfileLevel struct {
+	Price  float64
+	Volume float64
+	TPOs   int64
+}
+
+// VolatilityPoint is the annualised volatility of the window of log returns
+// ending at Time
+type VolatilityPoint struct {
+	Time       time.Time
+	Volatility float64
+}
+
+// CorrelationMatrix holds the pairwise correlation of log returns between
+// candle sets over the candle times shared by every set
+type CorrelationMatrix struct {
+	Pairs []currency.Pair
+	// Matrix[i][j] is the correlation between Pairs[i] and Pairs[j]
+	Matrix [][]float64
+	// Observations is the number of aligned returns correlated
+	Observations int
+	Start        time.Time
+	End          time.Time
+}
+
+// PatternType is a candlestick pattern
+type PatternType uint8
+
+// Candlestick patterns which can be detected
+const (
+	UnknownPattern PatternType = iota
+	Doji
+	Hammer
+	HangingMan
+	BullishEngulfing
+	BearishEngulfing
+	MorningStar
+	EveningStar
+	ThreeWhiteSoldiers
+	ThreeBlackCrows
+)
+
+// PatternBias is the price direction a candlestick pattern signals
+type PatternBias int8
+
+// Pattern biases
+const (
+	Bearish PatternBias = iota - 1
+	Neutral
+	Bullish
+)
+
+// Pattern is a candlestick pattern detected in a series of candles
+type Pattern struct {
+	Exchange string
+	Pair     currency.Pair
+	Asset    asset.Item
+	Interval Interval
+	Type     PatternType
+	Bias     PatternBias
+	// Start and End are the open times of the first and last candles forming
+	// the pattern
+	Start time.Time
+	End   time.Time
+}
+
+// patternStream detects candlestick patterns in streamed candles and
+// publishes them over dispatch
+type patternStream struct {
+	mu        sync.Mutex
+	series    map[patternKey]*patternSeries
+	exchanges map[string]uuid.UUID
+	allID     uuid.UUID
+	mux       *dispatch.Mux
+}
+
+// patternKey identifies a streamed candle series
+type patternKey struct {
+	key.ExchangePairAsset
+	interval Interval
+}
+
+// patternSeries holds the most recent closed candles of a streamed series and
+// the candle in progress
+type patternSeries struct {
+	closed  []Candle
+	current Candle
+}
@@ -0,0 +1,315 @@
+package kline
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/common/key"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+// Candle proportions used to classify candlestick patterns
+const (
+	// dojiBodyRatio is the largest body of a doji, and the largest upper
+	// shadow of a hammer, as a fraction of the candle's range
+	dojiBodyRatio = 0.1
+	// longBodyRatio is the smallest body of a long candle as a fraction of
+	// its range
+	longBodyRatio = 0.5
+	// starBodyRatio is the largest body of the middle candle of a star as a
+	// fraction of the first candle's body
+	starBodyRatio = 0.3
+	// hammerShadowMultiple is the smallest lower shadow of a hammer as a
+	// multiple of its body
+	hammerShadowMultiple = 2
+	// maxPatternCandles is the most candles forming a detected pattern
+	maxPatternCandles = 3
+)
+
+var (
+	errExchangeNameEmpty = errors.New("exchange name is empty")
+	errCandleTimeUnset   = errors.New("candle time is unset")
+)
+
+var streamedPatterns = newPatternStream()
+
+func newPatternStream() *patternStream {
+	return &patternStream{
+		series:    make(map[patternKey]*patternSeries),
+		exchanges: make(map[string]uuid.UUID),
+		mux:       dispatch.GetNewMux(nil),
+	}
+}
+
+// DetectPatterns returns the candlestick patterns formed by the candles, in
+// the order their last candle closed. Multi-candle patterns rely on the
+// candles being sorted by time
+func (k *Item) DetectPatterns() ([]Pattern, error) {
+	if k == nil {
+		return nil, fmt.Errorf("detect patterns %w", errNilItem)
+	}
+	if len(k.Candles) == 0 {
+		return nil, fmt.Errorf("detect patterns %w", errNoData)
+	}
+	var patterns []Pattern
+	for i := range k.Candles {
+		for _, p := range detectPatterns(k.Candles[max(0, i+1-maxPatternCandles) : i+1]) {
+			p.Exchange, p.Pair, p.Asset, p.Interval = k.Exchange, k.Pair, k.Asset, k.Interval
+			patterns = append(patterns, p)
+		}
+	}
+	return patterns, nil
+}
+
+// ProcessStreamedCandle adds a streamed candle to its series and returns the
+// patterns completed by the candle which closed, publishing each to pattern
+// subscribers. A candle with the same open time as the candle in progress
+// updates it, and the candle in progress closes once a candle with a later
+// open time is streamed. Candles older than the candle in progress are ignored
+func ProcessStreamedCandle(exchange string, p currency.Pair, a asset.Item, i Interval, c *Candle) ([]Pattern, error) {
+	return streamedPatterns.process(exchange, p, a, i, c)
+}
+
+// SubscribeToPatterns subscribes to the candlestick patterns detected in
+// candles streamed from every exchange
+func SubscribeToPatterns() (dispatch.Pipe, error) {
+	return streamedPatterns.subscribe("")
+}
+
+// SubscribeToExchangePatterns subscribes to the candlestick patterns detected
+// in candles streamed from an exchange
+func SubscribeToExchangePatterns(exchange string) (dispatch.Pipe, error) {
+	if exchange == "" {
+		return dispatch.Pipe{}, errExchangeNameEmpty
+	}
+	return streamedPatterns.subscribe(exchange)
+}
+
+func (s *patternStream) process(exchange string, p currency.Pair, a asset.Item, i Interval, c *Candle) ([]Pattern, error) {
+	if exchange == "" {
+		return nil, errExchangeNameEmpty
+	}
+	if p.IsEmpty() {
+		return nil, fmt.Errorf("%s %w", exchange, currency.ErrCurrencyPairEmpty)
+	}
+	if c == nil || c.Time.IsZero() {
+		return nil, fmt.Errorf("%s %s %s %w", exchange, p, a, errCandleTimeUnset)
+	}
+	exch := strings.ToLower(exchange)
+	k := patternKey{
+		ExchangePairAsset: key.ExchangePairAsset{
+			Exchange: exch,
+			Base:     p.Base.Item,
+			Quote:    p.Quote.Item,
+			Asset:    a,
+		},
+		interval: i,
+	}
+	s.mu.Lock()
+	series, ok := s.series[k]
+	if !ok {
+		s.series[k] = &patternSeries{current: *c}
+		s.mu.Unlock()
+		return nil, nil
+	}
+	if !c.Time.After(series.current.Time) {
+		if c.Time.Equal(series.current.Time) {
+			series.current = *c
+		}
+		s.mu.Unlock()
+		return nil, nil
+	}
+	series.closed = append(series.closed, series.current)
+	if over := len(series.closed) - maxPatternCandles; over > 0 {
+		series.closed = append(series.closed[:0], series.closed[over:]...)
+	}
+	series.current = *c
+	patterns := detectPatterns(series.closed)
+	if len(patterns) == 0 {
+		s.mu.Unlock()
+		return nil, nil
+	}
+	ids, err := s.getIDs(exch)
+	s.mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	for x := range patterns {
+		patterns[x].Exchange, patterns[x].Pair, patterns[x].Asset, patterns[x].Interval = exchange, p, a, i
+		if err := s.mux.Publish(patterns[x], ids...); err != nil {
+			return nil, err
+		}
+	}
+	return patterns, nil
+}
+
+// getIDs returns the dispatch IDs an exchange's patterns are published to.
+// s.mu must be held
+func (s *patternStream) getIDs(exch string) ([]uuid.UUID, error) {
+	if s.allID.IsNil() {
+		id, err := s.mux.GetID()
+		if err != nil {
+			return nil, err
+		}
+		s.allID = id
+	}
+	exchID, ok := s.exchanges[exch]
+	if !ok {
+		var err error
+		exchID, err = s.mux.GetID()
+		if err != nil {
+			return nil, err
+		}
+		s.exchanges[exch] = exchID
+	}
+	return []uuid.UUID{s.allID, exchID}, nil
+}
+
+func (s *patternStream) subscribe(exchange string) (dispatch.Pipe, error) {
+	s.mu.Lock()
+	ids, err := s.getIDs(strings.ToLower(exchange))
+	s.mu.Unlock()
+	if err != nil {
+		return dispatch.Pipe{}, err
+	}
+	if exchange == "" {
+		return s.mux.Subscribe(ids[0])
+	}
+	return s.mux.Subscribe(ids[1])
+}
+
+// detectPatterns returns the patterns completed by the last of up to three
+// candles. Single candle patterns other than the doji depend on the direction
+// of the preceding candle
+func detectPatterns(c []Candle) []Pattern {
+	last := &c[len(c)-1]
+	var found []Pattern
+	add := func(t PatternType, b PatternBias, first *Candle) {
+		found = append(found, Pattern{Type: t, Bias: b, Start: first.Time, End: last.Time})
+	}
+	if last.isDoji() {
+		add(Doji, Neutral, last)
+	}
+	if len(c) < 2 {
+		return found
+	}
+	prev := &c[len(c)-2]
+	if last.isHammer() {
+		switch {
+		case prev.bearish():
+			add(Hammer, Bullish, last)
+		case prev.bullish():
+			add(HangingMan, Bearish, last)
+		}
+	}
+	if prev.bearish() && last.bullish() && last.Open <= prev.Close && last.Close >= prev.Open && last.body() > prev.body() {
+		add(BullishEngulfing, Bullish, prev)
+	}
+	if prev.bullish() && last.bearish() && last.Open >= prev.Close && last.Close <= prev.Open && last.body() > prev.body() {
+		add(BearishEngulfing, Bearish, prev)
+	}
+	if len(c) < 3 {
+		return found
+	}
+	first := &c[len(c)-3]
+	if first.isLong() && prev.body() <= starBodyRatio*first.body() {
+		mid := (first.Open + first.Close) / 2
+		if first.bearish() && max(prev.Open, prev.Close) <= first.Close && last.bullish() && last.Close > mid {
+			add(MorningStar, Bullish, first)
+		}
+		if first.bullish() && min(prev.Open, prev.Close) >= first.Close && last.bearish() && last.Close < mid {
+			add(EveningStar, Bearish, first)
+		}
+	}
+	if first.isLong() && prev.isLong() && last.isLong() {
+		if first.bullish() && prev.bullish() && last.bullish() &&
+			prev.Open > first.Open && prev.Open <= first.Close && prev.Close > first.Close &&
+			last.Open > prev.Open && last.Open <= prev.Close && last.Close > prev.Close {
+			add(ThreeWhiteSoldiers, Bullish, first)
+		}
+		if first.bearish() && prev.bearish() && last.bearish() &&
+			prev.Open < first.Open && prev.Open >= first.Close && prev.Close < first.Close &&
+			last.Open < prev.Open && last.Open >= prev.Close && last.Close < prev.Close {
+			add(ThreeBlackCrows, Bearish, first)
+		}
+	}
+	return found
+}
+
+func (c *Candle) body() float64 {
+	return max(c.Close-c.Open, c.Open-c.Close)
+}
+
+func (c *Candle) bullish() bool {
+	return c.Close > c.Open
+}
+
+func (c *Candle) bearish() bool {
+	return c.Close < c.Open
+}
+
+// isDoji returns whether the candle opened and closed at almost the same price
+func (c *Candle) isDoji() bool {
+	r := c.High - c.Low
+	return r > 0 && c.body() <= dojiBodyRatio*r
+}
+
+// isLong returns whether the candle's body makes up most of its range
+func (c *Candle) isLong() bool {
+	r := c.High - c.Low
+	return r > 0 && c.body() >= longBodyRatio*r
+}
+
+// isHammer returns whether the candle has a small body at the top of its range
+// with a long lower shadow
+func (c *Candle) isHammer() bool {
+	r := c.High - c.Low
+	if r <= 0 || c.isDoji() {
+		return false
+	}
+	lower := min(c.Open, c.Close) - c.Low
+	upper := c.High - max(c.Open, c.Close)
+	return lower >= hammerShadowMultiple*c.body() && upper <= dojiBodyRatio*r
+}
+
+// String implements the stringer interface
+func (p PatternType) String() string {
+	switch p {
+	case Doji:
+		return "doji"
+	case Hammer:
+		return "hammer"
+	case HangingMan:
+		return "hanging man"
+	case BullishEngulfing:
+		return "bullish engulfing"
+	case BearishEngulfing:
+		return "bearish engulfing"
+	case MorningStar:
+		return "morning star"
+	case EveningStar:
+		return "evening star"
+	case ThreeWhiteSoldiers:
+		return "three white soldiers"
+	case ThreeBlackCrows:
+		return "three black crows"
+	default:
+		return "unknown"
+	}
+}
+
+// String implements the stringer interface
+func (b PatternBias) String() string {
+	switch b {
+	case Bullish:
+		return "bullish"
+	case Bearish:
+		return "bearish"
+	default:
+		return "neutral"
+	}
+}
@@ -0,0 +1,174 @@
+package kline
+
+import (
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+func TestMain(m *testing.M) {
+	if err := dispatch.Start(1, dispatch.DefaultJobsLimit); err != nil {
+		log.Fatal(err)
+	}
+	os.Exit(m.Run())
+}
+
+var patternStart = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// patternCandles builds hourly candles from open, high, low and close prices
+func patternCandles(ohlc ...[4]float64) []Candle {
+	c := make([]Candle, len(ohlc))
+	for i := range ohlc {
+		c[i] = Candle{Time: patternStart.Add(time.Hour * time.Duration(i)), Open: ohlc[i][0], High: ohlc[i][1], Low: ohlc[i][2], Close: ohlc[i][3]}
+	}
+	return c
+}
+
+func patternTypes(patterns []Pattern) []PatternType {
+	types := make([]PatternType, len(patterns))
+	for i := range patterns {
+		types[i] = patterns[i].Type
+	}
+	return types
+}
+
+func TestDetectPatterns(t *testing.T) {
+	t.Parallel()
+	var k *Item
+	_, err := k.DetectPatterns()
+	assert.ErrorIs(t, err, errNilItem)
+	_, err = (&Item{}).DetectPatterns()
+	assert.ErrorIs(t, err, errNoData)
+
+	for _, tc := range []struct {
+		name    string
+		candles []Candle
+		want    []PatternType
+		bias    PatternBias
+	}{
+		{name: "doji", candles: patternCandles([4]float64{100, 105, 95, 100.5}), want: []PatternType{Doji}, bias: Neutral},
+		{name: "flat", candles: patternCandles([4]float64{100, 100, 100, 100})},
+		{name: "hammer", candles: patternCandles([4]float64{110, 111, 100, 101}, [4]float64{100, 102.1, 92, 102}), want: []PatternType{Hammer}, bias: Bullish},
+		{name: "hanging man", candles: patternCandles([4]float64{90, 101, 89, 100}, [4]float64{100, 102.1, 92, 102}), want: []PatternType{HangingMan}, bias: Bearish},
+		{name: "bullish engulfing", candles: patternCandles([4]float64{104, 105, 100, 101}, [4]float64{100, 107, 99, 106}), want: []PatternType{BullishEngulfing}, bias: Bullish},
+		{name: "bearish engulfing", candles: patternCandles([4]float64{101, 105, 100, 104}, [4]float64{105, 106, 98, 99}), want: []PatternType{BearishEngulfing}, bias: Bearish},
+		{name: "not engulfing", candles: patternCandles([4]float64{104, 105, 100, 101}, [4]float64{102, 106, 101, 103})},
+		{
+			name:    "morning star",
+			candles: patternCandles([4]float64{110, 111, 99, 100}, [4]float64{99, 100, 97, 98}, [4]float64{99, 108, 98, 107}),
+			want:    []PatternType{MorningStar},
+			bias:    Bullish,
+		},
+		{
+			name:    "evening star",
+			candles: patternCandles([4]float64{100, 111, 99, 110}, [4]float64{111, 113, 110, 112}, [4]float64{111, 112, 102, 103}),
+			want:    []PatternType{EveningStar},
+			bias:    Bearish,
+		},
+		{
+			name:    "three white soldiers",
+			candles: patternCandles([4]float64{100, 105, 99.5, 104.5}, [4]float64{103, 108, 102.5, 107.5}, [4]float64{106, 111, 105.5, 110.5}),
+			want:    []PatternType{ThreeWhiteSoldiers},
+			bias:    Bullish,
+		},
+		{
+			name:    "three black crows",
+			candles: patternCandles([4]float64{110, 110.5, 105, 105.5}, [4]float64{107, 107.5, 102, 102.5}, [4]float64{104, 104.5, 99, 99.5}),
+			want:    []PatternType{ThreeBlackCrows},
+			bias:    Bearish,
+		},
+	} {
+		k := &Item{Exchange: "test", Pair: currency.NewPair(currency.BTC, currency.USDT), Asset: asset.Spot, Interval: OneHour, Candles: tc.candles}
+		patterns, err := k.DetectPatterns()
+		require.NoError(t, err, tc.name)
+		if len(tc.want) == 0 {
+			assert.Empty(t, patterns, tc.name)
+			continue
+		}
+		require.Equal(t, tc.want, patternTypes(patterns), tc.name)
+		p := patterns[len(patterns)-1]
+		assert.Equal(t, tc.bias, p.Bias, tc.name)
+		assert.Equal(t, "test", p.Exchange, tc.name)
+		assert.Equal(t, OneHour, p.Interval, tc.name)
+		assert.Equal(t, tc.candles[len(tc.candles)-1].Time, p.End, tc.name)
+	}
+
+	k = &Item{Candles: patternCandles([4]float64{110, 111, 99, 100}, [4]float64{99, 100, 97, 98}, [4]float64{99, 108, 98, 107})}
+	patterns, err := k.DetectPatterns()
+	require.NoError(t, err)
+	require.Len(t, patterns, 1)
+	assert.Equal(t, patternStart, patterns[0].Start, "three candle patterns should start at their first candle")
+}
+
+func TestProcessStreamedCandle(t *testing.T) {
+	t.Parallel()
+	pair := currency.NewPair(currency.BTC, currency.USDT)
+	_, err := ProcessStreamedCandle("", pair, asset.Spot, OneHour, &Candle{})
+	assert.ErrorIs(t, err, errExchangeNameEmpty)
+	_, err = ProcessStreamedCandle("test", currency.EMPTYPAIR, asset.Spot, OneHour, &Candle{})
+	assert.ErrorIs(t, err, currency.ErrCurrencyPairEmpty)
+	_, err = ProcessStreamedCandle("test", pair, asset.Spot, OneHour, nil)
+	assert.ErrorIs(t, err, errCandleTimeUnset)
+	_, err = SubscribeToExchangePatterns("")
+	assert.ErrorIs(t, err, errExchangeNameEmpty)
+
+	s := newPatternStream()
+	all, err := s.subscribe("")
+	require.NoError(t, err)
+	exch, err := s.subscribe("TEST")
+	require.NoError(t, err)
+
+	candles := patternCandles([4]float64{104, 105, 100, 101}, [4]float64{100, 107, 99, 106})
+	patterns, err := s.process("Test", pair, asset.Spot, OneHour, &candles[0])
+	require.NoError(t, err)
+	assert.Empty(t, patterns)
+	inProgress := candles[1]
+	inProgress.Close = 100.5
+	patterns, err = s.process("Test", pair, asset.Spot, OneHour, &inProgress)
+	require.NoError(t, err)
+	assert.Empty(t, patterns, "patterns should not be detected while a candle is in progress")
+	patterns, err = s.process("Test", pair, asset.Spot, OneHour, &candles[1])
+	require.NoError(t, err)
+	assert.Empty(t, patterns)
+	patterns, err = s.process("Test", pair, asset.Spot, OneHour, &candles[0])
+	require.NoError(t, err)
+	assert.Empty(t, patterns, "stale candles should be ignored")
+
+	next := Candle{Time: patternStart.Add(time.Hour * 2), Open: 106, High: 107, Low: 105, Close: 106.5}
+	patterns, err = s.process("Test", pair, asset.Spot, OneHour, &next)
+	require.NoError(t, err)
+	require.Len(t, patterns, 1, "patterns should be detected once the candle closes")
+	assert.Equal(t, BullishEngulfing, patterns[0].Type)
+	assert.Equal(t, "Test", patterns[0].Exchange)
+	assert.Equal(t, candles[1].Close, 106.0, "the latest update of the closed candle should be used")
+	for _, p := range []dispatch.Pipe{all, exch} {
+		select {
+		case data := <-p.Channel():
+			published, ok := data.(Pattern)
+			require.True(t, ok, "published data must be a Pattern")
+			assert.Equal(t, BullishEngulfing, published.Type)
+		case <-time.After(time.Second * 5):
+			require.Fail(t, "patterns must be published to subscribers")
+		}
+	}
+
+	patterns, err = s.process("Test", pair, asset.Spot, FourHour, &candles[0])
+	require.NoError(t, err)
+	assert.Empty(t, patterns, "intervals should be tracked as separate series")
+}
+
+func TestPatternStrings(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "three white soldiers", ThreeWhiteSoldiers.String())
+	assert.Equal(t, "unknown", UnknownPattern.String())
+	assert.Equal(t, "bullish", Bullish.String())
+	assert.Equal(t, "bearish", Bearish.String())
+	assert.Equal(t, "neutral", Neutral.String())
+}
@@ -0,0 +1,180 @@
+package kline
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	gctmath "github.com/thrasher-corp/gocryptotrader/common/math"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+// periodsPerYear annualises volatility, as crypto markets trade every day
+const periodsPerYear = time.Hour * 24 * 365
+
+var (
+	errInvalidVolatilityWindow = errors.New("volatility window must be at least two returns")
+	errInsufficientReturns     = errors.New("insufficient returns")
+	errNonPositiveClose        = errors.New("close price must be greater than zero")
+	errNilItem                 = errors.New("kline item is nil")
+)
+
+// GetHistoricalVolatility returns the rolling annualised volatility of the log
+// returns between consecutive candle closes. Each point is the sample standard
+// deviation of the window of returns ending at its candle. A zero window
+// returns a single point over every return
+func (k *Item) GetHistoricalVolatility(window int) ([]VolatilityPoint, error) {
+	if window < 0 || window == 1 {
+		return nil, fmt.Errorf("get historical volatility %w: %d", errInvalidVolatilityWindow, window)
+	}
+	if k.Interval <= 0 {
+		return nil, fmt.Errorf("get historical volatility %w", ErrInvalidInterval)
+	}
+	if len(k.Candles) == 0 {
+		return nil, fmt.Errorf("get historical volatility %w", errNoData)
+	}
+	closes := make([]float64, len(k.Candles))
+	for i := range k.Candles {
+		closes[i] = k.Candles[i].Close
+	}
+	returns, err := logReturns(closes)
+	if err != nil {
+		return nil, fmt.Errorf("get historical volatility %w", err)
+	}
+	if window == 0 {
+		window = len(returns)
+	}
+	if len(returns) < window || len(returns) < 2 {
+		return nil, fmt.Errorf("get historical volatility %w: %d returns for window of %d", errInsufficientReturns, len(returns), window)
+	}
+
+	annualise := math.Sqrt(float64(periodsPerYear) / float64(k.Interval.Duration()))
+	points := make([]VolatilityPoint, 0, len(returns)-window+1)
+	for i := window; i <= len(returns); i++ {
+		stdDev, err := gctmath.SampleStandardDeviation(returns[i-window : i])
+		if err != nil {
+			return nil, fmt.Errorf("get historical volatility %w", err)
+		}
+		// returns[i-1] is the return into candle i
+		points = append(points, VolatilityPoint{
+			Time:       k.Candles[i].Time,
+			Volatility: stdDev * annualise,
+		})
+	}
+	return points, nil
+}
+
+// CalculateCorrelationMatrix returns the Pearson correlation of log returns
+// between each pair of candle sets. Returns are taken between the candle times
+// present in every set, so that gaps in one set do not misalign the others.
+// Correlation with a set whose price never changes is zero
+func CalculateCorrelationMatrix(items ...*Item) (*CorrelationMatrix, error) {
+	if len(items) == 0 {
+		return nil, fmt.Errorf("calculate correlation matrix %w", errNoData)
+	}
+	shared := make(map[int64]int)
+	closes := make([]map[int64]float64, len(items))
+	for i := range items {
+		if items[i] == nil {
+			return nil, fmt.Errorf("calculate correlation matrix %w", errNilItem)
+		}
+		closes[i] = make(map[int64]float64, len(items[i].Candles))
+		for j := range items[i].Candles {
+			t := items[i].Candles[j].Time.UnixNano()
+			if _, ok := closes[i][t]; ok {
+				continue
+			}
+			closes[i][t] = items[i].Candles[j].Close
+			shared[t]++
+		}
+	}
+	times := make([]int64, 0, len(shared))
+	for t, count := range shared {
+		if count == len(items) {
+			times = append(times, t)
+		}
+	}
+	if len(times) < 3 {
+		return nil, fmt.Errorf("calculate correlation matrix %w: %d shared candle times", errInsufficientReturns, len(times))
+	}
+	slices.Sort(times)
+
+	returns := make([][]float64, len(items))
+	for i := range items {
+		aligned := make([]float64, len(times))
+		for j, t := range times {
+			aligned[j] = closes[i][t]
+		}
+		var err error
+		returns[i], err = logReturns(aligned)
+		if err != nil {
+			return nil, fmt.Errorf("calculate correlation matrix %s %w", items[i].Pair, err)
+		}
+	}
+
+	m := &CorrelationMatrix{
+		Pairs:        make([]currency.Pair, len(items)),
+		Matrix:       make([][]float64, len(items)),
+		Observations: len(times) - 1,
+		Start:        time.Unix(0, times[0]).UTC(),
+		End:          time.Unix(0, times[len(times)-1]).UTC(),
+	}
+	for i := range items {
+		m.Pairs[i] = items[i].Pair
+		m.Matrix[i] = make([]float64, len(items))
+	}
+	for i := range items {
+		m.Matrix[i][i] = 1
+		for j := i + 1; j < len(items); j++ {
+			c := pearsonCorrelation(returns[i], returns[j])
+			m.Matrix[i][j], m.Matrix[j][i] = c, c
+		}
+	}
+	return m, nil
+}
+
+// logReturns returns the natural log of each value divided by the value
+// before it
+func logReturns(values []float64) ([]float64, error) {
+	if len(values) < 2 {
+		return nil, fmt.Errorf("%w: %d closes", errInsufficientReturns, len(values))
+	}
+	if values[0] <= 0 {
+		return nil, errNonPositiveClose
+	}
+	returns := make([]float64, len(values)-1)
+	for i := 1; i < len(values); i++ {
+		if values[i] <= 0 {
+			return nil, errNonPositiveClose
+		}
+		returns[i-1] = math.Log(values[i] / values[i-1])
+	}
+	return returns, nil
+}
+
+// pearsonCorrelation returns the correlation coefficient of two equal length
+// series, or zero when either does not vary
+func pearsonCorrelation(x, y []float64) float64 {
+	var meanX, meanY float64
+	for i := range x {
+		meanX += x[i]
+		meanY += y[i]
+	}
+	meanX /= float64(len(x))
+	meanY /= float64(len(y))
+	var cov, varX, varY float64
+	for i := range x {
+		dx, dy := x[i]-meanX, y[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return 0
+	}
+	// Bound floating point error so perfectly correlated series cannot
+	// exceed one
+	return math.Max(-1, math.Min(1, cov/math.Sqrt(varX*varY)))
+}
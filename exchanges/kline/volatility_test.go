@@ -0,0 +1,93 @@
+package kline
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+func testVolatilityItem(p currency.Pair, start time.Time, closes ...float64) *Item {
+	k := &Item{Pair: p, Interval: OneDay, Candles: make([]Candle, len(closes))}
+	for i := range closes {
+		k.Candles[i] = Candle{Time: start.AddDate(0, 0, i), Close: closes[i]}
+	}
+	return k
+}
+
+func TestGetHistoricalVolatility(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	k := testVolatilityItem(currency.NewBTCUSDT(), start, 100, 110, 99, 108.9, 98.01)
+
+	_, err := k.GetHistoricalVolatility(1)
+	assert.ErrorIs(t, err, errInvalidVolatilityWindow)
+	_, err = k.GetHistoricalVolatility(-1)
+	assert.ErrorIs(t, err, errInvalidVolatilityWindow)
+	_, err = (&Item{Interval: OneDay}).GetHistoricalVolatility(2)
+	assert.ErrorIs(t, err, errNoData)
+	_, err = (&Item{}).GetHistoricalVolatility(2)
+	assert.ErrorIs(t, err, ErrInvalidInterval)
+	_, err = k.GetHistoricalVolatility(5)
+	assert.ErrorIs(t, err, errInsufficientReturns)
+	_, err = testVolatilityItem(currency.NewBTCUSDT(), start, 100, 0, 100).GetHistoricalVolatility(2)
+	assert.ErrorIs(t, err, errNonPositiveClose)
+
+	points, err := k.GetHistoricalVolatility(2)
+	require.NoError(t, err)
+	require.Len(t, points, 3, "there must be a point for each full window of returns")
+	assert.Equal(t, start.AddDate(0, 0, 2), points[0].Time, "a point should be timestamped at the last candle of its window")
+	up, down := math.Log(1.1), math.Log(0.9)
+	expected := math.Abs(up-down) / math.Sqrt2 * math.Sqrt(365)
+	for i := range points {
+		assert.InDelta(t, expected, points[i].Volatility, 1e-9, "volatility should be annualised by the candle interval")
+	}
+
+	points, err = k.GetHistoricalVolatility(0)
+	require.NoError(t, err)
+	require.Len(t, points, 1, "a zero window must return a single point")
+	assert.Equal(t, start.AddDate(0, 0, 4), points[0].Time)
+}
+
+func TestCalculateCorrelationMatrix(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	btc := testVolatilityItem(currency.NewBTCUSDT(), start, 100, 110, 99, 108.9, 98.01)
+	eth := testVolatilityItem(currency.NewPair(currency.ETH, currency.USDT), start, 10, 11, 9.9, 10.89, 9.801)
+	inverse := testVolatilityItem(currency.NewPair(currency.LTC, currency.USDT), start, 100, 90, 99, 89.1, 98.01)
+	flat := testVolatilityItem(currency.NewPair(currency.USDC, currency.USDT), start, 1, 1, 1, 1, 1)
+
+	_, err := CalculateCorrelationMatrix()
+	assert.ErrorIs(t, err, errNoData)
+	_, err = CalculateCorrelationMatrix(btc, nil)
+	assert.ErrorIs(t, err, errNilItem)
+	_, err = CalculateCorrelationMatrix(btc, testVolatilityItem(currency.NewBTCUSDT(), start.AddDate(0, 0, 3), 1, 2))
+	assert.ErrorIs(t, err, errInsufficientReturns)
+
+	m, err := CalculateCorrelationMatrix(btc, eth, inverse, flat)
+	require.NoError(t, err)
+	assert.Equal(t, 4, m.Observations)
+	assert.Equal(t, start, m.Start)
+	assert.Equal(t, start.AddDate(0, 0, 4), m.End)
+	require.Len(t, m.Matrix, 4)
+	for i := range m.Matrix {
+		assert.Equal(t, 1.0, m.Matrix[i][i], "a set should be perfectly correlated with itself")
+		for j := range m.Matrix[i] {
+			assert.Equal(t, m.Matrix[i][j], m.Matrix[j][i], "the matrix should be symmetric")
+		}
+	}
+	assert.InDelta(t, 1, m.Matrix[0][1], 1e-9)
+	assert.InDelta(t, -1, m.Matrix[0][2], 1e-9)
+	assert.Zero(t, m.Matrix[0][3], "correlation with an unchanging price should be zero")
+
+	// Removing a candle from one set excludes its time from every set
+	gapped := testVolatilityItem(eth.Pair, start, 10, 11, 9.9, 10.89, 9.801)
+	gapped.Candles = append(gapped.Candles[:2], gapped.Candles[3:]...)
+	m, err = CalculateCorrelationMatrix(btc, gapped)
+	require.NoError(t, err)
+	assert.Equal(t, 3, m.Observations, "returns should only span shared candle times")
+	assert.InDelta(t, 1, m.Matrix[0][1], 1e-9, "aligned returns should remain perfectly correlated")
+}
@@ -0,0 +1,214 @@
+package kline
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+)
+
+// DefaultValueArea is the conventional proportion of a session's volume held
+// within its value area
+const DefaultValueArea = 0.7
+
+// maxProfileLevels limits the price levels of a profile so a small tick size
+// over a wide price range cannot exhaust memory
+const maxProfileLevels = 100000
+
+// levelTolerance absorbs floating point error when dividing prices into
+// levels, so a price on a level boundary is not placed in the level below
+const levelTolerance = 1e-9
+
+var (
+	errInvalidTickSize      = errors.New("tick size must be greater than zero")
+	errInvalidValueArea     = errors.New("value area must be greater than zero and not exceed one")
+	errInvalidProfilePeriod = errors.New("profile session and TPO period must not be negative")
+	errTooManyProfileLevels = errors.New("price range exceeds maximum profile levels, use a larger tick size")
+)
+
+// profileBuilder accumulates the levels of a session's profile
+type profileBuilder struct {
+	start, end time.Time
+	volumes    map[int64]float64
+	periods    map[int64]map[int64]struct{}
+}
+
+// GetVolumeProfiles returns the volume and market profile of each session of
+// candles. Each candle's volume is spread evenly across the levels between its
+// low and high, and each candle counts as a TPO period
+func (k *Item) GetVolumeProfiles(session time.Duration, tickSize, valueArea float64) ([]VolumeProfile, error) {
+	if len(k.Candles) == 0 {
+		return nil, fmt.Errorf("get volume profiles %w", errNoData)
+	}
+	data := make([]PriceVolume, len(k.Candles))
+	for i := range k.Candles {
+		data[i] = PriceVolume{
+			Time:   k.Candles[i].Time,
+			Low:    k.Candles[i].Low,
+			High:   k.Candles[i].High,
+			Volume: k.Candles[i].Volume,
+		}
+	}
+	return CalculateVolumeProfiles(data, session, k.Interval.Duration(), tickSize, valueArea)
+}
+
+// CalculateVolumeProfiles groups data into sessions aligned to multiples of
+// the session duration in UTC, returning the profile of each session in time
+// order. A zero session returns a single profile of all data. TPOs are counted
+// per tpoPeriod, or per data point when tpoPeriod is zero. valueArea is the
+// proportion of volume within the value area, where 0.7 is 70%
+func CalculateVolumeProfiles(data []PriceVolume, session, tpoPeriod time.Duration, tickSize, valueArea float64) ([]VolumeProfile, error) {
+	if tickSize <= 0 {
+		return nil, fmt.Errorf("calculate volume profiles %w: %v", errInvalidTickSize, tickSize)
+	}
+	if valueArea <= 0 || valueArea > 1 {
+		return nil, fmt.Errorf("calculate volume profiles %w: %v", errInvalidValueArea, valueArea)
+	}
+	if session < 0 || tpoPeriod < 0 {
+		return nil, fmt.Errorf("calculate volume profiles %w", errInvalidProfilePeriod)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("calculate volume profiles %w", errNoData)
+	}
+	sessions := make(map[int64]*profileBuilder)
+	for i := range data {
+		if data[i].Volume < 0 {
+			continue
+		}
+		var sessionStart int64
+		if session > 0 {
+			sessionStart = data[i].Time.UTC().Truncate(session).UnixNano()
+		}
+		b, ok := sessions[sessionStart]
+		if !ok {
+			b = &profileBuilder{
+				start:   data[i].Time,
+				end:     data[i].Time,
+				volumes: make(map[int64]float64),
+				periods: make(map[int64]map[int64]struct{}),
+			}
+			if session > 0 {
+				b.start = time.Unix(0, sessionStart).UTC()
+				b.end = b.start.Add(session)
+			}
+			sessions[sessionStart] = b
+		}
+		if session == 0 {
+			if data[i].Time.Before(b.start) {
+				b.start = data[i].Time
+			}
+			if data[i].Time.After(b.end) {
+				b.end = data[i].Time
+			}
+		}
+		period := int64(i)
+		if tpoPeriod > 0 {
+			period = data[i].Time.Truncate(tpoPeriod).UnixNano()
+		}
+		if err := b.add(&data[i], period, tickSize); err != nil {
+			return nil, fmt.Errorf("calculate volume profiles %w", err)
+		}
+	}
+	if len(sessions) == 0 {
+		return nil, fmt.Errorf("calculate volume profiles %w", errNoData)
+	}
+	starts := make([]int64, 0, len(sessions))
+	for start := range sessions {
+		starts = append(starts, start)
+	}
+	slices.Sort(starts)
+	profiles := make([]VolumeProfile, len(starts))
+	for i := range starts {
+		profiles[i] = sessions[starts[i]].profile(tickSize, valueArea)
+	}
+	return profiles, nil
+}
+
+// add spreads a data point's volume across its levels and records the TPO
+// period at each level
+func (b *profileBuilder) add(d *PriceVolume, period int64, tickSize float64) error {
+	low, high := d.Low, d.High
+	if high < low {
+		high = low
+	}
+	lowLevel, highLevel := priceLevel(low, tickSize), priceLevel(high, tickSize)
+	levels := highLevel - lowLevel + 1
+	if levels > maxProfileLevels || int64(len(b.volumes))+levels > maxProfileLevels*2 {
+		return fmt.Errorf("%w: %v to %v at tick size %v", errTooManyProfileLevels, low, high, tickSize)
+	}
+	volume := d.Volume / float64(levels)
+	for level := lowLevel; level <= highLevel; level++ {
+		b.volumes[level] += volume
+		periods, ok := b.periods[level]
+		if !ok {
+			periods = make(map[int64]struct{})
+			b.periods[level] = periods
+		}
+		periods[period] = struct{}{}
+	}
+	return nil
+}
+
+// profile returns the session's levels in ascending price order with its
+// points of control and value area
+func (b *profileBuilder) profile(tickSize, valueArea float64) VolumeProfile {
+	levels := make([]int64, 0, len(b.volumes))
+	for level := range b.volumes {
+		levels = append(levels, level)
+	}
+	slices.Sort(levels)
+	p := VolumeProfile{
+		Start:    b.start,
+		End:      b.end,
+		TickSize: tickSize,
+		Levels:   make([]ProfileLevel, len(levels)),
+	}
+	var poc, tpoPOC int
+	for i := range levels {
+		p.Levels[i] = ProfileLevel{
+			Price:  float64(levels[i]) * tickSize,
+			Volume: b.volumes[levels[i]],
+			TPOs:   int64(len(b.periods[levels[i]])),
+		}
+		p.TotalVolume += p.Levels[i].Volume
+		if p.Levels[i].Volume > p.Levels[poc].Volume {
+			poc = i
+		}
+		if p.Levels[i].TPOs > p.Levels[tpoPOC].TPOs {
+			tpoPOC = i
+		}
+	}
+	p.PointOfControl = p.Levels[poc].Price
+	p.TPOPointOfControl = p.Levels[tpoPOC].Price
+
+	// Expand from the point of control towards the adjacent level with the
+	// most volume until the value area holds the target volume
+	target := p.TotalVolume * valueArea
+	low, high := poc, poc
+	p.ValueAreaVolume = p.Levels[poc].Volume
+	for p.ValueAreaVolume < target && (low > 0 || high < len(p.Levels)-1) {
+		below, above := -1.0, -1.0
+		if low > 0 {
+			below = p.Levels[low-1].Volume
+		}
+		if high < len(p.Levels)-1 {
+			above = p.Levels[high+1].Volume
+		}
+		if above >= below {
+			high++
+			p.ValueAreaVolume += above
+		} else {
+			low--
+			p.ValueAreaVolume += below
+		}
+	}
+	p.ValueAreaLow = p.Levels[low].Price
+	p.ValueAreaHigh = p.Levels[high].Price
+	return p
+}
+
+// priceLevel returns the index of the level containing a price
+func priceLevel(price, tickSize float64) int64 {
+	return int64(math.Floor(price/tickSize + levelTolerance))
+}
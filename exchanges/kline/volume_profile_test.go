@@ -0,0 +1,99 @@
+package kline
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testProfileData(start time.Time) []PriceVolume {
+	return []PriceVolume{
+		{Time: start, Low: 100, High: 100, Volume: 10},
+		{Time: start.Add(time.Minute), Low: 101, High: 101, Volume: 5},
+		{Time: start.Add(time.Minute * 2), Low: 99, High: 99, Volume: 3},
+		{Time: start.Add(time.Minute * 3), Low: 100, High: 102, Volume: 3},
+	}
+}
+
+func TestCalculateVolumeProfiles(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	data := testProfileData(start)
+
+	_, err := CalculateVolumeProfiles(data, 0, 0, 0, 0.7)
+	assert.ErrorIs(t, err, errInvalidTickSize)
+	_, err = CalculateVolumeProfiles(data, 0, 0, 1, 0)
+	assert.ErrorIs(t, err, errInvalidValueArea)
+	_, err = CalculateVolumeProfiles(data, 0, 0, 1, 1.1)
+	assert.ErrorIs(t, err, errInvalidValueArea)
+	_, err = CalculateVolumeProfiles(data, -time.Hour, 0, 1, 0.7)
+	assert.ErrorIs(t, err, errInvalidProfilePeriod)
+	_, err = CalculateVolumeProfiles(nil, 0, 0, 1, 0.7)
+	assert.ErrorIs(t, err, errNoData)
+	_, err = CalculateVolumeProfiles([]PriceVolume{{Low: 1, High: 1e9, Volume: 1}}, 0, 0, 1, 0.7)
+	assert.ErrorIs(t, err, errTooManyProfileLevels)
+
+	profiles, err := CalculateVolumeProfiles(data, 0, 0, 1, 0.7)
+	require.NoError(t, err)
+	require.Len(t, profiles, 1, "a zero session must return a single profile")
+	p := profiles[0]
+	assert.Equal(t, start, p.Start, "start should be the earliest data point")
+	assert.Equal(t, start.Add(time.Minute*3), p.End, "end should be the latest data point")
+	require.Len(t, p.Levels, 4)
+	assert.Equal(t, []ProfileLevel{
+		{Price: 99, Volume: 3, TPOs: 1},
+		{Price: 100, Volume: 11, TPOs: 2},
+		{Price: 101, Volume: 6, TPOs: 2},
+		{Price: 102, Volume: 1, TPOs: 1},
+	}, p.Levels, "levels should be ascending with range volume spread evenly")
+	assert.Equal(t, 21.0, p.TotalVolume)
+	assert.Equal(t, 100.0, p.PointOfControl)
+	assert.Equal(t, 100.0, p.TPOPointOfControl)
+	assert.Equal(t, 100.0, p.ValueAreaLow)
+	assert.Equal(t, 101.0, p.ValueAreaHigh, "value area should expand towards the larger adjacent level")
+	assert.Equal(t, 17.0, p.ValueAreaVolume)
+
+	profiles, err = CalculateVolumeProfiles(data, 0, time.Hour, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, profiles, 1)
+	for _, l := range profiles[0].Levels {
+		assert.Equal(t, int64(1), l.TPOs, "data within one TPO period should count once")
+	}
+	assert.Equal(t, 99.0, profiles[0].ValueAreaLow, "a full value area should cover every level")
+	assert.Equal(t, 102.0, profiles[0].ValueAreaHigh, "a full value area should cover every level")
+
+	data = append(data, testProfileData(start.Add(time.Hour))...)
+	data = append(data, PriceVolume{Time: start, Low: 50, High: 50, Volume: -1})
+	profiles, err = CalculateVolumeProfiles(data, time.Hour, 0, 0.5, 0.7)
+	require.NoError(t, err)
+	require.Len(t, profiles, 2, "data should be grouped into hourly sessions")
+	assert.Equal(t, start, profiles[0].Start)
+	assert.Equal(t, start.Add(time.Hour), profiles[0].End)
+	assert.Equal(t, start.Add(time.Hour), profiles[1].Start)
+	assert.Equal(t, 99.0, profiles[0].Levels[0].Price, "negative volume should be skipped")
+	assert.Len(t, profiles[0].Levels, 6, "levels should be split by tick size")
+}
+
+func TestGetVolumeProfiles(t *testing.T) {
+	t.Parallel()
+	k := &Item{Interval: OneMin}
+	_, err := k.GetVolumeProfiles(0, 1, 0.7)
+	assert.ErrorIs(t, err, errNoData)
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	k.Candles = []Candle{
+		{Time: start, Low: 100, High: 101, Volume: 4},
+		{Time: start.Add(time.Minute), Low: 101, High: 101, Volume: 2},
+	}
+	profiles, err := k.GetVolumeProfiles(time.Hour*24, 1, 0.7)
+	require.NoError(t, err)
+	require.Len(t, profiles, 1)
+	assert.Equal(t, []ProfileLevel{
+		{Price: 100, Volume: 2, TPOs: 1},
+		{Price: 101, Volume: 4, TPOs: 2},
+	}, profiles[0].Levels)
+	assert.Equal(t, 101.0, profiles[0].PointOfControl)
+	assert.Equal(t, 101.0, profiles[0].TPOPointOfControl)
+}
@@ -1219,7 +1219,7 @@ func setupWsTests(t *testing.T) {
 // TestWebsocketSubscribe tests returning a message with an id
 func TestWebsocketSubscribe(t *testing.T) {
 	setupWsTests(t)
-	err := k.Subscribe([]subscription.Subscription{
+	err := k.Subscribe(context.Background(), []subscription.Subscription{
 		{
 			Channel: defaultSubscribedChannels[0],
 			Pair:    currency.NewPairWithDelimiter("XBT", "USD", "/"),
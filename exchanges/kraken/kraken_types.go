@@ -9,6 +9,16 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/types"
 )
 
+// pairStates maps asset pair statuses to pair lifecycle states. Pairs with
+// other statuses are not tradable
+var pairStates = map[string]currency.PairState{
+	"online":      currency.PairStateTradeable,
+	"limit_only":  currency.PairStateTradeable,
+	"post_only":   currency.PairStatePostOnly,
+	"reduce_only": currency.PairStateReduceOnly,
+	"cancel_only": currency.PairStateSuspended,
+}
+
 const (
 	krakenAPIVersion       = "0"
 	krakenServerTime       = "Time"
@@ -742,4 +752,6 @@ var (
 	RequestParamsTimeGTC = RequestParamsTimeForceType("GTC")
 	// RequestParamsTimeIOC IOC
 	RequestParamsTimeIOC = RequestParamsTimeForceType("IOC")
+	// RequestParamsTimeGTD GTD
+	RequestParamsTimeGTD = RequestParamsTimeForceType("GTD")
 )
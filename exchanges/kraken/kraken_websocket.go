@@ -81,7 +81,7 @@ var defaultSubscribedChannels = []string{
 var authenticatedChannels = []string{krakenWsOwnTrades, krakenWsOpenOrders}
 
 // WsConnect initiates a websocket connection
-func (k *Kraken) WsConnect() error {
+func (k *Kraken) WsConnect(ctx context.Context) error {
 	if !k.Websocket.IsEnabled() || !k.IsEnabled() {
 		return stream.ErrWebsocketNotEnabled
 	}
@@ -98,7 +98,7 @@ func (k *Kraken) WsConnect() error {
 	go k.wsFunnelConnectionData(k.Websocket.Conn, comms)
 
 	if k.IsWebsocketAuthenticationSupported() {
-		authToken, err = k.GetWebsocketToken(context.TODO())
+		authToken, err = k.GetWebsocketToken(ctx)
 		if err != nil {
 			k.Websocket.SetCanUseAuthenticatedEndpoints(false)
 			log.Errorf(log.ExchangeSys,
@@ -1187,7 +1187,7 @@ func (k *Kraken) GenerateDefaultSubscriptions() ([]subscription.Subscription, er
 }
 
 // Subscribe sends a websocket message to receive data from the channel
-func (k *Kraken) Subscribe(channelsToSubscribe []subscription.Subscription) error {
+func (k *Kraken) Subscribe(ctx context.Context, channelsToSubscribe []subscription.Subscription) error {
 	var subscriptions = make(map[string]*[]WebsocketSubscriptionEventRequest)
 channels:
 	for i := range channelsToSubscribe {
@@ -1229,7 +1229,7 @@ channels:
 	for _, subs := range subscriptions {
 		for i := range *subs {
 			if common.StringDataContains(authenticatedChannels, (*subs)[i].Subscription.Name) {
-				_, err := k.Websocket.AuthConn.SendMessageReturnResponse((*subs)[i].RequestID, (*subs)[i])
+				_, err := k.Websocket.AuthConn.SendMessageReturnResponseWithContext(ctx, (*subs)[i].RequestID, (*subs)[i])
 				if err != nil {
 					errs = common.AppendError(errs, err)
 					continue
@@ -1237,7 +1237,7 @@ channels:
 				k.Websocket.AddSuccessfulSubscriptions((*subs)[i].Channels...)
 				continue
 			}
-			_, err := k.Websocket.Conn.SendMessageReturnResponse((*subs)[i].RequestID, (*subs)[i])
+			_, err := k.Websocket.Conn.SendMessageReturnResponseWithContext(ctx, (*subs)[i].RequestID, (*subs)[i])
 			if err != nil {
 				errs = common.AppendError(errs, err)
 				continue
@@ -1249,7 +1249,7 @@ channels:
 }
 
 // Unsubscribe sends a websocket message to stop receiving data from the channel
-func (k *Kraken) Unsubscribe(channelsToUnsubscribe []subscription.Subscription) error {
+func (k *Kraken) Unsubscribe(ctx context.Context, channelsToUnsubscribe []subscription.Subscription) error {
 	var unsubs []WebsocketSubscriptionEventRequest
 channels:
 	for x := range channelsToUnsubscribe {
@@ -1293,7 +1293,7 @@ channels:
 	var errs error
 	for i := range unsubs {
 		if common.StringDataContains(authenticatedChannels, unsubs[i].Subscription.Name) {
-			_, err := k.Websocket.AuthConn.SendMessageReturnResponse(unsubs[i].RequestID, unsubs[i])
+			_, err := k.Websocket.AuthConn.SendMessageReturnResponseWithContext(ctx, unsubs[i].RequestID, unsubs[i])
 			if err != nil {
 				errs = common.AppendError(errs, err)
 				continue
@@ -1302,7 +1302,7 @@ channels:
 			continue
 		}
 
-		_, err := k.Websocket.Conn.SendMessageReturnResponse(unsubs[i].RequestID, unsubs[i])
+		_, err := k.Websocket.Conn.SendMessageReturnResponseWithContext(ctx, unsubs[i].RequestID, unsubs[i])
 		if err != nil {
 			errs = common.AppendError(errs, err)
 			continue
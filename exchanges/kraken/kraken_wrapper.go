@@ -288,7 +288,7 @@ func (k *Kraken) fetchSpotPairInfo(ctx context.Context) (map[currency.Pair]*Asse
 	}
 
 	for _, info := range pairInfo {
-		if info.Status != "online" {
+		if _, ok := pairStates[info.Status]; !ok {
 			continue
 		}
 		base := assetTranslator.LookupAltName(info.Base)
@@ -359,6 +359,12 @@ func (k *Kraken) FetchTradablePairs(ctx context.Context, a asset.Item) (currency
 func (k *Kraken) UpdateTradablePairs(ctx context.Context, forceUpdate bool) error {
 	assets := k.GetAssetTypes(false)
 	for x := range assets {
+		if assets[x] == asset.Spot {
+			if err := k.updateSpotPairs(ctx, forceUpdate); err != nil {
+				return err
+			}
+			continue
+		}
 		pairs, err := k.FetchTradablePairs(ctx, assets[x])
 		if err != nil {
 			return err
@@ -371,6 +377,32 @@ func (k *Kraken) UpdateTradablePairs(ctx context.Context, forceUpdate bool) erro
 	return k.EnsureOnePairEnabled()
 }
 
+// updateSpotPairs updates the available spot pairs and their lifecycle states
+func (k *Kraken) updateSpotPairs(ctx context.Context, forceUpdate bool) error {
+	if !assetTranslator.Seeded() {
+		if err := k.SeedAssets(ctx); err != nil {
+			return err
+		}
+	}
+	pairInfo, err := k.fetchSpotPairInfo(ctx)
+	if err != nil {
+		return err
+	}
+	pairs := make(currency.Pairs, 0, len(pairInfo))
+	for pair := range pairInfo {
+		pairs = append(pairs, pair)
+	}
+	if err = k.UpdatePairs(pairs, asset.Spot, false, forceUpdate); err != nil {
+		return err
+	}
+	for pair, info := range pairInfo {
+		if err = k.SetPairState(pair, asset.Spot, pairStates[info.Status]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // UpdateTickers updates the ticker for all currency pairs of a given asset type
 func (k *Kraken) UpdateTickers(ctx context.Context, a asset.Item) error {
 	switch a {
@@ -716,7 +748,11 @@ func (k *Kraken) GetHistoricTrades(_ context.Context, _ currency.Pair, _ asset.I
 
 // SubmitOrder submits a new order
 func (k *Kraken) SubmitOrder(ctx context.Context, s *order.Submit) (*order.SubmitResponse, error) {
-	err := s.Validate()
+	supportedTIF := order.GTC | order.GTD | order.IOC
+	if s != nil && s.AssetType == asset.Futures {
+		supportedTIF = order.GTC | order.IOC
+	}
+	err := s.Validate(s.TimeInForceSupported(supportedTIF, true))
 	if err != nil {
 		return nil, err
 	}
@@ -726,8 +762,16 @@ func (k *Kraken) SubmitOrder(ctx context.Context, s *order.Submit) (*order.Submi
 	switch s.AssetType {
 	case asset.Spot:
 		timeInForce := RequestParamsTimeGTC
-		if s.ImmediateOrCancel {
+		var expireTime, orderFlags string
+		switch s.TimeInForce {
+		case order.IOC:
 			timeInForce = RequestParamsTimeIOC
+		case order.GTD:
+			timeInForce = RequestParamsTimeGTD
+			expireTime = strconv.FormatInt(s.Expiry.Unix(), 10)
+		}
+		if s.PostOnly {
+			orderFlags = "post"
 		}
 		if k.Websocket.CanUseAuthenticatedWebsocketForWrapper() {
 			orderID, err = k.wsAddOrder(&WsAddOrderRequest{
@@ -736,6 +780,8 @@ func (k *Kraken) SubmitOrder(ctx context.Context, s *order.Submit) (*order.Submi
 				Pair:        s.Pair.Format(currency.PairFormat{Uppercase: true, Delimiter: "/"}).String(), // required pair format: ISO 4217-A3
 				Price:       s.Price,
 				Volume:      s.Amount,
+				OFlags:      orderFlags,
+				ExpireTime:  expireTime,
 				TimeInForce: timeInForce,
 			})
 			if err != nil {
@@ -752,6 +798,8 @@ func (k *Kraken) SubmitOrder(ctx context.Context, s *order.Submit) (*order.Submi
 				0,
 				0,
 				&AddOrderOptions{
+					OrderFlags:  orderFlags,
+					ExpireTm:    expireTime,
 					TimeInForce: timeInForce,
 				})
 			if err != nil {
@@ -765,15 +813,19 @@ func (k *Kraken) SubmitOrder(ctx context.Context, s *order.Submit) (*order.Submi
 			status = order.Filled
 		}
 	case asset.Futures:
+		oType := s.Type
+		if s.PostOnly {
+			oType = order.PostOnly
+		}
 		var fOrder FuturesSendOrderData
 		fOrder, err = k.FuturesSendOrder(ctx,
-			s.Type,
+			oType,
 			s.Pair,
 			s.Side.Lower(),
 			"",
 			s.ClientOrderID,
 			"",
-			s.ImmediateOrCancel,
+			s.TimeInForce == order.IOC,
 			s.Amount,
 			s.Price,
 			0,
@@ -40,6 +40,8 @@ const (
 	kucoinAPIURL        = "https://api.kucoin.com/api"
 	kucoinAPIKeyVersion = "2"
 
+	supportedSTPModes = order.STPCancelMaker | order.STPCancelTaker | order.STPCancelBoth | order.STPDecrement
+
 	// Public endpoints
 	kucoinGetSymbols             = "/v2/symbols"
 	kucoinGetTicker              = "/v1/market/orderbook/level1"
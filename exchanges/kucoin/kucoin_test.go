@@ -2390,7 +2390,7 @@ func setupWS() {
 	if !sharedtestvalues.AreAPICredentialsSet(ku) {
 		ku.Websocket.SetCanUseAuthenticatedEndpoints(false)
 	}
-	err := ku.WsConnect()
+	err := ku.WsConnect(context.Background())
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -2548,7 +2548,7 @@ func TestProcessMarketSnapshot(t *testing.T) {
 func TestSubscribeMarketSnapshot(t *testing.T) {
 	t.Parallel()
 	setupWS()
-	err := ku.Subscribe([]subscription.Subscription{{Channel: marketSymbolSnapshotChannel, Pair: currency.Pair{Base: currency.BTC}}})
+	err := ku.Subscribe(context.Background(), []subscription.Subscription{{Channel: marketSymbolSnapshotChannel, Pair: currency.Pair{Base: currency.BTC}}})
 	assert.NoError(t, err, "Subscribe to MarketSnapshot should not error")
 }
 
@@ -2774,3 +2774,34 @@ func TestGetOpenInterest(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotEmpty(t, resp)
 }
+
+func TestGetTimeInForce(t *testing.T) {
+	t.Parallel()
+	tif, cancelAfter := getTimeInForce(&order.Submit{Type: order.Market, TimeInForce: order.IOC})
+	assert.Empty(t, tif, "market orders should not set a time in force")
+	assert.Zero(t, cancelAfter)
+	tif, _ = getTimeInForce(&order.Submit{Type: order.Limit})
+	assert.Equal(t, "GTC", tif)
+	tif, _ = getTimeInForce(&order.Submit{Type: order.Limit, PostOnly: true})
+	assert.Empty(t, tif, "post only orders should use the exchange default")
+	tif, _ = getTimeInForce(&order.Submit{Type: order.Limit, TimeInForce: order.IOC})
+	assert.Equal(t, "IOC", tif)
+	tif, _ = getTimeInForce(&order.Submit{Type: order.Limit, TimeInForce: order.FOK})
+	assert.Equal(t, "FOK", tif)
+	tif, cancelAfter = getTimeInForce(&order.Submit{Type: order.Limit, TimeInForce: order.GTD, Expiry: time.Now().Add(time.Hour)})
+	assert.Equal(t, "GTT", tif)
+	assert.InDelta(t, 3600, cancelAfter, 2)
+}
+
+func TestSTPToString(t *testing.T) {
+	t.Parallel()
+	for stp, expected := range map[order.SelfTradePrevention]string{
+		order.UnsetSelfTradePrevention: "",
+		order.STPCancelMaker:           "CO",
+		order.STPCancelTaker:           "CN",
+		order.STPCancelBoth:            "CB",
+		order.STPDecrement:             "DC",
+	} {
+		assert.Equal(t, expected, stpToString(stp), stp.String())
+	}
+}
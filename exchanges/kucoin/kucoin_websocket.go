@@ -95,7 +95,7 @@ var (
 )
 
 // WsConnect creates a new websocket connection.
-func (ku *Kucoin) WsConnect() error {
+func (ku *Kucoin) WsConnect(ctx context.Context) error {
 	if !ku.Websocket.IsEnabled() || !ku.IsEnabled() {
 		return stream.ErrWebsocketNotEnabled
 	}
@@ -950,13 +950,13 @@ func (ku *Kucoin) processMarketSnapshot(respData []byte, topic string) error {
 }
 
 // Subscribe sends a websocket message to receive data from the channel
-func (ku *Kucoin) Subscribe(subscriptions []subscription.Subscription) error {
-	return ku.handleSubscriptions(subscriptions, "subscribe")
+func (ku *Kucoin) Subscribe(ctx context.Context, subscriptions []subscription.Subscription) error {
+	return ku.handleSubscriptions(ctx, subscriptions, "subscribe")
 }
 
 // Unsubscribe sends a websocket message to stop receiving data from the channel
-func (ku *Kucoin) Unsubscribe(subscriptions []subscription.Subscription) error {
-	return ku.handleSubscriptions(subscriptions, "unsubscribe")
+func (ku *Kucoin) Unsubscribe(ctx context.Context, subscriptions []subscription.Subscription) error {
+	return ku.handleSubscriptions(ctx, subscriptions, "unsubscribe")
 }
 
 func (ku *Kucoin) expandManualSubscriptions(in []subscription.Subscription) ([]subscription.Subscription, error) {
@@ -983,7 +983,7 @@ func (ku *Kucoin) expandManualSubscriptions(in []subscription.Subscription) ([]s
 	return subs, nil
 }
 
-func (ku *Kucoin) handleSubscriptions(subs []subscription.Subscription, operation string) error {
+func (ku *Kucoin) handleSubscriptions(ctx context.Context, subs []subscription.Subscription, operation string) error {
 	var errs error
 	subs, errs = ku.expandManualSubscriptions(subs)
 	for i := range subs {
@@ -995,7 +995,7 @@ func (ku *Kucoin) handleSubscriptions(subs []subscription.Subscription, operatio
 			PrivateChannel: subs[i].Authenticated,
 			Response:       true,
 		}
-		if respRaw, err := ku.Websocket.Conn.SendMessageReturnResponse("msgID:"+msgID, req); err != nil {
+		if respRaw, err := ku.Websocket.Conn.SendMessageReturnResponseWithContext(ctx, "msgID:"+msgID, req); err != nil {
 			errs = common.AppendError(errs, err)
 		} else {
 			rType, err := jsonparser.GetUnsafeString(respRaw, "type")
@@ -119,6 +119,10 @@ func (ku *Kucoin) SetDefaults() {
 			},
 			MaximumOrderHistory: kline.OneDay.Duration() * 7,
 			WithdrawPermissions: exchange.AutoWithdrawCrypto,
+			SelfTradePrevention: map[asset.Item]order.SelfTradePrevention{
+				asset.Spot:   supportedSTPModes,
+				asset.Margin: supportedSTPModes,
+			},
 		},
 		Enabled: exchange.FeaturesEnabled{
 			AutoPairUpdates: true,
@@ -673,7 +677,11 @@ func (ku *Kucoin) GetHistoricTrades(_ context.Context, _ currency.Pair, _ asset.
 
 // SubmitOrder submits a new order
 func (ku *Kucoin) SubmitOrder(ctx context.Context, s *order.Submit) (*order.SubmitResponse, error) {
-	err := s.Validate()
+	supportedTIF, supportedSTP := order.GTC|order.GTD|order.IOC|order.FOK, supportedSTPModes
+	if s != nil && s.AssetType == asset.Futures {
+		supportedTIF, supportedSTP = order.GTC|order.IOC, order.UnsetSelfTradePrevention
+	}
+	err := s.Validate(s.TimeInForceSupported(supportedTIF, true), s.SelfTradePreventionSupported(supportedSTP))
 	if err != nil {
 		return nil, err
 	}
@@ -690,11 +698,13 @@ func (ku *Kucoin) SubmitOrder(ctx context.Context, s *order.Submit) (*order.Subm
 	}
 	switch s.AssetType {
 	case asset.Futures:
+		timeInForce, _ := getTimeInForce(s)
 		o, err := ku.PostFuturesOrder(ctx, &FuturesOrderParam{
 			ClientOrderID: s.ClientOrderID,
 			Side:          sideString,
 			Symbol:        s.Pair,
 			OrderType:     s.Type.Lower(),
+			TimeInForce:   timeInForce,
 			Size:          s.Amount,
 			Price:         s.Price,
 			StopPrice:     s.TriggerPrice,
@@ -707,41 +717,35 @@ func (ku *Kucoin) SubmitOrder(ctx context.Context, s *order.Submit) (*order.Subm
 		}
 		return s.DeriveSubmitResponse(o)
 	case asset.Spot:
-		timeInForce := ""
-		if s.Type == order.Limit {
-			switch {
-			case s.FillOrKill:
-				timeInForce = "FOK"
-			case s.ImmediateOrCancel:
-				timeInForce = "IOC"
-			case s.PostOnly:
-			default:
-				timeInForce = "GTC"
-			}
-		}
+		timeInForce, cancelAfter := getTimeInForce(s)
 		o, err := ku.PostOrder(ctx, &SpotOrderParam{
-			ClientOrderID: s.ClientOrderID,
-			Side:          sideString,
-			Symbol:        s.Pair,
-			OrderType:     s.Type.Lower(),
-			Size:          s.Amount,
-			Price:         s.Price,
-			PostOnly:      s.PostOnly,
-			Hidden:        s.Hidden,
-			TimeInForce:   timeInForce,
+			ClientOrderID:       s.ClientOrderID,
+			Side:                sideString,
+			Symbol:              s.Pair,
+			OrderType:           s.Type.Lower(),
+			Size:                s.Amount,
+			Price:               s.Price,
+			PostOnly:            s.PostOnly,
+			Hidden:              s.Hidden,
+			TimeInForce:         timeInForce,
+			CancelAfter:         cancelAfter,
+			SelfTradePrevention: stpToString(s.SelfTradePrevention),
 		})
 		if err != nil {
 			return nil, err
 		}
 		return s.DeriveSubmitResponse(o)
 	case asset.Margin:
+		timeInForce, cancelAfter := getTimeInForce(s)
 		o, err := ku.PostMarginOrder(ctx,
 			&MarginOrderParam{ClientOrderID: s.ClientOrderID,
 				Side: sideString, Symbol: s.Pair,
 				OrderType: s.Type.Lower(), MarginMode: marginModeToString(s.MarginType),
 				Price: s.Price, Size: s.Amount,
 				VisibleSize: s.Amount, PostOnly: s.PostOnly,
-				Hidden: s.Hidden, AutoBorrow: s.AutoBorrow})
+				Hidden: s.Hidden, AutoBorrow: s.AutoBorrow,
+				TimeInForce: timeInForce, CancelAfter: cancelAfter,
+				SelfTradePrevention: stpToString(s.SelfTradePrevention)})
 		if err != nil {
 			return nil, err
 		}
@@ -757,6 +761,43 @@ func (ku *Kucoin) SubmitOrder(ctx context.Context, s *order.Submit) (*order.Subm
 	}
 }
 
+// getTimeInForce returns the time in force for a limit order and, for good
+// till date orders, the number of seconds until the order is cancelled
+func getTimeInForce(s *order.Submit) (timeInForce string, cancelAfter int64) {
+	if s.Type != order.Limit {
+		return "", 0
+	}
+	switch s.TimeInForce {
+	case order.FOK:
+		return "FOK", 0
+	case order.IOC:
+		return "IOC", 0
+	case order.GTD:
+		return "GTT", max(int64(time.Until(s.Expiry)/time.Second), 1)
+	}
+	if s.PostOnly {
+		return "", 0
+	}
+	return "GTC", 0
+}
+
+// stpToString returns the self trade prevention mode for an order
+// submission, leaving it empty when unset
+func stpToString(stp order.SelfTradePrevention) string {
+	switch stp {
+	case order.STPCancelMaker:
+		return "CO"
+	case order.STPCancelTaker:
+		return "CN"
+	case order.STPCancelBoth:
+		return "CB"
+	case order.STPDecrement:
+		return "DC"
+	default:
+		return ""
+	}
+}
+
 func marginModeToString(mType margin.Type) string {
 	switch mType {
 	case margin.Isolated:
@@ -0,0 +1,55 @@
+// Package latency simulates poor network connectivity by injecting artificial
+// latency and jitter into exchange REST requests and websocket messages
+package latency
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+)
+
+var errInvalidLatency = errors.New("latency and jitter must not be negative")
+
+// Simulator delays operations by a base latency varied by a random jitter
+type Simulator struct {
+	latency time.Duration
+	jitter  time.Duration
+}
+
+// NewSimulator returns a simulator which delays operations by latency plus or
+// minus a uniformly distributed random jitter
+func NewSimulator(latency, jitter time.Duration) (*Simulator, error) {
+	if latency < 0 || jitter < 0 {
+		return nil, errInvalidLatency
+	}
+	return &Simulator{latency: latency, jitter: jitter}, nil
+}
+
+// Delay returns the next simulated delay. Delays are never negative
+func (s *Simulator) Delay() time.Duration {
+	if s == nil {
+		return 0
+	}
+	d := s.latency
+	if s.jitter > 0 {
+		d += time.Duration(rand.Int63n(int64(s.jitter)*2+1)) - s.jitter //nolint:gosec // Jitter does not require a secure random source
+	}
+	return max(d, 0)
+}
+
+// Wait blocks for the next simulated delay or until the context is done
+func (s *Simulator) Wait(ctx context.Context) error {
+	d := s.Delay()
+	if d == 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
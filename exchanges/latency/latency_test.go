@@ -0,0 +1,64 @@
+package latency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSimulator(t *testing.T) {
+	t.Parallel()
+	_, err := NewSimulator(-time.Millisecond, 0)
+	assert.ErrorIs(t, err, errInvalidLatency)
+	_, err = NewSimulator(0, -time.Millisecond)
+	assert.ErrorIs(t, err, errInvalidLatency)
+	s, err := NewSimulator(time.Millisecond, time.Microsecond)
+	require.NoError(t, err)
+	assert.Equal(t, time.Millisecond, s.latency)
+	assert.Equal(t, time.Microsecond, s.jitter)
+}
+
+func TestDelay(t *testing.T) {
+	t.Parallel()
+	var s *Simulator
+	assert.Zero(t, s.Delay(), "nil simulator should not delay")
+
+	s, err := NewSimulator(time.Millisecond*100, 0)
+	require.NoError(t, err)
+	assert.Equal(t, time.Millisecond*100, s.Delay(), "delay without jitter should be fixed")
+
+	s, err = NewSimulator(time.Millisecond*100, time.Millisecond*20)
+	require.NoError(t, err)
+	for range 1000 {
+		d := s.Delay()
+		require.GreaterOrEqual(t, d, time.Millisecond*80)
+		require.LessOrEqual(t, d, time.Millisecond*120)
+	}
+
+	s, err = NewSimulator(time.Millisecond, time.Millisecond*50)
+	require.NoError(t, err)
+	for range 1000 {
+		require.GreaterOrEqual(t, s.Delay(), time.Duration(0), "delay must not be negative")
+	}
+}
+
+func TestWait(t *testing.T) {
+	t.Parallel()
+	var s *Simulator
+	assert.NoError(t, s.Wait(context.Background()), "nil simulator should not wait")
+
+	s, err := NewSimulator(time.Millisecond*10, 0)
+	require.NoError(t, err)
+	start := time.Now()
+	require.NoError(t, s.Wait(context.Background()))
+	assert.GreaterOrEqual(t, time.Since(start), time.Millisecond*10)
+
+	s, err = NewSimulator(time.Hour, 0)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, s.Wait(ctx), context.Canceled)
+}
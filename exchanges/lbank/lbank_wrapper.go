@@ -441,7 +441,7 @@ allTrades:
 
 // SubmitOrder submits a new order
 func (l *Lbank) SubmitOrder(ctx context.Context, s *order.Submit) (*order.SubmitResponse, error) {
-	if err := s.Validate(); err != nil {
+	if err := s.Validate(s.TimeInForceSupported(order.GTC, false)); err != nil {
 		return nil, err
 	}
 
@@ -181,6 +181,7 @@ func (o *Okcoin) Setup(exch *config.Exchange) error {
 		ResponseMaxLimit:     exch.WebsocketResponseMaxLimit,
 		URL:                  okcoinPrivateWebsocketURL,
 		RateLimit:            okcoinWsRateLimit,
+		RateLimiter:          o.Requester.GetLimiter(),
 		Authenticated:        true,
 	})
 }
@@ -577,7 +578,7 @@ func (o *Okcoin) SubmitOrder(ctx context.Context, s *order.Submit) (*order.Submi
 	if !o.SupportsAsset(s.AssetType) {
 		return nil, fmt.Errorf("%w, asset: %v", asset.ErrNotSupported, s.AssetType)
 	}
-	err := s.Validate()
+	err := s.Validate(s.TimeInForceSupported(order.GTC|order.IOC|order.FOK, true))
 	if err != nil {
 		return nil, err
 	}
@@ -592,7 +593,7 @@ func (o *Okcoin) SubmitOrder(ctx context.Context, s *order.Submit) (*order.Submi
 		ClientOrderID: s.ClientID,
 		InstrumentID:  s.Pair,
 		Side:          s.Side.Lower(),
-		OrderType:     s.Type.Lower(),
+		OrderType:     orderTypeFromSubmit(s),
 		Size:          s.Amount,
 		TradeMode:     s.TradeMode,
 		Price:         s.Price,
@@ -615,6 +616,22 @@ func (o *Okcoin) SubmitOrder(ctx context.Context, s *order.Submit) (*order.Submi
 	return s.DeriveSubmitResponse(orderResponse.OrderID)
 }
 
+// orderTypeFromSubmit returns the order type for an order submission, as limit
+// orders carry their time in force and post only flag in the order type
+func orderTypeFromSubmit(s *order.Submit) string {
+	if s.Type == order.Limit {
+		switch {
+		case s.PostOnly:
+			return "post_only"
+		case s.TimeInForce == order.IOC:
+			return "ioc"
+		case s.TimeInForce == order.FOK:
+			return "fok"
+		}
+	}
+	return s.Type.Lower()
+}
+
 // ModifyOrder will allow of changing orderbook placement and limit to
 // market conversion
 func (o *Okcoin) ModifyOrder(ctx context.Context, req *order.Modify) (*order.ModifyResponse, error) {
@@ -1,12 +1,14 @@
 package okcoin
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"reflect"
 	"strconv"
 
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
 )
 
@@ -17,7 +19,7 @@ func (o *Okcoin) WsPlaceOrder(arg *PlaceTradeOrderParam) (*TradeOrderResponse, e
 		return nil, err
 	}
 	var resp []TradeOrderResponse
-	err = o.SendWebsocketRequest("order", arg, &resp, true)
+	err = o.SendWebsocketRequest(placeTradeOrderEPL, "order", arg, &resp, true)
 	if err != nil {
 		return nil, err
 	}
@@ -43,7 +45,7 @@ func (o *Okcoin) WsPlaceMultipleOrder(args []PlaceTradeOrderParam) ([]TradeOrder
 		}
 	}
 	var resp []TradeOrderResponse
-	return resp, o.SendWebsocketRequest("batch-orders", args, &resp, true)
+	return resp, o.SendWebsocketRequest(placeTradeMultipleOrdersEPL, "batch-orders", args, &resp, true)
 }
 
 // WsCancelTradeOrder cancels a single trade order through the websocket stream.
@@ -58,7 +60,7 @@ func (o *Okcoin) WsCancelTradeOrder(arg *CancelTradeOrderRequest) (*TradeOrderRe
 		return nil, errOrderIDOrClientOrderIDRequired
 	}
 	var resp []TradeOrderResponse
-	err := o.SendWebsocketRequest("cancel-order", &arg, &resp, true)
+	err := o.SendWebsocketRequest(cancelTradeOrderEPL, "cancel-order", &arg, &resp, true)
 	if err != nil {
 		return nil, err
 	}
@@ -85,7 +87,7 @@ func (o *Okcoin) WsCancelMultipleOrders(args []CancelTradeOrderRequest) ([]Trade
 		}
 	}
 	var resp []TradeOrderResponse
-	return resp, o.SendWebsocketRequest("batch-cancel-orders", args, &resp, true)
+	return resp, o.SendWebsocketRequest(cancelMultipleOrderEPL, "batch-cancel-orders", args, &resp, true)
 }
 
 // WsAmendOrder amends an incomplete order through the websocket connection
@@ -95,7 +97,7 @@ func (o *Okcoin) WsAmendOrder(arg *AmendTradeOrderRequestParam) (*AmendTradeOrde
 		return nil, err
 	}
 	var resp []AmendTradeOrderResponse
-	err = o.SendWebsocketRequest("amend-order", &arg, &resp, true)
+	err = o.SendWebsocketRequest(amendTradeOrderEPL, "amend-order", &arg, &resp, true)
 	if err != nil {
 		if len(resp) > 0 && resp[0].StatusCode != "0" && resp[0].StatusCode != "" {
 			return nil, fmt.Errorf("%w, code: %s msg: %s", err, resp[0].StatusCode, resp[0].StatusMessage)
@@ -123,11 +125,11 @@ func (o *Okcoin) WsAmendMultipleOrder(args []AmendTradeOrderRequestParam) ([]Ame
 		}
 	}
 	var resp []AmendTradeOrderResponse
-	return resp, o.SendWebsocketRequest("batch-amend-orders", args, &resp, true)
+	return resp, o.SendWebsocketRequest(amendMultipleOrdersEPL, "batch-amend-orders", args, &resp, true)
 }
 
 // SendWebsocketRequest send a request through the websocket connection.
-func (o *Okcoin) SendWebsocketRequest(operation string, data, result interface{}, authenticated bool) error {
+func (o *Okcoin) SendWebsocketRequest(epl request.EndpointLimit, operation string, data, result interface{}, authenticated bool) error {
 	switch {
 	case !o.Websocket.IsEnabled():
 		return stream.ErrWebsocketNotEnabled
@@ -151,11 +153,11 @@ func (o *Okcoin) SendWebsocketRequest(operation string, data, result interface{}
 	}
 	var byteData []byte
 	var err error
-	// TODO: ratelimits for websocket
+	// Order rate limits are shared with the REST API
 	if authenticated {
-		byteData, err = o.Websocket.AuthConn.SendMessageReturnResponse(req.ID, req)
+		byteData, err = o.Websocket.AuthConn.SendRateLimitedMessageReturnResponse(context.Background(), epl, req.ID, req)
 	} else {
-		byteData, err = o.Websocket.Conn.SendMessageReturnResponse(req.ID, req)
+		byteData, err = o.Websocket.Conn.SendRateLimitedMessageReturnResponse(context.Background(), epl, req.ID, req)
 	}
 	if err != nil {
 		return err
@@ -12,6 +12,7 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
@@ -37,6 +38,11 @@ type Okx struct {
 	// ok.WsRequestSemaphore <- 1
 	// defer func() { <-ok.WsRequestSemaphore }()
 	WsRequestSemaphore chan int
+
+	// resyncing holds the orderbook channels and instruments being resynced,
+	// whose updates are dropped until a new snapshot is loaded
+	resyncMtx sync.Mutex
+	resyncing map[string]struct{}
 }
 
 const (
@@ -759,11 +765,13 @@ func (ok *Okx) PlaceAlgoOrder(ctx context.Context, arg *AlgoOrderParams) (*AlgoO
 	}
 	arg.TradeMode = strings.ToLower(arg.TradeMode)
 	if arg.TradeMode != TradeModeCross &&
-		arg.TradeMode != TradeModeIsolated {
+		arg.TradeMode != TradeModeIsolated &&
+		arg.TradeMode != TradeModeCash {
 		return nil, errInvalidTradeModeValue
 	}
-	if arg.Side != order.Buy &&
-		arg.Side != order.Sell {
+	arg.Side = strings.ToLower(arg.Side)
+	if arg.Side != order.Buy.Lower() &&
+		arg.Side != order.Sell.Lower() {
 		return nil, errInvalidOrderSide
 	}
 	if arg.OrderType == "" {
@@ -773,7 +781,7 @@ func (ok *Okx) PlaceAlgoOrder(ctx context.Context, arg *AlgoOrderParams) (*AlgoO
 		return nil, errMissingNewSize
 	}
 	var resp []AlgoOrder
-	err := ok.SendHTTPRequest(ctx, exchange.RestSpot, placeAlgoOrderEPL, http.MethodGet, algoTradeOrder, arg, &resp, true)
+	err := ok.SendHTTPRequest(ctx, exchange.RestSpot, placeAlgoOrderEPL, http.MethodPost, algoTradeOrder, arg, &resp, true)
 	if err != nil {
 		return nil, err
 	}
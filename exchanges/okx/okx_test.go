@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/common/key"
 	"github.com/thrasher-corp/gocryptotrader/config"
@@ -20,12 +21,15 @@ import (
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/collateral"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/conversion"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/earn"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/fundingrate"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/margin"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/rfq"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/sharedtestvalues"
 	testexch "github.com/thrasher-corp/gocryptotrader/internal/testing/exchange"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
@@ -640,7 +644,7 @@ func TestStopOrder(t *testing.T) {
 		TakeProfitTriggerPriceType: "index",
 		InstrumentID:               "BTC-USDT",
 		OrderType:                  "conditional",
-		Side:                       order.Sell,
+		Side:                       order.Sell.Lower(),
 		TradeMode:                  "isolated",
 		Size:                       12,
 
@@ -653,7 +657,7 @@ func TestStopOrder(t *testing.T) {
 		CallbackRatio: 0.01,
 		InstrumentID:  "BTC-USDT",
 		OrderType:     "move_order_stop",
-		Side:          order.Buy,
+		Side:          order.Buy.Lower(),
 		TradeMode:     "isolated",
 		Size:          2,
 		ActivePrice:   1234,
@@ -667,7 +671,7 @@ func TestStopOrder(t *testing.T) {
 
 		InstrumentID: "BTC-USDT",
 		OrderType:    "iceberg",
-		Side:         order.Buy,
+		Side:         order.Buy.Lower(),
 
 		TradeMode: "isolated",
 		Size:      6,
@@ -681,7 +685,7 @@ func TestStopOrder(t *testing.T) {
 		OrderType:    "twap",
 		PriceSpread:  "0.4",
 		TradeMode:    "cross",
-		Side:         order.Sell,
+		Side:         order.Sell.Lower(),
 		Size:         6,
 		TimeInterval: kline.ThreeDay,
 	}); err != nil {
@@ -693,7 +697,7 @@ func TestStopOrder(t *testing.T) {
 
 		InstrumentID: "BTC-USDT",
 		OrderType:    "trigger",
-		Side:         order.Buy,
+		Side:         order.Buy.Lower(),
 		TradeMode:    "cross",
 		Size:         5,
 	}); err != nil {
@@ -1322,6 +1326,26 @@ func TestGetPositions(t *testing.T) {
 	}
 }
 
+func TestGetADLIndicators(t *testing.T) {
+	t.Parallel()
+	_, err := ok.GetADLIndicators(contextGenerate(), asset.Spot)
+	assert.ErrorIs(t, err, asset.ErrNotSupported)
+
+	sharedtestvalues.SkipTestIfCredentialsUnset(t, ok)
+	_, err = ok.GetADLIndicators(contextGenerate(), asset.PerpetualSwap)
+	assert.NoError(t, err, "GetADLIndicators should not error")
+}
+
+func TestGetScheduledEvents(t *testing.T) {
+	t.Parallel()
+	events, err := ok.GetScheduledEvents(contextGenerate())
+	require.NoError(t, err, "GetScheduledEvents must not error")
+	for i := range events {
+		assert.NotZero(t, events[i].Type, "Type should be set")
+		assert.False(t, events[i].Start.IsZero(), "Start should be set")
+	}
+}
+
 func TestGetPositionsHistory(t *testing.T) {
 	t.Parallel()
 	sharedtestvalues.SkipTestIfCredentialsUnset(t, ok)
@@ -2093,6 +2117,25 @@ func TestSubmitOrder(t *testing.T) {
 	}
 }
 
+func TestSubmitOrders(t *testing.T) {
+	t.Parallel()
+	_, err := ok.SubmitOrders(contextGenerate(), nil)
+	assert.ErrorIs(t, err, order.ErrSubmissionIsNil)
+	_, err = ok.SubmitOrders(contextGenerate(), make([]order.Submit, 21))
+	assert.ErrorIs(t, err, errExceedLimit)
+	_, err = ok.SubmitOrders(contextGenerate(), []order.Submit{{Exchange: ok.Name, Pair: currency.NewPair(currency.LTC, currency.BTC), AssetType: asset.Spot, Side: order.Buy, Type: order.Limit, Price: 1}})
+	assert.ErrorIs(t, err, order.ErrAmountIsInvalid)
+
+	sharedtestvalues.SkipTestIfCredentialsUnset(t, ok, canManipulateRealOrders)
+	s := []order.Submit{
+		{Exchange: ok.Name, Pair: currency.NewPair(currency.LTC, currency.BTC), AssetType: asset.Spot, Side: order.Buy, Type: order.Limit, Price: 1, Amount: 1},
+		{Exchange: ok.Name, Pair: currency.NewPair(currency.LTC, currency.BTC), AssetType: asset.Spot, Side: order.Buy, Type: order.Limit, Price: 2, Amount: 1},
+	}
+	resp, err := ok.SubmitOrders(contextGenerate(), s)
+	require.NoError(t, err)
+	assert.Len(t, resp, len(s))
+}
+
 func TestCancelOrder(t *testing.T) {
 	t.Parallel()
 	sharedtestvalues.SkipTestIfCredentialsUnset(t, ok, canManipulateRealOrders)
@@ -2411,6 +2454,17 @@ func TestSnapshotAndUpdateOrderBookPushData(t *testing.T) {
 	}
 }
 
+func TestWsProcessOrderBooksDuringResync(t *testing.T) {
+	// Not parallel as the BTC-USDT books are shared with other tests
+	key := okxChannelOrderBooks + ":BTC-USDT"
+	require.True(t, ok.markResyncing(key), "markResyncing must mark an orderbook which is not resyncing")
+	assert.False(t, ok.markResyncing(key), "only one resync of an orderbook should run at a time")
+	require.NoError(t, ok.WsHandleData([]byte(updateOrderBookPushDataJSON)), "WsHandleData must not error when dropping updates")
+	assert.True(t, ok.isResyncing(key), "updates should be dropped until the snapshot is loaded")
+	require.NoError(t, ok.WsHandleData([]byte(testSnapshotOrderbookPushData)), "WsHandleData must not error")
+	assert.False(t, ok.isResyncing(key), "loading the snapshot should end the resync")
+}
+
 var snapshotOrderBookPushData = `{"arg":{"channel":"books","instId":"%v"},"action":"snapshot","data":[{"asks":[["0.07026","5","0","1"],["0.07027","765","0","3"],["0.07028","110","0","1"],["0.0703","1264","0","1"],["0.07034","280","0","1"],["0.07035","2255","0","1"],["0.07036","28","0","1"],["0.07037","63","0","1"],["0.07039","137","0","2"],["0.0704","48","0","1"],["0.07041","32","0","1"],["0.07043","3985","0","1"],["0.07057","257","0","1"],["0.07058","7870","0","1"],["0.07059","161","0","1"],["0.07061","4539","0","1"],["0.07068","1438","0","3"],["0.07088","3162","0","1"],["0.07104","99","0","1"],["0.07108","5018","0","1"],["0.07115","1540","0","1"],["0.07129","5080","0","1"],["0.07145","1512","0","1"],["0.0715","5016","0","1"],["0.07171","5026","0","1"],["0.07192","5062","0","1"],["0.07197","1517","0","1"],["0.0726","1511","0","1"],["0.07314","10376","0","1"],["0.07354","1","0","1"],["0.07466","10277","0","1"],["0.07626","269","0","1"],["0.07636","269","0","1"],["0.0809","1","0","1"],["0.08899","1","0","1"],["0.09789","1","0","1"],["0.10768","1","0","1"]],"bids":[["0.07014","56","0","2"],["0.07011","608","0","1"],["0.07009","110","0","1"],["0.07006","1264","0","1"],["0.07004","2347","0","3"],["0.07003","279","0","1"],["0.07001","52","0","1"],["0.06997","91","0","1"],["0.06996","4242","0","2"],["0.06995","486","0","1"],["0.06992","161","0","1"],["0.06991","63","0","1"],["0.06988","7518","0","1"],["0.06976","186","0","1"],["0.06975","71","0","1"],["0.06973","1086","0","1"],["0.06961","513","0","2"],["0.06959","4603","0","1"],["0.0695","186","0","1"],["0.06946","3043","0","1"],["0.06939","103","0","1"],["0.0693","5053","0","1"],["0.06909","5039","0","1"],["0.06888","5037","0","1"],["0.06886","1526","0","1"],["0.06867","5008","0","1"],["0.06846","5065","0","1"],["0.06826","1572","0","1"],["0.06801","1565","0","1"],["0.06748","67","0","1"],["0.0674","111","0","1"],["0.0672","10038","0","1"],["0.06652","1","0","1"],["0.06625","1526","0","1"],["0.06619","10924","0","1"],["0.05986","1","0","1"],["0.05387","1","0","1"],["0.04848","1","0","1"],["0.04363","1","0","1"]],"ts":"1659792392540","checksum":-1462286744}]}`
 
 func TestSnapshotPushData(t *testing.T) {
@@ -2633,13 +2687,28 @@ func TestAccountGreeksPushData(t *testing.T) {
 	}
 }
 
-const rfqsPushDataJSON = `{"arg": {"channel": "account-greeks","ccy": "BTC"},"data": [{"thetaBS": "","thetaPA":"","deltaBS":"","deltaPA":"","gammaBS":"","gammaPA":"","vegaBS":"",    "vegaPA":"","ccy":"BTC","ts":"1620282889345"}]}`
-
 func TestRfqs(t *testing.T) {
 	t.Parallel()
-	if err := ok.WsHandleData([]byte(rfqsPushDataJSON)); err != nil {
-		t.Error("Okx Rfqs Push Data error", err)
-	}
+	n := new(Okx)
+	sharedtestvalues.TestFixtureToDataHandler(t, ok, n, "testdata/wsRfqs.json", n.WsHandleData)
+	require.Len(t, n.Websocket.DataHandler, 1, "DataHandler must receive the requests")
+	requests, isRequests := (<-n.Websocket.DataHandler).([]rfq.Request)
+	require.True(t, isRequests, "DataHandler must receive rfq requests")
+	require.Len(t, requests, 1)
+	r := requests[0]
+	assert.Equal(t, "22534", r.ID, "ID")
+	assert.Equal(t, "rfq01", r.ClientID, "ClientID")
+	assert.Equal(t, "VITALIK", r.TraderCode, "TraderCode")
+	assert.Equal(t, "active", r.State, "State")
+	assert.Equal(t, time.UnixMilli(1611033857557), r.ValidUntil, "ValidUntil")
+	assert.Equal(t, time.UnixMilli(1611033737572), r.Created, "Created")
+	require.Len(t, r.Legs, 2)
+	assert.Equal(t, asset.Spot, r.Legs[0].Asset, "enabled pairs should be matched to their asset")
+	assert.Equal(t, order.Buy, r.Legs[0].Side)
+	assert.Equal(t, 25.0, r.Legs[0].Amount)
+	assert.Equal(t, asset.Empty, r.Legs[1].Asset, "unmatched instruments should have no asset")
+	assert.Equal(t, "BTCUSD-221208-100000-C", r.Legs[1].Instrument)
+	assert.Equal(t, order.Sell, r.Legs[1].Side)
 }
 
 const accountsPushDataJSON = `{	"arg": {	  "channel": "account",	  "ccy": "BTC",	  "uid": "77982378738415879"	},	"data": [	  {		"uTime": "1597026383085",		"totalEq": "41624.32",		"isoEq": "3624.32",		"adjEq": "41624.32",		"ordFroz": "0",		"imr": "4162.33",		"mmr": "4",		"notionalUsd": "",		"mgnRatio": "41624.32",		"details": [		  {			"availBal": "",			"availEq": "1",			"ccy": "BTC",			"cashBal": "1",			"uTime": "1617279471503",			"disEq": "50559.01",			"eq": "1",			"eqUsd": "45078.3790756226851775",			"frozenBal": "0",			"interest": "0",			"isoEq": "0",			"liab": "0",			"maxLoan": "",			"mgnRatio": "",			"notionalLever": "0.0022195262185864",			"ordFrozen": "0",			"upl": "0",			"uplLiab": "0",			"crossLiab": "0",			"isoLiab": "0",			"coinUsdPrice": "60000",			"stgyEq":"0",			"spotInUseAmt":"",			"isoUpl":""		  }		]	  }	]}`
@@ -2719,7 +2788,7 @@ func setupWS() {
 	if !sharedtestvalues.AreAPICredentialsSet(ok) {
 		ok.Websocket.SetCanUseAuthenticatedEndpoints(false)
 	}
-	err := ok.WsConnect()
+	err := ok.WsConnect(context.Background())
 	if err != nil {
 		log.Fatal(err)
 	}
@@ -3200,6 +3269,95 @@ func TestGetAvailableTransferChains(t *testing.T) {
 	}
 }
 
+func TestGetTransferNetworks(t *testing.T) {
+	t.Parallel()
+	sharedtestvalues.SkipTestIfCredentialsUnset(t, ok)
+	_, err := ok.GetTransferNetworks(contextGenerate(), currency.USDT)
+	assert.NoError(t, err, "GetTransferNetworks should not error")
+}
+
+func TestGetConversionQuote(t *testing.T) {
+	t.Parallel()
+	_, err := ok.GetConversionQuote(contextGenerate(), &conversion.QuoteRequest{From: currency.BTC, To: currency.BTC, Amount: 1})
+	assert.ErrorIs(t, err, conversion.ErrSameCurrency)
+
+	sharedtestvalues.SkipTestIfCredentialsUnset(t, ok, canManipulateRealOrders)
+	q, err := ok.GetConversionQuote(contextGenerate(), &conversion.QuoteRequest{From: currency.USDT, To: currency.BTC, Amount: 10})
+	require.NoError(t, err, "GetConversionQuote must not error")
+	assert.Equal(t, currency.BTC.String(), q.Pair.Base.String(), "Pair base should be BTC")
+}
+
+func TestAcceptConversionQuote(t *testing.T) {
+	t.Parallel()
+	_, err := ok.AcceptConversionQuote(contextGenerate(), &conversion.Quote{From: currency.BTC, To: currency.USDT, FromAmount: 1})
+	assert.ErrorIs(t, err, conversion.ErrQuoteIDUnset)
+}
+
+func TestGetDustBalances(t *testing.T) {
+	t.Parallel()
+	sharedtestvalues.SkipTestIfCredentialsUnset(t, ok)
+	_, err := ok.GetDustBalances(contextGenerate())
+	assert.NoError(t, err, "GetDustBalances should not error")
+}
+
+func TestConvertDust(t *testing.T) {
+	t.Parallel()
+	_, err := ok.ConvertDust(contextGenerate(), nil)
+	assert.ErrorIs(t, err, conversion.ErrNilRequest)
+
+	sharedtestvalues.SkipTestIfCredentialsUnset(t, ok, canManipulateRealOrders)
+	_, err = ok.ConvertDust(contextGenerate(), &conversion.DustRequest{Currencies: []currency.Code{currency.ADA}, To: currency.USDT})
+	assert.NoError(t, err, "ConvertDust should not error")
+}
+
+func TestGetEarnProducts(t *testing.T) {
+	t.Parallel()
+	products, err := ok.GetEarnProducts(contextGenerate(), currency.USDT)
+	require.NoError(t, err, "GetEarnProducts must not error")
+	for i := range products {
+		assert.Equal(t, earn.Flexible, products[i].Type, "Type should be flexible")
+	}
+}
+
+func TestGetEarnPositions(t *testing.T) {
+	t.Parallel()
+	sharedtestvalues.SkipTestIfCredentialsUnset(t, ok)
+	_, err := ok.GetEarnPositions(contextGenerate())
+	assert.NoError(t, err, "GetEarnPositions should not error")
+}
+
+func TestSubscribeEarnProduct(t *testing.T) {
+	t.Parallel()
+	_, err := ok.SubscribeEarnProduct(contextGenerate(), nil)
+	assert.ErrorIs(t, err, earn.ErrNilRequest)
+	_, err = ok.SubscribeEarnProduct(contextGenerate(), &earn.SubscribeRequest{ProductID: "USDT", Currency: currency.USDT, Type: earn.Locked, Amount: 1})
+	assert.ErrorIs(t, err, earn.ErrInvalidType, "SubscribeEarnProduct should not support locked products")
+
+	sharedtestvalues.SkipTestIfCredentialsUnset(t, ok, canManipulateRealOrders)
+	_, err = ok.SubscribeEarnProduct(contextGenerate(), &earn.SubscribeRequest{ProductID: "USDT", Currency: currency.USDT, Type: earn.Flexible, Amount: 1})
+	assert.NoError(t, err, "SubscribeEarnProduct should not error")
+}
+
+func TestRedeemEarnProduct(t *testing.T) {
+	t.Parallel()
+	_, err := ok.RedeemEarnProduct(contextGenerate(), &earn.RedeemRequest{ProductID: "USDT", Currency: currency.USDT, Type: earn.Locked, All: true})
+	assert.ErrorIs(t, err, earn.ErrInvalidType, "RedeemEarnProduct should not support locked products")
+
+	sharedtestvalues.SkipTestIfCredentialsUnset(t, ok, canManipulateRealOrders)
+	_, err = ok.RedeemEarnProduct(contextGenerate(), &earn.RedeemRequest{ProductID: "USDT", Currency: currency.USDT, Type: earn.Flexible, All: true})
+	assert.NoError(t, err, "RedeemEarnProduct should not error")
+}
+
+func TestGetEarnRewards(t *testing.T) {
+	t.Parallel()
+	_, err := ok.GetEarnRewards(contextGenerate(), time.Now(), time.Now().Add(-time.Hour))
+	assert.ErrorIs(t, err, common.ErrStartAfterEnd)
+
+	sharedtestvalues.SkipTestIfCredentialsUnset(t, ok)
+	_, err = ok.GetEarnRewards(contextGenerate(), time.Now().AddDate(0, 0, -7), time.Now())
+	assert.NoError(t, err, "GetEarnRewards should not error")
+}
+
 func TestGetIntervalEnum(t *testing.T) {
 	t.Parallel()
 
@@ -3766,3 +3924,48 @@ func TestGetOpenInterest(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotEmpty(t, resp)
 }
+
+func TestOrderTypeFromSubmit(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		s        *order.Submit
+		expected string
+	}{
+		{&order.Submit{Type: order.Limit}, OkxOrderLimit},
+		{&order.Submit{Type: order.Limit, PostOnly: true}, OkxOrderPostOnly},
+		{&order.Submit{Type: order.Limit, TimeInForce: order.IOC}, OkxOrderIOC},
+		{&order.Submit{Type: order.Limit, TimeInForce: order.FOK}, OkxOrderFOK},
+		{&order.Submit{Type: order.Market, TimeInForce: order.IOC}, OkxOrderMarket},
+	} {
+		assert.Equal(t, tc.expected, orderTypeFromSubmit(tc.s))
+	}
+}
+
+func TestSTPModeToString(t *testing.T) {
+	t.Parallel()
+	for stp, expected := range map[order.SelfTradePrevention]string{
+		order.UnsetSelfTradePrevention: "",
+		order.STPCancelMaker:           "cancel_maker",
+		order.STPCancelTaker:           "cancel_taker",
+		order.STPCancelBoth:            "cancel_both",
+		order.STPDecrement:             "",
+	} {
+		assert.Equal(t, expected, stpModeToString(stp), stp.String())
+	}
+}
+
+func TestIcebergSupported(t *testing.T) {
+	t.Parallel()
+	s := &order.Submit{AssetType: asset.Spot, Type: order.Limit, DisplayAmount: 0.1}
+	assert.True(t, icebergSupported(s))
+	s.TimeInForce = order.GTC
+	assert.True(t, icebergSupported(s), "good till cancelled orders should be supported")
+	s.TimeInForce = order.IOC
+	assert.False(t, icebergSupported(s), "immediate or cancel orders should not be supported")
+	s.TimeInForce, s.PostOnly = order.UnsetTimeInForce, true
+	assert.False(t, icebergSupported(s), "post only orders should not be supported")
+	s.PostOnly, s.SelfTradePrevention = false, order.STPCancelMaker
+	assert.False(t, icebergSupported(s), "self trade prevention should not be supported")
+	s.SelfTradePrevention, s.AssetType = order.UnsetSelfTradePrevention, asset.Options
+	assert.False(t, icebergSupported(s), "options should not be supported")
+}
@@ -34,6 +34,9 @@ const (
 	positionSideLong  = "long"
 	positionSideShort = "short"
 	positionSideNet   = "net"
+
+	// maxADLIndicator is the highest position adl indicator level
+	maxADLIndicator = 5
 )
 
 const (
@@ -598,7 +601,8 @@ type PlaceOrderRequestParam struct {
 	Amount        float64    `json:"sz,string,omitempty"`
 	Price         float64    `json:"px,string,omitempty"`
 	ReduceOnly    bool       `json:"reduceOnly,string,omitempty"`
-	QuantityType  string     `json:"tgtCcy,omitempty"` // values base_ccy and quote_ccy
+	QuantityType  string     `json:"tgtCcy,omitempty"`  // values base_ccy and quote_ccy
+	STPMode       string     `json:"stpMode,omitempty"` // values cancel_maker, cancel_taker and cancel_both
 	// Added in the websocket requests
 	BanAmend   bool             `json:"banAmend,omitempty"` // Whether the SPOT Market Order size can be amended by the system.
 	ExpiryTime okxUnixMilliTime `json:"expTime,omitempty"`
@@ -791,16 +795,16 @@ type TransactionDetail struct {
 
 // AlgoOrderParams holds algo order information.
 type AlgoOrderParams struct {
-	InstrumentID string     `json:"instId"` // Required
-	TradeMode    string     `json:"tdMode"` // Required
-	Currency     string     `json:"ccy,omitempty"`
-	Side         order.Side `json:"side"` // Required
-	PositionSide string     `json:"posSide,omitempty"`
-	OrderType    string     `json:"ordType"`   // Required
-	Size         float64    `json:"sz,string"` // Required
-	ReduceOnly   bool       `json:"reduceOnly,omitempty"`
-	OrderTag     string     `json:"tag,omitempty"`
-	QuantityType string     `json:"tgtCcy,omitempty"`
+	InstrumentID string  `json:"instId"` // Required
+	TradeMode    string  `json:"tdMode"` // Required
+	Currency     string  `json:"ccy,omitempty"`
+	Side         string  `json:"side"` // Required
+	PositionSide string  `json:"posSide,omitempty"`
+	OrderType    string  `json:"ordType"`   // Required
+	Size         float64 `json:"sz,string"` // Required
+	ReduceOnly   bool    `json:"reduceOnly,omitempty"`
+	OrderTag     string  `json:"tag,omitempty"`
+	QuantityType string  `json:"tgtCcy,omitempty"`
 
 	// Place Stop Order params
 	TakeProfitTriggerPrice     float64 `json:"tpTriggerPx,string,omitempty"`
@@ -906,6 +910,7 @@ type CurrencyResponse struct {
 	MaxWithdrawal       types.Number `json:"maxWd"`       // Minimum amount of currency withdrawal in a single transaction
 	MinFee              types.Number `json:"minFee"`      // Minimum withdrawal fee
 	MinWithdrawal       string       `json:"minWd"`       // Minimum amount of currency withdrawal in a single transaction
+	MinDeposit          types.Number `json:"minDep"`      // Minimum deposit amount of currency in a single transaction
 	Name                string       `json:"name"`        // Chinese name of currency
 	UsedWithdrawalQuota string       `json:"usedWdQuota"` // Amount of currency withdrawal used in the past 24 hours, unit in BTC
 	WithdrawalQuota     string       `json:"wdQuota"`     // Minimum amount of currency withdrawal in a single transaction
@@ -1166,18 +1171,18 @@ type EstimateQuoteRequestInput struct {
 // EstimateQuoteResponse represents estimate quote response data.
 type EstimateQuoteResponse struct {
 	BaseCurrency    string           `json:"baseCcy"`
-	BaseSize        string           `json:"baseSz"`
+	BaseSize        types.Number     `json:"baseSz"`
 	ClientRequestID string           `json:"clQReqId"`
-	ConvertPrice    string           `json:"cnvtPx"`
-	OrigRfqSize     string           `json:"origRfqSz"`
+	ConvertPrice    types.Number     `json:"cnvtPx"`
+	OrigRfqSize     types.Number     `json:"origRfqSz"`
 	QuoteCurrency   string           `json:"quoteCcy"`
 	QuoteID         string           `json:"quoteId"`
-	QuoteSize       string           `json:"quoteSz"`
+	QuoteSize       types.Number     `json:"quoteSz"`
 	QuoteTime       okxUnixMilliTime `json:"quoteTime"`
-	RfqSize         string           `json:"rfqSz"`
+	RfqSize         types.Number     `json:"rfqSz"`
 	RfqSizeCurrency string           `json:"rfqSzCcy"`
 	Side            order.Side       `json:"side"`
-	TTLMs           string           `json:"ttlMs"` // Validity period of quotation in milliseconds
+	TTLMs           types.Number     `json:"ttlMs"` // Validity period of quotation in milliseconds
 }
 
 // ConvertTradeInput represents convert trade request input
@@ -2634,13 +2639,13 @@ type WsRfq struct {
 
 // WsRfqData represents rfq order response data streamed through the websocket channel
 type WsRfqData struct {
-	CreationTime   time.Time     `json:"cTime"`
-	UpdateTime     time.Time     `json:"uTime"`
+	CreationTime   okxTime       `json:"cTime"`
+	UpdateTime     okxTime       `json:"uTime"`
 	TraderCode     string        `json:"traderCode"`
 	RfqID          string        `json:"rfqId"`
 	ClientRfqID    string        `json:"clRfqId"`
 	State          string        `json:"state"`
-	ValidUntil     string        `json:"validUntil"`
+	ValidUntil     okxTime       `json:"validUntil"`
 	Counterparties []string      `json:"counterparties"`
 	Legs           []RfqOrderLeg `json:"legs"`
 }
@@ -2887,10 +2892,12 @@ type WsOrderBook struct {
 
 // WsOrderBookData represents a book order push data.
 type WsOrderBookData struct {
-	Asks      [][4]string      `json:"asks"`
-	Bids      [][4]string      `json:"bids"`
-	Timestamp okxUnixMilliTime `json:"ts"`
-	Checksum  int32            `json:"checksum,omitempty"`
+	Asks           [][4]string      `json:"asks"`
+	Bids           [][4]string      `json:"bids"`
+	Timestamp      okxUnixMilliTime `json:"ts"`
+	Checksum       int32            `json:"checksum,omitempty"`
+	SequenceID     int64            `json:"seqId"`
+	PrevSequenceID int64            `json:"prevSeqId"`
 }
 
 // WsOptionSummary represents option summary
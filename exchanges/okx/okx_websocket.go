@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"hash/crc32"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -17,9 +18,12 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/common/crypto"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/fundingrate"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/rfq"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/stream/buffer"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/subscription"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
@@ -36,6 +40,7 @@ var (
 		okxChannelTrades,
 		okxChannelOrderBooks,
 		okxChannelTickers,
+		okxChannelFundingRate,
 	}
 	// defaultAuthChannels list of channels which are subscribed when authenticated
 	defaultAuthChannels = []string{
@@ -214,7 +219,7 @@ const (
 )
 
 // WsConnect initiates a websocket connection
-func (ok *Okx) WsConnect() error {
+func (ok *Okx) WsConnect(ctx context.Context) error {
 	if !ok.Websocket.IsEnabled() || !ok.IsEnabled() {
 		return stream.ErrWebsocketNotEnabled
 	}
@@ -241,7 +246,7 @@ func (ok *Okx) WsConnect() error {
 		var authDialer websocket.Dialer
 		authDialer.ReadBufferSize = 8192
 		authDialer.WriteBufferSize = 8192
-		err = ok.WsAuth(context.TODO(), &authDialer)
+		err = ok.WsAuth(ctx, &authDialer)
 		if err != nil {
 			log.Errorf(log.ExchangeSys, "Error connecting auth socket: %s\n", err.Error())
 			ok.Websocket.SetCanUseAuthenticatedEndpoints(false)
@@ -348,21 +353,25 @@ func (ok *Okx) wsReadData(ws stream.Connection) {
 }
 
 // Subscribe sends a websocket subscription request to several channels to receive data.
-func (ok *Okx) Subscribe(channelsToSubscribe []subscription.Subscription) error {
-	return ok.handleSubscription(operationSubscribe, channelsToSubscribe)
+func (ok *Okx) Subscribe(ctx context.Context, channelsToSubscribe []subscription.Subscription) error {
+	return ok.handleSubscription(ctx, operationSubscribe, channelsToSubscribe)
 }
 
 // Unsubscribe sends a websocket unsubscription request to several channels to receive data.
-func (ok *Okx) Unsubscribe(channelsToUnsubscribe []subscription.Subscription) error {
-	return ok.handleSubscription(operationUnsubscribe, channelsToUnsubscribe)
+func (ok *Okx) Unsubscribe(ctx context.Context, channelsToUnsubscribe []subscription.Subscription) error {
+	return ok.handleSubscription(ctx, operationUnsubscribe, channelsToUnsubscribe)
 }
 
 // handleSubscription sends a subscription and unsubscription information thought the websocket endpoint.
 // as of the okx, exchange this endpoint sends subscription and unsubscription messages but with a list of json objects.
-func (ok *Okx) handleSubscription(operation string, subscriptions []subscription.Subscription) error {
+func (ok *Okx) handleSubscription(ctx context.Context, operation string, subscriptions []subscription.Subscription) error {
 	request := WSSubscriptionInformationList{Operation: operation}
 	authRequests := WSSubscriptionInformationList{Operation: operation}
-	ok.WsRequestSemaphore <- 1
+	select {
+	case ok.WsRequestSemaphore <- 1:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 	defer func() { <-ok.WsRequestSemaphore }()
 	var channels []subscription.Subscription
 	var authChannels []subscription.Subscription
@@ -609,8 +618,7 @@ func (ok *Okx) WsHandleData(respRaw []byte) error {
 		var response WsAdvancedAlgoOrder
 		return ok.wsProcessPushData(respRaw, &response)
 	case okxChannelRfqs:
-		var response WsRfq
-		return ok.wsProcessPushData(respRaw, &response)
+		return ok.wsProcessRfqs(respRaw)
 	case okxChannelQuotes:
 		var response WsQuote
 		return ok.wsProcessPushData(respRaw, &response)
@@ -655,8 +663,7 @@ func (ok *Okx) WsHandleData(respRaw []byte) error {
 		var response WsOptionSummary
 		return ok.wsProcessPushData(respRaw, &response)
 	case okxChannelFundingRate:
-		var response WsFundingRate
-		return ok.wsProcessPushData(respRaw, &response)
+		return ok.wsProcessFundingRates(respRaw)
 	case okxChannelMarkPriceCandle1Y, okxChannelMarkPriceCandle6M, okxChannelMarkPriceCandle3M, okxChannelMarkPriceCandle1M,
 		okxChannelMarkPriceCandle1W, okxChannelMarkPriceCandle1D, okxChannelMarkPriceCandle2D, okxChannelMarkPriceCandle3D,
 		okxChannelMarkPriceCandle5D, okxChannelMarkPriceCandle12H, okxChannelMarkPriceCandle6H, okxChannelMarkPriceCandle4H,
@@ -823,9 +830,16 @@ func (ok *Okx) wsProcessOrderBooks(data []byte) error {
 		return errIncompleteCurrencyPair
 	}
 	pair.Delimiter = currency.DashDelimiter
+	resyncKey := response.Argument.Channel + ":" + response.Argument.InstrumentID
+	if response.Action != wsOrderbookSnapshot && ok.isResyncing(resyncKey) {
+		// The book is out of sync until the resubscribed snapshot is loaded
+		return nil
+	}
 	for i := range response.Data {
 		if response.Action == wsOrderbookSnapshot {
-			err = ok.WsProcessSnapshotOrderBook(response.Data[i], pair, assets)
+			if err = ok.WsProcessSnapshotOrderBook(response.Data[i], pair, assets); err == nil {
+				ok.resyncDone(resyncKey)
+			}
 		} else {
 			if len(response.Data[i].Asks) == 0 && len(response.Data[i].Bids) == 0 {
 				return nil
@@ -833,20 +847,18 @@ func (ok *Okx) wsProcessOrderBooks(data []byte) error {
 			err = ok.WsProcessUpdateOrderbook(response.Data[i], pair, assets)
 		}
 		if err != nil {
-			if errors.Is(err, errInvalidChecksum) {
-				err = ok.Subscribe([]subscription.Subscription{
-					{
-						Channel: response.Argument.Channel,
-						Asset:   assets[0],
-						Pair:    pair,
-					},
-				})
-				if err != nil {
-					ok.Websocket.DataHandler <- err
-				}
-			} else {
+			if !errors.Is(err, errInvalidChecksum) && !errors.Is(err, buffer.ErrUpdateIDDiscontinuity) {
 				return err
 			}
+			// A missed update or mismatched checksum leaves the book out of
+			// sync, so resubscribe to receive a fresh snapshot
+			ok.Websocket.DataHandler <- err
+			ok.resyncOrderbook(resyncKey, &subscription.Subscription{
+				Channel: response.Argument.Channel,
+				Asset:   assets[0],
+				Pair:    pair,
+			})
+			return nil
 		}
 	}
 	if ok.Verbose {
@@ -858,6 +870,54 @@ func (ok *Okx) wsProcessOrderBooks(data []byte) error {
 	return nil
 }
 
+// resyncOrderbook resubscribes to an out of sync orderbook channel without
+// blocking the websocket reader. Only one resync of a channel and instrument
+// runs at a time
+func (ok *Okx) resyncOrderbook(key string, sub *subscription.Subscription) {
+	if !ok.markResyncing(key) {
+		return
+	}
+	go func() {
+		ctx, cancel := ok.Websocket.ShutdownContext()
+		defer cancel()
+		if err := ok.Websocket.ResyncSubscription(ctx, sub); err != nil {
+			ok.Websocket.DataHandler <- err
+			ok.resyncDone(key)
+		}
+	}()
+}
+
+// markResyncing marks an orderbook as resyncing, returning false when it
+// already is
+func (ok *Okx) markResyncing(key string) bool {
+	ok.resyncMtx.Lock()
+	defer ok.resyncMtx.Unlock()
+	if _, inFlight := ok.resyncing[key]; inFlight {
+		return false
+	}
+	if ok.resyncing == nil {
+		ok.resyncing = make(map[string]struct{})
+	}
+	ok.resyncing[key] = struct{}{}
+	return true
+}
+
+// isResyncing returns whether an orderbook is waiting on a resync snapshot
+func (ok *Okx) isResyncing(key string) bool {
+	ok.resyncMtx.Lock()
+	defer ok.resyncMtx.Unlock()
+	_, inFlight := ok.resyncing[key]
+	return inFlight
+}
+
+// resyncDone ends an orderbook's resync once its snapshot is loaded or the
+// resync fails
+func (ok *Okx) resyncDone(key string) {
+	ok.resyncMtx.Lock()
+	delete(ok.resyncing, key)
+	ok.resyncMtx.Unlock()
+}
+
 // WsProcessSnapshotOrderBook processes snapshot order books
 func (ok *Okx) WsProcessSnapshotOrderBook(data WsOrderBookData, pair currency.Pair, assets []asset.Item) error {
 	signedChecksum, err := ok.CalculateOrderbookChecksum(data)
@@ -887,6 +947,7 @@ func (ok *Okx) WsProcessSnapshotOrderBook(data WsOrderBookData, pair currency.Pa
 			Asks:            asks,
 			Bids:            bids,
 			LastUpdated:     data.Timestamp.Time(),
+			LastUpdateID:    data.SequenceID,
 			Pair:            pair,
 			Exchange:        ok.Name,
 			VerifyOrderbook: ok.CanVerifyOrderbook,
@@ -904,8 +965,10 @@ func (ok *Okx) WsProcessSnapshotOrderBook(data WsOrderBookData, pair currency.Pa
 // orderbook
 func (ok *Okx) WsProcessUpdateOrderbook(data WsOrderBookData, pair currency.Pair, assets []asset.Item) error {
 	update := orderbook.Update{
-		Pair:       pair,
-		UpdateTime: data.Timestamp.Time(),
+		Pair:         pair,
+		UpdateTime:   data.Timestamp.Time(),
+		UpdateID:     data.SequenceID,
+		PrevUpdateID: data.PrevSequenceID,
 	}
 	var err error
 	update.Asks, err = ok.AppendWsOrderbookItems(data.Asks)
@@ -1083,6 +1146,32 @@ func (ok *Okx) wsProcessTrades(data []byte) error {
 	return trade.AddTradesToBuffer(ok.Name, trades...)
 }
 
+// wsProcessFundingRates converts perpetual swap funding rates to
+// fundingrate.Update and sends them to the data handler
+func (ok *Okx) wsProcessFundingRates(respRaw []byte) error {
+	var response WsFundingRate
+	if err := json.Unmarshal(respRaw, &response); err != nil {
+		return err
+	}
+	updates := make([]fundingrate.Update, len(response.Data))
+	for i := range response.Data {
+		pair, err := ok.GetPairFromInstrumentID(response.Data[i].InstrumentID)
+		if err != nil {
+			return err
+		}
+		updates[i] = fundingrate.Update{
+			Exchange:       ok.Name,
+			Asset:          asset.PerpetualSwap,
+			Pair:           pair,
+			Rate:           response.Data[i].FundingRate.Decimal(),
+			PredictedRate:  response.Data[i].NextFundingRate.Decimal(),
+			TimeOfNextRate: response.Data[i].FundingTime.Time(),
+		}
+	}
+	ok.Websocket.DataHandler <- updates
+	return nil
+}
+
 // wsProcessOrders handles websocket order push data responses.
 func (ok *Okx) wsProcessOrders(respRaw []byte) error {
 	var response WsOrderResponse
@@ -1315,7 +1404,23 @@ func (ok *Okx) GenerateDefaultSubscriptions() ([]subscription.Subscription, erro
 					Asset:   assets[x],
 				})
 			}
-		case okxChannelCandle5m, okxChannelTickers, okxChannelOrderBooks, okxChannelFundingRate, okxChannelOrderBooks5, okxChannelOrderBooks50TBT, okxChannelOrderBooksTBT, okxChannelTrades:
+		case okxChannelFundingRate:
+			// Funding rates are only published for perpetual swaps
+			if !slices.Contains(assets, asset.PerpetualSwap) {
+				continue
+			}
+			pairs, err := ok.GetEnabledPairs(asset.PerpetualSwap)
+			if err != nil {
+				return nil, err
+			}
+			for p := range pairs {
+				subscriptions = append(subscriptions, subscription.Subscription{
+					Channel: subs[c],
+					Asset:   asset.PerpetualSwap,
+					Pair:    pairs[p],
+				})
+			}
+		case okxChannelCandle5m, okxChannelTickers, okxChannelOrderBooks, okxChannelOrderBooks5, okxChannelOrderBooks50TBT, okxChannelOrderBooksTBT, okxChannelTrades:
 			for x := range assets {
 				pairs, err := ok.GetEnabledPairs(assets[x])
 				if err != nil {
@@ -1338,6 +1443,52 @@ func (ok *Okx) GenerateDefaultSubscriptions() ([]subscription.Subscription, erro
 	return subscriptions, nil
 }
 
+// wsProcessRfqs converts requests for quote to rfq.Request and sends them to
+// the data handler
+func (ok *Okx) wsProcessRfqs(respRaw []byte) error {
+	var response WsRfq
+	if err := json.Unmarshal(respRaw, &response); err != nil {
+		return err
+	}
+	requests := make([]rfq.Request, len(response.Data))
+	for x := range response.Data {
+		d := &response.Data[x]
+		r := rfq.Request{
+			Exchange:       ok.Name,
+			ID:             d.RfqID,
+			ClientID:       d.ClientRfqID,
+			TraderCode:     d.TraderCode,
+			State:          d.State,
+			Counterparties: d.Counterparties,
+			Created:        d.CreationTime.Time,
+			Updated:        d.UpdateTime.Time,
+			ValidUntil:     d.ValidUntil.Time,
+			Legs:           make([]rfq.Leg, len(d.Legs)),
+		}
+		for i := range d.Legs {
+			side, err := order.StringToOrderSide(d.Legs[i].Side)
+			if err != nil {
+				return err
+			}
+			amount, err := strconv.ParseFloat(d.Legs[i].Size, 64)
+			if err != nil {
+				return err
+			}
+			leg := rfq.Leg{Instrument: d.Legs[i].InstrumentID, Side: side, Amount: amount}
+			leg.Pair, leg.Asset, err = ok.GetPairAndAssetTypeRequestFormatted(d.Legs[i].InstrumentID)
+			if err != nil {
+				if leg.Pair, err = ok.GetPairFromInstrumentID(d.Legs[i].InstrumentID); err != nil {
+					return err
+				}
+			}
+			r.Legs[i] = leg
+		}
+		requests[x] = r
+	}
+	ok.Websocket.DataHandler <- requests
+	return nil
+}
+
 // wsProcessPushData processes push data coming through the websocket channel
 func (ok *Okx) wsProcessPushData(data []byte, resp interface{}) error {
 	if err := json.Unmarshal(data, resp); err != nil {
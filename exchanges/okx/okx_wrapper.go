@@ -18,8 +18,11 @@ import (
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/calendar"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/collateral"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/conversion"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/deposit"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/earn"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/fundingrate"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/futures"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
@@ -32,12 +35,15 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/stream/buffer"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/transfer"
 	"github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
 )
 
 const (
 	okxWebsocketResponseMaxLimit = time.Second * 3
+
+	supportedSTPModes = order.STPCancelMaker | order.STPCancelTaker | order.STPCancelBoth
 )
 
 // SetDefaults sets the basic defaults for Okx
@@ -109,6 +115,19 @@ func (ok *Okx) SetDefaults() {
 				ModifyOrder:            true,
 			},
 			WithdrawPermissions: exchange.AutoWithdrawCrypto,
+			SelfTradePrevention: map[asset.Item]order.SelfTradePrevention{
+				asset.Spot:          supportedSTPModes,
+				asset.Margin:        supportedSTPModes,
+				asset.Futures:       supportedSTPModes,
+				asset.PerpetualSwap: supportedSTPModes,
+				asset.Options:       supportedSTPModes,
+			},
+			IcebergOrders: map[asset.Item]bool{
+				asset.Spot:          true,
+				asset.Margin:        true,
+				asset.Futures:       true,
+				asset.PerpetualSwap: true,
+			},
 			FuturesCapabilities: exchange.FuturesCapabilities{
 				Positions:      true,
 				Leverage:       true,
@@ -210,7 +229,8 @@ func (ok *Okx) Setup(exch *config.Exchange) error {
 		Features:                               &ok.Features.Supports.WebsocketCapabilities,
 		MaxWebsocketSubscriptionsPerConnection: 240,
 		OrderbookBufferConfig: buffer.Config{
-			Checksum: ok.CalculateUpdateOrderbookChecksum,
+			Checksum:                   ok.CalculateUpdateOrderbookChecksum,
+			ValidateUpdateIDContinuity: true,
 		},
 	}); err != nil {
 		return err
@@ -606,6 +626,7 @@ func (ok *Okx) GetWithdrawalsHistory(ctx context.Context, c currency.Code, _ ass
 			CryptoTxID:      withdrawals[x].TransactionID,
 			CryptoChain:     withdrawals[x].ChainName,
 			TransferID:      withdrawals[x].WithdrawalID,
+			ClientID:        withdrawals[x].ClientID,
 			Fee:             withdrawals[x].WithdrawalFee.Float64(),
 		})
 	}
@@ -708,7 +729,74 @@ allTrades:
 
 // SubmitOrder submits a new order
 func (ok *Okx) SubmitOrder(ctx context.Context, s *order.Submit) (*order.SubmitResponse, error) {
-	if err := s.Validate(); err != nil {
+	orderRequest, err := ok.placeOrderRequest(s)
+	if err != nil {
+		return nil, err
+	}
+	if s.DisplayAmount > 0 {
+		return ok.submitIcebergOrder(ctx, s, orderRequest)
+	}
+	var placeOrderResponse *OrderData
+	if ok.Websocket.CanUseAuthenticatedWebsocketForWrapper() {
+		placeOrderResponse, err = ok.WsPlaceOrder(orderRequest)
+	} else {
+		placeOrderResponse, err = ok.PlaceOrder(ctx, orderRequest, s.AssetType)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.DeriveSubmitResponse(placeOrderResponse.OrderID)
+}
+
+// SubmitOrders submits up to 20 orders in a single batch request
+func (ok *Okx) SubmitOrders(ctx context.Context, s []order.Submit) ([]*order.SubmitResponse, error) {
+	if len(s) > 20 {
+		return nil, fmt.Errorf("%w, cannot submit more than 20 orders", errExceedLimit)
+	} else if len(s) == 0 {
+		return nil, fmt.Errorf("%w, must have at least 1 order", order.ErrSubmissionIsNil)
+	}
+	orderRequests := make([]PlaceOrderRequestParam, len(s))
+	for i := range s {
+		orderRequest, err := ok.placeOrderRequest(&s[i])
+		if err != nil {
+			return nil, err
+		}
+		if s[i].DisplayAmount > 0 {
+			return nil, fmt.Errorf("%w for batch iceberg orders", common.ErrFunctionNotSupported)
+		}
+		orderRequests[i] = *orderRequest
+	}
+	var placed []OrderData
+	var err error
+	if ok.Websocket.CanUseAuthenticatedWebsocketForWrapper() {
+		placed, err = ok.WsPlaceMultipleOrder(orderRequests)
+	} else {
+		placed, err = ok.PlaceMultipleOrders(ctx, orderRequests)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(placed) != len(s) {
+		return nil, fmt.Errorf("%w, expected %d order responses, received %d", errNoValidResponseFromServer, len(s), len(placed))
+	}
+	resp := make([]*order.SubmitResponse, len(s))
+	var errs error
+	for i := range placed {
+		if placed[i].SCode != "0" {
+			errs = common.AppendError(errs, fmt.Errorf("order %d error code: %s message: %s", i, placed[i].SCode, placed[i].SMessage))
+			continue
+		}
+		if resp[i], err = s[i].DeriveSubmitResponse(placed[i].OrderID); err != nil {
+			errs = common.AppendError(errs, fmt.Errorf("order %d: %w", i, err))
+		}
+	}
+	return resp, errs
+}
+
+// placeOrderRequest validates an order submission and converts it to an
+// order request
+func (ok *Okx) placeOrderRequest(s *order.Submit) (*PlaceOrderRequestParam, error) {
+	if err := s.Validate(s.TimeInForceSupported(order.GTC|order.IOC|order.FOK, true), s.SelfTradePreventionSupported(supportedSTPModes), s.DisplayAmountSupported(icebergSupported(s))); err != nil {
 		return nil, err
 	}
 	if !ok.SupportsAsset(s.AssetType) {
@@ -750,17 +838,17 @@ func (ok *Okx) SubmitOrder(ctx context.Context, s *order.Submit) (*order.SubmitR
 		InstrumentID:  instrumentID,
 		TradeMode:     tradeMode,
 		Side:          sideType,
-		OrderType:     s.Type.Lower(),
+		OrderType:     orderTypeFromSubmit(s),
 		Amount:        amount,
 		ClientOrderID: s.ClientOrderID,
 		Price:         s.Price,
 		QuantityType:  targetCurrency,
+		STPMode:       stpModeToString(s.SelfTradePrevention),
 	}
-	switch s.Type.Lower() {
+	switch orderRequest.OrderType {
 	case OkxOrderLimit, OkxOrderPostOnly, OkxOrderFOK, OkxOrderIOC:
 		orderRequest.Price = s.Price
 	}
-	var placeOrderResponse *OrderData
 	if s.AssetType == asset.PerpetualSwap || s.AssetType == asset.Futures {
 		if s.Type.Lower() == "" {
 			orderRequest.OrderType = OkxOrderOptimalLimitIOC
@@ -772,21 +860,77 @@ func (ok *Okx) SubmitOrder(ctx context.Context, s *order.Submit) (*order.SubmitR
 			orderRequest.PositionSide = positionSideShort
 		}
 	}
-	if ok.Websocket.CanUseAuthenticatedWebsocketForWrapper() {
-		placeOrderResponse, err = ok.WsPlaceOrder(orderRequest)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		placeOrderResponse, err = ok.PlaceOrder(ctx, orderRequest, s.AssetType)
-		if err != nil {
-			return nil, err
-		}
+	return orderRequest, nil
+}
+
+// icebergSupported returns whether an order can be placed as an iceberg algo
+// order, which rests on the book without a time in force, post only or self
+// trade prevention setting
+func icebergSupported(s *order.Submit) bool {
+	switch s.AssetType {
+	case asset.Spot, asset.Margin, asset.Futures, asset.PerpetualSwap:
+	default:
+		return false
 	}
+	return !s.PostOnly &&
+		(s.TimeInForce == order.UnsetTimeInForce || s.TimeInForce == order.GTC) &&
+		s.SelfTradePrevention == order.UnsetSelfTradePrevention
+}
+
+// submitIcebergOrder places an order with a display amount as an iceberg algo
+// order, which is sliced by the exchange into orders of the display amount at
+// the limit price. The returned order ID is the algo order ID
+func (ok *Okx) submitIcebergOrder(ctx context.Context, s *order.Submit, req *PlaceOrderRequestParam) (*order.SubmitResponse, error) {
+	resp, err := ok.PlaceIcebergOrder(ctx, &AlgoOrderParams{
+		InstrumentID: req.InstrumentID,
+		TradeMode:    req.TradeMode,
+		Side:         req.Side,
+		PositionSide: req.PositionSide,
+		OrderType:    "iceberg",
+		Size:         s.Amount,
+		ReduceOnly:   s.ReduceOnly,
+		PriceSpread:  "0",
+		SizeLimit:    s.DisplayAmount,
+		PriceLimit:   s.Price,
+	})
 	if err != nil {
 		return nil, err
 	}
-	return s.DeriveSubmitResponse(placeOrderResponse.OrderID)
+	if resp.StatusCode != "" && resp.StatusCode != "0" {
+		return nil, fmt.Errorf("iceberg order error code: %s message: %s", resp.StatusCode, resp.StatusMsg)
+	}
+	return s.DeriveSubmitResponse(resp.AlgoID)
+}
+
+// stpModeToString returns the self trade prevention mode for an order
+// submission, leaving it empty for the account default when unset
+func stpModeToString(stp order.SelfTradePrevention) string {
+	switch stp {
+	case order.STPCancelMaker:
+		return "cancel_maker"
+	case order.STPCancelTaker:
+		return "cancel_taker"
+	case order.STPCancelBoth:
+		return "cancel_both"
+	default:
+		return ""
+	}
+}
+
+// orderTypeFromSubmit returns the order type for an order submission, as limit
+// orders carry their time in force and post only flag in the order type
+func orderTypeFromSubmit(s *order.Submit) string {
+	if s.Type == order.Limit {
+		switch {
+		case s.PostOnly:
+			return OkxOrderPostOnly
+		case s.TimeInForce == order.IOC:
+			return OkxOrderIOC
+		case s.TimeInForce == order.FOK:
+			return OkxOrderFOK
+		}
+	}
+	return s.Type.Lower()
 }
 
 func (ok *Okx) marginTypeToString(m margin.Type) string {
@@ -1126,6 +1270,7 @@ func (ok *Okx) WithdrawCryptocurrencyFunds(ctx context.Context, withdrawRequest
 		ToAddress:             withdrawRequest.Crypto.Address,
 		TransactionFee:        withdrawRequest.Crypto.FeeAmount,
 		WithdrawalDestination: "3",
+		ClientID:              withdrawRequest.ClientOrderID,
 	}
 	resp, err := ok.Withdrawal(ctx, &input)
 	if err != nil {
@@ -1465,6 +1610,338 @@ func (ok *Okx) GetAvailableTransferChains(ctx context.Context, cryptocurrency cu
 	return chains, nil
 }
 
+// GetTransferNetworks returns the normalised transfer networks of a currency,
+// including each network's withdrawal fee and minimums
+func (ok *Okx) GetTransferNetworks(ctx context.Context, cryptocurrency currency.Code) ([]transfer.Chain, error) {
+	currencyChains, err := ok.GetFundingCurrencies(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var chains []transfer.Chain
+	for x := range currencyChains {
+		if !strings.EqualFold(cryptocurrency.String(), currencyChains[x].Currency) {
+			continue
+		}
+		var minWithdrawal float64
+		if currencyChains[x].MinWithdrawal != "" {
+			minWithdrawal, err = strconv.ParseFloat(currencyChains[x].MinWithdrawal, 64)
+			if err != nil {
+				return nil, err
+			}
+		}
+		chains = append(chains, transfer.Chain{
+			Network:         transfer.NormaliseNetwork(currencyChains[x].Chain),
+			Name:            currencyChains[x].Chain,
+			DepositEnabled:  currencyChains[x].CanDeposit,
+			WithdrawEnabled: currencyChains[x].CanWithdraw,
+			WithdrawFee:     currencyChains[x].MinFee.Float64(),
+			MinWithdrawal:   minWithdrawal,
+			MinDeposit:      currencyChains[x].MinDeposit.Float64(),
+		})
+	}
+	return chains, nil
+}
+
+// GetConversionQuote requests a quote from OKX convert, which must be
+// accepted with AcceptConversionQuote before it expires
+func (ok *Okx) GetConversionQuote(ctx context.Context, req *conversion.QuoteRequest) (*conversion.Quote, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	pair, err := ok.getConvertPair(ctx, req.From, req.To)
+	if err != nil {
+		return nil, err
+	}
+	side := order.Buy
+	if req.From.Equal(pair.Base) {
+		side = order.Sell
+	}
+	est, err := ok.EstimateQuote(ctx, &EstimateQuoteRequestInput{
+		BaseCurrency:  pair.Base.String(),
+		QuoteCurrency: pair.Quote.String(),
+		Side:          side.Lower(),
+		RfqAmount:     req.Amount,
+		RfqSzCurrency: req.From.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	q := &conversion.Quote{
+		Exchange: ok.Name,
+		ID:       est.QuoteID,
+		From:     req.From,
+		To:       req.To,
+		Pair:     pair,
+		Expires:  est.QuoteTime.Time().Add(time.Duration(est.TTLMs.Int64()) * time.Millisecond),
+	}
+	if side == order.Sell {
+		q.FromAmount, q.ToAmount = est.BaseSize.Float64(), est.QuoteSize.Float64()
+		q.Rate = est.ConvertPrice.Float64()
+	} else {
+		q.FromAmount, q.ToAmount = est.QuoteSize.Float64(), est.BaseSize.Float64()
+		if px := est.ConvertPrice.Float64(); px != 0 {
+			q.Rate = 1 / px
+		}
+	}
+	return q, nil
+}
+
+// AcceptConversionQuote accepts a quote returned by GetConversionQuote
+func (ok *Okx) AcceptConversionQuote(ctx context.Context, q *conversion.Quote) (*conversion.Result, error) {
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+	pair := q.Pair
+	if pair.IsEmpty() {
+		var err error
+		if pair, err = ok.getConvertPair(ctx, q.From, q.To); err != nil {
+			return nil, err
+		}
+	}
+	side := order.Buy
+	if q.From.Equal(pair.Base) {
+		side = order.Sell
+	}
+	resp, err := ok.ConvertTrade(ctx, &ConvertTradeInput{
+		BaseCurrency:  pair.Base.String(),
+		QuoteCurrency: pair.Quote.String(),
+		Side:          side.Lower(),
+		Size:          q.FromAmount,
+		SizeCurrency:  q.From.String(),
+		QuoteID:       q.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := &conversion.Result{
+		Exchange: ok.Name,
+		QuoteID:  q.ID,
+		OrderID:  resp.TradeID,
+		From:     q.From,
+		To:       q.To,
+		Status:   resp.State,
+		Time:     resp.Timestamp.Time(),
+	}
+	if side == order.Sell {
+		result.FromAmount, result.ToAmount = resp.FillBaseSize.Float64(), resp.FillQuoteSize.Float64()
+	} else {
+		result.FromAmount, result.ToAmount = resp.FillQuoteSize.Float64(), resp.FillBaseSize.Float64()
+	}
+	return result, nil
+}
+
+// getConvertPair returns the OKX convert pair between two currencies
+func (ok *Okx) getConvertPair(ctx context.Context, from, to currency.Code) (currency.Pair, error) {
+	p, err := ok.GetConvertCurrencyPair(ctx, from.String(), to.String())
+	if err != nil {
+		return currency.EMPTYPAIR, err
+	}
+	return currency.NewPair(currency.NewCode(p.BaseCurrency), currency.NewCode(p.QuoteCurrency)), nil
+}
+
+// GetDustBalances returns balances which can be converted with easy convert
+func (ok *Okx) GetDustBalances(ctx context.Context) ([]conversion.DustBalance, error) {
+	resp, err := ok.GetEasyConvertCurrencyList(ctx)
+	if err != nil {
+		return nil, err
+	}
+	targets := make([]currency.Code, len(resp.ToCurrency))
+	for i := range resp.ToCurrency {
+		targets[i] = currency.NewCode(resp.ToCurrency[i])
+	}
+	balances := make([]conversion.DustBalance, len(resp.FromData))
+	for i := range resp.FromData {
+		balances[i] = conversion.DustBalance{
+			Currency: currency.NewCode(resp.FromData[i].FromCurrency),
+			Amount:   resp.FromData[i].FromAmount.Float64(),
+			Targets:  targets,
+		}
+	}
+	return balances, nil
+}
+
+// ConvertDust converts dust balances with easy convert, into OKB when no
+// target currency is set
+func (ok *Okx) ConvertDust(ctx context.Context, req *conversion.DustRequest) (*conversion.DustResult, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	to := req.To
+	if to.IsEmpty() {
+		to = currency.OKB
+	}
+	from := make([]string, len(req.Currencies))
+	for i := range req.Currencies {
+		from[i] = req.Currencies[i].String()
+	}
+	resp, err := ok.PlaceEasyConvert(ctx, PlaceEasyConvertParam{FromCurrency: from, ToCurrency: to.String()})
+	if err != nil {
+		return nil, err
+	}
+	result := &conversion.DustResult{
+		Exchange:    ok.Name,
+		To:          to,
+		Conversions: make([]conversion.DustConversion, len(resp)),
+	}
+	for i := range resp {
+		result.Conversions[i] = conversion.DustConversion{
+			Currency:  currency.NewCode(resp[i].FromCurrency),
+			Amount:    resp[i].FilFromSize.Float64(),
+			Converted: resp[i].FillToSize.Float64(),
+		}
+		result.Total += resp[i].FillToSize.Float64()
+		if t := resp[i].UpdateTime.Time(); t.After(result.Time) {
+			result.Time = t
+		}
+	}
+	return result, nil
+}
+
+// minimumSavingsRate is the lowest annual lending rate OKX accepts when
+// purchasing savings, used so subscriptions lend at the market rate
+const minimumSavingsRate = 0.01
+
+// GetEarnProducts returns the OKX savings products, of which there is a single
+// flexible product per currency identified by the currency code
+func (ok *Okx) GetEarnProducts(ctx context.Context, c currency.Code) ([]earn.Product, error) {
+	var ccy string
+	if !c.IsEmpty() {
+		ccy = c.String()
+	}
+	info, err := ok.GetPublicBorrowInfo(ctx, ccy)
+	if err != nil {
+		return nil, err
+	}
+	products := make([]earn.Product, len(info))
+	for i := range info {
+		products[i] = earn.Product{
+			Exchange:  ok.Name,
+			ID:        info[i].Currency,
+			Currency:  currency.NewCode(info[i].Currency),
+			Type:      earn.Flexible,
+			APR:       info[i].EstimatedRate.Float64(),
+			Available: true,
+		}
+	}
+	return products, nil
+}
+
+// GetEarnPositions returns the amounts held in OKX savings
+func (ok *Okx) GetEarnPositions(ctx context.Context) ([]earn.Position, error) {
+	balances, err := ok.GetSavingBalance(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	positions := make([]earn.Position, len(balances))
+	for i := range balances {
+		positions[i] = earn.Position{
+			Exchange:  ok.Name,
+			ProductID: balances[i].Currency,
+			Currency:  currency.NewCode(balances[i].Currency),
+			Type:      earn.Flexible,
+			Amount:    balances[i].Amount.Float64(),
+			APR:       balances[i].Rate.Float64(),
+		}
+	}
+	return positions, nil
+}
+
+// SubscribeEarnProduct purchases OKX savings at the minimum lending rate
+func (ok *Okx) SubscribeEarnProduct(ctx context.Context, req *earn.SubscribeRequest) (*earn.Result, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if req.Type != earn.Flexible {
+		return nil, fmt.Errorf("%w: %s", earn.ErrInvalidType, req.Type)
+	}
+	resp, err := ok.SavingsPurchaseOrRedemption(ctx, &SavingsPurchaseRedemptionInput{
+		Currency:   req.Currency.String(),
+		Amount:     req.Amount,
+		ActionType: "purchase",
+		Rate:       minimumSavingsRate,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &earn.Result{
+		Exchange:  ok.Name,
+		ProductID: req.ProductID,
+		Currency:  req.Currency,
+		Amount:    resp.Amount.Float64(),
+		Success:   true,
+		Time:      time.Now(),
+	}, nil
+}
+
+// RedeemEarnProduct redeems an amount, or the full balance, from OKX savings
+func (ok *Okx) RedeemEarnProduct(ctx context.Context, req *earn.RedeemRequest) (*earn.Result, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if req.Type != earn.Flexible {
+		return nil, fmt.Errorf("%w: %s", earn.ErrInvalidType, req.Type)
+	}
+	amount := req.Amount
+	if req.All {
+		balances, err := ok.GetSavingBalance(ctx, req.Currency.String())
+		if err != nil {
+			return nil, err
+		}
+		if len(balances) != 1 {
+			return nil, errNoValidResponseFromServer
+		}
+		amount = balances[0].Amount.Float64()
+	}
+	resp, err := ok.SavingsPurchaseOrRedemption(ctx, &SavingsPurchaseRedemptionInput{
+		Currency:   req.Currency.String(),
+		Amount:     amount,
+		ActionType: "redempt",
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &earn.Result{
+		Exchange:  ok.Name,
+		ProductID: req.ProductID,
+		Currency:  req.Currency,
+		Amount:    resp.Amount.Float64(),
+		Success:   true,
+		Time:      time.Now(),
+	}, nil
+}
+
+// GetEarnRewards returns the OKX savings earnings between the start and end
+// times
+func (ok *Okx) GetEarnRewards(ctx context.Context, start, end time.Time) ([]earn.Reward, error) {
+	if err := common.StartEndTimeCheck(start, end); err != nil {
+		return nil, err
+	}
+	var rewards []earn.Reward
+	for after := end; ; {
+		history, err := ok.GetLendingHistory(ctx, "", start, after, 100)
+		if err != nil {
+			return nil, err
+		}
+		for i := range history {
+			if history[i].Earnings.Float64() == 0 {
+				continue
+			}
+			rewards = append(rewards, earn.Reward{
+				Exchange:  ok.Name,
+				ProductID: history[i].Currency,
+				Currency:  currency.NewCode(history[i].Currency),
+				Amount:    history[i].Earnings.Float64(),
+				Time:      history[i].Timestamp.Time(),
+			})
+		}
+		if len(history) < 100 {
+			break
+		}
+		after = history[len(history)-1].Timestamp.Time()
+	}
+	return rewards, nil
+}
+
 // getInstrumentsForOptions returns the instruments for options asset type
 func (ok *Okx) getInstrumentsForOptions(ctx context.Context) ([]Instrument, error) {
 	underlyings, err := ok.GetPublicUnderlyings(context.Background(), okxInstTypeOption)
@@ -2270,3 +2747,101 @@ func (ok *Okx) GetOpenInterest(ctx context.Context, k ...key.PairAsset) ([]futur
 	}
 	return resp, nil
 }
+
+// GetADLIndicators returns the auto-deleveraging indicator of the account's
+// open positions for the asset type
+func (ok *Okx) GetADLIndicators(ctx context.Context, item asset.Item) ([]futures.ADLIndicator, error) {
+	if item != asset.PerpetualSwap && item != asset.Futures {
+		return nil, fmt.Errorf("%w %v", asset.ErrNotSupported, item)
+	}
+	positions, err := ok.GetPositions(ctx, ok.GetInstrumentTypeFromAssetItem(item), "", "")
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]futures.ADLIndicator, 0, len(positions))
+	for i := range positions {
+		size := positions[i].QuantityOfPosition.Float64()
+		if size == 0 {
+			continue
+		}
+		pair, err := ok.GetPairFromInstrumentID(positions[i].InstrumentID)
+		if err != nil {
+			return nil, err
+		}
+		var rank int64
+		if positions[i].AutoDeleveraging != "" {
+			rank, err = strconv.ParseInt(positions[i].AutoDeleveraging, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+		}
+		side := order.Long
+		switch positions[i].PositionSide {
+		case positionSideShort:
+			side = order.Short
+		case positionSideNet:
+			// net mode positions are short when their size is negative
+			if size < 0 {
+				side = order.Short
+			}
+		}
+		resp = append(resp, futures.ADLIndicator{
+			Exchange: ok.Name,
+			Asset:    item,
+			Pair:     pair,
+			Side:     side,
+			Rank:     rank,
+			MaxRank:  maxADLIndicator,
+			Time:     positions[i].UpdatedTime.Time(),
+		})
+	}
+	return resp, nil
+}
+
+// GetScheduledEvents returns scheduled system maintenance, instruments yet to
+// be listed and spot, margin and perpetual swap instruments with a delisting
+// time. Futures expiries are returned by GetFuturesContractDetails
+func (ok *Okx) GetScheduledEvents(ctx context.Context) ([]calendar.Event, error) {
+	statuses, err := ok.SystemStatusResponse(ctx, "scheduled")
+	if err != nil {
+		return nil, err
+	}
+	events := make([]calendar.Event, 0, len(statuses))
+	for i := range statuses {
+		events = append(events, calendar.Event{
+			Exchange:    ok.Name,
+			Type:        calendar.Maintenance,
+			Start:       statuses[i].Begin.Time(),
+			End:         statuses[i].End.Time(),
+			Description: statuses[i].Title,
+		})
+	}
+	now := time.Now()
+	assets := ok.GetAssetTypes(true)
+	for _, a := range assets {
+		if a == asset.Options {
+			continue
+		}
+		insts, err := ok.getInstrumentsForAsset(ctx, a)
+		if err != nil {
+			return nil, err
+		}
+		for i := range insts {
+			e := calendar.Event{Exchange: ok.Name, Asset: a}
+			switch {
+			case insts[i].ListTime.After(now):
+				e.Type, e.Start = calendar.Listing, insts[i].ListTime.Time
+			case a != asset.Futures && insts[i].ExpTime.After(now):
+				e.Type, e.Start = calendar.Delisting, insts[i].ExpTime.Time
+			default:
+				continue
+			}
+			e.Pair, err = currency.NewPairDelimiter(insts[i].InstrumentID, ok.CurrencyPairs.ConfigFormat.Delimiter)
+			if err != nil {
+				return nil, err
+			}
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
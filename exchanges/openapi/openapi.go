@@ -0,0 +1,80 @@
+// Package openapi provides the runtime support for REST clients generated from
+// exchange OpenAPI specifications by cmd/openapi_gen. Generated operations are
+// sent through an exchange's Sender so they use its requester, rate limits and
+// authentication
+package openapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+var (
+	errPathParamMissing   = errors.New("path parameter missing")
+	errPathParamUnmatched = errors.New("path parameter has no placeholder")
+)
+
+// Operation describes a REST operation generated from an OpenAPI spec
+type Operation struct {
+	// ID is the spec's operationId
+	ID     string
+	Method string
+	// Path is the spec path template, e.g. /ticker/{symbol}
+	Path string
+	// Authenticated is set when the spec requires security for the operation
+	Authenticated bool
+}
+
+// Sender is implemented by exchanges to send generated operations. path has
+// its parameters expanded, body is nil for operations without a request body
+// and result is nil for operations without a response body
+type Sender interface {
+	SendOperation(ctx context.Context, op *Operation, path string, query url.Values, body, result any) error
+}
+
+// ExpandPath substitutes path parameters into an operation path template.
+// Every parameter must be set and have a placeholder
+func ExpandPath(path string, params map[string]any) (string, error) {
+	for name, v := range params {
+		placeholder := "{" + name + "}"
+		if !strings.Contains(path, placeholder) {
+			return "", fmt.Errorf("%w: %s in %s", errPathParamUnmatched, name, path)
+		}
+		if isZero(v) {
+			return "", fmt.Errorf("%w: %s", errPathParamMissing, name)
+		}
+		path = strings.ReplaceAll(path, placeholder, url.PathEscape(fmt.Sprint(v)))
+	}
+	return path, nil
+}
+
+// SetQuery adds a query parameter. Optional parameters are omitted when they
+// hold their zero value and slices add a value for each element
+func SetQuery(q url.Values, name string, v any, required bool) {
+	if !required && isZero(v) {
+		return
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Slice {
+		for i := range rv.Len() {
+			q.Add(name, fmt.Sprint(rv.Index(i).Interface()))
+		}
+		return
+	}
+	q.Set(name, fmt.Sprint(v))
+}
+
+func isZero(v any) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Slice {
+		return rv.Len() == 0
+	}
+	return rv.IsZero()
+}
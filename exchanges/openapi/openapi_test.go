@@ -0,0 +1,34 @@
+package openapi
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandPath(t *testing.T) {
+	t.Parallel()
+	p, err := ExpandPath("/orders/{symbol}/{id}", map[string]any{"symbol": "BTC/USD", "id": int64(5)})
+	require.NoError(t, err, "ExpandPath must not error")
+	assert.Equal(t, "/orders/BTC%2FUSD/5", p, "path parameters should be escaped and substituted")
+
+	_, err = ExpandPath("/orders/{symbol}", map[string]any{"symbol": ""})
+	assert.ErrorIs(t, err, errPathParamMissing)
+	_, err = ExpandPath("/orders", map[string]any{"symbol": "BTCUSD"})
+	assert.ErrorIs(t, err, errPathParamUnmatched)
+}
+
+func TestSetQuery(t *testing.T) {
+	t.Parallel()
+	q := url.Values{}
+	SetQuery(q, "limit", int64(0), false)
+	SetQuery(q, "symbols", []string{}, false)
+	assert.Empty(t, q, "optional zero values should be omitted")
+
+	SetQuery(q, "offset", int64(0), true)
+	SetQuery(q, "symbols", []string{"BTC", "ETH"}, false)
+	SetQuery(q, "reverse", true, false)
+	assert.Equal(t, url.Values{"offset": {"0"}, "symbols": {"BTC", "ETH"}, "reverse": {"true"}}, q)
+}
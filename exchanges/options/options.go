@@ -0,0 +1,196 @@
+package options
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+// String returns the string representation of the option type
+func (t Type) String() string {
+	switch t {
+	case Call:
+		return "call"
+	case Put:
+		return "put"
+	default:
+		return "unset"
+	}
+}
+
+// ParseContract parses an option contract name such as BTC-27DEC24-60000-C or
+// BTC-USD-241227-60000-C into its underlying, expiry, strike and type
+func ParseContract(p currency.Pair) (*Contract, error) {
+	name := strings.ToUpper(p.Base.String() + "-" + p.Quote.String())
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '-' || r == '_' || r == '/'
+	})
+	if len(parts) < 4 {
+		return nil, fmt.Errorf("%w %s", errInvalidContractName, p)
+	}
+	c := &Contract{Underlying: currency.NewCode(parts[0])}
+	switch parts[len(parts)-1] {
+	case "C", "CALL":
+		c.Type = Call
+	case "P", "PUT":
+		c.Type = Put
+	default:
+		return nil, fmt.Errorf("%w %s: %w", errInvalidContractName, p, errInvalidOptionType)
+	}
+	var err error
+	c.Strike, err = strconv.ParseFloat(strings.ReplaceAll(parts[len(parts)-2], "D", "."), 64)
+	if err != nil || c.Strike <= 0 {
+		return nil, fmt.Errorf("%w %s: %w", errInvalidContractName, p, errInvalidStrike)
+	}
+	c.Expiry, err = parseExpiry(parts[len(parts)-3])
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: %w", errInvalidContractName, p, err)
+	}
+	return c, nil
+}
+
+// parseExpiry parses the date formats exchanges use in option contract names
+func parseExpiry(s string) (time.Time, error) {
+	var layouts []string
+	switch {
+	case len(s) == 6 && isDigits(s):
+		layouts = []string{"060102"}
+	case len(s) == 8 && isDigits(s):
+		layouts = []string{"20060102"}
+	default:
+		// month abbreviations are title cased for parsing, such as 27Dec24
+		if i := strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' }); i > 0 && len(s) > i+1 {
+			s = s[:i+1] + strings.ToLower(s[i+1:])
+		}
+		layouts = []string{"2Jan06", "02Jan06"}
+	}
+	for i := range layouts {
+		t, err := time.Parse(layouts[i], s)
+		if err == nil {
+			return t.Add(time.Hour * expiryHour), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognised expiry %q", s)
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// TimeToExpiry returns the time in years between now and expiry
+func TimeToExpiry(expiry, now time.Time) float64 {
+	return expiry.Sub(now).Hours() / 24 / daysPerYear
+}
+
+// Price returns the Black-76 price of an option
+func Price(p *Params) (float64, error) {
+	if err := p.validate(); err != nil {
+		return 0, err
+	}
+	d1, d2 := p.d()
+	discount := math.Exp(-p.RiskFreeRate * p.TimeToExpiry)
+	if p.Type == Call {
+		return discount * (p.Underlying*normCDF(d1) - p.Strike*normCDF(d2)), nil
+	}
+	return discount * (p.Strike*normCDF(-d2) - p.Underlying*normCDF(-d1)), nil
+}
+
+// CalculateGreeks returns the Black-76 greeks of an option
+func CalculateGreeks(p *Params) (*Greeks, error) {
+	price, err := Price(p)
+	if err != nil {
+		return nil, err
+	}
+	d1, _ := p.d()
+	discount := math.Exp(-p.RiskFreeRate * p.TimeToExpiry)
+	sqrtT := math.Sqrt(p.TimeToExpiry)
+	g := &Greeks{
+		Gamma: discount * normPDF(d1) / (p.Underlying * p.Volatility * sqrtT),
+		Vega:  discount * p.Underlying * normPDF(d1) * sqrtT / volatilityPointScale,
+		Theta: (-discount*p.Underlying*normPDF(d1)*p.Volatility/(2*sqrtT) + p.RiskFreeRate*price) / daysPerYear,
+	}
+	if p.Type == Call {
+		g.Delta = discount * normCDF(d1)
+	} else {
+		g.Delta = -discount * normCDF(-d1)
+	}
+	return g, nil
+}
+
+// ImpliedVolatility returns the volatility at which the Black-76 price of an
+// option matches the supplied price. The volatility field of params is ignored
+func ImpliedVolatility(price float64, p *Params) (float64, error) {
+	if price <= 0 {
+		return 0, errInvalidPrice
+	}
+	search := *p
+	search.Volatility = minVolatility
+	low, err := Price(&search)
+	if err != nil {
+		return 0, err
+	}
+	search.Volatility = maxVolatility
+	high, err := Price(&search)
+	if err != nil {
+		return 0, err
+	}
+	if price < low || price > high {
+		return 0, fmt.Errorf("%w price %v bounds %v-%v", errPriceOutsideBounds, price, low, high)
+	}
+	lowVol, highVol := minVolatility, maxVolatility
+	for range maxVolatilitySearch {
+		search.Volatility = (lowVol + highVol) / 2
+		estimate, err := Price(&search)
+		if err != nil {
+			return 0, err
+		}
+		if math.Abs(estimate-price) < volatilityTolerance || highVol-lowVol < volatilityTolerance {
+			return search.Volatility, nil
+		}
+		if estimate < price {
+			lowVol = search.Volatility
+		} else {
+			highVol = search.Volatility
+		}
+	}
+	return 0, errVolatilityNotReached
+}
+
+func (p *Params) validate() error {
+	switch {
+	case p.Type != Call && p.Type != Put:
+		return errInvalidOptionType
+	case p.Underlying <= 0:
+		return errInvalidPrice
+	case p.Strike <= 0:
+		return errInvalidStrike
+	case p.Volatility <= 0:
+		return errInvalidVolatility
+	case p.TimeToExpiry <= 0:
+		return errContractExpired
+	}
+	return nil
+}
+
+func (p *Params) d() (d1, d2 float64) {
+	volT := p.Volatility * math.Sqrt(p.TimeToExpiry)
+	d1 = (math.Log(p.Underlying/p.Strike) + p.Volatility*p.Volatility*p.TimeToExpiry/2) / volT
+	return d1, d1 - volT
+}
+
+func normCDF(x float64) float64 {
+	return math.Erfc(-x/math.Sqrt2) / 2
+}
+
+func normPDF(x float64) float64 {
+	return math.Exp(-x*x/2) / math.Sqrt(2*math.Pi)
+}
@@ -0,0 +1,93 @@
+package options
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+func TestParseContract(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		pair   currency.Pair
+		expiry time.Time
+		strike float64
+		typ    Type
+	}{
+		{currency.NewPair(currency.BTC, currency.NewCode("27DEC24-60000-C")), time.Date(2024, 12, 27, 8, 0, 0, 0, time.UTC), 60000, Call},
+		{currency.NewPair(currency.ETH, currency.NewCode("USD-241227-3000-P")), time.Date(2024, 12, 27, 8, 0, 0, 0, time.UTC), 3000, Put},
+		{currency.NewPair(currency.XRP, currency.NewCode("USDC_5JAN25_0d625_C")), time.Date(2025, 1, 5, 8, 0, 0, 0, time.UTC), 0.625, Call},
+		{currency.NewPair(currency.BTC, currency.NewCode("USD-20250328-80000-PUT")), time.Date(2025, 3, 28, 8, 0, 0, 0, time.UTC), 80000, Put},
+	} {
+		c, err := ParseContract(tc.pair)
+		require.NoError(t, err, tc.pair.String())
+		assert.Equal(t, tc.pair.Base, c.Underlying, tc.pair.String())
+		assert.Equal(t, tc.expiry, c.Expiry, tc.pair.String())
+		assert.Equal(t, tc.strike, c.Strike, tc.pair.String())
+		assert.Equal(t, tc.typ, c.Type, tc.pair.String())
+	}
+
+	_, err := ParseContract(currency.NewPair(currency.BTC, currency.USDT))
+	assert.ErrorIs(t, err, errInvalidContractName)
+	_, err = ParseContract(currency.NewPair(currency.BTC, currency.NewCode("27DEC24-60000-X")))
+	assert.ErrorIs(t, err, errInvalidOptionType)
+	_, err = ParseContract(currency.NewPair(currency.BTC, currency.NewCode("27DEC24-ABC-C")))
+	assert.ErrorIs(t, err, errInvalidStrike)
+	_, err = ParseContract(currency.NewPair(currency.BTC, currency.NewCode("NOTADATE-60000-C")))
+	assert.ErrorIs(t, err, errInvalidContractName)
+}
+
+func TestPriceAndGreeks(t *testing.T) {
+	t.Parallel()
+	p := &Params{Type: Call, Underlying: 100, Strike: 100, TimeToExpiry: 1, Volatility: 0.2}
+	price, err := Price(p)
+	require.NoError(t, err)
+	assert.InDelta(t, 7.9656, price, 1e-4)
+
+	g, err := CalculateGreeks(p)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.53983, g.Delta, 1e-5)
+	assert.InDelta(t, 0.019848, g.Gamma, 1e-6)
+	assert.InDelta(t, 0.396953, g.Vega, 1e-6)
+	assert.InDelta(t, -0.010876, g.Theta, 1e-6)
+
+	p.Type = Put
+	putPrice, err := Price(p)
+	require.NoError(t, err)
+	assert.InDelta(t, price, putPrice, 1e-9, "at the money call and put should be equal under put-call parity")
+	g, err = CalculateGreeks(p)
+	require.NoError(t, err)
+	assert.InDelta(t, -0.46017, g.Delta, 1e-5)
+
+	_, err = Price(&Params{Type: Call, Underlying: 100, Strike: 100, Volatility: 0.2})
+	assert.ErrorIs(t, err, errContractExpired)
+	_, err = Price(&Params{Underlying: 100, Strike: 100, TimeToExpiry: 1, Volatility: 0.2})
+	assert.ErrorIs(t, err, errInvalidOptionType)
+	_, err = CalculateGreeks(&Params{Type: Call, Underlying: 100, TimeToExpiry: 1, Volatility: 0.2})
+	assert.ErrorIs(t, err, errInvalidStrike)
+}
+
+func TestImpliedVolatility(t *testing.T) {
+	t.Parallel()
+	p := &Params{Type: Put, Underlying: 2500, Strike: 2200, TimeToExpiry: 0.25, Volatility: 0.65, RiskFreeRate: 0.03}
+	price, err := Price(p)
+	require.NoError(t, err)
+	vol, err := ImpliedVolatility(price, p)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.65, vol, 1e-6)
+
+	_, err = ImpliedVolatility(0, p)
+	assert.ErrorIs(t, err, errInvalidPrice)
+	_, err = ImpliedVolatility(5000, p)
+	assert.ErrorIs(t, err, errPriceOutsideBounds)
+}
+
+func TestTimeToExpiry(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	assert.InDelta(t, 1.0, TimeToExpiry(now.Add(time.Hour*24*365), now), 1e-9)
+	assert.Negative(t, TimeToExpiry(now.Add(-time.Hour), now))
+}
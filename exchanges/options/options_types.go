@@ -0,0 +1,74 @@
+package options
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+// expiryHour is the UTC hour at which options commonly expire when the
+// contract name only specifies a date
+const expiryHour = 8
+
+// Implied volatility search bounds and tolerance
+const (
+	minVolatility        = 1e-4
+	maxVolatility        = 10.0
+	volatilityTolerance  = 1e-8
+	maxVolatilitySearch  = 200
+	daysPerYear          = 365
+	volatilityPointScale = 100
+)
+
+var (
+	errInvalidContractName  = errors.New("invalid option contract name")
+	errInvalidOptionType    = errors.New("invalid option type")
+	errInvalidPrice         = errors.New("price must be greater than zero")
+	errInvalidStrike        = errors.New("strike must be greater than zero")
+	errInvalidVolatility    = errors.New("volatility must be greater than zero")
+	errContractExpired      = errors.New("option contract has expired")
+	errPriceOutsideBounds   = errors.New("option price is outside of no-arbitrage bounds")
+	errVolatilityNotReached = errors.New("implied volatility did not converge")
+)
+
+// Type is the right an option contract grants
+type Type uint8
+
+// Option types
+const (
+	UnsetType Type = iota
+	Call
+	Put
+)
+
+// Contract describes an option contract parsed from its name
+type Contract struct {
+	Underlying currency.Code
+	Expiry     time.Time
+	Strike     float64
+	Type       Type
+}
+
+// Params holds the inputs used to price an option with the Black-76 model
+type Params struct {
+	Type Type
+	// Underlying is the forward price of the underlying for the option's expiry
+	Underlying float64
+	Strike     float64
+	// TimeToExpiry is in years
+	TimeToExpiry float64
+	// Volatility is annualised, where 0.5 is 50%
+	Volatility   float64
+	RiskFreeRate float64
+}
+
+// Greeks holds the price sensitivities of a single option. Delta and gamma are
+// per unit of underlying, vega is per volatility point and theta is per
+// calendar day
+type Greeks struct {
+	Delta float64
+	Gamma float64
+	Vega  float64
+	Theta float64
+}
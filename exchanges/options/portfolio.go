@@ -0,0 +1,191 @@
+package options
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// GetPortfolioModel returns an approximation of a venue's published portfolio
+// margin risk scenarios
+func GetPortfolioModel(name string) (*PortfolioModel, error) {
+	switch strings.ToLower(name) {
+	case "deribit":
+		// Deribit simulates moves of up to 16% in either direction with
+		// volatility shifted up 45% and down 30%
+		return &PortfolioModel{
+			Name:                   "deribit",
+			PriceMoves:             priceRange(0.16, 0.02),
+			VolatilityShocks:       []float64{-0.3, 0, 0.45},
+			ShortOptionContingency: 0.006,
+			InitialMarginRatio:     1.3,
+		}, nil
+	case "okx":
+		// OKX simulates moves of up to 15% in either direction across seven
+		// points with volatility shifted up and down
+		return &PortfolioModel{
+			Name:                   "okx",
+			PriceMoves:             priceRange(0.15, 0.05),
+			VolatilityShocks:       []float64{-0.25, 0, 0.25},
+			ShortOptionContingency: 0.005,
+			InitialMarginRatio:     1.3,
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w %q", ErrUnknownPortfolioModel, name)
+	}
+}
+
+// priceRange returns price moves from -limit to limit in steps
+func priceRange(limit, step float64) []float64 {
+	steps := int(math.Round(limit / step))
+	moves := make([]float64, 0, steps*2+1)
+	for i := -steps; i <= steps; i++ {
+		moves = append(moves, float64(i)*step)
+	}
+	return moves
+}
+
+// Requirement returns the margin required by a portfolio under the model's
+// risk scenarios
+func (m *PortfolioModel) Requirement(market *PortfolioMarket, positions []PortfolioPosition) (*PortfolioRequirement, error) {
+	if len(m.PriceMoves) == 0 {
+		return nil, errNoScenarios
+	}
+	if market.UnderlyingPrice <= 0 {
+		return nil, errInvalidUnderlyingPrice
+	}
+	volShocks := m.VolatilityShocks
+	if len(volShocks) == 0 {
+		volShocks = []float64{0}
+	}
+	base := make([]float64, len(positions))
+	req := &PortfolioRequirement{}
+	for i := range positions {
+		v, err := positions[i].value(market, market.UnderlyingPrice, 0)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", positions[i].Pair, err)
+		}
+		base[i] = v
+		if positions[i].Contract != nil && positions[i].Size < 0 {
+			req.Contingency += -positions[i].Size * market.UnderlyingPrice * m.ShortOptionContingency
+		}
+	}
+	first := true
+	for _, move := range m.PriceMoves {
+		price := market.UnderlyingPrice * (1 + move)
+		if price <= 0 {
+			continue
+		}
+		for _, shock := range volShocks {
+			var pnl float64
+			for i := range positions {
+				v, err := positions[i].value(market, price, shock)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", positions[i].Pair, err)
+				}
+				pnl += v - base[i]
+			}
+			if first || pnl < req.WorstScenario.PNL {
+				req.WorstScenario = ScenarioResult{PriceMove: move, VolatilityShock: shock, PNL: pnl}
+				first = false
+			}
+		}
+	}
+	req.MaintenanceMargin = math.Max(-req.WorstScenario.PNL, 0) + req.Contingency
+	req.InitialMargin = req.MaintenanceMargin * m.InitialMarginRatio
+	return req, nil
+}
+
+// SimulateTrade returns the margin required by a portfolio before and after
+// adding a trade
+func (m *PortfolioModel) SimulateTrade(market *PortfolioMarket, positions []PortfolioPosition, trade *PortfolioPosition) (*TradeSimulation, error) {
+	if trade.Size == 0 {
+		return nil, errInvalidTradeSize
+	}
+	before, err := m.Requirement(market, positions)
+	if err != nil {
+		return nil, err
+	}
+	after, err := m.Requirement(market, append(positions[:len(positions):len(positions)], *trade))
+	if err != nil {
+		return nil, err
+	}
+	return &TradeSimulation{
+		Before:                       *before,
+		After:                        *after,
+		IncrementalInitialMargin:     after.InitialMargin - before.InitialMargin,
+		IncrementalMaintenanceMargin: after.MaintenanceMargin - before.MaintenanceMargin,
+	}, nil
+}
+
+// MaxTradeSize returns the largest size of a trade, in the direction of the
+// trade's size, which keeps the portfolio's initial margin within the
+// available margin. Zero is returned when no amount of the trade fits
+func (m *PortfolioModel) MaxTradeSize(market *PortfolioMarket, positions []PortfolioPosition, trade *PortfolioPosition, available float64) (float64, error) {
+	if trade.Size == 0 {
+		return 0, errInvalidTradeSize
+	}
+	held := positions[:len(positions):len(positions)]
+	direction := math.Copysign(1, trade.Size)
+	fits := func(size float64) (bool, error) {
+		t := *trade
+		t.Size = size * direction
+		req, err := m.Requirement(market, append(held, t))
+		if err != nil {
+			return false, err
+		}
+		return req.InitialMargin <= available, nil
+	}
+	// Grow the upper bound until the trade no longer fits
+	low, high := 0.0, math.Abs(trade.Size)
+	for {
+		ok, err := fits(high)
+		if err != nil {
+			return 0, err
+		}
+		if !ok {
+			break
+		}
+		low = high
+		high *= 2
+		if math.IsInf(high, 1) {
+			return low, nil
+		}
+	}
+	for range maxTradeSizeSearch {
+		mid := (low + high) / 2
+		ok, err := fits(mid)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			low = mid
+		} else {
+			high = mid
+		}
+	}
+	return low, nil
+}
+
+// value returns the value of a position at an underlying price with its
+// volatility shocked
+func (p *PortfolioPosition) value(market *PortfolioMarket, price, volShock float64) (float64, error) {
+	if p.Contract == nil {
+		return p.Size * price, nil
+	}
+	if p.Volatility <= 0 {
+		return 0, errInvalidVolatility
+	}
+	optionPrice, err := Price(&Params{
+		Type:         p.Contract.Type,
+		Underlying:   price,
+		Strike:       p.Contract.Strike,
+		TimeToExpiry: TimeToExpiry(p.Contract.Expiry, market.Time),
+		Volatility:   p.Volatility * (1 + volShock),
+		RiskFreeRate: market.RiskFreeRate,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return p.Size * optionPrice, nil
+}
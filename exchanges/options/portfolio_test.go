@@ -0,0 +1,121 @@
+package options
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+func testPortfolioMarket() *PortfolioMarket {
+	return &PortfolioMarket{UnderlyingPrice: 50000, Time: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func testShortCall(m *PortfolioMarket, size float64) PortfolioPosition {
+	return PortfolioPosition{
+		Pair:       currency.NewPair(currency.BTC, currency.USD),
+		Asset:      asset.Options,
+		Size:       -size,
+		Contract:   &Contract{Underlying: currency.BTC, Expiry: m.Time.AddDate(0, 0, 30), Strike: 55000, Type: Call},
+		Volatility: 0.6,
+	}
+}
+
+func TestGetPortfolioModel(t *testing.T) {
+	t.Parallel()
+	m, err := GetPortfolioModel("Deribit")
+	require.NoError(t, err)
+	assert.Equal(t, "deribit", m.Name)
+	require.Len(t, m.PriceMoves, 17)
+	assert.InDelta(t, -0.16, m.PriceMoves[0], 1e-9)
+	assert.InDelta(t, 0.16, m.PriceMoves[16], 1e-9)
+	m, err = GetPortfolioModel("okx")
+	require.NoError(t, err)
+	assert.Len(t, m.PriceMoves, 7)
+	_, err = GetPortfolioModel("bitstamp")
+	assert.ErrorIs(t, err, ErrUnknownPortfolioModel)
+}
+
+func TestPortfolioRequirement(t *testing.T) {
+	t.Parallel()
+	m, err := GetPortfolioModel("deribit")
+	require.NoError(t, err)
+	market := testPortfolioMarket()
+
+	_, err = (&PortfolioModel{}).Requirement(market, nil)
+	assert.ErrorIs(t, err, errNoScenarios)
+	_, err = m.Requirement(&PortfolioMarket{}, nil)
+	assert.ErrorIs(t, err, errInvalidUnderlyingPrice)
+	bad := testShortCall(market, 1)
+	bad.Volatility = 0
+	_, err = m.Requirement(market, []PortfolioPosition{bad})
+	assert.ErrorIs(t, err, errInvalidVolatility)
+
+	req, err := m.Requirement(market, nil)
+	require.NoError(t, err)
+	assert.Zero(t, req.InitialMargin, "an empty portfolio should require no margin")
+
+	long := PortfolioPosition{Pair: currency.NewPair(currency.BTC, currency.USD), Asset: asset.PerpetualContract, Size: 1}
+	req, err = m.Requirement(market, []PortfolioPosition{long})
+	require.NoError(t, err)
+	assert.InDelta(t, 8000, req.MaintenanceMargin, 1e-6, "a long future should lose the largest price fall")
+	assert.InDelta(t, -0.16, req.WorstScenario.PriceMove, 1e-9)
+	assert.InDelta(t, 10400, req.InitialMargin, 1e-6)
+
+	req, err = m.Requirement(market, []PortfolioPosition{testShortCall(market, 1)})
+	require.NoError(t, err)
+	assert.InDelta(t, 300, req.Contingency, 1e-9)
+	assert.InDelta(t, 0.16, req.WorstScenario.PriceMove, 1e-9, "a short call should lose the largest price rise")
+	assert.InDelta(t, 0.45, req.WorstScenario.VolatilityShock, 1e-9)
+	assert.Greater(t, req.MaintenanceMargin, req.Contingency)
+}
+
+func TestSimulateTrade(t *testing.T) {
+	t.Parallel()
+	m, err := GetPortfolioModel("deribit")
+	require.NoError(t, err)
+	market := testPortfolioMarket()
+	short := testShortCall(market, 1)
+
+	_, err = m.SimulateTrade(market, nil, &PortfolioPosition{})
+	assert.ErrorIs(t, err, errInvalidTradeSize)
+
+	hedge := PortfolioPosition{Pair: currency.NewPair(currency.BTC, currency.USD), Asset: asset.PerpetualContract, Size: 0.3}
+	sim, err := m.SimulateTrade(market, []PortfolioPosition{short}, &hedge)
+	require.NoError(t, err)
+	assert.Negative(t, sim.IncrementalInitialMargin, "delta hedging a short call should reduce margin")
+	assert.InDelta(t, sim.After.InitialMargin-sim.Before.InitialMargin, sim.IncrementalInitialMargin, 1e-9)
+
+	more := testShortCall(market, 1)
+	sim, err = m.SimulateTrade(market, []PortfolioPosition{short}, &more)
+	require.NoError(t, err)
+	assert.InDelta(t, sim.Before.InitialMargin, sim.IncrementalInitialMargin, 1e-6, "doubling a position should double its margin")
+}
+
+func TestMaxTradeSize(t *testing.T) {
+	t.Parallel()
+	m, err := GetPortfolioModel("deribit")
+	require.NoError(t, err)
+	market := testPortfolioMarket()
+
+	_, err = m.MaxTradeSize(market, nil, &PortfolioPosition{}, 1000)
+	assert.ErrorIs(t, err, errInvalidTradeSize)
+
+	long := PortfolioPosition{Pair: currency.NewPair(currency.BTC, currency.USD), Asset: asset.PerpetualContract, Size: 1}
+	size, err := m.MaxTradeSize(market, nil, &long, 20800)
+	require.NoError(t, err)
+	assert.InDelta(t, 2, size, 1e-6)
+
+	short := long
+	short.Size = -5
+	size, err = m.MaxTradeSize(market, []PortfolioPosition{long}, &short, 10400)
+	require.NoError(t, err)
+	assert.InDelta(t, 2, size, 1e-6, "closing the long should free margin for an equal short")
+
+	size, err = m.MaxTradeSize(market, []PortfolioPosition{long}, &long, 1000)
+	require.NoError(t, err)
+	assert.Zero(t, size)
+}
@@ -0,0 +1,85 @@
+package options
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+// Portfolio margin simulation errors
+var (
+	ErrUnknownPortfolioModel  = errors.New("unknown portfolio margin model")
+	errInvalidUnderlyingPrice = errors.New("underlying price must be greater than zero")
+	errInvalidTradeSize       = errors.New("trade size must be non-zero")
+	errNoScenarios            = errors.New("portfolio margin model has no scenarios")
+)
+
+// maxTradeSizeSearch is the number of bisection steps used when searching for
+// the largest trade which fits within available margin
+const maxTradeSizeSearch = 100
+
+// PortfolioModel describes a venue's portfolio margin risk scenarios. The
+// maintenance margin of a portfolio is its largest loss across every
+// combination of price move and volatility shock, plus a contingency charge
+// for short options
+type PortfolioModel struct {
+	Name string
+	// PriceMoves are relative moves of the underlying price, where -0.16 is a
+	// 16% fall
+	PriceMoves []float64
+	// VolatilityShocks are relative changes to option implied volatility,
+	// where 0.45 is a 45% increase
+	VolatilityShocks []float64
+	// ShortOptionContingency is charged per unit of short option as a fraction
+	// of the underlying price
+	ShortOptionContingency float64
+	// InitialMarginRatio scales maintenance margin to initial margin
+	InitialMarginRatio float64
+}
+
+// PortfolioMarket holds the market inputs used to reprice positions
+type PortfolioMarket struct {
+	UnderlyingPrice float64
+	RiskFreeRate    float64
+	Time            time.Time
+}
+
+// PortfolioPosition is a position in a portfolio margin account
+type PortfolioPosition struct {
+	Pair  currency.Pair
+	Asset asset.Item
+	// Size is in units of the underlying, negative when short
+	Size float64
+	// Contract is set for options, which are repriced in each scenario.
+	// Positions without a contract move one for one with the underlying
+	Contract *Contract
+	// Volatility is the option's current implied volatility
+	Volatility float64
+}
+
+// PortfolioRequirement is the margin required by a portfolio
+type PortfolioRequirement struct {
+	InitialMargin     float64
+	MaintenanceMargin float64
+	// WorstScenario is the scenario with the largest loss
+	WorstScenario ScenarioResult
+	Contingency   float64
+}
+
+// ScenarioResult is the profit or loss of a portfolio in a risk scenario
+type ScenarioResult struct {
+	PriceMove       float64
+	VolatilityShock float64
+	PNL             float64
+}
+
+// TradeSimulation is the margin impact of adding a trade to a portfolio
+type TradeSimulation struct {
+	Before PortfolioRequirement
+	After  PortfolioRequirement
+	// IncrementalInitialMargin is negative when the trade reduces risk
+	IncrementalInitialMargin     float64
+	IncrementalMaintenanceMargin float64
+}
@@ -0,0 +1,237 @@
+package options
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// String returns the string representation of the strategy type
+func (s StrategyType) String() string {
+	switch s {
+	case CustomStrategy:
+		return "custom"
+	case VerticalSpread:
+		return "vertical"
+	case Straddle:
+		return "straddle"
+	case CalendarSpread:
+		return "calendar"
+	default:
+		return "unset"
+	}
+}
+
+// StringToStrategyType returns the strategy type for a string
+func StringToStrategyType(s string) (StrategyType, error) {
+	switch strings.ToLower(s) {
+	case "custom":
+		return CustomStrategy, nil
+	case "vertical", "verticalspread":
+		return VerticalSpread, nil
+	case "straddle":
+		return Straddle, nil
+	case "calendar", "calendarspread":
+		return CalendarSpread, nil
+	default:
+		return UnsetStrategy, fmt.Errorf("%w %q", errUnsupportedStrategy, s)
+	}
+}
+
+// NewVertical returns a vertical spread buying the long contract and selling
+// the short contract, such as a bull call spread when the long call has the
+// lower strike
+func NewVertical(long, short currency.Pair) (*Strategy, error) {
+	return NewStrategy(VerticalSpread, []Leg{
+		{Pair: long, Side: order.Buy},
+		{Pair: short, Side: order.Sell},
+	})
+}
+
+// NewStraddle returns a straddle buying or selling both the call and the put
+func NewStraddle(call, put currency.Pair, side order.Side) (*Strategy, error) {
+	return NewStrategy(Straddle, []Leg{
+		{Pair: call, Side: side},
+		{Pair: put, Side: side},
+	})
+}
+
+// NewCalendar returns a calendar spread. A long calendar, where side is buy,
+// sells the near contract and buys the far contract
+func NewCalendar(near, far currency.Pair, side order.Side) (*Strategy, error) {
+	nearSide := order.Sell
+	if !side.IsLong() {
+		nearSide = order.Buy
+	}
+	farSide := order.Buy
+	if nearSide == order.Buy {
+		farSide = order.Sell
+	}
+	return NewStrategy(CalendarSpread, []Leg{
+		{Pair: near, Side: nearSide},
+		{Pair: far, Side: farSide},
+	})
+}
+
+// NewStrategy parses each leg's contract and checks the legs form the strategy
+// type
+func NewStrategy(t StrategyType, legs []Leg) (*Strategy, error) {
+	if len(legs) < 2 {
+		return nil, fmt.Errorf("%w %s: %w %d", ErrInvalidStrategy, t, errInvalidLegCount, len(legs))
+	}
+	s := &Strategy{Type: t, Legs: make([]Leg, len(legs))}
+	for i := range legs {
+		l := legs[i]
+		switch {
+		case l.Side.IsLong():
+			l.Side = order.Buy
+		case l.Side.IsShort():
+			l.Side = order.Sell
+		default:
+			return nil, fmt.Errorf("%w %s leg %s: %w", ErrInvalidStrategy, t, l.Pair, errInvalidLegSide)
+		}
+		if l.Ratio == 0 {
+			l.Ratio = 1
+		}
+		if l.Ratio < 0 {
+			return nil, fmt.Errorf("%w %s leg %s: %w", ErrInvalidStrategy, t, l.Pair, errInvalidLegRatio)
+		}
+		var err error
+		if l.Contract, err = ParseContract(l.Pair); err != nil {
+			return nil, fmt.Errorf("%w %s: %w", ErrInvalidStrategy, t, err)
+		}
+		s.Legs[i] = l
+	}
+	if err := s.validate(); err != nil {
+		return nil, fmt.Errorf("%w %s: %w", ErrInvalidStrategy, t, err)
+	}
+	return s, nil
+}
+
+// validate checks the parsed legs form the strategy type
+func (s *Strategy) validate() error {
+	if s.Type == CustomStrategy {
+		underlying := s.Legs[0].Contract.Underlying
+		for i := 1; i < len(s.Legs); i++ {
+			if !s.Legs[i].Contract.Underlying.Equal(underlying) {
+				return fmt.Errorf("%w, legs must share an underlying", errInvalidStrategyLegs)
+			}
+		}
+		return nil
+	}
+	if len(s.Legs) != 2 {
+		return fmt.Errorf("%w %d, expected 2", errInvalidLegCount, len(s.Legs))
+	}
+	a, b := s.Legs[0].Contract, s.Legs[1].Contract
+	if !a.Underlying.Equal(b.Underlying) || s.Legs[0].Ratio != s.Legs[1].Ratio {
+		return fmt.Errorf("%w, legs must share an underlying and ratio", errInvalidStrategyLegs)
+	}
+	sameSide := s.Legs[0].Side == s.Legs[1].Side
+	switch s.Type {
+	case VerticalSpread:
+		if sameSide || a.Type != b.Type || !a.Expiry.Equal(b.Expiry) || a.Strike == b.Strike {
+			return fmt.Errorf("%w, legs must be opposite sides of the same option type and expiry at different strikes", errInvalidStrategyLegs)
+		}
+	case Straddle:
+		if !sameSide || a.Type == b.Type || !a.Expiry.Equal(b.Expiry) || a.Strike != b.Strike {
+			return fmt.Errorf("%w, legs must be the same side of a call and a put at the same strike and expiry", errInvalidStrategyLegs)
+		}
+	case CalendarSpread:
+		if sameSide || a.Type != b.Type || a.Strike != b.Strike || a.Expiry.Equal(b.Expiry) {
+			return fmt.Errorf("%w, legs must be opposite sides of the same option type and strike at different expiries", errInvalidStrategyLegs)
+		}
+	default:
+		return fmt.Errorf("%w %q", errUnsupportedStrategy, s.Type)
+	}
+	return nil
+}
+
+// Orders returns an order for each leg of the strategy at the amount. Bought
+// legs are ordered first so that a failure part way through never leaves a
+// short option uncovered
+func (s *Strategy) Orders(exch string, a asset.Item, amount float64) ([]*order.Submit, error) {
+	if s == nil {
+		return nil, ErrNilStrategy
+	}
+	if amount <= 0 {
+		return nil, errInvalidStrategyAmount
+	}
+	orders := make([]*order.Submit, 0, len(s.Legs))
+	for _, side := range []order.Side{order.Buy, order.Sell} {
+		for i := range s.Legs {
+			if s.Legs[i].Side != side {
+				continue
+			}
+			o := &order.Submit{
+				Exchange:  exch,
+				Pair:      s.Legs[i].Pair,
+				AssetType: a,
+				Side:      s.Legs[i].Side,
+				Type:      order.Market,
+				Amount:    amount * s.Legs[i].Ratio,
+				Price:     s.Legs[i].Price,
+			}
+			if o.Price > 0 {
+				o.Type = order.Limit
+			}
+			orders = append(orders, o)
+		}
+	}
+	return orders, nil
+}
+
+// Pair returns the pair the strategy's legs are stored as when traded together
+// as an asset.OptionCombo, e.g. BTC-27DEC24-60000-C_27DEC24-70000-C. Leg sides
+// and ratios are not part of the pair
+func (s *Strategy) Pair() (currency.Pair, error) {
+	if s == nil {
+		return currency.EMPTYPAIR, ErrNilStrategy
+	}
+	if len(s.Legs) == 0 {
+		return currency.EMPTYPAIR, fmt.Errorf("%w %s: %w %d", ErrInvalidStrategy, s.Type, errInvalidLegCount, 0)
+	}
+	combo := currency.Instrument{Kind: currency.InstrumentCombo, Legs: make([]currency.Instrument, len(s.Legs))}
+	for i := range s.Legs {
+		c := s.Legs[i].Contract
+		if c == nil {
+			var err error
+			if c, err = ParseContract(s.Legs[i].Pair); err != nil {
+				return currency.EMPTYPAIR, err
+			}
+		}
+		optionType := currency.OptionCall
+		if c.Type == Put {
+			optionType = currency.OptionPut
+		}
+		combo.Base = c.Underlying
+		combo.Legs[i] = currency.Instrument{
+			Kind:       currency.InstrumentOption,
+			Base:       c.Underlying,
+			Expiry:     c.Expiry,
+			Strike:     c.Strike,
+			OptionType: optionType,
+		}
+	}
+	return combo.Pair()
+}
+
+// NetPrice returns the net premium paid per unit of the strategy from the leg
+// prices, which is negative when the strategy is entered for a credit. Zero is
+// returned unless every leg is priced
+func (s *Strategy) NetPrice() float64 {
+	var net float64
+	for i := range s.Legs {
+		if s.Legs[i].Price <= 0 {
+			return 0
+		}
+		if s.Legs[i].Side == order.Buy {
+			net += s.Legs[i].Price * s.Legs[i].Ratio
+		} else {
+			net -= s.Legs[i].Price * s.Legs[i].Ratio
+		}
+	}
+	return net
+}
@@ -0,0 +1,164 @@
+package options
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func optionPair(quote string) currency.Pair {
+	return currency.NewPair(currency.BTC, currency.NewCode(quote))
+}
+
+func TestStringToStrategyType(t *testing.T) {
+	t.Parallel()
+	for _, s := range []StrategyType{CustomStrategy, VerticalSpread, Straddle, CalendarSpread} {
+		got, err := StringToStrategyType(s.String())
+		require.NoError(t, err, s.String())
+		assert.Equal(t, s, got, s.String())
+	}
+	_, err := StringToStrategyType("butterfly")
+	assert.ErrorIs(t, err, errUnsupportedStrategy)
+}
+
+func TestNewVertical(t *testing.T) {
+	t.Parallel()
+	s, err := NewVertical(optionPair("27DEC24-60000-C"), optionPair("27DEC24-70000-C"))
+	require.NoError(t, err)
+	assert.Equal(t, VerticalSpread, s.Type)
+	require.Len(t, s.Legs, 2)
+	assert.Equal(t, order.Buy, s.Legs[0].Side)
+	assert.Equal(t, order.Sell, s.Legs[1].Side)
+	assert.Equal(t, 1.0, s.Legs[0].Ratio, "ratio should default to one")
+	assert.Equal(t, 60000.0, s.Legs[0].Contract.Strike)
+
+	_, err = NewVertical(optionPair("27DEC24-60000-C"), optionPair("27DEC24-60000-C"))
+	assert.ErrorIs(t, err, errInvalidStrategyLegs, "same strike should error")
+	_, err = NewVertical(optionPair("27DEC24-60000-C"), optionPair("27DEC24-70000-P"))
+	assert.ErrorIs(t, err, errInvalidStrategyLegs, "mixed option types should error")
+	_, err = NewVertical(optionPair("27DEC24-60000-C"), currency.NewPair(currency.BTC, currency.USDT))
+	assert.ErrorIs(t, err, errInvalidContractName)
+}
+
+func TestNewStraddle(t *testing.T) {
+	t.Parallel()
+	s, err := NewStraddle(optionPair("27DEC24-60000-C"), optionPair("27DEC24-60000-P"), order.Short)
+	require.NoError(t, err)
+	assert.Equal(t, order.Sell, s.Legs[0].Side, "short should normalise to sell")
+	assert.Equal(t, order.Sell, s.Legs[1].Side, "short should normalise to sell")
+
+	_, err = NewStraddle(optionPair("27DEC24-60000-C"), optionPair("27DEC24-65000-P"), order.Buy)
+	assert.ErrorIs(t, err, errInvalidStrategyLegs, "different strikes should error")
+	_, err = NewStraddle(optionPair("27DEC24-60000-C"), optionPair("27DEC24-60000-P"), order.UnknownSide)
+	assert.ErrorIs(t, err, errInvalidLegSide)
+}
+
+func TestNewCalendar(t *testing.T) {
+	t.Parallel()
+	s, err := NewCalendar(optionPair("27DEC24-60000-C"), optionPair("28MAR25-60000-C"), order.Buy)
+	require.NoError(t, err)
+	assert.Equal(t, order.Sell, s.Legs[0].Side, "long calendar should sell the near contract")
+	assert.Equal(t, order.Buy, s.Legs[1].Side, "long calendar should buy the far contract")
+
+	s, err = NewCalendar(optionPair("27DEC24-60000-C"), optionPair("28MAR25-60000-C"), order.Sell)
+	require.NoError(t, err)
+	assert.Equal(t, order.Buy, s.Legs[0].Side, "short calendar should buy the near contract")
+
+	_, err = NewCalendar(optionPair("27DEC24-60000-C"), optionPair("27DEC24-60000-C"), order.Buy)
+	assert.ErrorIs(t, err, errInvalidStrategyLegs, "same expiry should error")
+}
+
+func TestNewStrategy(t *testing.T) {
+	t.Parallel()
+	_, err := NewStrategy(CustomStrategy, []Leg{{Pair: optionPair("27DEC24-60000-C"), Side: order.Buy}})
+	assert.ErrorIs(t, err, errInvalidLegCount)
+
+	_, err = NewStrategy(CustomStrategy, []Leg{
+		{Pair: optionPair("27DEC24-60000-C"), Side: order.Buy},
+		{Pair: optionPair("27DEC24-70000-C"), Side: order.Sell, Ratio: -1},
+	})
+	assert.ErrorIs(t, err, errInvalidLegRatio)
+
+	_, err = NewStrategy(CustomStrategy, []Leg{
+		{Pair: optionPair("27DEC24-60000-C"), Side: order.Buy},
+		{Pair: currency.NewPair(currency.ETH, currency.NewCode("27DEC24-3000-C")), Side: order.Sell},
+	})
+	assert.ErrorIs(t, err, errInvalidStrategyLegs, "different underlyings should error")
+
+	s, err := NewStrategy(CustomStrategy, []Leg{
+		{Pair: optionPair("27DEC24-60000-C"), Side: order.Buy},
+		{Pair: optionPair("27DEC24-70000-C"), Side: order.Sell, Ratio: 2},
+		{Pair: optionPair("27DEC24-80000-C"), Side: order.Buy},
+	})
+	require.NoError(t, err, "butterfly should be a valid custom strategy")
+	assert.Len(t, s.Legs, 3)
+
+	_, err = NewStrategy(UnsetStrategy, []Leg{
+		{Pair: optionPair("27DEC24-60000-C"), Side: order.Buy},
+		{Pair: optionPair("27DEC24-70000-C"), Side: order.Sell},
+	})
+	assert.ErrorIs(t, err, errUnsupportedStrategy)
+}
+
+func TestStrategyOrders(t *testing.T) {
+	t.Parallel()
+	var s *Strategy
+	_, err := s.Orders("test", asset.Options, 1)
+	assert.ErrorIs(t, err, ErrNilStrategy)
+
+	s, err = NewStrategy(CustomStrategy, []Leg{
+		{Pair: optionPair("27DEC24-70000-C"), Side: order.Sell, Ratio: 2, Price: 0.01},
+		{Pair: optionPair("27DEC24-60000-C"), Side: order.Buy},
+	})
+	require.NoError(t, err)
+	_, err = s.Orders("test", asset.Options, 0)
+	assert.ErrorIs(t, err, errInvalidStrategyAmount)
+
+	orders, err := s.Orders("test", asset.Options, 0.5)
+	require.NoError(t, err)
+	require.Len(t, orders, 2)
+	assert.Equal(t, order.Buy, orders[0].Side, "bought legs should be ordered first")
+	assert.Equal(t, order.Market, orders[0].Type, "unpriced legs should be market orders")
+	assert.Equal(t, 0.5, orders[0].Amount)
+	assert.Equal(t, order.Sell, orders[1].Side)
+	assert.Equal(t, order.Limit, orders[1].Type, "priced legs should be limit orders")
+	assert.Equal(t, 1.0, orders[1].Amount, "amount should be scaled by the leg ratio")
+	assert.Equal(t, asset.Options, orders[1].AssetType)
+}
+
+func TestNetPrice(t *testing.T) {
+	t.Parallel()
+	s, err := NewVertical(optionPair("27DEC24-60000-C"), optionPair("27DEC24-70000-C"))
+	require.NoError(t, err)
+	assert.Zero(t, s.NetPrice(), "unpriced legs should return zero")
+	s.Legs[0].Price = 0.05
+	s.Legs[1].Price = 0.02
+	assert.InDelta(t, 0.03, s.NetPrice(), 1e-9, "debit spread should have a positive net price")
+}
+
+func TestStrategyPair(t *testing.T) {
+	t.Parallel()
+	var s *Strategy
+	_, err := s.Pair()
+	assert.ErrorIs(t, err, ErrNilStrategy)
+	_, err = (&Strategy{}).Pair()
+	assert.ErrorIs(t, err, errInvalidLegCount)
+
+	s, err = NewStraddle(optionPair("27DEC24-60000-C"), optionPair("27DEC24-60000-P"), order.Buy)
+	require.NoError(t, err)
+	p, err := s.Pair()
+	require.NoError(t, err)
+	assert.Equal(t, "BTC-27DEC24-60000-C_27DEC24-60000-P", p.String())
+	assert.Equal(t, "27DEC24-60000-C_27DEC24-60000-P", p.Quote.String(), "the legs should be stored as the quote")
+
+	inst, err := currency.PairInstrument(p)
+	require.NoError(t, err)
+	assert.Equal(t, asset.OptionCombo, inst.Asset(), "strategy pairs should parse as option combos")
+
+	_, err = (&Strategy{Legs: []Leg{{Pair: optionPair("27DEC24")}}}).Pair()
+	assert.ErrorIs(t, err, errInvalidContractName, "legs should be parsed when their contract is unset")
+}
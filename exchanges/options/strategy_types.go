@@ -0,0 +1,84 @@
+package options
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// Strategy errors
+var (
+	ErrNilStrategy           = errors.New("option strategy is nil")
+	ErrInvalidStrategy       = errors.New("invalid option strategy")
+	errUnsupportedStrategy   = errors.New("unsupported option strategy")
+	errInvalidLegCount       = errors.New("invalid number of legs")
+	errInvalidLegSide        = errors.New("leg side must be buy or sell")
+	errInvalidLegRatio       = errors.New("leg ratio must be greater than zero")
+	errInvalidStrategyLegs   = errors.New("legs do not form the strategy")
+	errInvalidStrategyAmount = errors.New("strategy amount must be greater than zero")
+)
+
+// StrategyType is a multi-leg option structure
+type StrategyType uint8
+
+// Strategy types
+const (
+	UnsetStrategy StrategyType = iota
+	// CustomStrategy is any combination of option legs
+	CustomStrategy
+	// VerticalSpread buys and sells options of the same type and expiry at
+	// different strikes
+	VerticalSpread
+	// Straddle buys or sells a call and a put at the same strike and expiry
+	Straddle
+	// CalendarSpread buys and sells options of the same type and strike at
+	// different expiries
+	CalendarSpread
+)
+
+// Leg is an option contract traded as part of a strategy
+type Leg struct {
+	Pair currency.Pair
+	Side order.Side
+	// Ratio is the leg's quantity per unit of the strategy amount, which
+	// defaults to one
+	Ratio float64
+	// Price is the leg's limit price, a market order is placed when unset
+	Price    float64
+	Contract *Contract
+}
+
+// Strategy is a validated multi-leg option structure
+type Strategy struct {
+	Type StrategyType
+	Legs []Leg
+}
+
+// ComboOrder is a strategy submitted to an exchange as a single order, so all
+// legs are filled together or not at all
+type ComboOrder struct {
+	Exchange string
+	// Asset is the asset the combo is traded as, asset.OptionCombo
+	Asset asset.Item
+	// Pair is the combo's pair as returned by Strategy.Pair
+	Pair     currency.Pair
+	Strategy *Strategy
+	Amount   float64
+	// Price is the net limit price of the combination, a market order is
+	// placed when unset
+	Price         float64
+	ClientOrderID string
+}
+
+// ComboResponse holds the result of a submitted combo order
+type ComboResponse struct {
+	Exchange string
+	OrderID  string
+	// ComboID is the exchange's identifier for the combination of legs
+	ComboID string
+	Status  order.Status
+	Time    time.Time
+}
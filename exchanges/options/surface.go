@@ -0,0 +1,303 @@
+package options
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+// String returns the string representation of the interpolation method
+func (i InterpolationMethod) String() string {
+	switch i {
+	case SplineInterpolation:
+		return "spline"
+	case SVIInterpolation:
+		return "svi"
+	default:
+		return "unset"
+	}
+}
+
+// StringToInterpolationMethod converts a string to an interpolation method
+func StringToInterpolationMethod(s string) (InterpolationMethod, error) {
+	switch strings.ToLower(s) {
+	case "spline":
+		return SplineInterpolation, nil
+	case "svi":
+		return SVIInterpolation, nil
+	default:
+		return UnsetInterpolation, fmt.Errorf("%w %q", errInvalidInterpolation, s)
+	}
+}
+
+// NewSurface builds an implied volatility surface from quotes. Quotes which
+// have expired or lack a positive strike or volatility are ignored, as are
+// expiries with fewer than two strikes. SVI slices with fewer than five strikes
+// fall back to spline interpolation
+func NewSurface(underlying currency.Code, forward float64, quotes []Quote, method InterpolationMethod, now time.Time) (*Surface, error) {
+	if forward <= 0 {
+		return nil, errInvalidForward
+	}
+	if method != SplineInterpolation && method != SVIInterpolation {
+		return nil, fmt.Errorf("%w %s", errInvalidInterpolation, method)
+	}
+	byExpiry := make(map[int64][]Quote)
+	for i := range quotes {
+		if quotes[i].Strike <= 0 || quotes[i].Volatility <= 0 || !quotes[i].Expiry.After(now) {
+			continue
+		}
+		k := quotes[i].Expiry.UnixNano()
+		byExpiry[k] = append(byExpiry[k], quotes[i])
+	}
+	s := &Surface{
+		Underlying: underlying,
+		Forward:    forward,
+		Method:     method,
+		Built:      now,
+	}
+	for _, q := range byExpiry {
+		sl, err := newSlice(q, forward, method, now)
+		if err != nil {
+			continue
+		}
+		s.Slices = append(s.Slices, *sl)
+	}
+	if len(s.Slices) == 0 {
+		return nil, fmt.Errorf("%w %s", ErrNoSurfaceQuotes, underlying)
+	}
+	sort.Slice(s.Slices, func(i, j int) bool {
+		return s.Slices[i].Expiry.Before(s.Slices[j].Expiry)
+	})
+	return s, nil
+}
+
+// newSlice prepares a single expiry for interpolation. Quotes sharing a strike,
+// such as a call and put, are averaged
+func newSlice(quotes []Quote, forward float64, method InterpolationMethod, now time.Time) (*Slice, error) {
+	sort.Slice(quotes, func(i, j int) bool { return quotes[i].Strike < quotes[j].Strike })
+	merged := quotes[:0]
+	count := 1
+	for i := range quotes {
+		if len(merged) > 0 && merged[len(merged)-1].Strike == quotes[i].Strike {
+			last := &merged[len(merged)-1]
+			last.Volatility = (last.Volatility*float64(count) + quotes[i].Volatility) / float64(count+1)
+			count++
+			continue
+		}
+		merged = append(merged, quotes[i])
+		count = 1
+	}
+	if len(merged) < 2 {
+		return nil, errInsufficientSliceQuotes
+	}
+	sl := &Slice{
+		Expiry:        merged[0].Expiry,
+		TimeToExpiry:  TimeToExpiry(merged[0].Expiry, now),
+		Quotes:        merged,
+		logMoneyness:  make([]float64, len(merged)),
+		totalVariance: make([]float64, len(merged)),
+	}
+	for i := range merged {
+		sl.logMoneyness[i] = math.Log(merged[i].Strike / forward)
+		sl.totalVariance[i] = merged[i].Volatility * merged[i].Volatility * sl.TimeToExpiry
+	}
+	if method == SVIInterpolation && len(merged) >= sviParameterCount {
+		sl.SVI = fitSVI(sl.logMoneyness, sl.totalVariance)
+	} else {
+		sl.secondDerivatives = naturalSpline(sl.logMoneyness, sl.totalVariance)
+	}
+	return sl, nil
+}
+
+// ImpliedVolatility returns the interpolated implied volatility at a strike
+// and expiry. Expiries outside of the surface use the nearest slice's
+// volatility, and strikes outside of a spline slice use the nearest strike's
+func (s *Surface) ImpliedVolatility(strike float64, expiry time.Time) (float64, error) {
+	if strike <= 0 || expiry.IsZero() {
+		return 0, errInvalidTarget
+	}
+	if len(s.Slices) == 0 {
+		return 0, ErrNoSurfaceQuotes
+	}
+	t := TimeToExpiry(expiry, s.Built)
+	if t <= 0 {
+		return 0, errContractExpired
+	}
+	k := math.Log(strike / s.Forward)
+	i := sort.Search(len(s.Slices), func(i int) bool {
+		return s.Slices[i].TimeToExpiry >= t
+	})
+	var variance float64
+	switch {
+	case i == 0:
+		variance = s.Slices[0].totalVarianceAt(k) / s.Slices[0].TimeToExpiry * t
+	case i == len(s.Slices):
+		last := &s.Slices[len(s.Slices)-1]
+		variance = last.totalVarianceAt(k) / last.TimeToExpiry * t
+	default:
+		lower, upper := &s.Slices[i-1], &s.Slices[i]
+		weight := (t - lower.TimeToExpiry) / (upper.TimeToExpiry - lower.TimeToExpiry)
+		variance = lower.totalVarianceAt(k)*(1-weight) + upper.totalVarianceAt(k)*weight
+	}
+	if variance <= 0 {
+		return 0, errNonPositiveVariance
+	}
+	return math.Sqrt(variance / t), nil
+}
+
+// totalVarianceAt returns the slice's total variance at a log-moneyness
+func (sl *Slice) totalVarianceAt(k float64) float64 {
+	if sl.SVI != nil {
+		return sl.SVI.totalVariance(k)
+	}
+	x, y := sl.logMoneyness, sl.totalVariance
+	if k <= x[0] {
+		return y[0]
+	}
+	if k >= x[len(x)-1] {
+		return y[len(y)-1]
+	}
+	hi := sort.SearchFloat64s(x, k)
+	lo := hi - 1
+	h := x[hi] - x[lo]
+	a := (x[hi] - k) / h
+	b := (k - x[lo]) / h
+	return a*y[lo] + b*y[hi] + ((a*a*a-a)*sl.secondDerivatives[lo]+(b*b*b-b)*sl.secondDerivatives[hi])*h*h/6
+}
+
+// naturalSpline returns the second derivatives of a natural cubic spline
+// through the points, which must be sorted by x
+func naturalSpline(x, y []float64) []float64 {
+	n := len(x)
+	d2 := make([]float64, n)
+	u := make([]float64, n)
+	for i := 1; i < n-1; i++ {
+		sig := (x[i] - x[i-1]) / (x[i+1] - x[i-1])
+		p := sig*d2[i-1] + 2
+		d2[i] = (sig - 1) / p
+		u[i] = (y[i+1]-y[i])/(x[i+1]-x[i]) - (y[i]-y[i-1])/(x[i]-x[i-1])
+		u[i] = (6*u[i]/(x[i+1]-x[i-1]) - sig*u[i-1]) / p
+	}
+	for i := n - 2; i >= 0; i-- {
+		d2[i] = d2[i]*d2[i+1] + u[i]
+	}
+	return d2
+}
+
+// totalVariance returns the SVI total variance at a log-moneyness
+func (p *SVIParams) totalVariance(k float64) float64 {
+	d := k - p.M
+	return p.A + p.B*(p.Rho*d+math.Sqrt(d*d+p.Sigma*p.Sigma))
+}
+
+// fitSVI calibrates raw SVI parameters to total variances by least squares
+// using a Nelder-Mead search. Parameters which would allow arbitrage in the
+// wings or negative variance are penalised
+func fitSVI(k, w []float64) *SVIParams {
+	minW, maxW := slices.Min(w), slices.Max(w)
+	minK, maxK := slices.Min(k), slices.Max(k)
+	spread := math.Max(maxK-minK, 1e-4)
+	loss := func(x []float64) float64 {
+		p := SVIParams{A: x[0], B: x[1], Rho: x[2], M: x[3], Sigma: x[4]}
+		if p.B < 0 || p.Sigma <= 0 || math.Abs(p.Rho) >= 1 || p.A+p.B*p.Sigma*math.Sqrt(1-p.Rho*p.Rho) < 0 {
+			return math.Inf(1)
+		}
+		var sum float64
+		for i := range k {
+			diff := p.totalVariance(k[i]) - w[i]
+			sum += diff * diff
+		}
+		return sum
+	}
+	best := nelderMead(loss, []float64{minW / 2, (maxW - minW) / spread, 0, 0, spread / 2})
+	p := &SVIParams{A: best[0], B: best[1], Rho: best[2], M: best[3], Sigma: best[4]}
+	var sum float64
+	for i := range k {
+		diff := p.totalVariance(k[i]) - w[i]
+		sum += diff * diff
+	}
+	p.RMSE = math.Sqrt(sum / float64(len(k)))
+	return p
+}
+
+// nelderMead minimises f from a starting point using the downhill simplex
+// method
+func nelderMead(f func([]float64) float64, start []float64) []float64 {
+	n := len(start)
+	simplex := make([][]float64, n+1)
+	values := make([]float64, n+1)
+	for i := range simplex {
+		simplex[i] = slices.Clone(start)
+		if i > 0 {
+			step := math.Abs(start[i-1]) * 0.1
+			if step == 0 {
+				step = 0.01
+			}
+			simplex[i][i-1] += step
+		}
+		values[i] = f(simplex[i])
+	}
+	point := func(centroid, towards []float64, scale float64) []float64 {
+		p := make([]float64, n)
+		for i := range p {
+			p[i] = centroid[i] + scale*(towards[i]-centroid[i])
+		}
+		return p
+	}
+	for range sviMaxIterations {
+		order := make([]int, n+1)
+		for i := range order {
+			order[i] = i
+		}
+		sort.Slice(order, func(i, j int) bool { return values[order[i]] < values[order[j]] })
+		sorted, sortedValues := make([][]float64, n+1), make([]float64, n+1)
+		for i, o := range order {
+			sorted[i], sortedValues[i] = simplex[o], values[o]
+		}
+		simplex, values = sorted, sortedValues
+		if math.Abs(values[n]-values[0]) < sviTolerance {
+			break
+		}
+		centroid := make([]float64, n)
+		for i := range n {
+			for j := range n {
+				centroid[j] += simplex[i][j] / float64(n)
+			}
+		}
+		reflected := point(centroid, simplex[n], -1)
+		reflectedValue := f(reflected)
+		switch {
+		case reflectedValue < values[0]:
+			expanded := point(centroid, simplex[n], -2)
+			if expandedValue := f(expanded); expandedValue < reflectedValue {
+				simplex[n], values[n] = expanded, expandedValue
+			} else {
+				simplex[n], values[n] = reflected, reflectedValue
+			}
+		case reflectedValue < values[n-1]:
+			simplex[n], values[n] = reflected, reflectedValue
+		default:
+			contracted := point(centroid, simplex[n], 0.5)
+			if contractedValue := f(contracted); contractedValue < values[n] {
+				simplex[n], values[n] = contracted, contractedValue
+				continue
+			}
+			for i := 1; i <= n; i++ {
+				simplex[i] = point(simplex[0], simplex[i], 0.5)
+				values[i] = f(simplex[i])
+			}
+		}
+	}
+	bestIdx := 0
+	for i := range values {
+		if values[i] < values[bestIdx] {
+			bestIdx = i
+		}
+	}
+	return simplex[bestIdx]
+}
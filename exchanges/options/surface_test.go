@@ -0,0 +1,109 @@
+package options
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+// smile returns a volatility which rises away from the forward and with expiry
+func smile(strike, forward float64, years float64) float64 {
+	k := math.Log(strike / forward)
+	return 0.5 + 0.4*k*k - 0.1*k + 0.05*years
+}
+
+func testQuotes(forward float64, now time.Time) []Quote {
+	var quotes []Quote
+	for _, days := range []int{30, 90} {
+		expiry := now.AddDate(0, 0, days)
+		for _, strike := range []float64{30000, 40000, 50000, 60000, 70000, 80000} {
+			quotes = append(quotes, Quote{Strike: strike, Expiry: expiry, Volatility: smile(strike, forward, TimeToExpiry(expiry, now))})
+		}
+	}
+	return quotes
+}
+
+func TestStringToInterpolationMethod(t *testing.T) {
+	t.Parallel()
+	m, err := StringToInterpolationMethod("SVI")
+	require.NoError(t, err)
+	assert.Equal(t, SVIInterpolation, m)
+	m, err = StringToInterpolationMethod("spline")
+	require.NoError(t, err)
+	assert.Equal(t, SplineInterpolation, m)
+	_, err = StringToInterpolationMethod("linear")
+	assert.ErrorIs(t, err, errInvalidInterpolation)
+}
+
+func TestNewSurface(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, err := NewSurface(currency.BTC, 0, nil, SplineInterpolation, now)
+	assert.ErrorIs(t, err, errInvalidForward)
+	_, err = NewSurface(currency.BTC, 50000, nil, UnsetInterpolation, now)
+	assert.ErrorIs(t, err, errInvalidInterpolation)
+	_, err = NewSurface(currency.BTC, 50000, []Quote{{Strike: 50000, Expiry: now.Add(time.Hour), Volatility: 0.5}}, SplineInterpolation, now)
+	assert.ErrorIs(t, err, ErrNoSurfaceQuotes, "single strike expiries should be ignored")
+
+	quotes := testQuotes(50000, now)
+	quotes = append(quotes,
+		Quote{Strike: 50000, Expiry: now.Add(-time.Hour), Volatility: 0.5},
+		Quote{Strike: 50000, Expiry: now.AddDate(0, 0, 30), Volatility: smile(50000, 50000, TimeToExpiry(now.AddDate(0, 0, 30), now))},
+	)
+	s, err := NewSurface(currency.BTC, 50000, quotes, SplineInterpolation, now)
+	require.NoError(t, err)
+	require.Len(t, s.Slices, 2, "expired quotes should be ignored")
+	assert.True(t, s.Slices[0].Expiry.Before(s.Slices[1].Expiry), "slices should be sorted by expiry")
+	assert.Len(t, s.Slices[0].Quotes, 6, "quotes sharing a strike should be merged")
+	assert.Nil(t, s.Slices[0].SVI)
+}
+
+func TestSurfaceImpliedVolatility(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, method := range []InterpolationMethod{SplineInterpolation, SVIInterpolation} {
+		s, err := NewSurface(currency.BTC, 50000, testQuotes(50000, now), method, now)
+		require.NoError(t, err, method.String())
+		if method == SVIInterpolation {
+			require.NotNil(t, s.Slices[0].SVI, "SVI slices should be calibrated")
+			assert.Less(t, s.Slices[0].SVI.RMSE, 1e-3, "SVI fit should be close")
+		}
+
+		expiry := now.AddDate(0, 0, 30)
+		iv, err := s.ImpliedVolatility(60000, expiry)
+		require.NoError(t, err, method.String())
+		assert.InDelta(t, smile(60000, 50000, TimeToExpiry(expiry, now)), iv, 0.01, "quoted strike should be recovered")
+
+		iv, err = s.ImpliedVolatility(55000, expiry)
+		require.NoError(t, err, method.String())
+		assert.InDelta(t, smile(55000, 50000, TimeToExpiry(expiry, now)), iv, 0.01, "strikes should be interpolated")
+
+		between := now.AddDate(0, 0, 60)
+		iv, err = s.ImpliedVolatility(50000, between)
+		require.NoError(t, err, method.String())
+		assert.Greater(t, iv, smile(50000, 50000, TimeToExpiry(expiry, now)), "expiries should be interpolated in total variance")
+		assert.Less(t, iv, smile(50000, 50000, TimeToExpiry(now.AddDate(0, 0, 90), now)), "expiries should be interpolated in total variance")
+
+		iv, err = s.ImpliedVolatility(50000, now.AddDate(0, 0, 7))
+		require.NoError(t, err, method.String())
+		near, err := s.ImpliedVolatility(50000, expiry)
+		require.NoError(t, err, method.String())
+		assert.InDelta(t, near, iv, 1e-9, "short expiries should use the nearest slice's volatility")
+	}
+
+	s, err := NewSurface(currency.BTC, 50000, testQuotes(50000, now), SplineInterpolation, now)
+	require.NoError(t, err)
+	_, err = s.ImpliedVolatility(0, now.AddDate(0, 0, 30))
+	assert.ErrorIs(t, err, errInvalidTarget)
+	_, err = s.ImpliedVolatility(50000, now.Add(-time.Hour))
+	assert.ErrorIs(t, err, errContractExpired)
+	wing, err := s.ImpliedVolatility(1000, now.AddDate(0, 0, 30))
+	require.NoError(t, err)
+	edge, err := s.ImpliedVolatility(30000, now.AddDate(0, 0, 30))
+	require.NoError(t, err)
+	assert.InDelta(t, edge, wing, 1e-9, "spline wings should be flat")
+}
@@ -0,0 +1,89 @@
+package options
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+// sviParameterCount is the number of raw SVI parameters and the minimum number
+// of quotes an expiry needs for a fit, slices with fewer quotes are splined
+const sviParameterCount = 5
+
+// SVI calibration search settings
+const (
+	sviMaxIterations = 2000
+	sviTolerance     = 1e-12
+)
+
+// Surface errors
+var (
+	ErrNoSurfaceQuotes         = errors.New("no quotes to build surface")
+	errInvalidForward          = errors.New("forward price must be greater than zero")
+	errInvalidInterpolation    = errors.New("invalid volatility interpolation method")
+	errInvalidTarget           = errors.New("strike and expiry must be set")
+	errInsufficientSliceQuotes = errors.New("expiry requires at least two strikes")
+	errNonPositiveVariance     = errors.New("interpolated variance is not positive")
+)
+
+// InterpolationMethod is how volatility is interpolated between strikes of an
+// expiry slice
+type InterpolationMethod uint8
+
+// Interpolation methods
+const (
+	UnsetInterpolation InterpolationMethod = iota
+	// SplineInterpolation fits a natural cubic spline through total variance
+	// by log-moneyness
+	SplineInterpolation
+	// SVIInterpolation calibrates the raw stochastic volatility inspired
+	// parameterisation to each expiry's total variance
+	SVIInterpolation
+)
+
+// Quote is an implied volatility observed for an option contract
+type Quote struct {
+	Strike float64
+	Expiry time.Time
+	// Volatility is annualised, where 0.5 is 50%
+	Volatility float64
+}
+
+// Surface is an implied volatility surface for an underlying. Volatility is
+// interpolated within an expiry by strike using the surface's method, and
+// linearly in total variance between expiries
+type Surface struct {
+	Underlying currency.Code
+	Forward    float64
+	Method     InterpolationMethod
+	Built      time.Time
+	Slices     []Slice
+}
+
+// Slice holds the quotes of a single expiry
+type Slice struct {
+	Expiry time.Time
+	// TimeToExpiry is in years from when the surface was built
+	TimeToExpiry float64
+	Quotes       []Quote
+	// SVI holds the calibrated parameters when the slice is fitted with SVI
+	SVI *SVIParams
+	// logMoneyness and totalVariance are sorted by log-moneyness
+	logMoneyness  []float64
+	totalVariance []float64
+	// secondDerivatives are the natural cubic spline coefficients
+	secondDerivatives []float64
+}
+
+// SVIParams are the raw SVI parameters where total variance at log-moneyness k
+// is A + B(Rho(k-M) + sqrt((k-M)^2 + Sigma^2))
+type SVIParams struct {
+	A     float64
+	B     float64
+	Rho   float64
+	M     float64
+	Sigma float64
+	// RMSE is the root mean squared error of the fit in total variance
+	RMSE float64
+}
@@ -57,6 +57,11 @@ var (
 	errInvalidQuoteLevels  = errors.New("invalid quote levels, cannot load limits")
 )
 
+// incrementTolerance is the fraction of a value within which it is treated as
+// off its increment by float rounding error. float64 values are precise to
+// around 1e-16, leaving room for error accumulated by arithmetic
+var incrementTolerance = decimal.New(1, -12)
+
 // ExecutionLimits defines minimum and maximum values in relation to
 // order size, order pricing, total notional values, total maximum orders etc
 // for execution on an exchange.
@@ -225,28 +230,32 @@ func (e *ExecutionLimits) CheckOrderExecutionLimits(a asset.Item, cp currency.Pa
 
 // Conforms checks outbound parameters
 func (m *MinMaxLevel) Conforms(price, amount float64, orderType Type) error {
+	return m.ConformsDecimal(decimal.NewFromFloat(price), decimal.NewFromFloat(amount), orderType)
+}
+
+// ConformsDecimal checks outbound parameters using decimal arithmetic, so that
+// precise step increments are not rejected by float rounding
+func (m *MinMaxLevel) ConformsDecimal(price, amount decimal.Decimal, orderType Type) error {
 	// TODO: Update to take in account Quote amounts as well as Base amounts.
 	if m == nil {
 		return nil
 	}
 
-	if m.MinimumBaseAmount != 0 && amount < m.MinimumBaseAmount {
-		return fmt.Errorf("%w min: %.8f supplied %.8f",
+	if m.MinimumBaseAmount != 0 && amount.LessThan(decimal.NewFromFloat(m.MinimumBaseAmount)) {
+		return fmt.Errorf("%w min: %v supplied %v",
 			ErrAmountBelowMin,
 			m.MinimumBaseAmount,
 			amount)
 	}
-	if m.MaximumBaseAmount != 0 && amount > m.MaximumBaseAmount {
-		return fmt.Errorf("%w min: %.8f supplied %.8f",
+	if m.MaximumBaseAmount != 0 && amount.GreaterThan(decimal.NewFromFloat(m.MaximumBaseAmount)) {
+		return fmt.Errorf("%w min: %v supplied %v",
 			ErrAmountExceedsMax,
 			m.MaximumBaseAmount,
 			amount)
 	}
 	if m.AmountStepIncrementSize != 0 {
-		dAmount := decimal.NewFromFloat(amount)
-		dStep := decimal.NewFromFloat(m.AmountStepIncrementSize)
-		if !dAmount.Mod(dStep).IsZero() {
-			return fmt.Errorf("%w stepSize: %.8f supplied %.8f",
+		if !amount.Mod(decimal.NewFromFloat(m.AmountStepIncrementSize)).IsZero() {
+			return fmt.Errorf("%w stepSize: %v supplied %v",
 				ErrAmountExceedsStep,
 				m.AmountStepIncrementSize,
 				amount)
@@ -271,30 +280,31 @@ func (m *MinMaxLevel) Conforms(price, amount float64, orderType Type) error {
 
 	// If order type is Market we do not need to do price checks
 	if orderType != Market {
-		if m.MinPrice != 0 && price < m.MinPrice {
-			return fmt.Errorf("%w min: %.8f supplied %.8f",
+		if m.MinPrice != 0 && price.LessThan(decimal.NewFromFloat(m.MinPrice)) {
+			return fmt.Errorf("%w min: %v supplied %v",
 				ErrPriceBelowMin,
 				m.MinPrice,
 				price)
 		}
-		if m.MaxPrice != 0 && price > m.MaxPrice {
-			return fmt.Errorf("%w max: %.8f supplied %.8f",
+		if m.MaxPrice != 0 && price.GreaterThan(decimal.NewFromFloat(m.MaxPrice)) {
+			return fmt.Errorf("%w max: %v supplied %v",
 				ErrPriceExceedsMax,
 				m.MaxPrice,
 				price)
 		}
-		if m.MinNotional != 0 && (amount*price) < m.MinNotional {
-			return fmt.Errorf("%w minimum notional: %.8f value of order %.8f",
-				ErrNotionalValue,
-				m.MinNotional,
-				amount*price)
+		if m.MinNotional != 0 {
+			if notional := amount.Mul(price); notional.LessThan(decimal.NewFromFloat(m.MinNotional)) {
+				return fmt.Errorf("%w minimum notional: %v value of order %v",
+					ErrNotionalValue,
+					m.MinNotional,
+					notional)
+			}
 		}
 		if m.PriceStepIncrementSize != 0 {
-			dPrice := decimal.NewFromFloat(price)
 			dMinPrice := decimal.NewFromFloat(m.MinPrice)
 			dStep := decimal.NewFromFloat(m.PriceStepIncrementSize)
-			if !dPrice.Sub(dMinPrice).Mod(dStep).IsZero() {
-				return fmt.Errorf("%w stepSize: %.8f supplied %.8f",
+			if !price.Sub(dMinPrice).Mod(dStep).IsZero() {
+				return fmt.Errorf("%w stepSize: %v supplied %v",
 					ErrPriceExceedsStep,
 					m.PriceStepIncrementSize,
 					price)
@@ -305,27 +315,26 @@ func (m *MinMaxLevel) Conforms(price, amount float64, orderType Type) error {
 
 	if m.MarketMinQty != 0 &&
 		m.MinimumBaseAmount < m.MarketMinQty &&
-		amount < m.MarketMinQty {
-		return fmt.Errorf("%w min: %.8f supplied %.8f",
+		amount.LessThan(decimal.NewFromFloat(m.MarketMinQty)) {
+		return fmt.Errorf("%w min: %v supplied %v",
 			ErrMarketAmountBelowMin,
 			m.MarketMinQty,
 			amount)
 	}
 	if m.MarketMaxQty != 0 &&
 		m.MaximumBaseAmount > m.MarketMaxQty &&
-		amount > m.MarketMaxQty {
-		return fmt.Errorf("%w max: %.8f supplied %.8f",
+		amount.GreaterThan(decimal.NewFromFloat(m.MarketMaxQty)) {
+		return fmt.Errorf("%w max: %v supplied %v",
 			ErrMarketAmountExceedsMax,
 			m.MarketMaxQty,
 			amount)
 	}
 	if m.MarketStepIncrementSize != 0 &&
 		m.AmountStepIncrementSize != m.MarketStepIncrementSize {
-		dAmount := decimal.NewFromFloat(amount)
 		dMinMAmount := decimal.NewFromFloat(m.MarketMinQty)
 		dStep := decimal.NewFromFloat(m.MarketStepIncrementSize)
-		if !dAmount.Sub(dMinMAmount).Mod(dStep).IsZero() {
-			return fmt.Errorf("%w stepSize: %.8f supplied %.8f",
+		if !amount.Sub(dMinMAmount).Mod(dStep).IsZero() {
+			return fmt.Errorf("%w stepSize: %v supplied %v",
 				ErrMarketAmountExceedsStep,
 				m.MarketStepIncrementSize,
 				amount)
@@ -334,6 +343,51 @@ func (m *MinMaxLevel) Conforms(price, amount float64, orderType Type) error {
 	return nil
 }
 
+// ConformToDecimalPrice snaps a price onto its tick increment when it is off
+// the increment by no more than float rounding error. Prices which are off the
+// increment by more are returned unchanged, as rounding them would alter the
+// order, and are rejected by ConformsDecimal
+func (m *MinMaxLevel) ConformToDecimalPrice(price decimal.Decimal) decimal.Decimal {
+	if m == nil {
+		return price
+	}
+	return snapToIncrement(price, decimal.NewFromFloat(m.MinPrice), decimal.NewFromFloat(m.PriceStepIncrementSize))
+}
+
+// ConformToDecimalOrderAmount snaps an amount onto the step increment for the
+// order type when it is off the increment by no more than float rounding
+// error. Unlike ConformToDecimalAmount it does not floor amounts which are
+// further off the increment
+func (m *MinMaxLevel) ConformToDecimalOrderAmount(amount decimal.Decimal, orderType Type) decimal.Decimal {
+	if m == nil {
+		return amount
+	}
+	if orderType == Market && m.MarketStepIncrementSize != 0 && m.AmountStepIncrementSize != m.MarketStepIncrementSize {
+		amount = snapToIncrement(amount, decimal.NewFromFloat(m.MarketMinQty), decimal.NewFromFloat(m.MarketStepIncrementSize))
+	}
+	return snapToIncrement(amount, decimal.Zero, decimal.NewFromFloat(m.AmountStepIncrementSize))
+}
+
+// snapToIncrement rounds a value to the nearest multiple of step from offset
+// when it is within incrementTolerance of it
+func snapToIncrement(value, offset, step decimal.Decimal) decimal.Decimal {
+	if !step.IsPositive() {
+		return value
+	}
+	rem := value.Sub(offset).Mod(step)
+	if rem.IsZero() {
+		return value
+	}
+	tolerance := value.Abs().Mul(incrementTolerance)
+	switch {
+	case rem.LessThanOrEqual(tolerance):
+		return value.Sub(rem)
+	case step.Sub(rem).LessThanOrEqual(tolerance):
+		return value.Sub(rem).Add(step)
+	}
+	return value
+}
+
 // ConformToDecimalAmount (POC) conforms amount to its amount interval
 func (m *MinMaxLevel) ConformToDecimalAmount(amount decimal.Decimal) decimal.Decimal {
 	if m == nil {
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 )
@@ -378,6 +379,50 @@ func TestConformToDecimalAmount(t *testing.T) {
 	}
 }
 
+func TestConformsDecimal(t *testing.T) {
+	t.Parallel()
+	var nilLevel *MinMaxLevel
+	assert.NoError(t, nilLevel.ConformsDecimal(decimal.Zero, decimal.Zero, Limit))
+
+	tenth, fifth := 0.1, 0.2 // Variables so that arithmetic is not folded into exact constants
+	tt := MinMaxLevel{PriceStepIncrementSize: 0.1, AmountStepIncrementSize: 0.00000001, MinNotional: 10}
+	assert.NoError(t, tt.ConformsDecimal(decimal.RequireFromString("123456.7"), decimal.RequireFromString("0.12345678"), Limit))
+	assert.ErrorIs(t, tt.ConformsDecimal(decimal.RequireFromString("123456.75"), decimal.RequireFromString("0.12345678"), Limit), ErrPriceExceedsStep)
+	assert.ErrorIs(t, tt.ConformsDecimal(decimal.RequireFromString("123456.7"), decimal.RequireFromString("0.123456789"), Limit), ErrAmountExceedsStep)
+	assert.ErrorIs(t, tt.ConformsDecimal(decimal.RequireFromString("1"), decimal.RequireFromString("1"), Limit), ErrNotionalValue)
+	assert.ErrorIs(t, tt.Conforms(tenth+fifth, 100, Limit), ErrPriceExceedsStep, "float rounding error should be rejected without conforming")
+}
+
+func TestConformToDecimalPrice(t *testing.T) {
+	t.Parallel()
+	tenth, fifth, seven := 0.1, 0.2, 7.0 // Variables so that arithmetic is not folded into exact constants
+	var nilLevel *MinMaxLevel
+	assert.True(t, nilLevel.ConformToDecimalPrice(decimal.NewFromFloat(tenth+fifth)).Equal(decimal.NewFromFloat(tenth+fifth)))
+
+	tt := MinMaxLevel{PriceStepIncrementSize: 0.1}
+	assert.Equal(t, "0.3", tt.ConformToDecimalPrice(decimal.NewFromFloat(tenth+fifth)).String(), "float rounding error above an increment should be snapped")
+	assert.Equal(t, "0.7", tt.ConformToDecimalPrice(decimal.NewFromFloat(tenth*seven)).String(), "float rounding error below an increment should be snapped")
+	assert.Equal(t, "0.35", tt.ConformToDecimalPrice(decimal.RequireFromString("0.35")).String(), "prices off an increment should not be rounded")
+
+	tt = MinMaxLevel{MinPrice: 0.05, PriceStepIncrementSize: 0.1}
+	assert.Equal(t, "0.35", tt.ConformToDecimalPrice(decimal.NewFromFloat(0.25+tenth)).String(), "increments should be offset from the minimum price")
+}
+
+func TestConformToDecimalOrderAmount(t *testing.T) {
+	t.Parallel()
+	var nilLevel *MinMaxLevel
+	assert.True(t, nilLevel.ConformToDecimalOrderAmount(decimal.NewFromFloat(1.1), Limit).Equal(decimal.NewFromFloat(1.1)))
+
+	thousandth, three := 0.001, 3.0 // Variables so that arithmetic is not folded into exact constants
+	tt := MinMaxLevel{AmountStepIncrementSize: 0.001}
+	assert.Equal(t, "0.003", tt.ConformToDecimalOrderAmount(decimal.NewFromFloat(thousandth*three), Limit).String())
+	assert.Equal(t, "0.0035", tt.ConformToDecimalOrderAmount(decimal.RequireFromString("0.0035"), Limit).String(), "amounts off an increment should not be floored")
+
+	tt.MarketMinQty = 0.0005
+	tt.MarketStepIncrementSize = 0.01
+	assert.Equal(t, "0.0305", tt.ConformToDecimalOrderAmount(decimal.NewFromFloat(0.0005+thousandth*30), Market).String(), "market orders should use the market increment")
+}
+
 func TestConformToAmount(t *testing.T) {
 	t.Parallel()
 	var tt MinMaxLevel
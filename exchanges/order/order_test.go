@@ -12,6 +12,7 @@ import (
 
 	"github.com/gofrs/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
@@ -64,13 +65,69 @@ func TestSubmit_Validate(t *testing.T) {
 		{
 			ExpectedErr: errTimeInForceConflict,
 			Submit: &Submit{
-				Exchange:          "test",
-				Pair:              testPair,
-				AssetType:         asset.Spot,
-				Side:              Ask,
-				Type:              Market,
-				ImmediateOrCancel: true,
-				FillOrKill:        true,
+				Exchange:    "test",
+				Pair:        testPair,
+				AssetType:   asset.Spot,
+				Side:        Ask,
+				Type:        Market,
+				TimeInForce: IOC | FOK,
+			},
+		},
+		{
+			ExpectedErr: errUnrecognisedTimeInForce,
+			Submit: &Submit{
+				Exchange:    "test",
+				Pair:        testPair,
+				AssetType:   asset.Spot,
+				Side:        Ask,
+				Type:        Market,
+				TimeInForce: 128,
+			},
+		},
+		{
+			ExpectedErr: errUnrecognisedSTP,
+			Submit: &Submit{
+				Exchange:            "test",
+				Pair:                testPair,
+				AssetType:           asset.Spot,
+				Side:                Ask,
+				Type:                Market,
+				SelfTradePrevention: STPCancelMaker | STPCancelTaker,
+			},
+		},
+		{
+			ExpectedErr: errExpiryRequired,
+			Submit: &Submit{
+				Exchange:    "test",
+				Pair:        testPair,
+				AssetType:   asset.Spot,
+				Side:        Ask,
+				Type:        Limit,
+				TimeInForce: GTD,
+			},
+		},
+		{
+			ExpectedErr: errExpiryNotAllowed,
+			Submit: &Submit{
+				Exchange:    "test",
+				Pair:        testPair,
+				AssetType:   asset.Spot,
+				Side:        Ask,
+				Type:        Limit,
+				TimeInForce: GTC,
+				Expiry:      time.Now().Add(time.Hour),
+			},
+		},
+		{
+			ExpectedErr: errTimeInForceConflict,
+			Submit: &Submit{
+				Exchange:    "test",
+				Pair:        testPair,
+				AssetType:   asset.Spot,
+				Side:        Ask,
+				Type:        Limit,
+				TimeInForce: IOC,
+				PostOnly:    true,
 			},
 		},
 		{
@@ -152,6 +209,44 @@ func TestSubmit_Validate(t *testing.T) {
 				AssetType: asset.Spot,
 			},
 		}, // valid pair, order side, type, amount but invalid price
+		{
+			ExpectedErr: errInvalidDisplayAmount,
+			Submit: &Submit{
+				Exchange:      "test",
+				Pair:          testPair,
+				Side:          Ask,
+				Type:          Limit,
+				Amount:        1,
+				Price:         1000,
+				DisplayAmount: 1,
+				AssetType:     asset.Spot,
+			},
+		}, // display amount must be less than amount
+		{
+			ExpectedErr: errInvalidDisplayAmount,
+			Submit: &Submit{
+				Exchange:      "test",
+				Pair:          testPair,
+				Side:          Ask,
+				Type:          Limit,
+				Amount:        1,
+				Price:         1000,
+				DisplayAmount: -0.1,
+				AssetType:     asset.Spot,
+			},
+		}, // negative display amount
+		{
+			ExpectedErr: errInvalidDisplayAmount,
+			Submit: &Submit{
+				Exchange:      "test",
+				Pair:          testPair,
+				Side:          Ask,
+				Type:          Market,
+				Amount:        1,
+				DisplayAmount: 0.1,
+				AssetType:     asset.Spot,
+			},
+		}, // display amount on a market order
 		{
 			ExpectedErr: errValidationCheckFailed,
 			Submit: &Submit{
@@ -188,6 +283,106 @@ func TestSubmit_Validate(t *testing.T) {
 	}
 }
 
+func TestOCOSubmitValidate(t *testing.T) {
+	t.Parallel()
+	var o *OCOSubmit
+	assert.ErrorIs(t, o.Validate(), ErrOCOIsNil)
+	o = &OCOSubmit{Exchange: "test", Pair: currency.NewPair(currency.BTC, currency.USDT), AssetType: asset.Spot, Side: Sell, Amount: 1}
+	assert.ErrorIs(t, o.Validate(), ErrPriceMustBeSetIfLimitOrder)
+	o.Price, o.TriggerPrice = 90, 100
+	assert.ErrorIs(t, o.Validate(), ErrOCOPricesInvalid, "selling must place the limit price above the trigger price")
+	o.Price, o.TriggerPrice = 110, 100
+	assert.NoError(t, o.Validate())
+	o.Side = Buy
+	assert.ErrorIs(t, o.Validate(), ErrOCOPricesInvalid, "buying must place the limit price below the trigger price")
+	o.Price, o.TriggerPrice = 90, 100
+	assert.NoError(t, o.Validate())
+	o.Amount = 0
+	assert.ErrorIs(t, o.Validate(), ErrAmountIsInvalid)
+}
+
+func TestOCOSubmitLegs(t *testing.T) {
+	t.Parallel()
+	o := &OCOSubmit{Exchange: "test", Pair: currency.NewPair(currency.BTC, currency.USDT), AssetType: asset.Spot, Side: Sell, Amount: 1, Price: 110, TriggerPrice: 100, StrategyTag: "tp"}
+	limit, stop := o.Legs()
+	assert.Equal(t, Limit, limit.Type)
+	assert.Equal(t, 110.0, limit.Price)
+	assert.Equal(t, Stop, stop.Type, "the stop leg should be a stop market order without a stop limit price")
+	assert.Equal(t, 100.0, stop.TriggerPrice)
+	assert.Zero(t, stop.Price)
+	assert.Equal(t, "tp", stop.StrategyTag)
+	assert.Empty(t, limit.ClientOrderID)
+
+	o.StopLimitPrice, o.ClientOrderID = 99, "list"
+	limit, stop = o.Legs()
+	assert.Equal(t, StopLimit, stop.Type)
+	assert.Equal(t, 99.0, stop.Price)
+	assert.Equal(t, "list-limit", limit.ClientOrderID)
+	assert.Equal(t, "list-stop", stop.ClientOrderID)
+}
+
+func TestTrailingStopSubmitValidate(t *testing.T) {
+	t.Parallel()
+	var ts *TrailingStopSubmit
+	assert.ErrorIs(t, ts.Validate(), ErrTrailingStopIsNil)
+	ts = &TrailingStopSubmit{Exchange: "test", Pair: currency.NewPair(currency.BTC, currency.USDT), AssetType: asset.Spot, Side: Sell, Amount: 1}
+	assert.ErrorIs(t, ts.Validate(), ErrTrailInvalid, "a trail must be set")
+	ts.TrailAmount, ts.TrailPercent = 10, 1
+	assert.ErrorIs(t, ts.Validate(), ErrTrailInvalid, "only one trail may be set")
+	ts.TrailAmount, ts.TrailPercent = 0, 100
+	assert.ErrorIs(t, ts.Validate(), ErrTrailInvalid)
+	ts.TrailPercent = 5
+	assert.NoError(t, ts.Validate())
+	ts.LimitOffset = -1
+	assert.ErrorIs(t, ts.Validate(), ErrPriceMustBeSetIfLimitOrder)
+	ts.LimitOffset = 1
+	assert.NoError(t, ts.Validate())
+	ts.Amount = 0
+	assert.ErrorIs(t, ts.Validate(), ErrAmountIsInvalid)
+}
+
+func TestTrailingStopSubmitPrices(t *testing.T) {
+	t.Parallel()
+	ts := &TrailingStopSubmit{Side: Sell, TrailAmount: 10}
+	assert.Equal(t, 90.0, ts.StopPrice(100))
+	assert.True(t, ts.Improves(101, 100))
+	assert.False(t, ts.Improves(99, 100))
+	assert.True(t, ts.Breached(90, 90))
+	assert.False(t, ts.Breached(91, 90))
+
+	ts = &TrailingStopSubmit{Side: Buy, TrailPercent: 5}
+	assert.Equal(t, 105.0, ts.StopPrice(100))
+	assert.True(t, ts.Improves(99, 100))
+	assert.False(t, ts.Improves(101, 100))
+	assert.True(t, ts.Breached(106, 105))
+	assert.False(t, ts.Breached(104, 105))
+
+	ts = &TrailingStopSubmit{Side: Sell, TrailAmount: 0.1}
+	assert.Equal(t, 0.2, ts.StopPrice(0.3), "stop prices should not carry float rounding error")
+	ts = &TrailingStopSubmit{Side: Buy, TrailPercent: 10}
+	assert.Equal(t, 0.33, ts.StopPrice(0.3), "stop prices should not carry float rounding error")
+}
+
+func TestTrailingStopSubmitOrder(t *testing.T) {
+	t.Parallel()
+	ts := &TrailingStopSubmit{Exchange: "test", Side: Sell, Amount: 2, TrailAmount: 10, ClientOrderID: "trail", StrategyTag: "ts"}
+	s := ts.Order(90)
+	assert.Equal(t, Market, s.Type)
+	assert.Zero(t, s.Price)
+	assert.Equal(t, 2.0, s.Amount)
+	assert.Equal(t, "trail", s.ClientOrderID)
+	assert.Equal(t, "ts", s.StrategyTag)
+
+	ts.LimitOffset = 1
+	s = ts.Order(90)
+	assert.Equal(t, Limit, s.Type)
+	assert.Equal(t, 89.0, s.Price, "sell limit orders should be priced below the stop price")
+	ts.Side = Buy
+	assert.Equal(t, 91.0, ts.Order(90).Price, "buy limit orders should be priced above the stop price")
+	ts.LimitOffset = 0.1
+	assert.Equal(t, 0.3, ts.Order(0.2).Price, "limit prices should not carry float rounding error")
+}
+
 func TestSubmit_DeriveSubmitResponse(t *testing.T) {
 	t.Parallel()
 	var s *Submit
@@ -196,7 +391,8 @@ func TestSubmit_DeriveSubmitResponse(t *testing.T) {
 		t.Fatalf("received: '%v' but expected: '%v'", err, errOrderSubmitIsNil)
 	}
 
-	s = &Submit{}
+	expiry := time.Now().Add(time.Hour)
+	s = &Submit{StrategyTag: "grid", TimeInForce: GTD, Expiry: expiry}
 	_, err = s.DeriveSubmitResponse("")
 	if !errors.Is(err, ErrOrderIDNotSet) {
 		t.Fatalf("received: '%v' but expected: '%v'", err, ErrOrderIDNotSet)
@@ -222,6 +418,12 @@ func TestSubmit_DeriveSubmitResponse(t *testing.T) {
 	if resp.LastUpdated.IsZero() {
 		t.Fatal("unexpected value")
 	}
+
+	if resp.StrategyTag != "grid" {
+		t.Fatal("unexpected value")
+	}
+	assert.Equal(t, GTD, resp.TimeInForce, "TimeInForce should be copied from the submission")
+	assert.Equal(t, expiry, resp.Expiry, "Expiry should be copied from the submission")
 }
 
 func TestSubmitResponse_DeriveDetail(t *testing.T) {
@@ -237,7 +439,7 @@ func TestSubmitResponse_DeriveDetail(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	s = &SubmitResponse{}
+	s = &SubmitResponse{StrategyTag: "grid", TimeInForce: FOK}
 	deets, err := s.DeriveDetail(id)
 	if !errors.Is(err, nil) {
 		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
@@ -246,6 +448,11 @@ func TestSubmitResponse_DeriveDetail(t *testing.T) {
 	if deets.InternalOrderID != id {
 		t.Fatal("unexpected value")
 	}
+
+	if deets.StrategyTag != "grid" {
+		t.Fatal("unexpected value")
+	}
+	assert.Equal(t, FOK, deets.TimeInForce, "TimeInForce should be copied from the submit response")
 }
 
 func TestOrderSides(t *testing.T) {
@@ -372,6 +579,13 @@ func TestInferCostsAndTimes(t *testing.T) {
 			detail.Cost,
 		)
 	}
+
+	detail = Detail{Amount: 0.3, ExecutedAmount: 0.3, Cost: 0.03}
+	detail.InferCostsAndTimes()
+	assert.Equal(t, 0.1, detail.AverageExecutedPrice, "average executed price should not carry float rounding error")
+	detail = Detail{Amount: 3, ExecutedAmount: 3, AverageExecutedPrice: 0.1}
+	detail.InferCostsAndTimes()
+	assert.Equal(t, 0.3, detail.Cost, "cost should not carry float rounding error")
 }
 
 func TestFilterOrdersByType(t *testing.T) {
@@ -988,6 +1202,109 @@ func BenchmarkStringToOrderStatus(b *testing.B) {
 	}
 }
 
+func TestSubmitTimeInForceSupported(t *testing.T) {
+	t.Parallel()
+	s := &Submit{Exchange: "test", AssetType: asset.Spot}
+	assert.NoError(t, s.TimeInForceSupported(UnsetTimeInForce, false).Check(), "unset time in force should always be supported")
+	s.TimeInForce = GTD
+	assert.ErrorIs(t, s.TimeInForceSupported(GTC|IOC, true).Check(), ErrUnsupportedTimeInForce)
+	assert.NoError(t, s.TimeInForceSupported(GTC|GTD, true).Check())
+	s.PostOnly = true
+	assert.ErrorIs(t, s.TimeInForceSupported(GTC|GTD, false).Check(), ErrUnsupportedTimeInForce, "unsupported post only should error")
+}
+
+func TestTimeInForceString(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		tif      TimeInForce
+		expected string
+	}{
+		{UnsetTimeInForce, ""},
+		{GTC, "GTC"},
+		{GTD, "GTD"},
+		{IOC, "IOC"},
+		{FOK, "FOK"},
+		{IOC | FOK, "UNKNOWN"},
+	} {
+		assert.Equal(t, tc.expected, tc.tif.String())
+	}
+}
+
+func TestStringToTimeInForce(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		in       string
+		expected TimeInForce
+		err      error
+	}{
+		{"", UnsetTimeInForce, nil},
+		{"gtc", GTC, nil},
+		{"GOOD_TILL_DATE", GTD, nil},
+		{"ioc", IOC, nil},
+		{"FillOrKill", FOK, nil},
+		{"day", UnsetTimeInForce, errUnrecognisedTimeInForce},
+	} {
+		tif, err := StringToTimeInForce(tc.in)
+		assert.ErrorIs(t, err, tc.err, tc.in)
+		assert.Equal(t, tc.expected, tif, tc.in)
+	}
+}
+
+func TestSubmitSelfTradePreventionSupported(t *testing.T) {
+	t.Parallel()
+	s := &Submit{Exchange: "test", AssetType: asset.Spot}
+	assert.NoError(t, s.SelfTradePreventionSupported(UnsetSelfTradePrevention).Check(), "unset self trade prevention should always be supported")
+	s.SelfTradePrevention = STPDecrement
+	assert.ErrorIs(t, s.SelfTradePreventionSupported(STPCancelMaker|STPCancelTaker|STPCancelBoth).Check(), ErrUnsupportedSTPMode)
+	assert.NoError(t, s.SelfTradePreventionSupported(STPCancelMaker|STPDecrement).Check())
+}
+
+func TestSubmitDisplayAmountSupported(t *testing.T) {
+	t.Parallel()
+	s := &Submit{Exchange: "test", AssetType: asset.Spot}
+	assert.NoError(t, s.DisplayAmountSupported(false).Check(), "orders without a display amount should always be supported")
+	s.DisplayAmount = 0.1
+	assert.ErrorIs(t, s.DisplayAmountSupported(false).Check(), ErrUnsupportedDisplayAmount)
+	assert.NoError(t, s.DisplayAmountSupported(true).Check())
+}
+
+func TestSelfTradePreventionString(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		stp      SelfTradePrevention
+		expected string
+	}{
+		{UnsetSelfTradePrevention, ""},
+		{STPCancelMaker, "CANCEL_MAKER"},
+		{STPCancelTaker, "CANCEL_TAKER"},
+		{STPCancelBoth, "CANCEL_BOTH"},
+		{STPDecrement, "DECREMENT"},
+		{STPCancelMaker | STPCancelTaker, "UNKNOWN"},
+	} {
+		assert.Equal(t, tc.expected, tc.stp.String())
+	}
+}
+
+func TestStringToSelfTradePrevention(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		in       string
+		expected SelfTradePrevention
+		err      error
+	}{
+		{"", UnsetSelfTradePrevention, nil},
+		{"cancel_maker", STPCancelMaker, nil},
+		{"CancelTaker", STPCancelTaker, nil},
+		{"cancel-both", STPCancelBoth, nil},
+		{"decrement", STPDecrement, nil},
+		{"allow", UnsetSelfTradePrevention, errUnrecognisedSTP},
+	} {
+		stp, err := StringToSelfTradePrevention(tc.in)
+		assert.ErrorIs(t, err, tc.err, tc.in)
+		assert.Equal(t, tc.expected, stp, tc.in)
+	}
+}
+
 func TestUpdateOrderFromModifyResponse(t *testing.T) {
 	od := Detail{OrderID: "1"}
 	updated := time.Now()
@@ -998,24 +1315,24 @@ func TestUpdateOrderFromModifyResponse(t *testing.T) {
 	}
 
 	om := ModifyResponse{
-		ImmediateOrCancel: true,
-		PostOnly:          true,
-		Price:             1,
-		Amount:            1,
-		TriggerPrice:      1,
-		RemainingAmount:   1,
-		Exchange:          "1",
-		Type:              1,
-		Side:              1,
-		Status:            1,
-		AssetType:         1,
-		LastUpdated:       updated,
-		Pair:              pair,
+		TimeInForce:     IOC,
+		PostOnly:        true,
+		Price:           1,
+		Amount:          1,
+		TriggerPrice:    1,
+		RemainingAmount: 1,
+		Exchange:        "1",
+		Type:            1,
+		Side:            1,
+		Status:          1,
+		AssetType:       1,
+		LastUpdated:     updated,
+		Pair:            pair,
 	}
 
 	od.UpdateOrderFromModifyResponse(&om)
 
-	if !od.ImmediateOrCancel {
+	if od.TimeInForce != IOC {
 		t.Error("Failed to update")
 	}
 	if !od.PostOnly {
@@ -1083,35 +1400,36 @@ func TestUpdateOrderFromDetail(t *testing.T) {
 		t.Fatalf("received: '%v' but expected: '%v'", err, ErrOrderDetailIsNil)
 	}
 
+	expiry := time.Now().Add(time.Hour)
 	om := &Detail{
-		ImmediateOrCancel: true,
-		HiddenOrder:       true,
-		FillOrKill:        true,
-		PostOnly:          true,
-		Leverage:          1,
-		Price:             1,
-		Amount:            1,
-		LimitPriceUpper:   1,
-		LimitPriceLower:   1,
-		TriggerPrice:      1,
-		QuoteAmount:       1,
-		ExecutedAmount:    1,
-		RemainingAmount:   1,
-		Fee:               1,
-		Exchange:          "1",
-		InternalOrderID:   id,
-		OrderID:           "1",
-		AccountID:         "1",
-		ClientID:          "1",
-		ClientOrderID:     "DukeOfWombleton",
-		WalletAddress:     "1",
-		Type:              1,
-		Side:              1,
-		Status:            1,
-		AssetType:         1,
-		LastUpdated:       updated,
-		Pair:              pair,
-		Trades:            []TradeHistory{},
+		TimeInForce:     GTD,
+		Expiry:          expiry,
+		HiddenOrder:     true,
+		PostOnly:        true,
+		Leverage:        1,
+		Price:           1,
+		Amount:          1,
+		LimitPriceUpper: 1,
+		LimitPriceLower: 1,
+		TriggerPrice:    1,
+		QuoteAmount:     1,
+		ExecutedAmount:  1,
+		RemainingAmount: 1,
+		Fee:             1,
+		Exchange:        "1",
+		InternalOrderID: id,
+		OrderID:         "1",
+		AccountID:       "1",
+		ClientID:        "1",
+		ClientOrderID:   "DukeOfWombleton",
+		WalletAddress:   "1",
+		Type:            1,
+		Side:            1,
+		Status:          1,
+		AssetType:       1,
+		LastUpdated:     updated,
+		Pair:            pair,
+		Trades:          []TradeHistory{},
 	}
 
 	od = &Detail{Exchange: "test"}
@@ -1128,13 +1446,13 @@ func TestUpdateOrderFromDetail(t *testing.T) {
 	if od.InternalOrderID != id {
 		t.Error("Failed to initialize the internal order ID")
 	}
-	if !od.ImmediateOrCancel {
+	if od.TimeInForce != GTD {
 		t.Error("Failed to update")
 	}
-	if !od.HiddenOrder {
+	if !od.Expiry.Equal(expiry) {
 		t.Error("Failed to update")
 	}
-	if !od.FillOrKill {
+	if !od.HiddenOrder {
 		t.Error("Failed to update")
 	}
 	if !od.PostOnly {
@@ -1272,6 +1590,16 @@ func TestUpdateOrderFromDetail(t *testing.T) {
 	if od.InternalOrderID == id {
 		t.Error("Should not be able to update the internal order ID after initialization")
 	}
+
+	od = &Detail{}
+	om = &Detail{RemainingAmount: 0.3, Trades: []TradeHistory{{TID: "1", Amount: 0.1}}}
+	require.NoError(t, od.UpdateOrderFromDetail(om))
+	assert.Equal(t, 0.2, od.RemainingAmount, "remaining amount should not carry float rounding error")
+
+	od = &Detail{TimeInForce: GTD, Expiry: expiry}
+	require.NoError(t, od.UpdateOrderFromDetail(&Detail{Exchange: "test"}))
+	assert.Equal(t, GTD, od.TimeInForce, "an unset time in force should not replace the existing one")
+	assert.Equal(t, expiry, od.Expiry, "an unset expiry should not replace the existing one")
 }
 
 func TestClassificationError_Error(t *testing.T) {
@@ -1436,6 +1764,7 @@ func TestMatchFilter(t *testing.T) {
 		15: {Exchange: "Binance", Type: Limit, Status: New},
 		16: {Exchange: "Binance", Type: AnyType},
 		17: {AccountID: "8888"},
+		18: {StrategyTag: "grid"},
 	}
 
 	orders := map[int]Detail{
@@ -1456,6 +1785,7 @@ func TestMatchFilter(t *testing.T) {
 		14: {Pair: currency.NewPair(currency.BTC, currency.USD)},
 		15: {Exchange: "Binance", Type: Limit, Status: New},
 		16: {AccountID: "8888"},
+		17: {StrategyTag: "grid"},
 	}
 	// empty filter tests
 	emptyFilter := filters[0]
@@ -1508,6 +1838,8 @@ func TestMatchFilter(t *testing.T) {
 		35: {filters[16], orders[15], true},
 		36: {filters[17], orders[16], true},
 		37: {filters[17], orders[15], false},
+		38: {filters[18], orders[17], true},
+		39: {filters[18], orders[16], false},
 	}
 	// specific tests
 	for num, tt := range tests {
@@ -2062,3 +2394,23 @@ func TestSideUnmarshal(t *testing.T) {
 	var jErr *json.UnmarshalTypeError
 	assert.ErrorAs(t, s.UnmarshalJSON([]byte(`14`)), &jErr, "non-string valid json is rejected")
 }
+
+func TestSubmitConformToLimits(t *testing.T) {
+	t.Parallel()
+	var s *Submit
+	s.ConformToLimits(&MinMaxLevel{})
+
+	tenth, fifth, thousandth := 0.1, 0.2, 0.001 // Variables so that arithmetic is not folded into exact constants
+	s = &Submit{Type: Limit, Price: tenth + fifth, TriggerPrice: tenth * 7, Amount: thousandth * 3, DisplayAmount: 0.0015}
+	s.ConformToLimits(nil)
+	assert.Equal(t, tenth+fifth, s.Price, "orders should be unchanged without limits")
+	assert.NotEqual(t, 0.3, s.Price, "price must carry float rounding error")
+
+	l := &MinMaxLevel{PriceStepIncrementSize: 0.1, AmountStepIncrementSize: 0.001}
+	s.ConformToLimits(l)
+	assert.Equal(t, 0.3, s.Price)
+	assert.Equal(t, 0.7, s.TriggerPrice)
+	assert.Equal(t, 0.003, s.Amount)
+	assert.Equal(t, 0.0015, s.DisplayAmount, "amounts off an increment should not be altered")
+	assert.NoError(t, l.Conforms(s.Price, s.Amount, s.Type), "conformed orders should pass the limits")
+}
@@ -28,6 +28,13 @@ var (
 	ErrSubmitLeverageNotSupported = errors.New("leverage is not supported via order submission")
 	ErrClientOrderIDNotSupported  = errors.New("client order id not supported")
 	ErrUnsupportedOrderType       = errors.New("unsupported order type")
+	ErrUnsupportedTimeInForce     = errors.New("unsupported time in force")
+	ErrUnsupportedSTPMode         = errors.New("unsupported self trade prevention mode")
+	ErrUnsupportedDisplayAmount   = errors.New("unsupported display amount")
+	ErrOCOIsNil                   = errors.New("OCO order submission is nil")
+	ErrOCOPricesInvalid           = errors.New("OCO limit price must be on the opposite side of the trigger price")
+	ErrTrailingStopIsNil          = errors.New("trailing stop submission is nil")
+	ErrTrailInvalid               = errors.New("trailing stop must trail by either an amount or a percentage below 100")
 	// ErrNoRates is returned when no margin rates are returned when they are expected
 	ErrNoRates         = errors.New("no rates")
 	ErrCannotLiquidate = errors.New("cannot liquidate position")
@@ -44,15 +51,22 @@ type Submit struct {
 	Pair      currency.Pair
 	AssetType asset.Item
 
-	// Time in force values ------ TODO: Time In Force uint8
-	ImmediateOrCancel bool
-	FillOrKill        bool
+	// TimeInForce defines how long the order remains active. When unset the
+	// exchange default is used, which is GTC for most limit orders
+	TimeInForce TimeInForce
+	// Expiry is when an unfilled GTD order is cancelled
+	Expiry time.Time
 
 	PostOnly bool
 	// ReduceOnly reduces a position instead of opening an opposing
 	// position; this also equates to closing the position in huobi_wrapper.go
 	// swaps.
 	ReduceOnly bool
+	// SelfTradePrevention defines how the order is handled when it would
+	// match a resting order from the same account. Exchanges which do not
+	// support the mode natively ignore it and it is emulated by the order
+	// manager instead
+	SelfTradePrevention SelfTradePrevention
 	// Leverage is the amount of leverage that will be used: see huobi_wrapper.go
 	Leverage float64
 	Price    float64
@@ -61,6 +75,10 @@ type Submit struct {
 	// QuoteAmount is the max amount in quote currency when purchasing base.
 	// This is only used in Market orders.
 	QuoteAmount float64
+	// DisplayAmount is the visible portion of an iceberg limit order in base
+	// terms. Exchanges which do not support iceberg orders natively ignore it
+	// and the order manager submits the amount in display sized slices instead
+	DisplayAmount float64
 	// TriggerPrice is mandatory if order type `Stop, Stop Limit or Take Profit`
 	// See btcmarkets_wrapper.go.
 	TriggerPrice float64
@@ -70,6 +88,9 @@ type Submit struct {
 	TriggerPriceType PriceType
 	ClientID         string // TODO: Shift to credentials
 	ClientOrderID    string
+	// StrategyTag identifies the strategy placing the order so its orders can
+	// be filtered and cancelled together. It is not sent to the exchange
+	StrategyTag string
 
 	// The system will first borrow you funds at the optimal interest rate and then place an order for you.
 	// see kucoin_wrapper.go
@@ -93,6 +114,62 @@ type Submit struct {
 	TradeMode string
 }
 
+// OCOSubmit contains a one-cancels-the-other order: a limit order and a stop
+// order for the same amount on the same side, where either leg executing
+// cancels the other. Selling places the limit price above the trigger price,
+// taking profit or stopping losses, and buying places it below
+type OCOSubmit struct {
+	Exchange  string
+	Pair      currency.Pair
+	AssetType asset.Item
+	Side      Side
+	Amount    float64
+	// Price is the limit leg's price
+	Price float64
+	// TriggerPrice is the price which triggers the stop leg
+	TriggerPrice float64
+	// StopLimitPrice is the limit price of the stop leg once triggered. The
+	// stop leg is a market order when unset
+	StopLimitPrice float64
+	// ClientOrderID identifies the order list, legs are suffixed with -limit
+	// and -stop
+	ClientOrderID string
+	StrategyTag   string
+}
+
+// OCOSubmitResponse is what is returned after submitting an OCO order to an
+// exchange
+type OCOSubmitResponse struct {
+	// ListID is the exchange's ID for the pair of orders when it has one
+	ListID string
+	Limit  *SubmitResponse
+	Stop   *SubmitResponse
+}
+
+// TrailingStopSubmit contains a trailing stop order, whose stop price trails
+// the best price reached since submission by a fixed amount or percentage.
+// Selling trails below the highest price, closing long exposure as the price
+// falls, and buying trails above the lowest price
+type TrailingStopSubmit struct {
+	Exchange  string
+	Pair      currency.Pair
+	AssetType asset.Item
+	Side      Side
+	Amount    float64
+	// TrailAmount is the distance between the best price and the stop price
+	// in quote currency
+	TrailAmount float64
+	// TrailPercent is the distance between the best price and the stop price
+	// as a percentage of the best price, used when TrailAmount is unset
+	TrailPercent float64
+	// LimitOffset prices the order submitted once the stop price is breached
+	// as a limit order this far beyond the stop price. The order is a market
+	// order when unset
+	LimitOffset   float64
+	ClientOrderID string
+	StrategyTag   string
+}
+
 // SubmitResponse is what is returned after submitting an order to an exchange
 type SubmitResponse struct {
 	Exchange  string
@@ -101,8 +178,8 @@ type SubmitResponse struct {
 	Pair      currency.Pair
 	AssetType asset.Item
 
-	ImmediateOrCancel    bool
-	FillOrKill           bool
+	TimeInForce          TimeInForce
+	Expiry               time.Time
 	PostOnly             bool
 	ReduceOnly           bool
 	Leverage             float64
@@ -113,6 +190,7 @@ type SubmitResponse struct {
 	TriggerPrice         float64
 	ClientID             string
 	ClientOrderID        string
+	StrategyTag          string
 
 	LastUpdated time.Time
 	Date        time.Time
@@ -143,11 +221,11 @@ type Modify struct {
 	Pair          currency.Pair
 
 	// Change fields
-	ImmediateOrCancel bool
-	PostOnly          bool
-	Price             float64
-	Amount            float64
-	TriggerPrice      float64
+	TimeInForce  TimeInForce
+	PostOnly     bool
+	Price        float64
+	Amount       float64
+	TriggerPrice float64
 
 	// added to represent a unified trigger price type information such as LastPrice, MarkPrice, and IndexPrice
 	// https://bybit-exchange.github.io/docs/v5/order/create-order
@@ -169,11 +247,11 @@ type ModifyResponse struct {
 	AssetType     asset.Item
 
 	// Fields that will be copied over from Modify
-	ImmediateOrCancel bool
-	PostOnly          bool
-	Price             float64
-	Amount            float64
-	TriggerPrice      float64
+	TimeInForce  TimeInForce
+	PostOnly     bool
+	Price        float64
+	Amount       float64
+	TriggerPrice float64
 
 	// Fields that need to be handled in scope after DeriveModifyResponse()
 	// if applicable
@@ -186,9 +264,9 @@ type ModifyResponse struct {
 // Each exchange has their own requirements, so not all fields
 // are required to be populated
 type Detail struct {
-	ImmediateOrCancel    bool
+	TimeInForce          TimeInForce
+	Expiry               time.Time
 	HiddenOrder          bool
-	FillOrKill           bool
 	PostOnly             bool
 	ReduceOnly           bool
 	Leverage             float64
@@ -210,6 +288,7 @@ type Detail struct {
 	InternalOrderID      uuid.UUID
 	OrderID              string
 	ClientOrderID        string
+	StrategyTag          string
 	AccountID            string
 	ClientID             string
 	WalletAddress        string
@@ -233,6 +312,7 @@ type Filter struct {
 	InternalOrderID uuid.UUID
 	OrderID         string
 	ClientOrderID   string
+	StrategyTag     string
 	AccountID       string
 	ClientID        string
 	WalletAddress   string
@@ -360,6 +440,45 @@ const (
 	ConditionalStop // One-way stop order
 )
 
+// TimeInForce enforces a standard for how long orders remain active across
+// the code base
+type TimeInForce uint8
+
+// Time in force types
+const (
+	UnsetTimeInForce TimeInForce = 0
+	// GTC orders remain active until filled or cancelled
+	GTC TimeInForce = 1 << iota
+	// GTD orders remain active until filled, cancelled or their expiry
+	GTD
+	// IOC orders fill as much as possible immediately and cancel the remainder
+	IOC
+	// FOK orders fill completely and immediately or are cancelled
+	FOK
+
+	supportedTimeInForce = GTC | GTD | IOC | FOK
+)
+
+// SelfTradePrevention enforces a standard for how orders that would match
+// resting orders from the same account are handled across the code base
+type SelfTradePrevention uint8
+
+// Self trade prevention modes
+const (
+	UnsetSelfTradePrevention SelfTradePrevention = 0
+	// STPCancelMaker cancels the resting order and places the new order
+	STPCancelMaker SelfTradePrevention = 1 << iota
+	// STPCancelTaker cancels the new order and keeps the resting order
+	STPCancelTaker
+	// STPCancelBoth cancels both the resting and the new order
+	STPCancelBoth
+	// STPDecrement reduces both orders by the smaller order's amount,
+	// cancelling the smaller order
+	STPDecrement
+
+	supportedSelfTradePrevention = STPCancelMaker | STPCancelTaker | STPCancelBoth | STPDecrement
+)
+
 // Side enforces a standard for order sides across the code base
 type Side uint32
 
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/shopspring/decimal"
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
@@ -41,6 +42,11 @@ var (
 	ErrUnknownPriceType = errors.New("unknown price type")
 
 	errTimeInForceConflict      = errors.New("multiple time in force options applied")
+	errUnrecognisedTimeInForce  = errors.New("unrecognised time in force")
+	errExpiryRequired           = errors.New("expiry must be set for GTD orders")
+	errExpiryNotAllowed         = errors.New("expiry can only be set for GTD orders")
+	errUnrecognisedSTP          = errors.New("unrecognised self trade prevention mode")
+	errInvalidDisplayAmount     = errors.New("invalid display amount")
 	errUnrecognisedOrderType    = errors.New("unrecognised order type")
 	errUnrecognisedOrderStatus  = errors.New("unrecognised order status")
 	errExchangeNameUnset        = errors.New("exchange name unset")
@@ -85,8 +91,12 @@ func (s *Submit) Validate(opt ...validate.Checker) error {
 		return ErrTypeIsInvalid
 	}
 
-	if s.ImmediateOrCancel && s.FillOrKill {
-		return errTimeInForceConflict
+	if err := s.validateTimeInForce(); err != nil {
+		return err
+	}
+
+	if s.SelfTradePrevention&^supportedSelfTradePrevention != 0 || s.SelfTradePrevention&(s.SelfTradePrevention-1) != 0 {
+		return fmt.Errorf("%w %d", errUnrecognisedSTP, s.SelfTradePrevention)
 	}
 
 	if s.Amount == 0 && s.QuoteAmount == 0 {
@@ -105,6 +115,10 @@ func (s *Submit) Validate(opt ...validate.Checker) error {
 		return ErrPriceMustBeSetIfLimitOrder
 	}
 
+	if err := s.validateDisplayAmount(); err != nil {
+		return err
+	}
+
 	for _, o := range opt {
 		err := o.Check()
 		if err != nil {
@@ -115,6 +129,104 @@ func (s *Submit) Validate(opt ...validate.Checker) error {
 	return nil
 }
 
+// validateTimeInForce checks the time in force is a single recognised value
+// and is compatible with the expiry and post only settings
+func (s *Submit) validateTimeInForce() error {
+	if s.TimeInForce&^supportedTimeInForce != 0 {
+		return fmt.Errorf("%w %d", errUnrecognisedTimeInForce, s.TimeInForce)
+	}
+	if s.TimeInForce&(s.TimeInForce-1) != 0 {
+		return fmt.Errorf("%w %d", errTimeInForceConflict, s.TimeInForce)
+	}
+	if s.TimeInForce == GTD && s.Expiry.IsZero() {
+		return errExpiryRequired
+	}
+	if s.TimeInForce != GTD && !s.Expiry.IsZero() {
+		return errExpiryNotAllowed
+	}
+	if s.PostOnly && (s.TimeInForce == IOC || s.TimeInForce == FOK) {
+		return fmt.Errorf("%w post only and %s", errTimeInForceConflict, s.TimeInForce)
+	}
+	return nil
+}
+
+// validateDisplayAmount checks an iceberg display amount is a positive
+// portion of a limit order's base amount
+func (s *Submit) validateDisplayAmount() error {
+	if s.DisplayAmount == 0 {
+		return nil
+	}
+	if s.DisplayAmount < 0 || s.DisplayAmount >= s.Amount {
+		return fmt.Errorf("%w %v must be above zero and less than amount %v", errInvalidDisplayAmount, s.DisplayAmount, s.Amount)
+	}
+	if s.Type != Limit {
+		return fmt.Errorf("%w for %s order, only limit orders can be iceberg orders", errInvalidDisplayAmount, s.Type)
+	}
+	return nil
+}
+
+// TimeInForceSupported is a validation check which returns
+// ErrUnsupportedTimeInForce when the time in force or post only setting is not
+// supported by an exchange, so it is rejected instead of being ignored
+func (s *Submit) TimeInForceSupported(supported TimeInForce, postOnly bool) validate.Checker {
+	return validate.Check(func() error {
+		if s.TimeInForce != UnsetTimeInForce && s.TimeInForce&supported == 0 {
+			return fmt.Errorf("%w %s for %s %s", ErrUnsupportedTimeInForce, s.TimeInForce, s.Exchange, s.AssetType)
+		}
+		if s.PostOnly && !postOnly {
+			return fmt.Errorf("%w post only for %s %s", ErrUnsupportedTimeInForce, s.Exchange, s.AssetType)
+		}
+		return nil
+	})
+}
+
+// SelfTradePreventionSupported is a validation check which returns
+// ErrUnsupportedSTPMode when the self trade prevention mode is not
+// supported by an exchange, so it can be emulated instead of being ignored
+func (s *Submit) SelfTradePreventionSupported(supported SelfTradePrevention) validate.Checker {
+	return validate.Check(func() error {
+		if s.SelfTradePrevention != UnsetSelfTradePrevention && s.SelfTradePrevention&supported == 0 {
+			return fmt.Errorf("%w %s for %s %s", ErrUnsupportedSTPMode, s.SelfTradePrevention, s.Exchange, s.AssetType)
+		}
+		return nil
+	})
+}
+
+// DisplayAmountSupported is a validation check which returns
+// ErrUnsupportedDisplayAmount when an iceberg display amount is set but not
+// supported by an exchange, so it is rejected instead of being ignored
+func (s *Submit) DisplayAmountSupported(supported bool) validate.Checker {
+	return validate.Check(func() error {
+		if s.DisplayAmount != 0 && !supported {
+			return fmt.Errorf("%w for %s %s", ErrUnsupportedDisplayAmount, s.Exchange, s.AssetType)
+		}
+		return nil
+	})
+}
+
+// ConformToLimits snaps the order's prices and amounts onto the exchange's
+// step increments when they are off them only by float rounding error, such
+// as 0.1+0.2 against a 0.1 tick, so that they are not rejected by precise
+// increments. Values further off an increment are left for the limits to
+// reject
+func (s *Submit) ConformToLimits(l *MinMaxLevel) {
+	if s == nil || l == nil {
+		return
+	}
+	if s.Price != 0 {
+		s.Price = l.ConformToDecimalPrice(decimal.NewFromFloat(s.Price)).InexactFloat64()
+	}
+	if s.TriggerPrice != 0 {
+		s.TriggerPrice = l.ConformToDecimalPrice(decimal.NewFromFloat(s.TriggerPrice)).InexactFloat64()
+	}
+	if s.Amount != 0 {
+		s.Amount = l.ConformToDecimalOrderAmount(decimal.NewFromFloat(s.Amount), s.Type).InexactFloat64()
+	}
+	if s.DisplayAmount != 0 {
+		s.DisplayAmount = l.ConformToDecimalOrderAmount(decimal.NewFromFloat(s.DisplayAmount), s.Type).InexactFloat64()
+	}
+}
+
 // UpdateOrderFromDetail Will update an order detail (used in order management)
 // by comparing passed in and existing values
 func (d *Detail) UpdateOrderFromDetail(m *Detail) error {
@@ -127,16 +239,16 @@ func (d *Detail) UpdateOrderFromDetail(m *Detail) error {
 	}
 
 	var updated bool
-	if d.ImmediateOrCancel != m.ImmediateOrCancel {
-		d.ImmediateOrCancel = m.ImmediateOrCancel
+	if m.TimeInForce != UnsetTimeInForce && m.TimeInForce != d.TimeInForce {
+		d.TimeInForce = m.TimeInForce
 		updated = true
 	}
-	if d.HiddenOrder != m.HiddenOrder {
-		d.HiddenOrder = m.HiddenOrder
+	if !m.Expiry.IsZero() && !m.Expiry.Equal(d.Expiry) {
+		d.Expiry = m.Expiry
 		updated = true
 	}
-	if d.FillOrKill != m.FillOrKill {
-		d.FillOrKill = m.FillOrKill
+	if d.HiddenOrder != m.HiddenOrder {
+		d.HiddenOrder = m.HiddenOrder
 		updated = true
 	}
 	if m.Price > 0 && m.Price != d.Price {
@@ -217,6 +329,7 @@ func (d *Detail) UpdateOrderFromDetail(m *Detail) error {
 		d.AssetType = m.AssetType
 		updated = true
 	}
+	remaining := decimal.NewFromFloat(m.RemainingAmount)
 	for x := range m.Trades {
 		var found bool
 		for y := range d.Trades {
@@ -261,10 +374,10 @@ func (d *Detail) UpdateOrderFromDetail(m *Detail) error {
 			d.Trades = append(d.Trades, m.Trades[x])
 			updated = true
 		}
-		m.RemainingAmount -= m.Trades[x].Amount
+		remaining = remaining.Sub(decimal.NewFromFloat(m.Trades[x].Amount))
 	}
-	if m.RemainingAmount > 0 && m.RemainingAmount != d.RemainingAmount {
-		d.RemainingAmount = m.RemainingAmount
+	if r := remaining.InexactFloat64(); r > 0 && r != d.RemainingAmount {
+		d.RemainingAmount = r
 		updated = true
 	}
 	if updated {
@@ -294,8 +407,8 @@ func (d *Detail) UpdateOrderFromModifyResponse(m *ModifyResponse) {
 		d.OrderID = m.OrderID
 		updated = true
 	}
-	if d.ImmediateOrCancel != m.ImmediateOrCancel {
-		d.ImmediateOrCancel = m.ImmediateOrCancel
+	if m.TimeInForce != UnsetTimeInForce && m.TimeInForce != d.TimeInForce {
+		d.TimeInForce = m.TimeInForce
 		updated = true
 	}
 	if m.Price > 0 && m.Price != d.Price {
@@ -369,6 +482,8 @@ func (d *Detail) MatchFilter(f *Filter) bool {
 		return false
 	case f.ClientOrderID != "" && d.ClientOrderID != f.ClientOrderID:
 		return false
+	case f.StrategyTag != "" && d.StrategyTag != f.StrategyTag:
+		return false
 	case f.ClientID != "" && d.ClientID != f.ClientID:
 		return false
 	case !f.InternalOrderID.IsNil() && d.InternalOrderID != f.InternalOrderID:
@@ -444,6 +559,128 @@ func (d *Detail) Copy() Detail {
 	return c
 }
 
+// Validate checks an OCO order is valid and that its limit price and trigger
+// price are on opposite sides so only one leg can be reached
+func (o *OCOSubmit) Validate() error {
+	if o == nil {
+		return ErrOCOIsNil
+	}
+	if o.Price <= 0 || o.TriggerPrice <= 0 || o.StopLimitPrice < 0 {
+		return ErrPriceMustBeSetIfLimitOrder
+	}
+	if o.Side.IsLong() && o.Price >= o.TriggerPrice ||
+		o.Side.IsShort() && o.Price <= o.TriggerPrice {
+		return fmt.Errorf("%w: %s limit price %v trigger price %v", ErrOCOPricesInvalid, o.Side, o.Price, o.TriggerPrice)
+	}
+	limit, _ := o.Legs()
+	return limit.Validate()
+}
+
+// Legs returns the limit and stop orders making up an OCO order
+func (o *OCOSubmit) Legs() (limit, stop *Submit) {
+	limit = &Submit{
+		Exchange:    o.Exchange,
+		Pair:        o.Pair,
+		AssetType:   o.AssetType,
+		Side:        o.Side,
+		Type:        Limit,
+		Amount:      o.Amount,
+		Price:       o.Price,
+		StrategyTag: o.StrategyTag,
+	}
+	stop = &Submit{
+		Exchange:     o.Exchange,
+		Pair:         o.Pair,
+		AssetType:    o.AssetType,
+		Side:         o.Side,
+		Type:         Stop,
+		Amount:       o.Amount,
+		TriggerPrice: o.TriggerPrice,
+		StrategyTag:  o.StrategyTag,
+	}
+	if o.StopLimitPrice > 0 {
+		stop.Type = StopLimit
+		stop.Price = o.StopLimitPrice
+	}
+	if o.ClientOrderID != "" {
+		limit.ClientOrderID = o.ClientOrderID + "-limit"
+		stop.ClientOrderID = o.ClientOrderID + "-stop"
+	}
+	return limit, stop
+}
+
+// Validate checks a trailing stop order has a valid trail and that the order
+// submitted once it is breached is valid
+func (t *TrailingStopSubmit) Validate() error {
+	if t == nil {
+		return ErrTrailingStopIsNil
+	}
+	if (t.TrailAmount > 0) == (t.TrailPercent > 0) || t.TrailAmount < 0 || t.TrailPercent < 0 || t.TrailPercent >= 100 {
+		return fmt.Errorf("%w: trail amount %v trail percent %v", ErrTrailInvalid, t.TrailAmount, t.TrailPercent)
+	}
+	if t.LimitOffset < 0 {
+		return fmt.Errorf("%w: limit offset %v", ErrPriceMustBeSetIfLimitOrder, t.LimitOffset)
+	}
+	// The limit price is only known once the stop price is breached
+	s := t.Order(0)
+	s.Type, s.Price = Market, 0
+	return s.Validate()
+}
+
+// StopPrice returns the stop price trailing the best price
+func (t *TrailingStopSubmit) StopPrice(best float64) float64 {
+	b := decimal.NewFromFloat(best)
+	trail := decimal.NewFromFloat(t.TrailAmount)
+	if !trail.IsPositive() {
+		trail = b.Mul(decimal.NewFromFloat(t.TrailPercent)).Div(decimal.NewFromInt(100))
+	}
+	if t.Side.IsShort() {
+		return b.Sub(trail).InexactFloat64()
+	}
+	return b.Add(trail).InexactFloat64()
+}
+
+// Breached returns whether a price has reached the stop price
+func (t *TrailingStopSubmit) Breached(price, stopPrice float64) bool {
+	if t.Side.IsShort() {
+		return price <= stopPrice
+	}
+	return price >= stopPrice
+}
+
+// Improves returns whether a price is better than the best price, moving the
+// stop price with it
+func (t *TrailingStopSubmit) Improves(price, best float64) bool {
+	if t.Side.IsShort() {
+		return price > best
+	}
+	return price < best
+}
+
+// Order returns the order submitted once the stop price is breached, a market
+// order or a limit order offset beyond the stop price
+func (t *TrailingStopSubmit) Order(stopPrice float64) *Submit {
+	s := &Submit{
+		Exchange:      t.Exchange,
+		Pair:          t.Pair,
+		AssetType:     t.AssetType,
+		Side:          t.Side,
+		Type:          Market,
+		Amount:        t.Amount,
+		ClientOrderID: t.ClientOrderID,
+		StrategyTag:   t.StrategyTag,
+	}
+	if t.LimitOffset > 0 {
+		s.Type = Limit
+		stop, offset := decimal.NewFromFloat(stopPrice), decimal.NewFromFloat(t.LimitOffset)
+		s.Price = stop.Add(offset).InexactFloat64()
+		if t.Side.IsShort() {
+			s.Price = stop.Sub(offset).InexactFloat64()
+		}
+	}
+	return s
+}
+
 // DeriveSubmitResponse will construct an order SubmitResponse when a successful
 // submission has occurred. NOTE: order status is populated as order.Filled for a
 // market order else order.New if an order is accepted as default, date and
@@ -470,18 +707,19 @@ func (s *Submit) DeriveSubmitResponse(orderID string) (*SubmitResponse, error) {
 		Pair:      s.Pair,
 		AssetType: s.AssetType,
 
-		ImmediateOrCancel: s.ImmediateOrCancel,
-		FillOrKill:        s.FillOrKill,
-		PostOnly:          s.PostOnly,
-		ReduceOnly:        s.ReduceOnly,
-		Leverage:          s.Leverage,
-		Price:             s.Price,
-		Amount:            s.Amount,
-		QuoteAmount:       s.QuoteAmount,
-		TriggerPrice:      s.TriggerPrice,
-		ClientID:          s.ClientID,
-		ClientOrderID:     s.ClientOrderID,
-		MarginType:        s.MarginType,
+		TimeInForce:   s.TimeInForce,
+		Expiry:        s.Expiry,
+		PostOnly:      s.PostOnly,
+		ReduceOnly:    s.ReduceOnly,
+		Leverage:      s.Leverage,
+		Price:         s.Price,
+		Amount:        s.Amount,
+		QuoteAmount:   s.QuoteAmount,
+		TriggerPrice:  s.TriggerPrice,
+		ClientID:      s.ClientID,
+		ClientOrderID: s.ClientOrderID,
+		StrategyTag:   s.StrategyTag,
+		MarginType:    s.MarginType,
 
 		LastUpdated: time.Now(),
 		Date:        time.Now(),
@@ -563,17 +801,18 @@ func (s *SubmitResponse) DeriveDetail(internal uuid.UUID) (*Detail, error) {
 		Pair:      s.Pair,
 		AssetType: s.AssetType,
 
-		ImmediateOrCancel: s.ImmediateOrCancel,
-		FillOrKill:        s.FillOrKill,
-		PostOnly:          s.PostOnly,
-		ReduceOnly:        s.ReduceOnly,
-		Leverage:          s.Leverage,
-		Price:             s.Price,
-		Amount:            s.Amount,
-		QuoteAmount:       s.QuoteAmount,
-		TriggerPrice:      s.TriggerPrice,
-		ClientID:          s.ClientID,
-		ClientOrderID:     s.ClientOrderID,
+		TimeInForce:   s.TimeInForce,
+		Expiry:        s.Expiry,
+		PostOnly:      s.PostOnly,
+		ReduceOnly:    s.ReduceOnly,
+		Leverage:      s.Leverage,
+		Price:         s.Price,
+		Amount:        s.Amount,
+		QuoteAmount:   s.QuoteAmount,
+		TriggerPrice:  s.TriggerPrice,
+		ClientID:      s.ClientID,
+		ClientOrderID: s.ClientOrderID,
+		StrategyTag:   s.StrategyTag,
 
 		InternalOrderID: internal,
 
@@ -623,18 +862,18 @@ func (m *Modify) DeriveModifyResponse() (*ModifyResponse, error) {
 		return nil, errOrderDetailIsNil
 	}
 	return &ModifyResponse{
-		Exchange:          m.Exchange,
-		OrderID:           m.OrderID,
-		ClientOrderID:     m.ClientOrderID,
-		Type:              m.Type,
-		Side:              m.Side,
-		AssetType:         m.AssetType,
-		Pair:              m.Pair,
-		ImmediateOrCancel: m.ImmediateOrCancel,
-		PostOnly:          m.PostOnly,
-		Price:             m.Price,
-		Amount:            m.Amount,
-		TriggerPrice:      m.TriggerPrice,
+		Exchange:      m.Exchange,
+		OrderID:       m.OrderID,
+		ClientOrderID: m.ClientOrderID,
+		Type:          m.Type,
+		Side:          m.Side,
+		AssetType:     m.AssetType,
+		Pair:          m.Pair,
+		TimeInForce:   m.TimeInForce,
+		PostOnly:      m.PostOnly,
+		Price:         m.Price,
+		Amount:        m.Amount,
+		TriggerPrice:  m.TriggerPrice,
 	}, nil
 }
 
@@ -657,6 +896,79 @@ func (d *Detail) DeriveCancel() (*Cancel, error) {
 	}, nil
 }
 
+// String implements the stringer interface
+func (t TimeInForce) String() string {
+	switch t {
+	case UnsetTimeInForce:
+		return ""
+	case GTC:
+		return "GTC"
+	case GTD:
+		return "GTD"
+	case IOC:
+		return "IOC"
+	case FOK:
+		return "FOK"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// StringToTimeInForce returns the time in force for a string
+func StringToTimeInForce(tif string) (TimeInForce, error) {
+	switch strings.ToUpper(tif) {
+	case "":
+		return UnsetTimeInForce, nil
+	case GTC.String(), "GOOD_TILL_CANCEL", "GOODTILLCANCEL":
+		return GTC, nil
+	case GTD.String(), "GOOD_TILL_DATE", "GOODTILLDATE":
+		return GTD, nil
+	case IOC.String(), "IMMEDIATE_OR_CANCEL", "IMMEDIATEORCANCEL":
+		return IOC, nil
+	case FOK.String(), "FILL_OR_KILL", "FILLORKILL":
+		return FOK, nil
+	default:
+		return UnsetTimeInForce, fmt.Errorf("%w %q", errUnrecognisedTimeInForce, tif)
+	}
+}
+
+// String implements the stringer interface
+func (s SelfTradePrevention) String() string {
+	switch s {
+	case UnsetSelfTradePrevention:
+		return ""
+	case STPCancelMaker:
+		return "CANCEL_MAKER"
+	case STPCancelTaker:
+		return "CANCEL_TAKER"
+	case STPCancelBoth:
+		return "CANCEL_BOTH"
+	case STPDecrement:
+		return "DECREMENT"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// StringToSelfTradePrevention returns the self trade prevention mode for a
+// string
+func StringToSelfTradePrevention(stp string) (SelfTradePrevention, error) {
+	switch strings.ToUpper(stp) {
+	case "", "NONE":
+		return UnsetSelfTradePrevention, nil
+	case STPCancelMaker.String(), "CANCELMAKER", "CANCEL-MAKER", "CANCEL_OLDEST":
+		return STPCancelMaker, nil
+	case STPCancelTaker.String(), "CANCELTAKER", "CANCEL-TAKER", "CANCEL_NEWEST":
+		return STPCancelTaker, nil
+	case STPCancelBoth.String(), "CANCELBOTH", "CANCEL-BOTH":
+		return STPCancelBoth, nil
+	case STPDecrement.String(), "DECREASE_AND_CANCEL":
+		return STPDecrement, nil
+	default:
+		return UnsetSelfTradePrevention, fmt.Errorf("%w %q", errUnrecognisedSTP, stp)
+	}
+}
+
 // String implements the stringer interface
 func (t Type) String() string {
 	switch t {
@@ -843,15 +1155,16 @@ func (d *Detail) InferCostsAndTimes() {
 		return
 	}
 
+	executed := decimal.NewFromFloat(d.ExecutedAmount)
 	if d.AverageExecutedPrice == 0 {
 		if d.Cost != 0 {
-			d.AverageExecutedPrice = d.Cost / d.ExecutedAmount
+			d.AverageExecutedPrice = decimal.NewFromFloat(d.Cost).Div(executed).InexactFloat64()
 		} else {
 			d.AverageExecutedPrice = d.Price
 		}
 	}
 	if d.Cost == 0 {
-		d.Cost = d.AverageExecutedPrice * d.ExecutedAmount
+		d.Cost = decimal.NewFromFloat(d.AverageExecutedPrice).Mul(executed).InexactFloat64()
 	}
 }
 
@@ -0,0 +1,85 @@
+package orderbook
+
+import (
+	"errors"
+	"sort"
+	"time"
+	"unsafe"
+)
+
+// levelSize is the approximate memory used by a single orderbook level
+var levelSize = int64(unsafe.Sizeof(Node{}))
+
+var errEvictedOverBudget = errors.New("evicted to reduce orderbook memory usage")
+
+// MemoryUsage returns the approximate memory used by the levels of every stored
+// orderbook in bytes
+func MemoryUsage() int64 {
+	return service.memoryUsage()
+}
+
+func (s *Service) memoryUsage() int64 {
+	var total int64
+	for _, d := range s.depths() {
+		usage, _ := d.memoryUsage()
+		total += usage
+	}
+	return total
+}
+
+// EvictStale invalidates the least recently updated orderbooks, freeing their
+// levels, until the approximate memory used is within the budget. Evicted
+// orderbooks are restored by their next snapshot. Returns the number of
+// orderbooks evicted
+func EvictStale(budget int64) int {
+	return service.evictStale(budget)
+}
+
+func (s *Service) evictStale(budget int64) int {
+	type book struct {
+		depth       *Depth
+		usage       int64
+		lastUpdated time.Time
+	}
+	var books []book
+	var total int64
+	for _, d := range s.depths() {
+		usage, lastUpdated := d.memoryUsage()
+		if usage == 0 {
+			continue
+		}
+		total += usage
+		books = append(books, book{depth: d, usage: usage, lastUpdated: lastUpdated})
+	}
+	sort.Slice(books, func(i, j int) bool {
+		return books[i].lastUpdated.Before(books[j].lastUpdated)
+	})
+	var evicted int
+	for i := 0; i < len(books) && total > budget; i++ {
+		_ = books[i].depth.Invalidate(errEvictedOverBudget)
+		total -= books[i].usage
+		evicted++
+	}
+	return evicted
+}
+
+// depths returns every stored orderbook
+func (s *Service) depths() []*Depth {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var depths []*Depth
+	for _, exch := range s.books {
+		for _, d := range exch.m {
+			depths = append(depths, d)
+		}
+	}
+	return depths
+}
+
+// memoryUsage returns the approximate memory used by the depth's levels and
+// when the depth was last updated
+func (d *Depth) memoryUsage() (usage int64, lastUpdated time.Time) {
+	d.m.Lock()
+	defer d.m.Unlock()
+	return int64(d.bids.length+d.asks.length) * levelSize, d.lastUpdated
+}
@@ -0,0 +1,34 @@
+package orderbook
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+func TestMemoryUsageAndEvictStale(t *testing.T) {
+	t.Parallel()
+	s := &Service{books: make(map[string]Exchange), Mux: dispatch.GetNewMux(nil)}
+	assert.Zero(t, s.memoryUsage())
+
+	stale, err := s.DeployDepth("test", currency.NewPair(currency.BTC, currency.USD), asset.Spot)
+	require.NoError(t, err)
+	require.NoError(t, stale.LoadSnapshot([]Item{{Price: 2, Amount: 1}, {Price: 1, Amount: 1}}, []Item{{Price: 3, Amount: 1}}, 0, time.Now().Add(-time.Hour), true))
+	fresh, err := s.DeployDepth("test", currency.NewPair(currency.ETH, currency.USD), asset.Spot)
+	require.NoError(t, err)
+	require.NoError(t, fresh.LoadSnapshot([]Item{{Price: 2, Amount: 1}}, []Item{{Price: 3, Amount: 1}}, 0, time.Now(), true))
+	assert.Equal(t, 5*levelSize, s.memoryUsage())
+
+	assert.Zero(t, s.evictStale(5*levelSize), "books within budget must not be evicted")
+	assert.Equal(t, 1, s.evictStale(2*levelSize))
+	assert.False(t, stale.IsValid(), "the least recently updated book should be evicted")
+	assert.True(t, fresh.IsValid())
+	assert.Equal(t, 2*levelSize, s.memoryUsage())
+	assert.Equal(t, 1, s.evictStale(0))
+	assert.Zero(t, s.memoryUsage())
+}
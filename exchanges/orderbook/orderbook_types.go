@@ -154,9 +154,12 @@ const (
 
 // Update and things and stuff
 type Update struct {
-	UpdateID   int64 // Used when no time is provided
-	UpdateTime time.Time
-	Asset      asset.Item
+	UpdateID int64 // Used when no time is provided
+	// PrevUpdateID is the ID of the update preceding this one, for exchanges
+	// which chain updates e.g. Deribit's prev_change_id
+	PrevUpdateID int64
+	UpdateTime   time.Time
+	Asset        asset.Item
 	Action
 	Bids []Item
 	Asks []Item
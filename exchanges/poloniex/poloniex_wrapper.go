@@ -541,7 +541,7 @@ allTrades:
 
 // SubmitOrder submits a new order
 func (p *Poloniex) SubmitOrder(ctx context.Context, s *order.Submit) (*order.SubmitResponse, error) {
-	if err := s.Validate(); err != nil {
+	if err := s.Validate(s.TimeInForceSupported(order.GTC|order.IOC|order.FOK, false)); err != nil {
 		return nil, err
 	}
 
@@ -553,8 +553,8 @@ func (p *Poloniex) SubmitOrder(ctx context.Context, s *order.Submit) (*order.Sub
 		fPair.String(),
 		s.Price,
 		s.Amount,
-		false,
-		s.Type == order.Market,
+		s.TimeInForce == order.IOC,
+		s.Type == order.Market || s.TimeInForce == order.FOK,
 		s.Side.IsLong())
 	if err != nil {
 		return nil, err
@@ -579,7 +579,7 @@ func (p *Poloniex) ModifyOrder(ctx context.Context, action *order.Modify) (*orde
 		action.Price,
 		action.Amount,
 		action.PostOnly,
-		action.ImmediateOrCancel)
+		action.TimeInForce == order.IOC)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,295 @@
+package request
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Circuit breaker states
+const (
+	CircuitClosed CircuitState = iota
+	CircuitOpen
+	CircuitHalfOpen
+)
+
+// Endpoint classes tracked by the circuit breaker
+const (
+	PublicEndpoints  = "public"
+	PrivateEndpoints = "private"
+)
+
+var (
+	// ErrCircuitOpen is returned when a request is short-circuited because
+	// the exchange has repeatedly failed to respond
+	ErrCircuitOpen = errors.New("circuit breaker open")
+
+	errInvalidFailureThreshold = errors.New("failure threshold must be greater than zero")
+	errInvalidCircuitCooldown  = errors.New("circuit breaker cooldown must be greater than zero")
+
+	globalCircuitBreakerMtx sync.Mutex
+)
+
+// CircuitState is the state of a circuit breaker
+type CircuitState uint8
+
+// CircuitBreakerSettings configures when a circuit breaker opens and how long
+// it waits before probing for recovery
+type CircuitBreakerSettings struct {
+	// PublicFailureThreshold is the number of consecutive failed public
+	// requests which opens the public circuit
+	PublicFailureThreshold int
+	// PrivateFailureThreshold is the number of consecutive failed
+	// authenticated requests which opens the private circuit
+	PrivateFailureThreshold int
+	// Cooldown is how long a circuit stays open before a single probe request
+	// is allowed through
+	Cooldown time.Duration
+	// OnStateChange is called whenever a circuit changes state
+	OnStateChange func(CircuitStateChange)
+}
+
+// CircuitStateChange holds the details of a circuit changing state
+type CircuitStateChange struct {
+	Exchange string
+	Class    string
+	From     CircuitState
+	To       CircuitState
+	Failures int
+	Err      error
+	Time     time.Time
+}
+
+// circuitBreaker tracks consecutive failures per endpoint class of a requester
+type circuitBreaker struct {
+	name     string
+	settings CircuitBreakerSettings
+	m        sync.Mutex
+	circuits map[string]*circuit
+}
+
+// circuit holds the state of a single endpoint class
+type circuit struct {
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// venueError marks an error as the exchange failing to serve a request, as
+// opposed to the request being rejected or cancelled
+type venueError struct {
+	err error
+}
+
+// SetupGlobalCircuitBreaker sets the circuit breaker settings used by all
+// requesters created afterwards
+func SetupGlobalCircuitBreaker(s *CircuitBreakerSettings) error {
+	if s != nil {
+		if err := s.validate(); err != nil {
+			return err
+		}
+	}
+	globalCircuitBreakerMtx.Lock()
+	globalCircuitBreaker = s
+	globalCircuitBreakerMtx.Unlock()
+	return nil
+}
+
+// getGlobalCircuitBreaker returns the circuit breaker settings used by new
+// requesters, nil if circuit breaking is disabled
+func getGlobalCircuitBreaker() *CircuitBreakerSettings {
+	globalCircuitBreakerMtx.Lock()
+	defer globalCircuitBreakerMtx.Unlock()
+	return globalCircuitBreaker
+}
+
+// validate checks the settings are usable
+func (s *CircuitBreakerSettings) validate() error {
+	if s.PublicFailureThreshold <= 0 || s.PrivateFailureThreshold <= 0 {
+		return errInvalidFailureThreshold
+	}
+	if s.Cooldown <= 0 {
+		return errInvalidCircuitCooldown
+	}
+	return nil
+}
+
+// String implements the stringer interface
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// String implements the stringer interface
+func (c CircuitStateChange) String() string {
+	s := fmt.Sprintf("%s %s circuit %s after %d consecutive failures", c.Exchange, c.Class, c.To, c.Failures)
+	if c.To == CircuitClosed {
+		s = fmt.Sprintf("%s %s circuit closed, requests recovered", c.Exchange, c.Class)
+	}
+	if c.Err != nil {
+		s += ": " + c.Err.Error()
+	}
+	return s
+}
+
+func (e *venueError) Error() string {
+	return e.err.Error()
+}
+
+func (e *venueError) Unwrap() error {
+	return e.err
+}
+
+// newCircuitBreaker returns a circuit breaker for a requester
+func newCircuitBreaker(name string, s *CircuitBreakerSettings) *circuitBreaker {
+	return &circuitBreaker{
+		name:     name,
+		settings: *s,
+		circuits: make(map[string]*circuit),
+	}
+}
+
+// endpointClass returns the circuit breaker endpoint class of a request type
+func endpointClass(requestType AuthType) string {
+	if requestType == AuthenticatedRequest {
+		return PrivateEndpoints
+	}
+	return PublicEndpoints
+}
+
+// allow returns ErrCircuitOpen if requests of the class should be
+// short-circuited. Once the cooldown has passed a single probe is let through
+// and the circuit is half-open until the probe's outcome is recorded
+func (b *circuitBreaker) allow(class string, now time.Time) error {
+	if b == nil {
+		return nil
+	}
+	change, err := b.allowProbe(class, now)
+	b.notify(change)
+	return err
+}
+
+// allowProbe applies allow under lock, returning any state change so the
+// handler can be notified once the lock is released
+func (b *circuitBreaker) allowProbe(class string, now time.Time) (*CircuitStateChange, error) {
+	b.m.Lock()
+	defer b.m.Unlock()
+	c, ok := b.circuits[class]
+	if !ok {
+		return nil, nil
+	}
+	var change *CircuitStateChange
+	switch c.state {
+	case CircuitOpen:
+		if now.Sub(c.openedAt) < b.settings.Cooldown {
+			return nil, fmt.Errorf("%s %s %w, retrying after %s", b.name, class, ErrCircuitOpen, c.openedAt.Add(b.settings.Cooldown).Format(time.RFC3339))
+		}
+		change = b.transition(class, c, CircuitHalfOpen, nil, now)
+		c.probing = true
+	case CircuitHalfOpen:
+		if c.probing {
+			return nil, fmt.Errorf("%s %s %w, awaiting recovery probe", b.name, class, ErrCircuitOpen)
+		}
+		c.probing = true
+	}
+	return change, nil
+}
+
+// record updates a class's circuit with the outcome of a request which was
+// allowed through. Only failures of the exchange to serve the request count
+// towards opening the circuit, other errors leave the circuit unchanged
+func (b *circuitBreaker) record(class string, err error, now time.Time) {
+	if b == nil {
+		return
+	}
+	b.notify(b.recordOutcome(class, err, now))
+}
+
+// recordOutcome applies record under lock, returning any state change so the
+// handler can be notified once the lock is released
+func (b *circuitBreaker) recordOutcome(class string, err error, now time.Time) *CircuitStateChange {
+	var ve *venueError
+	failed := errors.As(err, &ve)
+	b.m.Lock()
+	defer b.m.Unlock()
+	c, ok := b.circuits[class]
+	if !ok {
+		if !failed {
+			return nil
+		}
+		c = &circuit{}
+		b.circuits[class] = c
+	}
+	c.probing = false
+	if !failed {
+		if err != nil {
+			return nil
+		}
+		c.failures = 0
+		if c.state == CircuitClosed {
+			return nil
+		}
+		return b.transition(class, c, CircuitClosed, nil, now)
+	}
+	c.failures++
+	threshold := b.settings.PublicFailureThreshold
+	if class == PrivateEndpoints {
+		threshold = b.settings.PrivateFailureThreshold
+	}
+	if c.state == CircuitHalfOpen || (c.state == CircuitClosed && c.failures >= threshold) {
+		c.openedAt = now
+		return b.transition(class, c, CircuitOpen, ve.err, now)
+	}
+	return nil
+}
+
+// transition changes a circuit's state, b.m must be held. The returned change
+// must be passed to notify after b.m is released
+func (b *circuitBreaker) transition(class string, c *circuit, to CircuitState, err error, now time.Time) *CircuitStateChange {
+	change := &CircuitStateChange{
+		Exchange: b.name,
+		Class:    class,
+		From:     c.state,
+		To:       to,
+		Failures: c.failures,
+		Err:      err,
+		Time:     now,
+	}
+	c.state = to
+	return change
+}
+
+// notify calls the state change handler, if set, with a state change. It must
+// not be called while b.m is held so handlers may use the requester
+func (b *circuitBreaker) notify(change *CircuitStateChange) {
+	if change != nil && b.settings.OnStateChange != nil {
+		b.settings.OnStateChange(*change)
+	}
+}
+
+// state returns the current state of a class's circuit
+func (b *circuitBreaker) state(class string) CircuitState {
+	b.m.Lock()
+	defer b.m.Unlock()
+	if c, ok := b.circuits[class]; ok {
+		return c.state
+	}
+	return CircuitClosed
+}
+
+// isVenueFailure returns whether a response shows the exchange failing to
+// serve a request
+func isVenueFailure(resp *http.Response) bool {
+	return resp != nil && resp.StatusCode >= http.StatusInternalServerError
+}
@@ -0,0 +1,163 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetupGlobalCircuitBreaker(t *testing.T) {
+	assert.ErrorIs(t, SetupGlobalCircuitBreaker(&CircuitBreakerSettings{PublicFailureThreshold: 1, Cooldown: time.Second}), errInvalidFailureThreshold)
+	assert.ErrorIs(t, SetupGlobalCircuitBreaker(&CircuitBreakerSettings{PublicFailureThreshold: 1, PrivateFailureThreshold: 1}), errInvalidCircuitCooldown)
+	s := &CircuitBreakerSettings{PublicFailureThreshold: 1, PrivateFailureThreshold: 1, Cooldown: time.Second}
+	require.NoError(t, SetupGlobalCircuitBreaker(s))
+	r, err := New("test", new(http.Client))
+	require.NoError(t, SetupGlobalCircuitBreaker(nil))
+	require.NoError(t, err)
+	require.NotNil(t, r.breaker, "requesters should use the global circuit breaker")
+	assert.Equal(t, *s, r.breaker.settings)
+	r, err = New("test", new(http.Client))
+	require.NoError(t, err)
+	assert.Nil(t, r.breaker, "requesters should not use a circuit breaker when unset")
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	t.Parallel()
+	var changes []CircuitStateChange
+	var b *circuitBreaker
+	b = newCircuitBreaker("test", &CircuitBreakerSettings{
+		PublicFailureThreshold:  2,
+		PrivateFailureThreshold: 1,
+		Cooldown:                time.Minute,
+		OnStateChange: func(c CircuitStateChange) {
+			assert.Equal(t, c.To, b.state(c.Class), "handlers should be called once the breaker is unlocked")
+			changes = append(changes, c)
+		},
+	})
+	now := time.Now()
+	failure := &venueError{errors.New("bad gateway")}
+
+	require.NoError(t, b.allow(PublicEndpoints, now))
+	b.record(PublicEndpoints, failure, now)
+	assert.Equal(t, CircuitClosed, b.state(PublicEndpoints), "failures below the threshold should not open the circuit")
+	b.record(PublicEndpoints, nil, now)
+	b.record(PublicEndpoints, failure, now)
+	b.record(PublicEndpoints, errors.New("insufficient funds"), now)
+	assert.Equal(t, CircuitClosed, b.state(PublicEndpoints), "successes should reset consecutive failures and rejections should not count")
+	b.record(PublicEndpoints, failure, now)
+	assert.Equal(t, CircuitOpen, b.state(PublicEndpoints))
+	assert.Equal(t, CircuitClosed, b.state(PrivateEndpoints), "classes should be tracked separately")
+	require.Len(t, changes, 1)
+	assert.Equal(t, CircuitStateChange{Exchange: "test", Class: PublicEndpoints, From: CircuitClosed, To: CircuitOpen, Failures: 2, Err: failure.err, Time: now}, changes[0])
+	assert.Equal(t, "test public circuit open after 2 consecutive failures: bad gateway", changes[0].String())
+
+	assert.ErrorIs(t, b.allow(PublicEndpoints, now.Add(time.Second)), ErrCircuitOpen, "requests should be short-circuited while open")
+	require.NoError(t, b.allow(PrivateEndpoints, now))
+
+	require.NoError(t, b.allow(PublicEndpoints, now.Add(time.Minute)), "a probe should be allowed after the cooldown")
+	assert.Equal(t, CircuitHalfOpen, b.state(PublicEndpoints))
+	assert.ErrorIs(t, b.allow(PublicEndpoints, now.Add(time.Minute)), ErrCircuitOpen, "only one probe should be allowed at a time")
+	b.record(PublicEndpoints, failure, now.Add(time.Minute))
+	assert.Equal(t, CircuitOpen, b.state(PublicEndpoints), "a failed probe should reopen the circuit")
+	assert.ErrorIs(t, b.allow(PublicEndpoints, now.Add(time.Minute*2-time.Second)), ErrCircuitOpen, "a failed probe should restart the cooldown")
+
+	require.NoError(t, b.allow(PublicEndpoints, now.Add(time.Minute*2)))
+	b.record(PublicEndpoints, context.Canceled, now.Add(time.Minute*2))
+	assert.Equal(t, CircuitHalfOpen, b.state(PublicEndpoints), "probes which don't reach the exchange should not change state")
+	require.NoError(t, b.allow(PublicEndpoints, now.Add(time.Minute*2)), "another probe should be allowed")
+	b.record(PublicEndpoints, nil, now.Add(time.Minute*2))
+	assert.Equal(t, CircuitClosed, b.state(PublicEndpoints), "a successful probe should close the circuit")
+	require.Len(t, changes, 5)
+	assert.Equal(t, CircuitClosed, changes[4].To)
+	assert.Equal(t, "test public circuit closed, requests recovered", changes[4].String())
+
+	b.record(PrivateEndpoints, failure, now)
+	assert.Equal(t, CircuitOpen, b.state(PrivateEndpoints), "the private threshold should apply to private requests")
+
+	var nilBreaker *circuitBreaker
+	assert.NoError(t, nilBreaker.allow(PublicEndpoints, now))
+	nilBreaker.record(PublicEndpoints, failure, now)
+}
+
+func TestCircuitStateString(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "closed", CircuitClosed.String())
+	assert.Equal(t, "open", CircuitOpen.String())
+	assert.Equal(t, "half-open", CircuitHalfOpen.String())
+	assert.Equal(t, "unknown", CircuitState(99).String())
+}
+
+func TestSendPayloadCircuitBreaker(t *testing.T) {
+	t.Parallel()
+	var status atomic.Int32
+	status.Store(http.StatusBadGateway)
+	var served atomic.Int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		served.Add(1)
+		w.WriteHeader(int(status.Load()))
+		_, err := w.Write([]byte("{}"))
+		assert.NoError(t, err)
+	}))
+	defer s.Close()
+
+	var m sync.Mutex
+	var changes []CircuitStateChange
+	r, err := New("test", new(http.Client), WithCircuitBreaker(&CircuitBreakerSettings{
+		PublicFailureThreshold:  2,
+		PrivateFailureThreshold: 2,
+		Cooldown:                time.Millisecond * 50,
+		OnStateChange: func(c CircuitStateChange) {
+			m.Lock()
+			changes = append(changes, c)
+			m.Unlock()
+		},
+	}))
+	require.NoError(t, err)
+	send := func(path string, requestType AuthType) error {
+		return r.SendPayload(context.Background(), Unset, func() (*Item, error) {
+			return &Item{Method: http.MethodGet, Path: s.URL + path}, nil
+		}, requestType)
+	}
+
+	status.Store(http.StatusBadRequest)
+	for range 3 {
+		assert.Error(t, send("/", UnauthenticatedRequest))
+	}
+	assert.Equal(t, CircuitClosed, r.CircuitState(PublicEndpoints), "client errors should not open the circuit")
+
+	status.Store(http.StatusBadGateway)
+	for range 2 {
+		err = send("/", UnauthenticatedRequest)
+		require.Error(t, err)
+		var ve *venueError
+		assert.False(t, errors.As(err, &ve), "venue errors should not be returned to callers")
+	}
+	assert.Equal(t, CircuitOpen, r.CircuitState(PublicEndpoints))
+	assert.Equal(t, CircuitClosed, r.CircuitState(PrivateEndpoints))
+	servedBefore := served.Load()
+	assert.ErrorIs(t, send("/", UnauthenticatedRequest), ErrCircuitOpen)
+	assert.Equal(t, servedBefore, served.Load(), "short-circuited requests should not be sent")
+	err = send("/", AuthenticatedRequest)
+	assert.ErrorIs(t, err, ErrAuthRequestFailed)
+	assert.NotErrorIs(t, err, ErrCircuitOpen, "private requests should not be short-circuited by the public circuit")
+
+	status.Store(http.StatusOK)
+	time.Sleep(time.Millisecond * 50)
+	require.NoError(t, send("/", UnauthenticatedRequest), "a probe should be sent after the cooldown")
+	assert.Equal(t, CircuitClosed, r.CircuitState(PublicEndpoints), "a successful probe should close the circuit")
+	m.Lock()
+	defer m.Unlock()
+	require.Len(t, changes, 3)
+	assert.Equal(t, []CircuitState{CircuitOpen, CircuitHalfOpen, CircuitClosed}, []CircuitState{changes[0].To, changes[1].To, changes[2].To})
+
+	var nilRequester *Requester
+	assert.Equal(t, CircuitClosed, nilRequester.CircuitState(PublicEndpoints))
+}
@@ -0,0 +1,446 @@
+package request
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/thrasher-corp/gocryptotrader/common/codec"
+	"github.com/thrasher-corp/gocryptotrader/common/file"
+)
+
+// FixtureMode determines whether a FixtureTransport sends requests and records
+// their responses or replays recorded responses
+type FixtureMode uint8
+
+const (
+	// FixtureReplay serves recorded responses without network access
+	FixtureReplay FixtureMode = iota
+	// FixtureRecord sends requests and records sanitised request and response
+	// pairs
+	FixtureRecord
+)
+
+// fixtureRedacted replaces the values of sensitive fields in fixtures
+const fixtureRedacted = "REDACTED"
+
+var (
+	errFixtureNotFound    = errors.New("no recorded fixture matches request")
+	errInvalidFixtureMode = errors.New("invalid fixture mode")
+	errFixtureNotRecorded = errors.New("fixture transport is not recording")
+
+	// fixtureRequestRedactions are substrings of request query, form and JSON
+	// body keys whose values are redacted. Signatures, nonces and timestamps
+	// are redacted so replayed requests match their recordings
+	fixtureRequestRedactions = []string{"key", "secret", "sign", "pass", "token", "nonce", "timestamp", "recvwindow", "otp"}
+	// fixtureResponseRedactions are response JSON keys whose values are
+	// redacted to avoid storing credentials and account identifiers
+	fixtureResponseRedactions = []string{"apikey", "api_key", "secret", "passphrase", "password", "token", "email", "address", "addresstag", "address_tag", "memo", "uid", "userid", "user_id", "accountid", "account_id", "username"}
+)
+
+// Fixture holds recorded request and response pairs
+type Fixture struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction holds a sanitised request and the response it received
+type Interaction struct {
+	Request  FixtureRequest  `json:"request"`
+	Response FixtureResponse `json:"response"`
+}
+
+// FixtureRequest holds a sanitised request
+type FixtureRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// FixtureResponse holds a sanitised response
+type FixtureResponse struct {
+	StatusCode  int             `json:"statusCode"`
+	ContentType string          `json:"contentType,omitempty"`
+	Body        json.RawMessage `json:"body,omitempty"`
+	// Text holds response bodies which are not JSON
+	Text string `json:"text,omitempty"`
+}
+
+// FixtureTransport is a http.RoundTripper which records sanitised request and
+// response pairs to a fixture file, or replays them so exchange endpoints can
+// be tested without network access. When recording over an existing fixture,
+// changes to the structure of responses are reported as API drift
+type FixtureTransport struct {
+	mode     FixtureMode
+	path     string
+	next     http.RoundTripper
+	m        sync.Mutex
+	fixture  Fixture
+	previous Fixture
+	served   map[int]bool
+	drift    []string
+}
+
+// NewFixtureTransport returns a FixtureTransport for a fixture file. Replaying
+// requires the fixture file to exist. When recording, requests are sent using
+// next, or http.DefaultTransport when nil. Fixtures are stored in the codec
+// matching the file extension, such as msgpack for large recordings, and
+// otherwise as JSON
+func NewFixtureTransport(path string, mode FixtureMode, next http.RoundTripper) (*FixtureTransport, error) {
+	t := &FixtureTransport{
+		mode:   mode,
+		path:   path,
+		next:   next,
+		served: make(map[int]bool),
+	}
+	if t.next == nil {
+		t.next = http.DefaultTransport
+	}
+	contents, err := os.ReadFile(path)
+	switch mode {
+	case FixtureReplay:
+		if err != nil {
+			return nil, err
+		}
+		if err := codec.ForPath(path).Unmarshal(contents, &t.fixture); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	case FixtureRecord:
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				return nil, err
+			}
+			break
+		}
+		if err := codec.ForPath(path).Unmarshal(contents, &t.previous); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("%w: %d", errInvalidFixtureMode, mode)
+	}
+	return t, nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *FixtureTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	fr, err := sanitiseFixtureRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	if t.mode == FixtureReplay {
+		return t.replay(req, fr)
+	}
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	contents, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if err := resp.Body.Close(); err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(contents))
+	i := Interaction{
+		Request:  *fr,
+		Response: sanitiseFixtureResponse(resp.StatusCode, resp.Header.Get("Content-Type"), contents),
+	}
+	t.m.Lock()
+	t.fixture.Interactions = append(t.fixture.Interactions, i)
+	if prev := findInteraction(t.previous.Interactions, fr, nil); prev != nil {
+		for _, d := range fixtureDrift(prev.Response.Body, i.Response.Body) {
+			t.drift = append(t.drift, fr.Method+" "+fr.URL+": "+d)
+		}
+	}
+	t.m.Unlock()
+	return resp, nil
+}
+
+// replay returns the recorded response for a request. Each matching recording
+// is served in turn, with the last being served for any further requests
+func (t *FixtureTransport) replay(req *http.Request, fr *FixtureRequest) (*http.Response, error) {
+	t.m.Lock()
+	defer t.m.Unlock()
+	i := findInteraction(t.fixture.Interactions, fr, t.served)
+	if i == nil {
+		i = findInteraction(t.fixture.Interactions, fr, nil)
+	}
+	if i == nil {
+		return nil, fmt.Errorf("%w: %s %s", errFixtureNotFound, fr.Method, fr.URL)
+	}
+	body := []byte(i.Response.Text)
+	if len(i.Response.Body) > 0 {
+		body = i.Response.Body
+	}
+	header := make(http.Header)
+	if i.Response.ContentType != "" {
+		header.Set("Content-Type", i.Response.ContentType)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", i.Response.StatusCode, http.StatusText(i.Response.StatusCode)),
+		StatusCode:    i.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+// findInteraction returns the first interaction matching a request. When
+// served is not nil, served interactions are skipped and the match is marked
+// as served
+func findInteraction(interactions []Interaction, fr *FixtureRequest, served map[int]bool) *Interaction {
+	for x := range interactions {
+		if interactions[x].Request != *fr || served[x] {
+			continue
+		}
+		if served != nil {
+			served[x] = true
+		}
+		return &interactions[x]
+	}
+	return nil
+}
+
+// Save writes the recorded interactions to the fixture file
+func (t *FixtureTransport) Save() error {
+	if t.mode != FixtureRecord {
+		return errFixtureNotRecorded
+	}
+	t.m.Lock()
+	defer t.m.Unlock()
+	var payload []byte
+	var err error
+	if c := codec.ForPath(t.path); c.Name() == codec.JSON {
+		payload, err = json.MarshalIndent(t.fixture, "", " ")
+	} else {
+		payload, err = c.Marshal(t.fixture)
+	}
+	if err != nil {
+		return err
+	}
+	return file.Write(t.path, payload)
+}
+
+// Drift returns the changes in response structure between the previous
+// recording and the current recording, such as removed fields or fields which
+// have changed type
+func (t *FixtureTransport) Drift() []string {
+	t.m.Lock()
+	defer t.m.Unlock()
+	return append([]string(nil), t.drift...)
+}
+
+// sanitiseFixtureRequest returns a request with sensitive values redacted so
+// it can be stored and matched
+func sanitiseFixtureRequest(req *http.Request) (*FixtureRequest, error) {
+	u := *req.URL
+	u.RawQuery = redactURLValues(u.Query()).Encode()
+	fr := &FixtureRequest{Method: req.Method, URL: u.String()}
+	var body io.ReadCloser
+	switch {
+	case req.GetBody != nil:
+		b, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		body = b
+	case req.Body != nil && req.Body != http.NoBody:
+		contents, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		if err := req.Body.Close(); err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(contents))
+		body = io.NopCloser(bytes.NewReader(contents))
+	default:
+		return fr, nil
+	}
+	contents, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	if err := body.Close(); err != nil {
+		return nil, err
+	}
+	if len(contents) == 0 {
+		return fr, nil
+	}
+	if json.Valid(contents) {
+		redacted, err := redactJSON(contents, isFixtureRequestRedaction)
+		if err != nil {
+			return nil, err
+		}
+		fr.Body = string(redacted)
+		return fr, nil
+	}
+	if vals, err := url.ParseQuery(string(contents)); err == nil && strings.Contains(string(contents), "=") {
+		fr.Body = redactURLValues(vals).Encode()
+		return fr, nil
+	}
+	fr.Body = string(contents)
+	return fr, nil
+}
+
+// sanitiseFixtureResponse returns a response with sensitive values redacted
+func sanitiseFixtureResponse(statusCode int, contentType string, contents []byte) FixtureResponse {
+	resp := FixtureResponse{StatusCode: statusCode, ContentType: contentType}
+	if len(contents) == 0 {
+		return resp
+	}
+	if json.Valid(contents) {
+		if redacted, err := redactJSON(contents, isFixtureResponseRedaction); err == nil {
+			resp.Body = redacted
+			return resp
+		}
+	}
+	resp.Text = string(contents)
+	return resp
+}
+
+// redactURLValues redacts the values of sensitive request parameters
+func redactURLValues(vals url.Values) url.Values {
+	for k := range vals {
+		if isFixtureRequestRedaction(k) {
+			vals.Set(k, fixtureRedacted)
+		}
+	}
+	return vals
+}
+
+// isFixtureRequestRedaction returns whether a request parameter is sensitive
+func isFixtureRequestRedaction(key string) bool {
+	key = strings.ToLower(key)
+	for i := range fixtureRequestRedactions {
+		if strings.Contains(key, fixtureRequestRedactions[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// isFixtureResponseRedaction returns whether a response field is sensitive
+func isFixtureResponseRedaction(key string) bool {
+	for i := range fixtureResponseRedactions {
+		if strings.EqualFold(key, fixtureResponseRedactions[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactJSON redacts the string and number values of sensitive JSON object
+// fields. Numbers are decoded as json.Number to retain their precision
+func redactJSON(contents []byte, redact func(string) bool) (json.RawMessage, error) {
+	d := json.NewDecoder(bytes.NewReader(contents))
+	d.UseNumber()
+	var v any
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+	return json.Marshal(redactJSONValue(v, redact))
+}
+
+func redactJSONValue(v any, redact func(string) bool) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, field := range val {
+			switch field.(type) {
+			case string:
+				if redact(k) {
+					val[k] = fixtureRedacted
+				}
+			case json.Number:
+				if redact(k) {
+					val[k] = json.Number("0")
+				}
+			default:
+				val[k] = redactJSONValue(field, redact)
+			}
+		}
+	case []any:
+		for i := range val {
+			val[i] = redactJSONValue(val[i], redact)
+		}
+	}
+	return v
+}
+
+// fixtureDrift returns the differences in structure between two JSON
+// responses. Fields which are null in either response are not compared, as
+// they are commonly optional
+func fixtureDrift(previous, current json.RawMessage) []string {
+	prevShape, currShape := make(map[string]string), make(map[string]string)
+	jsonShape(previous, "$", prevShape)
+	jsonShape(current, "$", currShape)
+	var drift []string
+	for path, prevKind := range prevShape {
+		currKind, ok := currShape[path]
+		switch {
+		case !ok:
+			drift = append(drift, "removed "+path)
+		case prevKind != currKind && prevKind != "null" && currKind != "null":
+			drift = append(drift, fmt.Sprintf("%s changed from %s to %s", path, prevKind, currKind))
+		}
+	}
+	for path := range currShape {
+		if _, ok := prevShape[path]; !ok {
+			drift = append(drift, "added "+path)
+		}
+	}
+	sort.Strings(drift)
+	return drift
+}
+
+// jsonShape records the kind of each path within a JSON value. Array elements
+// share the path of their array suffixed with []
+func jsonShape(contents json.RawMessage, path string, shape map[string]string) {
+	if len(contents) == 0 {
+		return
+	}
+	var v any
+	d := json.NewDecoder(bytes.NewReader(contents))
+	d.UseNumber()
+	if err := d.Decode(&v); err != nil {
+		return
+	}
+	valueShape(v, path, shape)
+}
+
+func valueShape(v any, path string, shape map[string]string) {
+	switch val := v.(type) {
+	case map[string]any:
+		shape[path] = "object"
+		for k, field := range val {
+			valueShape(field, path+"."+k, shape)
+		}
+	case []any:
+		shape[path] = "array"
+		for i := range val {
+			valueShape(val[i], path+"[]", shape)
+		}
+	case string:
+		shape[path] = "string"
+	case json.Number:
+		shape[path] = "number"
+	case bool:
+		shape[path] = "bool"
+	case nil:
+		if _, ok := shape[path]; !ok {
+			shape[path] = "null"
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package request
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/common/codec"
+)
+
+func TestNewFixtureTransport(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	_, err := NewFixtureTransport(path, FixtureReplay, nil)
+	assert.ErrorIs(t, err, os.ErrNotExist, "replaying should require a fixture file")
+	_, err = NewFixtureTransport(path, 2, nil)
+	assert.ErrorIs(t, err, errInvalidFixtureMode)
+	ft, err := NewFixtureTransport(path, FixtureRecord, nil)
+	require.NoError(t, err, "recording should not require a fixture file")
+	assert.Equal(t, http.DefaultTransport, ft.next, "recording should default to the default transport")
+
+	require.NoError(t, os.WriteFile(path, []byte("bad"), 0o600))
+	_, err = NewFixtureTransport(path, FixtureReplay, nil)
+	assert.Error(t, err, "invalid fixtures should error")
+	_, err = NewFixtureTransport(path, FixtureRecord, nil)
+	assert.Error(t, err, "invalid previous fixtures should error")
+}
+
+func TestFixtureRecordAndReplay(t *testing.T) {
+	t.Parallel()
+	for _, ext := range []string{".json", ".msgpack"} {
+		t.Run(ext, func(t *testing.T) {
+			t.Parallel()
+			testFixtureRecordAndReplay(t, ext)
+		})
+	}
+}
+
+func testFixtureRecordAndReplay(t *testing.T, ext string) {
+	t.Helper()
+	var served atomic.Int32
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		served.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_, err := w.Write([]byte(`{"price":"1337.1","id":1234567890123456789,"apiKey":"hunter2","nested":[{"email":"a@b.c"}]}`))
+		assert.NoError(t, err)
+	}))
+	defer s.Close()
+
+	path := filepath.Join(t.TempDir(), "fixture"+ext)
+	ft, err := NewFixtureTransport(path, FixtureRecord, nil)
+	require.NoError(t, err)
+	r, err := New("fixture", &http.Client{Transport: ft})
+	require.NoError(t, err)
+
+	send := func(method, path string, body string) map[string]any {
+		t.Helper()
+		var resp map[string]any
+		err := r.SendPayload(context.Background(), Unset, func() (*Item, error) {
+			i := &Item{Method: method, Path: s.URL + path, Result: &resp}
+			if body != "" {
+				i.Body = strings.NewReader(body)
+			}
+			return i, nil
+		}, UnauthenticatedRequest)
+		require.NoError(t, err)
+		return resp
+	}
+	resp := send(http.MethodGet, "/ticker?symbol=BTCUSD&signature=abc&timestamp=1", "")
+	assert.Equal(t, "hunter2", resp["apiKey"], "recorded responses should be returned unmodified")
+	send(http.MethodPost, "/order", `{"symbol":"BTCUSD","nonce":1}`)
+	err = ft.Save()
+	assert.NoError(t, err)
+	assert.Empty(t, ft.Drift(), "new recordings should not report drift")
+
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(contents), "hunter2", "fixtures should not contain credentials")
+	assert.NotContains(t, string(contents), "a@b.c", "fixtures should not contain account identifiers")
+	assert.NotContains(t, string(contents), "signature=abc", "fixtures should not contain signatures")
+	if ext == ".json" {
+		assert.Contains(t, string(contents), "1234567890123456789", "numbers should retain their precision")
+	} else {
+		assert.False(t, json.Valid(contents), "fixtures should be stored in the codec matching their extension")
+		var f Fixture
+		require.NoError(t, codec.ForPath(path).Unmarshal(contents, &f))
+		require.Len(t, f.Interactions, 2)
+		assert.Contains(t, string(f.Interactions[0].Response.Body), "1234567890123456789", "numbers should retain their precision")
+	}
+
+	ft, err = NewFixtureTransport(path, FixtureReplay, nil)
+	require.NoError(t, err)
+	require.NoError(t, r.SetHTTPClient(&http.Client{Transport: ft}))
+	servedBefore := served.Load()
+	resp = send(http.MethodGet, "/ticker?timestamp=2&signature=def&symbol=BTCUSD", "")
+	assert.Equal(t, "1337.1", resp["price"], "replayed requests should match with differing signatures and timestamps")
+	assert.Equal(t, fixtureRedacted, resp["apiKey"])
+	send(http.MethodPost, "/order", `{"nonce":2,"symbol":"BTCUSD"}`)
+	send(http.MethodPost, "/order", `{"nonce":3,"symbol":"BTCUSD"}`)
+	assert.Equal(t, servedBefore, served.Load(), "replayed requests should not be sent")
+
+	err = r.SendPayload(context.Background(), Unset, func() (*Item, error) {
+		return &Item{Method: http.MethodGet, Path: s.URL + "/ticker?symbol=ETHUSD"}, nil
+	}, UnauthenticatedRequest)
+	assert.ErrorIs(t, err, errFixtureNotFound)
+	err = ft.Save()
+	assert.ErrorIs(t, err, errFixtureNotRecorded)
+}
+
+func TestFixtureReplayText(t *testing.T) {
+	t.Parallel()
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+		_, err := w.Write([]byte("pong"))
+		assert.NoError(t, err)
+	}))
+	defer s.Close()
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	ft, err := NewFixtureTransport(path, FixtureRecord, nil)
+	require.NoError(t, err)
+	c := &http.Client{Transport: ft}
+	resp, err := c.Post(s.URL, "application/x-www-form-urlencoded", strings.NewReader("a=1&sign=x"))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	err = ft.Save()
+	require.NoError(t, err)
+
+	ft, err = NewFixtureTransport(path, FixtureReplay, nil)
+	require.NoError(t, err)
+	c = &http.Client{Transport: ft}
+	resp, err = c.Post(s.URL, "application/x-www-form-urlencoded", strings.NewReader("sign=y&a=1"))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusTeapot, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "pong", string(body), "non JSON responses should be replayed")
+}
+
+func TestFixtureDrift(t *testing.T) {
+	t.Parallel()
+	body := `{"price":"1","size":1,"bids":[["1","2"]],"extra":null}`
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, err := w.Write([]byte(body))
+		assert.NoError(t, err)
+	}))
+	defer s.Close()
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	record := func() *FixtureTransport {
+		t.Helper()
+		ft, err := NewFixtureTransport(path, FixtureRecord, nil)
+		require.NoError(t, err)
+		resp, err := (&http.Client{Transport: ft}).Get(s.URL + "/ticker")
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		err = ft.Save()
+		require.NoError(t, err)
+		return ft
+	}
+	assert.Empty(t, record().Drift())
+	assert.Empty(t, record().Drift(), "unchanged responses should not report drift")
+	body = `{"price":1,"bids":[["1","2"]],"extra":"x","new":true}`
+	assert.Equal(t, []string{
+		"GET " + s.URL + "/ticker: $.price changed from string to number",
+		"GET " + s.URL + "/ticker: added $.new",
+		"GET " + s.URL + "/ticker: removed $.size",
+	}, record().Drift())
+}
@@ -34,6 +34,11 @@ func (b *BasicLimit) Limit(ctx context.Context, _ EndpointLimit) error {
 	return b.r.Wait(ctx)
 }
 
+// LimiterFor returns the rate limiter shared by all endpoints
+func (b *BasicLimit) LimiterFor(EndpointLimit) (limiter *rate.Limiter, cost int) {
+	return b.r, 1
+}
+
 // EndpointLimit defines individual endpoint rate limits that are set when
 // New is called.
 type EndpointLimit int
@@ -59,28 +64,131 @@ func NewRateLimit(interval time.Duration, actions int) *rate.Limiter {
 	return rate.NewLimiter(rate.Limit(rps), 1)
 }
 
+// WaitForTokens reserves tokens from a limiter one at a time, which avoids
+// needing burst capacity that would otherwise allow the rate limit to be
+// exceeded over short periods, then waits until the last token is available.
+// The reservations are returned to the limiter when the delay would exceed the
+// context deadline or the context is done while waiting
+func WaitForTokens(ctx context.Context, limiter *rate.Limiter, tokens int) error {
+	reserves := make([]*rate.Reservation, tokens)
+	var finalDelay time.Duration
+	for i := range reserves {
+		reserves[i] = limiter.Reserve()
+		finalDelay = reserves[i].Delay()
+	}
+	cancelReserves := func() {
+		// Cancel the latest first so each restores its tokens in full
+		for i := len(reserves) - 1; i >= 0; i-- {
+			reserves[i].Cancel()
+		}
+	}
+	if dl, ok := ctx.Deadline(); ok && dl.Before(time.Now().Add(finalDelay)) {
+		cancelReserves()
+		return fmt.Errorf("rate limit delay of %s will exceed deadline: %w",
+			finalDelay,
+			context.DeadlineExceeded)
+	}
+	if finalDelay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(finalDelay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		cancelReserves()
+		return context.Cause(ctx)
+	}
+}
+
 // NewBasicRateLimit returns an object that implements the limiter interface
 // for basic rate limit
 func NewBasicRateLimit(interval time.Duration, actions int) Limiter {
 	return &BasicLimit{NewRateLimit(interval, actions)}
 }
 
+// requesterLimiter draws from a requester's rate limits
+type requesterLimiter struct {
+	r *Requester
+}
+
+// Limit waits on the requester's rate limit for an endpoint
+func (l requesterLimiter) Limit(ctx context.Context, e EndpointLimit) error {
+	return l.r.InitiateRateLimit(ctx, e)
+}
+
+// GetLimiter returns a Limiter which draws from the same rate limit budget as
+// the requester's REST requests, so that other transports such as websocket
+// connections sharing an exchange's budget don't exceed it. The limiter
+// respects the requester's rate limiter being disabled
+func (r *Requester) GetLimiter() Limiter {
+	return requesterLimiter{r}
+}
+
 // InitiateRateLimit sleeps for designated end point rate limits
 func (r *Requester) InitiateRateLimit(ctx context.Context, e EndpointLimit) error {
 	if r == nil {
 		return ErrRequestSystemIsNil
 	}
-	if atomic.LoadInt32(&r.disableRateLimiter) == 1 {
-		return nil
+	start := time.Now()
+	if atomic.LoadInt32(&r.disableRateLimiter) == 0 && r.limiter != nil {
+		if err := r.limitByPriority(ctx, e); err != nil {
+			return err
+		}
 	}
 
-	if r.limiter != nil {
-		return r.limiter.Limit(ctx, e)
+	if r.limitStats != nil {
+		now := time.Now()
+		r.limitStats.record(e, now, now.Sub(start))
 	}
-
 	return nil
 }
 
+// limitByPriority waits on the rate limiter once it is the request's turn.
+// Requests preempted by a higher priority request rejoin the queue
+func (r *Requester) limitByPriority(ctx context.Context, e EndpointLimit) error {
+	p := PriorityFromContext(ctx, PriorityMarketData)
+	q := r.queueFor(e)
+	for {
+		turn, err := q.acquire(ctx, p)
+		if err != nil {
+			return err
+		}
+		err = r.limiter.Limit(turn.ctx, e)
+		preempted := err != nil && turn.preempted() && ctx.Err() == nil
+		q.release(turn)
+		if !preempted {
+			return err
+		}
+	}
+}
+
+// queueFor returns the priority queue of the rate limiter an endpoint draws
+// from, so requests only wait behind those competing for the same budget.
+// Limiters which don't report their rate limiter share the requester's queue
+func (r *Requester) queueFor(e EndpointLimit) *priorityQueue {
+	reporter, ok := r.limiter.(LimitReporter)
+	if !ok {
+		return &r.queue
+	}
+	limiter, _ := reporter.LimiterFor(e)
+	if limiter == nil {
+		return &r.queue
+	}
+	r.queuesMtx.Lock()
+	defer r.queuesMtx.Unlock()
+	q, ok := r.queues[limiter]
+	if !ok {
+		if r.queues == nil {
+			r.queues = make(map[*rate.Limiter]*priorityQueue)
+		}
+		q = &priorityQueue{}
+		r.queues[limiter] = q
+	}
+	return q
+}
+
 // DisableRateLimiter disables the rate limiting system for the exchange
 func (r *Requester) DisableRateLimiter() error {
 	if r == nil {
@@ -0,0 +1,155 @@
+package request
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// limitStatusWindow is the period over which the request rate of an
+	// endpoint is measured
+	limitStatusWindow = time.Minute
+	// limitWaitSamples is the number of recent rate limit waits retained per
+	// endpoint
+	limitWaitSamples = 50
+)
+
+// LimitReporter is implemented by limiters which can report the rate limiter
+// and token cost used for an endpoint, allowing the remaining rate limit budget
+// to be introspected
+type LimitReporter interface {
+	LimiterFor(EndpointLimit) (limiter *rate.Limiter, cost int)
+}
+
+// RateLimitStatus holds the recent rate limit usage of a single endpoint
+type RateLimitStatus struct {
+	Endpoint    EndpointLimit
+	LastRequest time.Time
+	// Requests is the number of requests within the measurement window and
+	// RequestRate is their rate per second
+	Requests    int
+	RequestRate float64
+	LastWait    time.Duration
+	AverageWait time.Duration
+	MaxWait     time.Duration
+	// TokensKnown is set when the limiter reports its token bucket, otherwise
+	// Tokens, Limit, Burst and Cost are not populated
+	TokensKnown bool
+	Tokens      float64
+	// Limit is the number of tokens replenished per second
+	Limit float64
+	Burst int
+	Cost  int
+	// Exhausting is set when the current request rate consumes tokens faster
+	// than they are replenished, TimeToExhaustion is then the projected time
+	// until requests start waiting
+	Exhausting       bool
+	TimeToExhaustion time.Duration
+}
+
+// limitTracker records the rate limit waits and request times of a requester's
+// endpoints
+type limitTracker struct {
+	m         sync.Mutex
+	endpoints map[EndpointLimit]*endpointUsage
+}
+
+// endpointUsage holds the recent usage of a single endpoint
+type endpointUsage struct {
+	requests []time.Time
+	waits    []time.Duration
+}
+
+func newLimitTracker() *limitTracker {
+	return &limitTracker{endpoints: make(map[EndpointLimit]*endpointUsage)}
+}
+
+// record adds a request and the time it waited on the rate limiter
+func (l *limitTracker) record(e EndpointLimit, at time.Time, wait time.Duration) {
+	l.m.Lock()
+	defer l.m.Unlock()
+	u, ok := l.endpoints[e]
+	if !ok {
+		u = &endpointUsage{}
+		l.endpoints[e] = u
+	}
+	u.requests = append(pruneRequests(u.requests, at), at)
+	if len(u.waits) == limitWaitSamples {
+		u.waits = u.waits[1:]
+	}
+	u.waits = append(u.waits, wait)
+}
+
+// pruneRequests removes request times which are outside the measurement window
+func pruneRequests(requests []time.Time, now time.Time) []time.Time {
+	cutoff := now.Add(-limitStatusWindow)
+	i := sort.Search(len(requests), func(i int) bool { return requests[i].After(cutoff) })
+	return requests[i:]
+}
+
+// GetRateLimitStatus returns the recent rate limit usage of every endpoint the
+// requester has made requests to, ordered by endpoint. Token bucket state is
+// included when the requester's limiter implements LimitReporter
+func (r *Requester) GetRateLimitStatus() ([]RateLimitStatus, error) {
+	if r == nil {
+		return nil, ErrRequestSystemIsNil
+	}
+	if r.limitStats == nil {
+		return nil, nil
+	}
+	reporter, _ := r.limiter.(LimitReporter)
+	now := time.Now()
+
+	r.limitStats.m.Lock()
+	defer r.limitStats.m.Unlock()
+	resp := make([]RateLimitStatus, 0, len(r.limitStats.endpoints))
+	for e, u := range r.limitStats.endpoints {
+		u.requests = pruneRequests(u.requests, now)
+		s := RateLimitStatus{
+			Endpoint:    e,
+			Requests:    len(u.requests),
+			RequestRate: float64(len(u.requests)) / limitStatusWindow.Seconds(),
+		}
+		if len(u.requests) > 0 {
+			s.LastRequest = u.requests[len(u.requests)-1]
+		}
+		if len(u.waits) > 0 {
+			s.LastWait = u.waits[len(u.waits)-1]
+			var total time.Duration
+			for _, w := range u.waits {
+				total += w
+				s.MaxWait = max(s.MaxWait, w)
+			}
+			s.AverageWait = total / time.Duration(len(u.waits))
+		}
+		if reporter != nil {
+			if limiter, cost := reporter.LimiterFor(e); limiter != nil {
+				s.TokensKnown = true
+				s.Tokens = limiter.TokensAt(now)
+				s.Limit = float64(limiter.Limit())
+				s.Burst = limiter.Burst()
+				s.Cost = cost
+				s.Exhausting, s.TimeToExhaustion = projectExhaustion(s.Tokens, s.Limit, s.RequestRate*float64(cost))
+			}
+		}
+		resp = append(resp, s)
+	}
+	sort.Slice(resp, func(i, j int) bool { return resp[i].Endpoint < resp[j].Endpoint })
+	return resp, nil
+}
+
+// projectExhaustion returns whether tokens are consumed faster than the limit
+// replenishes them and if so, how long until the available tokens run out
+func projectExhaustion(tokens, limit, consumption float64) (bool, time.Duration) {
+	if math.IsInf(limit, 1) || consumption <= limit {
+		return false, 0
+	}
+	if tokens <= 0 {
+		return true, 0
+	}
+	return true, time.Duration(tokens / (consumption - limit) * float64(time.Second))
+}
@@ -0,0 +1,75 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetRateLimitStatus(t *testing.T) {
+	t.Parallel()
+	var r *Requester
+	_, err := r.GetRateLimitStatus()
+	assert.ErrorIs(t, err, ErrRequestSystemIsNil)
+
+	r, err = New("test", new(http.Client), WithLimiter(NewBasicRateLimit(time.Second, 100)))
+	require.NoError(t, err, "New must not error")
+	status, err := r.GetRateLimitStatus()
+	require.NoError(t, err, "GetRateLimitStatus must not error")
+	assert.Empty(t, status, "status should be empty before any requests")
+
+	for range 3 {
+		require.NoError(t, r.InitiateRateLimit(context.Background(), Auth), "InitiateRateLimit must not error")
+	}
+	require.NoError(t, r.InitiateRateLimit(context.Background(), Unset), "InitiateRateLimit must not error")
+
+	status, err = r.GetRateLimitStatus()
+	require.NoError(t, err, "GetRateLimitStatus must not error")
+	require.Len(t, status, 2, "status must contain each endpoint used")
+	assert.Equal(t, Unset, status[0].Endpoint, "status should be ordered by endpoint")
+	s := status[1]
+	assert.Equal(t, Auth, s.Endpoint)
+	assert.Equal(t, 3, s.Requests, "Requests should count requests in the window")
+	assert.InDelta(t, 3/limitStatusWindow.Seconds(), s.RequestRate, 1e-9, "RequestRate should be per second over the window")
+	assert.False(t, s.LastRequest.IsZero(), "LastRequest should be set")
+	assert.Positive(t, s.MaxWait, "waits on a limiter with a burst of 1 should be recorded")
+	assert.GreaterOrEqual(t, s.MaxWait, s.AverageWait, "MaxWait should be at least AverageWait")
+	assert.True(t, s.TokensKnown, "BasicLimit should report its tokens")
+	assert.Equal(t, 100.0, s.Limit, "Limit should be tokens per second")
+	assert.Equal(t, 1, s.Burst)
+	assert.Equal(t, 1, s.Cost)
+	assert.False(t, s.Exhausting, "a low request rate should not exhaust the limit")
+}
+
+func TestLimitTrackerRecord(t *testing.T) {
+	t.Parallel()
+	l := newLimitTracker()
+	now := time.Now()
+	l.record(Auth, now.Add(-limitStatusWindow*2), time.Second)
+	for i := range limitWaitSamples {
+		l.record(Auth, now, time.Duration(i))
+	}
+	u := l.endpoints[Auth]
+	assert.Len(t, u.requests, limitWaitSamples, "requests outside the window should be pruned")
+	require.Len(t, u.waits, limitWaitSamples, "waits must be bounded")
+	assert.Equal(t, time.Duration(0), u.waits[0], "oldest wait should be dropped once full")
+}
+
+func TestProjectExhaustion(t *testing.T) {
+	t.Parallel()
+	exhausting, d := projectExhaustion(10, 1, 0.5)
+	assert.False(t, exhausting, "consumption below the limit should not exhaust")
+	assert.Zero(t, d)
+
+	exhausting, d = projectExhaustion(10, 1, 3)
+	assert.True(t, exhausting, "consumption above the limit should exhaust")
+	assert.Equal(t, time.Second*5, d, "tokens should be exhausted at the net consumption rate")
+
+	exhausting, d = projectExhaustion(-1, 1, 3)
+	assert.True(t, exhausting, "exhausted tokens should be exhausting")
+	assert.Zero(t, d, "exhausted tokens should have no time remaining")
+}
@@ -27,3 +27,15 @@ func WithReporter(rep Reporter) RequesterOption {
 		r.reporter = rep
 	}
 }
+
+// WithCircuitBreaker configures a circuit breaker for a Requester, overriding
+// the global circuit breaker. A nil value disables the circuit breaker.
+func WithCircuitBreaker(s *CircuitBreakerSettings) RequesterOption {
+	return func(r *Requester) {
+		if s == nil {
+			r.breaker = nil
+			return
+		}
+		r.breaker = newCircuitBreaker(r.name, s)
+	}
+}
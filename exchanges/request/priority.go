@@ -0,0 +1,150 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Request priorities, higher priorities take the rate limit budget first
+const (
+	UnsetPriority Priority = iota
+	// PriorityBackfill is for background downloads such as historic candles
+	// and trades
+	PriorityBackfill
+	// PriorityMarketData is the default priority of unauthenticated requests
+	PriorityMarketData
+	// PriorityAccount is the default priority of authenticated requests
+	PriorityAccount
+	// PriorityOrder is for order submission, modification and cancellation
+	PriorityOrder
+	priorityCount
+)
+
+const contextPriorityFlag priorityKey = "priority"
+
+var (
+	errInvalidPriority = errors.New("invalid request priority")
+	errPreempted       = errors.New("rate limit wait preempted by a higher priority request")
+)
+
+// Priority is the class of a request when waiting for rate limit budget
+type Priority uint8
+
+type priorityKey string
+
+// String returns the name of the priority
+func (p Priority) String() string {
+	switch p {
+	case PriorityBackfill:
+		return "backfill"
+	case PriorityMarketData:
+		return "market data"
+	case PriorityAccount:
+		return "account"
+	case PriorityOrder:
+		return "order"
+	default:
+		return "unset"
+	}
+}
+
+// WithPriority sets the priority of requests sent with the context. Requests
+// without a priority are prioritised as market data when unauthenticated and
+// as account queries when authenticated
+func WithPriority(ctx context.Context, p Priority) context.Context {
+	return context.WithValue(ctx, contextPriorityFlag, p)
+}
+
+// PriorityFromContext returns the priority set on a context, or the fallback
+// when unset or invalid
+func PriorityFromContext(ctx context.Context, fallback Priority) Priority {
+	if p, ok := ctx.Value(contextPriorityFlag).(Priority); ok && p > UnsetPriority && p < priorityCount {
+		return p
+	}
+	return fallback
+}
+
+// priorityQueue hands the turn to wait on a rate limiter to one request at a
+// time, highest priority first and in arrival order within a priority. A
+// lower priority request holding the turn is preempted when a higher priority
+// request arrives, returning its reservation and rejoining the queue, so
+// background requests cannot hold budget ahead of latency critical ones
+type priorityQueue struct {
+	m       sync.Mutex
+	active  *priorityTurn
+	waiting [priorityCount][]*priorityTurn
+}
+
+// priorityTurn is a request's turn to wait on the rate limiter
+type priorityTurn struct {
+	priority Priority
+	ready    chan struct{}
+	ctx      context.Context
+	cancel   context.CancelCauseFunc
+}
+
+// acquire waits for a turn to wait on the rate limiter. The returned context
+// is cancelled with errPreempted if a higher priority request arrives
+func (q *priorityQueue) acquire(ctx context.Context, p Priority) (*priorityTurn, error) {
+	if p <= UnsetPriority || p >= priorityCount {
+		return nil, errInvalidPriority
+	}
+	t := &priorityTurn{priority: p, ready: make(chan struct{})}
+	t.ctx, t.cancel = context.WithCancelCause(ctx)
+	q.m.Lock()
+	if q.active == nil {
+		q.active = t
+		q.m.Unlock()
+		return t, nil
+	}
+	if p > q.active.priority {
+		q.active.cancel(errPreempted)
+	}
+	q.waiting[p] = append(q.waiting[p], t)
+	q.m.Unlock()
+
+	select {
+	case <-t.ready:
+		return t, nil
+	case <-ctx.Done():
+		q.m.Lock()
+		for i, w := range q.waiting[p] {
+			if w == t {
+				q.waiting[p] = append(q.waiting[p][:i], q.waiting[p][i+1:]...)
+				q.m.Unlock()
+				t.cancel(nil)
+				return nil, ctx.Err()
+			}
+		}
+		q.m.Unlock()
+		// The turn was handed over as the context finished, pass it on
+		q.release(t)
+		return nil, ctx.Err()
+	}
+}
+
+// release hands the turn to the highest priority waiting request
+func (q *priorityQueue) release(t *priorityTurn) {
+	t.cancel(nil)
+	q.m.Lock()
+	defer q.m.Unlock()
+	if q.active != t {
+		return
+	}
+	q.active = nil
+	for p := priorityCount - 1; p > UnsetPriority; p-- {
+		if len(q.waiting[p]) == 0 {
+			continue
+		}
+		q.active = q.waiting[p][0]
+		q.waiting[p] = q.waiting[p][1:]
+		close(q.active.ready)
+		return
+	}
+}
+
+// preempted returns whether a turn was cancelled for a higher priority request
+func (t *priorityTurn) preempted() bool {
+	return errors.Is(context.Cause(t.ctx), errPreempted)
+}
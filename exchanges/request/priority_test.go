@@ -0,0 +1,135 @@
+package request
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+)
+
+// gatedLimiter blocks each request until the gate is opened or its context
+// is cancelled
+type gatedLimiter struct {
+	entered chan EndpointLimit
+	gate    chan struct{}
+}
+
+func (g *gatedLimiter) Limit(ctx context.Context, e EndpointLimit) error {
+	g.entered <- e
+	select {
+	case <-g.gate:
+		return nil
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+}
+
+func TestPriorityFromContext(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	assert.Equal(t, PriorityAccount, PriorityFromContext(ctx, PriorityAccount), "fallback should be used when unset")
+	assert.Equal(t, PriorityOrder, PriorityFromContext(WithPriority(ctx, PriorityOrder), PriorityAccount))
+	assert.Equal(t, PriorityAccount, PriorityFromContext(WithPriority(ctx, priorityCount), PriorityAccount), "fallback should be used when invalid")
+	assert.Equal(t, "backfill", PriorityBackfill.String())
+	assert.Equal(t, "unset", UnsetPriority.String())
+}
+
+func TestInitiateRateLimitPriority(t *testing.T) {
+	t.Parallel()
+	l := &gatedLimiter{entered: make(chan EndpointLimit, 10), gate: make(chan struct{})}
+	r, err := New("test", new(http.Client), WithLimiter(l))
+	require.NoError(t, err, "New must not error")
+
+	done := make(chan EndpointLimit, 3)
+	send := func(p Priority, e EndpointLimit) {
+		go func() {
+			assert.NoError(t, r.InitiateRateLimit(WithPriority(context.Background(), p), e), "InitiateRateLimit should not error")
+			done <- e
+		}()
+	}
+	send(PriorityBackfill, 1)
+	require.Equal(t, EndpointLimit(1), <-l.entered, "backfill must take the turn when alone")
+	send(PriorityOrder, 2)
+	require.Equal(t, EndpointLimit(2), <-l.entered, "order must preempt backfill")
+	send(PriorityMarketData, 3)
+	require.Eventually(t, func() bool {
+		r.queue.m.Lock()
+		defer r.queue.m.Unlock()
+		return len(r.queue.waiting[PriorityMarketData]) == 1 && len(r.queue.waiting[PriorityBackfill]) == 1
+	}, time.Second, time.Millisecond, "market data and backfill must be queued")
+
+	l.gate <- struct{}{}
+	assert.Equal(t, EndpointLimit(2), <-done)
+	assert.Equal(t, EndpointLimit(3), <-l.entered, "market data should be served before backfill")
+	l.gate <- struct{}{}
+	assert.Equal(t, EndpointLimit(3), <-done)
+	assert.Equal(t, EndpointLimit(1), <-l.entered, "backfill should be served last")
+	l.gate <- struct{}{}
+	assert.Equal(t, EndpointLimit(1), <-done)
+}
+
+func TestPriorityQueueCancel(t *testing.T) {
+	t.Parallel()
+	var q priorityQueue
+	_, err := q.acquire(context.Background(), UnsetPriority)
+	assert.ErrorIs(t, err, errInvalidPriority)
+
+	active, err := q.acquire(context.Background(), PriorityOrder)
+	require.NoError(t, err, "acquire must not error")
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	_, err = q.acquire(ctx, PriorityAccount)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Empty(t, q.waiting[PriorityAccount], "cancelled requests should leave the queue")
+	assert.False(t, active.preempted(), "lower priority requests should not preempt")
+
+	q.release(active)
+	assert.Nil(t, q.active, "the turn should be free once released")
+}
+
+// reportingGatedLimiter is a gated limiter which reports a rate limiter per
+// endpoint
+type reportingGatedLimiter struct {
+	gatedLimiter
+	limiters map[EndpointLimit]*rate.Limiter
+}
+
+func (g *reportingGatedLimiter) LimiterFor(e EndpointLimit) (limiter *rate.Limiter, cost int) {
+	return g.limiters[e], 1
+}
+
+func TestInitiateRateLimitPriorityPerLimiter(t *testing.T) {
+	t.Parallel()
+	shared := rate.NewLimiter(rate.Inf, 1)
+	l := &reportingGatedLimiter{
+		gatedLimiter: gatedLimiter{entered: make(chan EndpointLimit, 10), gate: make(chan struct{})},
+		limiters:     map[EndpointLimit]*rate.Limiter{1: shared, 2: rate.NewLimiter(rate.Inf, 1), 3: shared},
+	}
+	r, err := New("test", new(http.Client), WithLimiter(l))
+	require.NoError(t, err, "New must not error")
+	assert.Same(t, r.queueFor(1), r.queueFor(3), "endpoints sharing a limiter should share a queue")
+	assert.NotSame(t, r.queueFor(1), r.queueFor(2), "endpoints with different limiters should not share a queue")
+	assert.Same(t, &r.queue, r.queueFor(4), "endpoints without a limiter should use the requester queue")
+
+	errs := make(chan error, 2)
+	send := func(p Priority, e EndpointLimit) {
+		go func() { errs <- r.InitiateRateLimit(WithPriority(context.Background(), p), e) }()
+	}
+	send(PriorityBackfill, 1)
+	require.Equal(t, EndpointLimit(1), <-l.entered, "backfill must take the turn when alone")
+	send(PriorityOrder, 2)
+	require.Equal(t, EndpointLimit(2), <-l.entered, "orders on another limiter must not wait for the backfill turn")
+	l.gate <- struct{}{}
+	l.gate <- struct{}{}
+	require.NoError(t, <-errs)
+	require.NoError(t, <-errs)
+	select {
+	case e := <-l.entered:
+		assert.Failf(t, "backfill should not be preempted by another limiter's request", "endpoint %v re-entered", e)
+	default:
+	}
+}
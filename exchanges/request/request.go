@@ -13,6 +13,7 @@ import (
 
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/common/timedmutex"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/latency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/mock"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/nonce"
 	"github.com/thrasher-corp/gocryptotrader/log"
@@ -49,6 +50,13 @@ var (
 	errRequestTypeUnpopulated = errors.New("request type bool is not populated")
 )
 
+// SetupGlobalLatencySimulator sets a latency simulator to delay all exchange
+// requests sent by requesters created afterwards, used to test strategies under
+// poor connectivity
+func SetupGlobalLatencySimulator(s *latency.Simulator) {
+	globalLatencySimulator = s
+}
+
 // New returns a new Requester
 func New(name string, httpRequester *http.Client, opts ...RequesterOption) (*Requester, error) {
 	protectedClient, err := newProtectedClient(httpRequester)
@@ -63,6 +71,11 @@ func New(name string, httpRequester *http.Client, opts ...RequesterOption) (*Req
 		maxRetries:  MaxRetryAttempts,
 		timedLock:   timedmutex.NewTimedMutex(DefaultMutexLockTimeout),
 		reporter:    globalReporter,
+		latency:     globalLatencySimulator,
+		limitStats:  newLimitTracker(),
+	}
+	if cb := getGlobalCircuitBreaker(); cb != nil {
+		r.breaker = newCircuitBreaker(name, cb)
 	}
 
 	for _, o := range opts {
@@ -91,7 +104,23 @@ func (r *Requester) SendPayload(ctx context.Context, ep EndpointLimit, newReques
 		return errRequestFunctionIsNil
 	}
 
+	fallback := PriorityMarketData
+	if requestType == AuthenticatedRequest {
+		fallback = PriorityAccount
+	}
+	ctx = WithPriority(ctx, PriorityFromContext(ctx, fallback))
+
+	class := endpointClass(requestType)
+	if err := r.breaker.allow(class, time.Now()); err != nil {
+		return err
+	}
+
 	err := r.doRequest(ctx, ep, newRequest)
+	r.breaker.record(class, err, time.Now())
+	var ve *venueError
+	if errors.As(err, &ve) {
+		err = ve.err
+	}
 	if err != nil && requestType == AuthenticatedRequest {
 		err = common.AppendError(err, ErrAuthRequestFailed)
 	}
@@ -188,6 +217,10 @@ func (r *Requester) doRequest(ctx context.Context, endpoint EndpointLimit, newRe
 			}
 		}
 
+		if err = r.latency.Wait(ctx); err != nil {
+			return err
+		}
+
 		start := time.Now()
 
 		resp, err := r._HTTPClient.do(req)
@@ -197,6 +230,12 @@ func (r *Requester) doRequest(ctx context.Context, endpoint EndpointLimit, newRe
 		}
 
 		if retry, checkErr := r.retryPolicy(resp, err); checkErr != nil {
+			if err == nil {
+				checkErr = withResponseHint(checkErr, resp)
+			}
+			if err != nil || isVenueFailure(resp) {
+				return venueFailure(ctx, checkErr)
+			}
 			return checkErr
 		} else if retry {
 			if err == nil {
@@ -206,9 +245,9 @@ func (r *Requester) doRequest(ctx context.Context, endpoint EndpointLimit, newRe
 
 			if attempt > r.maxRetries {
 				if err != nil {
-					return fmt.Errorf("%w, err: %v", errFailedToRetryRequest, err)
+					return venueFailure(ctx, fmt.Errorf("%w, err: %w", errFailedToRetryRequest, err))
 				}
-				return fmt.Errorf("%w, status: %s", errFailedToRetryRequest, resp.Status)
+				return venueFailure(ctx, withResponseHint(fmt.Errorf("%w, status: %s", errFailedToRetryRequest, resp.Status), resp))
 			}
 
 			after := RetryAfter(resp, time.Now())
@@ -220,9 +259,9 @@ func (r *Requester) doRequest(ctx context.Context, endpoint EndpointLimit, newRe
 
 			if dl, ok := req.Context().Deadline(); ok && dl.Before(time.Now().Add(delay)) {
 				if err != nil {
-					return fmt.Errorf("deadline would be exceeded by retry, err: %v", err)
+					return venueFailure(ctx, fmt.Errorf("deadline would be exceeded by retry, err: %w", err))
 				}
-				return fmt.Errorf("deadline would be exceeded by retry, status: %s", resp.Status)
+				return venueFailure(ctx, withResponseHint(fmt.Errorf("deadline would be exceeded by retry, status: %s", resp.Status), resp))
 			}
 
 			if verbose {
@@ -260,10 +299,15 @@ func (r *Requester) doRequest(ctx context.Context, endpoint EndpointLimit, newRe
 
 		if resp.StatusCode < http.StatusOK ||
 			resp.StatusCode > http.StatusNoContent {
-			return fmt.Errorf("%s unsuccessful HTTP status code: %d raw response: %s",
+			err = fmt.Errorf("%s unsuccessful HTTP status code: %d raw response: %s",
 				r.name,
 				resp.StatusCode,
 				string(contents))
+			err = withResponseHint(err, resp)
+			if isVenueFailure(resp) {
+				return &venueError{err}
+			}
+			return err
 		}
 
 		if p.HTTPDebugging {
@@ -289,6 +333,24 @@ func (r *Requester) doRequest(ctx context.Context, endpoint EndpointLimit, newRe
 	}
 }
 
+// venueFailure marks an error as a failure of the exchange to serve a request,
+// unless the request was cancelled by its context
+func venueFailure(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return err
+	}
+	return &venueError{err}
+}
+
+// CircuitState returns the state of the requester's circuit breaker for an
+// endpoint class, which is always closed if no circuit breaker is set
+func (r *Requester) CircuitState(class string) CircuitState {
+	if r == nil || r.breaker == nil {
+		return CircuitClosed
+	}
+	return r.breaker.state(class)
+}
+
 func (r *Requester) drainBody(body io.ReadCloser) {
 	if _, err := io.Copy(io.Discard, io.LimitReader(body, drainBodyLimit)); err != nil {
 		log.Errorf(log.RequestSys, "%s failed to drain request body %s", r.name, err)
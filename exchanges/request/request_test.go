@@ -21,6 +21,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/latency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/nonce"
 	"golang.org/x/time/rate"
 )
@@ -495,6 +496,27 @@ func TestDoRequest_NotRetryable(t *testing.T) {
 	}
 }
 
+func TestDoRequestLatencySimulation(t *testing.T) {
+	t.Parallel()
+	r, err := New("test", new(http.Client))
+	require.NoError(t, err)
+	r.latency, err = latency.NewSimulator(time.Millisecond*50, 0)
+	require.NoError(t, err)
+	item := func() (*Item, error) {
+		return &Item{Method: http.MethodGet, Path: testURL}, nil
+	}
+	start := time.Now()
+	require.NoError(t, r.SendPayload(context.Background(), Unset, item, UnauthenticatedRequest))
+	assert.GreaterOrEqual(t, time.Since(start), time.Millisecond*50, "request should be delayed by the simulated latency")
+
+	r.latency, err = latency.NewSimulator(time.Hour, 0)
+	require.NoError(t, err)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer cancel()
+	err = r.SendPayload(ctx, Unset, item, UnauthenticatedRequest)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "simulated latency should respect context cancellation")
+}
+
 func TestGetNonce(t *testing.T) {
 	t.Parallel()
 	r, err := New("test", new(http.Client), WithLimiter(&globalshell))
@@ -594,6 +616,42 @@ func TestBasicLimiter(t *testing.T) {
 	}
 }
 
+func TestWaitForTokens(t *testing.T) {
+	t.Parallel()
+	limiter := rate.NewLimiter(rate.Every(time.Minute), 2)
+	require.NoError(t, WaitForTokens(context.Background(), limiter, 2), "WaitForTokens must not error with available tokens")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	err := WaitForTokens(ctx, limiter, 1)
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "delays beyond the deadline should error")
+
+	limiter = rate.NewLimiter(rate.Every(time.Minute), 1)
+	require.NoError(t, WaitForTokens(context.Background(), limiter, 1), "WaitForTokens must not error with available tokens")
+	cancelCtx, cancelCause := context.WithCancelCause(context.Background())
+	time.AfterFunc(time.Millisecond*10, func() { cancelCause(errPreempted) })
+	err = WaitForTokens(cancelCtx, limiter, 2)
+	assert.ErrorIs(t, err, errPreempted, "waits should end with the cause of a done context")
+	assert.InDelta(t, 0, limiter.Tokens(), 0.01, "unused reservations should be returned to the limiter")
+}
+
+func TestGetLimiter(t *testing.T) {
+	t.Parallel()
+	r, err := New("test", new(http.Client), WithLimiter(NewBasicRateLimit(time.Minute, 1)))
+	require.NoError(t, err)
+	l := r.GetLimiter()
+	require.NoError(t, l.Limit(context.Background(), Unset), "the first request should not be limited")
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+	defer cancel()
+	assert.Error(t, r.InitiateRateLimit(ctx, Unset), "REST requests should draw from the limiter's budget")
+	assert.Error(t, l.Limit(ctx, Unset), "the limiter should draw from the requester's budget")
+	require.NoError(t, r.DisableRateLimiter())
+	assert.NoError(t, l.Limit(ctx, Unset), "the limiter should respect the rate limiter being disabled")
+
+	var nilRequester *Requester
+	assert.ErrorIs(t, nilRequester.GetLimiter().Limit(context.Background(), Unset), ErrRequestSystemIsNil)
+}
+
 func TestEnableDisableRateLimit(t *testing.T) {
 	r, err := New("TestRequest",
 		new(http.Client),
@@ -3,10 +3,13 @@ package request
 import (
 	"io"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common/timedmutex"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/latency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/nonce"
+	"golang.org/x/time/rate"
 )
 
 // Const vars for rate limiter
@@ -20,8 +23,10 @@ const (
 
 // Vars for rate limiter
 var (
-	MaxRetryAttempts = DefaultMaxRetryAttempts
-	globalReporter   Reporter
+	MaxRetryAttempts       = DefaultMaxRetryAttempts
+	globalReporter         Reporter
+	globalLatencySimulator *latency.Simulator
+	globalCircuitBreaker   *CircuitBreakerSettings
 )
 
 // Requester struct for the request client
@@ -29,6 +34,7 @@ type Requester struct {
 	_HTTPClient        *client
 	limiter            Limiter
 	reporter           Reporter
+	latency            *latency.Simulator
 	name               string
 	userAgent          string
 	maxRetries         int
@@ -37,6 +43,13 @@ type Requester struct {
 	backoff            Backoff
 	retryPolicy        RetryPolicy
 	timedLock          *timedmutex.TimedMutex
+	breaker            *circuitBreaker
+	limitStats         *limitTracker
+	// queues hold a priority queue per underlying rate limiter, queue is used
+	// for limiters which don't report theirs
+	queuesMtx sync.Mutex
+	queues    map[*rate.Limiter]*priorityQueue
+	queue     priorityQueue
 }
 
 // Item is a temp item for requests
@@ -0,0 +1,125 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+)
+
+// RetryHint is a machine-readable description of whether and when a failed
+// request can be retried, allowing retry logic to be shared across exchanges
+type RetryHint struct {
+	// StatusCode is the HTTP status code of the response, zero if no response
+	// was received
+	StatusCode int
+	// RetryAfter is the minimum duration to wait before retrying, zero if the
+	// exchange did not specify one
+	RetryAfter time.Duration
+	// Permanent is set when resending the same request will fail again
+	Permanent bool
+	// NeedsReauth is set when the request was rejected due to its credentials,
+	// requests should not be retried until the credentials are updated
+	NeedsReauth bool
+}
+
+// HintedError is an error returned by an exchange annotated with how the
+// request can be retried
+type HintedError struct {
+	Err  error
+	Hint RetryHint
+}
+
+// Error implements the error interface
+func (e *HintedError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error
+func (e *HintedError) Unwrap() error {
+	return e.Err
+}
+
+// WithRetryHint annotates an error with a retry hint. Exchange wrappers use
+// this for errors parsed from response bodies, where an exchange's own error
+// codes describe the failure more precisely than the HTTP status code
+func WithRetryHint(err error, hint RetryHint) error {
+	if err == nil {
+		return nil
+	}
+	return &HintedError{Err: err, Hint: hint}
+}
+
+// GetRetryHint returns the retry hint of an error. The hint closest to the top
+// of the error chain is used, so hints added by exchange wrappers take
+// precedence over hints derived from the HTTP response. Errors without a hint
+// which show a request timing out are treated as transient. The returned bool
+// is false when nothing is known about whether the error is retryable
+func GetRetryHint(err error) (RetryHint, bool) {
+	if err == nil {
+		return RetryHint{}, false
+	}
+	var he *HintedError
+	if errors.As(err, &he) {
+		return he.Hint, true
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return RetryHint{}, true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return RetryHint{}, true
+	}
+	return RetryHint{}, false
+}
+
+// IsTransient returns whether an error is known to be temporary so that the
+// same request can be retried, after waiting for any RetryAfter duration
+func IsTransient(err error) bool {
+	h, ok := GetRetryHint(err)
+	return ok && !h.Permanent && !h.NeedsReauth
+}
+
+// IsPermanent returns whether an error is known to recur if the same request
+// is resent
+func IsPermanent(err error) bool {
+	h, ok := GetRetryHint(err)
+	return ok && h.Permanent
+}
+
+// NeedsReauth returns whether a request was rejected due to its credentials
+func NeedsReauth(err error) bool {
+	h, ok := GetRetryHint(err)
+	return ok && h.NeedsReauth
+}
+
+// RetryHintFromResponse derives a retry hint from an unsuccessful HTTP
+// response. Rate limits, timeouts and exchange failures are transient,
+// authentication failures need reauthentication and other client errors are
+// permanent
+func RetryHintFromResponse(resp *http.Response, now time.Time) RetryHint {
+	if resp == nil {
+		return RetryHint{}
+	}
+	h := RetryHint{
+		StatusCode: resp.StatusCode,
+		RetryAfter: max(RetryAfter(resp, now), 0),
+	}
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized, resp.StatusCode == http.StatusForbidden:
+		h.NeedsReauth = true
+	case resp.StatusCode == http.StatusRequestTimeout,
+		resp.StatusCode == http.StatusTooEarly,
+		resp.StatusCode == http.StatusTooManyRequests,
+		resp.StatusCode >= http.StatusInternalServerError:
+	case resp.StatusCode >= http.StatusBadRequest:
+		h.Permanent = true
+	}
+	return h
+}
+
+// withResponseHint annotates an error with the retry hint of its response
+func withResponseHint(err error, resp *http.Response) error {
+	return WithRetryHint(err, RetryHintFromResponse(resp, time.Now()))
+}
@@ -0,0 +1,108 @@
+package request
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/common"
+)
+
+func TestRetryHintFromResponse(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, RetryHint{}, RetryHintFromResponse(nil, time.Now()))
+	now := time.Now()
+	for _, tc := range []struct {
+		status int
+		header string
+		want   RetryHint
+	}{
+		{status: http.StatusTooManyRequests, header: "5", want: RetryHint{StatusCode: http.StatusTooManyRequests, RetryAfter: time.Second * 5}},
+		{status: http.StatusServiceUnavailable, want: RetryHint{StatusCode: http.StatusServiceUnavailable}},
+		{status: http.StatusRequestTimeout, want: RetryHint{StatusCode: http.StatusRequestTimeout}},
+		{status: http.StatusUnauthorized, want: RetryHint{StatusCode: http.StatusUnauthorized, NeedsReauth: true}},
+		{status: http.StatusForbidden, want: RetryHint{StatusCode: http.StatusForbidden, NeedsReauth: true}},
+		{status: http.StatusBadRequest, want: RetryHint{StatusCode: http.StatusBadRequest, Permanent: true}},
+		{status: http.StatusNotFound, header: now.Add(-time.Hour).Format(time.RFC1123), want: RetryHint{StatusCode: http.StatusNotFound, Permanent: true}},
+	} {
+		resp := &http.Response{StatusCode: tc.status, Header: http.Header{}}
+		if tc.header != "" {
+			resp.Header.Set(headerRetryAfter, tc.header)
+		}
+		assert.Equal(t, tc.want, RetryHintFromResponse(resp, now), http.StatusText(tc.status))
+	}
+}
+
+func TestGetRetryHint(t *testing.T) {
+	t.Parallel()
+	_, ok := GetRetryHint(nil)
+	assert.False(t, ok)
+	_, ok = GetRetryHint(errors.New("unknown"))
+	assert.False(t, ok, "errors without hints should be unknown")
+	assert.False(t, IsTransient(errors.New("unknown")))
+	assert.False(t, IsPermanent(errors.New("unknown")))
+	assert.NoError(t, WithRetryHint(nil, RetryHint{Permanent: true}))
+
+	transient := WithRetryHint(errors.New("busy"), RetryHint{RetryAfter: time.Second})
+	h, ok := GetRetryHint(fmt.Errorf("wrapped: %w", transient))
+	require.True(t, ok, "hints must be found through wrapped errors")
+	assert.Equal(t, time.Second, h.RetryAfter)
+	assert.True(t, IsTransient(transient))
+	assert.False(t, IsPermanent(transient))
+	assert.False(t, NeedsReauth(transient))
+
+	reauth := common.AppendError(WithRetryHint(errors.New("bad key"), RetryHint{NeedsReauth: true}), ErrAuthRequestFailed)
+	assert.True(t, NeedsReauth(reauth), "hints must be found through appended errors")
+	assert.False(t, IsTransient(reauth))
+
+	outer := WithRetryHint(WithRetryHint(errors.New("insufficient funds"), RetryHint{StatusCode: http.StatusServiceUnavailable}), RetryHint{Permanent: true})
+	assert.True(t, IsPermanent(outer), "the outermost hint should take precedence")
+
+	assert.True(t, IsTransient(fmt.Errorf("%w", context.DeadlineExceeded)))
+	assert.True(t, IsTransient(&net.DNSError{IsTimeout: true}))
+	assert.False(t, IsTransient(&net.DNSError{}))
+}
+
+func TestDoRequestRetryHints(t *testing.T) {
+	t.Parallel()
+	sm := http.NewServeMux()
+	sm.HandleFunc("/unauthorised", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	server := httptest.NewServer(sm)
+	t.Cleanup(server.Close)
+
+	r, err := New("test", new(http.Client), WithBackoff(func(int) time.Duration { return 0 }))
+	require.NoError(t, err)
+	send := func(url string, rt AuthType) error {
+		return r.SendPayload(context.Background(), Unset, func() (*Item, error) {
+			return &Item{Method: http.MethodGet, Path: url}, nil
+		}, rt)
+	}
+
+	err = send(testURL+"/error", UnauthenticatedRequest)
+	require.Error(t, err)
+	h, ok := GetRetryHint(err)
+	require.True(t, ok, "unsuccessful responses must have a retry hint")
+	assert.Equal(t, RetryHint{StatusCode: http.StatusBadRequest, Permanent: true}, h)
+
+	err = send(server.URL+"/unauthorised", AuthenticatedRequest)
+	assert.ErrorIs(t, err, ErrAuthRequestFailed)
+	assert.True(t, NeedsReauth(err))
+
+	err = send(testURL+"/always-retry", UnauthenticatedRequest)
+	assert.ErrorIs(t, err, errFailedToRetryRequest)
+	assert.True(t, IsTransient(err), "exhausting retries should be transient")
+	h, _ = GetRetryHint(err)
+	assert.Equal(t, http.StatusTooManyRequests, h.StatusCode)
+
+	err = send(testURL+"/timeout", UnauthenticatedRequest)
+	assert.True(t, IsTransient(err), "exchange failures should be transient")
+}
@@ -0,0 +1,37 @@
+package rfq
+
+import (
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// Request is a request for quote on a block trade, pushed to an exchange's
+// websocket DataHandler as it is created or changes state so market makers
+// can respond with quotes
+type Request struct {
+	Exchange string
+	ID       string
+	ClientID string
+	// TraderCode identifies the requesting counterparty where disclosed
+	TraderCode     string
+	State          string
+	Counterparties []string
+	Legs           []Leg
+	Created        time.Time
+	Updated        time.Time
+	ValidUntil     time.Time
+}
+
+// Leg is an instrument requested for quote. Instruments which cannot be
+// matched to an enabled pair have an empty asset and retain the exchange's
+// instrument name
+type Leg struct {
+	Instrument string
+	Pair       currency.Pair
+	Asset      asset.Item
+	Side       order.Side
+	Amount     float64
+}
@@ -1,6 +1,7 @@
 package buffer
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"sort"
@@ -14,7 +15,15 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/log"
 )
 
-const packageError = "websocket orderbook buffer error: %w"
+const (
+	packageError = "websocket orderbook buffer error: %w"
+
+	defaultSnapshotDeltaLimit = 1000
+)
+
+// ErrUpdateIDDiscontinuity is returned when an update does not follow on from
+// the last update applied to the orderbook
+var ErrUpdateIDDiscontinuity = errors.New("orderbook update ID discontinuity")
 
 var (
 	errExchangeConfigNil            = errors.New("exchange config is nil")
@@ -32,6 +41,10 @@ var (
 	errUpdateInsertFailure          = errors.New("orderbook update/insert update failure")
 	errRESTTimerLapse               = errors.New("rest sync timer lapse with active websocket connection")
 	errOrderbookFlushed             = errors.New("orderbook flushed")
+	errSnapshotFetcherUnset         = errors.New("snapshot fetcher unset")
+	errSnapshotSyncInProgress       = errors.New("snapshot sync already in progress")
+	errSnapshotSyncCancelled        = errors.New("snapshot sync cancelled by orderbook flush")
+	errSnapshotDeltaOverflow        = errors.New("snapshot delta buffer limit exceeded")
 )
 
 // Setup sets private variables
@@ -71,7 +84,14 @@ func (w *Orderbook) Setup(exchangeConfig *config.Exchange, c *Config, dataHandle
 	}
 	w.publishPeriod = orderbookPublishPeriod
 	w.updateIDProgression = c.UpdateIDProgression
+	w.validateUpdateIDContinuity = c.ValidateUpdateIDContinuity
 	w.checksum = c.Checksum
+	w.fetchSnapshot = c.FetchSnapshot
+	w.snapshotDeltaLimit = c.SnapshotDeltaLimit
+	if w.snapshotDeltaLimit <= 0 {
+		w.snapshotDeltaLimit = defaultSnapshotDeltaLimit
+	}
+	w.pending = make(map[key.PairAsset]*pendingSnapshot)
 	return nil
 }
 
@@ -94,7 +114,11 @@ func (w *Orderbook) Update(u *orderbook.Update) error {
 	}
 	w.mtx.Lock()
 	defer w.mtx.Unlock()
-	book, ok := w.ob[key.PairAsset{Base: u.Pair.Base.Item, Quote: u.Pair.Quote.Item, Asset: u.Asset}]
+	k := key.PairAsset{Base: u.Pair.Base.Item, Quote: u.Pair.Quote.Item, Asset: u.Asset}
+	if pending, ok := w.pending[k]; ok {
+		return w.bufferPendingDelta(pending, u)
+	}
+	book, ok := w.ob[k]
 	if !ok {
 		return fmt.Errorf("%w for Exchange %s CurrencyPair: %s AssetType: %s",
 			errDepthNotFound,
@@ -153,6 +177,18 @@ func (w *Orderbook) Update(u *orderbook.Update) error {
 		return book.ob.Invalidate(errRESTTimerLapse)
 	}
 
+	// A gap in the update chain means an update has been missed, so applying
+	// further updates would corrupt the book until a new snapshot is loaded
+	if w.validateUpdateIDContinuity {
+		if u.PrevUpdateID != book.updateID {
+			return book.ob.Invalidate(fmt.Errorf("%w: expected previous update ID %d received %d",
+				ErrUpdateIDDiscontinuity,
+				book.updateID,
+				u.PrevUpdateID))
+		}
+		book.updateID = u.UpdateID
+	}
+
 	if w.bufferEnabled {
 		var processed bool
 		processed, err = w.processBufferUpdate(book, u)
@@ -312,13 +348,40 @@ func (w *Orderbook) LoadSnapshot(book *orderbook.Base) error {
 
 	w.mtx.Lock()
 	defer w.mtx.Unlock()
+	holder, err := w.deploySnapshot(book)
+	if err != nil {
+		return err
+	}
+
+	if holder.ob.VerifyOrderbook {
+		// This is used here so as to not retrieve book if verification is off.
+		// Checks to see if orderbook snapshot that was deployed has not been
+		// altered in any way
+		book, err = holder.ob.Retrieve()
+		if err != nil {
+			return err
+		}
+		err = book.Verify()
+		if err != nil {
+			return holder.ob.Invalidate(err)
+		}
+	}
+
+	holder.ob.Publish()
+	w.dataHandler <- holder.ob
+	return nil
+}
+
+// deploySnapshot loads a snapshot into the stored orderbook depth, creating it
+// if needed. w.mtx must be held
+func (w *Orderbook) deploySnapshot(book *orderbook.Base) (*orderbookHolder, error) {
 	holder, ok := w.ob[key.PairAsset{Base: book.Pair.Base.Item, Quote: book.Pair.Quote.Item, Asset: book.Asset}]
 	if !ok {
 		// Associate orderbook pointer with local exchange depth map
 		var depth *orderbook.Depth
-		depth, err = orderbook.DeployDepth(book.Exchange, book.Pair, book.Asset)
+		depth, err := orderbook.DeployDepth(book.Exchange, book.Pair, book.Asset)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		depth.AssignOptions(book)
 		buffer := make([]orderbook.Update, w.obBufferLimit)
@@ -333,32 +396,15 @@ func (w *Orderbook) LoadSnapshot(book *orderbook.Base) error {
 
 	holder.updateID = book.LastUpdateID
 
-	err = holder.ob.LoadSnapshot(book.Bids,
+	err := holder.ob.LoadSnapshot(book.Bids,
 		book.Asks,
 		book.LastUpdateID,
 		book.LastUpdated,
 		false)
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	if holder.ob.VerifyOrderbook {
-		// This is used here so as to not retrieve book if verification is off.
-		// Checks to see if orderbook snapshot that was deployed has not been
-		// altered in any way
-		book, err = holder.ob.Retrieve()
-		if err != nil {
-			return err
-		}
-		err = book.Verify()
-		if err != nil {
-			return holder.ob.Invalidate(err)
-		}
-	}
-
-	holder.ob.Publish()
-	w.dataHandler <- holder.ob
-	return nil
+	return holder, nil
 }
 
 // GetOrderbook returns an orderbook copy as orderbook.Base
@@ -377,6 +423,7 @@ func (w *Orderbook) GetOrderbook(p currency.Pair, a asset.Item) (*orderbook.Base
 func (w *Orderbook) FlushBuffer() {
 	w.mtx.Lock()
 	w.ob = make(map[key.PairAsset]*orderbookHolder)
+	w.pending = make(map[key.PairAsset]*pendingSnapshot)
 	w.mtx.Unlock()
 }
 
@@ -396,3 +443,113 @@ func (w *Orderbook) FlushOrderbook(p currency.Pair, a asset.Item) error {
 	_ = book.ob.Invalidate(errOrderbookFlushed)
 	return nil
 }
+
+// CanSyncSnapshot returns whether a snapshot fetcher is configured for
+// SyncSnapshot
+func (w *Orderbook) CanSyncSnapshot() bool {
+	return w.fetchSnapshot != nil
+}
+
+// SyncSnapshot bootstraps an orderbook from a REST snapshot fetched by the
+// configured FetchSnapshot. Deltas passed to Update while the snapshot is being
+// fetched are buffered, then spliced onto the snapshot in update ID order:
+// deltas already contained in the snapshot are dropped, the first applied
+// delta must span the snapshot's LastUpdateID and each following delta's
+// PrevUpdateID must match the UpdateID of the delta before it. Exchanges should
+// call this on subscribing to an incremental orderbook channel, or when a book
+// is invalidated by ErrUpdateIDDiscontinuity.
+func (w *Orderbook) SyncSnapshot(ctx context.Context, p currency.Pair, a asset.Item) error {
+	if w.fetchSnapshot == nil {
+		return fmt.Errorf(packageError, errSnapshotFetcherUnset)
+	}
+	k := key.PairAsset{Base: p.Base.Item, Quote: p.Quote.Item, Asset: a}
+	pending := &pendingSnapshot{}
+	w.mtx.Lock()
+	if _, ok := w.pending[k]; ok {
+		w.mtx.Unlock()
+		return fmt.Errorf("%s %s %s %w", w.exchangeName, p, a, errSnapshotSyncInProgress)
+	}
+	w.pending[k] = pending
+	w.mtx.Unlock()
+
+	book, err := w.fetchSnapshot(ctx, p, a)
+	if err == nil {
+		err = book.Verify()
+	}
+
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	if w.pending[k] != pending {
+		return fmt.Errorf("%s %s %s %w", w.exchangeName, p, a, errSnapshotSyncCancelled)
+	}
+	delete(w.pending, k)
+	if err != nil {
+		return fmt.Errorf("%s %s %s fetching snapshot: %w", w.exchangeName, p, a, err)
+	}
+	if pending.overflow {
+		return fmt.Errorf("%s %s %s %w", w.exchangeName, p, a, errSnapshotDeltaOverflow)
+	}
+	return w.spliceSnapshot(book, pending.deltas)
+}
+
+// bufferPendingDelta stores a delta received while a snapshot is fetched. Once
+// the limit is exceeded the deltas are discarded as the sync cannot succeed.
+// w.mtx must be held
+func (w *Orderbook) bufferPendingDelta(pending *pendingSnapshot, u *orderbook.Update) error {
+	if pending.overflow {
+		return nil
+	}
+	if len(pending.deltas) >= w.snapshotDeltaLimit {
+		pending.overflow = true
+		pending.deltas = nil
+		return fmt.Errorf("%s %s %s %w", w.exchangeName, u.Pair, u.Asset, errSnapshotDeltaOverflow)
+	}
+	pending.deltas = append(pending.deltas, *u)
+	return nil
+}
+
+// spliceSnapshot loads a snapshot and applies the deltas which follow on from
+// it. w.mtx must be held
+func (w *Orderbook) spliceSnapshot(book *orderbook.Base, deltas []orderbook.Update) error {
+	holder, err := w.deploySnapshot(book)
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(deltas, func(i, j int) bool { return deltas[i].UpdateID < deltas[j].UpdateID })
+	last := book.LastUpdateID
+	for i := range deltas {
+		if deltas[i].UpdateID <= book.LastUpdateID {
+			continue
+		}
+		gap := deltas[i].PrevUpdateID != last
+		if last == book.LastUpdateID {
+			// The first delta may begin before the snapshot's last update
+			gap = deltas[i].PrevUpdateID > last
+		}
+		if gap {
+			return holder.ob.Invalidate(fmt.Errorf("%w: expected previous update ID %d received %d",
+				ErrUpdateIDDiscontinuity,
+				last,
+				deltas[i].PrevUpdateID))
+		}
+		if err = w.processObUpdate(holder, &deltas[i]); err != nil {
+			return err
+		}
+		last = deltas[i].UpdateID
+	}
+	holder.updateID = last
+
+	if holder.ob.VerifyOrderbook {
+		book, err = holder.ob.Retrieve()
+		if err != nil {
+			return err
+		}
+		if err = book.Verify(); err != nil {
+			return holder.ob.Invalidate(err)
+		}
+	}
+
+	holder.ob.Publish()
+	w.dataHandler <- holder.ob
+	return nil
+}
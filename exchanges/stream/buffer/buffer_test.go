@@ -1,12 +1,15 @@
 package buffer
 
 import (
+	"context"
 	"errors"
 	"math/rand"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/thrasher-corp/gocryptotrader/common/key"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
@@ -516,6 +519,101 @@ func TestOrderbookLastUpdateID(t *testing.T) {
 	}
 }
 
+func TestUpdateIDContinuity(t *testing.T) {
+	t.Parallel()
+	holder, _, _, err := createSnapshot()
+	require.NoError(t, err, "createSnapshot must not error")
+	holder.validateUpdateIDContinuity = true
+
+	for i := int64(1); i <= 3; i++ {
+		err = holder.Update(&orderbook.Update{
+			Asks:         []orderbook.Item{{Price: 4001, Amount: float64(i)}},
+			Pair:         cp,
+			UpdateID:     i,
+			PrevUpdateID: i - 1,
+			Asset:        asset.Spot,
+			UpdateTime:   time.Now(),
+		})
+		require.NoError(t, err, "Update must not error on a continuous update chain")
+	}
+
+	err = holder.Update(&orderbook.Update{
+		Asks:         []orderbook.Item{{Price: 4001, Amount: 5}},
+		Pair:         cp,
+		UpdateID:     5,
+		PrevUpdateID: 4,
+		Asset:        asset.Spot,
+		UpdateTime:   time.Now(),
+	})
+	assert.ErrorIs(t, err, ErrUpdateIDDiscontinuity, "Update should error on a gap in the update chain")
+	assert.ErrorIs(t, err, orderbook.ErrOrderbookInvalid, "Update should invalidate the book on a gap in the update chain")
+}
+
+func TestSyncSnapshot(t *testing.T) {
+	t.Parallel()
+	dataHandler := make(chan interface{}, 10)
+	exchCfg := &config.Exchange{Name: "syncSnapshotTest"}
+	w := &Orderbook{}
+	require.NoError(t, w.Setup(exchCfg, &Config{}, dataHandler), "Setup must not error")
+	assert.ErrorIs(t, w.SyncSnapshot(context.Background(), cp, asset.Spot), errSnapshotFetcherUnset)
+	assert.False(t, w.CanSyncSnapshot(), "CanSyncSnapshot should be false without a fetcher")
+
+	fetching, release := make(chan struct{}), make(chan struct{})
+	fetchErr := errors.New("fetch error")
+	var fail bool
+	fetcher := func(_ context.Context, p currency.Pair, a asset.Item) (*orderbook.Base, error) {
+		fetching <- struct{}{}
+		<-release
+		if fail {
+			return nil, fetchErr
+		}
+		return &orderbook.Base{
+			Exchange:     "syncSnapshotTest",
+			Pair:         p,
+			Asset:        a,
+			Bids:         orderbook.Items{{Price: 100, Amount: 1}},
+			Asks:         orderbook.Items{{Price: 101, Amount: 1}},
+			LastUpdateID: 10,
+			LastUpdated:  time.Now(),
+		}, nil
+	}
+	require.NoError(t, w.Setup(exchCfg, &Config{FetchSnapshot: fetcher, SnapshotDeltaLimit: 3, ValidateUpdateIDContinuity: true}, dataHandler), "Setup must not error")
+	assert.True(t, w.CanSyncSnapshot(), "CanSyncSnapshot should be true with a fetcher")
+
+	delta := func(prev, id int64, price float64) *orderbook.Update {
+		return &orderbook.Update{Pair: cp, Asset: asset.Spot, PrevUpdateID: prev, UpdateID: id, UpdateTime: time.Now(), Asks: orderbook.Items{{Price: price, Amount: 1}}}
+	}
+	syncBook := func(deltas ...*orderbook.Update) error {
+		errs := make(chan error, 1)
+		go func() { errs <- w.SyncSnapshot(context.Background(), cp, asset.Spot) }()
+		<-fetching
+		assert.ErrorIs(t, w.SyncSnapshot(context.Background(), cp, asset.Spot), errSnapshotSyncInProgress)
+		for _, d := range deltas {
+			_ = w.Update(d)
+		}
+		release <- struct{}{}
+		return <-errs
+	}
+
+	fail = true
+	assert.ErrorIs(t, syncBook(), fetchErr, "SyncSnapshot should error when the fetch fails")
+	fail = false
+
+	assert.ErrorIs(t, syncBook(delta(0, 1, 1), delta(1, 2, 1), delta(2, 3, 1), delta(3, 4, 1)), errSnapshotDeltaOverflow, "SyncSnapshot should error when deltas exceed the limit")
+
+	err := syncBook(delta(13, 14, 104), delta(10, 12, 102))
+	assert.ErrorIs(t, err, ErrUpdateIDDiscontinuity, "SyncSnapshot should error on a gap between deltas")
+	assert.ErrorIs(t, err, orderbook.ErrOrderbookInvalid, "SyncSnapshot should invalidate the book on a gap between deltas")
+
+	err = syncBook(delta(11, 13, 103), delta(5, 9, 99), delta(9, 11, 102))
+	require.NoError(t, err, "SyncSnapshot must not error when deltas follow on from the snapshot")
+	book, err := w.GetOrderbook(cp, asset.Spot)
+	require.NoError(t, err, "GetOrderbook must not error")
+	assert.Equal(t, int64(13), book.LastUpdateID, "LastUpdateID should be the last applied delta")
+	assert.Len(t, book.Asks, 3, "deltas contained in the snapshot should be dropped and the rest applied")
+	assert.NoError(t, w.Update(delta(13, 14, 104)), "Update should apply deltas following the spliced snapshot")
+}
+
 // TestRunUpdateWithoutSnapshot logic test
 func TestRunUpdateWithoutSnapshot(t *testing.T) {
 	t.Parallel()
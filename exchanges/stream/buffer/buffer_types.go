@@ -1,10 +1,13 @@
 package buffer
 
 import (
+	"context"
 	"sync"
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common/key"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
 )
 
@@ -22,10 +25,25 @@ type Config struct {
 	// UpdateIDProgression requires that the new update ID be greater than the
 	// prior ID. This will skip processing and not error.
 	UpdateIDProgression bool
+	// ValidateUpdateIDContinuity requires each update's PrevUpdateID to match
+	// the ID of the last update or snapshot. A gap invalidates the book and
+	// returns ErrUpdateIDDiscontinuity so the exchange can fetch a new
+	// snapshot.
+	ValidateUpdateIDContinuity bool
 	// Checksum is a package defined checksum calculation for updated books.
 	Checksum func(state *orderbook.Base, checksum uint32) error
+	// FetchSnapshot fetches a REST orderbook snapshot for SyncSnapshot. The
+	// snapshot's LastUpdateID must be comparable with the update IDs of the
+	// websocket deltas.
+	FetchSnapshot SnapshotFetcher
+	// SnapshotDeltaLimit is the maximum number of deltas buffered for a pair
+	// while its snapshot is fetched. Defaults to defaultSnapshotDeltaLimit.
+	SnapshotDeltaLimit int
 }
 
+// SnapshotFetcher fetches a full orderbook snapshot via the REST protocol
+type SnapshotFetcher func(ctx context.Context, p currency.Pair, a asset.Item) (*orderbook.Base, error)
+
 // Orderbook defines a local cache of orderbooks for amending, appending
 // and deleting changes and updates the main store for a stream
 type Orderbook struct {
@@ -42,9 +60,18 @@ type Orderbook struct {
 	// updateIDProgression requires that the new update ID be greater than the
 	// prior ID. This will skip processing and not error.
 	updateIDProgression bool
+	// validateUpdateIDContinuity requires each update to follow on from the
+	// last applied update ID
+	validateUpdateIDContinuity bool
 	// checksum is a package defined checksum calculation for updated books.
 	checksum func(state *orderbook.Base, checksum uint32) error
 
+	fetchSnapshot      SnapshotFetcher
+	snapshotDeltaLimit int
+	// pending holds the deltas received for pairs which are awaiting a REST
+	// snapshot
+	pending map[key.PairAsset]*pendingSnapshot
+
 	publishPeriod time.Duration
 
 	// TODO: sync.RWMutex. For the moment we process the orderbook in a single
@@ -66,3 +93,9 @@ type orderbookHolder struct {
 	ticker   *time.Ticker
 	updateID int64
 }
+
+// pendingSnapshot holds the deltas received while a REST snapshot is fetched
+type pendingSnapshot struct {
+	deltas   []orderbook.Update
+	overflow bool
+}
@@ -0,0 +1,154 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// JSONRPCVersion is the JSON-RPC protocol version sent with every request
+const JSONRPCVersion = "2.0"
+
+var (
+	errJSONRPCClientNotSetup = errors.New("JSON-RPC client connection or matcher not set")
+	errJSONRPCMethodEmpty    = errors.New("JSON-RPC method cannot be empty")
+)
+
+// JSONRPCRequest is a JSON-RPC 2.0 request. Notifications have a nil ID and do
+// not receive a response, calls always send their ID, including zero
+type JSONRPCRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      *int64 `json:"id,omitempty"`
+	Method  string `json:"method"`
+	Params  any    `json:"params,omitempty"`
+}
+
+// JSONRPCError is a JSON-RPC 2.0 error object
+type JSONRPCError struct {
+	Code    int64           `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// Error implements the error interface
+func (e *JSONRPCError) Error() string {
+	if len(e.Data) != 0 {
+		return fmt.Sprintf("JSON-RPC error code: %d message: %s data: %s", e.Code, e.Message, e.Data)
+	}
+	return fmt.Sprintf("JSON-RPC error code: %d message: %s", e.Code, e.Message)
+}
+
+// JSONRPCMessage is an incoming JSON-RPC 2.0 message, which is either a
+// response to a request or a notification from the exchange
+type JSONRPCMessage struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      *int64          `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params"`
+	Result  json.RawMessage `json:"result"`
+	Error   *JSONRPCError   `json:"error"`
+}
+
+// IsNotification returns whether the message is a notification rather than a
+// response to a request
+func (m *JSONRPCMessage) IsNotification() bool {
+	return m.ID == nil && m.Method != ""
+}
+
+// JSONRPCClient sends JSON-RPC 2.0 requests over a websocket connection and
+// routes their responses back to the caller via the websocket's Match
+type JSONRPCClient struct {
+	Conn  Connection
+	Match *Match
+}
+
+// NewJSONRPCClient returns a JSON-RPC client for a websocket connection; match
+// must be the Match used by the connection
+func NewJSONRPCClient(conn Connection, match *Match) *JSONRPCClient {
+	return &JSONRPCClient{Conn: conn, Match: match}
+}
+
+// CallRaw sends a request and returns the raw response once received, or a
+// *JSONRPCError if the exchange responded with an error object
+func (c *JSONRPCClient) CallRaw(ctx context.Context, method string, params any) ([]byte, error) {
+	if c.Conn == nil || c.Match == nil {
+		return nil, errJSONRPCClientNotSetup
+	}
+	if method == "" {
+		return nil, errJSONRPCMethodEmpty
+	}
+	id := c.Conn.GenerateMessageID(false)
+	resp, err := c.Conn.SendMessageReturnResponseWithContext(ctx, id, &JSONRPCRequest{
+		JSONRPC: JSONRPCVersion,
+		ID:      &id,
+		Method:  method,
+		Params:  params,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var msg JSONRPCMessage
+	if err = json.Unmarshal(resp, &msg); err != nil {
+		return nil, fmt.Errorf("%s: %w", method, err)
+	}
+	if msg.Error != nil {
+		return nil, fmt.Errorf("%s: %w", method, msg.Error)
+	}
+	return resp, nil
+}
+
+// Call sends a request and unmarshals the response result into result, which
+// may be nil if the result is not required
+func (c *JSONRPCClient) Call(ctx context.Context, method string, params, result any) error {
+	resp, err := c.CallRaw(ctx, method, params)
+	if err != nil || result == nil {
+		return err
+	}
+	var msg struct {
+		Result json.RawMessage `json:"result"`
+	}
+	if err = json.Unmarshal(resp, &msg); err != nil {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+	if len(msg.Result) == 0 {
+		return nil
+	}
+	if err = json.Unmarshal(msg.Result, result); err != nil {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+	return nil
+}
+
+// Notify sends a notification, for which no response is expected
+func (c *JSONRPCClient) Notify(method string, params any) error {
+	if c.Conn == nil {
+		return errJSONRPCClientNotSetup
+	}
+	if method == "" {
+		return errJSONRPCMethodEmpty
+	}
+	return c.Conn.SendJSONMessage(&JSONRPCRequest{
+		JSONRPC: JSONRPCVersion,
+		Method:  method,
+		Params:  params,
+	})
+}
+
+// HandleMessage routes an incoming message. Responses to pending requests are
+// delivered to their caller and a nil message is returned. Error responses
+// which match no request are returned as a *JSONRPCError alongside the
+// message; all other messages are returned for the exchange to process
+func (c *JSONRPCClient) HandleMessage(raw []byte) (*JSONRPCMessage, error) {
+	var msg JSONRPCMessage
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		return nil, err
+	}
+	if msg.ID != nil && c.Match != nil && c.Match.IncomingWithData(*msg.ID, raw) {
+		return nil, nil
+	}
+	if msg.Error != nil {
+		return &msg, msg.Error
+	}
+	return &msg, nil
+}
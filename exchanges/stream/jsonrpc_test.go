@@ -0,0 +1,134 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONRPCClient(t *testing.T) {
+	t.Parallel()
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		for {
+			var req JSONRPCRequest
+			if err = c.ReadJSON(&req); err != nil {
+				return
+			}
+			if req.JSONRPC != JSONRPCVersion {
+				return
+			}
+			if req.Method == "notify" {
+				assert.Nil(t, req.ID, "notifications must not send an ID")
+			} else if !assert.NotNil(t, req.ID, "calls must send an ID") {
+				return
+			}
+			var resp string
+			switch req.Method {
+			case "fail":
+				resp = `{"jsonrpc":"2.0","id":` + strconv.FormatInt(*req.ID, 10) + `,"error":{"code":20001,"message":"Insufficient funds"}}`
+			case "notify":
+				resp = `{"jsonrpc":"2.0","method":"ticker","params":{"last":"1"}}`
+			default:
+				resp = `{"jsonrpc":"2.0","id":` + strconv.FormatInt(*req.ID, 10) + `,"result":{"method":"` + req.Method + `"}}`
+			}
+			if err = c.WriteMessage(websocket.TextMessage, []byte(resp)); err != nil {
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	wc := &WebsocketConnection{
+		URL:              "ws" + strings.TrimPrefix(srv.URL, "http"),
+		Traffic:          make(chan struct{}, 1),
+		ShutdownC:        make(chan struct{}),
+		Match:            NewMatch(),
+		ResponseMaxLimit: time.Second * 5,
+	}
+	require.NoError(t, wc.Dial(&websocket.Dialer{}, http.Header{}))
+
+	c := NewJSONRPCClient(wc, wc.Match)
+	notifications := make(chan *JSONRPCMessage, 1)
+	go func() {
+		for {
+			resp := wc.ReadMessage()
+			if resp.Raw == nil {
+				return
+			}
+			if msg, err := c.HandleMessage(resp.Raw); err == nil && msg != nil {
+				notifications <- msg
+			}
+		}
+	}()
+
+	var result struct {
+		Method string `json:"method"`
+	}
+	require.NoError(t, c.Call(context.Background(), "getSymbol", nil, &result), "Call must not error")
+	assert.Equal(t, "getSymbol", result.Method, "Call should unmarshal the result")
+
+	err := c.Call(context.Background(), "fail", nil, nil)
+	var rpcErr *JSONRPCError
+	require.ErrorAs(t, err, &rpcErr, "Call must return the error object")
+	assert.Equal(t, int64(20001), rpcErr.Code, "Error code should be returned")
+
+	assert.ErrorIs(t, c.Call(context.Background(), "", nil, nil), errJSONRPCMethodEmpty, "Call should error without a method")
+	assert.ErrorIs(t, (&JSONRPCClient{}).Notify("notify", nil), errJSONRPCClientNotSetup, "Notify should error without a connection")
+
+	require.NoError(t, c.Notify("notify", nil), "Notify must not error")
+	select {
+	case msg := <-notifications:
+		assert.True(t, msg.IsNotification(), "Message should be a notification")
+		assert.Equal(t, "ticker", msg.Method, "Notification method should be returned")
+	case <-time.After(time.Second * 5):
+		assert.Fail(t, "Notification should be returned by HandleMessage")
+	}
+	require.NoError(t, wc.Shutdown())
+}
+
+func TestJSONRPCRequestID(t *testing.T) {
+	t.Parallel()
+	var id int64
+	b, err := json.Marshal(&JSONRPCRequest{JSONRPC: JSONRPCVersion, ID: &id, Method: "call"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","id":0,"method":"call"}`, string(b), "calls with an ID of zero must not be sent as notifications")
+	b, err = json.Marshal(&JSONRPCRequest{JSONRPC: JSONRPCVersion, Method: "notify"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"jsonrpc":"2.0","method":"notify"}`, string(b), "notifications should omit the ID")
+}
+
+func TestJSONRPCHandleMessage(t *testing.T) {
+	t.Parallel()
+	c := NewJSONRPCClient(nil, NewMatch())
+	_, err := c.HandleMessage([]byte(`{"jsonrpc":"2.0","id":7,"error":{"code":1002,"message":"Authorisation failed"}}`))
+	var rpcErr *JSONRPCError
+	require.ErrorAs(t, err, &rpcErr, "Unmatched error responses must return the error object")
+	assert.Equal(t, int64(1002), rpcErr.Code, "Error code should be returned")
+
+	m, err := c.Match.Set(int64(7))
+	require.NoError(t, err, "Match Set must not error")
+	defer m.Cleanup()
+	msg, err := c.HandleMessage([]byte(`{"jsonrpc":"2.0","id":7,"result":true}`))
+	require.NoError(t, err, "HandleMessage must not error")
+	assert.Nil(t, msg, "Matched responses should be delivered to the caller")
+	assert.Equal(t, `{"jsonrpc":"2.0","id":7,"result":true}`, string(<-m.C), "Matched response should be delivered")
+
+	_, err = c.HandleMessage([]byte(`{`))
+	var syntaxErr *json.SyntaxError
+	assert.ErrorAs(t, err, &syntaxErr, "Invalid JSON should error")
+}
@@ -0,0 +1,72 @@
+package stream
+
+import (
+	"hash/fnv"
+	"sync"
+)
+
+// messageProcessorBuffer is the number of messages which can be queued per
+// worker before Process blocks
+const messageProcessorBuffer = 256
+
+// MessageProcessor processes websocket messages across a pool of workers.
+// Messages are assigned to a worker by key, such as the instrument they relate
+// to, so messages sharing a key are processed in the order they are submitted
+// while messages for other keys are processed concurrently
+type MessageProcessor struct {
+	handler     func([]byte) error
+	dataHandler chan<- interface{}
+	workers     []chan []byte
+	wg          sync.WaitGroup
+}
+
+// NewMessageProcessor returns a message processor for the websocket's
+// configured number of processing workers. With no workers configured,
+// messages are processed synchronously by Process. Handler errors are sent to
+// the websocket's data handler. Close must be called once all messages have
+// been submitted
+func (w *Websocket) NewMessageProcessor(handler func([]byte) error) *MessageProcessor {
+	p := &MessageProcessor{
+		handler:     handler,
+		dataHandler: w.DataHandler,
+		workers:     make([]chan []byte, w.processingWorkers),
+	}
+	for i := range p.workers {
+		p.workers[i] = make(chan []byte, messageProcessorBuffer)
+		p.wg.Add(1)
+		go p.work(p.workers[i])
+	}
+	return p
+}
+
+// Process handles a message on the worker assigned to its key
+func (p *MessageProcessor) Process(key string, msg []byte) {
+	if len(p.workers) == 0 {
+		p.handle(msg)
+		return
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	p.workers[h.Sum32()%uint32(len(p.workers))] <- msg
+}
+
+// Close waits for all submitted messages to be processed and stops the workers
+func (p *MessageProcessor) Close() {
+	for i := range p.workers {
+		close(p.workers[i])
+	}
+	p.wg.Wait()
+}
+
+func (p *MessageProcessor) work(msgs <-chan []byte) {
+	defer p.wg.Done()
+	for msg := range msgs {
+		p.handle(msg)
+	}
+}
+
+func (p *MessageProcessor) handle(msg []byte) {
+	if err := p.handler(msg); err != nil {
+		p.dataHandler <- err
+	}
+}
@@ -0,0 +1,50 @@
+package stream
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errProcessorTest = errors.New("processor test error")
+
+func TestMessageProcessor(t *testing.T) {
+	t.Parallel()
+	for _, workers := range []int{0, 4} {
+		t.Run(strconv.Itoa(workers), func(t *testing.T) {
+			t.Parallel()
+			w := &Websocket{DataHandler: make(chan interface{}, 1), processingWorkers: workers}
+			var mtx sync.Mutex
+			processed := make(map[byte][]byte)
+			p := w.NewMessageProcessor(func(msg []byte) error {
+				if msg[0] == 'x' {
+					return errProcessorTest
+				}
+				mtx.Lock()
+				processed[msg[0]] = append(processed[msg[0]], msg[1])
+				mtx.Unlock()
+				return nil
+			})
+			for i := range byte(100) {
+				for _, key := range []byte("abc") {
+					p.Process(string(key), []byte{key, i})
+				}
+			}
+			p.Process("x", []byte("x"))
+			p.Close()
+
+			require.Len(t, processed, 3, "messages for every key must be processed")
+			for key, seq := range processed {
+				require.Lenf(t, seq, 100, "all messages for key %c must be processed", key)
+				for i := range seq {
+					assert.Equalf(t, byte(i), seq[i], "messages for key %c should be processed in order", key)
+				}
+			}
+			assert.ErrorIs(t, (<-w.DataHandler).(error), errProcessorTest, "handler errors should be sent to the data handler")
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package stream
 
 import (
+	"context"
 	"net/http"
 	"time"
 
@@ -8,6 +9,8 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/subscription"
 )
 
 // Connection defines a streaming services connection
@@ -18,6 +21,8 @@ type Connection interface {
 	SetupPingHandler(PingHandler)
 	GenerateMessageID(highPrecision bool) int64
 	SendMessageReturnResponse(signature interface{}, request interface{}) ([]byte, error)
+	SendMessageReturnResponseWithContext(ctx context.Context, signature, payload interface{}) ([]byte, error)
+	SendRateLimitedMessageReturnResponse(ctx context.Context, ep request.EndpointLimit, signature, payload interface{}) ([]byte, error)
 	SendRawMessage(messageType int, message []byte) error
 	SetURL(string)
 	SetProxy(string)
@@ -33,9 +38,16 @@ type Response struct {
 
 // ConnectionSetup defines variables for an individual stream connection
 type ConnectionSetup struct {
-	ResponseCheckTimeout    time.Duration
-	ResponseMaxLimit        time.Duration
-	RateLimit               int64
+	ResponseCheckTimeout time.Duration
+	ResponseMaxLimit     time.Duration
+	RateLimit            int64
+	// RateLimiter is drawn from before sending requests which await a
+	// response, allowing exchanges to share a rate limit budget between
+	// websocket and REST requests
+	RateLimiter request.Limiter
+	// RateLimitEndpoint is the endpoint drawn from RateLimiter when sending
+	// requests without a specific endpoint
+	RateLimitEndpoint       request.EndpointLimit
 	URL                     string
 	Authenticated           bool
 	ConnectionLevelReporter Reporter
@@ -91,6 +103,15 @@ type UnhandledMessageWarning struct {
 	Message string
 }
 
+// StaleChannelWarning is sent when a subscribed channel has not received a
+// message within the exchange's channel staleness timeout. LastMessage is the
+// time of the previous warning for channels which remain stale
+type StaleChannelWarning struct {
+	Exchange     string
+	Subscription subscription.Subscription
+	LastMessage  time.Time
+}
+
 // Reporter interface groups observability functionality over
 // Websocket request latency.
 type Reporter interface {
@@ -1,6 +1,7 @@
 package stream
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net"
@@ -11,6 +12,7 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/latency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/subscription"
 	"github.com/thrasher-corp/gocryptotrader/log"
 )
@@ -57,6 +59,7 @@ var (
 	errClosedConnection                     = errors.New("use of closed network connection")
 	errSubscriptionsExceedsLimit            = errors.New("subscriptions exceeds limit")
 	errInvalidMaxSubscriptions              = errors.New("max subscriptions cannot be less than 0")
+	errInvalidProcessingWorkers             = errors.New("websocket processing workers cannot be less than 0")
 	errNoSubscriptionsSupplied              = errors.New("no subscriptions supplied")
 	errChannelAlreadySubscribed             = errors.New("channel already subscribed")
 	errInvalidChannelState                  = errors.New("invalid Channel state")
@@ -65,12 +68,20 @@ var (
 	errAlreadyConnected                     = errors.New("websocket already connected")
 	errCannotShutdown                       = errors.New("websocket cannot shutdown")
 	errAlreadyReconnecting                  = errors.New("websocket in the process of reconnection")
+	errInvalidReconnectDelay                = errors.New("reconnect delay must not be negative")
 	errConnSetup                            = errors.New("error in connection setup")
+	errResyncInProgress                     = errors.New("subscription resync already in progress")
+	errResyncUnsupported                    = errors.New("subscription cannot be resynced without unsubscribe support or an orderbook snapshot fetcher")
+	errInvalidShards                        = errors.New("websocket shards cannot be less than 0")
+	errShardingUnsupported                  = errors.New("websocket sharding is not supported by the exchange")
+	errNoShardConnected                     = errors.New("no websocket shard connected")
+	errShardCapacityExceeded                = errors.New("websocket shards are at their subscription capacity")
 )
 
 var (
-	globalReporter       Reporter
-	trafficCheckInterval = 100 * time.Millisecond
+	globalReporter         Reporter
+	globalLatencySimulator *latency.Simulator
+	trafficCheckInterval   = 100 * time.Millisecond
 )
 
 // SetupGlobalReporter sets a reporter interface to be used
@@ -79,6 +90,13 @@ func SetupGlobalReporter(r Reporter) {
 	globalReporter = r
 }
 
+// SetupGlobalLatencySimulator sets a latency simulator to delay messages read
+// from all websocket connections set up afterwards, used to test strategies
+// under poor connectivity
+func SetupGlobalLatencySimulator(s *latency.Simulator) {
+	globalLatencySimulator = s
+}
+
 // NewWebsocket initialises the websocket struct
 func NewWebsocket() *Websocket {
 	return &Websocket{
@@ -146,7 +164,35 @@ func (w *Websocket) Setup(s *WebsocketSetup) error {
 	if w.connectionMonitorDelay <= 0 {
 		w.connectionMonitorDelay = config.DefaultConnectionMonitorDelay
 	}
+	w.reconcileInterval = s.ExchangeConfig.SubscriptionReconcileInterval
+	w.stalenessTimeout = s.ExchangeConfig.ChannelStalenessTimeout
+	w.resubscribeStale = s.ExchangeConfig.ResubscribeStaleChannels
+	w.orderEntry = s.ExchangeConfig.WebsocketOrderEntry
+	if s.ExchangeConfig.WebsocketProcessingWorkers < 0 {
+		return fmt.Errorf("%s %w", w.exchangeName, errInvalidProcessingWorkers)
+	}
+	w.processingWorkers = s.ExchangeConfig.WebsocketProcessingWorkers
+	w.subscriptionLister = s.SubscriptionLister
 	w.Unsubscriber = s.Unsubscriber
+	if s.ExchangeConfig.WebsocketShards < 0 {
+		return fmt.Errorf("%s %w", w.exchangeName, errInvalidShards)
+	}
+	if s.ExchangeConfig.WebsocketShards > 1 {
+		if s.ShardConnector == nil || s.ShardSubscriber == nil || s.ShardHandler == nil || (w.features.Unsubscribe && s.ShardUnsubscriber == nil) {
+			return fmt.Errorf("%s %w", w.exchangeName, errShardingUnsupported)
+		}
+		w.shardCount = s.ExchangeConfig.WebsocketShards
+		w.shardURL = s.ShardURL
+		w.shardConnector = s.ShardConnector
+		w.shardSubscriber = s.ShardSubscriber
+		w.shardUnsubscriber = s.ShardUnsubscriber
+		w.shardHandler = s.ShardHandler
+		// Subscriptions are distributed across the shards, so they cannot be
+		// listed from a single connection
+		w.Subscriber = w.shardSubscribe
+		w.Unsubscriber = w.shardUnsubscribe
+		w.subscriptionLister = nil
+	}
 
 	if s.GenerateSubscriptions == nil {
 		return fmt.Errorf("%s %w", w.exchangeName, errWebsocketSubscriptionsGeneratorUnset)
@@ -221,20 +267,37 @@ func (w *Websocket) SetupNewConnection(c ConnectionSetup) error {
 		return fmt.Errorf("%w: %w", errConnSetup, errReadMessageErrorsNil)
 	}
 
+	if !c.Authenticated {
+		w.shardConnectionSetup = c
+	}
+	newConn := w.newConnection(&c)
+
+	if c.Authenticated {
+		w.AuthConn = newConn
+	} else {
+		w.Conn = newConn
+	}
+
+	return nil
+}
+
+// newConnection returns a connection for the websocket, dialing the
+// websocket's running URL unless set by the connection setup
+func (w *Websocket) newConnection(c *ConnectionSetup) *WebsocketConnection {
 	connectionURL := w.GetWebsocketURL()
 	if c.URL != "" {
 		connectionURL = c.URL
 	}
 
-	if c.ConnectionLevelReporter == nil {
-		c.ConnectionLevelReporter = w.ExchangeLevelReporter
+	reporter := c.ConnectionLevelReporter
+	if reporter == nil {
+		reporter = w.ExchangeLevelReporter
 	}
-
-	if c.ConnectionLevelReporter == nil {
-		c.ConnectionLevelReporter = globalReporter
+	if reporter == nil {
+		reporter = globalReporter
 	}
 
-	newConn := &WebsocketConnection{
+	return &WebsocketConnection{
 		ExchangeName:      w.exchangeName,
 		URL:               connectionURL,
 		ProxyURL:          w.GetProxyAddress(),
@@ -246,16 +309,11 @@ func (w *Websocket) SetupNewConnection(c ConnectionSetup) error {
 		Wg:                w.Wg,
 		Match:             w.Match,
 		RateLimit:         c.RateLimit,
-		Reporter:          c.ConnectionLevelReporter,
-	}
-
-	if c.Authenticated {
-		w.AuthConn = newConn
-	} else {
-		w.Conn = newConn
+		RateLimiter:       c.RateLimiter,
+		RateLimitEndpoint: c.RateLimitEndpoint,
+		Reporter:          reporter,
+		latency:           globalLatencySimulator,
 	}
-
-	return nil
 }
 
 // Connect initiates a websocket connection by using a package defined connection
@@ -285,12 +343,25 @@ func (w *Websocket) Connect() error {
 	w.trafficMonitor()
 	w.setState(connecting)
 
-	err := w.connector()
+	// The connector may start routines which run for the lifetime of the
+	// connection, so its context is only cancelled by shutdown
+	ctx, cancel := w.ShutdownContext()
+	err := w.connector(ctx)
 	if err != nil {
+		cancel()
 		w.setState(disconnected)
 		return fmt.Errorf("%v Error connecting %w", w.exchangeName, err)
 	}
+	if w.shardCount > 1 {
+		if err := w.connectShards(ctx); err != nil {
+			cancel()
+			w.shutdownConnections()
+			w.setState(disconnected)
+			return fmt.Errorf("%v Error connecting %w", w.exchangeName, err)
+		}
+	}
 	w.setState(connected)
+	w.connections.Add(1)
 
 	if !w.IsConnectionMonitorRunning() {
 		err = w.connectionMonitor()
@@ -298,11 +369,14 @@ func (w *Websocket) Connect() error {
 			log.Errorf(log.WebsocketMgr, "%s cannot start websocket connection monitor %v", w.GetName(), err)
 		}
 	}
+	w.subscriptionReconciler()
+	w.stalenessWatchdog()
 
 	subs, err := w.GenerateSubs() // regenerate state on new connection
 	if err != nil {
 		return fmt.Errorf("%s websocket: %w", w.exchangeName, common.AppendError(ErrSubscriptionFailure, err))
 	}
+	w.setDesiredSubscriptions(subs)
 	if len(subs) == 0 {
 		return nil
 	}
@@ -310,13 +384,30 @@ func (w *Websocket) Connect() error {
 	if err != nil {
 		return fmt.Errorf("%s websocket: %w", w.exchangeName, common.AppendError(ErrSubscriptionFailure, err))
 	}
-	err = w.Subscriber(subs)
+	err = w.Subscriber(ctx, subs)
 	if err != nil {
 		return fmt.Errorf("%s websocket: %w", w.exchangeName, common.AppendError(ErrSubscriptionFailure, err))
 	}
 	return nil
 }
 
+// ShutdownContext returns a context which is cancelled when the websocket shuts
+// down, so that connection and subscription requests are interrupted. Message
+// handlers, which have no caller context, should use it for requests such as
+// ResyncSubscription. The cancel func must be called once the work is done
+func (w *Websocket) ShutdownContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	shutdown := w.ShutdownC
+	go func() {
+		select {
+		case <-shutdown:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return ctx, cancel
+}
+
 // Disable disables the exchange websocket protocol
 // Note that connectionMonitor will be responsible for shutting down the websocket after disabling
 func (w *Websocket) Disable() error {
@@ -380,10 +471,8 @@ func (w *Websocket) connectionMonitor() error {
 	if w.checkAndSetMonitorRunning() {
 		return errAlreadyRunning
 	}
-	delay := w.connectionMonitorDelay
-
 	go func() {
-		timer := time.NewTimer(delay)
+		timer := time.NewTimer(w.getReconnectDelay())
 		for {
 			if w.verbose {
 				log.Debugf(log.WebsocketMgr, "%v websocket: running connection monitor cycle", w.exchangeName)
@@ -428,7 +517,7 @@ func (w *Websocket) connectionMonitor() error {
 					default:
 					}
 				}
-				timer.Reset(delay)
+				timer.Reset(w.getReconnectDelay())
 			}
 		}
 	}()
@@ -468,11 +557,19 @@ func (w *Websocket) Shutdown() error {
 		}
 	}
 
+	if err := w.shutdownShards(); err != nil {
+		return err
+	}
+
 	// flush any subscriptions from last connection if needed
 	w.subscriptionMutex.Lock()
 	w.subscriptions = subscriptionMap{}
 	w.subscriptionMutex.Unlock()
 
+	w.activityMutex.Lock()
+	w.channelActivity = nil
+	w.activityMutex.Unlock()
+
 	w.setState(disconnected)
 
 	close(w.ShutdownC)
@@ -530,6 +627,7 @@ func (w *Websocket) FlushChannels() error {
 			w.subscriptionMutex.Lock()
 			w.subscriptions = subscriptionMap{}
 			w.subscriptionMutex.Unlock()
+			w.setDesiredSubscriptions(newsubs)
 			return w.SubscribeToChannels(newsubs)
 		}
 		return nil
@@ -600,6 +698,255 @@ func (w *Websocket) trafficMonitor() {
 	}()
 }
 
+// subscriptionReconciler periodically restores desired subscriptions which
+// have been silently dropped, if a reconcile interval is configured
+func (w *Websocket) subscriptionReconciler() {
+	if w.reconcileInterval <= 0 || !w.reconcilerRunning.CompareAndSwap(false, true) {
+		return
+	}
+	shutdown := w.ShutdownC
+	w.Wg.Add(1)
+
+	go func() {
+		defer func() {
+			w.reconcilerRunning.Store(false)
+			w.Wg.Done()
+		}()
+		t := time.NewTicker(w.reconcileInterval)
+		defer t.Stop()
+		for {
+			select {
+			case <-shutdown:
+				return
+			case <-t.C:
+				if err := w.ReconcileSubscriptions(); err != nil {
+					log.Errorln(log.WebsocketMgr, err)
+				}
+			}
+		}
+	}()
+}
+
+// ReconcileSubscriptions compares the desired subscriptions against those
+// acknowledged by the exchange and resubscribes any which are missing. When the
+// exchange supports listing its subscriptions, any not reported by the exchange
+// are treated as dropped
+func (w *Websocket) ReconcileSubscriptions() error {
+	if !w.IsConnected() {
+		return fmt.Errorf("%s %w", w.exchangeName, ErrNotConnected)
+	}
+	ctx, cancel := w.ShutdownContext()
+	defer cancel()
+
+	if w.subscriptionLister != nil {
+		reported, err := w.subscriptionLister(ctx)
+		if err != nil {
+			return fmt.Errorf("%s websocket: cannot list subscriptions: %w", w.exchangeName, err)
+		}
+		w.pruneUnreportedSubscriptions(reported)
+	}
+
+	missing := w.missingSubscriptions()
+	if len(missing) == 0 {
+		return nil
+	}
+	log.Warnf(log.WebsocketMgr, "%s websocket: resubscribing to %d dropped channels", w.exchangeName, len(missing))
+	if err := w.checkSubscriptions(missing); err != nil {
+		return fmt.Errorf("%s websocket: %w", w.exchangeName, common.AppendError(ErrSubscriptionFailure, err))
+	}
+	if err := w.Subscriber(ctx, missing); err != nil {
+		return fmt.Errorf("%s websocket: %w", w.exchangeName, common.AppendError(ErrSubscriptionFailure, err))
+	}
+	return nil
+}
+
+// pruneUnreportedSubscriptions removes acknowledged subscriptions which the
+// exchange no longer reports as active
+func (w *Websocket) pruneUnreportedSubscriptions(reported []subscription.Subscription) {
+	active := make(map[any]struct{}, len(reported))
+	for i := range reported {
+		active[reported[i].EnsureKeyed()] = struct{}{}
+	}
+	w.subscriptionMutex.Lock()
+	defer w.subscriptionMutex.Unlock()
+	for k, s := range w.subscriptions {
+		if _, ok := active[k]; !ok && s.State == subscription.SubscribedState {
+			delete(w.subscriptions, k)
+		}
+	}
+}
+
+// missingSubscriptions returns the desired subscriptions which are not held
+func (w *Websocket) missingSubscriptions() []subscription.Subscription {
+	w.subscriptionMutex.RLock()
+	defer w.subscriptionMutex.RUnlock()
+	var missing []subscription.Subscription
+	for k, s := range w.desiredSubscriptions {
+		if _, ok := w.subscriptions[k]; !ok {
+			missing = append(missing, *s)
+		}
+	}
+	return missing
+}
+
+// setDesiredSubscriptions replaces the desired subscriptions
+func (w *Websocket) setDesiredSubscriptions(subs []subscription.Subscription) {
+	w.subscriptionMutex.Lock()
+	w.desiredSubscriptions = subscriptionMap{}
+	w.subscriptionMutex.Unlock()
+	w.addDesiredSubscriptions(subs)
+}
+
+// addDesiredSubscriptions adds subscriptions to the desired subscriptions
+func (w *Websocket) addDesiredSubscriptions(subs []subscription.Subscription) {
+	w.subscriptionMutex.Lock()
+	defer w.subscriptionMutex.Unlock()
+	if w.desiredSubscriptions == nil {
+		w.desiredSubscriptions = subscriptionMap{}
+	}
+	for i := range subs {
+		s := subs[i]
+		s.State = subscription.UnknownState
+		w.desiredSubscriptions[s.EnsureKeyed()] = &s
+	}
+}
+
+// removeDesiredSubscriptions removes subscriptions from the desired
+// subscriptions so they are not restored by the reconciler
+func (w *Websocket) removeDesiredSubscriptions(subs []subscription.Subscription) {
+	w.subscriptionMutex.Lock()
+	defer w.subscriptionMutex.Unlock()
+	for i := range subs {
+		delete(w.desiredSubscriptions, subs[i].EnsureKeyed())
+	}
+}
+
+// RecordSubscriptionActivity records that a message was received for the
+// subscription key provided, for use by the staleness watchdog
+func (w *Websocket) RecordSubscriptionActivity(key any) {
+	if w.stalenessTimeout <= 0 || key == nil {
+		return
+	}
+	w.activityMutex.Lock()
+	if w.channelActivity == nil {
+		w.channelActivity = make(map[any]time.Time)
+	}
+	w.channelActivity[key] = time.Now()
+	w.activityMutex.Unlock()
+}
+
+// stalenessWatchdog periodically checks for subscribed channels which have
+// gone silent, if a channel staleness timeout is configured
+func (w *Websocket) stalenessWatchdog() {
+	if w.stalenessTimeout <= 0 || !w.stalenessWatchdogRunning.CompareAndSwap(false, true) {
+		return
+	}
+	shutdown := w.ShutdownC
+	w.Wg.Add(1)
+
+	go func() {
+		defer func() {
+			w.stalenessWatchdogRunning.Store(false)
+			w.Wg.Done()
+		}()
+		t := time.NewTicker(w.stalenessTimeout / 2)
+		defer t.Stop()
+		for {
+			select {
+			case <-shutdown:
+				return
+			case <-t.C:
+				w.checkStaleSubscriptions(time.Now())
+			}
+		}
+	}()
+}
+
+// checkStaleSubscriptions raises a warning for each stale channel and
+// resubscribes to them when enabled and supported by the exchange
+func (w *Websocket) checkStaleSubscriptions(now time.Time) {
+	warnings := w.staleSubscriptions(now)
+	if len(warnings) == 0 {
+		return
+	}
+	stale := make([]subscription.Subscription, len(warnings))
+	for i := range warnings {
+		w.DataHandler <- warnings[i]
+		stale[i] = warnings[i].Subscription
+	}
+	if !w.resubscribeStale || !w.features.Unsubscribe {
+		return
+	}
+	log.Warnf(log.WebsocketMgr, "%s websocket: resubscribing to %d stale channels", w.exchangeName, len(stale))
+	if err := w.UnsubscribeChannels(stale); err != nil {
+		log.Errorln(log.WebsocketMgr, err)
+		return
+	}
+	if err := w.SubscribeToChannels(stale); err != nil {
+		log.Errorln(log.WebsocketMgr, err)
+	}
+}
+
+// staleSubscriptions returns warnings for subscribed channels which have not
+// received a message within the staleness timeout. Channels without recorded
+// activity are given a full timeout from the first check, and each stale
+// channel is only reported once per timeout
+func (w *Websocket) staleSubscriptions(now time.Time) []StaleChannelWarning {
+	subs := w.GetSubscriptions()
+	w.activityMutex.Lock()
+	defer w.activityMutex.Unlock()
+	activity := make(map[any]time.Time, len(subs))
+	var warnings []StaleChannelWarning
+	for i := range subs {
+		if subs[i].State != subscription.SubscribedState {
+			continue
+		}
+		key := subs[i].EnsureKeyed()
+		last, ok := w.channelActivity[key]
+		switch {
+		case !ok:
+			activity[key] = now
+		case now.Sub(last) >= w.stalenessTimeout:
+			warnings = append(warnings, StaleChannelWarning{
+				Exchange:     w.exchangeName,
+				Subscription: subs[i],
+				LastMessage:  last,
+			})
+			activity[key] = now
+		default:
+			activity[key] = last
+		}
+	}
+	w.channelActivity = activity // Drops activity for channels no longer subscribed
+	return warnings
+}
+
+// ConnectionCount returns the number of times the websocket has connected,
+// so that reconnections can be measured
+func (w *Websocket) ConnectionCount() uint64 {
+	return w.connections.Load()
+}
+
+// SetReconnectDelay overrides the delay between the connection monitor's
+// reconnection attempts, such as while an exchange is under maintenance. The
+// new delay applies from the monitor's next attempt. Zero restores the
+// configured connection monitor delay
+func (w *Websocket) SetReconnectDelay(d time.Duration) error {
+	if d < 0 {
+		return fmt.Errorf("%s %w: %v", w.exchangeName, errInvalidReconnectDelay, d)
+	}
+	w.reconnectDelay.Store(int64(d))
+	return nil
+}
+
+// getReconnectDelay returns the delay between reconnection attempts
+func (w *Websocket) getReconnectDelay() time.Duration {
+	if d := w.reconnectDelay.Load(); d > 0 {
+		return time.Duration(d)
+	}
+	return w.connectionMonitorDelay
+}
+
 func (w *Websocket) setState(s uint32) {
 	w.state.Store(s)
 }
@@ -671,6 +1018,14 @@ func (w *Websocket) CanUseAuthenticatedWebsocketForWrapper() bool {
 	return false
 }
 
+// CanUseWebsocketOrderEntry returns whether orders should be submitted, amended
+// and cancelled over the authenticated websocket connection instead of REST,
+// which avoids a round trip to establish a new request. This requires
+// websocket order entry to be enabled in the exchange config
+func (w *Websocket) CanUseWebsocketOrderEntry() bool {
+	return w.orderEntry && w.CanUseAuthenticatedWebsocketForWrapper()
+}
+
 // SetWebsocketURL sets websocket URL and can refresh underlying connections
 func (w *Websocket) SetWebsocketURL(url string, auth, reconnect bool) error {
 	defaultVals := url == "" || url == config.WebsocketURLNonDefaultMessage
@@ -825,7 +1180,10 @@ func (w *Websocket) UnsubscribeChannels(channels []subscription.Subscription) er
 		}
 	}
 	w.subscriptionMutex.RUnlock()
-	return w.Unsubscriber(channels)
+	w.removeDesiredSubscriptions(channels)
+	ctx, cancel := w.ShutdownContext()
+	defer cancel()
+	return w.Unsubscriber(ctx, channels)
 }
 
 // ResubscribeToChannel resubscribes to channel
@@ -837,12 +1195,69 @@ func (w *Websocket) ResubscribeToChannel(subscribedChannel *subscription.Subscri
 	return w.SubscribeToChannels([]subscription.Subscription{*subscribedChannel})
 }
 
+// ResyncSubscription recovers a channel which has drifted out of sync, such as
+// an orderbook which has missed an update, by resubscribing to it and then
+// refetching a REST orderbook snapshot for its pair when the orderbook buffer
+// has a snapshot fetcher. Channels are only resubscribed when the exchange
+// supports unsubscribing. When neither is possible the pair's orderbook is
+// flushed so it is not used while out of sync, and errResyncUnsupported is
+// returned
+func (w *Websocket) ResyncSubscription(ctx context.Context, sub *subscription.Subscription) error {
+	if sub == nil {
+		return fmt.Errorf("%s websocket: %w", w.exchangeName, errNoSubscriptionsSupplied)
+	}
+	k := sub.EnsureKeyed()
+	w.resyncMutex.Lock()
+	if _, ok := w.resyncing[k]; ok {
+		w.resyncMutex.Unlock()
+		return fmt.Errorf("%s websocket %s: %w", w.exchangeName, sub, errResyncInProgress)
+	}
+	if w.resyncing == nil {
+		w.resyncing = make(map[any]struct{})
+	}
+	w.resyncing[k] = struct{}{}
+	w.resyncMutex.Unlock()
+	defer func() {
+		w.resyncMutex.Lock()
+		delete(w.resyncing, k)
+		w.resyncMutex.Unlock()
+	}()
+
+	canResubscribe := w.features != nil && w.features.Unsubscribe
+	canSync := w.Orderbook.CanSyncSnapshot() && !sub.Pair.IsEmpty()
+	if !canResubscribe && !canSync {
+		if !sub.Pair.IsEmpty() {
+			// FlushOrderbook only errors when there is no book to flush
+			_ = w.Orderbook.FlushOrderbook(sub.Pair, sub.Asset)
+		}
+		return fmt.Errorf("%s websocket %s: %w", w.exchangeName, sub, errResyncUnsupported)
+	}
+
+	log.Warnf(log.WebsocketMgr, "%s websocket: resyncing %s", w.exchangeName, sub)
+	if canResubscribe {
+		if s := w.GetSubscription(k); s != nil {
+			if err := w.ResubscribeToChannel(s); err != nil {
+				return err
+			}
+		} else if err := w.SubscribeToChannels([]subscription.Subscription{*sub}); err != nil {
+			return err
+		}
+	}
+	if !canSync {
+		return nil
+	}
+	return w.Orderbook.SyncSnapshot(ctx, sub.Pair, sub.Asset)
+}
+
 // SubscribeToChannels appends supplied channels to channelsToSubscribe
 func (w *Websocket) SubscribeToChannels(channels []subscription.Subscription) error {
 	if err := w.checkSubscriptions(channels); err != nil {
 		return fmt.Errorf("%s websocket: %w", w.exchangeName, common.AppendError(ErrSubscriptionFailure, err))
 	}
-	if err := w.Subscriber(channels); err != nil {
+	w.addDesiredSubscriptions(channels)
+	ctx, cancel := w.ShutdownContext()
+	defer cancel()
+	if err := w.Subscriber(ctx, channels); err != nil {
 		return fmt.Errorf("%s websocket: %w", w.exchangeName, common.AppendError(ErrSubscriptionFailure, err))
 	}
 	return nil
@@ -988,12 +1403,13 @@ func (w *Websocket) checkSubscriptions(subs []subscription.Subscription) error {
 	w.subscriptionMutex.RLock()
 	defer w.subscriptionMutex.RUnlock()
 
-	if w.MaxSubscriptionsPerConnection > 0 && len(w.subscriptions)+len(subs) > w.MaxSubscriptionsPerConnection {
-		return fmt.Errorf("%w: current subscriptions: %v, incoming subscriptions: %v, max subscriptions per connection: %v - please reduce enabled pairs",
+	if w.MaxSubscriptionsPerConnection > 0 && len(w.subscriptions)+len(subs) > w.MaxSubscriptionsPerConnection*max(w.shardCount, 1) {
+		return fmt.Errorf("%w: current subscriptions: %v, incoming subscriptions: %v, max subscriptions per connection: %v, connections: %v - please reduce enabled pairs",
 			errSubscriptionsExceedsLimit,
 			len(w.subscriptions),
 			len(subs),
-			w.MaxSubscriptionsPerConnection)
+			w.MaxSubscriptionsPerConnection,
+			max(w.shardCount, 1))
 	}
 
 	for i := range subs {
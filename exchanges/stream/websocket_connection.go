@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"compress/flate"
 	"compress/gzip"
+	"context"
 	"crypto/rand"
 	"encoding/json"
 	"fmt"
@@ -16,23 +17,47 @@ import (
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 	"github.com/thrasher-corp/gocryptotrader/log"
 )
 
 // SendMessageReturnResponse will send a WS message to the connection and wait
 // for response
 func (w *WebsocketConnection) SendMessageReturnResponse(signature, request interface{}) ([]byte, error) {
+	return w.SendRateLimitedMessageReturnResponse(context.Background(), w.RateLimitEndpoint, signature, request)
+}
+
+// SendMessageReturnResponseWithContext will send a WS message to the
+// connection and wait for response until the context is cancelled or its
+// deadline passes
+func (w *WebsocketConnection) SendMessageReturnResponseWithContext(ctx context.Context, signature, request interface{}) ([]byte, error) {
+	return w.SendRateLimitedMessageReturnResponse(ctx, w.RateLimitEndpoint, signature, request)
+}
+
+// SendRateLimitedMessageReturnResponse will wait on the connection's rate
+// limiter for an endpoint, then send a WS message to the connection and wait
+// for response until the context is cancelled or its deadline passes
+func (w *WebsocketConnection) SendRateLimitedMessageReturnResponse(ctx context.Context, ep request.EndpointLimit, signature, payload interface{}) ([]byte, error) {
 	m, err := w.Match.Set(signature)
 	if err != nil {
 		return nil, err
 	}
 	defer m.Cleanup()
 
-	b, err := json.Marshal(request)
+	b, err := json.Marshal(payload)
 	if err != nil {
 		return nil, fmt.Errorf("error marshaling json for %s: %w", signature, err)
 	}
 
+	if w.RateLimiter != nil {
+		if err = w.RateLimiter.Limit(ctx, ep); err != nil {
+			return nil, fmt.Errorf("%s websocket connection: failed to rate limit request: %w", w.ExchangeName, err)
+		}
+	}
+	if err = ctx.Err(); err != nil {
+		return nil, fmt.Errorf("%s websocket connection: %w", w.ExchangeName, err)
+	}
+
 	start := time.Now()
 	err = w.SendRawMessage(websocket.TextMessage, b)
 	if err != nil {
@@ -40,17 +65,19 @@ func (w *WebsocketConnection) SendMessageReturnResponse(signature, request inter
 	}
 
 	timer := time.NewTimer(w.ResponseMaxLimit)
+	defer timer.Stop()
 
 	select {
-	case payload := <-m.C:
+	case resp := <-m.C:
 		if w.Reporter != nil {
 			w.Reporter.Latency(w.ExchangeName, b, time.Since(start))
 		}
 
-		return payload, nil
+		return resp, nil
 	case <-timer.C:
-		timer.Stop()
 		return nil, fmt.Errorf("%s websocket connection: timeout waiting for response with signature: %v", w.ExchangeName, signature)
+	case <-ctx.Done():
+		return nil, fmt.Errorf("%s websocket connection: waiting for response with signature %v: %w", w.ExchangeName, signature, ctx.Err())
 	}
 }
 
@@ -230,6 +257,15 @@ func (w *WebsocketConnection) ReadMessage() Response {
 	default: // Non-Blocking write ensures 1 buffered signal per trafficCheckInterval to avoid flooding
 	}
 
+	if d := w.latency.Delay(); d > 0 {
+		t := time.NewTimer(d)
+		select {
+		case <-t.C:
+		case <-w.ShutdownC:
+			t.Stop()
+		}
+	}
+
 	var standardMessage []byte
 	switch mType {
 	case websocket.TextMessage:
@@ -0,0 +1,252 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/subscription"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// connectShards dials each of the connections subscriptions are sharded
+// across
+func (w *Websocket) connectShards(ctx context.Context) error {
+	w.shardMutex.Lock()
+	w.shardsClosed = false
+	w.shards = make([]*shard, w.shardCount)
+	for i := range w.shards {
+		w.shards[i] = &shard{id: i, subscriptions: subscriptionMap{}}
+	}
+	shards := slices.Clone(w.shards)
+	w.shardMutex.Unlock()
+
+	for _, s := range shards {
+		if err := w.dialShard(ctx, s); err != nil {
+			return fmt.Errorf("shard %d: %w", s.id, err)
+		}
+	}
+	return nil
+}
+
+// dialShard connects a shard and starts reading its messages
+func (w *Websocket) dialShard(ctx context.Context, s *shard) error {
+	c := w.shardConnectionSetup
+	c.URL = w.shardURL
+	if c.URL == "" {
+		c.URL = w.GetWebsocketURL()
+	}
+	conn := w.newConnection(&c)
+	// Disconnections are relayed to the shard's reader rather than the
+	// connection monitor, so that only the shard's subscriptions are moved
+	errs := make(chan error, 1)
+	conn.readMessageErrors = errs
+	if err := w.shardConnector(ctx, conn); err != nil {
+		return err
+	}
+
+	w.shardMutex.Lock()
+	defer w.shardMutex.Unlock()
+	if w.shardsClosed {
+		return conn.Shutdown()
+	}
+	s.conn = conn
+	s.connected = true
+	w.Wg.Add(1)
+	go w.readShard(s, conn, errs)
+	return nil
+}
+
+// readShard passes a shard's messages to the shard handler until it
+// disconnects, then rebalances its subscriptions
+func (w *Websocket) readShard(s *shard, conn Connection, errs <-chan error) {
+	defer w.Wg.Done()
+	for {
+		resp := conn.ReadMessage()
+		if resp.Raw == nil {
+			break
+		}
+		if err := w.shardHandler(conn, resp.Raw); err != nil {
+			w.DataHandler <- err
+		}
+	}
+	var reason error
+	select {
+	case reason = <-errs:
+	default:
+	}
+	w.rebalanceShard(s, reason)
+}
+
+// rebalanceShard moves a disconnected shard's subscriptions to the connected
+// shards and redials it. Should no shard remain connected, the disconnection is
+// relayed to the connection monitor to reconnect the websocket
+func (w *Websocket) rebalanceShard(s *shard, reason error) {
+	w.shardMutex.Lock()
+	if w.shardsClosed {
+		w.shardMutex.Unlock()
+		return
+	}
+	s.connected = false
+	orphaned := make([]subscription.Subscription, 0, len(s.subscriptions))
+	for _, sub := range s.subscriptions {
+		orphaned = append(orphaned, *sub)
+	}
+	s.subscriptions = subscriptionMap{}
+	w.shardMutex.Unlock()
+
+	log.Warnf(log.WebsocketMgr, "%s websocket shard %d disconnected, rebalancing %d subscriptions: %v", w.exchangeName, s.id, len(orphaned), reason)
+	w.RemoveSubscriptions(orphaned...)
+
+	ctx, cancel := w.ShutdownContext()
+	defer cancel()
+	if err := w.dialShard(ctx, s); err != nil {
+		log.Errorf(log.WebsocketMgr, "%s websocket shard %d unable to reconnect: %v", w.exchangeName, s.id, err)
+	}
+	if len(orphaned) == 0 {
+		return
+	}
+	if err := w.shardSubscribe(ctx, orphaned); err != nil {
+		w.DataHandler <- fmt.Errorf("%s websocket shard %d rebalance: %w", w.exchangeName, s.id, err)
+		if errors.Is(err, errNoShardConnected) && reason != nil {
+			select {
+			case w.ReadMessageErrors <- reason:
+			default:
+			}
+		}
+	}
+}
+
+// shardSubscribe assigns each subscription to the connected shard holding the
+// fewest subscriptions, then subscribes each shard's assignments
+func (w *Websocket) shardSubscribe(ctx context.Context, subs []subscription.Subscription) error {
+	batches := make(map[*shard][]subscription.Subscription)
+	conns := make(map[*shard]Connection)
+	var unassigned int
+	w.shardMutex.Lock()
+	for i := range subs {
+		s := w.leastLoadedShard()
+		if s == nil {
+			unassigned++
+			continue
+		}
+		sub := subs[i]
+		s.subscriptions[sub.EnsureKeyed()] = &sub
+		batches[s] = append(batches[s], sub)
+		conns[s] = s.conn
+	}
+	w.shardMutex.Unlock()
+
+	var errs error
+	if unassigned > 0 {
+		if len(batches) == 0 && !w.hasConnectedShard() {
+			errs = fmt.Errorf("%w: %d subscriptions unassigned", errNoShardConnected, unassigned)
+		} else {
+			errs = fmt.Errorf("%w: %d subscriptions unassigned", errShardCapacityExceeded, unassigned)
+		}
+	}
+	for s, batch := range batches {
+		if err := w.shardSubscriber(ctx, conns[s], batch); err != nil {
+			w.unassignShard(s, batch)
+			errs = common.AppendError(errs, fmt.Errorf("shard %d: %w", s.id, err))
+		}
+	}
+	return errs
+}
+
+// shardUnsubscribe unsubscribes each subscription from the shard it is
+// assigned to
+func (w *Websocket) shardUnsubscribe(ctx context.Context, subs []subscription.Subscription) error {
+	batches := make(map[*shard][]subscription.Subscription)
+	conns := make(map[*shard]Connection)
+	w.shardMutex.Lock()
+	for i := range subs {
+		k := subs[i].EnsureKeyed()
+		for _, s := range w.shards {
+			if _, ok := s.subscriptions[k]; ok {
+				batches[s] = append(batches[s], subs[i])
+				conns[s] = s.conn
+				break
+			}
+		}
+	}
+	w.shardMutex.Unlock()
+
+	var errs error
+	for s, batch := range batches {
+		if err := w.shardUnsubscriber(ctx, conns[s], batch); err != nil {
+			errs = common.AppendError(errs, fmt.Errorf("shard %d: %w", s.id, err))
+			continue
+		}
+		w.unassignShard(s, batch)
+	}
+	return errs
+}
+
+// leastLoadedShard returns the connected shard with the fewest subscriptions
+// and capacity for another, or nil. w.shardMutex must be held
+func (w *Websocket) leastLoadedShard() *shard {
+	var least *shard
+	for _, s := range w.shards {
+		if !s.connected || (w.MaxSubscriptionsPerConnection > 0 && len(s.subscriptions) >= w.MaxSubscriptionsPerConnection) {
+			continue
+		}
+		if least == nil || len(s.subscriptions) < len(least.subscriptions) {
+			least = s
+		}
+	}
+	return least
+}
+
+// hasConnectedShard returns whether any shard is connected
+func (w *Websocket) hasConnectedShard() bool {
+	w.shardMutex.Lock()
+	defer w.shardMutex.Unlock()
+	for _, s := range w.shards {
+		if s.connected {
+			return true
+		}
+	}
+	return false
+}
+
+// unassignShard removes subscriptions from a shard's assignments
+func (w *Websocket) unassignShard(s *shard, subs []subscription.Subscription) {
+	w.shardMutex.Lock()
+	defer w.shardMutex.Unlock()
+	for i := range subs {
+		delete(s.subscriptions, subs[i].EnsureKeyed())
+	}
+}
+
+// shutdownShards closes each shard's connection without rebalancing
+func (w *Websocket) shutdownShards() error {
+	w.shardMutex.Lock()
+	w.shardsClosed = true
+	shards := w.shards
+	w.shards = nil
+	w.shardMutex.Unlock()
+	var errs error
+	for _, s := range shards {
+		if s.conn != nil {
+			errs = common.AppendError(errs, s.conn.Shutdown())
+		}
+	}
+	return errs
+}
+
+// shutdownConnections closes any connections made by a failed Connect
+func (w *Websocket) shutdownConnections() {
+	for _, c := range []Connection{w.Conn, w.AuthConn} {
+		if c != nil {
+			if err := c.Shutdown(); err != nil {
+				log.Errorf(log.WebsocketMgr, "%s websocket: %v", w.exchangeName, err)
+			}
+		}
+	}
+	if err := w.shutdownShards(); err != nil {
+		log.Errorf(log.WebsocketMgr, "%s websocket: %v", w.exchangeName, err)
+	}
+}
@@ -0,0 +1,159 @@
+package stream
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/subscription"
+)
+
+func newShardSetup(shards int) *WebsocketSetup {
+	s := *defaultSetup
+	exch := *s.ExchangeConfig
+	exch.WebsocketShards = shards
+	s.ExchangeConfig = &exch
+	s.ShardConnector = func(context.Context, Connection) error { return nil }
+	s.ShardSubscriber = func(context.Context, Connection, []subscription.Subscription) error { return nil }
+	s.ShardUnsubscriber = func(context.Context, Connection, []subscription.Subscription) error { return nil }
+	s.ShardHandler = func(Connection, []byte) error { return nil }
+	return &s
+}
+
+func TestSetupShards(t *testing.T) {
+	t.Parallel()
+	assert.ErrorIs(t, NewWebsocket().Setup(newShardSetup(-1)), errInvalidShards)
+
+	s := newShardSetup(2)
+	s.ShardUnsubscriber = nil
+	assert.ErrorIs(t, NewWebsocket().Setup(s), errShardingUnsupported, "Setup should error when the exchange cannot unsubscribe a shard")
+
+	ws := NewWebsocket()
+	require.NoError(t, ws.Setup(newShardSetup(1)), "Setup must not error")
+	assert.Zero(t, ws.shardCount, "a single shard should use the websocket's connection")
+	assert.Nil(t, ws.shardConnector, "a single shard should use the websocket's connection")
+
+	ws = NewWebsocket()
+	require.NoError(t, ws.Setup(newShardSetup(3)), "Setup must not error")
+	assert.Equal(t, 3, ws.shardCount)
+	assert.Nil(t, ws.subscriptionLister, "subscriptions should not be listed from a single connection")
+}
+
+func TestShardSubscribe(t *testing.T) {
+	t.Parallel()
+	subErr := errDastardlyReason
+	var failShard Connection
+	ws := &Websocket{
+		MaxSubscriptionsPerConnection: 2,
+		shardSubscriber: func(_ context.Context, c Connection, _ []subscription.Subscription) error {
+			if c == failShard {
+				return subErr
+			}
+			return nil
+		},
+		shardUnsubscriber: func(context.Context, Connection, []subscription.Subscription) error { return nil },
+	}
+	conns := []Connection{&WebsocketConnection{}, &WebsocketConnection{}, &WebsocketConnection{}}
+	ws.shards = []*shard{
+		{id: 0, conn: conns[0], connected: true, subscriptions: subscriptionMap{}},
+		{id: 1, conn: conns[1], connected: true, subscriptions: subscriptionMap{}},
+		{id: 2, conn: conns[2], subscriptions: subscriptionMap{}},
+	}
+
+	subs := []subscription.Subscription{{Channel: "a"}, {Channel: "b"}, {Channel: "c"}, {Channel: "d"}, {Channel: "e"}}
+	err := ws.shardSubscribe(context.Background(), subs[:3])
+	require.NoError(t, err, "shardSubscribe must not error")
+	assert.Len(t, ws.shards[0].subscriptions, 2, "subscriptions should be assigned to the least loaded shard")
+	assert.Len(t, ws.shards[1].subscriptions, 1, "subscriptions should be assigned to the least loaded shard")
+	assert.Empty(t, ws.shards[2].subscriptions, "subscriptions should not be assigned to disconnected shards")
+
+	err = ws.shardSubscribe(context.Background(), subs[3:])
+	assert.ErrorIs(t, err, errShardCapacityExceeded, "shardSubscribe should error when shards are full")
+	assert.Len(t, ws.shards[1].subscriptions, 2, "capacity should be filled before erroring")
+
+	require.NoError(t, ws.shardUnsubscribe(context.Background(), subs[:2]), "shardUnsubscribe must not error")
+	assert.Len(t, ws.shards[0].subscriptions, 1, "unsubscribed subscriptions should be unassigned")
+	assert.Len(t, ws.shards[1].subscriptions, 1, "unsubscribed subscriptions should be unassigned")
+
+	failShard = conns[0]
+	err = ws.shardSubscribe(context.Background(), subs[:1])
+	assert.ErrorIs(t, err, subErr, "shardSubscribe should error when a shard fails to subscribe")
+	assert.Len(t, ws.shards[0].subscriptions, 1, "failed subscriptions should be unassigned")
+
+	ws.shards[0].connected, ws.shards[1].connected = false, false
+	err = ws.shardSubscribe(context.Background(), subs[:1])
+	assert.ErrorIs(t, err, errNoShardConnected, "shardSubscribe should error when no shard is connected")
+}
+
+func TestShardRebalance(t *testing.T) {
+	t.Parallel()
+	upgrader := websocket.Upgrader{}
+	serverConns := make(chan *websocket.Conn, 4)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		serverConns <- c
+		for {
+			if _, _, err := c.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	ws := NewWebsocket()
+	s := newShardSetup(2)
+	s.ShardURL = "ws" + strings.TrimPrefix(srv.URL, "http")
+	s.ShardConnector = func(_ context.Context, c Connection) error {
+		return c.Dial(&websocket.Dialer{}, http.Header{})
+	}
+	s.ShardSubscriber = func(_ context.Context, _ Connection, subs []subscription.Subscription) error {
+		ws.AddSuccessfulSubscriptions(subs...)
+		return nil
+	}
+	s.ShardUnsubscriber = func(_ context.Context, _ Connection, subs []subscription.Subscription) error {
+		ws.RemoveSubscriptions(subs...)
+		return nil
+	}
+	require.NoError(t, ws.Setup(s), "Setup must not error")
+	require.NoError(t, ws.SetupNewConnection(ConnectionSetup{ResponseMaxLimit: time.Second}), "SetupNewConnection must not error")
+	require.NoError(t, ws.Connect(), "Connect must not error")
+	defer func() {
+		ws.setEnabled(false)
+		assert.NoError(t, ws.Shutdown(), "Shutdown should not error")
+	}()
+
+	shardLoads := func() (loads []int) {
+		ws.shardMutex.Lock()
+		defer ws.shardMutex.Unlock()
+		for _, s := range ws.shards {
+			if s.connected {
+				loads = append(loads, len(s.subscriptions))
+			}
+		}
+		return loads
+	}
+	assert.Equal(t, []int{2, 2}, shardLoads(), "subscriptions should be distributed across shards")
+	assert.Len(t, ws.GetSubscriptions(), 4)
+
+	require.NoError(t, (<-serverConns).Close(), "closing a shard's server connection must not error")
+	assert.Eventually(t, func() bool {
+		return len(serverConns) == 2 && len(ws.GetSubscriptions()) == 4
+	}, time.Second*5, time.Millisecond*10, "the disconnected shard should redial and its subscriptions should be resubscribed")
+	assert.ElementsMatch(t, []int{2, 2}, shardLoads(), "subscriptions should be rebalanced across connected shards")
+}
+
+func TestCheckSubscriptionsShards(t *testing.T) {
+	t.Parallel()
+	ws := Websocket{MaxSubscriptionsPerConnection: 1, shardCount: 2}
+	assert.NoError(t, ws.checkSubscriptions([]subscription.Subscription{{Channel: "a"}, {Channel: "b"}}), "checkSubscriptions should allow a connection's limit per shard")
+	assert.ErrorIs(t, ws.checkSubscriptions([]subscription.Subscription{{Channel: "a"}, {Channel: "b"}, {Channel: "c"}}), errSubscriptionsExceedsLimit)
+}
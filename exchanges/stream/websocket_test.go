@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"compress/flate"
 	"compress/gzip"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"sort"
 	"strconv"
@@ -22,7 +24,12 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/latency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/protocol"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/stream/buffer"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/subscription"
 )
 
@@ -78,9 +85,9 @@ var defaultSetup = &WebsocketSetup{
 	},
 	DefaultURL:   "testDefaultURL",
 	RunningURL:   "wss://testRunningURL",
-	Connector:    func() error { return nil },
-	Subscriber:   func([]subscription.Subscription) error { return nil },
-	Unsubscriber: func([]subscription.Subscription) error { return nil },
+	Connector:    func(context.Context) error { return nil },
+	Subscriber:   func(context.Context, []subscription.Subscription) error { return nil },
+	Unsubscriber: func(context.Context, []subscription.Subscription) error { return nil },
 	GenerateSubscriptions: func() ([]subscription.Subscription, error) {
 		return []subscription.Subscription{
 			{Channel: "TestSub"},
@@ -143,16 +150,21 @@ func TestSetup(t *testing.T) {
 	err = w.Setup(websocketSetup)
 	assert.ErrorIs(t, err, errWebsocketConnectorUnset, "Setup should error correctly")
 
-	websocketSetup.Connector = func() error { return nil }
+	websocketSetup.Connector = func(context.Context) error { return nil }
 	err = w.Setup(websocketSetup)
 	assert.ErrorIs(t, err, errWebsocketSubscriberUnset, "Setup should error correctly")
 
-	websocketSetup.Subscriber = func([]subscription.Subscription) error { return nil }
+	websocketSetup.Subscriber = func(context.Context, []subscription.Subscription) error { return nil }
 	websocketSetup.Features.Unsubscribe = true
 	err = w.Setup(websocketSetup)
 	assert.ErrorIs(t, err, errWebsocketUnsubscriberUnset, "Setup should error correctly")
 
-	websocketSetup.Unsubscriber = func([]subscription.Subscription) error { return nil }
+	websocketSetup.Unsubscriber = func(context.Context, []subscription.Subscription) error { return nil }
+	websocketSetup.ExchangeConfig.WebsocketProcessingWorkers = -1
+	err = w.Setup(websocketSetup)
+	assert.ErrorIs(t, err, errInvalidProcessingWorkers, "Setup should error correctly")
+
+	websocketSetup.ExchangeConfig.WebsocketProcessingWorkers = 0
 	err = w.Setup(websocketSetup)
 	assert.ErrorIs(t, err, errWebsocketSubscriptionsGeneratorUnset, "Setup should error correctly")
 
@@ -302,7 +314,7 @@ func TestConnectionMessageErrors(t *testing.T) {
 	err := wsWrong.Connect()
 	assert.ErrorIs(t, err, errNoConnectFunc, "Connect should error correctly")
 
-	wsWrong.connector = func() error { return nil }
+	wsWrong.connector = func(context.Context) error { return nil }
 	err = wsWrong.Connect()
 	assert.ErrorIs(t, err, ErrWebsocketNotEnabled, "Connect should error correctly")
 
@@ -313,7 +325,7 @@ func TestConnectionMessageErrors(t *testing.T) {
 	assert.ErrorIs(t, err, errAlreadyReconnecting, "Connect should error correctly")
 
 	wsWrong.setState(disconnected)
-	wsWrong.connector = func() error { return errDastardlyReason }
+	wsWrong.connector = func(context.Context) error { return errDastardlyReason }
 	err = wsWrong.Connect()
 	assert.ErrorIs(t, err, errDastardlyReason, "Connect should error correctly")
 
@@ -321,7 +333,7 @@ func TestConnectionMessageErrors(t *testing.T) {
 	err = ws.Setup(defaultSetup)
 	require.NoError(t, err, "Setup must not error")
 	ws.trafficTimeout = time.Minute
-	ws.connector = func() error { return nil }
+	ws.connector = func(context.Context) error { return nil }
 
 	err = ws.Connect()
 	require.NoError(t, err, "Connect must not error")
@@ -435,8 +447,10 @@ func TestWebsocket(t *testing.T) {
 	assert.NoError(t, err, "SetWebsocketURL should not error on reconnect")
 
 	// -- initiate the reconnect which is usually handled by connection monitor
+	connections := ws.ConnectionCount()
 	err = ws.Connect()
 	assert.NoError(t, err, "ReConnect called manually should not error")
+	assert.Equal(t, connections+1, ws.ConnectionCount(), "ConnectionCount should increment on reconnect")
 
 	err = ws.Connect()
 	assert.ErrorIs(t, err, errAlreadyConnected, "ReConnect should error when already connected")
@@ -452,11 +466,11 @@ func TestSubscribeUnsubscribe(t *testing.T) {
 	ws := NewWebsocket()
 	assert.NoError(t, ws.Setup(defaultSetup), "WS Setup should not error")
 
-	fnSub := func(subs []subscription.Subscription) error {
+	fnSub := func(_ context.Context, subs []subscription.Subscription) error {
 		ws.AddSuccessfulSubscriptions(subs...)
 		return nil
 	}
-	fnUnsub := func(unsubs []subscription.Subscription) error {
+	fnUnsub := func(_ context.Context, unsubs []subscription.Subscription) error {
 		ws.RemoveSubscriptions(unsubs...)
 		return nil
 	}
@@ -504,11 +518,11 @@ func TestResubscribe(t *testing.T) {
 	err = ws.Setup(defaultSetup)
 	assert.NoError(t, err, "WS Setup should not error")
 
-	fnSub := func(subs []subscription.Subscription) error {
+	fnSub := func(_ context.Context, subs []subscription.Subscription) error {
 		ws.AddSuccessfulSubscriptions(subs...)
 		return nil
 	}
-	fnUnsub := func(unsubs []subscription.Subscription) error {
+	fnUnsub := func(_ context.Context, unsubs []subscription.Subscription) error {
 		ws.RemoveSubscriptions(unsubs...)
 		return nil
 	}
@@ -522,6 +536,150 @@ func TestResubscribe(t *testing.T) {
 	assert.NoError(t, ws.ResubscribeToChannel(&channel[0]), "Resubscribe should not error now the channel is subscribed")
 }
 
+func TestResyncSubscription(t *testing.T) {
+	t.Parallel()
+	ws := NewWebsocket()
+	require.NoError(t, ws.Setup(defaultSetup), "Setup must not error")
+	var subs, unsubs int
+	ws.Subscriber = func(_ context.Context, s []subscription.Subscription) error {
+		subs++
+		ws.AddSuccessfulSubscriptions(s...)
+		return nil
+	}
+	ws.Unsubscriber = func(_ context.Context, s []subscription.Subscription) error {
+		unsubs++
+		ws.RemoveSubscriptions(s...)
+		return nil
+	}
+
+	assert.ErrorIs(t, ws.ResyncSubscription(context.Background(), nil), errNoSubscriptionsSupplied)
+
+	p := currency.NewBTCUSDT()
+	sub := &subscription.Subscription{Channel: "books", Asset: asset.Spot, Pair: p}
+	require.NoError(t, ws.ResyncSubscription(context.Background(), sub), "ResyncSubscription must not error")
+	assert.Equal(t, 1, subs, "channels which are not subscribed should be subscribed")
+	assert.Zero(t, unsubs)
+	require.NoError(t, ws.ResyncSubscription(context.Background(), sub), "ResyncSubscription must not error")
+	assert.Equal(t, 2, subs, "subscribed channels should be resubscribed")
+	assert.Equal(t, 1, unsubs, "subscribed channels should be resubscribed")
+	assert.Empty(t, ws.resyncing, "resyncs should be cleared once complete")
+
+	ws.resyncing[sub.EnsureKeyed()] = struct{}{}
+	assert.ErrorIs(t, ws.ResyncSubscription(context.Background(), sub), errResyncInProgress)
+	delete(ws.resyncing, sub.EnsureKeyed())
+
+	fetchErr := errors.New("fetch error")
+	var fetched bool
+	fetcher := func(context.Context, currency.Pair, asset.Item) (*orderbook.Base, error) {
+		fetched = true
+		return nil, fetchErr
+	}
+	require.NoError(t, ws.Orderbook.Setup(defaultSetup.ExchangeConfig, &buffer.Config{FetchSnapshot: fetcher}, ws.DataHandler), "Orderbook Setup must not error")
+	assert.ErrorIs(t, ws.ResyncSubscription(context.Background(), sub), fetchErr, "snapshot errors should be returned")
+	assert.True(t, fetched, "a snapshot should be fetched when the buffer has a fetcher")
+	fetched = false
+	assert.NoError(t, ws.ResyncSubscription(context.Background(), &subscription.Subscription{Channel: "trades"}), "ResyncSubscription should not error")
+	assert.False(t, fetched, "snapshots should not be fetched for subscriptions without a pair")
+
+	ws.features = &protocol.Features{Subscribe: true} // copied as defaultSetup's features are shared
+	require.NoError(t, ws.Orderbook.Setup(defaultSetup.ExchangeConfig, &buffer.Config{}, ws.DataHandler), "Orderbook Setup must not error")
+	require.NoError(t, ws.Orderbook.LoadSnapshot(&orderbook.Base{Exchange: "test", Pair: p, Asset: asset.Spot, LastUpdated: time.Now()}), "LoadSnapshot must not error")
+	_, err := ws.Orderbook.GetOrderbook(p, asset.Spot)
+	require.NoError(t, err, "GetOrderbook must not error")
+	subsBefore := subs
+	assert.ErrorIs(t, ws.ResyncSubscription(context.Background(), sub), errResyncUnsupported, "resyncs which cannot recover the channel should error")
+	assert.Equal(t, subsBefore, subs, "channels should not be resubscribed without unsubscribe support")
+	_, err = ws.Orderbook.GetOrderbook(p, asset.Spot)
+	assert.Error(t, err, "the out of sync orderbook should be flushed")
+}
+
+// TestReconcileSubscriptions tests restoring dropped subscriptions
+func TestReconcileSubscriptions(t *testing.T) {
+	t.Parallel()
+	ws := NewWebsocket()
+	require.NoError(t, ws.Setup(defaultSetup), "Setup must not error")
+	ws.Subscriber = func(_ context.Context, subs []subscription.Subscription) error {
+		ws.AddSuccessfulSubscriptions(subs...)
+		return nil
+	}
+	ws.Unsubscriber = func(_ context.Context, unsubs []subscription.Subscription) error {
+		ws.RemoveSubscriptions(unsubs...)
+		return nil
+	}
+
+	assert.ErrorIs(t, ws.ReconcileSubscriptions(), ErrNotConnected, "ReconcileSubscriptions should error when not connected")
+	ws.setState(connected)
+
+	subs := []subscription.Subscription{{Channel: "keep"}, {Channel: "drop"}, {Channel: "unsub"}}
+	require.NoError(t, ws.SubscribeToChannels(subs), "SubscribeToChannels must not error")
+	require.NoError(t, ws.UnsubscribeChannels(subs[2:]), "UnsubscribeChannels must not error")
+
+	ws.RemoveSubscriptions(subs[1]) // Silently dropped by the exchange
+	require.NoError(t, ws.ReconcileSubscriptions(), "ReconcileSubscriptions must not error")
+	assert.Len(t, ws.GetSubscriptions(), 2, "Dropped subscription should be restored without restoring unsubscribed channels")
+	assert.NotNil(t, ws.GetSubscription(subscription.DefaultKey{Channel: "drop"}), "Dropped subscription should be restored")
+
+	ws.subscriptionLister = func(context.Context) ([]subscription.Subscription, error) {
+		return subs[:1], nil
+	}
+	var resubscribed []subscription.Subscription
+	ws.Subscriber = func(_ context.Context, s []subscription.Subscription) error {
+		resubscribed = s
+		ws.AddSuccessfulSubscriptions(s...)
+		return nil
+	}
+	require.NoError(t, ws.ReconcileSubscriptions(), "ReconcileSubscriptions must not error")
+	require.Len(t, resubscribed, 1, "Subscriptions not reported by the exchange must be resubscribed")
+	assert.Equal(t, "drop", resubscribed[0].Channel, "Only the unreported subscription should be resubscribed")
+
+	ws.subscriptionLister = func(context.Context) ([]subscription.Subscription, error) {
+		return nil, errDastardlyReason
+	}
+	assert.ErrorIs(t, ws.ReconcileSubscriptions(), errDastardlyReason, "ReconcileSubscriptions should error when listing fails")
+}
+
+// TestStaleSubscriptions tests the per channel staleness watchdog
+func TestStaleSubscriptions(t *testing.T) {
+	t.Parallel()
+	ws := NewWebsocket()
+	require.NoError(t, ws.Setup(defaultSetup), "Setup must not error")
+	ws.stalenessTimeout = time.Minute
+	ws.resubscribeStale = true
+	var resubscribed []subscription.Subscription
+	ws.Subscriber = func(_ context.Context, subs []subscription.Subscription) error {
+		resubscribed = subs
+		ws.AddSuccessfulSubscriptions(subs...)
+		return nil
+	}
+	ws.Unsubscriber = func(_ context.Context, unsubs []subscription.Subscription) error {
+		ws.RemoveSubscriptions(unsubs...)
+		return nil
+	}
+
+	live := subscription.Subscription{Channel: "live"}
+	silent := subscription.Subscription{Channel: "silent"}
+	ws.AddSuccessfulSubscriptions(live, silent)
+	require.NoError(t, ws.AddSubscription(&subscription.Subscription{Channel: "pending", State: subscription.SubscribingState}), "AddSubscription must not error")
+
+	start := time.Now()
+	assert.Empty(t, ws.staleSubscriptions(start), "Channels without activity should be given a full timeout")
+	ws.RecordSubscriptionActivity(live.EnsureKeyed())
+	warnings := ws.staleSubscriptions(start.Add(time.Minute))
+	require.Len(t, warnings, 1, "Only the silent channel should be stale")
+	assert.Equal(t, "silent", warnings[0].Subscription.Channel, "Warning should be for the silent channel")
+	assert.Equal(t, start, warnings[0].LastMessage, "Warning should report the last activity")
+	assert.Empty(t, ws.staleSubscriptions(start.Add(time.Minute)), "Stale channels should only be reported once per timeout")
+
+	ws.checkStaleSubscriptions(start.Add(time.Minute * 3))
+	require.Len(t, resubscribed, 2, "Stale channels should be resubscribed")
+	select {
+	case d := <-ws.DataHandler:
+		assert.IsType(t, StaleChannelWarning{}, d, "A stale channel warning should be sent to the data handler")
+	default:
+		assert.Fail(t, "A stale channel warning should be sent to the data handler")
+	}
+}
+
 // TestSubscriptionState tests Subscription state changes
 func TestSubscriptionState(t *testing.T) {
 	t.Parallel()
@@ -578,6 +736,18 @@ func TestConnectionMonitorNoConnection(t *testing.T) {
 	assert.ErrorIs(t, err, errAlreadyRunning, "connectionMonitor should error correctly")
 }
 
+func TestSetReconnectDelay(t *testing.T) {
+	t.Parallel()
+	ws := NewWebsocket()
+	ws.connectionMonitorDelay = time.Second
+	assert.ErrorIs(t, ws.SetReconnectDelay(-time.Second), errInvalidReconnectDelay)
+	assert.Equal(t, time.Second, ws.getReconnectDelay(), "the connection monitor delay should be used by default")
+	require.NoError(t, ws.SetReconnectDelay(time.Minute), "SetReconnectDelay must not error")
+	assert.Equal(t, time.Minute, ws.getReconnectDelay(), "the reconnect delay should be overridden")
+	require.NoError(t, ws.SetReconnectDelay(0), "SetReconnectDelay must not error")
+	assert.Equal(t, time.Second, ws.getReconnectDelay(), "zero should restore the connection monitor delay")
+}
+
 // TestGetSubscription logic test
 func TestGetSubscription(t *testing.T) {
 	t.Parallel()
@@ -891,6 +1061,20 @@ func TestCanUseAuthenticatedWebsocketForWrapper(t *testing.T) {
 	assert.True(t, ws.CanUseAuthenticatedWebsocketForWrapper(), "CanUseAuthenticatedWebsocketForWrapper should return true")
 }
 
+func TestCanUseWebsocketOrderEntry(t *testing.T) {
+	t.Parallel()
+	ws := &Websocket{}
+	ws.setState(connected)
+	ws.SetCanUseAuthenticatedEndpoints(true)
+	assert.False(t, ws.CanUseWebsocketOrderEntry(), "CanUseWebsocketOrderEntry should return false when not enabled")
+
+	ws.orderEntry = true
+	assert.True(t, ws.CanUseWebsocketOrderEntry(), "CanUseWebsocketOrderEntry should return true")
+
+	ws.SetCanUseAuthenticatedEndpoints(false)
+	assert.False(t, ws.CanUseWebsocketOrderEntry(), "CanUseWebsocketOrderEntry should return false when not authenticated")
+}
+
 func TestGenerateMessageID(t *testing.T) {
 	t.Parallel()
 	wc := WebsocketConnection{}
@@ -1009,7 +1193,7 @@ func (g *GenSubs) generateSubs() ([]subscription.Subscription, error) {
 	return superduperchannelsubs, nil
 }
 
-func (g *GenSubs) SUBME(subs []subscription.Subscription) error {
+func (g *GenSubs) SUBME(_ context.Context, subs []subscription.Subscription) error {
 	if len(subs) == 0 {
 		return errors.New("WOW")
 	}
@@ -1017,7 +1201,7 @@ func (g *GenSubs) SUBME(subs []subscription.Subscription) error {
 	return nil
 }
 
-func (g *GenSubs) UNSUBME(unsubs []subscription.Subscription) error {
+func (g *GenSubs) UNSUBME(_ context.Context, unsubs []subscription.Subscription) error {
 	if len(unsubs) == 0 {
 		return errors.New("WOW")
 	}
@@ -1026,7 +1210,7 @@ func (g *GenSubs) UNSUBME(unsubs []subscription.Subscription) error {
 }
 
 // sneaky connect func
-func connect() error { return nil }
+func connect(context.Context) error { return nil }
 
 func TestFlushChannels(t *testing.T) {
 	t.Parallel()
@@ -1150,7 +1334,7 @@ func TestEnable(t *testing.T) {
 		GenerateSubs: func() ([]subscription.Subscription, error) {
 			return []subscription.Subscription{{Channel: "test"}}, nil
 		},
-		Subscriber: func([]subscription.Subscription) error { return nil },
+		Subscriber: func(context.Context, []subscription.Subscription) error { return nil },
 	}
 
 	require.NoError(t, w.Enable(), "Enable must not error")
@@ -1208,6 +1392,164 @@ func TestWebsocketConnectionShutdown(t *testing.T) {
 	require.NoError(t, err, "Shutdown must not error")
 }
 
+func TestReadMessageLatencySimulation(t *testing.T) {
+	t.Parallel()
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		if err = c.WriteMessage(websocket.TextMessage, []byte(`{"event":"hello"}`)); err != nil {
+			return
+		}
+		_, _, _ = c.ReadMessage()
+	}))
+	defer srv.Close()
+
+	sim, err := latency.NewSimulator(time.Millisecond*50, 0)
+	require.NoError(t, err)
+	wc := &WebsocketConnection{
+		URL:       "ws" + strings.TrimPrefix(srv.URL, "http"),
+		Traffic:   make(chan struct{}, 1),
+		ShutdownC: make(chan struct{}),
+		Match:     NewMatch(),
+		latency:   sim,
+	}
+	require.NoError(t, wc.Dial(&websocket.Dialer{}, http.Header{}))
+	start := time.Now()
+	resp := wc.ReadMessage()
+	assert.Equal(t, `{"event":"hello"}`, string(resp.Raw))
+	assert.GreaterOrEqual(t, time.Since(start), time.Millisecond*50, "message should be delayed by the simulated latency")
+	require.NoError(t, wc.Shutdown())
+}
+
+type fakeLimiter struct {
+	m         sync.Mutex
+	endpoints []request.EndpointLimit
+	err       error
+}
+
+func (f *fakeLimiter) Limit(_ context.Context, ep request.EndpointLimit) error {
+	f.m.Lock()
+	defer f.m.Unlock()
+	f.endpoints = append(f.endpoints, ep)
+	return f.err
+}
+
+func TestSendRateLimitedMessageReturnResponse(t *testing.T) {
+	t.Parallel()
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		for {
+			mt, msg, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+			if err = c.WriteMessage(mt, msg); err != nil {
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	l := &fakeLimiter{}
+	wc := &WebsocketConnection{
+		URL:               "ws" + strings.TrimPrefix(srv.URL, "http"),
+		Traffic:           make(chan struct{}, 1),
+		ShutdownC:         make(chan struct{}),
+		Match:             NewMatch(),
+		ResponseMaxLimit:  time.Second * 5,
+		RateLimiter:       l,
+		RateLimitEndpoint: 7,
+	}
+	require.NoError(t, wc.Dial(&websocket.Dialer{}, http.Header{}))
+	go func() {
+		for {
+			resp := wc.ReadMessage()
+			if resp.Raw == nil {
+				return
+			}
+			var msg testRequest
+			if err := json.Unmarshal(resp.Raw, &msg); err == nil {
+				wc.Match.IncomingWithData(msg.RequestID, resp.Raw)
+			}
+		}
+	}()
+
+	_, err := wc.SendRateLimitedMessageReturnResponse(context.Background(), 3, int64(1), testRequest{RequestID: 1})
+	require.NoError(t, err)
+	_, err = wc.SendMessageReturnResponse(int64(2), testRequest{RequestID: 2})
+	require.NoError(t, err)
+	require.NoError(t, wc.SendJSONMessage(testRequest{Event: "subscribe"}))
+	assert.Equal(t, []request.EndpointLimit{3, 7}, l.endpoints, "requests should draw from the rate limiter, subscriptions should not")
+
+	l.err = errDastardlyReason
+	_, err = wc.SendRateLimitedMessageReturnResponse(context.Background(), 3, int64(3), testRequest{RequestID: 3})
+	assert.ErrorIs(t, err, errDastardlyReason, "rate limiter errors should be returned")
+	require.NoError(t, wc.Shutdown())
+}
+
+func TestSendMessageReturnResponseWithContext(t *testing.T) {
+	t.Parallel()
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer c.Close()
+		for {
+			if _, _, err = c.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	wc := &WebsocketConnection{
+		URL:              "ws" + strings.TrimPrefix(srv.URL, "http"),
+		Traffic:          make(chan struct{}, 1),
+		ShutdownC:        make(chan struct{}),
+		Match:            NewMatch(),
+		ResponseMaxLimit: time.Minute,
+	}
+	require.NoError(t, wc.Dial(&websocket.Dialer{}, http.Header{}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+	_, err := wc.SendMessageReturnResponseWithContext(ctx, int64(1), testRequest{RequestID: 1})
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "waiting for a response should stop at the context deadline")
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	_, err = wc.SendMessageReturnResponseWithContext(ctx, int64(2), testRequest{RequestID: 2})
+	assert.ErrorIs(t, err, context.Canceled, "cancelled requests should not be sent")
+	assert.False(t, wc.Match.IncomingWithData(int64(2), nil), "signatures should be released when a request is cancelled")
+	require.NoError(t, wc.Shutdown())
+}
+
+func TestShutdownContext(t *testing.T) {
+	t.Parallel()
+	w := &Websocket{ShutdownC: make(chan struct{})}
+	ctx, cancel := w.ShutdownContext()
+	defer cancel()
+	require.NoError(t, ctx.Err())
+	close(w.ShutdownC)
+	assert.Eventually(t, func() bool { return ctx.Err() != nil }, time.Second, time.Millisecond, "context should be cancelled on shutdown")
+
+	w.ShutdownC = make(chan struct{})
+	ctx, cancel = w.ShutdownContext()
+	cancel()
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+}
+
 // TestLatency logic test
 func TestLatency(t *testing.T) {
 	t.Parallel()
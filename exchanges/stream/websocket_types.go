@@ -1,6 +1,7 @@
 package stream
 
 import (
+	"context"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -8,7 +9,9 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/fill"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/latency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/protocol"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/stream/buffer"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/subscription"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
@@ -41,8 +44,17 @@ type Websocket struct {
 	connectionMonitorRunning     atomic.Bool
 	trafficMonitorRunning        atomic.Bool
 	dataMonitorRunning           atomic.Bool
+	reconcilerRunning            atomic.Bool
+	stalenessWatchdogRunning     atomic.Bool
+	connections                  atomic.Uint64
+	reconnectDelay               atomic.Int64
 	trafficTimeout               time.Duration
 	connectionMonitorDelay       time.Duration
+	reconcileInterval            time.Duration
+	stalenessTimeout             time.Duration
+	resubscribeStale             bool
+	orderEntry                   bool
+	processingWorkers            int
 	proxyAddr                    string
 	defaultURL                   string
 	defaultURLAuth               string
@@ -50,19 +62,44 @@ type Websocket struct {
 	runningURLAuth               string
 	exchangeName                 string
 	m                            sync.Mutex
-	connector                    func() error
+	connector                    func(context.Context) error
+	subscriptionLister           func(context.Context) ([]subscription.Subscription, error)
 
 	subscriptionMutex sync.RWMutex
 	subscriptions     subscriptionMap
 	Subscribe         chan []subscription.Subscription
 	Unsubscribe       chan []subscription.Subscription
+	// desiredSubscriptions are the subscriptions the websocket should hold;
+	// they survive dropped channels so the reconciler can restore them
+	desiredSubscriptions subscriptionMap
+
+	// channelActivity holds the last message time per subscription key for
+	// the staleness watchdog
+	activityMutex   sync.Mutex
+	channelActivity map[any]time.Time
+
+	// resyncing holds the keys of subscriptions being resynced
+	resyncMutex sync.Mutex
+	resyncing   map[any]struct{}
+
+	// Subscriptions are sharded across shardCount connections when above 1
+	shardCount           int
+	shardURL             string
+	shardConnectionSetup ConnectionSetup
+	shardConnector       func(context.Context, Connection) error
+	shardSubscriber      func(context.Context, Connection, []subscription.Subscription) error
+	shardUnsubscriber    func(context.Context, Connection, []subscription.Subscription) error
+	shardHandler         func(Connection, []byte) error
+	shardMutex           sync.Mutex
+	shards               []*shard
+	shardsClosed         bool
 
 	// Subscriber function for package defined websocket subscriber
 	// functionality
-	Subscriber func([]subscription.Subscription) error
+	Subscriber func(context.Context, []subscription.Subscription) error
 	// Unsubscriber function for packaged defined websocket unsubscriber
 	// functionality
-	Unsubscriber func([]subscription.Subscription) error
+	Unsubscriber func(context.Context, []subscription.Subscription) error
 	// GenerateSubs function for package defined websocket generate
 	// subscriptions functionality
 	GenerateSubs func() ([]subscription.Subscription, error)
@@ -107,15 +144,20 @@ type Websocket struct {
 
 // WebsocketSetup defines variables for setting up a websocket connection
 type WebsocketSetup struct {
-	ExchangeConfig        *config.Exchange
-	DefaultURL            string
-	RunningURL            string
-	RunningURLAuth        string
-	Connector             func() error
-	Subscriber            func([]subscription.Subscription) error
-	Unsubscriber          func([]subscription.Subscription) error
+	ExchangeConfig *config.Exchange
+	DefaultURL     string
+	RunningURL     string
+	RunningURLAuth string
+	// Connector, Subscriber and Unsubscriber are passed a context which is
+	// cancelled when the websocket shuts down
+	Connector             func(context.Context) error
+	Subscriber            func(context.Context, []subscription.Subscription) error
+	Unsubscriber          func(context.Context, []subscription.Subscription) error
 	GenerateSubscriptions func() ([]subscription.Subscription, error)
-	Features              *protocol.Features
+	// SubscriptionLister optionally queries the exchange for its active
+	// subscriptions, for exchanges which support listing them
+	SubscriptionLister func(context.Context) ([]subscription.Subscription, error)
+	Features           *protocol.Features
 
 	// Local orderbook buffer config values
 	OrderbookBufferConfig buffer.Config
@@ -128,6 +170,28 @@ type WebsocketSetup struct {
 	// MaxWebsocketSubscriptionsPerConnection defines the maximum number of
 	// subscriptions per connection that is allowed by the exchange.
 	MaxWebsocketSubscriptionsPerConnection int
+
+	// ShardConnector, ShardSubscriber, ShardUnsubscriber and ShardHandler
+	// support sharding subscriptions across the number of connections set by
+	// the exchange config's WebsocketShards. ShardConnector dials a shard
+	// connection, which the websocket reads, passing each message to
+	// ShardHandler. ShardSubscriber and ShardUnsubscriber send subscription
+	// requests on a shard connection
+	ShardConnector    func(context.Context, Connection) error
+	ShardSubscriber   func(context.Context, Connection, []subscription.Subscription) error
+	ShardUnsubscriber func(context.Context, Connection, []subscription.Subscription) error
+	ShardHandler      func(Connection, []byte) error
+	// ShardURL is the URL shard connections dial, the running URL if empty
+	ShardURL string
+}
+
+// shard is one of the connections an exchange's subscriptions are sharded
+// across
+type shard struct {
+	id            int
+	conn          Connection
+	connected     bool
+	subscriptions subscriptionMap
 }
 
 // WebsocketConnection contains all the data needed to send a message to a WS
@@ -140,13 +204,15 @@ type WebsocketConnection struct {
 	// writes methods
 	writeControl sync.Mutex
 
-	RateLimit    int64
-	ExchangeName string
-	URL          string
-	ProxyURL     string
-	Wg           *sync.WaitGroup
-	Connection   *websocket.Conn
-	ShutdownC    chan struct{}
+	RateLimit         int64
+	RateLimiter       request.Limiter
+	RateLimitEndpoint request.EndpointLimit
+	ExchangeName      string
+	URL               string
+	ProxyURL          string
+	Wg                *sync.WaitGroup
+	Connection        *websocket.Conn
+	ShutdownC         chan struct{}
 
 	Match             *Match
 	ResponseMaxLimit  time.Duration
@@ -154,4 +220,5 @@ type WebsocketConnection struct {
 	readMessageErrors chan error
 
 	Reporter Reporter
+	latency  *latency.Simulator
 }
@@ -46,6 +46,22 @@ type Subscription struct {
 	Interval      kline.Interval         `json:"interval,omitempty"`
 	Levels        int                    `json:"levels,omitempty"`
 	Authenticated bool                   `json:"authenticated,omitempty"`
+	// Overrides replace the subscription's settings for specific pairs when
+	// it is expanded across pairs
+	Overrides []PairOverride `json:"overrides,omitempty"`
+}
+
+// PairOverride replaces a subscription's settings for specific pairs. Unset
+// fields keep the subscription's value, so an Interval of 0 can be set
+// explicitly for pairs which should use an unthrottled channel
+type PairOverride struct {
+	Pairs currency.Pairs `json:"pairs"`
+	// Enabled set to false skips the pairs
+	Enabled  *bool                  `json:"enabled,omitempty"`
+	Channel  string                 `json:"channel,omitempty"`
+	Params   map[string]interface{} `json:"params,omitempty"`
+	Interval *kline.Interval        `json:"interval,omitempty"`
+	Levels   *int                   `json:"levels,omitempty"`
 }
 
 // MarshalJSON generates a JSON representation of a Subscription, specifically for config writing
@@ -63,9 +79,10 @@ func (s *Subscription) MarshalJSON() ([]byte, error) {
 		Levels        int                    `json:"levels,omitempty"`
 		Authenticated bool                   `json:"authenticated,omitempty"`
 		Pair          *currency.Pair         `json:"pair,omitempty"`
+		Overrides     []PairOverride         `json:"overrides,omitempty"`
 	}
 
-	k := MaybePair{s.Enabled, s.Channel, s.Asset, s.Params, s.Interval, s.Levels, s.Authenticated, nil}
+	k := MaybePair{s.Enabled, s.Channel, s.Asset, s.Params, s.Interval, s.Levels, s.Authenticated, nil, s.Overrides}
 	if s.Pair != currency.EMPTYPAIR {
 		k.Pair = &s.Pair
 	}
@@ -90,3 +107,53 @@ func (s *Subscription) EnsureKeyed() any {
 	}
 	return s.Key
 }
+
+// ForPair returns a copy of the subscription for a pair with the first
+// override containing the pair applied. False is returned when the override
+// disables the pair
+func (s *Subscription) ForPair(p currency.Pair) (Subscription, bool) {
+	c := *s
+	c.Pair = p
+	c.Overrides = nil
+	for i := range s.Overrides {
+		o := &s.Overrides[i]
+		if !o.Pairs.Contains(p, true) {
+			continue
+		}
+		if o.Enabled != nil && !*o.Enabled {
+			return c, false
+		}
+		if o.Channel != "" {
+			c.Channel = o.Channel
+		}
+		if o.Interval != nil {
+			c.Interval = *o.Interval
+		}
+		if o.Levels != nil {
+			c.Levels = *o.Levels
+		}
+		if len(o.Params) > 0 {
+			c.Params = make(map[string]interface{}, len(s.Params)+len(o.Params))
+			for k, v := range s.Params {
+				c.Params[k] = v
+			}
+			for k, v := range o.Params {
+				c.Params[k] = v
+			}
+		}
+		break
+	}
+	return c, true
+}
+
+// ExpandPairs returns the subscription for each pair with pair overrides
+// applied, skipping pairs which are disabled by an override
+func (s *Subscription) ExpandPairs(pairs currency.Pairs) []Subscription {
+	subs := make([]Subscription, 0, len(pairs))
+	for i := range pairs {
+		if c, ok := s.ForPair(pairs[i]); ok {
+			subs = append(subs, c)
+		}
+	}
+	return subs
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
@@ -58,3 +59,54 @@ func TestMarshaling(t *testing.T) {
 	assert.NoError(t, err, "Marshalling should not error")
 	assert.Equal(t, `{"enabled":true,"channel":"myTrades","authenticated":true}`, string(j), "Marshalling should be clean and concise")
 }
+
+func TestExpandPairs(t *testing.T) {
+	t.Parallel()
+	btc := currency.NewPair(currency.BTC, currency.USDT)
+	eth := currency.NewPair(currency.ETH, currency.USDT)
+	ltc := currency.NewPair(currency.LTC, currency.USDT)
+	raw := kline.Interval(0)
+	levels := 20
+	disabled := false
+	s := &Subscription{
+		Enabled:  true,
+		Channel:  OrderbookChannel,
+		Interval: kline.HundredMilliseconds,
+		Params:   map[string]interface{}{"depth": 5},
+		Overrides: []PairOverride{
+			{Pairs: currency.Pairs{btc}, Interval: &raw, Levels: &levels, Params: map[string]interface{}{"raw": true}},
+			{Pairs: currency.Pairs{ltc}, Enabled: &disabled},
+			{Pairs: currency.Pairs{btc}, Channel: TickerChannel},
+		},
+	}
+	subs := s.ExpandPairs(currency.Pairs{btc, eth, ltc})
+	require.Len(t, subs, 2, "disabled pairs must be skipped")
+
+	assert.Equal(t, btc, subs[0].Pair)
+	assert.Equal(t, OrderbookChannel, subs[0].Channel, "only the first matching override should apply")
+	assert.Equal(t, kline.Interval(0), subs[0].Interval, "an explicit zero interval should override")
+	assert.Equal(t, 20, subs[0].Levels)
+	assert.Equal(t, map[string]interface{}{"depth": 5, "raw": true}, subs[0].Params, "override params should be merged")
+	assert.Nil(t, subs[0].Overrides, "expanded subscriptions should not carry overrides")
+
+	assert.Equal(t, eth, subs[1].Pair)
+	assert.Equal(t, kline.HundredMilliseconds, subs[1].Interval, "pairs without an override should keep the template settings")
+	assert.Equal(t, map[string]interface{}{"depth": 5}, subs[1].Params)
+	assert.Equal(t, map[string]interface{}{"depth": 5}, s.Params, "the template params must not be modified")
+}
+
+func TestUnmarshalOverrides(t *testing.T) {
+	t.Parallel()
+	var s Subscription
+	require.NoError(t, json.Unmarshal([]byte(`{"enabled":true,"channel":"orderbook","interval":"100ms","overrides":[{"pairs":"BTC-USDT","interval":"0s"}]}`), &s), "Unmarshal must not error")
+	require.Len(t, s.Overrides, 1)
+	require.NotNil(t, s.Overrides[0].Interval)
+	assert.Equal(t, kline.Interval(0), *s.Overrides[0].Interval)
+	c, ok := s.ForPair(currency.NewPair(currency.BTC, currency.USDT))
+	assert.True(t, ok)
+	assert.Equal(t, kline.Interval(0), c.Interval)
+
+	j, err := json.Marshal(&s)
+	require.NoError(t, err, "Marshal must not error")
+	assert.Contains(t, string(j), `"overrides":[{"pairs":"BTC-USDT","interval":"0s"}]`)
+}
@@ -209,18 +209,32 @@ func (s *Service) update(p *Price) error {
 			s.mu.Unlock()
 			return err
 		}
+		s.recordHistory(newTicker, p)
 		service.Tickers[mapKey] = newTicker
 		s.mu.Unlock()
 		return nil
 	}
 
 	t.Price = *p
+	s.recordHistory(t, p)
 	//nolint: gocritic
 	ids := append(t.Assoc, t.Main)
 	s.mu.Unlock()
 	return s.mux.Publish(p, ids...)
 }
 
+// recordHistory retains the tick when history is enabled, must be called with
+// the service lock held
+func (s *Service) recordHistory(t *Ticker, p *Price) {
+	if s.historyLength == 0 {
+		return
+	}
+	if t.history == nil {
+		t.history = &tickRing{ticks: make([]Price, s.historyLength)}
+	}
+	t.history.push(p)
+}
+
 // setItemID retrieves and sets dispatch mux publish IDs
 func (s *Service) setItemID(t *Ticker, p *Price, exch string) error {
 	ids, err := s.getAssociations(exch)
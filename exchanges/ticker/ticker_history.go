@@ -0,0 +1,201 @@
+package ticker
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unsafe"
+
+	"github.com/thrasher-corp/gocryptotrader/common/key"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+var (
+	tickerSize = int64(unsafe.Sizeof(Ticker{}))
+	priceSize  = int64(unsafe.Sizeof(Price{}))
+)
+
+var (
+	errInvalidHistoryLength = errors.New("invalid ticker history length")
+	errHistoryDisabled      = errors.New("ticker history is disabled")
+	errInvalidLookback      = errors.New("invalid lookback period")
+	errNoTickHistory        = errors.New("no ticks within lookback period")
+)
+
+// SetHistoryLength sets the number of ticks retained per pair. Setting zero
+// disables retention and clears any stored history
+func SetHistoryLength(n int) error {
+	return service.setHistoryLength(n)
+}
+
+func (s *Service) setHistoryLength(n int) error {
+	if n < 0 {
+		return fmt.Errorf("%w: %d", errInvalidHistoryLength, n)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.historyLength = n
+	for _, t := range s.Tickers {
+		if n == 0 {
+			t.history = nil
+			continue
+		}
+		if t.history != nil {
+			t.history.resize(n)
+		}
+	}
+	return nil
+}
+
+// MemoryUsage returns the approximate memory used by the stored tickers and
+// their retained history in bytes
+func MemoryUsage() int64 {
+	return service.memoryUsage()
+}
+
+func (s *Service) memoryUsage() int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	total := int64(len(s.Tickers)) * tickerSize
+	for _, t := range s.Tickers {
+		if t.history != nil {
+			total += int64(len(t.history.ticks)) * priceSize
+		}
+	}
+	return total
+}
+
+// TrimHistory reduces the number of ticks retained per pair so the approximate
+// memory used by tickers is within the budget, returning the history length
+func TrimHistory(budget int64) (int, error) {
+	return service.trimHistory(budget)
+}
+
+func (s *Service) trimHistory(budget int64) (int, error) {
+	s.mu.Lock()
+	length := s.historyLength
+	tickers := int64(len(s.Tickers))
+	s.mu.Unlock()
+	if length == 0 || tickers == 0 {
+		return length, nil
+	}
+	var fits int
+	if available := budget - tickers*tickerSize; available > 0 {
+		fits = int(available / (tickers * priceSize))
+	}
+	if fits >= length {
+		return length, nil
+	}
+	return fits, s.setHistoryLength(fits)
+}
+
+// GetTickerHistory returns the retained ticks for a pair which were updated
+// within the lookback period, oldest first
+func GetTickerHistory(exchange string, p currency.Pair, a asset.Item, lookback time.Duration) ([]Price, error) {
+	return service.getTickerHistory(exchange, p, a, lookback)
+}
+
+func (s *Service) getTickerHistory(exchange string, p currency.Pair, a asset.Item, lookback time.Duration) ([]Price, error) {
+	if exchange == "" {
+		return nil, ErrExchangeNameIsEmpty
+	}
+	if p.IsEmpty() {
+		return nil, currency.ErrCurrencyPairEmpty
+	}
+	if !a.IsValid() {
+		return nil, fmt.Errorf("%w %v", asset.ErrNotSupported, a)
+	}
+	if lookback <= 0 {
+		return nil, fmt.Errorf("%w: %v", errInvalidLookback, lookback)
+	}
+	exchange = strings.ToLower(exchange)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.historyLength == 0 {
+		return nil, errHistoryDisabled
+	}
+	tick, ok := s.Tickers[key.ExchangePairAsset{
+		Exchange: exchange,
+		Base:     p.Base.Item,
+		Quote:    p.Quote.Item,
+		Asset:    a,
+	}]
+	if !ok {
+		return nil, fmt.Errorf("%w %s %s %s", ErrNoTickerFound, exchange, p, a)
+	}
+	if tick.history == nil {
+		return nil, nil
+	}
+	return tick.history.since(time.Now().Add(-lookback)), nil
+}
+
+// GetTickerOHLC returns the open, high, low and close of the last traded price
+// across the retained ticks within the lookback period
+func GetTickerOHLC(exchange string, p currency.Pair, a asset.Item, lookback time.Duration) (*OHLC, error) {
+	ticks, err := GetTickerHistory(exchange, p, a, lookback)
+	if err != nil {
+		return nil, err
+	}
+	if len(ticks) == 0 {
+		return nil, fmt.Errorf("%w %s %s %s %v", errNoTickHistory, exchange, p, a, lookback)
+	}
+	ohlc := &OHLC{
+		Open:      ticks[0].Last,
+		High:      ticks[0].Last,
+		Low:       ticks[0].Last,
+		Close:     ticks[len(ticks)-1].Last,
+		Ticks:     len(ticks),
+		StartTime: ticks[0].LastUpdated,
+		EndTime:   ticks[len(ticks)-1].LastUpdated,
+	}
+	for i := 1; i < len(ticks); i++ {
+		if ticks[i].Last > ohlc.High {
+			ohlc.High = ticks[i].Last
+		}
+		if ticks[i].Last < ohlc.Low {
+			ohlc.Low = ticks[i].Last
+		}
+	}
+	return ohlc, nil
+}
+
+// push adds a tick to the ring, overwriting the oldest tick when full
+func (r *tickRing) push(p *Price) {
+	if len(r.ticks) == 0 {
+		return
+	}
+	idx := (r.start + r.count) % len(r.ticks)
+	r.ticks[idx] = *p
+	if r.count < len(r.ticks) {
+		r.count++
+		return
+	}
+	r.start = (r.start + 1) % len(r.ticks)
+}
+
+// since returns a copy of the ticks updated at or after the cutoff, oldest
+// first
+func (r *tickRing) since(cutoff time.Time) []Price {
+	var ticks []Price
+	for i := 0; i < r.count; i++ {
+		t := &r.ticks[(r.start+i)%len(r.ticks)]
+		if t.LastUpdated.Before(cutoff) {
+			continue
+		}
+		ticks = append(ticks, *t)
+	}
+	return ticks
+}
+
+// resize changes the ring capacity, keeping the most recent ticks
+func (r *tickRing) resize(n int) {
+	ticks := r.since(time.Time{})
+	if len(ticks) > n {
+		ticks = ticks[len(ticks)-n:]
+	}
+	r.ticks = make([]Price, n)
+	r.start = 0
+	r.count = copy(r.ticks, ticks)
+}
@@ -0,0 +1,122 @@
+package ticker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/common/key"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+func TestTickRing(t *testing.T) {
+	t.Parallel()
+	r := &tickRing{ticks: make([]Price, 3)}
+	now := time.Now()
+	for i := 1; i <= 5; i++ {
+		r.push(&Price{Last: float64(i), LastUpdated: now.Add(time.Duration(i) * time.Second)})
+	}
+	ticks := r.since(time.Time{})
+	require.Len(t, ticks, 3, "ring must retain its capacity of ticks")
+	assert.Equal(t, 3.0, ticks[0].Last, "oldest retained tick should be first")
+	assert.Equal(t, 5.0, ticks[2].Last, "newest tick should be last")
+
+	assert.Len(t, r.since(now.Add(4*time.Second)), 2, "since should exclude ticks before the cutoff")
+
+	r.resize(2)
+	ticks = r.since(time.Time{})
+	require.Len(t, ticks, 2, "resize must truncate to the new capacity")
+	assert.Equal(t, 4.0, ticks[0].Last, "resize should keep the most recent ticks")
+
+	r.resize(4)
+	r.push(&Price{Last: 6})
+	assert.Len(t, r.since(time.Time{}), 3, "resize should allow the ring to grow")
+}
+
+func TestGetTickerHistory(t *testing.T) {
+	assert.ErrorIs(t, SetHistoryLength(-1), errInvalidHistoryLength, "SetHistoryLength should error on a negative length")
+
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	_, err := GetTickerHistory("historytest", p, asset.Spot, time.Minute)
+	assert.ErrorIs(t, err, errHistoryDisabled, "GetTickerHistory should error when history is disabled")
+
+	require.NoError(t, SetHistoryLength(10), "SetHistoryLength must not error")
+	defer func() {
+		require.NoError(t, SetHistoryLength(0), "SetHistoryLength must not error")
+	}()
+
+	_, err = GetTickerHistory("", p, asset.Spot, time.Minute)
+	assert.ErrorIs(t, err, ErrExchangeNameIsEmpty, "GetTickerHistory should error on an empty exchange")
+	_, err = GetTickerHistory("historytest", p, asset.Spot, 0)
+	assert.ErrorIs(t, err, errInvalidLookback, "GetTickerHistory should error on an invalid lookback")
+	_, err = GetTickerHistory("historytest", p, asset.Spot, time.Minute)
+	assert.ErrorIs(t, err, ErrNoTickerFound, "GetTickerHistory should error on an unknown ticker")
+
+	now := time.Now()
+	for i, last := range []float64{100, 120, 90, 110} {
+		err = ProcessTicker(&Price{
+			Last:         last,
+			Pair:         p,
+			ExchangeName: "historytest",
+			AssetType:    asset.Spot,
+			LastUpdated:  now.Add(time.Duration(i-4) * time.Second),
+		})
+		require.NoError(t, err, "ProcessTicker must not error")
+	}
+	err = ProcessTicker(&Price{
+		Last:         1,
+		Pair:         p,
+		ExchangeName: "historytest",
+		AssetType:    asset.Spot,
+		LastUpdated:  now.Add(-time.Hour),
+	})
+	require.NoError(t, err, "ProcessTicker must not error")
+
+	ticks, err := GetTickerHistory("HISTORYTEST", p, asset.Spot, time.Minute)
+	require.NoError(t, err, "GetTickerHistory must not error")
+	assert.Len(t, ticks, 4, "GetTickerHistory should only return ticks within the lookback")
+
+	ohlc, err := GetTickerOHLC("historytest", p, asset.Spot, time.Minute)
+	require.NoError(t, err, "GetTickerOHLC must not error")
+	assert.Equal(t, 100.0, ohlc.Open, "Open should be the first tick")
+	assert.Equal(t, 120.0, ohlc.High, "High should be the highest tick")
+	assert.Equal(t, 90.0, ohlc.Low, "Low should be the lowest tick")
+	assert.Equal(t, 110.0, ohlc.Close, "Close should be the last tick")
+	assert.Equal(t, 4, ohlc.Ticks, "Ticks should be the number of ticks in range")
+
+	_, err = GetTickerOHLC("historytest", p, asset.Spot, time.Millisecond)
+	assert.ErrorIs(t, err, errNoTickHistory, "GetTickerOHLC should error with no ticks in range")
+}
+
+func TestTrimHistory(t *testing.T) {
+	t.Parallel()
+	s := Service{Tickers: make(map[key.ExchangePairAsset]*Ticker)}
+	length, err := s.trimHistory(0)
+	require.NoError(t, err)
+	assert.Zero(t, length, "history length should remain disabled")
+
+	require.NoError(t, s.setHistoryLength(10))
+	for _, c := range []currency.Code{currency.BTC, currency.ETH} {
+		tick := &Ticker{}
+		s.recordHistory(tick, &Price{Last: 1})
+		s.Tickers[key.ExchangePairAsset{Exchange: "test", Base: c.Item, Quote: currency.USDT.Item, Asset: asset.Spot}] = tick
+	}
+	usage := s.memoryUsage()
+	assert.Equal(t, 2*tickerSize+20*priceSize, usage)
+
+	length, err = s.trimHistory(usage)
+	require.NoError(t, err)
+	assert.Equal(t, 10, length, "history within budget must not be trimmed")
+
+	length, err = s.trimHistory(2*tickerSize + 8*priceSize)
+	require.NoError(t, err)
+	assert.Equal(t, 4, length, "history should be trimmed to fit the budget")
+	assert.Equal(t, 2*tickerSize+8*priceSize, s.memoryUsage())
+
+	length, err = s.trimHistory(tickerSize)
+	require.NoError(t, err)
+	assert.Zero(t, length, "history should be disabled when tickers alone exceed the budget")
+	assert.Equal(t, 2*tickerSize, s.memoryUsage())
+}
@@ -29,6 +29,8 @@ type Service struct {
 	Exchange map[string]uuid.UUID
 	mux      *dispatch.Mux
 	mu       sync.Mutex
+	// historyLength is the number of ticks retained per pair, zero disables
+	historyLength int
 }
 
 // Price struct stores the currency pair and pricing information
@@ -58,11 +60,41 @@ type Price struct {
 	AskPeriod             float64
 	AskSize               float64
 	FlashReturnRateAmount float64
+
+	// Option greeks field variables. Delta and gamma are per unit of
+	// underlying, vega and rho are per percentage point and theta is per
+	// calendar day. ImpliedVolatility is annualised, where 0.5 is 50%
+	Delta             float64
+	Gamma             float64
+	Vega              float64
+	Theta             float64
+	Rho               float64
+	ImpliedVolatility float64
 }
 
 // Ticker struct holds the ticker information for a currency pair and type
 type Ticker struct {
 	Price
-	Main  uuid.UUID
-	Assoc []uuid.UUID
+	Main    uuid.UUID
+	Assoc   []uuid.UUID
+	history *tickRing
+}
+
+// tickRing is a fixed size ring buffer of the most recent ticks for a pair
+type tickRing struct {
+	ticks []Price
+	start int
+	count int
+}
+
+// OHLC holds the open, high, low and close of the last traded price across a
+// range of retained ticks
+type OHLC struct {
+	Open      float64
+	High      float64
+	Low       float64
+	Close     float64
+	Ticks     int
+	StartTime time.Time
+	EndTime   time.Time
 }
@@ -3,13 +3,16 @@ package trade
 import (
 	"errors"
 	"fmt"
+	"math"
 	"sort"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/gofrs/uuid"
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/key"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/database"
 	tradesql "github.com/thrasher-corp/gocryptotrader/database/repository/trade"
@@ -38,6 +41,7 @@ func (t *Trade) Setup(exchangeName string, tradeFeedEnabled bool, c chan interfa
 // Update processes trade data, either by saving it or routing it through
 // the data channel.
 func (t *Trade) Update(save bool, data ...Data) error {
+	data = t.deduplicator.filter(data)
 	if len(data) == 0 {
 		// nothing to do
 		return nil
@@ -105,11 +109,83 @@ func AddTradesToBuffer(exchangeName string, data ...Data) error {
 		validDatas = append(validDatas, data[i])
 	}
 	processor.mutex.Lock()
-	processor.buffer = append(processor.buffer, validDatas...)
+	processor.buffer = append(processor.buffer, processor.getDeduplicator(exchangeName).filter(validDatas)...)
 	processor.mutex.Unlock()
 	return errs
 }
 
+// getDeduplicator returns an exchange's deduplicator, creating it when the
+// exchange has not buffered trades before. The lock must be held
+func (p *Processor) getDeduplicator(exchangeName string) *deduplicator {
+	k := strings.ToLower(exchangeName)
+	d, ok := p.deduplicators[k]
+	if !ok {
+		if p.deduplicators == nil {
+			p.deduplicators = make(map[string]*deduplicator)
+		}
+		d = &deduplicator{}
+		p.deduplicators[k] = d
+	}
+	return d
+}
+
+// filter returns the trades whose IDs have not been seen within the most
+// recent MaxDeduplicatedTrades, remembering their IDs. Trades without an ID
+// cannot be deduplicated and are always returned
+func (d *deduplicator) filter(data []Data) []Data {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	if d.seen == nil {
+		d.seen = make(map[tradeKey]struct{})
+	}
+	var filtered []Data
+	for i := range data {
+		if d.isDuplicate(&data[i]) {
+			if filtered == nil {
+				// Copies the trades preceding the first duplicate so the
+				// caller's slice is left unaltered
+				filtered = append(make([]Data, 0, len(data)-1), data[:i]...)
+			}
+			continue
+		}
+		if filtered != nil {
+			filtered = append(filtered, data[i])
+		}
+	}
+	if filtered == nil {
+		return data
+	}
+	return filtered
+}
+
+// isDuplicate returns whether a trade's ID has been seen, otherwise
+// remembering it in place of the oldest remembered ID. The lock must be held
+func (d *deduplicator) isDuplicate(t *Data) bool {
+	if t.TID == "" {
+		return false
+	}
+	k := tradeKey{
+		PairAsset: key.PairAsset{
+			Base:  t.CurrencyPair.Base.Item,
+			Quote: t.CurrencyPair.Quote.Item,
+			Asset: t.AssetType,
+		},
+		TID: t.TID,
+	}
+	if _, ok := d.seen[k]; ok {
+		return true
+	}
+	d.seen[k] = struct{}{}
+	if len(d.recent) < MaxDeduplicatedTrades {
+		d.recent = append(d.recent, k)
+		return false
+	}
+	delete(d.seen, d.recent[d.next])
+	d.recent[d.next] = k
+	d.next = (d.next + 1) % MaxDeduplicatedTrades
+	return false
+}
+
 // Run will save trade data to the database in batches
 func (p *Processor) Run(wg *sync.WaitGroup) {
 	wg.Done()
@@ -136,11 +212,75 @@ func (p *Processor) Run(wg *sync.WaitGroup) {
 		}
 		err := SaveTradesToDatabase(bufferCopy...)
 		if err != nil {
+			if !database.DB.IsConnected() {
+				// retain trades until the database connection manager
+				// reconnects rather than silently losing them
+				p.requeue(bufferCopy)
+				log.Warnf(log.Trade, "database unavailable, %d trades retained for retry: %v", len(bufferCopy), err)
+				continue
+			}
 			log.Errorln(log.Trade, err)
 		}
 	}
 }
 
+// requeue returns unsaved trades to the front of the buffer, dropping the
+// oldest trades once MaxBufferedTrades is exceeded
+func (p *Processor) requeue(trades []Data) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.buffer = append(trades, p.buffer...)
+	if excess := len(p.buffer) - MaxBufferedTrades; excess > 0 {
+		log.Errorf(log.Trade, "trade buffer exceeded %d trades, dropping %d oldest trades", MaxBufferedTrades, excess)
+		p.buffer = p.buffer[excess:]
+	}
+}
+
+// BufferMemoryUsage returns the approximate memory used by trades buffered for
+// saving to the database in bytes
+func BufferMemoryUsage() int64 {
+	return processor.memoryUsage()
+}
+
+func (p *Processor) memoryUsage() int64 {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	var total int64
+	for i := range p.buffer {
+		total += p.buffer[i].memoryUsage()
+	}
+	return total
+}
+
+// TrimBuffer drops the oldest buffered trades until the approximate memory
+// used by the buffer is within the budget, returning the number dropped
+func TrimBuffer(budget int64) int {
+	return processor.trimBuffer(budget)
+}
+
+func (p *Processor) trimBuffer(budget int64) int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	var total int64
+	for i := range p.buffer {
+		total += p.buffer[i].memoryUsage()
+	}
+	var dropped int
+	for ; dropped < len(p.buffer) && total > budget; dropped++ {
+		total -= p.buffer[dropped].memoryUsage()
+	}
+	if dropped > 0 {
+		// Copies the retained trades so the dropped trades can be collected
+		p.buffer = append(p.buffer[:0:0], p.buffer[dropped:]...)
+	}
+	return dropped
+}
+
+// memoryUsage returns the approximate memory used by the trade in bytes
+func (d *Data) memoryUsage() int64 {
+	return dataSize + int64(len(d.TID)+len(d.Exchange))
+}
+
 // SaveTradesToDatabase converts trades and saves results to database
 func SaveTradesToDatabase(trades ...Data) error {
 	sqlTrades, err := tradeToSQLData(trades...)
@@ -216,6 +356,7 @@ func SQLDataToTrade(dbTrades ...tradesql.Data) ([]Data, error) {
 		}
 		result[i] = Data{
 			ID:           uuid.FromStringOrNil(dbTrades[i].ID),
+			TID:          dbTrades[i].TID,
 			Timestamp:    dbTrades[i].Timestamp.UTC(),
 			Exchange:     dbTrades[i].Exchange,
 			CurrencyPair: cp.Upper(),
@@ -247,6 +388,26 @@ func ConvertTradesToCandles(interval kline.Interval, trades ...Data) (*kline.Ite
 	return &candles, nil
 }
 
+// ConvertTradesToVolumeProfiles builds the volume and market profile of each
+// session of trades, placing each trade's amount at its price and counting
+// TPOs per tpoPeriod
+func ConvertTradesToVolumeProfiles(session, tpoPeriod time.Duration, tickSize, valueArea float64, trades ...Data) ([]kline.VolumeProfile, error) {
+	if len(trades) == 0 {
+		return nil, ErrNoTradesSupplied
+	}
+	data := make([]kline.PriceVolume, len(trades))
+	for i := range trades {
+		price := math.Abs(trades[i].Price)
+		data[i] = kline.PriceVolume{
+			Time:   trades[i].Timestamp,
+			Low:    price,
+			High:   price,
+			Volume: math.Abs(trades[i].Amount),
+		}
+	}
+	return kline.CalculateVolumeProfiles(data, session, tpoPeriod, tickSize, valueArea)
+}
+
 func groupTradesToInterval(interval kline.Interval, times ...Data) map[int64][]Data {
 	groupedData := make(map[int64][]Data)
 	for i := range times {
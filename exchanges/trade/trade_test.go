@@ -1,12 +1,15 @@
 package trade
 
 import (
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/database"
 	"github.com/thrasher-corp/gocryptotrader/database/drivers"
@@ -198,6 +201,26 @@ func TestConvertTradesToCandles(t *testing.T) {
 	}
 }
 
+func TestConvertTradesToVolumeProfiles(t *testing.T) {
+	t.Parallel()
+	_, err := ConvertTradesToVolumeProfiles(0, 0, 1, 0.7)
+	assert.ErrorIs(t, err, ErrNoTradesSupplied)
+
+	startDate := time.Date(2020, 1, 1, 1, 0, 0, 0, time.UTC)
+	profiles, err := ConvertTradesToVolumeProfiles(time.Hour, time.Minute, 1, 0.7,
+		Data{Timestamp: startDate, Price: 1337, Amount: 2},
+		Data{Timestamp: startDate.Add(time.Second), Price: 1337, Amount: 1},
+		Data{Timestamp: startDate.Add(time.Minute), Price: -1338, Amount: -1},
+	)
+	require.NoError(t, err)
+	require.Len(t, profiles, 1)
+	assert.Equal(t, []kline.ProfileLevel{
+		{Price: 1337, Volume: 3, TPOs: 1},
+		{Price: 1338, Volume: 1, TPOs: 1},
+	}, profiles[0].Levels, "trades should be placed at their absolute price")
+	assert.Equal(t, 1337.0, profiles[0].PointOfControl)
+}
+
 func TestShutdown(t *testing.T) {
 	t.Parallel()
 	var p Processor
@@ -250,3 +273,82 @@ func TestGetTradesInRange(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestRequeue(t *testing.T) {
+	t.Parallel()
+	var p Processor
+	p.buffer = make([]Data, MaxBufferedTrades-1)
+	p.buffer[0].Exchange = "newer"
+	p.requeue([]Data{{Exchange: "oldest"}, {Exchange: "older"}})
+	if len(p.buffer) != MaxBufferedTrades {
+		t.Fatalf("received %d trades, expected %d", len(p.buffer), MaxBufferedTrades)
+	}
+	if p.buffer[0].Exchange != "older" {
+		t.Errorf("received %q, expected oldest trade to be dropped", p.buffer[0].Exchange)
+	}
+	if p.buffer[1].Exchange != "newer" {
+		t.Errorf("received %q, expected requeued trades ahead of buffered trades", p.buffer[1].Exchange)
+	}
+}
+
+func TestTrimBuffer(t *testing.T) {
+	t.Parallel()
+	var p Processor
+	assert.Zero(t, p.memoryUsage())
+	p.buffer = []Data{{Exchange: "oldest"}, {Exchange: "older"}, {Exchange: "new", TID: "1"}}
+	usage := p.memoryUsage()
+	assert.Equal(t, 3*dataSize+int64(len("oldestoldernew1")), usage)
+
+	assert.Zero(t, p.trimBuffer(usage), "buffers within budget must not be trimmed")
+	assert.Equal(t, 2, p.trimBuffer(dataSize+4))
+	require.Len(t, p.buffer, 1)
+	assert.Equal(t, "new", p.buffer[0].Exchange, "the oldest trades should be dropped")
+	assert.Equal(t, 1, p.trimBuffer(0))
+	assert.Empty(t, p.buffer)
+}
+
+func TestDeduplicatorFilter(t *testing.T) {
+	t.Parallel()
+	var d deduplicator
+	p := currency.NewBTCUSDT()
+	trades := []Data{
+		{TID: "1", CurrencyPair: p, AssetType: asset.Spot},
+		{TID: "2", CurrencyPair: p, AssetType: asset.Spot},
+		{CurrencyPair: p, AssetType: asset.Spot},
+	}
+	assert.Equal(t, trades, d.filter(trades), "unseen trades should all be returned")
+
+	filtered := d.filter([]Data{
+		{TID: "1", CurrencyPair: p, AssetType: asset.Spot},
+		{TID: "1", CurrencyPair: p, AssetType: asset.Futures},
+		{TID: "3", CurrencyPair: p, AssetType: asset.Spot},
+		{TID: "3", CurrencyPair: p, AssetType: asset.Spot},
+		{CurrencyPair: p, AssetType: asset.Spot},
+	})
+	require.Len(t, filtered, 3, "duplicate trades must be dropped")
+	assert.Equal(t, asset.Futures, filtered[0].AssetType, "trades of another asset should not be duplicates")
+	assert.Equal(t, "3", filtered[1].TID, "only the first of duplicates within a batch should be returned")
+	assert.Empty(t, filtered[2].TID, "trades without an ID should always be returned")
+
+	for i := range MaxDeduplicatedTrades {
+		d.filter([]Data{{TID: strconv.Itoa(i + 10), CurrencyPair: p, AssetType: asset.Spot}})
+	}
+	assert.Len(t, d.seen, MaxDeduplicatedTrades, "remembered trade IDs must be bounded")
+	assert.Len(t, d.filter([]Data{{TID: "1", CurrencyPair: p, AssetType: asset.Spot}}), 1, "forgotten trade IDs should not be duplicates")
+}
+
+func TestUpdateDeduplicates(t *testing.T) {
+	t.Parallel()
+	c := make(chan interface{}, 2)
+	var tr Trade
+	tr.Setup("test", true, c)
+	p := currency.NewBTCUSDT()
+	first := Data{TID: "1", Exchange: "test", CurrencyPair: p, AssetType: asset.Spot}
+	second := Data{TID: "2", Exchange: "test", CurrencyPair: p, AssetType: asset.Spot}
+	require.NoError(t, tr.Update(false, first), "Update must not error")
+	require.NoError(t, tr.Update(false, first, second), "Update must not error")
+	require.NoError(t, tr.Update(false, second), "Update must not error")
+	require.Len(t, c, 2, "trades received twice must not be sent to the data handler")
+	assert.Equal(t, []Data{first}, <-c)
+	assert.Equal(t, []Data{second}, <-c)
+}
@@ -4,8 +4,10 @@ import (
 	"errors"
 	"sync"
 	"time"
+	"unsafe"
 
 	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/common/key"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
@@ -15,8 +17,18 @@ import (
 // to process queued trades and save them to the database
 const DefaultProcessorIntervalTime = time.Second * 15
 
+// MaxBufferedTrades is the maximum number of trades retained in memory while
+// the database is unavailable
+const MaxBufferedTrades = 100000
+
+// MaxDeduplicatedTrades is the number of most recent trade IDs remembered per
+// exchange to drop trades received more than once
+const MaxDeduplicatedTrades = 10000
+
 var (
 	processor Processor
+	// dataSize is the memory used by a trade excluding its strings
+	dataSize = int64(unsafe.Sizeof(Data{}))
 	// BufferProcessorIntervalTime is the interval to save trade buffer data to the database.
 	// Change this by changing the runtime param `-tradeprocessinginterval=15s`
 	BufferProcessorIntervalTime = DefaultProcessorIntervalTime
@@ -30,6 +42,7 @@ type Trade struct {
 	exchangeName     string
 	dataHandler      chan interface{}
 	tradeFeedEnabled bool
+	deduplicator     deduplicator
 }
 
 // Data defines trade data
@@ -52,6 +65,23 @@ type Processor struct {
 	started                 int32
 	bufferProcessorInterval time.Duration
 	buffer                  []Data
+	deduplicators           map[string]*deduplicator
+}
+
+// deduplicator remembers the most recent trade IDs so trades received via
+// more than one subscription or connection are only processed once
+type deduplicator struct {
+	mutex sync.Mutex
+	seen  map[tradeKey]struct{}
+	// recent is a ring of the remembered trade IDs, oldest at next once full
+	recent []tradeKey
+	next   int
+}
+
+// tradeKey is a unique map key signature for a trade
+type tradeKey struct {
+	key.PairAsset
+	TID string
 }
 
 // ByDate sorts trades by date ascending
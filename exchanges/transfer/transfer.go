@@ -0,0 +1,139 @@
+package transfer
+
+import (
+	"fmt"
+	"strings"
+)
+
+var networkAliases = map[string]Network{
+	"ERC20":              ERC20,
+	"ETH":                ERC20,
+	"ETHEREUM":           ERC20,
+	"ETHERC20":           ERC20,
+	"ETHEREUMERC20":      ERC20,
+	"TRC20":              TRC20,
+	"TRX":                TRC20,
+	"TRON":               TRC20,
+	"TRONTRC20":          TRC20,
+	"BEP20":              BEP20,
+	"BSC":                BEP20,
+	"BNBSMARTCHAIN":      BEP20,
+	"BNBSMARTCHAINBEP20": BEP20,
+	"BEP20BSC":           BEP20,
+	"BEP2":               BEP2,
+	"BNB":                BEP2,
+	"BNBBEACONCHAIN":     BEP2,
+	"BNBBEACONCHAINBEP2": BEP2,
+	"SOL":                Solana,
+	"SOLANA":             Solana,
+	"SPL":                Solana,
+	"POLYGON":            Polygon,
+	"POLYGONPOS":         Polygon,
+	"MATIC":              Polygon,
+	"POL":                Polygon,
+	"ARBITRUM":           Arbitrum,
+	"ARBITRUMONE":        Arbitrum,
+	"ARB":                Arbitrum,
+	"OPTIMISM":           Optimism,
+	"OP":                 Optimism,
+	"AVAXC":              AvaxC,
+	"AVAXCCHAIN":         AvaxC,
+	"AVALANCHECCHAIN":    AvaxC,
+	"CAVAX":              AvaxC,
+	"BTC":                Bitcoin,
+	"BITCOIN":            Bitcoin,
+	"LIGHTNING":          Lightning,
+	"LN":                 Lightning,
+	"BTCLIGHTNING":       Lightning,
+	"TON":                TON,
+	"TONCOIN":            TON,
+}
+
+// NormaliseNetwork returns the exchange agnostic network of an exchange's
+// chain name, such as ERC20 for "ETH", "Ethereum (ERC20)" or "USDT-ERC20".
+// Names prefixed with a currency are matched without the prefix when the full
+// name is not recognised
+func NormaliseNetwork(chain string) Network {
+	full := compact(chain)
+	if n, ok := networkAliases[full]; ok {
+		return n
+	}
+	if _, suffix, ok := strings.Cut(chain, "-"); ok {
+		if n, ok := networkAliases[compact(suffix)]; ok {
+			return n
+		}
+	}
+	return Network(full)
+}
+
+// compact upper cases a chain name and removes separators
+func compact(chain string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '_', '(', ')', '.':
+			return -1
+		}
+		return r
+	}, strings.ToUpper(strings.TrimSpace(chain)))
+}
+
+// FindChain returns the chain matching either a normalised network or an
+// exchange's own chain name. Exchange chain names are matched first, so
+// networks offered under more than one name can still be selected exactly
+func FindChain(chains []Chain, network string) (*Chain, error) {
+	if network == "" {
+		return nil, ErrNetworkUnset
+	}
+	for i := range chains {
+		if strings.EqualFold(chains[i].Name, network) {
+			return &chains[i], nil
+		}
+	}
+	n := NormaliseNetwork(network)
+	var match *Chain
+	for i := range chains {
+		if chains[i].Network != n {
+			continue
+		}
+		if match != nil {
+			return nil, fmt.Errorf("%w %s: %s, %s", ErrAmbiguousNetworkMatch, network, match.Name, chains[i].Name)
+		}
+		match = &chains[i]
+	}
+	if match == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNetworkNotFound, network)
+	}
+	return match, nil
+}
+
+// CanDeposit returns an error when deposits are disabled for the chain
+func (c *Chain) CanDeposit() error {
+	if !c.DepositEnabled {
+		return fmt.Errorf("%w: %s", ErrDepositsDisabled, c.Name)
+	}
+	return nil
+}
+
+// CanWithdraw returns an error when withdrawals are disabled for the chain or
+// the amount is below the chain's minimum withdrawal
+func (c *Chain) CanWithdraw(amount float64) error {
+	if !c.WithdrawEnabled {
+		return fmt.Errorf("%w: %s", ErrWithdrawalsDisabled, c.Name)
+	}
+	if amount < c.MinWithdrawal {
+		return fmt.Errorf("%w: %s %v < %v", ErrBelowMinimumWithdraw, c.Name, amount, c.MinWithdrawal)
+	}
+	return nil
+}
+
+// ParseDirection returns the transfer direction of a string such as "deposit"
+// or "withdraw"
+func ParseDirection(s string) (Direction, error) {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "DEPOSIT", "IN":
+		return Deposit, nil
+	case "WITHDRAWAL", "WITHDRAW", "OUT":
+		return Withdrawal, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrInvalidDirection, s)
+}
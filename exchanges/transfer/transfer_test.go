@@ -0,0 +1,84 @@
+package transfer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormaliseNetwork(t *testing.T) {
+	t.Parallel()
+	for chain, expected := range map[string]Network{
+		"ERC20":                  ERC20,
+		"eth":                    ERC20,
+		"Ethereum (ERC20)":       ERC20,
+		"USDT-ERC20":             ERC20,
+		"TRX":                    TRC20,
+		"USDT-TRC20":             TRC20,
+		"BSC":                    BEP20,
+		"BNB Smart Chain(BEP20)": BEP20,
+		"ETH-Arbitrum One":       Arbitrum,
+		"USDC-Avalanche C-Chain": AvaxC,
+		"BTC-Lightning":          Lightning,
+		"MATIC":                  Polygon,
+		"Some Chain":             "SOMECHAIN",
+	} {
+		assert.Equalf(t, expected, NormaliseNetwork(chain), "NormaliseNetwork should normalise %q", chain)
+	}
+}
+
+func TestFindChain(t *testing.T) {
+	t.Parallel()
+	chains := []Chain{
+		{Network: ERC20, Name: "USDT-ERC20"},
+		{Network: TRC20, Name: "USDT-TRC20"},
+		{Network: Polygon, Name: "USDT-Polygon"},
+		{Network: Polygon, Name: "USDT-Polygon (Bridged)"},
+	}
+	_, err := FindChain(chains, "")
+	assert.ErrorIs(t, err, ErrNetworkUnset)
+
+	c, err := FindChain(chains, "trc20")
+	require.NoError(t, err)
+	assert.Equal(t, "USDT-TRC20", c.Name)
+
+	c, err = FindChain(chains, "Ethereum")
+	require.NoError(t, err)
+	assert.Equal(t, "USDT-ERC20", c.Name, "FindChain should match normalised networks")
+
+	c, err = FindChain(chains, "usdt-polygon (bridged)")
+	require.NoError(t, err)
+	assert.Equal(t, "USDT-Polygon (Bridged)", c.Name, "FindChain should match exchange chain names first")
+
+	_, err = FindChain(chains, "MATIC")
+	assert.ErrorIs(t, err, ErrAmbiguousNetworkMatch)
+
+	_, err = FindChain(chains, "SOL")
+	assert.ErrorIs(t, err, ErrNetworkNotFound)
+}
+
+func TestChainCanDeposit(t *testing.T) {
+	t.Parallel()
+	assert.ErrorIs(t, (&Chain{}).CanDeposit(), ErrDepositsDisabled)
+	assert.NoError(t, (&Chain{DepositEnabled: true}).CanDeposit())
+}
+
+func TestChainCanWithdraw(t *testing.T) {
+	t.Parallel()
+	assert.ErrorIs(t, (&Chain{}).CanWithdraw(1), ErrWithdrawalsDisabled)
+	c := &Chain{WithdrawEnabled: true, MinWithdrawal: 10}
+	assert.ErrorIs(t, c.CanWithdraw(1), ErrBelowMinimumWithdraw)
+	assert.NoError(t, c.CanWithdraw(10))
+}
+
+func TestParseDirection(t *testing.T) {
+	t.Parallel()
+	for s, exp := range map[string]Direction{"deposit": Deposit, " IN ": Deposit, "Withdraw": Withdrawal, "withdrawal": Withdrawal, "out": Withdrawal} {
+		d, err := ParseDirection(s)
+		require.NoError(t, err, "ParseDirection must not error")
+		assert.Equal(t, exp, d, "ParseDirection should return the correct direction")
+	}
+	_, err := ParseDirection("sideways")
+	assert.ErrorIs(t, err, ErrInvalidDirection)
+}
@@ -0,0 +1,79 @@
+package transfer
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+// Transfer network errors
+var (
+	ErrNetworkNotFound       = errors.New("transfer network not found")
+	ErrNetworkUnset          = errors.New("transfer network unset")
+	ErrDepositsDisabled      = errors.New("deposits are disabled for transfer network")
+	ErrWithdrawalsDisabled   = errors.New("withdrawals are disabled for transfer network")
+	ErrBelowMinimumWithdraw  = errors.New("amount is below the transfer network's minimum withdrawal")
+	ErrAmbiguousNetworkMatch = errors.New("transfer network matches more than one chain")
+	ErrInvalidDirection      = errors.New("invalid transfer direction")
+)
+
+// Network is an exchange agnostic identifier of the blockchain network a
+// currency is transferred over, such as ERC20 or TRC20
+type Network string
+
+// Normalised transfer networks. Chains which cannot be normalised keep their
+// upper cased exchange name without separators
+const (
+	ERC20     Network = "ERC20"
+	TRC20     Network = "TRC20"
+	BEP20     Network = "BEP20"
+	BEP2      Network = "BEP2"
+	Solana    Network = "SOL"
+	Polygon   Network = "POLYGON"
+	Arbitrum  Network = "ARBITRUM"
+	Optimism  Network = "OPTIMISM"
+	AvaxC     Network = "AVAXC"
+	Bitcoin   Network = "BTC"
+	Lightning Network = "LIGHTNING"
+	TON       Network = "TON"
+)
+
+// Chain holds an exchange's deposit and withdrawal details for a currency on
+// a transfer network
+type Chain struct {
+	Network Network
+	// Name is the exchange's own name for the chain, which must be used when
+	// requesting deposit addresses or submitting withdrawals
+	Name            string
+	DepositEnabled  bool
+	WithdrawEnabled bool
+	WithdrawFee     float64
+	MinWithdrawal   float64
+	MinDeposit      float64
+}
+
+// Direction is whether a transfer moves funds into or out of an account
+type Direction string
+
+// Transfer directions
+const (
+	Deposit    Direction = "DEPOSIT"
+	Withdrawal Direction = "WITHDRAWAL"
+)
+
+// Event is a deposit or withdrawal update pushed by an exchange or provider
+type Event struct {
+	Exchange  string
+	ID        string
+	Currency  currency.Code
+	Network   Network
+	Direction Direction
+	// Status is the exchange's own status of the transfer
+	Status    string
+	Amount    float64
+	Fee       float64
+	Address   string
+	TxID      string
+	Timestamp time.Time
+}
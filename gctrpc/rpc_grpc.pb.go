@@ -61,6 +61,9 @@ const (
 	GoCryptoTraderService_GetAvailableTransferChains_FullMethodName        = "/gctrpc.GoCryptoTraderService/GetAvailableTransferChains"
 	GoCryptoTraderService_WithdrawFiatFunds_FullMethodName                 = "/gctrpc.GoCryptoTraderService/WithdrawFiatFunds"
 	GoCryptoTraderService_WithdrawCryptocurrencyFunds_FullMethodName       = "/gctrpc.GoCryptoTraderService/WithdrawCryptocurrencyFunds"
+	GoCryptoTraderService_GetWithdrawalSigningPayload_FullMethodName       = "/gctrpc.GoCryptoTraderService/GetWithdrawalSigningPayload"
+	GoCryptoTraderService_SubmitSignedWithdrawal_FullMethodName            = "/gctrpc.GoCryptoTraderService/SubmitSignedWithdrawal"
+	GoCryptoTraderService_GetWithdrawalLocks_FullMethodName                = "/gctrpc.GoCryptoTraderService/GetWithdrawalLocks"
 	GoCryptoTraderService_WithdrawalEventByID_FullMethodName               = "/gctrpc.GoCryptoTraderService/WithdrawalEventByID"
 	GoCryptoTraderService_WithdrawalEventsByExchange_FullMethodName        = "/gctrpc.GoCryptoTraderService/WithdrawalEventsByExchange"
 	GoCryptoTraderService_WithdrawalEventsByDate_FullMethodName            = "/gctrpc.GoCryptoTraderService/WithdrawalEventsByDate"
@@ -82,6 +85,9 @@ const (
 	GoCryptoTraderService_GCTScriptStopAll_FullMethodName                  = "/gctrpc.GoCryptoTraderService/GCTScriptStopAll"
 	GoCryptoTraderService_GCTScriptListAll_FullMethodName                  = "/gctrpc.GoCryptoTraderService/GCTScriptListAll"
 	GoCryptoTraderService_GCTScriptAutoLoadToggle_FullMethodName           = "/gctrpc.GoCryptoTraderService/GCTScriptAutoLoadToggle"
+	GoCryptoTraderService_GCTScriptScheduleAdd_FullMethodName              = "/gctrpc.GoCryptoTraderService/GCTScriptScheduleAdd"
+	GoCryptoTraderService_GCTScriptScheduleRemove_FullMethodName           = "/gctrpc.GoCryptoTraderService/GCTScriptScheduleRemove"
+	GoCryptoTraderService_GCTScriptScheduleList_FullMethodName             = "/gctrpc.GoCryptoTraderService/GCTScriptScheduleList"
 	GoCryptoTraderService_GetHistoricCandles_FullMethodName                = "/gctrpc.GoCryptoTraderService/GetHistoricCandles"
 	GoCryptoTraderService_SetExchangeAsset_FullMethodName                  = "/gctrpc.GoCryptoTraderService/SetExchangeAsset"
 	GoCryptoTraderService_SetAllExchangePairs_FullMethodName               = "/gctrpc.GoCryptoTraderService/SetAllExchangePairs"
@@ -121,6 +127,8 @@ const (
 	GoCryptoTraderService_GetMarginRatesHistory_FullMethodName             = "/gctrpc.GoCryptoTraderService/GetMarginRatesHistory"
 	GoCryptoTraderService_GetManagedPosition_FullMethodName                = "/gctrpc.GoCryptoTraderService/GetManagedPosition"
 	GoCryptoTraderService_GetAllManagedPositions_FullMethodName            = "/gctrpc.GoCryptoTraderService/GetAllManagedPositions"
+	GoCryptoTraderService_GetPortfolioGreeks_FullMethodName                = "/gctrpc.GoCryptoTraderService/GetPortfolioGreeks"
+	GoCryptoTraderService_StreamFills_FullMethodName                       = "/gctrpc.GoCryptoTraderService/StreamFills"
 	GoCryptoTraderService_GetFundingRates_FullMethodName                   = "/gctrpc.GoCryptoTraderService/GetFundingRates"
 	GoCryptoTraderService_GetLatestFundingRate_FullMethodName              = "/gctrpc.GoCryptoTraderService/GetLatestFundingRate"
 	GoCryptoTraderService_GetOrderbookMovement_FullMethodName              = "/gctrpc.GoCryptoTraderService/GetOrderbookMovement"
@@ -128,11 +136,38 @@ const (
 	GoCryptoTraderService_GetOrderbookAmountByImpact_FullMethodName        = "/gctrpc.GoCryptoTraderService/GetOrderbookAmountByImpact"
 	GoCryptoTraderService_GetCollateralMode_FullMethodName                 = "/gctrpc.GoCryptoTraderService/GetCollateralMode"
 	GoCryptoTraderService_GetLeverage_FullMethodName                       = "/gctrpc.GoCryptoTraderService/GetLeverage"
+	GoCryptoTraderService_GetMarginType_FullMethodName                     = "/gctrpc.GoCryptoTraderService/GetMarginType"
+	GoCryptoTraderService_GetExchangeCalendar_FullMethodName               = "/gctrpc.GoCryptoTraderService/GetExchangeCalendar"
+	GoCryptoTraderService_GetVolumeProfile_FullMethodName                  = "/gctrpc.GoCryptoTraderService/GetVolumeProfile"
+	GoCryptoTraderService_GetTransferNetworks_FullMethodName               = "/gctrpc.GoCryptoTraderService/GetTransferNetworks"
+	GoCryptoTraderService_GetEarnProducts_FullMethodName                   = "/gctrpc.GoCryptoTraderService/GetEarnProducts"
+	GoCryptoTraderService_GetEarnPositions_FullMethodName                  = "/gctrpc.GoCryptoTraderService/GetEarnPositions"
+	GoCryptoTraderService_GetEarnRewards_FullMethodName                    = "/gctrpc.GoCryptoTraderService/GetEarnRewards"
+	GoCryptoTraderService_GetLendingOpportunities_FullMethodName           = "/gctrpc.GoCryptoTraderService/GetLendingOpportunities"
+	GoCryptoTraderService_GetRPCCapabilities_FullMethodName                = "/gctrpc.GoCryptoTraderService/GetRPCCapabilities"
+	GoCryptoTraderService_GetMemoryUsage_FullMethodName                    = "/gctrpc.GoCryptoTraderService/GetMemoryUsage"
 	GoCryptoTraderService_SetCollateralMode_FullMethodName                 = "/gctrpc.GoCryptoTraderService/SetCollateralMode"
 	GoCryptoTraderService_SetMarginType_FullMethodName                     = "/gctrpc.GoCryptoTraderService/SetMarginType"
 	GoCryptoTraderService_SetLeverage_FullMethodName                       = "/gctrpc.GoCryptoTraderService/SetLeverage"
 	GoCryptoTraderService_ChangePositionMargin_FullMethodName              = "/gctrpc.GoCryptoTraderService/ChangePositionMargin"
+	GoCryptoTraderService_ConvertCurrency_FullMethodName                   = "/gctrpc.GoCryptoTraderService/ConvertCurrency"
+	GoCryptoTraderService_SweepDust_FullMethodName                         = "/gctrpc.GoCryptoTraderService/SweepDust"
+	GoCryptoTraderService_SubmitOptionStrategy_FullMethodName              = "/gctrpc.GoCryptoTraderService/SubmitOptionStrategy"
+	GoCryptoTraderService_CaptureProfile_FullMethodName                    = "/gctrpc.GoCryptoTraderService/CaptureProfile"
+	GoCryptoTraderService_SubscribeEarnProduct_FullMethodName              = "/gctrpc.GoCryptoTraderService/SubscribeEarnProduct"
+	GoCryptoTraderService_RedeemEarnProduct_FullMethodName                 = "/gctrpc.GoCryptoTraderService/RedeemEarnProduct"
 	GoCryptoTraderService_GetOpenInterest_FullMethodName                   = "/gctrpc.GoCryptoTraderService/GetOpenInterest"
+	GoCryptoTraderService_GetScheduledTasks_FullMethodName                 = "/gctrpc.GoCryptoTraderService/GetScheduledTasks"
+	GoCryptoTraderService_GetRateLimitStatus_FullMethodName                = "/gctrpc.GoCryptoTraderService/GetRateLimitStatus"
+	GoCryptoTraderService_GetVolatilitySurface_FullMethodName              = "/gctrpc.GoCryptoTraderService/GetVolatilitySurface"
+	GoCryptoTraderService_GetImpliedVolatility_FullMethodName              = "/gctrpc.GoCryptoTraderService/GetImpliedVolatility"
+	GoCryptoTraderService_SimulatePortfolioMargin_FullMethodName           = "/gctrpc.GoCryptoTraderService/SimulatePortfolioMargin"
+	GoCryptoTraderService_GetDataQualityScores_FullMethodName              = "/gctrpc.GoCryptoTraderService/GetDataQualityScores"
+	GoCryptoTraderService_GetDashboardSnapshot_FullMethodName              = "/gctrpc.GoCryptoTraderService/GetDashboardSnapshot"
+	GoCryptoTraderService_GetStreamedFundingRates_FullMethodName           = "/gctrpc.GoCryptoTraderService/GetStreamedFundingRates"
+	GoCryptoTraderService_GetStreamedFundingRateHistory_FullMethodName     = "/gctrpc.GoCryptoTraderService/GetStreamedFundingRateHistory"
+	GoCryptoTraderService_StreamFundingRates_FullMethodName                = "/gctrpc.GoCryptoTraderService/StreamFundingRates"
+	GoCryptoTraderService_GetVolatilityCorrelation_FullMethodName          = "/gctrpc.GoCryptoTraderService/GetVolatilityCorrelation"
 )
 
 // GoCryptoTraderServiceClient is the client API for GoCryptoTraderService service.
@@ -181,6 +216,9 @@ type GoCryptoTraderServiceClient interface {
 	GetAvailableTransferChains(ctx context.Context, in *GetAvailableTransferChainsRequest, opts ...grpc.CallOption) (*GetAvailableTransferChainsResponse, error)
 	WithdrawFiatFunds(ctx context.Context, in *WithdrawFiatRequest, opts ...grpc.CallOption) (*WithdrawResponse, error)
 	WithdrawCryptocurrencyFunds(ctx context.Context, in *WithdrawCryptoRequest, opts ...grpc.CallOption) (*WithdrawResponse, error)
+	GetWithdrawalSigningPayload(ctx context.Context, in *GetWithdrawalSigningPayloadRequest, opts ...grpc.CallOption) (*GetWithdrawalSigningPayloadResponse, error)
+	SubmitSignedWithdrawal(ctx context.Context, in *SubmitSignedWithdrawalRequest, opts ...grpc.CallOption) (*WithdrawResponse, error)
+	GetWithdrawalLocks(ctx context.Context, in *GetWithdrawalLocksRequest, opts ...grpc.CallOption) (*GetWithdrawalLocksResponse, error)
 	WithdrawalEventByID(ctx context.Context, in *WithdrawalEventByIDRequest, opts ...grpc.CallOption) (*WithdrawalEventByIDResponse, error)
 	WithdrawalEventsByExchange(ctx context.Context, in *WithdrawalEventsByExchangeRequest, opts ...grpc.CallOption) (*WithdrawalEventsByExchangeResponse, error)
 	WithdrawalEventsByDate(ctx context.Context, in *WithdrawalEventsByDateRequest, opts ...grpc.CallOption) (*WithdrawalEventsByExchangeResponse, error)
@@ -202,6 +240,9 @@ type GoCryptoTraderServiceClient interface {
 	GCTScriptStopAll(ctx context.Context, in *GCTScriptStopAllRequest, opts ...grpc.CallOption) (*GenericResponse, error)
 	GCTScriptListAll(ctx context.Context, in *GCTScriptListAllRequest, opts ...grpc.CallOption) (*GCTScriptStatusResponse, error)
 	GCTScriptAutoLoadToggle(ctx context.Context, in *GCTScriptAutoLoadRequest, opts ...grpc.CallOption) (*GenericResponse, error)
+	GCTScriptScheduleAdd(ctx context.Context, in *GCTScriptScheduleAddRequest, opts ...grpc.CallOption) (*GCTScriptSchedule, error)
+	GCTScriptScheduleRemove(ctx context.Context, in *GCTScriptScheduleRemoveRequest, opts ...grpc.CallOption) (*GenericResponse, error)
+	GCTScriptScheduleList(ctx context.Context, in *GCTScriptScheduleListRequest, opts ...grpc.CallOption) (*GCTScriptScheduleListResponse, error)
 	GetHistoricCandles(ctx context.Context, in *GetHistoricCandlesRequest, opts ...grpc.CallOption) (*GetHistoricCandlesResponse, error)
 	SetExchangeAsset(ctx context.Context, in *SetExchangeAssetRequest, opts ...grpc.CallOption) (*GenericResponse, error)
 	SetAllExchangePairs(ctx context.Context, in *SetExchangeAllPairsRequest, opts ...grpc.CallOption) (*GenericResponse, error)
@@ -241,6 +282,8 @@ type GoCryptoTraderServiceClient interface {
 	GetMarginRatesHistory(ctx context.Context, in *GetMarginRatesHistoryRequest, opts ...grpc.CallOption) (*GetMarginRatesHistoryResponse, error)
 	GetManagedPosition(ctx context.Context, in *GetManagedPositionRequest, opts ...grpc.CallOption) (*GetManagedPositionsResponse, error)
 	GetAllManagedPositions(ctx context.Context, in *GetAllManagedPositionsRequest, opts ...grpc.CallOption) (*GetManagedPositionsResponse, error)
+	GetPortfolioGreeks(ctx context.Context, in *GetPortfolioGreeksRequest, opts ...grpc.CallOption) (*GetPortfolioGreeksResponse, error)
+	StreamFills(ctx context.Context, in *StreamFillsRequest, opts ...grpc.CallOption) (GoCryptoTraderService_StreamFillsClient, error)
 	GetFundingRates(ctx context.Context, in *GetFundingRatesRequest, opts ...grpc.CallOption) (*GetFundingRatesResponse, error)
 	GetLatestFundingRate(ctx context.Context, in *GetLatestFundingRateRequest, opts ...grpc.CallOption) (*GetLatestFundingRateResponse, error)
 	GetOrderbookMovement(ctx context.Context, in *GetOrderbookMovementRequest, opts ...grpc.CallOption) (*GetOrderbookMovementResponse, error)
@@ -248,11 +291,38 @@ type GoCryptoTraderServiceClient interface {
 	GetOrderbookAmountByImpact(ctx context.Context, in *GetOrderbookAmountByImpactRequest, opts ...grpc.CallOption) (*GetOrderbookAmountByImpactResponse, error)
 	GetCollateralMode(ctx context.Context, in *GetCollateralModeRequest, opts ...grpc.CallOption) (*GetCollateralModeResponse, error)
 	GetLeverage(ctx context.Context, in *GetLeverageRequest, opts ...grpc.CallOption) (*GetLeverageResponse, error)
+	GetMarginType(ctx context.Context, in *GetMarginTypeRequest, opts ...grpc.CallOption) (*GetMarginTypeResponse, error)
+	GetExchangeCalendar(ctx context.Context, in *GetExchangeCalendarRequest, opts ...grpc.CallOption) (*GetExchangeCalendarResponse, error)
+	GetVolumeProfile(ctx context.Context, in *GetVolumeProfileRequest, opts ...grpc.CallOption) (*GetVolumeProfileResponse, error)
+	GetTransferNetworks(ctx context.Context, in *GetTransferNetworksRequest, opts ...grpc.CallOption) (*GetTransferNetworksResponse, error)
+	GetEarnProducts(ctx context.Context, in *GetEarnProductsRequest, opts ...grpc.CallOption) (*GetEarnProductsResponse, error)
+	GetEarnPositions(ctx context.Context, in *GetEarnPositionsRequest, opts ...grpc.CallOption) (*GetEarnPositionsResponse, error)
+	GetEarnRewards(ctx context.Context, in *GetEarnRewardsRequest, opts ...grpc.CallOption) (*GetEarnRewardsResponse, error)
+	GetLendingOpportunities(ctx context.Context, in *GetLendingOpportunitiesRequest, opts ...grpc.CallOption) (*GetLendingOpportunitiesResponse, error)
+	GetRPCCapabilities(ctx context.Context, in *GetRPCCapabilitiesRequest, opts ...grpc.CallOption) (*GetRPCCapabilitiesResponse, error)
+	GetMemoryUsage(ctx context.Context, in *GetMemoryUsageRequest, opts ...grpc.CallOption) (*GetMemoryUsageResponse, error)
 	SetCollateralMode(ctx context.Context, in *SetCollateralModeRequest, opts ...grpc.CallOption) (*SetCollateralModeResponse, error)
 	SetMarginType(ctx context.Context, in *SetMarginTypeRequest, opts ...grpc.CallOption) (*SetMarginTypeResponse, error)
 	SetLeverage(ctx context.Context, in *SetLeverageRequest, opts ...grpc.CallOption) (*SetLeverageResponse, error)
 	ChangePositionMargin(ctx context.Context, in *ChangePositionMarginRequest, opts ...grpc.CallOption) (*ChangePositionMarginResponse, error)
+	ConvertCurrency(ctx context.Context, in *ConvertCurrencyRequest, opts ...grpc.CallOption) (*ConvertCurrencyResponse, error)
+	SweepDust(ctx context.Context, in *SweepDustRequest, opts ...grpc.CallOption) (*SweepDustResponse, error)
+	SubmitOptionStrategy(ctx context.Context, in *SubmitOptionStrategyRequest, opts ...grpc.CallOption) (*SubmitOptionStrategyResponse, error)
+	CaptureProfile(ctx context.Context, in *CaptureProfileRequest, opts ...grpc.CallOption) (*CaptureProfileResponse, error)
+	SubscribeEarnProduct(ctx context.Context, in *SubscribeEarnProductRequest, opts ...grpc.CallOption) (*EarnResult, error)
+	RedeemEarnProduct(ctx context.Context, in *RedeemEarnProductRequest, opts ...grpc.CallOption) (*EarnResult, error)
 	GetOpenInterest(ctx context.Context, in *GetOpenInterestRequest, opts ...grpc.CallOption) (*GetOpenInterestResponse, error)
+	GetScheduledTasks(ctx context.Context, in *GetScheduledTasksRequest, opts ...grpc.CallOption) (*GetScheduledTasksResponse, error)
+	GetRateLimitStatus(ctx context.Context, in *GetRateLimitStatusRequest, opts ...grpc.CallOption) (*GetRateLimitStatusResponse, error)
+	GetVolatilitySurface(ctx context.Context, in *GetVolatilitySurfaceRequest, opts ...grpc.CallOption) (*GetVolatilitySurfaceResponse, error)
+	GetImpliedVolatility(ctx context.Context, in *GetImpliedVolatilityRequest, opts ...grpc.CallOption) (*GetImpliedVolatilityResponse, error)
+	SimulatePortfolioMargin(ctx context.Context, in *SimulatePortfolioMarginRequest, opts ...grpc.CallOption) (*SimulatePortfolioMarginResponse, error)
+	GetDataQualityScores(ctx context.Context, in *GetDataQualityScoresRequest, opts ...grpc.CallOption) (*GetDataQualityScoresResponse, error)
+	GetDashboardSnapshot(ctx context.Context, in *GetDashboardSnapshotRequest, opts ...grpc.CallOption) (*GetDashboardSnapshotResponse, error)
+	GetStreamedFundingRates(ctx context.Context, in *GetStreamedFundingRatesRequest, opts ...grpc.CallOption) (*GetStreamedFundingRatesResponse, error)
+	GetStreamedFundingRateHistory(ctx context.Context, in *GetStreamedFundingRateHistoryRequest, opts ...grpc.CallOption) (*GetStreamedFundingRateHistoryResponse, error)
+	StreamFundingRates(ctx context.Context, in *StreamFundingRatesRequest, opts ...grpc.CallOption) (GoCryptoTraderService_StreamFundingRatesClient, error)
+	GetVolatilityCorrelation(ctx context.Context, in *GetVolatilityCorrelationRequest, opts ...grpc.CallOption) (*GetVolatilityCorrelationResponse, error)
 }
 
 type goCryptoTraderServiceClient struct {
@@ -664,6 +734,33 @@ func (c *goCryptoTraderServiceClient) WithdrawCryptocurrencyFunds(ctx context.Co
 	return out, nil
 }
 
+func (c *goCryptoTraderServiceClient) GetWithdrawalSigningPayload(ctx context.Context, in *GetWithdrawalSigningPayloadRequest, opts ...grpc.CallOption) (*GetWithdrawalSigningPayloadResponse, error) {
+	out := new(GetWithdrawalSigningPayloadResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetWithdrawalSigningPayload_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) SubmitSignedWithdrawal(ctx context.Context, in *SubmitSignedWithdrawalRequest, opts ...grpc.CallOption) (*WithdrawResponse, error) {
+	out := new(WithdrawResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_SubmitSignedWithdrawal_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetWithdrawalLocks(ctx context.Context, in *GetWithdrawalLocksRequest, opts ...grpc.CallOption) (*GetWithdrawalLocksResponse, error) {
+	out := new(GetWithdrawalLocksResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetWithdrawalLocks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *goCryptoTraderServiceClient) WithdrawalEventByID(ctx context.Context, in *WithdrawalEventByIDRequest, opts ...grpc.CallOption) (*WithdrawalEventByIDResponse, error) {
 	out := new(WithdrawalEventByIDResponse)
 	err := c.cc.Invoke(ctx, GoCryptoTraderService_WithdrawalEventByID_FullMethodName, in, out, opts...)
@@ -945,6 +1042,33 @@ func (c *goCryptoTraderServiceClient) GCTScriptAutoLoadToggle(ctx context.Contex
 	return out, nil
 }
 
+func (c *goCryptoTraderServiceClient) GCTScriptScheduleAdd(ctx context.Context, in *GCTScriptScheduleAddRequest, opts ...grpc.CallOption) (*GCTScriptSchedule, error) {
+	out := new(GCTScriptSchedule)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GCTScriptScheduleAdd_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GCTScriptScheduleRemove(ctx context.Context, in *GCTScriptScheduleRemoveRequest, opts ...grpc.CallOption) (*GenericResponse, error) {
+	out := new(GenericResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GCTScriptScheduleRemove_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GCTScriptScheduleList(ctx context.Context, in *GCTScriptScheduleListRequest, opts ...grpc.CallOption) (*GCTScriptScheduleListResponse, error) {
+	out := new(GCTScriptScheduleListResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GCTScriptScheduleList_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *goCryptoTraderServiceClient) GetHistoricCandles(ctx context.Context, in *GetHistoricCandlesRequest, opts ...grpc.CallOption) (*GetHistoricCandlesResponse, error) {
 	out := new(GetHistoricCandlesResponse)
 	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetHistoricCandles_FullMethodName, in, out, opts...)
@@ -1319,6 +1443,47 @@ func (c *goCryptoTraderServiceClient) GetAllManagedPositions(ctx context.Context
 	return out, nil
 }
 
+func (c *goCryptoTraderServiceClient) GetPortfolioGreeks(ctx context.Context, in *GetPortfolioGreeksRequest, opts ...grpc.CallOption) (*GetPortfolioGreeksResponse, error) {
+	out := new(GetPortfolioGreeksResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetPortfolioGreeks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) StreamFills(ctx context.Context, in *StreamFillsRequest, opts ...grpc.CallOption) (GoCryptoTraderService_StreamFillsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GoCryptoTraderService_ServiceDesc.Streams[6], GoCryptoTraderService_StreamFills_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &goCryptoTraderServiceStreamFillsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GoCryptoTraderService_StreamFillsClient interface {
+	Recv() (*FillResponse, error)
+	grpc.ClientStream
+}
+
+type goCryptoTraderServiceStreamFillsClient struct {
+	grpc.ClientStream
+}
+
+func (x *goCryptoTraderServiceStreamFillsClient) Recv() (*FillResponse, error) {
+	m := new(FillResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *goCryptoTraderServiceClient) GetFundingRates(ctx context.Context, in *GetFundingRatesRequest, opts ...grpc.CallOption) (*GetFundingRatesResponse, error) {
 	out := new(GetFundingRatesResponse)
 	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetFundingRates_FullMethodName, in, out, opts...)
@@ -1382,6 +1547,96 @@ func (c *goCryptoTraderServiceClient) GetLeverage(ctx context.Context, in *GetLe
 	return out, nil
 }
 
+func (c *goCryptoTraderServiceClient) GetMarginType(ctx context.Context, in *GetMarginTypeRequest, opts ...grpc.CallOption) (*GetMarginTypeResponse, error) {
+	out := new(GetMarginTypeResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetMarginType_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetExchangeCalendar(ctx context.Context, in *GetExchangeCalendarRequest, opts ...grpc.CallOption) (*GetExchangeCalendarResponse, error) {
+	out := new(GetExchangeCalendarResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetExchangeCalendar_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetVolumeProfile(ctx context.Context, in *GetVolumeProfileRequest, opts ...grpc.CallOption) (*GetVolumeProfileResponse, error) {
+	out := new(GetVolumeProfileResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetVolumeProfile_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetTransferNetworks(ctx context.Context, in *GetTransferNetworksRequest, opts ...grpc.CallOption) (*GetTransferNetworksResponse, error) {
+	out := new(GetTransferNetworksResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetTransferNetworks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetEarnProducts(ctx context.Context, in *GetEarnProductsRequest, opts ...grpc.CallOption) (*GetEarnProductsResponse, error) {
+	out := new(GetEarnProductsResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetEarnProducts_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetEarnPositions(ctx context.Context, in *GetEarnPositionsRequest, opts ...grpc.CallOption) (*GetEarnPositionsResponse, error) {
+	out := new(GetEarnPositionsResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetEarnPositions_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetEarnRewards(ctx context.Context, in *GetEarnRewardsRequest, opts ...grpc.CallOption) (*GetEarnRewardsResponse, error) {
+	out := new(GetEarnRewardsResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetEarnRewards_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetLendingOpportunities(ctx context.Context, in *GetLendingOpportunitiesRequest, opts ...grpc.CallOption) (*GetLendingOpportunitiesResponse, error) {
+	out := new(GetLendingOpportunitiesResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetLendingOpportunities_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetRPCCapabilities(ctx context.Context, in *GetRPCCapabilitiesRequest, opts ...grpc.CallOption) (*GetRPCCapabilitiesResponse, error) {
+	out := new(GetRPCCapabilitiesResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetRPCCapabilities_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetMemoryUsage(ctx context.Context, in *GetMemoryUsageRequest, opts ...grpc.CallOption) (*GetMemoryUsageResponse, error) {
+	out := new(GetMemoryUsageResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetMemoryUsage_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *goCryptoTraderServiceClient) SetCollateralMode(ctx context.Context, in *SetCollateralModeRequest, opts ...grpc.CallOption) (*SetCollateralModeResponse, error) {
 	out := new(SetCollateralModeResponse)
 	err := c.cc.Invoke(ctx, GoCryptoTraderService_SetCollateralMode_FullMethodName, in, out, opts...)
@@ -1418,6 +1673,60 @@ func (c *goCryptoTraderServiceClient) ChangePositionMargin(ctx context.Context,
 	return out, nil
 }
 
+func (c *goCryptoTraderServiceClient) ConvertCurrency(ctx context.Context, in *ConvertCurrencyRequest, opts ...grpc.CallOption) (*ConvertCurrencyResponse, error) {
+	out := new(ConvertCurrencyResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_ConvertCurrency_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) SweepDust(ctx context.Context, in *SweepDustRequest, opts ...grpc.CallOption) (*SweepDustResponse, error) {
+	out := new(SweepDustResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_SweepDust_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) SubmitOptionStrategy(ctx context.Context, in *SubmitOptionStrategyRequest, opts ...grpc.CallOption) (*SubmitOptionStrategyResponse, error) {
+	out := new(SubmitOptionStrategyResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_SubmitOptionStrategy_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) CaptureProfile(ctx context.Context, in *CaptureProfileRequest, opts ...grpc.CallOption) (*CaptureProfileResponse, error) {
+	out := new(CaptureProfileResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_CaptureProfile_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) SubscribeEarnProduct(ctx context.Context, in *SubscribeEarnProductRequest, opts ...grpc.CallOption) (*EarnResult, error) {
+	out := new(EarnResult)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_SubscribeEarnProduct_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) RedeemEarnProduct(ctx context.Context, in *RedeemEarnProductRequest, opts ...grpc.CallOption) (*EarnResult, error) {
+	out := new(EarnResult)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_RedeemEarnProduct_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *goCryptoTraderServiceClient) GetOpenInterest(ctx context.Context, in *GetOpenInterestRequest, opts ...grpc.CallOption) (*GetOpenInterestResponse, error) {
 	out := new(GetOpenInterestResponse)
 	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetOpenInterest_FullMethodName, in, out, opts...)
@@ -1427,6 +1736,128 @@ func (c *goCryptoTraderServiceClient) GetOpenInterest(ctx context.Context, in *G
 	return out, nil
 }
 
+func (c *goCryptoTraderServiceClient) GetScheduledTasks(ctx context.Context, in *GetScheduledTasksRequest, opts ...grpc.CallOption) (*GetScheduledTasksResponse, error) {
+	out := new(GetScheduledTasksResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetScheduledTasks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetRateLimitStatus(ctx context.Context, in *GetRateLimitStatusRequest, opts ...grpc.CallOption) (*GetRateLimitStatusResponse, error) {
+	out := new(GetRateLimitStatusResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetRateLimitStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetVolatilitySurface(ctx context.Context, in *GetVolatilitySurfaceRequest, opts ...grpc.CallOption) (*GetVolatilitySurfaceResponse, error) {
+	out := new(GetVolatilitySurfaceResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetVolatilitySurface_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetImpliedVolatility(ctx context.Context, in *GetImpliedVolatilityRequest, opts ...grpc.CallOption) (*GetImpliedVolatilityResponse, error) {
+	out := new(GetImpliedVolatilityResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetImpliedVolatility_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) SimulatePortfolioMargin(ctx context.Context, in *SimulatePortfolioMarginRequest, opts ...grpc.CallOption) (*SimulatePortfolioMarginResponse, error) {
+	out := new(SimulatePortfolioMarginResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_SimulatePortfolioMargin_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetDataQualityScores(ctx context.Context, in *GetDataQualityScoresRequest, opts ...grpc.CallOption) (*GetDataQualityScoresResponse, error) {
+	out := new(GetDataQualityScoresResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetDataQualityScores_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetDashboardSnapshot(ctx context.Context, in *GetDashboardSnapshotRequest, opts ...grpc.CallOption) (*GetDashboardSnapshotResponse, error) {
+	out := new(GetDashboardSnapshotResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetDashboardSnapshot_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetStreamedFundingRates(ctx context.Context, in *GetStreamedFundingRatesRequest, opts ...grpc.CallOption) (*GetStreamedFundingRatesResponse, error) {
+	out := new(GetStreamedFundingRatesResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetStreamedFundingRates_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetStreamedFundingRateHistory(ctx context.Context, in *GetStreamedFundingRateHistoryRequest, opts ...grpc.CallOption) (*GetStreamedFundingRateHistoryResponse, error) {
+	out := new(GetStreamedFundingRateHistoryResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetStreamedFundingRateHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) StreamFundingRates(ctx context.Context, in *StreamFundingRatesRequest, opts ...grpc.CallOption) (GoCryptoTraderService_StreamFundingRatesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GoCryptoTraderService_ServiceDesc.Streams[7], GoCryptoTraderService_StreamFundingRates_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &goCryptoTraderServiceStreamFundingRatesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GoCryptoTraderService_StreamFundingRatesClient interface {
+	Recv() (*StreamedFundingRate, error)
+	grpc.ClientStream
+}
+
+type goCryptoTraderServiceStreamFundingRatesClient struct {
+	grpc.ClientStream
+}
+
+func (x *goCryptoTraderServiceStreamFundingRatesClient) Recv() (*StreamedFundingRate, error) {
+	m := new(StreamedFundingRate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetVolatilityCorrelation(ctx context.Context, in *GetVolatilityCorrelationRequest, opts ...grpc.CallOption) (*GetVolatilityCorrelationResponse, error) {
+	out := new(GetVolatilityCorrelationResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetVolatilityCorrelation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GoCryptoTraderServiceServer is the server API for GoCryptoTraderService service.
 // All implementations must embed UnimplementedGoCryptoTraderServiceServer
 // for forward compatibility
@@ -1473,6 +1904,9 @@ type GoCryptoTraderServiceServer interface {
 	GetAvailableTransferChains(context.Context, *GetAvailableTransferChainsRequest) (*GetAvailableTransferChainsResponse, error)
 	WithdrawFiatFunds(context.Context, *WithdrawFiatRequest) (*WithdrawResponse, error)
 	WithdrawCryptocurrencyFunds(context.Context, *WithdrawCryptoRequest) (*WithdrawResponse, error)
+	GetWithdrawalSigningPayload(context.Context, *GetWithdrawalSigningPayloadRequest) (*GetWithdrawalSigningPayloadResponse, error)
+	SubmitSignedWithdrawal(context.Context, *SubmitSignedWithdrawalRequest) (*WithdrawResponse, error)
+	GetWithdrawalLocks(context.Context, *GetWithdrawalLocksRequest) (*GetWithdrawalLocksResponse, error)
 	WithdrawalEventByID(context.Context, *WithdrawalEventByIDRequest) (*WithdrawalEventByIDResponse, error)
 	WithdrawalEventsByExchange(context.Context, *WithdrawalEventsByExchangeRequest) (*WithdrawalEventsByExchangeResponse, error)
 	WithdrawalEventsByDate(context.Context, *WithdrawalEventsByDateRequest) (*WithdrawalEventsByExchangeResponse, error)
@@ -1494,6 +1928,9 @@ type GoCryptoTraderServiceServer interface {
 	GCTScriptStopAll(context.Context, *GCTScriptStopAllRequest) (*GenericResponse, error)
 	GCTScriptListAll(context.Context, *GCTScriptListAllRequest) (*GCTScriptStatusResponse, error)
 	GCTScriptAutoLoadToggle(context.Context, *GCTScriptAutoLoadRequest) (*GenericResponse, error)
+	GCTScriptScheduleAdd(context.Context, *GCTScriptScheduleAddRequest) (*GCTScriptSchedule, error)
+	GCTScriptScheduleRemove(context.Context, *GCTScriptScheduleRemoveRequest) (*GenericResponse, error)
+	GCTScriptScheduleList(context.Context, *GCTScriptScheduleListRequest) (*GCTScriptScheduleListResponse, error)
 	GetHistoricCandles(context.Context, *GetHistoricCandlesRequest) (*GetHistoricCandlesResponse, error)
 	SetExchangeAsset(context.Context, *SetExchangeAssetRequest) (*GenericResponse, error)
 	SetAllExchangePairs(context.Context, *SetExchangeAllPairsRequest) (*GenericResponse, error)
@@ -1533,6 +1970,8 @@ type GoCryptoTraderServiceServer interface {
 	GetMarginRatesHistory(context.Context, *GetMarginRatesHistoryRequest) (*GetMarginRatesHistoryResponse, error)
 	GetManagedPosition(context.Context, *GetManagedPositionRequest) (*GetManagedPositionsResponse, error)
 	GetAllManagedPositions(context.Context, *GetAllManagedPositionsRequest) (*GetManagedPositionsResponse, error)
+	GetPortfolioGreeks(context.Context, *GetPortfolioGreeksRequest) (*GetPortfolioGreeksResponse, error)
+	StreamFills(*StreamFillsRequest, GoCryptoTraderService_StreamFillsServer) error
 	GetFundingRates(context.Context, *GetFundingRatesRequest) (*GetFundingRatesResponse, error)
 	GetLatestFundingRate(context.Context, *GetLatestFundingRateRequest) (*GetLatestFundingRateResponse, error)
 	GetOrderbookMovement(context.Context, *GetOrderbookMovementRequest) (*GetOrderbookMovementResponse, error)
@@ -1540,11 +1979,38 @@ type GoCryptoTraderServiceServer interface {
 	GetOrderbookAmountByImpact(context.Context, *GetOrderbookAmountByImpactRequest) (*GetOrderbookAmountByImpactResponse, error)
 	GetCollateralMode(context.Context, *GetCollateralModeRequest) (*GetCollateralModeResponse, error)
 	GetLeverage(context.Context, *GetLeverageRequest) (*GetLeverageResponse, error)
+	GetMarginType(context.Context, *GetMarginTypeRequest) (*GetMarginTypeResponse, error)
+	GetExchangeCalendar(context.Context, *GetExchangeCalendarRequest) (*GetExchangeCalendarResponse, error)
+	GetVolumeProfile(context.Context, *GetVolumeProfileRequest) (*GetVolumeProfileResponse, error)
+	GetTransferNetworks(context.Context, *GetTransferNetworksRequest) (*GetTransferNetworksResponse, error)
+	GetEarnProducts(context.Context, *GetEarnProductsRequest) (*GetEarnProductsResponse, error)
+	GetEarnPositions(context.Context, *GetEarnPositionsRequest) (*GetEarnPositionsResponse, error)
+	GetEarnRewards(context.Context, *GetEarnRewardsRequest) (*GetEarnRewardsResponse, error)
+	GetLendingOpportunities(context.Context, *GetLendingOpportunitiesRequest) (*GetLendingOpportunitiesResponse, error)
+	GetRPCCapabilities(context.Context, *GetRPCCapabilitiesRequest) (*GetRPCCapabilitiesResponse, error)
+	GetMemoryUsage(context.Context, *GetMemoryUsageRequest) (*GetMemoryUsageResponse, error)
 	SetCollateralMode(context.Context, *SetCollateralModeRequest) (*SetCollateralModeResponse, error)
 	SetMarginType(context.Context, *SetMarginTypeRequest) (*SetMarginTypeResponse, error)
 	SetLeverage(context.Context, *SetLeverageRequest) (*SetLeverageResponse, error)
 	ChangePositionMargin(context.Context, *ChangePositionMarginRequest) (*ChangePositionMarginResponse, error)
+	ConvertCurrency(context.Context, *ConvertCurrencyRequest) (*ConvertCurrencyResponse, error)
+	SweepDust(context.Context, *SweepDustRequest) (*SweepDustResponse, error)
+	SubmitOptionStrategy(context.Context, *SubmitOptionStrategyRequest) (*SubmitOptionStrategyResponse, error)
+	CaptureProfile(context.Context, *CaptureProfileRequest) (*CaptureProfileResponse, error)
+	SubscribeEarnProduct(context.Context, *SubscribeEarnProductRequest) (*EarnResult, error)
+	RedeemEarnProduct(context.Context, *RedeemEarnProductRequest) (*EarnResult, error)
 	GetOpenInterest(context.Context, *GetOpenInterestRequest) (*GetOpenInterestResponse, error)
+	GetScheduledTasks(context.Context, *GetScheduledTasksRequest) (*GetScheduledTasksResponse, error)
+	GetRateLimitStatus(context.Context, *GetRateLimitStatusRequest) (*GetRateLimitStatusResponse, error)
+	GetVolatilitySurface(context.Context, *GetVolatilitySurfaceRequest) (*GetVolatilitySurfaceResponse, error)
+	GetImpliedVolatility(context.Context, *GetImpliedVolatilityRequest) (*GetImpliedVolatilityResponse, error)
+	SimulatePortfolioMargin(context.Context, *SimulatePortfolioMarginRequest) (*SimulatePortfolioMarginResponse, error)
+	GetDataQualityScores(context.Context, *GetDataQualityScoresRequest) (*GetDataQualityScoresResponse, error)
+	GetDashboardSnapshot(context.Context, *GetDashboardSnapshotRequest) (*GetDashboardSnapshotResponse, error)
+	GetStreamedFundingRates(context.Context, *GetStreamedFundingRatesRequest) (*GetStreamedFundingRatesResponse, error)
+	GetStreamedFundingRateHistory(context.Context, *GetStreamedFundingRateHistoryRequest) (*GetStreamedFundingRateHistoryResponse, error)
+	StreamFundingRates(*StreamFundingRatesRequest, GoCryptoTraderService_StreamFundingRatesServer) error
+	GetVolatilityCorrelation(context.Context, *GetVolatilityCorrelationRequest) (*GetVolatilityCorrelationResponse, error)
 	mustEmbedUnimplementedGoCryptoTraderServiceServer()
 }
 
@@ -1678,6 +2144,15 @@ func (UnimplementedGoCryptoTraderServiceServer) WithdrawFiatFunds(context.Contex
 func (UnimplementedGoCryptoTraderServiceServer) WithdrawCryptocurrencyFunds(context.Context, *WithdrawCryptoRequest) (*WithdrawResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method WithdrawCryptocurrencyFunds not implemented")
 }
+func (UnimplementedGoCryptoTraderServiceServer) GetWithdrawalSigningPayload(context.Context, *GetWithdrawalSigningPayloadRequest) (*GetWithdrawalSigningPayloadResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWithdrawalSigningPayload not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) SubmitSignedWithdrawal(context.Context, *SubmitSignedWithdrawalRequest) (*WithdrawResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitSignedWithdrawal not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetWithdrawalLocks(context.Context, *GetWithdrawalLocksRequest) (*GetWithdrawalLocksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWithdrawalLocks not implemented")
+}
 func (UnimplementedGoCryptoTraderServiceServer) WithdrawalEventByID(context.Context, *WithdrawalEventByIDRequest) (*WithdrawalEventByIDResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method WithdrawalEventByID not implemented")
 }
@@ -1741,6 +2216,15 @@ func (UnimplementedGoCryptoTraderServiceServer) GCTScriptListAll(context.Context
 func (UnimplementedGoCryptoTraderServiceServer) GCTScriptAutoLoadToggle(context.Context, *GCTScriptAutoLoadRequest) (*GenericResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GCTScriptAutoLoadToggle not implemented")
 }
+func (UnimplementedGoCryptoTraderServiceServer) GCTScriptScheduleAdd(context.Context, *GCTScriptScheduleAddRequest) (*GCTScriptSchedule, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GCTScriptScheduleAdd not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GCTScriptScheduleRemove(context.Context, *GCTScriptScheduleRemoveRequest) (*GenericResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GCTScriptScheduleRemove not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GCTScriptScheduleList(context.Context, *GCTScriptScheduleListRequest) (*GCTScriptScheduleListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GCTScriptScheduleList not implemented")
+}
 func (UnimplementedGoCryptoTraderServiceServer) GetHistoricCandles(context.Context, *GetHistoricCandlesRequest) (*GetHistoricCandlesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetHistoricCandles not implemented")
 }
@@ -1858,6 +2342,12 @@ func (UnimplementedGoCryptoTraderServiceServer) GetManagedPosition(context.Conte
 func (UnimplementedGoCryptoTraderServiceServer) GetAllManagedPositions(context.Context, *GetAllManagedPositionsRequest) (*GetManagedPositionsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAllManagedPositions not implemented")
 }
+func (UnimplementedGoCryptoTraderServiceServer) GetPortfolioGreeks(context.Context, *GetPortfolioGreeksRequest) (*GetPortfolioGreeksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPortfolioGreeks not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) StreamFills(*StreamFillsRequest, GoCryptoTraderService_StreamFillsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamFills not implemented")
+}
 func (UnimplementedGoCryptoTraderServiceServer) GetFundingRates(context.Context, *GetFundingRatesRequest) (*GetFundingRatesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetFundingRates not implemented")
 }
@@ -1879,6 +2369,36 @@ func (UnimplementedGoCryptoTraderServiceServer) GetCollateralMode(context.Contex
 func (UnimplementedGoCryptoTraderServiceServer) GetLeverage(context.Context, *GetLeverageRequest) (*GetLeverageResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetLeverage not implemented")
 }
+func (UnimplementedGoCryptoTraderServiceServer) GetMarginType(context.Context, *GetMarginTypeRequest) (*GetMarginTypeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMarginType not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetExchangeCalendar(context.Context, *GetExchangeCalendarRequest) (*GetExchangeCalendarResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetExchangeCalendar not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetVolumeProfile(context.Context, *GetVolumeProfileRequest) (*GetVolumeProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVolumeProfile not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetTransferNetworks(context.Context, *GetTransferNetworksRequest) (*GetTransferNetworksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTransferNetworks not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetEarnProducts(context.Context, *GetEarnProductsRequest) (*GetEarnProductsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEarnProducts not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetEarnPositions(context.Context, *GetEarnPositionsRequest) (*GetEarnPositionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEarnPositions not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetEarnRewards(context.Context, *GetEarnRewardsRequest) (*GetEarnRewardsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEarnRewards not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetLendingOpportunities(context.Context, *GetLendingOpportunitiesRequest) (*GetLendingOpportunitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLendingOpportunities not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetRPCCapabilities(context.Context, *GetRPCCapabilitiesRequest) (*GetRPCCapabilitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRPCCapabilities not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetMemoryUsage(context.Context, *GetMemoryUsageRequest) (*GetMemoryUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMemoryUsage not implemented")
+}
 func (UnimplementedGoCryptoTraderServiceServer) SetCollateralMode(context.Context, *SetCollateralModeRequest) (*SetCollateralModeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetCollateralMode not implemented")
 }
@@ -1891,9 +2411,60 @@ func (UnimplementedGoCryptoTraderServiceServer) SetLeverage(context.Context, *Se
 func (UnimplementedGoCryptoTraderServiceServer) ChangePositionMargin(context.Context, *ChangePositionMarginRequest) (*ChangePositionMarginResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ChangePositionMargin not implemented")
 }
+func (UnimplementedGoCryptoTraderServiceServer) ConvertCurrency(context.Context, *ConvertCurrencyRequest) (*ConvertCurrencyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConvertCurrency not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) SweepDust(context.Context, *SweepDustRequest) (*SweepDustResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SweepDust not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) SubmitOptionStrategy(context.Context, *SubmitOptionStrategyRequest) (*SubmitOptionStrategyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitOptionStrategy not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) CaptureProfile(context.Context, *CaptureProfileRequest) (*CaptureProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CaptureProfile not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) SubscribeEarnProduct(context.Context, *SubscribeEarnProductRequest) (*EarnResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubscribeEarnProduct not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) RedeemEarnProduct(context.Context, *RedeemEarnProductRequest) (*EarnResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedeemEarnProduct not implemented")
+}
 func (UnimplementedGoCryptoTraderServiceServer) GetOpenInterest(context.Context, *GetOpenInterestRequest) (*GetOpenInterestResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetOpenInterest not implemented")
 }
+func (UnimplementedGoCryptoTraderServiceServer) GetScheduledTasks(context.Context, *GetScheduledTasksRequest) (*GetScheduledTasksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetScheduledTasks not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetRateLimitStatus(context.Context, *GetRateLimitStatusRequest) (*GetRateLimitStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRateLimitStatus not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetVolatilitySurface(context.Context, *GetVolatilitySurfaceRequest) (*GetVolatilitySurfaceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVolatilitySurface not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetImpliedVolatility(context.Context, *GetImpliedVolatilityRequest) (*GetImpliedVolatilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetImpliedVolatility not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) SimulatePortfolioMargin(context.Context, *SimulatePortfolioMarginRequest) (*SimulatePortfolioMarginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SimulatePortfolioMargin not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetDataQualityScores(context.Context, *GetDataQualityScoresRequest) (*GetDataQualityScoresResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDataQualityScores not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetDashboardSnapshot(context.Context, *GetDashboardSnapshotRequest) (*GetDashboardSnapshotResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDashboardSnapshot not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetStreamedFundingRates(context.Context, *GetStreamedFundingRatesRequest) (*GetStreamedFundingRatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStreamedFundingRates not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetStreamedFundingRateHistory(context.Context, *GetStreamedFundingRateHistoryRequest) (*GetStreamedFundingRateHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStreamedFundingRateHistory not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) StreamFundingRates(*StreamFundingRatesRequest, GoCryptoTraderService_StreamFundingRatesServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamFundingRates not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetVolatilityCorrelation(context.Context, *GetVolatilityCorrelationRequest) (*GetVolatilityCorrelationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetVolatilityCorrelation not implemented")
+}
 func (UnimplementedGoCryptoTraderServiceServer) mustEmbedUnimplementedGoCryptoTraderServiceServer() {}
 
 // UnsafeGoCryptoTraderServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -2666,6 +3237,60 @@ func _GoCryptoTraderService_WithdrawCryptocurrencyFunds_Handler(srv interface{},
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GoCryptoTraderService_GetWithdrawalSigningPayload_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWithdrawalSigningPayloadRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetWithdrawalSigningPayload(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GetWithdrawalSigningPayload_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetWithdrawalSigningPayload(ctx, req.(*GetWithdrawalSigningPayloadRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_SubmitSignedWithdrawal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitSignedWithdrawalRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).SubmitSignedWithdrawal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_SubmitSignedWithdrawal_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).SubmitSignedWithdrawal(ctx, req.(*SubmitSignedWithdrawalRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetWithdrawalLocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetWithdrawalLocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetWithdrawalLocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GetWithdrawalLocks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetWithdrawalLocks(ctx, req.(*GetWithdrawalLocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GoCryptoTraderService_WithdrawalEventByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(WithdrawalEventByIDRequest)
 	if err := dec(in); err != nil {
@@ -3056,6 +3681,60 @@ func _GoCryptoTraderService_GCTScriptAutoLoadToggle_Handler(srv interface{}, ctx
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GoCryptoTraderService_GCTScriptScheduleAdd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GCTScriptScheduleAddRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GCTScriptScheduleAdd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GCTScriptScheduleAdd_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GCTScriptScheduleAdd(ctx, req.(*GCTScriptScheduleAddRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GCTScriptScheduleRemove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GCTScriptScheduleRemoveRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GCTScriptScheduleRemove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GCTScriptScheduleRemove_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GCTScriptScheduleRemove(ctx, req.(*GCTScriptScheduleRemoveRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GCTScriptScheduleList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GCTScriptScheduleListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GCTScriptScheduleList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GCTScriptScheduleList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GCTScriptScheduleList(ctx, req.(*GCTScriptScheduleListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _GoCryptoTraderService_GetHistoricCandles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetHistoricCandlesRequest)
 	if err := dec(in); err != nil {
@@ -3761,6 +4440,45 @@ func _GoCryptoTraderService_GetAllManagedPositions_Handler(srv interface{}, ctx
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GoCryptoTraderService_GetPortfolioGreeks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPortfolioGreeksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetPortfolioGreeks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GetPortfolioGreeks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetPortfolioGreeks(ctx, req.(*GetPortfolioGreeksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_StreamFills_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamFillsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GoCryptoTraderServiceServer).StreamFills(m, &goCryptoTraderServiceStreamFillsServer{stream})
+}
+
+type GoCryptoTraderService_StreamFillsServer interface {
+	Send(*FillResponse) error
+	grpc.ServerStream
+}
+
+type goCryptoTraderServiceStreamFillsServer struct {
+	grpc.ServerStream
+}
+
+func (x *goCryptoTraderServiceStreamFillsServer) Send(m *FillResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _GoCryptoTraderService_GetFundingRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetFundingRatesRequest)
 	if err := dec(in); err != nil {
@@ -3887,195 +4605,684 @@ func _GoCryptoTraderService_GetLeverage_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GoCryptoTraderService_SetCollateralMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetCollateralModeRequest)
+func _GoCryptoTraderService_GetMarginType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMarginTypeRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GoCryptoTraderServiceServer).SetCollateralMode(ctx, in)
+		return srv.(GoCryptoTraderServiceServer).GetMarginType(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GoCryptoTraderService_SetCollateralMode_FullMethodName,
+		FullMethod: GoCryptoTraderService_GetMarginType_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GoCryptoTraderServiceServer).SetCollateralMode(ctx, req.(*SetCollateralModeRequest))
+		return srv.(GoCryptoTraderServiceServer).GetMarginType(ctx, req.(*GetMarginTypeRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GoCryptoTraderService_SetMarginType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetMarginTypeRequest)
+func _GoCryptoTraderService_GetExchangeCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExchangeCalendarRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GoCryptoTraderServiceServer).SetMarginType(ctx, in)
+		return srv.(GoCryptoTraderServiceServer).GetExchangeCalendar(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GoCryptoTraderService_SetMarginType_FullMethodName,
+		FullMethod: GoCryptoTraderService_GetExchangeCalendar_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GoCryptoTraderServiceServer).SetMarginType(ctx, req.(*SetMarginTypeRequest))
+		return srv.(GoCryptoTraderServiceServer).GetExchangeCalendar(ctx, req.(*GetExchangeCalendarRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GoCryptoTraderService_SetLeverage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(SetLeverageRequest)
+func _GoCryptoTraderService_GetVolumeProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVolumeProfileRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GoCryptoTraderServiceServer).SetLeverage(ctx, in)
+		return srv.(GoCryptoTraderServiceServer).GetVolumeProfile(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GoCryptoTraderService_SetLeverage_FullMethodName,
+		FullMethod: GoCryptoTraderService_GetVolumeProfile_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GoCryptoTraderServiceServer).SetLeverage(ctx, req.(*SetLeverageRequest))
+		return srv.(GoCryptoTraderServiceServer).GetVolumeProfile(ctx, req.(*GetVolumeProfileRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GoCryptoTraderService_ChangePositionMargin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ChangePositionMarginRequest)
+func _GoCryptoTraderService_GetTransferNetworks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTransferNetworksRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GoCryptoTraderServiceServer).ChangePositionMargin(ctx, in)
+		return srv.(GoCryptoTraderServiceServer).GetTransferNetworks(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GoCryptoTraderService_ChangePositionMargin_FullMethodName,
+		FullMethod: GoCryptoTraderService_GetTransferNetworks_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GoCryptoTraderServiceServer).ChangePositionMargin(ctx, req.(*ChangePositionMarginRequest))
+		return srv.(GoCryptoTraderServiceServer).GetTransferNetworks(ctx, req.(*GetTransferNetworksRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _GoCryptoTraderService_GetOpenInterest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetOpenInterestRequest)
+func _GoCryptoTraderService_GetEarnProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEarnProductsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(GoCryptoTraderServiceServer).GetOpenInterest(ctx, in)
+		return srv.(GoCryptoTraderServiceServer).GetEarnProducts(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: GoCryptoTraderService_GetOpenInterest_FullMethodName,
+		FullMethod: GoCryptoTraderService_GetEarnProducts_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(GoCryptoTraderServiceServer).GetOpenInterest(ctx, req.(*GetOpenInterestRequest))
+		return srv.(GoCryptoTraderServiceServer).GetEarnProducts(ctx, req.(*GetEarnProductsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// GoCryptoTraderService_ServiceDesc is the grpc.ServiceDesc for GoCryptoTraderService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var GoCryptoTraderService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "gctrpc.GoCryptoTraderService",
-	HandlerType: (*GoCryptoTraderServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetInfo",
-			Handler:    _GoCryptoTraderService_GetInfo_Handler,
-		},
-		{
-			MethodName: "GetSubsystems",
-			Handler:    _GoCryptoTraderService_GetSubsystems_Handler,
-		},
-		{
-			MethodName: "EnableSubsystem",
-			Handler:    _GoCryptoTraderService_EnableSubsystem_Handler,
-		},
-		{
-			MethodName: "DisableSubsystem",
-			Handler:    _GoCryptoTraderService_DisableSubsystem_Handler,
-		},
-		{
-			MethodName: "GetRPCEndpoints",
-			Handler:    _GoCryptoTraderService_GetRPCEndpoints_Handler,
-		},
-		{
-			MethodName: "GetCommunicationRelayers",
-			Handler:    _GoCryptoTraderService_GetCommunicationRelayers_Handler,
-		},
-		{
-			MethodName: "GetExchanges",
-			Handler:    _GoCryptoTraderService_GetExchanges_Handler,
-		},
-		{
-			MethodName: "DisableExchange",
-			Handler:    _GoCryptoTraderService_DisableExchange_Handler,
-		},
-		{
-			MethodName: "GetExchangeInfo",
-			Handler:    _GoCryptoTraderService_GetExchangeInfo_Handler,
-		},
-		{
-			MethodName: "GetExchangeOTPCode",
-			Handler:    _GoCryptoTraderService_GetExchangeOTPCode_Handler,
-		},
-		{
-			MethodName: "GetExchangeOTPCodes",
-			Handler:    _GoCryptoTraderService_GetExchangeOTPCodes_Handler,
-		},
-		{
-			MethodName: "EnableExchange",
-			Handler:    _GoCryptoTraderService_EnableExchange_Handler,
-		},
-		{
-			MethodName: "GetTicker",
-			Handler:    _GoCryptoTraderService_GetTicker_Handler,
-		},
-		{
-			MethodName: "GetTickers",
-			Handler:    _GoCryptoTraderService_GetTickers_Handler,
-		},
-		{
-			MethodName: "GetOrderbook",
-			Handler:    _GoCryptoTraderService_GetOrderbook_Handler,
-		},
-		{
-			MethodName: "GetOrderbooks",
-			Handler:    _GoCryptoTraderService_GetOrderbooks_Handler,
-		},
-		{
-			MethodName: "GetAccountInfo",
-			Handler:    _GoCryptoTraderService_GetAccountInfo_Handler,
-		},
-		{
-			MethodName: "UpdateAccountInfo",
-			Handler:    _GoCryptoTraderService_UpdateAccountInfo_Handler,
-		},
-		{
-			MethodName: "GetConfig",
-			Handler:    _GoCryptoTraderService_GetConfig_Handler,
-		},
-		{
-			MethodName: "GetPortfolio",
-			Handler:    _GoCryptoTraderService_GetPortfolio_Handler,
-		},
-		{
-			MethodName: "GetPortfolioSummary",
-			Handler:    _GoCryptoTraderService_GetPortfolioSummary_Handler,
-		},
-		{
-			MethodName: "AddPortfolioAddress",
-			Handler:    _GoCryptoTraderService_AddPortfolioAddress_Handler,
-		},
-		{
-			MethodName: "RemovePortfolioAddress",
-			Handler:    _GoCryptoTraderService_RemovePortfolioAddress_Handler,
-		},
+func _GoCryptoTraderService_GetEarnPositions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEarnPositionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetEarnPositions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GetEarnPositions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetEarnPositions(ctx, req.(*GetEarnPositionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetEarnRewards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetEarnRewardsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetEarnRewards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GetEarnRewards_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetEarnRewards(ctx, req.(*GetEarnRewardsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetLendingOpportunities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLendingOpportunitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetLendingOpportunities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GetLendingOpportunities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetLendingOpportunities(ctx, req.(*GetLendingOpportunitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetRPCCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRPCCapabilitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetRPCCapabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GetRPCCapabilities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetRPCCapabilities(ctx, req.(*GetRPCCapabilitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetMemoryUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMemoryUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetMemoryUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GetMemoryUsage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetMemoryUsage(ctx, req.(*GetMemoryUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_SetCollateralMode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCollateralModeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).SetCollateralMode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_SetCollateralMode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).SetCollateralMode(ctx, req.(*SetCollateralModeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_SetMarginType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMarginTypeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).SetMarginType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_SetMarginType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).SetMarginType(ctx, req.(*SetMarginTypeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_SetLeverage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLeverageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).SetLeverage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_SetLeverage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).SetLeverage(ctx, req.(*SetLeverageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_ChangePositionMargin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChangePositionMarginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).ChangePositionMargin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_ChangePositionMargin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).ChangePositionMargin(ctx, req.(*ChangePositionMarginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_ConvertCurrency_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertCurrencyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).ConvertCurrency(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_ConvertCurrency_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).ConvertCurrency(ctx, req.(*ConvertCurrencyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_SweepDust_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SweepDustRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).SweepDust(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_SweepDust_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).SweepDust(ctx, req.(*SweepDustRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_SubmitOptionStrategy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitOptionStrategyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).SubmitOptionStrategy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_SubmitOptionStrategy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).SubmitOptionStrategy(ctx, req.(*SubmitOptionStrategyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_CaptureProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CaptureProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).CaptureProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_CaptureProfile_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).CaptureProfile(ctx, req.(*CaptureProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_SubscribeEarnProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubscribeEarnProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).SubscribeEarnProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_SubscribeEarnProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).SubscribeEarnProduct(ctx, req.(*SubscribeEarnProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_RedeemEarnProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedeemEarnProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).RedeemEarnProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_RedeemEarnProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).RedeemEarnProduct(ctx, req.(*RedeemEarnProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetOpenInterest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOpenInterestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetOpenInterest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GetOpenInterest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetOpenInterest(ctx, req.(*GetOpenInterestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetScheduledTasks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetScheduledTasksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetScheduledTasks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GetScheduledTasks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetScheduledTasks(ctx, req.(*GetScheduledTasksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetRateLimitStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRateLimitStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetRateLimitStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GetRateLimitStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetRateLimitStatus(ctx, req.(*GetRateLimitStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetVolatilitySurface_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVolatilitySurfaceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetVolatilitySurface(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GetVolatilitySurface_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetVolatilitySurface(ctx, req.(*GetVolatilitySurfaceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetImpliedVolatility_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetImpliedVolatilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetImpliedVolatility(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GetImpliedVolatility_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetImpliedVolatility(ctx, req.(*GetImpliedVolatilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_SimulatePortfolioMargin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimulatePortfolioMarginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).SimulatePortfolioMargin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_SimulatePortfolioMargin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).SimulatePortfolioMargin(ctx, req.(*SimulatePortfolioMarginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetDataQualityScores_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDataQualityScoresRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetDataQualityScores(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GetDataQualityScores_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetDataQualityScores(ctx, req.(*GetDataQualityScoresRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetDashboardSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDashboardSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetDashboardSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GetDashboardSnapshot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetDashboardSnapshot(ctx, req.(*GetDashboardSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetStreamedFundingRates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStreamedFundingRatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetStreamedFundingRates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GetStreamedFundingRates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetStreamedFundingRates(ctx, req.(*GetStreamedFundingRatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetStreamedFundingRateHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStreamedFundingRateHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetStreamedFundingRateHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GetStreamedFundingRateHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetStreamedFundingRateHistory(ctx, req.(*GetStreamedFundingRateHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_StreamFundingRates_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamFundingRatesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GoCryptoTraderServiceServer).StreamFundingRates(m, &goCryptoTraderServiceStreamFundingRatesServer{stream})
+}
+
+type GoCryptoTraderService_StreamFundingRatesServer interface {
+	Send(*StreamedFundingRate) error
+	grpc.ServerStream
+}
+
+type goCryptoTraderServiceStreamFundingRatesServer struct {
+	grpc.ServerStream
+}
+
+func (x *goCryptoTraderServiceStreamFundingRatesServer) Send(m *StreamedFundingRate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GoCryptoTraderService_GetVolatilityCorrelation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetVolatilityCorrelationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetVolatilityCorrelation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GetVolatilityCorrelation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetVolatilityCorrelation(ctx, req.(*GetVolatilityCorrelationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GoCryptoTraderService_ServiceDesc is the grpc.ServiceDesc for GoCryptoTraderService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GoCryptoTraderService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gctrpc.GoCryptoTraderService",
+	HandlerType: (*GoCryptoTraderServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetInfo",
+			Handler:    _GoCryptoTraderService_GetInfo_Handler,
+		},
+		{
+			MethodName: "GetSubsystems",
+			Handler:    _GoCryptoTraderService_GetSubsystems_Handler,
+		},
+		{
+			MethodName: "EnableSubsystem",
+			Handler:    _GoCryptoTraderService_EnableSubsystem_Handler,
+		},
+		{
+			MethodName: "DisableSubsystem",
+			Handler:    _GoCryptoTraderService_DisableSubsystem_Handler,
+		},
+		{
+			MethodName: "GetRPCEndpoints",
+			Handler:    _GoCryptoTraderService_GetRPCEndpoints_Handler,
+		},
+		{
+			MethodName: "GetCommunicationRelayers",
+			Handler:    _GoCryptoTraderService_GetCommunicationRelayers_Handler,
+		},
+		{
+			MethodName: "GetExchanges",
+			Handler:    _GoCryptoTraderService_GetExchanges_Handler,
+		},
+		{
+			MethodName: "DisableExchange",
+			Handler:    _GoCryptoTraderService_DisableExchange_Handler,
+		},
+		{
+			MethodName: "GetExchangeInfo",
+			Handler:    _GoCryptoTraderService_GetExchangeInfo_Handler,
+		},
+		{
+			MethodName: "GetExchangeOTPCode",
+			Handler:    _GoCryptoTraderService_GetExchangeOTPCode_Handler,
+		},
+		{
+			MethodName: "GetExchangeOTPCodes",
+			Handler:    _GoCryptoTraderService_GetExchangeOTPCodes_Handler,
+		},
+		{
+			MethodName: "EnableExchange",
+			Handler:    _GoCryptoTraderService_EnableExchange_Handler,
+		},
+		{
+			MethodName: "GetTicker",
+			Handler:    _GoCryptoTraderService_GetTicker_Handler,
+		},
+		{
+			MethodName: "GetTickers",
+			Handler:    _GoCryptoTraderService_GetTickers_Handler,
+		},
+		{
+			MethodName: "GetOrderbook",
+			Handler:    _GoCryptoTraderService_GetOrderbook_Handler,
+		},
+		{
+			MethodName: "GetOrderbooks",
+			Handler:    _GoCryptoTraderService_GetOrderbooks_Handler,
+		},
+		{
+			MethodName: "GetAccountInfo",
+			Handler:    _GoCryptoTraderService_GetAccountInfo_Handler,
+		},
+		{
+			MethodName: "UpdateAccountInfo",
+			Handler:    _GoCryptoTraderService_UpdateAccountInfo_Handler,
+		},
+		{
+			MethodName: "GetConfig",
+			Handler:    _GoCryptoTraderService_GetConfig_Handler,
+		},
+		{
+			MethodName: "GetPortfolio",
+			Handler:    _GoCryptoTraderService_GetPortfolio_Handler,
+		},
+		{
+			MethodName: "GetPortfolioSummary",
+			Handler:    _GoCryptoTraderService_GetPortfolioSummary_Handler,
+		},
+		{
+			MethodName: "AddPortfolioAddress",
+			Handler:    _GoCryptoTraderService_AddPortfolioAddress_Handler,
+		},
+		{
+			MethodName: "RemovePortfolioAddress",
+			Handler:    _GoCryptoTraderService_RemovePortfolioAddress_Handler,
+		},
 		{
 			MethodName: "GetForexProviders",
 			Handler:    _GoCryptoTraderService_GetForexProviders_Handler,
@@ -4148,6 +5355,18 @@ var GoCryptoTraderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "WithdrawCryptocurrencyFunds",
 			Handler:    _GoCryptoTraderService_WithdrawCryptocurrencyFunds_Handler,
 		},
+		{
+			MethodName: "GetWithdrawalSigningPayload",
+			Handler:    _GoCryptoTraderService_GetWithdrawalSigningPayload_Handler,
+		},
+		{
+			MethodName: "SubmitSignedWithdrawal",
+			Handler:    _GoCryptoTraderService_SubmitSignedWithdrawal_Handler,
+		},
+		{
+			MethodName: "GetWithdrawalLocks",
+			Handler:    _GoCryptoTraderService_GetWithdrawalLocks_Handler,
+		},
 		{
 			MethodName: "WithdrawalEventByID",
 			Handler:    _GoCryptoTraderService_WithdrawalEventByID_Handler,
@@ -4216,6 +5435,18 @@ var GoCryptoTraderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GCTScriptAutoLoadToggle",
 			Handler:    _GoCryptoTraderService_GCTScriptAutoLoadToggle_Handler,
 		},
+		{
+			MethodName: "GCTScriptScheduleAdd",
+			Handler:    _GoCryptoTraderService_GCTScriptScheduleAdd_Handler,
+		},
+		{
+			MethodName: "GCTScriptScheduleRemove",
+			Handler:    _GoCryptoTraderService_GCTScriptScheduleRemove_Handler,
+		},
+		{
+			MethodName: "GCTScriptScheduleList",
+			Handler:    _GoCryptoTraderService_GCTScriptScheduleList_Handler,
+		},
 		{
 			MethodName: "GetHistoricCandles",
 			Handler:    _GoCryptoTraderService_GetHistoricCandles_Handler,
@@ -4368,6 +5599,10 @@ var GoCryptoTraderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAllManagedPositions",
 			Handler:    _GoCryptoTraderService_GetAllManagedPositions_Handler,
 		},
+		{
+			MethodName: "GetPortfolioGreeks",
+			Handler:    _GoCryptoTraderService_GetPortfolioGreeks_Handler,
+		},
 		{
 			MethodName: "GetFundingRates",
 			Handler:    _GoCryptoTraderService_GetFundingRates_Handler,
@@ -4396,6 +5631,46 @@ var GoCryptoTraderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetLeverage",
 			Handler:    _GoCryptoTraderService_GetLeverage_Handler,
 		},
+		{
+			MethodName: "GetMarginType",
+			Handler:    _GoCryptoTraderService_GetMarginType_Handler,
+		},
+		{
+			MethodName: "GetExchangeCalendar",
+			Handler:    _GoCryptoTraderService_GetExchangeCalendar_Handler,
+		},
+		{
+			MethodName: "GetVolumeProfile",
+			Handler:    _GoCryptoTraderService_GetVolumeProfile_Handler,
+		},
+		{
+			MethodName: "GetTransferNetworks",
+			Handler:    _GoCryptoTraderService_GetTransferNetworks_Handler,
+		},
+		{
+			MethodName: "GetEarnProducts",
+			Handler:    _GoCryptoTraderService_GetEarnProducts_Handler,
+		},
+		{
+			MethodName: "GetEarnPositions",
+			Handler:    _GoCryptoTraderService_GetEarnPositions_Handler,
+		},
+		{
+			MethodName: "GetEarnRewards",
+			Handler:    _GoCryptoTraderService_GetEarnRewards_Handler,
+		},
+		{
+			MethodName: "GetLendingOpportunities",
+			Handler:    _GoCryptoTraderService_GetLendingOpportunities_Handler,
+		},
+		{
+			MethodName: "GetRPCCapabilities",
+			Handler:    _GoCryptoTraderService_GetRPCCapabilities_Handler,
+		},
+		{
+			MethodName: "GetMemoryUsage",
+			Handler:    _GoCryptoTraderService_GetMemoryUsage_Handler,
+		},
 		{
 			MethodName: "SetCollateralMode",
 			Handler:    _GoCryptoTraderService_SetCollateralMode_Handler,
@@ -4412,10 +5687,74 @@ var GoCryptoTraderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ChangePositionMargin",
 			Handler:    _GoCryptoTraderService_ChangePositionMargin_Handler,
 		},
+		{
+			MethodName: "ConvertCurrency",
+			Handler:    _GoCryptoTraderService_ConvertCurrency_Handler,
+		},
+		{
+			MethodName: "SweepDust",
+			Handler:    _GoCryptoTraderService_SweepDust_Handler,
+		},
+		{
+			MethodName: "SubmitOptionStrategy",
+			Handler:    _GoCryptoTraderService_SubmitOptionStrategy_Handler,
+		},
+		{
+			MethodName: "CaptureProfile",
+			Handler:    _GoCryptoTraderService_CaptureProfile_Handler,
+		},
+		{
+			MethodName: "SubscribeEarnProduct",
+			Handler:    _GoCryptoTraderService_SubscribeEarnProduct_Handler,
+		},
+		{
+			MethodName: "RedeemEarnProduct",
+			Handler:    _GoCryptoTraderService_RedeemEarnProduct_Handler,
+		},
 		{
 			MethodName: "GetOpenInterest",
 			Handler:    _GoCryptoTraderService_GetOpenInterest_Handler,
 		},
+		{
+			MethodName: "GetScheduledTasks",
+			Handler:    _GoCryptoTraderService_GetScheduledTasks_Handler,
+		},
+		{
+			MethodName: "GetRateLimitStatus",
+			Handler:    _GoCryptoTraderService_GetRateLimitStatus_Handler,
+		},
+		{
+			MethodName: "GetVolatilitySurface",
+			Handler:    _GoCryptoTraderService_GetVolatilitySurface_Handler,
+		},
+		{
+			MethodName: "GetImpliedVolatility",
+			Handler:    _GoCryptoTraderService_GetImpliedVolatility_Handler,
+		},
+		{
+			MethodName: "SimulatePortfolioMargin",
+			Handler:    _GoCryptoTraderService_SimulatePortfolioMargin_Handler,
+		},
+		{
+			MethodName: "GetDataQualityScores",
+			Handler:    _GoCryptoTraderService_GetDataQualityScores_Handler,
+		},
+		{
+			MethodName: "GetDashboardSnapshot",
+			Handler:    _GoCryptoTraderService_GetDashboardSnapshot_Handler,
+		},
+		{
+			MethodName: "GetStreamedFundingRates",
+			Handler:    _GoCryptoTraderService_GetStreamedFundingRates_Handler,
+		},
+		{
+			MethodName: "GetStreamedFundingRateHistory",
+			Handler:    _GoCryptoTraderService_GetStreamedFundingRateHistory_Handler,
+		},
+		{
+			MethodName: "GetVolatilityCorrelation",
+			Handler:    _GoCryptoTraderService_GetVolatilityCorrelation_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -4448,6 +5787,16 @@ var GoCryptoTraderService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _GoCryptoTraderService_GetHistoricTrades_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "StreamFills",
+			Handler:       _GoCryptoTraderService_StreamFills_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StreamFundingRates",
+			Handler:       _GoCryptoTraderService_StreamFundingRates_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "rpc.proto",
 }
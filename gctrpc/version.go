@@ -0,0 +1,21 @@
+package gctrpc
+
+// APIVersion is the version of the GoCryptoTraderService API served by this
+// build. It is incremented when methods are removed or change incompatibly
+const APIVersion = 1
+
+// MinimumAPIVersion is the oldest API version a client may request
+const MinimumAPIVersion = 1
+
+// Metadata keys used to negotiate the API version and to signal deprecated
+// methods. Clients may send APIVersionMetadataKey to request a version, the
+// server returns it in the response headers of every call along with the
+// deprecation keys when the method called is deprecated. The gRPC proxy
+// forwards these as Grpc-Metadata- prefixed HTTP headers
+const (
+	APIVersionMetadataKey        = "gct-api-version"
+	DeprecatedMetadataKey        = "gct-deprecated"
+	DeprecationNoticeMetadataKey = "gct-deprecation-notice"
+	RemovalVersionMetadataKey    = "gct-removal-version"
+	ReplacementMetadataKey       = "gct-replacement"
+)
@@ -2,6 +2,7 @@ package vm
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/gofrs/uuid"
 	"github.com/thrasher-corp/gocryptotrader/common"
@@ -85,3 +86,73 @@ func (g *GctScriptManager) RemoveVM(id uuid.UUID) error {
 	}
 	return nil
 }
+
+// Reload atomically replaces a loaded Virtual Machine with a freshly loaded
+// copy of its script, handing over its state bag, and runs it. The existing
+// VM keeps running if the updated script fails to load or compile
+func (g *GctScriptManager) Reload(id uuid.UUID) (*VM, error) {
+	v, ok := AllVMSync.Load(id)
+	if !ok {
+		return nil, fmt.Errorf(ErrNoVMFound, id.String())
+	}
+	oldVM, ok := v.(*VM)
+	if !ok {
+		return nil, common.GetTypeAssertError("*VM", v)
+	}
+	newVM := g.NewVM()
+	if newVM == nil {
+		return nil, ErrNoVMLoaded
+	}
+	err := newVM.Load(oldVM.File)
+	if err != nil {
+		return nil, err
+	}
+	err = newVM.Compile()
+	if err != nil {
+		return nil, Error{Script: oldVM.ShortName(), Action: "Reload", Cause: err}
+	}
+
+	// hand over state after shutdown so that the final run of the old VM is kept
+	err = oldVM.Shutdown()
+	if err != nil {
+		return nil, err
+	}
+	oldVM.handOverState(newVM)
+	VMSCount.add()
+	AllVMSync.Store(newVM.ID, newVM)
+	newVM.event(StatusSuccess, TypeReload)
+	newVM.run()
+	return newVM, nil
+}
+
+// reloadChanged reloads any Virtual Machines whose script has been modified
+// since it was loaded
+func (g *GctScriptManager) reloadChanged() {
+	var changed []*VM
+	AllVMSync.Range(func(_, v interface{}) bool {
+		vm, ok := v.(*VM)
+		if !ok || vm.File == "" {
+			return true
+		}
+		info, err := os.Stat(vm.File)
+		if err != nil {
+			return true
+		}
+		if info.ModTime().After(vm.modTime) {
+			changed = append(changed, vm)
+		}
+		return true
+	})
+	for _, vm := range changed {
+		newVM, err := g.Reload(vm.ID)
+		if err != nil {
+			log.Errorf(log.GCTScriptMgr, "Failed to reload script %s: %v", vm.ShortName(), err)
+			// prevent the same broken revision from being retried every interval
+			if info, statErr := os.Stat(vm.File); statErr == nil {
+				vm.modTime = info.ModTime()
+			}
+			continue
+		}
+		log.Infof(log.GCTScriptMgr, "Reloaded script %s ID: %v", newVM.ShortName(), newVM.ID)
+	}
+}
@@ -3,12 +3,17 @@ package vm
 import (
 	"errors"
 	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/common/cron"
 )
 
 const (
 	gctScript = "GCT Script"
 	// ErrScriptFailedValidation message to display when a script fails its validation
 	ErrScriptFailedValidation = "validation failed"
+	// scheduleCheckInterval is how often scheduled scripts are checked
+	scheduleCheckInterval = time.Second
 )
 
 // Config user configurable options for gctscript
@@ -19,6 +24,39 @@ type Config struct {
 	AllowImports       bool          `json:"allow_imports"`
 	AutoLoad           []string      `json:"auto_load"`
 	Verbose            bool          `json:"verbose"`
+	HotReload          bool          `json:"hot_reload"`
+	HotReloadInterval  time.Duration `json:"hot_reload_interval"`
+	Schedules          []Schedule    `json:"schedules"`
+}
+
+// Schedule defines a script to be executed on a cron-style schedule
+type Schedule struct {
+	Script string `json:"script"`
+	// Schedule is a five field cron expression, a descriptor such as @daily
+	// or a fixed interval such as "@every 5m"
+	Schedule string `json:"schedule"`
+	// Timeout overrides the default script timeout when set
+	Timeout time.Duration `json:"timeout"`
+}
+
+// ScheduledScript tracks the state of a scheduled script
+type ScheduledScript struct {
+	ID        uuid.UUID
+	Script    string
+	Schedule  string
+	Timeout   time.Duration
+	NextRun   time.Time
+	LastRun   time.Time
+	LastError string
+	Running   bool
+	// Skipped counts executions skipped as the previous run had not finished
+	Skipped int64
+}
+
+// scheduled holds a parsed schedule and its run state
+type scheduled struct {
+	schedule *cron.Schedule
+	details  ScheduledScript
 }
 
 // Error interface to meet error requirements
@@ -38,4 +76,10 @@ var (
 	ErrScriptingDisabled = errors.New("scripting is disabled")
 	// ErrNoVMLoaded error message displayed if a virtual machine has not been initialised
 	ErrNoVMLoaded = errors.New("no virtual machine loaded")
+	// ErrScheduleNotFound is returned when a scheduled script cannot be found
+	ErrScheduleNotFound = errors.New("schedule not found")
+
+	errScriptNotFound   = errors.New("script not found")
+	errNegativeTimeout  = errors.New("schedule timeout cannot be negative")
+	errScheduleNeverRun = errors.New("schedule will never run")
 )
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"sync"
 	"sync/atomic"
+	"time"
 
+	"github.com/gofrs/uuid"
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/log"
 )
@@ -21,9 +23,12 @@ var ErrNilSubsystem = errors.New("gct script has not been set up")
 
 // GctScriptManager loads and runs GCT Tengo scripts
 type GctScriptManager struct {
-	config   *Config
-	started  int32
-	shutdown chan struct{}
+	config      *Config
+	started     int32
+	shutdown    chan struct{}
+	scheduleMtx sync.Mutex
+	schedules   map[uuid.UUID]*scheduled
+	scheduleWG  sync.WaitGroup
 	// Optional values to override stored config ('nil' if not overridden)
 	MaxVirtualMachines *uint8
 }
@@ -77,9 +82,32 @@ func (g *GctScriptManager) run(wg *sync.WaitGroup) {
 
 	SetDefaultScriptOutput()
 	g.autoLoad()
+	g.loadSchedules()
 	defer wg.Done()
 
-	<-g.shutdown
+	var reload <-chan time.Time
+	if g.config.HotReload {
+		interval := g.config.HotReloadInterval
+		if interval <= 0 {
+			interval = DefaultHotReloadInterval
+		}
+		reloadTicker := time.NewTicker(interval)
+		defer reloadTicker.Stop()
+		reload = reloadTicker.C
+	}
+	scheduleTicker := time.NewTicker(scheduleCheckInterval)
+	defer scheduleTicker.Stop()
+	for {
+		select {
+		case <-g.shutdown:
+			g.scheduleWG.Wait()
+			return
+		case <-reload:
+			g.reloadChanged()
+		case t := <-scheduleTicker.C:
+			g.runDueSchedules(t)
+		}
+	}
 }
 
 // GetMaxVirtualMachines returns the max number of VMs to create
@@ -0,0 +1,202 @@
+package vm
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/cron"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// AddSchedule registers a script to be executed on a cron-style schedule and
+// stores it in config so that it is restored on restart
+func (g *GctScriptManager) AddSchedule(s Schedule) (*ScheduledScript, error) {
+	details, err := g.addSchedule(s, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	g.scheduleMtx.Lock()
+	g.config.Schedules = append(g.config.Schedules, s)
+	g.scheduleMtx.Unlock()
+	if g.config.Verbose {
+		log.Debugf(log.GCTScriptMgr, "Scheduled script: %s schedule: %s ID: %v", details.Script, details.Schedule, details.ID)
+	}
+	return details, nil
+}
+
+// RemoveSchedule removes a scheduled script. A run already in progress is
+// allowed to complete
+func (g *GctScriptManager) RemoveSchedule(id uuid.UUID) error {
+	g.scheduleMtx.Lock()
+	defer g.scheduleMtx.Unlock()
+	s, ok := g.schedules[id]
+	if !ok {
+		return fmt.Errorf("%w: %v", ErrScheduleNotFound, id)
+	}
+	delete(g.schedules, id)
+	for x := range g.config.Schedules {
+		if scriptFileName(g.config.Schedules[x].Script) == s.details.Script &&
+			g.config.Schedules[x].Schedule == s.details.Schedule &&
+			g.config.Schedules[x].Timeout == s.details.Timeout {
+			g.config.Schedules = append(g.config.Schedules[:x], g.config.Schedules[x+1:]...)
+			break
+		}
+	}
+	if g.config.Verbose {
+		log.Debugf(log.GCTScriptMgr, "Removed scheduled script: %s ID: %v", s.details.Script, id)
+	}
+	return nil
+}
+
+// GetSchedules returns all scheduled scripts ordered by their next run time
+func (g *GctScriptManager) GetSchedules() []ScheduledScript {
+	g.scheduleMtx.Lock()
+	resp := make([]ScheduledScript, 0, len(g.schedules))
+	for _, s := range g.schedules {
+		resp = append(resp, s.details)
+	}
+	g.scheduleMtx.Unlock()
+	slices.SortFunc(resp, func(a, b ScheduledScript) int {
+		return a.NextRun.Compare(b.NextRun)
+	})
+	return resp
+}
+
+func (g *GctScriptManager) addSchedule(s Schedule, now time.Time) (*ScheduledScript, error) {
+	if s.Timeout < 0 {
+		return nil, errNegativeTimeout
+	}
+	name := scriptFileName(s.Script)
+	if _, err := os.Stat(filepath.Join(ScriptPath, name)); err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %v", errScriptNotFound, name)
+		}
+		return nil, err
+	}
+	schedule, err := cron.Parse(s.Schedule)
+	if err != nil {
+		return nil, err
+	}
+	next := schedule.Next(now)
+	if next.IsZero() {
+		return nil, fmt.Errorf("%w: %s", errScheduleNeverRun, s.Schedule)
+	}
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	sched := &scheduled{
+		schedule: schedule,
+		details: ScheduledScript{
+			ID:       id,
+			Script:   name,
+			Schedule: schedule.String(),
+			Timeout:  s.Timeout,
+			NextRun:  next,
+		},
+	}
+	g.scheduleMtx.Lock()
+	if g.schedules == nil {
+		g.schedules = make(map[uuid.UUID]*scheduled)
+	}
+	g.schedules[id] = sched
+	g.scheduleMtx.Unlock()
+	details := sched.details
+	return &details, nil
+}
+
+// loadSchedules registers all schedules stored in config
+func (g *GctScriptManager) loadSchedules() {
+	for x := range g.config.Schedules {
+		if _, err := g.addSchedule(g.config.Schedules[x], time.Now()); err != nil {
+			log.Errorf(log.GCTScriptMgr, "Unable to schedule script %s: %v", g.config.Schedules[x].Script, err)
+		}
+	}
+}
+
+// runDueSchedules executes all scheduled scripts due at or before t. A script
+// still running from its previous trigger is skipped to prevent overlapping
+// executions
+func (g *GctScriptManager) runDueSchedules(t time.Time) {
+	g.scheduleMtx.Lock()
+	defer g.scheduleMtx.Unlock()
+	for _, s := range g.schedules {
+		if t.Before(s.details.NextRun) {
+			continue
+		}
+		s.details.NextRun = s.schedule.Next(t)
+		if s.details.Running {
+			s.details.Skipped++
+			log.Warnf(log.GCTScriptMgr, "Scheduled script %s ID: %v skipped as previous run has not completed", s.details.Script, s.details.ID)
+			continue
+		}
+		s.details.Running = true
+		s.details.LastRun = t
+		g.scheduleWG.Add(1)
+		go g.executeSchedule(s)
+	}
+}
+
+// executeSchedule runs a single execution of a scheduled script and records
+// the result
+func (g *GctScriptManager) executeSchedule(s *scheduled) {
+	defer g.scheduleWG.Done()
+	g.scheduleMtx.Lock()
+	script, timeout := s.details.Script, s.details.Timeout
+	g.scheduleMtx.Unlock()
+
+	err := g.runScheduledScript(script, timeout)
+	if err != nil {
+		log.Errorf(log.GCTScriptMgr, "Scheduled script %s failed: %v", script, err)
+	}
+
+	g.scheduleMtx.Lock()
+	s.details.Running = false
+	s.details.LastError = ""
+	if err != nil {
+		s.details.LastError = err.Error()
+	}
+	g.scheduleMtx.Unlock()
+}
+
+// RunScript executes a script once outside of its configured schedules
+func (g *GctScriptManager) RunScript(script string, timeout time.Duration) error {
+	if !g.IsRunning() {
+		return ErrScriptingDisabled
+	}
+	return g.runScheduledScript(scriptFileName(script), timeout)
+}
+
+// runScheduledScript loads, compiles and executes a script once. Repeat timers
+// defined by the script are ignored as execution is driven by the schedule
+func (g *GctScriptManager) runScheduledScript(script string, timeout time.Duration) error {
+	vm := g.New()
+	if vm == nil {
+		return ErrNoVMLoaded
+	}
+	vm.timeout = timeout
+	err := vm.Load(filepath.Join(ScriptPath, script))
+	if err == nil {
+		err = vm.Compile()
+	}
+	if err == nil {
+		err = vm.RunCtx()
+	}
+	if shutdownErr := vm.Shutdown(); shutdownErr != nil && err == nil {
+		err = shutdownErr
+	}
+	return err
+}
+
+// scriptFileName returns the script name with the GCT script extension
+func scriptFileName(name string) string {
+	if filepath.Ext(name) != common.GctExt {
+		name += common.GctExt
+	}
+	return name
+}
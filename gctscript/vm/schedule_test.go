@@ -0,0 +1,124 @@
+package vm
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/common/cron"
+)
+
+func TestAddSchedule(t *testing.T) {
+	ScriptPath = filepath.Join("..", "..", "testdata", "gctscript")
+	g := &GctScriptManager{config: configHelper(true, true, maxTestVirtualMachines)}
+
+	_, err := g.AddSchedule(Schedule{Script: "once", Schedule: "* * * * *", Timeout: -1})
+	assert.ErrorIs(t, err, errNegativeTimeout)
+
+	_, err = g.AddSchedule(Schedule{Script: "missing", Schedule: "* * * * *"})
+	assert.ErrorIs(t, err, errScriptNotFound)
+
+	_, err = g.AddSchedule(Schedule{Script: "once", Schedule: "61 * * * *"})
+	assert.ErrorIs(t, err, cron.ErrInvalidSchedule)
+
+	_, err = g.AddSchedule(Schedule{Script: "once", Schedule: "0 0 31 2 *"})
+	assert.ErrorIs(t, err, errScheduleNeverRun)
+
+	s, err := g.AddSchedule(Schedule{Script: "once", Schedule: "@every 1h", Timeout: time.Second})
+	require.NoError(t, err)
+	assert.Equal(t, "once.gct", s.Script)
+	assert.Equal(t, time.Second, s.Timeout)
+	assert.True(t, s.NextRun.After(time.Now()), "NextRun should be in the future")
+	assert.Len(t, g.config.Schedules, 1, "AddSchedule should store the schedule in config")
+	assert.Len(t, g.GetSchedules(), 1)
+}
+
+func TestRemoveSchedule(t *testing.T) {
+	ScriptPath = filepath.Join("..", "..", "testdata", "gctscript")
+	g := &GctScriptManager{config: configHelper(true, true, maxTestVirtualMachines)}
+
+	err := g.RemoveSchedule(uuid.Nil)
+	assert.ErrorIs(t, err, ErrScheduleNotFound)
+
+	s, err := g.AddSchedule(Schedule{Script: "once", Schedule: "@hourly"})
+	require.NoError(t, err)
+	err = g.RemoveSchedule(s.ID)
+	require.NoError(t, err)
+	assert.Empty(t, g.config.Schedules, "RemoveSchedule should remove the schedule from config")
+	assert.Empty(t, g.GetSchedules())
+}
+
+func TestLoadSchedules(t *testing.T) {
+	ScriptPath = filepath.Join("..", "..", "testdata", "gctscript")
+	cfg := configHelper(true, true, maxTestVirtualMachines)
+	cfg.Schedules = []Schedule{
+		{Script: "once", Schedule: "*/5 * * * *"},
+		{Script: "missing", Schedule: "*/5 * * * *"},
+	}
+	g := &GctScriptManager{config: cfg}
+	g.loadSchedules()
+	assert.Len(t, g.GetSchedules(), 1, "loadSchedules should only load valid schedules")
+	assert.Len(t, g.config.Schedules, 2, "loadSchedules should not modify config")
+}
+
+func TestRunDueSchedules(t *testing.T) {
+	ScriptPath = filepath.Join("..", "..", "testdata", "gctscript")
+	g := &GctScriptManager{config: configHelper(true, true, maxTestVirtualMachines), started: 1}
+
+	ok, err := g.AddSchedule(Schedule{Script: "once", Schedule: "@every 1m"})
+	require.NoError(t, err)
+	broken, err := g.AddSchedule(Schedule{Script: "broken", Schedule: "@every 1m"})
+	require.NoError(t, err)
+
+	g.runDueSchedules(time.Now())
+	g.scheduleWG.Wait()
+	for _, s := range g.GetSchedules() {
+		assert.Zero(t, s.LastRun, "schedules should not run before they are due")
+	}
+
+	due := ok.NextRun
+	g.runDueSchedules(due)
+	g.scheduleWG.Wait()
+	for _, s := range g.GetSchedules() {
+		assert.Equal(t, due, s.LastRun)
+		assert.False(t, s.Running)
+		assert.True(t, s.NextRun.After(due), "NextRun should be advanced")
+		switch s.ID {
+		case ok.ID:
+			assert.Empty(t, s.LastError)
+		case broken.ID:
+			assert.NotEmpty(t, s.LastError, "failed runs should record an error")
+		}
+	}
+	assert.Zero(t, VMSCount.Len(), "scheduled Virtual Machines should be shutdown after each run")
+
+	g.scheduleMtx.Lock()
+	g.schedules[ok.ID].details.Running = true
+	g.scheduleMtx.Unlock()
+	g.runDueSchedules(due.Add(time.Hour))
+	g.scheduleWG.Wait()
+	g.scheduleMtx.Lock()
+	assert.Equal(t, int64(1), g.schedules[ok.ID].details.Skipped, "overlapping runs should be skipped")
+	assert.Equal(t, due, g.schedules[ok.ID].details.LastRun, "overlapping runs should not execute")
+	g.scheduleMtx.Unlock()
+}
+
+func TestRunScheduledScriptTimeout(t *testing.T) {
+	ScriptPath = filepath.Join("..", "..", "testdata", "gctscript")
+	g := &GctScriptManager{config: configHelper(true, true, maxTestVirtualMachines), started: 1}
+	err := g.runScheduledScript("once.gct", time.Nanosecond)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestRunScript(t *testing.T) {
+	ScriptPath = filepath.Join("..", "..", "testdata", "gctscript")
+	g := &GctScriptManager{config: configHelper(true, true, maxTestVirtualMachines)}
+	assert.ErrorIs(t, g.RunScript("once", time.Second), ErrScriptingDisabled, "RunScript should error when not running")
+
+	g.started = 1
+	assert.NoError(t, g.RunScript("once", time.Second), "RunScript should not error")
+}
@@ -81,6 +81,11 @@ func (vm *VM) Load(file string) error {
 	if err != nil {
 		return &Error{Action: "Load: ReadFile", Script: file, Cause: err}
 	}
+	info, err := os.Stat(file)
+	if err != nil {
+		return &Error{Action: "Load: Stat", Script: file, Cause: err}
+	}
+	vm.modTime = info.ModTime()
 
 	vm.File = file
 	vm.Path = filepath.Dir(file)
@@ -96,6 +101,16 @@ func (vm *VM) Load(file string) error {
 		return err
 	}
 
+	vm.stateMtx.Lock()
+	if vm.state == nil {
+		vm.state = &tengo.Map{Value: make(map[string]tengo.Object)}
+	}
+	err = vm.Script.Add("state", vm.state)
+	vm.stateMtx.Unlock()
+	if err != nil {
+		return err
+	}
+
 	vm.Script.SetImports(loader.GetModuleMap())
 	vm.Hash = vm.getHash()
 
@@ -117,7 +132,11 @@ func (vm *VM) Compile() (err error) {
 
 // RunCtx runs compiled byte code with context.Context support.
 func (vm *VM) RunCtx() (err error) {
-	ctx, cancel := context.WithTimeout(context.Background(), vm.config.ScriptTimeout)
+	timeout := vm.config.ScriptTimeout
+	if vm.timeout > 0 {
+		timeout = vm.timeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
 	if vm.config.Verbose {
@@ -132,10 +151,52 @@ func (vm *VM) RunCtx() (err error) {
 		vm.event(StatusFailure, TypeExecute)
 		return Error{Action: "RunCtx", Cause: err}
 	}
+	vm.captureState()
 	vm.event(StatusSuccess, TypeExecute)
 	return nil
 }
 
+// captureState stores the script's state global so that it is retained if the
+// script reassigned it rather than mutating it in place
+func (vm *VM) captureState() {
+	m, ok := vm.Compiled.Get("state").Object().(*tengo.Map)
+	if !ok {
+		return
+	}
+	vm.stateMtx.Lock()
+	vm.state = m
+	vm.stateMtx.Unlock()
+}
+
+// State returns a copy of the VM's state bag
+func (vm *VM) State() map[string]interface{} {
+	vm.stateMtx.Lock()
+	defer vm.stateMtx.Unlock()
+	if vm.state == nil {
+		return nil
+	}
+	resp := make(map[string]interface{}, len(vm.state.Value))
+	for k, v := range vm.state.Value {
+		resp[k] = tengo.ToInterface(v)
+	}
+	return resp
+}
+
+// handOverState copies the VM's state bag into the supplied VM
+func (vm *VM) handOverState(to *VM) {
+	vm.stateMtx.Lock()
+	defer vm.stateMtx.Unlock()
+	to.stateMtx.Lock()
+	defer to.stateMtx.Unlock()
+	if vm.state == nil || to.state == nil {
+		return
+	}
+	to.state.Value = make(map[string]tengo.Object, len(vm.state.Value))
+	for k, v := range vm.state.Value {
+		to.state.Value[k] = v.Copy()
+	}
+}
+
 // CompileAndRun Compile and Run script with support for task running
 func (vm *VM) CompileAndRun() {
 	if vm == nil {
@@ -151,7 +212,13 @@ func (vm *VM) CompileAndRun() {
 		return
 	}
 
-	err = vm.RunCtx()
+	vm.run()
+}
+
+// run executes compiled byte code and starts the runner if the script has a
+// repeat timer, otherwise the VM is shutdown
+func (vm *VM) run() {
+	err := vm.RunCtx()
 	if err != nil {
 		log.Errorln(log.GCTScriptMgr, err)
 		err = vm.unregister()
@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -25,6 +27,7 @@ var (
 	testScriptRunner1s       = filepath.Join("..", "..", "testdata", "gctscript", "1s_timer.gct")
 	testScriptRunnerNegative = filepath.Join("..", "..", "testdata", "gctscript", "negative_timer.gct")
 	testScriptRunnerInvalid  = filepath.Join("..", "..", "testdata", "gctscript", "invalid_timer.gct")
+	testScriptState          = filepath.Join("..", "..", "testdata", "gctscript", "state_timer.gct")
 )
 
 func TestNewVM(t *testing.T) {
@@ -569,6 +572,42 @@ func TestVMCount(t *testing.T) {
 	}
 }
 
+func TestReload(t *testing.T) {
+	manager := GctScriptManager{
+		config:  configHelper(true, true, maxTestVirtualMachines),
+		started: 1,
+	}
+	_, err := manager.Reload(uuid.Nil)
+	assert.ErrorContains(t, err, "not found")
+
+	oldVM := manager.New()
+	require.NotNil(t, oldVM, "New must return a VM")
+	require.NoError(t, oldVM.Load(testScriptState))
+	require.NoError(t, oldVM.Compile())
+	require.NoError(t, oldVM.RunCtx())
+	assert.Equal(t, int64(1), oldVM.State()["count"])
+
+	newVM, err := manager.Reload(oldVM.ID)
+	require.NoError(t, err)
+	assert.NotEqual(t, oldVM.ID, newVM.ID)
+	_, ok := AllVMSync.Load(oldVM.ID)
+	assert.False(t, ok, "old VM should be removed")
+	assert.Equal(t, int64(2), newVM.State()["count"], "state should be handed over and updated by the new VM")
+
+	// force a reload via the file watcher
+	newVM.modTime = time.Time{}
+	manager.reloadChanged()
+	_, ok = AllVMSync.Load(newVM.ID)
+	assert.False(t, ok, "changed VM should be replaced")
+	AllVMSync.Range(func(_, v interface{}) bool {
+		if vm, ok := v.(*VM); ok && vm.File == newVM.File {
+			assert.Equal(t, int64(3), vm.State()["count"], "state should be handed over by the file watcher")
+			assert.NoError(t, vm.Shutdown())
+		}
+		return true
+	})
+}
+
 func configHelper(enabled, imports bool, max uint8) *Config {
 	return &Config{
 		Enabled:            enabled,
@@ -13,6 +13,8 @@ const (
 	DefaultTimeoutValue = 30 * time.Second
 	// DefaultMaxVirtualMachines max number of virtual machines that can be loaded at one time
 	DefaultMaxVirtualMachines uint8 = 10
+	// DefaultHotReloadInterval default interval to check loaded scripts for changes
+	DefaultHotReloadInterval = 5 * time.Second
 
 	// TypeLoad text to display in script_event table when a VM is loaded
 	TypeLoad = "load"
@@ -24,6 +26,8 @@ const (
 	TypeStop = "stop"
 	// TypeRead text to display in script_event table when a script contents is read
 	TypeRead = "read"
+	// TypeReload text to display in script_event table when a script is hot reloaded
+	TypeReload = "reload"
 
 	// StatusSuccess text to display in script_event table on successful execution
 	StatusSuccess = "success"
@@ -54,4 +58,11 @@ type VM struct {
 	S          chan struct{}
 	config     *Config
 	unregister func() error
+	// state is the key/value bag exposed to scripts as the "state" global
+	// it is handed over to the replacement VM when a script is reloaded
+	state    *tengo.Map
+	stateMtx sync.Mutex
+	modTime  time.Time
+	// timeout overrides the configured script timeout when set
+	timeout time.Duration
 }
@@ -7,6 +7,7 @@ import (
 	"log"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"strings"
 	"sync"
 	"testing"
@@ -17,6 +18,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/config"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/mock"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/sharedtestvalues"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/subscription"
 )
@@ -75,6 +77,33 @@ func MockHTTPInstance(e exchange.IBotExchange) error {
 	return nil
 }
 
+// FixtureRecordEnv is the environment variable which, when set, re-records REST
+// fixtures against live exchange endpoints instead of replaying them
+const FixtureRecordEnv = "GCT_RECORD_FIXTURES"
+
+// FixtureHTTPInstance attaches a REST fixture transport to an exchange so its
+// endpoints can be tested hermetically. Recorded responses are replayed from
+// path, unless FixtureRecordEnv is set, in which case requests are sent to the
+// exchange and re-recorded when the test completes, failing the test if the
+// structure of any response has drifted from the previous recording
+func FixtureHTTPInstance(tb testing.TB, e exchange.IBotExchange, path string) {
+	tb.Helper()
+	mode := request.FixtureReplay
+	if os.Getenv(FixtureRecordEnv) != "" {
+		mode = request.FixtureRecord
+	}
+	ft, err := request.NewFixtureTransport(path, mode, nil)
+	require.NoError(tb, err, "NewFixtureTransport must not error")
+	err = e.GetBase().SetHTTPClient(&http.Client{Transport: ft})
+	require.NoError(tb, err, "SetHTTPClient must not error")
+	if mode == request.FixtureRecord {
+		tb.Cleanup(func() {
+			assert.Empty(tb, ft.Drift(), "REST responses should not drift from the previous recording")
+			assert.NoError(tb, ft.Save(), "Save should not error")
+		})
+	}
+}
+
 var upgrader = websocket.Upgrader{}
 
 // WsMockFunc is a websocket handler to be called with each websocket message
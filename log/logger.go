@@ -35,7 +35,7 @@ func CloseLogger() error {
 	globalLogConfig.Enabled = convert.BoolPtr(false)
 	jobsChannel <- &job{Passback: ch}
 	<-ch
-	return globalLogFile.Close()
+	return errors.Join(globalLogFile.Close(), closeRemoteWriters())
 }
 
 // Level retrieves the current sublogger levels
@@ -80,7 +80,11 @@ func loggerWorker() {
 		for x := range j.Writers {
 			// NOTE: byte slice is not copied, this is a pointer to the buffer.
 			// This is only safe if the buffer is not modified after this point.
-			n, err = j.Writers[x].Write(buffer)
+			if lw, ok := j.Writers[x].(levelWriter); ok {
+				n, err = lw.WriteLevel(j.Severity, j.SubLoggerName, buffer)
+			} else {
+				n, err = j.Writers[x].Write(buffer)
+			}
 			if err != nil {
 				displayError(fmt.Errorf("%T %w", j.Writers[x], err))
 			} else if n != len(buffer) {
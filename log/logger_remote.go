@@ -0,0 +1,317 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	errRemoteLoggingNotConfigured = errors.New("remote logging not configured")
+	errUnsupportedSyslogNetwork   = errors.New("unsupported syslog network")
+	errLokiURLIsEmpty             = errors.New("loki url is empty")
+	errLokiPushFailed             = errors.New("loki push failed")
+	errLokiEntriesDropped         = errors.New("loki entries dropped while endpoint unavailable")
+)
+
+// Syslog severities, the facility is always user
+const (
+	syslogUser    = 1
+	syslogError   = 3
+	syslogWarning = 4
+	syslogInfo    = 6
+	syslogDebug   = 7
+)
+
+// newSyslogWriter returns a syslog writer with defaults applied to any unset
+// settings
+func newSyslogWriter(c *SyslogConfig) (*syslogWriter, error) {
+	w := &syslogWriter{
+		network: strings.ToLower(c.Network),
+		address: c.Address,
+		tag:     c.Tag,
+		pid:     os.Getpid(),
+	}
+	switch w.network {
+	case "":
+		w.network = defaultSyslogNetwork
+	case "udp", "tcp", "unix", "unixgram":
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnsupportedSyslogNetwork, c.Network)
+	}
+	if w.address == "" {
+		w.address = defaultSyslogAddress
+	}
+	if w.tag == "" {
+		w.tag = defaultSyslogTag
+	}
+	var err error
+	if w.hostname, err = os.Hostname(); err != nil || w.hostname == "" {
+		w.hostname = "-"
+	}
+	return w, nil
+}
+
+// Write writes the entry at the info severity
+func (s *syslogWriter) Write(p []byte) (int, error) {
+	return s.WriteLevel("info", "", p)
+}
+
+// WriteLevel writes the entry to the syslog server, reconnecting once if the
+// write fails
+func (s *syslogWriter) WriteLevel(level, subLogger string, p []byte) (int, error) {
+	msg := s.format(level, subLogger, p)
+	s.m.Lock()
+	defer s.m.Unlock()
+	var err error
+	for range 2 {
+		if s.conn == nil {
+			if s.conn, err = net.DialTimeout(s.network, s.address, remoteTimeout); err != nil {
+				s.conn = nil
+				return 0, err
+			}
+		}
+		if _, err = s.conn.Write(msg); err == nil {
+			return len(p), nil
+		}
+		_ = s.conn.Close()
+		s.conn = nil
+	}
+	return 0, err
+}
+
+// format returns the entry as an RFC 5424 message with the sub logger as the
+// message ID. Stream connections are newline framed
+func (s *syslogWriter) format(level, subLogger string, p []byte) []byte {
+	if subLogger == "" {
+		subLogger = "-"
+	}
+	msg := make([]byte, 0, len(p)+128)
+	msg = append(msg, '<')
+	msg = strconv.AppendInt(msg, int64(syslogUser*8+syslogSeverity(level)), 10)
+	msg = append(msg, ">1 "...)
+	msg = time.Now().AppendFormat(msg, time.RFC3339Nano)
+	msg = append(msg, ' ')
+	msg = append(msg, s.hostname...)
+	msg = append(msg, ' ')
+	msg = append(msg, s.tag...)
+	msg = append(msg, ' ')
+	msg = strconv.AppendInt(msg, int64(s.pid), 10)
+	msg = append(msg, ' ')
+	msg = append(msg, subLogger...)
+	msg = append(msg, " - "...)
+	msg = append(msg, bytes.TrimRight(p, "\n")...)
+	if s.network == "tcp" || s.network == "unix" {
+		msg = append(msg, '\n')
+	}
+	return msg
+}
+
+// Close closes the connection to the syslog server
+func (s *syslogWriter) Close() error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+func syslogSeverity(level string) int {
+	switch level {
+	case "error":
+		return syslogError
+	case "warn":
+		return syslogWarning
+	case "debug":
+		return syslogDebug
+	default:
+		return syslogInfo
+	}
+}
+
+// newLokiWriter returns a Loki writer with defaults applied to any unset
+// settings and starts its flushing routine
+func newLokiWriter(c *LokiConfig, botName string) (*lokiWriter, error) {
+	if c.URL == "" {
+		return nil, errLokiURLIsEmpty
+	}
+	u, err := url.Parse(c.URL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = lokiPushPath
+	}
+	w := &lokiWriter{
+		url:       u.String(),
+		labels:    c.Labels,
+		batchSize: c.BatchSize,
+		username:  c.Username,
+		password:  c.Password,
+		botName:   botName,
+		client:    &http.Client{Timeout: remoteTimeout},
+		flush:     make(chan struct{}, 1),
+		shutdown:  make(chan struct{}),
+	}
+	if w.batchSize <= 0 {
+		w.batchSize = defaultLokiBatchSize
+	}
+	interval := c.FlushInterval
+	if interval <= 0 {
+		interval = defaultLokiFlushInterval
+	}
+	w.wg.Add(1)
+	go w.run(interval)
+	return w, nil
+}
+
+// Write queues the entry at the info level
+func (l *lokiWriter) Write(p []byte) (int, error) {
+	return l.WriteLevel("info", "", p)
+}
+
+// WriteLevel queues the entry to be pushed with the next batch. The oldest
+// entries are dropped when the endpoint falls too far behind
+func (l *lokiWriter) WriteLevel(level, subLogger string, p []byte) (int, error) {
+	l.m.Lock()
+	defer l.m.Unlock()
+	if limit := l.batchSize * maxLokiBatches; len(l.pending) >= limit {
+		drop := len(l.pending) - limit + 1
+		l.pending = append(l.pending[:0], l.pending[drop:]...)
+		l.dropped += drop
+	}
+	l.pending = append(l.pending, lokiEntry{
+		timestamp: time.Now(),
+		level:     level,
+		subLogger: subLogger,
+		line:      string(bytes.TrimRight(p, "\n")),
+	})
+	if len(l.pending) >= l.batchSize {
+		select {
+		case l.flush <- struct{}{}:
+		default:
+		}
+	}
+	return len(p), nil
+}
+
+// run pushes the pending entries when a batch fills or the interval elapses,
+// pushing any remaining entries on shutdown
+func (l *lokiWriter) run(interval time.Duration) {
+	defer l.wg.Done()
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-l.shutdown:
+			displayError(l.push())
+			return
+		case <-t.C:
+		case <-l.flush:
+		}
+		displayError(l.push())
+	}
+}
+
+// push sends the pending entries to Loki, requeueing them on failure
+func (l *lokiWriter) push() error {
+	l.m.Lock()
+	batch := l.pending
+	l.pending = nil
+	dropped := l.dropped
+	l.dropped = 0
+	l.m.Unlock()
+	if len(batch) == 0 {
+		return nil
+	}
+
+	err := l.send(batch)
+	if err != nil {
+		l.m.Lock()
+		l.pending = append(batch, l.pending...)
+		if limit := l.batchSize * maxLokiBatches; len(l.pending) > limit {
+			dropped += len(l.pending) - limit
+			l.pending = l.pending[len(l.pending)-limit:]
+		}
+		l.dropped += dropped
+		l.m.Unlock()
+		return err
+	}
+	if dropped > 0 {
+		return fmt.Errorf("%w: %d", errLokiEntriesDropped, dropped)
+	}
+	return nil
+}
+
+// send pushes the entries to Loki grouped into streams by sub logger and level
+func (l *lokiWriter) send(entries []lokiEntry) error {
+	var payload lokiPush
+	streams := make(map[[2]string]int)
+	for i := range entries {
+		key := [2]string{entries[i].subLogger, entries[i].level}
+		idx, ok := streams[key]
+		if !ok {
+			labels := make(map[string]string, len(l.labels)+3)
+			for k, v := range l.labels {
+				labels[k] = v
+			}
+			if l.botName != "" {
+				labels["bot"] = l.botName
+			}
+			if entries[i].subLogger != "" {
+				labels["sublogger"] = entries[i].subLogger
+			}
+			labels["level"] = entries[i].level
+			idx = len(payload.Streams)
+			streams[key] = idx
+			payload.Streams = append(payload.Streams, lokiStream{Stream: labels})
+		}
+		payload.Streams[idx].Values = append(payload.Streams[idx].Values, [2]string{
+			strconv.FormatInt(entries[i].timestamp.UnixNano(), 10),
+			entries[i].line,
+		})
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), remoteTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, l.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if l.username != "" {
+		req.SetBasicAuth(l.username, l.password)
+	}
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w: %s", errLokiPushFailed, resp.Status)
+	}
+	return nil
+}
+
+// Close pushes any pending entries and stops the flushing routine
+func (l *lokiWriter) Close() error {
+	close(l.shutdown)
+	l.wg.Wait()
+	return nil
+}
@@ -0,0 +1,127 @@
+package log
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyslogWriter(t *testing.T) {
+	t.Parallel()
+	_, err := newSyslogWriter(&SyslogConfig{Network: "carrier pigeon"})
+	assert.ErrorIs(t, err, errUnsupportedSyslogNetwork)
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	w, err := newSyslogWriter(&SyslogConfig{Address: conn.LocalAddr().String()})
+	require.NoError(t, err)
+	assert.Equal(t, defaultSyslogNetwork, w.network)
+	assert.Equal(t, defaultSyslogTag, w.tag)
+
+	n, err := w.WriteLevel("warn", "ORDER", []byte("[WARN] | order rejected\n"))
+	require.NoError(t, err)
+	assert.Equal(t, len("[WARN] | order rejected\n"), n, "should report the length of the entry written")
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(time.Second*5)))
+	buf := make([]byte, 1024)
+	n, _, err = conn.ReadFrom(buf)
+	require.NoError(t, err)
+	msg := string(buf[:n])
+	assert.True(t, strings.HasPrefix(msg, "<12>1 "), "should be prefixed with the user facility and warning severity")
+	assert.True(t, strings.HasSuffix(msg, " gocryptotrader "+strconv.Itoa(w.pid)+" ORDER - [WARN] | order rejected"), "should hold the tag, pid, sub logger and entry")
+	require.NoError(t, w.Close())
+}
+
+func TestSyslogSeverity(t *testing.T) {
+	t.Parallel()
+	for level, severity := range map[string]int{
+		"error": syslogError,
+		"warn":  syslogWarning,
+		"info":  syslogInfo,
+		"debug": syslogDebug,
+		"":      syslogInfo,
+	} {
+		assert.Equalf(t, severity, syslogSeverity(level), "syslogSeverity should return the correct severity for %q", level)
+	}
+}
+
+func TestLokiWriter(t *testing.T) {
+	t.Parallel()
+	_, err := newLokiWriter(&LokiConfig{}, "")
+	assert.ErrorIs(t, err, errLokiURLIsEmpty)
+
+	var m sync.Mutex
+	var pushes []lokiPush
+	fail := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, lokiPushPath, r.URL.Path)
+		m.Lock()
+		defer m.Unlock()
+		if fail {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var p lokiPush
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&p))
+		pushes = append(pushes, p)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	w, err := newLokiWriter(&LokiConfig{URL: srv.URL, Labels: map[string]string{"env": "test"}, BatchSize: 10, FlushInterval: time.Hour}, "bot")
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, w.Close()) }()
+
+	_, err = w.WriteLevel("info", "ORDER", []byte("one\n"))
+	require.NoError(t, err)
+	_, err = w.Write([]byte("two\n"))
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, w.push(), errLokiPushFailed)
+	w.m.Lock()
+	assert.Len(t, w.pending, 2, "failed pushes should be requeued")
+	w.m.Unlock()
+	m.Lock()
+	fail = false
+	m.Unlock()
+	require.NoError(t, w.push())
+
+	m.Lock()
+	defer m.Unlock()
+	require.Len(t, pushes, 1)
+	require.Len(t, pushes[0].Streams, 2, "entries should be grouped by sub logger and level")
+	assert.Equal(t, map[string]string{"env": "test", "bot": "bot", "sublogger": "ORDER", "level": "info"}, pushes[0].Streams[0].Stream)
+	require.Len(t, pushes[0].Streams[0].Values, 1)
+	assert.Equal(t, "one", pushes[0].Streams[0].Values[0][1], "trailing newlines should be trimmed")
+}
+
+func TestLokiWriterDropsOldest(t *testing.T) {
+	t.Parallel()
+	w := &lokiWriter{batchSize: 1, flush: make(chan struct{}, 1)}
+	for range maxLokiBatches + 2 {
+		_, err := w.WriteLevel("info", "", []byte("entry"))
+		require.NoError(t, err)
+	}
+	assert.Len(t, w.pending, maxLokiBatches, "pending entries should be capped")
+	assert.Equal(t, 2, w.dropped, "dropped entries should be counted")
+	assert.Len(t, w.flush, 1, "a full batch should signal a flush")
+}
+
+func TestGetWritersRemote(t *testing.T) {
+	t.Parallel()
+	err := getWritersProtected(&SubLoggerConfig{Output: "console|syslog"})
+	assert.ErrorIs(t, err, errRemoteLoggingNotConfigured)
+	err = getWritersProtected(&SubLoggerConfig{Output: "loki"})
+	assert.ErrorIs(t, err, errRemoteLoggingNotConfigured)
+}
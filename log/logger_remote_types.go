@@ -0,0 +1,100 @@
+package log
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultSyslogNetwork     = "udp"
+	defaultSyslogAddress     = "localhost:514"
+	defaultSyslogTag         = "gocryptotrader"
+	defaultLokiBatchSize     = 100
+	defaultLokiFlushInterval = time.Second * 5
+	lokiPushPath             = "/loki/api/v1/push"
+	remoteTimeout            = time.Second * 10
+	// maxLokiBatches is the number of batches held while the Loki endpoint is
+	// unavailable before the oldest entries are dropped
+	maxLokiBatches = 10
+)
+
+// RemoteConfig holds the settings for shipping logs to remote destinations.
+// Sub loggers ship to a destination by including syslog or loki in their
+// output
+type RemoteConfig struct {
+	Syslog *SyslogConfig `json:"syslog,omitempty"`
+	Loki   *LokiConfig   `json:"loki,omitempty"`
+}
+
+// SyslogConfig holds the settings for shipping logs to a syslog server
+type SyslogConfig struct {
+	// Network is udp, tcp or unix
+	Network string `json:"network"`
+	Address string `json:"address"`
+	Tag     string `json:"tag"`
+}
+
+// LokiConfig holds the settings for shipping logs to a Loki endpoint
+type LokiConfig struct {
+	URL string `json:"url"`
+	// Labels are added to every stream alongside the bot, sublogger and level
+	Labels        map[string]string `json:"labels,omitempty"`
+	BatchSize     int               `json:"batchSize"`
+	FlushInterval time.Duration     `json:"flushInterval"`
+	Username      string            `json:"username,omitempty"`
+	Password      string            `json:"password,omitempty"`
+}
+
+// levelWriter is implemented by writers which record the severity and sub
+// logger of each entry
+type levelWriter interface {
+	WriteLevel(level, subLogger string, p []byte) (int, error)
+}
+
+// syslogWriter writes log entries to a syslog server in the RFC 5424 format,
+// connecting on first write and reconnecting after a failed write
+type syslogWriter struct {
+	network  string
+	address  string
+	tag      string
+	hostname string
+	pid      int
+	m        sync.Mutex
+	conn     net.Conn
+}
+
+// lokiEntry is a log line awaiting shipping to Loki
+type lokiEntry struct {
+	timestamp time.Time
+	level     string
+	subLogger string
+	line      string
+}
+
+// lokiWriter batches log entries and pushes them to a Loki endpoint
+type lokiWriter struct {
+	url       string
+	labels    map[string]string
+	batchSize int
+	username  string
+	password  string
+	botName   string
+	client    *http.Client
+	m         sync.Mutex
+	pending   []lokiEntry
+	dropped   int
+	flush     chan struct{}
+	shutdown  chan struct{}
+	wg        sync.WaitGroup
+}
+
+type lokiPush struct {
+	Streams []lokiStream `json:"streams"`
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
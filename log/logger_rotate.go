@@ -1,10 +1,14 @@
 package log
 
 import (
+	"compress/gzip"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common/file"
@@ -32,7 +36,7 @@ func (r *Rotate) Write(output []byte) (n int, err error) {
 	}
 
 	if *r.Rotate {
-		if r.size+outputLen > r.maxSize() {
+		if r.size+outputLen > r.maxSize() || r.intervalElapsed(time.Now()) {
 			err = r.rotateFile()
 			if err != nil {
 				return 0, err
@@ -56,7 +60,8 @@ func (r *Rotate) openOrCreateFile(n int64) error {
 	}
 
 	if *r.Rotate {
-		if info.Size()+n >= r.maxSize() {
+		r.boundary = r.intervalBoundary(info.ModTime())
+		if info.Size()+n >= r.maxSize() || r.intervalElapsed(time.Now()) {
 			return r.rotateFile()
 		}
 	}
@@ -80,13 +85,15 @@ func (r *Rotate) openNew() error {
 	_, err := os.Stat(name)
 
 	if err == nil {
-		timestamp := time.Now().Format("2006-01-02T15-04-05")
+		timestamp := time.Now().Format(rotatedTimestampFormat)
 		newName := filepath.Join(GetLogPath(), timestamp+"-"+r.FileName)
 
 		err = file.Move(name, newName)
 		if err != nil {
 			return fmt.Errorf("can't rename log file: %s", err)
 		}
+		r.wg.Add(1)
+		go r.processRotated(newName)
 	}
 
 	file, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
@@ -96,6 +103,7 @@ func (r *Rotate) openNew() error {
 
 	r.output = file
 	r.size = 0
+	r.boundary = r.intervalBoundary(time.Now())
 	return nil
 }
 
@@ -108,9 +116,11 @@ func (r *Rotate) close() (err error) {
 	return err
 }
 
-// Close handler for open file
+// Close handler for open file, waits for rotated files to be processed
 func (r *Rotate) Close() error {
-	return r.close()
+	err := r.close()
+	r.wg.Wait()
+	return err
 }
 
 func (r *Rotate) rotateFile() (err error) {
@@ -127,3 +137,102 @@ func (r *Rotate) maxSize() int64 {
 	}
 	return r.MaxSize * megabyte
 }
+
+// intervalBoundary returns the start of the rotation interval containing t
+func (r *Rotate) intervalBoundary(t time.Time) time.Time {
+	if r.RotateInterval <= 0 {
+		return time.Time{}
+	}
+	return t.Truncate(r.RotateInterval)
+}
+
+// intervalElapsed returns whether t is past the rotation interval the current
+// file was written in
+func (r *Rotate) intervalElapsed(t time.Time) bool {
+	return r.RotateInterval > 0 && !r.intervalBoundary(t).Equal(r.boundary)
+}
+
+// processRotated compresses a rotated file and removes the oldest rotated
+// files over the backup limit. Errors are displayed as the logger cannot log
+// its own failures
+func (r *Rotate) processRotated(path string) {
+	defer r.wg.Done()
+	r.processing.Lock()
+	defer r.processing.Unlock()
+	if r.Compress {
+		displayError(compressFile(path))
+	}
+	displayError(r.removeOldBackups())
+}
+
+// compressFile gzips the file and removes the original
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(path+compressedExtension, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(dst)
+	_, err = io.Copy(gz, src)
+	if closeErr := gz.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return errors.Join(fmt.Errorf("can't compress log file: %w", err), os.Remove(path+compressedExtension))
+	}
+	if err := src.Close(); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
+
+// removeOldBackups removes the oldest rotated files so at most MaxBackups
+// remain
+func (r *Rotate) removeOldBackups() error {
+	if r.MaxBackups <= 0 {
+		return nil
+	}
+	backups, err := r.rotatedFiles()
+	if err != nil {
+		return err
+	}
+	if len(backups) <= r.MaxBackups {
+		return nil
+	}
+	var errs error
+	for _, backup := range backups[:len(backups)-r.MaxBackups] {
+		errs = errors.Join(errs, os.Remove(backup))
+	}
+	return errs
+}
+
+// rotatedFiles returns the rotated files for the log file ordered from oldest
+// to newest
+func (r *Rotate) rotatedFiles() ([]string, error) {
+	entries, err := os.ReadDir(GetLogPath())
+	if err != nil {
+		return nil, err
+	}
+	suffix := "-" + r.FileName
+	var backups []string
+	for _, entry := range entries {
+		name := strings.TrimSuffix(entry.Name(), compressedExtension)
+		if entry.IsDir() || !strings.HasSuffix(name, suffix) {
+			continue
+		}
+		if _, err := time.Parse(rotatedTimestampFormat, strings.TrimSuffix(name, suffix)); err != nil {
+			continue
+		}
+		backups = append(backups, filepath.Join(GetLogPath(), entry.Name()))
+	}
+	// Timestamp prefixes sort chronologically
+	sort.Strings(backups)
+	return backups, nil
+}
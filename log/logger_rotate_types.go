@@ -2,11 +2,16 @@ package log
 
 import (
 	"os"
+	"sync"
+	"time"
 )
 
 const (
 	defaultMaxSize int64 = 250
 	megabyte       int64 = 1024 * 1024
+
+	rotatedTimestampFormat = "2006-01-02T15-04-05"
+	compressedExtension    = ".gz"
 )
 
 // Rotate struct for each instance of Rotate
@@ -14,7 +19,21 @@ type Rotate struct {
 	FileName string
 	Rotate   *bool
 	MaxSize  int64
+	// RotateInterval rotates the file when the current time crosses an
+	// interval boundary, e.g. 24h rotates at midnight UTC. Zero disables time
+	// based rotation
+	RotateInterval time.Duration
+	// Compress gzips rotated files
+	Compress bool
+	// MaxBackups is the number of rotated files kept, the oldest are removed.
+	// Zero keeps all rotated files
+	MaxBackups int
 
-	size   int64
-	output *os.File
+	size     int64
+	boundary time.Time
+	output   *os.File
+	// wg tracks the compression and removal of rotated files, which is
+	// serialised by processing
+	wg         sync.WaitGroup
+	processing sync.Mutex
 }
@@ -39,6 +39,16 @@ func getWriters(s *SubLoggerConfig) (*multiWriterHolder, error) {
 				return nil, errFileLoggingNotConfiguredCorrectly
 			}
 			writer = globalLogFile
+		case "syslog":
+			if globalSyslog == nil {
+				return nil, fmt.Errorf("%w: %s", errRemoteLoggingNotConfigured, outputWriters[x])
+			}
+			writer = globalSyslog
+		case "loki":
+			if globalLoki == nil {
+				return nil, fmt.Errorf("%w: %s", errRemoteLoggingNotConfigured, outputWriters[x])
+			}
+			writer = globalLoki
 		default:
 			// Note: Do not want to add an io.Discard here as this adds
 			// additional write calls for no reason.
@@ -84,6 +94,18 @@ func SetGlobalLogConfig(incoming *Config) error {
 	if incoming.LoggerFileConfig != nil {
 		fileConf = *incoming.LoggerFileConfig
 	}
+	var remoteConf *RemoteConfig
+	if incoming.RemoteSettings != nil {
+		remoteConf = &RemoteConfig{}
+		if incoming.RemoteSettings.Syslog != nil {
+			syslogConf := *incoming.RemoteSettings.Syslog
+			remoteConf.Syslog = &syslogConf
+		}
+		if incoming.RemoteSettings.Loki != nil {
+			lokiConf := *incoming.RemoteSettings.Loki
+			remoteConf.Loki = &lokiConf
+		}
+	}
 	subs := make([]SubLoggerConfig, len(incoming.SubLoggers))
 	copy(subs, incoming.SubLoggers)
 	mu.Lock()
@@ -91,6 +113,7 @@ func SetGlobalLogConfig(incoming *Config) error {
 	globalLogConfig.SubLoggerConfig = incoming.SubLoggerConfig
 	globalLogConfig.Enabled = convert.BoolPtr(incoming.Enabled != nil && *incoming.Enabled)
 	globalLogConfig.LoggerFileConfig = &fileConf
+	globalLogConfig.RemoteSettings = remoteConf
 	globalLogConfig.AdvancedSettings = incoming.AdvancedSettings
 	return nil
 }
@@ -155,12 +178,19 @@ func SetupGlobalLogger(botName string, structuredOutput bool) error {
 
 	if fileLoggingConfiguredCorrectly {
 		globalLogFile = &Rotate{
-			FileName: globalLogConfig.LoggerFileConfig.FileName,
-			MaxSize:  globalLogConfig.LoggerFileConfig.MaxSize,
-			Rotate:   globalLogConfig.LoggerFileConfig.Rotate,
+			FileName:       globalLogConfig.LoggerFileConfig.FileName,
+			MaxSize:        globalLogConfig.LoggerFileConfig.MaxSize,
+			Rotate:         globalLogConfig.LoggerFileConfig.Rotate,
+			RotateInterval: globalLogConfig.LoggerFileConfig.RotateInterval,
+			Compress:       globalLogConfig.LoggerFileConfig.Compress,
+			MaxBackups:     globalLogConfig.LoggerFileConfig.MaxBackups,
 		}
 	}
 
+	if err := setupRemoteWriters(botName); err != nil {
+		return err
+	}
+
 	writers, err := getWriters(&globalLogConfig.SubLoggerConfig)
 	if err != nil {
 		return err
@@ -179,6 +209,44 @@ func SetupGlobalLogger(botName string, structuredOutput bool) error {
 	return nil
 }
 
+// setupRemoteWriters replaces the remote writers with those configured. Note:
+// Calling function must have mutex lock in place.
+func setupRemoteWriters(botName string) error {
+	if err := closeRemoteWriters(); err != nil {
+		return err
+	}
+	if globalLogConfig.RemoteSettings == nil {
+		return nil
+	}
+	var err error
+	if globalLogConfig.RemoteSettings.Syslog != nil {
+		if globalSyslog, err = newSyslogWriter(globalLogConfig.RemoteSettings.Syslog); err != nil {
+			return err
+		}
+	}
+	if globalLogConfig.RemoteSettings.Loki != nil {
+		if globalLoki, err = newLokiWriter(globalLogConfig.RemoteSettings.Loki, botName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// closeRemoteWriters closes the remote writers, pushing any pending entries.
+// Note: Calling function must have mutex lock in place.
+func closeRemoteWriters() error {
+	var errs error
+	if globalSyslog != nil {
+		errs = errors.Join(errs, globalSyslog.Close())
+		globalSyslog = nil
+	}
+	if globalLoki != nil {
+		errs = errors.Join(errs, globalLoki.Close())
+		globalLoki = nil
+	}
+	return errs
+}
+
 // SetFileLoggingState can set file logging state if it is correctly configured
 // or not. This will bypass the ability to log to file if set as false.
 func SetFileLoggingState(correctlyConfigured bool) {
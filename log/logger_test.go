@@ -1,16 +1,20 @@
 package log
 
 import (
+	"compress/gzip"
 	"encoding/json"
 	"errors"
 	"io"
 	"log"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/thrasher-corp/gocryptotrader/common/convert"
 )
 
@@ -656,6 +660,52 @@ func TestOpenNew(t *testing.T) {
 	}
 }
 
+func TestRotateInterval(t *testing.T) {
+	t.Parallel()
+	r := Rotate{Rotate: convert.BoolPtr(true), FileName: "interval.txt", RotateInterval: time.Hour}
+	_, err := r.Write([]byte("first\n"))
+	require.NoError(t, err)
+	assert.False(t, r.intervalElapsed(time.Now()), "interval should not elapse within the same hour")
+	r.boundary = r.boundary.Add(-time.Hour)
+	assert.True(t, r.intervalElapsed(time.Now()), "interval should elapse once the boundary is crossed")
+	_, err = r.Write([]byte("second\n"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(len("second\n")), r.size, "file should be rotated before writing")
+	require.NoError(t, r.Close())
+
+	r.RotateInterval = 0
+	assert.False(t, r.intervalElapsed(time.Now().Add(time.Hour*48)), "time based rotation should be disabled")
+}
+
+func TestRotateCompressAndPrune(t *testing.T) {
+	t.Parallel()
+	r := Rotate{Rotate: convert.BoolPtr(true), FileName: "prune.txt", MaxSize: 1, Compress: true, MaxBackups: 2}
+	for i := range 4 {
+		// Rotated files are named by the second they were rotated in
+		stale := filepath.Join(GetLogPath(), time.Now().Add(-time.Hour*time.Duration(i+1)).Format(rotatedTimestampFormat)+"-prune.txt")
+		require.NoError(t, os.WriteFile(stale, []byte("stale"), 0o600))
+	}
+	_, err := r.Write(make([]byte, megabyte-1))
+	require.NoError(t, err)
+	_, err = r.Write(make([]byte, 2))
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+
+	backups, err := r.rotatedFiles()
+	require.NoError(t, err)
+	require.Len(t, backups, 2, "oldest rotated files should be removed")
+	assert.True(t, strings.HasSuffix(backups[1], "-prune.txt.gz"), "newest rotated file should be compressed")
+
+	f, err := os.Open(backups[1])
+	require.NoError(t, err)
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	data, err := io.ReadAll(gz)
+	require.NoError(t, err)
+	assert.Len(t, data, int(megabyte-1), "compressed file should hold the rotated log")
+}
+
 type testBuffer struct {
 	value    []byte
 	Finished chan struct{}
@@ -3,6 +3,7 @@ package log
 import (
 	"io"
 	"sync"
+	"time"
 )
 
 const (
@@ -25,6 +26,10 @@ var (
 	globalLogConfig = &Config{}
 	// GlobalLogFile hold global configuration options for file logger
 	globalLogFile = &Rotate{}
+	// globalSyslog and globalLoki ship logs to remote destinations when
+	// configured
+	globalSyslog *syslogWriter
+	globalLoki   *lokiWriter
 
 	jobsPool    = &sync.Pool{New: func() interface{} { return new(job) }}
 	jobsChannel = make(chan *job, defaultJobChannelCapacity)
@@ -60,6 +65,7 @@ type Config struct {
 	Enabled *bool `json:"enabled"`
 	SubLoggerConfig
 	LoggerFileConfig *loggerFileConfig `json:"fileSettings,omitempty"`
+	RemoteSettings   *RemoteConfig     `json:"remoteSettings,omitempty"`
 	AdvancedSettings advancedSettings  `json:"advancedSettings"`
 	SubLoggers       []SubLoggerConfig `json:"subloggers,omitempty"`
 }
@@ -88,9 +94,12 @@ type SubLoggerConfig struct {
 }
 
 type loggerFileConfig struct {
-	FileName string `json:"filename,omitempty"`
-	Rotate   *bool  `json:"rotate,omitempty"`
-	MaxSize  int64  `json:"maxsize,omitempty"`
+	FileName       string        `json:"filename,omitempty"`
+	Rotate         *bool         `json:"rotate,omitempty"`
+	MaxSize        int64         `json:"maxsize,omitempty"`
+	RotateInterval time.Duration `json:"rotateinterval,omitempty"`
+	Compress       bool          `json:"compress,omitempty"`
+	MaxBackups     int           `json:"maxbackups,omitempty"`
 }
 
 // Logger each instance of logger settings
@@ -51,6 +51,7 @@ func main() {
 	flag.BoolVar(&settings.EnableCoinmarketcapAnalysis, "coinmarketcap", false, "overrides config and runs currency analysis")
 	flag.BoolVar(&settings.EnableEventManager, "eventmanager", true, "enables the event manager")
 	flag.BoolVar(&settings.EnableOrderManager, "ordermanager", true, "enables the order manager")
+	flag.BoolVar(&settings.EnablePaperTrading, "papertrading", false, "fills orders against live orderbooks instead of sending them to exchanges")
 	flag.BoolVar(&settings.EnableDepositAddressManager, "depositaddressmanager", true, "enables the deposit address manager")
 	flag.BoolVar(&settings.EnableConnectivityMonitor, "connectivitymonitor", true, "enables the connectivity monitor")
 	flag.BoolVar(&settings.EnableDatabaseManager, "databasemanager", true, "enables database manager")
@@ -59,6 +60,28 @@ func main() {
 	flag.BoolVar(&settings.EnableNTPClient, "ntpclient", true, "enables the NTP client to check system clock drift")
 	flag.BoolVar(&settings.EnableDispatcher, "dispatch", true, "enables the dispatch system")
 	flag.BoolVar(&settings.EnableCurrencyStateManager, "currencystatemanager", true, "enables the currency state manager")
+	flag.BoolVar(&settings.EnableDigestManager, "digestmanager", false, "enables the scheduled performance digest manager")
+	flag.BoolVar(&settings.EnableFillSyncManager, "fillsyncmanager", false, "enables syncing user fill history from exchanges to the database")
+	flag.BoolVar(&settings.EnablePairRefreshManager, "pairrefreshmanager", false, "enables scheduled pair list refreshes which enable pairs matching exchange pair rules")
+	flag.BoolVar(&settings.EnableEarnManager, "earnmanager", false, "enables syncing exchange earn and staking positions and recording their rewards")
+	flag.BoolVar(&settings.EnableLendingOptimizer, "lendingoptimizer", false, "enables moving idle stablecoin balances to the exchange offering the best flexible earn rate")
+	flag.BoolVar(&settings.EnableMemoryManager, "memorymanager", false, "enables attributing memory usage to the orderbook, ticker and trade buffer caches and trimming them when over budget")
+	flag.BoolVar(&settings.EnableScheduler, "scheduler", false, "enables running user defined tasks such as balance snapshots, pair refreshes, reports and scripts on cron schedules")
+	flag.BoolVar(&settings.EnableRolloverManager, "rollovermanager", false, "enables rolling futures and options positions to the next expiry before they expire")
+	flag.BoolVar(&settings.EnableCalendarSpreadManager, "calendarspreadmanager", false, "enables monitoring and alerting on spreads between perpetual and dated futures")
+	flag.BoolVar(&settings.EnableVolatilitySurfaceManager, "volatilitysurfacemanager", false, "enables building implied volatility surfaces from live option tickers")
+	flag.BoolVar(&settings.EnableMarginMonitor, "marginmonitor", false, "enables alerting on account margin utilisation and optional deleveraging of positions")
+	flag.BoolVar(&settings.EnableExchangeCalendar, "exchangecalendar", false, "enables aggregating upcoming contract expiries, listings, delistings and maintenance windows across exchanges")
+	flag.BoolVar(&settings.EnableMaintenanceScheduler, "maintenancescheduler", false, "enables pausing order routing, slowing websocket reconnection and suppressing alerts during each exchange's configured maintenance windows")
+	flag.BoolVar(&settings.EnableBasisHarvester, "basisharvester", false, "enables the cash and carry strategy holding spot long and perpetual short positions while the basis is high")
+	flag.BoolVar(&settings.EnableAnomalyDetector, "anomalydetector", false, "enables quarantining pairs from strategies and alerting on anomalous streamed prices, crossed books and zero size levels")
+	flag.BoolVar(&settings.EnableDataQualityMonitor, "dataqualitymonitor", false, "enables scoring each exchange's streamed market data quality by update gaps, stale ticks, crossed books and reconnections")
+	flag.BoolVar(&settings.EnableWebhookListener, "webhooklistener", false, "enables receiving fills and transfers pushed by exchanges and providers via signed HTTP callbacks")
+	flag.BoolVar(&settings.EnableADLMonitor, "adlmonitor", false, "enables collecting insurance fund balances and alerting on auto-deleveraging risk of held positions")
+	flag.BoolVar(&settings.EnableSurveillanceManager, "surveillancemanager", false, "enables flagging own trading activity resembling wash trading, spoofing or excessive cancelling")
+	flag.BoolVar(&settings.EnableFeeAccountingManager, "feeaccounting", false, "enables high-water mark tracking and management and performance fee statements for managed accounts")
+	flag.BoolVar(&settings.EnableCircuitBreaker, "circuitbreaker", false, "enables short-circuiting exchange REST requests after repeated failures")
+	flag.BoolVar(&settings.EnableLatencySimulation, "latencysimulation", false, "enables injecting artificial latency into exchange REST requests and websocket messages for strategy testing")
 	flag.IntVar(&settings.DispatchMaxWorkerAmount, "dispatchworkers", dispatch.DefaultMaxWorkers, "sets the dispatch package max worker generation limit")
 	flag.IntVar(&settings.DispatchJobsLimit, "dispatchjobslimit", dispatch.DefaultJobsLimit, "sets the dispatch package max jobs limit")
 
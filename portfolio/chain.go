@@ -0,0 +1,310 @@
+package portfolio
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// Supported chains for address balance fetching
+const (
+	ChainSolana   = "solana"
+	ChainTron     = "tron"
+	ChainArbitrum = "arbitrum"
+	ChainBase     = "base"
+)
+
+var (
+	errChainNotSupported = errors.New("chain not supported")
+	errNilChainClient    = errors.New("chain client cannot be nil")
+	errInvalidAddress    = errors.New("invalid address")
+	errNoNativeCoin      = errors.New("coin type must be set for chains without a default native coin")
+	errJSONRPC           = errors.New("json-rpc error")
+	errInvalidAmount     = errors.New("invalid amount")
+
+	solanaAddressRegexp = regexp.MustCompile("^[1-9A-HJ-NP-Za-km-z]{32,44}$")
+	tronAddressRegexp   = regexp.MustCompile("^T[1-9A-HJ-NP-Za-km-z]{33}$")
+	evmAddressRegexp    = regexp.MustCompile("^0x[0-9a-fA-F]{40}$")
+)
+
+// defaultChains holds the default endpoint, native coin and tracked tokens of
+// each supported chain
+var defaultChains = map[string]struct {
+	endpoint string
+	native   currency.Code
+	tokens   []Token
+}{
+	ChainSolana: {
+		endpoint: "https://api.mainnet-beta.solana.com",
+		native:   currency.SOL,
+		tokens: []Token{
+			{Symbol: "USDC", Contract: "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v", Decimals: 6},
+			{Symbol: "USDT", Contract: "Es9vMFrzaCERmJfrF4H2FYD4KCoNkY11McCe8BenwNYB", Decimals: 6},
+		},
+	},
+	ChainTron: {
+		endpoint: "https://api.trongrid.io",
+		native:   currency.TRX,
+		tokens: []Token{
+			{Symbol: "USDT", Contract: "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", Decimals: 6},
+			{Symbol: "USDC", Contract: "TEkxiTehnzSmSe2XqrBj4w32RUN966rdz8", Decimals: 6},
+		},
+	},
+	ChainArbitrum: {
+		endpoint: "https://arb1.arbitrum.io/rpc",
+		native:   currency.ETH,
+		tokens: []Token{
+			{Symbol: "USDC", Contract: "0xaf88d065e77c8cC2239327C5EDb3A432268e5831", Decimals: 6},
+			{Symbol: "USDT", Contract: "0xFd086bC7CD5C481DCC9C85ebE478A1C0b69FCbb9", Decimals: 6},
+			{Symbol: "ARB", Contract: "0x912CE59144191C1204E64559FE8253a0e49E6548", Decimals: 18},
+		},
+	},
+	ChainBase: {
+		endpoint: "https://mainnet.base.org",
+		native:   currency.ETH,
+		tokens: []Token{
+			{Symbol: "USDC", Contract: "0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913", Decimals: 6},
+		},
+	},
+}
+
+// RegisterChainClient sets the client used to fetch address balances on a
+// chain, replacing the default client for supported chains
+func (b *Base) RegisterChainClient(chain string, c ChainClient) error {
+	if chain == "" {
+		return fmt.Errorf("%w: chain name is empty", errChainNotSupported)
+	}
+	if c == nil {
+		return errNilChainClient
+	}
+	if b.chainClients == nil {
+		b.chainClients = make(map[string]ChainClient)
+	}
+	b.chainClients[strings.ToLower(chain)] = c
+	return nil
+}
+
+// SetUSDPricer sets the function used to value portfolio holdings in USD
+func (b *Base) SetUSDPricer(p USDPricer) {
+	b.usdPricer = p
+}
+
+// getChainClient returns the registered client for a chain, creating the
+// default client from the chains config for supported chains
+func (b *Base) getChainClient(chain string) (ChainClient, error) {
+	chain = strings.ToLower(chain)
+	if c, ok := b.chainClients[chain]; ok {
+		return c, nil
+	}
+	def, ok := defaultChains[chain]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errChainNotSupported, chain)
+	}
+	endpoint, apiKey := def.endpoint, ""
+	tokens := append([]Token(nil), def.tokens...)
+	for i := range b.Chains {
+		if !strings.EqualFold(b.Chains[i].Name, chain) {
+			continue
+		}
+		if b.Chains[i].Endpoint != "" {
+			endpoint = b.Chains[i].Endpoint
+		}
+		apiKey = b.Chains[i].APIKey
+		tokens = append(tokens, b.Chains[i].Tokens...)
+	}
+	var c ChainClient
+	switch chain {
+	case ChainSolana:
+		c = &solanaClient{endpoint: endpoint, tokens: tokens, verbose: b.Verbose}
+	case ChainTron:
+		c = &tronClient{endpoint: endpoint, apiKey: apiKey, tokens: tokens, verbose: b.Verbose}
+	default:
+		c = &evmClient{endpoint: endpoint, native: def.native, tokens: tokens, verbose: b.Verbose}
+	}
+	if err := b.RegisterChainClient(chain, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// AddChainAddress adds an address whose native and token balances are
+// fetched from a chain. When coinType is empty the chain's native coin is used
+func (b *Base) AddChainAddress(address, chain, description string, coinType currency.Code) error {
+	c, err := b.getChainClient(chain)
+	if err != nil {
+		return err
+	}
+	if err := c.ValidateAddress(address); err != nil {
+		return err
+	}
+	if coinType.IsEmpty() {
+		def, ok := defaultChains[strings.ToLower(chain)]
+		if !ok {
+			return errNoNativeCoin
+		}
+		coinType = def.native
+	}
+	if description == "" {
+		description = PersonalAddress
+	}
+	for x := range b.Addresses {
+		if b.Addresses[x].Address == address &&
+			strings.EqualFold(b.Addresses[x].Chain, chain) &&
+			b.Addresses[x].CoinType.Equal(coinType) {
+			return nil
+		}
+	}
+	b.Addresses = append(b.Addresses, Address{
+		Address:     address,
+		Chain:       strings.ToLower(chain),
+		CoinType:    coinType,
+		Description: description,
+	})
+	return nil
+}
+
+// UpdateChainAddresses fetches the balances of all addresses which have a
+// chain set. Tokens held by an address are added as separate entries and
+// tokens no longer returned have their balance set to zero
+func (b *Base) UpdateChainAddresses(ctx context.Context) error {
+	type chainAddress struct{ address, chain string }
+	var seen []chainAddress
+	var errs error
+	for x := range b.Addresses {
+		if b.Addresses[x].Chain == "" || b.Addresses[x].Description == ExchangeAddress {
+			continue
+		}
+		ca := chainAddress{address: b.Addresses[x].Address, chain: strings.ToLower(b.Addresses[x].Chain)}
+		var dupe bool
+		for i := range seen {
+			if seen[i] == ca {
+				dupe = true
+				break
+			}
+		}
+		if dupe {
+			continue
+		}
+		seen = append(seen, ca)
+		c, err := b.getChainClient(ca.chain)
+		if err != nil {
+			errs = common.AppendError(errs, err)
+			continue
+		}
+		balances, err := c.GetBalances(ctx, ca.address)
+		if err != nil {
+			errs = common.AppendError(errs, fmt.Errorf("%s %s: %w", ca.chain, ca.address, err))
+			continue
+		}
+		b.setChainBalances(ca.address, ca.chain, balances)
+	}
+	return errs
+}
+
+// setChainBalances updates the balances of an address's entries on a chain,
+// adding entries for newly held tokens
+func (b *Base) setChainBalances(address, chain string, balances []TokenBalance) {
+	var template *Address
+	for x := range b.Addresses {
+		if b.Addresses[x].Address == address && strings.EqualFold(b.Addresses[x].Chain, chain) {
+			t := b.Addresses[x]
+			template = &t
+			break
+		}
+	}
+	if template == nil {
+		return
+	}
+	updated := make(map[int]bool)
+	for i := range balances {
+		found := false
+		for x := range b.Addresses {
+			if b.Addresses[x].Address == address &&
+				strings.EqualFold(b.Addresses[x].Chain, chain) &&
+				b.Addresses[x].CoinType.Equal(balances[i].Coin) {
+				b.Addresses[x].Balance = balances[i].Balance
+				updated[x] = true
+				found = true
+				break
+			}
+		}
+		if found || balances[i].Balance <= 0 {
+			continue
+		}
+		entry := *template
+		entry.CoinType = balances[i].Coin
+		entry.Balance = balances[i].Balance
+		b.Addresses = append(b.Addresses, entry)
+		updated[len(b.Addresses)-1] = true
+	}
+	for x := range b.Addresses {
+		if !updated[x] && b.Addresses[x].Address == address && strings.EqualFold(b.Addresses[x].Chain, chain) {
+			b.Addresses[x].Balance = 0
+		}
+	}
+}
+
+// jsonRPCRequest holds a JSON-RPC 2.0 request
+type jsonRPCRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      int    `json:"id"`
+	Method  string `json:"method"`
+	Params  []any  `json:"params"`
+}
+
+// jsonRPCResponse holds a JSON-RPC 2.0 response
+type jsonRPCResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// sendJSONRPC sends a JSON-RPC 2.0 request and unmarshals its result
+func sendJSONRPC(ctx context.Context, endpoint, method string, params []any, verbose bool, result any) error {
+	body, err := json.Marshal(jsonRPCRequest{JSONRPC: "2.0", ID: 1, Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+	contents, err := common.SendHTTPRequest(ctx,
+		http.MethodPost,
+		endpoint,
+		map[string]string{"Content-Type": "application/json"},
+		bytes.NewReader(body),
+		verbose)
+	if err != nil {
+		return err
+	}
+	var resp jsonRPCResponse
+	if err := json.Unmarshal(contents, &resp); err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return fmt.Errorf("%w %s %d: %s", errJSONRPC, method, resp.Error.Code, resp.Error.Message)
+	}
+	return json.Unmarshal(resp.Result, result)
+}
+
+// scaleAmount converts an integer amount in a token's smallest unit to a float
+func scaleAmount(amount *big.Int, decimals int32) float64 {
+	return decimal.NewFromBigInt(amount, -decimals).InexactFloat64()
+}
+
+// logUnknownToken logs tokens held by an address which are not tracked
+func logUnknownToken(verbose bool, chain, address, contract string) {
+	if verbose {
+		log.Debugf(log.PortfolioMgr, "Portfolio %s address %s holds untracked token %s", chain, address, contract)
+	}
+}
@@ -0,0 +1,234 @@
+package portfolio
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+const (
+	solanaNativeDecimals   = 9
+	solanaTokenProgram     = "TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA"
+	solanaToken2022Program = "TokenzQdBNbLqP5VEhdkAS6EPFLC1PHnBqCXEpPxuEb"
+
+	tronNativeDecimals = 6
+	tronAccountPath    = "/v1/accounts/"
+
+	evmNativeDecimals = 18
+	// evmBalanceOfSelector is the ERC-20 balanceOf(address) function selector
+	evmBalanceOfSelector = "0x70a08231"
+)
+
+// solanaClient fetches SOL and SPL token balances via Solana JSON-RPC
+type solanaClient struct {
+	endpoint string
+	tokens   []Token
+	verbose  bool
+}
+
+// ValidateAddress checks the address is a base58 encoded public key
+func (c *solanaClient) ValidateAddress(address string) error {
+	if !solanaAddressRegexp.MatchString(address) {
+		return fmt.Errorf("%w %s for chain %s", errInvalidAddress, address, ChainSolana)
+	}
+	return nil
+}
+
+// GetBalances returns the SOL balance and the balance of each tracked token
+// account owned by the address
+func (c *solanaClient) GetBalances(ctx context.Context, address string) ([]TokenBalance, error) {
+	var native struct {
+		Value uint64 `json:"value"`
+	}
+	if err := sendJSONRPC(ctx, c.endpoint, "getBalance", []any{address}, c.verbose, &native); err != nil {
+		return nil, err
+	}
+	resp := []TokenBalance{{
+		Coin:    currency.SOL,
+		Balance: scaleAmount(new(big.Int).SetUint64(native.Value), solanaNativeDecimals),
+	}}
+	amounts := make(map[string]*big.Int)
+	decimals := make(map[string]int32)
+	for _, program := range []string{solanaTokenProgram, solanaToken2022Program} {
+		var accounts solanaTokenAccounts
+		if err := sendJSONRPC(ctx, c.endpoint, "getTokenAccountsByOwner", []any{
+			address,
+			map[string]string{"programId": program},
+			map[string]string{"encoding": "jsonParsed"},
+		}, c.verbose, &accounts); err != nil {
+			return nil, err
+		}
+		for i := range accounts.Value {
+			info := &accounts.Value[i].Account.Data.Parsed.Info
+			amount, ok := new(big.Int).SetString(info.TokenAmount.Amount, 10)
+			if !ok {
+				return nil, fmt.Errorf("%w %q for mint %s", errInvalidAmount, info.TokenAmount.Amount, info.Mint)
+			}
+			if total, ok := amounts[info.Mint]; ok {
+				total.Add(total, amount)
+				continue
+			}
+			amounts[info.Mint] = amount
+			decimals[info.Mint] = info.TokenAmount.Decimals
+		}
+	}
+	for i := range c.tokens {
+		amount, ok := amounts[c.tokens[i].Contract]
+		if !ok {
+			continue
+		}
+		delete(amounts, c.tokens[i].Contract)
+		resp = append(resp, TokenBalance{
+			Coin:     currency.NewCode(c.tokens[i].Symbol),
+			Contract: c.tokens[i].Contract,
+			Balance:  scaleAmount(amount, decimals[c.tokens[i].Contract]),
+		})
+	}
+	for mint := range amounts {
+		logUnknownToken(c.verbose, ChainSolana, address, mint)
+	}
+	return resp, nil
+}
+
+// tronClient fetches TRX and TRC-20 token balances via TronGrid
+type tronClient struct {
+	endpoint string
+	apiKey   string
+	tokens   []Token
+	verbose  bool
+}
+
+// ValidateAddress checks the address is a base58 encoded Tron address
+func (c *tronClient) ValidateAddress(address string) error {
+	if !tronAddressRegexp.MatchString(address) {
+		return fmt.Errorf("%w %s for chain %s", errInvalidAddress, address, ChainTron)
+	}
+	return nil
+}
+
+// GetBalances returns the TRX balance and the balance of each tracked TRC-20
+// token held by the address
+func (c *tronClient) GetBalances(ctx context.Context, address string) ([]TokenBalance, error) {
+	var headers map[string]string
+	if c.apiKey != "" {
+		headers = map[string]string{"TRON-PRO-API-KEY": c.apiKey}
+	}
+	contents, err := common.SendHTTPRequest(ctx,
+		http.MethodGet,
+		strings.TrimSuffix(c.endpoint, "/")+tronAccountPath+address,
+		headers,
+		nil,
+		c.verbose)
+	if err != nil {
+		return nil, err
+	}
+	var result tronAccountResponse
+	if err := json.Unmarshal(contents, &result); err != nil {
+		return nil, err
+	}
+	if !result.Success {
+		return nil, fmt.Errorf("tron account request unsuccessful: %s", result.Error)
+	}
+	resp := []TokenBalance{{Coin: currency.TRX}}
+	if len(result.Data) == 0 {
+		// accounts which have never received funds are not returned
+		return resp, nil
+	}
+	resp[0].Balance = scaleAmount(big.NewInt(result.Data[0].Balance), tronNativeDecimals)
+	amounts := make(map[string]*big.Int)
+	for _, holding := range result.Data[0].TRC20 {
+		for contract, raw := range holding {
+			amount, ok := new(big.Int).SetString(raw, 10)
+			if !ok {
+				return nil, fmt.Errorf("%w %q for contract %s", errInvalidAmount, raw, contract)
+			}
+			amounts[contract] = amount
+		}
+	}
+	for i := range c.tokens {
+		amount, ok := amounts[c.tokens[i].Contract]
+		if !ok {
+			continue
+		}
+		delete(amounts, c.tokens[i].Contract)
+		resp = append(resp, TokenBalance{
+			Coin:     currency.NewCode(c.tokens[i].Symbol),
+			Contract: c.tokens[i].Contract,
+			Balance:  scaleAmount(amount, c.tokens[i].Decimals),
+		})
+	}
+	for contract := range amounts {
+		logUnknownToken(c.verbose, ChainTron, address, contract)
+	}
+	return resp, nil
+}
+
+// evmClient fetches native and ERC-20 token balances via Ethereum JSON-RPC on
+// EVM compatible chains such as Arbitrum and Base. Only tracked tokens are
+// queried as token holdings cannot be enumerated via JSON-RPC
+type evmClient struct {
+	endpoint string
+	native   currency.Code
+	tokens   []Token
+	verbose  bool
+}
+
+// ValidateAddress checks the address is a hex encoded EVM address
+func (c *evmClient) ValidateAddress(address string) error {
+	if !evmAddressRegexp.MatchString(address) {
+		return fmt.Errorf("%w %s for EVM chain", errInvalidAddress, address)
+	}
+	return nil
+}
+
+// GetBalances returns the native balance and the balance of each tracked
+// ERC-20 token held by the address
+func (c *evmClient) GetBalances(ctx context.Context, address string) ([]TokenBalance, error) {
+	var raw string
+	if err := sendJSONRPC(ctx, c.endpoint, "eth_getBalance", []any{address, "latest"}, c.verbose, &raw); err != nil {
+		return nil, err
+	}
+	amount, err := parseHexQuantity(raw)
+	if err != nil {
+		return nil, err
+	}
+	resp := []TokenBalance{{Coin: c.native, Balance: scaleAmount(amount, evmNativeDecimals)}}
+	data := evmBalanceOfSelector + strings.Repeat("0", 24) + strings.ToLower(strings.TrimPrefix(address, "0x"))
+	for i := range c.tokens {
+		if err := sendJSONRPC(ctx, c.endpoint, "eth_call", []any{
+			map[string]string{"to": c.tokens[i].Contract, "data": data},
+			"latest",
+		}, c.verbose, &raw); err != nil {
+			return nil, fmt.Errorf("%s balance: %w", c.tokens[i].Symbol, err)
+		}
+		amount, err := parseHexQuantity(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s balance: %w", c.tokens[i].Symbol, err)
+		}
+		resp = append(resp, TokenBalance{
+			Coin:     currency.NewCode(c.tokens[i].Symbol),
+			Contract: c.tokens[i].Contract,
+			Balance:  scaleAmount(amount, c.tokens[i].Decimals),
+		})
+	}
+	return resp, nil
+}
+
+// parseHexQuantity parses a 0x prefixed hex encoded integer
+func parseHexQuantity(raw string) (*big.Int, error) {
+	hex := strings.TrimPrefix(raw, "0x")
+	if hex == "" {
+		return new(big.Int), nil
+	}
+	v, ok := new(big.Int).SetString(hex, 16)
+	if !ok {
+		return nil, fmt.Errorf("%w %q", errInvalidAmount, raw)
+	}
+	return v, nil
+}
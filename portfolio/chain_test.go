@@ -0,0 +1,312 @@
+package portfolio
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+const (
+	testSolanaAddress = "9WzDXwBbmkg8ZTbNMqUxvQRAyrZzDsGYdLVL9zYtAWWM"
+	testTronAddress   = "TJRabPrwbZy45sbavfcjinPJC18kjpRTv8"
+	testEVMAddress    = "0x742d35Cc6634C0532925a3b844Bc454e4438f44e"
+)
+
+type fakeChainClient struct {
+	balances []TokenBalance
+	err      error
+}
+
+func (f *fakeChainClient) ValidateAddress(string) error { return nil }
+
+func (f *fakeChainClient) GetBalances(context.Context, string) ([]TokenBalance, error) {
+	return f.balances, f.err
+}
+
+// newJSONRPCServer returns a server responding to JSON-RPC requests with the
+// result returned for each method
+func newJSONRPCServer(t *testing.T, results func(method string, params []json.RawMessage) any) *httptest.Server {
+	t.Helper()
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&req)) {
+			return
+		}
+		res := results(req.Method, req.Params)
+		if errMsg, ok := res.(error); ok {
+			assert.NoError(t, json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": -32602, "message": errMsg.Error()}}))
+			return
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(map[string]any{"result": res}))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestRegisterChainClient(t *testing.T) {
+	t.Parallel()
+	b := Base{}
+	assert.ErrorIs(t, b.RegisterChainClient("", &fakeChainClient{}), errChainNotSupported)
+	assert.ErrorIs(t, b.RegisterChainClient("cosmos", nil), errNilChainClient)
+	require.NoError(t, b.RegisterChainClient("Cosmos", &fakeChainClient{}))
+	c, err := b.getChainClient("cosmos")
+	require.NoError(t, err)
+	assert.IsType(t, &fakeChainClient{}, c)
+
+	_, err = b.getChainClient("dogechain")
+	assert.ErrorIs(t, err, errChainNotSupported)
+
+	b.Chains = []ChainConfig{{Name: "Tron", Endpoint: "http://localhost", APIKey: "key", Tokens: []Token{{Symbol: "BTT", Contract: "TAFjULxiVgT4qWk6UZwjqwZXTSaGaqnVp4", Decimals: 18}}}}
+	c, err = b.getChainClient(ChainTron)
+	require.NoError(t, err)
+	tc, ok := c.(*tronClient)
+	require.True(t, ok)
+	assert.Equal(t, "http://localhost", tc.endpoint, "endpoint should be overridden by config")
+	assert.Equal(t, "key", tc.apiKey)
+	assert.Len(t, tc.tokens, len(defaultChains[ChainTron].tokens)+1, "configured tokens should be tracked in addition to defaults")
+	c, err = b.getChainClient(ChainBase)
+	require.NoError(t, err)
+	assert.Equal(t, defaultChains[ChainBase].endpoint, c.(*evmClient).endpoint)
+}
+
+func TestAddChainAddress(t *testing.T) {
+	t.Parallel()
+	b := Base{}
+	assert.ErrorIs(t, b.AddChainAddress(testSolanaAddress, "dogechain", "", currency.EMPTYCODE), errChainNotSupported)
+	assert.ErrorIs(t, b.AddChainAddress(testEVMAddress, ChainSolana, "", currency.EMPTYCODE), errInvalidAddress)
+	assert.ErrorIs(t, b.AddChainAddress(testSolanaAddress, ChainTron, "", currency.EMPTYCODE), errInvalidAddress)
+	assert.ErrorIs(t, b.AddChainAddress(testTronAddress, ChainArbitrum, "", currency.EMPTYCODE), errInvalidAddress)
+	require.NoError(t, b.RegisterChainClient("cosmos", &fakeChainClient{}))
+	assert.ErrorIs(t, b.AddChainAddress("cosmos1abc", "cosmos", "", currency.EMPTYCODE), errNoNativeCoin)
+
+	require.NoError(t, b.AddChainAddress(testSolanaAddress, "Solana", "", currency.EMPTYCODE))
+	require.NoError(t, b.AddChainAddress(testSolanaAddress, ChainSolana, "", currency.EMPTYCODE))
+	require.NoError(t, b.AddChainAddress(testEVMAddress, ChainBase, "Cold", currency.EMPTYCODE))
+	require.Len(t, b.Addresses, 2, "duplicate chain addresses should not be added")
+	assert.Equal(t, ChainSolana, b.Addresses[0].Chain)
+	assert.Equal(t, currency.SOL, b.Addresses[0].CoinType, "the native coin should be used by default")
+	assert.Equal(t, PersonalAddress, b.Addresses[0].Description)
+	assert.Equal(t, currency.ETH, b.Addresses[1].CoinType)
+	assert.Equal(t, "Cold", b.Addresses[1].Description)
+	assert.Empty(t, b.GetPortfolioGroupedCoin(), "chain addresses should not be updated by coin")
+}
+
+func TestUpdateChainAddresses(t *testing.T) {
+	t.Parallel()
+	b := Base{Addresses: []Address{
+		{Address: testSolanaAddress, Chain: ChainSolana, CoinType: currency.SOL, Description: PersonalAddress, ColdStorage: true},
+		{Address: testSolanaAddress, Chain: ChainSolana, CoinType: currency.USDT, Description: PersonalAddress, Balance: 5},
+		{Address: testEVMAddress, Chain: "cosmos", CoinType: currency.ATOM, Description: PersonalAddress},
+		{Address: testTronAddress, Chain: "broken", CoinType: currency.TRX, Description: PersonalAddress},
+		{Address: "Binance", CoinType: currency.BTC, Description: ExchangeAddress, Balance: 1},
+	}}
+	require.NoError(t, b.RegisterChainClient(ChainSolana, &fakeChainClient{balances: []TokenBalance{
+		{Coin: currency.SOL, Balance: 2},
+		{Coin: currency.USDC, Contract: "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v", Balance: 100},
+		{Coin: currency.BONK, Contract: "bonk", Balance: 0},
+	}}))
+	require.NoError(t, b.RegisterChainClient("broken", &fakeChainClient{err: errJSONRPC}))
+	err := b.UpdateChainAddresses(context.Background())
+	assert.ErrorIs(t, err, errChainNotSupported, "addresses on unknown chains should error")
+	assert.ErrorIs(t, err, errJSONRPC, "client errors should be returned")
+
+	require.Len(t, b.Addresses, 6, "newly held tokens should be added and zero balances skipped")
+	assert.Equal(t, 2.0, b.Addresses[0].Balance)
+	assert.Zero(t, b.Addresses[1].Balance, "tokens no longer held should have a zero balance")
+	usdc := b.Addresses[5]
+	assert.Equal(t, currency.USDC, usdc.CoinType)
+	assert.Equal(t, 100.0, usdc.Balance)
+	assert.Equal(t, ChainSolana, usdc.Chain)
+	assert.True(t, usdc.ColdStorage, "token entries should inherit the address settings")
+	assert.Equal(t, 1.0, b.Addresses[4].Balance, "exchange addresses should not be changed")
+}
+
+func TestSolanaClientGetBalances(t *testing.T) {
+	t.Parallel()
+	tokenAccount := func(mint, amount string, decimals int) map[string]any {
+		return map[string]any{"account": map[string]any{"data": map[string]any{"parsed": map[string]any{"info": map[string]any{
+			"mint":        mint,
+			"tokenAmount": map[string]any{"amount": amount, "decimals": decimals},
+		}}}}}
+	}
+	s := newJSONRPCServer(t, func(method string, params []json.RawMessage) any {
+		switch method {
+		case "getBalance":
+			return map[string]any{"value": 1500000000}
+		case "getTokenAccountsByOwner":
+			if string(params[1]) != `{"programId":"`+solanaTokenProgram+`"}` {
+				return map[string]any{"value": []any{}}
+			}
+			return map[string]any{"value": []any{
+				tokenAccount("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v", "1500000", 6),
+				tokenAccount("EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v", "500000", 6),
+				tokenAccount("unknownMint", "1", 0),
+			}}
+		}
+		return errInvalidAddress
+	})
+	c := &solanaClient{endpoint: s.URL, tokens: defaultChains[ChainSolana].tokens}
+	assert.NoError(t, c.ValidateAddress(testSolanaAddress))
+	assert.ErrorIs(t, c.ValidateAddress("0xabc"), errInvalidAddress)
+
+	balances, err := c.GetBalances(context.Background(), testSolanaAddress)
+	require.NoError(t, err)
+	assert.Equal(t, []TokenBalance{
+		{Coin: currency.SOL, Balance: 1.5},
+		{Coin: currency.USDC, Contract: "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v", Balance: 2},
+	}, balances, "token accounts should be summed by mint and untracked mints skipped")
+
+	s = newJSONRPCServer(t, func(string, []json.RawMessage) any { return errInvalidAddress })
+	c.endpoint = s.URL
+	_, err = c.GetBalances(context.Background(), testSolanaAddress)
+	assert.ErrorIs(t, err, errJSONRPC)
+}
+
+func TestTronClientGetBalances(t *testing.T) {
+	t.Parallel()
+	var response string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, tronAccountPath+testTronAddress, r.URL.Path)
+		assert.Equal(t, "key", r.Header.Get("TRON-PRO-API-KEY"))
+		_, err := w.Write([]byte(response))
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(s.Close)
+	c := &tronClient{endpoint: s.URL + "/", apiKey: "key", tokens: defaultChains[ChainTron].tokens}
+	assert.NoError(t, c.ValidateAddress(testTronAddress))
+	assert.ErrorIs(t, c.ValidateAddress(testSolanaAddress), errInvalidAddress)
+
+	response = `{"data":[{"balance":2500000,"trc20":[{"TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t":"12345678"},{"TUnknown":"1"}]}],"success":true}`
+	balances, err := c.GetBalances(context.Background(), testTronAddress)
+	require.NoError(t, err)
+	assert.Equal(t, []TokenBalance{
+		{Coin: currency.TRX, Balance: 2.5},
+		{Coin: currency.USDT, Contract: "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", Balance: 12.345678},
+	}, balances)
+
+	response = `{"data":[],"success":true}`
+	balances, err = c.GetBalances(context.Background(), testTronAddress)
+	require.NoError(t, err)
+	assert.Equal(t, []TokenBalance{{Coin: currency.TRX}}, balances, "inactive accounts should have a zero balance")
+
+	response = `{"success":false,"error":"rate limited"}`
+	_, err = c.GetBalances(context.Background(), testTronAddress)
+	assert.ErrorContains(t, err, "rate limited")
+}
+
+func TestEVMClientGetBalances(t *testing.T) {
+	t.Parallel()
+	s := newJSONRPCServer(t, func(method string, params []json.RawMessage) any {
+		switch method {
+		case "eth_getBalance":
+			return "0xde0b6b3a7640000"
+		case "eth_call":
+			var call map[string]string
+			if !assert.NoError(t, json.Unmarshal(params[0], &call)) {
+				return errInvalidAddress
+			}
+			assert.Equal(t, evmBalanceOfSelector+"000000000000000000000000742d35cc6634c0532925a3b844bc454e4438f44e", call["data"])
+			if call["to"] == "0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913" {
+				return "0x00000000000000000000000000000000000000000000000000000000017d7840"
+			}
+			return "0x"
+		}
+		return errInvalidAddress
+	})
+	c := &evmClient{endpoint: s.URL, native: currency.ETH, tokens: append(append([]Token(nil), defaultChains[ChainBase].tokens...), Token{Symbol: "AERO", Contract: "0x940181a94A35A4569E4529A3CDfB74e38FD98631", Decimals: 18})}
+	assert.NoError(t, c.ValidateAddress(testEVMAddress))
+	assert.ErrorIs(t, c.ValidateAddress(testTronAddress), errInvalidAddress)
+
+	balances, err := c.GetBalances(context.Background(), testEVMAddress)
+	require.NoError(t, err)
+	assert.Equal(t, []TokenBalance{
+		{Coin: currency.ETH, Balance: 1},
+		{Coin: currency.USDC, Contract: "0x833589fCD6eDb6E08f4c7C32D4f71b54bdA02913", Balance: 25},
+		{Coin: currency.NewCode("AERO"), Contract: "0x940181a94A35A4569E4529A3CDfB74e38FD98631", Balance: 0},
+	}, balances)
+
+	_, err = parseHexQuantity("0xzz")
+	assert.ErrorIs(t, err, errInvalidAmount)
+}
+
+func TestGetPortfolioSummaryUSDValue(t *testing.T) {
+	t.Parallel()
+	b := Base{Addresses: []Address{
+		{Address: testSolanaAddress, Chain: ChainSolana, CoinType: currency.USDC, Description: PersonalAddress, Balance: 100},
+		{Address: testEVMAddress, Chain: ChainArbitrum, CoinType: currency.USDC, Description: PersonalAddress, Balance: 50},
+		{Address: testEVMAddress, Chain: ChainArbitrum, CoinType: currency.ETH, Description: PersonalAddress, Balance: 1},
+		{Address: "Binance", CoinType: currency.ETH, Description: ExchangeAddress, Balance: 1},
+		{Address: "Binance", CoinType: currency.DOGE, Description: ExchangeAddress, Balance: 10},
+	}}
+	s := b.GetPortfolioSummary()
+	assert.Zero(t, s.TotalUSDValue, "USD values should not be set without a pricer")
+
+	b.SetUSDPricer(func(c currency.Code) (float64, error) {
+		switch c {
+		case currency.USDC:
+			return 1, nil
+		case currency.ETH:
+			return 2000, nil
+		}
+		return 0, errInvalidAmount
+	})
+	s = b.GetPortfolioSummary()
+	assert.Equal(t, 4150.0, s.TotalUSDValue, "unpriced coins should be excluded from the total")
+	for i := range s.Totals {
+		switch s.Totals[i].Coin {
+		case currency.USDC:
+			assert.Equal(t, 150.0, s.Totals[i].USDValue, "token balances across chains should be aggregated")
+		case currency.ETH:
+			assert.Equal(t, 4000.0, s.Totals[i].USDValue)
+		case currency.DOGE:
+			assert.Zero(t, s.Totals[i].USDValue)
+		}
+	}
+	require.Len(t, s.OfflineSummary[currency.USDC], 2)
+	assert.Equal(t, ChainSolana, s.OfflineSummary[currency.USDC][0].Chain)
+	assert.Equal(t, 100.0, s.OfflineSummary[currency.USDC][0].USDValue)
+	assert.Equal(t, 2000.0, s.OnlineSummary["Binance"][currency.ETH].USDValue)
+}
+
+func TestGetPortfolioSummaryReportingValue(t *testing.T) {
+	t.Parallel()
+	b := Base{
+		ReportingCurrency: "btc",
+		Addresses: []Address{
+			{Address: "bank", CoinType: currency.EUR, Description: ManualAddress, Balance: 900},
+			{Address: "Binance", CoinType: currency.ETH, Description: ExchangeAddress, Balance: 2},
+		},
+	}
+	b.SetUSDPricer(func(c currency.Code) (float64, error) {
+		switch c {
+		case currency.BTC:
+			return 50000, nil
+		case currency.ETH:
+			return 2500, nil
+		case currency.EUR:
+			return 1.1, nil
+		}
+		return 0, errInvalidAmount
+	})
+	s := b.GetPortfolioSummary()
+	assert.Equal(t, currency.BTC, s.ReportingCurrency, "ReportingCurrency should be set from config")
+	assert.Equal(t, 50000.0, s.ReportingRate, "ReportingRate should be the USD price of the reporting currency")
+	assert.InDelta(t, 5990.0/50000, s.TotalReportingValue, 1e-9, "TotalReportingValue should be converted from USD")
+	assert.InDelta(t, 0.05, s.ConversionRates[currency.ETH], 1e-9, "ConversionRates should record the rate used per coin")
+	assert.InDelta(t, 1.1/50000, s.ConversionRates[currency.EUR], 1e-12, "ConversionRates should record the rate used per coin")
+	assert.False(t, s.ValuedAt.IsZero(), "ValuedAt should be set")
+
+	b.ReportingCurrency = ""
+	s = b.GetPortfolioSummary()
+	assert.Equal(t, currency.USD, s.ReportingCurrency, "ReportingCurrency should default to USD")
+	assert.Equal(t, s.TotalUSDValue, s.TotalReportingValue, "USD reporting values should match USD values")
+}
@@ -25,9 +25,18 @@ const (
 	ExchangeAddress = "Exchange"
 	// PersonalAddress is a label for a personal/offline address
 	PersonalAddress = "Personal"
+	// ManualAddress is a label for an off-exchange holding, such as a bank
+	// account or cold wallet, whose balance is maintained manually
+	ManualAddress = "Manual"
+	// EarnAddress is a label for an exchange's earn and staking balances,
+	// which are maintained by the earn manager
+	EarnAddress = "Earn"
 )
 
-var errNotEthAddress = errors.New("not an Ethereum address")
+var (
+	errNotEthAddress         = errors.New("not an Ethereum address")
+	errNegativeManualBalance = errors.New("manual balance cannot be negative")
+)
 
 // GetEthereumBalance single or multiple address information as
 // EtherchainBalanceResponse
@@ -145,7 +154,8 @@ func (b *Base) AddressExists(address string) bool {
 // associated with the portfolio base
 func (b *Base) ExchangeAddressExists(exchangeName string, coinType currency.Code) bool {
 	for x := range b.Addresses {
-		if b.Addresses[x].Address == exchangeName && b.Addresses[x].CoinType.Equal(coinType) {
+		if b.Addresses[x].Address == exchangeName && b.Addresses[x].CoinType.Equal(coinType) &&
+			b.Addresses[x].Description != EarnAddress {
 			return true
 		}
 	}
@@ -176,7 +186,8 @@ func (b *Base) UpdateAddressBalance(address string, amount float64) {
 // RemoveExchangeAddress removes an exchange address from the portfolio.
 func (b *Base) RemoveExchangeAddress(exchangeName string, coinType currency.Code) {
 	for x := range b.Addresses {
-		if b.Addresses[x].Address == exchangeName && b.Addresses[x].CoinType.Equal(coinType) {
+		if b.Addresses[x].Address == exchangeName && b.Addresses[x].CoinType.Equal(coinType) &&
+			b.Addresses[x].Description != EarnAddress {
 			b.Addresses = append(b.Addresses[:x], b.Addresses[x+1:]...)
 			return
 		}
@@ -187,7 +198,8 @@ func (b *Base) RemoveExchangeAddress(exchangeName string, coinType currency.Code
 // against correct exchangeName and coinType.
 func (b *Base) UpdateExchangeAddressBalance(exchangeName string, coinType currency.Code, balance float64) {
 	for x := range b.Addresses {
-		if b.Addresses[x].Address == exchangeName && b.Addresses[x].CoinType.Equal(coinType) {
+		if b.Addresses[x].Address == exchangeName && b.Addresses[x].CoinType.Equal(coinType) &&
+			b.Addresses[x].Description != EarnAddress {
 			b.Addresses[x].Balance = balance
 		}
 	}
@@ -203,6 +215,10 @@ func (b *Base) AddAddress(address, description string, coinType currency.Code, b
 		return errors.New("coin type is empty")
 	}
 
+	if strings.EqualFold(description, ManualAddress) {
+		return b.SetManualBalance(address, coinType, balance)
+	}
+
 	if description == ExchangeAddress {
 		b.AddExchangeAddress(address, coinType, balance)
 	}
@@ -224,6 +240,64 @@ func (b *Base) AddAddress(address, description string, coinType currency.Code, b
 	return nil
 }
 
+// SetManualBalance sets the balance of a manually maintained holding, adding it
+// if it does not exist. Manual holdings are included in portfolio valuation but
+// are never refreshed by the portfolio watcher. A holding may hold several
+// currencies, each tracked as a separate entry
+func (b *Base) SetManualBalance(name string, coinType currency.Code, balance float64) error {
+	if name == "" {
+		return errors.New("address is empty")
+	}
+	if coinType.IsEmpty() {
+		return errors.New("coin type is empty")
+	}
+	if balance < 0 {
+		return fmt.Errorf("%w: %s %s %v", errNegativeManualBalance, name, coinType, balance)
+	}
+	for x := range b.Addresses {
+		if b.Addresses[x].Address == name &&
+			b.Addresses[x].CoinType.Equal(coinType) &&
+			strings.EqualFold(b.Addresses[x].Description, ManualAddress) {
+			b.Addresses[x].Balance = balance
+			return nil
+		}
+	}
+	b.Addresses = append(b.Addresses, Address{
+		Address:     name,
+		CoinType:    coinType,
+		Balance:     balance,
+		Description: ManualAddress,
+	})
+	return nil
+}
+
+// SetEarnBalances replaces the earn and staking balances of an exchange.
+// Currencies which are no longer held are removed
+func (b *Base) SetEarnBalances(exchangeName string, balances map[currency.Code]float64) error {
+	if exchangeName == "" {
+		return errors.New("exchange name is empty")
+	}
+	addresses := b.Addresses[:0]
+	for x := range b.Addresses {
+		if b.Addresses[x].Address != exchangeName || b.Addresses[x].Description != EarnAddress {
+			addresses = append(addresses, b.Addresses[x])
+		}
+	}
+	b.Addresses = addresses
+	for c, balance := range balances {
+		if balance <= 0 {
+			continue
+		}
+		b.Addresses = append(b.Addresses, Address{
+			Address:     exchangeName,
+			CoinType:    c,
+			Balance:     balance,
+			Description: EarnAddress,
+		})
+	}
+	return nil
+}
+
 // RemoveAddress removes an address when checked against the correct address and
 // coinType
 func (b *Base) RemoveAddress(address, description string, coinType currency.Code) error {
@@ -306,22 +380,24 @@ func (b *Base) UpdatePortfolio(addresses []string, coinType currency.Code) error
 	return nil
 }
 
-// GetPortfolioByExchange returns currency portfolio amount by exchange
+// GetPortfolioByExchange returns currency portfolio amount by exchange,
+// including its earn and staking balances
 func (b *Base) GetPortfolioByExchange(exchangeName string) map[currency.Code]float64 {
 	result := make(map[currency.Code]float64)
 	for x := range b.Addresses {
 		if strings.Contains(b.Addresses[x].Address, exchangeName) {
-			result[b.Addresses[x].CoinType] = b.Addresses[x].Balance
+			result[b.Addresses[x].CoinType] += b.Addresses[x].Balance
 		}
 	}
 	return result
 }
 
-// GetExchangePortfolio returns current portfolio base information
+// GetExchangePortfolio returns current portfolio base information, including
+// earn and staking balances held on exchanges
 func (b *Base) GetExchangePortfolio() map[currency.Code]float64 {
 	result := make(map[currency.Code]float64)
 	for i := range b.Addresses {
-		if b.Addresses[i].Description != ExchangeAddress {
+		if !isOnline(&b.Addresses[i]) {
 			continue
 		}
 		balance, ok := result[b.Addresses[i].CoinType]
@@ -334,11 +410,22 @@ func (b *Base) GetExchangePortfolio() map[currency.Code]float64 {
 	return result
 }
 
+// GetEarnPortfolio returns the earn and staking balances held on exchanges
+func (b *Base) GetEarnPortfolio() map[currency.Code]float64 {
+	result := make(map[currency.Code]float64)
+	for i := range b.Addresses {
+		if b.Addresses[i].Description == EarnAddress {
+			result[b.Addresses[i].CoinType] += b.Addresses[i].Balance
+		}
+	}
+	return result
+}
+
 // GetPersonalPortfolio returns current portfolio base information
 func (b *Base) GetPersonalPortfolio() map[currency.Code]float64 {
 	result := make(map[currency.Code]float64)
 	for i := range b.Addresses {
-		if strings.EqualFold(b.Addresses[i].Description, ExchangeAddress) {
+		if isOnline(&b.Addresses[i]) {
 			continue
 		}
 		balance, ok := result[b.Addresses[i].CoinType]
@@ -351,6 +438,11 @@ func (b *Base) GetPersonalPortfolio() map[currency.Code]float64 {
 	return result
 }
 
+// isOnline returns whether the address is an exchange or exchange earn balance
+func isOnline(a *Address) bool {
+	return strings.EqualFold(a.Description, ExchangeAddress) || a.Description == EarnAddress
+}
+
 // getPercentage returns the percentage of the target coin amount against the
 // total coin amount.
 func getPercentage(input map[currency.Code]float64, target currency.Code, totals map[currency.Code]float64) float64 {
@@ -412,9 +504,17 @@ func (b *Base) GetPortfolioSummary() Summary {
 		portfolioOutput.Online = append(portfolioOutput.Online, coins)
 	}
 
+	for x, y := range b.GetEarnPortfolio() {
+		portfolioOutput.Earn = append(portfolioOutput.Earn, Coin{
+			Coin:       x,
+			Balance:    y,
+			Percentage: getPercentageSpecific(y, x, totalCoins),
+		})
+	}
+
 	var portfolioExchanges []string
 	for i := range b.Addresses {
-		if strings.EqualFold(b.Addresses[i].Description, ExchangeAddress) {
+		if isOnline(&b.Addresses[i]) {
 			if !common.StringDataCompare(portfolioExchanges, b.Addresses[i].Address) {
 				portfolioExchanges = append(portfolioExchanges, b.Addresses[i].Address)
 			}
@@ -440,9 +540,10 @@ func (b *Base) GetPortfolioSummary() Summary {
 
 	offlineSummary := make(map[currency.Code][]OfflineCoinSummary)
 	for i := range b.Addresses {
-		if !strings.EqualFold(b.Addresses[i].Description, ExchangeAddress) {
+		if !isOnline(&b.Addresses[i]) {
 			coinSummary := OfflineCoinSummary{
 				Address: b.Addresses[i].Address,
+				Chain:   b.Addresses[i].Chain,
 				Balance: b.Addresses[i].Balance,
 				Percentage: getPercentageSpecific(b.Addresses[i].Balance, b.Addresses[i].CoinType,
 					totalCoins),
@@ -458,14 +559,98 @@ func (b *Base) GetPortfolioSummary() Summary {
 		}
 	}
 	portfolioOutput.OfflineSummary = offlineSummary
+	b.valueSummary(&portfolioOutput)
 	return portfolioOutput
 }
 
-// GetPortfolioGroupedCoin returns portfolio base information grouped by coin
+// valueSummary populates the USD values of a portfolio summary using the USD
+// pricer. Coins which cannot be priced are left without a USD value
+func (b *Base) valueSummary(s *Summary) {
+	if b.usdPricer == nil {
+		return
+	}
+	prices := make(map[currency.Code]float64)
+	price := func(c currency.Code) float64 {
+		if c.Equal(currency.USD) {
+			return 1
+		}
+		if p, ok := prices[c]; ok {
+			return p
+		}
+		p, err := b.usdPricer(c)
+		if err != nil {
+			if b.Verbose {
+				log.Debugf(log.PortfolioMgr, "Portfolio unable to value %s in USD: %v", c, err)
+			}
+			p = 0
+		}
+		prices[c] = p
+		return p
+	}
+	s.ValuedAt = time.Now()
+	s.ReportingCurrency = currency.USD
+	if b.ReportingCurrency != "" {
+		s.ReportingCurrency = currency.NewCode(b.ReportingCurrency).Upper()
+	}
+	s.ReportingRate = price(s.ReportingCurrency)
+	if s.ReportingRate == 0 {
+		log.Warnf(log.PortfolioMgr, "Portfolio unable to value reporting currency %s in USD, reporting values will not be set", s.ReportingCurrency)
+	}
+	// toReporting converts a USD value to the reporting currency, recording
+	// the coin's conversion rate with the summary
+	toReporting := func(c currency.Code, usdValue float64) float64 {
+		if s.ReportingRate == 0 {
+			return 0
+		}
+		if p := price(c); p > 0 {
+			if s.ConversionRates == nil {
+				s.ConversionRates = make(map[currency.Code]float64)
+			}
+			s.ConversionRates[c] = p / s.ReportingRate
+		}
+		return usdValue / s.ReportingRate
+	}
+	for i := range s.Totals {
+		s.Totals[i].USDValue = s.Totals[i].Balance * price(s.Totals[i].Coin)
+		s.TotalUSDValue += s.Totals[i].USDValue
+		s.Totals[i].ReportingValue = toReporting(s.Totals[i].Coin, s.Totals[i].USDValue)
+		s.TotalReportingValue += s.Totals[i].ReportingValue
+	}
+	for i := range s.Offline {
+		s.Offline[i].USDValue = s.Offline[i].Balance * price(s.Offline[i].Coin)
+		s.Offline[i].ReportingValue = toReporting(s.Offline[i].Coin, s.Offline[i].USDValue)
+	}
+	for i := range s.Online {
+		s.Online[i].USDValue = s.Online[i].Balance * price(s.Online[i].Coin)
+		s.Online[i].ReportingValue = toReporting(s.Online[i].Coin, s.Online[i].USDValue)
+	}
+	for i := range s.Earn {
+		s.Earn[i].USDValue = s.Earn[i].Balance * price(s.Earn[i].Coin)
+		s.Earn[i].ReportingValue = toReporting(s.Earn[i].Coin, s.Earn[i].USDValue)
+	}
+	for c, summaries := range s.OfflineSummary {
+		for i := range summaries {
+			summaries[i].USDValue = summaries[i].Balance * price(c)
+		}
+	}
+	for _, coins := range s.OnlineSummary {
+		for c, summary := range coins {
+			summary.USDValue = summary.Balance * price(c)
+			coins[c] = summary
+		}
+	}
+}
+
+// GetPortfolioGroupedCoin returns portfolio base information grouped by coin.
+// Addresses with a chain set are excluded as they are updated via
+// UpdateChainAddresses, as are manual holdings which are never refreshed and
+// earn balances which are maintained by the earn manager
 func (b *Base) GetPortfolioGroupedCoin() map[currency.Code][]string {
 	result := make(map[currency.Code][]string)
 	for i := range b.Addresses {
-		if strings.EqualFold(b.Addresses[i].Description, ExchangeAddress) {
+		if isOnline(&b.Addresses[i]) ||
+			strings.EqualFold(b.Addresses[i].Description, ManualAddress) ||
+			b.Addresses[i].Chain != "" {
 			continue
 		}
 		result[b.Addresses[i].CoinType] = append(result[b.Addresses[i].CoinType], b.Addresses[i].Address)
@@ -503,6 +688,9 @@ func (b *Base) StartPortfolioWatcher() {
 				key,
 				value)
 		}
+		if err := b.UpdateChainAddresses(context.TODO()); err != nil {
+			log.Errorf(log.PortfolioMgr, "PortfolioWatcher chain address error %s\n", err)
+		}
 		time.Sleep(time.Minute * 10)
 	}
 }
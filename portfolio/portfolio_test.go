@@ -4,6 +4,8 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/thrasher-corp/gocryptotrader/core"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 )
@@ -529,6 +531,57 @@ func TestGetPortfolioGroupedCoin(t *testing.T) {
 	}
 }
 
+func TestSetManualBalance(t *testing.T) {
+	t.Parallel()
+	var b Base
+	assert.ErrorIs(t, b.SetManualBalance("bank", currency.USD, -1), errNegativeManualBalance, "SetManualBalance should error on a negative balance")
+
+	require.NoError(t, b.AddAddress("bank", "manual", currency.USD, 1000), "AddAddress must not error for a manual holding")
+	require.NoError(t, b.SetManualBalance("bank", currency.EUR, 500), "SetManualBalance must not error")
+	require.NoError(t, b.SetManualBalance("bank", currency.USD, 1500), "SetManualBalance must not error")
+	require.Len(t, b.Addresses, 2, "Each currency of a manual holding must be tracked separately")
+	assert.Equal(t, 1500.0, b.Addresses[0].Balance, "SetManualBalance should update the existing balance")
+	assert.Equal(t, ManualAddress, b.Addresses[0].Description, "Manual holdings should use the manual label")
+
+	assert.Empty(t, b.GetPortfolioGroupedCoin(), "Manual holdings should not be refreshed by the watcher")
+	assert.Equal(t, 500.0, b.GetPersonalPortfolio()[currency.EUR], "Manual holdings should be included in offline holdings")
+}
+
+func TestSetEarnBalances(t *testing.T) {
+	t.Parallel()
+	var b Base
+	assert.Error(t, b.SetEarnBalances("", nil), "SetEarnBalances should error without an exchange name")
+
+	b.AddExchangeAddress("Binance", currency.USDT, 100)
+	require.NoError(t, b.SetEarnBalances("Binance", map[currency.Code]float64{currency.USDT: 50, currency.BNB: 2}), "SetEarnBalances must not error")
+	require.Len(t, b.Addresses, 3, "Earn balances must be tracked separately from exchange balances")
+	assert.True(t, b.ExchangeAddressExists("Binance", currency.USDT), "ExchangeAddressExists should find the exchange balance")
+	assert.False(t, b.ExchangeAddressExists("Binance", currency.BNB), "ExchangeAddressExists should not match earn balances")
+
+	b.UpdateExchangeAddressBalance("Binance", currency.USDT, 150)
+	earned := b.GetEarnPortfolio()
+	assert.Equal(t, 50.0, earned[currency.USDT], "UpdateExchangeAddressBalance should not update earn balances")
+	assert.Equal(t, 2.0, earned[currency.BNB])
+	assert.Equal(t, 200.0, b.GetExchangePortfolio()[currency.USDT], "Earn balances should be included in exchange holdings")
+	assert.Equal(t, 200.0, b.GetPortfolioByExchange("Binance")[currency.USDT], "GetPortfolioByExchange should sum exchange and earn balances")
+	assert.Empty(t, b.GetPersonalPortfolio(), "Earn balances should not be included in offline holdings")
+	assert.Empty(t, b.GetPortfolioGroupedCoin(), "Earn balances should not be refreshed by the watcher")
+
+	require.NoError(t, b.SetEarnBalances("Binance", map[currency.Code]float64{currency.USDT: 60, currency.BNB: 0}), "SetEarnBalances must not error")
+	earned = b.GetEarnPortfolio()
+	assert.Len(t, earned, 1, "SetEarnBalances should remove currencies no longer held")
+	assert.Equal(t, 60.0, earned[currency.USDT], "SetEarnBalances should replace existing balances")
+
+	s := b.GetPortfolioSummary()
+	require.Len(t, s.Earn, 1, "Summary must include earn balances")
+	assert.Equal(t, 60.0, s.Earn[0].Balance)
+	assert.InDelta(t, 28.57, s.Earn[0].Percentage, 0.01, "Earn percentage should be relative to the coin total")
+	assert.Empty(t, s.Offline, "Earn balances should not be offline")
+
+	b.RemoveExchangeAddress("Binance", currency.USDT)
+	assert.Equal(t, 60.0, b.GetEarnPortfolio()[currency.USDT], "RemoveExchangeAddress should not remove earn balances")
+}
+
 func TestSeed(t *testing.T) {
 	t.Parallel()
 	newBase := Base{}
@@ -1,6 +1,7 @@
 package portfolio
 
 import (
+	"context"
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/currency"
@@ -10,13 +11,25 @@ import (
 type Base struct {
 	Addresses []Address `json:"addresses"`
 	Verbose   bool
+	// Chains configures the clients used to fetch balances of addresses on
+	// supported chains
+	Chains []ChainConfig `json:"chains,omitempty"`
+	// ReportingCurrency is the currency summaries are valued in alongside
+	// USD, such as EUR or BTC. Defaults to USD when unset
+	ReportingCurrency string `json:"reportingCurrency,omitempty"`
+
+	chainClients map[string]ChainClient
+	usdPricer    USDPricer
 }
 
 // Address sub type holding address information for portfolio
 type Address struct {
-	Address            string
-	AddressTag         string
-	CoinType           currency.Code
+	Address    string
+	AddressTag string
+	CoinType   currency.Code
+	// Chain is the chain the address balances are fetched from, such as
+	// solana. Each token held by the address is tracked as a separate entry
+	Chain              string `json:",omitempty"`
 	Balance            float64
 	Description        string
 	WhiteListed        bool
@@ -97,18 +110,22 @@ type ExchangeAccountCurrencyInfo struct {
 // Coin stores a coin type, balance, address and percentage relative to the total
 // amount.
 type Coin struct {
-	Coin       currency.Code `json:"coin"`
-	Balance    float64       `json:"balance"`
-	Address    string        `json:"address,omitempty"`
-	Percentage float64       `json:"percentage,omitempty"`
+	Coin           currency.Code `json:"coin"`
+	Balance        float64       `json:"balance"`
+	Address        string        `json:"address,omitempty"`
+	Percentage     float64       `json:"percentage,omitempty"`
+	USDValue       float64       `json:"usd_value,omitempty"`
+	ReportingValue float64       `json:"reporting_value,omitempty"`
 }
 
 // OfflineCoinSummary stores a coin types address, balance and percentage
 // relative to the total amount.
 type OfflineCoinSummary struct {
 	Address    string  `json:"address"`
+	Chain      string  `json:"chain,omitempty"`
 	Balance    float64 `json:"balance"`
 	Percentage float64 `json:"percentage,omitempty"`
+	USDValue   float64 `json:"usd_value,omitempty"`
 }
 
 // OnlineCoinSummary stores a coin types balance and percentage relative to the
@@ -116,15 +133,64 @@ type OfflineCoinSummary struct {
 type OnlineCoinSummary struct {
 	Balance    float64 `json:"balance"`
 	Percentage float64 `json:"percentage,omitempty"`
+	USDValue   float64 `json:"usd_value,omitempty"`
 }
 
-// Summary Stores the entire portfolio summary
+// Summary Stores the entire portfolio summary. USD and reporting values are
+// only populated when a USD pricer has been set
 type Summary struct {
 	Totals         []Coin                                         `json:"coin_totals"`
 	Offline        []Coin                                         `json:"coins_offline"`
 	OfflineSummary map[currency.Code][]OfflineCoinSummary         `json:"offline_summary"`
 	Online         []Coin                                         `json:"coins_online"`
 	OnlineSummary  map[string]map[currency.Code]OnlineCoinSummary `json:"online_summary"`
+	// Earn holds the earn and staking balances, which are also included in
+	// the online balances
+	Earn          []Coin  `json:"coins_earn,omitempty"`
+	TotalUSDValue float64 `json:"total_usd_value,omitempty"`
+
+	// ReportingCurrency is the currency the reporting values are in.
+	// ReportingRate is its USD price and ConversionRates the price of each
+	// coin in it at ValuedAt, so historical summaries can be reproduced
+	ReportingCurrency   currency.Code             `json:"reporting_currency"`
+	ReportingRate       float64                   `json:"reporting_rate,omitempty"`
+	ConversionRates     map[currency.Code]float64 `json:"conversion_rates,omitempty"`
+	TotalReportingValue float64                   `json:"total_reporting_value,omitempty"`
+	ValuedAt            time.Time                 `json:"valued_at"`
+}
+
+// USDPricer returns the USD price of a coin
+type USDPricer func(currency.Code) (float64, error)
+
+// ChainClient fetches the native and token balances of an address on a chain
+type ChainClient interface {
+	ValidateAddress(address string) error
+	GetBalances(ctx context.Context, address string) ([]TokenBalance, error)
+}
+
+// ChainConfig overrides a chain client's endpoint and adds tokens to track in
+// addition to the chain's default tokens
+type ChainConfig struct {
+	Name     string `json:"name"`
+	Endpoint string `json:"endpoint,omitempty"`
+	// APIKey is sent to endpoints which require one, such as TronGrid
+	APIKey string  `json:"apiKey,omitempty"`
+	Tokens []Token `json:"tokens,omitempty"`
+}
+
+// Token defines a token contract, or mint on Solana, whose balance is tracked
+type Token struct {
+	Symbol   string `json:"symbol"`
+	Contract string `json:"contract"`
+	Decimals int32  `json:"decimals"`
+}
+
+// TokenBalance holds an address's balance of a coin on a chain. Contract is
+// empty for the chain's native coin
+type TokenBalance struct {
+	Coin     currency.Code
+	Contract string
+	Balance  float64
 }
 
 // XRPScanAccount defines the return type for account data
@@ -159,3 +225,33 @@ type AccountInfo struct {
 	Twitter     string `json:"twitter"`
 	Verified    bool   `json:"verified"`
 }
+
+// solanaTokenAccounts holds the parsed token accounts returned by a Solana
+// getTokenAccountsByOwner request
+type solanaTokenAccounts struct {
+	Value []struct {
+		Account struct {
+			Data struct {
+				Parsed struct {
+					Info struct {
+						Mint        string `json:"mint"`
+						TokenAmount struct {
+							Amount   string `json:"amount"`
+							Decimals int32  `json:"decimals"`
+						} `json:"tokenAmount"`
+					} `json:"info"`
+				} `json:"parsed"`
+			} `json:"data"`
+		} `json:"account"`
+	} `json:"value"`
+}
+
+// tronAccountResponse holds the TronGrid account response
+type tronAccountResponse struct {
+	Data []struct {
+		Balance int64               `json:"balance"`
+		TRC20   []map[string]string `json:"trc20"`
+	} `json:"data"`
+	Success bool   `json:"success"`
+	Error   string `json:"error"`
+}
@@ -0,0 +1,52 @@
+package withdraw
+
+import (
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+)
+
+// Applies returns whether the lock prevents a withdraw request
+func (l *Lock) Applies(r *Request) bool {
+	if !strings.EqualFold(l.Exchange, r.Exchange) {
+		return false
+	}
+	if !l.Currency.IsEmpty() && !l.Currency.Equal(r.Currency) {
+		return false
+	}
+	return l.Address == "" || (r.Type == Crypto && l.Address == r.Crypto.Address)
+}
+
+// IsActive returns whether the lock is in effect at the supplied time
+func (l *Lock) IsActive(t time.Time) bool {
+	return l.Until.IsZero() || t.Before(l.Until)
+}
+
+// Error implements the error interface
+func (e *LockError) Error() string {
+	var sb strings.Builder
+	sb.WriteString(ErrWithdrawalLocked.Error())
+	sb.WriteString(": ")
+	sb.WriteString(e.Lock.Exchange)
+	if !e.Lock.Currency.IsEmpty() {
+		sb.WriteString(" " + e.Lock.Currency.String())
+	}
+	if e.Lock.Address != "" {
+		sb.WriteString(" to " + e.Lock.Address)
+	}
+	if e.Lock.Until.IsZero() {
+		sb.WriteString(" until further notice")
+	} else {
+		sb.WriteString(" until " + e.Lock.Until.Format(common.SimpleTimeFormatWithTimezone))
+	}
+	if e.Lock.Reason != "" {
+		sb.WriteString(" (" + e.Lock.Reason + ")")
+	}
+	return sb.String()
+}
+
+// Unwrap returns ErrWithdrawalLocked so lock errors match with errors.Is
+func (e *LockError) Unwrap() error {
+	return ErrWithdrawalLocked
+}
@@ -0,0 +1,44 @@
+package withdraw
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+func TestLockApplies(t *testing.T) {
+	t.Parallel()
+	r := &Request{Exchange: "Bitmex", Currency: currency.BTC, Type: Crypto, Crypto: CryptoRequest{Address: testBTCAddress}}
+	assert.True(t, (&Lock{Exchange: "bitmex"}).Applies(r), "exchange wide locks should apply to all requests")
+	assert.False(t, (&Lock{Exchange: "okx"}).Applies(r), "locks should not apply to other exchanges")
+	assert.True(t, (&Lock{Exchange: "bitmex", Currency: currency.BTC}).Applies(r))
+	assert.False(t, (&Lock{Exchange: "bitmex", Currency: currency.ETH}).Applies(r), "locks should not apply to other currencies")
+	assert.True(t, (&Lock{Exchange: "bitmex", Address: testBTCAddress}).Applies(r))
+	assert.False(t, (&Lock{Exchange: "bitmex", Address: "other"}).Applies(r), "locks should not apply to other addresses")
+	r.Type = Fiat
+	assert.False(t, (&Lock{Exchange: "bitmex", Address: testBTCAddress}).Applies(r), "address locks should not apply to fiat requests")
+}
+
+func TestLockIsActive(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	assert.True(t, (&Lock{}).IsActive(now), "locks without an expiry should always be active")
+	assert.True(t, (&Lock{Until: now.Add(time.Minute)}).IsActive(now))
+	assert.False(t, (&Lock{Until: now}).IsActive(now), "locks should be lifted at their expiry")
+}
+
+func TestLockError(t *testing.T) {
+	t.Parallel()
+	until := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	var err error = &LockError{Lock: Lock{Exchange: "Bitmex", Currency: currency.BTC, Address: "1337", Reason: "whitelist cooldown", Until: until}}
+	assert.ErrorIs(t, fmt.Errorf("wrapped: %w", err), ErrWithdrawalLocked)
+	assert.Equal(t, "withdrawals are locked: Bitmex BTC to 1337 until 2024-01-02 03:04:05 UTC (whitelist cooldown)", err.Error())
+	var lockErr *LockError
+	assert.True(t, errors.As(fmt.Errorf("wrapped: %w", err), &lockErr))
+	assert.Equal(t, until, lockErr.Lock.Until)
+	assert.Equal(t, "withdrawals are locked: Bitmex until further notice", (&LockError{Lock: Lock{Exchange: "Bitmex"}}).Error())
+}
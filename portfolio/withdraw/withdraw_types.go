@@ -46,6 +46,9 @@ var (
 	ErrInvalidRequest = errors.New("invalid request type")
 	// ErrStrAddressNotWhiteListed occurs when a withdrawal attempts to withdraw from a non-whitelisted address
 	ErrStrAddressNotWhiteListed = errors.New("address is not whitelisted for withdrawals")
+	// ErrWithdrawalLocked occurs when an exchange is enforcing a withdrawal
+	// lock or delay, such as an account lock or address whitelisting cooldown
+	ErrWithdrawalLocked = errors.New("withdrawals are locked")
 	// ErrStrExchangeNotSupportedByAddress message to return when attemptign to withdraw to an unsupported exchange
 	ErrStrExchangeNotSupportedByAddress = errors.New("address is not supported by exchange")
 	// CacheSize cache size to use for withdrawal request history
@@ -91,7 +94,10 @@ type Request struct {
 	Amount      float64       `json:"amount"`
 	Type        RequestType   `json:"type"`
 
-	// Used exclusively in Binance.US
+	// ClientOrderID is submitted to exchanges which record a client provided
+	// identifier with the withdrawal, so it can be found in the withdrawal
+	// history if the outcome of a submission is unknown. The withdraw manager
+	// generates one when it is not set
 	ClientOrderID string `json:"clientID"`
 
 	// Used exclusively in Okcoin to classify internal represented by '3' or on chain represented by '4'
@@ -123,3 +129,21 @@ type ExchangeResponse struct {
 	ID     string `json:"id"`
 	Status string `json:"status"`
 }
+
+// Lock holds an exchange enforced withdrawal lock or delay
+type Lock struct {
+	Exchange string `json:"exchange"`
+	// Currency is empty when withdrawals of all currencies are locked
+	Currency currency.Code `json:"currency"`
+	// Address is empty when withdrawals to all addresses are locked
+	Address string `json:"address"`
+	Reason  string `json:"reason"`
+	// Until is zero when the time the lock is lifted is unknown
+	Until time.Time `json:"until"`
+}
+
+// LockError is returned when a withdrawal is rejected due to a lock, allowing
+// callers to defer withdrawals until the lock is lifted
+type LockError struct {
+	Lock Lock
+}